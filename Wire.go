@@ -53,9 +53,13 @@ import (
 	appList2 "github.com/devtron-labs/devtron/api/restHandler/app/appList"
 	configDiff2 "github.com/devtron-labs/devtron/api/restHandler/app/configDiff"
 	pipeline3 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline"
+	buildQueue2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/buildQueue"
 	pipeline2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/configure"
 	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/history"
+	retention2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/retention"
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/schedule"
 	status2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/status"
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/testReport"
 	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/trigger"
 	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/webhook"
 	"github.com/devtron-labs/devtron/api/restHandler/app/workflow"
@@ -66,9 +70,13 @@ import (
 	"github.com/devtron-labs/devtron/api/router/app/appList"
 	configDiff3 "github.com/devtron-labs/devtron/api/router/app/configDiff"
 	pipeline5 "github.com/devtron-labs/devtron/api/router/app/pipeline"
+	buildQueue3 "github.com/devtron-labs/devtron/api/router/app/pipeline/buildQueue"
 	pipeline4 "github.com/devtron-labs/devtron/api/router/app/pipeline/configure"
 	history2 "github.com/devtron-labs/devtron/api/router/app/pipeline/history"
+	retention3 "github.com/devtron-labs/devtron/api/router/app/pipeline/retention"
+	schedule2 "github.com/devtron-labs/devtron/api/router/app/pipeline/schedule"
 	status3 "github.com/devtron-labs/devtron/api/router/app/pipeline/status"
+	testReport2 "github.com/devtron-labs/devtron/api/router/app/pipeline/testReport"
 	trigger2 "github.com/devtron-labs/devtron/api/router/app/pipeline/trigger"
 	workflow2 "github.com/devtron-labs/devtron/api/router/app/workflow"
 	"github.com/devtron-labs/devtron/api/server"
@@ -97,7 +105,6 @@ import (
 	"github.com/devtron-labs/devtron/client/fluxcd"
 	"github.com/devtron-labs/devtron/client/gitSensor"
 	"github.com/devtron-labs/devtron/client/grafana"
-	"github.com/devtron-labs/devtron/client/lens"
 	"github.com/devtron-labs/devtron/client/proxy"
 	"github.com/devtron-labs/devtron/client/telemetry"
 	"github.com/devtron-labs/devtron/internal/sql/repository"
@@ -154,6 +161,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/infraConfig"
 	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
 	repository7 "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/repository"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
 	"github.com/devtron-labs/devtron/pkg/notifier"
 	"github.com/devtron-labs/devtron/pkg/overview"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
@@ -175,6 +183,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/variables"
 	"github.com/devtron-labs/devtron/pkg/variables/parsers"
 	repository10 "github.com/devtron-labs/devtron/pkg/variables/repository"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
 	workflow3 "github.com/devtron-labs/devtron/pkg/workflow"
 	"github.com/devtron-labs/devtron/pkg/workflow/dag"
 	util2 "github.com/devtron-labs/devtron/util"
@@ -217,6 +226,7 @@ func InitializeApp() (*App, error) {
 		terminal.TerminalWireSet,
 		build.WireSet,
 		deployment2.DeploymentWireSet,
+		leaderElection.LeaderElectionWireSet,
 		argoApplication.ArgoApplicationWireSetFull,
 		fluxApplication.FluxApplicationWireSet,
 		eventProcessor.EventProcessorWireSet,
@@ -285,6 +295,58 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(deployment.DeploymentConfigRestHandler), new(*deployment.DeploymentConfigRestHandlerImpl)),
 		deployment.NewDeploymentRouterImpl,
 		wire.Bind(new(deployment.DeploymentConfigRouter), new(*deployment.DeploymentConfigRouterImpl)),
+		deployment.NewDeploymentWindowRestHandlerImpl,
+		wire.Bind(new(deployment.DeploymentWindowRestHandler), new(*deployment.DeploymentWindowRestHandlerImpl)),
+		deployment.NewDeploymentWindowRouterImpl,
+		wire.Bind(new(deployment.DeploymentWindowRouter), new(*deployment.DeploymentWindowRouterImpl)),
+		deployment.NewDeploymentApprovalRestHandlerImpl,
+		wire.Bind(new(deployment.DeploymentApprovalRestHandler), new(*deployment.DeploymentApprovalRestHandlerImpl)),
+		deployment.NewDeploymentApprovalRouterImpl,
+		wire.Bind(new(deployment.DeploymentApprovalRouter), new(*deployment.DeploymentApprovalRouterImpl)),
+		deployment.NewAutoRollbackRestHandlerImpl,
+		wire.Bind(new(deployment.AutoRollbackRestHandler), new(*deployment.AutoRollbackRestHandlerImpl)),
+		deployment.NewAutoRollbackRouterImpl,
+		wire.Bind(new(deployment.AutoRollbackRouter), new(*deployment.AutoRollbackRouterImpl)),
+		deployment.NewMetricVerificationRestHandlerImpl,
+		wire.Bind(new(deployment.MetricVerificationRestHandler), new(*deployment.MetricVerificationRestHandlerImpl)),
+		deployment.NewMetricVerificationRouterImpl,
+		wire.Bind(new(deployment.MetricVerificationRouter), new(*deployment.MetricVerificationRouterImpl)),
+		deployment.NewDriftDetectionRestHandlerImpl,
+		wire.Bind(new(deployment.DriftDetectionRestHandler), new(*deployment.DriftDetectionRestHandlerImpl)),
+		deployment.NewDriftDetectionRouterImpl,
+		wire.Bind(new(deployment.DriftDetectionRouter), new(*deployment.DriftDetectionRouterImpl)),
+		deployment.NewPreviewEnvironmentRestHandlerImpl,
+		wire.Bind(new(deployment.PreviewEnvironmentRestHandler), new(*deployment.PreviewEnvironmentRestHandlerImpl)),
+		deployment.NewPreviewEnvironmentRouterImpl,
+		wire.Bind(new(deployment.PreviewEnvironmentRouter), new(*deployment.PreviewEnvironmentRouterImpl)),
+		deployment.NewHibernationScheduleRestHandlerImpl,
+		wire.Bind(new(deployment.HibernationScheduleRestHandler), new(*deployment.HibernationScheduleRestHandlerImpl)),
+		deployment.NewHibernationScheduleRouterImpl,
+		wire.Bind(new(deployment.HibernationScheduleRouter), new(*deployment.HibernationScheduleRouterImpl)),
+		deployment.NewReleaseOrchestrationRestHandlerImpl,
+		wire.Bind(new(deployment.ReleaseOrchestrationRestHandler), new(*deployment.ReleaseOrchestrationRestHandlerImpl)),
+		deployment.NewReleaseOrchestrationRouterImpl,
+		wire.Bind(new(deployment.ReleaseOrchestrationRouter), new(*deployment.ReleaseOrchestrationRouterImpl)),
+		deployment.NewScheduledDeploymentRestHandlerImpl,
+		wire.Bind(new(deployment.ScheduledDeploymentRestHandler), new(*deployment.ScheduledDeploymentRestHandlerImpl)),
+		deployment.NewScheduledDeploymentRouterImpl,
+		wire.Bind(new(deployment.ScheduledDeploymentRouter), new(*deployment.ScheduledDeploymentRouterImpl)),
+		deployment.NewDeploymentPreviewRestHandlerImpl,
+		wire.Bind(new(deployment.DeploymentPreviewRestHandler), new(*deployment.DeploymentPreviewRestHandlerImpl)),
+		deployment.NewDeploymentPreviewRouterImpl,
+		wire.Bind(new(deployment.DeploymentPreviewRouter), new(*deployment.DeploymentPreviewRouterImpl)),
+		deployment.NewClusterSetRestHandlerImpl,
+		wire.Bind(new(deployment.ClusterSetRestHandler), new(*deployment.ClusterSetRestHandlerImpl)),
+		deployment.NewClusterSetRouterImpl,
+		wire.Bind(new(deployment.ClusterSetRouter), new(*deployment.ClusterSetRouterImpl)),
+		deployment.NewGitOpsPullRequestRestHandlerImpl,
+		wire.Bind(new(deployment.GitOpsPullRequestRestHandler), new(*deployment.GitOpsPullRequestRestHandlerImpl)),
+		deployment.NewGitOpsPullRequestRouterImpl,
+		wire.Bind(new(deployment.GitOpsPullRequestRouter), new(*deployment.GitOpsPullRequestRouterImpl)),
+		deployment.NewGitOpsMonoRepoRestHandlerImpl,
+		wire.Bind(new(deployment.GitOpsMonoRepoRestHandler), new(*deployment.GitOpsMonoRepoRestHandlerImpl)),
+		deployment.NewGitOpsMonoRepoRouterImpl,
+		wire.Bind(new(deployment.GitOpsMonoRepoRouter), new(*deployment.GitOpsMonoRepoRouterImpl)),
 
 		dashboardEvent.NewDashboardTelemetryRestHandlerImpl,
 		wire.Bind(new(dashboardEvent.DashboardTelemetryRestHandler), new(*dashboardEvent.DashboardTelemetryRestHandlerImpl)),
@@ -467,6 +529,10 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(router.GitProviderRouter), new(*router.GitProviderRouterImpl)),
 		restHandler.NewGitProviderRestHandlerImpl,
 		wire.Bind(new(restHandler.GitProviderRestHandler), new(*restHandler.GitProviderRestHandlerImpl)),
+		router.NewCommitStatusRouterImpl,
+		wire.Bind(new(router.CommitStatusRouter), new(*router.CommitStatusRouterImpl)),
+		restHandler.NewCommitStatusRestHandlerImpl,
+		wire.Bind(new(restHandler.CommitStatusRestHandler), new(*restHandler.CommitStatusRestHandlerImpl)),
 
 		router.NewNotificationRouterImpl,
 		wire.Bind(new(router.NotificationRouter), new(*router.NotificationRouterImpl)),
@@ -586,9 +652,6 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(restHandler.ReleaseMetricsRestHandler), new(*restHandler.ReleaseMetricsRestHandlerImpl)),
 		router.NewReleaseMetricsRouterImpl,
 		wire.Bind(new(router.ReleaseMetricsRouter), new(*router.ReleaseMetricsRouterImpl)),
-		lens.GetLensConfig,
-		lens.NewLensClientImpl,
-		wire.Bind(new(lens.LensClient), new(*lens.LensClientImpl)),
 
 		pipelineConfig.NewCdWorkflowRepositoryImpl,
 		wire.Bind(new(pipelineConfig.CdWorkflowRepository), new(*pipelineConfig.CdWorkflowRepositoryImpl)),
@@ -657,6 +720,14 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(router.ImageScanRouter), new(*router.ImageScanRouterImpl)),
 		restHandler.NewImageScanRestHandlerImpl,
 		wire.Bind(new(restHandler.ImageScanRestHandler), new(*restHandler.ImageScanRestHandlerImpl)),
+		router.NewSbomRouterImpl,
+		wire.Bind(new(router.SbomRouter), new(*router.SbomRouterImpl)),
+		restHandler.NewSbomRestHandlerImpl,
+		wire.Bind(new(restHandler.SbomRestHandler), new(*restHandler.SbomRestHandlerImpl)),
+		router.NewImageSignatureRouterImpl,
+		wire.Bind(new(router.ImageSignatureRouter), new(*router.ImageSignatureRouterImpl)),
+		restHandler.NewImageSignatureRestHandlerImpl,
+		wire.Bind(new(restHandler.ImageSignatureRestHandler), new(*restHandler.ImageSignatureRestHandlerImpl)),
 		router.NewPolicyRouterImpl,
 		wire.Bind(new(router.PolicyRouter), new(*router.PolicyRouterImpl)),
 		restHandler.NewPolicyRestHandlerImpl,
@@ -730,6 +801,11 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(restHandler.GitHostRestHandler), new(*restHandler.GitHostRestHandlerImpl)),
 		restHandler.NewWebhookEventHandlerImpl,
 		wire.Bind(new(restHandler.WebhookEventHandler), new(*restHandler.WebhookEventHandlerImpl)),
+		signature.WebhookSignatureWireSet,
+		restHandler.NewWebhookSignatureRestHandlerImpl,
+		wire.Bind(new(restHandler.WebhookSignatureRestHandler), new(*restHandler.WebhookSignatureRestHandlerImpl)),
+		router.NewWebhookSignatureRouterImpl,
+		wire.Bind(new(router.WebhookSignatureRouter), new(*router.WebhookSignatureRouterImpl)),
 		router.NewGitHostRouterImpl,
 		wire.Bind(new(router.GitHostRouter), new(*router.GitHostRouterImpl)),
 		router.NewWebhookListenerRouterImpl,
@@ -743,6 +819,22 @@ func InitializeApp() (*App, error) {
 
 		app3.NewAppRouterImpl,
 		wire.Bind(new(app3.AppRouter), new(*app3.AppRouterImpl)),
+		schedule2.NewCiPipelineScheduleRouterImpl,
+		wire.Bind(new(schedule2.CiPipelineScheduleRouter), new(*schedule2.CiPipelineScheduleRouterImpl)),
+		schedule.NewCiPipelineScheduleRestHandlerImpl,
+		wire.Bind(new(schedule.CiPipelineScheduleRestHandler), new(*schedule.CiPipelineScheduleRestHandlerImpl)),
+		buildQueue3.NewCiBuildQueueRouterImpl,
+		wire.Bind(new(buildQueue3.CiBuildQueueRouter), new(*buildQueue3.CiBuildQueueRouterImpl)),
+		buildQueue2.NewCiBuildQueueRestHandlerImpl,
+		wire.Bind(new(buildQueue2.CiBuildQueueRestHandler), new(*buildQueue2.CiBuildQueueRestHandlerImpl)),
+		retention3.NewArtifactRetentionRouterImpl,
+		wire.Bind(new(retention3.ArtifactRetentionRouter), new(*retention3.ArtifactRetentionRouterImpl)),
+		retention2.NewArtifactRetentionRestHandlerImpl,
+		wire.Bind(new(retention2.ArtifactRetentionRestHandler), new(*retention2.ArtifactRetentionRestHandlerImpl)),
+		testReport2.NewTestReportRouterImpl,
+		wire.Bind(new(testReport2.TestReportRouter), new(*testReport2.TestReportRouterImpl)),
+		testReport.NewTestReportRestHandlerImpl,
+		wire.Bind(new(testReport.TestReportRestHandler), new(*testReport.TestReportRestHandlerImpl)),
 		appInfo2.NewAppInfoRouterImpl,
 		wire.Bind(new(appInfo2.AppInfoRouter), new(*appInfo2.AppInfoRouterImpl)),
 		appInfo.NewAppInfoRestHandlerImpl,
@@ -853,6 +945,43 @@ func InitializeApp() (*App, error) {
 		cron.NewCiTriggerCronImpl,
 		wire.Bind(new(cron.CiTriggerCron), new(*cron.CiTriggerCronImpl)),
 
+		cron.GetCiScheduleTriggerCronConfig,
+		cron.NewCiScheduleTriggerCronImpl,
+		wire.Bind(new(cron.CiScheduleTriggerCron), new(*cron.CiScheduleTriggerCronImpl)),
+
+		cron.GetCiBuildQueueCronConfig,
+		cron.NewCiBuildQueueCronImpl,
+		wire.Bind(new(cron.CiBuildQueueCron), new(*cron.CiBuildQueueCronImpl)),
+		cron.GetArtifactGcCronConfig,
+		cron.NewArtifactGcCronImpl,
+		wire.Bind(new(cron.ArtifactGcCron), new(*cron.ArtifactGcCronImpl)),
+		cron.GetSbomSyncCronConfig,
+		cron.NewSbomSyncCronImpl,
+		wire.Bind(new(cron.SbomSyncCron), new(*cron.SbomSyncCronImpl)),
+		cron.GetWebhookDeliveryCleanupCronConfig,
+		cron.NewWebhookDeliveryCleanupCronImpl,
+		wire.Bind(new(cron.WebhookDeliveryCleanupCron), new(*cron.WebhookDeliveryCleanupCronImpl)),
+
+		cron.GetDeploymentWindowQueueCronConfig,
+		cron.NewDeploymentWindowQueueCronImpl,
+		wire.Bind(new(cron.DeploymentWindowQueueCron), new(*cron.DeploymentWindowQueueCronImpl)),
+
+		cron.GetAutoRollbackCronConfig,
+		cron.NewAutoRollbackCronImpl,
+		wire.Bind(new(cron.AutoRollbackCron), new(*cron.AutoRollbackCronImpl)),
+
+		cron.GetMetricVerificationCronConfig,
+		cron.NewMetricVerificationCronImpl,
+		wire.Bind(new(cron.MetricVerificationCron), new(*cron.MetricVerificationCronImpl)),
+
+		cron.GetDriftDetectionCronConfig,
+		cron.NewDriftDetectionCronImpl,
+		wire.Bind(new(cron.DriftDetectionCron), new(*cron.DriftDetectionCronImpl)),
+
+		cron.GetGitOpsPullRequestCronConfig,
+		cron.NewGitOpsPullRequestCronImpl,
+		wire.Bind(new(cron.GitOpsPullRequestCron), new(*cron.GitOpsPullRequestCronImpl)),
+
 		status2.NewPipelineStatusTimelineRestHandlerImpl,
 		wire.Bind(new(status2.PipelineStatusTimelineRestHandler), new(*status2.PipelineStatusTimelineRestHandlerImpl)),
 
@@ -35,12 +35,13 @@ func NewWorkflowType(workflowType string) WorkflowType {
 type DeploymentConfigurationType string
 
 const (
-	CD_WORKFLOW_TYPE_PRE              WorkflowType                = "PRE"
-	CD_WORKFLOW_TYPE_POST             WorkflowType                = "POST"
-	CD_WORKFLOW_TYPE_DEPLOY           WorkflowType                = "DEPLOY"
-	CI_WORKFLOW_TYPE                  WorkflowType                = "CI"
-	WEBHOOK_WORKFLOW_TYPE             WorkflowType                = "WEBHOOK"
-	DEPLOYMENT_CONFIG_TYPE_LAST_SAVED DeploymentConfigurationType = "LAST_SAVED_CONFIG"
+	CD_WORKFLOW_TYPE_PRE                WorkflowType                = "PRE"
+	CD_WORKFLOW_TYPE_POST               WorkflowType                = "POST"
+	CD_WORKFLOW_TYPE_DEPLOY             WorkflowType                = "DEPLOY"
+	CD_WORKFLOW_TYPE_CLUSTER_SET_DEPLOY WorkflowType                = "CLUSTER_SET_DEPLOY" // deployment to a member cluster of the cluster set
+	CI_WORKFLOW_TYPE                    WorkflowType                = "CI"
+	WEBHOOK_WORKFLOW_TYPE               WorkflowType                = "WEBHOOK"
+	DEPLOYMENT_CONFIG_TYPE_LAST_SAVED   DeploymentConfigurationType = "LAST_SAVED_CONFIG"
 	//latest trigger is not being used because this is being handled at FE and we anyhow identify latest trigger as
 	//last deployed wfr which is also a specific trigger
 	DEPLOYMENT_CONFIG_TYPE_LATEST_TRIGGER   DeploymentConfigurationType = "LATEST_TRIGGER_CONFIG"
@@ -82,10 +83,13 @@ type ValuesOverrideRequest struct {
 	DeploymentType                        models.DeploymentType       `json:"deploymentType"`     // required for async install/upgrade handling; previously if was used internally
 	ForceSyncDeployment                   bool                        `json:"forceSyncDeployment,notnull"`
 	IsRollbackDeployment                  bool                        `json:"isRollbackDeployment"`
+	IsAutoRollback                        bool                        `json:"-"` // set for rollbacks triggered by the auto rollback policy of the pipeline
+	IsDriftReconcile                      bool                        `json:"-"` // set for redeployments triggered to reconcile the drift of the pipeline
 	UserId                                int32                       `json:"-"`
 	EnvId                                 int                         `json:"-"`
 	EnvName                               string                      `json:"-"`
 	ClusterId                             int                         `json:"-"`
+	ClusterSetClusterName                 string                      `json:"-"` // set when the values are built for the member cluster ClusterId of the cluster set of the pipeline, scoped variables are resolved for the member cluster
 	AppName                               string                      `json:"-"`
 	PipelineName                          string                      `json:"-"`
 	DeploymentAppType                     string                      `json:"-"`
@@ -23,7 +23,7 @@ import (
 
 type GitOpsConfigDto struct {
 	Id                    int             `json:"id,omitempty"`
-	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD"`
+	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD GITEA"`
 	Username              string          `json:"username"`
 	Token                 string          `json:"token"`
 	GitLabGroupId         string          `json:"gitLabGroupId"`
@@ -33,6 +33,7 @@ type GitOpsConfigDto struct {
 	AzureProjectName      string          `json:"azureProjectName"`
 	BitBucketWorkspaceId  string          `json:"bitBucketWorkspaceId"`
 	BitBucketProjectKey   string          `json:"bitBucketProjectKey"`
+	GiteaOrgId            string          `json:"giteaOrgId"`
 	AllowCustomRepository bool            `json:"allowCustomRepository"`
 	EnableTLSVerification bool            `json:"enableTLSVerification"`
 	TLSConfig             *bean.TLSConfig `json:"tlsConfig"`
@@ -65,6 +66,7 @@ type GitRepoRequestDto struct {
 	AzureProjectName     string `json:"azureProjectName"`
 	BitBucketWorkspaceId string `json:"bitBucketWorkspaceId"`
 	BitBucketProjectKey  string `json:"bitBucketProjectKey"`
+	GiteaOrgId           string `json:"giteaOrgId"`
 }
 
 type DetailedErrorGitOpsConfigResponse struct {
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package deployment
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type AutoRollbackRestHandler interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type AutoRollbackRestHandlerImpl struct {
+	logger              *zap.SugaredLogger
+	userService         user.UserService
+	enforcer            casbin.Enforcer
+	enforcerUtil        rbac.EnforcerUtil
+	validator           *validator.Validate
+	autoRollbackService autoRollback.AutoRollbackService
+}
+
+func NewAutoRollbackRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	autoRollbackService autoRollback.AutoRollbackService) *AutoRollbackRestHandlerImpl {
+	return &AutoRollbackRestHandlerImpl{
+		logger:              logger,
+		userService:         userService,
+		enforcer:            enforcer,
+		enforcerUtil:        enforcerUtil,
+		validator:           validator,
+		autoRollbackService: autoRollbackService,
+	}
+}
+
+func (handler *AutoRollbackRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.autoRollbackService.GetPolicy(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *AutoRollbackRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.AutoRollbackPolicyDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// auto rollback policy is a part of the cd pipeline configuration
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, request.PipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.autoRollbackService.SavePolicy(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *AutoRollbackRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.autoRollbackService.DeletePolicy(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *AutoRollbackRestHandlerImpl) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	size, err := common.ExtractIntQueryParam(w, r, "size", 20)
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.autoRollbackService.GetHistory(pipelineId, offset, size)
+	if err != nil {
+		handler.logger.Errorw("service err, GetHistory", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
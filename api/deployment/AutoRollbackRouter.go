@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type AutoRollbackRouter interface {
+	Init(autoRollbackRouter *mux.Router)
+}
+
+type AutoRollbackRouterImpl struct {
+	autoRollbackRestHandler AutoRollbackRestHandler
+}
+
+func NewAutoRollbackRouterImpl(autoRollbackRestHandler AutoRollbackRestHandler) *AutoRollbackRouterImpl {
+	return &AutoRollbackRouterImpl{
+		autoRollbackRestHandler: autoRollbackRestHandler,
+	}
+}
+
+func (router AutoRollbackRouterImpl) Init(autoRollbackRouter *mux.Router) {
+	autoRollbackRouter.Path("/policy").
+		HandlerFunc(router.autoRollbackRestHandler.SavePolicy).Methods("POST")
+	autoRollbackRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.autoRollbackRestHandler.GetPolicy).Methods("GET")
+	autoRollbackRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.autoRollbackRestHandler.DeletePolicy).Methods("DELETE")
+	autoRollbackRouter.Path("/pipeline/{pipelineId}/history").
+		HandlerFunc(router.autoRollbackRestHandler.GetHistory).Methods("GET")
+}
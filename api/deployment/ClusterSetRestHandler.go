@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	k8sBean "github.com/devtron-labs/devtron/pkg/k8s/application/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type ClusterSetRestHandler interface {
+	GetClusterLabels(w http.ResponseWriter, r *http.Request)
+	SaveClusterLabels(w http.ResponseWriter, r *http.Request)
+	GetTarget(w http.ResponseWriter, r *http.Request)
+	SaveTarget(w http.ResponseWriter, r *http.Request)
+	DeleteTarget(w http.ResponseWriter, r *http.Request)
+	Deploy(w http.ResponseWriter, r *http.Request)
+	GetDeployments(w http.ResponseWriter, r *http.Request)
+	GetDeployment(w http.ResponseWriter, r *http.Request)
+}
+
+type ClusterSetRestHandlerImpl struct {
+	logger            *zap.SugaredLogger
+	userService       user.UserService
+	enforcer          casbin.Enforcer
+	enforcerUtil      rbac.EnforcerUtil
+	validator         *validator.Validate
+	clusterSetService clusterSet.ClusterSetService
+}
+
+func NewClusterSetRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	clusterSetService clusterSet.ClusterSetService) *ClusterSetRestHandlerImpl {
+	return &ClusterSetRestHandlerImpl{
+		logger:            logger,
+		userService:       userService,
+		enforcer:          enforcer,
+		enforcerUtil:      enforcerUtil,
+		validator:         validator,
+		clusterSetService: clusterSetService,
+	}
+}
+
+// labels decide which clusters a pipeline deploys to, so they are managed by super admins
+func (handler *ClusterSetRestHandlerImpl) GetClusterLabels(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userService, handler.enforcer, casbin.ActionCreate); !ok {
+		return
+	}
+	res, err := handler.clusterSetService.GetClusterLabels()
+	if err != nil {
+		handler.logger.Errorw("service err, GetClusterLabels", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) SaveClusterLabels(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, handler.userService, handler.enforcer, casbin.ActionCreate)
+	if !ok {
+		return
+	}
+	clusterId, err := common.ExtractIntPathParamWithContext(w, r, "clusterId")
+	if err != nil {
+		return
+	}
+	var request bean.ClusterLabelsDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveClusterLabels", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.ClusterId = clusterId
+	request.UserId = userId
+	res, err := handler.clusterSetService.SaveClusterLabels(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveClusterLabels", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) GetTarget(w http.ResponseWriter, r *http.Request) {
+	pipelineId, _, ok := handler.authorizePipeline(w, r, casbin.ActionGet, false)
+	if !ok {
+		return
+	}
+	res, err := handler.clusterSetService.GetTarget(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetTarget", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) SaveTarget(w http.ResponseWriter, r *http.Request) {
+	pipelineId, userId, ok := handler.authorizePipeline(w, r, casbin.ActionUpdate, false)
+	if !ok {
+		return
+	}
+	var request bean.ClusterSetTargetDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveTarget", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.PipelineId = pipelineId
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveTarget", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	res, err := handler.clusterSetService.SaveTarget(&request, handler.checkAuthForCluster(token, casbin.ActionUpdate))
+	if err != nil {
+		handler.logger.Errorw("service err, SaveTarget", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) DeleteTarget(w http.ResponseWriter, r *http.Request) {
+	pipelineId, userId, ok := handler.authorizePipeline(w, r, casbin.ActionUpdate, false)
+	if !ok {
+		return
+	}
+	err := handler.clusterSetService.DeleteTarget(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteTarget", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) Deploy(w http.ResponseWriter, r *http.Request) {
+	pipelineId, userId, ok := handler.authorizePipeline(w, r, casbin.ActionTrigger, true)
+	if !ok {
+		return
+	}
+	var request bean.DeployRequest
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			handler.logger.Errorw("request err, Deploy", "err", err, "payload", request)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	request.PipelineId = pipelineId
+	request.UserId = userId
+	token := r.Header.Get("token")
+	res, err := handler.clusterSetService.Deploy(&request, handler.checkAuthForCluster(token, casbin.ActionUpdate))
+	if err != nil {
+		handler.logger.Errorw("service err, Deploy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) GetDeployments(w http.ResponseWriter, r *http.Request) {
+	pipelineId, _, ok := handler.authorizePipeline(w, r, casbin.ActionGet, false)
+	if !ok {
+		return
+	}
+	res, err := handler.clusterSetService.GetDeployments(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeployments", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ClusterSetRestHandlerImpl) GetDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	res, err := handler.clusterSetService.GetDeployment(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeployment", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if ok := handler.enforcePipeline(w, r, res.PipelineId, casbin.ActionGet, false); !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// authorizePipeline checks the action on the app, and on the environment if asked, of the pipeline of the request path.
+// The response is already written when it returns false.
+func (handler *ClusterSetRestHandlerImpl) authorizePipeline(w http.ResponseWriter, r *http.Request, action string, checkEnv bool) (int, int32, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, 0, false
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return 0, 0, false
+	}
+	if ok := handler.enforcePipeline(w, r, pipelineId, action, checkEnv); !ok {
+		return 0, 0, false
+	}
+	return pipelineId, userId, true
+}
+
+func (handler *ClusterSetRestHandlerImpl) enforcePipeline(w http.ResponseWriter, r *http.Request, pipelineId int, action string, checkEnv bool) bool {
+	objects, found := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !found || len(objects) < 2 {
+		common.WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+		return false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, objects[0]); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	if checkEnv {
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, objects[1]); !ok {
+			common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
+
+// checkAuthForCluster allows deploying to a member cluster only if the user can manage every resource in the
+// namespace of the pipeline in that cluster, as the rendered chart may contain any kind of resource
+func (handler *ClusterSetRestHandlerImpl) checkAuthForCluster(token string, action string) func(clusterName, namespace string) bool {
+	return func(clusterName, namespace string) bool {
+		resourceName, objectName := handler.enforcerUtil.GetRBACNameForClusterEntity(clusterName, k8s.ResourceIdentifier{
+			Name:      k8sBean.ALL,
+			Namespace: namespace,
+			GroupVersionKind: schema.GroupVersionKind{
+				Group: k8sBean.ALL,
+				Kind:  k8sBean.ALL,
+			},
+		})
+		return handler.enforcer.Enforce(token, strings.ToLower(resourceName), action, objectName)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ClusterSetRouter interface {
+	Init(clusterSetRouter *mux.Router)
+}
+
+type ClusterSetRouterImpl struct {
+	clusterSetRestHandler ClusterSetRestHandler
+}
+
+func NewClusterSetRouterImpl(clusterSetRestHandler ClusterSetRestHandler) *ClusterSetRouterImpl {
+	return &ClusterSetRouterImpl{
+		clusterSetRestHandler: clusterSetRestHandler,
+	}
+}
+
+func (router ClusterSetRouterImpl) Init(clusterSetRouter *mux.Router) {
+	clusterSetRouter.Path("/cluster/labels").
+		HandlerFunc(router.clusterSetRestHandler.GetClusterLabels).Methods("GET")
+	clusterSetRouter.Path("/cluster/{clusterId}/labels").
+		HandlerFunc(router.clusterSetRestHandler.SaveClusterLabels).Methods("PUT")
+	clusterSetRouter.Path("/pipeline/{pipelineId}").
+		HandlerFunc(router.clusterSetRestHandler.GetTarget).Methods("GET")
+	clusterSetRouter.Path("/pipeline/{pipelineId}").
+		HandlerFunc(router.clusterSetRestHandler.SaveTarget).Methods("PUT")
+	clusterSetRouter.Path("/pipeline/{pipelineId}").
+		HandlerFunc(router.clusterSetRestHandler.DeleteTarget).Methods("DELETE")
+	clusterSetRouter.Path("/pipeline/{pipelineId}/deploy").
+		HandlerFunc(router.clusterSetRestHandler.Deploy).Methods("POST")
+	clusterSetRouter.Path("/pipeline/{pipelineId}/deployments").
+		HandlerFunc(router.clusterSetRestHandler.GetDeployments).Methods("GET")
+	clusterSetRouter.Path("/deployment/{id}").
+		HandlerFunc(router.clusterSetRestHandler.GetDeployment).Methods("GET")
+}
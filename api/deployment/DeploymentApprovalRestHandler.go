@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type DeploymentApprovalRestHandler interface {
+	GetApprovalPolicy(w http.ResponseWriter, r *http.Request)
+	SaveApprovalPolicy(w http.ResponseWriter, r *http.Request)
+	DeleteApprovalPolicy(w http.ResponseWriter, r *http.Request)
+	RaiseApprovalRequest(w http.ResponseWriter, r *http.Request)
+	TakeApprovalAction(w http.ResponseWriter, r *http.Request)
+	GetApprovalRequests(w http.ResponseWriter, r *http.Request)
+	GetApprovalRequest(w http.ResponseWriter, r *http.Request)
+}
+
+type DeploymentApprovalRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+	deploymentApprovalService approval.DeploymentApprovalService
+}
+
+func NewDeploymentApprovalRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	deploymentApprovalService approval.DeploymentApprovalService) *DeploymentApprovalRestHandlerImpl {
+	return &DeploymentApprovalRestHandlerImpl{
+		logger:                    logger,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+		deploymentApprovalService: deploymentApprovalService,
+	}
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) GetApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizePipeline(w, r, pipelineId, casbin.ActionGet, false); !ok {
+		return
+	}
+	res, err := handler.deploymentApprovalService.GetApprovalPolicy(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetApprovalPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) SaveApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.ApprovalPolicyDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveApprovalPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveApprovalPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// approval policy is a part of the cd pipeline configuration
+	if ok := handler.authorizePipeline(w, r, request.PipelineId, casbin.ActionUpdate, false); !ok {
+		return
+	}
+	res, err := handler.deploymentApprovalService.SaveApprovalPolicy(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveApprovalPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) DeleteApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizePipeline(w, r, pipelineId, casbin.ActionUpdate, false); !ok {
+		return
+	}
+	err = handler.deploymentApprovalService.DeleteApprovalPolicy(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteApprovalPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) RaiseApprovalRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.RaiseApprovalRequestDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, RaiseApprovalRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, RaiseApprovalRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if ok := handler.authorizePipeline(w, r, request.PipelineId, casbin.ActionTrigger, true); !ok {
+		return
+	}
+	res, err := handler.deploymentApprovalService.RaiseApprovalRequest(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, RaiseApprovalRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) TakeApprovalAction(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.ApprovalActionRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, TakeApprovalAction", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, TakeApprovalAction", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	approvalRequest, err := handler.deploymentApprovalService.GetApprovalRequestById(request.ApprovalRequestId)
+	if err != nil {
+		handler.logger.Errorw("service err, TakeApprovalAction", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// approvers need trigger access on the pipeline, approver role groups of the policy are checked by the service
+	if ok := handler.authorizePipeline(w, r, approvalRequest.PipelineId, casbin.ActionTrigger, true); !ok {
+		return
+	}
+	res, err := handler.deploymentApprovalService.TakeApprovalAction(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, TakeApprovalAction", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) GetApprovalRequests(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	size, err := common.ExtractIntQueryParam(w, r, "size", 20)
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizePipeline(w, r, pipelineId, casbin.ActionGet, false); !ok {
+		return
+	}
+	res, err := handler.deploymentApprovalService.GetApprovalRequests(pipelineId, offset, size)
+	if err != nil {
+		handler.logger.Errorw("service err, GetApprovalRequests", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentApprovalRestHandlerImpl) GetApprovalRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	res, err := handler.deploymentApprovalService.GetApprovalRequestById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetApprovalRequest", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if ok := handler.authorizePipeline(w, r, res.PipelineId, casbin.ActionGet, false); !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// authorizePipeline checks the action on the app of the cd pipeline, and on its environment if checkEnv is set,
+// the response is already written when it returns false
+func (handler *DeploymentApprovalRestHandlerImpl) authorizePipeline(w http.ResponseWriter, r *http.Request, pipelineId int, action string, checkEnv bool) bool {
+	objects, ok := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) != 2 {
+		common.WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+		return false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, objects[0]); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	if checkEnv {
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, action, objects[1]); !ok {
+			common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type DeploymentApprovalRouter interface {
+	Init(deploymentApprovalRouter *mux.Router)
+}
+
+type DeploymentApprovalRouterImpl struct {
+	deploymentApprovalRestHandler DeploymentApprovalRestHandler
+}
+
+func NewDeploymentApprovalRouterImpl(deploymentApprovalRestHandler DeploymentApprovalRestHandler) *DeploymentApprovalRouterImpl {
+	return &DeploymentApprovalRouterImpl{
+		deploymentApprovalRestHandler: deploymentApprovalRestHandler,
+	}
+}
+
+func (router DeploymentApprovalRouterImpl) Init(deploymentApprovalRouter *mux.Router) {
+	deploymentApprovalRouter.Path("/policy").
+		HandlerFunc(router.deploymentApprovalRestHandler.SaveApprovalPolicy).Methods("POST")
+	deploymentApprovalRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.deploymentApprovalRestHandler.GetApprovalPolicy).Methods("GET")
+	deploymentApprovalRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.deploymentApprovalRestHandler.DeleteApprovalPolicy).Methods("DELETE")
+	deploymentApprovalRouter.Path("/request").
+		HandlerFunc(router.deploymentApprovalRestHandler.RaiseApprovalRequest).Methods("POST")
+	deploymentApprovalRouter.Path("/request/action").
+		HandlerFunc(router.deploymentApprovalRestHandler.TakeApprovalAction).Methods("POST")
+	deploymentApprovalRouter.Path("/request/{id}").
+		HandlerFunc(router.deploymentApprovalRestHandler.GetApprovalRequest).Methods("GET")
+	deploymentApprovalRouter.Path("/pipeline/{pipelineId}/request").
+		HandlerFunc(router.deploymentApprovalRestHandler.GetApprovalRequests).Methods("GET")
+}
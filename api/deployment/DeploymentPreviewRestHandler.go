@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentPreview"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+)
+
+// previewTimeout bounds the rendering and the dry runs of all the resources of a preview
+const previewTimeout = 2 * time.Minute
+
+type DeploymentPreviewRestHandler interface {
+	GetPreview(w http.ResponseWriter, r *http.Request)
+}
+
+type DeploymentPreviewRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	userService              user.UserService
+	enforcer                 casbin.Enforcer
+	enforcerUtil             rbac.EnforcerUtil
+	deploymentPreviewService deploymentPreview.DeploymentPreviewService
+}
+
+func NewDeploymentPreviewRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, deploymentPreviewService deploymentPreview.DeploymentPreviewService) *DeploymentPreviewRestHandlerImpl {
+	return &DeploymentPreviewRestHandlerImpl{
+		logger:                   logger,
+		userService:              userService,
+		enforcer:                 enforcer,
+		enforcerUtil:             enforcerUtil,
+		deploymentPreviewService: deploymentPreviewService,
+	}
+}
+
+func (handler *DeploymentPreviewRestHandlerImpl) GetPreview(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var overrideRequest apiBean.ValuesOverrideRequest
+	err = json.NewDecoder(r.Body).Decode(&overrideRequest)
+	if err != nil {
+		handler.logger.Errorw("request err, GetPreview", "err", err, "payload", overrideRequest)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if overrideRequest.PipelineId == 0 || overrideRequest.CiArtifactId == 0 {
+		common.WriteJsonResp(w, errors.New("pipelineId and ciArtifactId are required"), nil, http.StatusBadRequest)
+		return
+	}
+	overrideRequest.UserId = userId
+	// the preview is for the reviewers of a deployment, who need not be allowed to deploy it
+	token := r.Header.Get("token")
+	appObject := handler.enforcerUtil.GetAppRBACNameByAppId(overrideRequest.AppId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, appObject); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	envObject := handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(overrideRequest.AppId, overrideRequest.PipelineId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionGet, envObject); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	showSecrets := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionUpdate, appObject)
+	ctx, cancel := context.WithTimeout(r.Context(), previewTimeout)
+	defer cancel()
+	res, err := handler.deploymentPreviewService.GetPreview(ctx, &overrideRequest, showSecrets)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPreview", "pipelineId", overrideRequest.PipelineId, "artifactId", overrideRequest.CiArtifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
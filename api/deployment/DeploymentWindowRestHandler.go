@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type DeploymentWindowRestHandler interface {
+	SaveDeploymentWindow(w http.ResponseWriter, r *http.Request)
+	GetDeploymentWindows(w http.ResponseWriter, r *http.Request)
+	DeleteDeploymentWindow(w http.ResponseWriter, r *http.Request)
+	GetDeploymentWindowState(w http.ResponseWriter, r *http.Request)
+	GetBypassAudits(w http.ResponseWriter, r *http.Request)
+	GetQueuedTriggers(w http.ResponseWriter, r *http.Request)
+}
+
+type DeploymentWindowRestHandlerImpl struct {
+	logger                  *zap.SugaredLogger
+	userService             user.UserService
+	enforcer                casbin.Enforcer
+	validator               *validator.Validate
+	environmentService      environment.EnvironmentService
+	deploymentWindowService deploymentWindow.DeploymentWindowService
+}
+
+func NewDeploymentWindowRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	validator *validator.Validate, environmentService environment.EnvironmentService,
+	deploymentWindowService deploymentWindow.DeploymentWindowService) *DeploymentWindowRestHandlerImpl {
+	return &DeploymentWindowRestHandlerImpl{
+		logger:                  logger,
+		userService:             userService,
+		enforcer:                enforcer,
+		validator:               validator,
+		environmentService:      environmentService,
+		deploymentWindowService: deploymentWindowService,
+	}
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) SaveDeploymentWindow(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.DeploymentWindowDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveDeploymentWindow", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveDeploymentWindow", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// deployment windows govern all the apps of an environment, so they are managed by super admins
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.deploymentWindowService.CreateOrUpdate(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveDeploymentWindow", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetDeploymentWindows(w http.ResponseWriter, r *http.Request) {
+	envId, ok := handler.authorizeEnvironmentRead(w, r)
+	if !ok {
+		return
+	}
+	res, err := handler.deploymentWindowService.GetByEnvironmentId(envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeploymentWindows", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) DeleteDeploymentWindow(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionDelete); !ok {
+		return
+	}
+	err = handler.deploymentWindowService.Delete(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteDeploymentWindow", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetDeploymentWindowState(w http.ResponseWriter, r *http.Request) {
+	envId, ok := handler.authorizeEnvironmentRead(w, r)
+	if !ok {
+		return
+	}
+	res, err := handler.deploymentWindowService.GetWindowState(envId, time.Now())
+	if err != nil {
+		handler.logger.Errorw("service err, GetDeploymentWindowState", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetBypassAudits(w http.ResponseWriter, r *http.Request) {
+	envId, ok := handler.authorizeEnvironmentRead(w, r)
+	if !ok {
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	size, err := common.ExtractIntQueryParam(w, r, "size", 20)
+	if err != nil {
+		return
+	}
+	res, err := handler.deploymentWindowService.GetBypassAudits(envId, offset, size)
+	if err != nil {
+		handler.logger.Errorw("service err, GetBypassAudits", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DeploymentWindowRestHandlerImpl) GetQueuedTriggers(w http.ResponseWriter, r *http.Request) {
+	envId, ok := handler.authorizeEnvironmentRead(w, r)
+	if !ok {
+		return
+	}
+	res, err := handler.deploymentWindowService.GetQueuedTriggers(envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetQueuedTriggers", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// authorizeEnvironmentRead extracts the envId path param and checks view access on the environment,
+// the response is already written when it returns false
+func (handler *DeploymentWindowRestHandlerImpl) authorizeEnvironmentRead(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, false
+	}
+	envId, err := common.ExtractIntPathParamWithContext(w, r, "envId")
+	if err != nil {
+		return 0, false
+	}
+	env, err := handler.environmentService.FindById(envId)
+	if err != nil {
+		handler.logger.Errorw("error in fetching environment", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobalEnvironment, casbin.ActionGet, env.EnvironmentIdentifier); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return envId, true
+}
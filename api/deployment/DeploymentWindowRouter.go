@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type DeploymentWindowRouter interface {
+	Init(deploymentWindowRouter *mux.Router)
+}
+
+type DeploymentWindowRouterImpl struct {
+	deploymentWindowRestHandler DeploymentWindowRestHandler
+}
+
+func NewDeploymentWindowRouterImpl(deploymentWindowRestHandler DeploymentWindowRestHandler) *DeploymentWindowRouterImpl {
+	return &DeploymentWindowRouterImpl{
+		deploymentWindowRestHandler: deploymentWindowRestHandler,
+	}
+}
+
+func (router DeploymentWindowRouterImpl) Init(deploymentWindowRouter *mux.Router) {
+	deploymentWindowRouter.Path("").
+		HandlerFunc(router.deploymentWindowRestHandler.SaveDeploymentWindow).Methods("POST")
+	deploymentWindowRouter.Path("/{id}").
+		HandlerFunc(router.deploymentWindowRestHandler.DeleteDeploymentWindow).Methods("DELETE")
+	deploymentWindowRouter.Path("/env/{envId}").
+		HandlerFunc(router.deploymentWindowRestHandler.GetDeploymentWindows).Methods("GET")
+	deploymentWindowRouter.Path("/env/{envId}/state").
+		HandlerFunc(router.deploymentWindowRestHandler.GetDeploymentWindowState).Methods("GET")
+	deploymentWindowRouter.Path("/env/{envId}/bypass-audit").
+		HandlerFunc(router.deploymentWindowRestHandler.GetBypassAudits).Methods("GET")
+	deploymentWindowRouter.Path("/env/{envId}/queue").
+		HandlerFunc(router.deploymentWindowRestHandler.GetQueuedTriggers).Methods("GET")
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type DriftDetectionRestHandler interface {
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	SaveConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+	GetDriftStatus(w http.ResponseWriter, r *http.Request)
+	CheckDrift(w http.ResponseWriter, r *http.Request)
+	GetAppDriftStatuses(w http.ResponseWriter, r *http.Request)
+}
+
+type DriftDetectionRestHandlerImpl struct {
+	logger                *zap.SugaredLogger
+	userService           user.UserService
+	enforcer              casbin.Enforcer
+	enforcerUtil          rbac.EnforcerUtil
+	validator             *validator.Validate
+	driftDetectionService driftDetection.DriftDetectionService
+}
+
+func NewDriftDetectionRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	driftDetectionService driftDetection.DriftDetectionService) *DriftDetectionRestHandlerImpl {
+	return &DriftDetectionRestHandlerImpl{
+		logger:                logger,
+		userService:           userService,
+		enforcer:              enforcer,
+		enforcerUtil:          enforcerUtil,
+		validator:             validator,
+		driftDetectionService: driftDetectionService,
+	}
+}
+
+func (handler *DriftDetectionRestHandlerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.driftDetectionService.GetConfig(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetConfig", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DriftDetectionRestHandlerImpl) SaveConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.DriftDetectionConfigDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// drift detection is a part of the cd pipeline configuration
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, request.PipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.driftDetectionService.SaveConfig(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DriftDetectionRestHandlerImpl) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.driftDetectionService.DeleteConfig(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteConfig", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *DriftDetectionRestHandlerImpl) GetDriftStatus(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.driftDetectionService.GetDriftStatus(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetDriftStatus", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if res == nil {
+		common.WriteJsonResp(w, errors.New("drift status not found"), nil, http.StatusNotFound)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DriftDetectionRestHandlerImpl) CheckDrift(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.driftDetectionService.CheckDrift(r.Context(), pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, CheckDrift", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *DriftDetectionRestHandlerImpl) GetAppDriftStatuses(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, handler.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	statuses, err := handler.driftDetectionService.GetDriftStatusesByAppId(appId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetAppDriftStatuses", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	pipelineIds := make([]int, 0, len(statuses))
+	for _, status := range statuses {
+		pipelineIds = append(pipelineIds, status.PipelineId)
+	}
+	// only the environments the user has access to are listed
+	objectsByPipelineId := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds(pipelineIds)
+	envObjects := make([]string, 0, len(objectsByPipelineId))
+	for _, objects := range objectsByPipelineId {
+		if len(objects) == 2 {
+			envObjects = append(envObjects, objects[1])
+		}
+	}
+	results := handler.enforcer.EnforceInBatch(token, casbin.ResourceEnvironment, casbin.ActionGet, envObjects)
+	res := make([]*bean.DriftStatusDto, 0, len(statuses))
+	for _, status := range statuses {
+		objects := objectsByPipelineId[status.PipelineId]
+		if len(objects) == 2 && results[objects[1]] {
+			res = append(res, status)
+		}
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
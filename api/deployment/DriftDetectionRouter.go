@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type DriftDetectionRouter interface {
+	Init(driftDetectionRouter *mux.Router)
+}
+
+type DriftDetectionRouterImpl struct {
+	driftDetectionRestHandler DriftDetectionRestHandler
+}
+
+func NewDriftDetectionRouterImpl(driftDetectionRestHandler DriftDetectionRestHandler) *DriftDetectionRouterImpl {
+	return &DriftDetectionRouterImpl{
+		driftDetectionRestHandler: driftDetectionRestHandler,
+	}
+}
+
+func (router DriftDetectionRouterImpl) Init(driftDetectionRouter *mux.Router) {
+	driftDetectionRouter.Path("/config").
+		HandlerFunc(router.driftDetectionRestHandler.SaveConfig).Methods("POST")
+	driftDetectionRouter.Path("/config/{pipelineId}").
+		HandlerFunc(router.driftDetectionRestHandler.GetConfig).Methods("GET")
+	driftDetectionRouter.Path("/config/{pipelineId}").
+		HandlerFunc(router.driftDetectionRestHandler.DeleteConfig).Methods("DELETE")
+	driftDetectionRouter.Path("/pipeline/{pipelineId}/status").
+		HandlerFunc(router.driftDetectionRestHandler.GetDriftStatus).Methods("GET")
+	driftDetectionRouter.Path("/pipeline/{pipelineId}/check").
+		HandlerFunc(router.driftDetectionRestHandler.CheckDrift).Methods("POST")
+	driftDetectionRouter.Path("/app/{appId}/status").
+		HandlerFunc(router.driftDetectionRestHandler.GetAppDriftStatuses).Methods("GET")
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type GitOpsMonoRepoRestHandler interface {
+	GetLocation(w http.ResponseWriter, r *http.Request)
+	MigrateApp(w http.ResponseWriter, r *http.Request)
+}
+
+type GitOpsMonoRepoRestHandlerImpl struct {
+	logger          *zap.SugaredLogger
+	userService     user.UserService
+	enforcer        casbin.Enforcer
+	enforcerUtil    rbac.EnforcerUtil
+	validator       *validator.Validate
+	monoRepoService monoRepo.MonoRepoService
+}
+
+func NewGitOpsMonoRepoRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	monoRepoService monoRepo.MonoRepoService) *GitOpsMonoRepoRestHandlerImpl {
+	return &GitOpsMonoRepoRestHandlerImpl{
+		logger:          logger,
+		userService:     userService,
+		enforcer:        enforcer,
+		enforcerUtil:    enforcerUtil,
+		validator:       validator,
+		monoRepoService: monoRepoService,
+	}
+}
+
+func (handler *GitOpsMonoRepoRestHandlerImpl) GetLocation(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	envId, err := common.ExtractIntPathParamWithContext(w, r, "envId")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, handler.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	res, err := handler.monoRepoService.GetMonoRepoLocation(appId, envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetLocation", "appId", appId, "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *GitOpsMonoRepoRestHandlerImpl) MigrateApp(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.MigrateAppRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, MigrateApp", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, MigrateApp", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// migration re-points ArgoCd applications to another repository, only super admins can trigger it
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.monoRepoService.MigrateApp(r.Context(), &request)
+	if err != nil {
+		handler.logger.Errorw("service err, MigrateApp", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
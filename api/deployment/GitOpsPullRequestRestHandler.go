@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type GitOpsPullRequestRestHandler interface {
+	GetAllConfigs(w http.ResponseWriter, r *http.Request)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	SaveConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+	GetPullRequest(w http.ResponseWriter, r *http.Request)
+}
+
+type GitOpsPullRequestRestHandlerImpl struct {
+	logger                 *zap.SugaredLogger
+	userService            user.UserService
+	enforcer               casbin.Enforcer
+	enforcerUtil           rbac.EnforcerUtil
+	validator              *validator.Validate
+	pullRequestModeService pullRequest.PullRequestModeService
+}
+
+func NewGitOpsPullRequestRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	pullRequestModeService pullRequest.PullRequestModeService) *GitOpsPullRequestRestHandlerImpl {
+	return &GitOpsPullRequestRestHandlerImpl{
+		logger:                 logger,
+		userService:            userService,
+		enforcer:               enforcer,
+		enforcerUtil:           enforcerUtil,
+		validator:              validator,
+		pullRequestModeService: pullRequestModeService,
+	}
+}
+
+func (handler *GitOpsPullRequestRestHandlerImpl) GetAllConfigs(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.pullRequestModeService.GetAllConfigs()
+	if err != nil {
+		handler.logger.Errorw("service err, GetAllConfigs", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *GitOpsPullRequestRestHandlerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	envId, err := common.ExtractIntPathParamWithContext(w, r, "envId")
+	if err != nil {
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.pullRequestModeService.GetConfig(envId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetConfig", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *GitOpsPullRequestRestHandlerImpl) SaveConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.PullRequestModeConfigDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// pull request mode is a compliance control, only super admins can change it
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.pullRequestModeService.SaveConfig(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveConfig", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *GitOpsPullRequestRestHandlerImpl) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	envId, err := common.ExtractIntPathParamWithContext(w, r, "envId")
+	if err != nil {
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.pullRequestModeService.DeleteConfig(envId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteConfig", "envId", envId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, envId, http.StatusOK)
+}
+
+func (handler *GitOpsPullRequestRestHandlerImpl) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	wfrId, err := common.ExtractIntPathParamWithContext(w, r, "wfrId")
+	if err != nil {
+		return
+	}
+	objects, ok := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) != 2 {
+		common.WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, objects[0]); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	res, err := handler.pullRequestModeService.GetPullRequest(wfrId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPullRequest", "wfrId", wfrId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if res == nil || res.PipelineId != pipelineId {
+		common.WriteJsonResp(w, errors.New("pull request not found"), nil, http.StatusNotFound)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type GitOpsPullRequestRouter interface {
+	Init(gitOpsPullRequestRouter *mux.Router)
+}
+
+type GitOpsPullRequestRouterImpl struct {
+	gitOpsPullRequestRestHandler GitOpsPullRequestRestHandler
+}
+
+func NewGitOpsPullRequestRouterImpl(gitOpsPullRequestRestHandler GitOpsPullRequestRestHandler) *GitOpsPullRequestRouterImpl {
+	return &GitOpsPullRequestRouterImpl{
+		gitOpsPullRequestRestHandler: gitOpsPullRequestRestHandler,
+	}
+}
+
+func (router GitOpsPullRequestRouterImpl) Init(gitOpsPullRequestRouter *mux.Router) {
+	gitOpsPullRequestRouter.Path("/config").
+		HandlerFunc(router.gitOpsPullRequestRestHandler.GetAllConfigs).Methods("GET")
+	gitOpsPullRequestRouter.Path("/config").
+		HandlerFunc(router.gitOpsPullRequestRestHandler.SaveConfig).Methods("POST")
+	gitOpsPullRequestRouter.Path("/config/{envId}").
+		HandlerFunc(router.gitOpsPullRequestRestHandler.GetConfig).Methods("GET")
+	gitOpsPullRequestRouter.Path("/config/{envId}").
+		HandlerFunc(router.gitOpsPullRequestRestHandler.DeleteConfig).Methods("DELETE")
+	gitOpsPullRequestRouter.Path("/pipeline/{pipelineId}/wfr/{wfrId}").
+		HandlerFunc(router.gitOpsPullRequestRestHandler.GetPullRequest).Methods("GET")
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type HibernationScheduleRestHandler interface {
+	GetSchedules(w http.ResponseWriter, r *http.Request)
+	GetSchedule(w http.ResponseWriter, r *http.Request)
+	SaveSchedule(w http.ResponseWriter, r *http.Request)
+	DeleteSchedule(w http.ResponseWriter, r *http.Request)
+	KeepAwake(w http.ResponseWriter, r *http.Request)
+	SaveAppOverride(w http.ResponseWriter, r *http.Request)
+	GetRuns(w http.ResponseWriter, r *http.Request)
+}
+
+type HibernationScheduleRestHandlerImpl struct {
+	logger                     *zap.SugaredLogger
+	userService                user.UserService
+	enforcer                   casbin.Enforcer
+	enforcerUtil               rbac.EnforcerUtil
+	validator                  *validator.Validate
+	environmentService         environment.EnvironmentService
+	hibernationScheduleService hibernationSchedule.HibernationScheduleService
+}
+
+func NewHibernationScheduleRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate, environmentService environment.EnvironmentService,
+	hibernationScheduleService hibernationSchedule.HibernationScheduleService) *HibernationScheduleRestHandlerImpl {
+	return &HibernationScheduleRestHandlerImpl{
+		logger:                     logger,
+		userService:                userService,
+		enforcer:                   enforcer,
+		enforcerUtil:               enforcerUtil,
+		validator:                  validator,
+		environmentService:         environmentService,
+		hibernationScheduleService: hibernationScheduleService,
+	}
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	schedules, err := handler.hibernationScheduleService.GetSchedules()
+	if err != nil {
+		handler.logger.Errorw("service err, GetSchedules", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	envIds := make([]*int, 0, len(schedules))
+	for _, schedule := range schedules {
+		envIds = append(envIds, &schedule.EnvironmentId)
+	}
+	envIdentifiers := make(map[int]string, len(envIds))
+	if len(envIds) > 0 {
+		environments, err := handler.environmentService.FindByIds(envIds)
+		if err != nil {
+			handler.logger.Errorw("service err, GetSchedules", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+		for _, environmentBean := range environments {
+			envIdentifiers[environmentBean.Id] = environmentBean.EnvironmentIdentifier
+		}
+	}
+	identifiers := make([]string, 0, len(envIdentifiers))
+	for _, identifier := range envIdentifiers {
+		identifiers = append(identifiers, identifier)
+	}
+	token := r.Header.Get("token")
+	authorized := handler.enforcer.EnforceInBatch(token, casbin.ResourceGlobalEnvironment, casbin.ActionGet, identifiers)
+	res := make([]*bean.HibernationScheduleDto, 0, len(schedules))
+	for _, schedule := range schedules {
+		if identifier, ok := envIdentifiers[schedule.EnvironmentId]; ok && authorized[identifier] {
+			res = append(res, schedule)
+		}
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := handler.authorizeSchedule(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, schedule, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) SaveSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	var request bean.HibernationScheduleDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	res, err := handler.hibernationScheduleService.SaveSchedule(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionDelete); !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.hibernationScheduleService.DeleteSchedule(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteSchedule", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) KeepAwake(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := handler.authorizeSchedule(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.KeepAwakeRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, KeepAwake", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.ScheduleId = schedule.Id
+	request.UserId = userId
+	res, err := handler.hibernationScheduleService.KeepAwake(&request, handler.checkAuthForWake(r.Header.Get("token")))
+	if err != nil {
+		handler.logger.Errorw("service err, KeepAwake", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) SaveAppOverride(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	schedule, err := handler.hibernationScheduleService.GetSchedule(id)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveAppOverride", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// app owners may opt out their own apps, the override skips their deployments on the environment
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionTrigger, handler.enforcerUtil.GetEnvRBACNameByAppId(appId, schedule.EnvironmentId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return
+	}
+	var request bean.AppOverrideDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveAppOverride", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.ScheduleId = schedule.Id
+	request.AppId = appId
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveAppOverride", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	res, err := handler.hibernationScheduleService.SaveAppOverride(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveAppOverride", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *HibernationScheduleRestHandlerImpl) GetRuns(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := handler.authorizeSchedule(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	res, err := handler.hibernationScheduleService.GetRuns(schedule.Id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRuns", "id", schedule.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// checkAuthForWake checks the trigger access on the app and its environment, as the bulk un-hibernate does
+func (handler *HibernationScheduleRestHandlerImpl) checkAuthForWake(token string) func(target *bean.Target) bool {
+	return func(target *bean.Target) bool {
+		appObject := handler.enforcerUtil.GetAppRBACNameByAppId(target.AppId)
+		if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionTrigger, strings.ToLower(appObject)); !ok {
+			return false
+		}
+		envObject := handler.enforcerUtil.GetEnvRBACNameByAppId(target.AppId, target.EnvironmentId)
+		return handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionTrigger, strings.ToLower(envObject))
+	}
+}
+
+// authorizeSchedule checks the action on the environment of the schedule of the request, the response is already written when it returns false
+func (handler *HibernationScheduleRestHandlerImpl) authorizeSchedule(w http.ResponseWriter, r *http.Request, action string) (*bean.HibernationScheduleDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	schedule, err := handler.hibernationScheduleService.GetSchedule(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSchedule", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	environmentBean, err := handler.environmentService.FindById(schedule.EnvironmentId)
+	if err != nil {
+		handler.logger.Errorw("service err, FindById", "envId", schedule.EnvironmentId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobalEnvironment, action, environmentBean.EnvironmentIdentifier); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	return schedule, true
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type MetricVerificationRestHandler interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetRun(w http.ResponseWriter, r *http.Request)
+}
+
+type MetricVerificationRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+	metricVerificationService metricVerification.MetricVerificationService
+}
+
+func NewMetricVerificationRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	metricVerificationService metricVerification.MetricVerificationService) *MetricVerificationRestHandlerImpl {
+	return &MetricVerificationRestHandlerImpl{
+		logger:                    logger,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+		metricVerificationService: metricVerificationService,
+	}
+}
+
+func (handler *MetricVerificationRestHandlerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.metricVerificationService.GetPolicy(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *MetricVerificationRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.MetricVerificationPolicyDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// metric verification policy is a part of the cd pipeline configuration
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, request.PipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	res, err := handler.metricVerificationService.SavePolicy(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *MetricVerificationRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.metricVerificationService.DeletePolicy(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, pipelineId, http.StatusOK)
+}
+
+func (handler *MetricVerificationRestHandlerImpl) GetRun(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	wfrId, err := common.ExtractIntPathParamWithContext(w, r, "wfrId")
+	if err != nil {
+		return
+	}
+	if ok := common.AuthorizePipeline(w, r, handler.enforcer, handler.enforcerUtil, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	res, err := handler.metricVerificationService.GetRun(wfrId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRun", "pipelineId", pipelineId, "wfrId", wfrId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if res == nil || res.PipelineId != pipelineId {
+		common.WriteJsonResp(w, errors.New("metric verification not found"), nil, http.StatusNotFound)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type MetricVerificationRouter interface {
+	Init(metricVerificationRouter *mux.Router)
+}
+
+type MetricVerificationRouterImpl struct {
+	metricVerificationRestHandler MetricVerificationRestHandler
+}
+
+func NewMetricVerificationRouterImpl(metricVerificationRestHandler MetricVerificationRestHandler) *MetricVerificationRouterImpl {
+	return &MetricVerificationRouterImpl{
+		metricVerificationRestHandler: metricVerificationRestHandler,
+	}
+}
+
+func (router MetricVerificationRouterImpl) Init(metricVerificationRouter *mux.Router) {
+	metricVerificationRouter.Path("/policy").
+		HandlerFunc(router.metricVerificationRestHandler.SavePolicy).Methods("POST")
+	metricVerificationRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.metricVerificationRestHandler.GetPolicy).Methods("GET")
+	metricVerificationRouter.Path("/policy/{pipelineId}").
+		HandlerFunc(router.metricVerificationRestHandler.DeletePolicy).Methods("DELETE")
+	metricVerificationRouter.Path("/pipeline/{pipelineId}/run/{wfrId}").
+		HandlerFunc(router.metricVerificationRestHandler.GetRun).Methods("GET")
+}
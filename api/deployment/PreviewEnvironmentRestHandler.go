@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type PreviewEnvironmentRestHandler interface {
+	GetTemplate(w http.ResponseWriter, r *http.Request)
+	SaveTemplate(w http.ResponseWriter, r *http.Request)
+	DeleteTemplate(w http.ResponseWriter, r *http.Request)
+	GetPreviews(w http.ResponseWriter, r *http.Request)
+	DeletePreview(w http.ResponseWriter, r *http.Request)
+}
+
+type PreviewEnvironmentRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+	previewEnvironmentService previewEnvironment.PreviewEnvironmentService
+}
+
+func NewPreviewEnvironmentRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	previewEnvironmentService previewEnvironment.PreviewEnvironmentService) *PreviewEnvironmentRestHandlerImpl {
+	return &PreviewEnvironmentRestHandlerImpl{
+		logger:                    logger,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+		previewEnvironmentService: previewEnvironmentService,
+	}
+}
+
+func (handler *PreviewEnvironmentRestHandlerImpl) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	appId, ok := handler.authorizeApp(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	res, err := handler.previewEnvironmentService.GetTemplate(appId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetTemplate", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *PreviewEnvironmentRestHandlerImpl) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, ok := handler.authorizeApp(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request bean.PreviewTemplateDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveTemplate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.AppId = appId
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveTemplate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	res, err := handler.previewEnvironmentService.SaveTemplate(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveTemplate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *PreviewEnvironmentRestHandlerImpl) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, ok := handler.authorizeApp(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	err = handler.previewEnvironmentService.DeleteTemplate(appId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteTemplate", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, appId, http.StatusOK)
+}
+
+func (handler *PreviewEnvironmentRestHandlerImpl) GetPreviews(w http.ResponseWriter, r *http.Request) {
+	appId, ok := handler.authorizeApp(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	res, err := handler.previewEnvironmentService.GetPreviews(appId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetPreviews", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *PreviewEnvironmentRestHandlerImpl) DeletePreview(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, ok := handler.authorizeApp(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	previewId, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.previewEnvironmentService.DeletePreview(appId, previewId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePreview", "appId", appId, "previewId", previewId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, previewId, http.StatusOK)
+}
+
+// authorizeApp checks the action on the app of the request, the response is already written when it returns false
+func (handler *PreviewEnvironmentRestHandlerImpl) authorizeApp(w http.ResponseWriter, r *http.Request, action string) (int, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, false
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, handler.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return appId, true
+}
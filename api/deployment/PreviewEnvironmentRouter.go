@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type PreviewEnvironmentRouter interface {
+	Init(previewEnvironmentRouter *mux.Router)
+}
+
+type PreviewEnvironmentRouterImpl struct {
+	previewEnvironmentRestHandler PreviewEnvironmentRestHandler
+}
+
+func NewPreviewEnvironmentRouterImpl(previewEnvironmentRestHandler PreviewEnvironmentRestHandler) *PreviewEnvironmentRouterImpl {
+	return &PreviewEnvironmentRouterImpl{
+		previewEnvironmentRestHandler: previewEnvironmentRestHandler,
+	}
+}
+
+func (router PreviewEnvironmentRouterImpl) Init(previewEnvironmentRouter *mux.Router) {
+	previewEnvironmentRouter.Path("/app/{appId}/template").
+		HandlerFunc(router.previewEnvironmentRestHandler.GetTemplate).Methods("GET")
+	previewEnvironmentRouter.Path("/app/{appId}/template").
+		HandlerFunc(router.previewEnvironmentRestHandler.SaveTemplate).Methods("PUT")
+	previewEnvironmentRouter.Path("/app/{appId}/template").
+		HandlerFunc(router.previewEnvironmentRestHandler.DeleteTemplate).Methods("DELETE")
+	previewEnvironmentRouter.Path("/app/{appId}").
+		HandlerFunc(router.previewEnvironmentRestHandler.GetPreviews).Methods("GET")
+	previewEnvironmentRouter.Path("/app/{appId}/{id}").
+		HandlerFunc(router.previewEnvironmentRestHandler.DeletePreview).Methods("DELETE")
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ReleaseOrchestrationRestHandler interface {
+	GetReleases(w http.ResponseWriter, r *http.Request)
+	GetRelease(w http.ResponseWriter, r *http.Request)
+	CreateRelease(w http.ResponseWriter, r *http.Request)
+	StartRelease(w http.ResponseWriter, r *http.Request)
+	ResumeRelease(w http.ResponseWriter, r *http.Request)
+	RollbackRelease(w http.ResponseWriter, r *http.Request)
+	GetTimeline(w http.ResponseWriter, r *http.Request)
+}
+
+type ReleaseOrchestrationRestHandlerImpl struct {
+	logger                      *zap.SugaredLogger
+	userService                 user.UserService
+	enforcer                    casbin.Enforcer
+	enforcerUtil                rbac.EnforcerUtil
+	validator                   *validator.Validate
+	releaseOrchestrationService releaseOrchestration.ReleaseOrchestrationService
+}
+
+func NewReleaseOrchestrationRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	releaseOrchestrationService releaseOrchestration.ReleaseOrchestrationService) *ReleaseOrchestrationRestHandlerImpl {
+	return &ReleaseOrchestrationRestHandlerImpl{
+		logger:                      logger,
+		userService:                 userService,
+		enforcer:                    enforcer,
+		enforcerUtil:                enforcerUtil,
+		validator:                   validator,
+		releaseOrchestrationService: releaseOrchestrationService,
+	}
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) GetReleases(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	releases, err := handler.releaseOrchestrationService.GetReleases()
+	if err != nil {
+		handler.logger.Errorw("service err, GetReleases", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	var pipelineIds []int
+	for _, release := range releases {
+		for _, item := range release.Items {
+			pipelineIds = append(pipelineIds, item.PipelineId)
+		}
+	}
+	objectsByPipelineId := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds(pipelineIds)
+	appObjects := make([]string, 0, len(objectsByPipelineId))
+	for _, objects := range objectsByPipelineId {
+		appObjects = append(appObjects, objects[0])
+	}
+	token := r.Header.Get("token")
+	authorized := handler.enforcer.EnforceInBatch(token, casbin.ResourceApplications, casbin.ActionGet, appObjects)
+	// a release is listed only if all of its apps are visible to the user
+	res := make([]*bean.ReleaseDto, 0, len(releases))
+	for _, release := range releases {
+		visible := true
+		for _, item := range release.Items {
+			objects, ok := objectsByPipelineId[item.PipelineId]
+			if !ok || !authorized[objects[0]] {
+				visible = false
+				break
+			}
+		}
+		if visible {
+			res = append(res, release)
+		}
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) GetRelease(w http.ResponseWriter, r *http.Request) {
+	release, ok := handler.authorizeRelease(w, r, casbin.ActionGet, false)
+	if !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, release, http.StatusOK)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) CreateRelease(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.ReleaseDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, CreateRelease", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateRelease", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if ok := handler.authorizeItems(w, r, request.Items, casbin.ActionTrigger, true); !ok {
+		return
+	}
+	res, err := handler.releaseOrchestrationService.CreateRelease(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateRelease", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) StartRelease(w http.ResponseWriter, r *http.Request) {
+	handler.runReleaseAction(w, r, "StartRelease", handler.releaseOrchestrationService.StartRelease)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) ResumeRelease(w http.ResponseWriter, r *http.Request) {
+	handler.runReleaseAction(w, r, "ResumeRelease", handler.releaseOrchestrationService.ResumeRelease)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) RollbackRelease(w http.ResponseWriter, r *http.Request) {
+	handler.runReleaseAction(w, r, "RollbackRelease", handler.releaseOrchestrationService.RollbackRelease)
+}
+
+func (handler *ReleaseOrchestrationRestHandlerImpl) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	release, ok := handler.authorizeRelease(w, r, casbin.ActionGet, false)
+	if !ok {
+		return
+	}
+	res, err := handler.releaseOrchestrationService.GetTimeline(release.Id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetTimeline", "id", release.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// runReleaseAction deploys the release on behalf of the user, who needs to be allowed to deploy all of its apps
+func (handler *ReleaseOrchestrationRestHandlerImpl) runReleaseAction(w http.ResponseWriter, r *http.Request, name string,
+	action func(id int, userId int32) (*bean.ReleaseDto, error)) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	release, ok := handler.authorizeRelease(w, r, casbin.ActionTrigger, true)
+	if !ok {
+		return
+	}
+	res, err := action(release.Id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, "+name, "id", release.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// authorizeRelease checks the action on all the apps of the release of the request, the response is already written when it returns false
+func (handler *ReleaseOrchestrationRestHandlerImpl) authorizeRelease(w http.ResponseWriter, r *http.Request, action string, checkEnv bool) (*bean.ReleaseDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	release, err := handler.releaseOrchestrationService.GetRelease(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRelease", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	if ok := handler.authorizeItems(w, r, release.Items, action, checkEnv); !ok {
+		return nil, false
+	}
+	return release, true
+}
+
+// authorizeItems checks the action on the apps, and on the environments if asked, of the pipelines of the items
+func (handler *ReleaseOrchestrationRestHandlerImpl) authorizeItems(w http.ResponseWriter, r *http.Request, items []*bean.ReleaseItemDto, action string, checkEnv bool) bool {
+	pipelineIds := make([]int, 0, len(items))
+	for _, item := range items {
+		pipelineIds = append(pipelineIds, item.PipelineId)
+	}
+	objectsByPipelineId := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds(pipelineIds)
+	appObjects := make([]string, 0, len(objectsByPipelineId))
+	envObjects := make([]string, 0, len(objectsByPipelineId))
+	for _, pipelineId := range pipelineIds {
+		objects, ok := objectsByPipelineId[pipelineId]
+		if !ok || len(objects) != 2 {
+			common.WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+			return false
+		}
+		appObjects = append(appObjects, objects[0])
+		envObjects = append(envObjects, objects[1])
+	}
+	token := r.Header.Get("token")
+	for _, allowed := range handler.enforcer.EnforceInBatch(token, casbin.ResourceApplications, action, appObjects) {
+		if !allowed {
+			common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+			return false
+		}
+	}
+	if checkEnv {
+		for _, allowed := range handler.enforcer.EnforceInBatch(token, casbin.ResourceEnvironment, action, envObjects) {
+			if !allowed {
+				common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ReleaseOrchestrationRouter interface {
+	Init(releaseOrchestrationRouter *mux.Router)
+}
+
+type ReleaseOrchestrationRouterImpl struct {
+	releaseOrchestrationRestHandler ReleaseOrchestrationRestHandler
+}
+
+func NewReleaseOrchestrationRouterImpl(releaseOrchestrationRestHandler ReleaseOrchestrationRestHandler) *ReleaseOrchestrationRouterImpl {
+	return &ReleaseOrchestrationRouterImpl{
+		releaseOrchestrationRestHandler: releaseOrchestrationRestHandler,
+	}
+}
+
+func (router ReleaseOrchestrationRouterImpl) Init(releaseOrchestrationRouter *mux.Router) {
+	releaseOrchestrationRouter.Path("").
+		HandlerFunc(router.releaseOrchestrationRestHandler.GetReleases).Methods("GET")
+	releaseOrchestrationRouter.Path("").
+		HandlerFunc(router.releaseOrchestrationRestHandler.CreateRelease).Methods("POST")
+	releaseOrchestrationRouter.Path("/{id}").
+		HandlerFunc(router.releaseOrchestrationRestHandler.GetRelease).Methods("GET")
+	releaseOrchestrationRouter.Path("/{id}/start").
+		HandlerFunc(router.releaseOrchestrationRestHandler.StartRelease).Methods("PUT")
+	releaseOrchestrationRouter.Path("/{id}/resume").
+		HandlerFunc(router.releaseOrchestrationRestHandler.ResumeRelease).Methods("PUT")
+	releaseOrchestrationRouter.Path("/{id}/rollback").
+		HandlerFunc(router.releaseOrchestrationRestHandler.RollbackRelease).Methods("PUT")
+	releaseOrchestrationRouter.Path("/{id}/timeline").
+		HandlerFunc(router.releaseOrchestrationRestHandler.GetTimeline).Methods("GET")
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ScheduledDeploymentRestHandler interface {
+	GetScheduledDeployments(w http.ResponseWriter, r *http.Request)
+	GetScheduledDeployment(w http.ResponseWriter, r *http.Request)
+	CreateScheduledDeployment(w http.ResponseWriter, r *http.Request)
+	CancelScheduledDeployment(w http.ResponseWriter, r *http.Request)
+}
+
+type ScheduledDeploymentRestHandlerImpl struct {
+	logger                     *zap.SugaredLogger
+	userService                user.UserService
+	enforcer                   casbin.Enforcer
+	enforcerUtil               rbac.EnforcerUtil
+	validator                  *validator.Validate
+	scheduledDeploymentService scheduledDeployment.ScheduledDeploymentService
+}
+
+func NewScheduledDeploymentRestHandlerImpl(logger *zap.SugaredLogger, userService user.UserService, enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	scheduledDeploymentService scheduledDeployment.ScheduledDeploymentService) *ScheduledDeploymentRestHandlerImpl {
+	return &ScheduledDeploymentRestHandlerImpl{
+		logger:                     logger,
+		userService:                userService,
+		enforcer:                   enforcer,
+		enforcerUtil:               enforcerUtil,
+		validator:                  validator,
+		scheduledDeploymentService: scheduledDeploymentService,
+	}
+}
+
+func (handler *ScheduledDeploymentRestHandlerImpl) GetScheduledDeployments(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntQueryParam(w, r, "pipelineId", 0)
+	if err != nil {
+		return
+	}
+	filter := &bean.ListFilter{
+		AppId:      appId,
+		PipelineId: pipelineId,
+	}
+	if statuses := r.URL.Query().Get("status"); len(statuses) > 0 {
+		filter.Statuses = strings.Split(statuses, ",")
+	}
+	scheduledDeployments, err := handler.scheduledDeploymentService.GetScheduledDeployments(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetScheduledDeployments", "filter", filter, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	pipelineIds := make([]int, 0, len(scheduledDeployments))
+	for _, scheduledDeployment := range scheduledDeployments {
+		pipelineIds = append(pipelineIds, scheduledDeployment.OverrideRequest.PipelineId)
+	}
+	objectsByPipelineId := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds(pipelineIds)
+	appObjects := make([]string, 0, len(objectsByPipelineId))
+	for _, objects := range objectsByPipelineId {
+		appObjects = append(appObjects, objects[0])
+	}
+	token := r.Header.Get("token")
+	authorized := handler.enforcer.EnforceInBatch(token, casbin.ResourceApplications, casbin.ActionGet, appObjects)
+	res := make([]*bean.ScheduledDeploymentDto, 0, len(scheduledDeployments))
+	for _, scheduledDeployment := range scheduledDeployments {
+		objects, ok := objectsByPipelineId[scheduledDeployment.OverrideRequest.PipelineId]
+		if ok && authorized[objects[0]] {
+			res = append(res, scheduledDeployment)
+		}
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ScheduledDeploymentRestHandlerImpl) GetScheduledDeployment(w http.ResponseWriter, r *http.Request) {
+	scheduledDeployment, ok := handler.authorizeScheduledDeployment(w, r, casbin.ActionGet, false)
+	if !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, scheduledDeployment, http.StatusOK)
+}
+
+func (handler *ScheduledDeploymentRestHandlerImpl) CreateScheduledDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.ScheduledDeploymentDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, CreateScheduledDeployment", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateScheduledDeployment", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if ok := handler.authorizeTrigger(w, r, request.OverrideRequest.AppId, request.OverrideRequest.PipelineId); !ok {
+		return
+	}
+	res, err := handler.scheduledDeploymentService.CreateScheduledDeployment(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateScheduledDeployment", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *ScheduledDeploymentRestHandlerImpl) CancelScheduledDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	scheduledDeployment, ok := handler.authorizeScheduledDeployment(w, r, casbin.ActionTrigger, true)
+	if !ok {
+		return
+	}
+	res, err := handler.scheduledDeploymentService.CancelScheduledDeployment(scheduledDeployment.Id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, CancelScheduledDeployment", "id", scheduledDeployment.Id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// authorizeScheduledDeployment checks the action on the app, and on the environment if asked, of the scheduled deployment
+// of the request, the response is already written when it returns false
+func (handler *ScheduledDeploymentRestHandlerImpl) authorizeScheduledDeployment(w http.ResponseWriter, r *http.Request, action string, checkEnv bool) (*bean.ScheduledDeploymentDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	scheduledDeployment, err := handler.scheduledDeploymentService.GetScheduledDeployment(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetScheduledDeployment", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	appId, pipelineId := scheduledDeployment.OverrideRequest.AppId, scheduledDeployment.OverrideRequest.PipelineId
+	if action == casbin.ActionTrigger {
+		return scheduledDeployment, handler.authorizeTrigger(w, r, appId, pipelineId)
+	}
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, action, handler.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	return scheduledDeployment, true
+}
+
+// authorizeTrigger checks the trigger access on the app and the environment of the pipeline, as for a manual deployment
+func (handler *ScheduledDeploymentRestHandlerImpl) authorizeTrigger(w http.ResponseWriter, r *http.Request, appId, pipelineId int) bool {
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionTrigger, handler.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceEnvironment, casbin.ActionTrigger, handler.enforcerUtil.GetAppRBACByAppIdAndPipelineId(appId, pipelineId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
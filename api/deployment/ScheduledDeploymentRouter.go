@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployment
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type ScheduledDeploymentRouter interface {
+	Init(scheduledDeploymentRouter *mux.Router)
+}
+
+type ScheduledDeploymentRouterImpl struct {
+	scheduledDeploymentRestHandler ScheduledDeploymentRestHandler
+}
+
+func NewScheduledDeploymentRouterImpl(scheduledDeploymentRestHandler ScheduledDeploymentRestHandler) *ScheduledDeploymentRouterImpl {
+	return &ScheduledDeploymentRouterImpl{
+		scheduledDeploymentRestHandler: scheduledDeploymentRestHandler,
+	}
+}
+
+func (router ScheduledDeploymentRouterImpl) Init(scheduledDeploymentRouter *mux.Router) {
+	scheduledDeploymentRouter.Path("").
+		HandlerFunc(router.scheduledDeploymentRestHandler.GetScheduledDeployments).Methods("GET")
+	scheduledDeploymentRouter.Path("").
+		HandlerFunc(router.scheduledDeploymentRestHandler.CreateScheduledDeployment).Methods("POST")
+	scheduledDeploymentRouter.Path("/{id}").
+		HandlerFunc(router.scheduledDeploymentRestHandler.GetScheduledDeployment).Methods("GET")
+	scheduledDeploymentRouter.Path("/{id}/cancel").
+		HandlerFunc(router.scheduledDeploymentRestHandler.CancelScheduledDeployment).Methods("PUT")
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restHandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/gitProvider/read"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CommitStatusRestHandler interface {
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	SaveConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+}
+
+type CommitStatusRestHandlerImpl struct {
+	logger                 *zap.SugaredLogger
+	commitStatusService    commitStatus.CommitStatusService
+	gitProviderReadService read.GitProviderReadService
+	userService            user.UserService
+	enforcer               casbin.Enforcer
+	validator              *validator.Validate
+}
+
+func NewCommitStatusRestHandlerImpl(logger *zap.SugaredLogger, commitStatusService commitStatus.CommitStatusService,
+	gitProviderReadService read.GitProviderReadService, userService user.UserService,
+	enforcer casbin.Enforcer, validator *validator.Validate) *CommitStatusRestHandlerImpl {
+	return &CommitStatusRestHandlerImpl{
+		logger:                 logger,
+		commitStatusService:    commitStatusService,
+		gitProviderReadService: gitProviderReadService,
+		userService:            userService,
+		enforcer:               enforcer,
+		validator:              validator,
+	}
+}
+
+func (impl CommitStatusRestHandlerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	gitProviderId, _, ok := impl.authorizeGitProvider(w, r, casbin.ActionGet)
+	if !ok {
+		return
+	}
+	resp, err := impl.commitStatusService.GetConfig(gitProviderId)
+	if err != nil {
+		impl.logger.Errorw("service err, GetConfig", "gitProviderId", gitProviderId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl CommitStatusRestHandlerImpl) SaveConfig(w http.ResponseWriter, r *http.Request) {
+	gitProviderId, userId, ok := impl.authorizeGitProvider(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request commitStatusBean.CommitStatusConfigDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, SaveConfig", "err", err, "gitProviderId", gitProviderId)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.GitProviderId = gitProviderId
+	request.UserId = userId
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, SaveConfig", "err", err, "gitProviderId", gitProviderId)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := impl.commitStatusService.SaveConfig(&request)
+	if err != nil {
+		impl.logger.Errorw("service err, SaveConfig", "gitProviderId", gitProviderId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl CommitStatusRestHandlerImpl) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	gitProviderId, userId, ok := impl.authorizeGitProvider(w, r, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	err := impl.commitStatusService.DeleteConfig(gitProviderId, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, DeleteConfig", "gitProviderId", gitProviderId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, true, http.StatusOK)
+}
+
+// authorizeGitProvider checks the access of the logged in user on the git provider in the path, commit status
+// reporting shares the rbac of the git account whose credentials are used to publish the statuses
+func (impl CommitStatusRestHandlerImpl) authorizeGitProvider(w http.ResponseWriter, r *http.Request, action string) (int, int32, bool) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.WriteJsonResp(w, err, "Unauthorized User", http.StatusUnauthorized)
+		return 0, 0, false
+	}
+	id := mux.Vars(r)["id"]
+	gitProviderId, err := strconv.Atoi(id)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return 0, 0, false
+	}
+	provider, err := impl.gitProviderReadService.FetchOneGitProvider(id)
+	if util.IsErrNoRows(err) {
+		common.WriteJsonResp(w, err, nil, http.StatusNotFound)
+		return 0, 0, false
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching git provider", "gitProviderId", gitProviderId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return 0, 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceGit, action, provider.Name); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return 0, 0, false
+	}
+	return gitProviderId, userId, true
+}
@@ -19,7 +19,11 @@ package restHandler
 import (
 	"encoding/json"
 	util3 "github.com/devtron-labs/devtron/api/util"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
+	signatureBean "github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
 	"github.com/devtron-labs/devtron/pkg/workflow/dag"
+	util2 "github.com/devtron-labs/devtron/util"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -37,22 +41,25 @@ type ExternalCiRestHandler interface {
 }
 
 type ExternalCiRestHandlerImpl struct {
-	logger              *zap.SugaredLogger
-	validator           *validator.Validate
-	userService         user.UserService
-	enforcer            casbin.Enforcer
-	workflowDagExecutor dag.WorkflowDagExecutor
+	logger                  *zap.SugaredLogger
+	validator               *validator.Validate
+	userService             user.UserService
+	enforcer                casbin.Enforcer
+	workflowDagExecutor     dag.WorkflowDagExecutor
+	webhookSignatureService signature.WebhookSignatureService
 }
 
 func NewExternalCiRestHandlerImpl(logger *zap.SugaredLogger, validator *validator.Validate,
 	userService user.UserService, enforcer casbin.Enforcer,
-	workflowDagExecutor dag.WorkflowDagExecutor) *ExternalCiRestHandlerImpl {
+	workflowDagExecutor dag.WorkflowDagExecutor,
+	webhookSignatureService signature.WebhookSignatureService) *ExternalCiRestHandlerImpl {
 	return &ExternalCiRestHandlerImpl{
-		logger:              logger,
-		validator:           validator,
-		userService:         userService,
-		enforcer:            enforcer,
-		workflowDagExecutor: workflowDagExecutor,
+		logger:                  logger,
+		validator:               validator,
+		userService:             userService,
+		enforcer:                enforcer,
+		workflowDagExecutor:     workflowDagExecutor,
+		webhookSignatureService: webhookSignatureService,
 	}
 }
 
@@ -70,9 +77,27 @@ func (impl ExternalCiRestHandlerImpl) HandleExternalCiWebhook(w http.ResponseWri
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
 		return
 	}
-	decoder := json.NewDecoder(r.Body)
+	requestBodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		impl.logger.Errorw("request err, HandleExternalCiWebhook", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// the payload signature is verified in addition to the api token when signature verification is configured
+	_, err = impl.webhookSignatureService.VerifyDelivery(&signatureBean.DeliveryRequest{
+		WebhookType: signatureBean.WebhookTypeExternalCi,
+		WebhookId:   externalCiId,
+		Header:      r.Header,
+		Body:        requestBodyBytes,
+		SourceIp:    util2.GetClientIP(r),
+	})
+	if err != nil {
+		impl.logger.Errorw("webhook delivery verification failed, HandleExternalCiWebhook", "externalCiId", externalCiId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
 	var req pipeline.ExternalCiWebhookDto
-	err = decoder.Decode(&req)
+	err = json.Unmarshal(requestBodyBytes, &req)
 	if err != nil {
 		impl.logger.Errorw("request err, HandleExternalCiWebhook", "err", err, "payload", req)
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restHandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning"
+	imageSigningBean "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ImageSignatureRestHandler interface {
+	GetTrustedIdentities(w http.ResponseWriter, r *http.Request)
+	CreateTrustedIdentity(w http.ResponseWriter, r *http.Request)
+	UpdateTrustedIdentity(w http.ResponseWriter, r *http.Request)
+	DeleteTrustedIdentity(w http.ResponseWriter, r *http.Request)
+	GetPolicies(w http.ResponseWriter, r *http.Request)
+	CreatePolicy(w http.ResponseWriter, r *http.Request)
+	UpdatePolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+}
+
+type ImageSignatureRestHandlerImpl struct {
+	logger                *zap.SugaredLogger
+	imageSignatureService imageSigning.ImageSignatureService
+	userService           user.UserService
+	enforcer              casbin.Enforcer
+	validator             *validator.Validate
+}
+
+func NewImageSignatureRestHandlerImpl(logger *zap.SugaredLogger, imageSignatureService imageSigning.ImageSignatureService,
+	userService user.UserService, enforcer casbin.Enforcer, validator *validator.Validate) *ImageSignatureRestHandlerImpl {
+	return &ImageSignatureRestHandlerImpl{
+		logger:                logger,
+		imageSignatureService: imageSignatureService,
+		userService:           userService,
+		enforcer:              enforcer,
+		validator:             validator,
+	}
+}
+
+// trusted identities and policies are global config limited to super admins, as they decide what can be deployed
+// across all the environments
+
+func (impl ImageSignatureRestHandlerImpl) GetTrustedIdentities(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := impl.imageSignatureService.GetAllTrustedIdentities()
+	if err != nil {
+		impl.logger.Errorw("service err, GetTrustedIdentities", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl ImageSignatureRestHandlerImpl) CreateTrustedIdentity(w http.ResponseWriter, r *http.Request) {
+	impl.saveTrustedIdentity(w, r, impl.imageSignatureService.CreateTrustedIdentity)
+}
+
+func (impl ImageSignatureRestHandlerImpl) UpdateTrustedIdentity(w http.ResponseWriter, r *http.Request) {
+	impl.saveTrustedIdentity(w, r, impl.imageSignatureService.UpdateTrustedIdentity)
+}
+
+func (impl ImageSignatureRestHandlerImpl) saveTrustedIdentity(w http.ResponseWriter, r *http.Request,
+	save func(request *imageSigningBean.TrustedIdentityDto) (*imageSigningBean.TrustedIdentityDto, error)) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request imageSigningBean.TrustedIdentityDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, saveTrustedIdentity", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, saveTrustedIdentity", "err", err, "name", request.Name)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	resp, err := save(&request)
+	if err != nil {
+		impl.logger.Errorw("service err, saveTrustedIdentity", "err", err, "name", request.Name)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl ImageSignatureRestHandlerImpl) DeleteTrustedIdentity(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = impl.imageSignatureService.DeleteTrustedIdentity(id, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, DeleteTrustedIdentity", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (impl ImageSignatureRestHandlerImpl) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := impl.imageSignatureService.GetAllPolicies()
+	if err != nil {
+		impl.logger.Errorw("service err, GetPolicies", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl ImageSignatureRestHandlerImpl) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	impl.savePolicy(w, r, impl.imageSignatureService.CreatePolicy)
+}
+
+func (impl ImageSignatureRestHandlerImpl) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	impl.savePolicy(w, r, impl.imageSignatureService.UpdatePolicy)
+}
+
+func (impl ImageSignatureRestHandlerImpl) savePolicy(w http.ResponseWriter, r *http.Request,
+	save func(request *imageSigningBean.SignaturePolicyDto) (*imageSigningBean.SignaturePolicyDto, error)) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request imageSigningBean.SignaturePolicyDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, savePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, savePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	impl.logger.Infow("request payload, savePolicy", "payload", request)
+	resp, err := save(&request)
+	if err != nil {
+		impl.logger.Errorw("service err, savePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl ImageSignatureRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = impl.imageSignatureService.DeletePolicy(id, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, DeletePolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
@@ -254,6 +254,19 @@ func (handler *OverviewRestHandlerImpl) GetDoraMetrics(w http.ResponseWriter, r
 		PrevTo:           prevTimeWindow.To,
 	}
 
+	// optional filters to scope metrics to apps, environments or projects
+	for paramName, ids := range map[string]*[]int{"appIds": &doraRequest.AppIds, "envIds": &doraRequest.EnvIds, "teamIds": &doraRequest.TeamIds} {
+		if len(r.URL.Query().Get(paramName)) == 0 {
+			continue
+		}
+		*ids, err = common.ExtractIntArrayQueryParam(w, r, paramName)
+		if err != nil {
+			handler.logger.Errorw("error in parsing query param", "param", paramName, "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+
 	if err := handler.validator.Struct(doraRequest); err != nil {
 		handler.logger.Errorw("validation error", "err", err)
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
@@ -22,11 +22,11 @@ import (
 	"net/http"
 
 	"github.com/devtron-labs/devtron/api/restHandler/common"
-	"github.com/devtron-labs/devtron/client/lens"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/pkg/app"
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/overview/bean"
 	"github.com/devtron-labs/devtron/pkg/team"
 	"github.com/devtron-labs/devtron/util/rbac"
 	"github.com/gorilla/schema"
@@ -151,7 +151,7 @@ func (impl *ReleaseMetricsRestHandlerImpl) ResetDataForAllAppEnvironment(w http.
 
 func (impl *ReleaseMetricsRestHandlerImpl) GetDeploymentMetrics(w http.ResponseWriter, r *http.Request) {
 	//decoder := json.NewDecoder(r.Body)
-	metricRequest := &lens.MetricRequest{}
+	metricRequest := &bean.DeploymentMetricsRequest{}
 	decoder := schema.NewDecoder()
 	err := decoder.Decode(metricRequest, r.URL.Query())
 	if err != nil {
@@ -169,16 +169,11 @@ func (impl *ReleaseMetricsRestHandlerImpl) GetDeploymentMetrics(w http.ResponseW
 		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
 		return
 	}
-	resBody, resCode, err := impl.ReleaseDataService.GetDeploymentMetrics(metricRequest)
+	metrics, err := impl.ReleaseDataService.GetDeploymentMetrics(metricRequest)
 	if err != nil {
 		impl.logger.Errorw("service err, GetDeploymentMetrics", "err", err, "payload", metricRequest)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(int(*resCode))
-	_, err = w.Write(resBody)
-	if err != nil {
-		impl.logger.Errorw("service err, GetDeploymentMetrics", "err", err, "resCode", resCode)
-	}
+	common.WriteJsonResp(w, nil, metrics, http.StatusOK)
 }
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restHandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom"
+	sbomBean "github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+)
+
+type SbomRestHandler interface {
+	GetArtifactSbom(w http.ResponseWriter, r *http.Request)
+	DownloadArtifactSbom(w http.ResponseWriter, r *http.Request)
+	SearchDeployedPackages(w http.ResponseWriter, r *http.Request)
+}
+
+type SbomRestHandlerImpl struct {
+	logger       *zap.SugaredLogger
+	sbomService  sbom.SbomService
+	userService  user.UserService
+	enforcer     casbin.Enforcer
+	enforcerUtil rbac.EnforcerUtil
+}
+
+func NewSbomRestHandlerImpl(logger *zap.SugaredLogger, sbomService sbom.SbomService, userService user.UserService,
+	enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil) *SbomRestHandlerImpl {
+	return &SbomRestHandlerImpl{
+		logger:       logger,
+		sbomService:  sbomService,
+		userService:  userService,
+		enforcer:     enforcer,
+		enforcerUtil: enforcerUtil,
+	}
+}
+
+func (impl SbomRestHandlerImpl) GetArtifactSbom(w http.ResponseWriter, r *http.Request) {
+	artifactId, ok := impl.authorizeArtifactRead(w, r)
+	if !ok {
+		return
+	}
+	includePackages := r.URL.Query().Get("packages") != "false"
+	resp, err := impl.sbomService.GetArtifactSbom(artifactId, includePackages)
+	if err != nil {
+		impl.logger.Errorw("service err, GetArtifactSbom", "artifactId", artifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl SbomRestHandlerImpl) DownloadArtifactSbom(w http.ResponseWriter, r *http.Request) {
+	artifactId, ok := impl.authorizeArtifactRead(w, r)
+	if !ok {
+		return
+	}
+	document, err := impl.sbomService.GetSbomDocument(artifactId)
+	if err != nil {
+		impl.logger.Errorw("service err, DownloadArtifactSbom", "artifactId", artifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	contentType := "application/vnd.cyclonedx+json"
+	if document.Format == sbomBean.SbomFormatSpdx {
+		contentType = "application/spdx+json"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	w.Header().Set("Content-Type", contentType)
+	_, err = w.Write(document.Content)
+	if err != nil {
+		impl.logger.Errorw("error in writing sbom document, DownloadArtifactSbom", "artifactId", artifactId, "err", err)
+	}
+}
+
+// SearchDeployedPackages answers which deployed artifacts contain a package, results are limited
+// to the apps and environments the user has access to
+func (impl SbomRestHandlerImpl) SearchDeployedPackages(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request sbomBean.PackageSearchRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, SearchDeployedPackages", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	var filter sbomBean.DeploymentFilter
+	if isSuperAdmin := common.IsSuperAdmin(r, impl.enforcer, casbin.ActionGet); !isSuperAdmin {
+		filter = func(deployments []*sbomBean.DeployedPackageDto) []*sbomBean.DeployedPackageDto {
+			return impl.filterAuthorizedDeployments(token, deployments)
+		}
+	}
+	resp, err := impl.sbomService.SearchDeployedPackages(&request, filter)
+	if err != nil {
+		impl.logger.Errorw("service err, SearchDeployedPackages", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl SbomRestHandlerImpl) filterAuthorizedDeployments(token string, deployments []*sbomBean.DeployedPackageDto) []*sbomBean.DeployedPackageDto {
+	appObjects := make([]string, 0, len(deployments))
+	envObjects := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		appObjects = append(appObjects, getAppRbacObject(deployment))
+		envObjects = append(envObjects, getEnvRbacObject(deployment))
+	}
+	appResults := impl.enforcer.EnforceInBatch(token, casbin.ResourceApplications, casbin.ActionGet, appObjects)
+	envResults := impl.enforcer.EnforceInBatch(token, casbin.ResourceEnvironment, casbin.ActionGet, envObjects)
+	authorized := make([]*sbomBean.DeployedPackageDto, 0, len(deployments))
+	for _, deployment := range deployments {
+		if appResults[getAppRbacObject(deployment)] && envResults[getEnvRbacObject(deployment)] {
+			authorized = append(authorized, deployment)
+		}
+	}
+	return authorized
+}
+
+func getAppRbacObject(deployment *sbomBean.DeployedPackageDto) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", deployment.TeamName, deployment.AppName))
+}
+
+func getEnvRbacObject(deployment *sbomBean.DeployedPackageDto) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", deployment.EnvironmentIdentifier, deployment.AppName))
+}
+
+func (impl SbomRestHandlerImpl) authorizeArtifactRead(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, false
+	}
+	artifactId, err := common.ExtractIntPathParamWithContext(w, r, "artifactId")
+	if err != nil {
+		return 0, false
+	}
+	appId, err := impl.sbomService.GetAppIdByArtifactId(artifactId)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	resourceName := impl.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, resourceName); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return artifactId, true
+}
@@ -20,8 +20,12 @@ import (
 	bean3 "github.com/devtron-labs/devtron/pkg/build/git/gitHost/bean"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitHost/read"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitWebhook"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out/bean"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
+	signatureBean "github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+	util2 "github.com/devtron-labs/devtron/util"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -44,12 +48,16 @@ type WebhookEventHandlerImpl struct {
 	webhookSecretValidator        gitWebhook.WebhookSecretValidator
 	webhookEventDataConfig        pipeline.WebhookEventDataConfig
 	ciPipelineEventPublishService out.CIPipelineEventPublishService
+	webhookSignatureService       signature.WebhookSignatureService
+	previewEnvironmentService     previewEnvironment.PreviewEnvironmentService
 }
 
 func NewWebhookEventHandlerImpl(logger *zap.SugaredLogger, eventClient client.EventClient,
 	webhookSecretValidator gitWebhook.WebhookSecretValidator, webhookEventDataConfig pipeline.WebhookEventDataConfig,
 	ciPipelineEventPublishService out.CIPipelineEventPublishService,
-	gitHostReadService read.GitHostReadService) *WebhookEventHandlerImpl {
+	gitHostReadService read.GitHostReadService,
+	webhookSignatureService signature.WebhookSignatureService,
+	previewEnvironmentService previewEnvironment.PreviewEnvironmentService) *WebhookEventHandlerImpl {
 	return &WebhookEventHandlerImpl{
 		logger:                        logger,
 		eventClient:                   eventClient,
@@ -57,6 +65,8 @@ func NewWebhookEventHandlerImpl(logger *zap.SugaredLogger, eventClient client.Ev
 		webhookEventDataConfig:        webhookEventDataConfig,
 		ciPipelineEventPublishService: ciPipelineEventPublishService,
 		gitHostReadService:            gitHostReadService,
+		webhookSignatureService:       webhookSignatureService,
+		previewEnvironmentService:     previewEnvironmentService,
 	}
 }
 
@@ -105,18 +115,37 @@ func (impl WebhookEventHandlerImpl) OnWebhookEvent(w http.ResponseWriter, r *htt
 		return
 	}
 
-	isValidSig := impl.webhookSecretValidator.ValidateSecret(r, secretFromRequest, requestBodyBytes, gitHost)
-	impl.logger.Debug("Secret validation result: " + strconv.FormatBool(isValidSig))
-	if !isValidSig {
-		impl.logger.Error("Signature mismatch")
-		common.WriteJsonResp(w, err, nil, http.StatusUnauthorized)
+	// deliveries of git hosts with signature verification configured are verified against the webhook secrets,
+	// the git host secret is validated otherwise
+	var eventType string
+	if len(gitHost.EventTypeHeader) > 0 {
+		eventType = r.Header.Get(gitHost.EventTypeHeader)
+	}
+	verified, err := impl.webhookSignatureService.VerifyDelivery(&signatureBean.DeliveryRequest{
+		WebhookType: signatureBean.WebhookTypeGitHost,
+		WebhookId:   gitHostId,
+		Header:      r.Header,
+		Body:        requestBodyBytes,
+		EventType:   eventType,
+		SourceIp:    util2.GetClientIP(r),
+	})
+	if err != nil {
+		impl.logger.Errorw("webhook delivery verification failed", "gitHostId", gitHostId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+	if !verified {
+		isValidSig := impl.webhookSecretValidator.ValidateSecret(r, secretFromRequest, requestBodyBytes, gitHost)
+		impl.logger.Debug("Secret validation result: " + strconv.FormatBool(isValidSig))
+		if !isValidSig {
+			impl.logger.Error("Signature mismatch")
+			common.WriteJsonResp(w, err, nil, http.StatusUnauthorized)
+			return
+		}
+	}
 
 	// validate event type if configured
-	var eventType string
 	if len(gitHost.EventTypeHeader) > 0 {
-		eventType = r.Header.Get(gitHost.EventTypeHeader)
 		impl.logger.Debug("eventType: " + eventType)
 		if len(eventType) == 0 {
 			impl.logger.Errorw("Event type not known ", "eventType", eventType)
@@ -148,4 +177,7 @@ func (impl WebhookEventHandlerImpl) OnWebhookEvent(w http.ResponseWriter, r *htt
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
+
+	// previews of closed pull requests are torn down
+	impl.previewEnvironmentService.HandlePullRequestEventAsync(eventType, requestBodyBytes)
 }
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package restHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
+	signatureBean "github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type WebhookSignatureRestHandler interface {
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	CreateConfig(w http.ResponseWriter, r *http.Request)
+	UpdateConfig(w http.ResponseWriter, r *http.Request)
+	DeleteConfig(w http.ResponseWriter, r *http.Request)
+	RotateSecret(w http.ResponseWriter, r *http.Request)
+	GetDeliveries(w http.ResponseWriter, r *http.Request)
+}
+
+type WebhookSignatureRestHandlerImpl struct {
+	logger                  *zap.SugaredLogger
+	webhookSignatureService signature.WebhookSignatureService
+	userService             user.UserService
+	enforcer                casbin.Enforcer
+	validator               *validator.Validate
+}
+
+func NewWebhookSignatureRestHandlerImpl(logger *zap.SugaredLogger, webhookSignatureService signature.WebhookSignatureService,
+	userService user.UserService, enforcer casbin.Enforcer, validator *validator.Validate) *WebhookSignatureRestHandlerImpl {
+	return &WebhookSignatureRestHandlerImpl{
+		logger:                  logger,
+		webhookSignatureService: webhookSignatureService,
+		userService:             userService,
+		enforcer:                enforcer,
+		validator:               validator,
+	}
+}
+
+// webhook secrets authenticate the deliveries of git hosts and external ci pipelines, they are limited to super admins
+// like the git host config itself
+
+func (impl WebhookSignatureRestHandlerImpl) GetConfig(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	webhookType, webhookId, err := extractWebhookQueryParams(r)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := impl.webhookSignatureService.GetConfig(webhookType, webhookId)
+	if err != nil {
+		impl.logger.Errorw("service err, GetConfig", "webhookType", webhookType, "webhookId", webhookId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl WebhookSignatureRestHandlerImpl) CreateConfig(w http.ResponseWriter, r *http.Request) {
+	request, ok := impl.decodeConfigRequest(w, r)
+	if !ok {
+		return
+	}
+	resp, err := impl.webhookSignatureService.CreateConfig(request)
+	if err != nil {
+		impl.logger.Errorw("service err, CreateConfig", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl WebhookSignatureRestHandlerImpl) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	request, ok := impl.decodeConfigRequest(w, r)
+	if !ok {
+		return
+	}
+	resp, err := impl.webhookSignatureService.UpdateConfig(request)
+	if err != nil {
+		impl.logger.Errorw("service err, UpdateConfig", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl WebhookSignatureRestHandlerImpl) decodeConfigRequest(w http.ResponseWriter, r *http.Request) (*signatureBean.WebhookSignatureConfigDto, bool) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return nil, false
+	}
+	var request signatureBean.WebhookSignatureConfigDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, decodeConfigRequest", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, decodeConfigRequest", "err", err, "webhookType", request.WebhookType, "webhookId", request.WebhookId)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	request.UserId = userId
+	return &request, true
+}
+
+func (impl WebhookSignatureRestHandlerImpl) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	webhookType, webhookId, err := extractWebhookQueryParams(r)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = impl.webhookSignatureService.DeleteConfig(webhookType, webhookId, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, DeleteConfig", "webhookType", webhookType, "webhookId", webhookId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (impl WebhookSignatureRestHandlerImpl) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request signatureBean.RotateSecretRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, RotateSecret", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, RotateSecret", "err", err, "webhookType", request.WebhookType, "webhookId", request.WebhookId)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	resp, err := impl.webhookSignatureService.RotateSecret(&request)
+	if err != nil {
+		impl.logger.Errorw("service err, RotateSecret", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (impl WebhookSignatureRestHandlerImpl) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, impl.userService, impl.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	query := r.URL.Query()
+	filter := &signatureBean.DeliveryFilter{
+		WebhookType: signatureBean.WebhookType(query.Get("webhookType")),
+		Status:      signatureBean.DeliveryStatus(query.Get("status")),
+	}
+	var err error
+	if filter.WebhookId, err = common.ExtractIntQueryParam(w, r, "webhookId", 0); err != nil {
+		return
+	}
+	if filter.Offset, err = common.ExtractIntQueryParam(w, r, "offset", 0); err != nil {
+		return
+	}
+	if filter.Size, err = common.ExtractIntQueryParam(w, r, "size", 0); err != nil {
+		return
+	}
+	resp, err := impl.webhookSignatureService.GetDeliveries(filter)
+	if err != nil {
+		impl.logger.Errorw("service err, GetDeliveries", "filter", filter, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func extractWebhookQueryParams(r *http.Request) (signatureBean.WebhookType, int, error) {
+	webhookType := signatureBean.WebhookType(r.URL.Query().Get("webhookType"))
+	if webhookType != signatureBean.WebhookTypeGitHost && webhookType != signatureBean.WebhookTypeExternalCi {
+		return "", 0, errors.New("webhookType must be GIT_HOST or EXTERNAL_CI")
+	}
+	webhookId, err := strconv.Atoi(r.URL.Query().Get("webhookId"))
+	if err != nil {
+		return "", 0, errors.New("invalid webhookId")
+	}
+	return webhookType, webhookId, nil
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildQueue
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CiBuildQueueRestHandler interface {
+	GetQueue(w http.ResponseWriter, r *http.Request)
+	GetQueueByAppId(w http.ResponseWriter, r *http.Request)
+	GetQueuePosition(w http.ResponseWriter, r *http.Request)
+	GetLimits(w http.ResponseWriter, r *http.Request)
+	SaveLimit(w http.ResponseWriter, r *http.Request)
+	DeleteLimit(w http.ResponseWriter, r *http.Request)
+}
+
+type CiBuildQueueRestHandlerImpl struct {
+	logger              *zap.SugaredLogger
+	userAuthService     user.UserService
+	enforcer            casbin.Enforcer
+	enforcerUtil        rbac.EnforcerUtil
+	validator           *validator.Validate
+	ciBuildQueueService buildQueue.CiBuildQueueService
+}
+
+func NewCiBuildQueueRestHandlerImpl(logger *zap.SugaredLogger, userAuthService user.UserService,
+	enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	ciBuildQueueService buildQueue.CiBuildQueueService) *CiBuildQueueRestHandlerImpl {
+	return &CiBuildQueueRestHandlerImpl{
+		logger:              logger,
+		userAuthService:     userAuthService,
+		enforcer:            enforcer,
+		enforcerUtil:        enforcerUtil,
+		validator:           validator,
+		ciBuildQueueService: ciBuildQueueService,
+	}
+}
+
+// GetQueue returns the whole build queue across apps, only for super admins
+func (handler *CiBuildQueueRestHandlerImpl) GetQueue(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.ciBuildQueueService.GetQueuedBuilds(nil)
+	if err != nil {
+		handler.logger.Errorw("service err, GetQueue", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) GetQueueByAppId(w http.ResponseWriter, r *http.Request) {
+	appId, ok := handler.authorizeAppRead(w, r)
+	if !ok {
+		return
+	}
+	filter := &bean.QueueFilter{AppId: appId}
+	if ciPipelineIdParam := r.URL.Query().Get("ciPipelineId"); len(ciPipelineIdParam) > 0 {
+		ciPipelineId, err := strconv.Atoi(ciPipelineIdParam)
+		if err != nil {
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+		filter.CiPipelineId = ciPipelineId
+	}
+	resp, err := handler.ciBuildQueueService.GetQueuedBuilds(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetQueueByAppId", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) GetQueuePosition(w http.ResponseWriter, r *http.Request) {
+	appId, ok := handler.authorizeAppRead(w, r)
+	if !ok {
+		return
+	}
+	ciWorkflowId, err := common.ExtractIntPathParamWithContext(w, r, "ciWorkflowId")
+	if err != nil {
+		return
+	}
+	resp, err := handler.ciBuildQueueService.GetQueuedBuildByCiWorkflowId(ciWorkflowId)
+	if err == pg.ErrNoRows {
+		common.WriteJsonResp(w, errors.New("build was not queued"), nil, http.StatusNotFound)
+		return
+	} else if err != nil {
+		handler.logger.Errorw("service err, GetQueuePosition", "ciWorkflowId", ciWorkflowId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if resp.AppId != appId {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) GetLimits(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.ciBuildQueueService.GetLimits()
+	if err != nil {
+		handler.logger.Errorw("service err, GetLimits", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) SaveLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.BuildQueueLimitDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveLimit", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveLimit", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// limits govern builds of all the apps, so they are managed by super admins
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	request.UserId = userId
+	handler.logger.Infow("request payload, SaveLimit", "payload", request)
+	resp, err := handler.ciBuildQueueService.SaveLimit(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveLimit", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) DeleteLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	if ok := common.EnforceSuperAdmin(w, r, handler.enforcer, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.ciBuildQueueService.DeleteLimit(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteLimit", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (handler *CiBuildQueueRestHandlerImpl) authorizeAppRead(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, false
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return 0, false
+	}
+	return appId, true
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type ArtifactRetentionRestHandler interface {
+	GetPolicies(w http.ResponseWriter, r *http.Request)
+	SavePolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	CreateDryRun(w http.ResponseWriter, r *http.Request)
+	GetRuns(w http.ResponseWriter, r *http.Request)
+	GetRunReport(w http.ResponseWriter, r *http.Request)
+	ExecuteRun(w http.ResponseWriter, r *http.Request)
+}
+
+type ArtifactRetentionRestHandlerImpl struct {
+	logger                   *zap.SugaredLogger
+	userAuthService          user.UserService
+	enforcer                 casbin.Enforcer
+	validator                *validator.Validate
+	artifactRetentionService retention.ArtifactRetentionService
+}
+
+func NewArtifactRetentionRestHandlerImpl(logger *zap.SugaredLogger, userAuthService user.UserService,
+	enforcer casbin.Enforcer, validator *validator.Validate,
+	artifactRetentionService retention.ArtifactRetentionService) *ArtifactRetentionRestHandlerImpl {
+	return &ArtifactRetentionRestHandlerImpl{
+		logger:                   logger,
+		userAuthService:          userAuthService,
+		enforcer:                 enforcer,
+		validator:                validator,
+		artifactRetentionService: artifactRetentionService,
+	}
+}
+
+// all the apis are limited to super admins, as the policies delete artifacts of apps across projects
+
+func (handler *ArtifactRetentionRestHandlerImpl) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.artifactRetentionService.GetPolicies()
+	if err != nil {
+		handler.logger.Errorw("service err, GetPolicies", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) SavePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	var request bean.RetentionPolicyDto
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	handler.logger.Infow("request payload, SavePolicy", "payload", request)
+	resp, err := handler.artifactRetentionService.SavePolicy(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SavePolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = handler.artifactRetentionService.DeletePolicy(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeletePolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) CreateDryRun(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionUpdate)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.artifactRetentionService.CreateDryRun(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateDryRun", "policyId", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) GetRuns(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	policyId, err := strconv.Atoi(r.URL.Query().Get("policyId"))
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	resp, err := handler.artifactRetentionService.GetRuns(policyId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRuns", "policyId", policyId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) GetRunReport(w http.ResponseWriter, r *http.Request) {
+	if _, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionGet); !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.artifactRetentionService.GetRunReport(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetRunReport", "runId", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *ArtifactRetentionRestHandlerImpl) ExecuteRun(w http.ResponseWriter, r *http.Request) {
+	userId, ok := common.AuthorizeSuperAdmin(w, r, handler.userAuthService, handler.enforcer, casbin.ActionDelete)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	handler.logger.Infow("request, ExecuteRun", "runId", id, "userId", userId)
+	resp, err := handler.artifactRetentionService.ExecuteRun(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, ExecuteRun", "runId", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/build/schedule"
+	"github.com/devtron-labs/devtron/pkg/build/schedule/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CiPipelineScheduleRestHandler interface {
+	GetSchedule(w http.ResponseWriter, r *http.Request)
+	GetSchedulesByAppId(w http.ResponseWriter, r *http.Request)
+	SaveSchedule(w http.ResponseWriter, r *http.Request)
+	DeleteSchedule(w http.ResponseWriter, r *http.Request)
+}
+
+type CiPipelineScheduleRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	userAuthService           user.UserService
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+	ciPipelineScheduleService schedule.CiPipelineScheduleService
+}
+
+func NewCiPipelineScheduleRestHandlerImpl(logger *zap.SugaredLogger, userAuthService user.UserService,
+	enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	ciPipelineScheduleService schedule.CiPipelineScheduleService) *CiPipelineScheduleRestHandlerImpl {
+	return &CiPipelineScheduleRestHandlerImpl{
+		logger:                    logger,
+		userAuthService:           userAuthService,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+		ciPipelineScheduleService: ciPipelineScheduleService,
+	}
+}
+
+func (handler *CiPipelineScheduleRestHandlerImpl) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciPipelineId, err := common.ExtractIntPathParamWithContext(w, r, "ciPipelineId")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	resp, err := handler.ciPipelineScheduleService.GetScheduleByCiPipelineId(ciPipelineId)
+	if err == pg.ErrNoRows {
+		common.WriteJsonResp(w, nil, nil, http.StatusOK)
+		return
+	} else if err != nil {
+		handler.logger.Errorw("service err, GetSchedule", "ciPipelineId", ciPipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if resp.AppId != appId {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiPipelineScheduleRestHandlerImpl) GetSchedulesByAppId(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	resp, err := handler.ciPipelineScheduleService.GetSchedulesByAppId(appId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSchedulesByAppId", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiPipelineScheduleRestHandlerImpl) SaveSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.CiPipelineScheduleDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(request.AppId)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionUpdate); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	request.UserId = userId
+	handler.logger.Infow("request payload, SaveSchedule", "payload", request)
+	resp, err := handler.ciPipelineScheduleService.SaveSchedule(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveSchedule", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *CiPipelineScheduleRestHandlerImpl) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntPathParamWithContext(w, r, "appId")
+	if err != nil {
+		return
+	}
+	ciPipelineId, err := common.ExtractIntPathParamWithContext(w, r, "ciPipelineId")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionUpdate); !ok {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	existing, err := handler.ciPipelineScheduleService.GetScheduleByCiPipelineId(ciPipelineId)
+	if err == pg.ErrNoRows {
+		common.WriteJsonResp(w, nil, nil, http.StatusOK)
+		return
+	} else if err != nil {
+		handler.logger.Errorw("service err, DeleteSchedule", "ciPipelineId", ciPipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if existing.AppId != appId {
+		common.WriteJsonResp(w, nil, "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	err = handler.ciPipelineScheduleService.DeleteSchedule(ciPipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteSchedule", "ciPipelineId", ciPipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testReport
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/build/testReport"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type TestReportRestHandler interface {
+	GetTestSummary(w http.ResponseWriter, r *http.Request)
+	IngestTestReports(w http.ResponseWriter, r *http.Request)
+	GetTestGate(w http.ResponseWriter, r *http.Request)
+	SaveTestGate(w http.ResponseWriter, r *http.Request)
+	DeleteTestGate(w http.ResponseWriter, r *http.Request)
+	EvaluateTestGate(w http.ResponseWriter, r *http.Request)
+}
+
+type TestReportRestHandlerImpl struct {
+	logger            *zap.SugaredLogger
+	userAuthService   user.UserService
+	enforcer          casbin.Enforcer
+	enforcerUtil      rbac.EnforcerUtil
+	validator         *validator.Validate
+	testReportService testReport.TestReportService
+	testGateService   testReport.TestGateService
+}
+
+func NewTestReportRestHandlerImpl(logger *zap.SugaredLogger, userAuthService user.UserService,
+	enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, validator *validator.Validate,
+	testReportService testReport.TestReportService,
+	testGateService testReport.TestGateService) *TestReportRestHandlerImpl {
+	return &TestReportRestHandlerImpl{
+		logger:            logger,
+		userAuthService:   userAuthService,
+		enforcer:          enforcer,
+		enforcerUtil:      enforcerUtil,
+		validator:         validator,
+		testReportService: testReportService,
+		testGateService:   testGateService,
+	}
+}
+
+func (handler *TestReportRestHandlerImpl) GetTestSummary(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	ciPipelineId, err := common.ExtractIntPathParamWithContext(w, r, "ciPipelineId")
+	if err != nil {
+		return
+	}
+	workflowId, err := common.ExtractIntPathParamWithContext(w, r, "workflowId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizeCiPipeline(w, r, ciPipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.testReportService.GetTestSummary(ciPipelineId, workflowId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetTestSummary", "ciPipelineId", ciPipelineId, "workflowId", workflowId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TestReportRestHandlerImpl) IngestTestReports(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	ciPipelineId, err := common.ExtractIntPathParamWithContext(w, r, "ciPipelineId")
+	if err != nil {
+		return
+	}
+	workflowId, err := common.ExtractIntPathParamWithContext(w, r, "workflowId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizeCiPipeline(w, r, ciPipelineId, casbin.ActionTrigger); !ok {
+		return
+	}
+	resp, err := handler.testReportService.RefreshTestSummary(ciPipelineId, workflowId)
+	if err != nil {
+		handler.logger.Errorw("service err, IngestTestReports", "ciPipelineId", ciPipelineId, "workflowId", workflowId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TestReportRestHandlerImpl) GetTestGate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizeCdPipeline(w, r, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.testGateService.GetTestGate(pipelineId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetTestGate", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TestReportRestHandlerImpl) SaveTestGate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean.TestGateConfigDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, SaveTestGate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, SaveTestGate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	if ok := handler.authorizeCdPipeline(w, r, request.PipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	request.UserId = userId
+	handler.logger.Infow("request payload, SaveTestGate", "payload", request)
+	resp, err := handler.testGateService.SaveTestGate(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, SaveTestGate", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler *TestReportRestHandlerImpl) DeleteTestGate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizeCdPipeline(w, r, pipelineId, casbin.ActionUpdate); !ok {
+		return
+	}
+	err = handler.testGateService.DeleteTestGate(pipelineId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, DeleteTestGate", "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+func (handler *TestReportRestHandlerImpl) EvaluateTestGate(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	artifactId, err := common.ExtractIntPathParamWithContext(w, r, "artifactId")
+	if err != nil {
+		return
+	}
+	if ok := handler.authorizeCdPipeline(w, r, pipelineId, casbin.ActionGet); !ok {
+		return
+	}
+	resp, err := handler.testGateService.EvaluateTestGateForArtifactId(pipelineId, artifactId)
+	if err != nil {
+		handler.logger.Errorw("service err, EvaluateTestGate", "pipelineId", pipelineId, "artifactId", artifactId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+// authorizeCiPipeline checks the action on the app or job of the ci pipeline,
+// the response is already written when it returns false
+func (handler *TestReportRestHandlerImpl) authorizeCiPipeline(w http.ResponseWriter, r *http.Request, ciPipelineId int, action string) bool {
+	object, ok := handler.enforcerUtil.GetAppObjectByCiPipelineIds([]int{ciPipelineId})[ciPipelineId]
+	if !ok {
+		common.WriteJsonResp(w, errors.New("ci pipeline not found"), nil, http.StatusNotFound)
+		return false
+	}
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(r.Header.Get("token"), object, action); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeCdPipeline checks the action on the app of the cd pipeline,
+// the response is already written when it returns false
+func (handler *TestReportRestHandlerImpl) authorizeCdPipeline(w http.ResponseWriter, r *http.Request, pipelineId int, action string) bool {
+	objects, ok := handler.enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) != 2 {
+		common.WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+		return false
+	}
+	if ok := handler.enforcer.Enforce(r.Header.Get("token"), casbin.ResourceApplications, action, objects[0]); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
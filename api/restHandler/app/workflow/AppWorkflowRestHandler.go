@@ -35,6 +35,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
 	"github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/schedule"
 	"github.com/devtron-labs/devtron/pkg/chart"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	resourceGroup2 "github.com/devtron-labs/devtron/pkg/resourceGroup"
@@ -65,11 +66,13 @@ type AppWorkflowRestHandlerImpl struct {
 	appRepository      app.AppRepository
 	enforcerUtil       rbac.EnforcerUtil
 	chartService       chart.ChartService
+	ciScheduleService  schedule.CiPipelineScheduleService
 }
 
 func NewAppWorkflowRestHandlerImpl(Logger *zap.SugaredLogger, userAuthService user.UserService, appWorkflowService appWorkflow.AppWorkflowService,
 	teamService team.TeamService, enforcer casbin.Enforcer, pipelineBuilder pipeline.PipelineBuilder,
-	appRepository app.AppRepository, enforcerUtil rbac.EnforcerUtil, chartService chart.ChartService) *AppWorkflowRestHandlerImpl {
+	appRepository app.AppRepository, enforcerUtil rbac.EnforcerUtil, chartService chart.ChartService,
+	ciScheduleService schedule.CiPipelineScheduleService) *AppWorkflowRestHandlerImpl {
 	return &AppWorkflowRestHandlerImpl{
 		Logger:             Logger,
 		appWorkflowService: appWorkflowService,
@@ -80,6 +83,7 @@ func NewAppWorkflowRestHandlerImpl(Logger *zap.SugaredLogger, userAuthService us
 		appRepository:      appRepository,
 		enforcerUtil:       enforcerUtil,
 		chartService:       chartService,
+		ciScheduleService:  ciScheduleService,
 	}
 }
 
@@ -483,11 +487,19 @@ func (handler *AppWorkflowRestHandlerImpl) GetWorkflowsViewData(w http.ResponseW
 		}
 	}
 
+	ciSchedules, err := handler.ciScheduleService.GetSchedulesByAppId(appId)
+	if err != nil {
+		handler.Logger.Errorw("service err, GetSchedulesByAppId", "appId", appId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+
 	response := &bean2.TriggerViewWorkflowConfig{
 		Workflows:        appWorkflows,
 		CiConfig:         ciPipelineViewData,
 		CdPipelines:      cdPipelinesForApp,
 		ExternalCiConfig: externalCiData,
+		CiSchedules:      ciSchedules,
 	}
 
 	// filter based on envIds
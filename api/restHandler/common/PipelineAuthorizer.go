@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/util/rbac"
+)
+
+// AuthorizePipeline checks the action on the app of the cd pipeline, the response is already written when it returns false
+func AuthorizePipeline(w http.ResponseWriter, r *http.Request, enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil, pipelineId int, action string) bool {
+	objects, ok := enforcerUtil.GetAppAndEnvObjectByPipelineIds([]int{pipelineId})[pipelineId]
+	if !ok || len(objects) != 2 {
+		WriteJsonResp(w, errors.New("pipeline not found"), nil, http.StatusNotFound)
+		return false
+	}
+	token := r.Header.Get("token")
+	if ok := enforcer.Enforce(token, casbin.ResourceApplications, action, objects[0]); !ok {
+		WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
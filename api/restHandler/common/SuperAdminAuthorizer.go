@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+)
+
+// AuthorizeSuperAdmin resolves the logged in user and checks the action on the global resource,
+// the response is already written when it returns false
+func AuthorizeSuperAdmin(w http.ResponseWriter, r *http.Request, userService user.UserService, enforcer casbin.Enforcer, action string) (int32, bool) {
+	userId, err := userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		HandleUnauthorized(w, r)
+		return 0, false
+	}
+	if ok := EnforceSuperAdmin(w, r, enforcer, action); !ok {
+		return 0, false
+	}
+	return userId, true
+}
+
+// EnforceSuperAdmin checks the action on the global resource for a request whose user is already resolved,
+// the response is already written when it returns false
+func EnforceSuperAdmin(w http.ResponseWriter, r *http.Request, enforcer casbin.Enforcer, action string) bool {
+	if ok := IsSuperAdmin(r, enforcer, action); !ok {
+		WriteJsonResp(w, errors.New("unauthorized user"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// IsSuperAdmin checks the action on the global resource without writing a response, for the apis which serve
+// the other users a narrower result
+func IsSuperAdmin(r *http.Request, enforcer casbin.Enforcer, action string) bool {
+	token := r.Header.Get("token")
+	return enforcer.Enforce(token, casbin.ResourceGlobal, action, "*")
+}
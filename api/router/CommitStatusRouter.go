@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler"
+	"github.com/gorilla/mux"
+)
+
+type CommitStatusRouter interface {
+	InitCommitStatusRouter(gitRouter *mux.Router)
+}
+
+type CommitStatusRouterImpl struct {
+	commitStatusRestHandler restHandler.CommitStatusRestHandler
+}
+
+func NewCommitStatusRouterImpl(commitStatusRestHandler restHandler.CommitStatusRestHandler) *CommitStatusRouterImpl {
+	return &CommitStatusRouterImpl{commitStatusRestHandler: commitStatusRestHandler}
+}
+
+func (impl CommitStatusRouterImpl) InitCommitStatusRouter(gitRouter *mux.Router) {
+	gitRouter.Path("/provider/{id}/commit-status").
+		HandlerFunc(impl.commitStatusRestHandler.GetConfig).
+		Methods("GET")
+	gitRouter.Path("/provider/{id}/commit-status").
+		HandlerFunc(impl.commitStatusRestHandler.SaveConfig).
+		Methods("PUT")
+	gitRouter.Path("/provider/{id}/commit-status").
+		HandlerFunc(impl.commitStatusRestHandler.DeleteConfig).
+		Methods("DELETE")
+}
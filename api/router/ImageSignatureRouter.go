@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler"
+	"github.com/gorilla/mux"
+)
+
+type ImageSignatureRouter interface {
+	InitImageSignatureRouter(imageSignatureRouter *mux.Router)
+}
+
+type ImageSignatureRouterImpl struct {
+	imageSignatureRestHandler restHandler.ImageSignatureRestHandler
+}
+
+func NewImageSignatureRouterImpl(imageSignatureRestHandler restHandler.ImageSignatureRestHandler) *ImageSignatureRouterImpl {
+	return &ImageSignatureRouterImpl{imageSignatureRestHandler: imageSignatureRestHandler}
+}
+
+func (impl ImageSignatureRouterImpl) InitImageSignatureRouter(imageSignatureRouter *mux.Router) {
+	imageSignatureRouter.Path("/trusted-identity").HandlerFunc(impl.imageSignatureRestHandler.GetTrustedIdentities).Methods("GET")
+	imageSignatureRouter.Path("/trusted-identity").HandlerFunc(impl.imageSignatureRestHandler.CreateTrustedIdentity).Methods("POST")
+	imageSignatureRouter.Path("/trusted-identity").HandlerFunc(impl.imageSignatureRestHandler.UpdateTrustedIdentity).Methods("PUT")
+	imageSignatureRouter.Path("/trusted-identity/{id}").HandlerFunc(impl.imageSignatureRestHandler.DeleteTrustedIdentity).Methods("DELETE")
+	imageSignatureRouter.Path("/policy").HandlerFunc(impl.imageSignatureRestHandler.GetPolicies).Methods("GET")
+	imageSignatureRouter.Path("/policy").HandlerFunc(impl.imageSignatureRestHandler.CreatePolicy).Methods("POST")
+	imageSignatureRouter.Path("/policy").HandlerFunc(impl.imageSignatureRestHandler.UpdatePolicy).Methods("PUT")
+	imageSignatureRouter.Path("/policy/{id}").HandlerFunc(impl.imageSignatureRestHandler.DeletePolicy).Methods("DELETE")
+}
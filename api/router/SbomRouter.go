@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler"
+	"github.com/gorilla/mux"
+)
+
+type SbomRouter interface {
+	InitSbomRouter(sbomRouter *mux.Router)
+}
+
+type SbomRouterImpl struct {
+	sbomRestHandler restHandler.SbomRestHandler
+}
+
+func NewSbomRouterImpl(sbomRestHandler restHandler.SbomRestHandler) *SbomRouterImpl {
+	return &SbomRouterImpl{sbomRestHandler: sbomRestHandler}
+}
+
+func (impl SbomRouterImpl) InitSbomRouter(sbomRouter *mux.Router) {
+	sbomRouter.Path("/artifact/{artifactId}").HandlerFunc(impl.sbomRestHandler.GetArtifactSbom).Methods("GET")
+	sbomRouter.Path("/artifact/{artifactId}/download").HandlerFunc(impl.sbomRestHandler.DownloadArtifactSbom).Methods("GET")
+	sbomRouter.Path("/package/search").HandlerFunc(impl.sbomRestHandler.SearchDeployedPackages).Methods("POST")
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler"
+	"github.com/gorilla/mux"
+)
+
+type WebhookSignatureRouter interface {
+	InitWebhookSignatureRouter(webhookSignatureRouter *mux.Router)
+}
+
+type WebhookSignatureRouterImpl struct {
+	webhookSignatureRestHandler restHandler.WebhookSignatureRestHandler
+}
+
+func NewWebhookSignatureRouterImpl(webhookSignatureRestHandler restHandler.WebhookSignatureRestHandler) *WebhookSignatureRouterImpl {
+	return &WebhookSignatureRouterImpl{webhookSignatureRestHandler: webhookSignatureRestHandler}
+}
+
+func (impl WebhookSignatureRouterImpl) InitWebhookSignatureRouter(webhookSignatureRouter *mux.Router) {
+	webhookSignatureRouter.Path("/config").HandlerFunc(impl.webhookSignatureRestHandler.GetConfig).
+		Queries("webhookType", "{webhookType}", "webhookId", "{webhookId}").Methods("GET")
+	webhookSignatureRouter.Path("/config").HandlerFunc(impl.webhookSignatureRestHandler.CreateConfig).Methods("POST")
+	webhookSignatureRouter.Path("/config").HandlerFunc(impl.webhookSignatureRestHandler.UpdateConfig).Methods("PUT")
+	webhookSignatureRouter.Path("/config").HandlerFunc(impl.webhookSignatureRestHandler.DeleteConfig).
+		Queries("webhookType", "{webhookType}", "webhookId", "{webhookId}").Methods("DELETE")
+	webhookSignatureRouter.Path("/secret/rotate").HandlerFunc(impl.webhookSignatureRestHandler.RotateSecret).Methods("POST")
+	webhookSignatureRouter.Path("/delivery").HandlerFunc(impl.webhookSignatureRestHandler.GetDeliveries).Methods("GET")
+}
@@ -22,9 +22,13 @@ import (
 	"github.com/devtron-labs/devtron/api/router/app/appInfo"
 	appList2 "github.com/devtron-labs/devtron/api/router/app/appList"
 	pipeline2 "github.com/devtron-labs/devtron/api/router/app/pipeline"
+	"github.com/devtron-labs/devtron/api/router/app/pipeline/buildQueue"
 	"github.com/devtron-labs/devtron/api/router/app/pipeline/configure"
 	"github.com/devtron-labs/devtron/api/router/app/pipeline/history"
+	"github.com/devtron-labs/devtron/api/router/app/pipeline/retention"
+	"github.com/devtron-labs/devtron/api/router/app/pipeline/schedule"
 	"github.com/devtron-labs/devtron/api/router/app/pipeline/status"
+	"github.com/devtron-labs/devtron/api/router/app/pipeline/testReport"
 	"github.com/devtron-labs/devtron/api/router/app/pipeline/trigger"
 	"github.com/devtron-labs/devtron/api/router/app/workflow"
 	"github.com/gorilla/mux"
@@ -44,6 +48,10 @@ type AppRouterImpl struct {
 	pipelineStatusRouter         status.PipelineStatusRouter
 	appWorkflowRouter            workflow.AppWorkflowRouter
 	devtronAppAutoCompleteRouter pipeline2.DevtronAppAutoCompleteRouter
+	ciPipelineScheduleRouter     schedule.CiPipelineScheduleRouter
+	ciBuildQueueRouter           buildQueue.CiBuildQueueRouter
+	artifactRetentionRouter      retention.ArtifactRetentionRouter
+	testReportRouter             testReport.TestReportRouter
 
 	// TODO remove these dependencies after migration
 	appWorkflowRestHandler  workflow2.AppWorkflowRestHandler
@@ -60,6 +68,10 @@ func NewAppRouterImpl(appFilteringRouter appList2.AppFilteringRouter,
 	pipelineStatusRouter status.PipelineStatusRouter,
 	appWorkflowRouter workflow.AppWorkflowRouter,
 	devtronAppAutoCompleteRouter pipeline2.DevtronAppAutoCompleteRouter,
+	ciPipelineScheduleRouter schedule.CiPipelineScheduleRouter,
+	ciBuildQueueRouter buildQueue.CiBuildQueueRouter,
+	artifactRetentionRouter retention.ArtifactRetentionRouter,
+	testReportRouter testReport.TestReportRouter,
 	appWorkflowRestHandler workflow2.AppWorkflowRestHandler,
 	appListingRestHandler appList.AppListingRestHandler,
 	appFilteringRestHandler appList.AppFilteringRestHandler) *AppRouterImpl {
@@ -73,6 +85,10 @@ func NewAppRouterImpl(appFilteringRouter appList2.AppFilteringRouter,
 		pipelineStatusRouter:         pipelineStatusRouter,
 		appWorkflowRouter:            appWorkflowRouter,
 		devtronAppAutoCompleteRouter: devtronAppAutoCompleteRouter,
+		ciPipelineScheduleRouter:     ciPipelineScheduleRouter,
+		ciBuildQueueRouter:           ciBuildQueueRouter,
+		artifactRetentionRouter:      artifactRetentionRouter,
+		testReportRouter:             testReportRouter,
 		appWorkflowRestHandler:       appWorkflowRestHandler,
 		appListingRestHandler:        appListingRestHandler,
 		appFilteringRestHandler:      appFilteringRestHandler,
@@ -101,6 +117,18 @@ func (router AppRouterImpl) InitAppRouter(AppRouter *mux.Router) {
 	appWorkflowRouter := AppRouter.PathPrefix("/app-wf").Subrouter()
 	router.appWorkflowRouter.InitAppWorkflowRouter(appWorkflowRouter)
 
+	ciPipelineScheduleRouter := AppRouter.PathPrefix("/ci-pipeline-schedule").Subrouter()
+	router.ciPipelineScheduleRouter.InitCiPipelineScheduleRouter(ciPipelineScheduleRouter)
+
+	ciBuildQueueRouter := AppRouter.PathPrefix("/ci-build-queue").Subrouter()
+	router.ciBuildQueueRouter.InitCiBuildQueueRouter(ciBuildQueueRouter)
+
+	artifactRetentionRouter := AppRouter.PathPrefix("/artifact-retention").Subrouter()
+	router.artifactRetentionRouter.InitArtifactRetentionRouter(artifactRetentionRouter)
+
+	testReportRouter := AppRouter.PathPrefix("/test-report").Subrouter()
+	router.testReportRouter.InitTestReportRouter(testReportRouter)
+
 	// TODO refactoring: categorise and move to respective folders
 	AppRouter.Path("/allApps").
 		HandlerFunc(router.appListingRestHandler.FetchAllDevtronManagedApps).
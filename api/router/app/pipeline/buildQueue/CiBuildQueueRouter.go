@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildQueue
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/buildQueue"
+	"github.com/gorilla/mux"
+)
+
+type CiBuildQueueRouter interface {
+	InitCiBuildQueueRouter(buildQueueRouter *mux.Router)
+}
+
+type CiBuildQueueRouterImpl struct {
+	restHandler buildQueue.CiBuildQueueRestHandler
+}
+
+func NewCiBuildQueueRouterImpl(restHandler buildQueue.CiBuildQueueRestHandler) *CiBuildQueueRouterImpl {
+	return &CiBuildQueueRouterImpl{
+		restHandler: restHandler,
+	}
+}
+
+func (router CiBuildQueueRouterImpl) InitCiBuildQueueRouter(buildQueueRouter *mux.Router) {
+	buildQueueRouter.Path("").
+		HandlerFunc(router.restHandler.GetQueue).
+		Methods("GET")
+	buildQueueRouter.Path("/limit").
+		HandlerFunc(router.restHandler.GetLimits).
+		Methods("GET")
+	buildQueueRouter.Path("/limit").
+		HandlerFunc(router.restHandler.SaveLimit).
+		Methods("POST")
+	buildQueueRouter.Path("/limit/{id}").
+		HandlerFunc(router.restHandler.DeleteLimit).
+		Methods("DELETE")
+	buildQueueRouter.Path("/{appId}").
+		HandlerFunc(router.restHandler.GetQueueByAppId).
+		Methods("GET")
+	buildQueueRouter.Path("/{appId}/workflow/{ciWorkflowId}").
+		HandlerFunc(router.restHandler.GetQueuePosition).
+		Methods("GET")
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/retention"
+	"github.com/gorilla/mux"
+)
+
+type ArtifactRetentionRouter interface {
+	InitArtifactRetentionRouter(retentionRouter *mux.Router)
+}
+
+type ArtifactRetentionRouterImpl struct {
+	restHandler retention.ArtifactRetentionRestHandler
+}
+
+func NewArtifactRetentionRouterImpl(restHandler retention.ArtifactRetentionRestHandler) *ArtifactRetentionRouterImpl {
+	return &ArtifactRetentionRouterImpl{
+		restHandler: restHandler,
+	}
+}
+
+func (router ArtifactRetentionRouterImpl) InitArtifactRetentionRouter(retentionRouter *mux.Router) {
+	retentionRouter.Path("/policy").
+		HandlerFunc(router.restHandler.GetPolicies).
+		Methods("GET")
+	retentionRouter.Path("/policy").
+		HandlerFunc(router.restHandler.SavePolicy).
+		Methods("POST")
+	retentionRouter.Path("/policy/{id}").
+		HandlerFunc(router.restHandler.DeletePolicy).
+		Methods("DELETE")
+	retentionRouter.Path("/policy/{id}/dry-run").
+		HandlerFunc(router.restHandler.CreateDryRun).
+		Methods("POST")
+	retentionRouter.Path("/run").
+		Queries("policyId", "{policyId}").
+		HandlerFunc(router.restHandler.GetRuns).
+		Methods("GET")
+	retentionRouter.Path("/run/{id}").
+		HandlerFunc(router.restHandler.GetRunReport).
+		Methods("GET")
+	retentionRouter.Path("/run/{id}/execute").
+		HandlerFunc(router.restHandler.ExecuteRun).
+		Methods("POST")
+}
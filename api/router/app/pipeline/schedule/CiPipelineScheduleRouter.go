@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/schedule"
+	"github.com/gorilla/mux"
+)
+
+type CiPipelineScheduleRouter interface {
+	InitCiPipelineScheduleRouter(scheduleRouter *mux.Router)
+}
+
+type CiPipelineScheduleRouterImpl struct {
+	restHandler schedule.CiPipelineScheduleRestHandler
+}
+
+func NewCiPipelineScheduleRouterImpl(restHandler schedule.CiPipelineScheduleRestHandler) *CiPipelineScheduleRouterImpl {
+	return &CiPipelineScheduleRouterImpl{
+		restHandler: restHandler,
+	}
+}
+
+func (router CiPipelineScheduleRouterImpl) InitCiPipelineScheduleRouter(scheduleRouter *mux.Router) {
+	scheduleRouter.Path("").
+		HandlerFunc(router.restHandler.SaveSchedule).
+		Methods("POST")
+	scheduleRouter.Path("/{appId}").
+		HandlerFunc(router.restHandler.GetSchedulesByAppId).
+		Methods("GET")
+	scheduleRouter.Path("/{appId}/{ciPipelineId}").
+		HandlerFunc(router.restHandler.GetSchedule).
+		Methods("GET")
+	scheduleRouter.Path("/{appId}/{ciPipelineId}").
+		HandlerFunc(router.restHandler.DeleteSchedule).
+		Methods("DELETE")
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testReport
+
+import (
+	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/testReport"
+	"github.com/gorilla/mux"
+)
+
+type TestReportRouter interface {
+	InitTestReportRouter(testReportRouter *mux.Router)
+}
+
+type TestReportRouterImpl struct {
+	restHandler testReport.TestReportRestHandler
+}
+
+func NewTestReportRouterImpl(restHandler testReport.TestReportRestHandler) *TestReportRouterImpl {
+	return &TestReportRouterImpl{
+		restHandler: restHandler,
+	}
+}
+
+func (router TestReportRouterImpl) InitTestReportRouter(testReportRouter *mux.Router) {
+	testReportRouter.Path("/ci-pipeline/{ciPipelineId}/workflow/{workflowId}").
+		HandlerFunc(router.restHandler.GetTestSummary).
+		Methods("GET")
+	testReportRouter.Path("/ci-pipeline/{ciPipelineId}/workflow/{workflowId}/ingest").
+		HandlerFunc(router.restHandler.IngestTestReports).
+		Methods("POST")
+	testReportRouter.Path("/gate").
+		HandlerFunc(router.restHandler.SaveTestGate).
+		Methods("POST")
+	testReportRouter.Path("/gate/{pipelineId}").
+		HandlerFunc(router.restHandler.GetTestGate).
+		Methods("GET")
+	testReportRouter.Path("/gate/{pipelineId}").
+		HandlerFunc(router.restHandler.DeleteTestGate).
+		Methods("DELETE")
+	testReportRouter.Path("/gate/{pipelineId}/artifact/{artifactId}").
+		HandlerFunc(router.restHandler.EvaluateTestGate).
+		Methods("GET")
+}
@@ -83,6 +83,10 @@ type MuxRouter struct {
 	chartGroupRouter                   chartGroup.ChartGroupRouter
 	batchOperationRouter               BatchOperationRouter
 	imageScanRouter                    ImageScanRouter
+	sbomRouter                         SbomRouter
+	imageSignatureRouter               ImageSignatureRouter
+	webhookSignatureRouter             WebhookSignatureRouter
+	commitStatusRouter                 CommitStatusRouter
 	policyRouter                       PolicyRouter
 	gitOpsConfigRouter                 GitOpsConfigRouter
 	dashboardRouter                    dashboard.DashboardRouter
@@ -119,6 +123,29 @@ type MuxRouter struct {
 	rbacRoleRouter                     user.RbacRoleRouter
 	scopedVariableRouter               ScopedVariableRouter
 	ciTriggerCron                      cron.CiTriggerCron
+	ciScheduleTriggerCron              cron.CiScheduleTriggerCron
+	ciBuildQueueCron                   cron.CiBuildQueueCron
+	artifactGcCron                     cron.ArtifactGcCron
+	sbomSyncCron                       cron.SbomSyncCron
+	webhookDeliveryCleanupCron         cron.WebhookDeliveryCleanupCron
+	deploymentWindowQueueCron          cron.DeploymentWindowQueueCron
+	deploymentWindowRouter             deployment.DeploymentWindowRouter
+	deploymentApprovalRouter           deployment.DeploymentApprovalRouter
+	autoRollbackCron                   cron.AutoRollbackCron
+	autoRollbackRouter                 deployment.AutoRollbackRouter
+	metricVerificationCron             cron.MetricVerificationCron
+	metricVerificationRouter           deployment.MetricVerificationRouter
+	driftDetectionCron                 cron.DriftDetectionCron
+	driftDetectionRouter               deployment.DriftDetectionRouter
+	gitOpsPullRequestCron              cron.GitOpsPullRequestCron
+	gitOpsPullRequestRouter            deployment.GitOpsPullRequestRouter
+	gitOpsMonoRepoRouter               deployment.GitOpsMonoRepoRouter
+	previewEnvironmentRouter           deployment.PreviewEnvironmentRouter
+	hibernationScheduleRouter          deployment.HibernationScheduleRouter
+	releaseOrchestrationRouter         deployment.ReleaseOrchestrationRouter
+	scheduledDeploymentRouter          deployment.ScheduledDeploymentRouter
+	deploymentPreviewRouter            deployment.DeploymentPreviewRouter
+	clusterSetRouter                   deployment.ClusterSetRouter
 	deploymentConfigurationRouter      configDiff.DeploymentConfigurationRouter
 	infraConfigRouter                  infraConfig.InfraConfigRouter
 	argoApplicationRouter              argoApplication.ArgoApplicationRouter
@@ -141,6 +168,10 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 	ChartRefRouter ChartRefRouter, ConfigMapRouter ConfigMapRouter, AppStoreRouter appStore.AppStoreRouter, chartRepositoryRouter chartRepo.ChartRepositoryRouter,
 	ReleaseMetricsRouter ReleaseMetricsRouter, deploymentGroupRouter DeploymentGroupRouter, batchOperationRouter BatchOperationRouter,
 	chartGroupRouter chartGroup.ChartGroupRouter, imageScanRouter ImageScanRouter,
+	sbomRouter SbomRouter,
+	imageSignatureRouter ImageSignatureRouter,
+	webhookSignatureRouter WebhookSignatureRouter,
+	commitStatusRouter CommitStatusRouter,
 	policyRouter PolicyRouter, gitOpsConfigRouter GitOpsConfigRouter, dashboardRouter dashboard.DashboardRouter, attributesRouter AttributesRouter, userAttributesRouter UserAttributesRouter,
 	commonRouter CommonRouter, grafanaRouter GrafanaRouter, ssoLoginRouter sso.SsoLoginRouter, telemetryRouter TelemetryRouter, telemetryWatcher telemetry.TelemetryEventClient, bulkUpdateRouter BulkUpdateRouter, webhookListenerRouter WebhookListenerRouter, appRouter app.AppRouter,
 	coreAppRouter CoreAppRouter, helmAppRouter client.HelmAppRouter, k8sApplicationRouter application.K8sApplicationRouter,
@@ -155,6 +186,29 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 	rbacRoleRouter user.RbacRoleRouter,
 	scopedVariableRouter ScopedVariableRouter,
 	ciTriggerCron cron.CiTriggerCron,
+	ciScheduleTriggerCron cron.CiScheduleTriggerCron,
+	ciBuildQueueCron cron.CiBuildQueueCron,
+	artifactGcCron cron.ArtifactGcCron,
+	sbomSyncCron cron.SbomSyncCron,
+	webhookDeliveryCleanupCron cron.WebhookDeliveryCleanupCron,
+	deploymentWindowQueueCron cron.DeploymentWindowQueueCron,
+	deploymentWindowRouter deployment.DeploymentWindowRouter,
+	deploymentApprovalRouter deployment.DeploymentApprovalRouter,
+	autoRollbackCron cron.AutoRollbackCron,
+	autoRollbackRouter deployment.AutoRollbackRouter,
+	metricVerificationCron cron.MetricVerificationCron,
+	metricVerificationRouter deployment.MetricVerificationRouter,
+	driftDetectionCron cron.DriftDetectionCron,
+	driftDetectionRouter deployment.DriftDetectionRouter,
+	gitOpsPullRequestCron cron.GitOpsPullRequestCron,
+	gitOpsPullRequestRouter deployment.GitOpsPullRequestRouter,
+	gitOpsMonoRepoRouter deployment.GitOpsMonoRepoRouter,
+	previewEnvironmentRouter deployment.PreviewEnvironmentRouter,
+	hibernationScheduleRouter deployment.HibernationScheduleRouter,
+	releaseOrchestrationRouter deployment.ReleaseOrchestrationRouter,
+	scheduledDeploymentRouter deployment.ScheduledDeploymentRouter,
+	deploymentPreviewRouter deployment.DeploymentPreviewRouter,
+	clusterSetRouter deployment.ClusterSetRouter,
 	proxyRouter proxy.ProxyRouter,
 	deploymentConfigurationRouter configDiff.DeploymentConfigurationRouter,
 	infraConfigRouter infraConfig.InfraConfigRouter,
@@ -188,6 +242,10 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 		batchOperationRouter:               batchOperationRouter,
 		chartGroupRouter:                   chartGroupRouter,
 		imageScanRouter:                    imageScanRouter,
+		sbomRouter:                         sbomRouter,
+		imageSignatureRouter:               imageSignatureRouter,
+		webhookSignatureRouter:             webhookSignatureRouter,
+		commitStatusRouter:                 commitStatusRouter,
 		policyRouter:                       policyRouter,
 		gitOpsConfigRouter:                 gitOpsConfigRouter,
 		attributesRouter:                   attributesRouter,
@@ -225,6 +283,29 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 		rbacRoleRouter:                     rbacRoleRouter,
 		scopedVariableRouter:               scopedVariableRouter,
 		ciTriggerCron:                      ciTriggerCron,
+		ciScheduleTriggerCron:              ciScheduleTriggerCron,
+		ciBuildQueueCron:                   ciBuildQueueCron,
+		artifactGcCron:                     artifactGcCron,
+		sbomSyncCron:                       sbomSyncCron,
+		webhookDeliveryCleanupCron:         webhookDeliveryCleanupCron,
+		deploymentWindowQueueCron:          deploymentWindowQueueCron,
+		deploymentWindowRouter:             deploymentWindowRouter,
+		deploymentApprovalRouter:           deploymentApprovalRouter,
+		autoRollbackCron:                   autoRollbackCron,
+		autoRollbackRouter:                 autoRollbackRouter,
+		metricVerificationCron:             metricVerificationCron,
+		metricVerificationRouter:           metricVerificationRouter,
+		driftDetectionCron:                 driftDetectionCron,
+		driftDetectionRouter:               driftDetectionRouter,
+		gitOpsPullRequestCron:              gitOpsPullRequestCron,
+		gitOpsPullRequestRouter:            gitOpsPullRequestRouter,
+		gitOpsMonoRepoRouter:               gitOpsMonoRepoRouter,
+		previewEnvironmentRouter:           previewEnvironmentRouter,
+		hibernationScheduleRouter:          hibernationScheduleRouter,
+		releaseOrchestrationRouter:         releaseOrchestrationRouter,
+		scheduledDeploymentRouter:          scheduledDeploymentRouter,
+		deploymentPreviewRouter:            deploymentPreviewRouter,
+		clusterSetRouter:                   clusterSetRouter,
 		deploymentConfigurationRouter:      deploymentConfigurationRouter,
 		infraConfigRouter:                  infraConfigRouter,
 		argoApplicationRouter:              argoApplicationRouter,
@@ -296,6 +377,7 @@ func (r MuxRouter) Init() {
 
 	gitRouter := r.Router.PathPrefix("/orchestrator/git").Subrouter()
 	r.GitProviderRouter.InitGitProviderRouter(gitRouter)
+	r.commitStatusRouter.InitCommitStatusRouter(gitRouter)
 	r.GitHostRouter.InitGitHostRouter(gitRouter)
 
 	dockerRouter := r.Router.PathPrefix("/orchestrator/docker").Subrouter()
@@ -340,6 +422,15 @@ func (r MuxRouter) Init() {
 	imageScanRouter := r.Router.PathPrefix("/orchestrator/security/scan").Subrouter()
 	r.imageScanRouter.InitImageScanRouter(imageScanRouter)
 
+	sbomRouter := r.Router.PathPrefix("/orchestrator/security/sbom").Subrouter()
+	r.sbomRouter.InitSbomRouter(sbomRouter)
+
+	imageSignatureRouter := r.Router.PathPrefix("/orchestrator/security/image-signing").Subrouter()
+	r.imageSignatureRouter.InitImageSignatureRouter(imageSignatureRouter)
+
+	webhookSignatureRouter := r.Router.PathPrefix("/orchestrator/security/webhook-signature").Subrouter()
+	r.webhookSignatureRouter.InitWebhookSignatureRouter(webhookSignatureRouter)
+
 	scanResultRouter := r.Router.PathPrefix("/orchestrator/scan-result").Subrouter()
 	r.scanningResultRouter.InitScanningResultRouter(scanResultRouter)
 
@@ -396,6 +487,43 @@ func (r MuxRouter) Init() {
 	//  deployment router starts
 	deploymentConfigSubRouter := r.Router.PathPrefix("/orchestrator/deployment/template").Subrouter()
 	r.deploymentConfigRouter.Init(deploymentConfigSubRouter)
+	deploymentWindowSubRouter := r.Router.PathPrefix("/orchestrator/deployment-window").Subrouter()
+	r.deploymentWindowRouter.Init(deploymentWindowSubRouter)
+
+	deploymentApprovalSubRouter := r.Router.PathPrefix("/orchestrator/deployment-approval").Subrouter()
+	r.deploymentApprovalRouter.Init(deploymentApprovalSubRouter)
+
+	autoRollbackSubRouter := r.Router.PathPrefix("/orchestrator/auto-rollback").Subrouter()
+	r.autoRollbackRouter.Init(autoRollbackSubRouter)
+
+	metricVerificationSubRouter := r.Router.PathPrefix("/orchestrator/metric-verification").Subrouter()
+	r.metricVerificationRouter.Init(metricVerificationSubRouter)
+
+	driftDetectionSubRouter := r.Router.PathPrefix("/orchestrator/drift-detection").Subrouter()
+	r.driftDetectionRouter.Init(driftDetectionSubRouter)
+
+	gitOpsPullRequestSubRouter := r.Router.PathPrefix("/orchestrator/gitops-pr-mode").Subrouter()
+	r.gitOpsPullRequestRouter.Init(gitOpsPullRequestSubRouter)
+	gitOpsMonoRepoSubRouter := r.Router.PathPrefix("/orchestrator/gitops-monorepo").Subrouter()
+	r.gitOpsMonoRepoRouter.Init(gitOpsMonoRepoSubRouter)
+
+	previewEnvironmentSubRouter := r.Router.PathPrefix("/orchestrator/preview-environment").Subrouter()
+	r.previewEnvironmentRouter.Init(previewEnvironmentSubRouter)
+
+	hibernationScheduleSubRouter := r.Router.PathPrefix("/orchestrator/hibernation-schedule").Subrouter()
+	r.hibernationScheduleRouter.Init(hibernationScheduleSubRouter)
+
+	releaseOrchestrationSubRouter := r.Router.PathPrefix("/orchestrator/release-orchestration").Subrouter()
+	r.releaseOrchestrationRouter.Init(releaseOrchestrationSubRouter)
+
+	scheduledDeploymentSubRouter := r.Router.PathPrefix("/orchestrator/scheduled-deployment").Subrouter()
+	r.scheduledDeploymentRouter.Init(scheduledDeploymentSubRouter)
+
+	deploymentPreviewSubRouter := r.Router.PathPrefix("/orchestrator/deployment-preview").Subrouter()
+	r.deploymentPreviewRouter.Init(deploymentPreviewSubRouter)
+
+	clusterSetSubRouter := r.Router.PathPrefix("/orchestrator/cluster-set").Subrouter()
+	r.clusterSetRouter.Init(clusterSetSubRouter)
 	// deployment router ends
 
 	//  dashboard event router starts
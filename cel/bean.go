@@ -25,6 +25,10 @@ const ContainerRepo ParamName = "containerRepository"
 const ContainerImage ParamName = "containerImage"
 const ContainerImageTag ParamName = "containerImageTag"
 const ImageLabels ParamName = "imageLabels"
+const Branch ParamName = "branch"
+const CommitHash ParamName = "commit"
+const PreviousStepStatus ParamName = "previousStepStatus"
+const StepStatuses ParamName = "stepStatuses"
 
 type Request struct {
 	Expression         string             `json:"expression"`
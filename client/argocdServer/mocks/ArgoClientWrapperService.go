@@ -0,0 +1,644 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	application "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+
+	argocdServerbean "github.com/devtron-labs/devtron/client/argocdServer/bean"
+
+	bean "github.com/devtron-labs/devtron/client/argocdServer/repoCredsK8sClient/bean"
+
+	certificate "github.com/argoproj/argo-cd/v2/pkg/apiclient/certificate"
+
+	cluster "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
+
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repocreds "github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
+
+	v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+)
+
+// ArgoClientWrapperService is an autogenerated mock type for the ArgoClientWrapperService type
+type ArgoClientWrapperService struct {
+	mock.Mock
+}
+
+// AddChartRepository provides a mock function with given fields: request
+func (_m *ArgoClientWrapperService) AddChartRepository(request bean.ChartRepositoryAddRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddChartRepository")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bean.ChartRepositoryAddRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddOrUpdateOCIRegistry provides a mock function with given fields: username, password, uniqueId, registryUrl, repo, isPublic
+func (_m *ArgoClientWrapperService) AddOrUpdateOCIRegistry(username string, password string, uniqueId int, registryUrl string, repo string, isPublic bool) error {
+	ret := _m.Called(username, password, uniqueId, registryUrl, repo, isPublic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrUpdateOCIRegistry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, string, string, bool) error); ok {
+		r0 = rf(username, password, uniqueId, registryUrl, repo, isPublic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCertificate provides a mock function with given fields: ctx, query
+func (_m *ArgoClientWrapperService) CreateCertificate(ctx context.Context, query *certificate.RepositoryCertificateCreateRequest) (*v1alpha1.RepositoryCertificateList, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCertificate")
+	}
+
+	var r0 *v1alpha1.RepositoryCertificateList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *certificate.RepositoryCertificateCreateRequest) (*v1alpha1.RepositoryCertificateList, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *certificate.RepositoryCertificateCreateRequest) *v1alpha1.RepositoryCertificateList); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RepositoryCertificateList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *certificate.RepositoryCertificateCreateRequest) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCluster provides a mock function with given fields: ctx, clusterRequest
+func (_m *ArgoClientWrapperService) CreateCluster(ctx context.Context, clusterRequest *cluster.ClusterCreateRequest) (*v1alpha1.Cluster, error) {
+	ret := _m.Called(ctx, clusterRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCluster")
+	}
+
+	var r0 *v1alpha1.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.ClusterCreateRequest) (*v1alpha1.Cluster, error)); ok {
+		return rf(ctx, clusterRequest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.ClusterCreateRequest) *v1alpha1.Cluster); ok {
+		r0 = rf(ctx, clusterRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *cluster.ClusterCreateRequest) error); ok {
+		r1 = rf(ctx, clusterRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRepoCreds provides a mock function with given fields: ctx, query
+func (_m *ArgoClientWrapperService) CreateRepoCreds(ctx context.Context, query *repocreds.RepoCredsCreateRequest) (*v1alpha1.RepoCreds, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRepoCreds")
+	}
+
+	var r0 *v1alpha1.RepoCreds
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repocreds.RepoCredsCreateRequest) (*v1alpha1.RepoCreds, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repocreds.RepoCredsCreateRequest) *v1alpha1.RepoCreds); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RepoCreds)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repocreds.RepoCredsCreateRequest) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteArgoApp provides a mock function with given fields: ctx, appName, cascadeDelete
+func (_m *ArgoClientWrapperService) DeleteArgoApp(ctx context.Context, appName string, cascadeDelete bool) (*application.ApplicationResponse, error) {
+	ret := _m.Called(ctx, appName, cascadeDelete)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArgoApp")
+	}
+
+	var r0 *application.ApplicationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*application.ApplicationResponse, error)); ok {
+		return rf(ctx, appName, cascadeDelete)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *application.ApplicationResponse); ok {
+		r0 = rf(ctx, appName, cascadeDelete)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*application.ApplicationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, appName, cascadeDelete)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteArgoAppWithK8sClient provides a mock function with given fields: ctx, clusterId, namespace, appName, cascadeDelete
+func (_m *ArgoClientWrapperService) DeleteArgoAppWithK8sClient(ctx context.Context, clusterId int, namespace string, appName string, cascadeDelete bool) error {
+	ret := _m.Called(ctx, clusterId, namespace, appName, cascadeDelete)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArgoAppWithK8sClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string, bool) error); ok {
+		r0 = rf(ctx, clusterId, namespace, appName, cascadeDelete)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCertificate provides a mock function with given fields: ctx, query, opts
+func (_m *ArgoClientWrapperService) DeleteCertificate(ctx context.Context, query *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, query)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCertificate")
+	}
+
+	var r0 *v1alpha1.RepositoryCertificateList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *certificate.RepositoryCertificateQuery, ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error)); ok {
+		return rf(ctx, query, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *certificate.RepositoryCertificateQuery, ...grpc.CallOption) *v1alpha1.RepositoryCertificateList); ok {
+		r0 = rf(ctx, query, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.RepositoryCertificateList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *certificate.RepositoryCertificateQuery, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, query, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteChartRepository provides a mock function with given fields: name, url
+func (_m *ArgoClientWrapperService) DeleteChartRepository(name string, url string) error {
+	ret := _m.Called(name, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChartRepository")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOCIRegistry provides a mock function with given fields: registryURL, repo, ociRegistryId
+func (_m *ArgoClientWrapperService) DeleteOCIRegistry(registryURL string, repo string, ociRegistryId int) error {
+	ret := _m.Called(registryURL, repo, ociRegistryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOCIRegistry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int) error); ok {
+		r0 = rf(registryURL, repo, ociRegistryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetApplicationResource provides a mock function with given fields: ctx, query
+func (_m *ArgoClientWrapperService) GetApplicationResource(ctx context.Context, query *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplicationResource")
+	}
+
+	var r0 *application.ApplicationResourceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest) *application.ApplicationResourceResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*application.ApplicationResourceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceRequest) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArgoAppByName provides a mock function with given fields: ctx, appName
+func (_m *ArgoClientWrapperService) GetArgoAppByName(ctx context.Context, appName string) (*v1alpha1.Application, error) {
+	ret := _m.Called(ctx, appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArgoAppByName")
+	}
+
+	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, appName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v1alpha1.Application); ok {
+		r0 = rf(ctx, appName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Application)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, appName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArgoAppByNameWithK8sClient provides a mock function with given fields: ctx, clusterId, namespace, appName
+func (_m *ArgoClientWrapperService) GetArgoAppByNameWithK8sClient(ctx context.Context, clusterId int, namespace string, appName string) (*v1alpha1.Application, error) {
+	ret := _m.Called(ctx, clusterId, namespace, appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArgoAppByNameWithK8sClient")
+	}
+
+	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, clusterId, namespace, appName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *v1alpha1.Application); ok {
+		r0 = rf(ctx, clusterId, namespace, appName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Application)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, clusterId, namespace, appName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArgoClient provides a mock function with given fields: ctxt
+func (_m *ArgoClientWrapperService) GetArgoClient(ctxt context.Context) (application.ApplicationServiceClient, *grpc.ClientConn, error) {
+	ret := _m.Called(ctxt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArgoClient")
+	}
+
+	var r0 application.ApplicationServiceClient
+	var r1 *grpc.ClientConn
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (application.ApplicationServiceClient, *grpc.ClientConn, error)); ok {
+		return rf(ctxt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) application.ApplicationServiceClient); ok {
+		r0 = rf(ctxt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(application.ApplicationServiceClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) *grpc.ClientConn); ok {
+		r1 = rf(ctxt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*grpc.ClientConn)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctxt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGitOpsRepoNameForApplication provides a mock function with given fields: ctx, appName
+func (_m *ArgoClientWrapperService) GetGitOpsRepoNameForApplication(ctx context.Context, appName string) (string, error) {
+	ret := _m.Called(ctx, appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsRepoNameForApplication")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, appName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, appName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitOpsRepoURLForApplication provides a mock function with given fields: ctx, appName
+func (_m *ArgoClientWrapperService) GetGitOpsRepoURLForApplication(ctx context.Context, appName string) (string, error) {
+	ret := _m.Called(ctx, appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsRepoURLForApplication")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, appName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, appName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsArgoAppPatchRequired provides a mock function with given fields: argoAppSpec, currentGitRepoUrl, currentTargetRevision, currentChartPath
+func (_m *ArgoClientWrapperService) IsArgoAppPatchRequired(argoAppSpec *v1alpha1.ApplicationSource, currentGitRepoUrl string, currentTargetRevision string, currentChartPath string) bool {
+	ret := _m.Called(argoAppSpec, currentGitRepoUrl, currentTargetRevision, currentChartPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsArgoAppPatchRequired")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*v1alpha1.ApplicationSource, string, string, string) bool); ok {
+		r0 = rf(argoAppSpec, currentGitRepoUrl, currentTargetRevision, currentChartPath)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// PatchArgoCdApp provides a mock function with given fields: ctx, dto
+func (_m *ArgoClientWrapperService) PatchArgoCdApp(ctx context.Context, dto *argocdServerbean.ArgoCdAppPatchReqDto) error {
+	ret := _m.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchArgoCdApp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *argocdServerbean.ArgoCdAppPatchReqDto) error); ok {
+		r0 = rf(ctx, dto)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterGitOpsRepoInArgoWithRetry provides a mock function with given fields: ctx, gitOpsRepoUrl, targetRevision, userId
+func (_m *ArgoClientWrapperService) RegisterGitOpsRepoInArgoWithRetry(ctx context.Context, gitOpsRepoUrl string, targetRevision string, userId int32) error {
+	ret := _m.Called(ctx, gitOpsRepoUrl, targetRevision, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterGitOpsRepoInArgoWithRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) error); ok {
+		r0 = rf(ctx, gitOpsRepoUrl, targetRevision, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResourceTree provides a mock function with given fields: ctxt, query
+func (_m *ArgoClientWrapperService) ResourceTree(ctxt context.Context, query *application.ResourcesQuery) (*v1alpha1.ApplicationTree, error) {
+	ret := _m.Called(ctxt, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceTree")
+	}
+
+	var r0 *v1alpha1.ApplicationTree
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery) (*v1alpha1.ApplicationTree, error)); ok {
+		return rf(ctxt, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery) *v1alpha1.ApplicationTree); ok {
+		r0 = rf(ctxt, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ApplicationTree)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *application.ResourcesQuery) error); ok {
+		r1 = rf(ctxt, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncArgoCDApplicationIfNeededAndRefresh provides a mock function with given fields: ctx, argoAppName, targetRevision
+func (_m *ArgoClientWrapperService) SyncArgoCDApplicationIfNeededAndRefresh(ctx context.Context, argoAppName string, targetRevision string) error {
+	ret := _m.Called(ctx, argoAppName, targetRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncArgoCDApplicationIfNeededAndRefresh")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, argoAppName, targetRevision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateArgoCDSyncModeIfNeeded provides a mock function with given fields: ctx, argoApplication
+func (_m *ArgoClientWrapperService) UpdateArgoCDSyncModeIfNeeded(ctx context.Context, argoApplication *v1alpha1.Application) error {
+	ret := _m.Called(ctx, argoApplication)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArgoCDSyncModeIfNeeded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.Application) error); ok {
+		r0 = rf(ctx, argoApplication)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateChartRepository provides a mock function with given fields: request
+func (_m *ArgoClientWrapperService) UpdateChartRepository(request bean.ChartRepositoryUpdateRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChartRepository")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bean.ChartRepositoryUpdateRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCluster provides a mock function with given fields: ctx, clusterRequest
+func (_m *ArgoClientWrapperService) UpdateCluster(ctx context.Context, clusterRequest *cluster.ClusterUpdateRequest) (*v1alpha1.Cluster, error) {
+	ret := _m.Called(ctx, clusterRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCluster")
+	}
+
+	var r0 *v1alpha1.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.ClusterUpdateRequest) (*v1alpha1.Cluster, error)); ok {
+		return rf(ctx, clusterRequest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *cluster.ClusterUpdateRequest) *v1alpha1.Cluster); ok {
+		r0 = rf(ctx, clusterRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *cluster.ClusterUpdateRequest) error); ok {
+		r1 = rf(ctx, clusterRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewArgoClientWrapperService creates a new instance of ArgoClientWrapperService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewArgoClientWrapperService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ArgoClientWrapperService {
+	mock := &ArgoClientWrapperService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
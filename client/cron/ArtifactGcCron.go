@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type ArtifactGcCron interface {
+	RunScheduledGc()
+}
+
+type ArtifactGcCronImpl struct {
+	logger                   *zap.SugaredLogger
+	cron                     *cron.Cron
+	cfg                      *ArtifactGcCronConfig
+	artifactRetentionService retention.ArtifactRetentionService
+}
+
+func NewArtifactGcCronImpl(logger *zap.SugaredLogger, cfg *ArtifactGcCronConfig,
+	cronLogger *cron2.CronLoggerImpl, artifactRetentionService retention.ArtifactRetentionService) *ArtifactGcCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &ArtifactGcCronImpl{
+		logger:                   logger,
+		cron:                     cron,
+		cfg:                      cfg,
+		artifactRetentionService: artifactRetentionService,
+	}
+	_, err := cron.AddFunc(cfg.Schedule, impl.RunScheduledGc)
+	if err != nil {
+		logger.Errorw("error while configure cron job for artifact garbage collection", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type ArtifactGcCronConfig struct {
+	Schedule string `env:"ARTIFACT_GC_CRON_SCHEDULE" envDefault:"0 2 * * *" description:"Cron schedule at which dry run reports of the artifact retention policies are created and due reports are executed"`
+}
+
+func GetArtifactGcCronConfig() (*ArtifactGcCronConfig, error) {
+	cfg := &ArtifactGcCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse artifact gc cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *ArtifactGcCronImpl) RunScheduledGc() {
+	impl.artifactRetentionService.RunScheduledGc()
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type AutoRollbackCron interface {
+	TriggerAutoRollbacks()
+}
+
+type AutoRollbackCronImpl struct {
+	logger           *zap.SugaredLogger
+	cron             *cron.Cron
+	cfg              *AutoRollbackCronConfig
+	cdHandlerService devtronApps.HandlerService
+}
+
+func NewAutoRollbackCronImpl(logger *zap.SugaredLogger, cfg *AutoRollbackCronConfig,
+	cronLogger *cron2.CronLoggerImpl, cdHandlerService devtronApps.HandlerService) *AutoRollbackCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &AutoRollbackCronImpl{
+		logger:           logger,
+		cron:             cron,
+		cfg:              cfg,
+		cdHandlerService: cdHandlerService,
+	}
+	_, err := cron.AddFunc(cfg.AutoRollbackCron, impl.TriggerAutoRollbacks)
+	if err != nil {
+		logger.Errorw("error while configure cron job for auto rollback", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type AutoRollbackCronConfig struct {
+	AutoRollbackCron string `env:"AUTO_ROLLBACK_CRON" envDefault:"* * * * *" description:"Cron at which the latest deployments of the pipelines with auto rollback enabled are checked, unhealthy deployments past the health timeout are rolled back"`
+}
+
+func GetAutoRollbackCronConfig() (*AutoRollbackCronConfig, error) {
+	cfg := &AutoRollbackCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse auto rollback cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *AutoRollbackCronImpl) TriggerAutoRollbacks() {
+	impl.cdHandlerService.TriggerAutoRollbacks()
+}
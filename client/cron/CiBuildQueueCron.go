@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/build/trigger"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type CiBuildQueueCron interface {
+	DispatchQueuedBuilds()
+}
+
+type CiBuildQueueCronImpl struct {
+	logger           *zap.SugaredLogger
+	cron             *cron.Cron
+	cfg              *CiBuildQueueCronConfig
+	ciHandlerService trigger.HandlerService
+}
+
+func NewCiBuildQueueCronImpl(logger *zap.SugaredLogger, cfg *CiBuildQueueCronConfig,
+	cronLogger *cron2.CronLoggerImpl, ciHandlerService trigger.HandlerService) *CiBuildQueueCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &CiBuildQueueCronImpl{
+		logger:           logger,
+		cron:             cron,
+		cfg:              cfg,
+		ciHandlerService: ciHandlerService,
+	}
+	// queued builds are dispatched even if the queue is disabled later, so that already queued builds are not left behind
+	_, err := cron.AddFunc(fmt.Sprintf("@every %ds", cfg.DispatchIntervalSecs), impl.DispatchQueuedBuilds)
+	if err != nil {
+		logger.Errorw("error while configure cron job for ci build queue dispatch", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type CiBuildQueueCronConfig struct {
+	DispatchIntervalSecs int `env:"CI_BUILD_QUEUE_DISPATCH_INTERVAL_SECS" envDefault:"10" description:"Interval in seconds at which queued builds are checked for a free slot"`
+}
+
+func GetCiBuildQueueCronConfig() (*CiBuildQueueCronConfig, error) {
+	cfg := &CiBuildQueueCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse ci build queue cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *CiBuildQueueCronImpl) DispatchQueuedBuilds() {
+	impl.ciHandlerService.DispatchQueuedBuilds()
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/build/schedule"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type CiScheduleTriggerCron interface {
+	TriggerScheduledCi()
+}
+
+type CiScheduleTriggerCronImpl struct {
+	logger                    *zap.SugaredLogger
+	cron                      *cron.Cron
+	cfg                       *CiScheduleTriggerCronConfig
+	ciPipelineScheduleService schedule.CiPipelineScheduleService
+}
+
+func NewCiScheduleTriggerCronImpl(logger *zap.SugaredLogger, cfg *CiScheduleTriggerCronConfig,
+	cronLogger *cron2.CronLoggerImpl, ciPipelineScheduleService schedule.CiPipelineScheduleService) *CiScheduleTriggerCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &CiScheduleTriggerCronImpl{
+		logger:                    logger,
+		cron:                      cron,
+		cfg:                       cfg,
+		ciPipelineScheduleService: ciPipelineScheduleService,
+	}
+	if !cfg.CiScheduleTriggerEnabled {
+		return impl
+	}
+	// schedules are evaluated at minute granularity, so polling every minute is enough
+	_, err := cron.AddFunc(cfg.CiScheduleTriggerCron, impl.TriggerScheduledCi)
+	if err != nil {
+		logger.Errorw("error while configure cron job for scheduled ci trigger", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type CiScheduleTriggerCronConfig struct {
+	CiScheduleTriggerEnabled bool   `env:"CI_SCHEDULE_TRIGGER_ENABLED" envDefault:"true" description:"Enables triggering of cron scheduled ci and job pipelines"`
+	CiScheduleTriggerCron    string `env:"CI_SCHEDULE_TRIGGER_CRON" envDefault:"* * * * *" description:"Cron at which due ci pipeline schedules are polled and triggered"`
+}
+
+func GetCiScheduleTriggerCronConfig() (*CiScheduleTriggerCronConfig, error) {
+	cfg := &CiScheduleTriggerCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse ci schedule trigger cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *CiScheduleTriggerCronImpl) TriggerScheduledCi() {
+	impl.ciPipelineScheduleService.TriggerDueSchedules()
+}
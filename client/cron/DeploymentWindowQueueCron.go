@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type DeploymentWindowQueueCron interface {
+	ReleaseQueuedTriggers()
+}
+
+type DeploymentWindowQueueCronImpl struct {
+	logger           *zap.SugaredLogger
+	cron             *cron.Cron
+	cfg              *DeploymentWindowQueueCronConfig
+	cdHandlerService devtronApps.HandlerService
+}
+
+func NewDeploymentWindowQueueCronImpl(logger *zap.SugaredLogger, cfg *DeploymentWindowQueueCronConfig,
+	cronLogger *cron2.CronLoggerImpl, cdHandlerService devtronApps.HandlerService) *DeploymentWindowQueueCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &DeploymentWindowQueueCronImpl{
+		logger:           logger,
+		cron:             cron,
+		cfg:              cfg,
+		cdHandlerService: cdHandlerService,
+	}
+	_, err := cron.AddFunc(cfg.DeploymentWindowQueueCron, impl.ReleaseQueuedTriggers)
+	if err != nil {
+		logger.Errorw("error while configure cron job for deployment window queued triggers", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type DeploymentWindowQueueCronConfig struct {
+	DeploymentWindowQueueCron string `env:"DEPLOYMENT_WINDOW_QUEUE_CRON" envDefault:"* * * * *" description:"Cron at which automatic deployments queued due to a deployment window are released if the environment is open"`
+}
+
+func GetDeploymentWindowQueueCronConfig() (*DeploymentWindowQueueCronConfig, error) {
+	cfg := &DeploymentWindowQueueCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse deployment window queue cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *DeploymentWindowQueueCronImpl) ReleaseQueuedTriggers() {
+	impl.cdHandlerService.ReleaseDeploymentWindowQueuedTriggers()
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type DriftDetectionCron interface {
+	DetectDrifts()
+}
+
+type DriftDetectionCronImpl struct {
+	logger                *zap.SugaredLogger
+	cron                  *cron.Cron
+	cfg                   *DriftDetectionCronConfig
+	driftDetectionService driftDetection.DriftDetectionService
+	cdHandlerService      devtronApps.HandlerService
+}
+
+func NewDriftDetectionCronImpl(logger *zap.SugaredLogger, cfg *DriftDetectionCronConfig,
+	cronLogger *cron2.CronLoggerImpl, driftDetectionService driftDetection.DriftDetectionService,
+	cdHandlerService devtronApps.HandlerService) *DriftDetectionCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &DriftDetectionCronImpl{
+		logger:                logger,
+		cron:                  cron,
+		cfg:                   cfg,
+		driftDetectionService: driftDetectionService,
+		cdHandlerService:      cdHandlerService,
+	}
+	_, err := cron.AddFunc(cfg.DriftDetectionCron, impl.DetectDrifts)
+	if err != nil {
+		logger.Errorw("error while configure cron job for drift detection", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type DriftDetectionCronConfig struct {
+	DriftDetectionCron string `env:"DRIFT_DETECTION_CRON" envDefault:"@every 5m" description:"Cron at which the live objects of the cd pipelines with drift detection enabled are compared with their last deployment"`
+}
+
+func GetDriftDetectionCronConfig() (*DriftDetectionCronConfig, error) {
+	cfg := &DriftDetectionCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse drift detection cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *DriftDetectionCronImpl) DetectDrifts() {
+	reconcileRequests := impl.driftDetectionService.DetectDrifts()
+	for _, request := range reconcileRequests {
+		impl.logger.Infow("reconciling drifted deployment", "pipelineId", request.PipelineId, "wfrId", request.CdWorkflowRunnerId, "artifactId", request.CiArtifactId)
+		reconcileWfrId, err := impl.cdHandlerService.ReconcileDrift(request)
+		if err != nil {
+			impl.logger.Errorw("error in reconciling drift", "pipelineId", request.PipelineId, "wfrId", request.CdWorkflowRunnerId, "err", err)
+		}
+		impl.driftDetectionService.UpdateReconcileOutcome(request.PipelineId, reconcileWfrId, err)
+	}
+}
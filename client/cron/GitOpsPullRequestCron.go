@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type GitOpsPullRequestCron interface {
+	ProcessOpenPullRequests()
+}
+
+type GitOpsPullRequestCronImpl struct {
+	logger                 *zap.SugaredLogger
+	cron                   *cron.Cron
+	cfg                    *GitOpsPullRequestCronConfig
+	pullRequestModeService pullRequest.PullRequestModeService
+}
+
+func NewGitOpsPullRequestCronImpl(logger *zap.SugaredLogger, cfg *GitOpsPullRequestCronConfig,
+	cronLogger *cron2.CronLoggerImpl, pullRequestModeService pullRequest.PullRequestModeService) *GitOpsPullRequestCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &GitOpsPullRequestCronImpl{
+		logger:                 logger,
+		cron:                   cron,
+		cfg:                    cfg,
+		pullRequestModeService: pullRequestModeService,
+	}
+	_, err := cron.AddFunc(cfg.GitOpsPullRequestCron, impl.ProcessOpenPullRequests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for gitops pull requests", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type GitOpsPullRequestCronConfig struct {
+	GitOpsPullRequestCron string `env:"GITOPS_PULL_REQUEST_CRON" envDefault:"@every 1m" description:"Cron at which the open gitops pull requests are checked, deployments continue once their pull request is merged"`
+}
+
+func GetGitOpsPullRequestCronConfig() (*GitOpsPullRequestCronConfig, error) {
+	cfg := &GitOpsPullRequestCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse gitops pull request cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *GitOpsPullRequestCronImpl) ProcessOpenPullRequests() {
+	impl.pullRequestModeService.ProcessOpenPullRequests()
+}
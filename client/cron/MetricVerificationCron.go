@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/workflow/dag"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type MetricVerificationCron interface {
+	EvaluateMetricVerifications()
+}
+
+type MetricVerificationCronImpl struct {
+	logger                    *zap.SugaredLogger
+	cron                      *cron.Cron
+	cfg                       *MetricVerificationCronConfig
+	metricVerificationService metricVerification.MetricVerificationService
+	workflowDagExecutor       dag.WorkflowDagExecutor
+}
+
+func NewMetricVerificationCronImpl(logger *zap.SugaredLogger, cfg *MetricVerificationCronConfig,
+	cronLogger *cron2.CronLoggerImpl, metricVerificationService metricVerification.MetricVerificationService,
+	workflowDagExecutor dag.WorkflowDagExecutor) *MetricVerificationCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &MetricVerificationCronImpl{
+		logger:                    logger,
+		cron:                      cron,
+		cfg:                       cfg,
+		metricVerificationService: metricVerificationService,
+		workflowDagExecutor:       workflowDagExecutor,
+	}
+	_, err := cron.AddFunc(cfg.MetricVerificationCron, impl.EvaluateMetricVerifications)
+	if err != nil {
+		logger.Errorw("error while configure cron job for metric verification", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type MetricVerificationCronConfig struct {
+	MetricVerificationCron string `env:"METRIC_VERIFICATION_CRON" envDefault:"@every 15s" description:"Cron at which the due metric verifications of healthy deployments are evaluated against prometheus, should not be coarser than the smallest verification interval"`
+}
+
+func GetMetricVerificationCronConfig() (*MetricVerificationCronConfig, error) {
+	cfg := &MetricVerificationCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse metric verification cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *MetricVerificationCronImpl) EvaluateMetricVerifications() {
+	outcomes := impl.metricVerificationService.EvaluateDueRuns(time.Now())
+	for _, outcome := range outcomes {
+		if outcome.Status != bean.VerificationStatusPassed {
+			continue
+		}
+		triggerContext := triggerBean.TriggerContext{Context: context.Background()}
+		err := impl.workflowDagExecutor.HandleMetricVerificationSuccessEvent(triggerContext, outcome.PipelineOverrideId)
+		if err != nil {
+			impl.logger.Errorw("error in handling metric verification success", "runId", outcome.RunId, "pipelineOverrideId", outcome.PipelineOverrideId, "err", err)
+		}
+	}
+}
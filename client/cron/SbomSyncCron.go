@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type SbomSyncCron interface {
+	SyncScannerSboms()
+}
+
+type SbomSyncCronImpl struct {
+	logger      *zap.SugaredLogger
+	cron        *cron.Cron
+	cfg         *SbomSyncCronConfig
+	sbomService sbom.SbomService
+}
+
+func NewSbomSyncCronImpl(logger *zap.SugaredLogger, cfg *SbomSyncCronConfig,
+	cronLogger *cron2.CronLoggerImpl, sbomService sbom.SbomService) *SbomSyncCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &SbomSyncCronImpl{
+		logger:      logger,
+		cron:        cron,
+		cfg:         cfg,
+		sbomService: sbomService,
+	}
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.ScannerSyncIntervalMins), impl.SyncScannerSboms)
+	if err != nil {
+		logger.Errorw("error while configure cron job for sbom sync", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type SbomSyncCronConfig struct {
+	ScannerSyncIntervalMins int `env:"SBOM_SCANNER_SYNC_INTERVAL_MINS" envDefault:"5" description:"Interval in minutes at which sboms produced by the image scanner are stored for the scanned artifacts"`
+}
+
+func GetSbomSyncCronConfig() (*SbomSyncCronConfig, error) {
+	cfg := &SbomSyncCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse sbom sync cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *SbomSyncCronImpl) SyncScannerSboms() {
+	impl.sbomService.SyncScannerSboms()
+}
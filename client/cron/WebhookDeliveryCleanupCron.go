@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type WebhookDeliveryCleanupCron interface {
+	DeleteExpiredDeliveries()
+}
+
+type WebhookDeliveryCleanupCronImpl struct {
+	logger                  *zap.SugaredLogger
+	cron                    *cron.Cron
+	cfg                     *WebhookDeliveryCleanupCronConfig
+	webhookSignatureService signature.WebhookSignatureService
+}
+
+func NewWebhookDeliveryCleanupCronImpl(logger *zap.SugaredLogger, cfg *WebhookDeliveryCleanupCronConfig,
+	cronLogger *cron2.CronLoggerImpl, webhookSignatureService signature.WebhookSignatureService) *WebhookDeliveryCleanupCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &WebhookDeliveryCleanupCronImpl{
+		logger:                  logger,
+		cron:                    cron,
+		cfg:                     cfg,
+		webhookSignatureService: webhookSignatureService,
+	}
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.CleanupIntervalMins), impl.DeleteExpiredDeliveries)
+	if err != nil {
+		logger.Errorw("error while configure cron job for webhook delivery cleanup", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type WebhookDeliveryCleanupCronConfig struct {
+	CleanupIntervalMins int `env:"WEBHOOK_DELIVERY_CLEANUP_INTERVAL_MINS" envDefault:"60" description:"Interval in minutes at which webhook deliveries older than the retention period are deleted"`
+}
+
+func GetWebhookDeliveryCleanupCronConfig() (*WebhookDeliveryCleanupCronConfig, error) {
+	cfg := &WebhookDeliveryCleanupCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse webhook delivery cleanup cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *WebhookDeliveryCleanupCronImpl) DeleteExpiredDeliveries() {
+	impl.webhookSignatureService.DeleteExpiredDeliveries()
+}
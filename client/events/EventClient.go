@@ -81,19 +81,29 @@ type Event struct {
 }
 
 type Payload struct {
-	AppName               string                         `json:"appName"`
-	EnvName               string                         `json:"envName"`
-	PipelineName          string                         `json:"pipelineName"`
-	Source                string                         `json:"source"`
-	DockerImageUrl        string                         `json:"dockerImageUrl"`
-	TriggeredBy           string                         `json:"triggeredBy"`
-	Stage                 string                         `json:"stage"`
-	DeploymentHistoryLink string                         `json:"deploymentHistoryLink"`
-	AppDetailLink         string                         `json:"appDetailLink"`
-	DownloadLink          string                         `json:"downloadLink"`
-	BuildHistoryLink      string                         `json:"buildHistoryLink"`
-	MaterialTriggerInfo   *buildBean.MaterialTriggerInfo `json:"material"`
-	FailureReason         string                         `json:"failureReason"`
+	AppName                    string                         `json:"appName"`
+	EnvName                    string                         `json:"envName"`
+	PipelineName               string                         `json:"pipelineName"`
+	Source                     string                         `json:"source"`
+	DockerImageUrl             string                         `json:"dockerImageUrl"`
+	TriggeredBy                string                         `json:"triggeredBy"`
+	Stage                      string                         `json:"stage"`
+	DeploymentHistoryLink      string                         `json:"deploymentHistoryLink"`
+	AppDetailLink              string                         `json:"appDetailLink"`
+	DownloadLink               string                         `json:"downloadLink"`
+	BuildHistoryLink           string                         `json:"buildHistoryLink"`
+	MaterialTriggerInfo        *buildBean.MaterialTriggerInfo `json:"material"`
+	FailureReason              string                         `json:"failureReason"`
+	ImageApprovalLink          string                         `json:"imageApprovalLink,omitempty"`
+	ApprovalRequestStatus      string                         `json:"approvalRequestStatus,omitempty"`
+	ApprovalActionBy           string                         `json:"approvalActionBy,omitempty"`
+	ApprovalComment            string                         `json:"approvalComment,omitempty"`
+	AutoRollbackStatus         string                         `json:"autoRollbackStatus,omitempty"`
+	AutoRollbackMessage        string                         `json:"autoRollbackMessage,omitempty"`
+	FailedDockerImageUrl       string                         `json:"failedDockerImageUrl,omitempty"`
+	DriftSummary               string                         `json:"driftSummary,omitempty"`
+	ScheduledDeploymentStatus  string                         `json:"scheduledDeploymentStatus,omitempty"`
+	ScheduledDeploymentMessage string                         `json:"scheduledDeploymentMessage,omitempty"`
 }
 
 type EventRESTClientImpl struct {
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	client "github.com/devtron-labs/devtron/client/events"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventClient is an autogenerated mock type for the EventClient type
+type EventClient struct {
+	mock.Mock
+}
+
+// WriteNatsEvent provides a mock function with given fields: channel, payload
+func (_m *EventClient) WriteNatsEvent(channel string, payload interface{}) error {
+	ret := _m.Called(channel, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteNatsEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}) error); ok {
+		r0 = rf(channel, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WriteNotificationEvent provides a mock function with given fields: event
+func (_m *EventClient) WriteNotificationEvent(event client.Event) (bool, error) {
+	ret := _m.Called(event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteNotificationEvent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(client.Event) (bool, error)); ok {
+		return rf(event)
+	}
+	if rf, ok := ret.Get(0).(func(client.Event) bool); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(client.Event) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventClient creates a new instance of EventClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventClient {
+	mock := &EventClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	pipelinebean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
+
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+// EventFactory is an autogenerated mock type for the EventFactory type
+type EventFactory struct {
+	mock.Mock
+}
+
+// Build provides a mock function with given fields: eventType, sourceId, appId, envId, pipelineType
+func (_m *EventFactory) Build(eventType util.EventType, sourceId *int, appId int, envId *int, pipelineType util.PipelineType) (client.Event, error) {
+	ret := _m.Called(eventType, sourceId, appId, envId, pipelineType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Build")
+	}
+
+	var r0 client.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(util.EventType, *int, int, *int, util.PipelineType) (client.Event, error)); ok {
+		return rf(eventType, sourceId, appId, envId, pipelineType)
+	}
+	if rf, ok := ret.Get(0).(func(util.EventType, *int, int, *int, util.PipelineType) client.Event); ok {
+		r0 = rf(eventType, sourceId, appId, envId, pipelineType)
+	} else {
+		r0 = ret.Get(0).(client.Event)
+	}
+
+	if rf, ok := ret.Get(1).(func(util.EventType, *int, int, *int, util.PipelineType) error); ok {
+		r1 = rf(eventType, sourceId, appId, envId, pipelineType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BuildExtraCDData provides a mock function with given fields: event, wfr, pipelineOverrideId, stage
+func (_m *EventFactory) BuildExtraCDData(event client.Event, wfr *pipelineConfig.CdWorkflowRunner, pipelineOverrideId int, stage bean.WorkflowType) client.Event {
+	ret := _m.Called(event, wfr, pipelineOverrideId, stage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildExtraCDData")
+	}
+
+	var r0 client.Event
+	if rf, ok := ret.Get(0).(func(client.Event, *pipelineConfig.CdWorkflowRunner, int, bean.WorkflowType) client.Event); ok {
+		r0 = rf(event, wfr, pipelineOverrideId, stage)
+	} else {
+		r0 = ret.Get(0).(client.Event)
+	}
+
+	return r0
+}
+
+// BuildExtraCIData provides a mock function with given fields: event, material
+func (_m *EventFactory) BuildExtraCIData(event client.Event, material *pipelinebean.MaterialTriggerInfo) client.Event {
+	ret := _m.Called(event, material)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildExtraCIData")
+	}
+
+	var r0 client.Event
+	if rf, ok := ret.Get(0).(func(client.Event, *pipelinebean.MaterialTriggerInfo) client.Event); ok {
+		r0 = rf(event, material)
+	} else {
+		r0 = ret.Get(0).(client.Event)
+	}
+
+	return r0
+}
+
+// NewEventFactory creates a new instance of EventFactory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventFactory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventFactory {
+	mock := &EventFactory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pg "github.com/go-pg/pg"
+
+	time "time"
 )
 
 // AppRepository is an autogenerated mock type for the AppRepository type
@@ -20,6 +22,10 @@ type AppRepository struct {
 func (_m *AppRepository) CheckAppExists(appNames []string) ([]*app.App, error) {
 	ret := _m.Called(appNames)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAppExists")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]string) ([]*app.App, error)); ok {
@@ -42,10 +48,14 @@ func (_m *AppRepository) CheckAppExists(appNames []string) ([]*app.App, error) {
 	return r0, r1
 }
 
-// FetchAllActiveDevtronAppsWithAppIdAndName provides a mock function with given fields:
+// FetchAllActiveDevtronAppsWithAppIdAndName provides a mock function with no fields
 func (_m *AppRepository) FetchAllActiveDevtronAppsWithAppIdAndName() ([]*app.App, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllActiveDevtronAppsWithAppIdAndName")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
@@ -68,10 +78,14 @@ func (_m *AppRepository) FetchAllActiveDevtronAppsWithAppIdAndName() ([]*app.App
 	return r0, r1
 }
 
-// FetchAllActiveInstalledAppsWithAppIdAndName provides a mock function with given fields:
+// FetchAllActiveInstalledAppsWithAppIdAndName provides a mock function with no fields
 func (_m *AppRepository) FetchAllActiveInstalledAppsWithAppIdAndName() ([]*app.App, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllActiveInstalledAppsWithAppIdAndName")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
@@ -94,10 +108,53 @@ func (_m *AppRepository) FetchAllActiveInstalledAppsWithAppIdAndName() ([]*app.A
 	return r0, r1
 }
 
+// FetchAppIdsByDisplayNamesForJobs provides a mock function with given fields: names
+func (_m *AppRepository) FetchAppIdsByDisplayNamesForJobs(names []string) (map[int]string, []int, error) {
+	ret := _m.Called(names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAppIdsByDisplayNamesForJobs")
+	}
+
+	var r0 map[int]string
+	var r1 []int
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]string) (map[int]string, []int, error)); ok {
+		return rf(names)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[int]string); ok {
+		r0 = rf(names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) []int); ok {
+		r1 = rf(names)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func([]string) error); ok {
+		r2 = rf(names)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FetchAppIdsWithFilter provides a mock function with given fields: jobListingFilter
 func (_m *AppRepository) FetchAppIdsWithFilter(jobListingFilter helper.AppListingFilter) ([]int, error) {
 	ret := _m.Called(jobListingFilter)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAppIdsWithFilter")
+	}
+
 	var r0 []int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(helper.AppListingFilter) ([]int, error)); ok {
@@ -124,6 +181,10 @@ func (_m *AppRepository) FetchAppIdsWithFilter(jobListingFilter helper.AppListin
 func (_m *AppRepository) FetchAppsByFilterV2(appNameIncludes string, appNameExcludes string, environmentId int) ([]*app.App, error) {
 	ret := _m.Called(appNameIncludes, appNameExcludes, environmentId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAppsByFilterV2")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, string, int) ([]*app.App, error)); ok {
@@ -150,6 +211,10 @@ func (_m *AppRepository) FetchAppsByFilterV2(appNameIncludes string, appNameExcl
 func (_m *AppRepository) FindActiveById(id int) (*app.App, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveById")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*app.App, error)); ok {
@@ -176,6 +241,10 @@ func (_m *AppRepository) FindActiveById(id int) (*app.App, error) {
 func (_m *AppRepository) FindActiveByName(appName string) (*app.App, error) {
 	ret := _m.Called(appName)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByName")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*app.App, error)); ok {
@@ -202,6 +271,10 @@ func (_m *AppRepository) FindActiveByName(appName string) (*app.App, error) {
 func (_m *AppRepository) FindActiveListByName(appName string) ([]*app.App, error) {
 	ret := _m.Called(appName)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveListByName")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) ([]*app.App, error)); ok {
@@ -224,10 +297,14 @@ func (_m *AppRepository) FindActiveListByName(appName string) ([]*app.App, error
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields:
+// FindAll provides a mock function with no fields
 func (_m *AppRepository) FindAll() ([]*app.App, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
@@ -250,25 +327,89 @@ func (_m *AppRepository) FindAll() ([]*app.App, error) {
 	return r0, r1
 }
 
-// FindAllActiveAppsWithTeam provides a mock function with given fields:
-func (_m *AppRepository) FindAllActiveAppsWithTeam() ([]*app.App, error) {
-	ret := _m.Called()
+// FindAllActiveAppsWithTeam provides a mock function with given fields: appType
+func (_m *AppRepository) FindAllActiveAppsWithTeam(appType helper.AppType) ([]*app.App, error) {
+	ret := _m.Called(appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveAppsWithTeam")
+	}
 
 	var r0 []*app.App
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(helper.AppType) ([]*app.App, error)); ok {
+		return rf(appType)
 	}
-	if rf, ok := ret.Get(0).(func() []*app.App); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(helper.AppType) []*app.App); ok {
+		r0 = rf(appType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*app.App)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(helper.AppType) error); ok {
+		r1 = rf(appType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAllActiveAppsWithTeamByAppNameMatch provides a mock function with given fields: appNameMatch, appType
+func (_m *AppRepository) FindAllActiveAppsWithTeamByAppNameMatch(appNameMatch string, appType helper.AppType) ([]*app.App, error) {
+	ret := _m.Called(appNameMatch, appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveAppsWithTeamByAppNameMatch")
+	}
+
+	var r0 []*app.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, helper.AppType) ([]*app.App, error)); ok {
+		return rf(appNameMatch, appType)
+	}
+	if rf, ok := ret.Get(0).(func(string, helper.AppType) []*app.App); ok {
+		r0 = rf(appNameMatch, appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, helper.AppType) error); ok {
+		r1 = rf(appNameMatch, appType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAllActiveAppsWithTeamWithTeamId provides a mock function with given fields: teamID, appType
+func (_m *AppRepository) FindAllActiveAppsWithTeamWithTeamId(teamID int, appType helper.AppType) ([]*app.App, error) {
+	ret := _m.Called(teamID, appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveAppsWithTeamWithTeamId")
+	}
+
+	var r0 []*app.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, helper.AppType) ([]*app.App, error)); ok {
+		return rf(teamID, appType)
+	}
+	if rf, ok := ret.Get(0).(func(int, helper.AppType) []*app.App); ok {
+		r0 = rf(teamID, appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, helper.AppType) error); ok {
+		r1 = rf(teamID, appType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -276,17 +417,21 @@ func (_m *AppRepository) FindAllActiveAppsWithTeam() ([]*app.App, error) {
 	return r0, r1
 }
 
-// FindAllActiveAppsWithTeamByAppNameMatch provides a mock function with given fields: appNameMatch
-func (_m *AppRepository) FindAllActiveAppsWithTeamByAppNameMatch(appNameMatch string) ([]*app.App, error) {
-	ret := _m.Called(appNameMatch)
+// FindAllActiveByName provides a mock function with given fields: appName
+func (_m *AppRepository) FindAllActiveByName(appName string) ([]*app.App, error) {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveByName")
+	}
 
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) ([]*app.App, error)); ok {
-		return rf(appNameMatch)
+		return rf(appName)
 	}
 	if rf, ok := ret.Get(0).(func(string) []*app.App); ok {
-		r0 = rf(appNameMatch)
+		r0 = rf(appName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*app.App)
@@ -294,7 +439,7 @@ func (_m *AppRepository) FindAllActiveAppsWithTeamByAppNameMatch(appNameMatch st
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(appNameMatch)
+		r1 = rf(appName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -302,25 +447,89 @@ func (_m *AppRepository) FindAllActiveAppsWithTeamByAppNameMatch(appNameMatch st
 	return r0, r1
 }
 
-// FindAllActiveAppsWithTeamWithTeamId provides a mock function with given fields: teamID
-func (_m *AppRepository) FindAllActiveAppsWithTeamWithTeamId(teamID int) ([]*app.App, error) {
-	ret := _m.Called(teamID)
+// FindAllActiveChartStoreAppsInTimeRange provides a mock function with given fields: from, to
+func (_m *AppRepository) FindAllActiveChartStoreAppsInTimeRange(from *time.Time, to *time.Time) ([]*app.App, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveChartStoreAppsInTimeRange")
+	}
 
 	var r0 []*app.App
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]*app.App, error)); ok {
-		return rf(teamID)
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) ([]*app.App, error)); ok {
+		return rf(from, to)
 	}
-	if rf, ok := ret.Get(0).(func(int) []*app.App); ok {
-		r0 = rf(teamID)
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) []*app.App); ok {
+		r0 = rf(from, to)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*app.App)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(teamID)
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAllActiveDevtronAppsInTimeRange provides a mock function with given fields: from, to
+func (_m *AppRepository) FindAllActiveDevtronAppsInTimeRange(from *time.Time, to *time.Time) ([]*app.App, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllActiveDevtronAppsInTimeRange")
+	}
+
+	var r0 []*app.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) ([]*app.App, error)); ok {
+		return rf(from, to)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) []*app.App); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAllChartStoreApps provides a mock function with no fields
+func (_m *AppRepository) FindAllChartStoreApps() ([]*app.App, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllChartStoreApps")
+	}
+
+	var r0 []*app.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*app.App); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -332,6 +541,10 @@ func (_m *AppRepository) FindAllActiveAppsWithTeamWithTeamId(teamID int) ([]*app
 func (_m *AppRepository) FindAllMatchesByAppName(appName string, appType helper.AppType) ([]*app.App, error) {
 	ret := _m.Called(appName, appType)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllMatchesByAppName")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, helper.AppType) ([]*app.App, error)); ok {
@@ -358,6 +571,10 @@ func (_m *AppRepository) FindAllMatchesByAppName(appName string, appType helper.
 func (_m *AppRepository) FindAppAndProjectByAppId(appId int) (*app.App, error) {
 	ret := _m.Called(appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppAndProjectByAppId")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*app.App, error)); ok {
@@ -380,10 +597,44 @@ func (_m *AppRepository) FindAppAndProjectByAppId(appId int) (*app.App, error) {
 	return r0, r1
 }
 
+// FindAppAndProjectByAppIds provides a mock function with given fields: appIds
+func (_m *AppRepository) FindAppAndProjectByAppIds(appIds []*int) ([]*app.App, error) {
+	ret := _m.Called(appIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppAndProjectByAppIds")
+	}
+
+	var r0 []*app.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*int) ([]*app.App, error)); ok {
+		return rf(appIds)
+	}
+	if rf, ok := ret.Get(0).(func([]*int) []*app.App); ok {
+		r0 = rf(appIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*int) error); ok {
+		r1 = rf(appIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindAppAndProjectByAppName provides a mock function with given fields: appName
 func (_m *AppRepository) FindAppAndProjectByAppName(appName string) (*app.App, error) {
 	ret := _m.Called(appName)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppAndProjectByAppName")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*app.App, error)); ok {
@@ -410,6 +661,10 @@ func (_m *AppRepository) FindAppAndProjectByAppName(appName string) (*app.App, e
 func (_m *AppRepository) FindAppAndProjectByIdsIn(ids []int) ([]*app.App, error) {
 	ret := _m.Called(ids)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppAndProjectByIdsIn")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*app.App, error)); ok {
@@ -432,10 +687,42 @@ func (_m *AppRepository) FindAppAndProjectByIdsIn(ids []int) ([]*app.App, error)
 	return r0, r1
 }
 
+// FindAppIdByName provides a mock function with given fields: appName
+func (_m *AppRepository) FindAppIdByName(appName string) (int, error) {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppIdByName")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(appName)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(appName)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(appName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindAppsByEnvironmentId provides a mock function with given fields: environmentId
 func (_m *AppRepository) FindAppsByEnvironmentId(environmentId int) ([]app.App, error) {
 	ret := _m.Called(environmentId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppsByEnvironmentId")
+	}
+
 	var r0 []app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]app.App, error)); ok {
@@ -462,6 +749,10 @@ func (_m *AppRepository) FindAppsByEnvironmentId(environmentId int) ([]app.App,
 func (_m *AppRepository) FindAppsByTeamId(teamId int) ([]*app.App, error) {
 	ret := _m.Called(teamId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppsByTeamId")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*app.App, error)); ok {
@@ -488,6 +779,10 @@ func (_m *AppRepository) FindAppsByTeamId(teamId int) ([]*app.App, error) {
 func (_m *AppRepository) FindAppsByTeamIds(teamId []int, appType string) ([]app.App, error) {
 	ret := _m.Called(teamId, appType)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppsByTeamIds")
+	}
+
 	var r0 []app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int, string) ([]app.App, error)); ok {
@@ -514,6 +809,10 @@ func (_m *AppRepository) FindAppsByTeamIds(teamId []int, appType string) ([]app.
 func (_m *AppRepository) FindAppsByTeamName(teamName string) ([]app.App, error) {
 	ret := _m.Called(teamName)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppsByTeamName")
+	}
+
 	var r0 []app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) ([]app.App, error)); ok {
@@ -540,6 +839,10 @@ func (_m *AppRepository) FindAppsByTeamName(teamName string) ([]app.App, error)
 func (_m *AppRepository) FindById(id int) (*app.App, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindById")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*app.App, error)); ok {
@@ -566,6 +869,10 @@ func (_m *AppRepository) FindById(id int) (*app.App, error) {
 func (_m *AppRepository) FindByIds(ids []*int) ([]*app.App, error) {
 	ret := _m.Called(ids)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIds")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]*int) ([]*app.App, error)); ok {
@@ -592,6 +899,10 @@ func (_m *AppRepository) FindByIds(ids []*int) ([]*app.App, error) {
 func (_m *AppRepository) FindByNames(appNames []string) ([]*app.App, error) {
 	ret := _m.Called(appNames)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByNames")
+	}
+
 	var r0 []*app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]string) ([]*app.App, error)); ok {
@@ -614,10 +925,42 @@ func (_m *AppRepository) FindByNames(appNames []string) ([]*app.App, error) {
 	return r0, r1
 }
 
+// FindDevtronAppCount provides a mock function with no fields
+func (_m *AppRepository) FindDevtronAppCount() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDevtronAppCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindEnvironmentIdForInstalledApp provides a mock function with given fields: appId
 func (_m *AppRepository) FindEnvironmentIdForInstalledApp(appId int) (int, error) {
 	ret := _m.Called(appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindEnvironmentIdForInstalledApp")
+	}
+
 	var r0 int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
@@ -642,6 +985,10 @@ func (_m *AppRepository) FindEnvironmentIdForInstalledApp(appId int) (int, error
 func (_m *AppRepository) FindIdsByNames(appNames []string) ([]int, error) {
 	ret := _m.Called(appNames)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdsByNames")
+	}
+
 	var r0 []int
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]string) ([]int, error)); ok {
@@ -668,6 +1015,10 @@ func (_m *AppRepository) FindIdsByNames(appNames []string) ([]int, error) {
 func (_m *AppRepository) FindIdsByTeamIdsAndTeamNames(teamIds []int, teamNames []string) ([]int, error) {
 	ret := _m.Called(teamIds, teamNames)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdsByTeamIdsAndTeamNames")
+	}
+
 	var r0 []int
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int, []string) ([]int, error)); ok {
@@ -694,6 +1045,10 @@ func (_m *AppRepository) FindIdsByTeamIdsAndTeamNames(teamIds []int, teamNames [
 func (_m *AppRepository) FindJobByDisplayName(appName string) (*app.App, error) {
 	ret := _m.Called(appName)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindJobByDisplayName")
+	}
+
 	var r0 *app.App
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*app.App, error)); ok {
@@ -716,10 +1071,70 @@ func (_m *AppRepository) FindJobByDisplayName(appName string) (*app.App, error)
 	return r0, r1
 }
 
-// GetConnection provides a mock function with given fields:
+// FindJobCount provides a mock function with no fields
+func (_m *AppRepository) FindJobCount() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindJobCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveCiCdAppsCount provides a mock function with no fields
+func (_m *AppRepository) GetActiveCiCdAppsCount() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveCiCdAppsCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConnection provides a mock function with no fields
 func (_m *AppRepository) GetConnection() *pg.DB {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetConnection")
+	}
+
 	var r0 *pg.DB
 	if rf, ok := ret.Get(0).(func() *pg.DB); ok {
 		r0 = rf()
@@ -732,13 +1147,17 @@ func (_m *AppRepository) GetConnection() *pg.DB {
 	return r0
 }
 
-// Save provides a mock function with given fields: pipelineGroup
-func (_m *AppRepository) Save(pipelineGroup *app.App) error {
-	ret := _m.Called(pipelineGroup)
+// SaveWithTxn provides a mock function with given fields: pipelineGroup, tx
+func (_m *AppRepository) SaveWithTxn(pipelineGroup *app.App, tx *pg.Tx) error {
+	ret := _m.Called(pipelineGroup, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWithTxn")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*app.App) error); ok {
-		r0 = rf(pipelineGroup)
+	if rf, ok := ret.Get(0).(func(*app.App, *pg.Tx) error); ok {
+		r0 = rf(pipelineGroup, tx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -746,13 +1165,17 @@ func (_m *AppRepository) Save(pipelineGroup *app.App) error {
 	return r0
 }
 
-// SaveWithTxn provides a mock function with given fields: pipelineGroup, tx
-func (_m *AppRepository) SaveWithTxn(pipelineGroup *app.App, tx *pg.Tx) error {
-	ret := _m.Called(pipelineGroup, tx)
+// SetDescription provides a mock function with given fields: id, description, userId
+func (_m *AppRepository) SetDescription(id int, description string, userId int32) error {
+	ret := _m.Called(id, description, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDescription")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*app.App, *pg.Tx) error); ok {
-		r0 = rf(pipelineGroup, tx)
+	if rf, ok := ret.Get(0).(func(int, string, int32) error); ok {
+		r0 = rf(id, description, userId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -764,6 +1187,10 @@ func (_m *AppRepository) SaveWithTxn(pipelineGroup *app.App, tx *pg.Tx) error {
 func (_m *AppRepository) Update(_a0 *app.App) error {
 	ret := _m.Called(_a0)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*app.App) error); ok {
 		r0 = rf(_a0)
@@ -774,10 +1201,32 @@ func (_m *AppRepository) Update(_a0 *app.App) error {
 	return r0
 }
 
+// UpdateAppOfferingModeForAppIds provides a mock function with given fields: successAppIds, appOfferingMode, userId
+func (_m *AppRepository) UpdateAppOfferingModeForAppIds(successAppIds []*int, appOfferingMode string, userId int32) error {
+	ret := _m.Called(successAppIds, appOfferingMode, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAppOfferingModeForAppIds")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*int, string, int32) error); ok {
+		r0 = rf(successAppIds, appOfferingMode, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateWithTxn provides a mock function with given fields: _a0, tx
 func (_m *AppRepository) UpdateWithTxn(_a0 *app.App, tx *pg.Tx) error {
 	ret := _m.Called(_a0, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithTxn")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*app.App, *pg.Tx) error); ok {
 		r0 = rf(_a0, tx)
@@ -788,13 +1237,12 @@ func (_m *AppRepository) UpdateWithTxn(_a0 *app.App, tx *pg.Tx) error {
 	return r0
 }
 
-type mockConstructorTestingTNewAppRepository interface {
+// NewAppRepository creates a new instance of AppRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAppRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewAppRepository creates a new instance of AppRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewAppRepository(t mockConstructorTestingTNewAppRepository) *AppRepository {
+}) *AppRepository {
 	mock := &AppRepository{}
 	mock.Mock.Test(t)
 
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
@@ -14,10 +14,14 @@ type AppWorkflowRepository struct {
 	mock.Mock
 }
 
-// DeleteAppWorkflow provides a mock function with given fields: _a0, tx
-func (_m *AppWorkflowRepository) DeleteAppWorkflow(_a0 *appWorkflow.AppWorkflow, tx *pg.Tx) error {
+// DeleteAppWorkflowAndAllMappings provides a mock function with given fields: _a0, tx
+func (_m *AppWorkflowRepository) DeleteAppWorkflowAndAllMappings(_a0 *appWorkflow.AppWorkflow, tx *pg.Tx) error {
 	ret := _m.Called(_a0, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAppWorkflowAndAllMappings")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflow, *pg.Tx) error); ok {
 		r0 = rf(_a0, tx)
@@ -32,6 +36,10 @@ func (_m *AppWorkflowRepository) DeleteAppWorkflow(_a0 *appWorkflow.AppWorkflow,
 func (_m *AppWorkflowRepository) DeleteAppWorkflowMapping(_a0 *appWorkflow.AppWorkflowMapping, tx *pg.Tx) error {
 	ret := _m.Called(_a0, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAppWorkflowMapping")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflowMapping, *pg.Tx) error); ok {
 		r0 = rf(_a0, tx)
@@ -46,6 +54,10 @@ func (_m *AppWorkflowRepository) DeleteAppWorkflowMapping(_a0 *appWorkflow.AppWo
 func (_m *AppWorkflowRepository) DeleteAppWorkflowMappingsByCdPipelineId(pipelineId int, tx *pg.Tx) error {
 	ret := _m.Called(pipelineId, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAppWorkflowMappingsByCdPipelineId")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(int, *pg.Tx) error); ok {
 		r0 = rf(pipelineId, tx)
@@ -60,6 +72,10 @@ func (_m *AppWorkflowRepository) DeleteAppWorkflowMappingsByCdPipelineId(pipelin
 func (_m *AppWorkflowRepository) FindAllWFMappingsByAppId(appId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllWFMappingsByAppId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -86,6 +102,10 @@ func (_m *AppWorkflowRepository) FindAllWFMappingsByAppId(appId int) ([]*appWork
 func (_m *AppWorkflowRepository) FindAllWfsHavingCdPipelinesFromSpecificEnvsOnly(envIds []int, appIds []int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(envIds, appIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllWfsHavingCdPipelinesFromSpecificEnvsOnly")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int, []int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -112,6 +132,10 @@ func (_m *AppWorkflowRepository) FindAllWfsHavingCdPipelinesFromSpecificEnvsOnly
 func (_m *AppWorkflowRepository) FindByAppId(appId int) ([]*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAppId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflow, error)); ok {
@@ -138,6 +162,10 @@ func (_m *AppWorkflowRepository) FindByAppId(appId int) ([]*appWorkflow.AppWorkf
 func (_m *AppWorkflowRepository) FindByAppIds(appIds []int) ([]*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(appIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAppIds")
+	}
+
 	var r0 []*appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflow, error)); ok {
@@ -164,6 +192,10 @@ func (_m *AppWorkflowRepository) FindByAppIds(appIds []int) ([]*appWorkflow.AppW
 func (_m *AppWorkflowRepository) FindByCDPipelineIds(cdPipelineIds []int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(cdPipelineIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCDPipelineIds")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -190,6 +222,10 @@ func (_m *AppWorkflowRepository) FindByCDPipelineIds(cdPipelineIds []int) ([]*ap
 func (_m *AppWorkflowRepository) FindByComponent(id int, componentType string) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(id, componentType)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByComponent")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, string) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -212,10 +248,44 @@ func (_m *AppWorkflowRepository) FindByComponent(id int, componentType string) (
 	return r0, r1
 }
 
+// FindByComponentId provides a mock function with given fields: componentId
+func (_m *AppWorkflowRepository) FindByComponentId(componentId int) ([]*appWorkflow.AppWorkflowMapping, error) {
+	ret := _m.Called(componentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByComponentId")
+	}
+
+	var r0 []*appWorkflow.AppWorkflowMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
+		return rf(componentId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*appWorkflow.AppWorkflowMapping); ok {
+		r0 = rf(componentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*appWorkflow.AppWorkflowMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(componentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindById provides a mock function with given fields: id
 func (_m *AppWorkflowRepository) FindById(id int) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindById")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*appWorkflow.AppWorkflow, error)); ok {
@@ -242,6 +312,10 @@ func (_m *AppWorkflowRepository) FindById(id int) (*appWorkflow.AppWorkflow, err
 func (_m *AppWorkflowRepository) FindByIdAndAppId(id int, appId int) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(id, appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIdAndAppId")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, int) (*appWorkflow.AppWorkflow, error)); ok {
@@ -268,6 +342,10 @@ func (_m *AppWorkflowRepository) FindByIdAndAppId(id int, appId int) (*appWorkfl
 func (_m *AppWorkflowRepository) FindByIds(ids []int) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(ids)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIds")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) (*appWorkflow.AppWorkflow, error)); ok {
@@ -294,6 +372,10 @@ func (_m *AppWorkflowRepository) FindByIds(ids []int) (*appWorkflow.AppWorkflow,
 func (_m *AppWorkflowRepository) FindByNameAndAppId(name string, appId int) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(name, appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByNameAndAppId")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, int) (*appWorkflow.AppWorkflow, error)); ok {
@@ -320,6 +402,10 @@ func (_m *AppWorkflowRepository) FindByNameAndAppId(name string, appId int) (*ap
 func (_m *AppWorkflowRepository) FindByTypeAndComponentId(wfId int, componentId int, componentType string) (*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(wfId, componentId, componentType)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTypeAndComponentId")
+	}
+
 	var r0 *appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, int, string) (*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -346,6 +432,10 @@ func (_m *AppWorkflowRepository) FindByTypeAndComponentId(wfId int, componentId
 func (_m *AppWorkflowRepository) FindByWorkflowId(workflowId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(workflowId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByWorkflowId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -372,6 +462,10 @@ func (_m *AppWorkflowRepository) FindByWorkflowId(workflowId int) ([]*appWorkflo
 func (_m *AppWorkflowRepository) FindByWorkflowIds(workflowIds []int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(workflowIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByWorkflowIds")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -398,6 +492,10 @@ func (_m *AppWorkflowRepository) FindByWorkflowIds(workflowIds []int) ([]*appWor
 func (_m *AppWorkflowRepository) FindChildCDIdsByParentCDPipelineId(cdPipelineId int) ([]int, error) {
 	ret := _m.Called(cdPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindChildCDIdsByParentCDPipelineId")
+	}
+
 	var r0 []int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]int, error)); ok {
@@ -424,6 +522,10 @@ func (_m *AppWorkflowRepository) FindChildCDIdsByParentCDPipelineId(cdPipelineId
 func (_m *AppWorkflowRepository) FindCiPipelineIdsFromAppWfIds(appWfIds []int) ([]int, error) {
 	ret := _m.Called(appWfIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindCiPipelineIdsFromAppWfIds")
+	}
+
 	var r0 []int
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]int, error)); ok {
@@ -450,6 +552,10 @@ func (_m *AppWorkflowRepository) FindCiPipelineIdsFromAppWfIds(appWfIds []int) (
 func (_m *AppWorkflowRepository) FindMappingByAppIds(appIds []int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(appIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindMappingByAppIds")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -476,6 +582,10 @@ func (_m *AppWorkflowRepository) FindMappingByAppIds(appIds []int) ([]*appWorkfl
 func (_m *AppWorkflowRepository) FindWFAllMappingByWorkflowId(workflowId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(workflowId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFAllMappingByWorkflowId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -502,6 +612,10 @@ func (_m *AppWorkflowRepository) FindWFAllMappingByWorkflowId(workflowId int) ([
 func (_m *AppWorkflowRepository) FindWFCDMappingByCDPipelineId(cdPipelineId int) (*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(cdPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByCDPipelineId")
+	}
+
 	var r0 *appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -528,6 +642,10 @@ func (_m *AppWorkflowRepository) FindWFCDMappingByCDPipelineId(cdPipelineId int)
 func (_m *AppWorkflowRepository) FindWFCDMappingByCIPipelineId(ciPipelineId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(ciPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByCIPipelineId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -554,6 +672,10 @@ func (_m *AppWorkflowRepository) FindWFCDMappingByCIPipelineId(ciPipelineId int)
 func (_m *AppWorkflowRepository) FindWFCDMappingByCIPipelineIds(ciPipelineIds []int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(ciPipelineIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByCIPipelineIds")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -580,6 +702,10 @@ func (_m *AppWorkflowRepository) FindWFCDMappingByCIPipelineIds(ciPipelineIds []
 func (_m *AppWorkflowRepository) FindWFCDMappingByExternalCiId(externalCiId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(externalCiId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByExternalCiId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -602,10 +728,44 @@ func (_m *AppWorkflowRepository) FindWFCDMappingByExternalCiId(externalCiId int)
 	return r0, r1
 }
 
+// FindWFCDMappingByExternalCiIdByIdsIn provides a mock function with given fields: externalCiId
+func (_m *AppWorkflowRepository) FindWFCDMappingByExternalCiIdByIdsIn(externalCiId []int) ([]*appWorkflow.AppWorkflowMapping, error) {
+	ret := _m.Called(externalCiId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByExternalCiIdByIdsIn")
+	}
+
+	var r0 []*appWorkflow.AppWorkflowMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
+		return rf(externalCiId)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*appWorkflow.AppWorkflowMapping); ok {
+		r0 = rf(externalCiId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*appWorkflow.AppWorkflowMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(externalCiId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindWFCDMappingByParentCDPipelineId provides a mock function with given fields: cdPipelineId
 func (_m *AppWorkflowRepository) FindWFCDMappingByParentCDPipelineId(cdPipelineId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(cdPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingByParentCDPipelineId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -628,10 +788,44 @@ func (_m *AppWorkflowRepository) FindWFCDMappingByParentCDPipelineId(cdPipelineI
 	return r0, r1
 }
 
+// FindWFCDMappingsByWorkflowId provides a mock function with given fields: appWorkflowId
+func (_m *AppWorkflowRepository) FindWFCDMappingsByWorkflowId(appWorkflowId int) ([]*appWorkflow.AppWorkflowMapping, error) {
+	ret := _m.Called(appWorkflowId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCDMappingsByWorkflowId")
+	}
+
+	var r0 []*appWorkflow.AppWorkflowMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
+		return rf(appWorkflowId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*appWorkflow.AppWorkflowMapping); ok {
+		r0 = rf(appWorkflowId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*appWorkflow.AppWorkflowMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(appWorkflowId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindWFCIMappingByCIPipelineId provides a mock function with given fields: ciPipelineId
 func (_m *AppWorkflowRepository) FindWFCIMappingByCIPipelineId(ciPipelineId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(ciPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCIMappingByCIPipelineId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -658,6 +852,10 @@ func (_m *AppWorkflowRepository) FindWFCIMappingByCIPipelineId(ciPipelineId int)
 func (_m *AppWorkflowRepository) FindWFCIMappingByWorkflowId(workflowId int) ([]*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(workflowId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFCIMappingByWorkflowId")
+	}
+
 	var r0 []*appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -680,10 +878,44 @@ func (_m *AppWorkflowRepository) FindWFCIMappingByWorkflowId(workflowId int) ([]
 	return r0, r1
 }
 
+// FindWFMappingByComponent provides a mock function with given fields: componentType, componentId
+func (_m *AppWorkflowRepository) FindWFMappingByComponent(componentType string, componentId int) (*appWorkflow.AppWorkflowMapping, error) {
+	ret := _m.Called(componentType, componentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWFMappingByComponent")
+	}
+
+	var r0 *appWorkflow.AppWorkflowMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (*appWorkflow.AppWorkflowMapping, error)); ok {
+		return rf(componentType, componentId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) *appWorkflow.AppWorkflowMapping); ok {
+		r0 = rf(componentType, componentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appWorkflow.AppWorkflowMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(componentType, componentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetParentDetailsByPipelineId provides a mock function with given fields: pipelineId
 func (_m *AppWorkflowRepository) GetParentDetailsByPipelineId(pipelineId int) (*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(pipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentDetailsByPipelineId")
+	}
+
 	var r0 *appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -710,6 +942,10 @@ func (_m *AppWorkflowRepository) GetParentDetailsByPipelineId(pipelineId int) (*
 func (_m *AppWorkflowRepository) SaveAppWorkflow(wf *appWorkflow.AppWorkflow) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(wf)
 
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAppWorkflow")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflow) (*appWorkflow.AppWorkflow, error)); ok {
@@ -736,6 +972,10 @@ func (_m *AppWorkflowRepository) SaveAppWorkflow(wf *appWorkflow.AppWorkflow) (*
 func (_m *AppWorkflowRepository) SaveAppWorkflowMapping(wf *appWorkflow.AppWorkflowMapping, tx *pg.Tx) (*appWorkflow.AppWorkflowMapping, error) {
 	ret := _m.Called(wf, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAppWorkflowMapping")
+	}
+
 	var r0 *appWorkflow.AppWorkflowMapping
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflowMapping, *pg.Tx) (*appWorkflow.AppWorkflowMapping, error)); ok {
@@ -762,6 +1002,10 @@ func (_m *AppWorkflowRepository) SaveAppWorkflowMapping(wf *appWorkflow.AppWorkf
 func (_m *AppWorkflowRepository) SaveAppWorkflowWithTx(wf *appWorkflow.AppWorkflow, tx *pg.Tx) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(wf, tx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAppWorkflowWithTx")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflow, *pg.Tx) (*appWorkflow.AppWorkflow, error)); ok {
@@ -788,6 +1032,10 @@ func (_m *AppWorkflowRepository) SaveAppWorkflowWithTx(wf *appWorkflow.AppWorkfl
 func (_m *AppWorkflowRepository) UpdateAppWorkflow(wf *appWorkflow.AppWorkflow) (*appWorkflow.AppWorkflow, error) {
 	ret := _m.Called(wf)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAppWorkflow")
+	}
+
 	var r0 *appWorkflow.AppWorkflow
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*appWorkflow.AppWorkflow) (*appWorkflow.AppWorkflow, error)); ok {
@@ -810,13 +1058,30 @@ func (_m *AppWorkflowRepository) UpdateAppWorkflow(wf *appWorkflow.AppWorkflow)
 	return r0, r1
 }
 
-type mockConstructorTestingTNewAppWorkflowRepository interface {
-	mock.TestingT
-	Cleanup(func())
+// UpdateParentComponentDetails provides a mock function with given fields: tx, oldComponentId, oldComponentType, newComponentId, newComponentType, componentIdsFilter
+func (_m *AppWorkflowRepository) UpdateParentComponentDetails(tx *pg.Tx, oldComponentId int, oldComponentType string, newComponentId int, newComponentType string, componentIdsFilter []int) error {
+	ret := _m.Called(tx, oldComponentId, oldComponentType, newComponentId, newComponentType, componentIdsFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateParentComponentDetails")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, string, int, string, []int) error); ok {
+		r0 = rf(tx, oldComponentId, oldComponentType, newComponentId, newComponentType, componentIdsFilter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewAppWorkflowRepository creates a new instance of AppWorkflowRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewAppWorkflowRepository(t mockConstructorTestingTNewAppWorkflowRepository) *AppWorkflowRepository {
+// The first argument is typically a *testing.T value.
+func NewAppWorkflowRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AppWorkflowRepository {
 	mock := &AppWorkflowRepository{}
 	mock.Mock.Test(t)
 
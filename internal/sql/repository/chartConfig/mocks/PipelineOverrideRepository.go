@@ -1,12 +1,19 @@
-// Code generated by mockery v2.14.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
 import (
+	context "context"
+
 	chartConfig "github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/devtron-labs/devtron/internal/sql/models"
+
+	pg "github.com/go-pg/pg"
+
+	time "time"
 )
 
 // PipelineOverrideRepository is an autogenerated mock type for the PipelineOverrideRepository type
@@ -14,22 +21,29 @@ type PipelineOverrideRepository struct {
 	mock.Mock
 }
 
-// FetchHelmTypePipelineOverridesForStatusUpdate provides a mock function with given fields:
-func (_m *PipelineOverrideRepository) FetchHelmTypePipelineOverridesForStatusUpdate() ([]*chartConfig.PipelineOverride, error) {
-	ret := _m.Called()
+// FindById provides a mock function with given fields: id
+func (_m *PipelineOverrideRepository) FindById(id int) (*chartConfig.PipelineOverride, error) {
+	ret := _m.Called(id)
 
-	var r0 []*chartConfig.PipelineOverride
-	if rf, ok := ret.Get(0).(func() []*chartConfig.PipelineOverride); ok {
-		r0 = rf()
+	if len(ret) == 0 {
+		panic("no return value specified for FindById")
+	}
+
+	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *chartConfig.PipelineOverride); ok {
+		r0 = rf(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*chartConfig.PipelineOverride)
+			r0 = ret.Get(0).(*chartConfig.PipelineOverride)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -37,22 +51,29 @@ func (_m *PipelineOverrideRepository) FetchHelmTypePipelineOverridesForStatusUpd
 	return r0, r1
 }
 
-// FindById provides a mock function with given fields: id
-func (_m *PipelineOverrideRepository) FindById(id int) (*chartConfig.PipelineOverride, error) {
-	ret := _m.Called(id)
+// FindByPipelineLikeTriggerGitHash provides a mock function with given fields: gitHash
+func (_m *PipelineOverrideRepository) FindByPipelineLikeTriggerGitHash(gitHash string) (*chartConfig.PipelineOverride, error) {
+	ret := _m.Called(gitHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPipelineLikeTriggerGitHash")
+	}
 
 	var r0 *chartConfig.PipelineOverride
-	if rf, ok := ret.Get(0).(func(int) *chartConfig.PipelineOverride); ok {
-		r0 = rf(id)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(gitHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *chartConfig.PipelineOverride); ok {
+		r0 = rf(gitHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*chartConfig.PipelineOverride)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(gitHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,7 +85,15 @@ func (_m *PipelineOverrideRepository) FindById(id int) (*chartConfig.PipelineOve
 func (_m *PipelineOverrideRepository) FindByPipelineTriggerGitHash(gitHash string) (*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(gitHash)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPipelineTriggerGitHash")
+	}
+
 	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(gitHash)
+	}
 	if rf, ok := ret.Get(0).(func(string) *chartConfig.PipelineOverride); ok {
 		r0 = rf(gitHash)
 	} else {
@@ -73,7 +102,6 @@ func (_m *PipelineOverrideRepository) FindByPipelineTriggerGitHash(gitHash strin
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
 		r1 = rf(gitHash)
 	} else {
@@ -83,22 +111,59 @@ func (_m *PipelineOverrideRepository) FindByPipelineTriggerGitHash(gitHash strin
 	return r0, r1
 }
 
-// FindLatestByAppIdAndEnvId provides a mock function with given fields: appId, environmentId
-func (_m *PipelineOverrideRepository) FindLatestByAppIdAndEnvId(appId int, environmentId int) (*chartConfig.PipelineOverride, error) {
-	ret := _m.Called(appId, environmentId)
+// FindLatestByAppIdAndEnvId provides a mock function with given fields: appId, environmentId, deploymentAppType
+func (_m *PipelineOverrideRepository) FindLatestByAppIdAndEnvId(appId int, environmentId int, deploymentAppType string) (*chartConfig.PipelineOverride, error) {
+	ret := _m.Called(appId, environmentId, deploymentAppType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestByAppIdAndEnvId")
+	}
 
 	var r0 *chartConfig.PipelineOverride
-	if rf, ok := ret.Get(0).(func(int, int) *chartConfig.PipelineOverride); ok {
-		r0 = rf(appId, environmentId)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, string) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(appId, environmentId, deploymentAppType)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, string) *chartConfig.PipelineOverride); ok {
+		r0 = rf(appId, environmentId, deploymentAppType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*chartConfig.PipelineOverride)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(appId, environmentId, deploymentAppType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestByCdWorkflowId provides a mock function with given fields: cdWorkflowId
+func (_m *PipelineOverrideRepository) FindLatestByCdWorkflowId(cdWorkflowId int) (*chartConfig.PipelineOverride, error) {
+	ret := _m.Called(cdWorkflowId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestByCdWorkflowId")
+	}
+
+	var r0 *chartConfig.PipelineOverride
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(appId, environmentId)
+	if rf, ok := ret.Get(0).(func(int) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(cdWorkflowId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *chartConfig.PipelineOverride); ok {
+		r0 = rf(cdWorkflowId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chartConfig.PipelineOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(cdWorkflowId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,7 +175,15 @@ func (_m *PipelineOverrideRepository) FindLatestByAppIdAndEnvId(appId int, envir
 func (_m *PipelineOverrideRepository) GetAllRelease(appId int, environmentId int) ([]*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(appId, environmentId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllRelease")
+	}
+
 	var r0 []*chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]*chartConfig.PipelineOverride, error)); ok {
+		return rf(appId, environmentId)
+	}
 	if rf, ok := ret.Get(0).(func(int, int) []*chartConfig.PipelineOverride); ok {
 		r0 = rf(appId, environmentId)
 	} else {
@@ -119,7 +192,6 @@ func (_m *PipelineOverrideRepository) GetAllRelease(appId int, environmentId int
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int, int) error); ok {
 		r1 = rf(appId, environmentId)
 	} else {
@@ -133,7 +205,15 @@ func (_m *PipelineOverrideRepository) GetAllRelease(appId int, environmentId int
 func (_m *PipelineOverrideRepository) GetByDeployedImage(appId int, environmentId int, images []string) (*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(appId, environmentId, images)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByDeployedImage")
+	}
+
 	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, []string) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(appId, environmentId, images)
+	}
 	if rf, ok := ret.Get(0).(func(int, int, []string) *chartConfig.PipelineOverride); ok {
 		r0 = rf(appId, environmentId, images)
 	} else {
@@ -142,7 +222,6 @@ func (_m *PipelineOverrideRepository) GetByDeployedImage(appId int, environmentI
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int, int, []string) error); ok {
 		r1 = rf(appId, environmentId, images)
 	} else {
@@ -156,7 +235,15 @@ func (_m *PipelineOverrideRepository) GetByDeployedImage(appId int, environmentI
 func (_m *PipelineOverrideRepository) GetByPipelineIdAndReleaseNo(pipelineId int, releaseNo int) ([]*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(pipelineId, releaseNo)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPipelineIdAndReleaseNo")
+	}
+
 	var r0 []*chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]*chartConfig.PipelineOverride, error)); ok {
+		return rf(pipelineId, releaseNo)
+	}
 	if rf, ok := ret.Get(0).(func(int, int) []*chartConfig.PipelineOverride); ok {
 		r0 = rf(pipelineId, releaseNo)
 	} else {
@@ -165,7 +252,6 @@ func (_m *PipelineOverrideRepository) GetByPipelineIdAndReleaseNo(pipelineId int
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int, int) error); ok {
 		r1 = rf(pipelineId, releaseNo)
 	} else {
@@ -179,14 +265,21 @@ func (_m *PipelineOverrideRepository) GetByPipelineIdAndReleaseNo(pipelineId int
 func (_m *PipelineOverrideRepository) GetCurrentPipelineReleaseCounter(pipelineId int) (int, error) {
 	ret := _m.Called(pipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentPipelineReleaseCounter")
+	}
+
 	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return rf(pipelineId)
+	}
 	if rf, ok := ret.Get(0).(func(int) int); ok {
 		r0 = rf(pipelineId)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int) error); ok {
 		r1 = rf(pipelineId)
 	} else {
@@ -200,7 +293,15 @@ func (_m *PipelineOverrideRepository) GetCurrentPipelineReleaseCounter(pipelineI
 func (_m *PipelineOverrideRepository) GetLatestConfigByEnvironmentConfigOverrideId(envConfigOverrideId int) (*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(envConfigOverrideId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestConfigByEnvironmentConfigOverrideId")
+	}
+
 	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(envConfigOverrideId)
+	}
 	if rf, ok := ret.Get(0).(func(int) *chartConfig.PipelineOverride); ok {
 		r0 = rf(envConfigOverrideId)
 	} else {
@@ -209,7 +310,6 @@ func (_m *PipelineOverrideRepository) GetLatestConfigByEnvironmentConfigOverride
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int) error); ok {
 		r1 = rf(envConfigOverrideId)
 	} else {
@@ -223,7 +323,15 @@ func (_m *PipelineOverrideRepository) GetLatestConfigByEnvironmentConfigOverride
 func (_m *PipelineOverrideRepository) GetLatestConfigByRequestIdentifier(requestIdentifier string) (*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(requestIdentifier)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestConfigByRequestIdentifier")
+	}
+
 	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(requestIdentifier)
+	}
 	if rf, ok := ret.Get(0).(func(string) *chartConfig.PipelineOverride); ok {
 		r0 = rf(requestIdentifier)
 	} else {
@@ -232,7 +340,6 @@ func (_m *PipelineOverrideRepository) GetLatestConfigByRequestIdentifier(request
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
 		r1 = rf(requestIdentifier)
 	} else {
@@ -246,7 +353,15 @@ func (_m *PipelineOverrideRepository) GetLatestConfigByRequestIdentifier(request
 func (_m *PipelineOverrideRepository) GetLatestRelease(appId int, environmentId int) (*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(appId, environmentId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestRelease")
+	}
+
 	var r0 *chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*chartConfig.PipelineOverride, error)); ok {
+		return rf(appId, environmentId)
+	}
 	if rf, ok := ret.Get(0).(func(int, int) *chartConfig.PipelineOverride); ok {
 		r0 = rf(appId, environmentId)
 	} else {
@@ -255,7 +370,6 @@ func (_m *PipelineOverrideRepository) GetLatestRelease(appId int, environmentId
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int, int) error); ok {
 		r1 = rf(appId, environmentId)
 	} else {
@@ -269,7 +383,15 @@ func (_m *PipelineOverrideRepository) GetLatestRelease(appId int, environmentId
 func (_m *PipelineOverrideRepository) GetLatestReleaseByPipelineIds(pipelineIds []int) ([]*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(pipelineIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestReleaseByPipelineIds")
+	}
+
 	var r0 []*chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*chartConfig.PipelineOverride, error)); ok {
+		return rf(pipelineIds)
+	}
 	if rf, ok := ret.Get(0).(func([]int) []*chartConfig.PipelineOverride); ok {
 		r0 = rf(pipelineIds)
 	} else {
@@ -278,7 +400,6 @@ func (_m *PipelineOverrideRepository) GetLatestReleaseByPipelineIds(pipelineIds
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func([]int) error); ok {
 		r1 = rf(pipelineIds)
 	} else {
@@ -292,7 +413,15 @@ func (_m *PipelineOverrideRepository) GetLatestReleaseByPipelineIds(pipelineIds
 func (_m *PipelineOverrideRepository) GetLatestReleaseDeploymentType(pipelineIds []int) ([]*chartConfig.PipelineOverride, error) {
 	ret := _m.Called(pipelineIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestReleaseDeploymentType")
+	}
+
 	var r0 []*chartConfig.PipelineOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*chartConfig.PipelineOverride, error)); ok {
+		return rf(pipelineIds)
+	}
 	if rf, ok := ret.Get(0).(func([]int) []*chartConfig.PipelineOverride); ok {
 		r0 = rf(pipelineIds)
 	} else {
@@ -301,7 +430,6 @@ func (_m *PipelineOverrideRepository) GetLatestReleaseDeploymentType(pipelineIds
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func([]int) error); ok {
 		r1 = rf(pipelineIds)
 	} else {
@@ -311,10 +439,44 @@ func (_m *PipelineOverrideRepository) GetLatestReleaseDeploymentType(pipelineIds
 	return r0, r1
 }
 
+// GetLatestReleaseForAppIds provides a mock function with given fields: appIds, envId
+func (_m *PipelineOverrideRepository) GetLatestReleaseForAppIds(appIds []int, envId int) ([]*chartConfig.PipelineConfigOverrideMetadata, error) {
+	ret := _m.Called(appIds, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestReleaseForAppIds")
+	}
+
+	var r0 []*chartConfig.PipelineConfigOverrideMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int, int) ([]*chartConfig.PipelineConfigOverrideMetadata, error)); ok {
+		return rf(appIds, envId)
+	}
+	if rf, ok := ret.Get(0).(func([]int, int) []*chartConfig.PipelineConfigOverrideMetadata); ok {
+		r0 = rf(appIds, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*chartConfig.PipelineConfigOverrideMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int, int) error); ok {
+		r1 = rf(appIds, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: _a0
 func (_m *PipelineOverrideRepository) Save(_a0 *chartConfig.PipelineOverride) error {
 	ret := _m.Called(_a0)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*chartConfig.PipelineOverride) error); ok {
 		r0 = rf(_a0)
@@ -329,6 +491,10 @@ func (_m *PipelineOverrideRepository) Save(_a0 *chartConfig.PipelineOverride) er
 func (_m *PipelineOverrideRepository) Update(pipelineOverride *chartConfig.PipelineOverride) error {
 	ret := _m.Called(pipelineOverride)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*chartConfig.PipelineOverride) error); ok {
 		r0 = rf(pipelineOverride)
@@ -339,18 +505,61 @@ func (_m *PipelineOverrideRepository) Update(pipelineOverride *chartConfig.Pipel
 	return r0
 }
 
+// UpdateCommitDetails provides a mock function with given fields: ctx, tx, id, gitHash, commitTime, userId
+func (_m *PipelineOverrideRepository) UpdateCommitDetails(ctx context.Context, tx *pg.Tx, id int, gitHash string, commitTime time.Time, userId int32) error {
+	ret := _m.Called(ctx, tx, id, gitHash, commitTime, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCommitDetails")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pg.Tx, int, string, time.Time, int32) error); ok {
+		r0 = rf(ctx, tx, id, gitHash, commitTime, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePipelineMergedValues provides a mock function with given fields: ctx, tx, id, pipelineMergedValues, userId
+func (_m *PipelineOverrideRepository) UpdatePipelineMergedValues(ctx context.Context, tx *pg.Tx, id int, pipelineMergedValues string, userId int32) error {
+	ret := _m.Called(ctx, tx, id, pipelineMergedValues, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePipelineMergedValues")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pg.Tx, int, string, int32) error); ok {
+		r0 = rf(ctx, tx, id, pipelineMergedValues, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateStatusByRequestIdentifier provides a mock function with given fields: requestId, newStatus
 func (_m *PipelineOverrideRepository) UpdateStatusByRequestIdentifier(requestId string, newStatus models.ChartStatus) (int, error) {
 	ret := _m.Called(requestId, newStatus)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusByRequestIdentifier")
+	}
+
 	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, models.ChartStatus) (int, error)); ok {
+		return rf(requestId, newStatus)
+	}
 	if rf, ok := ret.Get(0).(func(string, models.ChartStatus) int); ok {
 		r0 = rf(requestId, newStatus)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(string, models.ChartStatus) error); ok {
 		r1 = rf(requestId, newStatus)
 	} else {
@@ -360,13 +569,12 @@ func (_m *PipelineOverrideRepository) UpdateStatusByRequestIdentifier(requestId
 	return r0, r1
 }
 
-type mockConstructorTestingTNewPipelineOverrideRepository interface {
+// NewPipelineOverrideRepository creates a new instance of PipelineOverrideRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPipelineOverrideRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewPipelineOverrideRepository creates a new instance of PipelineOverrideRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewPipelineOverrideRepository(t mockConstructorTestingTNewPipelineOverrideRepository) *PipelineOverrideRepository {
+}) *PipelineOverrideRepository {
 	mock := &PipelineOverrideRepository{}
 	mock.Mock.Test(t)
 
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
@@ -18,6 +18,10 @@ type CiArtifactRepository struct {
 func (_m *CiArtifactRepository) Delete(artifact *repository.CiArtifact) error {
 	ret := _m.Called(artifact)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*repository.CiArtifact) error); ok {
 		r0 = rf(artifact)
@@ -28,10 +32,51 @@ func (_m *CiArtifactRepository) Delete(artifact *repository.CiArtifact) error {
 	return r0
 }
 
+// FetchArtifactsByCdPipelineIdV2 provides a mock function with given fields: listingFilterOptions
+func (_m *CiArtifactRepository) FetchArtifactsByCdPipelineIdV2(listingFilterOptions bean.ArtifactsListFilterOptions) ([]repository.CiArtifactWithExtraData, int, error) {
+	ret := _m.Called(listingFilterOptions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchArtifactsByCdPipelineIdV2")
+	}
+
+	var r0 []repository.CiArtifactWithExtraData
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(bean.ArtifactsListFilterOptions) ([]repository.CiArtifactWithExtraData, int, error)); ok {
+		return rf(listingFilterOptions)
+	}
+	if rf, ok := ret.Get(0).(func(bean.ArtifactsListFilterOptions) []repository.CiArtifactWithExtraData); ok {
+		r0 = rf(listingFilterOptions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CiArtifactWithExtraData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bean.ArtifactsListFilterOptions) int); ok {
+		r1 = rf(listingFilterOptions)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(bean.ArtifactsListFilterOptions) error); ok {
+		r2 = rf(listingFilterOptions)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FinDByParentCiArtifactAndCiId provides a mock function with given fields: parentCiArtifact, ciPipelineIds
 func (_m *CiArtifactRepository) FinDByParentCiArtifactAndCiId(parentCiArtifact int, ciPipelineIds []int) ([]*repository.CiArtifact, error) {
 	ret := _m.Called(parentCiArtifact, ciPipelineIds)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FinDByParentCiArtifactAndCiId")
+	}
+
 	var r0 []*repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, []int) ([]*repository.CiArtifact, error)); ok {
@@ -54,10 +99,81 @@ func (_m *CiArtifactRepository) FinDByParentCiArtifactAndCiId(parentCiArtifact i
 	return r0, r1
 }
 
+// FindArtifactByListFilter provides a mock function with given fields: listingFilterOptions
+func (_m *CiArtifactRepository) FindArtifactByListFilter(listingFilterOptions *bean.ArtifactsListFilterOptions) ([]*repository.CiArtifact, int, error) {
+	ret := _m.Called(listingFilterOptions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindArtifactByListFilter")
+	}
+
+	var r0 []*repository.CiArtifact
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*bean.ArtifactsListFilterOptions) ([]*repository.CiArtifact, int, error)); ok {
+		return rf(listingFilterOptions)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ArtifactsListFilterOptions) []*repository.CiArtifact); ok {
+		r0 = rf(listingFilterOptions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ArtifactsListFilterOptions) int); ok {
+		r1 = rf(listingFilterOptions)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*bean.ArtifactsListFilterOptions) error); ok {
+		r2 = rf(listingFilterOptions)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindCiArtifactByImagePaths provides a mock function with given fields: images
+func (_m *CiArtifactRepository) FindCiArtifactByImagePaths(images []string) ([]repository.CiArtifact, error) {
+	ret := _m.Called(images)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCiArtifactByImagePaths")
+	}
+
+	var r0 []repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]repository.CiArtifact, error)); ok {
+		return rf(images)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []repository.CiArtifact); ok {
+		r0 = rf(images)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(images)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: id
 func (_m *CiArtifactRepository) Get(id int) (*repository.CiArtifact, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
 	var r0 *repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*repository.CiArtifact, error)); ok {
@@ -84,6 +200,10 @@ func (_m *CiArtifactRepository) Get(id int) (*repository.CiArtifact, error) {
 func (_m *CiArtifactRepository) GetArtifactByCdWorkflowId(cdWorkflowId int) (*repository.CiArtifact, error) {
 	ret := _m.Called(cdWorkflowId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactByCdWorkflowId")
+	}
+
 	var r0 *repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*repository.CiArtifact, error)); ok {
@@ -110,6 +230,10 @@ func (_m *CiArtifactRepository) GetArtifactByCdWorkflowId(cdWorkflowId int) (*re
 func (_m *CiArtifactRepository) GetArtifactParentCiAndWorkflowDetailsByIds(ids []int) ([]*repository.CiArtifact, error) {
 	ret := _m.Called(ids)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactParentCiAndWorkflowDetailsByIds")
+	}
+
 	var r0 []*repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*repository.CiArtifact, error)); ok {
@@ -133,19 +257,23 @@ func (_m *CiArtifactRepository) GetArtifactParentCiAndWorkflowDetailsByIds(ids [
 }
 
 // GetArtifactsByCDPipeline provides a mock function with given fields: cdPipelineId, limit, parentId, parentType
-func (_m *CiArtifactRepository) GetArtifactsByCDPipeline(cdPipelineId int, limit int, parentId int, parentType bean.WorkflowType) ([]repository.CiArtifact, error) {
+func (_m *CiArtifactRepository) GetArtifactsByCDPipeline(cdPipelineId int, limit int, parentId int, parentType bean.WorkflowType) ([]*repository.CiArtifact, error) {
 	ret := _m.Called(cdPipelineId, limit, parentId, parentType)
 
-	var r0 []repository.CiArtifact
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCDPipeline")
+	}
+
+	var r0 []*repository.CiArtifact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, int, bean.WorkflowType) ([]repository.CiArtifact, error)); ok {
+	if rf, ok := ret.Get(0).(func(int, int, int, bean.WorkflowType) ([]*repository.CiArtifact, error)); ok {
 		return rf(cdPipelineId, limit, parentId, parentType)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, int, bean.WorkflowType) []repository.CiArtifact); ok {
+	if rf, ok := ret.Get(0).(func(int, int, int, bean.WorkflowType) []*repository.CiArtifact); ok {
 		r0 = rf(cdPipelineId, limit, parentId, parentType)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repository.CiArtifact)
+			r0 = ret.Get(0).([]*repository.CiArtifact)
 		}
 	}
 
@@ -162,6 +290,10 @@ func (_m *CiArtifactRepository) GetArtifactsByCDPipeline(cdPipelineId int, limit
 func (_m *CiArtifactRepository) GetArtifactsByCDPipelineAndRunnerType(cdPipelineId int, runnerType bean.WorkflowType) ([]repository.CiArtifact, error) {
 	ret := _m.Called(cdPipelineId, runnerType)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCDPipelineAndRunnerType")
+	}
+
 	var r0 []repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, bean.WorkflowType) ([]repository.CiArtifact, error)); ok {
@@ -188,6 +320,10 @@ func (_m *CiArtifactRepository) GetArtifactsByCDPipelineAndRunnerType(cdPipeline
 func (_m *CiArtifactRepository) GetArtifactsByCDPipelineV2(cdPipelineId int) ([]repository.CiArtifact, error) {
 	ret := _m.Called(cdPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCDPipelineV2")
+	}
+
 	var r0 []repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]repository.CiArtifact, error)); ok {
@@ -210,10 +346,51 @@ func (_m *CiArtifactRepository) GetArtifactsByCDPipelineV2(cdPipelineId int) ([]
 	return r0, r1
 }
 
+// GetArtifactsByCDPipelineV3 provides a mock function with given fields: listingFilterOpts
+func (_m *CiArtifactRepository) GetArtifactsByCDPipelineV3(listingFilterOpts *bean.ArtifactsListFilterOptions) ([]*repository.CiArtifact, int, error) {
+	ret := _m.Called(listingFilterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCDPipelineV3")
+	}
+
+	var r0 []*repository.CiArtifact
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*bean.ArtifactsListFilterOptions) ([]*repository.CiArtifact, int, error)); ok {
+		return rf(listingFilterOpts)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ArtifactsListFilterOptions) []*repository.CiArtifact); ok {
+		r0 = rf(listingFilterOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ArtifactsListFilterOptions) int); ok {
+		r1 = rf(listingFilterOpts)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*bean.ArtifactsListFilterOptions) error); ok {
+		r2 = rf(listingFilterOpts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetArtifactsByCiPipelineId provides a mock function with given fields: ciPipelineId
 func (_m *CiArtifactRepository) GetArtifactsByCiPipelineId(ciPipelineId int) ([]repository.CiArtifact, error) {
 	ret := _m.Called(ciPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCiPipelineId")
+	}
+
 	var r0 []repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) ([]repository.CiArtifact, error)); ok {
@@ -236,10 +413,104 @@ func (_m *CiArtifactRepository) GetArtifactsByCiPipelineId(ciPipelineId int) ([]
 	return r0, r1
 }
 
+// GetArtifactsByCiPipelineIds provides a mock function with given fields: ciPipelineIds
+func (_m *CiArtifactRepository) GetArtifactsByCiPipelineIds(ciPipelineIds []int) ([]repository.CiArtifact, error) {
+	ret := _m.Called(ciPipelineIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByCiPipelineIds")
+	}
+
+	var r0 []repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]repository.CiArtifact, error)); ok {
+		return rf(ciPipelineIds)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []repository.CiArtifact); ok {
+		r0 = rf(ciPipelineIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ciPipelineIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArtifactsByDataSourceAndComponentId provides a mock function with given fields: dataSource, componentId
+func (_m *CiArtifactRepository) GetArtifactsByDataSourceAndComponentId(dataSource string, componentId int) ([]repository.CiArtifact, error) {
+	ret := _m.Called(dataSource, componentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByDataSourceAndComponentId")
+	}
+
+	var r0 []repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]repository.CiArtifact, error)); ok {
+		return rf(dataSource, componentId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []repository.CiArtifact); ok {
+		r0 = rf(dataSource, componentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(dataSource, componentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArtifactsByParentCiWorkflowId provides a mock function with given fields: parentCiWorkflowId
+func (_m *CiArtifactRepository) GetArtifactsByParentCiWorkflowId(parentCiWorkflowId int) ([]string, error) {
+	ret := _m.Called(parentCiWorkflowId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactsByParentCiWorkflowId")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
+		return rf(parentCiWorkflowId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(parentCiWorkflowId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(parentCiWorkflowId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByIds provides a mock function with given fields: ids
 func (_m *CiArtifactRepository) GetByIds(ids []int) ([]*repository.CiArtifact, error) {
 	ret := _m.Called(ids)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIds")
+	}
+
 	var r0 []*repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func([]int) ([]*repository.CiArtifact, error)); ok {
@@ -266,6 +537,10 @@ func (_m *CiArtifactRepository) GetByIds(ids []int) ([]*repository.CiArtifact, e
 func (_m *CiArtifactRepository) GetByImageDigest(imageDigest string) (*repository.CiArtifact, error) {
 	ret := _m.Called(imageDigest)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByImageDigest")
+	}
+
 	var r0 *repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*repository.CiArtifact, error)); ok {
@@ -292,6 +567,10 @@ func (_m *CiArtifactRepository) GetByImageDigest(imageDigest string) (*repositor
 func (_m *CiArtifactRepository) GetByWfId(wfId int) (*repository.CiArtifact, error) {
 	ret := _m.Called(wfId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByWfId")
+	}
+
 	var r0 *repository.CiArtifact
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (*repository.CiArtifact, error)); ok {
@@ -318,6 +597,10 @@ func (_m *CiArtifactRepository) GetByWfId(wfId int) (*repository.CiArtifact, err
 func (_m *CiArtifactRepository) GetLatest(cdPipelineId int) (int, error) {
 	ret := _m.Called(cdPipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatest")
+	}
+
 	var r0 int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
@@ -338,10 +621,178 @@ func (_m *CiArtifactRepository) GetLatest(cdPipelineId int) (int, error) {
 	return r0, r1
 }
 
+// GetLatestArtifactTimeByCiPipelineId provides a mock function with given fields: ciPipelineId
+func (_m *CiArtifactRepository) GetLatestArtifactTimeByCiPipelineId(ciPipelineId int) (*repository.CiArtifact, error) {
+	ret := _m.Called(ciPipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestArtifactTimeByCiPipelineId")
+	}
+
+	var r0 *repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*repository.CiArtifact, error)); ok {
+		return rf(ciPipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *repository.CiArtifact); ok {
+		r0 = rf(ciPipelineId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(ciPipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestArtifactTimeByCiPipelineIds provides a mock function with given fields: ciPipelineIds
+func (_m *CiArtifactRepository) GetLatestArtifactTimeByCiPipelineIds(ciPipelineIds []int) ([]*repository.CiArtifact, error) {
+	ret := _m.Called(ciPipelineIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestArtifactTimeByCiPipelineIds")
+	}
+
+	var r0 []*repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*repository.CiArtifact, error)); ok {
+		return rf(ciPipelineIds)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*repository.CiArtifact); ok {
+		r0 = rf(ciPipelineIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ciPipelineIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestArtifactsByCiPipelineId provides a mock function with given fields: ciPipelineId, limit
+func (_m *CiArtifactRepository) GetLatestArtifactsByCiPipelineId(ciPipelineId int, limit int) ([]repository.CiArtifact, error) {
+	ret := _m.Called(ciPipelineId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestArtifactsByCiPipelineId")
+	}
+
+	var r0 []repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]repository.CiArtifact, error)); ok {
+		return rf(ciPipelineId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) []repository.CiArtifact); ok {
+		r0 = rf(ciPipelineId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(ciPipelineId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IfArtifactExistByImage provides a mock function with given fields: imageName, pipelineId
+func (_m *CiArtifactRepository) IfArtifactExistByImage(imageName string, pipelineId int) (bool, error) {
+	ret := _m.Called(imageName, pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IfArtifactExistByImage")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (bool, error)); ok {
+		return rf(imageName, pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) bool); ok {
+		r0 = rf(imageName, pipelineId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(imageName, pipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IfArtifactExistByImageDigest provides a mock function with given fields: imageDigest, imageName, pipelineId
+func (_m *CiArtifactRepository) IfArtifactExistByImageDigest(imageDigest string, imageName string, pipelineId int) (bool, error) {
+	ret := _m.Called(imageDigest, imageName, pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IfArtifactExistByImageDigest")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) (bool, error)); ok {
+		return rf(imageDigest, imageName, pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) bool); ok {
+		r0 = rf(imageDigest, imageName, pipelineId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(imageDigest, imageName, pipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateToWebHookDataSourceType provides a mock function with given fields: id
+func (_m *CiArtifactRepository) MigrateToWebHookDataSourceType(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateToWebHookDataSourceType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: artifact
 func (_m *CiArtifactRepository) Save(artifact *repository.CiArtifact) error {
 	ret := _m.Called(artifact)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*repository.CiArtifact) error); ok {
 		r0 = rf(artifact)
@@ -353,12 +804,46 @@ func (_m *CiArtifactRepository) Save(artifact *repository.CiArtifact) error {
 }
 
 // SaveAll provides a mock function with given fields: artifacts
-func (_m *CiArtifactRepository) SaveAll(artifacts []*repository.CiArtifact) error {
+func (_m *CiArtifactRepository) SaveAll(artifacts []*repository.CiArtifact) ([]*repository.CiArtifact, error) {
 	ret := _m.Called(artifacts)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func([]*repository.CiArtifact) error); ok {
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAll")
+	}
+
+	var r0 []*repository.CiArtifact
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*repository.CiArtifact) ([]*repository.CiArtifact, error)); ok {
+		return rf(artifacts)
+	}
+	if rf, ok := ret.Get(0).(func([]*repository.CiArtifact) []*repository.CiArtifact); ok {
 		r0 = rf(artifacts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.CiArtifact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*repository.CiArtifact) error); ok {
+		r1 = rf(artifacts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ciArtifact
+func (_m *CiArtifactRepository) Update(ciArtifact *repository.CiArtifact) error {
+	ret := _m.Called(ciArtifact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.CiArtifact) error); ok {
+		r0 = rf(ciArtifact)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -366,13 +851,30 @@ func (_m *CiArtifactRepository) SaveAll(artifacts []*repository.CiArtifact) erro
 	return r0
 }
 
-type mockConstructorTestingTNewCiArtifactRepository interface {
-	mock.TestingT
-	Cleanup(func())
+// UpdateLatestTimestamp provides a mock function with given fields: artifactIds
+func (_m *CiArtifactRepository) UpdateLatestTimestamp(artifactIds []int) error {
+	ret := _m.Called(artifactIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLatestTimestamp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int) error); ok {
+		r0 = rf(artifactIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewCiArtifactRepository creates a new instance of CiArtifactRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewCiArtifactRepository(t mockConstructorTestingTNewCiArtifactRepository) *CiArtifactRepository {
+// The first argument is typically a *testing.T value.
+func NewCiArtifactRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CiArtifactRepository {
 	mock := &CiArtifactRepository{}
 	mock.Mock.Test(t)
 
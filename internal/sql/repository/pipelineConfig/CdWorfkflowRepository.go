@@ -51,6 +51,7 @@ type CdWorkflowRepository interface {
 	UpdateWorkFlowRunners(wfr []*CdWorkflowRunner) error
 	FindWorkflowRunnerByCdWorkflowId(wfIds []int) ([]*CdWorkflowRunner, error)
 	FindPreviousCdWfRunnerByStatus(pipelineId int, currentWFRunnerId int, status []string) ([]*CdWorkflowRunner, error)
+	FindPreviousCdWfRunnersInStatus(pipelineId int, currentWFRunnerId int, status []string, limit int) ([]*CdWorkflowRunner, error)
 	FindWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
 	FindPreOrPostCdWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
 	FindBasicWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
@@ -136,7 +137,16 @@ type CdWorkflowRunner struct {
 	ImagePathReservationIds []int                               `sql:"image_path_reservation_ids" pg:",array,notnull"`
 	ReferenceId             *string                             `sql:"reference_id"`
 	ImageState              constants.ImageStateWhileDeployment `sql:"image_state"` // image_state currently not utilized in oss
-	CdWorkflow              *CdWorkflow
+	// TriggerType is MANUAL, AUTOMATIC or SYSTEM for the deployments triggered by devtron itself on a schedule,
+	// empty for the runners created before it was recorded
+	TriggerType string `sql:"trigger_type"`
+	// DeploymentApprovalRequestId is the approval request the artifact was deployed with
+	DeploymentApprovalRequestId int `sql:"deployment_approval_request_id"`
+	// SignatureVerificationStatus is the result of the image signature verification of the deployed artifact,
+	// empty when no signature policy applies to the environment
+	SignatureVerificationStatus  string `sql:"signature_verification_status"`
+	SignatureVerificationMessage string `sql:"signature_verification_message"`
+	CdWorkflow                   *CdWorkflow
 	sql.AuditLog
 }
 
@@ -221,6 +231,21 @@ func (impl *CdWorkflowRepositoryImpl) FindPreviousCdWfRunnerByStatus(pipelineId
 	return runner, err
 }
 
+func (impl *CdWorkflowRepositoryImpl) FindPreviousCdWfRunnersInStatus(pipelineId int, currentWFRunnerId int, status []string, limit int) ([]*CdWorkflowRunner, error) {
+	var runner []*CdWorkflowRunner
+	err := impl.dbConnection.
+		Model(&runner).
+		Column("cd_workflow_runner.*", "CdWorkflow").
+		Where("cd_workflow.pipeline_id = ?", pipelineId).
+		Where("cd_workflow_runner.id < ?", currentWFRunnerId).
+		Where("workflow_type = ? ", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Where("cd_workflow_runner.status in (?) ", pg.In(status)).
+		Order("cd_workflow_runner.id DESC").
+		Limit(limit).
+		Select()
+	return runner, err
+}
+
 func (impl *CdWorkflowRepositoryImpl) SaveWorkFlow(ctx context.Context, wf *CdWorkflow) error {
 	_, span := otel.Tracer("orchestrator").Start(ctx, "cdWorkflowRepository.SaveWorkFlow")
 	defer span.End()
@@ -246,7 +271,11 @@ func (impl *CdWorkflowRepositoryImpl) FindById(wfId int) (*CdWorkflow, error) {
 
 func (impl *CdWorkflowRepositoryImpl) FindLatestCdWorkflowByPipelineId(pipelineIds []int) (*CdWorkflow, error) {
 	cdWorkflow := &CdWorkflow{}
-	err := impl.dbConnection.Model(cdWorkflow).Where("pipeline_id in (?)", pg.In(pipelineIds)).Order("id DESC").Limit(1).Select()
+	// workflows of cluster set member releases are not deployments to the pipeline environment
+	err := impl.dbConnection.Model(cdWorkflow).
+		Where("pipeline_id in (?)", pg.In(pipelineIds)).
+		Where("NOT EXISTS (SELECT 1 FROM cd_workflow_runner cdwr WHERE cdwr.cd_workflow_id = cd_workflow.id AND cdwr.workflow_type = ?)", apiBean.CD_WORKFLOW_TYPE_CLUSTER_SET_DEPLOY).
+		Order("id DESC").Limit(1).Select()
 	return cdWorkflow, err
 }
 
@@ -326,6 +355,7 @@ func (impl *CdWorkflowRepositoryImpl) FindCdWorkflowMetaByPipelineId(pipelineId
 		Model(&wfrList).
 		Column("cd_workflow_runner.*", "CdWorkflow", "CdWorkflow.Pipeline", "CdWorkflow.CiArtifact").
 		Where("cd_workflow.pipeline_id = ?", pipelineId).
+		Where("cd_workflow_runner.workflow_type <> ?", apiBean.CD_WORKFLOW_TYPE_CLUSTER_SET_DEPLOY).
 		Order("cd_workflow_runner.id DESC").
 		// Join("inner join cd_workflow wf on wf.id = cd_workflow_runner.cd_workflow_id").
 		// Join("inner join ci_artifact cia on cia.id = wf.ci_artifact_id").
@@ -367,7 +397,7 @@ func (impl *CdWorkflowRepositoryImpl) FindLastPreOrPostTriggeredByPipelineId(pip
 		Model(&wfr).
 		Column("cd_workflow_runner.*", "CdWorkflow", "CdWorkflow.Pipeline", "CdWorkflow.CiArtifact").
 		Where("cd_workflow.pipeline_id = ?", pipelineId).
-		Where("cd_workflow_runner.workflow_type != ?", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Where("cd_workflow_runner.workflow_type IN (?)", pg.In([]apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_PRE, apiBean.CD_WORKFLOW_TYPE_POST})).
 		Order("cd_workflow_runner.id DESC").
 		Limit(1).
 		Select()
@@ -439,7 +469,7 @@ func (impl *CdWorkflowRepositoryImpl) FindLastPreOrPostTriggeredByEnvironmentId(
 		Column("cd_workflow_runner.*", "CdWorkflow", "CdWorkflow.Pipeline", "CdWorkflow.CiArtifact").
 		Where("p.environment_id = ?", environmentId).
 		Where("p.app_id = ?", appId).
-		Where("cd_workflow_runner.workflow_type != ?", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Where("cd_workflow_runner.workflow_type IN (?)", pg.In([]apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_PRE, apiBean.CD_WORKFLOW_TYPE_POST})).
 		Order("cd_workflow_runner.id DESC").
 		Join("inner join cd_workflow wf on wf.id = cd_workflow_runner.cd_workflow_id").
 		Join("inner join ci_artifact cia on cia.id = wf.ci_artifact_id").
@@ -535,7 +565,7 @@ func (impl *CdWorkflowRepositoryImpl) FindPreOrPostCdWorkflowRunnerById(wfrId in
 	err := impl.dbConnection.Model(wfr).
 		Column("cd_workflow_runner.*", "CdWorkflow", "CdWorkflow.Pipeline", "CdWorkflow.CiArtifact", "CdWorkflow.Pipeline.Environment").
 		Where("cd_workflow_runner.id = ?", wfrId).
-		Where("cd_workflow_runner.workflow_type != ?", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Where("cd_workflow_runner.workflow_type IN (?)", pg.In([]apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_PRE, apiBean.CD_WORKFLOW_TYPE_POST})).
 		Select()
 	return wfr, err
 }
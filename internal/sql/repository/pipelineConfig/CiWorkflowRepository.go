@@ -34,6 +34,7 @@ type CiWorkflowRepository interface {
 	UpdateWorkFlowWithTx(wf *CiWorkflow, tx *pg.Tx) error
 	UpdateArtifactUploaded(id int, isUploaded workflow.ArtifactUploadedType) error
 	FindByStatusesIn(activeStatuses []string) ([]*CiWorkflow, error)
+	FindByCiPipelineIdAndStatusesIn(ciPipelineId int, statuses []string) ([]*CiWorkflow, error)
 	FindByPipelineId(pipelineId int, offset int, size int) ([]WorkflowWithArtifact, error)
 	FindById(id int) (*CiWorkflow, error)
 	FindRetriedWorkflowCountByReferenceId(id int) (int, error)
@@ -130,6 +131,7 @@ type CiWorkflow struct {
 	ExecutorType            cdWorkflow.WorkflowExecutorType `sql:"executor_type"` //awf, system
 	ImagePathReservationId  int                             `sql:"image_path_reservation_id"`
 	ImagePathReservationIds []int                           `sql:"image_path_reservation_ids" pg:",array"`
+	TriggerType             workflow.CiTriggerType          `sql:"trigger_type"`
 	CiPipeline              *CiPipeline
 }
 
@@ -180,6 +182,7 @@ type WorkflowWithArtifact struct {
 	ExecutorType            cdWorkflow.WorkflowExecutorType `sql:"executor_type"` //awf, system
 	ImagePathReservationId  int                             `sql:"image_path_reservation_id"`
 	ImagePathReservationIds []int                           `sql:"image_path_reservation_ids" pg:",array"`
+	TriggerType             workflow.CiTriggerType          `sql:"trigger_type"`
 }
 
 func (w *WorkflowWithArtifact) GetIsArtifactUploaded() (isArtifactUploaded bool, isMigrationRequired bool) {
@@ -232,6 +235,16 @@ func (impl *CiWorkflowRepositoryImpl) FindByStatusesIn(activeStatuses []string)
 	return ciWorkFlows, err
 }
 
+func (impl *CiWorkflowRepositoryImpl) FindByCiPipelineIdAndStatusesIn(ciPipelineId int, statuses []string) ([]*CiWorkflow, error) {
+	var ciWorkFlows []*CiWorkflow
+	err := impl.dbConnection.Model(&ciWorkFlows).
+		Column("ci_workflow.*").
+		Where("ci_workflow.ci_pipeline_id = ?", ciPipelineId).
+		Where("ci_workflow.status in (?)", pg.In(statuses)).
+		Select()
+	return ciWorkFlows, err
+}
+
 // FindByPipelineId gets only those workflowWithArtifact whose parent_ci_workflow_id is null, this is done to accommodate multiple ci_artifacts through a single workflow(parent), making child workflows for other ci_artifacts (this has been done due to design understanding and db constraint) single workflow single ci-artifact
 func (impl *CiWorkflowRepositoryImpl) FindByPipelineId(pipelineId int, offset int, limit int) ([]WorkflowWithArtifact, error) {
 	var wfs []WorkflowWithArtifact
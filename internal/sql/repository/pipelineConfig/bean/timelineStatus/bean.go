@@ -51,6 +51,18 @@ const (
 	TIMELINE_STATUS_UNABLE_TO_FETCH_STATUS TimelineStatus = "UNABLE_TO_FETCH_STATUS"
 	TIMELINE_STATUS_DEPLOYMENT_SUPERSEDED  TimelineStatus = "DEPLOYMENT_SUPERSEDED"
 	TIMELINE_STATUS_MANIFEST_GENERATED     TimelineStatus = "HELM_PACKAGE_GENERATED" // TODO: remove as this deployment type is not supported
+	// TIMELINE_STATUS_AUTO_ROLLBACK_TRIGGERED and TIMELINE_STATUS_AUTO_ROLLBACK_FAILED are added to an unhealthy deployment
+	// after its terminal status, they record the outcome of the auto rollback policy of the pipeline.
+	TIMELINE_STATUS_AUTO_ROLLBACK_TRIGGERED TimelineStatus = "AUTO_ROLLBACK_TRIGGERED"
+	TIMELINE_STATUS_AUTO_ROLLBACK_FAILED    TimelineStatus = "AUTO_ROLLBACK_FAILED"
+	// TIMELINE_STATUS_METRIC_VERIFICATION_STARTED is added to a healthy deployment of a pipeline with metric verification,
+	// post-cd is triggered only once TIMELINE_STATUS_METRIC_VERIFICATION_PASSED is added.
+	TIMELINE_STATUS_METRIC_VERIFICATION_STARTED TimelineStatus = "METRIC_VERIFICATION_STARTED"
+	TIMELINE_STATUS_METRIC_VERIFICATION_PASSED  TimelineStatus = "METRIC_VERIFICATION_PASSED"
+	TIMELINE_STATUS_METRIC_VERIFICATION_FAILED  TimelineStatus = "METRIC_VERIFICATION_FAILED"
+	// TIMELINE_STATUS_GIT_AWAITING_MERGE is added in place of TIMELINE_STATUS_GIT_COMMIT for environments in gitops pull request mode,
+	// TIMELINE_STATUS_GIT_COMMIT is added once the pull request is merged.
+	TIMELINE_STATUS_GIT_AWAITING_MERGE TimelineStatus = "GIT_AWAITING_MERGE"
 )
 
 const (
@@ -62,4 +74,11 @@ const (
 	TIMELINE_DESCRIPTION_ARGOCD_SYNC_COMPLETED        string = "ArgoCD sync completed."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED         string = "Deployment has been performed successfully. Waiting for application to be healthy..."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_SUPERSEDED        string = "This deployment is superseded."
+	TIMELINE_DESCRIPTION_AUTO_ROLLBACK_TRIGGERED      string = "Deployment was not healthy within the health timeout, rolled back to the last healthy deployment."
+	TIMELINE_DESCRIPTION_AUTO_ROLLBACK_FAILED         string = "Deployment was not healthy within the health timeout, auto rollback failed: "
+	TIMELINE_DESCRIPTION_METRIC_VERIFICATION_STARTED  string = "Verifying deployment against prometheus metrics for %d minute(s)."
+	TIMELINE_DESCRIPTION_METRIC_VERIFICATION_PASSED   string = "Deployment metrics were within thresholds for the verification duration."
+	TIMELINE_DESCRIPTION_METRIC_VERIFICATION_FAILED   string = "Deployment failed metric verification: "
+	TIMELINE_DESCRIPTION_GIT_AWAITING_MERGE           string = "Pull request opened, waiting for it to be merged: %s"
+	TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_MERGED      string = "Pull request merged: %s"
 )
@@ -29,3 +29,16 @@ const (
 	ArtifactUploaded     ArtifactUploadedType = "Uploaded"
 	ArtifactNotUploaded  ArtifactUploadedType = "NotUploaded"
 )
+
+// CiTriggerType tells how a ci workflow was triggered
+type CiTriggerType string
+
+func (r CiTriggerType) String() string {
+	return string(r)
+}
+
+const (
+	CiTriggerTypeManual    CiTriggerType = "MANUAL"
+	CiTriggerTypeWebhook   CiTriggerType = "WEBHOOK"
+	CiTriggerTypeScheduled CiTriggerType = "SCHEDULED"
+)
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
@@ -6,12 +6,19 @@ import (
 	context "context"
 
 	bean "github.com/devtron-labs/devtron/api/bean"
+	cdWorkflow "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 
 	mock "github.com/stretchr/testify/mock"
 
+	overviewbean "github.com/devtron-labs/devtron/pkg/overview/bean"
+
 	pg "github.com/go-pg/pg"
 
 	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	time "time"
+
+	workflow "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 )
 
 // CdWorkflowRepository is an autogenerated mock type for the CdWorkflowRepository type
@@ -195,7 +202,7 @@ func (_m *CdWorkflowRepository) FetchEnvAllCdStagesLatestEntityStatus(wfrIds []i
 	return r0, r1
 }
 
-// FindAllTriggeredWorkflowCountInLast24Hour provides a mock function with given fields:
+// FindAllTriggeredWorkflowCountInLast24Hour provides a mock function with no fields
 func (_m *CdWorkflowRepository) FindAllTriggeredWorkflowCountInLast24Hour() (int, error) {
 	ret := _m.Called()
 
@@ -401,6 +408,36 @@ func (_m *CdWorkflowRepository) FindCdWorkflowMetaByPipelineId(pipelineId int, o
 	return r0, r1
 }
 
+// FindDeployedCdWorkflowRunnersByPipelineId provides a mock function with given fields: pipelineId
+func (_m *CdWorkflowRepository) FindDeployedCdWorkflowRunnersByPipelineId(pipelineId int) ([]*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeployedCdWorkflowRunnersByPipelineId")
+	}
+
+	var r0 []*pipelineConfig.CdWorkflowRunner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(pipelineId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.CdWorkflowRunner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(pipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindLastPreOrPostTriggeredByEnvironmentId provides a mock function with given fields: appId, environmentId
 func (_m *CdWorkflowRepository) FindLastPreOrPostTriggeredByEnvironmentId(appId int, environmentId int) (pipelineConfig.CdWorkflowRunner, error) {
 	ret := _m.Called(appId, environmentId)
@@ -575,6 +612,36 @@ func (_m *CdWorkflowRepository) FindLatestCdWorkflowByPipelineIdV2(pipelineIds [
 	return r0, r1
 }
 
+// FindLatestCdWorkflowRunnerArtifactMetadataForAppAndEnvIds provides a mock function with given fields: appVsEnvIdMap, runnerType
+func (_m *CdWorkflowRepository) FindLatestCdWorkflowRunnerArtifactMetadataForAppAndEnvIds(appVsEnvIdMap map[int][]int, runnerType bean.WorkflowType) ([]*cdWorkflow.CdWorkflowRunnerArtifactMetadata, error) {
+	ret := _m.Called(appVsEnvIdMap, runnerType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestCdWorkflowRunnerArtifactMetadataForAppAndEnvIds")
+	}
+
+	var r0 []*cdWorkflow.CdWorkflowRunnerArtifactMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[int][]int, bean.WorkflowType) ([]*cdWorkflow.CdWorkflowRunnerArtifactMetadata, error)); ok {
+		return rf(appVsEnvIdMap, runnerType)
+	}
+	if rf, ok := ret.Get(0).(func(map[int][]int, bean.WorkflowType) []*cdWorkflow.CdWorkflowRunnerArtifactMetadata); ok {
+		r0 = rf(appVsEnvIdMap, runnerType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cdWorkflow.CdWorkflowRunnerArtifactMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[int][]int, bean.WorkflowType) error); ok {
+		r1 = rf(appVsEnvIdMap, runnerType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindLatestCdWorkflowRunnerByEnvironmentIdAndRunnerType provides a mock function with given fields: appId, environmentId, runnerType
 func (_m *CdWorkflowRepository) FindLatestCdWorkflowRunnerByEnvironmentIdAndRunnerType(appId int, environmentId int, runnerType bean.WorkflowType) (pipelineConfig.CdWorkflowRunner, error) {
 	ret := _m.Called(appId, environmentId, runnerType)
@@ -663,6 +730,36 @@ func (_m *CdWorkflowRepository) FindLatestWfrByAppIdAndEnvironmentId(appId int,
 	return r0, r1
 }
 
+// FindPreOrPostCdWorkflowRunnerById provides a mock function with given fields: wfrId
+func (_m *CdWorkflowRepository) FindPreOrPostCdWorkflowRunnerById(wfrId int) (*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(wfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPreOrPostCdWorkflowRunnerById")
+	}
+
+	var r0 *pipelineConfig.CdWorkflowRunner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(wfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(wfrId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.CdWorkflowRunner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(wfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPreviousCdWfRunnerByStatus provides a mock function with given fields: pipelineId, currentWFRunnerId, status
 func (_m *CdWorkflowRepository) FindPreviousCdWfRunnerByStatus(pipelineId int, currentWFRunnerId int, status []string) ([]*pipelineConfig.CdWorkflowRunner, error) {
 	ret := _m.Called(pipelineId, currentWFRunnerId, status)
@@ -693,6 +790,36 @@ func (_m *CdWorkflowRepository) FindPreviousCdWfRunnerByStatus(pipelineId int, c
 	return r0, r1
 }
 
+// FindPreviousCdWfRunnersInStatus provides a mock function with given fields: pipelineId, currentWFRunnerId, status, limit
+func (_m *CdWorkflowRepository) FindPreviousCdWfRunnersInStatus(pipelineId int, currentWFRunnerId int, status []string, limit int) ([]*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(pipelineId, currentWFRunnerId, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPreviousCdWfRunnersInStatus")
+	}
+
+	var r0 []*pipelineConfig.CdWorkflowRunner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, []string, int) ([]*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(pipelineId, currentWFRunnerId, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, []string, int) []*pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(pipelineId, currentWFRunnerId, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.CdWorkflowRunner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, []string, int) error); ok {
+		r1 = rf(pipelineId, currentWFRunnerId, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindRetriedWorkflowCountByReferenceId provides a mock function with given fields: wfrId
 func (_m *CdWorkflowRepository) FindRetriedWorkflowCountByReferenceId(wfrId int) (int, error) {
 	ret := _m.Called(wfrId)
@@ -781,7 +908,37 @@ func (_m *CdWorkflowRepository) FindWorkflowRunnerById(wfrId int) (*pipelineConf
 	return r0, r1
 }
 
-// GetConnection provides a mock function with given fields:
+// GetBlockedDeploymentsForTrend provides a mock function with given fields: from, to
+func (_m *CdWorkflowRepository) GetBlockedDeploymentsForTrend(from *time.Time, to *time.Time) ([]pipelineConfig.BlockedDeploymentData, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedDeploymentsForTrend")
+	}
+
+	var r0 []pipelineConfig.BlockedDeploymentData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) ([]pipelineConfig.BlockedDeploymentData, error)); ok {
+		return rf(from, to)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) []pipelineConfig.BlockedDeploymentData); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipelineConfig.BlockedDeploymentData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConnection provides a mock function with no fields
 func (_m *CdWorkflowRepository) GetConnection() *pg.DB {
 	ret := _m.Called()
 
@@ -801,9 +958,67 @@ func (_m *CdWorkflowRepository) GetConnection() *pg.DB {
 	return r0
 }
 
-// GetLatestTriggersOfHelmPipelinesStuckInNonTerminalStatuses provides a mock function with given fields: getPipelineDeployedWithinHours
-func (_m *CdWorkflowRepository) GetLatestTriggersOfHelmPipelinesStuckInNonTerminalStatuses(getPipelineDeployedWithinHours int) ([]*pipelineConfig.CdWorkflowRunner, error) {
-	ret := _m.Called(getPipelineDeployedWithinHours)
+// GetDeploymentCountInTimeRange provides a mock function with given fields: from, to
+func (_m *CdWorkflowRepository) GetDeploymentCountInTimeRange(from *time.Time, to *time.Time) (int, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeploymentCountInTimeRange")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) (int, error)); ok {
+		return rf(from, to)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) int); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeploymentWorkflowsForStatusTrend provides a mock function with given fields: from, to
+func (_m *CdWorkflowRepository) GetDeploymentWorkflowsForStatusTrend(from *time.Time, to *time.Time) ([]pipelineConfig.DeploymentStatusData, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeploymentWorkflowsForStatusTrend")
+	}
+
+	var r0 []pipelineConfig.DeploymentStatusData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) ([]pipelineConfig.DeploymentStatusData, error)); ok {
+		return rf(from, to)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) []pipelineConfig.DeploymentStatusData); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipelineConfig.DeploymentStatusData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestTriggersOfPipelinesStuckInNonTerminalStatuses provides a mock function with given fields: getPipelineDeployedWithinHours, deploymentAppType
+func (_m *CdWorkflowRepository) GetLatestTriggersOfPipelinesStuckInNonTerminalStatuses(getPipelineDeployedWithinHours int, deploymentAppType string) ([]*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(getPipelineDeployedWithinHours, deploymentAppType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLatestTriggersOfPipelinesStuckInNonTerminalStatuses")
@@ -811,19 +1026,19 @@ func (_m *CdWorkflowRepository) GetLatestTriggersOfHelmPipelinesStuckInNonTermin
 
 	var r0 []*pipelineConfig.CdWorkflowRunner
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]*pipelineConfig.CdWorkflowRunner, error)); ok {
-		return rf(getPipelineDeployedWithinHours)
+	if rf, ok := ret.Get(0).(func(int, string) ([]*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(getPipelineDeployedWithinHours, deploymentAppType)
 	}
-	if rf, ok := ret.Get(0).(func(int) []*pipelineConfig.CdWorkflowRunner); ok {
-		r0 = rf(getPipelineDeployedWithinHours)
+	if rf, ok := ret.Get(0).(func(int, string) []*pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(getPipelineDeployedWithinHours, deploymentAppType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*pipelineConfig.CdWorkflowRunner)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(getPipelineDeployedWithinHours)
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(getPipelineDeployedWithinHours, deploymentAppType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -861,6 +1076,43 @@ func (_m *CdWorkflowRepository) GetPreviousQueuedRunners(cdWfrId int, pipelineId
 	return r0, r1
 }
 
+// GetTriggeredCDPipelines provides a mock function with given fields: from, to, sortOrder, limit, offset
+func (_m *CdWorkflowRepository) GetTriggeredCDPipelines(from *time.Time, to *time.Time, sortOrder overviewbean.SortOrder, limit int, offset int) ([]pipelineConfig.PipelineUsageData, int, error) {
+	ret := _m.Called(from, to, sortOrder, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTriggeredCDPipelines")
+	}
+
+	var r0 []pipelineConfig.PipelineUsageData
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time, overviewbean.SortOrder, int, int) ([]pipelineConfig.PipelineUsageData, int, error)); ok {
+		return rf(from, to, sortOrder, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time, overviewbean.SortOrder, int, int) []pipelineConfig.PipelineUsageData); ok {
+		r0 = rf(from, to, sortOrder, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipelineConfig.PipelineUsageData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time, overviewbean.SortOrder, int, int) int); ok {
+		r1 = rf(from, to, sortOrder, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*time.Time, *time.Time, overviewbean.SortOrder, int, int) error); ok {
+		r2 = rf(from, to, sortOrder, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // IsLatestCDWfr provides a mock function with given fields: pipelineId, wfrId
 func (_m *CdWorkflowRepository) IsLatestCDWfr(pipelineId int, wfrId int) (bool, error) {
 	ret := _m.Called(pipelineId, wfrId)
@@ -917,6 +1169,16 @@ func (_m *CdWorkflowRepository) IsLatestWf(pipelineId int, wfId int) (bool, erro
 	return r0, r1
 }
 
+// MigrateCdArtifactLocation provides a mock function with given fields: wfrId, cdArtifactLocation
+func (_m *CdWorkflowRepository) MigrateCdArtifactLocation(wfrId int, cdArtifactLocation string) {
+	_m.Called(wfrId, cdArtifactLocation)
+}
+
+// MigrateIsArtifactUploaded provides a mock function with given fields: wfrId, isArtifactUploaded
+func (_m *CdWorkflowRepository) MigrateIsArtifactUploaded(wfrId int, isArtifactUploaded bool) {
+	_m.Called(wfrId, isArtifactUploaded)
+}
+
 // SaveWorkFlow provides a mock function with given fields: ctx, wf
 func (_m *CdWorkflowRepository) SaveWorkFlow(ctx context.Context, wf *pipelineConfig.CdWorkflow) error {
 	ret := _m.Called(ctx, wf)
@@ -935,34 +1197,22 @@ func (_m *CdWorkflowRepository) SaveWorkFlow(ctx context.Context, wf *pipelineCo
 	return r0
 }
 
-// SaveWorkFlowRunner provides a mock function with given fields: wfr
-func (_m *CdWorkflowRepository) SaveWorkFlowRunner(wfr *pipelineConfig.CdWorkflowRunner) (*pipelineConfig.CdWorkflowRunner, error) {
-	ret := _m.Called(wfr)
+// SaveWorkFlowRunnerWithTx provides a mock function with given fields: wfr, tx
+func (_m *CdWorkflowRepository) SaveWorkFlowRunnerWithTx(wfr *pipelineConfig.CdWorkflowRunner, tx *pg.Tx) error {
+	ret := _m.Called(wfr, tx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveWorkFlowRunner")
-	}
-
-	var r0 *pipelineConfig.CdWorkflowRunner
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner) (*pipelineConfig.CdWorkflowRunner, error)); ok {
-		return rf(wfr)
-	}
-	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner) *pipelineConfig.CdWorkflowRunner); ok {
-		r0 = rf(wfr)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*pipelineConfig.CdWorkflowRunner)
-		}
+		panic("no return value specified for SaveWorkFlowRunnerWithTx")
 	}
 
-	if rf, ok := ret.Get(1).(func(*pipelineConfig.CdWorkflowRunner) error); ok {
-		r1 = rf(wfr)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner, *pg.Tx) error); ok {
+		r0 = rf(wfr, tx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // SaveWorkFlows provides a mock function with given fields: wfs
@@ -990,7 +1240,7 @@ func (_m *CdWorkflowRepository) SaveWorkFlows(wfs ...*pipelineConfig.CdWorkflow)
 }
 
 // UpdateIsArtifactUploaded provides a mock function with given fields: wfrId, isArtifactUploaded
-func (_m *CdWorkflowRepository) UpdateIsArtifactUploaded(wfrId int, isArtifactUploaded bool) error {
+func (_m *CdWorkflowRepository) UpdateIsArtifactUploaded(wfrId int, isArtifactUploaded workflow.ArtifactUploadedType) error {
 	ret := _m.Called(wfrId, isArtifactUploaded)
 
 	if len(ret) == 0 {
@@ -998,7 +1248,7 @@ func (_m *CdWorkflowRepository) UpdateIsArtifactUploaded(wfrId int, isArtifactUp
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, bool) error); ok {
+	if rf, ok := ret.Get(0).(func(int, workflow.ArtifactUploadedType) error); ok {
 		r0 = rf(wfrId, isArtifactUploaded)
 	} else {
 		r0 = ret.Error(0)
@@ -1050,17 +1300,17 @@ func (_m *CdWorkflowRepository) UpdateWorkFlow(wf *pipelineConfig.CdWorkflow) er
 	return r0
 }
 
-// UpdateWorkFlowRunner provides a mock function with given fields: wfr
-func (_m *CdWorkflowRepository) UpdateWorkFlowRunner(wfr *pipelineConfig.CdWorkflowRunner) error {
-	ret := _m.Called(wfr)
+// UpdateWorkFlowRunnerWithTx provides a mock function with given fields: wfr, tx
+func (_m *CdWorkflowRepository) UpdateWorkFlowRunnerWithTx(wfr *pipelineConfig.CdWorkflowRunner, tx *pg.Tx) error {
+	ret := _m.Called(wfr, tx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateWorkFlowRunner")
+		panic("no return value specified for UpdateWorkFlowRunnerWithTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner) error); ok {
-		r0 = rf(wfr)
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner, *pg.Tx) error); ok {
+		r0 = rf(wfr, tx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.42.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	time "time"
 )
 
 // PipelineRepository is an autogenerated mock type for the PipelineRepository type
@@ -154,7 +156,7 @@ func (_m *PipelineRepository) FindActiveByAppIdAndEnvironmentId(appId int, envir
 	return r0, r1
 }
 
-// FindActiveByAppIdAndEnvironmentIdV2 provides a mock function with given fields:
+// FindActiveByAppIdAndEnvironmentIdV2 provides a mock function with no fields
 func (_m *PipelineRepository) FindActiveByAppIdAndEnvironmentIdV2() ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called()
 
@@ -244,6 +246,36 @@ func (_m *PipelineRepository) FindActiveByAppIds(appIds []int) ([]*pipelineConfi
 	return r0, r1
 }
 
+// FindActiveByCiPipelineIdsIn provides a mock function with given fields: ciPipelineIds
+func (_m *PipelineRepository) FindActiveByCiPipelineIdsIn(ciPipelineIds []int) ([]*pipelineConfig.Pipeline, error) {
+	ret := _m.Called(ciPipelineIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByCiPipelineIdsIn")
+	}
+
+	var r0 []*pipelineConfig.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*pipelineConfig.Pipeline, error)); ok {
+		return rf(ciPipelineIds)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*pipelineConfig.Pipeline); ok {
+		r0 = rf(ciPipelineIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ciPipelineIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindActiveByEnvId provides a mock function with given fields: envId
 func (_m *PipelineRepository) FindActiveByEnvId(envId int) ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called(envId)
@@ -334,6 +366,36 @@ func (_m *PipelineRepository) FindActiveByEnvIds(envId []int) ([]*pipelineConfig
 	return r0, r1
 }
 
+// FindActiveByEnvironmentType provides a mock function with given fields: isProd
+func (_m *PipelineRepository) FindActiveByEnvironmentType(isProd bool) ([]*pipelineConfig.Pipeline, error) {
+	ret := _m.Called(isProd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByEnvironmentType")
+	}
+
+	var r0 []*pipelineConfig.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bool) ([]*pipelineConfig.Pipeline, error)); ok {
+		return rf(isProd)
+	}
+	if rf, ok := ret.Get(0).(func(bool) []*pipelineConfig.Pipeline); ok {
+		r0 = rf(isProd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(isProd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindActiveByInFilter provides a mock function with given fields: envId, appIdIncludes
 func (_m *PipelineRepository) FindActiveByInFilter(envId int, appIdIncludes []int) ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called(envId, appIdIncludes)
@@ -484,7 +546,7 @@ func (_m *PipelineRepository) FindActivePipelineByEnvId(envId int) ([]*pipelineC
 	return r0, r1
 }
 
-// FindAllDeletedPipelineCountInLast24Hour provides a mock function with given fields:
+// FindAllDeletedPipelineCountInLast24Hour provides a mock function with no fields
 func (_m *PipelineRepository) FindAllDeletedPipelineCountInLast24Hour() (int, error) {
 	ret := _m.Called()
 
@@ -512,7 +574,7 @@ func (_m *PipelineRepository) FindAllDeletedPipelineCountInLast24Hour() (int, er
 	return r0, r1
 }
 
-// FindAllPipelineCreatedCountInLast24Hour provides a mock function with given fields:
+// FindAllPipelineCreatedCountInLast24Hour provides a mock function with no fields
 func (_m *PipelineRepository) FindAllPipelineCreatedCountInLast24Hour() (int, error) {
 	ret := _m.Called()
 
@@ -540,29 +602,29 @@ func (_m *PipelineRepository) FindAllPipelineCreatedCountInLast24Hour() (int, er
 	return r0, r1
 }
 
-// FindAllPipelinesByChartsOverrideAndAppIdAndChartId provides a mock function with given fields: chartOverridden, appId, chartId
-func (_m *PipelineRepository) FindAllPipelinesByChartsOverrideAndAppIdAndChartId(chartOverridden bool, appId int, chartId int) ([]*pipelineConfig.Pipeline, error) {
-	ret := _m.Called(chartOverridden, appId, chartId)
+// FindAllPipelinesWithoutOverriddenCharts provides a mock function with given fields: appId
+func (_m *PipelineRepository) FindAllPipelinesWithoutOverriddenCharts(appId int) ([]int, error) {
+	ret := _m.Called(appId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindAllPipelinesByChartsOverrideAndAppIdAndChartId")
+		panic("no return value specified for FindAllPipelinesWithoutOverriddenCharts")
 	}
 
-	var r0 []*pipelineConfig.Pipeline
+	var r0 []int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(bool, int, int) ([]*pipelineConfig.Pipeline, error)); ok {
-		return rf(chartOverridden, appId, chartId)
+	if rf, ok := ret.Get(0).(func(int) ([]int, error)); ok {
+		return rf(appId)
 	}
-	if rf, ok := ret.Get(0).(func(bool, int, int) []*pipelineConfig.Pipeline); ok {
-		r0 = rf(chartOverridden, appId, chartId)
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(appId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*pipelineConfig.Pipeline)
+			r0 = ret.Get(0).([]int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(bool, int, int) error); ok {
-		r1 = rf(chartOverridden, appId, chartId)
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(appId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1048,6 +1110,36 @@ func (_m *PipelineRepository) FindDeploymentTypeByPipelineIds(cdPipelineIds []in
 	return r0, r1
 }
 
+// FindEnvIdsByIdsInIncludingDeleted provides a mock function with given fields: ids
+func (_m *PipelineRepository) FindEnvIdsByIdsInIncludingDeleted(ids []int) ([]int, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEnvIdsByIdsInIncludingDeleted")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]int, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []int); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindIdsByAppIdsAndEnvironmentIds provides a mock function with given fields: appIds, environmentIds
 func (_m *PipelineRepository) FindIdsByAppIdsAndEnvironmentIds(appIds []int, environmentIds []int) ([]int, error) {
 	ret := _m.Called(appIds, environmentIds)
@@ -1136,6 +1228,66 @@ func (_m *PipelineRepository) FindNumberOfAppsWithCdPipeline(appIds []int) (int,
 	return r0, r1
 }
 
+// FindOneByAppIdAndEnvId provides a mock function with given fields: appId, envId
+func (_m *PipelineRepository) FindOneByAppIdAndEnvId(appId int, envId int) (*pipelineConfig.Pipeline, error) {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOneByAppIdAndEnvId")
+	}
+
+	var r0 *pipelineConfig.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*pipelineConfig.Pipeline, error)); ok {
+		return rf(appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *pipelineConfig.Pipeline); ok {
+		r0 = rf(appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.Pipeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindProdPipelinesWithAppDataAndDeploymentHistoryInTimeRange provides a mock function with given fields: from, to
+func (_m *PipelineRepository) FindProdPipelinesWithAppDataAndDeploymentHistoryInTimeRange(from *time.Time, to *time.Time) ([]*pipelineConfig.PipelineWithAppData, error) {
+	ret := _m.Called(from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProdPipelinesWithAppDataAndDeploymentHistoryInTimeRange")
+	}
+
+	var r0 []*pipelineConfig.PipelineWithAppData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) ([]*pipelineConfig.PipelineWithAppData, error)); ok {
+		return rf(from, to)
+	}
+	if rf, ok := ret.Get(0).(func(*time.Time, *time.Time) []*pipelineConfig.PipelineWithAppData); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.PipelineWithAppData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*time.Time, *time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindWithEnvironmentByCiIds provides a mock function with given fields: ctx, cIPipelineIds
 func (_m *PipelineRepository) FindWithEnvironmentByCiIds(ctx context.Context, cIPipelineIds []int) ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called(ctx, cIPipelineIds)
@@ -1166,6 +1318,94 @@ func (_m *PipelineRepository) FindWithEnvironmentByCiIds(ctx context.Context, cI
 	return r0, r1
 }
 
+// GetActivePipelineCountByEnvironmentTypeInTimeRange provides a mock function with given fields: isProd, from, to
+func (_m *PipelineRepository) GetActivePipelineCountByEnvironmentTypeInTimeRange(isProd bool, from *time.Time, to *time.Time) (int, error) {
+	ret := _m.Called(isProd, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivePipelineCountByEnvironmentTypeInTimeRange")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bool, *time.Time, *time.Time) (int, error)); ok {
+		return rf(isProd, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(bool, *time.Time, *time.Time) int); ok {
+		r0 = rf(isProd, from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(bool, *time.Time, *time.Time) error); ok {
+		r1 = rf(isProd, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAppsByClusterAndDeploymentAppType provides a mock function with given fields: clusterIds, deploymentAppName
+func (_m *PipelineRepository) GetAllAppsByClusterAndDeploymentAppType(clusterIds []int, deploymentAppName string) ([]*pipelineConfig.PipelineDeploymentConfigObj, error) {
+	ret := _m.Called(clusterIds, deploymentAppName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAppsByClusterAndDeploymentAppType")
+	}
+
+	var r0 []*pipelineConfig.PipelineDeploymentConfigObj
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int, string) ([]*pipelineConfig.PipelineDeploymentConfigObj, error)); ok {
+		return rf(clusterIds, deploymentAppName)
+	}
+	if rf, ok := ret.Get(0).(func([]int, string) []*pipelineConfig.PipelineDeploymentConfigObj); ok {
+		r0 = rf(clusterIds, deploymentAppName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.PipelineDeploymentConfigObj)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int, string) error); ok {
+		r1 = rf(clusterIds, deploymentAppName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllArgoAppInfoByDeploymentAppNames provides a mock function with given fields: deploymentAppNames
+func (_m *PipelineRepository) GetAllArgoAppInfoByDeploymentAppNames(deploymentAppNames []string) ([]*pipelineConfig.PipelineDeploymentConfigObj, error) {
+	ret := _m.Called(deploymentAppNames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllArgoAppInfoByDeploymentAppNames")
+	}
+
+	var r0 []*pipelineConfig.PipelineDeploymentConfigObj
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*pipelineConfig.PipelineDeploymentConfigObj, error)); ok {
+		return rf(deploymentAppNames)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*pipelineConfig.PipelineDeploymentConfigObj); ok {
+		r0 = rf(deploymentAppNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pipelineConfig.PipelineDeploymentConfigObj)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(deploymentAppNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAppAndEnvDetailsForDeploymentAppTypePipeline provides a mock function with given fields: deploymentAppType, clusterIds
 func (_m *PipelineRepository) GetAppAndEnvDetailsForDeploymentAppTypePipeline(deploymentAppType string, clusterIds []int) ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called(deploymentAppType, clusterIds)
@@ -1197,22 +1437,24 @@ func (_m *PipelineRepository) GetAppAndEnvDetailsForDeploymentAppTypePipeline(de
 }
 
 // GetArgoPipelineByArgoAppName provides a mock function with given fields: argoAppName
-func (_m *PipelineRepository) GetArgoPipelineByArgoAppName(argoAppName string) (pipelineConfig.Pipeline, error) {
+func (_m *PipelineRepository) GetArgoPipelineByArgoAppName(argoAppName string) ([]pipelineConfig.Pipeline, error) {
 	ret := _m.Called(argoAppName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetArgoPipelineByArgoAppName")
 	}
 
-	var r0 pipelineConfig.Pipeline
+	var r0 []pipelineConfig.Pipeline
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (pipelineConfig.Pipeline, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]pipelineConfig.Pipeline, error)); ok {
 		return rf(argoAppName)
 	}
-	if rf, ok := ret.Get(0).(func(string) pipelineConfig.Pipeline); ok {
+	if rf, ok := ret.Get(0).(func(string) []pipelineConfig.Pipeline); ok {
 		r0 = rf(argoAppName)
 	} else {
-		r0 = ret.Get(0).(pipelineConfig.Pipeline)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipelineConfig.Pipeline)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
@@ -1342,7 +1584,7 @@ func (_m *PipelineRepository) GetByEnvOverrideIdAndEnvId(envOverrideId int, envI
 	return r0, r1
 }
 
-// GetConnection provides a mock function with given fields:
+// GetConnection provides a mock function with no fields
 func (_m *PipelineRepository) GetConnection() *pg.DB {
 	ret := _m.Called()
 
@@ -1362,6 +1604,62 @@ func (_m *PipelineRepository) GetConnection() *pg.DB {
 	return r0
 }
 
+// GetPipelineCountByDeploymentType provides a mock function with given fields: deploymentType
+func (_m *PipelineRepository) GetPipelineCountByDeploymentType(deploymentType string) (int, error) {
+	ret := _m.Called(deploymentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipelineCountByDeploymentType")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(deploymentType)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(deploymentType)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deploymentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPipelineCountByEnvironmentType provides a mock function with given fields: isProd
+func (_m *PipelineRepository) GetPipelineCountByEnvironmentType(isProd bool) (int, error) {
+	ret := _m.Called(isProd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPipelineCountByEnvironmentType")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bool) (int, error)); ok {
+		return rf(isProd)
+	}
+	if rf, ok := ret.Get(0).(func(bool) int); ok {
+		r0 = rf(isProd)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(isProd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostStageConfigById provides a mock function with given fields: id
 func (_m *PipelineRepository) GetPostStageConfigById(id int) (*pipelineConfig.Pipeline, error) {
 	ret := _m.Called(id)
@@ -1474,7 +1772,7 @@ func (_m *PipelineRepository) SetDeploymentAppCreatedInPipeline(deploymentAppCre
 	return r0
 }
 
-// UniqueAppEnvironmentPipelines provides a mock function with given fields:
+// UniqueAppEnvironmentPipelines provides a mock function with no fields
 func (_m *PipelineRepository) UniqueAppEnvironmentPipelines() ([]*pipelineConfig.Pipeline, error) {
 	ret := _m.Called()
 
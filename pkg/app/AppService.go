@@ -488,6 +488,18 @@ func (impl *AppServiceImpl) CheckIfPipelineUpdateEventIsValid(app *v1alpha1.Appl
 		// drop event
 		return isValid, pipeline, cdWfr, pipelineOverride, nil
 	}
+	if len(pipelineOverride.GitHash) == 0 {
+		// if the deployment is awaiting merge of its gitops pull request, the event is of a previous deployment
+		isAwaitingMerge, err := impl.pipelineStatusTimelineRepository.CheckIfTimelineStatusPresentByWfrId(cdWfr.Id, timelineStatus.TIMELINE_STATUS_GIT_AWAITING_MERGE)
+		if err != nil {
+			impl.logger.Errorw("error in checking if deployment is awaiting merge", "cdWfrId", cdWfr.Id, "err", err)
+			return isValid, pipeline, cdWfr, pipelineOverride, err
+		}
+		if isAwaitingMerge {
+			// drop event
+			return isValid, pipeline, cdWfr, pipelineOverride, nil
+		}
+	}
 	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
 	if err != nil {
 		impl.logger.Errorw("error in getting deployment config by appId and environmentId", "appId", pipeline.AppId, "environmentId", pipeline.EnvironmentId, "err", err)
@@ -783,6 +795,8 @@ type ValuesOverrideResponse struct {
 	Pipeline             *pipelineConfig.Pipeline
 	DeploymentConfig     *bean2.DeploymentConfig
 	ManifestPushTemplate *bean3.ManifestPushTemplate
+	// AwaitingGitOpsMerge is set when the manifest is pushed to a pull request, the argo cd application is synced once it is merged
+	AwaitingGitOpsMerge bool
 }
 
 func (impl *AppServiceImpl) CreateGitOpsRepo(app *app.App, targetRevision string, userId int32) (gitOpsRepoName string, chartGitAttr *commonBean.ChartGitAttribute, err error) {
@@ -17,24 +17,32 @@
 package app
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
 	client "github.com/devtron-labs/devtron/client/events"
-	"github.com/devtron-labs/devtron/client/lens"
 	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/overview/adaptor"
+	"github.com/devtron-labs/devtron/pkg/overview/bean"
+	"github.com/devtron-labs/devtron/pkg/overview/repository"
+	util2 "github.com/devtron-labs/devtron/pkg/overview/util"
 	"go.uber.org/zap"
 )
 
 type ReleaseDataService interface {
 	TriggerEventForAllRelease(appId, environmentId int) error
-	GetDeploymentMetrics(request *lens.MetricRequest) (resBody []byte, resCode *lens.StatusCode, err error)
+	GetDeploymentMetrics(request *bean.DeploymentMetricsRequest) (*bean.AppEnvDeploymentMetrics, error)
 }
 type ReleaseDataServiceImpl struct {
 	pipelineOverrideRepository   chartConfig.PipelineOverrideRepository
 	logger                       *zap.SugaredLogger
 	ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository
 	eventClient                  client.EventClient
-	lensClient                   lens.LensClient
+	doraMetricsRepository        repository.DoraMetricsRepository
 }
 
 func NewReleaseDataServiceImpl(
@@ -42,13 +50,13 @@ func NewReleaseDataServiceImpl(
 	logger *zap.SugaredLogger,
 	ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository,
 	eventClient client.EventClient,
-	lensClient lens.LensClient) *ReleaseDataServiceImpl {
+	doraMetricsRepository repository.DoraMetricsRepository) *ReleaseDataServiceImpl {
 	return &ReleaseDataServiceImpl{
 		pipelineOverrideRepository:   pipelineOverrideRepository,
 		logger:                       logger,
 		ciPipelineMaterialRepository: ciPipelineMaterialRepository,
 		eventClient:                  eventClient,
-		lensClient:                   lensClient,
+		doraMetricsRepository:        doraMetricsRepository,
 	}
 
 }
@@ -101,7 +109,27 @@ func (impl *ReleaseDataServiceImpl) TriggerEventForAllRelease(appId, environment
 	return nil
 }
 
-func (impl *ReleaseDataServiceImpl) GetDeploymentMetrics(request *lens.MetricRequest) (resBody []byte, resCode *lens.StatusCode, err error) {
-	resBody, resCode, err = impl.lensClient.GetAppMetrics(request)
-	return resBody, resCode, err
+func (impl *ReleaseDataServiceImpl) GetDeploymentMetrics(request *bean.DeploymentMetricsRequest) (*bean.AppEnvDeploymentMetrics, error) {
+	from, err := time.Parse(time.RFC3339, request.From)
+	if err != nil {
+		errMsg := fmt.Sprintf("invalid from time %q, expected RFC3339 format", request.From)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, err.Error())
+	}
+	to, err := time.Parse(time.RFC3339, request.To)
+	if err != nil {
+		errMsg := fmt.Sprintf("invalid to time %q, expected RFC3339 format", request.To)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, err.Error())
+	}
+	filter := &repository.DeploymentRunnerFilter{
+		From:   &from,
+		To:     &to,
+		AppIds: []int{request.AppId},
+		EnvIds: []int{request.EnvId},
+	}
+	runners, err := impl.doraMetricsRepository.FindDeploymentRunnersInTimeRange(filter)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployments for deployment metrics", "request", request, "err", err)
+		return nil, err
+	}
+	return util2.CalculateDeploymentMetrics(adaptor.GetDoraDeployments(runners)), nil
 }
@@ -23,6 +23,7 @@ const Workflows = "workflows"
 
 type ManifestPushTemplate struct {
 	WorkflowRunnerId       int
+	PipelineId             int
 	AppId                  int
 	ChartRefId             int
 	EnvironmentId          int
@@ -36,9 +37,11 @@ type ManifestPushTemplate struct {
 	ChartVersion           string
 	ChartLocation          string
 	RepoUrl                string
+	GitOpsRepoBasePath     string
 	TargetRevision         string
 	ValuesFilePath         string
 	ReleaseMode            string
+	DeploymentAppType      string
 	IsCustomGitRepository  bool
 	BuiltChartPath         string
 	BuiltChartBytes        *[]byte
@@ -48,8 +51,12 @@ type ManifestPushTemplate struct {
 
 type ManifestPushResponse struct {
 	NewGitRepoUrl string
-	CommitHash    string
-	CommitTime    time.Time
+	// NewGitOpsRepoBasePath is set when the chart is moved under the <app>/<env> directory of a shared GitOps repository
+	NewGitOpsRepoBasePath string
+	CommitHash            string
+	CommitTime            time.Time
+	// AwaitingMerge is set when the manifest is committed to a pull request, CommitHash is known once it is merged
+	AwaitingMerge bool
 	Error         error
 }
 
@@ -21,6 +21,8 @@ import (
 	"github.com/devtron-labs/devtron/api/bean"
 	client "github.com/devtron-labs/devtron/client/events"
 	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
 	util "github.com/devtron-labs/devtron/util/event"
 	"go.uber.org/zap"
 )
@@ -30,18 +32,21 @@ type DeploymentEventHandler interface {
 }
 
 type DeploymentEventHandlerImpl struct {
-	logger        *zap.SugaredLogger
-	eventFactory  client.EventFactory
-	eventClient   client.EventClient
-	asyncRunnable *async.Runnable
+	logger              *zap.SugaredLogger
+	eventFactory        client.EventFactory
+	eventClient         client.EventClient
+	asyncRunnable       *async.Runnable
+	commitStatusService commitStatus.CommitStatusService
 }
 
-func NewDeploymentEventHandlerImpl(logger *zap.SugaredLogger, eventClient client.EventClient, eventFactory client.EventFactory, asyncRunnable *async.Runnable) *DeploymentEventHandlerImpl {
+func NewDeploymentEventHandlerImpl(logger *zap.SugaredLogger, eventClient client.EventClient, eventFactory client.EventFactory, asyncRunnable *async.Runnable,
+	commitStatusService commitStatus.CommitStatusService) *DeploymentEventHandlerImpl {
 	deploymentEventHandlerImpl := &DeploymentEventHandlerImpl{
-		logger:        logger,
-		eventClient:   eventClient,
-		eventFactory:  eventFactory,
-		asyncRunnable: asyncRunnable,
+		logger:              logger,
+		eventClient:         eventClient,
+		eventFactory:        eventFactory,
+		asyncRunnable:       asyncRunnable,
+		commitStatusService: commitStatusService,
 	}
 	return deploymentEventHandlerImpl
 }
@@ -54,6 +59,27 @@ func (impl *DeploymentEventHandlerImpl) writeCDNotificationEvent(appId int, envI
 	if evtErr != nil {
 		impl.logger.Errorw("error in writing event", "event", event, "err", evtErr)
 	}
+	impl.reportCommitStatus(appId, envId, override, eventType)
+}
+
+func (impl *DeploymentEventHandlerImpl) reportCommitStatus(appId int, envId int, override *chartConfig.PipelineOverride, eventType util.EventType) {
+	var state commitStatusBean.State
+	switch eventType {
+	case util.Success:
+		state = commitStatusBean.StateSuccess
+	case util.Fail:
+		state = commitStatusBean.StateFailure
+	default:
+		return
+	}
+	impl.commitStatusService.ReportDeploymentStatusAsync(&commitStatusBean.DeploymentStatusRequest{
+		AppId:        appId,
+		EnvId:        envId,
+		PipelineId:   override.PipelineId,
+		CiArtifactId: override.CiArtifactId,
+		CdWorkflowId: override.CdWorkflowId,
+		State:        state,
+	})
 }
 
 // WriteCDNotificationEventAsync executes WriteCDNotificationEvent in a panic-safe goroutine
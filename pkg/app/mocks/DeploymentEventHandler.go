@@ -0,0 +1,34 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	chartConfig "github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	mock "github.com/stretchr/testify/mock"
+
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+// DeploymentEventHandler is an autogenerated mock type for the DeploymentEventHandler type
+type DeploymentEventHandler struct {
+	mock.Mock
+}
+
+// WriteCDNotificationEventAsync provides a mock function with given fields: appId, envId, override, eventType
+func (_m *DeploymentEventHandler) WriteCDNotificationEventAsync(appId int, envId int, override *chartConfig.PipelineOverride, eventType util.EventType) {
+	_m.Called(appId, envId, override, eventType)
+}
+
+// NewDeploymentEventHandler creates a new instance of DeploymentEventHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeploymentEventHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeploymentEventHandler {
+	mock := &DeploymentEventHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,301 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/app/status/bean"
+	mock "github.com/stretchr/testify/mock"
+
+	pg "github.com/go-pg/pg"
+
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	status "github.com/devtron-labs/devtron/pkg/app/status"
+
+	timelineStatus "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+)
+
+// PipelineStatusTimelineService is an autogenerated mock type for the PipelineStatusTimelineService type
+type PipelineStatusTimelineService struct {
+	mock.Mock
+}
+
+// FetchTimelines provides a mock function with given fields: appId, envId, wfrId, showTimeline
+func (_m *PipelineStatusTimelineService) FetchTimelines(appId int, envId int, wfrId int, showTimeline bool) (*status.PipelineTimelineDetailDto, error) {
+	ret := _m.Called(appId, envId, wfrId, showTimeline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTimelines")
+	}
+
+	var r0 *status.PipelineTimelineDetailDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) (*status.PipelineTimelineDetailDto, error)); ok {
+		return rf(appId, envId, wfrId, showTimeline)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) *status.PipelineTimelineDetailDto); ok {
+		r0 = rf(appId, envId, wfrId, showTimeline)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*status.PipelineTimelineDetailDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int, bool) error); ok {
+		r1 = rf(appId, envId, wfrId, showTimeline)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchTimelinesForAppStore provides a mock function with given fields: installedAppId, envId, installedAppVersionHistoryId, showTimeline
+func (_m *PipelineStatusTimelineService) FetchTimelinesForAppStore(installedAppId int, envId int, installedAppVersionHistoryId int, showTimeline bool) (*status.PipelineTimelineDetailDto, error) {
+	ret := _m.Called(installedAppId, envId, installedAppVersionHistoryId, showTimeline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTimelinesForAppStore")
+	}
+
+	var r0 *status.PipelineTimelineDetailDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) (*status.PipelineTimelineDetailDto, error)); ok {
+		return rf(installedAppId, envId, installedAppVersionHistoryId, showTimeline)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) *status.PipelineTimelineDetailDto); ok {
+		r0 = rf(installedAppId, envId, installedAppVersionHistoryId, showTimeline)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*status.PipelineTimelineDetailDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int, bool) error); ok {
+		r1 = rf(installedAppId, envId, installedAppVersionHistoryId, showTimeline)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArgoAppSyncStatus provides a mock function with given fields: cdWfrId
+func (_m *PipelineStatusTimelineService) GetArgoAppSyncStatus(cdWfrId int) bool {
+	ret := _m.Called(cdWfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArgoAppSyncStatus")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(cdWfrId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// GetArgoAppSyncStatusForAppStore provides a mock function with given fields: installedAppVersionHistoryId
+func (_m *PipelineStatusTimelineService) GetArgoAppSyncStatusForAppStore(installedAppVersionHistoryId int) bool {
+	ret := _m.Called(installedAppVersionHistoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArgoAppSyncStatusForAppStore")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(installedAppVersionHistoryId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// GetTimelineStatusesFor provides a mock function with given fields: request
+func (_m *PipelineStatusTimelineService) GetTimelineStatusesFor(request *bean.TimelineGetRequest) ([]timelineStatus.TimelineStatus, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimelineStatusesFor")
+	}
+
+	var r0 []timelineStatus.TimelineStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.TimelineGetRequest) ([]timelineStatus.TimelineStatus, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.TimelineGetRequest) []timelineStatus.TimelineStatus); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]timelineStatus.TimelineStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.TimelineGetRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPipelineStatusTimelineFailed provides a mock function with given fields: cdWfrId, statusDetailMessage
+func (_m *PipelineStatusTimelineService) MarkPipelineStatusTimelineFailed(cdWfrId int, statusDetailMessage string) error {
+	ret := _m.Called(cdWfrId, statusDetailMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPipelineStatusTimelineFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(cdWfrId, statusDetailMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkPipelineStatusTimelineSuperseded provides a mock function with given fields: cdWfrId
+func (_m *PipelineStatusTimelineService) MarkPipelineStatusTimelineSuperseded(cdWfrId int) error {
+	ret := _m.Called(cdWfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPipelineStatusTimelineSuperseded")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(cdWfrId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDevtronAppPipelineStatusTimelineDbObject provides a mock function with given fields: cdWorkflowRunnerId, _a1, timelineDescription, userId
+func (_m *PipelineStatusTimelineService) NewDevtronAppPipelineStatusTimelineDbObject(cdWorkflowRunnerId int, _a1 timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	ret := _m.Called(cdWorkflowRunnerId, _a1, timelineDescription, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewDevtronAppPipelineStatusTimelineDbObject")
+	}
+
+	var r0 *pipelineConfig.PipelineStatusTimeline
+	if rf, ok := ret.Get(0).(func(int, timelineStatus.TimelineStatus, string, int32) *pipelineConfig.PipelineStatusTimeline); ok {
+		r0 = rf(cdWorkflowRunnerId, _a1, timelineDescription, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.PipelineStatusTimeline)
+		}
+	}
+
+	return r0
+}
+
+// NewHelmAppDeploymentStatusTimelineDbObject provides a mock function with given fields: installedAppVersionHistoryId, _a1, timelineDescription, userId
+func (_m *PipelineStatusTimelineService) NewHelmAppDeploymentStatusTimelineDbObject(installedAppVersionHistoryId int, _a1 timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	ret := _m.Called(installedAppVersionHistoryId, _a1, timelineDescription, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewHelmAppDeploymentStatusTimelineDbObject")
+	}
+
+	var r0 *pipelineConfig.PipelineStatusTimeline
+	if rf, ok := ret.Get(0).(func(int, timelineStatus.TimelineStatus, string, int32) *pipelineConfig.PipelineStatusTimeline); ok {
+		r0 = rf(installedAppVersionHistoryId, _a1, timelineDescription, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.PipelineStatusTimeline)
+		}
+	}
+
+	return r0
+}
+
+// SaveMultipleTimelinesIfNotAlreadyPresent provides a mock function with given fields: timelines, tx
+func (_m *PipelineStatusTimelineService) SaveMultipleTimelinesIfNotAlreadyPresent(timelines []*pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	ret := _m.Called(timelines, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMultipleTimelinesIfNotAlreadyPresent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*pipelineConfig.PipelineStatusTimeline, *pg.Tx) error); ok {
+		r0 = rf(timelines, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTimeline provides a mock function with given fields: timeline, tx
+func (_m *PipelineStatusTimelineService) SaveTimeline(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	ret := _m.Called(timeline, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTimeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.PipelineStatusTimeline, *pg.Tx) error); ok {
+		r0 = rf(timeline, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTimelineIfNotAlreadyPresent provides a mock function with given fields: timeline, tx
+func (_m *PipelineStatusTimelineService) SaveTimelineIfNotAlreadyPresent(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) (bool, error) {
+	ret := _m.Called(timeline, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTimelineIfNotAlreadyPresent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.PipelineStatusTimeline, *pg.Tx) (bool, error)); ok {
+		return rf(timeline, tx)
+	}
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.PipelineStatusTimeline, *pg.Tx) bool); ok {
+		r0 = rf(timeline, tx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pipelineConfig.PipelineStatusTimeline, *pg.Tx) error); ok {
+		r1 = rf(timeline, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPipelineStatusTimelineService creates a new instance of PipelineStatusTimelineService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPipelineStatusTimelineService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PipelineStatusTimelineService {
+	mock := &PipelineStatusTimelineService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/deckarep/golang-set"
 	"github.com/devtron-labs/devtron/pkg/bean"
+	scheduleBean "github.com/devtron-labs/devtron/pkg/build/schedule/bean"
 )
 
 const (
@@ -71,10 +72,11 @@ type AppWorkflowDto struct {
 }
 
 type TriggerViewWorkflowConfig struct {
-	Workflows        []AppWorkflowDto          `json:"workflows"`
-	CiConfig         *bean.TriggerViewCiConfig `json:"ciConfig"`
-	CdPipelines      *bean.CdPipelines         `json:"cdConfig"`
-	ExternalCiConfig []*bean.ExternalCiConfig  `json:"externalCiConfig"`
+	Workflows        []AppWorkflowDto                      `json:"workflows"`
+	CiConfig         *bean.TriggerViewCiConfig             `json:"ciConfig"`
+	CdPipelines      *bean.CdPipelines                     `json:"cdConfig"`
+	ExternalCiConfig []*bean.ExternalCiConfig              `json:"externalCiConfig"`
+	CiSchedules      []*scheduleBean.CiPipelineScheduleDto `json:"ciSchedules"`
 }
 
 type AppWorkflowMappingDto struct {
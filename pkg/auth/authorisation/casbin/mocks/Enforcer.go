@@ -0,0 +1,139 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Enforcer is an autogenerated mock type for the Enforcer type
+type Enforcer struct {
+	mock.Mock
+}
+
+// Enforce provides a mock function with given fields: token, resource, action, resourceItem
+func (_m *Enforcer) Enforce(token string, resource string, action string, resourceItem string) bool {
+	ret := _m.Called(token, resource, action, resourceItem)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enforce")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string, string) bool); ok {
+		r0 = rf(token, resource, action, resourceItem)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EnforceByEmail provides a mock function with given fields: emailId, resource, action, resourceItem
+func (_m *Enforcer) EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool {
+	ret := _m.Called(emailId, resource, action, resourceItem)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnforceByEmail")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string, string) bool); ok {
+		r0 = rf(emailId, resource, action, resourceItem)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// EnforceInBatch provides a mock function with given fields: token, resource, action, vals
+func (_m *Enforcer) EnforceInBatch(token string, resource string, action string, vals []string) map[string]bool {
+	ret := _m.Called(token, resource, action, vals)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnforceInBatch")
+	}
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func(string, string, string, []string) map[string]bool); ok {
+		r0 = rf(token, resource, action, vals)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	return r0
+}
+
+// GetCacheDump provides a mock function with no fields
+func (_m *Enforcer) GetCacheDump() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCacheDump")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// InvalidateCache provides a mock function with given fields: emailId
+func (_m *Enforcer) InvalidateCache(emailId string) bool {
+	ret := _m.Called(emailId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCache")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(emailId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// InvalidateCompleteCache provides a mock function with no fields
+func (_m *Enforcer) InvalidateCompleteCache() {
+	_m.Called()
+}
+
+// ReloadPolicy provides a mock function with no fields
+func (_m *Enforcer) ReloadPolicy() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEnforcer creates a new instance of Enforcer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEnforcer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Enforcer {
+	mock := &Enforcer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -43,7 +43,8 @@ type Enforcer interface {
 	Enforce(token string, resource string, action string, resourceItem string) bool
 	//EnforceErr(emailId string, resource string, action string, resourceItem string) error
 	EnforceInBatch(token string, resource string, action string, vals []string) map[string]bool
-	//EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
+	// EnforceByEmail checks the roles of a user without a token, used for the actions run later on behalf of the user
+	EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
 	//EnforceByEmailInBatch(emailId string, resource string, action string, vals []string) map[string]bool
 	InvalidateCache(emailId string) bool
 	InvalidateCompleteCache()
@@ -0,0 +1,385 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_user
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+)
+
+// RoleGroupService is an autogenerated mock type for the RoleGroupService type
+type RoleGroupService struct {
+	mock.Mock
+}
+
+// BulkDeleteRoleGroups provides a mock function with given fields: request
+func (_m *RoleGroupService) BulkDeleteRoleGroups(request *bean.BulkDeleteRequest) (bool, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteRoleGroups")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.BulkDeleteRequest) (bool, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.BulkDeleteRequest) bool); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.BulkDeleteRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoleGroup provides a mock function with given fields: request
+func (_m *RoleGroupService) CreateRoleGroup(request *bean.RoleGroup) (*bean.RoleGroup, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoleGroup")
+	}
+
+	var r0 *bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup) (*bean.RoleGroup, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup) *bean.RoleGroup); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.RoleGroup) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRoleGroup provides a mock function with given fields: model
+func (_m *RoleGroupService) DeleteRoleGroup(model *bean.RoleGroup) (bool, error) {
+	ret := _m.Called(model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoleGroup")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup) (bool, error)); ok {
+		return rf(model)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup) bool); ok {
+		r0 = rf(model)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.RoleGroup) error); ok {
+		r1 = rf(model)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDetailedRoleGroups provides a mock function with given fields: req
+func (_m *RoleGroupService) FetchDetailedRoleGroups(req *bean.ListingRequest) ([]*bean.RoleGroup, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDetailedRoleGroups")
+	}
+
+	var r0 []*bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) ([]*bean.RoleGroup, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) []*bean.RoleGroup); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ListingRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRoleGroups provides a mock function with no fields
+func (_m *RoleGroupService) FetchRoleGroups() ([]*bean.RoleGroup, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRoleGroups")
+	}
+
+	var r0 []*bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*bean.RoleGroup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*bean.RoleGroup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRoleGroupsById provides a mock function with given fields: id
+func (_m *RoleGroupService) FetchRoleGroupsById(id int32) (*bean.RoleGroup, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRoleGroupsById")
+	}
+
+	var r0 *bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32) (*bean.RoleGroup, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int32) *bean.RoleGroup); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRoleGroupsByName provides a mock function with given fields: name
+func (_m *RoleGroupService) FetchRoleGroupsByName(name string) ([]*bean.RoleGroup, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRoleGroupsByName")
+	}
+
+	var r0 []*bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*bean.RoleGroup, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*bean.RoleGroup); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRoleGroupsV2 provides a mock function with given fields: req
+func (_m *RoleGroupService) FetchRoleGroupsV2(req *bean.ListingRequest) (*bean.RoleGroupListingResponse, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRoleGroupsV2")
+	}
+
+	var r0 *bean.RoleGroupListingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) (*bean.RoleGroupListingResponse, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) *bean.RoleGroupListingResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.RoleGroupListingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ListingRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRoleGroupsWithFilters provides a mock function with given fields: request
+func (_m *RoleGroupService) FetchRoleGroupsWithFilters(request *bean.ListingRequest) (*bean.RoleGroupListingResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRoleGroupsWithFilters")
+	}
+
+	var r0 *bean.RoleGroupListingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) (*bean.RoleGroupListingResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) *bean.RoleGroupListingResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.RoleGroupListingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ListingRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRolesForUserRoleGroups provides a mock function with given fields: userRoleGroups
+func (_m *RoleGroupService) FetchRolesForUserRoleGroups(userRoleGroups []bean.UserRoleGroup) ([]*bean.RoleFilter, error) {
+	ret := _m.Called(userRoleGroups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRolesForUserRoleGroups")
+	}
+
+	var r0 []*bean.RoleFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]bean.UserRoleGroup) ([]*bean.RoleFilter, error)); ok {
+		return rf(userRoleGroups)
+	}
+	if rf, ok := ret.Get(0).(func([]bean.UserRoleGroup) []*bean.RoleFilter); ok {
+		r0 = rf(userRoleGroups)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.RoleFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]bean.UserRoleGroup) error); ok {
+		r1 = rf(userRoleGroups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupIdVsRoleGroupMapForIds provides a mock function with given fields: ids
+func (_m *RoleGroupService) GetGroupIdVsRoleGroupMapForIds(ids []int32) (map[int32]*repository.RoleGroup, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupIdVsRoleGroupMapForIds")
+	}
+
+	var r0 map[int32]*repository.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int32) (map[int32]*repository.RoleGroup, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int32) map[int32]*repository.RoleGroup); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int32]*repository.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int32) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRoleGroup provides a mock function with given fields: request, token, checkRBACForGroupUpdate, managerAuth
+func (_m *RoleGroupService) UpdateRoleGroup(request *bean.RoleGroup, token string, checkRBACForGroupUpdate func(string, *bean.RoleGroup, []*repository.RoleModel, bool) (bool, error), managerAuth func(string, string, string) bool) (*bean.RoleGroup, error) {
+	ret := _m.Called(request, token, checkRBACForGroupUpdate, managerAuth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRoleGroup")
+	}
+
+	var r0 *bean.RoleGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup, string, func(string, *bean.RoleGroup, []*repository.RoleModel, bool) (bool, error), func(string, string, string) bool) (*bean.RoleGroup, error)); ok {
+		return rf(request, token, checkRBACForGroupUpdate, managerAuth)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.RoleGroup, string, func(string, *bean.RoleGroup, []*repository.RoleModel, bool) (bool, error), func(string, string, string) bool) *bean.RoleGroup); ok {
+		r0 = rf(request, token, checkRBACForGroupUpdate, managerAuth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.RoleGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.RoleGroup, string, func(string, *bean.RoleGroup, []*repository.RoleModel, bool) (bool, error), func(string, string, string) bool) error); ok {
+		r1 = rf(request, token, checkRBACForGroupUpdate, managerAuth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRoleGroupService creates a new instance of RoleGroupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleGroupService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleGroupService {
+	mock := &RoleGroupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,814 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mock_user
+
+import (
+	context "context"
+
+	bean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+)
+
+// UserService is an autogenerated mock type for the UserService type
+type UserService struct {
+	mock.Mock
+}
+
+// BulkDeleteUsers provides a mock function with given fields: request
+func (_m *UserService) BulkDeleteUsers(request *bean.BulkDeleteRequest) (bool, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteUsers")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.BulkDeleteRequest) (bool, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.BulkDeleteRequest) bool); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.BulkDeleteRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckIfTokenIsValid provides a mock function with given fields: email, version
+func (_m *UserService) CheckIfTokenIsValid(email string, version string) error {
+	ret := _m.Called(email, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIfTokenIsValid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(email, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckUserRoles provides a mock function with given fields: id, token
+func (_m *UserService) CheckUserRoles(id int32, token string) ([]string, error) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckUserRoles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32, string) ([]string, error)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(int32, string) []string); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32, string) error); ok {
+		r1 = rf(id, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckUserStatusAndUpdateLoginAudit provides a mock function with given fields: token
+func (_m *UserService) CheckUserStatusAndUpdateLoginAudit(token string) (bool, int32, string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckUserStatusAndUpdateLoginAudit")
+	}
+
+	var r0 bool
+	var r1 int32
+	var r2 string
+	var r3 error
+	if rf, ok := ret.Get(0).(func(string) (bool, int32, string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) int32); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Get(1).(int32)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) string); ok {
+		r2 = rf(token)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	if rf, ok := ret.Get(3).(func(string) error); ok {
+		r3 = rf(token)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// CreateUser provides a mock function with given fields: userInfo, token, managerAuth
+func (_m *UserService) CreateUser(userInfo *bean.UserInfo, token string, managerAuth func(string, string, string) bool) ([]*bean.UserInfo, error) {
+	ret := _m.Called(userInfo, token, managerAuth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 []*bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo, string, func(string, string, string) bool) ([]*bean.UserInfo, error)); ok {
+		return rf(userInfo, token, managerAuth)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo, string, func(string, string, string) bool) []*bean.UserInfo); ok {
+		r0 = rf(userInfo, token, managerAuth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.UserInfo, string, func(string, string, string) bool) error); ok {
+		r1 = rf(userInfo, token, managerAuth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: userInfo
+func (_m *UserService) DeleteUser(userInfo *bean.UserInfo) (bool, error) {
+	ret := _m.Called(userInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo) (bool, error)); ok {
+		return rf(userInfo)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo) bool); ok {
+		r0 = rf(userInfo)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.UserInfo) error); ok {
+		r1 = rf(userInfo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveEmailById provides a mock function with given fields: userId
+func (_m *UserService) GetActiveEmailById(userId int32) (string, error) {
+	ret := _m.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveEmailById")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32) (string, error)); ok {
+		return rf(userId)
+	}
+	if rf, ok := ret.Get(0).(func(int32) string); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with no fields
+func (_m *UserService) GetAll() ([]bean.UserInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]bean.UserInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []bean.UserInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllDetailedUsers provides a mock function with no fields
+func (_m *UserService) GetAllDetailedUsers() ([]bean.UserInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllDetailedUsers")
+	}
+
+	var r0 []bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]bean.UserInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []bean.UserInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllWithFilters provides a mock function with given fields: request
+func (_m *UserService) GetAllWithFilters(request *bean.ListingRequest) (*bean.UserListingResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllWithFilters")
+	}
+
+	var r0 *bean.UserListingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) (*bean.UserListingResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.ListingRequest) *bean.UserListingResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.UserListingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.ListingRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIdIncludeDeleted provides a mock function with given fields: id
+func (_m *UserService) GetByIdIncludeDeleted(id int32) (*bean.UserInfo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIdIncludeDeleted")
+	}
+
+	var r0 *bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32) (*bean.UserInfo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int32) *bean.UserInfo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIdWithoutGroupClaims provides a mock function with given fields: id
+func (_m *UserService) GetByIdWithoutGroupClaims(id int32) (*bean.UserInfo, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIdWithoutGroupClaims")
+	}
+
+	var r0 *bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32) (*bean.UserInfo, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int32) *bean.UserInfo); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIds provides a mock function with given fields: ids
+func (_m *UserService) GetByIds(ids []int32) ([]bean.UserInfo, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIds")
+	}
+
+	var r0 []bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int32) ([]bean.UserInfo, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int32) []bean.UserInfo); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int32) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailAndGroupClaimsFromToken provides a mock function with given fields: token
+func (_m *UserService) GetEmailAndGroupClaimsFromToken(token string) (string, []string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailAndGroupClaimsFromToken")
+	}
+
+	var r0 string
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, []string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) []string); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetEmailAndVersionFromToken provides a mock function with given fields: token
+func (_m *UserService) GetEmailAndVersionFromToken(token string) (string, string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailAndVersionFromToken")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (string, string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetEmailById provides a mock function with given fields: userId
+func (_m *UserService) GetEmailById(userId int32) (string, error) {
+	ret := _m.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailById")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int32) (string, error)); ok {
+		return rf(userId)
+	}
+	if rf, ok := ret.Get(0).(func(int32) string); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailFromToken provides a mock function with given fields: token
+func (_m *UserService) GetEmailFromToken(token string) (string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailFromToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoggedInUser provides a mock function with given fields: r
+func (_m *UserService) GetLoggedInUser(r *http.Request) (int32, error) {
+	ret := _m.Called(r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoggedInUser")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*http.Request) (int32, error)); ok {
+		return rf(r)
+	}
+	if rf, ok := ret.Get(0).(func(*http.Request) int32); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleFiltersByUserRoleGroups provides a mock function with given fields: userRoleGroups
+func (_m *UserService) GetRoleFiltersByUserRoleGroups(userRoleGroups []bean.UserRoleGroup) ([]bean.RoleFilter, error) {
+	ret := _m.Called(userRoleGroups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleFiltersByUserRoleGroups")
+	}
+
+	var r0 []bean.RoleFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]bean.UserRoleGroup) ([]bean.RoleFilter, error)); ok {
+		return rf(userRoleGroups)
+	}
+	if rf, ok := ret.Get(0).(func([]bean.UserRoleGroup) []bean.RoleFilter); ok {
+		r0 = rf(userRoleGroups)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bean.RoleFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]bean.UserRoleGroup) error); ok {
+		r1 = rf(userRoleGroups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByToken provides a mock function with given fields: _a0, token
+func (_m *UserService) GetUserByToken(_a0 context.Context, token string) (int32, string, error) {
+	ret := _m.Called(_a0, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByToken")
+	}
+
+	var r0 int32
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int32, string, error)); ok {
+		return rf(_a0, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int32); ok {
+		r0 = rf(_a0, token)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(_a0, token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(_a0, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IsSuperAdmin provides a mock function with given fields: userId, token
+func (_m *UserService) IsSuperAdmin(userId int, token string) (bool, error) {
+	ret := _m.Called(userId, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSuperAdmin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (bool, error)); ok {
+		return rf(userId, token)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) bool); ok {
+		r0 = rf(userId, token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userId, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLoginAudit provides a mock function with given fields: emailId, clientIp, id
+func (_m *UserService) SaveLoginAudit(emailId string, clientIp string, id int32) {
+	_m.Called(emailId, clientIp, id)
+}
+
+// SelfRegisterUserIfNotExists provides a mock function with given fields: selfRegisterDto
+func (_m *UserService) SelfRegisterUserIfNotExists(selfRegisterDto *bean.SelfRegisterDto) ([]*bean.UserInfo, error) {
+	ret := _m.Called(selfRegisterDto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelfRegisterUserIfNotExists")
+	}
+
+	var r0 []*bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.SelfRegisterDto) ([]*bean.UserInfo, error)); ok {
+		return rf(selfRegisterDto)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.SelfRegisterDto) []*bean.UserInfo); ok {
+		r0 = rf(selfRegisterDto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.SelfRegisterDto) error); ok {
+		r1 = rf(selfRegisterDto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncOrchestratorToCasbin provides a mock function with no fields
+func (_m *UserService) SyncOrchestratorToCasbin() (bool, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncOrchestratorToCasbin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (bool, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTriggerPolicyForTerminalAccess provides a mock function with no fields
+func (_m *UserService) UpdateTriggerPolicyForTerminalAccess() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTriggerPolicyForTerminalAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: userInfo, token, checkRBACForUserUpdate, managerAuth
+func (_m *UserService) UpdateUser(userInfo *bean.UserInfo, token string, checkRBACForUserUpdate func(string, *bean.UserInfo, bool, []*repository.RoleModel, []*repository.RoleModel, map[string]bool) (bool, error), managerAuth func(string, string, string) bool) (*bean.UserInfo, error) {
+	ret := _m.Called(userInfo, token, checkRBACForUserUpdate, managerAuth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *bean.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo, string, func(string, *bean.UserInfo, bool, []*repository.RoleModel, []*repository.RoleModel, map[string]bool) (bool, error), func(string, string, string) bool) (*bean.UserInfo, error)); ok {
+		return rf(userInfo, token, checkRBACForUserUpdate, managerAuth)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.UserInfo, string, func(string, *bean.UserInfo, bool, []*repository.RoleModel, []*repository.RoleModel, map[string]bool) (bool, error), func(string, string, string) bool) *bean.UserInfo); ok {
+		r0 = rf(userInfo, token, checkRBACForUserUpdate, managerAuth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.UserInfo, string, func(string, *bean.UserInfo, bool, []*repository.RoleModel, []*repository.RoleModel, map[string]bool) (bool, error), func(string, string, string) bool) error); ok {
+		r1 = rf(userInfo, token, checkRBACForUserUpdate, managerAuth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserGroupMappingIfActiveUser provides a mock function with given fields: emailId, groups
+func (_m *UserService) UpdateUserGroupMappingIfActiveUser(emailId string, groups []string) error {
+	ret := _m.Called(emailId, groups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserGroupMappingIfActiveUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(emailId, groups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserExists provides a mock function with given fields: emailId
+func (_m *UserService) UserExists(emailId string) bool {
+	ret := _m.Called(emailId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExists")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(emailId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserService {
+	mock := &UserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
 	repository2 "github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 	"github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/bean/common"
 	CiPipeline2 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
@@ -34,6 +35,7 @@ import (
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
+	imageSigningBean "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
 	"strings"
 	"time"
 )
@@ -376,16 +378,19 @@ type CiPipelineMaterial struct {
 	Active        bool                     `json:"Active"`
 	GitCommit     pipelineConfig.GitCommit `json:"GitCommit"`
 	GitTag        string                   `json:"GitTag"`
+	// BranchOverride builds the commit from this branch instead of the configured one, set only by internal triggers
+	BranchOverride string `json:"-"`
 }
 
 type CiTriggerRequest struct {
-	PipelineId          int                  `json:"pipelineId"`
-	CiPipelineMaterial  []CiPipelineMaterial `json:"ciPipelineMaterials" validate:"required"`
-	TriggeredBy         int32                `json:"triggeredBy"`
-	InvalidateCache     bool                 `json:"invalidateCache"`
-	EnvironmentId       int                  `json:"environmentId"`
-	PipelineType        string               `json:"pipelineType"`
-	CiArtifactLastFetch time.Time            `json:"ciArtifactLastFetch"`
+	PipelineId          int                    `json:"pipelineId"`
+	CiPipelineMaterial  []CiPipelineMaterial   `json:"ciPipelineMaterials" validate:"required"`
+	TriggeredBy         int32                  `json:"triggeredBy"`
+	InvalidateCache     bool                   `json:"invalidateCache"`
+	EnvironmentId       int                    `json:"environmentId"`
+	PipelineType        string                 `json:"pipelineType"`
+	CiArtifactLastFetch time.Time              `json:"ciArtifactLastFetch"`
+	TriggerType         workflow.CiTriggerType `json:"-"` // set internally, defaults to MANUAL
 }
 
 type CiTrigger struct {
@@ -889,6 +894,8 @@ type CiArtifactBean struct {
 	CiPipelineId                  int                       `json:"-"`
 	CredentialsSourceType         string                    `json:"-"`
 	CredentialsSourceValue        string                    `json:"-"`
+	// SignatureVerification is the image signature verification result of the latest deployment of the artifact
+	SignatureVerification *imageSigningBean.ArtifactSignatureVerification `json:"signatureVerification,omitempty"`
 }
 
 type CiArtifactResponse struct {
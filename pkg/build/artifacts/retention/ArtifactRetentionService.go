@@ -0,0 +1,866 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	appRepository "github.com/devtron-labs/devtron/internal/sql/repository/app"
+	dockerRegistry "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	retentionRepository "github.com/devtron-labs/devtron/pkg/build/artifacts/retention/repository"
+	retentionUtil "github.com/devtron-labs/devtron/pkg/build/artifacts/retention/util"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/read"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	teamRepository "github.com/devtron-labs/devtron/pkg/team/repository"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ArtifactRetentionService interface {
+	GetConfig() *bean.ArtifactGcConfig
+	GetPolicies() ([]*bean.RetentionPolicyDto, error)
+	SavePolicy(request *bean.RetentionPolicyDto) (*bean.RetentionPolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+	// CreateDryRun plans the deletions of the policy and saves them as a report, nothing is deleted.
+	// Pending reports of the policy are discarded in favour of the new one.
+	CreateDryRun(policyId int, userId int32) (*bean.GcRunDto, error)
+	// ExecuteRun deletes what the dry run report planned in the background, the protections
+	// are checked again before every deletion
+	ExecuteRun(runId int, userId int32) (*bean.GcRunDto, error)
+	GetRuns(policyId int) ([]*bean.GcRunDto, error)
+	GetRunReport(runId int) (*bean.GcRunDto, error)
+	// RunScheduledGc fails the interrupted runs, executes the scheduled reports whose grace period is over
+	// when auto execution is enabled and creates a report for the policies without a pending one
+	RunScheduledGc()
+}
+
+type ArtifactRetentionServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	config                        *bean.ArtifactGcConfig
+	policyRepository              retentionRepository.ArtifactRetentionPolicyRepository
+	gcRunRepository               retentionRepository.ArtifactGcRunRepository
+	gcRepository                  retentionRepository.ArtifactGcRepository
+	appRepository                 appRepository.AppRepository
+	teamRepository                teamRepository.TeamRepository
+	ciArtifactRepository          repository.CiArtifactRepository
+	ciWorkflowRepository          pipelineConfig.CiWorkflowRepository
+	ciPipelineRepository          pipelineConfig.CiPipelineRepository
+	ciPipelineConfigReadService   read.CiPipelineConfigReadService
+	dockerArtifactStoreRepository dockerRegistry.DockerArtifactStoreRepository
+	customTagService              pipeline.CustomTagService
+	buildBlobCleaner              BuildBlobCleaner
+	registryTagCleaner            RegistryTagCleaner
+	asyncRunnable                 *async.Runnable
+}
+
+func NewArtifactRetentionServiceImpl(logger *zap.SugaredLogger,
+	policyRepository retentionRepository.ArtifactRetentionPolicyRepository,
+	gcRunRepository retentionRepository.ArtifactGcRunRepository,
+	gcRepository retentionRepository.ArtifactGcRepository,
+	appRepository appRepository.AppRepository,
+	teamRepository teamRepository.TeamRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	ciPipelineConfigReadService read.CiPipelineConfigReadService,
+	dockerArtifactStoreRepository dockerRegistry.DockerArtifactStoreRepository,
+	customTagService pipeline.CustomTagService,
+	buildBlobCleaner BuildBlobCleaner,
+	registryTagCleaner RegistryTagCleaner,
+	asyncRunnable *async.Runnable) *ArtifactRetentionServiceImpl {
+	return &ArtifactRetentionServiceImpl{
+		logger:                        logger,
+		config:                        getArtifactGcConfig(logger),
+		policyRepository:              policyRepository,
+		gcRunRepository:               gcRunRepository,
+		gcRepository:                  gcRepository,
+		appRepository:                 appRepository,
+		teamRepository:                teamRepository,
+		ciArtifactRepository:          ciArtifactRepository,
+		ciWorkflowRepository:          ciWorkflowRepository,
+		ciPipelineRepository:          ciPipelineRepository,
+		ciPipelineConfigReadService:   ciPipelineConfigReadService,
+		dockerArtifactStoreRepository: dockerArtifactStoreRepository,
+		customTagService:              customTagService,
+		buildBlobCleaner:              buildBlobCleaner,
+		registryTagCleaner:            registryTagCleaner,
+		asyncRunnable:                 asyncRunnable,
+	}
+}
+
+func getArtifactGcConfig(logger *zap.SugaredLogger) *bean.ArtifactGcConfig {
+	config := &bean.ArtifactGcConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing artifact gc config, scheduled garbage collection is disabled", "err", err)
+		config = &bean.ArtifactGcConfig{}
+	}
+	return config
+}
+
+func (impl *ArtifactRetentionServiceImpl) GetConfig() *bean.ArtifactGcConfig {
+	return impl.config
+}
+
+func (impl *ArtifactRetentionServiceImpl) GetPolicies() ([]*bean.RetentionPolicyDto, error) {
+	policies, err := impl.policyRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact retention policies", "err", err)
+		return nil, err
+	}
+	policyDtos := make([]*bean.RetentionPolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		policyDtos = append(policyDtos, adapter.BuildRetentionPolicyDto(policy))
+	}
+	return policyDtos, nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) SavePolicy(request *bean.RetentionPolicyDto) (*bean.RetentionPolicyDto, error) {
+	err := impl.validatePolicyScope(request.ScopeType, request.ScopeId)
+	if err != nil {
+		return nil, err
+	}
+	if request.KeepLastN < 1 {
+		return nil, util.NewApiError(http.StatusBadRequest, "at least the latest artifact must be kept", "keepLastN must be at least 1")
+	}
+	if request.KeepDeployedWithinDays < 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "invalid keep deployed within days", "keepDeployedWithinDays can not be negative")
+	}
+	policy, err := impl.policyRepository.FindActiveByScope(request.ScopeType.String(), request.ScopeId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching artifact retention policy", "scopeType", request.ScopeType, "scopeId", request.ScopeId, "err", err)
+		return nil, err
+	}
+	isNew := err == pg.ErrNoRows
+	if isNew {
+		policy = &retentionRepository.ArtifactRetentionPolicy{
+			ScopeType: request.ScopeType.String(),
+			ScopeId:   request.ScopeId,
+			Active:    true,
+			AuditLog:  sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		policy.UpdateAuditLog(request.UserId)
+	}
+	policy.KeepLastN = request.KeepLastN
+	policy.KeepDeployedWithinDays = request.KeepDeployedWithinDays
+	policy.DeleteRegistryTags = request.DeleteRegistryTags
+	policy.DeleteBuildLogs = request.DeleteBuildLogs
+	policy.DeleteBuildCache = request.DeleteBuildCache
+	if isNew {
+		err = impl.policyRepository.Save(policy)
+	} else {
+		err = impl.policyRepository.Update(policy)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving artifact retention policy", "scopeType", request.ScopeType, "scopeId", request.ScopeId, "err", err)
+		return nil, err
+	}
+	if !isNew {
+		// pending reports were planned as per the previous settings
+		err = impl.discardDryRuns(policy.Id, bean.PolicyUpdatedMessage, request.UserId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return adapter.BuildRetentionPolicyDto(policy), nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) validatePolicyScope(scopeType bean.PolicyScopeType, scopeId int) error {
+	if !scopeType.IsValid() {
+		errMsg := fmt.Sprintf("invalid scope type %s", scopeType)
+		return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	var err error
+	switch scopeType {
+	case bean.PolicyScopeApp:
+		_, err = impl.appRepository.FindById(scopeId)
+	case bean.PolicyScopeProject:
+		_, err = impl.teamRepository.FindOne(scopeId)
+	}
+	if err == pg.ErrNoRows {
+		errMsg := fmt.Sprintf("%s %d not found", strings.ToLower(scopeType.String()), scopeId)
+		return util.NewApiError(http.StatusNotFound, errMsg, errMsg)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching retention policy scope", "scopeType", scopeType, "scopeId", scopeId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.policyRepository.FindById(id)
+	if err == pg.ErrNoRows {
+		return util.NewApiError(http.StatusNotFound, "artifact retention policy not found", "artifact retention policy not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact retention policy", "id", id, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	err = impl.policyRepository.Update(policy)
+	if err != nil {
+		impl.logger.Errorw("error in deleting artifact retention policy", "id", id, "err", err)
+		return err
+	}
+	return impl.discardDryRuns(policy.Id, bean.PolicyDeletedMessage, userId)
+}
+
+func (impl *ArtifactRetentionServiceImpl) discardDryRuns(policyId int, message string, userId int32) error {
+	tx, err := impl.gcRunRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction for discarding dry run reports", "policyId", policyId, "err", err)
+		return err
+	}
+	defer impl.gcRunRepository.RollbackTx(tx)
+	err = impl.gcRunRepository.LockPolicyWithTx(tx, policyId)
+	if err != nil {
+		impl.logger.Errorw("error in acquiring artifact gc lock", "policyId", policyId, "err", err)
+		return err
+	}
+	err = impl.discardDryRunsWithTx(tx, policyId, message, userId)
+	if err != nil {
+		return err
+	}
+	return impl.gcRunRepository.CommitTx(tx)
+}
+
+func (impl *ArtifactRetentionServiceImpl) discardDryRunsWithTx(tx *pg.Tx, policyId int, message string, userId int32) error {
+	dryRuns, err := impl.gcRunRepository.FindDryRunsByPolicyIdWithTx(tx, policyId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching dry run reports", "policyId", policyId, "err", err)
+		return err
+	}
+	for _, dryRun := range dryRuns {
+		_, err = impl.gcRunRepository.UpdateStatusWithTx(tx, dryRun.Id, bean.RunStatusDryRun, bean.RunStatusDiscarded, message, userId)
+		if err != nil {
+			impl.logger.Errorw("error in discarding dry run report", "runId", dryRun.Id, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) CreateDryRun(policyId int, userId int32) (*bean.GcRunDto, error) {
+	policy, err := impl.policyRepository.FindById(policyId)
+	if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "artifact retention policy not found", "artifact retention policy not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact retention policy", "id", policyId, "err", err)
+		return nil, err
+	}
+	return impl.createDryRun(policy, bean.RunTriggerManual, userId)
+}
+
+// createDryRun saves the report of the policy, a scheduled report is not created when the policy
+// already has a pending report or when there is nothing to delete
+func (impl *ArtifactRetentionServiceImpl) createDryRun(policy *retentionRepository.ArtifactRetentionPolicy,
+	triggerType bean.RunTriggerType, userId int32) (*bean.GcRunDto, error) {
+	plan, err := impl.planGc(policy)
+	if err != nil {
+		return nil, err
+	}
+	isScheduled := triggerType == bean.RunTriggerScheduled
+	if isScheduled && len(plan.Items) == 0 {
+		return nil, nil
+	}
+	tx, err := impl.gcRunRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction for saving dry run report", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	defer impl.gcRunRepository.RollbackTx(tx)
+	err = impl.gcRunRepository.LockPolicyWithTx(tx, policy.Id)
+	if err != nil {
+		impl.logger.Errorw("error in acquiring artifact gc lock", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	if isScheduled {
+		pendingRuns, err := impl.gcRunRepository.FindDryRunsByPolicyIdWithTx(tx, policy.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching dry run reports", "policyId", policy.Id, "err", err)
+			return nil, err
+		} else if len(pendingRuns) > 0 {
+			return nil, nil
+		}
+	}
+	plannedCount := retentionUtil.GetPlannedCount(plan)
+	run := &retentionRepository.ArtifactGcRun{
+		PolicyId:     policy.Id,
+		Status:       bean.RunStatusDryRun.String(),
+		TriggerType:  triggerType.String(),
+		KeptCount:    plan.KeptCount,
+		PlannedCount: plannedCount,
+		SkippedCount: len(plan.Items) - plannedCount,
+		AuditLog:     sql.NewDefaultAuditLog(userId),
+	}
+	err = impl.gcRunRepository.SaveWithTx(tx, run)
+	if err != nil {
+		impl.logger.Errorw("error in saving dry run report", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	err = impl.discardDryRunsExceptWithTx(tx, policy.Id, run.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*retentionRepository.ArtifactGcRunItem, 0, len(plan.Items))
+	for _, plannedItem := range plan.Items {
+		items = append(items, adapter.GetGcRunItemDbObj(run.Id, plannedItem, userId))
+	}
+	err = impl.gcRunRepository.SaveItemsWithTx(tx, items)
+	if err != nil {
+		impl.logger.Errorw("error in saving dry run report items", "runId", run.Id, "err", err)
+		return nil, err
+	}
+	err = impl.gcRunRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing dry run report", "runId", run.Id, "err", err)
+		return nil, err
+	}
+	return adapter.BuildGcRunDto(run), nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) discardDryRunsExceptWithTx(tx *pg.Tx, policyId, runId int, userId int32) error {
+	dryRuns, err := impl.gcRunRepository.FindDryRunsByPolicyIdWithTx(tx, policyId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching dry run reports", "policyId", policyId, "err", err)
+		return err
+	}
+	for _, dryRun := range dryRuns {
+		if dryRun.Id == runId {
+			continue
+		}
+		_, err = impl.gcRunRepository.UpdateStatusWithTx(tx, dryRun.Id, bean.RunStatusDryRun, bean.RunStatusDiscarded, fmt.Sprintf(bean.ReplacedByNewerRunMessage, runId), userId)
+		if err != nil {
+			impl.logger.Errorw("error in discarding dry run report", "runId", dryRun.Id, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) planGc(policy *retentionRepository.ArtifactRetentionPolicy) (*bean.GcPlan, error) {
+	appIds, err := impl.getAppIdsOfPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := impl.getArtifactDetails(appIds)
+	if err != nil {
+		return nil, err
+	}
+	var deletedCiPipelines []*bean.DeletedCiPipelineDetail
+	if policy.DeleteBuildCache {
+		deletedCiPipelines, err = impl.gcRepository.FindDeletedCiPipelinesWithBuildCache(appIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching deleted ci pipelines", "appIds", appIds, "err", err)
+			return nil, err
+		}
+	}
+	return retentionUtil.PlanArtifactGc(adapter.BuildRetentionPolicyDto(policy), artifacts, deletedCiPipelines, time.Now(), impl.config.MaxItemsPerRun), nil
+}
+
+// getAppIdsOfPolicy returns the apps governed by the policy, apps of a project having their own policy are left to it
+func (impl *ArtifactRetentionServiceImpl) getAppIdsOfPolicy(policy *retentionRepository.ArtifactRetentionPolicy) ([]int, error) {
+	appIds := make([]int, 0)
+	switch bean.PolicyScopeType(policy.ScopeType) {
+	case bean.PolicyScopeApp:
+		_, err := impl.appRepository.FindById(policy.ScopeId)
+		if err == pg.ErrNoRows {
+			return appIds, nil
+		} else if err != nil {
+			impl.logger.Errorw("error in fetching app", "appId", policy.ScopeId, "err", err)
+			return nil, err
+		}
+		appIds = append(appIds, policy.ScopeId)
+	case bean.PolicyScopeProject:
+		apps, err := impl.appRepository.FindAppsByTeamId(policy.ScopeId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching apps of team", "teamId", policy.ScopeId, "err", err)
+			return nil, err
+		}
+		policies, err := impl.policyRepository.FindAllActive()
+		if err != nil {
+			impl.logger.Errorw("error in fetching artifact retention policies", "err", err)
+			return nil, err
+		}
+		appsWithPolicy := make(map[int]bool)
+		for _, appPolicy := range policies {
+			if appPolicy.ScopeType == bean.PolicyScopeApp.String() {
+				appsWithPolicy[appPolicy.ScopeId] = true
+			}
+		}
+		for _, app := range apps {
+			if !appsWithPolicy[app.Id] {
+				appIds = append(appIds, app.Id)
+			}
+		}
+	}
+	return appIds, nil
+}
+
+// getArtifactDetails returns the artifacts of the apps along with their deployments and references
+func (impl *ArtifactRetentionServiceImpl) getArtifactDetails(appIds []int) ([]*bean.ArtifactDetail, error) {
+	candidates, err := impl.gcRepository.FindCandidatesByAppIds(appIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifacts of apps", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	artifacts := make([]*bean.ArtifactDetail, 0, len(candidates))
+	artifactIds := make([]int, 0, len(candidates))
+	for _, candidate := range candidates {
+		artifacts = append(artifacts, adapter.GetArtifactDetail(candidate))
+		artifactIds = append(artifactIds, candidate.CiArtifactId)
+	}
+	if len(artifacts) == 0 {
+		return artifacts, nil
+	}
+	currentlyDeployedIds, err := impl.gcRepository.FindCurrentlyDeployedArtifactIds(appIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching currently deployed artifacts", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	deployments, err := impl.gcRepository.FindDeploymentInfo(artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployments of artifacts", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	referencedIds, err := impl.gcRepository.FindReferencedArtifactIds(artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching referenced artifacts", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	buildLogsDeletedIds, err := impl.gcRunRepository.FindArtifactIdsWithDeletedBuildLogs(artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifacts with deleted build logs", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	imageDeletedIds, err := impl.gcRunRepository.FindArtifactIdsWithDeletedImages(artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifacts with deleted images", "appIds", appIds, "err", err)
+		return nil, err
+	}
+	currentlyDeployed := toSet(currentlyDeployedIds)
+	referenced := toSet(referencedIds)
+	buildLogsDeleted := toSet(buildLogsDeletedIds)
+	imageDeleted := toSet(imageDeletedIds)
+	lastDeployedOn := make(map[int]time.Time, len(deployments))
+	for _, deployment := range deployments {
+		lastDeployedOn[deployment.CiArtifactId] = deployment.LastDeployedOn
+	}
+	for _, artifact := range artifacts {
+		artifact.CurrentlyDeployed = currentlyDeployed[artifact.Id]
+		artifact.Referenced = referenced[artifact.Id]
+		artifact.BuildLogsDeleted = buildLogsDeleted[artifact.Id]
+		artifact.ImageDeleted = imageDeleted[artifact.Id]
+		if deployedOn, ok := lastDeployedOn[artifact.Id]; ok {
+			artifact.LastDeployedOn = &deployedOn
+		}
+	}
+	return artifacts, nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) ExecuteRun(runId int, userId int32) (*bean.GcRunDto, error) {
+	run, policy, err := impl.claimRun(runId, userId)
+	if err != nil {
+		return nil, err
+	}
+	impl.asyncRunnable.Execute(func() { impl.executeRun(run, policy, userId) })
+	return adapter.BuildGcRunDto(run), nil
+}
+
+// claimRun moves the dry run report to executing, only one caller across the replicas succeeds
+func (impl *ArtifactRetentionServiceImpl) claimRun(runId int, userId int32) (*retentionRepository.ArtifactGcRun, *retentionRepository.ArtifactRetentionPolicy, error) {
+	run, err := impl.gcRunRepository.FindById(runId)
+	if err == pg.ErrNoRows {
+		return nil, nil, util.NewApiError(http.StatusNotFound, "garbage collection run not found", "garbage collection run not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching garbage collection run", "runId", runId, "err", err)
+		return nil, nil, err
+	}
+	if run.Status != bean.RunStatusDryRun.String() {
+		errMsg := fmt.Sprintf("only a dry run report can be executed, run is %s", run.Status)
+		return nil, nil, util.NewApiError(http.StatusConflict, errMsg, errMsg)
+	}
+	policy, err := impl.policyRepository.FindById(run.PolicyId)
+	if err == pg.ErrNoRows {
+		_, err = impl.gcRunRepository.UpdateStatus(run.Id, bean.RunStatusDryRun, bean.RunStatusDiscarded, bean.PolicyDeletedMessage, userId)
+		if err != nil {
+			impl.logger.Errorw("error in discarding dry run report", "runId", run.Id, "err", err)
+		}
+		return nil, nil, util.NewApiError(http.StatusConflict, bean.PolicyDeletedMessage, bean.PolicyDeletedMessage)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact retention policy", "id", run.PolicyId, "err", err)
+		return nil, nil, err
+	}
+	claimed, err := impl.gcRunRepository.UpdateStatus(run.Id, bean.RunStatusDryRun, bean.RunStatusExecuting, "", userId)
+	if err != nil {
+		impl.logger.Errorw("error in claiming dry run report", "runId", run.Id, "err", err)
+		return nil, nil, err
+	} else if !claimed {
+		return nil, nil, util.NewApiError(http.StatusConflict, "run is already executed or discarded", "run is already executed or discarded")
+	}
+	run.Status = bean.RunStatusExecuting.String()
+	run.ExecutedOn = time.Now()
+	run.ExecutedBy = userId
+	return run, policy, nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) executeRun(run *retentionRepository.ArtifactGcRun, policy *retentionRepository.ArtifactRetentionPolicy, userId int32) {
+	items, err := impl.gcRunRepository.FindItemsByRunId(run.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching garbage collection run items", "runId", run.Id, "err", err)
+		impl.finishRun(run, nil, err, userId)
+		return
+	}
+	appIdSet := make(map[int]bool)
+	deleteImageArtifactIds := make([]int, 0)
+	for _, item := range items {
+		if item.Status != bean.ItemStatusPlanned.String() {
+			continue
+		}
+		appIdSet[item.AppId] = true
+		if item.Action == bean.ItemActionDeleteArtifact.String() || item.Action == bean.ItemActionDeleteImage.String() {
+			deleteImageArtifactIds = append(deleteImageArtifactIds, item.CiArtifactId)
+		}
+	}
+	appIds := make([]int, 0, len(appIdSet))
+	for appId := range appIdSet {
+		appIds = append(appIds, appId)
+	}
+	// deployments and references made since the dry run protect the artifacts again
+	artifacts, err := impl.getArtifactDetails(appIds)
+	if err != nil {
+		impl.finishRun(run, items, err, userId)
+		return
+	}
+	artifactById := make(map[int]*bean.ArtifactDetail, len(artifacts))
+	for _, artifact := range artifacts {
+		artifactById[artifact.Id] = artifact
+	}
+	sharingImage := make(map[int]bool)
+	if policy.DeleteRegistryTags {
+		sharingImageIds, err := impl.gcRepository.FindArtifactIdsSharingImage(deleteImageArtifactIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching artifacts sharing image", "runId", run.Id, "err", err)
+			impl.finishRun(run, items, err, userId)
+			return
+		}
+		sharingImage = toSet(sharingImageIds)
+	}
+	now := time.Now()
+	for _, item := range items {
+		if item.Status != bean.ItemStatusPlanned.String() {
+			continue
+		}
+		impl.executeItem(item, policy, artifactById[item.CiArtifactId], sharingImage[item.CiArtifactId], now)
+		item.UpdateAuditLog(userId)
+		err = impl.gcRunRepository.UpdateItem(item)
+		if err != nil {
+			impl.logger.Errorw("error in updating garbage collection run item", "itemId", item.Id, "err", err)
+		}
+	}
+	impl.finishRun(run, items, nil, userId)
+}
+
+func (impl *ArtifactRetentionServiceImpl) executeItem(item *retentionRepository.ArtifactGcRunItem, policy *retentionRepository.ArtifactRetentionPolicy,
+	artifact *bean.ArtifactDetail, isImageShared bool, now time.Time) {
+	if item.Action == bean.ItemActionDeleteBuildCache.String() {
+		impl.deleteBuildCache(item)
+		return
+	}
+	if artifact == nil {
+		item.Status = bean.ItemStatusSkipped.String()
+		item.StatusMessage = bean.ArtifactNotFoundMessage
+		return
+	}
+	reason := retentionUtil.GetProtectionReason(artifact, policy.KeepDeployedWithinDays, now)
+	if len(reason) == 0 && artifact.Referenced && item.Action == bean.ItemActionDeleteArtifact.String() {
+		reason = bean.ReasonReferencedByHistory
+	}
+	if len(reason) > 0 {
+		item.Status = bean.ItemStatusSkipped.String()
+		item.StatusMessage = fmt.Sprintf(bean.ReasonBecameProtected, reason)
+		return
+	}
+	if item.Action == bean.ItemActionDeleteBuildLogs.String() {
+		err := impl.deleteBuildLogs(item.CiWorkflowId)
+		if err != nil {
+			markItemFailed(item, err)
+			return
+		}
+		item.BlobObjectsDeleted = true
+		item.Status = bean.ItemStatusDeleted.String()
+		return
+	}
+	if item.Action == bean.ItemActionDeleteImage.String() {
+		messages, deleted := impl.deleteImage(item, policy, artifact, isImageShared)
+		if !deleted {
+			return
+		}
+		item.Status = bean.ItemStatusDeleted.String()
+		item.StatusMessage = strings.Join(messages, ", ")
+		return
+	}
+	impl.deleteArtifact(item, policy, artifact, isImageShared)
+}
+
+// deleteImage deletes the registry tag and the build logs of the artifact as per the policy, the item is
+// marked failed if either deletion fails. Tags which can not be deleted are reported in the returned messages
+func (impl *ArtifactRetentionServiceImpl) deleteImage(item *retentionRepository.ArtifactGcRunItem, policy *retentionRepository.ArtifactRetentionPolicy,
+	artifact *bean.ArtifactDetail, isImageShared bool) ([]string, bool) {
+	messages := make([]string, 0)
+	if policy.DeleteRegistryTags {
+		if isImageShared {
+			messages = append(messages, bean.ReasonRegistryTagShared)
+		} else if err := impl.deleteRegistryTag(artifact); errors.Is(err, ErrTagDeletionNotSupported) {
+			messages = append(messages, err.Error())
+		} else if err != nil {
+			markItemFailed(item, err)
+			return nil, false
+		} else {
+			item.RegistryTagDeleted = true
+		}
+	}
+	if policy.DeleteBuildLogs && retentionUtil.HasBuildLogs(artifact) {
+		err := impl.deleteBuildLogs(artifact.CiWorkflowId)
+		if err != nil {
+			markItemFailed(item, err)
+			return nil, false
+		}
+		item.BlobObjectsDeleted = true
+	}
+	return messages, true
+}
+
+// deleteArtifact deletes the registry tag and the build logs before the row, so that a failed
+// deletion leaves the artifact in place to be planned again in the next run
+func (impl *ArtifactRetentionServiceImpl) deleteArtifact(item *retentionRepository.ArtifactGcRunItem, policy *retentionRepository.ArtifactRetentionPolicy,
+	artifact *bean.ArtifactDetail, isImageShared bool) {
+	messages, deleted := impl.deleteImage(item, policy, artifact, isImageShared)
+	if !deleted {
+		return
+	}
+	err := impl.customTagService.DeactivateImagePathReservationByImagePath([]string{artifact.Image})
+	if err != nil {
+		impl.logger.Errorw("error in deactivating image path reservation", "image", artifact.Image, "err", err)
+		markItemFailed(item, err)
+		return
+	}
+	// rows referenced since the executed checks are protected by the foreign keys
+	err = impl.ciArtifactRepository.Delete(&repository.CiArtifact{Id: artifact.Id})
+	if err != nil {
+		impl.logger.Errorw("error in deleting artifact", "ciArtifactId", artifact.Id, "err", err)
+		markItemFailed(item, err)
+		return
+	}
+	item.RowDeleted = true
+	item.Status = bean.ItemStatusDeleted.String()
+	item.StatusMessage = strings.Join(messages, ", ")
+}
+
+func (impl *ArtifactRetentionServiceImpl) deleteRegistryTag(artifact *bean.ArtifactDetail) error {
+	ciArtifact, err := impl.ciArtifactRepository.Get(artifact.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "ciArtifactId", artifact.Id, "err", err)
+		return err
+	}
+	registryId := ""
+	if ciArtifact.IsRegistryCredentialMapped() {
+		registryId = ciArtifact.CredentialSourceValue
+	} else {
+		dockerRegistryId, err := impl.ciPipelineConfigReadService.GetDockerRegistryIdForCiPipeline(artifact.CiPipelineId, ciArtifact)
+		if err == pg.ErrNoRows {
+			return fmt.Errorf("%w, registry of the deleted ci pipeline is unknown", ErrTagDeletionNotSupported)
+		} else if err != nil {
+			return err
+		}
+		if dockerRegistryId != nil {
+			registryId = *dockerRegistryId
+		}
+	}
+	if len(registryId) == 0 {
+		return fmt.Errorf("%w, registry of the image is unknown", ErrTagDeletionNotSupported)
+	}
+	store, err := impl.dockerArtifactStoreRepository.FindOne(registryId)
+	if err == pg.ErrNoRows {
+		return fmt.Errorf("%w, registry %s is deleted", ErrTagDeletionNotSupported, registryId)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "registryId", registryId, "err", err)
+		return err
+	}
+	return impl.registryTagCleaner.DeleteImageTag(ciArtifact.Image, store)
+}
+
+func (impl *ArtifactRetentionServiceImpl) deleteBuildLogs(ciWorkflowId int) error {
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(ciWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci workflow", "ciWorkflowId", ciWorkflowId, "err", err)
+		return err
+	}
+	return impl.buildBlobCleaner.DeleteBuildLogs(ciWorkflow)
+}
+
+func (impl *ArtifactRetentionServiceImpl) deleteBuildCache(item *retentionRepository.ArtifactGcRunItem) {
+	ciPipeline, err := impl.ciPipelineRepository.FindByIdIncludingInActive(item.CiPipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", item.CiPipelineId, "err", err)
+		markItemFailed(item, err)
+		return
+	}
+	err = impl.buildBlobCleaner.DeleteBuildCache(ciPipeline)
+	if err != nil {
+		markItemFailed(item, err)
+		return
+	}
+	item.BlobObjectsDeleted = true
+	item.Status = bean.ItemStatusDeleted.String()
+}
+
+func markItemFailed(item *retentionRepository.ArtifactGcRunItem, err error) {
+	item.Status = bean.ItemStatusFailed.String()
+	item.StatusMessage = err.Error()
+}
+
+func (impl *ArtifactRetentionServiceImpl) finishRun(run *retentionRepository.ArtifactGcRun, items []*retentionRepository.ArtifactGcRunItem, runErr error, userId int32) {
+	run.DeletedCount, run.SkippedCount, run.FailedCount = 0, 0, 0
+	for _, item := range items {
+		switch bean.ItemStatus(item.Status) {
+		case bean.ItemStatusDeleted:
+			run.DeletedCount++
+		case bean.ItemStatusSkipped:
+			run.SkippedCount++
+		case bean.ItemStatusFailed:
+			run.FailedCount++
+		}
+	}
+	run.Status = bean.RunStatusCompleted.String()
+	if runErr != nil {
+		run.Status = bean.RunStatusFailed.String()
+		run.StatusMessage = runErr.Error()
+	}
+	run.FinishedOn = time.Now()
+	run.UpdateAuditLog(userId)
+	err := impl.gcRunRepository.Update(run)
+	if err != nil {
+		impl.logger.Errorw("error in updating garbage collection run", "runId", run.Id, "err", err)
+		return
+	}
+	impl.logger.Infow("artifact garbage collection run finished", "runId", run.Id, "policyId", run.PolicyId, "status", run.Status,
+		"deleted", run.DeletedCount, "skipped", run.SkippedCount, "failed", run.FailedCount)
+}
+
+func (impl *ArtifactRetentionServiceImpl) GetRuns(policyId int) ([]*bean.GcRunDto, error) {
+	runs, err := impl.gcRunRepository.FindRecent(policyId, bean.RecentRunsLimit)
+	if err != nil {
+		impl.logger.Errorw("error in fetching garbage collection runs", "policyId", policyId, "err", err)
+		return nil, err
+	}
+	runDtos := make([]*bean.GcRunDto, 0, len(runs))
+	for _, run := range runs {
+		runDtos = append(runDtos, adapter.BuildGcRunDto(run))
+	}
+	return runDtos, nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) GetRunReport(runId int) (*bean.GcRunDto, error) {
+	run, err := impl.gcRunRepository.FindById(runId)
+	if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "garbage collection run not found", "garbage collection run not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching garbage collection run", "runId", runId, "err", err)
+		return nil, err
+	}
+	items, err := impl.gcRunRepository.FindItemsByRunId(runId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching garbage collection run items", "runId", runId, "err", err)
+		return nil, err
+	}
+	report := adapter.BuildGcRunDto(run)
+	policy, err := impl.policyRepository.FindById(run.PolicyId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching artifact retention policy", "id", run.PolicyId, "err", err)
+		return nil, err
+	} else if err == nil {
+		report.Policy = adapter.BuildRetentionPolicyDto(policy)
+	}
+	report.Items = make([]*bean.GcRunItemDto, 0, len(items))
+	for _, item := range items {
+		report.Items = append(report.Items, adapter.BuildGcRunItemDto(item))
+	}
+	return report, nil
+}
+
+func (impl *ArtifactRetentionServiceImpl) RunScheduledGc() {
+	if !impl.config.Enabled {
+		return
+	}
+	now := time.Now()
+	failedCount, err := impl.gcRunRepository.MarkStaleExecutionsFailed(now.Add(-time.Duration(impl.config.StaleExecutionTimeoutHours) * time.Hour))
+	if err != nil {
+		impl.logger.Errorw("error in failing interrupted garbage collection runs", "err", err)
+	} else if failedCount > 0 {
+		impl.logger.Infow("failed interrupted garbage collection runs", "count", failedCount)
+	}
+	if impl.config.AutoExecute {
+		dueRuns, err := impl.gcRunRepository.FindDryRunsCreatedBefore(bean.RunTriggerScheduled, now.Add(-time.Duration(impl.config.DryRunGracePeriodHours)*time.Hour))
+		if err != nil {
+			impl.logger.Errorw("error in fetching dry run reports due for execution", "err", err)
+		}
+		for _, dueRun := range dueRuns {
+			run, policy, err := impl.claimRun(dueRun.Id, bean2.SYSTEM_USER_ID)
+			if err != nil {
+				impl.logger.Warnw("skipping execution of dry run report", "runId", dueRun.Id, "err", err)
+				continue
+			}
+			impl.executeRun(run, policy, bean2.SYSTEM_USER_ID)
+		}
+	}
+	policies, err := impl.policyRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact retention policies", "err", err)
+		return
+	}
+	for _, policy := range policies {
+		run, err := impl.createDryRun(policy, bean.RunTriggerScheduled, bean2.SYSTEM_USER_ID)
+		if err != nil {
+			impl.logger.Errorw("error in creating scheduled dry run report", "policyId", policy.Id, "err", err)
+		} else if run != nil {
+			impl.logger.Infow("created scheduled dry run report", "policyId", policy.Id, "runId", run.Id, "plannedCount", run.PlannedCount)
+		}
+	}
+}
+
+func toSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
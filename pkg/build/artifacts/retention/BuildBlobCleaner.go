@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	pipelineUtil "github.com/devtron-labs/devtron/pkg/pipeline/util"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+)
+
+// BuildBlobCleaner deletes the objects stored by ci builds in the blob storage configured for the orchestrator.
+// On versioned buckets the delete only hides the latest version, older versions expire as per the bucket lifecycle.
+type BuildBlobCleaner interface {
+	// DeleteBuildLogs deletes the logs and the uploaded artifacts of the ci workflow
+	DeleteBuildLogs(ciWorkflow *pipelineConfig.CiWorkflow) error
+	// DeleteBuildCache deletes the build cache of the ci pipeline
+	DeleteBuildCache(ciPipeline *pipelineConfig.CiPipeline) error
+}
+
+type BuildBlobCleanerImpl struct {
+	logger  *zap.SugaredLogger
+	config  *types.CiConfig
+	timeout time.Duration
+}
+
+func NewBuildBlobCleanerImpl(logger *zap.SugaredLogger) (*BuildBlobCleanerImpl, error) {
+	config, err := types.GetCiConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &BuildBlobCleanerImpl{
+		logger:  logger,
+		config:  config,
+		timeout: time.Duration(getArtifactGcConfig(logger).BlobStorageRequestTimeoutSecs) * time.Second,
+	}, nil
+}
+
+func (impl *BuildBlobCleanerImpl) DeleteBuildLogs(ciWorkflow *pipelineConfig.CiWorkflow) error {
+	logsKey := impl.config.GetDefaultBuildLogsKeyPrefix() + "/" + ciWorkflow.Name + "/main.log"
+	if strings.Contains(ciWorkflow.LogLocation, "main.log") {
+		logsKey = ciWorkflow.LogLocation
+	}
+	artifactKey := fmt.Sprintf(impl.config.GetArtifactLocationFormat(), ciWorkflow.Id, ciWorkflow.Id)
+	if len(ciWorkflow.CiArtifactLocation) != 0 && pipelineUtil.IsValidUrlSubPath(ciWorkflow.CiArtifactLocation) {
+		artifactKey = ciWorkflow.CiArtifactLocation
+	}
+	return impl.deleteObjects(impl.getLogsBucket(), impl.getLogsRegion(), []string{logsKey, artifactKey})
+}
+
+func (impl *BuildBlobCleanerImpl) DeleteBuildCache(ciPipeline *pipelineConfig.CiPipeline) error {
+	// same key as the one the ci runner uploads the cache to
+	cacheKey := ciPipeline.Name + "-" + fmt.Sprint(ciPipeline.Id) + ".tar.gz"
+	bucket := impl.config.DefaultCacheBucket
+	if impl.config.CloudProvider == types.BLOB_STORAGE_AZURE {
+		bucket = impl.config.AzureBlobContainerCiCache
+	}
+	return impl.deleteObjects(bucket, impl.config.DefaultCacheBucketRegion, []string{cacheKey})
+}
+
+func (impl *BuildBlobCleanerImpl) getLogsBucket() string {
+	if impl.config.CloudProvider == types.BLOB_STORAGE_AZURE {
+		return impl.config.AzureBlobContainerCiLog
+	}
+	return impl.config.GetDefaultBuildLogsBucket()
+}
+
+func (impl *BuildBlobCleanerImpl) getLogsRegion() string {
+	if region := impl.config.GetDefaultCdLogsBucketRegion(); len(region) != 0 {
+		return region
+	}
+	return impl.config.DefaultCacheBucketRegion
+}
+
+func (impl *BuildBlobCleanerImpl) deleteObjects(bucket, region string, keys []string) error {
+	if !impl.config.BlobStorageEnabled {
+		return errors.New("blob storage is not enabled")
+	}
+	if len(bucket) == 0 {
+		return errors.New("blob storage bucket is not configured")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), impl.timeout)
+	defer cancel()
+	switch impl.config.CloudProvider {
+	case types.BLOB_STORAGE_S3, types.BLOB_STORAGE_MINIO:
+		return impl.deleteS3Objects(ctx, bucket, region, keys)
+	case types.BLOB_STORAGE_AZURE:
+		return impl.deleteAzureBlobs(ctx, bucket, keys)
+	case types.BLOB_STORAGE_GCP:
+		return impl.deleteGcpObjects(ctx, bucket, keys)
+	default:
+		return fmt.Errorf("blob storage %s not supported", impl.config.CloudProvider)
+	}
+}
+
+func (impl *BuildBlobCleanerImpl) deleteS3Objects(ctx context.Context, bucket, region string, keys []string) error {
+	awsCfg := &aws.Config{
+		Region: aws.String(region),
+	}
+	if impl.config.BlobStorageS3AccessKey != "" {
+		awsCfg.Credentials = credentials.NewStaticCredentials(impl.config.BlobStorageS3AccessKey, impl.config.BlobStorageS3SecretKey, "")
+	}
+	if impl.config.BlobStorageS3Endpoint != "" { // to handle s3 compatible storage
+		awsCfg.Endpoint = aws.String(impl.config.BlobStorageS3Endpoint)
+		awsCfg.DisableSSL = aws.Bool(impl.config.BlobStorageS3EndpointInsecure)
+		awsCfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return err
+	}
+	svc := s3.New(sess)
+	for _, key := range keys {
+		// deleting a missing key is not an error in s3
+		_, err = svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			impl.logger.Errorw("error in deleting s3 object", "bucket", bucket, "key", key, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *BuildBlobCleanerImpl) deleteAzureBlobs(ctx context.Context, container string, keys []string) error {
+	if len(impl.config.AzureAccountKey) == 0 {
+		return errors.New("azure account key is required to delete blobs")
+	}
+	credential, err := azblob.NewSharedKeyCredential(impl.config.AzureAccountName, impl.config.AzureAccountKey)
+	if err != nil {
+		return err
+	}
+	containerUrl, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s", impl.config.AzureAccountName, container))
+	if err != nil {
+		return err
+	}
+	containerURL := azblob.NewContainerURL(*containerUrl, azblob.NewPipeline(credential, azblob.PipelineOptions{}))
+	for _, key := range keys {
+		_, err = containerURL.NewBlobURL(key).Delete(ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+		var storageErr azblob.StorageError
+		if errors.As(err, &storageErr) && storageErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			continue
+		} else if err != nil {
+			impl.logger.Errorw("error in deleting azure blob", "container", container, "key", key, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *BuildBlobCleanerImpl) deleteGcpObjects(ctx context.Context, bucket string, keys []string) error {
+	var client *storage.Client
+	var err error
+	if len(impl.config.BlobStorageGcpCredentialJson) != 0 {
+		client, err = storage.NewClient(ctx, option.WithCredentialsJSON([]byte(impl.config.BlobStorageGcpCredentialJson)))
+	} else {
+		client, err = storage.NewClient(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	for _, key := range keys {
+		err = client.Bucket(bucket).Object(key).Delete(ctx)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			continue
+		} else if err != nil {
+			impl.logger.Errorw("error in deleting gcs object", "bucket", bucket, "key", key, "err", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	dockerRegistry "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/util"
+	"github.com/devtron-labs/devtron/util/registryUtil"
+	"go.uber.org/zap"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrTagDeletionNotSupported is returned when the tag of the image can not be deleted from its registry,
+// the artifact is still deleted as the registry tag is left to the registry retention
+var ErrTagDeletionNotSupported = errors.New("registry tag deletion is not supported")
+
+// RegistryTagCleaner deletes the tag of a built image from the container registry it was pushed to
+type RegistryTagCleaner interface {
+	DeleteImageTag(image string, store *dockerRegistry.DockerArtifactStore) error
+}
+
+type RegistryTagCleanerImpl struct {
+	logger  *zap.SugaredLogger
+	timeout time.Duration
+}
+
+func NewRegistryTagCleanerImpl(logger *zap.SugaredLogger) *RegistryTagCleanerImpl {
+	return &RegistryTagCleanerImpl{
+		logger:  logger,
+		timeout: time.Duration(getArtifactGcConfig(logger).RegistryRequestTimeoutSecs) * time.Second,
+	}
+}
+
+func (impl *RegistryTagCleanerImpl) DeleteImageTag(image string, store *dockerRegistry.DockerArtifactStore) error {
+	imageMetadata, err := util.ExtractImageRepoAndTag(image)
+	if err != nil {
+		return err
+	}
+	if len(imageMetadata.Tag) == 0 {
+		return fmt.Errorf("image %s is not tagged", image)
+	}
+	host, repoName, found := strings.Cut(imageMetadata.Repo, "/")
+	if !found {
+		return fmt.Errorf("invalid image repository %s", imageMetadata.Repo)
+	}
+	// the registry of the ci pipeline may have been changed after the image was pushed
+	if registryHost := registryUtil.GetRegistryHost(store.RegistryURL); registryHost != host {
+		return fmt.Errorf("%w, image %s is not pushed to the registry %s", ErrTagDeletionNotSupported, image, store.Id)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), impl.timeout)
+	defer cancel()
+	switch store.RegistryType {
+	case dockerRegistry.REGISTRYTYPE_ECR:
+		return impl.deleteEcrImageTag(ctx, repoName, imageMetadata.Tag, store)
+	case dockerRegistry.REGISTRYTYPE_DOCKER_HUB:
+		return fmt.Errorf("%w by docker hub through the registry api", ErrTagDeletionNotSupported)
+	default:
+		return impl.deleteOciImageTag(ctx, imageMetadata.Repo, imageMetadata.Tag, store)
+	}
+}
+
+func (impl *RegistryTagCleanerImpl) deleteEcrImageTag(ctx context.Context, repoName, tag string, store *dockerRegistry.DockerArtifactStore) error {
+	sess, err := registryUtil.NewEcrSession(store)
+	if err != nil {
+		return err
+	}
+	output, err := ecr.New(sess).BatchDeleteImageWithContext(ctx, &ecr.BatchDeleteImageInput{
+		RepositoryName: aws.String(repoName),
+		ImageIds:       []*ecr.ImageIdentifier{{ImageTag: aws.String(tag)}},
+	})
+	if err != nil {
+		impl.logger.Errorw("error in deleting ecr image tag", "repository", repoName, "tag", tag, "err", err)
+		return err
+	}
+	for _, failure := range output.Failures {
+		if aws.StringValue(failure.FailureCode) == ecr.ImageFailureCodeImageNotFound {
+			continue
+		}
+		return fmt.Errorf("error in deleting ecr image tag %s: %s", tag, aws.StringValue(failure.FailureReason))
+	}
+	return nil
+}
+
+func (impl *RegistryTagCleanerImpl) deleteOciImageTag(ctx context.Context, repo, tag string, store *dockerRegistry.DockerArtifactStore) error {
+	repository, err := remote.NewRepository(repo)
+	if err != nil {
+		return err
+	}
+	repository.PlainHTTP = strings.HasPrefix(store.RegistryURL, "http://")
+	repository.Client = &auth.Client{
+		Client: auth.DefaultClient.Client,
+		Cache:  auth.NewCache(),
+		Credential: auth.StaticCredential(repository.Reference.Registry, auth.Credential{
+			Username: store.Username,
+			Password: store.Password.String(),
+		}),
+	}
+	// registries delete manifests by digest, the tag is resolved to its digest first
+	descriptor, err := repository.Resolve(ctx, tag)
+	if err != nil {
+		impl.logger.Errorw("error in resolving image tag", "repository", repo, "tag", tag, "err", err)
+		return err
+	}
+	err = repository.Delete(ctx, descriptor)
+	if err != nil {
+		impl.logger.Errorw("error in deleting image manifest", "repository", repo, "tag", tag, "digest", descriptor.Digest, "err", err)
+		return err
+	}
+	return nil
+}
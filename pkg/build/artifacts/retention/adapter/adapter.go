@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util"
+)
+
+func BuildRetentionPolicyDto(policy *repository.ArtifactRetentionPolicy) *bean.RetentionPolicyDto {
+	return &bean.RetentionPolicyDto{
+		Id:                     policy.Id,
+		ScopeType:              bean.PolicyScopeType(policy.ScopeType),
+		ScopeId:                policy.ScopeId,
+		KeepLastN:              policy.KeepLastN,
+		KeepDeployedWithinDays: policy.KeepDeployedWithinDays,
+		DeleteRegistryTags:     policy.DeleteRegistryTags,
+		DeleteBuildLogs:        policy.DeleteBuildLogs,
+		DeleteBuildCache:       policy.DeleteBuildCache,
+	}
+}
+
+func GetArtifactDetail(candidate *repository.ArtifactGcCandidate) *bean.ArtifactDetail {
+	return &bean.ArtifactDetail{
+		Id:                 candidate.CiArtifactId,
+		CiPipelineId:       candidate.CiPipelineId,
+		AppId:              candidate.AppId,
+		Image:              candidate.Image,
+		CiWorkflowId:       candidate.CiWorkflowId,
+		BlobStorageEnabled: candidate.BlobStorageEnabled,
+		CreatedOn:          candidate.CreatedOn,
+	}
+}
+
+func GetGcRunItemDbObj(gcRunId int, plannedItem *bean.PlannedItem, userId int32) *repository.ArtifactGcRunItem {
+	item := &repository.ArtifactGcRunItem{
+		GcRunId:      gcRunId,
+		Action:       plannedItem.Action.String(),
+		CiPipelineId: plannedItem.CiPipelineId,
+		AppId:        plannedItem.AppId,
+		Reason:       plannedItem.Reason,
+		Status:       bean.ItemStatusPlanned.String(),
+		AuditLog:     sql.NewDefaultAuditLog(userId),
+	}
+	if plannedItem.Action == bean.ItemActionSkip {
+		item.Status = bean.ItemStatusSkipped.String()
+	}
+	if artifact := plannedItem.Artifact; artifact != nil {
+		item.CiArtifactId = artifact.Id
+		item.CiWorkflowId = artifact.CiWorkflowId
+		item.Image = artifact.Image
+		item.ArtifactCreatedOn = artifact.CreatedOn
+		if artifact.LastDeployedOn != nil {
+			item.LastDeployedOn = *artifact.LastDeployedOn
+		}
+	}
+	return item
+}
+
+func BuildGcRunDto(run *repository.ArtifactGcRun) *bean.GcRunDto {
+	return &bean.GcRunDto{
+		Id:            run.Id,
+		PolicyId:      run.PolicyId,
+		Status:        bean.RunStatus(run.Status),
+		StatusMessage: run.StatusMessage,
+		TriggerType:   bean.RunTriggerType(run.TriggerType),
+		KeptCount:     run.KeptCount,
+		PlannedCount:  run.PlannedCount,
+		DeletedCount:  run.DeletedCount,
+		SkippedCount:  run.SkippedCount,
+		FailedCount:   run.FailedCount,
+		CreatedOn:     run.CreatedOn,
+		CreatedBy:     run.CreatedBy,
+		ExecutedOn:    util.GetTimePtr(run.ExecutedOn),
+		ExecutedBy:    run.ExecutedBy,
+		FinishedOn:    util.GetTimePtr(run.FinishedOn),
+	}
+}
+
+func BuildGcRunItemDto(item *repository.ArtifactGcRunItem) *bean.GcRunItemDto {
+	return &bean.GcRunItemDto{
+		Id:                 item.Id,
+		Action:             bean.ItemAction(item.Action),
+		CiArtifactId:       item.CiArtifactId,
+		CiWorkflowId:       item.CiWorkflowId,
+		CiPipelineId:       item.CiPipelineId,
+		AppId:              item.AppId,
+		Image:              item.Image,
+		ArtifactCreatedOn:  util.GetTimePtr(item.ArtifactCreatedOn),
+		LastDeployedOn:     util.GetTimePtr(item.LastDeployedOn),
+		Reason:             item.Reason,
+		Status:             bean.ItemStatus(item.Status),
+		StatusMessage:      item.StatusMessage,
+		RegistryTagDeleted: item.RegistryTagDeleted,
+		BlobObjectsDeleted: item.BlobObjectsDeleted,
+		RowDeleted:         item.RowDeleted,
+	}
+}
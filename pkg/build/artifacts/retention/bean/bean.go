@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"time"
+)
+
+// CATEGORY=ARTIFACT_GC
+type ArtifactGcConfig struct {
+	Enabled                       bool `env:"ARTIFACT_GC_ENABLED" envDefault:"false" description:"Create garbage collection dry run reports of the artifact retention policies on the configured schedule"`
+	AutoExecute                   bool `env:"ARTIFACT_GC_AUTO_EXECUTE" envDefault:"false" description:"Execute the scheduled dry run reports once the grace period is over, otherwise reports are executed manually"`
+	DryRunGracePeriodHours        int  `env:"ARTIFACT_GC_DRY_RUN_GRACE_PERIOD_HOURS" envDefault:"12" description:"Min age of a scheduled dry run report before it is executed automatically"`
+	MaxItemsPerRun                int  `env:"ARTIFACT_GC_MAX_ITEMS_PER_RUN" envDefault:"500" description:"Max artifacts and build caches planned for deletion in a single run, the rest are picked in the next run"`
+	StaleExecutionTimeoutHours    int  `env:"ARTIFACT_GC_STALE_EXECUTION_TIMEOUT_HOURS" envDefault:"6" description:"Runs executing for longer than this, e.g. when the replica restarted, are marked failed"`
+	RegistryRequestTimeoutSecs    int  `env:"ARTIFACT_GC_REGISTRY_REQUEST_TIMEOUT_SECS" envDefault:"30" description:"Timeout of a container registry request made while deleting an image tag"`
+	BlobStorageRequestTimeoutSecs int  `env:"ARTIFACT_GC_BLOB_STORAGE_REQUEST_TIMEOUT_SECS" envDefault:"30" description:"Timeout of a blob storage request made while deleting build logs and caches"`
+}
+
+type PolicyScopeType string
+
+const (
+	PolicyScopeApp     PolicyScopeType = "APP"
+	PolicyScopeProject PolicyScopeType = "PROJECT"
+)
+
+func (s PolicyScopeType) String() string {
+	return string(s)
+}
+
+func (s PolicyScopeType) IsValid() bool {
+	switch s {
+	case PolicyScopeApp, PolicyScopeProject:
+		return true
+	}
+	return false
+}
+
+type RunStatus string
+
+const (
+	// RunStatusDryRun is a report waiting to be executed, nothing is deleted yet
+	RunStatusDryRun    RunStatus = "DRY_RUN"
+	RunStatusExecuting RunStatus = "EXECUTING"
+	RunStatusCompleted RunStatus = "COMPLETED"
+	RunStatusFailed    RunStatus = "FAILED"
+	// RunStatusDiscarded is a report replaced by a newer report of the policy, or of a deleted policy
+	RunStatusDiscarded RunStatus = "DISCARDED"
+)
+
+func (s RunStatus) String() string {
+	return string(s)
+}
+
+type RunTriggerType string
+
+const (
+	RunTriggerScheduled RunTriggerType = "SCHEDULED"
+	RunTriggerManual    RunTriggerType = "MANUAL"
+)
+
+func (t RunTriggerType) String() string {
+	return string(t)
+}
+
+type ItemAction string
+
+const (
+	// ItemActionDeleteArtifact deletes the artifact row along with its build logs, image path reservation and optionally the registry tag
+	ItemActionDeleteArtifact ItemAction = "DELETE_ARTIFACT"
+	// ItemActionDeleteImage deletes the registry tag and the build logs of an artifact whose row is kept for the deployment history
+	ItemActionDeleteImage ItemAction = "DELETE_IMAGE"
+	// ItemActionDeleteBuildLogs deletes only the build logs of an artifact kept for the deployment history
+	ItemActionDeleteBuildLogs ItemAction = "DELETE_BUILD_LOGS"
+	// ItemActionDeleteBuildCache deletes the build cache of a deleted ci pipeline
+	ItemActionDeleteBuildCache ItemAction = "DELETE_BUILD_CACHE"
+	// ItemActionSkip reports an artifact beyond the latest ones which is protected from deletion
+	ItemActionSkip ItemAction = "SKIP"
+)
+
+func (a ItemAction) String() string {
+	return string(a)
+}
+
+type ItemStatus string
+
+const (
+	ItemStatusPlanned ItemStatus = "PLANNED"
+	ItemStatusDeleted ItemStatus = "DELETED"
+	ItemStatusSkipped ItemStatus = "SKIPPED"
+	ItemStatusFailed  ItemStatus = "FAILED"
+)
+
+func (s ItemStatus) String() string {
+	return string(s)
+}
+
+const (
+	ReasonBeyondLatest          = "older than the latest %d artifacts of the ci pipeline"
+	ReasonCurrentlyDeployed     = "currently deployed"
+	ReasonDeployedWithinDays    = "deployed within the last %d days"
+	ReasonReferencedByHistory   = "kept for deployment history, tags or derived artifacts"
+	ReasonDeletedCiPipeline     = "ci pipeline is deleted"
+	ReasonBecameProtected       = "protected since the dry run: %s"
+	ReasonRegistryTagShared     = "registry tag is shared with a retained artifact"
+	InterruptedExecutionMessage = "execution was interrupted, remaining items are planned again in the next run"
+	PolicyDeletedMessage        = "retention policy is deleted"
+	PolicyUpdatedMessage        = "retention policy is updated"
+	ArtifactNotFoundMessage     = "artifact no longer exists"
+	ReplacedByNewerRunMessage   = "replaced by the dry run report %d"
+)
+
+const (
+	GcLockKeyFormat = "artifact-gc-policy-%d"
+	RecentRunsLimit = 50
+)
+
+type RetentionPolicyDto struct {
+	Id                     int             `json:"id"`
+	ScopeType              PolicyScopeType `json:"scopeType" validate:"required"`
+	ScopeId                int             `json:"scopeId" validate:"required,min=1"`
+	KeepLastN              int             `json:"keepLastN" validate:"required,min=1"`
+	KeepDeployedWithinDays int             `json:"keepDeployedWithinDays" validate:"min=0"`
+	DeleteRegistryTags     bool            `json:"deleteRegistryTags"`
+	DeleteBuildLogs        bool            `json:"deleteBuildLogs"`
+	DeleteBuildCache       bool            `json:"deleteBuildCache"`
+	UserId                 int32           `json:"-"`
+}
+
+type GcRunDto struct {
+	Id            int                 `json:"id"`
+	PolicyId      int                 `json:"policyId"`
+	Status        RunStatus           `json:"status"`
+	StatusMessage string              `json:"statusMessage,omitempty"`
+	TriggerType   RunTriggerType      `json:"triggerType"`
+	KeptCount     int                 `json:"keptCount"`
+	PlannedCount  int                 `json:"plannedCount"`
+	DeletedCount  int                 `json:"deletedCount"`
+	SkippedCount  int                 `json:"skippedCount"`
+	FailedCount   int                 `json:"failedCount"`
+	CreatedOn     time.Time           `json:"createdOn"`
+	CreatedBy     int32               `json:"createdBy"`
+	ExecutedOn    *time.Time          `json:"executedOn,omitempty"`
+	ExecutedBy    int32               `json:"executedBy,omitempty"`
+	FinishedOn    *time.Time          `json:"finishedOn,omitempty"`
+	Policy        *RetentionPolicyDto `json:"policy,omitempty"`
+	Items         []*GcRunItemDto     `json:"items,omitempty"`
+}
+
+type GcRunItemDto struct {
+	Id                 int        `json:"id"`
+	Action             ItemAction `json:"action"`
+	CiArtifactId       int        `json:"ciArtifactId,omitempty"`
+	CiWorkflowId       int        `json:"ciWorkflowId,omitempty"`
+	CiPipelineId       int        `json:"ciPipelineId"`
+	AppId              int        `json:"appId"`
+	Image              string     `json:"image,omitempty"`
+	ArtifactCreatedOn  *time.Time `json:"artifactCreatedOn,omitempty"`
+	LastDeployedOn     *time.Time `json:"lastDeployedOn,omitempty"`
+	Reason             string     `json:"reason"`
+	Status             ItemStatus `json:"status"`
+	StatusMessage      string     `json:"statusMessage,omitempty"`
+	RegistryTagDeleted bool       `json:"registryTagDeleted"`
+	BlobObjectsDeleted bool       `json:"blobObjectsDeleted"`
+	RowDeleted         bool       `json:"rowDeleted"`
+}
+
+// ArtifactDetail is an artifact built by a ci pipeline of the policy scope, along with its deployment details
+type ArtifactDetail struct {
+	Id                 int
+	CiPipelineId       int
+	AppId              int
+	Image              string
+	CiWorkflowId       int
+	BlobStorageEnabled bool
+	CreatedOn          time.Time
+	LastDeployedOn     *time.Time
+	CurrentlyDeployed  bool
+	// Referenced is set when the artifact row can not be deleted without losing the deployment
+	// history, image tags and comments, approvals or artifacts derived from it
+	Referenced bool
+	// BuildLogsDeleted is set when the build logs of the artifact were deleted by an earlier run
+	BuildLogsDeleted bool
+	// ImageDeleted is set when the image of the artifact kept for the deployment history was deleted by an earlier run
+	ImageDeleted bool
+}
+
+type DeletedCiPipelineDetail struct {
+	CiPipelineId int
+	AppId        int
+}
+
+type PlannedItem struct {
+	Action       ItemAction
+	Artifact     *ArtifactDetail
+	CiPipelineId int
+	AppId        int
+	Reason       string
+}
+
+type GcPlan struct {
+	KeptCount int
+	Items     []*PlannedItem
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	autoRollbackBean "github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	deploymentWindowBean "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	previewEnvironmentBean "github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+// artifactReference is a column holding a foreign key on ci_artifact, an artifact referenced by any of them is kept
+type artifactReference struct {
+	Table  string
+	Column string
+	// Statuses limits the reference to the rows still in one of them, when set
+	Statuses []string
+}
+
+// artifactReferences are the columns referencing ci_artifact without cascading the delete, along with the columns
+// holding an artifact id with no foreign key which still needs the artifact to be deployed
+var artifactReferences = []artifactReference{
+	{Table: "cd_workflow", Column: "ci_artifact_id"},
+	{Table: "pipeline_config_override", Column: "ci_artifact_id"},
+	{Table: "deployment_approval_request", Column: "ci_artifact_id"},
+	{Table: "user_deployment_request", Column: "ci_artifact_id"},
+	{Table: "artifact_promotion_approval_request", Column: "artifact_id"},
+	{Table: "release_tags", Column: "artifact_id"},
+	{Table: "image_comments", Column: "artifact_id"},
+	{Table: "image_tagging_audit", Column: "artifact_id"},
+	{Table: "ci_artifact", Column: "parent_ci_artifact"},
+	{Table: "release_orchestration_item", Column: "ci_artifact_id"},
+	{Table: "scheduled_deployment", Column: "ci_artifact_id"},
+	{Table: "cluster_set_deployment", Column: "ci_artifact_id"},
+	{Table: "deployment_window_queued_trigger", Column: "ci_artifact_id", Statuses: []string{string(deploymentWindowBean.QueuedTriggerStatusQueued)}},
+	{Table: "auto_rollback_history", Column: "ci_artifact_id", Statuses: []string{autoRollbackBean.RollbackStatusInitiated.String()}},
+	{Table: "preview_environment", Column: "last_deployed_artifact_id", Statuses: previewEnvironmentBean.LiveStatuses},
+}
+
+type ArtifactGcCandidate struct {
+	CiArtifactId       int       `sql:"ci_artifact_id"`
+	CiPipelineId       int       `sql:"ci_pipeline_id"`
+	AppId              int       `sql:"app_id"`
+	Image              string    `sql:"image"`
+	CiWorkflowId       int       `sql:"ci_workflow_id"`
+	BlobStorageEnabled bool      `sql:"blob_storage_enabled"`
+	CreatedOn          time.Time `sql:"created_on"`
+}
+
+// ArtifactDeploymentInfo is the latest deployment of an artifact or of the artifacts derived from it
+type ArtifactDeploymentInfo struct {
+	CiArtifactId   int       `sql:"ci_artifact_id"`
+	LastDeployedOn time.Time `sql:"last_deployed_on"`
+}
+
+// ArtifactGcRepository reads the artifacts, deployments and references needed to plan and execute a garbage collection
+type ArtifactGcRepository interface {
+	// FindCandidatesByAppIds returns the artifacts built or pushed for the ci pipelines of the apps, artifacts
+	// derived from other artifacts, like the ones of linked ci and pre/post stages, are not included
+	FindCandidatesByAppIds(appIds []int) ([]*ArtifactGcCandidate, error)
+	// FindDeploymentInfo returns the latest deployment of the artifacts, deployments of derived artifacts count for their parent
+	FindDeploymentInfo(artifactIds []int) ([]*ArtifactDeploymentInfo, error)
+	// FindCurrentlyDeployedArtifactIds returns the artifacts of the latest and the latest succeeded
+	// deployment of every active cd pipeline of the apps
+	FindCurrentlyDeployedArtifactIds(appIds []int) ([]int, error)
+	// FindReferencedArtifactIds returns the artifacts among artifactIds whose rows can not be deleted
+	FindReferencedArtifactIds(artifactIds []int) ([]int, error)
+	// FindArtifactIdsSharingImage returns the artifacts among artifactIds whose image or image digest
+	// is also used by an artifact not in artifactIds
+	FindArtifactIdsSharingImage(artifactIds []int) ([]int, error)
+	// FindDeletedCiPipelinesWithBuildCache returns the deleted ci pipelines of the apps whose build cache is not deleted yet
+	FindDeletedCiPipelinesWithBuildCache(appIds []int) ([]*bean.DeletedCiPipelineDetail, error)
+}
+
+type ArtifactGcRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewArtifactGcRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ArtifactGcRepositoryImpl {
+	return &ArtifactGcRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindCandidatesByAppIds(appIds []int) ([]*ArtifactGcCandidate, error) {
+	var candidates []*ArtifactGcCandidate
+	if len(appIds) == 0 {
+		return candidates, nil
+	}
+	query := `SELECT ca.id AS ci_artifact_id, ca.pipeline_id AS ci_pipeline_id, cp.app_id, ca.image, 
+		COALESCE(ca.ci_workflow_id, 0) AS ci_workflow_id, COALESCE(cw.blob_storage_enabled, false) AS blob_storage_enabled, ca.created_on 
+		FROM ci_artifact ca 
+		INNER JOIN ci_pipeline cp ON cp.id = ca.pipeline_id 
+		LEFT JOIN ci_workflow cw ON cw.id = ca.ci_workflow_id 
+		WHERE cp.app_id IN (?) 
+		AND COALESCE(ca.parent_ci_artifact, 0) = 0 
+		AND ca.data_source NOT IN (?);`
+	_, err := impl.dbConnection.Query(&candidates, query, pg.In(appIds), pg.In([]string{repository.PRE_CD, repository.POST_CD}))
+	return candidates, err
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindDeploymentInfo(artifactIds []int) ([]*ArtifactDeploymentInfo, error) {
+	var deployments []*ArtifactDeploymentInfo
+	if len(artifactIds) == 0 {
+		return deployments, nil
+	}
+	query := `SELECT COALESCE(NULLIF(ca.parent_ci_artifact, 0), ca.id) AS ci_artifact_id, MAX(cwr.started_on) AS last_deployed_on 
+		FROM cd_workflow_runner cwr 
+		INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id 
+		INNER JOIN ci_artifact ca ON ca.id = cw.ci_artifact_id 
+		WHERE cwr.workflow_type = ? 
+		AND (ca.id IN (?) OR ca.parent_ci_artifact IN (?)) 
+		GROUP BY 1;`
+	_, err := impl.dbConnection.Query(&deployments, query, apiBean.CD_WORKFLOW_TYPE_DEPLOY, pg.In(artifactIds), pg.In(artifactIds))
+	return deployments, err
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindCurrentlyDeployedArtifactIds(appIds []int) ([]int, error) {
+	var artifactIds []int
+	if len(appIds) == 0 {
+		return artifactIds, nil
+	}
+	// the latest runner of any stage covers deployments in progress, the latest succeeded deployment
+	// covers pipelines whose latest deployment failed and still run the previous artifact
+	query := `SELECT DISTINCT COALESCE(NULLIF(ca.parent_ci_artifact, 0), ca.id) 
+		FROM (
+			(SELECT DISTINCT ON (cw.pipeline_id) cw.ci_artifact_id 
+			FROM cd_workflow_runner cwr 
+			INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id 
+			INNER JOIN pipeline p ON p.id = cw.pipeline_id AND p.deleted = false 
+			WHERE p.app_id IN (?) 
+			ORDER BY cw.pipeline_id, cwr.id DESC) 
+			UNION 
+			(SELECT DISTINCT ON (cw.pipeline_id) cw.ci_artifact_id 
+			FROM cd_workflow_runner cwr 
+			INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id 
+			INNER JOIN pipeline p ON p.id = cw.pipeline_id AND p.deleted = false 
+			WHERE p.app_id IN (?) AND cwr.workflow_type = ? AND cwr.status IN (?) 
+			ORDER BY cw.pipeline_id, cwr.id DESC)
+		) latest 
+		INNER JOIN ci_artifact ca ON ca.id = latest.ci_artifact_id;`
+	_, err := impl.dbConnection.Query(&artifactIds, query, pg.In(appIds), pg.In(appIds), apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		pg.In([]string{cdWorkflow.WorkflowSucceeded, string(health.HealthStatusHealthy)}))
+	return artifactIds, err
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindReferencedArtifactIds(artifactIds []int) ([]int, error) {
+	var referencedIds []int
+	if len(artifactIds) == 0 {
+		return referencedIds, nil
+	}
+	_, err := impl.dbConnection.Query(&referencedIds, getReferencedArtifactIdsQuery(), pg.In(artifactIds))
+	return referencedIds, err
+}
+
+func getReferencedArtifactIdsQuery() string {
+	selects := make([]string, 0, len(artifactReferences))
+	for _, reference := range artifactReferences {
+		selectQuery := fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (?0)", reference.Column, reference.Table, reference.Column)
+		if len(reference.Statuses) > 0 {
+			selectQuery += fmt.Sprintf(" AND status IN ('%s')", strings.Join(reference.Statuses, "', '"))
+		}
+		selects = append(selects, selectQuery)
+	}
+	return strings.Join(selects, " UNION ") + ";"
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindArtifactIdsSharingImage(artifactIds []int) ([]int, error) {
+	var sharedIds []int
+	if len(artifactIds) == 0 {
+		return sharedIds, nil
+	}
+	// registries delete the manifest, so a tag pointing to the same digest is lost along with it. The image and the
+	// digest are looked up separately, so that each lookup uses its index on ci_artifact
+	query := `SELECT ca.id FROM ci_artifact ca 
+		WHERE ca.id IN (?0) 
+		AND EXISTS (SELECT 1 FROM ci_artifact other WHERE other.image = ca.image AND other.id NOT IN (?0)) 
+		UNION 
+		SELECT ca.id FROM ci_artifact ca 
+		WHERE ca.id IN (?0) AND ca.image_digest <> '' 
+		AND EXISTS (SELECT 1 FROM ci_artifact other WHERE other.image_digest = ca.image_digest AND other.id NOT IN (?0));`
+	_, err := impl.dbConnection.Query(&sharedIds, query, pg.In(artifactIds))
+	return sharedIds, err
+}
+
+func (impl *ArtifactGcRepositoryImpl) FindDeletedCiPipelinesWithBuildCache(appIds []int) ([]*bean.DeletedCiPipelineDetail, error) {
+	var ciPipelines []*bean.DeletedCiPipelineDetail
+	if len(appIds) == 0 {
+		return ciPipelines, nil
+	}
+	query := `SELECT cp.id AS ci_pipeline_id, cp.app_id 
+		FROM ci_pipeline cp 
+		WHERE cp.app_id IN (?) AND cp.deleted = true 
+		AND EXISTS (SELECT 1 FROM ci_workflow cw WHERE cw.ci_pipeline_id = cp.id AND cw.blob_storage_enabled = true) 
+		AND NOT EXISTS (SELECT 1 FROM artifact_gc_run_item gri WHERE gri.ci_pipeline_id = cp.id AND gri.action = ? AND gri.status = ?) 
+		ORDER BY cp.id;`
+	_, err := impl.dbConnection.Query(&ciPipelines, query, pg.In(appIds), bean.ItemActionDeleteBuildCache, bean.ItemStatusDeleted)
+	return ciPipelines, err
+}
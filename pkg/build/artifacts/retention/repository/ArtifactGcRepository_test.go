@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/devtron-labs/common-lib/utils"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+const migrationsDir = "../../../../../scripts/sql"
+
+// TestArtifactReferencesExistInMigrations checks that the referencing columns are created by the migrations, a wrong
+// table name fails the whole query and with it every garbage collection run
+func TestArtifactReferencesExistInMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	migrations := ""
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		migrations += string(content) + "\n"
+	}
+	for _, reference := range artifactReferences {
+		createTable := regexp.MustCompile(`(?is)create table (if not exists )?("?public"?\.)?"?` + reference.Table + `"?\s*\(([^;]*)\)\s*;`)
+		match := createTable.FindStringSubmatch(migrations)
+		if !assert.NotNil(t, match, "table %s is not created by the migrations", reference.Table) {
+			continue
+		}
+		column := regexp.MustCompile(`(?m)^\s*"?` + reference.Column + `"?\s`)
+		assert.True(t, column.MatchString(match[3]), "column %s is not created in table %s", reference.Column, reference.Table)
+		if len(reference.Statuses) > 0 {
+			statusColumn := regexp.MustCompile(`(?m)^\s*"?status"?\s`)
+			assert.True(t, statusColumn.MatchString(match[3]), "column status is not created in table %s", reference.Table)
+		}
+	}
+}
+
+// TestArtifactImageIndexesExistInMigrations checks that the image lookups of FindArtifactIdsSharingImage are indexed,
+// without the indexes every garbage collection run scans the ci artifacts
+func TestArtifactImageIndexesExistInMigrations(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(migrationsDir, "37304600_artifact_retention.up.sql"))
+	assert.NoError(t, err)
+	for _, column := range []string{"image", "image_digest"} {
+		createIndex := regexp.MustCompile(`(?is)create index (if not exists )?"?idx_ci_artifact_` + column + `"?\s+on "?public"?\."?ci_artifact"?\s*\("` + column + `"\)`)
+		assert.True(t, createIndex.Match(content), "index on ci_artifact %s is not created by the migration", column)
+	}
+}
+
+func TestGetReferencedArtifactIdsQuery(t *testing.T) {
+	query := getReferencedArtifactIdsQuery()
+	tests := []struct {
+		name      string
+		reference string
+	}{
+		{
+			name:      "fk reference is not limited by status",
+			reference: "SELECT ci_artifact_id FROM cd_workflow WHERE ci_artifact_id IN (?0) UNION",
+		},
+		{
+			name:      "queued deployment window trigger",
+			reference: "SELECT ci_artifact_id FROM deployment_window_queued_trigger WHERE ci_artifact_id IN (?0) AND status IN ('QUEUED')",
+		},
+		{
+			name:      "initiated auto rollback",
+			reference: "SELECT ci_artifact_id FROM auto_rollback_history WHERE ci_artifact_id IN (?0) AND status IN ('INITIATED')",
+		},
+		{
+			name:      "live preview environment",
+			reference: "SELECT last_deployed_artifact_id FROM preview_environment WHERE last_deployed_artifact_id IN (?0) AND status IN ('ACTIVE', 'HIBERNATED')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, query, tt.reference)
+		})
+	}
+}
+
+func TestFindReferencedArtifactIds(t *testing.T) {
+	cfg, err := sql.GetConfig()
+	if err != nil {
+		t.Skip("database config not found")
+	}
+	logger, err := utils.NewSugardLogger()
+	assert.NoError(t, err)
+	dbConnection, err := sql.NewDbConnection(cfg, logger)
+	if err != nil {
+		t.Skip("database is not reachable")
+	}
+	defer dbConnection.Close()
+	repositoryImpl := NewArtifactGcRepositoryImpl(dbConnection, logger)
+	referencedIds, err := repositoryImpl.FindReferencedArtifactIds([]int{-1})
+	assert.NoError(t, err)
+	assert.Empty(t, referencedIds)
+}
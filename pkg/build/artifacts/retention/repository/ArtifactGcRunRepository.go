@@ -0,0 +1,259 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type ArtifactGcRun struct {
+	tableName     struct{}  `sql:"artifact_gc_run" pg:",discard_unknown_columns"`
+	Id            int       `sql:"id,pk"`
+	PolicyId      int       `sql:"policy_id,notnull"`
+	Status        string    `sql:"status,notnull"`
+	StatusMessage string    `sql:"status_message"`
+	TriggerType   string    `sql:"trigger_type,notnull"`
+	KeptCount     int       `sql:"kept_count,notnull"`
+	PlannedCount  int       `sql:"planned_count,notnull"`
+	DeletedCount  int       `sql:"deleted_count,notnull"`
+	SkippedCount  int       `sql:"skipped_count,notnull"`
+	FailedCount   int       `sql:"failed_count,notnull"`
+	ExecutedOn    time.Time `sql:"executed_on"`
+	ExecutedBy    int32     `sql:"executed_by"`
+	FinishedOn    time.Time `sql:"finished_on"`
+	sql.AuditLog
+}
+
+type ArtifactGcRunItem struct {
+	tableName          struct{}  `sql:"artifact_gc_run_item" pg:",discard_unknown_columns"`
+	Id                 int       `sql:"id,pk"`
+	GcRunId            int       `sql:"gc_run_id,notnull"`
+	Action             string    `sql:"action,notnull"`
+	CiArtifactId       int       `sql:"ci_artifact_id"`
+	CiWorkflowId       int       `sql:"ci_workflow_id"`
+	CiPipelineId       int       `sql:"ci_pipeline_id,notnull"`
+	AppId              int       `sql:"app_id,notnull"`
+	Image              string    `sql:"image"`
+	ArtifactCreatedOn  time.Time `sql:"artifact_created_on"`
+	LastDeployedOn     time.Time `sql:"last_deployed_on"`
+	Reason             string    `sql:"reason"`
+	Status             string    `sql:"status,notnull"`
+	StatusMessage      string    `sql:"status_message"`
+	RegistryTagDeleted bool      `sql:"registry_tag_deleted,notnull"`
+	BlobObjectsDeleted bool      `sql:"blob_objects_deleted,notnull"`
+	RowDeleted         bool      `sql:"row_deleted,notnull"`
+	sql.AuditLog
+}
+
+type ArtifactGcRunRepository interface {
+	sql.TransactionWrapper
+	SaveWithTx(tx *pg.Tx, run *ArtifactGcRun) error
+	SaveItemsWithTx(tx *pg.Tx, items []*ArtifactGcRunItem) error
+	Update(run *ArtifactGcRun) error
+	UpdateItem(item *ArtifactGcRunItem) error
+	FindById(id int) (*ArtifactGcRun, error)
+	FindItemsByRunId(runId int) ([]*ArtifactGcRunItem, error)
+	// FindRecent returns the latest runs, of all the policies when policyId is 0
+	FindRecent(policyId int, limit int) ([]*ArtifactGcRun, error)
+	FindDryRunsByPolicyIdWithTx(tx *pg.Tx, policyId int) ([]*ArtifactGcRun, error)
+	// FindDryRunsCreatedBefore returns the dry run reports of the given trigger type which are older than createdBefore
+	FindDryRunsCreatedBefore(triggerType bean.RunTriggerType, createdBefore time.Time) ([]*ArtifactGcRun, error)
+	// LockPolicyWithTx serializes the creation of the reports of a policy across orchestrator replicas, released with the transaction
+	LockPolicyWithTx(tx *pg.Tx, policyId int) error
+	// UpdateStatus changes the status only if it still holds fromStatus
+	UpdateStatus(id int, fromStatus, toStatus bean.RunStatus, message string, userId int32) (bool, error)
+	UpdateStatusWithTx(tx *pg.Tx, id int, fromStatus, toStatus bean.RunStatus, message string, userId int32) (bool, error)
+	// MarkStaleExecutionsFailed fails the runs executing since before executedBefore and returns their count
+	MarkStaleExecutionsFailed(executedBefore time.Time) (int, error)
+	// FindArtifactIdsWithDeletedBuildLogs returns the artifacts among artifactIds whose build logs were deleted by a completed run
+	FindArtifactIdsWithDeletedBuildLogs(artifactIds []int) ([]int, error)
+	// FindArtifactIdsWithDeletedImages returns the artifacts among artifactIds whose image was deleted by a completed run
+	// while their row was kept
+	FindArtifactIdsWithDeletedImages(artifactIds []int) ([]int, error)
+}
+
+type ArtifactGcRunRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewArtifactGcRunRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	TransactionUtilImpl *sql.TransactionUtilImpl) *ArtifactGcRunRepositoryImpl {
+	return &ArtifactGcRunRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) SaveWithTx(tx *pg.Tx, run *ArtifactGcRun) error {
+	return tx.Insert(run)
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) SaveItemsWithTx(tx *pg.Tx, items []*ArtifactGcRunItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := tx.Model(&items).Insert()
+	return err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) Update(run *ArtifactGcRun) error {
+	return impl.dbConnection.Update(run)
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) UpdateItem(item *ArtifactGcRunItem) error {
+	return impl.dbConnection.Update(item)
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindById(id int) (*ArtifactGcRun, error) {
+	run := &ArtifactGcRun{}
+	err := impl.dbConnection.Model(run).
+		Where("id = ?", id).
+		Select()
+	return run, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindItemsByRunId(runId int) ([]*ArtifactGcRunItem, error) {
+	var items []*ArtifactGcRunItem
+	err := impl.dbConnection.Model(&items).
+		Where("gc_run_id = ?", runId).
+		Order("id ASC").
+		Select()
+	return items, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindRecent(policyId int, limit int) ([]*ArtifactGcRun, error) {
+	var runs []*ArtifactGcRun
+	query := impl.dbConnection.Model(&runs)
+	if policyId > 0 {
+		query = query.Where("policy_id = ?", policyId)
+	}
+	err := query.
+		Order("id DESC").
+		Limit(limit).
+		Select()
+	return runs, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindDryRunsByPolicyIdWithTx(tx *pg.Tx, policyId int) ([]*ArtifactGcRun, error) {
+	var runs []*ArtifactGcRun
+	err := tx.Model(&runs).
+		Where("policy_id = ?", policyId).
+		Where("status = ?", bean.RunStatusDryRun).
+		Order("id ASC").
+		Select()
+	return runs, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindDryRunsCreatedBefore(triggerType bean.RunTriggerType, createdBefore time.Time) ([]*ArtifactGcRun, error) {
+	var runs []*ArtifactGcRun
+	err := impl.dbConnection.Model(&runs).
+		Where("status = ?", bean.RunStatusDryRun).
+		Where("trigger_type = ?", triggerType).
+		Where("created_on < ?", createdBefore).
+		Order("id ASC").
+		Select()
+	return runs, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) LockPolicyWithTx(tx *pg.Tx, policyId int) error {
+	_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf(bean.GcLockKeyFormat, policyId))
+	return err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) UpdateStatus(id int, fromStatus, toStatus bean.RunStatus, message string, userId int32) (bool, error) {
+	return impl.updateStatus(impl.dbConnection, id, fromStatus, toStatus, message, userId)
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) UpdateStatusWithTx(tx *pg.Tx, id int, fromStatus, toStatus bean.RunStatus, message string, userId int32) (bool, error) {
+	return impl.updateStatus(tx, id, fromStatus, toStatus, message, userId)
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) updateStatus(db orm.DB, id int, fromStatus, toStatus bean.RunStatus, message string, userId int32) (bool, error) {
+	now := time.Now()
+	query := db.Model((*ArtifactGcRun)(nil)).
+		Set("status = ?", toStatus).
+		Set("status_message = ?", message).
+		Set("updated_on = ?", now).
+		Set("updated_by = ?", userId)
+	if toStatus == bean.RunStatusExecuting {
+		query = query.Set("executed_on = ?", now).
+			Set("executed_by = ?", userId)
+	}
+	res, err := query.
+		Where("id = ?", id).
+		Where("status = ?", fromStatus).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) MarkStaleExecutionsFailed(executedBefore time.Time) (int, error) {
+	now := time.Now()
+	res, err := impl.dbConnection.Model((*ArtifactGcRun)(nil)).
+		Set("status = ?", bean.RunStatusFailed).
+		Set("status_message = ?", bean.InterruptedExecutionMessage).
+		Set("finished_on = ?", now).
+		Set("updated_on = ?", now).
+		Where("status = ?", bean.RunStatusExecuting).
+		Where("executed_on < ?", executedBefore).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindArtifactIdsWithDeletedBuildLogs(artifactIds []int) ([]int, error) {
+	var ids []int
+	if len(artifactIds) == 0 {
+		return ids, nil
+	}
+	err := impl.dbConnection.Model((*ArtifactGcRunItem)(nil)).
+		ColumnExpr("DISTINCT ci_artifact_id").
+		Where("ci_artifact_id IN (?)", pg.In(artifactIds)).
+		Where("action IN (?)", pg.In([]bean.ItemAction{bean.ItemActionDeleteBuildLogs, bean.ItemActionDeleteImage})).
+		Where("status = ?", bean.ItemStatusDeleted).
+		Where("blob_objects_deleted = ?", true).
+		Select(&ids)
+	return ids, err
+}
+
+func (impl *ArtifactGcRunRepositoryImpl) FindArtifactIdsWithDeletedImages(artifactIds []int) ([]int, error) {
+	var ids []int
+	if len(artifactIds) == 0 {
+		return ids, nil
+	}
+	err := impl.dbConnection.Model((*ArtifactGcRunItem)(nil)).
+		ColumnExpr("DISTINCT ci_artifact_id").
+		Where("ci_artifact_id IN (?)", pg.In(artifactIds)).
+		Where("action = ?", bean.ItemActionDeleteImage).
+		Where("status = ?", bean.ItemStatusDeleted).
+		Select(&ids)
+	return ids, err
+}
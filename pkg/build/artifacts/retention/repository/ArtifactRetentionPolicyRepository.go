@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ArtifactRetentionPolicy struct {
+	tableName              struct{} `sql:"artifact_retention_policy" pg:",discard_unknown_columns"`
+	Id                     int      `sql:"id,pk"`
+	ScopeType              string   `sql:"scope_type,notnull"`
+	ScopeId                int      `sql:"scope_id,notnull"`
+	KeepLastN              int      `sql:"keep_last_n,notnull"`
+	KeepDeployedWithinDays int      `sql:"keep_deployed_within_days,notnull"`
+	DeleteRegistryTags     bool     `sql:"delete_registry_tags,notnull"`
+	DeleteBuildLogs        bool     `sql:"delete_build_logs,notnull"`
+	DeleteBuildCache       bool     `sql:"delete_build_cache,notnull"`
+	Active                 bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ArtifactRetentionPolicyRepository interface {
+	Save(policy *ArtifactRetentionPolicy) error
+	Update(policy *ArtifactRetentionPolicy) error
+	FindById(id int) (*ArtifactRetentionPolicy, error)
+	FindActiveByScope(scopeType string, scopeId int) (*ArtifactRetentionPolicy, error)
+	FindAllActive() ([]*ArtifactRetentionPolicy, error)
+}
+
+type ArtifactRetentionPolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewArtifactRetentionPolicyRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ArtifactRetentionPolicyRepositoryImpl {
+	return &ArtifactRetentionPolicyRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ArtifactRetentionPolicyRepositoryImpl) Save(policy *ArtifactRetentionPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *ArtifactRetentionPolicyRepositoryImpl) Update(policy *ArtifactRetentionPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *ArtifactRetentionPolicyRepositoryImpl) FindById(id int) (*ArtifactRetentionPolicy, error) {
+	policy := &ArtifactRetentionPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *ArtifactRetentionPolicyRepositoryImpl) FindActiveByScope(scopeType string, scopeId int) (*ArtifactRetentionPolicy, error) {
+	policy := &ArtifactRetentionPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("scope_type = ?", scopeType).
+		Where("scope_id = ?", scopeId).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *ArtifactRetentionPolicyRepositoryImpl) FindAllActive() ([]*ArtifactRetentionPolicy, error) {
+	var policies []*ArtifactRetentionPolicy
+	err := impl.dbConnection.Model(&policies).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
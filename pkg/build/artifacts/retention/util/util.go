@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+)
+
+// GetProtectionReason returns why an artifact beyond the latest ones of its ci pipeline must not be
+// deleted, an empty reason means the artifact is not protected
+func GetProtectionReason(artifact *bean.ArtifactDetail, keepDeployedWithinDays int, now time.Time) string {
+	if artifact.CurrentlyDeployed {
+		return bean.ReasonCurrentlyDeployed
+	}
+	if keepDeployedWithinDays > 0 && artifact.LastDeployedOn != nil &&
+		artifact.LastDeployedOn.After(now.AddDate(0, 0, -keepDeployedWithinDays)) {
+		return fmt.Sprintf(bean.ReasonDeployedWithinDays, keepDeployedWithinDays)
+	}
+	return ""
+}
+
+// PlanArtifactGc selects the artifacts and build caches to be deleted as per the policy. The latest
+// KeepLastN artifacts of every ci pipeline are kept, older ones are deleted unless protected, and
+// artifacts referenced by the deployment history keep their row but lose their registry tag and
+// build logs, or only their build logs if registry tags are not deleted by the policy. At most maxItems
+// deletions are planned, oldest artifacts first, the rest are left for the next run.
+func PlanArtifactGc(policy *bean.RetentionPolicyDto, artifacts []*bean.ArtifactDetail,
+	deletedCiPipelines []*bean.DeletedCiPipelineDetail, now time.Time, maxItems int) *bean.GcPlan {
+	plan := &bean.GcPlan{}
+	artifactsByCiPipeline := make(map[int][]*bean.ArtifactDetail)
+	ciPipelineIds := make([]int, 0)
+	for _, artifact := range artifacts {
+		if _, ok := artifactsByCiPipeline[artifact.CiPipelineId]; !ok {
+			ciPipelineIds = append(ciPipelineIds, artifact.CiPipelineId)
+		}
+		artifactsByCiPipeline[artifact.CiPipelineId] = append(artifactsByCiPipeline[artifact.CiPipelineId], artifact)
+	}
+	sort.Ints(ciPipelineIds)
+	skippedItems := make([]*bean.PlannedItem, 0)
+	deleteItems := make([]*bean.PlannedItem, 0)
+	for _, ciPipelineId := range ciPipelineIds {
+		pipelineArtifacts := artifactsByCiPipeline[ciPipelineId]
+		sort.Slice(pipelineArtifacts, func(i, j int) bool {
+			return pipelineArtifacts[i].Id > pipelineArtifacts[j].Id
+		})
+		for index, artifact := range pipelineArtifacts {
+			if index < policy.KeepLastN {
+				plan.KeptCount++
+				continue
+			}
+			item := &bean.PlannedItem{
+				Artifact:     artifact,
+				CiPipelineId: artifact.CiPipelineId,
+				AppId:        artifact.AppId,
+			}
+			if reason := GetProtectionReason(artifact, policy.KeepDeployedWithinDays, now); len(reason) > 0 {
+				item.Action = bean.ItemActionSkip
+				item.Reason = reason
+				skippedItems = append(skippedItems, item)
+				continue
+			}
+			if artifact.Referenced {
+				if policy.DeleteRegistryTags && !artifact.ImageDeleted {
+					item.Action = bean.ItemActionDeleteImage
+				} else if policy.DeleteBuildLogs && HasBuildLogs(artifact) {
+					item.Action = bean.ItemActionDeleteBuildLogs
+				} else {
+					plan.KeptCount++
+					continue
+				}
+				item.Reason = bean.ReasonReferencedByHistory
+			} else {
+				item.Action = bean.ItemActionDeleteArtifact
+				item.Reason = fmt.Sprintf(bean.ReasonBeyondLatest, policy.KeepLastN)
+			}
+			deleteItems = append(deleteItems, item)
+		}
+	}
+	sort.SliceStable(deleteItems, func(i, j int) bool {
+		return deleteItems[i].Artifact.Id < deleteItems[j].Artifact.Id
+	})
+	if policy.DeleteBuildCache {
+		for _, ciPipeline := range deletedCiPipelines {
+			deleteItems = append(deleteItems, &bean.PlannedItem{
+				Action:       bean.ItemActionDeleteBuildCache,
+				CiPipelineId: ciPipeline.CiPipelineId,
+				AppId:        ciPipeline.AppId,
+				Reason:       bean.ReasonDeletedCiPipeline,
+			})
+		}
+	}
+	if maxItems > 0 && len(deleteItems) > maxItems {
+		deleteItems = deleteItems[:maxItems]
+	}
+	plan.Items = append(deleteItems, skippedItems...)
+	return plan
+}
+
+// HasBuildLogs is false for artifacts without a ci workflow, like the ones pushed by webhook,
+// and for builds which did not store logs in the blob storage
+func HasBuildLogs(artifact *bean.ArtifactDetail) bool {
+	return artifact.CiWorkflowId > 0 && artifact.BlobStorageEnabled && !artifact.BuildLogsDeleted
+}
+
+// GetPlannedCount is the number of items of the plan which delete something
+func GetPlannedCount(plan *bean.GcPlan) int {
+	count := 0
+	for _, item := range plan.Items {
+		if item.Action != bean.ItemActionSkip {
+			count++
+		}
+	}
+	return count
+}
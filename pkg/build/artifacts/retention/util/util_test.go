@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func artifactDetail(id, ciPipelineId int) *bean.ArtifactDetail {
+	return &bean.ArtifactDetail{Id: id, CiPipelineId: ciPipelineId, AppId: 1, CiWorkflowId: id, BlobStorageEnabled: true}
+}
+
+func plannedActions(plan *bean.GcPlan) map[int]bean.ItemAction {
+	actions := make(map[int]bean.ItemAction)
+	for _, item := range plan.Items {
+		if item.Artifact != nil {
+			actions[item.Artifact.Id] = item.Action
+		}
+	}
+	return actions
+}
+
+func TestPlanArtifactGc(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -2)
+	old := now.AddDate(0, 0, -40)
+	policy := &bean.RetentionPolicyDto{KeepLastN: 2, KeepDeployedWithinDays: 30, DeleteBuildLogs: true, DeleteBuildCache: true}
+
+	t.Run("keeps latest artifacts of every ci pipeline", func(t *testing.T) {
+		artifacts := []*bean.ArtifactDetail{artifactDetail(1, 1), artifactDetail(2, 1), artifactDetail(3, 1), artifactDetail(4, 2), artifactDetail(5, 2)}
+		plan := PlanArtifactGc(policy, artifacts, nil, now, 0)
+		assert.Equal(t, 4, plan.KeptCount)
+		assert.Equal(t, map[int]bean.ItemAction{1: bean.ItemActionDeleteArtifact}, plannedActions(plan))
+	})
+
+	t.Run("protects deployed artifacts", func(t *testing.T) {
+		currentlyDeployed := artifactDetail(1, 1)
+		currentlyDeployed.CurrentlyDeployed = true
+		currentlyDeployed.LastDeployedOn = &old
+		recentlyDeployed := artifactDetail(2, 1)
+		recentlyDeployed.LastDeployedOn = &recent
+		deployedLongAgo := artifactDetail(3, 1)
+		deployedLongAgo.LastDeployedOn = &old
+		deployedLongAgo.Referenced = true
+		artifacts := []*bean.ArtifactDetail{currentlyDeployed, recentlyDeployed, deployedLongAgo, artifactDetail(4, 1), artifactDetail(5, 1)}
+		plan := PlanArtifactGc(policy, artifacts, nil, now, 0)
+		assert.Equal(t, map[int]bean.ItemAction{
+			1: bean.ItemActionSkip,
+			2: bean.ItemActionSkip,
+			3: bean.ItemActionDeleteBuildLogs,
+		}, plannedActions(plan))
+		assert.Equal(t, 1, GetPlannedCount(plan))
+	})
+
+	t.Run("referenced artifact without build logs is kept", func(t *testing.T) {
+		referenced := artifactDetail(1, 1)
+		referenced.Referenced = true
+		referenced.BuildLogsDeleted = true
+		plan := PlanArtifactGc(policy, []*bean.ArtifactDetail{referenced, artifactDetail(2, 1), artifactDetail(3, 1)}, nil, now, 0)
+		assert.Empty(t, plan.Items)
+		assert.Equal(t, 3, plan.KeptCount)
+	})
+
+	t.Run("deletes image of referenced artifact deployed outside the keep window", func(t *testing.T) {
+		imagePolicy := &bean.RetentionPolicyDto{KeepLastN: 2, KeepDeployedWithinDays: 30, DeleteRegistryTags: true, DeleteBuildLogs: true}
+		deployedLongAgo := artifactDetail(1, 1)
+		deployedLongAgo.LastDeployedOn = &old
+		deployedLongAgo.Referenced = true
+		recentlyDeployed := artifactDetail(2, 1)
+		recentlyDeployed.LastDeployedOn = &recent
+		recentlyDeployed.Referenced = true
+		imageDeleted := artifactDetail(3, 1)
+		imageDeleted.LastDeployedOn = &old
+		imageDeleted.Referenced = true
+		imageDeleted.ImageDeleted = true
+		imageAndLogsDeleted := artifactDetail(4, 1)
+		imageAndLogsDeleted.LastDeployedOn = &old
+		imageAndLogsDeleted.Referenced = true
+		imageAndLogsDeleted.ImageDeleted = true
+		imageAndLogsDeleted.BuildLogsDeleted = true
+		artifacts := []*bean.ArtifactDetail{deployedLongAgo, recentlyDeployed, imageDeleted, imageAndLogsDeleted, artifactDetail(5, 1), artifactDetail(6, 1)}
+		plan := PlanArtifactGc(imagePolicy, artifacts, nil, now, 0)
+		assert.Equal(t, map[int]bean.ItemAction{
+			1: bean.ItemActionDeleteImage,
+			2: bean.ItemActionSkip,
+			3: bean.ItemActionDeleteBuildLogs,
+		}, plannedActions(plan))
+		assert.Equal(t, 3, plan.KeptCount)
+		for _, item := range plan.Items {
+			if item.Action == bean.ItemActionDeleteImage {
+				assert.Equal(t, bean.ReasonReferencedByHistory, item.Reason)
+			}
+		}
+	})
+
+	t.Run("caps deletions oldest first and adds build caches", func(t *testing.T) {
+		artifacts := []*bean.ArtifactDetail{artifactDetail(7, 1), artifactDetail(3, 1), artifactDetail(9, 1), artifactDetail(1, 1), artifactDetail(8, 1)}
+		deletedCiPipelines := []*bean.DeletedCiPipelineDetail{{CiPipelineId: 5, AppId: 1}}
+		plan := PlanArtifactGc(policy, artifacts, deletedCiPipelines, now, 2)
+		assert.Len(t, plan.Items, 2)
+		assert.Equal(t, 1, plan.Items[0].Artifact.Id)
+		assert.Equal(t, 3, plan.Items[1].Artifact.Id)
+
+		plan = PlanArtifactGc(policy, artifacts, deletedCiPipelines, now, 0)
+		assert.Len(t, plan.Items, 4)
+		assert.Equal(t, bean.ItemActionDeleteBuildCache, plan.Items[3].Action)
+		assert.Equal(t, 5, plan.Items[3].CiPipelineId)
+	})
+}
@@ -0,0 +1,21 @@
+package retention
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention/repository"
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	repository.NewArtifactRetentionPolicyRepositoryImpl,
+	wire.Bind(new(repository.ArtifactRetentionPolicyRepository), new(*repository.ArtifactRetentionPolicyRepositoryImpl)),
+	repository.NewArtifactGcRunRepositoryImpl,
+	wire.Bind(new(repository.ArtifactGcRunRepository), new(*repository.ArtifactGcRunRepositoryImpl)),
+	repository.NewArtifactGcRepositoryImpl,
+	wire.Bind(new(repository.ArtifactGcRepository), new(*repository.ArtifactGcRepositoryImpl)),
+	NewBuildBlobCleanerImpl,
+	wire.Bind(new(BuildBlobCleaner), new(*BuildBlobCleanerImpl)),
+	NewRegistryTagCleanerImpl,
+	wire.Bind(new(RegistryTagCleaner), new(*RegistryTagCleanerImpl)),
+	NewArtifactRetentionServiceImpl,
+	wire.Bind(new(ArtifactRetentionService), new(*ArtifactRetentionServiceImpl)),
+)
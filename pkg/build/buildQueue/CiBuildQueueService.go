@@ -0,0 +1,368 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildQueue
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/repository"
+	buildQueueUtil "github.com/devtron-labs/devtron/pkg/build/buildQueue/util"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiBuildQueueService interface {
+	IsEnabled() bool
+	GetConfig() *bean.CiBuildQueueConfig
+	Enqueue(request *bean.EnqueueRequest) (*bean.QueuedBuildDto, error)
+	IsCancelSupersededEnabled(ciPipelineId, appId, teamId int) (bool, error)
+	// SupersedeQueuedBuilds marks the other queued builds of the branch superseded and returns their ci workflow ids
+	SupersedeQueuedBuilds(ciPipelineId int, branch string, newQueueId, newCiWorkflowId int) ([]int, error)
+	// ClaimDispatchableBuilds claims the queued builds fitting in the free slots, safe to be called
+	// concurrently from multiple replicas as the claim is serialized over a database lock
+	ClaimDispatchableBuilds() ([]*bean.ClaimedBuildDto, error)
+	MarkDispatched(id int) error
+	MarkFailed(id int, message string) error
+	// CancelQueuedBuild removes the build of the ci workflow from the queue, false is returned if it is not waiting in the queue
+	CancelQueuedBuild(ciWorkflowId int, userId int32) (bool, error)
+	GetQueuedBuilds(filter *bean.QueueFilter) ([]*bean.QueuedBuildDto, error)
+	GetQueuedBuildByCiWorkflowId(ciWorkflowId int) (*bean.QueuedBuildDto, error)
+	GetLimits() ([]*bean.BuildQueueLimitDto, error)
+	SaveLimit(request *bean.BuildQueueLimitDto) (*bean.BuildQueueLimitDto, error)
+	DeleteLimit(id int, userId int32) error
+}
+
+type CiBuildQueueServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	config                      *bean.CiBuildQueueConfig
+	ciBuildQueueRepository      repository.CiBuildQueueRepository
+	ciBuildQueueLimitRepository repository.CiBuildQueueLimitRepository
+	hostname                    string
+}
+
+func NewCiBuildQueueServiceImpl(logger *zap.SugaredLogger,
+	ciBuildQueueRepository repository.CiBuildQueueRepository,
+	ciBuildQueueLimitRepository repository.CiBuildQueueLimitRepository) *CiBuildQueueServiceImpl {
+	config := &bean.CiBuildQueueConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing ci build queue config, build queue is disabled", "err", err)
+		config = &bean.CiBuildQueueConfig{}
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warnw("error in getting hostname, claimed builds are recorded without it", "err", err)
+	}
+	return &CiBuildQueueServiceImpl{
+		logger:                      logger,
+		config:                      config,
+		ciBuildQueueRepository:      ciBuildQueueRepository,
+		ciBuildQueueLimitRepository: ciBuildQueueLimitRepository,
+		hostname:                    hostname,
+	}
+}
+
+func (impl *CiBuildQueueServiceImpl) IsEnabled() bool {
+	return impl.config.Enabled
+}
+
+func (impl *CiBuildQueueServiceImpl) GetConfig() *bean.CiBuildQueueConfig {
+	return impl.config
+}
+
+func (impl *CiBuildQueueServiceImpl) Enqueue(request *bean.EnqueueRequest) (*bean.QueuedBuildDto, error) {
+	item := adapter.GetCiBuildQueueDbObj(request)
+	err := impl.ciBuildQueueRepository.Save(item)
+	if err != nil {
+		impl.logger.Errorw("error in saving queued build", "ciPipelineId", request.CiPipelineId, "ciWorkflowId", request.CiWorkflowId, "err", err)
+		return nil, err
+	}
+	return impl.GetQueuedBuildByCiWorkflowId(request.CiWorkflowId)
+}
+
+func (impl *CiBuildQueueServiceImpl) IsCancelSupersededEnabled(ciPipelineId, appId, teamId int) (bool, error) {
+	limits, err := impl.GetLimits()
+	if err != nil {
+		return false, err
+	}
+	return buildQueueUtil.ResolveCancelSuperseded(impl.config.CancelSupersededBuilds, ciPipelineId, appId, teamId, limits), nil
+}
+
+func (impl *CiBuildQueueServiceImpl) SupersedeQueuedBuilds(ciPipelineId int, branch string, newQueueId, newCiWorkflowId int) ([]int, error) {
+	items, err := impl.ciBuildQueueRepository.FindQueuedByCiPipelineIdAndBranch(ciPipelineId, branch, newQueueId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching queued builds of branch", "ciPipelineId", ciPipelineId, "branch", branch, "err", err)
+		return nil, err
+	}
+	message := fmt.Sprintf(bean.SupersededMessageFormat, branch, newCiWorkflowId)
+	supersededCiWorkflowIds := make([]int, 0, len(items))
+	for _, item := range items {
+		if item.Id > newQueueId {
+			// a newer build of the branch is already queued, it supersedes the older ones
+			continue
+		}
+		updated, err := impl.ciBuildQueueRepository.UpdateStatus(item.Id, bean.QueueStatusQueued, bean.QueueStatusSuperseded, message, item.TriggeredBy)
+		if err != nil {
+			impl.logger.Errorw("error in marking queued build superseded", "queueId", item.Id, "err", err)
+			return supersededCiWorkflowIds, err
+		}
+		if updated {
+			supersededCiWorkflowIds = append(supersededCiWorkflowIds, item.CiWorkflowId)
+		}
+	}
+	return supersededCiWorkflowIds, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) ClaimDispatchableBuilds() ([]*bean.ClaimedBuildDto, error) {
+	tx, err := impl.ciBuildQueueRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction for claiming queued builds", "err", err)
+		return nil, err
+	}
+	defer impl.ciBuildQueueRepository.RollbackTx(tx)
+	err = impl.ciBuildQueueRepository.LockQueueWithTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in acquiring build queue lock", "err", err)
+		return nil, err
+	}
+	now := time.Now()
+	requeuedCount, err := impl.ciBuildQueueRepository.RequeueStaleDispatchingWithTx(tx, now.Add(-time.Duration(impl.config.StaleDispatchTimeoutSecs)*time.Second))
+	if err != nil {
+		impl.logger.Errorw("error in re-queuing stale claimed builds", "err", err)
+		return nil, err
+	} else if requeuedCount > 0 {
+		impl.logger.Infow("re-queued claimed builds which were not started", "count", requeuedCount)
+	}
+	runningBuilds, err := impl.ciBuildQueueRepository.FindRunningBuildsWithTx(tx, now.Add(-time.Duration(impl.config.RunningBuildLookbackHours)*time.Hour))
+	if err != nil {
+		impl.logger.Errorw("error in fetching running builds", "err", err)
+		return nil, err
+	}
+	limits, err := impl.GetLimits()
+	if err != nil {
+		return nil, err
+	}
+	concurrencyLimits := buildQueueUtil.NewConcurrencyLimits(impl.config, limits)
+	selectedBuilds := make([]*bean.QueuedBuildDto, 0)
+	triggerRequests := make(map[int]string)
+	// paging through the queue, so that the builds of scopes which are at their limit do not block the builds of other scopes
+	for offset := 0; len(selectedBuilds) < impl.config.DispatchBatchSize; offset += impl.config.DispatchBatchSize {
+		if concurrencyLimits.Global > 0 && len(runningBuilds) >= concurrencyLimits.Global {
+			break
+		}
+		queuedItems, err := impl.ciBuildQueueRepository.FindQueuedWithTx(tx, offset, impl.config.DispatchBatchSize)
+		if err != nil {
+			impl.logger.Errorw("error in fetching queued builds", "offset", offset, "err", err)
+			return nil, err
+		}
+		queuedBuilds := make([]*bean.QueuedBuildDto, 0, len(queuedItems))
+		for _, item := range queuedItems {
+			queuedBuilds = append(queuedBuilds, adapter.BuildQueuedBuildDto(item))
+			triggerRequests[item.Id] = item.TriggerRequest
+		}
+		for _, build := range buildQueueUtil.SelectDispatchableBuilds(queuedBuilds, runningBuilds, concurrencyLimits) {
+			if len(selectedBuilds) == impl.config.DispatchBatchSize {
+				break
+			}
+			selectedBuilds = append(selectedBuilds, build)
+			// the selected builds take their slots for the next pages
+			runningBuilds = append(runningBuilds, &bean.RunningBuild{CiPipelineId: build.CiPipelineId, AppId: build.AppId, TeamId: build.TeamId})
+		}
+		if len(queuedItems) < impl.config.DispatchBatchSize {
+			break
+		}
+	}
+	if len(selectedBuilds) == 0 {
+		return nil, impl.ciBuildQueueRepository.CommitTx(tx)
+	}
+	selectedIds := make([]int, 0, len(selectedBuilds))
+	claimedBuilds := make([]*bean.ClaimedBuildDto, 0, len(selectedBuilds))
+	for _, build := range selectedBuilds {
+		selectedIds = append(selectedIds, build.Id)
+		build.Status = bean.QueueStatusDispatching
+		claimedBuilds = append(claimedBuilds, &bean.ClaimedBuildDto{
+			QueuedBuildDto: build,
+			TriggerRequest: []byte(triggerRequests[build.Id]),
+		})
+	}
+	err = impl.ciBuildQueueRepository.ClaimWithTx(tx, selectedIds, impl.hostname, now)
+	if err != nil {
+		impl.logger.Errorw("error in claiming queued builds", "queueIds", selectedIds, "err", err)
+		return nil, err
+	}
+	err = impl.ciBuildQueueRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing claimed builds", "queueIds", selectedIds, "err", err)
+		return nil, err
+	}
+	return claimedBuilds, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) MarkDispatched(id int) error {
+	_, err := impl.ciBuildQueueRepository.UpdateStatus(id, bean.QueueStatusDispatching, bean.QueueStatusDispatched, "", 0)
+	if err != nil {
+		impl.logger.Errorw("error in marking queued build dispatched", "queueId", id, "err", err)
+	}
+	return err
+}
+
+func (impl *CiBuildQueueServiceImpl) MarkFailed(id int, message string) error {
+	_, err := impl.ciBuildQueueRepository.UpdateStatus(id, bean.QueueStatusDispatching, bean.QueueStatusFailed, message, 0)
+	if err != nil {
+		impl.logger.Errorw("error in marking queued build failed", "queueId", id, "err", err)
+	}
+	return err
+}
+
+func (impl *CiBuildQueueServiceImpl) CancelQueuedBuild(ciWorkflowId int, userId int32) (bool, error) {
+	item, err := impl.ciBuildQueueRepository.FindByCiWorkflowId(ciWorkflowId)
+	if err == pg.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching queued build", "ciWorkflowId", ciWorkflowId, "err", err)
+		return false, err
+	}
+	cancelled, err := impl.ciBuildQueueRepository.UpdateStatus(item.Id, bean.QueueStatusQueued, bean.QueueStatusCancelled, bean.CancelledInQueueMessage, userId)
+	if err != nil {
+		impl.logger.Errorw("error in cancelling queued build", "queueId", item.Id, "err", err)
+		return false, err
+	}
+	return cancelled, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) GetQueuedBuilds(filter *bean.QueueFilter) ([]*bean.QueuedBuildDto, error) {
+	items, err := impl.ciBuildQueueRepository.FindAllQueued()
+	if err != nil {
+		impl.logger.Errorw("error in fetching queued builds", "err", err)
+		return nil, err
+	}
+	queuedBuilds := make([]*bean.QueuedBuildDto, 0)
+	for index, item := range items {
+		if filter != nil && ((filter.AppId > 0 && item.AppId != filter.AppId) ||
+			(filter.CiPipelineId > 0 && item.CiPipelineId != filter.CiPipelineId)) {
+			continue
+		}
+		queuedBuild := adapter.BuildQueuedBuildDto(item)
+		// position is in the dispatch order of the whole queue
+		queuedBuild.Position = index + 1
+		queuedBuilds = append(queuedBuilds, queuedBuild)
+	}
+	return queuedBuilds, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) GetQueuedBuildByCiWorkflowId(ciWorkflowId int) (*bean.QueuedBuildDto, error) {
+	item, err := impl.ciBuildQueueRepository.FindByCiWorkflowId(ciWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching queued build", "ciWorkflowId", ciWorkflowId, "err", err)
+		return nil, err
+	}
+	queuedBuild := adapter.BuildQueuedBuildDto(item)
+	if queuedBuild.Status != bean.QueueStatusQueued {
+		return queuedBuild, nil
+	}
+	queuedItems, err := impl.ciBuildQueueRepository.FindAllQueued()
+	if err != nil {
+		impl.logger.Errorw("error in fetching queued builds", "err", err)
+		return nil, err
+	}
+	for index, queuedItem := range queuedItems {
+		if queuedItem.Id == item.Id {
+			queuedBuild.Position = index + 1
+			break
+		}
+	}
+	return queuedBuild, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) GetLimits() ([]*bean.BuildQueueLimitDto, error) {
+	limits, err := impl.ciBuildQueueLimitRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching build queue limits", "err", err)
+		return nil, err
+	}
+	limitDtos := make([]*bean.BuildQueueLimitDto, 0, len(limits))
+	for _, limit := range limits {
+		limitDtos = append(limitDtos, adapter.BuildQueueLimitDto(limit))
+	}
+	return limitDtos, nil
+}
+
+func (impl *CiBuildQueueServiceImpl) SaveLimit(request *bean.BuildQueueLimitDto) (*bean.BuildQueueLimitDto, error) {
+	if !request.ScopeType.IsValid() {
+		errMsg := fmt.Sprintf("invalid scope type %s", request.ScopeType)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	if request.ScopeType == bean.LimitScopeGlobal {
+		request.ScopeId = 0
+	} else if request.ScopeId <= 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "scope id is required", "scope id is required for non global scopes")
+	}
+	limit, err := impl.ciBuildQueueLimitRepository.FindActiveByScope(request.ScopeType.String(), request.ScopeId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching build queue limit", "scopeType", request.ScopeType, "scopeId", request.ScopeId, "err", err)
+		return nil, err
+	}
+	isNew := err == pg.ErrNoRows
+	if isNew {
+		limit = &repository.CiBuildQueueLimit{
+			ScopeType: request.ScopeType.String(),
+			ScopeId:   request.ScopeId,
+			Active:    true,
+			AuditLog:  sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		limit.UpdateAuditLog(request.UserId)
+	}
+	limit.MaxConcurrentBuilds = request.MaxConcurrentBuilds
+	limit.CancelSuperseded = request.CancelSuperseded
+	if isNew {
+		err = impl.ciBuildQueueLimitRepository.Save(limit)
+	} else {
+		err = impl.ciBuildQueueLimitRepository.Update(limit)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving build queue limit", "scopeType", request.ScopeType, "scopeId", request.ScopeId, "err", err)
+		return nil, err
+	}
+	return adapter.BuildQueueLimitDto(limit), nil
+}
+
+func (impl *CiBuildQueueServiceImpl) DeleteLimit(id int, userId int32) error {
+	limit, err := impl.ciBuildQueueLimitRepository.FindById(id)
+	if err == pg.ErrNoRows {
+		return util.NewApiError(http.StatusNotFound, "build queue limit not found", "build queue limit not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching build queue limit", "id", id, "err", err)
+		return err
+	}
+	limit.Active = false
+	limit.UpdateAuditLog(userId)
+	err = impl.ciBuildQueueLimitRepository.Update(limit)
+	if err != nil {
+		impl.logger.Errorw("error in deleting build queue limit", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
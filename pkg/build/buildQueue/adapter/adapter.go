@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util"
+)
+
+func GetCiBuildQueueDbObj(request *bean.EnqueueRequest) *repository.CiBuildQueue {
+	return &repository.CiBuildQueue{
+		CiWorkflowId:   request.CiWorkflowId,
+		CiPipelineId:   request.CiPipelineId,
+		AppId:          request.AppId,
+		TeamId:         request.TeamId,
+		Branch:         request.Branch,
+		TriggerType:    request.TriggerType.String(),
+		Priority:       bean.GetPriorityForTriggerType(request.TriggerType),
+		Status:         bean.QueueStatusQueued.String(),
+		TriggerRequest: string(request.TriggerRequest),
+		TriggeredBy:    request.TriggeredBy,
+		AuditLog:       sql.NewDefaultAuditLog(request.TriggeredBy),
+	}
+}
+
+func BuildQueuedBuildDto(item *repository.CiBuildQueue) *bean.QueuedBuildDto {
+	return &bean.QueuedBuildDto{
+		Id:            item.Id,
+		CiWorkflowId:  item.CiWorkflowId,
+		CiPipelineId:  item.CiPipelineId,
+		AppId:         item.AppId,
+		TeamId:        item.TeamId,
+		Branch:        item.Branch,
+		TriggerType:   workflow.CiTriggerType(item.TriggerType),
+		Priority:      item.Priority,
+		Status:        bean.QueueStatus(item.Status),
+		StatusMessage: item.StatusMessage,
+		TriggeredBy:   item.TriggeredBy,
+		QueuedOn:      item.CreatedOn,
+		DispatchedOn:  util.GetTimePtr(item.DispatchedOn),
+	}
+}
+
+func BuildQueueLimitDto(limit *repository.CiBuildQueueLimit) *bean.BuildQueueLimitDto {
+	return &bean.BuildQueueLimitDto{
+		Id:                  limit.Id,
+		ScopeType:           bean.LimitScopeType(limit.ScopeType),
+		ScopeId:             limit.ScopeId,
+		MaxConcurrentBuilds: limit.MaxConcurrentBuilds,
+		CancelSuperseded:    limit.CancelSuperseded,
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
+)
+
+// CATEGORY=CI_BUILD_QUEUE
+type CiBuildQueueConfig struct {
+	Enabled                     bool `env:"CI_BUILD_QUEUE_ENABLED" envDefault:"false" description:"Queue ci triggers and submit them only when the configured concurrency limits allow"`
+	GlobalMaxConcurrentBuilds   int  `env:"CI_BUILD_QUEUE_GLOBAL_MAX_CONCURRENT_BUILDS" envDefault:"0" description:"Max builds running at a time across all the ci pipelines, 0 means unlimited"`
+	ProjectMaxConcurrentBuilds  int  `env:"CI_BUILD_QUEUE_PROJECT_MAX_CONCURRENT_BUILDS" envDefault:"0" description:"Default max builds running at a time in a project, 0 means unlimited"`
+	AppMaxConcurrentBuilds      int  `env:"CI_BUILD_QUEUE_APP_MAX_CONCURRENT_BUILDS" envDefault:"0" description:"Default max builds running at a time in an app, 0 means unlimited"`
+	PipelineMaxConcurrentBuilds int  `env:"CI_BUILD_QUEUE_PIPELINE_MAX_CONCURRENT_BUILDS" envDefault:"0" description:"Default max builds running at a time for a ci pipeline, 0 means unlimited"`
+	CancelSupersededBuilds      bool `env:"CI_BUILD_QUEUE_CANCEL_SUPERSEDED_BUILDS" envDefault:"false" description:"Cancel queued and running builds of a branch when a newer build of the same branch is triggered, can be overridden per scope"`
+	DispatchBatchSize           int  `env:"CI_BUILD_QUEUE_DISPATCH_BATCH_SIZE" envDefault:"100" description:"Max queued builds claimed in a single dispatch run, the queue is also read in pages of this size until the free slots are filled"`
+	StaleDispatchTimeoutSecs    int  `env:"CI_BUILD_QUEUE_STALE_DISPATCH_TIMEOUT_SECS" envDefault:"300" description:"Claimed builds not started within this duration, e.g. when the replica restarted, are queued again"`
+	RunningBuildLookbackHours   int  `env:"CI_BUILD_QUEUE_RUNNING_BUILD_LOOKBACK_HOURS" envDefault:"24" description:"Non terminal builds started before this many hours are not counted against the concurrency limits"`
+}
+
+type QueueStatus string
+
+const (
+	QueueStatusQueued      QueueStatus = "QUEUED"
+	QueueStatusDispatching QueueStatus = "DISPATCHING"
+	QueueStatusDispatched  QueueStatus = "DISPATCHED"
+	QueueStatusCancelled   QueueStatus = "CANCELLED"
+	QueueStatusSuperseded  QueueStatus = "SUPERSEDED"
+	QueueStatusFailed      QueueStatus = "FAILED"
+)
+
+func (s QueueStatus) String() string {
+	return string(s)
+}
+
+type LimitScopeType string
+
+const (
+	LimitScopeGlobal     LimitScopeType = "GLOBAL"
+	LimitScopeProject    LimitScopeType = "PROJECT"
+	LimitScopeApp        LimitScopeType = "APP"
+	LimitScopeCiPipeline LimitScopeType = "CI_PIPELINE"
+)
+
+func (s LimitScopeType) String() string {
+	return string(s)
+}
+
+func (s LimitScopeType) IsValid() bool {
+	switch s {
+	case LimitScopeGlobal, LimitScopeProject, LimitScopeApp, LimitScopeCiPipeline:
+		return true
+	}
+	return false
+}
+
+// queued builds with a higher priority are dispatched first, builds with the same priority in the order they were queued
+const (
+	PriorityWebhook   = 0
+	PriorityScheduled = 10
+	PriorityManual    = 20
+)
+
+// GetPriorityForTriggerType gives manual triggers precedence over scheduled and webhook triggers
+func GetPriorityForTriggerType(triggerType workflow.CiTriggerType) int {
+	switch triggerType {
+	case workflow.CiTriggerTypeWebhook:
+		return PriorityWebhook
+	case workflow.CiTriggerTypeScheduled:
+		return PriorityScheduled
+	default:
+		return PriorityManual
+	}
+}
+
+const (
+	QueueLockKey             = "ci-build-queue"
+	SupersededMessageFormat  = "superseded by a newer build of branch %s, ci workflow %d"
+	CancelledInQueueMessage  = "cancelled while waiting in the build queue"
+	StaleDispatchRequeuedMsg = "re-queued as the claimed build was not started"
+	// CiWorkflowErrorStatus is the terminal argo status of a ci workflow not covered in the cd terminal statuses
+	CiWorkflowErrorStatus = "Error"
+)
+
+// EnqueueRequest holds the details of a ci trigger waiting for a free build slot
+type EnqueueRequest struct {
+	CiWorkflowId   int
+	CiPipelineId   int
+	AppId          int
+	TeamId         int
+	Branch         string
+	TriggerType    workflow.CiTriggerType
+	TriggeredBy    int32
+	TriggerRequest []byte
+}
+
+// QueuedBuildDto is a ci trigger in the build queue, position is 1 based
+// and is only set while the build is waiting in the queue
+type QueuedBuildDto struct {
+	Id            int                    `json:"id"`
+	CiWorkflowId  int                    `json:"ciWorkflowId"`
+	CiPipelineId  int                    `json:"ciPipelineId"`
+	AppId         int                    `json:"appId"`
+	TeamId        int                    `json:"teamId"`
+	Branch        string                 `json:"branch,omitempty"`
+	TriggerType   workflow.CiTriggerType `json:"triggerType"`
+	Priority      int                    `json:"priority"`
+	Status        QueueStatus            `json:"status"`
+	StatusMessage string                 `json:"statusMessage,omitempty"`
+	Position      int                    `json:"position,omitempty"`
+	TriggeredBy   int32                  `json:"triggeredBy"`
+	QueuedOn      time.Time              `json:"queuedOn"`
+	DispatchedOn  *time.Time             `json:"dispatchedOn,omitempty"`
+}
+
+// ClaimedBuildDto is a queued build claimed by this replica for dispatch
+type ClaimedBuildDto struct {
+	*QueuedBuildDto
+	TriggerRequest []byte
+}
+
+// RunningBuild is a build occupying a slot against the concurrency limits
+type RunningBuild struct {
+	CiPipelineId int `sql:"ci_pipeline_id"`
+	AppId        int `sql:"app_id"`
+	TeamId       int `sql:"team_id"`
+}
+
+// BuildQueueLimitDto overrides the concurrency limit of a scope, ScopeId is 0 for the global scope
+type BuildQueueLimitDto struct {
+	Id                  int            `json:"id"`
+	ScopeType           LimitScopeType `json:"scopeType" validate:"required"`
+	ScopeId             int            `json:"scopeId" validate:"min=0"`
+	MaxConcurrentBuilds int            `json:"maxConcurrentBuilds" validate:"min=0"`
+	CancelSuperseded    *bool          `json:"cancelSuperseded,omitempty"`
+	UserId              int32          `json:"-"`
+}
+
+// QueueFilter filters the queued builds, zero valued fields are not applied
+type QueueFilter struct {
+	AppId        int
+	CiPipelineId int
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiBuildQueueLimit struct {
+	tableName           struct{} `sql:"ci_build_queue_limit" pg:",discard_unknown_columns"`
+	Id                  int      `sql:"id,pk"`
+	ScopeType           string   `sql:"scope_type,notnull"`
+	ScopeId             int      `sql:"scope_id,notnull"`
+	MaxConcurrentBuilds int      `sql:"max_concurrent_builds,notnull"`
+	CancelSuperseded    *bool    `sql:"cancel_superseded"`
+	Active              bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type CiBuildQueueLimitRepository interface {
+	Save(limit *CiBuildQueueLimit) error
+	Update(limit *CiBuildQueueLimit) error
+	FindById(id int) (*CiBuildQueueLimit, error)
+	FindActiveByScope(scopeType string, scopeId int) (*CiBuildQueueLimit, error)
+	FindAllActive() ([]*CiBuildQueueLimit, error)
+}
+
+type CiBuildQueueLimitRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCiBuildQueueLimitRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CiBuildQueueLimitRepositoryImpl {
+	return &CiBuildQueueLimitRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CiBuildQueueLimitRepositoryImpl) Save(limit *CiBuildQueueLimit) error {
+	return impl.dbConnection.Insert(limit)
+}
+
+func (impl *CiBuildQueueLimitRepositoryImpl) Update(limit *CiBuildQueueLimit) error {
+	return impl.dbConnection.Update(limit)
+}
+
+func (impl *CiBuildQueueLimitRepositoryImpl) FindById(id int) (*CiBuildQueueLimit, error) {
+	limit := &CiBuildQueueLimit{}
+	err := impl.dbConnection.Model(limit).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return limit, err
+}
+
+func (impl *CiBuildQueueLimitRepositoryImpl) FindActiveByScope(scopeType string, scopeId int) (*CiBuildQueueLimit, error) {
+	limit := &CiBuildQueueLimit{}
+	err := impl.dbConnection.Model(limit).
+		Where("scope_type = ?", scopeType).
+		Where("scope_id = ?", scopeId).
+		Where("active = ?", true).
+		Select()
+	return limit, err
+}
+
+func (impl *CiBuildQueueLimitRepositoryImpl) FindAllActive() ([]*CiBuildQueueLimit, error) {
+	var limits []*CiBuildQueueLimit
+	err := impl.dbConnection.Model(&limits).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return limits, err
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiBuildQueue struct {
+	tableName      struct{}  `sql:"ci_build_queue" pg:",discard_unknown_columns"`
+	Id             int       `sql:"id,pk"`
+	CiWorkflowId   int       `sql:"ci_workflow_id,notnull"`
+	CiPipelineId   int       `sql:"ci_pipeline_id,notnull"`
+	AppId          int       `sql:"app_id,notnull"`
+	TeamId         int       `sql:"team_id,notnull"`
+	Branch         string    `sql:"branch"`
+	TriggerType    string    `sql:"trigger_type,notnull"`
+	Priority       int       `sql:"priority,notnull"`
+	Status         string    `sql:"status,notnull"`
+	StatusMessage  string    `sql:"status_message"`
+	TriggerRequest string    `sql:"trigger_request,notnull"`
+	TriggeredBy    int32     `sql:"triggered_by,notnull"`
+	DispatchedOn   time.Time `sql:"dispatched_on"`
+	DispatchedBy   string    `sql:"dispatched_by"`
+	sql.AuditLog
+}
+
+type CiBuildQueueRepository interface {
+	sql.TransactionWrapper
+	Save(item *CiBuildQueue) error
+	FindById(id int) (*CiBuildQueue, error)
+	FindByCiWorkflowId(ciWorkflowId int) (*CiBuildQueue, error)
+	// FindAllQueued returns the queued builds in dispatch order
+	FindAllQueued() ([]*CiBuildQueue, error)
+	// FindQueuedWithTx returns a page of the queued builds in dispatch order
+	FindQueuedWithTx(tx *pg.Tx, offset, limit int) ([]*CiBuildQueue, error)
+	FindQueuedByCiPipelineIdAndBranch(ciPipelineId int, branch string, excludedId int) ([]*CiBuildQueue, error)
+	// FindRunningBuildsWithTx returns the builds holding a slot, non terminal ci workflows started after startedAfter
+	// and claimed builds whose ci workflow is still waiting to be submitted
+	FindRunningBuildsWithTx(tx *pg.Tx, startedAfter time.Time) ([]*bean.RunningBuild, error)
+	// LockQueueWithTx serializes the dispatch across orchestrator replicas, released with the transaction
+	LockQueueWithTx(tx *pg.Tx) error
+	ClaimWithTx(tx *pg.Tx, ids []int, dispatchedBy string, dispatchedOn time.Time) error
+	RequeueStaleDispatchingWithTx(tx *pg.Tx, claimedBefore time.Time) (int, error)
+	// UpdateStatus changes the status only if it still holds fromStatus
+	UpdateStatus(id int, fromStatus, toStatus bean.QueueStatus, message string, userId int32) (bool, error)
+}
+
+type CiBuildQueueRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewCiBuildQueueRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	TransactionUtilImpl *sql.TransactionUtilImpl) *CiBuildQueueRepositoryImpl {
+	return &CiBuildQueueRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: TransactionUtilImpl,
+	}
+}
+
+func (impl *CiBuildQueueRepositoryImpl) Save(item *CiBuildQueue) error {
+	return impl.dbConnection.Insert(item)
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindById(id int) (*CiBuildQueue, error) {
+	item := &CiBuildQueue{}
+	err := impl.dbConnection.Model(item).
+		Where("id = ?", id).
+		Select()
+	return item, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindByCiWorkflowId(ciWorkflowId int) (*CiBuildQueue, error) {
+	item := &CiBuildQueue{}
+	err := impl.dbConnection.Model(item).
+		Where("ci_workflow_id = ?", ciWorkflowId).
+		Select()
+	return item, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindAllQueued() ([]*CiBuildQueue, error) {
+	var items []*CiBuildQueue
+	err := impl.dbConnection.Model(&items).
+		Column("id", "ci_workflow_id", "ci_pipeline_id", "app_id", "team_id", "branch", "trigger_type",
+			"priority", "status", "status_message", "triggered_by", "created_on").
+		Where("status = ?", bean.QueueStatusQueued).
+		Order("priority DESC").
+		Order("id ASC").
+		Select()
+	return items, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindQueuedWithTx(tx *pg.Tx, offset, limit int) ([]*CiBuildQueue, error) {
+	var items []*CiBuildQueue
+	err := tx.Model(&items).
+		Where("status = ?", bean.QueueStatusQueued).
+		Order("priority DESC").
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Select()
+	return items, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindQueuedByCiPipelineIdAndBranch(ciPipelineId int, branch string, excludedId int) ([]*CiBuildQueue, error) {
+	var items []*CiBuildQueue
+	err := impl.dbConnection.Model(&items).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Where("branch = ?", branch).
+		Where("status = ?", bean.QueueStatusQueued).
+		Where("id <> ?", excludedId).
+		Select()
+	return items, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) FindRunningBuildsWithTx(tx *pg.Tx, startedAfter time.Time) ([]*bean.RunningBuild, error) {
+	var runningBuilds []*bean.RunningBuild
+	query := `SELECT cw.ci_pipeline_id, cp.app_id, a.team_id FROM ci_workflow cw
+		INNER JOIN ci_pipeline cp ON cp.id = cw.ci_pipeline_id
+		INNER JOIN app a ON a.id = cp.app_id
+		WHERE cw.status NOT IN (?) AND cw.started_on > ?
+		UNION ALL
+		SELECT q.ci_pipeline_id, q.app_id, q.team_id FROM ci_build_queue q
+		INNER JOIN ci_workflow cw ON cw.id = q.ci_workflow_id
+		WHERE q.status = ? AND cw.status = ?;`
+	notRunningStatuses := append([]string{cdWorkflow.WorkflowInQueue}, cdWorkflow.WfrTerminalStatusList...)
+	notRunningStatuses = append(notRunningStatuses, bean.CiWorkflowErrorStatus)
+	_, err := tx.Query(&runningBuilds, query, pg.In(notRunningStatuses), startedAfter,
+		bean.QueueStatusDispatching, cdWorkflow.WorkflowInQueue)
+	return runningBuilds, err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) LockQueueWithTx(tx *pg.Tx) error {
+	_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", bean.QueueLockKey)
+	return err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) ClaimWithTx(tx *pg.Tx, ids []int, dispatchedBy string, dispatchedOn time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := tx.Model((*CiBuildQueue)(nil)).
+		Set("status = ?", bean.QueueStatusDispatching).
+		Set("dispatched_by = ?", dispatchedBy).
+		Set("dispatched_on = ?", dispatchedOn).
+		Set("updated_on = ?", dispatchedOn).
+		Where("id IN (?)", pg.In(ids)).
+		Where("status = ?", bean.QueueStatusQueued).
+		Update()
+	return err
+}
+
+func (impl *CiBuildQueueRepositoryImpl) RequeueStaleDispatchingWithTx(tx *pg.Tx, claimedBefore time.Time) (int, error) {
+	res, err := tx.Model((*CiBuildQueue)(nil)).
+		Set("status = ?", bean.QueueStatusQueued).
+		Set("status_message = ?", bean.StaleDispatchRequeuedMsg).
+		Set("updated_on = ?", time.Now()).
+		Where("status = ?", bean.QueueStatusDispatching).
+		Where("dispatched_on < ?", claimedBefore).
+		Where("ci_workflow_id IN (SELECT id FROM ci_workflow WHERE status = ?)", cdWorkflow.WorkflowInQueue).
+		Update()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func (impl *CiBuildQueueRepositoryImpl) UpdateStatus(id int, fromStatus, toStatus bean.QueueStatus, message string, userId int32) (bool, error) {
+	res, err := impl.dbConnection.Model((*CiBuildQueue)(nil)).
+		Set("status = ?", toStatus).
+		Set("status_message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status = ?", fromStatus).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
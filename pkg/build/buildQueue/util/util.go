@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"sort"
+
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	bean2 "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+)
+
+// ConcurrencyLimits resolves the max concurrent builds of each scope, scope overrides
+// take precedence over the defaults and a limit of 0 means unlimited
+type ConcurrencyLimits struct {
+	Global          int
+	DefaultProject  int
+	DefaultApp      int
+	DefaultPipeline int
+	Project         map[int]int
+	App             map[int]int
+	Pipeline        map[int]int
+}
+
+func NewConcurrencyLimits(cfg *bean.CiBuildQueueConfig, overrides []*bean.BuildQueueLimitDto) *ConcurrencyLimits {
+	limits := &ConcurrencyLimits{
+		Global:          cfg.GlobalMaxConcurrentBuilds,
+		DefaultProject:  cfg.ProjectMaxConcurrentBuilds,
+		DefaultApp:      cfg.AppMaxConcurrentBuilds,
+		DefaultPipeline: cfg.PipelineMaxConcurrentBuilds,
+		Project:         make(map[int]int),
+		App:             make(map[int]int),
+		Pipeline:        make(map[int]int),
+	}
+	for _, override := range overrides {
+		switch override.ScopeType {
+		case bean.LimitScopeGlobal:
+			limits.Global = override.MaxConcurrentBuilds
+		case bean.LimitScopeProject:
+			limits.Project[override.ScopeId] = override.MaxConcurrentBuilds
+		case bean.LimitScopeApp:
+			limits.App[override.ScopeId] = override.MaxConcurrentBuilds
+		case bean.LimitScopeCiPipeline:
+			limits.Pipeline[override.ScopeId] = override.MaxConcurrentBuilds
+		}
+	}
+	return limits
+}
+
+func (limits *ConcurrencyLimits) getProjectLimit(teamId int) int {
+	if limit, ok := limits.Project[teamId]; ok {
+		return limit
+	}
+	return limits.DefaultProject
+}
+
+func (limits *ConcurrencyLimits) getAppLimit(appId int) int {
+	if limit, ok := limits.App[appId]; ok {
+		return limit
+	}
+	return limits.DefaultApp
+}
+
+func (limits *ConcurrencyLimits) getPipelineLimit(ciPipelineId int) int {
+	if limit, ok := limits.Pipeline[ciPipelineId]; ok {
+		return limit
+	}
+	return limits.DefaultPipeline
+}
+
+func isWithinLimit(limit, running int) bool {
+	return limit <= 0 || running < limit
+}
+
+// SelectDispatchableBuilds walks the queued builds in dispatch order and picks the ones which fit
+// in the free slots, a build blocked by its pipeline, app or project limit does not block builds of other scopes
+func SelectDispatchableBuilds(queued []*bean.QueuedBuildDto, running []*bean.RunningBuild, limits *ConcurrencyLimits) []*bean.QueuedBuildDto {
+	globalCount := len(running)
+	projectCount := make(map[int]int)
+	appCount := make(map[int]int)
+	pipelineCount := make(map[int]int)
+	for _, build := range running {
+		projectCount[build.TeamId]++
+		appCount[build.AppId]++
+		pipelineCount[build.CiPipelineId]++
+	}
+	selected := make([]*bean.QueuedBuildDto, 0)
+	for _, build := range queued {
+		if !isWithinLimit(limits.Global, globalCount) {
+			break
+		}
+		if !isWithinLimit(limits.getProjectLimit(build.TeamId), projectCount[build.TeamId]) ||
+			!isWithinLimit(limits.getAppLimit(build.AppId), appCount[build.AppId]) ||
+			!isWithinLimit(limits.getPipelineLimit(build.CiPipelineId), pipelineCount[build.CiPipelineId]) {
+			continue
+		}
+		selected = append(selected, build)
+		globalCount++
+		projectCount[build.TeamId]++
+		appCount[build.AppId]++
+		pipelineCount[build.CiPipelineId]++
+	}
+	return selected
+}
+
+// ResolveCancelSuperseded returns the most specific cancel superseded setting among the
+// pipeline, app and project overrides, falling back to the default
+func ResolveCancelSuperseded(defaultValue bool, ciPipelineId, appId, teamId int, overrides []*bean.BuildQueueLimitDto) bool {
+	resolved := map[bean.LimitScopeType]*bool{}
+	for _, override := range overrides {
+		if override.CancelSuperseded == nil {
+			continue
+		}
+		switch {
+		case override.ScopeType == bean.LimitScopeCiPipeline && override.ScopeId == ciPipelineId,
+			override.ScopeType == bean.LimitScopeApp && override.ScopeId == appId,
+			override.ScopeType == bean.LimitScopeProject && override.ScopeId == teamId,
+			override.ScopeType == bean.LimitScopeGlobal:
+			resolved[override.ScopeType] = override.CancelSuperseded
+		}
+	}
+	for _, scopeType := range []bean.LimitScopeType{bean.LimitScopeCiPipeline, bean.LimitScopeApp, bean.LimitScopeProject, bean.LimitScopeGlobal} {
+		if value, ok := resolved[scopeType]; ok {
+			return *value
+		}
+	}
+	return defaultValue
+}
+
+// GetBranchFromGitTriggers returns the branch being built from the first material which builds a branch,
+// for pull request webhooks it is the source branch of the pull request
+func GetBranchFromGitTriggers(gitTriggers map[int]pipelineConfig.GitCommit) string {
+	materialIds := make([]int, 0, len(gitTriggers))
+	for materialId := range gitTriggers {
+		materialIds = append(materialIds, materialId)
+	}
+	sort.Ints(materialIds)
+	for _, materialId := range materialIds {
+		gitTrigger := gitTriggers[materialId]
+		switch gitTrigger.CiConfigureSourceType {
+		case constants.SOURCE_TYPE_BRANCH_FIXED:
+			if len(gitTrigger.CiConfigureSourceValue) > 0 {
+				return gitTrigger.CiConfigureSourceValue
+			}
+		case constants.SOURCE_TYPE_WEBHOOK:
+			if branch := gitTrigger.WebhookData.Data[bean2.WEBHOOK_SELECTOR_SOURCE_BRANCH_NAME_NAME]; len(branch) > 0 {
+				return branch
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+)
+
+func queuedBuild(id, ciPipelineId, appId, teamId int) *bean.QueuedBuildDto {
+	return &bean.QueuedBuildDto{Id: id, CiPipelineId: ciPipelineId, AppId: appId, TeamId: teamId}
+}
+
+func selectedIds(builds []*bean.QueuedBuildDto) []int {
+	ids := make([]int, 0, len(builds))
+	for _, build := range builds {
+		ids = append(ids, build.Id)
+	}
+	return ids
+}
+
+func TestSelectDispatchableBuilds(t *testing.T) {
+	tests := []struct {
+		name    string
+		queued  []*bean.QueuedBuildDto
+		running []*bean.RunningBuild
+		limits  *ConcurrencyLimits
+		want    []int
+	}{
+		{
+			name:   "unlimited",
+			queued: []*bean.QueuedBuildDto{queuedBuild(1, 1, 1, 1), queuedBuild(2, 1, 1, 1)},
+			limits: &ConcurrencyLimits{},
+			want:   []int{1, 2},
+		},
+		{
+			name:    "global limit reached",
+			queued:  []*bean.QueuedBuildDto{queuedBuild(1, 1, 1, 1)},
+			running: []*bean.RunningBuild{{CiPipelineId: 2, AppId: 2, TeamId: 2}},
+			limits:  &ConcurrencyLimits{Global: 1},
+			want:    []int{},
+		},
+		{
+			name:   "pipeline limit does not block other pipelines",
+			queued: []*bean.QueuedBuildDto{queuedBuild(1, 1, 1, 1), queuedBuild(2, 1, 1, 1), queuedBuild(3, 2, 1, 1)},
+			limits: &ConcurrencyLimits{DefaultPipeline: 1},
+			want:   []int{1, 3},
+		},
+		{
+			name:    "app override over default",
+			queued:  []*bean.QueuedBuildDto{queuedBuild(1, 1, 1, 1), queuedBuild(2, 2, 2, 1)},
+			running: []*bean.RunningBuild{{CiPipelineId: 3, AppId: 1, TeamId: 1}},
+			limits:  &ConcurrencyLimits{DefaultApp: 1, App: map[int]int{1: 2}},
+			want:    []int{1, 2},
+		},
+		{
+			name:    "project limit counts running builds",
+			queued:  []*bean.QueuedBuildDto{queuedBuild(1, 1, 1, 1), queuedBuild(2, 2, 2, 2)},
+			running: []*bean.RunningBuild{{CiPipelineId: 3, AppId: 3, TeamId: 1}},
+			limits:  &ConcurrencyLimits{Project: map[int]int{1: 1}},
+			want:    []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectedIds(SelectDispatchableBuilds(tt.queued, tt.running, tt.limits))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectDispatchableBuilds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCancelSuperseded(t *testing.T) {
+	enabled, disabled := true, false
+	overrides := []*bean.BuildQueueLimitDto{
+		{ScopeType: bean.LimitScopeGlobal, CancelSuperseded: &enabled},
+		{ScopeType: bean.LimitScopeApp, ScopeId: 1, CancelSuperseded: &disabled},
+		{ScopeType: bean.LimitScopeCiPipeline, ScopeId: 10, CancelSuperseded: &enabled},
+		{ScopeType: bean.LimitScopeProject, ScopeId: 5, MaxConcurrentBuilds: 2},
+	}
+	if !ResolveCancelSuperseded(false, 10, 1, 5, overrides) {
+		t.Errorf("pipeline override should take precedence over app override")
+	}
+	if ResolveCancelSuperseded(false, 11, 1, 5, overrides) {
+		t.Errorf("app override should take precedence over global override")
+	}
+	if !ResolveCancelSuperseded(false, 12, 2, 5, overrides) {
+		t.Errorf("override without a cancel superseded setting should be inherited from global")
+	}
+	if !ResolveCancelSuperseded(true, 12, 2, 5, nil) {
+		t.Errorf("default should be used without overrides")
+	}
+}
+
+func TestGetBranchFromGitTriggers(t *testing.T) {
+	gitTriggers := map[int]pipelineConfig.GitCommit{
+		7: {CiConfigureSourceType: constants.SOURCE_TYPE_BRANCH_FIXED, CiConfigureSourceValue: "develop"},
+		3: {CiConfigureSourceType: constants.SOURCE_TYPE_WEBHOOK, WebhookData: pipelineConfig.WebhookData{
+			Data: map[string]string{"source branch name": "feature/login"},
+		}},
+	}
+	if got := GetBranchFromGitTriggers(gitTriggers); got != "feature/login" {
+		t.Errorf("GetBranchFromGitTriggers() = %s, want feature/login", got)
+	}
+	if got := GetBranchFromGitTriggers(nil); got != "" {
+		t.Errorf("GetBranchFromGitTriggers() = %s, want empty", got)
+	}
+}
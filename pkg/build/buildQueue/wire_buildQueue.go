@@ -0,0 +1,15 @@
+package buildQueue
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue/repository"
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	repository.NewCiBuildQueueRepositoryImpl,
+	wire.Bind(new(repository.CiBuildQueueRepository), new(*repository.CiBuildQueueRepositoryImpl)),
+	repository.NewCiBuildQueueLimitRepositoryImpl,
+	wire.Bind(new(repository.CiBuildQueueLimitRepository), new(*repository.CiBuildQueueLimitRepositoryImpl)),
+	NewCiBuildQueueServiceImpl,
+	wire.Bind(new(CiBuildQueueService), new(*CiBuildQueueServiceImpl)),
+)
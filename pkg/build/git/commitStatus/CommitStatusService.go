@@ -0,0 +1,436 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commitStatus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	repository3 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/attributes"
+	attributesBean "github.com/devtron-labs/devtron/pkg/attributes/bean"
+	bean2 "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/publisher"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/repository"
+	materialRepository "github.com/devtron-labs/devtron/pkg/build/git/gitMaterial/repository"
+	"github.com/devtron-labs/devtron/pkg/build/git/gitProvider/read"
+	gitProviderRepository "github.com/devtron-labs/devtron/pkg/build/git/gitProvider/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"go.uber.org/zap"
+)
+
+type CommitStatusService interface {
+	GetConfig(gitProviderId int) (*bean.CommitStatusConfigDto, error)
+	// SaveConfig creates or updates the commit status config of the git provider
+	SaveConfig(request *bean.CommitStatusConfigDto) (*bean.CommitStatusConfigDto, error)
+	DeleteConfig(gitProviderId int, userId int32) error
+
+	// ReportCiWorkflowStatusAsync reports the state of the ci workflow on the commits it built, for the git providers
+	// with ci reporting enabled
+	ReportCiWorkflowStatusAsync(ciWorkflowId int, state bean.State)
+	// ReportDeploymentStatusAsync reports the state of a deployment on the commits of the deployed artifact, for the
+	// git providers with deployment reporting enabled
+	ReportDeploymentStatusAsync(request *bean.DeploymentStatusRequest)
+	// ReportPreviewEnvironmentAsync reports the preview environment of a pull request on its head commit, for the git
+	// providers with deployment reporting enabled
+	ReportPreviewEnvironmentAsync(request *bean.PreviewStatusRequest)
+}
+
+type CommitStatusServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	config                       *bean.CommitStatusConfig
+	commitStatusConfigRepository repository.CommitStatusConfigRepository
+	gitProviderReadService       read.GitProviderReadService
+	materialRepository           materialRepository.MaterialRepository
+	ciWorkflowRepository         pipelineConfig.CiWorkflowRepository
+	ciArtifactRepository         repository3.CiArtifactRepository
+	pipelineRepository           pipelineConfig.PipelineRepository
+	cdWorkflowRepository         pipelineConfig.CdWorkflowRepository
+	attributesService            attributes.AttributesService
+	asyncRunnable                *async.Runnable
+}
+
+func NewCommitStatusServiceImpl(logger *zap.SugaredLogger,
+	commitStatusConfigRepository repository.CommitStatusConfigRepository,
+	gitProviderReadService read.GitProviderReadService,
+	materialRepository materialRepository.MaterialRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	ciArtifactRepository repository3.CiArtifactRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	attributesService attributes.AttributesService,
+	asyncRunnable *async.Runnable) (*CommitStatusServiceImpl, error) {
+	config := &bean.CommitStatusConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing commit status config", "err", err)
+		return nil, err
+	}
+	return &CommitStatusServiceImpl{
+		logger:                       logger,
+		config:                       config,
+		commitStatusConfigRepository: commitStatusConfigRepository,
+		gitProviderReadService:       gitProviderReadService,
+		materialRepository:           materialRepository,
+		ciWorkflowRepository:         ciWorkflowRepository,
+		ciArtifactRepository:         ciArtifactRepository,
+		pipelineRepository:           pipelineRepository,
+		cdWorkflowRepository:         cdWorkflowRepository,
+		attributesService:            attributesService,
+		asyncRunnable:                asyncRunnable,
+	}, nil
+}
+
+// commitRef is a commit built or deployed from a git material
+type commitRef struct {
+	repoUrl string
+	sha     string
+}
+
+func (impl *CommitStatusServiceImpl) GetConfig(gitProviderId int) (*bean.CommitStatusConfigDto, error) {
+	config, err := impl.commitStatusConfigRepository.FindActiveByGitProviderId(gitProviderId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "commit status reporting is not configured for the git provider", "commit status config not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching commit status config", "gitProviderId", gitProviderId, "err", err)
+		return nil, err
+	}
+	return adapter.GetCommitStatusConfigDto(config), nil
+}
+
+func (impl *CommitStatusServiceImpl) SaveConfig(request *bean.CommitStatusConfigDto) (*bean.CommitStatusConfigDto, error) {
+	provider, err := impl.gitProviderReadService.FetchOneGitProvider(strconv.Itoa(request.GitProviderId))
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("git provider %d not found", request.GitProviderId), "git provider not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching git provider", "gitProviderId", request.GitProviderId, "err", err)
+		return nil, err
+	}
+	if provider.AuthMode == constants.AUTH_MODE_SSH || provider.AuthMode == constants.AUTH_MODE_ANONYMOUS {
+		return nil, util.NewApiError(http.StatusBadRequest, "commit statuses are published with the api token or password of the git provider, configure one to enable reporting",
+			fmt.Sprintf("unsupported auth mode %s", provider.AuthMode))
+	}
+	config, err := impl.commitStatusConfigRepository.FindActiveByGitProviderId(request.GitProviderId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching commit status config", "gitProviderId", request.GitProviderId, "err", err)
+		return nil, err
+	}
+	isNew := util.IsErrNoRows(err)
+	if isNew {
+		config = &repository.CommitStatusConfig{
+			GitProviderId: request.GitProviderId,
+			Active:        true,
+			AuditLog:      sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		config.UpdateAuditLog(request.UserId)
+	}
+	config.ProviderType = string(request.ProviderType)
+	config.ApiUrl = strings.TrimSpace(request.ApiUrl)
+	config.ReportCi = request.ReportCi
+	config.ReportDeployment = request.ReportDeployment
+	if isNew {
+		err = impl.commitStatusConfigRepository.Save(config)
+	} else {
+		err = impl.commitStatusConfigRepository.Update(config)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving commit status config", "gitProviderId", request.GitProviderId, "err", err)
+		return nil, err
+	}
+	return adapter.GetCommitStatusConfigDto(config), nil
+}
+
+func (impl *CommitStatusServiceImpl) DeleteConfig(gitProviderId int, userId int32) error {
+	config, err := impl.commitStatusConfigRepository.FindActiveByGitProviderId(gitProviderId)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, "commit status reporting is not configured for the git provider", "commit status config not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching commit status config", "gitProviderId", gitProviderId, "err", err)
+		return err
+	}
+	config.Active = false
+	config.UpdateAuditLog(userId)
+	err = impl.commitStatusConfigRepository.Update(config)
+	if err != nil {
+		impl.logger.Errorw("error in deleting commit status config", "gitProviderId", gitProviderId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CommitStatusServiceImpl) ReportCiWorkflowStatusAsync(ciWorkflowId int, state bean.State) {
+	impl.asyncRunnable.Execute(func() {
+		impl.reportCiWorkflowStatus(ciWorkflowId, state)
+	})
+}
+
+func (impl *CommitStatusServiceImpl) reportCiWorkflowStatus(ciWorkflowId int, state bean.State) {
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(ciWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci workflow for commit status", "ciWorkflowId", ciWorkflowId, "err", err)
+		return
+	}
+	if ciWorkflow.CiPipeline == nil || ciWorkflow.CiPipeline.App == nil {
+		return
+	}
+	var commits []commitRef
+	for _, gitCommit := range ciWorkflow.GitTriggers {
+		sha := gitCommit.Commit
+		if len(sha) == 0 {
+			// pull request builds check out the head commit of the source branch
+			sha = gitCommit.WebhookData.Data[bean2.WEBHOOK_SELECTOR_SOURCE_CHECKOUT_NAME]
+		}
+		commits = append(commits, commitRef{repoUrl: gitCommit.GitRepoUrl, sha: sha})
+	}
+	appId := ciWorkflow.CiPipeline.AppId
+	status := &bean.CommitStatus{
+		Context:     fmt.Sprintf("%s/ci/%s/%s", impl.config.ContextPrefix, ciWorkflow.CiPipeline.App.AppName, ciWorkflow.CiPipeline.Name),
+		State:       state,
+		Description: ciDescription(state),
+		TargetUrl:   impl.deepLink(fmt.Sprintf("/dashboard/app/%d/ci-details/%d/%d/artifacts", appId, ciWorkflow.CiPipelineId, ciWorkflow.Id)),
+	}
+	impl.publish(appId, commits, status, func(config *repository.CommitStatusConfig) bool {
+		return config.ReportCi
+	})
+}
+
+func (impl *CommitStatusServiceImpl) ReportDeploymentStatusAsync(request *bean.DeploymentStatusRequest) {
+	impl.asyncRunnable.Execute(func() {
+		impl.reportDeploymentStatus(request)
+	})
+}
+
+func (impl *CommitStatusServiceImpl) reportDeploymentStatus(request *bean.DeploymentStatusRequest) {
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline for commit status", "pipelineId", request.PipelineId, "err", err)
+		return
+	}
+	artifact, err := impl.ciArtifactRepository.Get(request.CiArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact for commit status", "ciArtifactId", request.CiArtifactId, "err", err)
+		return
+	}
+	materialInfo, err := artifact.ParseMaterialInfo()
+	if err != nil {
+		impl.logger.Debugw("no commits to report deployment status on", "ciArtifactId", request.CiArtifactId, "err", err)
+		return
+	}
+	var commits []commitRef
+	for repoUrl, sha := range materialInfo {
+		commits = append(commits, commitRef{repoUrl: repoUrl, sha: sha})
+	}
+	wfrId := request.CdWorkflowRunnerId
+	if wfrId == 0 && request.CdWorkflowId > 0 {
+		wfr, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(context.Background(), request.CdWorkflowId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+		if err != nil {
+			impl.logger.Errorw("error in fetching deployment runner for commit status", "cdWorkflowId", request.CdWorkflowId, "err", err)
+		} else {
+			wfrId = wfr.Id
+		}
+	}
+	targetUrl := fmt.Sprintf("/dashboard/app/%d/details/%d", request.AppId, request.EnvId)
+	if wfrId > 0 {
+		targetUrl = fmt.Sprintf("/dashboard/app/%d/cd-details/%d/%d/%d", request.AppId, request.EnvId, request.PipelineId, wfrId)
+	}
+	envName := pipeline.Environment.Name
+	status := &bean.CommitStatus{
+		Context:     fmt.Sprintf("%s/deploy/%s/%s", impl.config.ContextPrefix, pipeline.App.AppName, envName),
+		State:       request.State,
+		Description: deploymentDescription(request.State, envName),
+		TargetUrl:   impl.deepLink(targetUrl),
+	}
+	impl.publish(request.AppId, commits, status, func(config *repository.CommitStatusConfig) bool {
+		return config.ReportDeployment
+	})
+}
+
+func (impl *CommitStatusServiceImpl) ReportPreviewEnvironmentAsync(request *bean.PreviewStatusRequest) {
+	impl.asyncRunnable.Execute(func() {
+		status := &bean.CommitStatus{
+			Context:     fmt.Sprintf("%s/preview/%s", impl.config.ContextPrefix, request.AppName),
+			State:       request.State,
+			Description: request.Description,
+			TargetUrl:   request.TargetUrl,
+		}
+		commits := []commitRef{{repoUrl: request.RepoUrl, sha: request.Sha}}
+		impl.publish(request.AppId, commits, status, func(config *repository.CommitStatusConfig) bool {
+			return config.ReportDeployment
+		})
+	})
+}
+
+// publish publishes the status on each commit whose git material belongs to a git provider with reporting enabled
+func (impl *CommitStatusServiceImpl) publish(appId int, commits []commitRef, status *bean.CommitStatus,
+	isEnabled func(config *repository.CommitStatusConfig) bool) {
+	if len(commits) == 0 {
+		return
+	}
+	materials, err := impl.materialRepository.FindByAppId(appId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching git materials for commit status", "appId", appId, "err", err)
+		return
+	}
+	materialByUrl := make(map[string]*materialRepository.GitMaterial, len(materials))
+	gitProviderIds := make([]int, 0, len(materials))
+	for _, material := range materials {
+		materialByUrl[strings.TrimSpace(material.Url)] = material
+		gitProviderIds = append(gitProviderIds, material.GitProviderId)
+	}
+	configs, err := impl.commitStatusConfigRepository.FindActiveByGitProviderIds(gitProviderIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching commit status configs", "gitProviderIds", gitProviderIds, "err", err)
+		return
+	}
+	configByProviderId := make(map[int]*repository.CommitStatusConfig, len(configs))
+	for _, config := range configs {
+		if isEnabled(config) {
+			configByProviderId[config.GitProviderId] = config
+		}
+	}
+	if len(configByProviderId) == 0 {
+		return
+	}
+	for _, commit := range commits {
+		material, ok := materialByUrl[strings.TrimSpace(commit.repoUrl)]
+		if !ok || material.GitProvider == nil || len(commit.sha) == 0 {
+			continue
+		}
+		config, ok := configByProviderId[material.GitProviderId]
+		if !ok {
+			continue
+		}
+		commitStatus := *status
+		commitStatus.Sha = commit.sha
+		err = impl.publishCommitStatus(config, material.GitProvider, commit.repoUrl, &commitStatus)
+		if err != nil {
+			impl.logger.Errorw("error in publishing commit status", "repoUrl", commit.repoUrl, "sha", commit.sha, "context", status.Context, "state", status.State, "err", err)
+			continue
+		}
+		impl.logger.Debugw("published commit status", "repoUrl", commit.repoUrl, "sha", commit.sha, "context", status.Context, "state", status.State)
+	}
+}
+
+func (impl *CommitStatusServiceImpl) publishCommitStatus(config *repository.CommitStatusConfig, provider *gitProviderRepository.GitProvider,
+	repoUrl string, status *bean.CommitStatus) error {
+	repo, err := publisher.ParseRepositoryUrl(repoUrl)
+	if err != nil {
+		return err
+	}
+	target := &publisher.Target{
+		ProviderType: bean.ProviderType(config.ProviderType),
+		ApiUrl:       config.ApiUrl,
+		Repository:   repo,
+	}
+	switch provider.AuthMode {
+	case constants.AUTH_MODE_ACCESS_TOKEN:
+		target.Token = provider.AccessToken.String()
+	case constants.AUTH_MODE_USERNAME_PASSWORD:
+		target.Username = provider.UserName
+		target.Token = provider.Password.String()
+		if target.ProviderType != bean.ProviderTypeBitbucketCloud && target.ProviderType != bean.ProviderTypeBitbucketServer {
+			// the password of the other providers is a personal access token
+			target.Username = ""
+		}
+	default:
+		return fmt.Errorf("git provider %s has no api credentials", provider.Name)
+	}
+	client, err := impl.getHttpClient(provider)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(impl.config.RequestTimeoutSecs)*time.Second)
+	defer cancel()
+	return publisher.NewPublisher(client).Publish(ctx, target, status)
+}
+
+// getHttpClient trusts the ca and presents the client certificate configured on the git provider
+func (impl *CommitStatusServiceImpl) getHttpClient(provider *gitProviderRepository.GitProvider) (*http.Client, error) {
+	if !provider.EnableTLSVerification || (len(provider.CaCert) == 0 && len(provider.TlsCert) == 0) {
+		return http.DefaultClient, nil
+	}
+	tlsConfig := &tls.Config{}
+	if len(provider.CaCert) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(provider.CaCert)) {
+			return nil, fmt.Errorf("invalid ca certificate of git provider %s", provider.Name)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if len(provider.TlsCert) > 0 && len(provider.TlsKey) > 0 {
+		certificate, err := tls.X509KeyPair([]byte(provider.TlsCert), []byte(provider.TlsKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// deepLink prefixes the dashboard path with the host url of devtron
+func (impl *CommitStatusServiceImpl) deepLink(path string) string {
+	hostUrl, err := impl.attributesService.GetByKey(attributesBean.HostUrlKey)
+	if err != nil || hostUrl == nil {
+		impl.logger.Warnw("host url is not configured, commit status links are relative", "err", err)
+		return path
+	}
+	return strings.TrimSuffix(hostUrl.Value, "/") + path
+}
+
+func ciDescription(state bean.State) string {
+	switch state {
+	case bean.StateSuccess:
+		return "Build succeeded"
+	case bean.StateFailure:
+		return "Build failed"
+	case bean.StateError:
+		return "Build aborted"
+	}
+	return "Build in progress"
+}
+
+func deploymentDescription(state bean.State, envName string) string {
+	switch state {
+	case bean.StateSuccess:
+		return fmt.Sprintf("Deployed to %s", envName)
+	case bean.StateFailure:
+		return fmt.Sprintf("Deployment to %s failed", envName)
+	case bean.StateError:
+		return fmt.Sprintf("Deployment to %s aborted", envName)
+	}
+	return fmt.Sprintf("Deploying to %s", envName)
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/repository"
+)
+
+func GetCommitStatusConfigDto(config *repository.CommitStatusConfig) *bean.CommitStatusConfigDto {
+	return &bean.CommitStatusConfigDto{
+		Id:               config.Id,
+		GitProviderId:    config.GitProviderId,
+		ProviderType:     bean.ProviderType(config.ProviderType),
+		ApiUrl:           config.ApiUrl,
+		ReportCi:         config.ReportCi,
+		ReportDeployment: config.ReportDeployment,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+// CATEGORY=COMMIT_STATUS
+type CommitStatusConfig struct {
+	RequestTimeoutSecs int    `env:"COMMIT_STATUS_REQUEST_TIMEOUT_SECS" envDefault:"10" description:"Timeout in seconds for publishing a commit status to the git provider"`
+	ContextPrefix      string `env:"COMMIT_STATUS_CONTEXT_PREFIX" envDefault:"devtron" description:"Prefix of the status context reported on commits, branch protection rules refer to the status by its context"`
+}
+
+type ProviderType string
+
+const (
+	ProviderTypeGithub          ProviderType = "GITHUB"
+	ProviderTypeGitlab          ProviderType = "GITLAB"
+	ProviderTypeBitbucketCloud  ProviderType = "BITBUCKET_CLOUD"
+	ProviderTypeBitbucketServer ProviderType = "BITBUCKET_SERVER"
+	ProviderTypeAzureDevops     ProviderType = "AZURE_DEVOPS"
+)
+
+type State string
+
+const (
+	StatePending State = "PENDING"
+	StateSuccess State = "SUCCESS"
+	StateFailure State = "FAILURE"
+	// StateError is reported when the workflow could not run, e.g. it was aborted
+	StateError State = "ERROR"
+)
+
+// CommitStatus is the status of a commit published to its git provider
+type CommitStatus struct {
+	Sha string
+	// Context identifies the status on the commit, a status with the same context replaces the previous one
+	Context     string
+	State       State
+	Description string
+	TargetUrl   string
+}
+
+// CommitStatusConfigDto configures the commit statuses reported to the repositories of a git provider
+type CommitStatusConfigDto struct {
+	Id            int          `json:"id"`
+	GitProviderId int          `json:"gitProviderId"`
+	ProviderType  ProviderType `json:"providerType" validate:"oneof=GITHUB GITLAB BITBUCKET_CLOUD BITBUCKET_SERVER AZURE_DEVOPS"`
+	// ApiUrl overrides the api url derived from the repository url, for providers served behind a custom path
+	ApiUrl           string `json:"apiUrl,omitempty" validate:"omitempty,url"`
+	ReportCi         bool   `json:"reportCi"`
+	ReportDeployment bool   `json:"reportDeployment"`
+	UserId           int32  `json:"-"`
+}
+
+// DeploymentStatusRequest is a state change of a deployment to be reported on the commits of the deployed artifact
+type DeploymentStatusRequest struct {
+	AppId              int
+	EnvId              int
+	PipelineId         int
+	CiArtifactId       int
+	CdWorkflowId       int
+	CdWorkflowRunnerId int
+	State              State
+}
+
+// PreviewStatusRequest is a state change of the preview environment of a pull request, the url of the preview is
+// posted back on the head commit of the pull request
+type PreviewStatusRequest struct {
+	AppId       int
+	AppName     string
+	RepoUrl     string
+	Sha         string
+	State       State
+	Description string
+	TargetUrl   string
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+)
+
+const (
+	githubApiUrl          = "https://api.github.com"
+	bitbucketCloudApiUrl  = "https://api.bitbucket.org/2.0"
+	azureDevopsApiVersion = "7.1"
+	// maxDescriptionLength is the shortest description limit among the providers, github allows 140 characters
+	maxDescriptionLength = 140
+)
+
+// Target is the repository a commit status is published to
+type Target struct {
+	ProviderType bean.ProviderType
+	// ApiUrl overrides the api url derived from the repository
+	ApiUrl     string
+	Repository *Repository
+	Username   string
+	Token      string
+}
+
+// Publisher publishes commit statuses with the status apis of the git providers
+type Publisher struct {
+	client *http.Client
+}
+
+func NewPublisher(client *http.Client) *Publisher {
+	return &Publisher{client: client}
+}
+
+func (impl *Publisher) Publish(ctx context.Context, target *Target, status *bean.CommitStatus) error {
+	req, err := newStatusRequest(ctx, target, status)
+	if err != nil {
+		return err
+	}
+	resp, err := impl.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s status api responded with %d: %s", target.ProviderType, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func newStatusRequest(ctx context.Context, target *Target, status *bean.CommitStatus) (*http.Request, error) {
+	description := status.Description
+	if len(description) > maxDescriptionLength {
+		description = description[:maxDescriptionLength-3] + "..."
+	}
+	repo := target.Repository
+	var endpoint string
+	var payload interface{}
+	switch target.ProviderType {
+	case bean.ProviderTypeGithub:
+		owner, name, err := repo.lastSegments()
+		if err != nil {
+			return nil, err
+		}
+		apiUrl := githubApiUrl
+		if repo.Host != "github.com" {
+			// github enterprise serves the api on the host of the repository
+			apiUrl = "https://" + repo.Host + "/api/v3"
+		}
+		endpoint = fmt.Sprintf("%s/repos/%s/%s/statuses/%s", apiUrlOrDefault(target, apiUrl), owner, name, status.Sha)
+		payload = map[string]string{
+			"state":       githubState(status.State),
+			"context":     status.Context,
+			"description": description,
+			"target_url":  status.TargetUrl,
+		}
+	case bean.ProviderTypeGitlab:
+		apiUrl := apiUrlOrDefault(target, "https://"+repo.Host+"/api/v4")
+		endpoint = fmt.Sprintf("%s/projects/%s/statuses/%s", apiUrl, url.PathEscape(repo.Path), status.Sha)
+		payload = map[string]string{
+			"state":       gitlabState(status.State),
+			"name":        status.Context,
+			"description": description,
+			"target_url":  status.TargetUrl,
+		}
+	case bean.ProviderTypeBitbucketCloud:
+		workspace, name, err := repo.lastSegments()
+		if err != nil {
+			return nil, err
+		}
+		endpoint = fmt.Sprintf("%s/repositories/%s/%s/commit/%s/statuses/build", apiUrlOrDefault(target, bitbucketCloudApiUrl), workspace, name, status.Sha)
+		payload = bitbucketPayload(status, description)
+	case bean.ProviderTypeBitbucketServer:
+		endpoint = fmt.Sprintf("%s/rest/build-status/1.0/commits/%s", apiUrlOrDefault(target, "https://"+repo.Host), status.Sha)
+		payload = bitbucketPayload(status, description)
+	case bean.ProviderTypeAzureDevops:
+		orgUrl, project, name, err := repo.azureDevopsCoordinates()
+		if err != nil {
+			return nil, err
+		}
+		endpoint = fmt.Sprintf("%s/%s/_apis/git/repositories/%s/commits/%s/statuses?api-version=%s",
+			apiUrlOrDefault(target, orgUrl), url.PathEscape(project), url.PathEscape(name), status.Sha, azureDevopsApiVersion)
+		contextGenre, contextName := splitContext(status.Context)
+		payload = map[string]interface{}{
+			"state":       azureDevopsState(status.State),
+			"description": description,
+			"targetUrl":   status.TargetUrl,
+			"context":     map[string]string{"genre": contextGenre, "name": contextName},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported provider type %s", target.ProviderType)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	setAuth(req, target)
+	return req, nil
+}
+
+func setAuth(req *http.Request, target *Target) {
+	switch target.ProviderType {
+	case bean.ProviderTypeGitlab:
+		req.Header.Set("PRIVATE-TOKEN", target.Token)
+	case bean.ProviderTypeAzureDevops:
+		// personal access tokens are sent as the password of an empty user
+		req.SetBasicAuth("", target.Token)
+	case bean.ProviderTypeBitbucketCloud, bean.ProviderTypeBitbucketServer:
+		// app passwords need the user, access tokens are bearer tokens
+		if len(target.Username) > 0 {
+			req.SetBasicAuth(target.Username, target.Token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+target.Token)
+		}
+	default:
+		req.Header.Set("Authorization", "Bearer "+target.Token)
+	}
+}
+
+func apiUrlOrDefault(target *Target, defaultApiUrl string) string {
+	if len(target.ApiUrl) > 0 {
+		return strings.TrimSuffix(target.ApiUrl, "/")
+	}
+	return defaultApiUrl
+}
+
+func bitbucketPayload(status *bean.CommitStatus, description string) map[string]string {
+	return map[string]string{
+		"state":       bitbucketState(status.State),
+		"key":         status.Context,
+		"name":        status.Context,
+		"description": description,
+		"url":         status.TargetUrl,
+	}
+}
+
+// splitContext splits the context into the genre and name of an azure devops status, e.g. devtron/ci/app into
+// devtron and ci/app
+func splitContext(context string) (string, string) {
+	if i := strings.Index(context, "/"); i > 0 {
+		return context[:i], context[i+1:]
+	}
+	return "", context
+}
+
+func githubState(state bean.State) string {
+	switch state {
+	case bean.StateSuccess:
+		return "success"
+	case bean.StateFailure:
+		return "failure"
+	case bean.StateError:
+		return "error"
+	}
+	return "pending"
+}
+
+func gitlabState(state bean.State) string {
+	switch state {
+	case bean.StateSuccess:
+		return "success"
+	case bean.StateFailure:
+		return "failed"
+	case bean.StateError:
+		return "canceled"
+	}
+	return "running"
+}
+
+func bitbucketState(state bean.State) string {
+	switch state {
+	case bean.StateSuccess:
+		return "SUCCESSFUL"
+	case bean.StateFailure:
+		return "FAILED"
+	case bean.StateError:
+		return "STOPPED"
+	}
+	return "INPROGRESS"
+}
+
+func azureDevopsState(state bean.State) string {
+	switch state {
+	case bean.StateSuccess:
+		return "succeeded"
+	case bean.StateFailure:
+		return "failed"
+	case bean.StateError:
+		return "error"
+	}
+	return "pending"
+}
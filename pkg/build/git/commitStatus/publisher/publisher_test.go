@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+)
+
+func TestParseRepositoryUrl(t *testing.T) {
+	tests := []struct {
+		repoUrl  string
+		wantHost string
+		wantPath string
+	}{
+		{"https://github.com/devtron-labs/devtron.git", "github.com", "devtron-labs/devtron"},
+		{"git@github.com:devtron-labs/devtron.git", "github.com", "devtron-labs/devtron"},
+		{"ssh://git@bitbucket.example.com:7999/proj/repo.git", "bitbucket.example.com", "proj/repo"},
+		{"https://gitlab.com/group/subgroup/project", "gitlab.com", "group/subgroup/project"},
+		{"https://org@dev.azure.com/org/project/_git/repo", "dev.azure.com", "org/project/_git/repo"},
+	}
+	for _, tt := range tests {
+		repo, err := ParseRepositoryUrl(tt.repoUrl)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", tt.repoUrl, err)
+		}
+		if repo.Host != tt.wantHost || repo.Path != tt.wantPath {
+			t.Errorf("%s parsed to %s %s, want %s %s", tt.repoUrl, repo.Host, repo.Path, tt.wantHost, tt.wantPath)
+		}
+	}
+	if _, err := ParseRepositoryUrl("/local/repo"); err == nil {
+		t.Errorf("expected error for a local path")
+	}
+}
+
+func TestAzureDevopsCoordinates(t *testing.T) {
+	for _, repoUrl := range []string{
+		"https://dev.azure.com/org/project/_git/repo",
+		"https://org.visualstudio.com/project/_git/repo",
+		"git@ssh.dev.azure.com:v3/org/project/repo",
+	} {
+		repo, err := ParseRepositoryUrl(repoUrl)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", repoUrl, err)
+		}
+		orgUrl, project, name, err := repo.azureDevopsCoordinates()
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", repoUrl, err)
+		}
+		if project != "project" || name != "repo" || (orgUrl != "https://dev.azure.com/org" && orgUrl != "https://org.visualstudio.com") {
+			t.Errorf("%s resolved to %s %s %s", repoUrl, orgUrl, project, name)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	status := &bean.CommitStatus{
+		Sha:         "0123456789abcdef",
+		Context:     "devtron/ci/app",
+		State:       bean.StateFailure,
+		Description: "Build failed",
+		TargetUrl:   "https://devtron.example.com/dashboard/app/1/ci-details/2/3/artifacts",
+	}
+	tests := []struct {
+		name        string
+		target      *Target
+		repoUrl     string
+		wantPath    string
+		wantState   string
+		wantAuthHdr string
+		wantAuthVal string
+	}{
+		{
+			name:        "github",
+			target:      &Target{ProviderType: bean.ProviderTypeGithub, Token: "token"},
+			repoUrl:     "https://github.com/org/repo.git",
+			wantPath:    "/repos/org/repo/statuses/0123456789abcdef",
+			wantState:   "failure",
+			wantAuthHdr: "Authorization",
+			wantAuthVal: "Bearer token",
+		},
+		{
+			name:        "gitlab subgroup",
+			target:      &Target{ProviderType: bean.ProviderTypeGitlab, Token: "token"},
+			repoUrl:     "https://gitlab.com/group/sub/repo.git",
+			wantPath:    "/projects/group%2Fsub%2Frepo/statuses/0123456789abcdef",
+			wantState:   "failed",
+			wantAuthHdr: "PRIVATE-TOKEN",
+			wantAuthVal: "token",
+		},
+		{
+			name:        "bitbucket cloud",
+			target:      &Target{ProviderType: bean.ProviderTypeBitbucketCloud, Username: "user", Token: "app-password"},
+			repoUrl:     "git@bitbucket.org:workspace/repo.git",
+			wantPath:    "/repositories/workspace/repo/commit/0123456789abcdef/statuses/build",
+			wantState:   "FAILED",
+			wantAuthHdr: "Authorization",
+			wantAuthVal: "Basic dXNlcjphcHAtcGFzc3dvcmQ=",
+		},
+		{
+			name:        "bitbucket server",
+			target:      &Target{ProviderType: bean.ProviderTypeBitbucketServer, Token: "token"},
+			repoUrl:     "https://bitbucket.example.com/scm/proj/repo.git",
+			wantPath:    "/rest/build-status/1.0/commits/0123456789abcdef",
+			wantState:   "FAILED",
+			wantAuthHdr: "Authorization",
+			wantAuthVal: "Bearer token",
+		},
+		{
+			name:        "azure devops",
+			target:      &Target{ProviderType: bean.ProviderTypeAzureDevops, Token: "pat"},
+			repoUrl:     "https://dev.azure.com/org/project/_git/repo",
+			wantPath:    "/project/_apis/git/repositories/repo/commits/0123456789abcdef/statuses",
+			wantState:   "failed",
+			wantAuthHdr: "Authorization",
+			wantAuthVal: "Basic OnBhdA==",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotAuth string
+			var gotPayload map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.EscapedPath()
+				gotAuth = r.Header.Get(tt.wantAuthHdr)
+				_ = json.NewDecoder(r.Body).Decode(&gotPayload)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+			repo, err := ParseRepositoryUrl(tt.repoUrl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.target.Repository = repo
+			tt.target.ApiUrl = server.URL
+			err = NewPublisher(server.Client()).Publish(context.Background(), tt.target, status)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("path %s, want %s", gotPath, tt.wantPath)
+			}
+			if gotAuth != tt.wantAuthVal {
+				t.Errorf("%s header %q, want %q", tt.wantAuthHdr, gotAuth, tt.wantAuthVal)
+			}
+			if gotPayload["state"] != tt.wantState {
+				t.Errorf("state %v, want %s", gotPayload["state"], tt.wantState)
+			}
+		})
+	}
+}
+
+func TestPublishErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	repo, _ := ParseRepositoryUrl("https://github.com/org/repo.git")
+	target := &Target{ProviderType: bean.ProviderTypeGithub, ApiUrl: server.URL, Repository: repo, Token: "token"}
+	err := NewPublisher(server.Client()).Publish(context.Background(), target, &bean.CommitStatus{Sha: "abc", State: bean.StatePending})
+	if err == nil {
+		t.Fatal("expected error for unauthorized response")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Repository is a git repository parsed from its clone url
+type Repository struct {
+	Host string
+	// Path is the path of the repository on the host without the .git suffix, e.g. org/repo
+	Path string
+}
+
+// ParseRepositoryUrl parses https, ssh and scp-like (git@host:org/repo.git) clone urls
+func ParseRepositoryUrl(repoUrl string) (*Repository, error) {
+	repoUrl = strings.TrimSpace(repoUrl)
+	var host, path string
+	if strings.Contains(repoUrl, "://") {
+		parsed, err := url.Parse(repoUrl)
+		if err != nil {
+			return nil, err
+		}
+		host, path = parsed.Hostname(), parsed.Path
+	} else if at := strings.Index(repoUrl, "@"); at >= 0 && strings.Contains(repoUrl[at:], ":") {
+		hostAndPath := strings.SplitN(repoUrl[at+1:], ":", 2)
+		host, path = hostAndPath[0], hostAndPath[1]
+	} else {
+		return nil, fmt.Errorf("unsupported repository url %q", repoUrl)
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if len(host) == 0 || len(path) == 0 {
+		return nil, fmt.Errorf("unsupported repository url %q", repoUrl)
+	}
+	return &Repository{Host: host, Path: path}, nil
+}
+
+func (r *Repository) segments() []string {
+	return strings.Split(r.Path, "/")
+}
+
+// lastSegments returns the owner and name of repositories addressed by the last two segments of their path
+func (r *Repository) lastSegments() (string, string, error) {
+	segments := r.segments()
+	if len(segments) < 2 {
+		return "", "", fmt.Errorf("repository path %q has no owner", r.Path)
+	}
+	return segments[len(segments)-2], segments[len(segments)-1], nil
+}
+
+// azureDevopsCoordinates returns the organization url, project and repository of an azure devops repository, from
+// https://dev.azure.com/{org}/{project}/_git/{repo}, https://{org}.visualstudio.com/{project}/_git/{repo} and
+// git@ssh.dev.azure.com:v3/{org}/{project}/{repo} urls
+func (r *Repository) azureDevopsCoordinates() (string, string, string, error) {
+	segments := r.segments()
+	switch {
+	case strings.HasSuffix(r.Host, ".visualstudio.com") && len(segments) == 3 && segments[1] == "_git":
+		return "https://" + r.Host, segments[0], segments[2], nil
+	case len(segments) == 4 && segments[2] == "_git":
+		return "https://" + r.Host + "/" + segments[0], segments[1], segments[3], nil
+	case len(segments) == 4 && segments[0] == "v3":
+		return "https://dev.azure.com/" + segments[1], segments[2], segments[3], nil
+	}
+	return "", "", "", fmt.Errorf("repository path %q is not an azure devops repository", r.Path)
+}
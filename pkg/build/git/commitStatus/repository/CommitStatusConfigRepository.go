@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CommitStatusConfig struct {
+	tableName        struct{} `sql:"commit_status_config" pg:",discard_unknown_columns"`
+	Id               int      `sql:"id,pk"`
+	GitProviderId    int      `sql:"git_provider_id,notnull"`
+	ProviderType     string   `sql:"provider_type,notnull"`
+	ApiUrl           string   `sql:"api_url"`
+	ReportCi         bool     `sql:"report_ci,notnull"`
+	ReportDeployment bool     `sql:"report_deployment,notnull"`
+	Active           bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type CommitStatusConfigRepository interface {
+	Save(config *CommitStatusConfig) error
+	Update(config *CommitStatusConfig) error
+	FindActiveByGitProviderId(gitProviderId int) (*CommitStatusConfig, error)
+	FindActiveByGitProviderIds(gitProviderIds []int) ([]*CommitStatusConfig, error)
+}
+
+type CommitStatusConfigRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCommitStatusConfigRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CommitStatusConfigRepositoryImpl {
+	return &CommitStatusConfigRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CommitStatusConfigRepositoryImpl) Save(config *CommitStatusConfig) error {
+	return impl.dbConnection.Insert(config)
+}
+
+func (impl *CommitStatusConfigRepositoryImpl) Update(config *CommitStatusConfig) error {
+	return impl.dbConnection.Update(config)
+}
+
+func (impl *CommitStatusConfigRepositoryImpl) FindActiveByGitProviderId(gitProviderId int) (*CommitStatusConfig, error) {
+	config := &CommitStatusConfig{}
+	err := impl.dbConnection.Model(config).
+		Where("git_provider_id = ?", gitProviderId).
+		Where("active = ?", true).
+		Select()
+	return config, err
+}
+
+func (impl *CommitStatusConfigRepositoryImpl) FindActiveByGitProviderIds(gitProviderIds []int) ([]*CommitStatusConfig, error) {
+	var configs []*CommitStatusConfig
+	if len(gitProviderIds) == 0 {
+		return configs, nil
+	}
+	err := impl.dbConnection.Model(&configs).
+		Where("git_provider_id IN (?)", pg.In(gitProviderIds)).
+		Where("active = ?", true).
+		Select()
+	return configs, err
+}
@@ -0,0 +1,13 @@
+package commitStatus
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus/repository"
+	"github.com/google/wire"
+)
+
+var CommitStatusWireSet = wire.NewSet(
+	repository.NewCommitStatusConfigRepositoryImpl,
+	wire.Bind(new(repository.CommitStatusConfigRepository), new(*repository.CommitStatusConfigRepositoryImpl)),
+	NewCommitStatusServiceImpl,
+	wire.Bind(new(CommitStatusService), new(*CommitStatusServiceImpl)),
+)
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitHost"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitMaterial"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitProvider"
@@ -13,6 +14,7 @@ var GitWireSet = wire.NewSet(
 	gitProvider.GitProviderWireSet,
 	gitHost.GitHostWireSet,
 	gitMaterial.GitMaterialWireSet,
+	commitStatus.CommitStatusWireSet,
 
 	gitWebhook.NewWebhookSecretValidatorImpl,
 	wire.Bind(new(gitWebhook.WebhookSecretValidator), new(*gitWebhook.WebhookSecretValidatorImpl)),
@@ -0,0 +1,370 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/client/gitSensor"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	bean3 "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
+	"github.com/devtron-labs/devtron/pkg/build/schedule/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/schedule/bean"
+	"github.com/devtron-labs/devtron/pkg/build/schedule/repository"
+	scheduleUtil "github.com/devtron-labs/devtron/pkg/build/schedule/util"
+	"github.com/devtron-labs/devtron/pkg/build/trigger"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiPipelineScheduleService interface {
+	SaveSchedule(request *bean.CiPipelineScheduleDto) (*bean.CiPipelineScheduleDto, error)
+	GetScheduleByCiPipelineId(ciPipelineId int) (*bean.CiPipelineScheduleDto, error)
+	GetSchedulesByAppId(appId int) ([]*bean.CiPipelineScheduleDto, error)
+	DeleteSchedule(ciPipelineId int, userId int32) error
+	// TriggerDueSchedules triggers every enabled schedule whose next run is due,
+	// safe to be called concurrently from multiple replicas
+	TriggerDueSchedules()
+}
+
+type CiPipelineScheduleServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	ciPipelineScheduleRepository repository.CiPipelineScheduleRepository
+	ciPipelineRepository         pipelineConfig.CiPipelineRepository
+	ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository
+	ciWorkflowRepository         pipelineConfig.CiWorkflowRepository
+	gitSensorClient              gitSensor.Client
+	ciHandlerService             trigger.HandlerService
+}
+
+func NewCiPipelineScheduleServiceImpl(logger *zap.SugaredLogger,
+	ciPipelineScheduleRepository repository.CiPipelineScheduleRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	gitSensorClient gitSensor.Client,
+	ciHandlerService trigger.HandlerService) *CiPipelineScheduleServiceImpl {
+	return &CiPipelineScheduleServiceImpl{
+		logger:                       logger,
+		ciPipelineScheduleRepository: ciPipelineScheduleRepository,
+		ciPipelineRepository:         ciPipelineRepository,
+		ciPipelineMaterialRepository: ciPipelineMaterialRepository,
+		ciWorkflowRepository:         ciWorkflowRepository,
+		gitSensorClient:              gitSensorClient,
+		ciHandlerService:             ciHandlerService,
+	}
+}
+
+// dueSchedulesBatchSize limits the schedules picked in a single poll, remaining ones are picked in the next poll
+const dueSchedulesBatchSize = 100
+
+func (impl *CiPipelineScheduleServiceImpl) SaveSchedule(request *bean.CiPipelineScheduleDto) (*bean.CiPipelineScheduleDto, error) {
+	if len(request.Timezone) == 0 {
+		request.Timezone = bean.DefaultScheduleTimezone
+	}
+	ciPipeline, err := impl.ciPipelineRepository.FindById(request.CiPipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", request.CiPipelineId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "ci pipeline not found", "ci pipeline not found")
+	}
+	err = impl.validateScheduleRequest(request, ciPipeline)
+	if err != nil {
+		return nil, err
+	}
+	nextRunAt, err := scheduleUtil.GetNextRunTime(request.CronExpression, request.Timezone, time.Now())
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	schedule, err := impl.ciPipelineScheduleRepository.FindActiveByCiPipelineId(request.CiPipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci pipeline schedule", "ciPipelineId", request.CiPipelineId, "err", err)
+		return nil, err
+	}
+	isNew := err == pg.ErrNoRows
+	if isNew {
+		schedule = &repository.CiPipelineSchedule{
+			CiPipelineId: request.CiPipelineId,
+			Active:       true,
+			AuditLog:     sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		schedule.UpdateAuditLog(request.UserId)
+	}
+	schedule.CronExpression = request.CronExpression
+	schedule.Timezone = request.Timezone
+	schedule.EnvironmentId = request.EnvironmentId
+	schedule.MaterialSelection = adapter.GetScheduleMaterials(request.Materials)
+	schedule.SkipIfNoNewCommit = request.SkipIfNoNewCommit
+	schedule.Enabled = request.Enabled
+	schedule.NextRunAt = nextRunAt
+	if isNew {
+		err = impl.ciPipelineScheduleRepository.Save(schedule)
+	} else {
+		err = impl.ciPipelineScheduleRepository.Update(schedule)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving ci pipeline schedule", "ciPipelineId", request.CiPipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.BuildScheduleDto(schedule, ciPipeline.AppId), nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) validateScheduleRequest(request *bean.CiPipelineScheduleDto, ciPipeline *pipelineConfig.CiPipeline) error {
+	if ciPipeline.AppId != request.AppId {
+		return util.NewApiError(http.StatusBadRequest, "ci pipeline does not belong to the app", "ci pipeline does not belong to the app")
+	}
+	pipelineType := common.PipelineType(ciPipeline.PipelineType)
+	if ciPipeline.IsExternal || ciPipeline.ParentCiPipeline > 0 ||
+		pipelineType == common.LINKED || pipelineType == common.LINKED_CD || pipelineType == common.EXTERNAL {
+		return util.NewApiError(http.StatusBadRequest, "schedule is supported only for build and job pipelines", "schedule is not supported for linked or external ci pipelines")
+	}
+	if request.EnvironmentId > 0 && pipelineType != common.CI_JOB {
+		return util.NewApiError(http.StatusBadRequest, "environment can only be selected for job pipelines", "environment can only be selected for job pipelines")
+	}
+	pipelineMaterials := make(map[int]*pipelineConfig.CiPipelineMaterial, len(ciPipeline.CiPipelineMaterials))
+	for _, material := range ciPipeline.CiPipelineMaterials {
+		pipelineMaterials[material.Id] = material
+	}
+	for _, material := range ciPipeline.CiPipelineMaterials {
+		if material.Type == constants.SOURCE_TYPE_WEBHOOK {
+			return util.NewApiError(http.StatusBadRequest, "schedule is not supported for pipelines with pull request or tag based source", "webhook source type material found in ci pipeline")
+		}
+	}
+	for _, material := range request.Materials {
+		if _, ok := pipelineMaterials[material.CiPipelineMaterialId]; !ok {
+			errMsg := fmt.Sprintf("material %d does not belong to the ci pipeline", material.CiPipelineMaterialId)
+			return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+		}
+	}
+	return nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) GetScheduleByCiPipelineId(ciPipelineId int) (*bean.CiPipelineScheduleDto, error) {
+	ciPipeline, err := impl.ciPipelineRepository.FindOneWithMinData(ciPipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	}
+	schedule, err := impl.ciPipelineScheduleRepository.FindActiveByCiPipelineId(ciPipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.BuildScheduleDto(schedule, ciPipeline.AppId), nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) GetSchedulesByAppId(appId int) ([]*bean.CiPipelineScheduleDto, error) {
+	ciPipelines, err := impl.ciPipelineRepository.FindByAppId(appId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci pipelines", "appId", appId, "err", err)
+		return nil, err
+	}
+	ciPipelineIds := make([]int, 0, len(ciPipelines))
+	for _, ciPipeline := range ciPipelines {
+		ciPipelineIds = append(ciPipelineIds, ciPipeline.Id)
+	}
+	schedules, err := impl.ciPipelineScheduleRepository.FindActiveByCiPipelineIds(ciPipelineIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline schedules", "appId", appId, "err", err)
+		return nil, err
+	}
+	scheduleDtos := make([]*bean.CiPipelineScheduleDto, 0, len(schedules))
+	for _, schedule := range schedules {
+		scheduleDtos = append(scheduleDtos, adapter.BuildScheduleDto(schedule, appId))
+	}
+	return scheduleDtos, nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) DeleteSchedule(ciPipelineId int, userId int32) error {
+	err := impl.ciPipelineScheduleRepository.MarkInactiveByCiPipelineId(ciPipelineId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) TriggerDueSchedules() {
+	now := time.Now()
+	schedules, err := impl.ciPipelineScheduleRepository.FindDueSchedules(now, dueSchedulesBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error in fetching due ci pipeline schedules", "err", err)
+		return
+	}
+	for _, schedule := range schedules {
+		claimed, err := impl.claimScheduleRun(schedule, now)
+		if err != nil || !claimed {
+			continue
+		}
+		status, message, ciWorkflowId := impl.runSchedule(schedule)
+		err = impl.ciPipelineScheduleRepository.UpdateLastRun(schedule.Id, now, status.String(), message, ciWorkflowId)
+		if err != nil {
+			impl.logger.Errorw("error in updating last run of ci pipeline schedule", "scheduleId", schedule.Id, "err", err)
+		}
+	}
+}
+
+// claimScheduleRun moves the schedule to its next activation, a run missed while orchestrator was down
+// is triggered once and not replayed for every missed activation
+func (impl *CiPipelineScheduleServiceImpl) claimScheduleRun(schedule *repository.CiPipelineSchedule, now time.Time) (bool, error) {
+	nextRunAt, err := scheduleUtil.GetNextRunTime(schedule.CronExpression, schedule.Timezone, now)
+	if err != nil {
+		impl.logger.Errorw("error in computing next run of ci pipeline schedule", "scheduleId", schedule.Id, "err", err)
+		return false, err
+	}
+	claimed, err := impl.ciPipelineScheduleRepository.ClaimScheduleRun(schedule.Id, schedule.NextRunAt, nextRunAt)
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		impl.logger.Debugw("ci pipeline schedule run already claimed", "scheduleId", schedule.Id)
+	}
+	return claimed, nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) runSchedule(schedule *repository.CiPipelineSchedule) (bean.ScheduleRunStatus, string, int) {
+	ciPipeline, err := impl.ciPipelineRepository.FindById(schedule.CiPipelineId)
+	if err == pg.ErrNoRows {
+		impl.logger.Infow("ci pipeline not found, deleting its schedule", "ciPipelineId", schedule.CiPipelineId)
+		err = impl.ciPipelineScheduleRepository.MarkInactiveByCiPipelineId(schedule.CiPipelineId, bean2.SYSTEM_USER_ID)
+		if err != nil {
+			impl.logger.Errorw("error in deleting ci pipeline schedule", "ciPipelineId", schedule.CiPipelineId, "err", err)
+		}
+		return bean.ScheduleRunFailed, "ci pipeline not found", 0
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", schedule.CiPipelineId, "err", err)
+		return bean.ScheduleRunFailed, err.Error(), 0
+	}
+	materials, err := impl.resolveMaterials(schedule, ciPipeline)
+	if err != nil {
+		impl.logger.Errorw("error in resolving commits for scheduled run", "ciPipelineId", schedule.CiPipelineId, "err", err)
+		return bean.ScheduleRunFailed, err.Error(), 0
+	}
+	if schedule.SkipIfNoNewCommit {
+		hasNewCommit, err := impl.hasNewCommit(schedule.CiPipelineId, materials)
+		if err != nil {
+			return bean.ScheduleRunFailed, err.Error(), 0
+		}
+		if !hasNewCommit {
+			impl.logger.Infow("skipping scheduled ci run, no new commit", "ciPipelineId", schedule.CiPipelineId)
+			return bean.ScheduleRunSkipped, bean.NoNewCommitSkippedMessage, 0
+		}
+	}
+	ciTriggerRequest := bean3.CiTriggerRequest{
+		PipelineId:         schedule.CiPipelineId,
+		CiPipelineMaterial: materials,
+		TriggeredBy:        bean2.SYSTEM_USER_ID,
+		EnvironmentId:      schedule.EnvironmentId,
+		PipelineType:       ciPipeline.PipelineType,
+		TriggerType:        workflow.CiTriggerTypeScheduled,
+	}
+	ciWorkflowId, err := impl.ciHandlerService.HandleCIManual(ciTriggerRequest)
+	if err != nil {
+		impl.logger.Errorw("error in triggering scheduled ci run", "ciPipelineId", schedule.CiPipelineId, "err", err)
+		return bean.ScheduleRunFailed, err.Error(), ciWorkflowId
+	}
+	return bean.ScheduleRunTriggered, "", ciWorkflowId
+}
+
+// resolveMaterials picks the latest commit for every material of the pipeline, from the overridden branch
+// if one is set in the schedule else from the configured branch, the overridden branch is built and recorded in the git triggers
+func (impl *CiPipelineScheduleServiceImpl) resolveMaterials(schedule *repository.CiPipelineSchedule, ciPipeline *pipelineConfig.CiPipeline) ([]bean3.CiPipelineMaterial, error) {
+	branchOverrides := make(map[int]string, len(schedule.MaterialSelection))
+	for _, material := range schedule.MaterialSelection {
+		if len(material.Branch) > 0 {
+			branchOverrides[material.CiPipelineMaterialId] = material.Branch
+		}
+	}
+	var headMaterialIds []int
+	for _, material := range ciPipeline.CiPipelineMaterials {
+		if material.Type == constants.SOURCE_TYPE_WEBHOOK {
+			return nil, fmt.Errorf("material %d is of pull request or tag type, not supported for scheduled builds", material.Id)
+		}
+		if _, ok := branchOverrides[material.Id]; !ok {
+			headMaterialIds = append(headMaterialIds, material.Id)
+		}
+	}
+	commits := make(map[int]string, len(ciPipeline.CiPipelineMaterials))
+	if len(headMaterialIds) > 0 {
+		heads, err := impl.gitSensorClient.GetHeadForPipelineMaterials(context.Background(), &gitSensor.HeadRequest{MaterialIds: headMaterialIds})
+		if err != nil {
+			impl.logger.Errorw("error in fetching head for pipeline materials", "materialIds", headMaterialIds, "err", err)
+			return nil, err
+		}
+		for _, head := range heads {
+			commits[head.Id] = head.GitCommit.Commit
+		}
+	}
+	for materialId, branch := range branchOverrides {
+		gitCommit, err := impl.gitSensorClient.GetCommitMetadata(context.Background(), &gitSensor.CommitMetadataRequest{
+			PipelineMaterialId: materialId,
+			BranchName:         branch,
+		})
+		if err != nil {
+			impl.logger.Errorw("error in fetching latest commit of branch", "ciPipelineMaterialId", materialId, "branch", branch, "err", err)
+			return nil, err
+		}
+		if gitCommit != nil {
+			commits[materialId] = gitCommit.Commit
+		}
+	}
+	materials := make([]bean3.CiPipelineMaterial, 0, len(ciPipeline.CiPipelineMaterials))
+	for _, material := range ciPipeline.CiPipelineMaterials {
+		commit := commits[material.Id]
+		if len(commit) == 0 {
+			return nil, fmt.Errorf("no commit found for material %d", material.Id)
+		}
+		materials = append(materials, bean3.CiPipelineMaterial{
+			Id:             material.Id,
+			GitMaterialId:  material.GitMaterialId,
+			Type:           string(material.Type),
+			Value:          material.Value,
+			Active:         material.Active,
+			GitCommit:      pipelineConfig.GitCommit{Commit: commit},
+			BranchOverride: branchOverrides[material.Id],
+		})
+	}
+	return materials, nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) hasNewCommit(ciPipelineId int, materials []bean3.CiPipelineMaterial) (bool, error) {
+	lastWorkflow, err := impl.ciWorkflowRepository.FindLastTriggeredWorkflow(ciPipelineId)
+	if err == pg.ErrNoRows {
+		return true, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching last triggered ci workflow", "ciPipelineId", ciPipelineId, "err", err)
+		return false, err
+	}
+	for _, material := range materials {
+		lastCommit, ok := lastWorkflow.GitTriggers[material.Id]
+		if !ok || lastCommit.Commit != material.GitCommit.Commit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
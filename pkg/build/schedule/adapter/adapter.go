@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/schedule/bean"
+	"github.com/devtron-labs/devtron/pkg/build/schedule/repository"
+	"github.com/devtron-labs/devtron/util"
+)
+
+func BuildScheduleDto(schedule *repository.CiPipelineSchedule, appId int) *bean.CiPipelineScheduleDto {
+	dto := &bean.CiPipelineScheduleDto{
+		Id:                schedule.Id,
+		AppId:             appId,
+		CiPipelineId:      schedule.CiPipelineId,
+		CronExpression:    schedule.CronExpression,
+		Timezone:          schedule.Timezone,
+		EnvironmentId:     schedule.EnvironmentId,
+		SkipIfNoNewCommit: schedule.SkipIfNoNewCommit,
+		Enabled:           schedule.Enabled,
+		LastRunStatus:     bean.ScheduleRunStatus(schedule.LastRunStatus),
+		LastRunMessage:    schedule.LastRunMessage,
+		LastCiWorkflowId:  schedule.LastCiWorkflowId,
+		NextRunAt:         util.GetTimePtr(schedule.NextRunAt),
+		LastRunAt:         util.GetTimePtr(schedule.LastRunAt),
+	}
+	for _, material := range schedule.MaterialSelection {
+		dto.Materials = append(dto.Materials, &bean.ScheduleMaterialDto{
+			CiPipelineMaterialId: material.CiPipelineMaterialId,
+			Branch:               material.Branch,
+		})
+	}
+	if !schedule.Enabled {
+		// next run is kept in db for bookkeeping only, a disabled schedule never runs
+		dto.NextRunAt = nil
+	}
+	return dto
+}
+
+func GetScheduleMaterials(materials []*bean.ScheduleMaterialDto) []*repository.ScheduleMaterial {
+	scheduleMaterials := make([]*repository.ScheduleMaterial, 0, len(materials))
+	for _, material := range materials {
+		scheduleMaterials = append(scheduleMaterials, &repository.ScheduleMaterial{
+			CiPipelineMaterialId: material.CiPipelineMaterialId,
+			Branch:               material.Branch,
+		})
+	}
+	return scheduleMaterials
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunTriggered ScheduleRunStatus = "Triggered"
+	ScheduleRunSkipped   ScheduleRunStatus = "Skipped"
+	ScheduleRunFailed    ScheduleRunStatus = "Failed"
+)
+
+func (s ScheduleRunStatus) String() string {
+	return string(s)
+}
+
+const (
+	DefaultScheduleTimezone   = "UTC"
+	NoNewCommitSkippedMessage = "no new commit found since the last build"
+)
+
+// CiPipelineScheduleDto is the cron schedule configured on a ci pipeline or a job pipeline
+type CiPipelineScheduleDto struct {
+	Id                int                    `json:"id"`
+	AppId             int                    `json:"appId"`
+	CiPipelineId      int                    `json:"ciPipelineId" validate:"required,min=1"`
+	CronExpression    string                 `json:"cronExpression" validate:"required"`
+	Timezone          string                 `json:"timezone"`
+	EnvironmentId     int                    `json:"environmentId,omitempty"` // only applicable for job pipelines
+	Materials         []*ScheduleMaterialDto `json:"materials,omitempty" validate:"dive"`
+	SkipIfNoNewCommit bool                   `json:"skipIfNoNewCommit"`
+	Enabled           bool                   `json:"enabled"`
+	NextRunAt         *time.Time             `json:"nextRunAt,omitempty"`
+	LastRunAt         *time.Time             `json:"lastRunAt,omitempty"`
+	LastRunStatus     ScheduleRunStatus      `json:"lastRunStatus,omitempty"`
+	LastRunMessage    string                 `json:"lastRunMessage,omitempty"`
+	LastCiWorkflowId  int                    `json:"lastCiWorkflowId,omitempty"`
+	UserId            int32                  `json:"-"`
+}
+
+// ScheduleMaterialDto overrides the branch built for a ci pipeline material on scheduled runs,
+// materials not listed are built from the latest commit of their configured branch
+type ScheduleMaterialDto struct {
+	CiPipelineMaterialId int    `json:"ciPipelineMaterialId" validate:"required,min=1"`
+	Branch               string `json:"branch"`
+}
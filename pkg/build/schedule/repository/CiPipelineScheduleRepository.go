@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiPipelineSchedule struct {
+	tableName         struct{}            `sql:"ci_pipeline_schedule" pg:",discard_unknown_columns"`
+	Id                int                 `sql:"id,pk"`
+	CiPipelineId      int                 `sql:"ci_pipeline_id,notnull"`
+	CronExpression    string              `sql:"cron_expression,notnull"`
+	Timezone          string              `sql:"timezone,notnull"`
+	EnvironmentId     int                 `sql:"environment_id"`
+	MaterialSelection []*ScheduleMaterial `sql:"material_selection"`
+	SkipIfNoNewCommit bool                `sql:"skip_if_no_new_commit,notnull"`
+	Enabled           bool                `sql:"enabled,notnull"`
+	NextRunAt         time.Time           `sql:"next_run_at"`
+	LastRunAt         time.Time           `sql:"last_run_at"`
+	LastRunStatus     string              `sql:"last_run_status"`
+	LastRunMessage    string              `sql:"last_run_message"`
+	LastCiWorkflowId  int                 `sql:"last_ci_workflow_id"`
+	Active            bool                `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ScheduleMaterial struct {
+	CiPipelineMaterialId int    `json:"ciPipelineMaterialId"`
+	Branch               string `json:"branch"`
+}
+
+type CiPipelineScheduleRepository interface {
+	Save(schedule *CiPipelineSchedule) error
+	Update(schedule *CiPipelineSchedule) error
+	FindActiveByCiPipelineId(ciPipelineId int) (*CiPipelineSchedule, error)
+	FindActiveByCiPipelineIds(ciPipelineIds []int) ([]*CiPipelineSchedule, error)
+	// FindDueSchedules returns enabled schedules whose next run is at or before the given time
+	FindDueSchedules(dueAt time.Time, limit int) ([]*CiPipelineSchedule, error)
+	// ClaimScheduleRun moves next_run_at forward only if it still holds the value read by the caller,
+	// so that a run is picked by exactly one orchestrator replica
+	ClaimScheduleRun(id int, currentNextRunAt time.Time, nextRunAt time.Time) (bool, error)
+	UpdateLastRun(id int, lastRunAt time.Time, status string, message string, ciWorkflowId int) error
+	MarkInactiveByCiPipelineId(ciPipelineId int, userId int32) error
+}
+
+type CiPipelineScheduleRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCiPipelineScheduleRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CiPipelineScheduleRepositoryImpl {
+	return &CiPipelineScheduleRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) Save(schedule *CiPipelineSchedule) error {
+	return impl.dbConnection.Insert(schedule)
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) Update(schedule *CiPipelineSchedule) error {
+	return impl.dbConnection.Update(schedule)
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) FindActiveByCiPipelineId(ciPipelineId int) (*CiPipelineSchedule, error) {
+	schedule := &CiPipelineSchedule{}
+	err := impl.dbConnection.Model(schedule).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Where("active = ?", true).
+		Limit(1).
+		Select()
+	return schedule, err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) FindActiveByCiPipelineIds(ciPipelineIds []int) ([]*CiPipelineSchedule, error) {
+	var schedules []*CiPipelineSchedule
+	if len(ciPipelineIds) == 0 {
+		return schedules, nil
+	}
+	err := impl.dbConnection.Model(&schedules).
+		Where("ci_pipeline_id in (?)", pg.In(ciPipelineIds)).
+		Where("active = ?", true).
+		Select()
+	return schedules, err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) FindDueSchedules(dueAt time.Time, limit int) ([]*CiPipelineSchedule, error) {
+	var schedules []*CiPipelineSchedule
+	err := impl.dbConnection.Model(&schedules).
+		Where("active = ?", true).
+		Where("enabled = ?", true).
+		Where("next_run_at <= ?", dueAt).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Select()
+	return schedules, err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) ClaimScheduleRun(id int, currentNextRunAt time.Time, nextRunAt time.Time) (bool, error) {
+	result, err := impl.dbConnection.Model((*CiPipelineSchedule)(nil)).
+		Set("next_run_at = ?", nextRunAt).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Where("enabled = ?", true).
+		Where("next_run_at = ?", currentNextRunAt).
+		Update()
+	if err != nil {
+		impl.logger.Errorw("error in claiming ci pipeline schedule run", "id", id, "err", err)
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) UpdateLastRun(id int, lastRunAt time.Time, status string, message string, ciWorkflowId int) error {
+	_, err := impl.dbConnection.Model((*CiPipelineSchedule)(nil)).
+		Set("last_run_at = ?", lastRunAt).
+		Set("last_run_status = ?", status).
+		Set("last_run_message = ?", message).
+		Set("last_ci_workflow_id = ?", ciWorkflowId).
+		Where("id = ?", id).
+		Update()
+	return err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) MarkInactiveByCiPipelineId(ciPipelineId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*CiPipelineSchedule)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
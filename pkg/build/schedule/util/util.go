@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// GetNextRunTime parses a standard 5 field cron expression (descriptors like @daily are also allowed)
+// and returns the first activation strictly after the given time, evaluated in the given timezone.
+// The returned time is in UTC.
+func GetNextRunTime(cronExpression string, timezone string, after time.Time) (time.Time, error) {
+	cronExpression = strings.TrimSpace(cronExpression)
+	if len(cronExpression) == 0 {
+		return time.Time{}, fmt.Errorf("cron expression is empty")
+	}
+	if strings.HasPrefix(cronExpression, "TZ=") || strings.HasPrefix(cronExpression, "CRON_TZ=") {
+		return time.Time{}, fmt.Errorf("timezone must not be part of the cron expression, use the timezone field instead")
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	schedule, err := cron.ParseStandard(cronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", cronExpression, err)
+	}
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never activates", cronExpression)
+	}
+	return next.UTC(), nil
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNextRunTime(t *testing.T) {
+	after := time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		want       time.Time
+		wantErr    bool
+	}{
+		{
+			name:       "every hour in utc",
+			expression: "0 * * * *",
+			timezone:   "UTC",
+			want:       time.Date(2024, 3, 10, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "daily at midnight in kolkata",
+			expression: "0 0 * * *",
+			timezone:   "Asia/Kolkata",
+			want:       time.Date(2024, 3, 10, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "descriptor",
+			expression: "@daily",
+			timezone:   "UTC",
+			want:       time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "invalid expression",
+			expression: "61 * * * *",
+			timezone:   "UTC",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid timezone",
+			expression: "0 * * * *",
+			timezone:   "Mars/Olympus",
+			wantErr:    true,
+		},
+		{
+			name:       "timezone inside expression",
+			expression: "CRON_TZ=Asia/Kolkata 0 * * * *",
+			timezone:   "UTC",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetNextRunTime(tt.expression, tt.timezone, after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetNextRunTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("GetNextRunTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/schedule/repository"
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	repository.NewCiPipelineScheduleRepositoryImpl,
+	wire.Bind(new(repository.CiPipelineScheduleRepository), new(*repository.CiPipelineScheduleRepositoryImpl)),
+	NewCiPipelineScheduleServiceImpl,
+	wire.Bind(new(CiPipelineScheduleService), new(*CiPipelineScheduleServiceImpl)),
+)
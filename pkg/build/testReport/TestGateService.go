@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testReport
+
+import (
+	"net/http"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/helper"
+	testReportRepository "github.com/devtron-labs/devtron/pkg/build/testReport/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type TestGateService interface {
+	GetTestGate(pipelineId int) (*bean.TestGateConfigDto, error)
+	SaveTestGate(request *bean.TestGateConfigDto) (*bean.TestGateConfigDto, error)
+	DeleteTestGate(pipelineId int, userId int32) error
+	// EvaluateTestGate checks the test summary of the ci workflow which built the artifact against the
+	// test gate of the cd pipeline, artifacts are always allowed on pipelines without a test gate
+	EvaluateTestGate(pipelineId int, artifact *repository.CiArtifact) (*bean.TestGateEvaluation, error)
+	EvaluateTestGateForArtifactId(pipelineId, artifactId int) (*bean.TestGateEvaluation, error)
+}
+
+type TestGateServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	cdPipelineTestGateRepository testReportRepository.CdPipelineTestGateRepository
+	pipelineRepository           pipelineConfig.PipelineRepository
+	ciArtifactRepository         repository.CiArtifactRepository
+	testReportService            TestReportService
+}
+
+func NewTestGateServiceImpl(logger *zap.SugaredLogger,
+	cdPipelineTestGateRepository testReportRepository.CdPipelineTestGateRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	testReportService TestReportService) *TestGateServiceImpl {
+	return &TestGateServiceImpl{
+		logger:                       logger,
+		cdPipelineTestGateRepository: cdPipelineTestGateRepository,
+		pipelineRepository:           pipelineRepository,
+		ciArtifactRepository:         ciArtifactRepository,
+		testReportService:            testReportService,
+	}
+}
+
+func (impl *TestGateServiceImpl) GetTestGate(pipelineId int) (*bean.TestGateConfigDto, error) {
+	gate, err := impl.cdPipelineTestGateRepository.FindActiveByPipelineId(pipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching test gate", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, nil
+	}
+	return adapter.BuildTestGateDto(gate), nil
+}
+
+func (impl *TestGateServiceImpl) SaveTestGate(request *bean.TestGateConfigDto) (*bean.TestGateConfigDto, error) {
+	if !request.BlockOnFailedTests && request.MinPassRate == 0 && !request.FailIfReportMissing {
+		return nil, util.NewApiError(http.StatusBadRequest, "at least one test gate condition is required", "no test gate condition configured")
+	}
+	_, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "cd pipeline not found", "cd pipeline not found")
+	}
+	gate, err := impl.cdPipelineTestGateRepository.FindActiveByPipelineId(request.PipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching test gate", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	isNew := err == pg.ErrNoRows
+	if isNew {
+		gate = &testReportRepository.CdPipelineTestGate{
+			PipelineId: request.PipelineId,
+			Active:     true,
+			AuditLog:   sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		gate.UpdateAuditLog(request.UserId)
+	}
+	gate.BlockOnFailedTests = request.BlockOnFailedTests
+	gate.MinPassRate = request.MinPassRate
+	gate.FailIfReportMissing = request.FailIfReportMissing
+	if isNew {
+		err = impl.cdPipelineTestGateRepository.Save(gate)
+	} else {
+		err = impl.cdPipelineTestGateRepository.Update(gate)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving test gate", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.BuildTestGateDto(gate), nil
+}
+
+func (impl *TestGateServiceImpl) DeleteTestGate(pipelineId int, userId int32) error {
+	err := impl.cdPipelineTestGateRepository.MarkInactiveByPipelineId(pipelineId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting test gate", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *TestGateServiceImpl) EvaluateTestGateForArtifactId(pipelineId, artifactId int) (*bean.TestGateEvaluation, error) {
+	artifact, err := impl.ciArtifactRepository.Get(artifactId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci artifact", "artifactId", artifactId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "artifact not found", "artifact not found")
+	}
+	return impl.EvaluateTestGate(pipelineId, artifact)
+}
+
+func (impl *TestGateServiceImpl) EvaluateTestGate(pipelineId int, artifact *repository.CiArtifact) (*bean.TestGateEvaluation, error) {
+	evaluation := &bean.TestGateEvaluation{
+		PipelineId: pipelineId,
+		ArtifactId: artifact.Id,
+		Allowed:    true,
+	}
+	gate, err := impl.GetTestGate(pipelineId)
+	if err != nil {
+		return nil, err
+	} else if gate == nil {
+		return evaluation, nil
+	}
+	evaluation.GateEnabled = true
+	evaluation.TestGate = gate
+	summary, err := impl.testReportService.GetTestSummaryForArtifact(artifact)
+	if err != nil {
+		impl.logger.Errorw("error in fetching test summary for artifact", "pipelineId", pipelineId, "artifactId", artifact.Id, "err", err)
+		return nil, err
+	}
+	evaluation.TestSummary = summary
+	evaluation.Reasons = helper.EvaluateTestGate(gate, summary)
+	evaluation.Allowed = len(evaluation.Reasons) == 0
+	return evaluation, nil
+}
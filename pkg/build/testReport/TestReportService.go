@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testReport
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/helper"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/parser"
+	testReportRepository "github.com/devtron-labs/devtron/pkg/build/testReport/repository"
+	"github.com/devtron-labs/devtron/pkg/build/trigger"
+	pipelineStageRepository "github.com/devtron-labs/devtron/pkg/pipeline/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type TestReportService interface {
+	// IngestTestReports downloads the artifacts uploaded by a ci workflow, parses the test reports declared
+	// on its steps and stores the summary against the workflow, an existing summary is replaced
+	IngestTestReports(ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error)
+	// GetTestSummary returns the stored summary of a ci workflow, reports are ingested if not done yet
+	GetTestSummary(ciPipelineId, ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error)
+	// RefreshTestSummary re-ingests the reports of a ci workflow of the pipeline, used when reports could not be fetched earlier
+	RefreshTestSummary(ciPipelineId, ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error)
+	// GetTestSummaryForArtifact returns the summary of the ci workflow which built the artifact,
+	// nil is returned for artifacts not built by a ci workflow
+	GetTestSummaryForArtifact(artifact *repository.CiArtifact) (*bean.CiWorkflowTestSummaryDto, error)
+}
+
+type TestReportServiceImpl struct {
+	logger                          *zap.SugaredLogger
+	ciWorkflowTestSummaryRepository testReportRepository.CiWorkflowTestSummaryRepository
+	ciWorkflowRepository            pipelineConfig.CiWorkflowRepository
+	ciArtifactRepository            repository.CiArtifactRepository
+	pipelineStageRepository         pipelineStageRepository.PipelineStageRepository
+	ciHandlerService                trigger.HandlerService
+	// ingestionLocks serialises ingestion of the same workflow triggered by ci completion and api reads,
+	// workflows are striped over a fixed set of locks
+	ingestionLocks [ingestionLockStripes]sync.Mutex
+}
+
+const ingestionLockStripes = 32
+
+func NewTestReportServiceImpl(logger *zap.SugaredLogger,
+	ciWorkflowTestSummaryRepository testReportRepository.CiWorkflowTestSummaryRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	pipelineStageRepository pipelineStageRepository.PipelineStageRepository,
+	ciHandlerService trigger.HandlerService) *TestReportServiceImpl {
+	return &TestReportServiceImpl{
+		logger:                          logger,
+		ciWorkflowTestSummaryRepository: ciWorkflowTestSummaryRepository,
+		ciWorkflowRepository:            ciWorkflowRepository,
+		ciArtifactRepository:            ciArtifactRepository,
+		pipelineStageRepository:         pipelineStageRepository,
+		ciHandlerService:                ciHandlerService,
+	}
+}
+
+func (impl *TestReportServiceImpl) IngestTestReports(ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error) {
+	lock := &impl.ingestionLocks[ciWorkflowId%ingestionLockStripes]
+	lock.Lock()
+	defer lock.Unlock()
+	return impl.ingestTestReports(ciWorkflowId)
+}
+
+func (impl *TestReportServiceImpl) ingestTestReports(ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error) {
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(ciWorkflowId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci workflow", "ciWorkflowId", ciWorkflowId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, util.NewApiError(http.StatusNotFound, "ci workflow not found", "ci workflow not found")
+	}
+	// the ci runner uploads artifacts before reporting step failures, the workflow may still be running then
+	if isArtifactUploaded, _ := ciWorkflow.GetIsArtifactUploaded(); ciWorkflow.InProgress() && !isArtifactUploaded {
+		return nil, util.NewApiError(http.StatusConflict, "ci workflow is still running", "ci workflow is still running")
+	}
+	declaredPaths, err := impl.getDeclaredTestReportPaths(ciWorkflow.CiPipelineId)
+	if err != nil {
+		return nil, err
+	}
+	if len(declaredPaths) == 0 {
+		// nothing is stored, report paths may be declared later and the workflow should not be marked as ingested
+		return &bean.CiWorkflowTestSummaryDto{
+			CiWorkflowId: ciWorkflow.Id,
+			CiPipelineId: ciWorkflow.CiPipelineId,
+			Status:       bean.SummaryNoReports,
+			Message:      bean.NoTestReportPathsDeclaredMessage,
+		}, nil
+	}
+	status, message, summary := impl.parseWorkflowReports(ciWorkflow, declaredPaths)
+	savedSummary, err := impl.saveTestSummary(ciWorkflow.Id, status, message, summary)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.BuildTestSummaryDto(savedSummary, ciWorkflow.CiPipelineId), nil
+}
+
+func (impl *TestReportServiceImpl) parseWorkflowReports(ciWorkflow *pipelineConfig.CiWorkflow, declaredPaths []string) (bean.SummaryStatus, string, *bean.TestSummary) {
+	isArtifactUploaded, _ := ciWorkflow.GetIsArtifactUploaded()
+	if !ciWorkflow.BlobStorageEnabled || !isArtifactUploaded {
+		return bean.SummaryNoReports, bean.ArtifactsNotUploadedMessage, nil
+	}
+	artifactFile, err := impl.ciHandlerService.DownloadCiWorkflowArtifacts(ciWorkflow.CiPipelineId, ciWorkflow.Id)
+	if err != nil {
+		impl.logger.Errorw("error in downloading ci workflow artifacts", "ciWorkflowId", ciWorkflow.Id, "err", err)
+		return bean.SummaryFailed, fmt.Sprintf("error in downloading artifacts: %s", err.Error()), nil
+	}
+	defer func() {
+		artifactFile.Close()
+		os.Remove(artifactFile.Name())
+	}()
+	reports, parseErrors, err := impl.parseReportsFromArtifactZip(artifactFile, declaredPaths)
+	if err != nil {
+		impl.logger.Errorw("error in reading ci workflow artifacts", "ciWorkflowId", ciWorkflow.Id, "err", err)
+		return bean.SummaryFailed, fmt.Sprintf("error in reading artifacts: %s", err.Error()), nil
+	}
+	if len(reports) == 0 {
+		if len(parseErrors) > 0 {
+			return bean.SummaryFailed, strings.Join(parseErrors, "\n"), nil
+		}
+		return bean.SummaryNoReports, bean.NoTestReportFoundMessage, nil
+	}
+	// reports which could not be parsed are reported along with the summary of the parsed ones
+	return bean.SummaryIngested, strings.Join(parseErrors, "\n"), helper.BuildTestSummary(reports)
+}
+
+func (impl *TestReportServiceImpl) parseReportsFromArtifactZip(artifactFile *os.File, declaredPaths []string) ([]*bean.ParsedReport, []string, error) {
+	fileInfo, err := artifactFile.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	zipReader, err := zip.NewReader(artifactFile, fileInfo.Size())
+	if err != nil {
+		return nil, nil, err
+	}
+	var reports []*bean.ParsedReport
+	var parseErrors []string
+	for _, entry := range zipReader.File {
+		if entry.FileInfo().IsDir() || !helper.IsDeclaredReportFile(entry.Name, declaredPaths) {
+			continue
+		}
+		if entry.UncompressedSize64 > bean.MaxReportFileSize {
+			parseErrors = append(parseErrors, fmt.Sprintf("report %s skipped, size exceeds %d bytes", entry.Name, bean.MaxReportFileSize))
+			continue
+		}
+		content, err := readZipEntry(entry)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("error in reading report %s: %s", entry.Name, err.Error()))
+			continue
+		}
+		if _, ok := parser.DetectFormat(entry.Name, content); !ok {
+			// declared directories may contain files other than reports
+			continue
+		}
+		report, err := parser.Parse(entry.Name, content)
+		if err != nil {
+			impl.logger.Warnw("error in parsing test report", "file", entry.Name, "err", err)
+			parseErrors = append(parseErrors, err.Error())
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, parseErrors, nil
+}
+
+func readZipEntry(entry *zip.File) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, bean.MaxReportFileSize))
+}
+
+func (impl *TestReportServiceImpl) saveTestSummary(ciWorkflowId int, status bean.SummaryStatus, message string, summary *bean.TestSummary) (*testReportRepository.CiWorkflowTestSummary, error) {
+	model, err := impl.ciWorkflowTestSummaryRepository.FindByCiWorkflowId(ciWorkflowId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching test summary", "ciWorkflowId", ciWorkflowId, "err", err)
+		return nil, err
+	}
+	isNew := err == pg.ErrNoRows
+	if isNew {
+		model = &testReportRepository.CiWorkflowTestSummary{
+			CiWorkflowId: ciWorkflowId,
+			AuditLog:     sql.NewDefaultAuditLog(userBean.SystemUserId),
+		}
+	} else {
+		model.UpdateAuditLog(userBean.SystemUserId)
+	}
+	model.Status = status
+	model.Message = message
+	adapter.SetTestSummary(model, summary)
+	if isNew {
+		err = impl.ciWorkflowTestSummaryRepository.Save(model)
+	} else {
+		err = impl.ciWorkflowTestSummaryRepository.Update(model)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving test summary", "ciWorkflowId", ciWorkflowId, "err", err)
+		return nil, err
+	}
+	return model, nil
+}
+
+func (impl *TestReportServiceImpl) GetTestSummary(ciPipelineId, ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error) {
+	if ciPipelineId > 0 {
+		if err := impl.validateWorkflowPipeline(ciPipelineId, ciWorkflowId); err != nil {
+			return nil, err
+		}
+	}
+	summary, err := impl.ciWorkflowTestSummaryRepository.FindByCiWorkflowId(ciWorkflowId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching test summary", "ciWorkflowId", ciWorkflowId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return impl.IngestTestReports(ciWorkflowId)
+	}
+	return adapter.BuildTestSummaryDto(summary, ciPipelineId), nil
+}
+
+func (impl *TestReportServiceImpl) RefreshTestSummary(ciPipelineId, ciWorkflowId int) (*bean.CiWorkflowTestSummaryDto, error) {
+	if err := impl.validateWorkflowPipeline(ciPipelineId, ciWorkflowId); err != nil {
+		return nil, err
+	}
+	return impl.IngestTestReports(ciWorkflowId)
+}
+
+func (impl *TestReportServiceImpl) validateWorkflowPipeline(ciPipelineId, ciWorkflowId int) error {
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(ciWorkflowId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci workflow", "ciWorkflowId", ciWorkflowId, "err", err)
+		return err
+	}
+	if err == pg.ErrNoRows || ciWorkflow.CiPipelineId != ciPipelineId {
+		return util.NewApiError(http.StatusNotFound, "ci workflow not found for the pipeline", "ci workflow not found for the pipeline")
+	}
+	return nil
+}
+
+func (impl *TestReportServiceImpl) GetTestSummaryForArtifact(artifact *repository.CiArtifact) (*bean.CiWorkflowTestSummaryDto, error) {
+	workflowId := artifact.WorkflowId
+	if workflowId == nil && artifact.ParentCiArtifact > 0 {
+		// artifacts of linked ci pipelines refer to the artifact built by the source pipeline
+		parentArtifact, err := impl.ciArtifactRepository.Get(artifact.ParentCiArtifact)
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("error in fetching parent ci artifact", "parentCiArtifact", artifact.ParentCiArtifact, "err", err)
+			return nil, err
+		} else if err == nil {
+			workflowId = parentArtifact.WorkflowId
+		}
+	}
+	if workflowId == nil || *workflowId == 0 {
+		return nil, nil
+	}
+	return impl.GetTestSummary(0, *workflowId)
+}
+
+func (impl *TestReportServiceImpl) getDeclaredTestReportPaths(ciPipelineId int) ([]string, error) {
+	stages, err := impl.pipelineStageRepository.GetAllCiStagesByCiPipelineId(ciPipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci stages", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	}
+	var declaredPaths []string
+	for _, stage := range stages {
+		steps, err := impl.pipelineStageRepository.GetAllStepsByStageId(stage.Id)
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("error in fetching ci stage steps", "stageId", stage.Id, "err", err)
+			return nil, err
+		}
+		for _, step := range steps {
+			declaredPaths = append(declaredPaths, step.TestReportPaths...)
+		}
+	}
+	return declaredPaths, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/helper"
+	"github.com/devtron-labs/devtron/pkg/build/testReport/repository"
+)
+
+func BuildTestSummaryDto(summary *repository.CiWorkflowTestSummary, ciPipelineId int) *bean.CiWorkflowTestSummaryDto {
+	dto := &bean.CiWorkflowTestSummaryDto{
+		CiWorkflowId: summary.CiWorkflowId,
+		CiPipelineId: ciPipelineId,
+		Status:       summary.Status,
+		Message:      summary.Message,
+		IngestedOn:   summary.UpdatedOn,
+	}
+	if summary.Status == bean.SummaryIngested {
+		dto.TestSummary = &bean.TestSummary{
+			Total:        summary.Total,
+			Passed:       summary.Passed,
+			Failed:       summary.Failed,
+			Skipped:      summary.Skipped,
+			DurationMs:   summary.DurationMs,
+			PassRate:     helper.GetPassRate(summary.Passed, summary.Total, summary.Skipped),
+			Formats:      summary.Formats,
+			ReportFiles:  summary.ReportFiles,
+			FailedTests:  summary.FailedTests,
+			SlowestTests: summary.SlowestTests,
+		}
+	}
+	return dto
+}
+
+// SetTestSummary copies the aggregated result on the db model, counters are reset when summary is nil
+func SetTestSummary(model *repository.CiWorkflowTestSummary, summary *bean.TestSummary) {
+	if summary == nil {
+		summary = &bean.TestSummary{}
+	}
+	model.Total = summary.Total
+	model.Passed = summary.Passed
+	model.Failed = summary.Failed
+	model.Skipped = summary.Skipped
+	model.DurationMs = summary.DurationMs
+	model.Formats = summary.Formats
+	model.ReportFiles = summary.ReportFiles
+	model.FailedTests = summary.FailedTests
+	model.SlowestTests = summary.SlowestTests
+}
+
+func BuildTestGateDto(gate *repository.CdPipelineTestGate) *bean.TestGateConfigDto {
+	return &bean.TestGateConfigDto{
+		Id:                  gate.Id,
+		PipelineId:          gate.PipelineId,
+		BlockOnFailedTests:  gate.BlockOnFailedTests,
+		MinPassRate:         gate.MinPassRate,
+		FailIfReportMissing: gate.FailIfReportMissing,
+	}
+}
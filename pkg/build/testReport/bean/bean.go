@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type ReportFormat string
+
+const (
+	ReportFormatJUnit     ReportFormat = "JUNIT"
+	ReportFormatTest2Json ReportFormat = "GO_TEST2JSON"
+	ReportFormatTAP       ReportFormat = "TAP"
+)
+
+func (f ReportFormat) String() string {
+	return string(f)
+}
+
+type TestCaseStatus string
+
+const (
+	TestCasePassed  TestCaseStatus = "PASSED"
+	TestCaseFailed  TestCaseStatus = "FAILED"
+	TestCaseSkipped TestCaseStatus = "SKIPPED"
+)
+
+type SummaryStatus string
+
+const (
+	// SummaryIngested is set once at least one report was parsed for the workflow
+	SummaryIngested SummaryStatus = "INGESTED"
+	// SummaryNoReports is set when no report was found in the uploaded artifacts of the workflow
+	SummaryNoReports SummaryStatus = "NO_REPORTS"
+	// SummaryFailed is set when reports could not be fetched or parsed
+	SummaryFailed SummaryStatus = "FAILED"
+)
+
+func (s SummaryStatus) String() string {
+	return string(s)
+}
+
+const (
+	MaxFailedTestsInSummary  = 100
+	MaxSlowestTestsInSummary = 10
+	// MaxFailureMessageLength caps the failure message stored for a test case, stack traces are usually longer
+	MaxFailureMessageLength = 2000
+	// MaxReportFileSize is the max size of a single report file read from the artifact zip
+	MaxReportFileSize = 50 * 1024 * 1024
+)
+
+const (
+	NoTestReportPathsDeclaredMessage = "no test report paths declared on the ci pipeline steps"
+	NoTestReportFoundMessage         = "no test report found in uploaded artifacts"
+	ArtifactsNotUploadedMessage      = "artifacts were not uploaded for the workflow"
+)
+
+// TestCaseResult is a single test case parsed from a report
+type TestCaseResult struct {
+	Suite     string         `json:"suite,omitempty"`
+	ClassName string         `json:"className,omitempty"`
+	Name      string         `json:"name"`
+	Status    TestCaseStatus `json:"status"`
+	// DurationMs is the time taken by the test case in milliseconds
+	DurationMs int64  `json:"durationMs"`
+	Message    string `json:"message,omitempty"`
+}
+
+// ParsedReport is the result of parsing a single report file
+type ParsedReport struct {
+	FileName  string
+	Format    ReportFormat
+	TestCases []*TestCaseResult
+}
+
+// TestSummary is the aggregated test result of a ci workflow
+type TestSummary struct {
+	Total        int               `json:"total"`
+	Passed       int               `json:"passed"`
+	Failed       int               `json:"failed"`
+	Skipped      int               `json:"skipped"`
+	DurationMs   int64             `json:"durationMs"`
+	PassRate     float64           `json:"passRate"`
+	Formats      []string          `json:"formats"`
+	ReportFiles  []string          `json:"reportFiles"`
+	FailedTests  []*TestCaseResult `json:"failedTests"`
+	SlowestTests []*TestCaseResult `json:"slowestTests"`
+}
+
+// CiWorkflowTestSummaryDto is the test summary of a ci workflow exposed via api
+type CiWorkflowTestSummaryDto struct {
+	CiWorkflowId int           `json:"ciWorkflowId"`
+	CiPipelineId int           `json:"ciPipelineId"`
+	Status       SummaryStatus `json:"status"`
+	Message      string        `json:"message,omitempty"`
+	*TestSummary
+	IngestedOn time.Time `json:"ingestedOn"`
+}
+
+// TestGateConfigDto is the test gate configured on a cd pipeline, evaluated on the test summary
+// of the ci workflow which built the artifact being deployed
+type TestGateConfigDto struct {
+	Id                 int  `json:"id"`
+	PipelineId         int  `json:"pipelineId" validate:"required,min=1"`
+	BlockOnFailedTests bool `json:"blockOnFailedTests"`
+	// MinPassRate is the minimum percentage of passed tests out of the tests which were not skipped, 0 disables the check
+	MinPassRate         float64 `json:"minPassRate" validate:"min=0,max=100"`
+	FailIfReportMissing bool    `json:"failIfReportMissing"`
+	UserId              int32   `json:"-"`
+}
+
+// TestGateEvaluation is the result of evaluating a test gate for an artifact
+type TestGateEvaluation struct {
+	PipelineId  int                       `json:"pipelineId"`
+	ArtifactId  int                       `json:"artifactId"`
+	GateEnabled bool                      `json:"gateEnabled"`
+	Allowed     bool                      `json:"allowed"`
+	Reasons     []string                  `json:"reasons,omitempty"`
+	TestSummary *CiWorkflowTestSummaryDto `json:"testSummary,omitempty"`
+	TestGate    *TestGateConfigDto        `json:"testGate,omitempty"`
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+// IsDeclaredReportFile checks if an entry of the uploaded artifact zip belongs to one of the declared report paths.
+// The ci runner keeps the absolute path of uploaded files inside the zip, a declared path matches an entry
+// if it is the same file, a parent directory of it or a glob matching it; zip entries may carry an extra prefix
+func IsDeclaredReportFile(entryName string, declaredPaths []string) bool {
+	entryName = strings.TrimPrefix(path.Clean("/"+entryName), "/")
+	for _, declaredPath := range declaredPaths {
+		declaredPath = strings.TrimPrefix(path.Clean("/"+strings.TrimSpace(declaredPath)), "/")
+		if len(declaredPath) == 0 {
+			continue
+		}
+		if entryName == declaredPath || strings.HasSuffix(entryName, "/"+declaredPath) {
+			return true
+		}
+		if strings.HasPrefix(entryName, declaredPath+"/") || strings.Contains(entryName, "/"+declaredPath+"/") {
+			return true
+		}
+		if strings.ContainsAny(declaredPath, "*?[") && matchGlobSuffix(entryName, declaredPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlobSuffix matches the glob against the trailing path segments of the entry
+func matchGlobSuffix(entryName, pattern string) bool {
+	entrySegments := strings.Split(entryName, "/")
+	patternSegmentCount := len(strings.Split(pattern, "/"))
+	if patternSegmentCount > len(entrySegments) {
+		return false
+	}
+	suffix := strings.Join(entrySegments[len(entrySegments)-patternSegmentCount:], "/")
+	matched, err := path.Match(pattern, suffix)
+	return err == nil && matched
+}
+
+// BuildTestSummary aggregates parsed reports into a single summary
+func BuildTestSummary(reports []*bean.ParsedReport) *bean.TestSummary {
+	summary := &bean.TestSummary{
+		Formats:      make([]string, 0),
+		ReportFiles:  make([]string, 0),
+		FailedTests:  make([]*bean.TestCaseResult, 0),
+		SlowestTests: make([]*bean.TestCaseResult, 0),
+	}
+	formats := make(map[bean.ReportFormat]bool)
+	var allTestCases []*bean.TestCaseResult
+	for _, report := range reports {
+		summary.ReportFiles = append(summary.ReportFiles, report.FileName)
+		if !formats[report.Format] {
+			formats[report.Format] = true
+			summary.Formats = append(summary.Formats, report.Format.String())
+		}
+		for _, testCase := range report.TestCases {
+			summary.Total++
+			summary.DurationMs += testCase.DurationMs
+			switch testCase.Status {
+			case bean.TestCasePassed:
+				summary.Passed++
+			case bean.TestCaseFailed:
+				summary.Failed++
+				if len(summary.FailedTests) < bean.MaxFailedTestsInSummary {
+					summary.FailedTests = append(summary.FailedTests, testCase)
+				}
+			case bean.TestCaseSkipped:
+				summary.Skipped++
+			}
+			allTestCases = append(allTestCases, testCase)
+		}
+	}
+	summary.PassRate = GetPassRate(summary.Passed, summary.Total, summary.Skipped)
+	sort.SliceStable(allTestCases, func(i, j int) bool {
+		return allTestCases[i].DurationMs > allTestCases[j].DurationMs
+	})
+	for _, testCase := range allTestCases {
+		if len(summary.SlowestTests) >= bean.MaxSlowestTestsInSummary {
+			break
+		}
+		if testCase.Status == bean.TestCaseSkipped {
+			continue
+		}
+		summary.SlowestTests = append(summary.SlowestTests, testCase)
+	}
+	return summary
+}
+
+// GetPassRate returns the percentage of passed tests out of the executed ones, skipped tests are not counted
+func GetPassRate(passed, total, skipped int) float64 {
+	executed := total - skipped
+	if executed <= 0 {
+		return 100
+	}
+	return float64(passed) * 100 / float64(executed)
+}
+
+// EvaluateTestGate returns the reasons for which a test summary does not satisfy the gate, no reasons means allowed
+func EvaluateTestGate(gate *bean.TestGateConfigDto, summary *bean.CiWorkflowTestSummaryDto) []string {
+	reasons := make([]string, 0)
+	if summary == nil || summary.Status != bean.SummaryIngested || summary.TestSummary == nil {
+		if gate.FailIfReportMissing {
+			reasons = append(reasons, "test report not available for the artifact")
+		}
+		return reasons
+	}
+	if gate.BlockOnFailedTests && summary.Failed > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d of %d tests failed", summary.Failed, summary.Total))
+	}
+	if gate.MinPassRate > 0 && summary.PassRate < gate.MinPassRate {
+		reasons = append(reasons, fmt.Sprintf("pass rate %.2f%% is below the required %.2f%%", summary.PassRate, gate.MinPassRate))
+	}
+	return reasons
+}
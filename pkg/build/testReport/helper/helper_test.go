@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+func TestIsDeclaredReportFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		entryName     string
+		declaredPaths []string
+		want          bool
+	}{
+		{name: "exact file", entryName: "app/reports/junit.xml", declaredPaths: []string{"/app/reports/junit.xml"}, want: true},
+		{name: "file under directory", entryName: "app/reports/unit/junit.xml", declaredPaths: []string{"/app/reports"}, want: true},
+		{name: "zip entry with prefix", entryName: "artifacts/app/reports/junit.xml", declaredPaths: []string{"/app/reports"}, want: true},
+		{name: "glob", entryName: "app/reports/TEST-calc.xml", declaredPaths: []string{"/app/reports/TEST-*.xml"}, want: true},
+		{name: "glob not matching", entryName: "app/reports/coverage.xml", declaredPaths: []string{"/app/reports/TEST-*.xml"}, want: false},
+		{name: "sibling directory", entryName: "app/reports-old/junit.xml", declaredPaths: []string{"/app/reports"}, want: false},
+		{name: "no declared paths", entryName: "app/reports/junit.xml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDeclaredReportFile(tt.entryName, tt.declaredPaths); got != tt.want {
+				t.Errorf("IsDeclaredReportFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTestSummary(t *testing.T) {
+	reports := []*bean.ParsedReport{
+		{
+			FileName: "junit.xml",
+			Format:   bean.ReportFormatJUnit,
+			TestCases: []*bean.TestCaseResult{
+				{Name: "a", Status: bean.TestCasePassed, DurationMs: 10},
+				{Name: "b", Status: bean.TestCaseFailed, DurationMs: 300, Message: "boom"},
+				{Name: "c", Status: bean.TestCaseSkipped, DurationMs: 900},
+			},
+		},
+		{
+			FileName: "go.json",
+			Format:   bean.ReportFormatTest2Json,
+			TestCases: []*bean.TestCaseResult{
+				{Name: "d", Status: bean.TestCasePassed, DurationMs: 200},
+				{Name: "e", Status: bean.TestCasePassed, DurationMs: 50},
+			},
+		},
+	}
+	summary := BuildTestSummary(reports)
+	if summary.Total != 5 || summary.Passed != 3 || summary.Failed != 1 || summary.Skipped != 1 {
+		t.Fatalf("unexpected totals %+v", summary)
+	}
+	if summary.DurationMs != 1460 {
+		t.Errorf("DurationMs = %d, want 1460", summary.DurationMs)
+	}
+	if summary.PassRate != 75 {
+		t.Errorf("PassRate = %v, want 75", summary.PassRate)
+	}
+	if len(summary.FailedTests) != 1 || summary.FailedTests[0].Message != "boom" {
+		t.Errorf("unexpected failed tests %+v", summary.FailedTests)
+	}
+	if len(summary.SlowestTests) != 4 || summary.SlowestTests[0].Name != "b" || summary.SlowestTests[1].Name != "d" {
+		t.Errorf("unexpected slowest tests order")
+	}
+	if len(summary.Formats) != 2 {
+		t.Errorf("Formats = %v, want 2 formats", summary.Formats)
+	}
+}
+
+func TestEvaluateTestGate(t *testing.T) {
+	ingested := func(total, passed, failed, skipped int) *bean.CiWorkflowTestSummaryDto {
+		return &bean.CiWorkflowTestSummaryDto{
+			Status: bean.SummaryIngested,
+			TestSummary: &bean.TestSummary{
+				Total: total, Passed: passed, Failed: failed, Skipped: skipped,
+				PassRate: GetPassRate(passed, total, skipped),
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		gate        *bean.TestGateConfigDto
+		summary     *bean.CiWorkflowTestSummaryDto
+		wantAllowed bool
+	}{
+		{name: "no failures", gate: &bean.TestGateConfigDto{BlockOnFailedTests: true}, summary: ingested(10, 10, 0, 0), wantAllowed: true},
+		{name: "failed tests blocked", gate: &bean.TestGateConfigDto{BlockOnFailedTests: true}, summary: ingested(10, 9, 1, 0), wantAllowed: false},
+		{name: "pass rate met", gate: &bean.TestGateConfigDto{MinPassRate: 90}, summary: ingested(10, 9, 1, 0), wantAllowed: true},
+		{name: "pass rate not met", gate: &bean.TestGateConfigDto{MinPassRate: 95}, summary: ingested(10, 9, 1, 0), wantAllowed: false},
+		{name: "skipped tests ignored in pass rate", gate: &bean.TestGateConfigDto{MinPassRate: 100}, summary: ingested(10, 8, 0, 2), wantAllowed: true},
+		{name: "missing report allowed", gate: &bean.TestGateConfigDto{BlockOnFailedTests: true}, summary: nil, wantAllowed: true},
+		{name: "missing report blocked", gate: &bean.TestGateConfigDto{FailIfReportMissing: true}, summary: &bean.CiWorkflowTestSummaryDto{Status: bean.SummaryNoReports}, wantAllowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := EvaluateTestGate(tt.gate, tt.summary)
+			if (len(reasons) == 0) != tt.wantAllowed {
+				t.Errorf("EvaluateTestGate() reasons = %v, wantAllowed %v", reasons, tt.wantAllowed)
+			}
+		})
+	}
+}
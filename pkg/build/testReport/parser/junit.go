@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	XMLName    xml.Name          `xml:"testsuite"`
+	Name       string            `xml:"name,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+	TestCases  []*junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *junitFailure `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func (f *junitFailure) text() string {
+	if len(strings.TrimSpace(f.Body)) == 0 {
+		return f.Message
+	}
+	if len(f.Message) == 0 {
+		return f.Body
+	}
+	return f.Message + "\n" + f.Body
+}
+
+// parseJUnit parses a JUnit XML report having either <testsuites> or <testsuite> as the root element
+func parseJUnit(content []byte) ([]*bean.TestCaseResult, error) {
+	var suites []*junitTestSuite
+	root := &junitTestSuites{}
+	err := xml.Unmarshal(content, root)
+	if err == nil {
+		suites = root.TestSuites
+	} else {
+		suite := &junitTestSuite{}
+		if suiteErr := xml.Unmarshal(content, suite); suiteErr != nil {
+			return nil, err
+		}
+		suites = []*junitTestSuite{suite}
+	}
+	testCases := make([]*bean.TestCaseResult, 0)
+	for _, suite := range suites {
+		testCases = appendJUnitSuite(testCases, suite)
+	}
+	return testCases, nil
+}
+
+func appendJUnitSuite(testCases []*bean.TestCaseResult, suite *junitTestSuite) []*bean.TestCaseResult {
+	for _, testCase := range suite.TestCases {
+		result := &bean.TestCaseResult{
+			Suite:      suite.Name,
+			ClassName:  testCase.ClassName,
+			Name:       testCase.Name,
+			Status:     bean.TestCasePassed,
+			DurationMs: secondsToMillis(testCase.Time),
+		}
+		switch {
+		case testCase.Failure != nil:
+			result.Status = bean.TestCaseFailed
+			result.Message = truncateMessage(testCase.Failure.text())
+		case testCase.Error != nil:
+			result.Status = bean.TestCaseFailed
+			result.Message = truncateMessage(testCase.Error.text())
+		case testCase.Skipped != nil:
+			result.Status = bean.TestCaseSkipped
+			result.Message = truncateMessage(testCase.Skipped.text())
+		}
+		testCases = append(testCases, result)
+	}
+	for _, nestedSuite := range suite.TestSuites {
+		testCases = appendJUnitSuite(testCases, nestedSuite)
+	}
+	return testCases
+}
+
+func secondsToMillis(seconds string) int64 {
+	// some reporters format the time with thousands separators
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(seconds), ",", ""), 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+	return int64(value * 1000)
+}
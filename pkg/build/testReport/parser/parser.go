@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+// Parse parses a test report, the format is detected from the file extension and falls back to the content
+func Parse(fileName string, content []byte) (*bean.ParsedReport, error) {
+	format, ok := DetectFormat(fileName, content)
+	if !ok {
+		return nil, fmt.Errorf("unsupported test report format for file %s", fileName)
+	}
+	var testCases []*bean.TestCaseResult
+	var err error
+	switch format {
+	case bean.ReportFormatJUnit:
+		testCases, err = parseJUnit(content)
+	case bean.ReportFormatTest2Json:
+		testCases, err = parseTest2Json(content)
+	case bean.ReportFormatTAP:
+		testCases, err = parseTAP(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing %s report %s: %w", format, fileName, err)
+	}
+	return &bean.ParsedReport{
+		FileName:  fileName,
+		Format:    format,
+		TestCases: testCases,
+	}, nil
+}
+
+// DetectFormat returns the report format of a file, false is returned if the file is not a supported report
+func DetectFormat(fileName string, content []byte) (bean.ReportFormat, bool) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".xml":
+		return bean.ReportFormatJUnit, true
+	case ".json", ".jsonl":
+		return bean.ReportFormatTest2Json, true
+	case ".tap":
+		return bean.ReportFormatTAP, true
+	}
+	trimmed := bytes.TrimSpace(content)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return bean.ReportFormatJUnit, true
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return bean.ReportFormatTest2Json, true
+	case bytes.HasPrefix(trimmed, []byte("TAP version")), bytes.HasPrefix(trimmed, []byte("1..")),
+		bytes.HasPrefix(trimmed, []byte("ok ")), bytes.HasPrefix(trimmed, []byte("not ok ")):
+		return bean.ReportFormatTAP, true
+	}
+	return "", false
+}
+
+func truncateMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) > bean.MaxFailureMessageLength {
+		return message[:bean.MaxFailureMessageLength]
+	}
+	return message
+}
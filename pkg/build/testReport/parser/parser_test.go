@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+const junitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="calculator" tests="4">
+    <testcase classname="calc.AddTest" name="adds" time="0.120"/>
+    <testcase classname="calc.AddTest" name="overflows" time="1.5">
+      <failure message="expected 0 but was 1">at AddTest.java:12</failure>
+    </testcase>
+    <testcase classname="calc.DivTest" name="by zero" time="0.010">
+      <error message="ArithmeticException"/>
+    </testcase>
+    <testcase classname="calc.DivTest" name="pending" time="0">
+      <skipped/>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+const test2JsonReport = `go: downloading github.com/stretchr/testify v1.8.4
+{"Action":"run","Package":"example.com/calc","Test":"TestAdd"}
+{"Action":"output","Package":"example.com/calc","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"pass","Package":"example.com/calc","Test":"TestAdd","Elapsed":0.25}
+{"Action":"run","Package":"example.com/calc","Test":"TestDiv"}
+{"Action":"output","Package":"example.com/calc","Test":"TestDiv","Output":"    calc_test.go:20: division mismatch\n"}
+{"Action":"fail","Package":"example.com/calc","Test":"TestDiv","Elapsed":0.5}
+{"Action":"skip","Package":"example.com/calc","Test":"TestMod","Elapsed":0}
+{"Action":"fail","Package":"example.com/calc","Elapsed":0.8}
+`
+
+const tapReport = `TAP version 13
+1..4
+ok 1 - adds numbers
+not ok 2 - divides numbers
+  ---
+  message: 'expected 2, got 3'
+  duration_ms: 42
+  ...
+ok 3 - modulo # SKIP not implemented
+not ok 4 - power # TODO later
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		content     string
+		wantFormat  bean.ReportFormat
+		wantStatus  []bean.TestCaseStatus
+		wantMessage map[int]string
+	}{
+		{
+			name:       "junit",
+			fileName:   "reports/junit.xml",
+			content:    junitReport,
+			wantFormat: bean.ReportFormatJUnit,
+			wantStatus: []bean.TestCaseStatus{bean.TestCasePassed, bean.TestCaseFailed, bean.TestCaseFailed, bean.TestCaseSkipped},
+			wantMessage: map[int]string{
+				1: "expected 0 but was 1\nat AddTest.java:12",
+				2: "ArithmeticException",
+			},
+		},
+		{
+			name:       "junit single suite detected from content",
+			fileName:   "report",
+			content:    `<testsuite name="s"><testcase name="a" time="1"/></testsuite>`,
+			wantFormat: bean.ReportFormatJUnit,
+			wantStatus: []bean.TestCaseStatus{bean.TestCasePassed},
+		},
+		{
+			name:       "go test2json",
+			fileName:   "go-test.json",
+			content:    test2JsonReport,
+			wantFormat: bean.ReportFormatTest2Json,
+			wantStatus: []bean.TestCaseStatus{bean.TestCasePassed, bean.TestCaseFailed, bean.TestCaseSkipped},
+			wantMessage: map[int]string{
+				1: "calc_test.go:20: division mismatch",
+			},
+		},
+		{
+			name:       "tap",
+			fileName:   "results.tap",
+			content:    tapReport,
+			wantFormat: bean.ReportFormatTAP,
+			wantStatus: []bean.TestCaseStatus{bean.TestCasePassed, bean.TestCaseFailed, bean.TestCaseSkipped, bean.TestCaseSkipped},
+			wantMessage: map[int]string{
+				1: "message: 'expected 2, got 3'\nduration_ms: 42",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Parse(tt.fileName, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if report.Format != tt.wantFormat {
+				t.Fatalf("Parse() format = %s, want %s", report.Format, tt.wantFormat)
+			}
+			if len(report.TestCases) != len(tt.wantStatus) {
+				t.Fatalf("Parse() got %d test cases, want %d", len(report.TestCases), len(tt.wantStatus))
+			}
+			for i, testCase := range report.TestCases {
+				if testCase.Status != tt.wantStatus[i] {
+					t.Errorf("test case %d status = %s, want %s", i, testCase.Status, tt.wantStatus[i])
+				}
+				if message, ok := tt.wantMessage[i]; ok && testCase.Message != message {
+					t.Errorf("test case %d message = %q, want %q", i, testCase.Message, message)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDurations(t *testing.T) {
+	report, err := Parse("junit.xml", []byte(junitReport))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if report.TestCases[1].DurationMs != 1500 {
+		t.Errorf("junit duration = %d, want 1500", report.TestCases[1].DurationMs)
+	}
+	report, err = Parse("results.tap", []byte(tapReport))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if report.TestCases[1].DurationMs != 42 {
+		t.Errorf("tap duration = %d, want 42", report.TestCases[1].DurationMs)
+	}
+}
+
+func TestParseUnsupported(t *testing.T) {
+	if _, err := Parse("coverage.out", []byte("mode: set\n")); err == nil {
+		t.Fatal("Parse() expected error for unsupported report")
+	}
+	if _, err := Parse("broken.xml", []byte("<html></html>")); err == nil {
+		t.Fatal("Parse() expected error for non junit xml")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+var (
+	// tapTestLineRegex matches "ok 1 - description # directive" and "not ok 2 description"
+	tapTestLineRegex = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?([^#]*)(?:#\s*(.*))?$`)
+	tapDurationRegex = regexp.MustCompile(`^duration_ms:\s*([0-9.]+)`)
+)
+
+const (
+	tapSkipDirective       = "SKIP"
+	tapTodoDirective       = "TODO"
+	tapYamlBlockStart      = "---"
+	tapYamlBlockEnd        = "..."
+	tapBailOutPrefix       = "Bail out!"
+	tapSubtestIndentPrefix = " "
+)
+
+// parseTAP parses a Test Anything Protocol report, YAML diagnostic blocks following a test line
+// are used as the failure message and for the duration_ms key
+func parseTAP(content []byte) ([]*bean.TestCaseResult, error) {
+	testCases := make([]*bean.TestCaseResult, 0)
+	var lastResult *bean.TestCaseResult
+	var yamlBlock *strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), bean.MaxReportFileSize)
+	for scanner.Scan() {
+		rawLine := scanner.Text()
+		line := strings.TrimSpace(rawLine)
+		if yamlBlock != nil {
+			if line == tapYamlBlockEnd {
+				if lastResult != nil && lastResult.Status == bean.TestCaseFailed {
+					lastResult.Message = truncateMessage(yamlBlock.String())
+				}
+				yamlBlock = nil
+				continue
+			}
+			if match := tapDurationRegex.FindStringSubmatch(line); match != nil && lastResult != nil {
+				if duration, err := strconv.ParseFloat(match[1], 64); err == nil {
+					lastResult.DurationMs = int64(duration)
+				}
+			}
+			yamlBlock.WriteString(line)
+			yamlBlock.WriteString("\n")
+			continue
+		}
+		if line == tapYamlBlockStart {
+			yamlBlock = &strings.Builder{}
+			continue
+		}
+		if strings.HasPrefix(line, tapBailOutPrefix) {
+			break
+		}
+		// subtests are indented, only the top level results are counted
+		if strings.HasPrefix(rawLine, tapSubtestIndentPrefix) || strings.HasPrefix(rawLine, "\t") {
+			continue
+		}
+		match := tapTestLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		name := strings.TrimSpace(match[3])
+		if len(name) == 0 {
+			name = match[2]
+		}
+		result := &bean.TestCaseResult{
+			Name:   name,
+			Status: bean.TestCasePassed,
+		}
+		directive := strings.ToUpper(strings.TrimSpace(match[4]))
+		switch {
+		case strings.HasPrefix(directive, tapSkipDirective), strings.HasPrefix(directive, tapTodoDirective):
+			// failing TODO tests are not treated as failures as per the TAP spec
+			result.Status = bean.TestCaseSkipped
+			result.Message = strings.TrimSpace(match[4])
+		case match[1] == "not ok":
+			result.Status = bean.TestCaseFailed
+		}
+		testCases = append(testCases, result)
+		lastResult = result
+	}
+	return testCases, scanner.Err()
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+)
+
+// test2JsonEvent is a single event emitted by `go test -json`
+type test2JsonEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+const (
+	test2JsonActionPass   = "pass"
+	test2JsonActionFail   = "fail"
+	test2JsonActionSkip   = "skip"
+	test2JsonActionOutput = "output"
+)
+
+// parseTest2Json parses the output of `go test -json`, lines which are not json events are ignored
+// as the report usually contains build output as well
+func parseTest2Json(content []byte) ([]*bean.TestCaseResult, error) {
+	testCases := make([]*bean.TestCaseResult, 0)
+	outputs := make(map[string]*strings.Builder)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), bean.MaxReportFileSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		event := &test2JsonEvent{}
+		if err := json.Unmarshal(line, event); err != nil || len(event.Test) == 0 {
+			continue
+		}
+		key := event.Package + "/" + event.Test
+		switch event.Action {
+		case test2JsonActionOutput:
+			output, ok := outputs[key]
+			if !ok {
+				output = &strings.Builder{}
+				outputs[key] = output
+			}
+			if output.Len() < bean.MaxFailureMessageLength {
+				output.WriteString(event.Output)
+			}
+		case test2JsonActionPass, test2JsonActionFail, test2JsonActionSkip:
+			result := &bean.TestCaseResult{
+				Suite:      event.Package,
+				Name:       event.Test,
+				Status:     bean.TestCasePassed,
+				DurationMs: int64(event.Elapsed * 1000),
+			}
+			if event.Action == test2JsonActionFail {
+				result.Status = bean.TestCaseFailed
+			} else if event.Action == test2JsonActionSkip {
+				result.Status = bean.TestCaseSkipped
+			}
+			if output, ok := outputs[key]; ok && result.Status == bean.TestCaseFailed {
+				result.Message = truncateMessage(output.String())
+			}
+			delete(outputs, key)
+			testCases = append(testCases, result)
+		}
+	}
+	return testCases, scanner.Err()
+}
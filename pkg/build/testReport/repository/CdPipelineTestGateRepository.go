@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CdPipelineTestGate struct {
+	tableName           struct{} `sql:"cd_pipeline_test_gate" pg:",discard_unknown_columns"`
+	Id                  int      `sql:"id,pk"`
+	PipelineId          int      `sql:"pipeline_id,notnull"`
+	BlockOnFailedTests  bool     `sql:"block_on_failed_tests,notnull"`
+	MinPassRate         float64  `sql:"min_pass_rate,notnull"`
+	FailIfReportMissing bool     `sql:"fail_if_report_missing,notnull"`
+	Active              bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type CdPipelineTestGateRepository interface {
+	Save(gate *CdPipelineTestGate) error
+	Update(gate *CdPipelineTestGate) error
+	FindActiveByPipelineId(pipelineId int) (*CdPipelineTestGate, error)
+	MarkInactiveByPipelineId(pipelineId int, userId int32) error
+}
+
+type CdPipelineTestGateRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCdPipelineTestGateRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CdPipelineTestGateRepositoryImpl {
+	return &CdPipelineTestGateRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CdPipelineTestGateRepositoryImpl) Save(gate *CdPipelineTestGate) error {
+	return impl.dbConnection.Insert(gate)
+}
+
+func (impl *CdPipelineTestGateRepositoryImpl) Update(gate *CdPipelineTestGate) error {
+	return impl.dbConnection.Update(gate)
+}
+
+func (impl *CdPipelineTestGateRepositoryImpl) FindActiveByPipelineId(pipelineId int) (*CdPipelineTestGate, error) {
+	gate := &CdPipelineTestGate{}
+	err := impl.dbConnection.Model(gate).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Limit(1).
+		Select()
+	return gate, err
+}
+
+func (impl *CdPipelineTestGateRepositoryImpl) MarkInactiveByPipelineId(pipelineId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*CdPipelineTestGate)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
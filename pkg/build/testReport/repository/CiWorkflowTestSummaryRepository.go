@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/build/testReport/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type CiWorkflowTestSummary struct {
+	tableName    struct{}               `sql:"ci_workflow_test_summary" pg:",discard_unknown_columns"`
+	Id           int                    `sql:"id,pk"`
+	CiWorkflowId int                    `sql:"ci_workflow_id,notnull"`
+	Status       bean.SummaryStatus     `sql:"status,notnull"`
+	Total        int                    `sql:"total,notnull"`
+	Passed       int                    `sql:"passed,notnull"`
+	Failed       int                    `sql:"failed,notnull"`
+	Skipped      int                    `sql:"skipped,notnull"`
+	DurationMs   int64                  `sql:"duration_ms,notnull"`
+	Formats      []string               `sql:"formats" pg:",array"`
+	ReportFiles  []string               `sql:"report_files" pg:",array"`
+	FailedTests  []*bean.TestCaseResult `sql:"failed_tests"`
+	SlowestTests []*bean.TestCaseResult `sql:"slowest_tests"`
+	Message      string                 `sql:"message"`
+	sql.AuditLog
+}
+
+type CiWorkflowTestSummaryRepository interface {
+	Save(summary *CiWorkflowTestSummary) error
+	Update(summary *CiWorkflowTestSummary) error
+	FindByCiWorkflowId(ciWorkflowId int) (*CiWorkflowTestSummary, error)
+}
+
+type CiWorkflowTestSummaryRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCiWorkflowTestSummaryRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CiWorkflowTestSummaryRepositoryImpl {
+	return &CiWorkflowTestSummaryRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CiWorkflowTestSummaryRepositoryImpl) Save(summary *CiWorkflowTestSummary) error {
+	return impl.dbConnection.Insert(summary)
+}
+
+func (impl *CiWorkflowTestSummaryRepositoryImpl) Update(summary *CiWorkflowTestSummary) error {
+	return impl.dbConnection.Update(summary)
+}
+
+func (impl *CiWorkflowTestSummaryRepositoryImpl) FindByCiWorkflowId(ciWorkflowId int) (*CiWorkflowTestSummary, error) {
+	summary := &CiWorkflowTestSummary{}
+	err := impl.dbConnection.Model(summary).
+		Where("ci_workflow_id = ?", ciWorkflowId).
+		Limit(1).
+		Select()
+	return summary, err
+}
@@ -32,6 +32,7 @@ import (
 	repository3 "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
 	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/app"
@@ -41,6 +42,7 @@ import (
 	bean6 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/devtron-labs/devtron/pkg/bean"
 	"github.com/devtron-labs/devtron/pkg/bean/common"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	buildCommonBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
@@ -82,6 +84,8 @@ type HandlerService interface {
 	StartCiWorkflowAndPrepareWfRequest(trigger *types.CiTriggerRequest) (map[string]string, *pipelineConfig.CiWorkflow, *types.WorkflowRequest, error)
 
 	CancelBuild(workflowId int, forceAbort bool) (int, error)
+	// DispatchQueuedBuilds starts the queued builds which fit in the free build slots
+	DispatchQueuedBuilds()
 	GetRunningWorkflowLogs(workflowId int, followLogs bool) (*bufio.Reader, func() error, error)
 	GetHistoricBuildLogs(workflowId int, ciWorkflow *pipelineConfig.CiWorkflow) (map[string]string, error)
 	DownloadCiWorkflowArtifacts(pipelineId int, buildId int) (*os.File, error)
@@ -125,6 +129,7 @@ type HandlerServiceImpl struct {
 	K8sUtil                      *k8s.K8sServiceImpl
 	asyncRunnable                *async.Runnable
 	workflowTriggerAuditService  auditService.WorkflowTriggerAuditService
+	ciBuildQueueService          buildQueue.CiBuildQueueService
 }
 
 func NewHandlerServiceImpl(Logger *zap.SugaredLogger, workflowService executor.WorkflowService,
@@ -152,6 +157,7 @@ func NewHandlerServiceImpl(Logger *zap.SugaredLogger, workflowService executor.W
 	K8sUtil *k8s.K8sServiceImpl,
 	asyncRunnable *async.Runnable,
 	workflowTriggerAuditService auditService.WorkflowTriggerAuditService,
+	ciBuildQueueService buildQueue.CiBuildQueueService,
 ) *HandlerServiceImpl {
 	buildxCacheFlags := &BuildxGlobalFlags{}
 	err := env.Parse(buildxCacheFlags)
@@ -187,6 +193,7 @@ func NewHandlerServiceImpl(Logger *zap.SugaredLogger, workflowService executor.W
 		K8sUtil:                      K8sUtil,
 		asyncRunnable:                asyncRunnable,
 		workflowTriggerAuditService:  workflowTriggerAuditService,
+		ciBuildQueueService:          ciBuildQueueService,
 	}
 	config, err := types.GetCiConfig()
 	if err != nil {
@@ -341,6 +348,10 @@ func (impl *HandlerServiceImpl) HandleCIManual(ciTriggerRequest bean.CiTriggerRe
 	if err != pg.ErrNoRows {
 		createdOn = ciArtifact.CreatedOn
 	}
+	triggerType := ciTriggerRequest.TriggerType
+	if len(triggerType) == 0 {
+		triggerType = workflow.CiTriggerTypeManual
+	}
 
 	trigger := &types.CiTriggerRequest{
 		PipelineId:          ciTriggerRequest.PipelineId,
@@ -352,6 +363,7 @@ func (impl *HandlerServiceImpl) HandleCIManual(ciTriggerRequest bean.CiTriggerRe
 		EnvironmentId:       ciTriggerRequest.EnvironmentId,
 		PipelineType:        ciTriggerRequest.PipelineType,
 		CiArtifactLastFetch: createdOn,
+		TriggerType:         triggerType,
 	}
 	id, err := impl.triggerCiPipeline(trigger)
 
@@ -405,6 +417,7 @@ func (impl *HandlerServiceImpl) HandleCIWebhook(gitCiTriggerRequest bean.GitCiTr
 		CiMaterials:       ciMaterials,
 		TriggeredBy:       gitCiTriggerRequest.TriggeredBy,
 		RuntimeParameters: runtimeParams,
+		TriggerType:       workflow.CiTriggerTypeWebhook,
 	}
 	id, err := impl.triggerCiPipeline(trigger)
 	if err != nil {
@@ -561,6 +574,9 @@ func (impl *HandlerServiceImpl) BuildManualTriggerCommitHashesForSourceTypeBranc
 		CiConfigureSourceValue: pipeLineMaterialFromDb.Value,
 		CiConfigureSourceType:  pipeLineMaterialFromDb.Type,
 	}
+	if len(ciPipelineMaterial.BranchOverride) > 0 {
+		gitCommit.CiConfigureSourceValue = ciPipelineMaterial.BranchOverride
+	}
 
 	return gitCommit, nil
 }
@@ -703,6 +719,9 @@ func (impl *HandlerServiceImpl) prepareCiWfRequest(trigger *types.CiTriggerReque
 }
 
 func (impl *HandlerServiceImpl) triggerCiPipeline(trigger *types.CiTriggerRequest) (int, error) {
+	if impl.isBuildQueueApplicable(trigger) {
+		return impl.enqueueCiTrigger(trigger)
+	}
 	variableSnapshot, savedCiWf, workflowRequest, err := impl.prepareCiWfRequest(trigger)
 	if err != nil {
 		impl.Logger.Errorw("error in preparing wf request", "triggerRequest", trigger, "err", err)
@@ -808,7 +827,12 @@ func (impl *HandlerServiceImpl) StartCiWorkflowAndPrepareWfRequest(trigger *type
 			AppName:   pipeline.App.AppName,
 		}
 	}
-	savedCiWf, err := impl.saveNewWorkflowForCITrigger(pipeline, ciWorkflowConfigNamespace, trigger.CommitHashes, trigger.TriggeredBy, ciMaterials, trigger.EnvironmentId, isJob, trigger.ReferenceCiWorkflowId)
+	var savedCiWf *pipelineConfig.CiWorkflow
+	if trigger.QueuedCiWorkflow != nil {
+		savedCiWf, err = impl.startQueuedCiWorkflow(trigger.QueuedCiWorkflow, pipeline, ciWorkflowConfigNamespace, ciMaterials, trigger.EnvironmentId, isJob)
+	} else {
+		savedCiWf, err = impl.saveNewWorkflowForCITrigger(pipeline, ciWorkflowConfigNamespace, trigger.CommitHashes, trigger.TriggeredBy, ciMaterials, trigger.EnvironmentId, isJob, trigger.ReferenceCiWorkflowId, trigger.TriggerType)
+	}
 	if err != nil {
 		impl.Logger.Errorw("could not save new workflow", "err", err)
 		return nil, nil, nil, err
@@ -866,6 +890,7 @@ func (impl *HandlerServiceImpl) StartCiWorkflowAndPrepareWfRequest(trigger *type
 	workflowRequest.Scope = scope
 	workflowRequest.AppId = pipeline.AppId
 	workflowRequest.Env = envModal
+	workflowRequest.TriggerType = trigger.TriggerType
 	if isJob {
 		workflowRequest.Type = pipelineConfigBean.JOB_WORKFLOW_PIPELINE_TYPE
 	} else {
@@ -937,7 +962,8 @@ func (impl *HandlerServiceImpl) BuildPayload(trigger types.CiTriggerRequest, pip
 }
 
 func (impl *HandlerServiceImpl) saveNewWorkflowForCITrigger(pipeline *pipelineConfig.CiPipeline, ciWorkflowConfigNamespace string,
-	commitHashes map[int]pipelineConfig.GitCommit, userId int32, ciMaterials []*pipelineConfig.CiPipelineMaterial, EnvironmentId int, isJob bool, refCiWorkflowId int) (*pipelineConfig.CiWorkflow, error) {
+	commitHashes map[int]pipelineConfig.GitCommit, userId int32, ciMaterials []*pipelineConfig.CiPipelineMaterial, EnvironmentId int, isJob bool, refCiWorkflowId int,
+	triggerType workflow.CiTriggerType) (*pipelineConfig.CiWorkflow, error) {
 
 	isCiTriggerBlocked, err := impl.checkIfCITriggerIsBlocked(pipeline, ciMaterials, isJob)
 	if err != nil {
@@ -957,6 +983,7 @@ func (impl *HandlerServiceImpl) saveNewWorkflowForCITrigger(pipeline *pipelineCo
 		TriggeredBy:           userId,
 		ReferenceCiWorkflowId: refCiWorkflowId,
 		ExecutorType:          impl.config.GetWorkflowExecutorType(),
+		TriggerType:           triggerType,
 	}
 	if isJob {
 		ciWorkflow.Namespace = ciWorkflowConfigNamespace
@@ -996,6 +1023,15 @@ func (impl *HandlerServiceImpl) buildDefaultArtifactLocation(savedWf *pipelineCo
 	return ArtifactLocation
 }
 
+// getSourceValue returns the branch the commit was picked from, which differs from the configured branch
+// for builds of an overridden branch
+func getSourceValue(ciMaterial *pipelineConfig.CiPipelineMaterial, gitCommit pipelineConfig.GitCommit) string {
+	if ciMaterial.Type == constants.SOURCE_TYPE_BRANCH_FIXED && len(gitCommit.CiConfigureSourceValue) > 0 {
+		return gitCommit.CiConfigureSourceValue
+	}
+	return ciMaterial.Value
+}
+
 func (impl *HandlerServiceImpl) buildWfRequestForCiPipeline(pipeline *pipelineConfig.CiPipeline, trigger *types.CiTriggerRequest, ciMaterials []*pipelineConfig.CiPipelineMaterial, savedWf *pipelineConfig.CiWorkflow, ciWorkflowConfigNamespace string, ciPipelineScripts []*pipelineConfig.CiPipelineScript, preCiSteps []*pipelineConfigBean.StepObject, postCiSteps []*pipelineConfigBean.StepObject, refPluginsData []*pipelineConfigBean.RefPluginObject, isJob bool) (*types.WorkflowRequest, error) {
 	var ciProjectDetails []pipelineConfigBean.CiProjectDetails
 	commitHashes := trigger.CommitHashes
@@ -1013,7 +1049,7 @@ func (impl *HandlerServiceImpl) buildWfRequestForCiPipeline(pipeline *pipelineCo
 			CommitHash:      commitHashForPipelineId.Commit,
 			Author:          commitHashForPipelineId.Author,
 			SourceType:      ciMaterial.Type,
-			SourceValue:     ciMaterial.Value,
+			SourceValue:     getSourceValue(ciMaterial, commitHashForPipelineId),
 			GitTag:          ciMaterial.GitTag,
 			Message:         commitHashForPipelineId.Message,
 			Type:            string(ciMaterial.Type),
@@ -1680,6 +1716,10 @@ func (impl *HandlerServiceImpl) CancelBuild(workflowId int, forceAbort bool) (in
 		impl.Logger.Errorw("error in finding ci-workflow by workflow id", "ciWorkflowId", workflowId, "err", err)
 		return 0, err
 	}
+	if workflow.Status == cdWorkflow.WorkflowInQueue {
+		// build has not been submitted yet, removing it from the queue is enough
+		return impl.cancelQueuedBuild(workflow)
+	}
 	isExt := workflow.Namespace != constants2.DefaultCiWorkflowNamespace
 	var env *repository6.Environment
 	var restConfig *rest.Config
@@ -19,5 +19,6 @@ func GetCiWorkflowFromRefCiWorkflow(refCiWorkflow *pipelineConfig.CiWorkflow, wo
 		ReferenceCiWorkflowId: refCiWorkflow.Id, // Reference to original workflow
 		ExecutorType:          refCiWorkflow.ExecutorType,
 		EnvironmentId:         refCiWorkflow.EnvironmentId,
+		TriggerType:           refCiWorkflow.TriggerType,
 	}
 }
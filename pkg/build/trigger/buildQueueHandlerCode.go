@@ -0,0 +1,250 @@
+package trigger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	bean6 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	buildQueueBean "github.com/devtron-labs/devtron/pkg/build/buildQueue/bean"
+	buildQueueUtil "github.com/devtron-labs/devtron/pkg/build/buildQueue/util"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+)
+
+// isBuildQueueApplicable tells if a fresh trigger has to wait in the build queue,
+// re-triggers of a failed build and dispatched queued triggers are started right away
+func (impl *HandlerServiceImpl) isBuildQueueApplicable(trigger *types.CiTriggerRequest) bool {
+	return impl.ciBuildQueueService.IsEnabled() && !trigger.IsRetrigger && trigger.QueuedCiWorkflow == nil
+}
+
+// enqueueCiTrigger saves a queued ci workflow for the trigger and queues it, the queued build
+// is started by DispatchQueuedBuilds once the concurrency limits allow
+func (impl *HandlerServiceImpl) enqueueCiTrigger(trigger *types.CiTriggerRequest) (int, error) {
+	ciMaterials, err := impl.GetCiMaterials(trigger.PipelineId, trigger.CiMaterials)
+	if err != nil {
+		impl.Logger.Errorw("error in getting ci materials", "pipelineId", trigger.PipelineId, "err", err)
+		return 0, err
+	}
+	if len(ciMaterials) == 0 {
+		return 0, util.NewApiError(http.StatusNotFound, "no materials found for ci pipeline", "no materials found for ci pipeline")
+	}
+	pipeline := ciMaterials[0].CiPipeline
+	app, err := impl.appRepository.FindById(pipeline.AppId)
+	if err != nil {
+		impl.Logger.Errorw("could not find app", "appId", pipeline.AppId, "err", err)
+		return 0, err
+	}
+	isJob := app.AppType == helper.Job
+	queuedCiWf := &pipelineConfig.CiWorkflow{
+		Name:                  pipeline.Name + "-" + strconv.Itoa(pipeline.Id),
+		StartedOn:             time.Now(),
+		CiPipelineId:          pipeline.Id,
+		Namespace:             impl.config.GetDefaultNamespace(),
+		BlobStorageEnabled:    impl.config.BlobStorageEnabled,
+		GitTriggers:           trigger.CommitHashes,
+		TriggeredBy:           trigger.TriggeredBy,
+		ReferenceCiWorkflowId: trigger.ReferenceCiWorkflowId,
+		ExecutorType:          impl.config.GetWorkflowExecutorType(),
+		TriggerType:           trigger.TriggerType,
+	}
+	if isJob {
+		queuedCiWf.EnvironmentId = trigger.EnvironmentId
+	}
+	isCiTriggerBlocked, err := impl.checkIfCITriggerIsBlocked(pipeline, ciMaterials, isJob)
+	if err != nil {
+		impl.Logger.Errorw("error, checkIfCITriggerIsBlocked", "pipelineId", pipeline.Id, "err", err)
+		return 0, err
+	} else if isCiTriggerBlocked {
+		_, err = impl.handleWFIfCITriggerIsBlocked(queuedCiWf)
+		return 0, err
+	}
+	// materials are loaded again at dispatch, the queued trigger only keeps the trigger inputs
+	queuedTrigger := *trigger
+	queuedTrigger.CiMaterials = nil
+	triggerRequest, err := json.Marshal(queuedTrigger)
+	if err != nil {
+		impl.Logger.Errorw("error in marshalling ci trigger request", "pipelineId", pipeline.Id, "err", err)
+		return 0, err
+	}
+	err = impl.ciService.SaveQueuedCiWorkflow(queuedCiWf)
+	if err != nil {
+		impl.Logger.Errorw("error in saving queued ci workflow", "pipelineId", pipeline.Id, "err", err)
+		return 0, err
+	}
+	branch := buildQueueUtil.GetBranchFromGitTriggers(trigger.CommitHashes)
+	queuedBuild, err := impl.ciBuildQueueService.Enqueue(&buildQueueBean.EnqueueRequest{
+		CiWorkflowId:   queuedCiWf.Id,
+		CiPipelineId:   pipeline.Id,
+		AppId:          pipeline.AppId,
+		TeamId:         app.TeamId,
+		Branch:         branch,
+		TriggerType:    trigger.TriggerType,
+		TriggeredBy:    trigger.TriggeredBy,
+		TriggerRequest: triggerRequest,
+	})
+	if err != nil {
+		impl.Logger.Errorw("error in queuing ci trigger", "pipelineId", pipeline.Id, "ciWorkflowId", queuedCiWf.Id, "err", err)
+		if dbErr := impl.markQueuedCiWorkflowFailed(queuedCiWf, err); dbErr != nil {
+			impl.Logger.Errorw("error in marking queued ci workflow failed", "ciWorkflowId", queuedCiWf.Id, "err", dbErr)
+		}
+		return 0, err
+	}
+	impl.Logger.Infow("ci trigger queued", "pipelineId", pipeline.Id, "ciWorkflowId", queuedCiWf.Id, "position", queuedBuild.Position)
+	if len(branch) > 0 {
+		impl.cancelSupersededBuilds(pipeline.Id, pipeline.AppId, app.TeamId, branch, queuedBuild.Id, queuedCiWf.Id)
+	}
+	// the queued build is started right away if a slot is free, otherwise the cron picks it later
+	impl.asyncRunnable.Execute(impl.DispatchQueuedBuilds)
+	return queuedCiWf.Id, nil
+}
+
+// cancelSupersededBuilds cancels the older queued and running builds of the branch if enabled for the pipeline
+func (impl *HandlerServiceImpl) cancelSupersededBuilds(ciPipelineId, appId, teamId int, branch string, queueId, ciWorkflowId int) {
+	isEnabled, err := impl.ciBuildQueueService.IsCancelSupersededEnabled(ciPipelineId, appId, teamId)
+	if err != nil || !isEnabled {
+		return
+	}
+	message := fmt.Sprintf(buildQueueBean.SupersededMessageFormat, branch, ciWorkflowId)
+	supersededCiWorkflowIds, err := impl.ciBuildQueueService.SupersedeQueuedBuilds(ciPipelineId, branch, queueId, ciWorkflowId)
+	if err != nil {
+		impl.Logger.Errorw("error in superseding queued builds", "ciPipelineId", ciPipelineId, "branch", branch, "err", err)
+	}
+	for _, supersededCiWorkflowId := range supersededCiWorkflowIds {
+		supersededCiWf, err := impl.ciWorkflowRepository.FindById(supersededCiWorkflowId)
+		if err != nil {
+			impl.Logger.Errorw("error in fetching superseded ci workflow", "ciWorkflowId", supersededCiWorkflowId, "err", err)
+			continue
+		}
+		supersededCiWf.Status = cdWorkflow.WorkflowCancel
+		supersededCiWf.Message = message
+		supersededCiWf.FinishedOn = time.Now()
+		err = impl.ciService.UpdateQueuedCiWorkflow(supersededCiWf)
+		if err != nil {
+			impl.Logger.Errorw("error in cancelling superseded ci workflow", "ciWorkflowId", supersededCiWorkflowId, "err", err)
+		}
+	}
+	inProgressStatuses := []string{cdWorkflow.WorkflowStarting, cdWorkflow.WorkflowWaitingToStart, string(v1alpha1.NodePending), string(v1alpha1.NodeRunning)}
+	inProgressCiWfs, err := impl.ciWorkflowRepository.FindByCiPipelineIdAndStatusesIn(ciPipelineId, inProgressStatuses)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching in progress ci workflows", "ciPipelineId", ciPipelineId, "err", err)
+		return
+	}
+	for _, inProgressCiWf := range inProgressCiWfs {
+		if buildQueueUtil.GetBranchFromGitTriggers(inProgressCiWf.GitTriggers) != branch {
+			continue
+		}
+		impl.Logger.Infow("cancelling superseded running build", "ciWorkflowId", inProgressCiWf.Id, "supersededBy", ciWorkflowId, "branch", branch)
+		_, err = impl.CancelBuild(inProgressCiWf.Id, false)
+		if err != nil {
+			impl.Logger.Errorw("error in cancelling superseded running build", "ciWorkflowId", inProgressCiWf.Id, "err", err)
+		}
+	}
+}
+
+func (impl *HandlerServiceImpl) DispatchQueuedBuilds() {
+	claimedBuilds, err := impl.ciBuildQueueService.ClaimDispatchableBuilds()
+	if err != nil {
+		impl.Logger.Errorw("error in claiming queued builds", "err", err)
+		return
+	}
+	for _, claimedBuild := range claimedBuilds {
+		err = impl.dispatchQueuedBuild(claimedBuild)
+		if err != nil {
+			impl.Logger.Errorw("error in dispatching queued build", "queueId", claimedBuild.Id, "ciWorkflowId", claimedBuild.CiWorkflowId, "err", err)
+			err = impl.ciBuildQueueService.MarkFailed(claimedBuild.Id, err.Error())
+		} else {
+			err = impl.ciBuildQueueService.MarkDispatched(claimedBuild.Id)
+		}
+		if err != nil {
+			impl.Logger.Errorw("error in updating dispatched queued build", "queueId", claimedBuild.Id, "err", err)
+		}
+	}
+}
+
+func (impl *HandlerServiceImpl) dispatchQueuedBuild(claimedBuild *buildQueueBean.ClaimedBuildDto) error {
+	queuedCiWf, err := impl.ciWorkflowRepository.FindById(claimedBuild.CiWorkflowId)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching queued ci workflow", "ciWorkflowId", claimedBuild.CiWorkflowId, "err", err)
+		return err
+	}
+	if queuedCiWf.Status != cdWorkflow.WorkflowInQueue {
+		return fmt.Errorf("ci workflow %d is not queued, status: %s", queuedCiWf.Id, queuedCiWf.Status)
+	}
+	trigger := &types.CiTriggerRequest{}
+	err = json.Unmarshal(claimedBuild.TriggerRequest, trigger)
+	if err != nil {
+		impl.Logger.Errorw("error in unmarshalling queued ci trigger request", "queueId", claimedBuild.Id, "err", err)
+	} else {
+		trigger.QueuedCiWorkflow = queuedCiWf
+		impl.Logger.Infow("dispatching queued build", "queueId", claimedBuild.Id, "ciWorkflowId", queuedCiWf.Id, "pipelineId", trigger.PipelineId)
+		_, err = impl.triggerCiPipeline(trigger)
+	}
+	if err != nil && queuedCiWf.Status == cdWorkflow.WorkflowInQueue {
+		// failed before the ci workflow was started, it would otherwise stay queued
+		if dbErr := impl.markQueuedCiWorkflowFailed(queuedCiWf, err); dbErr != nil {
+			impl.Logger.Errorw("error in marking queued ci workflow failed", "ciWorkflowId", queuedCiWf.Id, "err", dbErr)
+		}
+	}
+	return err
+}
+
+// startQueuedCiWorkflow moves a dispatched queued ci workflow to the starting state, it is the queued counterpart of saveNewWorkflowForCITrigger
+func (impl *HandlerServiceImpl) startQueuedCiWorkflow(queuedCiWf *pipelineConfig.CiWorkflow, pipeline *pipelineConfig.CiPipeline, ciWorkflowConfigNamespace string,
+	ciMaterials []*pipelineConfig.CiPipelineMaterial, environmentId int, isJob bool) (*pipelineConfig.CiWorkflow, error) {
+	isCiTriggerBlocked, err := impl.checkIfCITriggerIsBlocked(pipeline, ciMaterials, isJob)
+	if err != nil {
+		impl.Logger.Errorw("error, checkIfCITriggerIsBlocked", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	} else if isCiTriggerBlocked {
+		_, err = impl.handleWFIfCITriggerIsBlocked(queuedCiWf)
+		return nil, err
+	}
+	queuedCiWf.StartedOn = time.Now()
+	queuedCiWf.Namespace = impl.config.GetDefaultNamespace()
+	queuedCiWf.BlobStorageEnabled = impl.config.BlobStorageEnabled
+	queuedCiWf.ExecutorType = impl.config.GetWorkflowExecutorType()
+	if isJob {
+		queuedCiWf.Namespace = ciWorkflowConfigNamespace
+		queuedCiWf.EnvironmentId = environmentId
+	}
+	err = impl.ciService.StartQueuedCiWorkflowWithStage(queuedCiWf)
+	if err != nil {
+		impl.Logger.Errorw("error in starting queued ci workflow", "ciWorkflowId", queuedCiWf.Id, "err", err)
+		return nil, err
+	}
+	return queuedCiWf, nil
+}
+
+func (impl *HandlerServiceImpl) markQueuedCiWorkflowFailed(queuedCiWf *pipelineConfig.CiWorkflow, err error) error {
+	queuedCiWf.Status = cdWorkflow.WorkflowFailed
+	queuedCiWf.Message = err.Error()
+	queuedCiWf.FinishedOn = time.Now()
+	return impl.ciService.UpdateQueuedCiWorkflow(queuedCiWf)
+}
+
+func (impl *HandlerServiceImpl) cancelQueuedBuild(queuedCiWf *pipelineConfig.CiWorkflow) (int, error) {
+	cancelled, err := impl.ciBuildQueueService.CancelQueuedBuild(queuedCiWf.Id, bean6.SYSTEM_USER_ID)
+	if err != nil {
+		impl.Logger.Errorw("error in cancelling queued build", "ciWorkflowId", queuedCiWf.Id, "err", err)
+		return 0, err
+	} else if !cancelled {
+		errMsg := "build is being started from the queue, please retry cancelling in a few seconds"
+		return 0, util.NewApiError(http.StatusConflict, errMsg, errMsg)
+	}
+	queuedCiWf.Status = cdWorkflow.WorkflowCancel
+	queuedCiWf.Message = buildQueueBean.CancelledInQueueMessage
+	queuedCiWf.FinishedOn = time.Now()
+	err = impl.ciService.UpdateQueuedCiWorkflow(queuedCiWf)
+	if err != nil {
+		impl.Logger.Errorw("error in updating cancelled queued ci workflow", "ciWorkflowId", queuedCiWf.Id, "err", err)
+		return 0, err
+	}
+	return queuedCiWf.Id, nil
+}
@@ -18,8 +18,12 @@ package build
 
 import (
 	"github.com/devtron-labs/devtron/pkg/build/artifacts"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue"
 	"github.com/devtron-labs/devtron/pkg/build/git"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline"
+	"github.com/devtron-labs/devtron/pkg/build/schedule"
+	"github.com/devtron-labs/devtron/pkg/build/testReport"
 	"github.com/devtron-labs/devtron/pkg/build/trigger"
 	"github.com/google/wire"
 )
@@ -29,4 +33,8 @@ var WireSet = wire.NewSet(
 	pipeline.WireSet,
 	git.GitWireSet,
 	trigger.WireSet,
+	schedule.WireSet,
+	testReport.WireSet,
+	buildQueue.WireSet,
+	retention.WireSet,
 )
@@ -41,6 +41,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/bulkAction/utils"
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
 	bean5 "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/configMapAndSecret"
@@ -109,6 +110,7 @@ type BulkUpdateServiceImpl struct {
 	deployedAppService               deployedApp.DeployedAppService
 	cdPipelineEventPublishService    out.CDPipelineEventPublishService
 	ciHandlerService                 trigger.HandlerService
+	deploymentApprovalService        approval.DeploymentApprovalService
 	*BulkUpdateServiceEntImpl
 }
 
@@ -131,6 +133,7 @@ func NewBulkUpdateServiceImpl(bulkUpdateRepository repository.BulkEditRepository
 	deployedAppService deployedApp.DeployedAppService,
 	cdPipelineEventPublishService out.CDPipelineEventPublishService,
 	ciHandlerService trigger.HandlerService,
+	deploymentApprovalService approval.DeploymentApprovalService,
 	bulkUpdateServiceEntImpl *BulkUpdateServiceEntImpl,
 ) *BulkUpdateServiceImpl {
 	return &BulkUpdateServiceImpl{
@@ -153,6 +156,7 @@ func NewBulkUpdateServiceImpl(bulkUpdateRepository repository.BulkEditRepository
 		deployedAppService:               deployedAppService,
 		cdPipelineEventPublishService:    cdPipelineEventPublishService,
 		ciHandlerService:                 ciHandlerService,
+		deploymentApprovalService:        deploymentApprovalService,
 		BulkUpdateServiceEntImpl:         bulkUpdateServiceEntImpl,
 	}
 }
@@ -1503,6 +1507,15 @@ func (impl BulkUpdateServiceImpl) BulkDeploy(ctx *util2.RequestCtx, request *bea
 			continue
 		}
 		artifact := artifacts[0]
+		_, err = impl.deploymentApprovalService.CheckArtifactApproved(pipeline.Id, artifact.Id)
+		if err != nil {
+			// artifact is not approved, the deployment would be rejected
+			impl.logger.Errorw("artifact not approved for deployment, BulkDeploy", "err", err, "pipelineId", pipeline.Id, "artifactId", artifact.Id)
+			pipelineResponse := response[appKey]
+			pipelineResponse[pipelineKey] = false
+			response[appKey] = pipelineResponse
+			continue
+		}
 		err = impl.cdPipelineEventPublishService.PublishBulkTriggerTopicEvent(pipeline.Id, pipeline.AppId, artifact.Id, userMetadata)
 		if err != nil {
 			impl.logger.Errorw("error, PublishBulkTriggerTopicEvent", "err", err, "pipeline", pipeline)
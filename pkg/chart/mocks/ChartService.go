@@ -1,17 +1,20 @@
-// Code generated by mockery v2.32.0. DO NOT EDIT.
+// Code generated by mockery v2.53.5. DO NOT EDIT.
 
 package mocks
 
 import (
 	chart "github.com/devtron-labs/devtron/pkg/chart"
-	bean2 "github.com/devtron-labs/devtron/pkg/chart/bean"
 	bean "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef/bean"
 
-	context "context"
+	chartbean "github.com/devtron-labs/devtron/pkg/chart/bean"
+
+	commonbean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
 
-	json "encoding/json"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	pipelinebean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
 )
 
 // ChartService is an autogenerated mock type for the ChartService type
@@ -23,6 +26,10 @@ type ChartService struct {
 func (_m *ChartService) ChartRefAutocompleteForAppOrEnv(appId int, envId int) (*bean.ChartRefAutocompleteResponse, error) {
 	ret := _m.Called(appId, envId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ChartRefAutocompleteForAppOrEnv")
+	}
+
 	var r0 *bean.ChartRefAutocompleteResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, int) (*bean.ChartRefAutocompleteResponse, error)); ok {
@@ -45,10 +52,44 @@ func (_m *ChartService) ChartRefAutocompleteForAppOrEnv(appId int, envId int) (*
 	return r0, r1
 }
 
+// ChartRefAutocompleteGlobalData provides a mock function with no fields
+func (_m *ChartService) ChartRefAutocompleteGlobalData() (*bean.ChartRefAutocompleteResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChartRefAutocompleteGlobalData")
+	}
+
+	var r0 *bean.ChartRefAutocompleteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*bean.ChartRefAutocompleteResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *bean.ChartRefAutocompleteResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.ChartRefAutocompleteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckIfChartRefUserUploadedByAppId provides a mock function with given fields: id
 func (_m *ChartService) CheckIfChartRefUserUploadedByAppId(id int) (bool, error) {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIfChartRefUserUploadedByAppId")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
@@ -69,25 +110,29 @@ func (_m *ChartService) CheckIfChartRefUserUploadedByAppId(id int) (bool, error)
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: templateRequest, ctx
-func (_m *ChartService) Create(templateRequest bean2.TemplateRequest, ctx context.Context) (*bean2.TemplateRequest, error) {
-	ret := _m.Called(templateRequest, ctx)
+// ConfigureGitOpsRepoUrlForApp provides a mock function with given fields: appId, repoUrl, chartLocation, isCustomRepo, userId
+func (_m *ChartService) ConfigureGitOpsRepoUrlForApp(appId int, repoUrl string, chartLocation string, isCustomRepo bool, userId int32) (*commonbean.DeploymentConfig, error) {
+	ret := _m.Called(appId, repoUrl, chartLocation, isCustomRepo, userId)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigureGitOpsRepoUrlForApp")
+	}
+
+	var r0 *commonbean.DeploymentConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(bean2.TemplateRequest, context.Context) (*bean2.TemplateRequest, error)); ok {
-		return rf(templateRequest, ctx)
+	if rf, ok := ret.Get(0).(func(int, string, string, bool, int32) (*commonbean.DeploymentConfig, error)); ok {
+		return rf(appId, repoUrl, chartLocation, isCustomRepo, userId)
 	}
-	if rf, ok := ret.Get(0).(func(bean2.TemplateRequest, context.Context) *bean2.TemplateRequest); ok {
-		r0 = rf(templateRequest, ctx)
+	if rf, ok := ret.Get(0).(func(int, string, string, bool, int32) *commonbean.DeploymentConfig); ok {
+		r0 = rf(appId, repoUrl, chartLocation, isCustomRepo, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(*commonbean.DeploymentConfig)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(bean2.TemplateRequest, context.Context) error); ok {
-		r1 = rf(templateRequest, ctx)
+	if rf, ok := ret.Get(1).(func(int, string, string, bool, int32) error); ok {
+		r1 = rf(appId, repoUrl, chartLocation, isCustomRepo, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -95,24 +140,28 @@ func (_m *ChartService) Create(templateRequest bean2.TemplateRequest, ctx contex
 	return r0, r1
 }
 
-// CreateChartFromEnvOverride provides a mock function with given fields: templateRequest, ctx
-func (_m *ChartService) CreateChartFromEnvOverride(templateRequest bean2.TemplateRequest, ctx context.Context) (*bean2.TemplateRequest, error) {
+// Create provides a mock function with given fields: templateRequest, ctx
+func (_m *ChartService) Create(templateRequest chartbean.TemplateRequest, ctx context.Context) (*chartbean.TemplateRequest, error) {
 	ret := _m.Called(templateRequest, ctx)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *chartbean.TemplateRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(bean2.TemplateRequest, context.Context) (*bean2.TemplateRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(chartbean.TemplateRequest, context.Context) (*chartbean.TemplateRequest, error)); ok {
 		return rf(templateRequest, ctx)
 	}
-	if rf, ok := ret.Get(0).(func(bean2.TemplateRequest, context.Context) *bean2.TemplateRequest); ok {
+	if rf, ok := ret.Get(0).(func(chartbean.TemplateRequest, context.Context) *chartbean.TemplateRequest); ok {
 		r0 = rf(templateRequest, ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(*chartbean.TemplateRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(bean2.TemplateRequest, context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(chartbean.TemplateRequest, context.Context) error); ok {
 		r1 = rf(templateRequest, ctx)
 	} else {
 		r1 = ret.Error(1)
@@ -121,25 +170,29 @@ func (_m *ChartService) CreateChartFromEnvOverride(templateRequest bean2.Templat
 	return r0, r1
 }
 
-// FindLatestChartForAppByAppId provides a mock function with given fields: appId
-func (_m *ChartService) FindLatestChartForAppByAppId(appId int) (*bean2.TemplateRequest, error) {
-	ret := _m.Called(appId)
+// CreateChartFromEnvOverride provides a mock function with given fields: ctx, templateRequest
+func (_m *ChartService) CreateChartFromEnvOverride(ctx context.Context, templateRequest chartbean.TemplateRequest) (*chartbean.TemplateRequest, error) {
+	ret := _m.Called(ctx, templateRequest)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChartFromEnvOverride")
+	}
+
+	var r0 *chartbean.TemplateRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*bean2.TemplateRequest, error)); ok {
-		return rf(appId)
+	if rf, ok := ret.Get(0).(func(context.Context, chartbean.TemplateRequest) (*chartbean.TemplateRequest, error)); ok {
+		return rf(ctx, templateRequest)
 	}
-	if rf, ok := ret.Get(0).(func(int) *bean2.TemplateRequest); ok {
-		r0 = rf(appId)
+	if rf, ok := ret.Get(0).(func(context.Context, chartbean.TemplateRequest) *chartbean.TemplateRequest); ok {
+		r0 = rf(ctx, templateRequest)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(*chartbean.TemplateRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(appId)
+	if rf, ok := ret.Get(1).(func(context.Context, chartbean.TemplateRequest) error); ok {
+		r1 = rf(ctx, templateRequest)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -148,19 +201,23 @@ func (_m *ChartService) FindLatestChartForAppByAppId(appId int) (*bean2.Template
 }
 
 // FindPreviousChartByAppId provides a mock function with given fields: appId
-func (_m *ChartService) FindPreviousChartByAppId(appId int) (*bean2.TemplateRequest, error) {
+func (_m *ChartService) FindPreviousChartByAppId(appId int) (*chartbean.TemplateRequest, error) {
 	ret := _m.Called(appId)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for FindPreviousChartByAppId")
+	}
+
+	var r0 *chartbean.TemplateRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*bean2.TemplateRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(int) (*chartbean.TemplateRequest, error)); ok {
 		return rf(appId)
 	}
-	if rf, ok := ret.Get(0).(func(int) *bean2.TemplateRequest); ok {
+	if rf, ok := ret.Get(0).(func(int) *chartbean.TemplateRequest); ok {
 		r0 = rf(appId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(*chartbean.TemplateRequest)
 		}
 	}
 
@@ -173,20 +230,24 @@ func (_m *ChartService) FindPreviousChartByAppId(appId int) (*bean2.TemplateRequ
 	return r0, r1
 }
 
-// GetByAppIdAndChartRefId provides a mock function with given fields: appId, chartRefId
-func (_m *ChartService) GetByAppIdAndChartRefId(appId int, chartRefId int) (*bean2.TemplateRequest, error) {
+// GetDeploymentTemplateDataByAppIdAndCharRefId provides a mock function with given fields: appId, chartRefId
+func (_m *ChartService) GetDeploymentTemplateDataByAppIdAndCharRefId(appId int, chartRefId int) (map[string]interface{}, error) {
 	ret := _m.Called(appId, chartRefId)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeploymentTemplateDataByAppIdAndCharRefId")
+	}
+
+	var r0 map[string]interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) (*bean2.TemplateRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(int, int) (map[string]interface{}, error)); ok {
 		return rf(appId, chartRefId)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) *bean2.TemplateRequest); ok {
+	if rf, ok := ret.Get(0).(func(int, int) map[string]interface{}); ok {
 		r0 = rf(appId, chartRefId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(map[string]interface{})
 		}
 	}
 
@@ -199,23 +260,57 @@ func (_m *ChartService) GetByAppIdAndChartRefId(appId int, chartRefId int) (*bea
 	return r0, r1
 }
 
-// IsGitOpsRepoAlreadyRegistered provides a mock function with given fields: gitOpsRepoUrl
-func (_m *ChartService) IsGitOpsRepoAlreadyRegistered(gitOpsRepoUrl string) (bool, error) {
-	ret := _m.Called(gitOpsRepoUrl)
+// GetLatestEnvironmentProperties provides a mock function with given fields: appId, environmentId
+func (_m *ChartService) GetLatestEnvironmentProperties(appId int, environmentId int) (*pipelinebean.EnvironmentProperties, error) {
+	ret := _m.Called(appId, environmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestEnvironmentProperties")
+	}
+
+	var r0 *pipelinebean.EnvironmentProperties
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*pipelinebean.EnvironmentProperties, error)); ok {
+		return rf(appId, environmentId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *pipelinebean.EnvironmentProperties); ok {
+		r0 = rf(appId, environmentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelinebean.EnvironmentProperties)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, environmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsGitOpsRepoAlreadyRegistered provides a mock function with given fields: gitOpsRepoUrl, appId
+func (_m *ChartService) IsGitOpsRepoAlreadyRegistered(gitOpsRepoUrl string, appId int) (bool, error) {
+	ret := _m.Called(gitOpsRepoUrl, appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsGitOpsRepoAlreadyRegistered")
+	}
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return rf(gitOpsRepoUrl)
+	if rf, ok := ret.Get(0).(func(string, int) (bool, error)); ok {
+		return rf(gitOpsRepoUrl, appId)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(gitOpsRepoUrl)
+	if rf, ok := ret.Get(0).(func(string, int) bool); ok {
+		r0 = rf(gitOpsRepoUrl, appId)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(gitOpsRepoUrl)
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(gitOpsRepoUrl, appId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,10 +318,14 @@ func (_m *ChartService) IsGitOpsRepoAlreadyRegistered(gitOpsRepoUrl string) (boo
 	return r0, r1
 }
 
-// IsGitOpsRepoConfiguredForDevtronApps provides a mock function with given fields: appId
-func (_m *ChartService) IsGitOpsRepoConfiguredForDevtronApps(appId int) (bool, error) {
+// IsGitOpsRepoConfiguredForDevtronApp provides a mock function with given fields: appId
+func (_m *ChartService) IsGitOpsRepoConfiguredForDevtronApp(appId int) (bool, error) {
 	ret := _m.Called(appId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for IsGitOpsRepoConfiguredForDevtronApp")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
@@ -251,6 +350,10 @@ func (_m *ChartService) IsGitOpsRepoConfiguredForDevtronApps(appId int) (bool, e
 func (_m *ChartService) IsReadyToTrigger(appId int, envId int, pipelineId int) (chart.IsReady, error) {
 	ret := _m.Called(appId, envId, pipelineId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for IsReadyToTrigger")
+	}
+
 	var r0 chart.IsReady
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, int, int) (chart.IsReady, error)); ok {
@@ -271,50 +374,28 @@ func (_m *ChartService) IsReadyToTrigger(appId int, envId int, pipelineId int) (
 	return r0, r1
 }
 
-// PatchEnvOverrides provides a mock function with given fields: values, oldChartType, newChartType
-func (_m *ChartService) PatchEnvOverrides(values json.RawMessage, oldChartType string, newChartType string) (json.RawMessage, error) {
-	ret := _m.Called(values, oldChartType, newChartType)
-
-	var r0 json.RawMessage
-	var r1 error
-	if rf, ok := ret.Get(0).(func(json.RawMessage, string, string) (json.RawMessage, error)); ok {
-		return rf(values, oldChartType, newChartType)
-	}
-	if rf, ok := ret.Get(0).(func(json.RawMessage, string, string) json.RawMessage); ok {
-		r0 = rf(values, oldChartType, newChartType)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(json.RawMessage)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(json.RawMessage, string, string) error); ok {
-		r1 = rf(values, oldChartType, newChartType)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UpdateAppOverride provides a mock function with given fields: ctx, templateRequest
-func (_m *ChartService) UpdateAppOverride(ctx context.Context, templateRequest *bean2.TemplateRequest) (*bean2.TemplateRequest, error) {
+func (_m *ChartService) UpdateAppOverride(ctx context.Context, templateRequest *chartbean.TemplateRequest) (*chartbean.TemplateRequest, error) {
 	ret := _m.Called(ctx, templateRequest)
 
-	var r0 *bean2.TemplateRequest
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAppOverride")
+	}
+
+	var r0 *chartbean.TemplateRequest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *bean2.TemplateRequest) (*bean2.TemplateRequest, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *chartbean.TemplateRequest) (*chartbean.TemplateRequest, error)); ok {
 		return rf(ctx, templateRequest)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *bean2.TemplateRequest) *bean2.TemplateRequest); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *chartbean.TemplateRequest) *chartbean.TemplateRequest); ok {
 		r0 = rf(ctx, templateRequest)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*bean2.TemplateRequest)
+			r0 = ret.Get(0).(*chartbean.TemplateRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *bean2.TemplateRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *chartbean.TemplateRequest) error); ok {
 		r1 = rf(ctx, templateRequest)
 	} else {
 		r1 = ret.Error(1)
@@ -323,24 +404,14 @@ func (_m *ChartService) UpdateAppOverride(ctx context.Context, templateRequest *
 	return r0, r1
 }
 
-// ConfigureGitOpsRepoUrl provides a mock function with given fields: appId, repoUrl, chartLocation, userId
-func (_m *ChartService) ConfigureGitOpsRepoUrl(appId int, repoUrl string, chartLocation string, userId int32) error {
-	ret := _m.Called(appId, repoUrl, chartLocation, userId)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string, string, int32) error); ok {
-		r0 = rf(appId, repoUrl, chartLocation, userId)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpgradeForApp provides a mock function with given fields: appId, chartRefId, newAppOverride, userId, ctx
 func (_m *ChartService) UpgradeForApp(appId int, chartRefId int, newAppOverride map[string]interface{}, userId int32, ctx context.Context) (bool, error) {
 	ret := _m.Called(appId, chartRefId, newAppOverride, userId, ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeForApp")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(int, int, map[string]interface{}, int32, context.Context) (bool, error)); ok {
@@ -335,6 +335,11 @@ func (impl *DeploymentConfigurationServiceImpl) GetManifest(ctx context.Context,
 		return nil, err
 	}
 
+	if manifestRequest.ResourceType == bean.DeploymentTemplate {
+		// values are the complete deployment values, the whole rendered release is the manifest
+		return &bean2.ManifestResponse{Manifest: templateChartResponse.GeneratedManifest}, nil
+	}
+
 	yamlSplits, err := kube.SplitYAML([]byte(templateChartResponse.GeneratedManifest))
 	for _, yaml := range yamlSplits {
 		if (manifestRequest.ResourceType == bean.CM && yaml.GetKind() == "ConfigMap") || (manifestRequest.ResourceType == bean.CS && yaml.GetKind() == "Secret") {
@@ -358,6 +363,8 @@ func (impl *DeploymentConfigurationServiceImpl) getMergedValuesForCMCSHelmTempla
 		err        error
 	)
 	switch manifestRequest.ResourceType {
+	case bean.DeploymentTemplate:
+		CMCSValues = []byte(resolvedTemplate)
 	case bean.CM:
 		ConfigMapRoot := bean4.ConfigMapRootJson{
 			ConfigMapJson: bean4.ConfigMapJson{
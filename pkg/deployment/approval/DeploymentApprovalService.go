@@ -0,0 +1,565 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package approval
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/constants"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
+	bean2 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentApprovalService interface {
+	// GetApprovalPolicy returns nil if the pipeline does not require approval for deployment
+	GetApprovalPolicy(pipelineId int) (*bean.ApprovalPolicyDto, error)
+	SaveApprovalPolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error)
+	DeleteApprovalPolicy(pipelineId int, userId int32) error
+
+	RaiseApprovalRequest(request *bean.RaiseApprovalRequestDto) (*bean.ApprovalRequestDto, error)
+	TakeApprovalAction(request *bean.ApprovalActionRequest) (*bean.ApprovalRequestDto, error)
+	GetApprovalRequestById(id int) (*bean.ApprovalRequestDto, error)
+	GetApprovalRequests(pipelineId int, offset, limit int) ([]*bean.ApprovalRequestDto, error)
+	GetApprovalRequestsByIds(ids []int) (map[int]*bean.ApprovalRequestDto, error)
+
+	// CheckArtifactApproved returns an error if the artifact is not approved for deployment on the pipeline,
+	// the approved request is returned for linking it to the deployment, nil if the pipeline does not require approval
+	CheckArtifactApproved(pipelineId, artifactId int) (*bean.ApprovalRequestDto, error)
+	// RequestApprovalForAutoTrigger raises an approval request for an automatic deployment which is blocked for approval,
+	// the deployment is triggered once the request is approved
+	RequestApprovalForAutoTrigger(pipeline *pipelineConfig.Pipeline, artifactId, cdWorkflowId int, triggeredBy int32) error
+	MarkArtifactDeploymentTriggered(approvalRequestId int, userId int32) error
+}
+
+type DeploymentApprovalServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	deploymentApprovalRepository repository.DeploymentApprovalRepository
+	pipelineRepository           pipelineConfig.PipelineRepository
+	ciArtifactRepository         repository2.CiArtifactRepository
+	userService                  user.UserService
+	roleGroupService             user.RoleGroupService
+	eventFactory                 client.EventFactory
+	eventClient                  client.EventClient
+	deploymentWindowService      deploymentWindow.DeploymentWindowService
+}
+
+func NewDeploymentApprovalServiceImpl(logger *zap.SugaredLogger,
+	deploymentApprovalRepository repository.DeploymentApprovalRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	userService user.UserService,
+	roleGroupService user.RoleGroupService,
+	eventFactory client.EventFactory,
+	eventClient client.EventClient,
+	deploymentWindowService deploymentWindow.DeploymentWindowService) *DeploymentApprovalServiceImpl {
+	return &DeploymentApprovalServiceImpl{
+		logger:                       logger,
+		deploymentApprovalRepository: deploymentApprovalRepository,
+		pipelineRepository:           pipelineRepository,
+		ciArtifactRepository:         ciArtifactRepository,
+		userService:                  userService,
+		roleGroupService:             roleGroupService,
+		eventFactory:                 eventFactory,
+		eventClient:                  eventClient,
+		deploymentWindowService:      deploymentWindowService,
+	}
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApprovalPolicy(pipelineId int) (*bean.ApprovalPolicyDto, error) {
+	config, err := impl.deploymentApprovalRepository.GetUserApprovalConfig(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval config of pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	if len(config) == 0 {
+		return nil, nil
+	}
+	userApprovalConfig := &bean.UserApprovalConfig{}
+	err = json.Unmarshal([]byte(config), userApprovalConfig)
+	if err != nil {
+		impl.logger.Errorw("error in unmarshalling approval config of pipeline", "pipelineId", pipelineId, "config", config, "err", err)
+		return nil, err
+	}
+	if userApprovalConfig.RequiredCount <= 0 {
+		return nil, nil
+	}
+	return adapter.GetApprovalPolicyDto(pipelineId, userApprovalConfig), nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) SaveApprovalPolicy(policy *bean.ApprovalPolicyDto) (*bean.ApprovalPolicyDto, error) {
+	if policy.RequiredApprovals <= 0 || policy.RequiredApprovals > bean.MaxRequiredApprovals {
+		return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("required approvals should be between 1 and %d", bean.MaxRequiredApprovals), "invalid required approvals")
+	}
+	if len(policy.ApproverRoleGroupIds) > 0 {
+		roleGroups, err := impl.roleGroupService.GetGroupIdVsRoleGroupMapForIds(policy.ApproverRoleGroupIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching approver role groups", "roleGroupIds", policy.ApproverRoleGroupIds, "err", err)
+			return nil, err
+		}
+		for _, roleGroupId := range policy.ApproverRoleGroupIds {
+			if _, ok := roleGroups[roleGroupId]; !ok {
+				return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("approver role group %d not found", roleGroupId), "approver role group not found")
+			}
+		}
+	}
+	config, err := json.Marshal(adapter.GetUserApprovalConfig(policy))
+	if err != nil {
+		impl.logger.Errorw("error in marshalling approval config", "policy", policy, "err", err)
+		return nil, err
+	}
+	err = impl.deploymentApprovalRepository.UpdateUserApprovalConfig(policy.PipelineId, string(config), policy.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in saving approval config of pipeline", "pipelineId", policy.PipelineId, "err", err)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) DeleteApprovalPolicy(pipelineId int, userId int32) error {
+	err := impl.deploymentApprovalRepository.UpdateUserApprovalConfig(pipelineId, "", userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting approval config of pipeline", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) RaiseApprovalRequest(request *bean.RaiseApprovalRequestDto) (*bean.ApprovalRequestDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	model, isNew, err := impl.getOrCreateApprovalRequest(pipeline, request.CiArtifactId, request.Comment, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		impl.sendApprovalNotification(model, pipeline, util2.Approval, request.UserId, request.Comment)
+	}
+	return impl.GetApprovalRequestById(model.Id)
+}
+
+func (impl *DeploymentApprovalServiceImpl) RequestApprovalForAutoTrigger(pipeline *pipelineConfig.Pipeline, artifactId, cdWorkflowId int, triggeredBy int32) error {
+	model, isNew, err := impl.getOrCreateApprovalRequest(pipeline, artifactId, "", triggeredBy)
+	if err != nil {
+		return err
+	}
+	if bean.ApprovalStatus(model.Status) != bean.ApprovalStatusRequested {
+		// approved in the meantime, the trigger is retried through the queue
+		return impl.queueApprovedAutoTrigger(model, pipeline)
+	}
+	model.AutoTriggerPending = true
+	model.CdWorkflowId = cdWorkflowId
+	model.UpdateAuditLog(triggeredBy)
+	err = impl.deploymentApprovalRepository.Update(model)
+	if err != nil {
+		impl.logger.Errorw("error in marking automatic deployment pending on approval request", "approvalRequestId", model.Id, "err", err)
+		return err
+	}
+	if isNew {
+		impl.sendApprovalNotification(model, pipeline, util2.Approval, triggeredBy, "")
+	}
+	return nil
+}
+
+// getOrCreateApprovalRequest returns the open request of the artifact on the pipeline, a new request is raised
+// if there is none or the last one is already closed
+func (impl *DeploymentApprovalServiceImpl) getOrCreateApprovalRequest(pipeline *pipelineConfig.Pipeline, artifactId int, comment string, userId int32) (*repository.DeploymentApprovalRequest, bool, error) {
+	policy, err := impl.GetApprovalPolicy(pipeline.Id)
+	if err != nil {
+		return nil, false, err
+	}
+	if policy == nil {
+		return nil, false, util.NewApiError(http.StatusBadRequest, "approval is not configured for this pipeline", "approval is not configured for this pipeline")
+	}
+	artifact, err := impl.ciArtifactRepository.Get(artifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", artifactId, "err", err)
+		if util.IsErrNoRows(err) {
+			return nil, false, util.NewApiError(http.StatusNotFound, "artifact not found", "artifact not found")
+		}
+		return nil, false, err
+	}
+	now := time.Now()
+	existing, err := impl.deploymentApprovalRepository.FindActiveByPipelineIdAndArtifactId(pipeline.Id, artifact.Id)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval request", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return nil, false, err
+	}
+	if existing != nil && existing.Id > 0 {
+		status := bean.ApprovalStatus(existing.Status)
+		isExpired := !existing.ExpiresOn.IsZero() && now.After(existing.ExpiresOn)
+		if (status == bean.ApprovalStatusRequested || status == bean.ApprovalStatusApproved) && !isExpired {
+			return existing, false, nil
+		}
+		existing.Active = false
+		existing.UpdateAuditLog(userId)
+		err = impl.deploymentApprovalRepository.Update(existing)
+		if err != nil {
+			impl.logger.Errorw("error in deactivating approval request", "approvalRequestId", existing.Id, "err", err)
+			return nil, false, err
+		}
+	}
+	model := &repository.DeploymentApprovalRequest{
+		PipelineId:        pipeline.Id,
+		CiArtifactId:      artifact.Id,
+		Active:            true,
+		Status:            bean.ApprovalStatusRequested.String(),
+		RequiredApprovals: policy.RequiredApprovals,
+		Comment:           comment,
+		AuditLog:          sql.NewDefaultAuditLog(userId),
+	}
+	if policy.ExpiryMinutes > 0 {
+		model.ExpiresOn = now.Add(time.Duration(policy.ExpiryMinutes) * time.Minute)
+	}
+	err = impl.deploymentApprovalRepository.Save(model)
+	if err != nil {
+		impl.logger.Errorw("error in saving approval request", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return nil, false, err
+	}
+	return model, true, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) TakeApprovalAction(request *bean.ApprovalActionRequest) (*bean.ApprovalRequestDto, error) {
+	model, err := impl.deploymentApprovalRepository.FindById(request.ApprovalRequestId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching approval request", "approvalRequestId", request.ApprovalRequestId, "err", err)
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "approval request not found", "approval request not found")
+		}
+		return nil, err
+	}
+	if !model.Active || bean.ApprovalStatus(model.Status) != bean.ApprovalStatusRequested {
+		return nil, util.NewApiError(http.StatusConflict, fmt.Sprintf("approval request is already %s", model.Status), "approval request is not open")
+	}
+	if !model.ExpiresOn.IsZero() && time.Now().After(model.ExpiresOn) {
+		return nil, util.NewApiError(http.StatusConflict, "approval request has expired, raise a new request", "approval request has expired")
+	}
+	pipeline, err := impl.pipelineRepository.FindById(model.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", model.PipelineId, "err", err)
+		return nil, err
+	}
+	switch request.ActionType {
+	case bean.ApprovalActionCancel:
+		err = impl.cancelApprovalRequest(model, request)
+	case bean.ApprovalActionApprove, bean.ApprovalActionReject:
+		err = impl.saveUserApprovalAction(model, pipeline, request)
+	default:
+		err = util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid action %s", request.ActionType), "invalid approval action")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetApprovalRequestById(model.Id)
+}
+
+func (impl *DeploymentApprovalServiceImpl) cancelApprovalRequest(model *repository.DeploymentApprovalRequest, request *bean.ApprovalActionRequest) error {
+	if model.CreatedBy != request.UserId {
+		return util.NewApiError(http.StatusForbidden, "only the requester can cancel an approval request", "only the requester can cancel an approval request")
+	}
+	_, err := impl.deploymentApprovalRepository.UpdateStatus(model.Id, bean.ApprovalStatusRequested.String(), bean.ApprovalStatusCancelled.String(), request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in cancelling approval request", "approvalRequestId", model.Id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) saveUserApprovalAction(model *repository.DeploymentApprovalRequest, pipeline *pipelineConfig.Pipeline, request *bean.ApprovalActionRequest) error {
+	if model.CreatedBy == request.UserId {
+		return util.NewApiError(http.StatusForbidden, "self approval is not allowed, requester cannot approve or reject their own request", "self approval is not allowed")
+	}
+	policy, err := impl.GetApprovalPolicy(model.PipelineId)
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		isApprover, err := impl.isApprover(request.UserId, policy.ApproverRoleGroupIds)
+		if err != nil {
+			return err
+		}
+		if !isApprover {
+			return util.NewApiError(http.StatusForbidden, "user is not a member of the approver role groups of this pipeline", "user is not an approver")
+		}
+	}
+	approvalActions, err := impl.deploymentApprovalRepository.FindUserDataByRequestIds([]int{model.Id}, bean.DeploymentApprovalRequestType)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval actions", "approvalRequestId", model.Id, "err", err)
+		return err
+	}
+	approvalCount := 0
+	for _, item := range approvalActions {
+		if item.UserId == request.UserId {
+			return util.NewApiError(http.StatusConflict, "user has already taken action on this approval request", "duplicate approval action")
+		}
+		if bean.UserApprovalResponse(item.UserResponse) == bean.UserApprovalResponseApproved {
+			approvalCount++
+		}
+	}
+	userResponse := bean.UserApprovalResponseApproved
+	if request.ActionType == bean.ApprovalActionReject {
+		userResponse = bean.UserApprovalResponseRejected
+	}
+	// one action per user is also guarded by the unique index on request_approval_user_data
+	userData := &repository.RequestApprovalUserData{
+		ApprovalRequestId: model.Id,
+		UserId:            request.UserId,
+		UserResponse:      int(userResponse),
+		Comments:          request.Comment,
+		RequestType:       bean.DeploymentApprovalRequestType,
+		AuditLog:          sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.deploymentApprovalRepository.SaveUserData(userData)
+	if err != nil {
+		impl.logger.Errorw("error in saving approval action", "approvalRequestId", model.Id, "userId", request.UserId, "err", err)
+		return err
+	}
+
+	if userResponse == bean.UserApprovalResponseRejected {
+		// a single rejection closes the request
+		rejected, err := impl.deploymentApprovalRepository.UpdateStatus(model.Id, bean.ApprovalStatusRequested.String(), bean.ApprovalStatusRejected.String(), request.UserId)
+		if err != nil {
+			impl.logger.Errorw("error in rejecting approval request", "approvalRequestId", model.Id, "err", err)
+			return err
+		}
+		if rejected {
+			model.Status = bean.ApprovalStatusRejected.String()
+			impl.sendApprovalNotification(model, pipeline, util2.ApprovalAction, request.UserId, request.Comment)
+		}
+		return nil
+	}
+	if approvalCount+1 < model.RequiredApprovals {
+		return nil
+	}
+	approved, err := impl.deploymentApprovalRepository.UpdateStatus(model.Id, bean.ApprovalStatusRequested.String(), bean.ApprovalStatusApproved.String(), request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in approving approval request", "approvalRequestId", model.Id, "err", err)
+		return err
+	}
+	if !approved {
+		// finalised by a concurrent action
+		return nil
+	}
+	model.Status = bean.ApprovalStatusApproved.String()
+	impl.sendApprovalNotification(model, pipeline, util2.ApprovalAction, request.UserId, request.Comment)
+	if model.AutoTriggerPending {
+		return impl.queueApprovedAutoTrigger(model, pipeline)
+	}
+	return nil
+}
+
+// queueApprovedAutoTrigger hands over the automatic deployment waiting for approval to the deployment trigger queue,
+// which triggers it as soon as the environment is open for deployment
+func (impl *DeploymentApprovalServiceImpl) queueApprovedAutoTrigger(model *repository.DeploymentApprovalRequest, pipeline *pipelineConfig.Pipeline) error {
+	queuedTrigger := &bean2.QueuedTriggerDto{
+		PipelineId:    pipeline.Id,
+		EnvironmentId: pipeline.EnvironmentId,
+		CiArtifactId:  model.CiArtifactId,
+		CdWorkflowId:  model.CdWorkflowId,
+		WorkflowType:  apiBean.CD_WORKFLOW_TYPE_DEPLOY.String(),
+		TriggeredBy:   model.CreatedBy,
+	}
+	err := impl.deploymentWindowService.QueueTrigger(queuedTrigger, nil)
+	if err != nil {
+		impl.logger.Errorw("error in queuing approved automatic deployment", "approvalRequestId", model.Id, "pipelineId", pipeline.Id, "err", err)
+		return err
+	}
+	impl.logger.Infow("approved automatic deployment queued", "approvalRequestId", model.Id, "pipelineId", pipeline.Id, "queuedTriggerId", queuedTrigger.Id)
+	return nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) isApprover(userId int32, approverRoleGroupIds []int32) (bool, error) {
+	if len(approverRoleGroupIds) == 0 {
+		// trigger access on the pipeline is checked by the caller
+		return true, nil
+	}
+	userInfo, err := impl.userService.GetByIdWithoutGroupClaims(userId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching user", "userId", userId, "err", err)
+		return false, err
+	}
+	for _, userRoleGroup := range userInfo.UserRoleGroup {
+		if userRoleGroup.RoleGroup == nil {
+			continue
+		}
+		for _, roleGroupId := range approverRoleGroupIds {
+			if userRoleGroup.RoleGroup.Id == roleGroupId {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) CheckArtifactApproved(pipelineId, artifactId int) (*bean.ApprovalRequestDto, error) {
+	policy, err := impl.GetApprovalPolicy(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	model, err := impl.deploymentApprovalRepository.FindActiveByPipelineIdAndArtifactId(pipelineId, artifactId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval request", "pipelineId", pipelineId, "artifactId", artifactId, "err", err)
+		return nil, err
+	}
+	if err == pg.ErrNoRows || model.Id == 0 {
+		message := fmt.Sprintf("artifact is not approved for deployment, %d approval(s) required", policy.RequiredApprovals)
+		return nil, util.NewApiError(http.StatusForbidden, message, message).WithCode(constants.ApprovalNodeFail)
+	}
+	dto := adapter.GetApprovalRequestDto(model, nil, nil, time.Now())
+	if dto.Status != bean.ApprovalStatusApproved {
+		message := fmt.Sprintf("artifact is not approved for deployment, approval request is %s", dto.Status)
+		return nil, util.NewApiError(http.StatusForbidden, message, message).WithCode(constants.ApprovalNodeFail)
+	}
+	return dto, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) MarkArtifactDeploymentTriggered(approvalRequestId int, userId int32) error {
+	err := impl.deploymentApprovalRepository.MarkArtifactDeploymentTriggered(approvalRequestId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in marking approved artifact deployed", "approvalRequestId", approvalRequestId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApprovalRequestById(id int) (*bean.ApprovalRequestDto, error) {
+	requests, err := impl.GetApprovalRequestsByIds([]int{id})
+	if err != nil {
+		return nil, err
+	}
+	request, ok := requests[id]
+	if !ok {
+		return nil, util.NewApiError(http.StatusNotFound, "approval request not found", "approval request not found")
+	}
+	return request, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApprovalRequests(pipelineId int, offset, limit int) ([]*bean.ApprovalRequestDto, error) {
+	models, err := impl.deploymentApprovalRepository.FindByPipelineId(pipelineId, offset, limit)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval requests", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	dtos, err := impl.getApprovalRequestDtos(models)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*bean.ApprovalRequestDto, 0, len(models))
+	for _, model := range models {
+		result = append(result, dtos[model.Id])
+	}
+	return result, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) GetApprovalRequestsByIds(ids []int) (map[int]*bean.ApprovalRequestDto, error) {
+	models, err := impl.deploymentApprovalRepository.FindByIds(ids)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval requests", "ids", ids, "err", err)
+		return nil, err
+	}
+	return impl.getApprovalRequestDtos(models)
+}
+
+func (impl *DeploymentApprovalServiceImpl) getApprovalRequestDtos(models []*repository.DeploymentApprovalRequest) (map[int]*bean.ApprovalRequestDto, error) {
+	result := make(map[int]*bean.ApprovalRequestDto, len(models))
+	if len(models) == 0 {
+		return result, nil
+	}
+	requestIds := make([]int, 0, len(models))
+	userIds := make([]int32, 0, len(models))
+	for _, model := range models {
+		requestIds = append(requestIds, model.Id)
+		userIds = append(userIds, model.CreatedBy)
+	}
+	userData, err := impl.deploymentApprovalRepository.FindUserDataByRequestIds(requestIds, bean.DeploymentApprovalRequestType)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching approval actions", "approvalRequestIds", requestIds, "err", err)
+		return nil, err
+	}
+	requestIdToUserData := make(map[int][]*repository.RequestApprovalUserData)
+	for _, item := range userData {
+		requestIdToUserData[item.ApprovalRequestId] = append(requestIdToUserData[item.ApprovalRequestId], item)
+		userIds = append(userIds, item.UserId)
+	}
+	userEmails := make(map[int32]string)
+	users, err := impl.userService.GetByIds(userIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching users", "userIds", userIds, "err", err)
+	}
+	for _, item := range users {
+		userEmails[item.Id] = item.EmailId
+	}
+	now := time.Now()
+	for _, model := range models {
+		result[model.Id] = adapter.GetApprovalRequestDto(model, requestIdToUserData[model.Id], userEmails, now)
+	}
+	return result, nil
+}
+
+func (impl *DeploymentApprovalServiceImpl) sendApprovalNotification(model *repository.DeploymentApprovalRequest, pipeline *pipelineConfig.Pipeline,
+	eventType util2.EventType, actionBy int32, comment string) {
+	event, err := impl.eventFactory.Build(eventType, &pipeline.Id, pipeline.AppId, &pipeline.EnvironmentId, util2.CD)
+	if err != nil {
+		impl.logger.Errorw("error in building approval event", "approvalRequestId", model.Id, "err", err)
+		return
+	}
+	actionByEmail, err := impl.userService.GetEmailById(actionBy)
+	if err != nil {
+		impl.logger.Errorw("error in fetching user email", "userId", actionBy, "err", err)
+	}
+	payload := &client.Payload{
+		TriggeredBy:           actionByEmail,
+		ImageApprovalLink:     fmt.Sprintf("/dashboard/app/%d/trigger?approval-node=%d", pipeline.AppId, pipeline.Id),
+		ApprovalRequestStatus: model.Status,
+		ApprovalActionBy:      actionByEmail,
+		ApprovalComment:       comment,
+	}
+	artifact, err := impl.ciArtifactRepository.Get(model.CiArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", model.CiArtifactId, "err", err)
+	} else {
+		payload.DockerImageUrl = artifact.Image
+	}
+	event.Payload = payload
+	event.UserId = int(actionBy)
+	event.CiArtifactId = model.CiArtifactId
+	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
+	if evtErr != nil {
+		impl.logger.Errorw("approval event not sent", "approvalRequestId", model.Id, "eventType", eventType, "error", evtErr)
+	}
+}
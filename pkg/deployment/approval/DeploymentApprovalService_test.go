@@ -0,0 +1,486 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package approval
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	eventMocks "github.com/devtron-labs/devtron/client/events/mocks"
+	"github.com/devtron-labs/devtron/internal/constants"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	repositoryMocks "github.com/devtron-labs/devtron/internal/sql/repository/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	pipelineConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	mock_user "github.com/devtron-labs/devtron/pkg/auth/user/mocks"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/repository/mocks"
+	bean2 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	deploymentWindowMocks "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/mocks"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testPipelineId        = 1
+	testEnvironmentId     = 2
+	testArtifactId        = 3
+	testApprovalRequestId = 4
+	testCdWorkflowId      = 5
+	requesterId           = int32(10)
+	approverId            = int32(11)
+	approverRoleGroupId   = int32(20)
+)
+
+type approvalServiceMocks struct {
+	deploymentApprovalRepository *mocks.DeploymentApprovalRepository
+	pipelineRepository           *pipelineConfigMocks.PipelineRepository
+	ciArtifactRepository         *repositoryMocks.CiArtifactRepository
+	userService                  *mock_user.UserService
+	roleGroupService             *mock_user.RoleGroupService
+	eventFactory                 *eventMocks.EventFactory
+	eventClient                  *eventMocks.EventClient
+	deploymentWindowService      *deploymentWindowMocks.DeploymentWindowService
+}
+
+func TestSaveApprovalPolicy(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requiredApprovals    int
+		approverRoleGroupIds []int32
+		// existingRoleGroupIds is nil if the role groups are not expected to be looked up
+		existingRoleGroupIds []int32
+		wantStatusCode       int
+	}{
+		{name: "no approval required", requiredApprovals: 0, wantStatusCode: http.StatusBadRequest},
+		{name: "more than max approvals required", requiredApprovals: bean.MaxRequiredApprovals + 1, wantStatusCode: http.StatusBadRequest},
+		{name: "max approvals required", requiredApprovals: bean.MaxRequiredApprovals},
+		{name: "approver role group not found", requiredApprovals: 2, approverRoleGroupIds: []int32{approverRoleGroupId, 21},
+			existingRoleGroupIds: []int32{approverRoleGroupId}, wantStatusCode: http.StatusBadRequest},
+		{name: "approver role groups found", requiredApprovals: 2, approverRoleGroupIds: []int32{approverRoleGroupId},
+			existingRoleGroupIds: []int32{approverRoleGroupId}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestDeploymentApprovalService(t)
+			if tt.existingRoleGroupIds != nil {
+				roleGroups := make(map[int32]*userRepository.RoleGroup)
+				for _, roleGroupId := range tt.existingRoleGroupIds {
+					roleGroups[roleGroupId] = &userRepository.RoleGroup{Id: roleGroupId}
+				}
+				m.roleGroupService.On("GetGroupIdVsRoleGroupMapForIds", tt.approverRoleGroupIds).Return(roleGroups, nil)
+			}
+			if tt.wantStatusCode == 0 {
+				m.deploymentApprovalRepository.On("UpdateUserApprovalConfig", testPipelineId, mock.MatchedBy(func(config string) bool {
+					userApprovalConfig := &bean.UserApprovalConfig{}
+					return json.Unmarshal([]byte(config), userApprovalConfig) == nil && userApprovalConfig.RequiredCount == tt.requiredApprovals
+				}), requesterId).Return(nil)
+			}
+			_, err := impl.SaveApprovalPolicy(&bean.ApprovalPolicyDto{
+				PipelineId:           testPipelineId,
+				RequiredApprovals:    tt.requiredApprovals,
+				ApproverRoleGroupIds: tt.approverRoleGroupIds,
+				UserId:               requesterId,
+			})
+			assertApiError(t, err, tt.wantStatusCode)
+		})
+	}
+}
+
+func TestTakeApprovalAction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		actionType           bean.ApprovalActionType
+		userId               int32
+		requiredApprovals    int
+		approverRoleGroupIds []int32
+		// userRoleGroupIds is nil if the user is not expected to be looked up
+		userRoleGroupIds   []int32
+		expiresOn          time.Time
+		autoTriggerPending bool
+		// existingApprovals are the users who have already approved the request
+		existingApprovals []int32
+		wantStatusCode    int
+		// wantStatus is the status the request is moved to, empty if the status is not expected to change
+		wantStatus     bean.ApprovalStatus
+		wantQueueAuto  bool
+		wantSavedUsers bool
+	}{
+		{
+			name:              "self approval is rejected",
+			actionType:        bean.ApprovalActionApprove,
+			userId:            requesterId,
+			requiredApprovals: 1,
+			wantStatusCode:    http.StatusForbidden,
+		},
+		{
+			name:              "self rejection is rejected",
+			actionType:        bean.ApprovalActionReject,
+			userId:            requesterId,
+			requiredApprovals: 1,
+			wantStatusCode:    http.StatusForbidden,
+		},
+		{
+			name:                 "user outside the approver role groups",
+			actionType:           bean.ApprovalActionApprove,
+			userId:               approverId,
+			requiredApprovals:    1,
+			approverRoleGroupIds: []int32{approverRoleGroupId},
+			userRoleGroupIds:     []int32{21},
+			wantStatusCode:       http.StatusForbidden,
+		},
+		{
+			name:              "expired request",
+			actionType:        bean.ApprovalActionApprove,
+			userId:            approverId,
+			requiredApprovals: 1,
+			expiresOn:         time.Now().Add(-time.Minute),
+			wantStatusCode:    http.StatusConflict,
+		},
+		{
+			name:              "user already approved",
+			actionType:        bean.ApprovalActionApprove,
+			userId:            approverId,
+			requiredApprovals: 2,
+			existingApprovals: []int32{approverId},
+			wantStatusCode:    http.StatusConflict,
+		},
+		{
+			name:                 "approval below the required count",
+			actionType:           bean.ApprovalActionApprove,
+			userId:               approverId,
+			requiredApprovals:    2,
+			approverRoleGroupIds: []int32{approverRoleGroupId},
+			userRoleGroupIds:     []int32{approverRoleGroupId},
+			wantSavedUsers:       true,
+		},
+		{
+			name:              "approval reaching the required count",
+			actionType:        bean.ApprovalActionApprove,
+			userId:            approverId,
+			requiredApprovals: 2,
+			expiresOn:         time.Now().Add(time.Hour),
+			existingApprovals: []int32{12},
+			wantSavedUsers:    true,
+			wantStatus:        bean.ApprovalStatusApproved,
+		},
+		{
+			name:               "approval of a pending automatic deployment queues the trigger",
+			actionType:         bean.ApprovalActionApprove,
+			userId:             approverId,
+			requiredApprovals:  1,
+			autoTriggerPending: true,
+			wantSavedUsers:     true,
+			wantStatus:         bean.ApprovalStatusApproved,
+			wantQueueAuto:      true,
+		},
+		{
+			name:               "rejection closes the request",
+			actionType:         bean.ApprovalActionReject,
+			userId:             approverId,
+			requiredApprovals:  2,
+			autoTriggerPending: true,
+			existingApprovals:  []int32{12},
+			wantSavedUsers:     true,
+			wantStatus:         bean.ApprovalStatusRejected,
+		},
+		{
+			name:              "cancel by a user other than the requester",
+			actionType:        bean.ApprovalActionCancel,
+			userId:            approverId,
+			requiredApprovals: 1,
+			wantStatusCode:    http.StatusForbidden,
+		},
+		{
+			name:              "cancel by the requester",
+			actionType:        bean.ApprovalActionCancel,
+			userId:            requesterId,
+			requiredApprovals: 1,
+			wantStatus:        bean.ApprovalStatusCancelled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestDeploymentApprovalService(t)
+			model := &repository.DeploymentApprovalRequest{
+				Id:                 testApprovalRequestId,
+				PipelineId:         testPipelineId,
+				CiArtifactId:       testArtifactId,
+				Active:             true,
+				Status:             bean.ApprovalStatusRequested.String(),
+				RequiredApprovals:  tt.requiredApprovals,
+				ExpiresOn:          tt.expiresOn,
+				AutoTriggerPending: tt.autoTriggerPending,
+				CdWorkflowId:       testCdWorkflowId,
+				AuditLog:           sql.NewDefaultAuditLog(requesterId),
+			}
+			m.deploymentApprovalRepository.On("FindById", testApprovalRequestId).Return(model, nil)
+			m.pipelineRepository.On("FindById", testPipelineId).Return(testPipeline(), nil).Maybe()
+			mockApprovalPolicy(m, tt.requiredApprovals, tt.approverRoleGroupIds).Maybe()
+			if tt.userRoleGroupIds != nil {
+				mockUserRoleGroups(m, tt.userId, tt.userRoleGroupIds)
+			}
+			existingActions := make([]*repository.RequestApprovalUserData, 0, len(tt.existingApprovals))
+			for _, userId := range tt.existingApprovals {
+				existingActions = append(existingActions, &repository.RequestApprovalUserData{
+					ApprovalRequestId: testApprovalRequestId,
+					UserId:            userId,
+					UserResponse:      int(bean.UserApprovalResponseApproved),
+				})
+			}
+			m.deploymentApprovalRepository.On("FindUserDataByRequestIds", []int{testApprovalRequestId}, bean.DeploymentApprovalRequestType).
+				Return(existingActions, nil).Maybe()
+			if tt.wantSavedUsers {
+				wantResponse := bean.UserApprovalResponseApproved
+				if tt.actionType == bean.ApprovalActionReject {
+					wantResponse = bean.UserApprovalResponseRejected
+				}
+				m.deploymentApprovalRepository.On("SaveUserData", mock.MatchedBy(func(userData *repository.RequestApprovalUserData) bool {
+					return userData.ApprovalRequestId == testApprovalRequestId && userData.UserId == tt.userId && userData.UserResponse == int(wantResponse)
+				})).Return(nil)
+			}
+			if len(tt.wantStatus) > 0 {
+				m.deploymentApprovalRepository.On("UpdateStatus", testApprovalRequestId, bean.ApprovalStatusRequested.String(), tt.wantStatus.String(), tt.userId).
+					Return(true, nil)
+			}
+			if tt.wantQueueAuto {
+				m.deploymentWindowService.On("QueueTrigger", &bean2.QueuedTriggerDto{
+					PipelineId:    testPipelineId,
+					EnvironmentId: testEnvironmentId,
+					CiArtifactId:  testArtifactId,
+					CdWorkflowId:  testCdWorkflowId,
+					WorkflowType:  apiBean.CD_WORKFLOW_TYPE_DEPLOY.String(),
+					TriggeredBy:   requesterId,
+				}, (*bean2.WindowState)(nil)).Return(nil)
+			}
+			mockNotification(m)
+			m.deploymentApprovalRepository.On("FindByIds", []int{testApprovalRequestId}).Return([]*repository.DeploymentApprovalRequest{model}, nil).Maybe()
+			m.userService.On("GetByIds", mock.Anything).Return(nil, nil).Maybe()
+
+			_, err := impl.TakeApprovalAction(&bean.ApprovalActionRequest{
+				ApprovalRequestId: testApprovalRequestId,
+				ActionType:        tt.actionType,
+				UserId:            tt.userId,
+			})
+			assertApiError(t, err, tt.wantStatusCode)
+			if tt.wantStatusCode != 0 {
+				m.deploymentApprovalRepository.AssertNotCalled(t, "SaveUserData", mock.Anything)
+				m.deploymentApprovalRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if !tt.wantQueueAuto {
+				m.deploymentWindowService.AssertNotCalled(t, "QueueTrigger", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRequestApprovalForAutoTrigger(t *testing.T) {
+	tests := []struct {
+		name string
+		// existingStatus is empty if there is no request for the artifact yet
+		existingStatus    bean.ApprovalStatus
+		existingExpiresOn time.Time
+		wantNewRequest    bool
+		wantQueueAuto     bool
+	}{
+		{name: "no request raised yet", wantNewRequest: true},
+		{name: "open request", existingStatus: bean.ApprovalStatusRequested},
+		{name: "approved request queues the trigger", existingStatus: bean.ApprovalStatusApproved, wantQueueAuto: true},
+		{name: "expired approved request raises a new request", existingStatus: bean.ApprovalStatusApproved,
+			existingExpiresOn: time.Now().Add(-time.Minute), wantNewRequest: true},
+		{name: "rejected request raises a new request", existingStatus: bean.ApprovalStatusRejected, wantNewRequest: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestDeploymentApprovalService(t)
+			mockApprovalPolicy(m, 1, nil)
+			m.ciArtifactRepository.On("Get", testArtifactId).Return(&repository2.CiArtifact{Id: testArtifactId}, nil)
+			if len(tt.existingStatus) == 0 {
+				m.deploymentApprovalRepository.On("FindActiveByPipelineIdAndArtifactId", testPipelineId, testArtifactId).Return(nil, pg.ErrNoRows)
+			} else {
+				existing := &repository.DeploymentApprovalRequest{
+					Id:                testApprovalRequestId,
+					PipelineId:        testPipelineId,
+					CiArtifactId:      testArtifactId,
+					Active:            true,
+					Status:            tt.existingStatus.String(),
+					RequiredApprovals: 1,
+					ExpiresOn:         tt.existingExpiresOn,
+					CdWorkflowId:      testCdWorkflowId,
+					AuditLog:          sql.NewDefaultAuditLog(requesterId),
+				}
+				m.deploymentApprovalRepository.On("FindActiveByPipelineIdAndArtifactId", testPipelineId, testArtifactId).Return(existing, nil)
+			}
+			if tt.wantNewRequest {
+				if len(tt.existingStatus) > 0 {
+					m.deploymentApprovalRepository.On("Update", mock.MatchedBy(func(model *repository.DeploymentApprovalRequest) bool {
+						return model.Id == testApprovalRequestId && !model.Active
+					})).Return(nil).Once()
+				}
+				m.deploymentApprovalRepository.On("Save", mock.MatchedBy(func(model *repository.DeploymentApprovalRequest) bool {
+					return model.Active && model.Status == bean.ApprovalStatusRequested.String()
+				})).Return(nil)
+			}
+			if !tt.wantQueueAuto {
+				m.deploymentApprovalRepository.On("Update", mock.MatchedBy(func(model *repository.DeploymentApprovalRequest) bool {
+					return model.Active && model.AutoTriggerPending && model.CdWorkflowId == testCdWorkflowId
+				})).Return(nil).Once()
+			} else {
+				m.deploymentWindowService.On("QueueTrigger", mock.MatchedBy(func(queuedTrigger *bean2.QueuedTriggerDto) bool {
+					return queuedTrigger.PipelineId == testPipelineId && queuedTrigger.CiArtifactId == testArtifactId && queuedTrigger.CdWorkflowId == testCdWorkflowId
+				}), (*bean2.WindowState)(nil)).Return(nil)
+			}
+			mockNotification(m)
+
+			err := impl.RequestApprovalForAutoTrigger(testPipeline(), testArtifactId, testCdWorkflowId, requesterId)
+			assert.NoError(t, err)
+			if !tt.wantNewRequest {
+				m.deploymentApprovalRepository.AssertNotCalled(t, "Save", mock.Anything)
+			}
+			if !tt.wantQueueAuto {
+				m.deploymentWindowService.AssertNotCalled(t, "QueueTrigger", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCheckArtifactApproved(t *testing.T) {
+	tests := []struct {
+		name           string
+		policyRequired bool
+		// requestStatus is empty if there is no request for the artifact
+		requestStatus  bean.ApprovalStatus
+		expiresOn      time.Time
+		wantApproved   bool
+		wantStatusCode int
+	}{
+		{name: "approval not required"},
+		{name: "no approval request", policyRequired: true, wantStatusCode: http.StatusForbidden},
+		{name: "open request", policyRequired: true, requestStatus: bean.ApprovalStatusRequested, wantStatusCode: http.StatusForbidden},
+		{name: "rejected request", policyRequired: true, requestStatus: bean.ApprovalStatusRejected, wantStatusCode: http.StatusForbidden},
+		{name: "approved request", policyRequired: true, requestStatus: bean.ApprovalStatusApproved, wantApproved: true},
+		{name: "approved request within expiry", policyRequired: true, requestStatus: bean.ApprovalStatusApproved,
+			expiresOn: time.Now().Add(time.Hour), wantApproved: true},
+		{name: "approved request past expiry", policyRequired: true, requestStatus: bean.ApprovalStatusApproved,
+			expiresOn: time.Now().Add(-time.Minute), wantStatusCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestDeploymentApprovalService(t)
+			if tt.policyRequired {
+				mockApprovalPolicy(m, 1, nil)
+				if len(tt.requestStatus) == 0 {
+					m.deploymentApprovalRepository.On("FindActiveByPipelineIdAndArtifactId", testPipelineId, testArtifactId).Return(nil, pg.ErrNoRows)
+				} else {
+					m.deploymentApprovalRepository.On("FindActiveByPipelineIdAndArtifactId", testPipelineId, testArtifactId).Return(&repository.DeploymentApprovalRequest{
+						Id:           testApprovalRequestId,
+						PipelineId:   testPipelineId,
+						CiArtifactId: testArtifactId,
+						Active:       true,
+						Status:       tt.requestStatus.String(),
+						ExpiresOn:    tt.expiresOn,
+					}, nil)
+				}
+			} else {
+				m.deploymentApprovalRepository.On("GetUserApprovalConfig", testPipelineId).Return("", nil)
+			}
+
+			approved, err := impl.CheckArtifactApproved(testPipelineId, testArtifactId)
+			assertApiError(t, err, tt.wantStatusCode)
+			if tt.wantStatusCode != 0 {
+				assert.Equal(t, constants.ApprovalNodeFail, err.(*util.ApiError).Code)
+			}
+			if tt.wantApproved {
+				assert.Equal(t, testApprovalRequestId, approved.Id)
+			} else {
+				assert.Nil(t, approved)
+			}
+		})
+	}
+}
+
+func newTestDeploymentApprovalService(t *testing.T) (*DeploymentApprovalServiceImpl, *approvalServiceMocks) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	m := &approvalServiceMocks{
+		deploymentApprovalRepository: mocks.NewDeploymentApprovalRepository(t),
+		pipelineRepository:           pipelineConfigMocks.NewPipelineRepository(t),
+		ciArtifactRepository:         repositoryMocks.NewCiArtifactRepository(t),
+		userService:                  mock_user.NewUserService(t),
+		roleGroupService:             mock_user.NewRoleGroupService(t),
+		eventFactory:                 eventMocks.NewEventFactory(t),
+		eventClient:                  eventMocks.NewEventClient(t),
+		deploymentWindowService:      deploymentWindowMocks.NewDeploymentWindowService(t),
+	}
+	impl := NewDeploymentApprovalServiceImpl(logger, m.deploymentApprovalRepository, m.pipelineRepository, m.ciArtifactRepository,
+		m.userService, m.roleGroupService, m.eventFactory, m.eventClient, m.deploymentWindowService)
+	return impl, m
+}
+
+func testPipeline() *pipelineConfig.Pipeline {
+	return &pipelineConfig.Pipeline{
+		Id:            testPipelineId,
+		AppId:         6,
+		EnvironmentId: testEnvironmentId,
+	}
+}
+
+func mockApprovalPolicy(m *approvalServiceMocks, requiredApprovals int, approverRoleGroupIds []int32) *mock.Call {
+	config, _ := json.Marshal(&bean.UserApprovalConfig{
+		RequiredCount:        requiredApprovals,
+		ApproverRoleGroupIds: approverRoleGroupIds,
+	})
+	return m.deploymentApprovalRepository.On("GetUserApprovalConfig", testPipelineId).Return(string(config), nil)
+}
+
+func mockUserRoleGroups(m *approvalServiceMocks, userId int32, roleGroupIds []int32) {
+	userInfo := &userBean.UserInfo{Id: userId}
+	for _, roleGroupId := range roleGroupIds {
+		userInfo.UserRoleGroup = append(userInfo.UserRoleGroup, userBean.UserRoleGroup{RoleGroup: &userBean.RoleGroup{Id: roleGroupId}})
+	}
+	m.userService.On("GetByIdWithoutGroupClaims", userId).Return(userInfo, nil)
+}
+
+// mockNotification allows the approval notifications, which are best effort and do not change the outcome
+func mockNotification(m *approvalServiceMocks) {
+	m.eventFactory.On("Build", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(client.Event{}, nil).Maybe()
+	m.userService.On("GetEmailById", mock.Anything).Return("user@example.com", nil).Maybe()
+	m.ciArtifactRepository.On("Get", testArtifactId).Return(&repository2.CiArtifact{Id: testArtifactId}, nil).Maybe()
+	m.eventClient.On("WriteNotificationEvent", mock.Anything).Return(true, nil).Maybe()
+}
+
+// assertApiError checks that err is an api error with wantStatusCode, or that there is no error if wantStatusCode is 0
+func assertApiError(t *testing.T, err error, wantStatusCode int) {
+	if wantStatusCode == 0 {
+		assert.NoError(t, err)
+		return
+	}
+	apiErr, ok := err.(*util.ApiError)
+	if assert.True(t, ok, "expected api error, got %v", err) {
+		assert.Equal(t, wantStatusCode, apiErr.HttpStatusCode)
+	}
+}
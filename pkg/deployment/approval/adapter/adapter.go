@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval/repository"
+	"github.com/devtron-labs/devtron/util"
+)
+
+func GetUserApprovalConfig(policy *bean.ApprovalPolicyDto) *bean.UserApprovalConfig {
+	return &bean.UserApprovalConfig{
+		RequiredCount:        policy.RequiredApprovals,
+		ApproverRoleGroupIds: policy.ApproverRoleGroupIds,
+		ExpiryMinutes:        policy.ExpiryMinutes,
+	}
+}
+
+func GetApprovalPolicyDto(pipelineId int, config *bean.UserApprovalConfig) *bean.ApprovalPolicyDto {
+	approverRoleGroupIds := config.ApproverRoleGroupIds
+	if approverRoleGroupIds == nil {
+		approverRoleGroupIds = make([]int32, 0)
+	}
+	return &bean.ApprovalPolicyDto{
+		PipelineId:           pipelineId,
+		RequiredApprovals:    config.RequiredCount,
+		ApproverRoleGroupIds: approverRoleGroupIds,
+		ExpiryMinutes:        config.ExpiryMinutes,
+	}
+}
+
+// GetApprovalRequestDto converts the request with its user actions, requests past expiry are reported as expired
+func GetApprovalRequestDto(model *repository.DeploymentApprovalRequest, userData []*repository.RequestApprovalUserData,
+	userEmails map[int32]string, now time.Time) *bean.ApprovalRequestDto {
+	dto := &bean.ApprovalRequestDto{
+		Id:                 model.Id,
+		PipelineId:         model.PipelineId,
+		CiArtifactId:       model.CiArtifactId,
+		Status:             bean.ApprovalStatus(model.Status),
+		RequiredApprovals:  model.RequiredApprovals,
+		Comment:            model.Comment,
+		RequestedBy:        model.CreatedBy,
+		RequestedByEmail:   userEmails[model.CreatedBy],
+		RequestedOn:        model.CreatedOn,
+		AutoTriggerPending: model.AutoTriggerPending,
+		Deployed:           model.ArtifactDeploymentTriggered,
+		UserActions:        make([]*bean.ApprovalUserActionDto, 0, len(userData)),
+		ExpiresOn:          util.GetTimePtr(model.ExpiresOn),
+	}
+	if dto.ExpiresOn != nil && (dto.Status == bean.ApprovalStatusRequested || dto.Status == bean.ApprovalStatusApproved) && now.After(*dto.ExpiresOn) {
+		dto.Status = bean.ApprovalStatusExpired
+	}
+	for _, item := range userData {
+		dto.UserActions = append(dto.UserActions, &bean.ApprovalUserActionDto{
+			UserId:    item.UserId,
+			UserEmail: userEmails[item.UserId],
+			Response:  bean.UserApprovalResponse(item.UserResponse).String(),
+			Comment:   item.Comments,
+			ActionOn:  item.CreatedOn,
+		})
+	}
+	return dto
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusRequested ApprovalStatus = "REQUESTED"
+	ApprovalStatusApproved  ApprovalStatus = "APPROVED"
+	ApprovalStatusRejected  ApprovalStatus = "REJECTED"
+	ApprovalStatusCancelled ApprovalStatus = "CANCELLED"
+	// ApprovalStatusExpired is not persisted, requests past their expiry are reported as expired
+	ApprovalStatusExpired ApprovalStatus = "EXPIRED"
+)
+
+func (s ApprovalStatus) String() string {
+	return string(s)
+}
+
+type ApprovalActionType string
+
+const (
+	ApprovalActionApprove ApprovalActionType = "APPROVE"
+	ApprovalActionReject  ApprovalActionType = "REJECT"
+	ApprovalActionCancel  ApprovalActionType = "CANCEL"
+)
+
+// UserApprovalResponse is stored in request_approval_user_data.user_response
+type UserApprovalResponse int
+
+const (
+	UserApprovalResponseApproved UserApprovalResponse = 1
+	UserApprovalResponseRejected UserApprovalResponse = 2
+)
+
+func (r UserApprovalResponse) String() string {
+	switch r {
+	case UserApprovalResponseApproved:
+		return string(ApprovalStatusApproved)
+	case UserApprovalResponseRejected:
+		return string(ApprovalStatusRejected)
+	}
+	return ""
+}
+
+// DeploymentApprovalRequestType is the request_type of request_approval_user_data for deployment approvals
+const DeploymentApprovalRequestType = 1
+
+const MaxRequiredApprovals = 10
+
+// UserApprovalConfig is the approval policy as stored in pipeline.user_approval_config
+type UserApprovalConfig struct {
+	RequiredCount        int     `json:"requiredCount"`
+	ApproverRoleGroupIds []int32 `json:"approverRoleGroupIds,omitempty"`
+	ExpiryMinutes        int     `json:"expiryMinutes,omitempty"`
+}
+
+type ApprovalPolicyDto struct {
+	PipelineId        int `json:"pipelineId" validate:"number,required"`
+	RequiredApprovals int `json:"requiredApprovals" validate:"min=1,max=10"`
+	// ApproverRoleGroupIds restricts the approvers to the members of these role groups, any user with trigger access can approve if empty
+	ApproverRoleGroupIds []int32 `json:"approverRoleGroupIds"`
+	// ExpiryMinutes is the validity of an approval request from the time it is raised, 0 means it never expires
+	ExpiryMinutes int   `json:"expiryMinutes" validate:"min=0"`
+	UserId        int32 `json:"-"`
+}
+
+type RaiseApprovalRequestDto struct {
+	PipelineId   int    `json:"pipelineId" validate:"number,required"`
+	CiArtifactId int    `json:"ciArtifactId" validate:"number,required"`
+	Comment      string `json:"comment" validate:"max=1000"`
+	UserId       int32  `json:"-"`
+}
+
+type ApprovalActionRequest struct {
+	ApprovalRequestId int                `json:"approvalRequestId" validate:"number,required"`
+	ActionType        ApprovalActionType `json:"actionType" validate:"oneof=APPROVE REJECT CANCEL"`
+	Comment           string             `json:"comment" validate:"max=1000"`
+	UserId            int32              `json:"-"`
+}
+
+type ApprovalRequestDto struct {
+	Id                 int                      `json:"id"`
+	PipelineId         int                      `json:"pipelineId"`
+	CiArtifactId       int                      `json:"ciArtifactId"`
+	Status             ApprovalStatus           `json:"status"`
+	RequiredApprovals  int                      `json:"requiredApprovals"`
+	Comment            string                   `json:"comment,omitempty"`
+	RequestedBy        int32                    `json:"requestedBy"`
+	RequestedByEmail   string                   `json:"requestedByEmail,omitempty"`
+	RequestedOn        time.Time                `json:"requestedOn"`
+	ExpiresOn          *time.Time               `json:"expiresOn,omitempty"`
+	AutoTriggerPending bool                     `json:"autoTriggerPending"`
+	Deployed           bool                     `json:"deployed"`
+	UserActions        []*ApprovalUserActionDto `json:"userActions"`
+}
+
+// GetApprovalCount returns the number of users who have approved the request
+func (dto *ApprovalRequestDto) GetApprovalCount() int {
+	count := 0
+	for _, action := range dto.UserActions {
+		if action.Response == UserApprovalResponseApproved.String() {
+			count++
+		}
+	}
+	return count
+}
+
+type ApprovalUserActionDto struct {
+	UserId    int32     `json:"userId"`
+	UserEmail string    `json:"userEmail,omitempty"`
+	Response  string    `json:"response"`
+	Comment   string    `json:"comment,omitempty"`
+	ActionOn  time.Time `json:"actionOn"`
+}
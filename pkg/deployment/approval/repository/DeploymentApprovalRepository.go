@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentApprovalRequest struct {
+	tableName                   struct{}  `sql:"deployment_approval_request" pg:",discard_unknown_columns"`
+	Id                          int       `sql:"id,pk"`
+	PipelineId                  int       `sql:"pipeline_id"`
+	CiArtifactId                int       `sql:"ci_artifact_id"`
+	Active                      bool      `sql:"active,notnull"`
+	ArtifactDeploymentTriggered bool      `sql:"artifact_deployment_triggered,notnull"`
+	Status                      string    `sql:"status"`
+	RequiredApprovals           int       `sql:"required_approvals"`
+	Comment                     string    `sql:"comment"`
+	ExpiresOn                   time.Time `sql:"expires_on"`
+	AutoTriggerPending          bool      `sql:"auto_trigger_pending,notnull"`
+	CdWorkflowId                int       `sql:"cd_workflow_id"`
+	sql.AuditLog
+}
+
+type RequestApprovalUserData struct {
+	tableName         struct{} `sql:"request_approval_user_data" pg:",discard_unknown_columns"`
+	Id                int      `sql:"id,pk"`
+	ApprovalRequestId int      `sql:"approval_request_id"`
+	UserId            int32    `sql:"user_id"`
+	UserResponse      int      `sql:"user_response,notnull"`
+	Comments          string   `sql:"comments"`
+	RequestType       int      `sql:"request_type,notnull"`
+	sql.AuditLog
+}
+
+// PipelineUserApprovalConfig maps the approval policy column of a cd pipeline, it is not a part of the pipeline model
+// so that the pipeline updates do not overwrite the policy
+type PipelineUserApprovalConfig struct {
+	tableName          struct{} `sql:"pipeline" pg:",discard_unknown_columns"`
+	Id                 int      `sql:"id,pk"`
+	UserApprovalConfig string   `sql:"user_approval_config"`
+}
+
+type DeploymentApprovalRepository interface {
+	Save(request *DeploymentApprovalRequest) error
+	Update(request *DeploymentApprovalRequest) error
+	FindById(id int) (*DeploymentApprovalRequest, error)
+	FindByIds(ids []int) ([]*DeploymentApprovalRequest, error)
+	// FindActiveByPipelineIdAndArtifactId returns the latest request raised for an artifact on a pipeline
+	FindActiveByPipelineIdAndArtifactId(pipelineId, artifactId int) (*DeploymentApprovalRequest, error)
+	FindByPipelineId(pipelineId int, offset, limit int) ([]*DeploymentApprovalRequest, error)
+	// UpdateStatus changes the status only if the request still holds fromStatus,
+	// so that concurrent actions on a request finalise it exactly once
+	UpdateStatus(id int, fromStatus, toStatus string, userId int32) (bool, error)
+	MarkArtifactDeploymentTriggered(id int, userId int32) error
+
+	SaveUserData(userData *RequestApprovalUserData) error
+	FindUserDataByRequestIds(requestIds []int, requestType int) ([]*RequestApprovalUserData, error)
+
+	GetUserApprovalConfig(pipelineId int) (string, error)
+	UpdateUserApprovalConfig(pipelineId int, userApprovalConfig string, userId int32) error
+}
+
+type DeploymentApprovalRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDeploymentApprovalRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DeploymentApprovalRepositoryImpl {
+	return &DeploymentApprovalRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) Save(request *DeploymentApprovalRequest) error {
+	return impl.dbConnection.Insert(request)
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) Update(request *DeploymentApprovalRequest) error {
+	return impl.dbConnection.Update(request)
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) FindById(id int) (*DeploymentApprovalRequest, error) {
+	request := &DeploymentApprovalRequest{}
+	err := impl.dbConnection.Model(request).
+		Where("id = ?", id).
+		Select()
+	return request, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) FindByIds(ids []int) ([]*DeploymentApprovalRequest, error) {
+	var requests []*DeploymentApprovalRequest
+	if len(ids) == 0 {
+		return requests, nil
+	}
+	err := impl.dbConnection.Model(&requests).
+		Where("id in (?)", pg.In(ids)).
+		Select()
+	return requests, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) FindActiveByPipelineIdAndArtifactId(pipelineId, artifactId int) (*DeploymentApprovalRequest, error) {
+	request := &DeploymentApprovalRequest{}
+	err := impl.dbConnection.Model(request).
+		Where("pipeline_id = ?", pipelineId).
+		Where("ci_artifact_id = ?", artifactId).
+		Where("active = ?", true).
+		Order("id DESC").
+		Limit(1).
+		Select()
+	return request, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) FindByPipelineId(pipelineId int, offset, limit int) ([]*DeploymentApprovalRequest, error) {
+	var requests []*DeploymentApprovalRequest
+	err := impl.dbConnection.Model(&requests).
+		Where("pipeline_id = ?", pipelineId).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Select()
+	return requests, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) UpdateStatus(id int, fromStatus, toStatus string, userId int32) (bool, error) {
+	res, err := impl.dbConnection.Model((*DeploymentApprovalRequest)(nil)).
+		Set("status = ?", toStatus).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status = ?", fromStatus).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) MarkArtifactDeploymentTriggered(id int, userId int32) error {
+	_, err := impl.dbConnection.Model((*DeploymentApprovalRequest)(nil)).
+		Set("artifact_deployment_triggered = ?", true).
+		Set("auto_trigger_pending = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Update()
+	return err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) SaveUserData(userData *RequestApprovalUserData) error {
+	return impl.dbConnection.Insert(userData)
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) FindUserDataByRequestIds(requestIds []int, requestType int) ([]*RequestApprovalUserData, error) {
+	var userData []*RequestApprovalUserData
+	if len(requestIds) == 0 {
+		return userData, nil
+	}
+	err := impl.dbConnection.Model(&userData).
+		Where("approval_request_id in (?)", pg.In(requestIds)).
+		Where("request_type = ?", requestType).
+		Order("id ASC").
+		Select()
+	return userData, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) GetUserApprovalConfig(pipelineId int) (string, error) {
+	approvalConfig := &PipelineUserApprovalConfig{}
+	err := impl.dbConnection.Model(approvalConfig).
+		Column("user_approval_config").
+		Where("id = ?", pipelineId).
+		Where("deleted = ?", false).
+		Select()
+	return approvalConfig.UserApprovalConfig, err
+}
+
+func (impl *DeploymentApprovalRepositoryImpl) UpdateUserApprovalConfig(pipelineId int, userApprovalConfig string, userId int32) error {
+	var config interface{}
+	if len(userApprovalConfig) > 0 {
+		config = userApprovalConfig
+	}
+	_, err := impl.dbConnection.Model((*PipelineUserApprovalConfig)(nil)).
+		Set("user_approval_config = ?", config).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", pipelineId).
+		Where("deleted = ?", false).
+		Update()
+	return err
+}
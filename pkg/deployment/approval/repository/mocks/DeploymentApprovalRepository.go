@@ -0,0 +1,323 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "github.com/devtron-labs/devtron/pkg/deployment/approval/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeploymentApprovalRepository is an autogenerated mock type for the DeploymentApprovalRepository type
+type DeploymentApprovalRepository struct {
+	mock.Mock
+}
+
+// FindActiveByPipelineIdAndArtifactId provides a mock function with given fields: pipelineId, artifactId
+func (_m *DeploymentApprovalRepository) FindActiveByPipelineIdAndArtifactId(pipelineId int, artifactId int) (*repository.DeploymentApprovalRequest, error) {
+	ret := _m.Called(pipelineId, artifactId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByPipelineIdAndArtifactId")
+	}
+
+	var r0 *repository.DeploymentApprovalRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*repository.DeploymentApprovalRequest, error)); ok {
+		return rf(pipelineId, artifactId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *repository.DeploymentApprovalRequest); ok {
+		r0 = rf(pipelineId, artifactId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.DeploymentApprovalRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(pipelineId, artifactId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindById provides a mock function with given fields: id
+func (_m *DeploymentApprovalRepository) FindById(id int) (*repository.DeploymentApprovalRequest, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindById")
+	}
+
+	var r0 *repository.DeploymentApprovalRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*repository.DeploymentApprovalRequest, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *repository.DeploymentApprovalRequest); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.DeploymentApprovalRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIds provides a mock function with given fields: ids
+func (_m *DeploymentApprovalRepository) FindByIds(ids []int) ([]*repository.DeploymentApprovalRequest, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIds")
+	}
+
+	var r0 []*repository.DeploymentApprovalRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*repository.DeploymentApprovalRequest, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*repository.DeploymentApprovalRequest); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.DeploymentApprovalRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPipelineId provides a mock function with given fields: pipelineId, offset, limit
+func (_m *DeploymentApprovalRepository) FindByPipelineId(pipelineId int, offset int, limit int) ([]*repository.DeploymentApprovalRequest, error) {
+	ret := _m.Called(pipelineId, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPipelineId")
+	}
+
+	var r0 []*repository.DeploymentApprovalRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int) ([]*repository.DeploymentApprovalRequest, error)); ok {
+		return rf(pipelineId, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int) []*repository.DeploymentApprovalRequest); ok {
+		r0 = rf(pipelineId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.DeploymentApprovalRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(pipelineId, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserDataByRequestIds provides a mock function with given fields: requestIds, requestType
+func (_m *DeploymentApprovalRepository) FindUserDataByRequestIds(requestIds []int, requestType int) ([]*repository.RequestApprovalUserData, error) {
+	ret := _m.Called(requestIds, requestType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserDataByRequestIds")
+	}
+
+	var r0 []*repository.RequestApprovalUserData
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int, int) ([]*repository.RequestApprovalUserData, error)); ok {
+		return rf(requestIds, requestType)
+	}
+	if rf, ok := ret.Get(0).(func([]int, int) []*repository.RequestApprovalUserData); ok {
+		r0 = rf(requestIds, requestType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.RequestApprovalUserData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int, int) error); ok {
+		r1 = rf(requestIds, requestType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserApprovalConfig provides a mock function with given fields: pipelineId
+func (_m *DeploymentApprovalRepository) GetUserApprovalConfig(pipelineId int) (string, error) {
+	ret := _m.Called(pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserApprovalConfig")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (string, error)); ok {
+		return rf(pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(pipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(pipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkArtifactDeploymentTriggered provides a mock function with given fields: id, userId
+func (_m *DeploymentApprovalRepository) MarkArtifactDeploymentTriggered(id int, userId int32) error {
+	ret := _m.Called(id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkArtifactDeploymentTriggered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int32) error); ok {
+		r0 = rf(id, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: request
+func (_m *DeploymentApprovalRepository) Save(request *repository.DeploymentApprovalRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.DeploymentApprovalRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUserData provides a mock function with given fields: userData
+func (_m *DeploymentApprovalRepository) SaveUserData(userData *repository.RequestApprovalUserData) error {
+	ret := _m.Called(userData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUserData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.RequestApprovalUserData) error); ok {
+		r0 = rf(userData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: request
+func (_m *DeploymentApprovalRepository) Update(request *repository.DeploymentApprovalRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.DeploymentApprovalRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: id, fromStatus, toStatus, userId
+func (_m *DeploymentApprovalRepository) UpdateStatus(id int, fromStatus string, toStatus string, userId int32) (bool, error) {
+	ret := _m.Called(id, fromStatus, toStatus, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, string, int32) (bool, error)); ok {
+		return rf(id, fromStatus, toStatus, userId)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, string, int32) bool); ok {
+		r0 = rf(id, fromStatus, toStatus, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, string, int32) error); ok {
+		r1 = rf(id, fromStatus, toStatus, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserApprovalConfig provides a mock function with given fields: pipelineId, userApprovalConfig, userId
+func (_m *DeploymentApprovalRepository) UpdateUserApprovalConfig(pipelineId int, userApprovalConfig string, userId int32) error {
+	ret := _m.Called(pipelineId, userApprovalConfig, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserApprovalConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, int32) error); ok {
+		r0 = rf(pipelineId, userApprovalConfig, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDeploymentApprovalRepository creates a new instance of DeploymentApprovalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeploymentApprovalRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeploymentApprovalRepository {
+	mock := &DeploymentApprovalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package autoRollback
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// unhealthyDeploymentStatuses are the deployment statuses rolled back once the health timeout is over
+var unhealthyDeploymentStatuses = []string{cdWorkflow.WorkflowFailed, cdWorkflow.WorkflowTimedOut, string(health.HealthStatusDegraded)}
+
+// healthyDeploymentStatuses are the deployment statuses a rollback can target
+var healthyDeploymentStatuses = []string{string(health.HealthStatusHealthy), cdWorkflow.WorkflowSucceeded}
+
+type AutoRollbackService interface {
+	// GetPolicy returns nil if auto rollback is not configured for the pipeline
+	GetPolicy(pipelineId int) (*bean.AutoRollbackPolicyDto, error)
+	SavePolicy(policy *bean.AutoRollbackPolicyDto) (*bean.AutoRollbackPolicyDto, error)
+	DeletePolicy(pipelineId int, userId int32) error
+	GetHistory(pipelineId int, offset, limit int) ([]*bean.AutoRollbackHistoryDto, error)
+
+	// GetRollbackCandidates returns the latest deployments of the pipelines with auto rollback enabled
+	// which are still unhealthy after the health timeout and are not yet considered for rollback
+	GetRollbackCandidates(now time.Time) ([]*bean.RollbackCandidate, error)
+	// ClaimRollback saves the history of the candidate, false is returned if the candidate is already claimed.
+	// Candidates which are rollbacks themselves or have no rollback target are saved as skipped.
+	ClaimRollback(candidate *bean.RollbackCandidate) (historyId int, claimed bool, err error)
+	UpdateRollbackOutcome(historyId int, status bean.RollbackStatus, rollbackWfrId int, message string) error
+	SendRollbackNotification(candidate *bean.RollbackCandidate, status bean.RollbackStatus, message string)
+}
+
+type AutoRollbackServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	autoRollbackPolicyRepository  repository.AutoRollbackPolicyRepository
+	autoRollbackHistoryRepository repository.AutoRollbackHistoryRepository
+	pipelineRepository            pipelineConfig.PipelineRepository
+	cdWorkflowRepository          pipelineConfig.CdWorkflowRepository
+	pipelineOverrideRepository    chartConfig.PipelineOverrideRepository
+	ciArtifactRepository          repository2.CiArtifactRepository
+	eventFactory                  client.EventFactory
+	eventClient                   client.EventClient
+}
+
+func NewAutoRollbackServiceImpl(logger *zap.SugaredLogger,
+	autoRollbackPolicyRepository repository.AutoRollbackPolicyRepository,
+	autoRollbackHistoryRepository repository.AutoRollbackHistoryRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	eventFactory client.EventFactory,
+	eventClient client.EventClient) *AutoRollbackServiceImpl {
+	return &AutoRollbackServiceImpl{
+		logger:                        logger,
+		autoRollbackPolicyRepository:  autoRollbackPolicyRepository,
+		autoRollbackHistoryRepository: autoRollbackHistoryRepository,
+		pipelineRepository:            pipelineRepository,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		pipelineOverrideRepository:    pipelineOverrideRepository,
+		ciArtifactRepository:          ciArtifactRepository,
+		eventFactory:                  eventFactory,
+		eventClient:                   eventClient,
+	}
+}
+
+func (impl *AutoRollbackServiceImpl) GetPolicy(pipelineId int) (*bean.AutoRollbackPolicyDto, error) {
+	policy, err := impl.autoRollbackPolicyRepository.FindByPipelineId(pipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching auto rollback policy", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetAutoRollbackPolicyDto(policy), nil
+}
+
+func (impl *AutoRollbackServiceImpl) SavePolicy(policyDto *bean.AutoRollbackPolicyDto) (*bean.AutoRollbackPolicyDto, error) {
+	if policyDto.HealthTimeoutMinutes < bean.MinHealthTimeoutMinutes || policyDto.HealthTimeoutMinutes > bean.MaxHealthTimeoutMinutes {
+		return nil, util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("health timeout should be between %d and %d minutes", bean.MinHealthTimeoutMinutes, bean.MaxHealthTimeoutMinutes), "invalid health timeout")
+	}
+	pipeline, err := impl.pipelineRepository.FindById(policyDto.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.Deleted {
+		return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline is deleted")
+	}
+	policy, err := impl.autoRollbackPolicyRepository.FindByPipelineId(policyDto.PipelineId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching auto rollback policy", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	if errors.Is(err, pg.ErrNoRows) {
+		policy = &repository.AutoRollbackPolicy{
+			PipelineId: policyDto.PipelineId,
+			Active:     true,
+			AuditLog:   sql.NewDefaultAuditLog(policyDto.UserId),
+		}
+	}
+	policy.Enabled = policyDto.Enabled
+	policy.HealthTimeoutMinutes = policyDto.HealthTimeoutMinutes
+	if policy.Id == 0 {
+		err = impl.autoRollbackPolicyRepository.Save(policy)
+	} else {
+		policy.UpdateAuditLog(policyDto.UserId)
+		err = impl.autoRollbackPolicyRepository.Update(policy)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving auto rollback policy", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetAutoRollbackPolicyDto(policy), nil
+}
+
+func (impl *AutoRollbackServiceImpl) DeletePolicy(pipelineId int, userId int32) error {
+	err := impl.autoRollbackPolicyRepository.MarkInactiveByPipelineId(pipelineId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting auto rollback policy", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *AutoRollbackServiceImpl) GetHistory(pipelineId int, offset, limit int) ([]*bean.AutoRollbackHistoryDto, error) {
+	histories, err := impl.autoRollbackHistoryRepository.FindByPipelineId(pipelineId, offset, limit)
+	if err != nil {
+		impl.logger.Errorw("error in fetching auto rollback history", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	result := make([]*bean.AutoRollbackHistoryDto, 0, len(histories))
+	for _, history := range histories {
+		result = append(result, adapter.GetAutoRollbackHistoryDto(history))
+	}
+	return result, nil
+}
+
+func (impl *AutoRollbackServiceImpl) GetRollbackCandidates(now time.Time) ([]*bean.RollbackCandidate, error) {
+	policies, err := impl.autoRollbackPolicyRepository.FindAllEnabled()
+	if err != nil {
+		impl.logger.Errorw("error in fetching enabled auto rollback policies", "err", err)
+		return nil, err
+	}
+	candidates := make([]*bean.RollbackCandidate, 0)
+	for _, policy := range policies {
+		candidate, err := impl.getRollbackCandidate(policy, now)
+		if err != nil {
+			// other pipelines are still evaluated
+			impl.logger.Errorw("error in evaluating auto rollback of pipeline", "pipelineId", policy.PipelineId, "err", err)
+			continue
+		}
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+func (impl *AutoRollbackServiceImpl) getRollbackCandidate(policy *repository.AutoRollbackPolicy, now time.Time) (*bean.RollbackCandidate, error) {
+	latestRunner, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(policy.PipelineId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if util.IsErrNoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(unhealthyDeploymentStatuses, latestRunner.Status) {
+		return nil, nil
+	}
+	healthTimeout := time.Duration(policy.HealthTimeoutMinutes) * time.Minute
+	if latestRunner.StartedOn.Add(healthTimeout).After(now) {
+		// deployment can still recover
+		return nil, nil
+	}
+	isConsidered, err := impl.autoRollbackHistoryRepository.ExistsByFailedWfrId(latestRunner.Id)
+	if err != nil {
+		return nil, err
+	}
+	if isConsidered {
+		return nil, nil
+	}
+	deploymentType, err := impl.getDeploymentType(latestRunner.CdWorkflowId)
+	if err != nil {
+		return nil, err
+	}
+	if isHibernationDeployment(deploymentType) {
+		// hibernation and un-hibernation are not rolled back
+		return nil, nil
+	}
+	candidate := &bean.RollbackCandidate{
+		PipelineId:   policy.PipelineId,
+		FailedWfrId:  latestRunner.Id,
+		FailedStatus: latestRunner.Status,
+	}
+	if latestRunner.CdWorkflow != nil {
+		candidate.FailedArtifact = latestRunner.CdWorkflow.CiArtifactId
+		if latestRunner.CdWorkflow.Pipeline != nil {
+			candidate.AppId = latestRunner.CdWorkflow.Pipeline.AppId
+			candidate.EnvironmentId = latestRunner.CdWorkflow.Pipeline.EnvironmentId
+		}
+		if latestRunner.CdWorkflow.CiArtifact != nil {
+			candidate.FailedImage = latestRunner.CdWorkflow.CiArtifact.Image
+		}
+	}
+	// a rollback deployment which is not healthy is not rolled back again, so that rollbacks do not cascade.
+	// The deployment type covers rollbacks whose runner is not yet recorded in the history.
+	candidate.IsRollbackDeployment = deploymentType == models.DEPLOYMENTTYPE_ROLLBACK && latestRunner.TriggeredBy == userBean.SystemUserId
+	if !candidate.IsRollbackDeployment {
+		candidate.IsRollbackDeployment, err = impl.autoRollbackHistoryRepository.ExistsByRollbackWfrId(latestRunner.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if candidate.IsRollbackDeployment {
+		return candidate, nil
+	}
+	healthyRunners, err := impl.cdWorkflowRepository.FindPreviousCdWfRunnersInStatus(policy.PipelineId, latestRunner.Id, healthyDeploymentStatuses, bean.RollbackTargetLookupLimit)
+	if err != nil && !util.IsErrNoRows(err) {
+		return nil, err
+	}
+	for _, runner := range healthyRunners {
+		deploymentType, err = impl.getDeploymentType(runner.CdWorkflowId)
+		if err != nil {
+			return nil, err
+		}
+		if isHibernationDeployment(deploymentType) {
+			continue
+		}
+		candidate.TargetWfrId = runner.Id
+		candidate.TargetArtifactId = runner.CdWorkflow.CiArtifactId
+		break
+	}
+	return candidate, nil
+}
+
+func (impl *AutoRollbackServiceImpl) getDeploymentType(cdWorkflowId int) (models.DeploymentType, error) {
+	override, err := impl.pipelineOverrideRepository.FindLatestByCdWorkflowId(cdWorkflowId)
+	if util.IsErrNoRows(err) {
+		// release failed before the override was saved
+		return models.DEPLOYMENTTYPE_UNKNOWN, nil
+	} else if err != nil {
+		return models.DEPLOYMENTTYPE_UNKNOWN, err
+	}
+	return override.DeploymentType, nil
+}
+
+func isHibernationDeployment(deploymentType models.DeploymentType) bool {
+	return deploymentType == models.DEPLOYMENTTYPE_STOP || deploymentType == models.DEPLOYMENTTYPE_START
+}
+
+func (impl *AutoRollbackServiceImpl) ClaimRollback(candidate *bean.RollbackCandidate) (int, bool, error) {
+	history := adapter.NewAutoRollbackHistory(candidate, userBean.SystemUserId)
+	claimed, err := impl.autoRollbackHistoryRepository.SaveIfAbsent(history)
+	if err != nil {
+		impl.logger.Errorw("error in saving auto rollback history", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId, "err", err)
+		return 0, false, err
+	}
+	return history.Id, claimed, nil
+}
+
+func (impl *AutoRollbackServiceImpl) UpdateRollbackOutcome(historyId int, status bean.RollbackStatus, rollbackWfrId int, message string) error {
+	err := impl.autoRollbackHistoryRepository.UpdateOutcome(historyId, status.String(), rollbackWfrId, message, userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in updating auto rollback history", "historyId", historyId, "status", status, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *AutoRollbackServiceImpl) SendRollbackNotification(candidate *bean.RollbackCandidate, status bean.RollbackStatus, message string) {
+	event, err := impl.eventFactory.Build(util2.AutoRollback, &candidate.PipelineId, candidate.AppId, &candidate.EnvironmentId, util2.CD)
+	if err != nil {
+		impl.logger.Errorw("error in building auto rollback event", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId, "err", err)
+		return
+	}
+	payload := &client.Payload{
+		AutoRollbackStatus:   status.String(),
+		AutoRollbackMessage:  message,
+		FailedDockerImageUrl: candidate.FailedImage,
+	}
+	if candidate.TargetArtifactId > 0 {
+		event.CiArtifactId = candidate.TargetArtifactId
+		artifact, err := impl.ciArtifactRepository.Get(candidate.TargetArtifactId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching artifact", "artifactId", candidate.TargetArtifactId, "err", err)
+		} else {
+			payload.DockerImageUrl = artifact.Image
+		}
+	}
+	event.Payload = payload
+	event.UserId = userBean.SystemUserId
+	event.CdWorkflowRunnerId = candidate.FailedWfrId
+	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
+	if evtErr != nil {
+		impl.logger.Errorw("auto rollback event not sent", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId, "error", evtErr)
+	}
+}
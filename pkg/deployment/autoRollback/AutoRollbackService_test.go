@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoRollback
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	chartConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/chartConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	pipelineConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testPipelineId     = 1
+	failedWfrId        = 9
+	failedCdWorkflowId = 90
+)
+
+type healthyRunner struct {
+	wfrId          int
+	artifactId     int
+	deploymentType models.DeploymentType
+}
+
+func TestGetRollbackCandidates(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name                 string
+		failedStartedOn      time.Time
+		alreadyConsidered    bool
+		failedDeploymentType models.DeploymentType
+		failedTriggeredBy    int32
+		// rolledBackInHistory is nil if the history is not expected to be looked up
+		rolledBackInHistory *bool
+		// healthyRunners is nil if the rollback target is not expected to be looked up
+		healthyRunners       []healthyRunner
+		wantCandidate        bool
+		wantRollback         bool
+		wantTargetWfrId      int
+		wantTargetArtifactId int
+	}{
+		{
+			name:                 "last healthy release is the rollback target",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_DEPLOY,
+			failedTriggeredBy:    2,
+			rolledBackInHistory:  boolPtr(false),
+			healthyRunners: []healthyRunner{
+				{wfrId: 8, artifactId: 800, deploymentType: models.DEPLOYMENTTYPE_DEPLOY},
+				{wfrId: 7, artifactId: 700, deploymentType: models.DEPLOYMENTTYPE_DEPLOY},
+			},
+			wantCandidate:        true,
+			wantTargetWfrId:      8,
+			wantTargetArtifactId: 800,
+		},
+		{
+			name:                 "hibernation releases are not rollback targets",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_DEPLOY,
+			failedTriggeredBy:    2,
+			rolledBackInHistory:  boolPtr(false),
+			healthyRunners: []healthyRunner{
+				{wfrId: 8, artifactId: 800, deploymentType: models.DEPLOYMENTTYPE_STOP},
+				{wfrId: 7, artifactId: 700, deploymentType: models.DEPLOYMENTTYPE_START},
+				{wfrId: 6, artifactId: 600, deploymentType: models.DEPLOYMENTTYPE_DEPLOY},
+			},
+			wantCandidate:        true,
+			wantTargetWfrId:      6,
+			wantTargetArtifactId: 600,
+		},
+		{
+			name:                 "no healthy release leaves the candidate without a target",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_DEPLOY,
+			failedTriggeredBy:    2,
+			rolledBackInHistory:  boolPtr(false),
+			healthyRunners:       []healthyRunner{},
+			wantCandidate:        true,
+		},
+		{
+			name:                 "manual rollback by a user is rolled back",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_ROLLBACK,
+			failedTriggeredBy:    2,
+			rolledBackInHistory:  boolPtr(false),
+			healthyRunners: []healthyRunner{
+				{wfrId: 8, artifactId: 800, deploymentType: models.DEPLOYMENTTYPE_DEPLOY},
+			},
+			wantCandidate:        true,
+			wantTargetWfrId:      8,
+			wantTargetArtifactId: 800,
+		},
+		{
+			name:                 "auto rollback is not rolled back again",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_ROLLBACK,
+			failedTriggeredBy:    userBean.SystemUserId,
+			wantCandidate:        true,
+			wantRollback:         true,
+		},
+		{
+			name:                 "auto rollback recorded in the history is not rolled back again",
+			failedStartedOn:      now.Add(-time.Hour),
+			failedDeploymentType: models.DEPLOYMENTTYPE_UNKNOWN,
+			failedTriggeredBy:    userBean.SystemUserId,
+			rolledBackInHistory:  boolPtr(true),
+			wantCandidate:        true,
+			wantRollback:         true,
+		},
+		{
+			name:            "deployment within the health timeout can still recover",
+			failedStartedOn: now.Add(-time.Minute),
+		},
+		{
+			name:              "deployment already considered for rollback",
+			failedStartedOn:   now.Add(-time.Hour),
+			alreadyConsidered: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, policyRepository, historyRepository, cdWorkflowRepository, pipelineOverrideRepository := newTestAutoRollbackService(t)
+			policyRepository.On("FindAllEnabled").Return([]*repository.AutoRollbackPolicy{
+				{PipelineId: testPipelineId, Enabled: true, HealthTimeoutMinutes: 10},
+			}, nil)
+			cdWorkflowRepository.On("FindLatestByPipelineIdAndRunnerType", testPipelineId, apiBean.CD_WORKFLOW_TYPE_DEPLOY).Return(pipelineConfig.CdWorkflowRunner{
+				Id:           failedWfrId,
+				Status:       cdWorkflow.WorkflowFailed,
+				StartedOn:    tt.failedStartedOn,
+				TriggeredBy:  tt.failedTriggeredBy,
+				CdWorkflowId: failedCdWorkflowId,
+				CdWorkflow:   &pipelineConfig.CdWorkflow{Id: failedCdWorkflowId, CiArtifactId: 900},
+			}, nil)
+			if tt.failedStartedOn.Before(now.Add(-10 * time.Minute)) {
+				historyRepository.On("ExistsByFailedWfrId", failedWfrId).Return(tt.alreadyConsidered, nil)
+			}
+			if tt.wantCandidate {
+				mockDeploymentType(pipelineOverrideRepository, failedCdWorkflowId, tt.failedDeploymentType)
+			}
+			if tt.rolledBackInHistory != nil {
+				historyRepository.On("ExistsByRollbackWfrId", failedWfrId).Return(*tt.rolledBackInHistory, nil)
+			}
+			if tt.healthyRunners != nil {
+				runners := make([]*pipelineConfig.CdWorkflowRunner, 0, len(tt.healthyRunners))
+				for _, runner := range tt.healthyRunners {
+					cdWorkflowId := runner.wfrId * 10
+					runners = append(runners, &pipelineConfig.CdWorkflowRunner{
+						Id:           runner.wfrId,
+						CdWorkflowId: cdWorkflowId,
+						CdWorkflow:   &pipelineConfig.CdWorkflow{Id: cdWorkflowId, CiArtifactId: runner.artifactId},
+					})
+					// runners after the target are not looked up
+					mockDeploymentType(pipelineOverrideRepository, cdWorkflowId, runner.deploymentType).Maybe()
+				}
+				cdWorkflowRepository.On("FindPreviousCdWfRunnersInStatus", testPipelineId, failedWfrId, healthyDeploymentStatuses, bean.RollbackTargetLookupLimit).
+					Return(runners, nil)
+			}
+
+			candidates, err := impl.GetRollbackCandidates(now)
+
+			assert.NoError(t, err)
+			if !tt.wantCandidate {
+				assert.Empty(t, candidates)
+				return
+			}
+			if assert.Len(t, candidates, 1) {
+				candidate := candidates[0]
+				assert.Equal(t, failedWfrId, candidate.FailedWfrId)
+				assert.Equal(t, tt.wantRollback, candidate.IsRollbackDeployment)
+				assert.Equal(t, tt.wantTargetWfrId, candidate.TargetWfrId)
+				assert.Equal(t, tt.wantTargetArtifactId, candidate.TargetArtifactId)
+			}
+		})
+	}
+}
+
+func TestClaimRollback(t *testing.T) {
+	tests := []struct {
+		name          string
+		candidate     *bean.RollbackCandidate
+		savedByOthers bool
+		saveErr       error
+		wantClaimed   bool
+		wantStatus    bean.RollbackStatus
+		wantErr       bool
+	}{
+		{
+			name:        "first replica claims the rollback",
+			candidate:   &bean.RollbackCandidate{PipelineId: testPipelineId, FailedWfrId: failedWfrId, TargetWfrId: 8, TargetArtifactId: 800},
+			wantClaimed: true,
+			wantStatus:  bean.RollbackStatusInitiated,
+		},
+		{
+			name:          "rollback claimed by another replica is not claimed again",
+			candidate:     &bean.RollbackCandidate{PipelineId: testPipelineId, FailedWfrId: failedWfrId, TargetWfrId: 8, TargetArtifactId: 800},
+			savedByOthers: true,
+			wantStatus:    bean.RollbackStatusInitiated,
+		},
+		{
+			name:        "auto rollback is claimed as skipped",
+			candidate:   &bean.RollbackCandidate{PipelineId: testPipelineId, FailedWfrId: failedWfrId, IsRollbackDeployment: true},
+			wantClaimed: true,
+			wantStatus:  bean.RollbackStatusSkipped,
+		},
+		{
+			name:        "candidate without a target is claimed as skipped",
+			candidate:   &bean.RollbackCandidate{PipelineId: testPipelineId, FailedWfrId: failedWfrId},
+			wantClaimed: true,
+			wantStatus:  bean.RollbackStatusSkipped,
+		},
+		{
+			name:       "error in saving the claim",
+			candidate:  &bean.RollbackCandidate{PipelineId: testPipelineId, FailedWfrId: failedWfrId, TargetWfrId: 8, TargetArtifactId: 800},
+			saveErr:    errors.New("connection refused"),
+			wantStatus: bean.RollbackStatusInitiated,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, _, historyRepository, _, _ := newTestAutoRollbackService(t)
+			historyRepository.On("SaveIfAbsent", mock.MatchedBy(func(history *repository.AutoRollbackHistory) bool {
+				return history.FailedCdWorkflowRunnerId == tt.candidate.FailedWfrId && history.Status == tt.wantStatus.String() &&
+					history.CiArtifactId == tt.candidate.TargetArtifactId
+			})).Run(func(args mock.Arguments) {
+				if tt.saveErr == nil && !tt.savedByOthers {
+					args.Get(0).(*repository.AutoRollbackHistory).Id = 5
+				}
+			}).Return(!tt.savedByOthers && tt.saveErr == nil, tt.saveErr)
+
+			historyId, claimed, err := impl.ClaimRollback(tt.candidate)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, claimed)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantClaimed, claimed)
+			if tt.wantClaimed {
+				assert.Equal(t, 5, historyId)
+			}
+		})
+	}
+}
+
+func newTestAutoRollbackService(t *testing.T) (*AutoRollbackServiceImpl, *mocks.AutoRollbackPolicyRepository, *mocks.AutoRollbackHistoryRepository,
+	*pipelineConfigMocks.CdWorkflowRepository, *chartConfigMocks.PipelineOverrideRepository) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	policyRepository := mocks.NewAutoRollbackPolicyRepository(t)
+	historyRepository := mocks.NewAutoRollbackHistoryRepository(t)
+	cdWorkflowRepository := pipelineConfigMocks.NewCdWorkflowRepository(t)
+	pipelineOverrideRepository := chartConfigMocks.NewPipelineOverrideRepository(t)
+	impl := NewAutoRollbackServiceImpl(logger, policyRepository, historyRepository, nil, cdWorkflowRepository,
+		pipelineOverrideRepository, nil, nil, nil)
+	return impl, policyRepository, historyRepository, cdWorkflowRepository, pipelineOverrideRepository
+}
+
+func mockDeploymentType(pipelineOverrideRepository *chartConfigMocks.PipelineOverrideRepository, cdWorkflowId int, deploymentType models.DeploymentType) *mock.Call {
+	return pipelineOverrideRepository.On("FindLatestByCdWorkflowId", cdWorkflowId).Return(&chartConfig.PipelineOverride{
+		CdWorkflowId:   cdWorkflowId,
+		DeploymentType: deploymentType,
+	}, nil)
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetAutoRollbackPolicyDto(model *repository.AutoRollbackPolicy) *bean.AutoRollbackPolicyDto {
+	return &bean.AutoRollbackPolicyDto{
+		Id:                   model.Id,
+		PipelineId:           model.PipelineId,
+		Enabled:              model.Enabled,
+		HealthTimeoutMinutes: model.HealthTimeoutMinutes,
+	}
+}
+
+func GetAutoRollbackHistoryDto(model *repository.AutoRollbackHistory) *bean.AutoRollbackHistoryDto {
+	return &bean.AutoRollbackHistoryDto{
+		Id:                         model.Id,
+		PipelineId:                 model.PipelineId,
+		FailedCdWorkflowRunnerId:   model.FailedCdWorkflowRunnerId,
+		FailedStatus:               model.FailedStatus,
+		TargetCdWorkflowRunnerId:   model.TargetCdWorkflowRunnerId,
+		CiArtifactId:               model.CiArtifactId,
+		RollbackCdWorkflowRunnerId: model.RollbackCdWorkflowRunnerId,
+		Status:                     bean.RollbackStatus(model.Status),
+		Message:                    model.Message,
+		CreatedOn:                  model.CreatedOn,
+	}
+}
+
+// NewAutoRollbackHistory returns the history of a candidate, candidates without a rollback target are saved as skipped
+func NewAutoRollbackHistory(candidate *bean.RollbackCandidate, userId int32) *repository.AutoRollbackHistory {
+	history := &repository.AutoRollbackHistory{
+		PipelineId:               candidate.PipelineId,
+		FailedCdWorkflowRunnerId: candidate.FailedWfrId,
+		FailedStatus:             candidate.FailedStatus,
+		TargetCdWorkflowRunnerId: candidate.TargetWfrId,
+		CiArtifactId:             candidate.TargetArtifactId,
+		Status:                   bean.RollbackStatusInitiated.String(),
+		AuditLog:                 sql.NewDefaultAuditLog(userId),
+	}
+	if candidate.IsRollbackDeployment {
+		history.Status = bean.RollbackStatusSkipped.String()
+		history.Message = bean.SkippedForRollbackDeploymentMessage
+	} else if candidate.TargetWfrId == 0 {
+		history.Status = bean.RollbackStatusSkipped.String()
+		history.Message = bean.NoHealthyDeploymentMessage
+	}
+	return history
+}
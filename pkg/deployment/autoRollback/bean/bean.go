@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bean
+
+import "time"
+
+type RollbackStatus string
+
+const (
+	// RollbackStatusInitiated is set when a replica claims an unhealthy deployment for rollback
+	RollbackStatusInitiated RollbackStatus = "INITIATED"
+	RollbackStatusTriggered RollbackStatus = "TRIGGERED"
+	RollbackStatusFailed    RollbackStatus = "FAILED"
+	// RollbackStatusSkipped is set when an unhealthy deployment is not rolled back, e.g. it is a rollback itself
+	RollbackStatusSkipped RollbackStatus = "SKIPPED"
+)
+
+func (s RollbackStatus) String() string {
+	return string(s)
+}
+
+const (
+	MinHealthTimeoutMinutes = 1
+	MaxHealthTimeoutMinutes = 1440
+	// RollbackTargetLookupLimit is the number of previous healthy deployments looked up for a rollback target
+	RollbackTargetLookupLimit = 10
+)
+
+const (
+	SkippedForRollbackDeploymentMessage = "deployment was triggered by auto rollback, it is not rolled back again"
+	NoHealthyDeploymentMessage          = "no previous healthy deployment found to roll back to"
+)
+
+type AutoRollbackPolicyDto struct {
+	Id         int `json:"id"`
+	PipelineId int `json:"pipelineId" validate:"number,required"`
+	// Enabled rolls back unhealthy deployments of the pipeline, rollbacks bypass the deployment windows of the environment
+	Enabled bool `json:"enabled"`
+	// HealthTimeoutMinutes is the time a deployment is given to become healthy, counted from its trigger
+	HealthTimeoutMinutes int   `json:"healthTimeoutMinutes" validate:"min=1,max=1440"`
+	UserId               int32 `json:"-"`
+}
+
+type AutoRollbackHistoryDto struct {
+	Id                         int            `json:"id"`
+	PipelineId                 int            `json:"pipelineId"`
+	FailedCdWorkflowRunnerId   int            `json:"failedCdWorkflowRunnerId"`
+	FailedStatus               string         `json:"failedStatus"`
+	TargetCdWorkflowRunnerId   int            `json:"targetCdWorkflowRunnerId,omitempty"`
+	CiArtifactId               int            `json:"ciArtifactId,omitempty"`
+	RollbackCdWorkflowRunnerId int            `json:"rollbackCdWorkflowRunnerId,omitempty"`
+	Status                     RollbackStatus `json:"status"`
+	Message                    string         `json:"message,omitempty"`
+	CreatedOn                  time.Time      `json:"createdOn"`
+}
+
+// RollbackCandidate is an unhealthy deployment past the health timeout of its pipeline policy
+type RollbackCandidate struct {
+	PipelineId     int
+	AppId          int
+	EnvironmentId  int
+	FailedWfrId    int
+	FailedStatus   string
+	FailedImage    string
+	FailedArtifact int
+	// TargetWfrId is the last healthy deployment of the pipeline, 0 if there is none
+	TargetWfrId      int
+	TargetArtifactId int
+	// IsRollbackDeployment is set if the unhealthy deployment was triggered by auto rollback
+	IsRollbackDeployment bool
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type AutoRollbackHistory struct {
+	tableName                  struct{} `sql:"auto_rollback_history" pg:",discard_unknown_columns"`
+	Id                         int      `sql:"id,pk"`
+	PipelineId                 int      `sql:"pipeline_id,notnull"`
+	FailedCdWorkflowRunnerId   int      `sql:"failed_cd_workflow_runner_id,notnull"`
+	FailedStatus               string   `sql:"failed_status,notnull"`
+	TargetCdWorkflowRunnerId   int      `sql:"target_cd_workflow_runner_id"`
+	CiArtifactId               int      `sql:"ci_artifact_id"`
+	RollbackCdWorkflowRunnerId int      `sql:"rollback_cd_workflow_runner_id"`
+	Status                     string   `sql:"status,notnull"`
+	Message                    string   `sql:"message"`
+	sql.AuditLog
+}
+
+type AutoRollbackHistoryRepository interface {
+	// SaveIfAbsent inserts the history only if the failed deployment has none yet,
+	// so that an unhealthy deployment is rolled back by exactly one orchestrator replica
+	SaveIfAbsent(history *AutoRollbackHistory) (bool, error)
+	UpdateOutcome(id int, status string, rollbackWfrId int, message string, userId int32) error
+	ExistsByFailedWfrId(failedWfrId int) (bool, error)
+	ExistsByRollbackWfrId(rollbackWfrId int) (bool, error)
+	FindByPipelineId(pipelineId int, offset, limit int) ([]*AutoRollbackHistory, error)
+}
+
+type AutoRollbackHistoryRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewAutoRollbackHistoryRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *AutoRollbackHistoryRepositoryImpl {
+	return &AutoRollbackHistoryRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *AutoRollbackHistoryRepositoryImpl) SaveIfAbsent(history *AutoRollbackHistory) (bool, error) {
+	res, err := impl.dbConnection.Model(history).
+		OnConflict("(failed_cd_workflow_runner_id) DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *AutoRollbackHistoryRepositoryImpl) UpdateOutcome(id int, status string, rollbackWfrId int, message string, userId int32) error {
+	query := impl.dbConnection.Model((*AutoRollbackHistory)(nil)).
+		Set("status = ?", status).
+		Set("message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId)
+	if rollbackWfrId > 0 {
+		query = query.Set("rollback_cd_workflow_runner_id = ?", rollbackWfrId)
+	}
+	_, err := query.Where("id = ?", id).Update()
+	return err
+}
+
+func (impl *AutoRollbackHistoryRepositoryImpl) ExistsByFailedWfrId(failedWfrId int) (bool, error) {
+	return impl.dbConnection.Model((*AutoRollbackHistory)(nil)).
+		Where("failed_cd_workflow_runner_id = ?", failedWfrId).
+		Exists()
+}
+
+func (impl *AutoRollbackHistoryRepositoryImpl) ExistsByRollbackWfrId(rollbackWfrId int) (bool, error) {
+	return impl.dbConnection.Model((*AutoRollbackHistory)(nil)).
+		Where("rollback_cd_workflow_runner_id = ?", rollbackWfrId).
+		Exists()
+}
+
+func (impl *AutoRollbackHistoryRepositoryImpl) FindByPipelineId(pipelineId int, offset, limit int) ([]*AutoRollbackHistory, error) {
+	var histories []*AutoRollbackHistory
+	err := impl.dbConnection.Model(&histories).
+		Where("pipeline_id = ?", pipelineId).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Select()
+	return histories, err
+}
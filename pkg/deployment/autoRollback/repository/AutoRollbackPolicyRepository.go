@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type AutoRollbackPolicy struct {
+	tableName            struct{} `sql:"auto_rollback_policy" pg:",discard_unknown_columns"`
+	Id                   int      `sql:"id,pk"`
+	PipelineId           int      `sql:"pipeline_id,notnull"`
+	Enabled              bool     `sql:"enabled,notnull"`
+	HealthTimeoutMinutes int      `sql:"health_timeout_minutes,notnull"`
+	Active               bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type AutoRollbackPolicyRepository interface {
+	Save(policy *AutoRollbackPolicy) error
+	Update(policy *AutoRollbackPolicy) error
+	FindByPipelineId(pipelineId int) (*AutoRollbackPolicy, error)
+	// FindAllEnabled returns the enabled policies of the cd pipelines which are not deleted
+	FindAllEnabled() ([]*AutoRollbackPolicy, error)
+	MarkInactiveByPipelineId(pipelineId int, userId int32) error
+}
+
+type AutoRollbackPolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewAutoRollbackPolicyRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *AutoRollbackPolicyRepositoryImpl {
+	return &AutoRollbackPolicyRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) Save(policy *AutoRollbackPolicy) error {
+	return impl.dbConnection.Insert(policy)
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) Update(policy *AutoRollbackPolicy) error {
+	return impl.dbConnection.Update(policy)
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) FindByPipelineId(pipelineId int) (*AutoRollbackPolicy, error) {
+	policy := &AutoRollbackPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) FindAllEnabled() ([]*AutoRollbackPolicy, error) {
+	var policies []*AutoRollbackPolicy
+	err := impl.dbConnection.Model(&policies).
+		Join("INNER JOIN pipeline p ON p.id = auto_rollback_policy.pipeline_id").
+		Where("auto_rollback_policy.active = ?", true).
+		Where("auto_rollback_policy.enabled = ?", true).
+		Where("p.deleted = ?", false).
+		Order("auto_rollback_policy.id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl *AutoRollbackPolicyRepositoryImpl) MarkInactiveByPipelineId(pipelineId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*AutoRollbackPolicy)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
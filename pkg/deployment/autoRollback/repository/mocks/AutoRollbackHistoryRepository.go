@@ -0,0 +1,159 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AutoRollbackHistoryRepository is an autogenerated mock type for the AutoRollbackHistoryRepository type
+type AutoRollbackHistoryRepository struct {
+	mock.Mock
+}
+
+// ExistsByFailedWfrId provides a mock function with given fields: failedWfrId
+func (_m *AutoRollbackHistoryRepository) ExistsByFailedWfrId(failedWfrId int) (bool, error) {
+	ret := _m.Called(failedWfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByFailedWfrId")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(failedWfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(failedWfrId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(failedWfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExistsByRollbackWfrId provides a mock function with given fields: rollbackWfrId
+func (_m *AutoRollbackHistoryRepository) ExistsByRollbackWfrId(rollbackWfrId int) (bool, error) {
+	ret := _m.Called(rollbackWfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByRollbackWfrId")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(rollbackWfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(rollbackWfrId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(rollbackWfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPipelineId provides a mock function with given fields: pipelineId, offset, limit
+func (_m *AutoRollbackHistoryRepository) FindByPipelineId(pipelineId int, offset int, limit int) ([]*repository.AutoRollbackHistory, error) {
+	ret := _m.Called(pipelineId, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPipelineId")
+	}
+
+	var r0 []*repository.AutoRollbackHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int) ([]*repository.AutoRollbackHistory, error)); ok {
+		return rf(pipelineId, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int) []*repository.AutoRollbackHistory); ok {
+		r0 = rf(pipelineId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.AutoRollbackHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(pipelineId, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveIfAbsent provides a mock function with given fields: history
+func (_m *AutoRollbackHistoryRepository) SaveIfAbsent(history *repository.AutoRollbackHistory) (bool, error) {
+	ret := _m.Called(history)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIfAbsent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*repository.AutoRollbackHistory) (bool, error)); ok {
+		return rf(history)
+	}
+	if rf, ok := ret.Get(0).(func(*repository.AutoRollbackHistory) bool); ok {
+		r0 = rf(history)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*repository.AutoRollbackHistory) error); ok {
+		r1 = rf(history)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOutcome provides a mock function with given fields: id, status, rollbackWfrId, message, userId
+func (_m *AutoRollbackHistoryRepository) UpdateOutcome(id int, status string, rollbackWfrId int, message string, userId int32) error {
+	ret := _m.Called(id, status, rollbackWfrId, message, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOutcome")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, int, string, int32) error); ok {
+		r0 = rf(id, status, rollbackWfrId, message, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAutoRollbackHistoryRepository creates a new instance of AutoRollbackHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAutoRollbackHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AutoRollbackHistoryRepository {
+	mock := &AutoRollbackHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
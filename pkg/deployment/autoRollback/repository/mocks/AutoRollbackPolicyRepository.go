@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AutoRollbackPolicyRepository is an autogenerated mock type for the AutoRollbackPolicyRepository type
+type AutoRollbackPolicyRepository struct {
+	mock.Mock
+}
+
+// FindAllEnabled provides a mock function with no fields
+func (_m *AutoRollbackPolicyRepository) FindAllEnabled() ([]*repository.AutoRollbackPolicy, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllEnabled")
+	}
+
+	var r0 []*repository.AutoRollbackPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*repository.AutoRollbackPolicy, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*repository.AutoRollbackPolicy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.AutoRollbackPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPipelineId provides a mock function with given fields: pipelineId
+func (_m *AutoRollbackPolicyRepository) FindByPipelineId(pipelineId int) (*repository.AutoRollbackPolicy, error) {
+	ret := _m.Called(pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPipelineId")
+	}
+
+	var r0 *repository.AutoRollbackPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*repository.AutoRollbackPolicy, error)); ok {
+		return rf(pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *repository.AutoRollbackPolicy); ok {
+		r0 = rf(pipelineId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.AutoRollbackPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(pipelineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkInactiveByPipelineId provides a mock function with given fields: pipelineId, userId
+func (_m *AutoRollbackPolicyRepository) MarkInactiveByPipelineId(pipelineId int, userId int32) error {
+	ret := _m.Called(pipelineId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkInactiveByPipelineId")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int32) error); ok {
+		r0 = rf(pipelineId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: policy
+func (_m *AutoRollbackPolicyRepository) Save(policy *repository.AutoRollbackPolicy) error {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.AutoRollbackPolicy) error); ok {
+		r0 = rf(policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: policy
+func (_m *AutoRollbackPolicyRepository) Update(policy *repository.AutoRollbackPolicy) error {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.AutoRollbackPolicy) error); ok {
+		r0 = rf(policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAutoRollbackPolicyRepository creates a new instance of AutoRollbackPolicyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAutoRollbackPolicyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AutoRollbackPolicyRepository {
+	mock := &AutoRollbackPolicyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
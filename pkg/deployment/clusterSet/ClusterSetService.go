@@ -0,0 +1,826 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clusterSet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/repository"
+	clusterSetUtil "github.com/devtron-labs/devtron/pkg/deployment/clusterSet/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	leaderElectionBean "github.com/devtron-labs/devtron/pkg/leaderElection/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// deploymentsListLimit is the max number of deployments listed for a pipeline, latest first
+const deploymentsListLimit = 20
+
+type ClusterSetService interface {
+	// GetClusterLabels lists the labels of the active clusters, virtual clusters can not be part of a cluster set
+	GetClusterLabels() ([]*bean.ClusterLabelsDto, error)
+	// SaveClusterLabels replaces the labels of the cluster, running deployments keep the members they started with
+	SaveClusterLabels(request *bean.ClusterLabelsDto) (*bean.ClusterLabelsDto, error)
+
+	// GetTarget returns the cluster set of the pipeline with the clusters it currently selects, nil if not configured
+	GetTarget(pipelineId int) (*bean.ClusterSetTargetDto, error)
+	// SaveTarget saves the cluster set of the pipeline only if checkAuthForCluster allows the user to deploy to the
+	// namespace of the pipeline in every cluster the selector selects
+	SaveTarget(request *bean.ClusterSetTargetDto, checkAuthForCluster func(clusterName, namespace string) bool) (*bean.ClusterSetTargetDto, error)
+	// DeleteTarget deletes the cluster set of the pipeline, halts its queued and running deployments and deletes the
+	// releases of the pipeline on the clusters it was released on
+	DeleteTarget(pipelineId int, userId int32) error
+
+	// Deploy queues the fan out of the artifact to the clusters selected by the cluster set of the pipeline, deploying
+	// the pipeline queues it as well. The latest queued deployment starts once the running one finished, its clusters are
+	// released in the background, parallelism at a time, with the config of the pipeline and the scoped variables resolved
+	// for each cluster. The artifact goes through the deployment gates of the pipeline before it is queued and again
+	// before each cluster is released, and checkAuthForCluster must allow the user to deploy to every cluster.
+	Deploy(request *bean.DeployRequest, checkAuthForCluster func(clusterName, namespace string) bool) (*bean.ClusterSetDeploymentDto, error)
+	GetDeployments(pipelineId int) ([]*bean.ClusterSetDeploymentDto, error)
+	GetDeployment(id int) (*bean.ClusterSetDeploymentDto, error)
+}
+
+type ClusterSetServiceImpl struct {
+	logger                  *zap.SugaredLogger
+	config                  *bean.ClusterSetConfig
+	clusterSetRepository    repository.ClusterSetRepository
+	clusterLabelRepository  repository.ClusterLabelRepository
+	clusterRepository       clusterRepository.ClusterRepository
+	pipelineRepository      pipelineConfig.PipelineRepository
+	cdWorkflowRepository    pipelineConfig.CdWorkflowRepository
+	ciArtifactRepository    repository2.CiArtifactRepository
+	deploymentConfigService common.DeploymentConfigService
+	leaderElectionService   leaderElection.LeaderElectionService
+	cdHandlerService        devtronApps.HandlerService
+}
+
+func NewClusterSetServiceImpl(logger *zap.SugaredLogger,
+	clusterSetRepository repository.ClusterSetRepository,
+	clusterLabelRepository repository.ClusterLabelRepository,
+	clusterRepository clusterRepository.ClusterRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	deploymentConfigService common.DeploymentConfigService,
+	leaderElectionService leaderElection.LeaderElectionService,
+	cdHandlerService devtronApps.HandlerService,
+	cronLogger *cron2.CronLoggerImpl) (*ClusterSetServiceImpl, error) {
+	config := &bean.ClusterSetConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing cluster set config", "err", err)
+		return nil, err
+	}
+	impl := &ClusterSetServiceImpl{
+		logger:                  logger,
+		config:                  config,
+		clusterSetRepository:    clusterSetRepository,
+		clusterLabelRepository:  clusterLabelRepository,
+		clusterRepository:       clusterRepository,
+		pipelineRepository:      pipelineRepository,
+		cdWorkflowRepository:    cdWorkflowRepository,
+		ciArtifactRepository:    ciArtifactRepository,
+		deploymentConfigService: deploymentConfigService,
+		leaderElectionService:   leaderElectionService,
+		cdHandlerService:        cdHandlerService,
+	}
+	if !config.Enabled {
+		return impl, nil
+	}
+	progressCron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	progressCron.Start()
+	_, err = progressCron.AddFunc(config.ProgressCron, impl.progressDeployments)
+	if err != nil {
+		logger.Errorw("error while configure cron job for cluster set deployments", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *ClusterSetServiceImpl) GetClusterLabels() ([]*bean.ClusterLabelsDto, error) {
+	clusters, err := impl.clusterRepository.FindAllActiveExceptVirtual()
+	if err != nil {
+		impl.logger.Errorw("error in fetching clusters", "err", err)
+		return nil, err
+	}
+	labelsByClusterId, err := impl.getLabelsByClusterId()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*bean.ClusterLabelsDto, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterLabels := labelsByClusterId[cluster.Id]
+		if clusterLabels == nil {
+			clusterLabels = make(map[string]string)
+		}
+		res = append(res, &bean.ClusterLabelsDto{
+			ClusterId:   cluster.Id,
+			ClusterName: cluster.ClusterName,
+			Labels:      clusterLabels,
+		})
+	}
+	return res, nil
+}
+
+func (impl *ClusterSetServiceImpl) SaveClusterLabels(request *bean.ClusterLabelsDto) (*bean.ClusterLabelsDto, error) {
+	cluster, err := impl.clusterRepository.FindById(request.ClusterId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "cluster not found", "cluster not found")
+		}
+		impl.logger.Errorw("error in fetching cluster", "clusterId", request.ClusterId, "err", err)
+		return nil, err
+	}
+	if cluster.IsVirtualCluster {
+		return nil, util.NewApiError(http.StatusBadRequest, "labels can not be set on a virtual cluster", "virtual cluster")
+	}
+	clusterLabels := make([]*repository.ClusterLabel, 0, len(request.Labels))
+	for key, value := range request.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid label key %q: %s", key, strings.Join(errs, ", ")), "invalid label key")
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid value of label %q: %s", key, strings.Join(errs, ", ")), "invalid label value")
+		}
+		clusterLabels = append(clusterLabels, &repository.ClusterLabel{
+			ClusterId: cluster.Id,
+			Key:       key,
+			Value:     value,
+			AuditLog:  sql.NewDefaultAuditLog(request.UserId),
+		})
+	}
+	err = impl.clusterLabelRepository.ReplaceForCluster(cluster.Id, clusterLabels)
+	if err != nil {
+		impl.logger.Errorw("error in saving cluster labels", "clusterId", cluster.Id, "err", err)
+		return nil, err
+	}
+	request.ClusterName = cluster.ClusterName
+	return request, nil
+}
+
+func (impl *ClusterSetServiceImpl) GetTarget(pipelineId int) (*bean.ClusterSetTargetDto, error) {
+	target, err := impl.clusterSetRepository.FindActiveTargetByPipelineId(pipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, nil
+		}
+		impl.logger.Errorw("error in fetching cluster set", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	dto := adapter.GetClusterSetTargetDto(target)
+	clusters, err := impl.getMemberClusters(target.Selector, pipeline.Environment.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		dto.Clusters = append(dto.Clusters, &bean.MemberClusterDto{ClusterId: cluster.Id, ClusterName: cluster.ClusterName})
+	}
+	return dto, nil
+}
+
+func (impl *ClusterSetServiceImpl) SaveTarget(request *bean.ClusterSetTargetDto, checkAuthForCluster func(clusterName, namespace string) bool) (*bean.ClusterSetTargetDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline not found")
+		}
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.Environment.IsVirtualEnvironment {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.VirtualEnvironmentMessage, bean.VirtualEnvironmentMessage)
+	}
+	clusters, err := impl.getMemberClusters(request.Selector, pipeline.Environment.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkMemberClustersAuth(clusters, pipeline.Environment.Namespace, checkAuthForCluster); err != nil {
+		return nil, err
+	}
+	if request.HealthTimeoutMins == 0 {
+		request.HealthTimeoutMins = impl.config.DefaultHealthTimeoutMins
+	}
+	if request.HealthTimeoutMins > bean.MaxHealthTimeoutMins {
+		return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("health timeout can not be more than %d minutes", bean.MaxHealthTimeoutMins), "invalid health timeout")
+	}
+	target, err := impl.clusterSetRepository.FindActiveTargetByPipelineId(request.PipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching cluster set", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	if target == nil || target.Id == 0 {
+		target = &repository.ClusterSetTarget{
+			PipelineId: request.PipelineId,
+			Active:     true,
+			AuditLog:   sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		target.UpdateAuditLog(request.UserId)
+	}
+	target.Selector = request.Selector
+	target.Parallelism = request.Parallelism
+	target.MaxUnavailable = request.MaxUnavailable
+	target.HealthTimeoutMins = request.HealthTimeoutMins
+	if target.Id == 0 {
+		err = impl.clusterSetRepository.SaveTarget(target)
+	} else {
+		err = impl.clusterSetRepository.UpdateTarget(target)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving cluster set", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	return impl.GetTarget(request.PipelineId)
+}
+
+func (impl *ClusterSetServiceImpl) DeleteTarget(pipelineId int, userId int32) error {
+	target, err := impl.getActiveTarget(pipelineId)
+	if err != nil {
+		return err
+	}
+	target.Active = false
+	target.UpdateAuditLog(userId)
+	err = impl.clusterSetRepository.UpdateTarget(target)
+	if err != nil {
+		impl.logger.Errorw("error in deleting cluster set", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	impl.haltDeployments(pipelineId, userId)
+	impl.deleteMemberReleases(pipelineId)
+	return nil
+}
+
+func (impl *ClusterSetServiceImpl) Deploy(request *bean.DeployRequest, checkAuthForCluster func(clusterName, namespace string) bool) (*bean.ClusterSetDeploymentDto, error) {
+	target, err := impl.getActiveTarget(request.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	if request.CiArtifactId == 0 {
+		wfr, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(pipeline.Id, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+		if err != nil {
+			if util.IsErrNoRows(err) {
+				return nil, util.NewApiError(http.StatusBadRequest, "pipeline has not been deployed yet, artifact is required", "no deployment found")
+			}
+			impl.logger.Errorw("error in fetching latest deployment", "pipelineId", pipeline.Id, "err", err)
+			return nil, err
+		}
+		request.CiArtifactId = wfr.CdWorkflow.CiArtifactId
+	}
+	artifact, err := impl.ciArtifactRepository.Get(request.CiArtifactId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "artifact not found", "artifact not found")
+		}
+		impl.logger.Errorw("error in fetching artifact", "artifactId", request.CiArtifactId, "err", err)
+		return nil, err
+	}
+	clusters, err := impl.getMemberClusters(target.Selector, pipeline.Environment.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.NoMemberClustersMessage, bean.NoMemberClustersMessage)
+	}
+	if err = checkMemberClustersAuth(clusters, pipeline.Environment.Namespace, checkAuthForCluster); err != nil {
+		return nil, err
+	}
+	err = impl.cdHandlerService.ValidateDeploymentGates(context.Background(), pipeline, artifact, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("deployment to cluster set not allowed", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return nil, err
+	}
+	deployment := &repository.ClusterSetDeployment{
+		TargetId:          target.Id,
+		PipelineId:        pipeline.Id,
+		CiArtifactId:      artifact.Id,
+		Status:            bean.DeploymentStatusQueued.String(),
+		Parallelism:       target.Parallelism,
+		MaxUnavailable:    target.MaxUnavailable,
+		HealthTimeoutMins: target.HealthTimeoutMins,
+		TriggeredBy:       request.UserId,
+		StartedOn:         time.Now(),
+		AuditLog:          sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.clusterSetRepository.SaveDeploymentWithMembers(deployment, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving cluster set deployment", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	dto := adapter.GetClusterSetDeploymentDto(deployment, nil, nil, true)
+	dto.Image = artifact.Image
+	return dto, nil
+}
+
+func (impl *ClusterSetServiceImpl) GetDeployments(pipelineId int) ([]*bean.ClusterSetDeploymentDto, error) {
+	deployments, err := impl.clusterSetRepository.FindDeploymentsByPipelineId(pipelineId, deploymentsListLimit)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster set deployments", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	res := make([]*bean.ClusterSetDeploymentDto, 0, len(deployments))
+	if len(deployments) == 0 {
+		return res, nil
+	}
+	deploymentIds := make([]int, 0, len(deployments))
+	for _, deployment := range deployments {
+		deploymentIds = append(deploymentIds, deployment.Id)
+	}
+	members, err := impl.clusterSetRepository.FindMembersByDeploymentIds(deploymentIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster set deployment members", "deploymentIds", deploymentIds, "err", err)
+		return nil, err
+	}
+	membersByDeploymentId := make(map[int][]*repository.ClusterSetDeploymentMember, len(deployments))
+	for _, member := range members {
+		membersByDeploymentId[member.DeploymentId] = append(membersByDeploymentId[member.DeploymentId], member)
+	}
+	for _, deployment := range deployments {
+		res = append(res, adapter.GetClusterSetDeploymentDto(deployment, membersByDeploymentId[deployment.Id], nil, false))
+	}
+	return res, nil
+}
+
+func (impl *ClusterSetServiceImpl) GetDeployment(id int) (*bean.ClusterSetDeploymentDto, error) {
+	deployment, err := impl.clusterSetRepository.FindDeploymentById(id)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "cluster set deployment not found", "cluster set deployment not found")
+		}
+		impl.logger.Errorw("error in fetching cluster set deployment", "id", id, "err", err)
+		return nil, err
+	}
+	members, err := impl.clusterSetRepository.FindMembersByDeploymentId(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster set deployment members", "deploymentId", id, "err", err)
+		return nil, err
+	}
+	clusterIds := make([]int, 0, len(members))
+	for _, member := range members {
+		clusterIds = append(clusterIds, member.ClusterId)
+	}
+	clusterNameById := make(map[int]string, len(members))
+	if len(clusterIds) > 0 {
+		clusters, err := impl.clusterRepository.FindByIds(clusterIds)
+		if err != nil {
+			impl.logger.Errorw("error in fetching clusters", "clusterIds", clusterIds, "err", err)
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			clusterNameById[cluster.Id] = cluster.ClusterName
+		}
+	}
+	dto := adapter.GetClusterSetDeploymentDto(deployment, members, clusterNameById, true)
+	artifact, err := impl.ciArtifactRepository.Get(deployment.CiArtifactId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", deployment.CiArtifactId, "err", err)
+		return nil, err
+	}
+	if artifact != nil {
+		dto.Image = artifact.Image
+	}
+	return dto, nil
+}
+
+func (impl *ClusterSetServiceImpl) getActiveTarget(pipelineId int) (*repository.ClusterSetTarget, error) {
+	target, err := impl.clusterSetRepository.FindActiveTargetByPipelineId(pipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, bean.TargetNotFoundMessage, bean.TargetNotFoundMessage)
+		}
+		impl.logger.Errorw("error in fetching cluster set", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return target, nil
+}
+
+func (impl *ClusterSetServiceImpl) getLabelsByClusterId() (map[int]map[string]string, error) {
+	clusterLabels, err := impl.clusterLabelRepository.FindAll()
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster labels", "err", err)
+		return nil, err
+	}
+	labelsByClusterId := make(map[int]map[string]string)
+	for _, clusterLabel := range clusterLabels {
+		if labelsByClusterId[clusterLabel.ClusterId] == nil {
+			labelsByClusterId[clusterLabel.ClusterId] = make(map[string]string)
+		}
+		labelsByClusterId[clusterLabel.ClusterId][clusterLabel.Key] = clusterLabel.Value
+	}
+	return labelsByClusterId, nil
+}
+
+// getMemberClusters returns the active, non virtual, clusters matching the selector. The cluster of the environment of
+// the pipeline is left out as it is deployed by the pipeline itself.
+func (impl *ClusterSetServiceImpl) getMemberClusters(selector string, pipelineClusterId int) ([]clusterRepository.Cluster, error) {
+	parsedSelector, err := clusterSetUtil.ParseSelector(selector)
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid selector: %s", err.Error()), err.Error())
+	}
+	clusters, err := impl.clusterRepository.FindAllActiveExceptVirtual()
+	if err != nil {
+		impl.logger.Errorw("error in fetching clusters", "err", err)
+		return nil, err
+	}
+	labelsByClusterId, err := impl.getLabelsByClusterId()
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(map[int]map[string]string, len(clusters))
+	clusterById := make(map[int]clusterRepository.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Id == pipelineClusterId {
+			continue
+		}
+		clusterLabels := labelsByClusterId[cluster.Id]
+		if clusterLabels == nil {
+			clusterLabels = make(map[string]string)
+		}
+		candidates[cluster.Id] = clusterLabels
+		clusterById[cluster.Id] = cluster
+	}
+	memberClusterIds := clusterSetUtil.GetMemberClusterIds(parsedSelector, candidates)
+	res := make([]clusterRepository.Cluster, 0, len(memberClusterIds))
+	for _, clusterId := range memberClusterIds {
+		res = append(res, clusterById[clusterId])
+	}
+	return res, nil
+}
+
+// checkMemberClustersAuth returns a forbidden error naming the member clusters the user can not deploy to
+func checkMemberClustersAuth(clusters []clusterRepository.Cluster, namespace string, checkAuthForCluster func(clusterName, namespace string) bool) error {
+	unauthorizedClusters := make([]string, 0)
+	for _, cluster := range clusters {
+		if !checkAuthForCluster(cluster.ClusterName, namespace) {
+			unauthorizedClusters = append(unauthorizedClusters, cluster.ClusterName)
+		}
+	}
+	if len(unauthorizedClusters) > 0 {
+		return util.NewApiError(http.StatusForbidden, fmt.Sprintf("not authorized to deploy to clusters %s", strings.Join(unauthorizedClusters, ", ")), "unauthorized user")
+	}
+	return nil
+}
+
+func (impl *ClusterSetServiceImpl) progressDeployments() {
+	if !impl.leaderElectionService.IsLeader(leaderElectionBean.ClusterSetDeployer) {
+		return
+	}
+	deployments, err := impl.clusterSetRepository.FindDeploymentsByStatus(bean.DeploymentStatusRunning.String())
+	if err != nil {
+		impl.logger.Errorw("error in fetching running cluster set deployments", "err", err)
+		return
+	}
+	for _, deployment := range deployments {
+		err = impl.progressDeployment(deployment)
+		if err != nil {
+			impl.logger.Errorw("error in progressing cluster set deployment", "deploymentId", deployment.Id, "err", err)
+		}
+	}
+	impl.startQueuedDeployments()
+}
+
+// startQueuedDeployments starts the latest queued deployment of each pipeline with no running deployment, the older
+// queued ones are halted as superseded
+func (impl *ClusterSetServiceImpl) startQueuedDeployments() {
+	queuedDeployments, err := impl.clusterSetRepository.FindDeploymentsByStatus(bean.DeploymentStatusQueued.String())
+	if err != nil {
+		impl.logger.Errorw("error in fetching queued cluster set deployments", "err", err)
+		return
+	}
+	pipelineIds := make([]int, 0)
+	for _, deployment := range queuedDeployments {
+		if !slices.Contains(pipelineIds, deployment.PipelineId) {
+			pipelineIds = append(pipelineIds, deployment.PipelineId)
+		}
+	}
+	activeStatuses := []string{bean.DeploymentStatusQueued.String(), bean.DeploymentStatusRunning.String()}
+	for _, pipelineId := range pipelineIds {
+		deployments, err := impl.clusterSetRepository.FindDeploymentsByPipelineIdAndStatuses(pipelineId, activeStatuses)
+		if err != nil {
+			impl.logger.Errorw("error in fetching cluster set deployments", "pipelineId", pipelineId, "err", err)
+			continue
+		}
+		var latestQueued *repository.ClusterSetDeployment
+		running := false
+		for _, deployment := range deployments {
+			if deployment.Status == bean.DeploymentStatusRunning.String() {
+				running = true
+				continue
+			}
+			if latestQueued != nil {
+				impl.haltDeployment(latestQueued, nil, bean.SupersededMessage, latestQueued.TriggeredBy)
+			}
+			latestQueued = deployment
+		}
+		if running || latestQueued == nil {
+			continue
+		}
+		err = impl.startDeployment(latestQueued)
+		if err != nil {
+			impl.logger.Errorw("error in starting cluster set deployment", "deploymentId", latestQueued.Id, "err", err)
+		}
+	}
+}
+
+// startDeployment selects the member clusters of the deployment with the current selector and settings of the cluster
+// set, the deployment is halted if the cluster set was deleted in between
+func (impl *ClusterSetServiceImpl) startDeployment(deployment *repository.ClusterSetDeployment) error {
+	target, err := impl.clusterSetRepository.FindActiveTargetByPipelineId(deployment.PipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			impl.haltDeployment(deployment, nil, bean.TargetDeletedMessage, deployment.TriggeredBy)
+			return nil
+		}
+		return err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(deployment.PipelineId)
+	if err != nil {
+		return err
+	}
+	clusters, err := impl.getMemberClusters(target.Selector, pipeline.Environment.ClusterId)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		impl.haltDeployment(deployment, nil, bean.NoMemberClustersMessage, deployment.TriggeredBy)
+		return nil
+	}
+	members := make([]*repository.ClusterSetDeploymentMember, 0, len(clusters))
+	for _, cluster := range clusters {
+		members = append(members, &repository.ClusterSetDeploymentMember{
+			ClusterId: cluster.Id,
+			Status:    bean.MemberStatusPending.String(),
+		})
+	}
+	deployment.TargetId = target.Id
+	deployment.Status = bean.DeploymentStatusRunning.String()
+	deployment.Parallelism = target.Parallelism
+	deployment.MaxUnavailable = target.MaxUnavailable
+	deployment.HealthTimeoutMins = target.HealthTimeoutMins
+	deployment.StartedOn = time.Now()
+	deployment.UpdateAuditLog(deployment.TriggeredBy)
+	_, err = impl.clusterSetRepository.StartDeploymentIfInStatus(deployment, members, bean.DeploymentStatusQueued.String())
+	return err
+}
+
+// haltDeployments halts the queued and running deployments of the pipeline as its cluster set was deleted
+func (impl *ClusterSetServiceImpl) haltDeployments(pipelineId int, userId int32) {
+	activeStatuses := []string{bean.DeploymentStatusQueued.String(), bean.DeploymentStatusRunning.String()}
+	deployments, err := impl.clusterSetRepository.FindDeploymentsByPipelineIdAndStatuses(pipelineId, activeStatuses)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster set deployments", "pipelineId", pipelineId, "err", err)
+		return
+	}
+	for _, deployment := range deployments {
+		var members []*repository.ClusterSetDeploymentMember
+		if deployment.Status == bean.DeploymentStatusRunning.String() {
+			members, err = impl.clusterSetRepository.FindMembersByDeploymentId(deployment.Id)
+			if err != nil {
+				impl.logger.Errorw("error in fetching cluster set deployment members", "deploymentId", deployment.Id, "err", err)
+				continue
+			}
+		}
+		impl.haltDeployment(deployment, members, bean.TargetDeletedMessage, userId)
+	}
+}
+
+// haltDeployment skips the pending members and fails the deploying members of the deployment before halting it
+func (impl *ClusterSetServiceImpl) haltDeployment(deployment *repository.ClusterSetDeployment,
+	members []*repository.ClusterSetDeploymentMember, message string, userId int32) {
+	for _, member := range members {
+		switch member.Status {
+		case bean.MemberStatusPending.String():
+			impl.finishMember(member, bean.MemberStatusPending, bean.MemberStatusSkipped, message)
+		case bean.MemberStatusDeploying.String():
+			impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, message)
+		}
+	}
+	now := time.Now()
+	fromStatus := deployment.Status
+	deployment.Status = bean.DeploymentStatusHalted.String()
+	deployment.Message = message
+	deployment.FinishedOn = &now
+	deployment.UpdateAuditLog(userId)
+	_, err := impl.clusterSetRepository.UpdateDeploymentIfInStatus(deployment, fromStatus)
+	if err != nil {
+		impl.logger.Errorw("error in halting cluster set deployment", "deploymentId", deployment.Id, "err", err)
+	}
+}
+
+// deleteMemberReleases deletes the releases of the pipeline on every cluster it was released on through a cluster set.
+// Failures are only logged, as the cluster set is already deleted.
+func (impl *ClusterSetServiceImpl) deleteMemberReleases(pipelineId int) {
+	clusterIds, err := impl.clusterSetRepository.FindDeployedClusterIdsByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching clusters released on by the cluster set", "pipelineId", pipelineId, "err", err)
+		return
+	}
+	if len(clusterIds) == 0 {
+		return
+	}
+	pipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", pipelineId, "err", err)
+		return
+	}
+	envDeploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment deployment config by appId and envId", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return
+	}
+	ctx := context.Background()
+	for _, clusterId := range clusterIds {
+		err = impl.cdHandlerService.DeleteClusterSetMemberRelease(ctx, pipeline, envDeploymentConfig.DeploymentAppType, clusterId)
+		if err != nil {
+			impl.logger.Errorw("error in deleting release of cluster set member", "pipelineId", pipelineId, "clusterId", clusterId, "err", err)
+		}
+	}
+}
+
+// progressDeployment checks the health of the deploying clusters, deploys the next pending ones and finishes the
+// deployment once no cluster is pending or deploying
+func (impl *ClusterSetServiceImpl) progressDeployment(deployment *repository.ClusterSetDeployment) error {
+	ctx := context.Background()
+	members, err := impl.clusterSetRepository.FindMembersByDeploymentId(deployment.Id)
+	if err != nil {
+		return err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(deployment.PipelineId)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Status == bean.MemberStatusDeploying.String() {
+			impl.checkMemberHealth(ctx, deployment, pipeline, member)
+		}
+	}
+	progress := clusterSetUtil.GetProgress(getMemberStatuses(members), deployment.Parallelism, deployment.MaxUnavailable)
+	if progress.SkipPending || progress.Start > 0 {
+		started := 0
+		for _, member := range members {
+			if member.Status != bean.MemberStatusPending.String() {
+				continue
+			}
+			if progress.SkipPending {
+				impl.finishMember(member, bean.MemberStatusPending, bean.MemberStatusSkipped, bean.SkippedMessage)
+			} else if started < progress.Start {
+				impl.deployMember(ctx, deployment, pipeline, member)
+				started++
+			}
+		}
+		progress = clusterSetUtil.GetProgress(getMemberStatuses(members), deployment.Parallelism, deployment.MaxUnavailable)
+	}
+	if !progress.Done {
+		return nil
+	}
+	now := time.Now()
+	deployment.Status = progress.Status.String()
+	deployment.FinishedOn = &now
+	if progress.Status == bean.DeploymentStatusHalted {
+		summary := &bean.MemberSummary{}
+		for _, member := range members {
+			summary.Add(bean.MemberStatus(member.Status))
+		}
+		deployment.Message = fmt.Sprintf(bean.HaltedMessageTmpl, summary.Failed, deployment.MaxUnavailable)
+	}
+	deployment.UpdateAuditLog(deployment.TriggeredBy)
+	_, err = impl.clusterSetRepository.UpdateDeploymentIfInStatus(deployment, bean.DeploymentStatusRunning.String())
+	return err
+}
+
+// deployMember releases the artifact on the member cluster through the release path of the pipeline, helm or argo cd,
+// with the scoped variables resolved for the cluster. The gates are checked again as the deployment window, approval
+// or signature policies may have changed since the deployment started.
+func (impl *ClusterSetServiceImpl) deployMember(ctx context.Context, deployment *repository.ClusterSetDeployment,
+	pipeline *pipelineConfig.Pipeline, member *repository.ClusterSetDeploymentMember) {
+	now := time.Now()
+	member.Status = bean.MemberStatusDeploying.String()
+	member.StartedOn = &now
+	claimed, err := impl.clusterSetRepository.UpdateMemberIfInStatus(member, bean.MemberStatusPending.String())
+	if err != nil || !claimed {
+		impl.logger.Errorw("error in claiming cluster set member", "memberId", member.Id, "claimed", claimed, "err", err)
+		member.Status = bean.MemberStatusPending.String()
+		return
+	}
+	cluster, err := impl.clusterRepository.FindById(member.ClusterId)
+	if err != nil {
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, fmt.Sprintf("error in fetching cluster: %s", err.Error()))
+		return
+	}
+	if !cluster.Active || cluster.IsVirtualCluster {
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, fmt.Sprintf("cluster %s is no longer active", cluster.ClusterName))
+		return
+	}
+	artifact, err := impl.ciArtifactRepository.Get(deployment.CiArtifactId)
+	if err != nil {
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, fmt.Sprintf("error in fetching artifact: %s", err.Error()))
+		return
+	}
+	err = impl.cdHandlerService.ValidateDeploymentGates(ctx, pipeline, artifact, deployment.TriggeredBy)
+	if err != nil {
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, err.Error())
+		return
+	}
+	runnerId, err := impl.cdHandlerService.TriggerClusterSetMemberRelease(ctx, pipeline, artifact, cluster, deployment.TriggeredBy)
+	member.CdWorkflowRunnerId = runnerId
+	if err != nil {
+		impl.logger.Errorw("error in deploying to member cluster", "deploymentId", deployment.Id, "clusterId", member.ClusterId, "err", err)
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, util.GetClientErrorDetailedMessage(err))
+		return
+	}
+	_, err = impl.clusterSetRepository.UpdateMemberIfInStatus(member, bean.MemberStatusDeploying.String())
+	if err != nil {
+		impl.logger.Errorw("error in updating cluster set member", "memberId", member.Id, "err", err)
+	}
+}
+
+// checkMemberHealth finishes the member with the status of the runner of its release once the release is healthy on
+// the cluster, or is not healthy within the health timeout of the deployment
+func (impl *ClusterSetServiceImpl) checkMemberHealth(ctx context.Context, deployment *repository.ClusterSetDeployment,
+	pipeline *pipelineConfig.Pipeline, member *repository.ClusterSetDeploymentMember) {
+	if member.CdWorkflowRunnerId == 0 {
+		// the release was not recorded, the replica releasing the member stopped in between
+		if member.StartedOn != nil && time.Since(*member.StartedOn) > time.Duration(deployment.HealthTimeoutMins)*time.Minute {
+			impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, bean.ReleaseNotRecordedMessage)
+		}
+		return
+	}
+	timeout := time.Duration(deployment.HealthTimeoutMins) * time.Minute
+	runner, err := impl.cdHandlerService.SyncClusterSetMemberRunnerStatus(ctx, pipeline, member.CdWorkflowRunnerId, member.ClusterId, timeout)
+	if err != nil {
+		impl.logger.Errorw("error in syncing runner of cluster set member", "memberId", member.Id, "runnerId", member.CdWorkflowRunnerId, "err", err)
+		return
+	}
+	if !slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		return
+	}
+	if runner.Status == cdWorkflow.WorkflowSucceeded {
+		impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusSucceeded, "")
+		return
+	}
+	impl.finishMember(member, bean.MemberStatusDeploying, bean.MemberStatusFailed, runner.Message)
+}
+
+func (impl *ClusterSetServiceImpl) finishMember(member *repository.ClusterSetDeploymentMember, fromStatus, status bean.MemberStatus, message string) {
+	now := time.Now()
+	member.Status = status.String()
+	member.Message = message
+	member.FinishedOn = &now
+	updated, err := impl.clusterSetRepository.UpdateMemberIfInStatus(member, fromStatus.String())
+	if err != nil || !updated {
+		impl.logger.Errorw("error in updating cluster set member", "memberId", member.Id, "status", status, "updated", updated, "err", err)
+		member.Status = fromStatus.String()
+	}
+}
+
+func getMemberStatuses(members []*repository.ClusterSetDeploymentMember) []bean.MemberStatus {
+	statuses := make([]bean.MemberStatus, 0, len(members))
+	for _, member := range members {
+		statuses = append(statuses, bean.MemberStatus(member.Status))
+	}
+	return statuses
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/repository"
+)
+
+func GetClusterSetTargetDto(target *repository.ClusterSetTarget) *bean.ClusterSetTargetDto {
+	return &bean.ClusterSetTargetDto{
+		Id:                target.Id,
+		PipelineId:        target.PipelineId,
+		Selector:          target.Selector,
+		Parallelism:       target.Parallelism,
+		MaxUnavailable:    target.MaxUnavailable,
+		HealthTimeoutMins: target.HealthTimeoutMins,
+		Clusters:          make([]*bean.MemberClusterDto, 0),
+	}
+}
+
+// GetClusterSetDeploymentDto builds the deployment with the summary of its members, the members are listed only if
+// withMembers is set
+func GetClusterSetDeploymentDto(deployment *repository.ClusterSetDeployment, members []*repository.ClusterSetDeploymentMember,
+	clusterNameById map[int]string, withMembers bool) *bean.ClusterSetDeploymentDto {
+	dto := &bean.ClusterSetDeploymentDto{
+		Id:                deployment.Id,
+		PipelineId:        deployment.PipelineId,
+		CiArtifactId:      deployment.CiArtifactId,
+		Status:            bean.DeploymentStatus(deployment.Status),
+		Message:           deployment.Message,
+		Parallelism:       deployment.Parallelism,
+		MaxUnavailable:    deployment.MaxUnavailable,
+		HealthTimeoutMins: deployment.HealthTimeoutMins,
+		Summary:           &bean.MemberSummary{},
+		TriggeredBy:       deployment.TriggeredBy,
+		StartedOn:         deployment.StartedOn,
+		FinishedOn:        deployment.FinishedOn,
+	}
+	if withMembers {
+		dto.Members = make([]*bean.MemberStatusDto, 0, len(members))
+	}
+	for _, member := range members {
+		dto.Summary.Add(bean.MemberStatus(member.Status))
+		if withMembers {
+			dto.Members = append(dto.Members, &bean.MemberStatusDto{
+				ClusterId:          member.ClusterId,
+				ClusterName:        clusterNameById[member.ClusterId],
+				Status:             bean.MemberStatus(member.Status),
+				Message:            member.Message,
+				CdWorkflowRunnerId: member.CdWorkflowRunnerId,
+				StartedOn:          member.StartedOn,
+				FinishedOn:         member.FinishedOn,
+			})
+		}
+	}
+	return dto
+}
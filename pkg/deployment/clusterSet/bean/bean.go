@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"time"
+)
+
+// CATEGORY=CLUSTER_SET
+type ClusterSetConfig struct {
+	Enabled                  bool   `env:"CLUSTER_SET_ENABLED" envDefault:"true" description:"Enables the deployment of cd pipelines to their cluster sets"`
+	ProgressCron             string `env:"CLUSTER_SET_CRON" envDefault:"* * * * *" description:"Cron at which the leader replica deploys the next member clusters and checks the health of the deploying ones"`
+	DefaultHealthTimeoutMins int    `env:"CLUSTER_SET_DEFAULT_HEALTH_TIMEOUT_MINS" envDefault:"10" description:"Minutes a member cluster has to become healthy after it is deployed, if not set on the cluster set"`
+}
+
+// MaxHealthTimeoutMins bounds the health timeout of a member cluster
+const MaxHealthTimeoutMins = 120
+
+type DeploymentStatus string
+
+const (
+	// DeploymentStatusQueued is set when the pipeline is deployed, the deployment starts once the previous one finished
+	DeploymentStatusQueued    DeploymentStatus = "QUEUED"
+	DeploymentStatusRunning   DeploymentStatus = "RUNNING"
+	DeploymentStatusSucceeded DeploymentStatus = "SUCCEEDED"
+	// DeploymentStatusDegraded is set when the deployment finished with no more failed clusters than the max unavailable
+	DeploymentStatusDegraded DeploymentStatus = "DEGRADED"
+	// DeploymentStatusHalted is set when more clusters failed than the max unavailable, the remaining clusters are skipped
+	DeploymentStatusHalted DeploymentStatus = "HALTED"
+)
+
+func (s DeploymentStatus) String() string {
+	return string(s)
+}
+
+type MemberStatus string
+
+const (
+	MemberStatusPending   MemberStatus = "PENDING"
+	MemberStatusDeploying MemberStatus = "DEPLOYING"
+	MemberStatusSucceeded MemberStatus = "SUCCEEDED"
+	MemberStatusFailed    MemberStatus = "FAILED"
+	MemberStatusSkipped   MemberStatus = "SKIPPED"
+)
+
+func (s MemberStatus) String() string {
+	return string(s)
+}
+
+func (s MemberStatus) IsTerminal() bool {
+	return s == MemberStatusSucceeded || s == MemberStatusFailed || s == MemberStatusSkipped
+}
+
+const (
+	NoMemberClustersMessage      = "no cluster matches the selector of the cluster set"
+	HaltedMessageTmpl            = "%d cluster(s) failed, more than the %d allowed to be unavailable"
+	SkippedMessage               = "skipped as the deployment halted"
+	HealthTimeoutMessageTmpl     = "not healthy within %d minutes: %s"
+	SupersededMessage            = "superseded by a newer deployment of the pipeline"
+	TargetDeletedMessage         = "halted as the cluster set of the pipeline was deleted"
+	ReleaseNotRecordedMessage    = "release on the cluster was not recorded"
+	VirtualEnvironmentMessage    = "cluster sets are not supported for pipelines of virtual environments"
+	TargetNotFoundMessage        = "cluster set is not configured for the pipeline"
+	UnsupportedWorkflowTypeMsg   = "only deployments can be fanned out to the cluster set"
+	UnsupportedConfigTypeMessage = "only the last saved config can be deployed to the cluster set, as scoped variables are resolved per cluster"
+)
+
+// ClusterLabelsDto are the labels of a cluster, cluster sets select their member clusters by them
+type ClusterLabelsDto struct {
+	ClusterId   int               `json:"clusterId"`
+	ClusterName string            `json:"clusterName,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	UserId      int32             `json:"-"`
+}
+
+// ClusterSetTargetDto is the set of clusters, selected by a label selector, the cd pipeline is deployed to besides its
+// own environment. Parallelism is the number of clusters deployed at the same time and MaxUnavailable the number of
+// clusters allowed to fail before the deployment halts.
+type ClusterSetTargetDto struct {
+	Id                int                 `json:"id"`
+	PipelineId        int                 `json:"pipelineId"`
+	Selector          string              `json:"selector" validate:"required"`
+	Parallelism       int                 `json:"parallelism" validate:"min=1"`
+	MaxUnavailable    int                 `json:"maxUnavailable" validate:"min=0"`
+	HealthTimeoutMins int                 `json:"healthTimeoutMins" validate:"min=0"`
+	Clusters          []*MemberClusterDto `json:"clusters,omitempty"`
+	UserId            int32               `json:"-"`
+}
+
+type MemberClusterDto struct {
+	ClusterId   int    `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+}
+
+type DeployRequest struct {
+	PipelineId int `json:"-"`
+	// CiArtifactId defaults to the artifact of the last deployment of the pipeline
+	CiArtifactId int   `json:"ciArtifactId"`
+	UserId       int32 `json:"-"`
+}
+
+type ClusterSetDeploymentDto struct {
+	Id                int                `json:"id"`
+	PipelineId        int                `json:"pipelineId"`
+	CiArtifactId      int                `json:"ciArtifactId"`
+	Image             string             `json:"image,omitempty"`
+	Status            DeploymentStatus   `json:"status"`
+	Message           string             `json:"message,omitempty"`
+	Parallelism       int                `json:"parallelism"`
+	MaxUnavailable    int                `json:"maxUnavailable"`
+	HealthTimeoutMins int                `json:"healthTimeoutMins"`
+	Summary           *MemberSummary     `json:"summary"`
+	Members           []*MemberStatusDto `json:"members,omitempty"`
+	TriggeredBy       int32              `json:"triggeredBy"`
+	StartedOn         time.Time          `json:"startedOn"`
+	FinishedOn        *time.Time         `json:"finishedOn,omitempty"`
+}
+
+type MemberStatusDto struct {
+	ClusterId   int          `json:"clusterId"`
+	ClusterName string       `json:"clusterName,omitempty"`
+	Status      MemberStatus `json:"status"`
+	Message     string       `json:"message,omitempty"`
+	// CdWorkflowRunnerId is the runner of the release on the cluster
+	CdWorkflowRunnerId int        `json:"cdWorkflowRunnerId,omitempty"`
+	StartedOn          *time.Time `json:"startedOn,omitempty"`
+	FinishedOn         *time.Time `json:"finishedOn,omitempty"`
+}
+
+// MemberSummary counts the member clusters of a deployment by their status
+type MemberSummary struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Deploying int `json:"deploying"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+func (s *MemberSummary) Add(status MemberStatus) {
+	s.Total++
+	switch status {
+	case MemberStatusPending:
+		s.Pending++
+	case MemberStatusDeploying:
+		s.Deploying++
+	case MemberStatusSucceeded:
+		s.Succeeded++
+	case MemberStatusFailed:
+		s.Failed++
+	case MemberStatusSkipped:
+		s.Skipped++
+	}
+}
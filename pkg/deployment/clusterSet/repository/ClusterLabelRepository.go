@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ClusterLabel struct {
+	tableName struct{} `sql:"cluster_label" pg:",discard_unknown_columns"`
+	Id        int      `sql:"id,pk"`
+	ClusterId int      `sql:"cluster_id,notnull"`
+	Key       string   `sql:"key,notnull"`
+	Value     string   `sql:"value,notnull"`
+	sql.AuditLog
+}
+
+type ClusterLabelRepository interface {
+	FindAll() ([]*ClusterLabel, error)
+	FindByClusterId(clusterId int) ([]*ClusterLabel, error)
+	// ReplaceForCluster replaces all the labels of the cluster in a single transaction
+	ReplaceForCluster(clusterId int, labels []*ClusterLabel) error
+}
+
+type ClusterLabelRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewClusterLabelRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ClusterLabelRepositoryImpl {
+	return &ClusterLabelRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ClusterLabelRepositoryImpl) FindAll() ([]*ClusterLabel, error) {
+	var labels []*ClusterLabel
+	err := impl.dbConnection.Model(&labels).
+		Order("cluster_id ASC").
+		Order("key ASC").
+		Select()
+	return labels, err
+}
+
+func (impl *ClusterLabelRepositoryImpl) FindByClusterId(clusterId int) ([]*ClusterLabel, error) {
+	var labels []*ClusterLabel
+	err := impl.dbConnection.Model(&labels).
+		Where("cluster_id = ?", clusterId).
+		Order("key ASC").
+		Select()
+	return labels, err
+}
+
+func (impl *ClusterLabelRepositoryImpl) ReplaceForCluster(clusterId int, labels []*ClusterLabel) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		_, err := tx.Model((*ClusterLabel)(nil)).
+			Where("cluster_id = ?", clusterId).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if len(labels) == 0 {
+			return nil
+		}
+		_, err = tx.Model(&labels).Insert()
+		return err
+	})
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ClusterSetTarget struct {
+	tableName         struct{} `sql:"cluster_set_target" pg:",discard_unknown_columns"`
+	Id                int      `sql:"id,pk"`
+	PipelineId        int      `sql:"pipeline_id,notnull"`
+	Selector          string   `sql:"selector,notnull"`
+	Parallelism       int      `sql:"parallelism,notnull"`
+	MaxUnavailable    int      `sql:"max_unavailable,notnull"`
+	HealthTimeoutMins int      `sql:"health_timeout_mins,notnull"`
+	Active            bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ClusterSetDeployment struct {
+	tableName         struct{}   `sql:"cluster_set_deployment" pg:",discard_unknown_columns"`
+	Id                int        `sql:"id,pk"`
+	TargetId          int        `sql:"target_id,notnull"`
+	PipelineId        int        `sql:"pipeline_id,notnull"`
+	CiArtifactId      int        `sql:"ci_artifact_id,notnull"`
+	Status            string     `sql:"status,notnull"`
+	Parallelism       int        `sql:"parallelism,notnull"`
+	MaxUnavailable    int        `sql:"max_unavailable,notnull"`
+	HealthTimeoutMins int        `sql:"health_timeout_mins,notnull"`
+	Message           string     `sql:"message"`
+	TriggeredBy       int32      `sql:"triggered_by,notnull"`
+	StartedOn         time.Time  `sql:"started_on,notnull"`
+	FinishedOn        *time.Time `sql:"finished_on"`
+	sql.AuditLog
+}
+
+type ClusterSetDeploymentMember struct {
+	tableName          struct{}   `sql:"cluster_set_deployment_member" pg:",discard_unknown_columns"`
+	Id                 int        `sql:"id,pk"`
+	DeploymentId       int        `sql:"deployment_id,notnull"`
+	ClusterId          int        `sql:"cluster_id,notnull"`
+	Status             string     `sql:"status,notnull"`
+	CdWorkflowRunnerId int        `sql:"cd_workflow_runner_id"`
+	Message            string     `sql:"message"`
+	StartedOn          *time.Time `sql:"started_on"`
+	FinishedOn         *time.Time `sql:"finished_on"`
+}
+
+type ClusterSetRepository interface {
+	SaveTarget(target *ClusterSetTarget) error
+	UpdateTarget(target *ClusterSetTarget) error
+	FindActiveTargetByPipelineId(pipelineId int) (*ClusterSetTarget, error)
+
+	// SaveDeploymentWithMembers saves the deployment and its member clusters in a single transaction
+	SaveDeploymentWithMembers(deployment *ClusterSetDeployment, members []*ClusterSetDeploymentMember) error
+	// StartDeploymentIfInStatus updates the deployment and saves its member clusters in a single transaction, only if
+	// the deployment is still in the given status. false is returned and nothing is saved otherwise.
+	StartDeploymentIfInStatus(deployment *ClusterSetDeployment, members []*ClusterSetDeploymentMember, status string) (bool, error)
+	// UpdateDeploymentIfInStatus updates the deployment only if it is still in the given status, so it is finished
+	// once even if several replicas progress it. false is returned if its status changed in between.
+	UpdateDeploymentIfInStatus(deployment *ClusterSetDeployment, status string) (bool, error)
+	FindDeploymentById(id int) (*ClusterSetDeployment, error)
+	FindDeploymentsByPipelineId(pipelineId int, limit int) ([]*ClusterSetDeployment, error)
+	FindDeploymentsByStatus(status string) ([]*ClusterSetDeployment, error)
+	FindDeploymentsByPipelineIdAndStatuses(pipelineId int, statuses []string) ([]*ClusterSetDeployment, error)
+
+	FindMembersByDeploymentId(deploymentId int) ([]*ClusterSetDeploymentMember, error)
+	FindMembersByDeploymentIds(deploymentIds []int) ([]*ClusterSetDeploymentMember, error)
+	// UpdateMemberIfInStatus updates the member only if it is still in the given status, false is returned otherwise
+	UpdateMemberIfInStatus(member *ClusterSetDeploymentMember, status string) (bool, error)
+	// FindDeployedClusterIdsByPipelineId returns the clusters a release of the pipeline was created on
+	FindDeployedClusterIdsByPipelineId(pipelineId int) ([]int, error)
+}
+
+type ClusterSetRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewClusterSetRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ClusterSetRepositoryImpl {
+	return &ClusterSetRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ClusterSetRepositoryImpl) SaveTarget(target *ClusterSetTarget) error {
+	return impl.dbConnection.Insert(target)
+}
+
+func (impl *ClusterSetRepositoryImpl) UpdateTarget(target *ClusterSetTarget) error {
+	return impl.dbConnection.Update(target)
+}
+
+func (impl *ClusterSetRepositoryImpl) FindActiveTargetByPipelineId(pipelineId int) (*ClusterSetTarget, error) {
+	target := &ClusterSetTarget{}
+	err := impl.dbConnection.Model(target).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return target, err
+}
+
+func (impl *ClusterSetRepositoryImpl) SaveDeploymentWithMembers(deployment *ClusterSetDeployment, members []*ClusterSetDeploymentMember) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		err := tx.Insert(deployment)
+		if err != nil || len(members) == 0 {
+			return err
+		}
+		for _, member := range members {
+			member.DeploymentId = deployment.Id
+		}
+		_, err = tx.Model(&members).Insert()
+		return err
+	})
+}
+
+func (impl *ClusterSetRepositoryImpl) StartDeploymentIfInStatus(deployment *ClusterSetDeployment, members []*ClusterSetDeploymentMember, status string) (bool, error) {
+	started := false
+	err := impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		res, err := tx.Model(deployment).
+			WherePK().
+			Where("status = ?", status).
+			Update()
+		if err != nil || res.RowsAffected() != 1 {
+			return err
+		}
+		started = true
+		if len(members) == 0 {
+			return nil
+		}
+		for _, member := range members {
+			member.DeploymentId = deployment.Id
+		}
+		_, err = tx.Model(&members).Insert()
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return started, nil
+}
+
+func (impl *ClusterSetRepositoryImpl) UpdateDeploymentIfInStatus(deployment *ClusterSetDeployment, status string) (bool, error) {
+	res, err := impl.dbConnection.Model(deployment).
+		WherePK().
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ClusterSetRepositoryImpl) FindDeploymentById(id int) (*ClusterSetDeployment, error) {
+	deployment := &ClusterSetDeployment{}
+	err := impl.dbConnection.Model(deployment).
+		Where("id = ?", id).
+		Select()
+	return deployment, err
+}
+
+func (impl *ClusterSetRepositoryImpl) FindDeploymentsByPipelineId(pipelineId int, limit int) ([]*ClusterSetDeployment, error) {
+	var deployments []*ClusterSetDeployment
+	err := impl.dbConnection.Model(&deployments).
+		Where("pipeline_id = ?", pipelineId).
+		Order("id DESC").
+		Limit(limit).
+		Select()
+	return deployments, err
+}
+
+func (impl *ClusterSetRepositoryImpl) FindDeploymentsByStatus(status string) ([]*ClusterSetDeployment, error) {
+	var deployments []*ClusterSetDeployment
+	err := impl.dbConnection.Model(&deployments).
+		Where("status = ?", status).
+		Order("id ASC").
+		Select()
+	return deployments, err
+}
+
+func (impl *ClusterSetRepositoryImpl) FindDeploymentsByPipelineIdAndStatuses(pipelineId int, statuses []string) ([]*ClusterSetDeployment, error) {
+	var deployments []*ClusterSetDeployment
+	err := impl.dbConnection.Model(&deployments).
+		Where("pipeline_id = ?", pipelineId).
+		Where("status IN (?)", pg.In(statuses)).
+		Order("id ASC").
+		Select()
+	return deployments, err
+}
+
+func (impl *ClusterSetRepositoryImpl) FindMembersByDeploymentId(deploymentId int) ([]*ClusterSetDeploymentMember, error) {
+	var members []*ClusterSetDeploymentMember
+	err := impl.dbConnection.Model(&members).
+		Where("deployment_id = ?", deploymentId).
+		Order("id ASC").
+		Select()
+	return members, err
+}
+
+func (impl *ClusterSetRepositoryImpl) FindMembersByDeploymentIds(deploymentIds []int) ([]*ClusterSetDeploymentMember, error) {
+	if len(deploymentIds) == 0 {
+		return nil, nil
+	}
+	var members []*ClusterSetDeploymentMember
+	err := impl.dbConnection.Model(&members).
+		Where("deployment_id IN (?)", pg.In(deploymentIds)).
+		Order("id ASC").
+		Select()
+	return members, err
+}
+
+func (impl *ClusterSetRepositoryImpl) UpdateMemberIfInStatus(member *ClusterSetDeploymentMember, status string) (bool, error) {
+	res, err := impl.dbConnection.Model(member).
+		WherePK().
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ClusterSetRepositoryImpl) FindDeployedClusterIdsByPipelineId(pipelineId int) ([]int, error) {
+	var clusterIds []int
+	query := `SELECT DISTINCT m.cluster_id FROM cluster_set_deployment_member m
+		INNER JOIN cluster_set_deployment d ON d.id = m.deployment_id
+		WHERE d.pipeline_id = ? AND m.cd_workflow_runner_id IS NOT NULL;`
+	_, err := impl.dbConnection.Query(&clusterIds, query, pipelineId)
+	return clusterIds, err
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"sort"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Progress is the next step of a cluster set deployment as per the statuses of its member clusters
+type Progress struct {
+	// Start is the number of pending clusters to deploy now
+	Start int
+	// SkipPending is set when the deployment halted, the pending clusters are not deployed
+	SkipPending bool
+	// Done is set when no cluster is pending or deploying, Status is then the final status of the deployment
+	Done   bool
+	Status bean.DeploymentStatus
+}
+
+// ParseSelector parses a kubernetes label selector, e.g. "region in (eu, us), tier=edge"
+func ParseSelector(selector string) (labels.Selector, error) {
+	return labels.Parse(selector)
+}
+
+// GetMemberClusterIds returns the ids, in order, of the clusters whose labels match the selector
+func GetMemberClusterIds(selector labels.Selector, labelsByClusterId map[int]map[string]string) []int {
+	clusterIds := make([]int, 0)
+	for clusterId, clusterLabels := range labelsByClusterId {
+		if selector.Matches(labels.Set(clusterLabels)) {
+			clusterIds = append(clusterIds, clusterId)
+		}
+	}
+	sort.Ints(clusterIds)
+	return clusterIds
+}
+
+// GetProgress deploys up to parallelism clusters at a time and halts the deployment once more than maxUnavailable
+// clusters failed, the clusters being deployed when it halts are still tracked until they finish
+func GetProgress(statuses []bean.MemberStatus, parallelism, maxUnavailable int) *Progress {
+	summary := &bean.MemberSummary{}
+	for _, status := range statuses {
+		summary.Add(status)
+	}
+	progress := &Progress{}
+	if summary.Failed > maxUnavailable {
+		progress.SkipPending = true
+		progress.Done = summary.Deploying == 0
+		progress.Status = bean.DeploymentStatusHalted
+		return progress
+	}
+	progress.Start = parallelism - summary.Deploying
+	if progress.Start > summary.Pending {
+		progress.Start = summary.Pending
+	}
+	if progress.Start < 0 {
+		progress.Start = 0
+	}
+	progress.Done = summary.Pending == 0 && summary.Deploying == 0
+	progress.Status = bean.DeploymentStatusRunning
+	if progress.Done {
+		progress.Status = bean.DeploymentStatusSucceeded
+		if summary.Failed > 0 {
+			progress.Status = bean.DeploymentStatusDegraded
+		}
+	}
+	return progress
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMemberClusterIds(t *testing.T) {
+	labelsByClusterId := map[int]map[string]string{
+		3: {"region": "eu", "tier": "edge"},
+		1: {"region": "us", "tier": "edge"},
+		2: {"region": "eu", "tier": "core"},
+		4: {},
+	}
+	selector, err := ParseSelector("region in (eu, us), tier=edge")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, GetMemberClusterIds(selector, labelsByClusterId))
+
+	selector, err = ParseSelector("!tier")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4}, GetMemberClusterIds(selector, labelsByClusterId))
+
+	_, err = ParseSelector("region in eu")
+	assert.Error(t, err)
+}
+
+func TestGetProgress(t *testing.T) {
+	pending, deploying := bean.MemberStatusPending, bean.MemberStatusDeploying
+	succeeded, failed := bean.MemberStatusSucceeded, bean.MemberStatusFailed
+
+	progress := GetProgress([]bean.MemberStatus{pending, pending, pending}, 2, 0)
+	assert.Equal(t, &Progress{Start: 2, Status: bean.DeploymentStatusRunning}, progress)
+
+	// a slot frees up as a cluster succeeds
+	progress = GetProgress([]bean.MemberStatus{succeeded, deploying, pending}, 2, 0)
+	assert.Equal(t, &Progress{Start: 1, Status: bean.DeploymentStatusRunning}, progress)
+
+	// failures within the max unavailable do not halt the deployment
+	progress = GetProgress([]bean.MemberStatus{failed, succeeded, pending}, 2, 1)
+	assert.Equal(t, &Progress{Start: 1, Status: bean.DeploymentStatusRunning}, progress)
+	progress = GetProgress([]bean.MemberStatus{failed, succeeded, succeeded}, 2, 1)
+	assert.Equal(t, &Progress{Done: true, Status: bean.DeploymentStatusDegraded}, progress)
+
+	// the deployment halts once more clusters failed, the deploying ones are waited for
+	progress = GetProgress([]bean.MemberStatus{failed, failed, deploying, pending}, 2, 1)
+	assert.Equal(t, &Progress{SkipPending: true, Status: bean.DeploymentStatusHalted}, progress)
+	progress = GetProgress([]bean.MemberStatus{failed, failed, succeeded, pending}, 2, 1)
+	assert.Equal(t, &Progress{SkipPending: true, Done: true, Status: bean.DeploymentStatusHalted}, progress)
+
+	progress = GetProgress([]bean.MemberStatus{succeeded, succeeded}, 2, 0)
+	assert.Equal(t, &Progress{Done: true, Status: bean.DeploymentStatusSucceeded}, progress)
+}
@@ -20,6 +20,9 @@ type ReleaseConfiguration struct {
 	Version    ReleaseConfigVersion `json:"version"`
 	ArgoCDSpec ArgoCDSpec           `json:"argoCDSpec"`
 	FluxCDSpec FluxCDSpec           `json:"fluxCDSpec"`
+	// GitOpsRepoBasePath is the <app>/<env> directory holding the chart in a shared (mono) GitOps repository,
+	// empty when the GitOps repository is dedicated to the app
+	GitOpsRepoBasePath string `json:"gitOpsRepoBasePath,omitempty"`
 }
 
 type FluxCDSpec struct {
@@ -305,7 +308,33 @@ func (d *DeploymentConfig) SetRepoURL(repoURL string) *DeploymentConfig {
 	return d
 }
 
+func (d *DeploymentConfig) GetGitOpsRepoBasePath() string {
+	if d.ReleaseConfiguration == nil {
+		return ""
+	}
+	return d.ReleaseConfiguration.GitOpsRepoBasePath
+}
+
+// SetGitOpsRepoBasePath moves the chart location under the given base path of a shared GitOps repository
+func (d *DeploymentConfig) SetGitOpsRepoBasePath(basePath string) {
+	if d.ReleaseConfiguration == nil {
+		return
+	}
+	chartLocation := d.GetChartLocation()
+	if currentBasePath := d.ReleaseConfiguration.GitOpsRepoBasePath; len(currentBasePath) != 0 {
+		chartLocation = strings.TrimPrefix(chartLocation, currentBasePath+"/")
+	}
+	d.ReleaseConfiguration.GitOpsRepoBasePath = strings.Trim(basePath, "/")
+	if len(chartLocation) != 0 {
+		d.SetChartLocation(chartLocation)
+	}
+}
+
+// SetChartLocation sets the chart location relative to the GitOps repository base path (if any)
 func (d *DeploymentConfig) SetChartLocation(chartLocation string) {
+	if basePath := d.GetGitOpsRepoBasePath(); len(basePath) != 0 && !strings.HasPrefix(chartLocation, basePath+"/") {
+		chartLocation = path.Join(basePath, chartLocation)
+	}
 	if d.IsFluxRelease() && d.ReleaseConfiguration != nil {
 		d.ReleaseConfiguration.FluxCDSpec.ChartLocation = chartLocation
 		return
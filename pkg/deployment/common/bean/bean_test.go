@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/internal/util"
+)
+
+func newArgoDeploymentConfig(chartLocation string) *DeploymentConfig {
+	return &DeploymentConfig{
+		DeploymentAppType: util.PIPELINE_DEPLOYMENT_TYPE_ACD,
+		ReleaseConfiguration: &ReleaseConfiguration{
+			Version: Version,
+			ArgoCDSpec: ArgoCDSpec{
+				Spec: ApplicationSpec{
+					Source: &ApplicationSource{Path: chartLocation},
+				},
+			},
+		},
+	}
+}
+
+func TestSetGitOpsRepoBasePath(t *testing.T) {
+	config := newArgoDeploymentConfig("reference-chart_4-19-0/4.19.0")
+	config.SetGitOpsRepoBasePath("/my-app/dev/")
+	if got := config.GetChartLocation(); got != "my-app/dev/reference-chart_4-19-0/4.19.0" {
+		t.Fatalf("unexpected chart location after setting base path: %s", got)
+	}
+	// chart upgrade recomputes the location without the base path
+	config.SetChartLocation("reference-chart_5-0-0/5.0.0")
+	if got := config.GetChartLocation(); got != "my-app/dev/reference-chart_5-0-0/5.0.0" {
+		t.Fatalf("base path not preserved on chart location update: %s", got)
+	}
+	// already prefixed location is kept as is
+	config.SetChartLocation("my-app/dev/reference-chart_5-0-0/5.0.0")
+	if got := config.GetChartLocation(); got != "my-app/dev/reference-chart_5-0-0/5.0.0" {
+		t.Fatalf("base path applied twice: %s", got)
+	}
+	config.SetGitOpsRepoBasePath("my-app/prod")
+	if got := config.GetChartLocation(); got != "my-app/prod/reference-chart_5-0-0/5.0.0" {
+		t.Fatalf("unexpected chart location after moving base path: %s", got)
+	}
+}
+
+func TestSetChartLocationWithoutBasePath(t *testing.T) {
+	config := newArgoDeploymentConfig("reference-chart_4-19-0/4.19.0")
+	config.SetChartLocation("reference-chart_5-0-0/5.0.0")
+	if got := config.GetChartLocation(); got != "reference-chart_5-0-0/5.0.0" {
+		t.Fatalf("unexpected chart location: %s", got)
+	}
+	if got := config.GetGitOpsRepoBasePath(); got != "" {
+		t.Fatalf("unexpected base path: %s", got)
+	}
+}
@@ -53,6 +53,8 @@ type DeploymentConfigService interface {
 	GetConfigEvenIfInactive(appId, envId int) (*bean.DeploymentConfig, error)
 	GetAndMigrateConfigIfAbsentForHelmApp(appId, envId int) (*bean.DeploymentConfig, error)
 	UpdateRepoUrlForAppAndEnvId(repoURL string, appId, envId int) error
+	// UpdateGitOpsRepoForAppAndEnvId points the env level config to the given GitOps repository and base path
+	UpdateGitOpsRepoForAppAndEnvId(tx *pg.Tx, repoURL, basePath string, appId, envId int, userId int32) (*bean.DeploymentConfig, error)
 	GetConfigsByAppIds(appIds []int) ([]*bean.DeploymentConfig, error)
 	UpdateChartLocationInDeploymentConfig(tx *pg.Tx, appId, envId, chartRefId int, userId int32, chartVersion string) error
 	GetAllArgoAppInfosByDeploymentAppNames(deploymentAppNames []string) ([]*bean.DevtronArgoCdAppInfo, error)
@@ -298,6 +300,22 @@ func (impl *DeploymentConfigServiceImpl) UpdateRepoUrlForAppAndEnvId(repoURL str
 	return nil
 }
 
+func (impl *DeploymentConfigServiceImpl) UpdateGitOpsRepoForAppAndEnvId(tx *pg.Tx, repoURL, basePath string, appId, envId int, userId int32) (*bean.DeploymentConfig, error) {
+	config, err := impl.GetConfigForDevtronApps(tx, appId, envId)
+	if err != nil {
+		impl.logger.Errorw("error in getting deployment config", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	config.SetRepoURL(repoURL)
+	config.SetGitOpsRepoBasePath(basePath)
+	config, err = impl.CreateOrUpdateConfig(tx, config, userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating gitOps repo in deployment config", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	return config, nil
+}
+
 func (impl *DeploymentConfigServiceImpl) GetConfigsByAppIds(appIds []int) ([]*bean.DeploymentConfig, error) {
 	if len(appIds) == 0 {
 		return nil, nil
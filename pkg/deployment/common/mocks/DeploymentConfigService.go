@@ -0,0 +1,468 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pg "github.com/go-pg/pg"
+
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+)
+
+// DeploymentConfigService is an autogenerated mock type for the DeploymentConfigService type
+type DeploymentConfigService struct {
+	mock.Mock
+}
+
+// CheckIfURLAlreadyPresent provides a mock function with given fields: repoURL, appId
+func (_m *DeploymentConfigService) CheckIfURLAlreadyPresent(repoURL string, appId int) (bool, error) {
+	ret := _m.Called(repoURL, appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIfURLAlreadyPresent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (bool, error)); ok {
+		return rf(repoURL, appId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) bool); ok {
+		r0 = rf(repoURL, appId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(repoURL, appId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrUpdateConfig provides a mock function with given fields: tx, config, userId
+func (_m *DeploymentConfigService) CreateOrUpdateConfig(tx *pg.Tx, config *bean.DeploymentConfig, userId int32) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(tx, config, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdateConfig")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, *bean.DeploymentConfig, int32) (*bean.DeploymentConfig, error)); ok {
+		return rf(tx, config, userId)
+	}
+	if rf, ok := ret.Get(0).(func(*pg.Tx, *bean.DeploymentConfig, int32) *bean.DeploymentConfig); ok {
+		r0 = rf(tx, config, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*pg.Tx, *bean.DeploymentConfig, int32) error); ok {
+		r1 = rf(tx, config, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrUpdateConfigInBulk provides a mock function with given fields: tx, configToBeCreated, configToBeUpdated, userId
+func (_m *DeploymentConfigService) CreateOrUpdateConfigInBulk(tx *pg.Tx, configToBeCreated []*bean.DeploymentConfig, configToBeUpdated []*bean.DeploymentConfig, userId int32) error {
+	ret := _m.Called(tx, configToBeCreated, configToBeUpdated, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdateConfigInBulk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, []*bean.DeploymentConfig, []*bean.DeploymentConfig, int32) error); ok {
+		r0 = rf(tx, configToBeCreated, configToBeUpdated, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilterPipelinesByApplicationClusterIdAndNamespace provides a mock function with given fields: pipelines, applicationObjectClusterId, applicationObjectNamespace
+func (_m *DeploymentConfigService) FilterPipelinesByApplicationClusterIdAndNamespace(pipelines []pipelineConfig.Pipeline, applicationObjectClusterId int, applicationObjectNamespace string) (pipelineConfig.Pipeline, error) {
+	ret := _m.Called(pipelines, applicationObjectClusterId, applicationObjectNamespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterPipelinesByApplicationClusterIdAndNamespace")
+	}
+
+	var r0 pipelineConfig.Pipeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]pipelineConfig.Pipeline, int, string) (pipelineConfig.Pipeline, error)); ok {
+		return rf(pipelines, applicationObjectClusterId, applicationObjectNamespace)
+	}
+	if rf, ok := ret.Get(0).(func([]pipelineConfig.Pipeline, int, string) pipelineConfig.Pipeline); ok {
+		r0 = rf(pipelines, applicationObjectClusterId, applicationObjectNamespace)
+	} else {
+		r0 = ret.Get(0).(pipelineConfig.Pipeline)
+	}
+
+	if rf, ok := ret.Get(1).(func([]pipelineConfig.Pipeline, int, string) error); ok {
+		r1 = rf(pipelines, applicationObjectClusterId, applicationObjectNamespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllArgoAppInfosByDeploymentAppNames provides a mock function with given fields: deploymentAppNames
+func (_m *DeploymentConfigService) GetAllArgoAppInfosByDeploymentAppNames(deploymentAppNames []string) ([]*bean.DevtronArgoCdAppInfo, error) {
+	ret := _m.Called(deploymentAppNames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllArgoAppInfosByDeploymentAppNames")
+	}
+
+	var r0 []*bean.DevtronArgoCdAppInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*bean.DevtronArgoCdAppInfo, error)); ok {
+		return rf(deploymentAppNames)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*bean.DevtronArgoCdAppInfo); ok {
+		r0 = rf(deploymentAppNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.DevtronArgoCdAppInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(deploymentAppNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAndMigrateConfigIfAbsentForDevtronApps provides a mock function with given fields: tx, appId, envId
+func (_m *DeploymentConfigService) GetAndMigrateConfigIfAbsentForDevtronApps(tx *pg.Tx, appId int, envId int) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(tx, appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndMigrateConfigIfAbsentForDevtronApps")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, int) (*bean.DeploymentConfig, error)); ok {
+		return rf(tx, appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, int) *bean.DeploymentConfig); ok {
+		r0 = rf(tx, appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*pg.Tx, int, int) error); ok {
+		r1 = rf(tx, appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAndMigrateConfigIfAbsentForHelmApp provides a mock function with given fields: appId, envId
+func (_m *DeploymentConfigService) GetAndMigrateConfigIfAbsentForHelmApp(appId int, envId int) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAndMigrateConfigIfAbsentForHelmApp")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*bean.DeploymentConfig, error)); ok {
+		return rf(appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *bean.DeploymentConfig); ok {
+		r0 = rf(appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigEvenIfInactive provides a mock function with given fields: appId, envId
+func (_m *DeploymentConfigService) GetConfigEvenIfInactive(appId int, envId int) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigEvenIfInactive")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*bean.DeploymentConfig, error)); ok {
+		return rf(appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *bean.DeploymentConfig); ok {
+		r0 = rf(appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigForDevtronApps provides a mock function with given fields: tx, appId, envId
+func (_m *DeploymentConfigService) GetConfigForDevtronApps(tx *pg.Tx, appId int, envId int) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(tx, appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigForDevtronApps")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, int) (*bean.DeploymentConfig, error)); ok {
+		return rf(tx, appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, int) *bean.DeploymentConfig); ok {
+		r0 = rf(tx, appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*pg.Tx, int, int) error); ok {
+		r1 = rf(tx, appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigForHelmApps provides a mock function with given fields: appId, envId
+func (_m *DeploymentConfigService) GetConfigForHelmApps(appId int, envId int) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigForHelmApps")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (*bean.DeploymentConfig, error)); ok {
+		return rf(appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) *bean.DeploymentConfig); ok {
+		r0 = rf(appId, envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfigsByAppIds provides a mock function with given fields: appIds
+func (_m *DeploymentConfigService) GetConfigsByAppIds(appIds []int) ([]*bean.DeploymentConfig, error) {
+	ret := _m.Called(appIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfigsByAppIds")
+	}
+
+	var r0 []*bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]*bean.DeploymentConfig, error)); ok {
+		return rf(appIds)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []*bean.DeploymentConfig); ok {
+		r0 = rf(appIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(appIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExternalReleaseType provides a mock function with given fields: appId, environmentId
+func (_m *DeploymentConfigService) GetExternalReleaseType(appId int, environmentId int) (bean.ExternalReleaseType, error) {
+	ret := _m.Called(appId, environmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExternalReleaseType")
+	}
+
+	var r0 bean.ExternalReleaseType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) (bean.ExternalReleaseType, error)); ok {
+		return rf(appId, environmentId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) bean.ExternalReleaseType); ok {
+		r0 = rf(appId, environmentId)
+	} else {
+		r0 = ret.Get(0).(bean.ExternalReleaseType)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(appId, environmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsChartStoreAppManagedByArgoCd provides a mock function with given fields: appId
+func (_m *DeploymentConfigService) IsChartStoreAppManagedByArgoCd(appId int) (bool, error) {
+	ret := _m.Called(appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsChartStoreAppManagedByArgoCd")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(appId)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(appId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(appId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateChartLocationInDeploymentConfig provides a mock function with given fields: tx, appId, envId, chartRefId, userId, chartVersion
+func (_m *DeploymentConfigService) UpdateChartLocationInDeploymentConfig(tx *pg.Tx, appId int, envId int, chartRefId int, userId int32, chartVersion string) error {
+	ret := _m.Called(tx, appId, envId, chartRefId, userId, chartVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChartLocationInDeploymentConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, int, int, int32, string) error); ok {
+		r0 = rf(tx, appId, envId, chartRefId, userId, chartVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateGitOpsRepoForAppAndEnvId provides a mock function with given fields: tx, repoURL, basePath, appId, envId, userId
+func (_m *DeploymentConfigService) UpdateGitOpsRepoForAppAndEnvId(tx *pg.Tx, repoURL string, basePath string, appId int, envId int, userId int32) (*bean.DeploymentConfig, error) {
+	ret := _m.Called(tx, repoURL, basePath, appId, envId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGitOpsRepoForAppAndEnvId")
+	}
+
+	var r0 *bean.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, string, string, int, int, int32) (*bean.DeploymentConfig, error)); ok {
+		return rf(tx, repoURL, basePath, appId, envId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(*pg.Tx, string, string, int, int, int32) *bean.DeploymentConfig); ok {
+		r0 = rf(tx, repoURL, basePath, appId, envId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*pg.Tx, string, string, int, int, int32) error); ok {
+		r1 = rf(tx, repoURL, basePath, appId, envId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRepoUrlForAppAndEnvId provides a mock function with given fields: repoURL, appId, envId
+func (_m *DeploymentConfigService) UpdateRepoUrlForAppAndEnvId(repoURL string, appId int, envId int) error {
+	ret := _m.Called(repoURL, appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRepoUrlForAppAndEnvId")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, int) error); ok {
+		r0 = rf(repoURL, appId, envId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDeploymentConfigService creates a new instance of DeploymentConfigService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeploymentConfigService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeploymentConfigService {
+	mock := &DeploymentConfigService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
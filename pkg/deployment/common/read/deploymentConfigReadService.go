@@ -376,6 +376,11 @@ func (impl *DeploymentConfigReadServiceImpl) ParseEnvLevelReleaseConfigForDevtro
 				},
 			},
 		}
+		// charts in a shared GitOps repo stay under the <app>/<env> directory
+		if basePath := config.GetGitOpsRepoBasePath(); len(basePath) != 0 {
+			releaseConfig.GitOpsRepoBasePath = basePath
+			releaseConfig.ArgoCDSpec.Spec.Source.Path = filepath.Join(basePath, latestChart.ChartLocation)
+		}
 	}
 	return releaseConfig, nil
 }
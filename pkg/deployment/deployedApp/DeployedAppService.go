@@ -128,6 +128,9 @@ func (impl *DeployedAppServiceImpl) stopStartApp(ctx context.Context, stopReques
 		Context:     ctx,
 		ReferenceId: stopRequest.ReferenceId,
 	}
+	if stopRequest.TriggeredBySystem {
+		triggerContext.TriggerType = bean3.System
+	}
 	id, _, _, err := impl.cdHandlerService.ManualCdTrigger(triggerContext, overrideRequest, userMetadata)
 	if err != nil {
 		impl.logger.Errorw("error in stopping app", "err", err, "appId", stopRequest.AppId, "envId", stopRequest.EnvironmentId)
@@ -42,6 +42,8 @@ type StopAppRequest struct {
 	ReferenceId                  *string
 	StopPatch                    string // for internal use only
 	IsHibernationPatchConfigured bool   //for internal use only
+	// TriggeredBySystem records the deployment as triggered by the system, for internal use only
+	TriggeredBySystem bool `json:"-"`
 }
 
 type HibernationPatchResponse struct {
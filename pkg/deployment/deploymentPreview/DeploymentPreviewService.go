@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentPreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/config/configDiff"
+	configDiffBean "github.com/devtron-labs/devtron/pkg/config/configDiff/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentPreview/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentPreview/helper"
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	driftHelper "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/helper"
+	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/adapter"
+	k8sService "github.com/devtron-labs/devtron/pkg/k8s"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	util2 "github.com/devtron-labs/devtron/util"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+type DeploymentPreviewService interface {
+	// GetPreview renders the manifest the override request would deploy and dry runs it on the cluster of the pipeline,
+	// returning the resources the deployment would create, update or delete. The data of secrets is masked unless
+	// showSecrets is set.
+	GetPreview(ctx context.Context, overrideRequest *apiBean.ValuesOverrideRequest, showSecrets bool) (*bean.DeploymentPreviewDto, error)
+	// RenderObjects templates the chart of the pipeline with the merged values, helm hooks are left out as they are
+	// not part of the live state of a release
+	RenderObjects(ctx context.Context, pipeline *pipelineConfig.Pipeline, mergedValues string) ([]*unstructured.Unstructured, error)
+}
+
+type DeploymentPreviewServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	pipelineRepository             pipelineConfig.PipelineRepository
+	cdWorkflowRepository           pipelineConfig.CdWorkflowRepository
+	pipelineOverrideRepository     chartConfig.PipelineOverrideRepository
+	deploymentConfigService        common.DeploymentConfigService
+	manifestCreationService        manifest.ManifestCreationService
+	deploymentConfigurationService configDiff.DeploymentConfigurationService
+	k8sCommonService               k8sService.K8sCommonService
+	k8sUtil                        *k8s.K8sServiceImpl
+}
+
+func NewDeploymentPreviewServiceImpl(logger *zap.SugaredLogger,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	deploymentConfigService common.DeploymentConfigService,
+	manifestCreationService manifest.ManifestCreationService,
+	deploymentConfigurationService configDiff.DeploymentConfigurationService,
+	k8sCommonService k8sService.K8sCommonService,
+	k8sUtil *k8s.K8sServiceImpl) *DeploymentPreviewServiceImpl {
+	return &DeploymentPreviewServiceImpl{
+		logger:                         logger,
+		pipelineRepository:             pipelineRepository,
+		cdWorkflowRepository:           cdWorkflowRepository,
+		pipelineOverrideRepository:     pipelineOverrideRepository,
+		deploymentConfigService:        deploymentConfigService,
+		manifestCreationService:        manifestCreationService,
+		deploymentConfigurationService: deploymentConfigurationService,
+		k8sCommonService:               k8sCommonService,
+		k8sUtil:                        k8sUtil,
+	}
+}
+
+func (impl *DeploymentPreviewServiceImpl) GetPreview(ctx context.Context, overrideRequest *apiBean.ValuesOverrideRequest, showSecrets bool) (*bean.DeploymentPreviewDto, error) {
+	pipeline, err := impl.pipelineRepository.FindById(overrideRequest.PipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline not found")
+		}
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", overrideRequest.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.AppId != overrideRequest.AppId {
+		return nil, util.NewApiError(http.StatusBadRequest, "pipeline does not belong to the app", "invalid app id")
+	}
+	if len(overrideRequest.CdWorkflowType) == 0 {
+		overrideRequest.CdWorkflowType = apiBean.CD_WORKFLOW_TYPE_DEPLOY
+	}
+	envDeploymentConfig, err := impl.deploymentConfigService.GetAndMigrateConfigIfAbsentForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment deployment config", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return nil, err
+	}
+	adapter.SetPipelineFieldsInOverrideRequest(overrideRequest, pipeline, envDeploymentConfig)
+	valuesOverrideResponse, err := impl.manifestCreationService.GetValuesOverrideForPreview(ctx, overrideRequest, envDeploymentConfig)
+	if err != nil {
+		impl.logger.Errorw("error in building values for preview", "pipelineId", pipeline.Id, "artifactId", overrideRequest.CiArtifactId, "err", err)
+		return nil, err
+	}
+	objects, err := impl.RenderObjects(ctx, pipeline, valuesOverrideResponse.MergedValues)
+	if err != nil {
+		return nil, err
+	}
+	manifestYaml, err := helper.GetManifestYaml(objects, !showSecrets)
+	if err != nil {
+		return nil, err
+	}
+	preview := &bean.DeploymentPreviewDto{
+		PipelineId:      pipeline.Id,
+		AppId:           pipeline.AppId,
+		EnvironmentId:   pipeline.EnvironmentId,
+		EnvironmentName: pipeline.Environment.Name,
+		CiArtifactId:    overrideRequest.CiArtifactId,
+		Image:           valuesOverrideResponse.Artifact.Image,
+		Manifest:        manifestYaml,
+		Resources:       make([]*bean.ResourcePreview, 0),
+		Summary:         &bean.PreviewSummary{},
+	}
+	if pipeline.Environment.IsVirtualEnvironment {
+		preview.Message = bean.VirtualEnvironmentMessage
+		return preview, nil
+	}
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(ctx, pipeline.Environment.ClusterId)
+	if err != nil {
+		impl.logger.Errorw("error in getting rest config", "clusterId", pipeline.Environment.ClusterId, "err", err)
+		return nil, fmt.Errorf("error in connecting to cluster: %w", err)
+	}
+	autoscaledWorkloads := driftHelper.GetAutoscaledWorkloads(objects)
+	renderedKeys := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		renderedKeys[helper.GetResourceKey(object, pipeline.Environment.Namespace)] = true
+		isAutoscaled := autoscaledWorkloads[driftHelper.GetWorkloadKey(object.GetKind(), object.GetName())]
+		preview.Resources = append(preview.Resources, impl.dryRunObject(ctx, restConfig, object, pipeline.Environment.Namespace, isAutoscaled))
+	}
+	deletedResources, err := impl.getDeletedResources(ctx, restConfig, pipeline, renderedKeys)
+	if err != nil {
+		return nil, err
+	}
+	preview.Resources = append(preview.Resources, deletedResources...)
+	for _, resource := range preview.Resources {
+		preview.Summary.Add(resource)
+	}
+	return preview, nil
+}
+
+func (impl *DeploymentPreviewServiceImpl) RenderObjects(ctx context.Context, pipeline *pipelineConfig.Pipeline, mergedValues string) ([]*unstructured.Unstructured, error) {
+	manifestRequest := &configDiffBean.ManifestRequest{
+		Values:             json.RawMessage(mergedValues),
+		ResourceType:       pipelineBean.DeploymentTemplate,
+		AppId:              pipeline.AppId,
+		EnvironmentId:      pipeline.EnvironmentId,
+		UserHasAdminAccess: true,
+	}
+	manifestResponse, err := impl.deploymentConfigurationService.GetManifest(util2.SetSuperAdminInContext(ctx, true), manifestRequest)
+	if err != nil {
+		impl.logger.Errorw("error in rendering manifest", "pipelineId", pipeline.Id, "err", err)
+		return nil, fmt.Errorf("error in rendering manifest: %w", err)
+	}
+	objects, err := kube.SplitYAML([]byte(manifestResponse.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing rendered manifest: %w", err)
+	}
+	res := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		if _, isHook := object.GetAnnotations()[driftBean.HelmHookAnnotation]; !isHook {
+			res = append(res, object)
+		}
+	}
+	return res, nil
+}
+
+// dryRunObject server side applies the object with dry run, so that defaults, mutating webhooks and validations
+// are applied by the api server, and diffs the result with the live object
+func (impl *DeploymentPreviewServiceImpl) dryRunObject(ctx context.Context, restConfig *rest.Config, object *unstructured.Unstructured,
+	defaultNamespace string, isAutoscaled bool) *bean.ResourcePreview {
+	gvk := object.GroupVersionKind()
+	resource := &bean.ResourcePreview{
+		Group:   gvk.Group,
+		Version: gvk.Version,
+		Kind:    gvk.Kind,
+		Name:    object.GetName(),
+		Action:  bean.ResourceActionCreate,
+	}
+	resourceIf, namespaced, err := impl.k8sUtil.GetResourceIf(restConfig, gvk)
+	if err != nil {
+		resource.Error = fmt.Sprintf(bean.KindNotServedMessageTmpl, gvk.Kind, err.Error())
+		return resource
+	}
+	var client dynamic.ResourceInterface = resourceIf
+	if namespaced {
+		namespace := object.GetNamespace()
+		if len(namespace) == 0 {
+			namespace = defaultNamespace
+			object = object.DeepCopy()
+			object.SetNamespace(namespace)
+		}
+		resource.Namespace = namespace
+		client = resourceIf.Namespace(namespace)
+	}
+	live, err := client.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		resource.Error = fmt.Sprintf("error in fetching live object: %s", err.Error())
+		return resource
+	}
+	if err == nil {
+		resource.Action = bean.ResourceActionUpdate
+	}
+	objectJson, err := json.Marshal(object.Object)
+	if err != nil {
+		resource.Error = err.Error()
+		return resource
+	}
+	force := true
+	dryRun, err := client.Patch(ctx, object.GetName(), types.ApplyPatchType, objectJson, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: bean.DryRunFieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		resource.Error = err.Error()
+		resource.AdmissionRejected = helper.IsAdmissionRejection(err)
+		return resource
+	}
+	if resource.Action == bean.ResourceActionUpdate {
+		resource.Fields = driftHelper.DiffResource(dryRun, live, isAutoscaled)
+		if len(resource.Fields) == 0 {
+			resource.Action = bean.ResourceActionUnchanged
+		}
+	}
+	return resource
+}
+
+// getDeletedResources returns the live resources of the last deployment of the pipeline which are no longer rendered
+func (impl *DeploymentPreviewServiceImpl) getDeletedResources(ctx context.Context, restConfig *rest.Config, pipeline *pipelineConfig.Pipeline,
+	renderedKeys map[string]bool) ([]*bean.ResourcePreview, error) {
+	wfr, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(pipeline.Id, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching latest deployment", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindLatestByCdWorkflowId(wfr.CdWorkflowId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching pipeline override", "pipelineId", pipeline.Id, "cdWorkflowId", wfr.CdWorkflowId, "err", err)
+		return nil, err
+	} else if err != nil || len(pipelineOverride.PipelineMergedValues) == 0 {
+		return nil, nil
+	}
+	deployedObjects, err := impl.RenderObjects(ctx, pipeline, pipelineOverride.PipelineMergedValues)
+	if err != nil {
+		return nil, err
+	}
+	deleted := make([]*bean.ResourcePreview, 0)
+	for _, object := range deployedObjects {
+		if renderedKeys[helper.GetResourceKey(object, pipeline.Environment.Namespace)] {
+			continue
+		}
+		namespace := object.GetNamespace()
+		if len(namespace) == 0 {
+			namespace = pipeline.Environment.Namespace
+		}
+		gvk := object.GroupVersionKind()
+		resource := &bean.ResourcePreview{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Name:      object.GetName(),
+			Namespace: namespace,
+			Action:    bean.ResourceActionDelete,
+		}
+		_, err = impl.k8sUtil.GetResource(ctx, namespace, object.GetName(), gvk, restConfig)
+		if k8sErrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			resource.Error = fmt.Sprintf("error in fetching live object: %s", err.Error())
+		}
+		deleted = append(deleted, resource)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+)
+
+// DryRunFieldManager is the field manager of the server side dry run applies, nothing is persisted with it
+const DryRunFieldManager = "devtron-deployment-preview"
+
+const (
+	VirtualEnvironmentMessage = "dry run is not supported for virtual environments, only the rendered manifest is returned"
+	KindNotServedMessageTmpl  = "kind %s is not served by the cluster: %s"
+)
+
+type ResourceAction string
+
+const (
+	ResourceActionCreate    ResourceAction = "CREATE"
+	ResourceActionUpdate    ResourceAction = "UPDATE"
+	ResourceActionDelete    ResourceAction = "DELETE"
+	ResourceActionUnchanged ResourceAction = "UNCHANGED"
+)
+
+// ResourcePreview is the change the deployment would make to a resource as per the server side dry run,
+// Error is set if the dry run failed, AdmissionRejected if it was denied by an admission webhook or policy
+type ResourcePreview struct {
+	Group             string                 `json:"group"`
+	Version           string                 `json:"version"`
+	Kind              string                 `json:"kind"`
+	Name              string                 `json:"name"`
+	Namespace         string                 `json:"namespace,omitempty"`
+	Action            ResourceAction         `json:"action"`
+	Fields            []*driftBean.FieldDiff `json:"fields,omitempty"`
+	AdmissionRejected bool                   `json:"admissionRejected,omitempty"`
+	Error             string                 `json:"error,omitempty"`
+}
+
+type PreviewSummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+	Rejected  int `json:"rejected"`
+	Failed    int `json:"failed"`
+}
+
+type DeploymentPreviewDto struct {
+	PipelineId      int                `json:"pipelineId"`
+	AppId           int                `json:"appId"`
+	EnvironmentId   int                `json:"environmentId"`
+	EnvironmentName string             `json:"environmentName"`
+	CiArtifactId    int                `json:"ciArtifactId"`
+	Image           string             `json:"image"`
+	Manifest        string             `json:"manifest"`
+	Resources       []*ResourcePreview `json:"resources"`
+	Summary         *PreviewSummary    `json:"summary"`
+	Message         string             `json:"message,omitempty"`
+}
+
+func (s *PreviewSummary) Add(resource *ResourcePreview) {
+	switch {
+	case resource.AdmissionRejected:
+		s.Rejected++
+	case len(resource.Error) > 0:
+		s.Failed++
+	case resource.Action == ResourceActionCreate:
+		s.Created++
+	case resource.Action == ResourceActionUpdate:
+		s.Updated++
+	case resource.Action == ResourceActionDelete:
+		s.Deleted++
+	default:
+		s.Unchanged++
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// admissionRejectionMarkers are in the messages of the requests denied by admission webhooks and validating admission policies
+var admissionRejectionMarkers = []string{"admission webhook", "ValidatingAdmissionPolicy"}
+
+// GetResourceKey identifies a resource across the versions of its kind
+func GetResourceKey(object *unstructured.Unstructured, defaultNamespace string) string {
+	namespace := object.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+	gvk := object.GroupVersionKind()
+	return fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Kind, namespace, object.GetName())
+}
+
+func IsAdmissionRejection(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, marker := range admissionRejectionMarkers {
+		if strings.Contains(err.Error(), marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetManifestYaml joins the objects into a multi document yaml, the data of secrets is masked unless asked otherwise
+func GetManifestYaml(objects []*unstructured.Unstructured, maskSecrets bool) (string, error) {
+	documents := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if maskSecrets && object.GetKind() == "Secret" {
+			object = maskSecretData(object)
+		}
+		document, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, string(document))
+	}
+	return strings.Join(documents, "---\n"), nil
+}
+
+func maskSecretData(secret *unstructured.Unstructured) *unstructured.Unstructured {
+	masked := secret.DeepCopy()
+	for _, field := range []string{"data", "stringData"} {
+		data, found, _ := unstructured.NestedMap(masked.Object, field)
+		if !found {
+			continue
+		}
+		for key := range data {
+			data[key] = driftBean.MaskedValue
+		}
+		_ = unstructured.SetNestedMap(masked.Object, data, field)
+	}
+	return masked
+}
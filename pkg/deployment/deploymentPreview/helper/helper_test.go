@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsAdmissionRejection(t *testing.T) {
+	assert.True(t, IsAdmissionRejection(errors.New(`admission webhook "validate.kyverno.svc-fail" denied the request: image tag latest is not allowed`)))
+	assert.True(t, IsAdmissionRejection(errors.New(`deployments.apps "app" is forbidden: ValidatingAdmissionPolicy 'replicas' with binding 'replicas' denied request`)))
+	assert.False(t, IsAdmissionRejection(errors.New(`namespaces "devtron-demo" not found`)))
+	assert.False(t, IsAdmissionRejection(nil))
+}
+
+func TestGetManifestYaml(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "app-secret"},
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+	}}
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "app-cm"},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+	masked, err := GetManifestYaml([]*unstructured.Unstructured{secret, configMap}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(strings.Split(masked, "---\n")))
+	assert.NotContains(t, masked, "c2VjcmV0")
+	assert.Contains(t, masked, "key: value")
+	// the rendered objects are left as is
+	assert.Equal(t, "c2VjcmV0", secret.Object["data"].(map[string]interface{})["password"])
+
+	unmasked, err := GetManifestYaml([]*unstructured.Unstructured{secret}, false)
+	assert.NoError(t, err)
+	assert.Contains(t, unmasked, "c2VjcmV0")
+}
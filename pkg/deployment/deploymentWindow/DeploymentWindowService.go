@@ -0,0 +1,313 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploymentWindow
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/constants"
+	"github.com/devtron-labs/devtron/internal/util"
+	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/repository"
+	util2 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/util"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentWindowService interface {
+	CreateOrUpdate(request *bean.DeploymentWindowDto) (*bean.DeploymentWindowDto, error)
+	GetByEnvironmentId(envId int) ([]*bean.DeploymentWindowDto, error)
+	Delete(id int, userId int32) error
+
+	// GetWindowState evaluates the deployment windows of an environment at the given time
+	GetWindowState(envId int, at time.Time) (*bean.WindowState, error)
+	// CheckDeploymentAllowed returns an error with the next allowed window if deployment to the environment is blocked,
+	// super admins are allowed to bypass the windows and the result is marked as bypassed for auditing
+	CheckDeploymentAllowed(envId int, envName string, isUserSuperAdmin bool) (*bean.DeploymentWindowCheckResult, error)
+	SaveBypassAudit(result *bean.DeploymentWindowCheckResult, pipelineId, cdWorkflowRunnerId int, userId int32) error
+	GetBypassAudits(envId int, offset, limit int) ([]*bean.BypassAuditDto, error)
+
+	// QueueTrigger queues an automatic trigger blocked by a deployment window, older queued triggers of the pipeline are superseded
+	QueueTrigger(queuedTrigger *bean.QueuedTriggerDto, state *bean.WindowState) error
+	// SupersedeQueuedTriggers drops the queued triggers of a pipeline, used when a newer deployment has happened
+	SupersedeQueuedTriggers(pipelineId int, userId int32) error
+	GetQueuedTriggers(envId int) ([]*bean.QueuedTriggerDto, error)
+	// GetReleasableQueuedTriggers returns the queued triggers whose environment is open for deployment now
+	GetReleasableQueuedTriggers(limit int) ([]*bean.QueuedTriggerDto, error)
+	// ClaimQueuedTrigger marks a queued trigger released, returns false if some other replica has already claimed it
+	ClaimQueuedTrigger(id int) (bool, error)
+	MarkQueuedTriggerFailed(id int, message string) error
+}
+
+type DeploymentWindowServiceImpl struct {
+	logger                                  *zap.SugaredLogger
+	deploymentWindowRepository              repository.DeploymentWindowRepository
+	deploymentWindowBypassAuditRepository   repository.DeploymentWindowBypassAuditRepository
+	deploymentWindowQueuedTriggerRepository repository.DeploymentWindowQueuedTriggerRepository
+	environmentRepository                   repository2.EnvironmentRepository
+}
+
+func NewDeploymentWindowServiceImpl(logger *zap.SugaredLogger,
+	deploymentWindowRepository repository.DeploymentWindowRepository,
+	deploymentWindowBypassAuditRepository repository.DeploymentWindowBypassAuditRepository,
+	deploymentWindowQueuedTriggerRepository repository.DeploymentWindowQueuedTriggerRepository,
+	environmentRepository repository2.EnvironmentRepository) *DeploymentWindowServiceImpl {
+	return &DeploymentWindowServiceImpl{
+		logger:                                  logger,
+		deploymentWindowRepository:              deploymentWindowRepository,
+		deploymentWindowBypassAuditRepository:   deploymentWindowBypassAuditRepository,
+		deploymentWindowQueuedTriggerRepository: deploymentWindowQueuedTriggerRepository,
+		environmentRepository:                   environmentRepository,
+	}
+}
+
+func (impl *DeploymentWindowServiceImpl) CreateOrUpdate(request *bean.DeploymentWindowDto) (*bean.DeploymentWindowDto, error) {
+	err := util2.ValidateDeploymentWindow(request)
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	_, err = impl.environmentRepository.FindById(request.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", request.EnvironmentId, "err", err)
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "environment not found", err.Error())
+		}
+		return nil, err
+	}
+	var model *repository.DeploymentWindow
+	if request.Id > 0 {
+		model, err = impl.deploymentWindowRepository.FindActiveById(request.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching deployment window", "id", request.Id, "err", err)
+			if util.IsErrNoRows(err) {
+				return nil, util.NewApiError(http.StatusNotFound, "deployment window not found", err.Error())
+			}
+			return nil, err
+		}
+		model = adapter.GetDeploymentWindowDbObj(request, model)
+		model.UpdateAuditLog(request.UserId)
+		err = impl.deploymentWindowRepository.Update(model)
+	} else {
+		model = adapter.GetDeploymentWindowDbObj(request, nil)
+		model.AuditLog = sql.NewDefaultAuditLog(request.UserId)
+		err = impl.deploymentWindowRepository.Save(model)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window", "request", request, "err", err)
+		return nil, err
+	}
+	return adapter.GetDeploymentWindowDto(model), nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetByEnvironmentId(envId int) ([]*bean.DeploymentWindowDto, error) {
+	models, err := impl.deploymentWindowRepository.FindActiveByEnvironmentId(envId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching deployment windows", "envId", envId, "err", err)
+		return nil, err
+	}
+	return adapter.GetDeploymentWindowDtos(models), nil
+}
+
+func (impl *DeploymentWindowServiceImpl) Delete(id int, userId int32) error {
+	model, err := impl.deploymentWindowRepository.FindActiveById(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment window", "id", id, "err", err)
+		if util.IsErrNoRows(err) {
+			return util.NewApiError(http.StatusNotFound, "deployment window not found", err.Error())
+		}
+		return err
+	}
+	model.Active = false
+	model.UpdateAuditLog(userId)
+	err = impl.deploymentWindowRepository.Update(model)
+	if err != nil {
+		impl.logger.Errorw("error in deleting deployment window", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetWindowState(envId int, at time.Time) (*bean.WindowState, error) {
+	models, err := impl.deploymentWindowRepository.FindEnabledByEnvironmentIds([]int{envId})
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching deployment windows", "envId", envId, "err", err)
+		return nil, err
+	}
+	state, err := util2.EvaluateDeploymentWindows(envId, adapter.GetDeploymentWindowDtos(models), at)
+	if err != nil {
+		impl.logger.Errorw("error in evaluating deployment windows", "envId", envId, "err", err)
+		return nil, err
+	}
+	return state, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) CheckDeploymentAllowed(envId int, envName string, isUserSuperAdmin bool) (*bean.DeploymentWindowCheckResult, error) {
+	state, err := impl.GetWindowState(envId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	result := &bean.DeploymentWindowCheckResult{WindowState: state}
+	if state.IsAllowed {
+		return result, nil
+	}
+	message := util2.GetDeploymentBlockedMessage(envName, state)
+	result.Message = message
+	if isUserSuperAdmin {
+		impl.logger.Infow("bypassing deployment window for super admin", "envId", envId, "windowIds", state.GetBlockingWindowIds())
+		result.IsBypassed = true
+		return result, nil
+	}
+	return result, util.NewApiError(http.StatusForbidden, message, message).WithCode(constants.DeploymentWindowFail)
+}
+
+func (impl *DeploymentWindowServiceImpl) SaveBypassAudit(result *bean.DeploymentWindowCheckResult, pipelineId, cdWorkflowRunnerId int, userId int32) error {
+	if result == nil || !result.IsBypassed {
+		return nil
+	}
+	audit := &repository.DeploymentWindowBypassAudit{
+		CdWorkflowRunnerId:  cdWorkflowRunnerId,
+		PipelineId:          pipelineId,
+		EnvironmentId:       result.EnvironmentId,
+		DeploymentWindowIds: result.GetBlockingWindowIds(),
+		Message:             result.Message,
+		AuditLog:            sql.NewDefaultAuditLog(userId),
+	}
+	err := impl.deploymentWindowBypassAuditRepository.Save(audit)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment window bypass audit", "pipelineId", pipelineId, "wfrId", cdWorkflowRunnerId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetBypassAudits(envId int, offset, limit int) ([]*bean.BypassAuditDto, error) {
+	models, err := impl.deploymentWindowBypassAuditRepository.FindByEnvironmentId(envId, offset, limit)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching deployment window bypass audits", "envId", envId, "err", err)
+		return nil, err
+	}
+	audits := make([]*bean.BypassAuditDto, 0, len(models))
+	for _, model := range models {
+		audits = append(audits, adapter.GetBypassAuditDto(model))
+	}
+	return audits, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) QueueTrigger(queuedTrigger *bean.QueuedTriggerDto, state *bean.WindowState) error {
+	model := &repository.DeploymentWindowQueuedTrigger{
+		PipelineId:    queuedTrigger.PipelineId,
+		EnvironmentId: queuedTrigger.EnvironmentId,
+		CiArtifactId:  queuedTrigger.CiArtifactId,
+		CdWorkflowId:  queuedTrigger.CdWorkflowId,
+		WorkflowType:  queuedTrigger.WorkflowType,
+		TriggeredBy:   queuedTrigger.TriggeredBy,
+		Status:        bean.QueuedTriggerStatusQueued.String(),
+		AuditLog:      sql.NewDefaultAuditLog(queuedTrigger.TriggeredBy),
+	}
+	if state != nil && state.NextAllowedAt != nil {
+		model.ReleaseAfter = *state.NextAllowedAt
+	}
+	err := impl.deploymentWindowQueuedTriggerRepository.SupersedeAndSave(model, bean.QueuedTriggerSupersededMessage)
+	if err != nil {
+		impl.logger.Errorw("error in queuing trigger blocked by deployment window", "queuedTrigger", queuedTrigger, "err", err)
+		return err
+	}
+	queuedTrigger.Id = model.Id
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) SupersedeQueuedTriggers(pipelineId int, userId int32) error {
+	err := impl.deploymentWindowQueuedTriggerRepository.UpdateStatusByPipelineId(pipelineId, bean.QueuedTriggerStatusQueued.String(),
+		bean.QueuedTriggerStatusSuperseded.String(), bean.QueuedTriggerSupersededMessage, userId)
+	if err != nil {
+		impl.logger.Errorw("error in superseding queued triggers", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetQueuedTriggers(envId int) ([]*bean.QueuedTriggerDto, error) {
+	models, err := impl.deploymentWindowQueuedTriggerRepository.FindByEnvironmentId(envId, bean.QueuedTriggerStatusQueued.String())
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching queued triggers", "envId", envId, "err", err)
+		return nil, err
+	}
+	queuedTriggers := make([]*bean.QueuedTriggerDto, 0, len(models))
+	for _, model := range models {
+		queuedTriggers = append(queuedTriggers, adapter.GetQueuedTriggerDto(model))
+	}
+	return queuedTriggers, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) GetReleasableQueuedTriggers(limit int) ([]*bean.QueuedTriggerDto, error) {
+	now := time.Now()
+	isAllowedByEnv := make(map[int]bool)
+	releasable := make([]*bean.QueuedTriggerDto, 0, limit)
+	// paging through the due triggers, so that the ones of environments which are still closed do not starve the rest
+	afterId := 0
+	for len(releasable) < limit {
+		models, err := impl.deploymentWindowQueuedTriggerRepository.FindDueByStatus(bean.QueuedTriggerStatusQueued.String(), now, afterId, limit)
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("error in fetching queued triggers", "afterId", afterId, "err", err)
+			return nil, err
+		}
+		for _, model := range models {
+			afterId = model.Id
+			isAllowed, ok := isAllowedByEnv[model.EnvironmentId]
+			if !ok {
+				// windows could have been changed after the trigger was queued, evaluating again
+				state, err := impl.GetWindowState(model.EnvironmentId, now)
+				if err != nil {
+					impl.logger.Errorw("error in getting deployment window state, skipping queued trigger", "queuedTriggerId", model.Id, "err", err)
+					continue
+				}
+				isAllowed = state.IsAllowed
+				isAllowedByEnv[model.EnvironmentId] = isAllowed
+			}
+			if isAllowed && len(releasable) < limit {
+				releasable = append(releasable, adapter.GetQueuedTriggerDto(model))
+			}
+		}
+		if len(models) < limit {
+			break
+		}
+	}
+	return releasable, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) ClaimQueuedTrigger(id int) (bool, error) {
+	claimed, err := impl.deploymentWindowQueuedTriggerRepository.ClaimQueuedTrigger(id, bean.QueuedTriggerStatusQueued.String(),
+		bean.QueuedTriggerStatusReleased.String(), time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in claiming queued trigger", "id", id, "err", err)
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (impl *DeploymentWindowServiceImpl) MarkQueuedTriggerFailed(id int, message string) error {
+	err := impl.deploymentWindowQueuedTriggerRepository.UpdateStatus(id, bean.QueuedTriggerStatusFailed.String(), message)
+	if err != nil {
+		impl.logger.Errorw("error in marking queued trigger failed", "id", id, "message", message, "err", err)
+		return err
+	}
+	return nil
+}
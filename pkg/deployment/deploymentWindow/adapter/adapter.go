@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/repository"
+	"github.com/devtron-labs/devtron/util"
+)
+
+func GetDeploymentWindowDbObj(dto *bean.DeploymentWindowDto, model *repository.DeploymentWindow) *repository.DeploymentWindow {
+	if model == nil {
+		model = &repository.DeploymentWindow{}
+	}
+	model.Name = dto.Name
+	model.Description = dto.Description
+	model.EnvironmentId = dto.EnvironmentId
+	model.WindowType = string(dto.WindowType)
+	model.ScheduleType = string(dto.ScheduleType)
+	model.Timezone = dto.Timezone
+	model.Enabled = dto.Enabled
+	model.Active = true
+	model.CronExpression = ""
+	model.DurationMinutes = 0
+	model.StartTime = time.Time{}
+	model.EndTime = time.Time{}
+	if dto.ScheduleType == bean.ScheduleTypeRecurring {
+		model.CronExpression = dto.CronExpression
+		model.DurationMinutes = dto.DurationMinutes
+	} else {
+		model.StartTime = *dto.StartTime
+		model.EndTime = *dto.EndTime
+	}
+	return model
+}
+
+func GetDeploymentWindowDto(model *repository.DeploymentWindow) *bean.DeploymentWindowDto {
+	dto := &bean.DeploymentWindowDto{
+		Id:              model.Id,
+		Name:            model.Name,
+		Description:     model.Description,
+		EnvironmentId:   model.EnvironmentId,
+		WindowType:      bean.WindowType(model.WindowType),
+		ScheduleType:    bean.ScheduleType(model.ScheduleType),
+		CronExpression:  model.CronExpression,
+		DurationMinutes: model.DurationMinutes,
+		Timezone:        model.Timezone,
+		Enabled:         model.Enabled,
+		StartTime:       util.GetTimePtr(model.StartTime),
+		EndTime:         util.GetTimePtr(model.EndTime),
+	}
+	return dto
+}
+
+func GetDeploymentWindowDtos(models []*repository.DeploymentWindow) []*bean.DeploymentWindowDto {
+	dtos := make([]*bean.DeploymentWindowDto, 0, len(models))
+	for _, model := range models {
+		dtos = append(dtos, GetDeploymentWindowDto(model))
+	}
+	return dtos
+}
+
+func GetBypassAuditDto(model *repository.DeploymentWindowBypassAudit) *bean.BypassAuditDto {
+	return &bean.BypassAuditDto{
+		Id:                  model.Id,
+		CdWorkflowRunnerId:  model.CdWorkflowRunnerId,
+		PipelineId:          model.PipelineId,
+		EnvironmentId:       model.EnvironmentId,
+		DeploymentWindowIds: model.DeploymentWindowIds,
+		Message:             model.Message,
+		TriggeredBy:         model.CreatedBy,
+		TriggeredOn:         model.CreatedOn,
+	}
+}
+
+func GetQueuedTriggerDto(model *repository.DeploymentWindowQueuedTrigger) *bean.QueuedTriggerDto {
+	dto := &bean.QueuedTriggerDto{
+		Id:            model.Id,
+		PipelineId:    model.PipelineId,
+		EnvironmentId: model.EnvironmentId,
+		CiArtifactId:  model.CiArtifactId,
+		CdWorkflowId:  model.CdWorkflowId,
+		WorkflowType:  model.WorkflowType,
+		TriggeredBy:   model.TriggeredBy,
+		Status:        bean.QueuedTriggerStatus(model.Status),
+		StatusMessage: model.StatusMessage,
+		QueuedOn:      model.CreatedOn,
+		ReleaseAfter:  util.GetTimePtr(model.ReleaseAfter),
+	}
+	return dto
+}
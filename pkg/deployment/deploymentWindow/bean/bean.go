@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type WindowType string
+
+const (
+	// WindowTypeMaintenance deployments are allowed only while one of the maintenance windows of the environment is open
+	WindowTypeMaintenance WindowType = "MAINTENANCE"
+	// WindowTypeBlackout deployments are not allowed while a blackout (change freeze) is in effect
+	WindowTypeBlackout WindowType = "BLACKOUT"
+)
+
+type ScheduleType string
+
+const (
+	ScheduleTypeOneOff    ScheduleType = "ONE_OFF"
+	ScheduleTypeRecurring ScheduleType = "RECURRING"
+)
+
+type QueuedTriggerStatus string
+
+const (
+	QueuedTriggerStatusQueued     QueuedTriggerStatus = "QUEUED"
+	QueuedTriggerStatusReleased   QueuedTriggerStatus = "RELEASED"
+	QueuedTriggerStatusSuperseded QueuedTriggerStatus = "SUPERSEDED"
+	QueuedTriggerStatusFailed     QueuedTriggerStatus = "FAILED"
+)
+
+func (status QueuedTriggerStatus) String() string {
+	return string(status)
+}
+
+const DefaultTimezone = "UTC"
+
+// QueuedTriggerLockKeyFormat is formatted with the pipeline id, used as the advisory lock key while queuing a trigger
+const QueuedTriggerLockKeyFormat = "deployment-window-queued-trigger-%d"
+
+// QueuedTriggerSupersededMessage is the status message of the queued triggers replaced by a newer trigger
+const QueuedTriggerSupersededMessage = "superseded by a newer trigger"
+
+type DeploymentWindowDto struct {
+	Id              int          `json:"id"`
+	Name            string       `json:"name" validate:"required,max=250"`
+	Description     string       `json:"description"`
+	EnvironmentId   int          `json:"environmentId" validate:"required,number,gt=0"`
+	WindowType      WindowType   `json:"windowType" validate:"required,oneof=MAINTENANCE BLACKOUT"`
+	ScheduleType    ScheduleType `json:"scheduleType" validate:"required,oneof=ONE_OFF RECURRING"`
+	CronExpression  string       `json:"cronExpression,omitempty"`
+	DurationMinutes int          `json:"durationMinutes,omitempty"`
+	StartTime       *time.Time   `json:"startTime,omitempty"`
+	EndTime         *time.Time   `json:"endTime,omitempty"`
+	Timezone        string       `json:"timezone"`
+	Enabled         bool         `json:"enabled"`
+	UserId          int32        `json:"-"`
+}
+
+// WindowState is the evaluation of all the deployment windows of an environment at a point in time
+type WindowState struct {
+	EnvironmentId int  `json:"environmentId"`
+	IsAllowed     bool `json:"isAllowed"`
+	// BlockingWindows are the windows because of which deployment is not allowed right now, empty when allowed
+	BlockingWindows []*DeploymentWindowDto `json:"blockingWindows,omitempty"`
+	// NextAllowedAt is the earliest time at which deployment will be allowed, nil when allowed now
+	// or when no allowed time could be found in the lookahead period
+	NextAllowedAt *time.Time `json:"nextAllowedAt,omitempty"`
+	// AllowedUntil is the time till which deployment remains allowed, nil when not allowed or when no window closes it
+	AllowedUntil *time.Time `json:"allowedUntil,omitempty"`
+	EvaluatedAt  time.Time  `json:"evaluatedAt"`
+}
+
+func (state *WindowState) GetBlockingWindowIds() []int {
+	ids := make([]int, 0, len(state.BlockingWindows))
+	for _, window := range state.BlockingWindows {
+		ids = append(ids, window.Id)
+	}
+	return ids
+}
+
+type DeploymentWindowCheckResult struct {
+	*WindowState
+	// IsBypassed is set when deployment was not allowed but the triggering user is allowed to bypass the windows
+	IsBypassed bool
+	// Message is the reason for which deployment is not allowed, empty when allowed
+	Message string
+}
+
+type BypassAuditDto struct {
+	Id                  int       `json:"id"`
+	CdWorkflowRunnerId  int       `json:"cdWorkflowRunnerId"`
+	PipelineId          int       `json:"pipelineId"`
+	EnvironmentId       int       `json:"environmentId"`
+	DeploymentWindowIds []int     `json:"deploymentWindowIds"`
+	Message             string    `json:"message"`
+	TriggeredBy         int32     `json:"triggeredBy"`
+	TriggeredOn         time.Time `json:"triggeredOn"`
+}
+
+type QueuedTriggerDto struct {
+	Id            int                 `json:"id"`
+	PipelineId    int                 `json:"pipelineId"`
+	EnvironmentId int                 `json:"environmentId"`
+	CiArtifactId  int                 `json:"ciArtifactId"`
+	CdWorkflowId  int                 `json:"cdWorkflowId,omitempty"`
+	WorkflowType  string              `json:"workflowType"`
+	TriggeredBy   int32               `json:"triggeredBy"`
+	Status        QueuedTriggerStatus `json:"status"`
+	StatusMessage string              `json:"statusMessage,omitempty"`
+	ReleaseAfter  *time.Time          `json:"releaseAfter,omitempty"`
+	QueuedOn      time.Time           `json:"queuedOn"`
+}
@@ -0,0 +1,358 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DeploymentWindowService is an autogenerated mock type for the DeploymentWindowService type
+type DeploymentWindowService struct {
+	mock.Mock
+}
+
+// CheckDeploymentAllowed provides a mock function with given fields: envId, envName, isUserSuperAdmin
+func (_m *DeploymentWindowService) CheckDeploymentAllowed(envId int, envName string, isUserSuperAdmin bool) (*bean.DeploymentWindowCheckResult, error) {
+	ret := _m.Called(envId, envName, isUserSuperAdmin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDeploymentAllowed")
+	}
+
+	var r0 *bean.DeploymentWindowCheckResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, bool) (*bean.DeploymentWindowCheckResult, error)); ok {
+		return rf(envId, envName, isUserSuperAdmin)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, bool) *bean.DeploymentWindowCheckResult); ok {
+		r0 = rf(envId, envName, isUserSuperAdmin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentWindowCheckResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
+		r1 = rf(envId, envName, isUserSuperAdmin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimQueuedTrigger provides a mock function with given fields: id
+func (_m *DeploymentWindowService) ClaimQueuedTrigger(id int) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimQueuedTrigger")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrUpdate provides a mock function with given fields: request
+func (_m *DeploymentWindowService) CreateOrUpdate(request *bean.DeploymentWindowDto) (*bean.DeploymentWindowDto, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdate")
+	}
+
+	var r0 *bean.DeploymentWindowDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bean.DeploymentWindowDto) (*bean.DeploymentWindowDto, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*bean.DeploymentWindowDto) *bean.DeploymentWindowDto); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.DeploymentWindowDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bean.DeploymentWindowDto) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: id, userId
+func (_m *DeploymentWindowService) Delete(id int, userId int32) error {
+	ret := _m.Called(id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int32) error); ok {
+		r0 = rf(id, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByEnvironmentId provides a mock function with given fields: envId
+func (_m *DeploymentWindowService) GetByEnvironmentId(envId int) ([]*bean.DeploymentWindowDto, error) {
+	ret := _m.Called(envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEnvironmentId")
+	}
+
+	var r0 []*bean.DeploymentWindowDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*bean.DeploymentWindowDto, error)); ok {
+		return rf(envId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*bean.DeploymentWindowDto); ok {
+		r0 = rf(envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.DeploymentWindowDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBypassAudits provides a mock function with given fields: envId, offset, limit
+func (_m *DeploymentWindowService) GetBypassAudits(envId int, offset int, limit int) ([]*bean.BypassAuditDto, error) {
+	ret := _m.Called(envId, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBypassAudits")
+	}
+
+	var r0 []*bean.BypassAuditDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, int) ([]*bean.BypassAuditDto, error)); ok {
+		return rf(envId, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int) []*bean.BypassAuditDto); ok {
+		r0 = rf(envId, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.BypassAuditDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = rf(envId, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQueuedTriggers provides a mock function with given fields: envId
+func (_m *DeploymentWindowService) GetQueuedTriggers(envId int) ([]*bean.QueuedTriggerDto, error) {
+	ret := _m.Called(envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueuedTriggers")
+	}
+
+	var r0 []*bean.QueuedTriggerDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*bean.QueuedTriggerDto, error)); ok {
+		return rf(envId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*bean.QueuedTriggerDto); ok {
+		r0 = rf(envId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.QueuedTriggerDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(envId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReleasableQueuedTriggers provides a mock function with given fields: limit
+func (_m *DeploymentWindowService) GetReleasableQueuedTriggers(limit int) ([]*bean.QueuedTriggerDto, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReleasableQueuedTriggers")
+	}
+
+	var r0 []*bean.QueuedTriggerDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*bean.QueuedTriggerDto, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*bean.QueuedTriggerDto); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bean.QueuedTriggerDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWindowState provides a mock function with given fields: envId, at
+func (_m *DeploymentWindowService) GetWindowState(envId int, at time.Time) (*bean.WindowState, error) {
+	ret := _m.Called(envId, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWindowState")
+	}
+
+	var r0 *bean.WindowState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Time) (*bean.WindowState, error)); ok {
+		return rf(envId, at)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Time) *bean.WindowState); ok {
+		r0 = rf(envId, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.WindowState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Time) error); ok {
+		r1 = rf(envId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkQueuedTriggerFailed provides a mock function with given fields: id, message
+func (_m *DeploymentWindowService) MarkQueuedTriggerFailed(id int, message string) error {
+	ret := _m.Called(id, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkQueuedTriggerFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(id, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QueueTrigger provides a mock function with given fields: queuedTrigger, state
+func (_m *DeploymentWindowService) QueueTrigger(queuedTrigger *bean.QueuedTriggerDto, state *bean.WindowState) error {
+	ret := _m.Called(queuedTrigger, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueueTrigger")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*bean.QueuedTriggerDto, *bean.WindowState) error); ok {
+		r0 = rf(queuedTrigger, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveBypassAudit provides a mock function with given fields: result, pipelineId, cdWorkflowRunnerId, userId
+func (_m *DeploymentWindowService) SaveBypassAudit(result *bean.DeploymentWindowCheckResult, pipelineId int, cdWorkflowRunnerId int, userId int32) error {
+	ret := _m.Called(result, pipelineId, cdWorkflowRunnerId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBypassAudit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*bean.DeploymentWindowCheckResult, int, int, int32) error); ok {
+		r0 = rf(result, pipelineId, cdWorkflowRunnerId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SupersedeQueuedTriggers provides a mock function with given fields: pipelineId, userId
+func (_m *DeploymentWindowService) SupersedeQueuedTriggers(pipelineId int, userId int32) error {
+	ret := _m.Called(pipelineId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupersedeQueuedTriggers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int32) error); ok {
+		r0 = rf(pipelineId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDeploymentWindowService creates a new instance of DeploymentWindowService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeploymentWindowService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeploymentWindowService {
+	mock := &DeploymentWindowService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentWindowBypassAudit struct {
+	tableName           struct{} `sql:"deployment_window_bypass_audit" pg:",discard_unknown_columns"`
+	Id                  int      `sql:"id,pk"`
+	CdWorkflowRunnerId  int      `sql:"cd_workflow_runner_id,notnull"`
+	PipelineId          int      `sql:"pipeline_id,notnull"`
+	EnvironmentId       int      `sql:"environment_id,notnull"`
+	DeploymentWindowIds []int    `sql:"deployment_window_ids"`
+	Message             string   `sql:"message"`
+	sql.AuditLog
+}
+
+type DeploymentWindowBypassAuditRepository interface {
+	Save(audit *DeploymentWindowBypassAudit) error
+	FindByEnvironmentId(envId int, offset, limit int) ([]*DeploymentWindowBypassAudit, error)
+}
+
+type DeploymentWindowBypassAuditRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDeploymentWindowBypassAuditRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DeploymentWindowBypassAuditRepositoryImpl {
+	return &DeploymentWindowBypassAuditRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DeploymentWindowBypassAuditRepositoryImpl) Save(audit *DeploymentWindowBypassAudit) error {
+	return impl.dbConnection.Insert(audit)
+}
+
+func (impl *DeploymentWindowBypassAuditRepositoryImpl) FindByEnvironmentId(envId int, offset, limit int) ([]*DeploymentWindowBypassAudit, error) {
+	var audits []*DeploymentWindowBypassAudit
+	err := impl.dbConnection.Model(&audits).
+		Where("environment_id = ?", envId).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Select()
+	return audits, err
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentWindowQueuedTrigger struct {
+	tableName     struct{}  `sql:"deployment_window_queued_trigger" pg:",discard_unknown_columns"`
+	Id            int       `sql:"id,pk"`
+	PipelineId    int       `sql:"pipeline_id,notnull"`
+	EnvironmentId int       `sql:"environment_id,notnull"`
+	CiArtifactId  int       `sql:"ci_artifact_id,notnull"`
+	CdWorkflowId  int       `sql:"cd_workflow_id"`
+	WorkflowType  string    `sql:"workflow_type,notnull"`
+	TriggeredBy   int32     `sql:"triggered_by,notnull"`
+	Status        string    `sql:"status,notnull"`
+	StatusMessage string    `sql:"status_message"`
+	ReleaseAfter  time.Time `sql:"release_after"`
+	ReleasedOn    time.Time `sql:"released_on"`
+	sql.AuditLog
+}
+
+type DeploymentWindowQueuedTriggerRepository interface {
+	Update(queuedTrigger *DeploymentWindowQueuedTrigger) error
+	// SupersedeAndSave supersedes the queued triggers of the pipeline and saves the new one in a single transaction,
+	// serialized per pipeline so that concurrent triggers leave exactly one queued trigger
+	SupersedeAndSave(queuedTrigger *DeploymentWindowQueuedTrigger, supersededMessage string) error
+	// FindDueByStatus returns, in order of id, the triggers after afterId in the status whose release time has passed
+	FindDueByStatus(status string, now time.Time, afterId int, limit int) ([]*DeploymentWindowQueuedTrigger, error)
+	FindByEnvironmentId(envId int, status string) ([]*DeploymentWindowQueuedTrigger, error)
+	// UpdateStatusByPipelineId moves all the queued triggers of a pipeline in fromStatus to toStatus
+	UpdateStatusByPipelineId(pipelineId int, fromStatus, toStatus, message string, userId int32) error
+	// ClaimQueuedTrigger changes the status only if it still holds fromStatus,
+	// so that a queued trigger is released by exactly one orchestrator replica
+	ClaimQueuedTrigger(id int, fromStatus, toStatus string, releasedOn time.Time) (bool, error)
+	UpdateStatus(id int, status, message string) error
+}
+
+type DeploymentWindowQueuedTriggerRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDeploymentWindowQueuedTriggerRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DeploymentWindowQueuedTriggerRepositoryImpl {
+	return &DeploymentWindowQueuedTriggerRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) Update(queuedTrigger *DeploymentWindowQueuedTrigger) error {
+	return impl.dbConnection.Update(queuedTrigger)
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) SupersedeAndSave(queuedTrigger *DeploymentWindowQueuedTrigger, supersededMessage string) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf(bean.QueuedTriggerLockKeyFormat, queuedTrigger.PipelineId))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Model((*DeploymentWindowQueuedTrigger)(nil)).
+			Set("status = ?", bean.QueuedTriggerStatusSuperseded.String()).
+			Set("status_message = ?", supersededMessage).
+			Set("updated_on = ?", queuedTrigger.UpdatedOn).
+			Set("updated_by = ?", queuedTrigger.UpdatedBy).
+			Where("pipeline_id = ?", queuedTrigger.PipelineId).
+			Where("status = ?", bean.QueuedTriggerStatusQueued.String()).
+			Update()
+		if err != nil {
+			return err
+		}
+		return tx.Insert(queuedTrigger)
+	})
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) FindDueByStatus(status string, now time.Time, afterId int, limit int) ([]*DeploymentWindowQueuedTrigger, error) {
+	var queuedTriggers []*DeploymentWindowQueuedTrigger
+	err := impl.dbConnection.Model(&queuedTriggers).
+		Where("status = ?", status).
+		Where("release_after IS NULL OR release_after <= ?", now).
+		Where("id > ?", afterId).
+		Order("id ASC").
+		Limit(limit).
+		Select()
+	return queuedTriggers, err
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) FindByEnvironmentId(envId int, status string) ([]*DeploymentWindowQueuedTrigger, error) {
+	var queuedTriggers []*DeploymentWindowQueuedTrigger
+	err := impl.dbConnection.Model(&queuedTriggers).
+		Where("environment_id = ?", envId).
+		Where("status = ?", status).
+		Order("id ASC").
+		Select()
+	return queuedTriggers, err
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) UpdateStatusByPipelineId(pipelineId int, fromStatus, toStatus, message string, userId int32) error {
+	_, err := impl.dbConnection.Model((*DeploymentWindowQueuedTrigger)(nil)).
+		Set("status = ?", toStatus).
+		Set("status_message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("status = ?", fromStatus).
+		Update()
+	return err
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) ClaimQueuedTrigger(id int, fromStatus, toStatus string, releasedOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*DeploymentWindowQueuedTrigger)(nil)).
+		Set("status = ?", toStatus).
+		Set("released_on = ?", releasedOn).
+		Set("updated_on = ?", releasedOn).
+		Where("id = ?", id).
+		Where("status = ?", fromStatus).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *DeploymentWindowQueuedTriggerRepositoryImpl) UpdateStatus(id int, status, message string) error {
+	_, err := impl.dbConnection.Model((*DeploymentWindowQueuedTrigger)(nil)).
+		Set("status = ?", status).
+		Set("status_message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Where("id = ?", id).
+		Update()
+	return err
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DeploymentWindow struct {
+	tableName       struct{}  `sql:"deployment_window" pg:",discard_unknown_columns"`
+	Id              int       `sql:"id,pk"`
+	Name            string    `sql:"name,notnull"`
+	Description     string    `sql:"description"`
+	EnvironmentId   int       `sql:"environment_id,notnull"`
+	WindowType      string    `sql:"window_type,notnull"`
+	ScheduleType    string    `sql:"schedule_type,notnull"`
+	CronExpression  string    `sql:"cron_expression"`
+	DurationMinutes int       `sql:"duration_minutes"`
+	StartTime       time.Time `sql:"start_time"`
+	EndTime         time.Time `sql:"end_time"`
+	Timezone        string    `sql:"timezone,notnull"`
+	Enabled         bool      `sql:"enabled,notnull"`
+	Active          bool      `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type DeploymentWindowRepository interface {
+	Save(window *DeploymentWindow) error
+	Update(window *DeploymentWindow) error
+	FindActiveById(id int) (*DeploymentWindow, error)
+	FindActiveByEnvironmentId(envId int) ([]*DeploymentWindow, error)
+	// FindEnabledByEnvironmentIds returns the windows which are enforced for the given environments
+	FindEnabledByEnvironmentIds(envIds []int) ([]*DeploymentWindow, error)
+}
+
+type DeploymentWindowRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDeploymentWindowRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DeploymentWindowRepositoryImpl {
+	return &DeploymentWindowRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DeploymentWindowRepositoryImpl) Save(window *DeploymentWindow) error {
+	return impl.dbConnection.Insert(window)
+}
+
+func (impl *DeploymentWindowRepositoryImpl) Update(window *DeploymentWindow) error {
+	return impl.dbConnection.Update(window)
+}
+
+func (impl *DeploymentWindowRepositoryImpl) FindActiveById(id int) (*DeploymentWindow, error) {
+	window := &DeploymentWindow{}
+	err := impl.dbConnection.Model(window).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return window, err
+}
+
+func (impl *DeploymentWindowRepositoryImpl) FindActiveByEnvironmentId(envId int) ([]*DeploymentWindow, error) {
+	var windows []*DeploymentWindow
+	err := impl.dbConnection.Model(&windows).
+		Where("environment_id = ?", envId).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return windows, err
+}
+
+func (impl *DeploymentWindowRepositoryImpl) FindEnabledByEnvironmentIds(envIds []int) ([]*DeploymentWindow, error) {
+	var windows []*DeploymentWindow
+	if len(envIds) == 0 {
+		return windows, nil
+	}
+	err := impl.dbConnection.Model(&windows).
+		Where("environment_id in (?)", pg.In(envIds)).
+		Where("active = ?", true).
+		Where("enabled = ?", true).
+		Order("id ASC").
+		Select()
+	return windows, err
+}
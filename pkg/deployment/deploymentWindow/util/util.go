@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// lookAheadPeriod is the period in which the next allowed (or blocked) time is searched
+	lookAheadPeriod = 366 * 24 * time.Hour
+	// maxTransitions caps the number of window boundaries visited while searching
+	maxTransitions = 5000
+)
+
+// ValidateDeploymentWindow checks the schedule of a window, it returns an error with a user readable message
+func ValidateDeploymentWindow(window *bean.DeploymentWindowDto) error {
+	if len(window.Timezone) == 0 {
+		window.Timezone = bean.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(window.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q", window.Timezone)
+	}
+	switch window.ScheduleType {
+	case bean.ScheduleTypeOneOff:
+		if window.StartTime == nil || window.EndTime == nil {
+			return fmt.Errorf("start time and end time are required for a one-off window")
+		}
+		if !window.EndTime.After(*window.StartTime) {
+			return fmt.Errorf("end time must be after start time")
+		}
+	case bean.ScheduleTypeRecurring:
+		if _, err := parseCronExpression(window.CronExpression); err != nil {
+			return err
+		}
+		if window.DurationMinutes <= 0 {
+			return fmt.Errorf("duration in minutes must be greater than 0 for a recurring window")
+		}
+	default:
+		return fmt.Errorf("invalid schedule type %q", window.ScheduleType)
+	}
+	if window.WindowType != bean.WindowTypeMaintenance && window.WindowType != bean.WindowTypeBlackout {
+		return fmt.Errorf("invalid window type %q", window.WindowType)
+	}
+	return nil
+}
+
+func parseCronExpression(cronExpression string) (cron.Schedule, error) {
+	cronExpression = strings.TrimSpace(cronExpression)
+	if len(cronExpression) == 0 {
+		return nil, fmt.Errorf("cron expression is required for a recurring window")
+	}
+	if strings.HasPrefix(cronExpression, "TZ=") || strings.HasPrefix(cronExpression, "CRON_TZ=") {
+		return nil, fmt.Errorf("timezone must not be part of the cron expression, use the timezone field instead")
+	}
+	schedule, err := cron.ParseStandard(cronExpression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", cronExpression, err)
+	}
+	return schedule, nil
+}
+
+// windowSchedule is a parsed window, every occurrence of it is the half open interval [start, start+duration)
+type windowSchedule struct {
+	window   *bean.DeploymentWindowDto
+	location *time.Location
+	cron     cron.Schedule
+	duration time.Duration
+}
+
+func newWindowSchedule(window *bean.DeploymentWindowDto) (*windowSchedule, error) {
+	if err := ValidateDeploymentWindow(window); err != nil {
+		return nil, fmt.Errorf("deployment window %d: %w", window.Id, err)
+	}
+	schedule := &windowSchedule{window: window}
+	schedule.location, _ = time.LoadLocation(window.Timezone)
+	if window.ScheduleType == bean.ScheduleTypeRecurring {
+		schedule.cron, _ = parseCronExpression(window.CronExpression)
+		schedule.duration = time.Duration(window.DurationMinutes) * time.Minute
+	}
+	return schedule, nil
+}
+
+func (s *windowSchedule) isRecurring() bool {
+	return s.cron != nil
+}
+
+// firstOccurrenceEndingAfter returns the earliest occurrence which has not ended at t
+func (s *windowSchedule) firstOccurrenceEndingAfter(t time.Time) (start, end time.Time, found bool) {
+	if s.isRecurring() {
+		// the occurrence starting at exactly t-duration ends at t, cron Next skips it as it is exclusive
+		start = s.cron.Next(t.Add(-s.duration).In(s.location))
+		if start.IsZero() {
+			return start, end, false
+		}
+		return start, start.Add(s.duration), true
+	}
+	if s.window.EndTime.After(t) {
+		return *s.window.StartTime, *s.window.EndTime, true
+	}
+	return start, end, false
+}
+
+// firstOccurrenceStartingAfter returns the earliest occurrence starting strictly after t
+func (s *windowSchedule) firstOccurrenceStartingAfter(t time.Time) (start time.Time, found bool) {
+	if s.isRecurring() {
+		start = s.cron.Next(t.In(s.location))
+		return start, !start.IsZero()
+	}
+	if s.window.StartTime.After(t) {
+		return *s.window.StartTime, true
+	}
+	return start, false
+}
+
+func (s *windowSchedule) isOpenAt(t time.Time) bool {
+	start, _, found := s.firstOccurrenceEndingAfter(t)
+	return found && !start.After(t)
+}
+
+// isExpiredAt is true for one-off windows which have ended, they no longer affect deployments
+func (s *windowSchedule) isExpiredAt(t time.Time) bool {
+	_, _, found := s.firstOccurrenceEndingAfter(t)
+	return !found
+}
+
+type windowSchedules []*windowSchedule
+
+// blockingWindowsAt returns the windows because of which deployment is not allowed at t, empty if allowed.
+// Deployment is blocked inside any blackout, and outside all the maintenance windows if the environment has any.
+func (schedules windowSchedules) blockingWindowsAt(t time.Time) []*bean.DeploymentWindowDto {
+	var blackouts, maintenanceWindows []*bean.DeploymentWindowDto
+	isInsideMaintenance := false
+	for _, s := range schedules {
+		switch s.window.WindowType {
+		case bean.WindowTypeBlackout:
+			if s.isOpenAt(t) {
+				blackouts = append(blackouts, s.window)
+			}
+		case bean.WindowTypeMaintenance:
+			if s.isExpiredAt(t) {
+				continue
+			}
+			maintenanceWindows = append(maintenanceWindows, s.window)
+			if s.isOpenAt(t) {
+				isInsideMaintenance = true
+			}
+		}
+	}
+	if len(blackouts) > 0 {
+		return blackouts
+	}
+	if len(maintenanceWindows) > 0 && !isInsideMaintenance {
+		return maintenanceWindows
+	}
+	return nil
+}
+
+// nextOpeningAfter is the earliest time after t at which deployment may become allowed,
+// that is a blackout ending or a maintenance window starting
+func (schedules windowSchedules) nextOpeningAfter(t time.Time) (next time.Time, found bool) {
+	for _, s := range schedules {
+		var candidate time.Time
+		var ok bool
+		switch s.window.WindowType {
+		case bean.WindowTypeBlackout:
+			_, candidate, ok = s.firstOccurrenceEndingAfter(t)
+		case bean.WindowTypeMaintenance:
+			candidate, ok = s.firstOccurrenceStartingAfter(t)
+		}
+		if ok && (!found || candidate.Before(next)) {
+			next, found = candidate, true
+		}
+	}
+	return next, found
+}
+
+// nextClosingAfter is the earliest time after t at which deployment may become blocked,
+// that is a blackout starting or a maintenance window ending
+func (schedules windowSchedules) nextClosingAfter(t time.Time) (next time.Time, found bool) {
+	for _, s := range schedules {
+		var candidate time.Time
+		var ok bool
+		switch s.window.WindowType {
+		case bean.WindowTypeBlackout:
+			candidate, ok = s.firstOccurrenceStartingAfter(t)
+		case bean.WindowTypeMaintenance:
+			_, candidate, ok = s.firstOccurrenceEndingAfter(t)
+		}
+		if ok && (!found || candidate.Before(next)) {
+			next, found = candidate, true
+		}
+	}
+	return next, found
+}
+
+// EvaluateDeploymentWindows computes whether deployment is allowed at the given time for an environment
+// and when it will next be allowed (or blocked). Disabled windows are ignored.
+func EvaluateDeploymentWindows(envId int, windows []*bean.DeploymentWindowDto, at time.Time) (*bean.WindowState, error) {
+	schedules := make(windowSchedules, 0, len(windows))
+	for _, window := range windows {
+		if !window.Enabled {
+			continue
+		}
+		schedule, err := newWindowSchedule(window)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	state := &bean.WindowState{
+		EnvironmentId: envId,
+		EvaluatedAt:   at,
+	}
+	state.BlockingWindows = schedules.blockingWindowsAt(at)
+	state.IsAllowed = len(state.BlockingWindows) == 0
+	lookAheadTill := at.Add(lookAheadPeriod)
+	cursor := at
+	for i := 0; i < maxTransitions; i++ {
+		var next time.Time
+		var found bool
+		if state.IsAllowed {
+			next, found = schedules.nextClosingAfter(cursor)
+		} else {
+			next, found = schedules.nextOpeningAfter(cursor)
+		}
+		if !found || next.After(lookAheadTill) {
+			break
+		}
+		isAllowedAtNext := len(schedules.blockingWindowsAt(next)) == 0
+		if isAllowedAtNext != state.IsAllowed {
+			next = next.UTC()
+			if state.IsAllowed {
+				state.AllowedUntil = &next
+			} else {
+				state.NextAllowedAt = &next
+			}
+			break
+		}
+		cursor = next
+	}
+	return state, nil
+}
+
+// GetDeploymentBlockedMessage builds the user facing message for a deployment blocked by deployment windows
+func GetDeploymentBlockedMessage(envName string, state *bean.WindowState) string {
+	names := make([]string, 0, len(state.BlockingWindows))
+	timezone := bean.DefaultTimezone
+	for _, window := range state.BlockingWindows {
+		names = append(names, fmt.Sprintf("%q", window.Name))
+		timezone = window.Timezone
+	}
+	message := fmt.Sprintf("deployment to environment %s is not allowed right now due to deployment window %s", envName, strings.Join(names, ", "))
+	if state.NextAllowedAt == nil {
+		return message + ", no allowed window found in the next 366 days"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return fmt.Sprintf("%s, next allowed window starts at %s", message, state.NextAllowedAt.In(location).Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+)
+
+func recurringWindow(id int, windowType bean.WindowType, cronExpression string, durationMinutes int, timezone string) *bean.DeploymentWindowDto {
+	return &bean.DeploymentWindowDto{
+		Id:              id,
+		Name:            "window",
+		WindowType:      windowType,
+		ScheduleType:    bean.ScheduleTypeRecurring,
+		CronExpression:  cronExpression,
+		DurationMinutes: durationMinutes,
+		Timezone:        timezone,
+		Enabled:         true,
+	}
+}
+
+func oneOffWindow(id int, windowType bean.WindowType, start, end time.Time) *bean.DeploymentWindowDto {
+	return &bean.DeploymentWindowDto{
+		Id:           id,
+		Name:         "freeze",
+		WindowType:   windowType,
+		ScheduleType: bean.ScheduleTypeOneOff,
+		StartTime:    &start,
+		EndTime:      &end,
+		Timezone:     bean.DefaultTimezone,
+		Enabled:      true,
+	}
+}
+
+func TestEvaluateDeploymentWindows(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	tests := []struct {
+		name              string
+		windows           []*bean.DeploymentWindowDto
+		wantAllowed       bool
+		wantNextAllowedAt time.Time
+		wantAllowedUntil  time.Time
+	}{
+		{
+			name:        "no windows",
+			wantAllowed: true,
+		},
+		{
+			name: "inside daily maintenance window",
+			windows: []*bean.DeploymentWindowDto{
+				recurringWindow(1, bean.WindowTypeMaintenance, "0 10 * * *", 180, bean.DefaultTimezone),
+			},
+			wantAllowed:      true,
+			wantAllowedUntil: time.Date(2024, 5, 15, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "outside maintenance window in another timezone",
+			windows: []*bean.DeploymentWindowDto{
+				// 22:00 IST is 16:30 UTC
+				recurringWindow(1, bean.WindowTypeMaintenance, "0 22 * * *", 60, "Asia/Kolkata"),
+			},
+			wantAllowed:       false,
+			wantNextAllowedAt: time.Date(2024, 5, 15, 22, 0, 0, 0, kolkata).UTC(),
+		},
+		{
+			name: "inside one-off freeze",
+			windows: []*bean.DeploymentWindowDto{
+				oneOffWindow(1, bean.WindowTypeBlackout, now.Add(-time.Hour), now.Add(48*time.Hour)),
+			},
+			wantAllowed:       false,
+			wantNextAllowedAt: now.Add(48 * time.Hour),
+		},
+		{
+			name: "freeze ends outside maintenance window",
+			windows: []*bean.DeploymentWindowDto{
+				oneOffWindow(1, bean.WindowTypeBlackout, now.Add(-time.Hour), now.Add(26*time.Hour)),
+				// weekdays 09:00-11:00
+				recurringWindow(2, bean.WindowTypeMaintenance, "0 9 * * 1-5", 120, bean.DefaultTimezone),
+			},
+			wantAllowed: false,
+			// freeze ends on Thursday 14:00, next maintenance opens on Friday 09:00
+			wantNextAllowedAt: time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekend freeze starts later",
+			windows: []*bean.DeploymentWindowDto{
+				recurringWindow(1, bean.WindowTypeBlackout, "0 18 * * 5", 60*62, bean.DefaultTimezone),
+			},
+			wantAllowed:      true,
+			wantAllowedUntil: time.Date(2024, 5, 17, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "expired maintenance and disabled freeze are ignored",
+			windows: []*bean.DeploymentWindowDto{
+				oneOffWindow(1, bean.WindowTypeMaintenance, now.Add(-48*time.Hour), now.Add(-24*time.Hour)),
+				func() *bean.DeploymentWindowDto {
+					window := oneOffWindow(2, bean.WindowTypeBlackout, now.Add(-time.Hour), now.Add(time.Hour))
+					window.Enabled = false
+					return window
+				}(),
+			},
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := EvaluateDeploymentWindows(1, tt.windows, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if state.IsAllowed != tt.wantAllowed {
+				t.Fatalf("IsAllowed = %v, want %v", state.IsAllowed, tt.wantAllowed)
+			}
+			assertTime(t, "NextAllowedAt", state.NextAllowedAt, tt.wantNextAllowedAt)
+			assertTime(t, "AllowedUntil", state.AllowedUntil, tt.wantAllowedUntil)
+		})
+	}
+}
+
+func TestValidateDeploymentWindow(t *testing.T) {
+	start := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	invalid := []*bean.DeploymentWindowDto{
+		recurringWindow(1, bean.WindowTypeMaintenance, "", 60, bean.DefaultTimezone),
+		recurringWindow(1, bean.WindowTypeMaintenance, "0 9 * * *", 0, bean.DefaultTimezone),
+		recurringWindow(1, bean.WindowTypeMaintenance, "0 9 * * *", 60, "Mars/Olympus"),
+		recurringWindow(1, bean.WindowTypeMaintenance, "CRON_TZ=UTC 0 9 * * *", 60, bean.DefaultTimezone),
+		recurringWindow(1, "OTHER", "0 9 * * *", 60, bean.DefaultTimezone),
+		oneOffWindow(1, bean.WindowTypeBlackout, start, start),
+	}
+	for _, window := range invalid {
+		if err := ValidateDeploymentWindow(window); err == nil {
+			t.Errorf("expected validation error for %+v", window)
+		}
+	}
+	if err := ValidateDeploymentWindow(recurringWindow(1, bean.WindowTypeBlackout, "@daily", 30, "")); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestGetDeploymentBlockedMessage(t *testing.T) {
+	next := time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC)
+	window := recurringWindow(1, bean.WindowTypeMaintenance, "0 9 * * 1-5", 120, "Asia/Kolkata")
+	window.Name = "business hours"
+	message := GetDeploymentBlockedMessage("prod", &bean.WindowState{BlockingWindows: []*bean.DeploymentWindowDto{window}, NextAllowedAt: &next})
+	if !strings.Contains(message, `"business hours"`) || !strings.Contains(message, "next allowed window starts at 2024-05-17 14:30 IST") {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func assertTime(t *testing.T, name string, got *time.Time, want time.Time) {
+	t.Helper()
+	if want.IsZero() {
+		if got != nil {
+			t.Errorf("%s = %v, want nil", name, got)
+		}
+		return
+	}
+	if got == nil || !got.Equal(want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
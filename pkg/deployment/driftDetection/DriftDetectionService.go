@@ -0,0 +1,499 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package driftDetection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/config/configDiff"
+	configDiffBean "github.com/devtron-labs/devtron/pkg/config/configDiff/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/helper"
+	driftRepository "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/repository"
+	k8sService "github.com/devtron-labs/devtron/pkg/k8s"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	util2 "github.com/devtron-labs/devtron/util"
+	util3 "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+// comparableDeploymentStatuses are the deployment statuses whose manifest is expected to be live in the cluster
+var comparableDeploymentStatuses = []string{string(health.HealthStatusHealthy), cdWorkflow.WorkflowSucceeded}
+
+type DriftDetectionService interface {
+	// GetConfig returns nil if drift detection is not configured for the pipeline
+	GetConfig(pipelineId int) (*bean.DriftDetectionConfigDto, error)
+	SaveConfig(config *bean.DriftDetectionConfigDto) (*bean.DriftDetectionConfigDto, error)
+	DeleteConfig(pipelineId int, userId int32) error
+	// GetDriftStatus returns nil if the pipeline was never checked
+	GetDriftStatus(pipelineId int) (*bean.DriftStatusDto, error)
+	// GetDriftStatusesByAppId returns the drift status of the checked cd pipelines of the app
+	GetDriftStatusesByAppId(appId int) ([]*bean.DriftStatusDto, error)
+
+	// CheckDrift compares the last deployment of the pipeline with the live objects and saves the drift status
+	CheckDrift(ctx context.Context, pipelineId int) (*bean.DriftStatusDto, error)
+	// DetectDrifts checks the pipelines with drift detection enabled and returns the drifted
+	// pipelines which are to be reconciled as per their config
+	DetectDrifts() []*bean.ReconcileRequest
+	// UpdateReconcileOutcome records the deployment triggered to reconcile the drift of the pipeline
+	UpdateReconcileOutcome(pipelineId int, reconcileWfrId int, reconcileErr error)
+}
+
+type DriftDetectionServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	configRepository               driftRepository.DriftDetectionConfigRepository
+	statusRepository               driftRepository.DriftStatusRepository
+	pipelineRepository             pipelineConfig.PipelineRepository
+	cdWorkflowRepository           pipelineConfig.CdWorkflowRepository
+	pipelineOverrideRepository     chartConfig.PipelineOverrideRepository
+	deploymentConfigurationService configDiff.DeploymentConfigurationService
+	k8sCommonService               k8sService.K8sCommonService
+	k8sUtil                        *k8s.K8sServiceImpl
+	eventFactory                   client.EventFactory
+	eventClient                    client.EventClient
+}
+
+func NewDriftDetectionServiceImpl(logger *zap.SugaredLogger,
+	configRepository driftRepository.DriftDetectionConfigRepository,
+	statusRepository driftRepository.DriftStatusRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	deploymentConfigurationService configDiff.DeploymentConfigurationService,
+	k8sCommonService k8sService.K8sCommonService,
+	k8sUtil *k8s.K8sServiceImpl,
+	eventFactory client.EventFactory,
+	eventClient client.EventClient) *DriftDetectionServiceImpl {
+	return &DriftDetectionServiceImpl{
+		logger:                         logger,
+		configRepository:               configRepository,
+		statusRepository:               statusRepository,
+		pipelineRepository:             pipelineRepository,
+		cdWorkflowRepository:           cdWorkflowRepository,
+		pipelineOverrideRepository:     pipelineOverrideRepository,
+		deploymentConfigurationService: deploymentConfigurationService,
+		k8sCommonService:               k8sCommonService,
+		k8sUtil:                        k8sUtil,
+		eventFactory:                   eventFactory,
+		eventClient:                    eventClient,
+	}
+}
+
+func (impl *DriftDetectionServiceImpl) GetConfig(pipelineId int) (*bean.DriftDetectionConfigDto, error) {
+	config, err := impl.configRepository.FindByPipelineId(pipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching drift detection config", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetDriftDetectionConfigDto(config), nil
+}
+
+func (impl *DriftDetectionServiceImpl) SaveConfig(configDto *bean.DriftDetectionConfigDto) (*bean.DriftDetectionConfigDto, error) {
+	_, err := impl.getPipeline(configDto.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+	config, err := impl.configRepository.FindByPipelineId(configDto.PipelineId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching drift detection config", "pipelineId", configDto.PipelineId, "err", err)
+		return nil, err
+	}
+	if errors.Is(err, pg.ErrNoRows) {
+		config = adapter.NewDriftDetectionConfig(configDto)
+		err = impl.configRepository.Save(config)
+	} else {
+		config.Enabled = configDto.Enabled
+		config.Notify = configDto.Notify
+		config.AutoReconcile = configDto.AutoReconcile
+		config.UpdateAuditLog(configDto.UserId)
+		err = impl.configRepository.Update(config)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving drift detection config", "pipelineId", configDto.PipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetDriftDetectionConfigDto(config), nil
+}
+
+func (impl *DriftDetectionServiceImpl) DeleteConfig(pipelineId int, userId int32) error {
+	err := impl.configRepository.MarkInactiveByPipelineId(pipelineId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting drift detection config", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *DriftDetectionServiceImpl) GetDriftStatus(pipelineId int) (*bean.DriftStatusDto, error) {
+	pipeline, err := impl.getPipeline(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	status, err := impl.statusRepository.FindByPipelineId(pipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching drift status", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return getDriftStatusDto(status, pipeline), nil
+}
+
+func (impl *DriftDetectionServiceImpl) GetDriftStatusesByAppId(appId int) ([]*bean.DriftStatusDto, error) {
+	pipelines, err := impl.pipelineRepository.FindActiveByAppId(appId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching cd pipelines", "appId", appId, "err", err)
+		return nil, err
+	}
+	pipelineIds := make([]int, 0, len(pipelines))
+	pipelineById := make(map[int]*pipelineConfig.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelineIds = append(pipelineIds, pipeline.Id)
+		pipelineById[pipeline.Id] = pipeline
+	}
+	statuses, err := impl.statusRepository.FindByPipelineIds(pipelineIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching drift statuses", "appId", appId, "err", err)
+		return nil, err
+	}
+	statusDtos := make([]*bean.DriftStatusDto, 0, len(statuses))
+	for _, status := range statuses {
+		statusDtos = append(statusDtos, getDriftStatusDto(status, pipelineById[status.PipelineId]))
+	}
+	return statusDtos, nil
+}
+
+// getPipeline returns not found for a deleted pipeline, as the repository does not return deleted pipelines
+func (impl *DriftDetectionServiceImpl) getPipeline(pipelineId int) (*pipelineConfig.Pipeline, error) {
+	pipeline, err := impl.pipelineRepository.FindById(pipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline is deleted")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+func getDriftStatusDto(status *driftRepository.DriftStatus, pipeline *pipelineConfig.Pipeline) *bean.DriftStatusDto {
+	statusDto := adapter.GetDriftStatusDto(status)
+	statusDto.AppId = pipeline.AppId
+	statusDto.EnvironmentId = pipeline.EnvironmentId
+	statusDto.EnvironmentName = pipeline.Environment.Name
+	return statusDto
+}
+
+func (impl *DriftDetectionServiceImpl) CheckDrift(ctx context.Context, pipelineId int) (*bean.DriftStatusDto, error) {
+	pipeline, err := impl.getPipeline(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	config, err := impl.configRepository.FindByPipelineId(pipelineId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching drift detection config", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	status, _, err := impl.checkPipeline(ctx, pipeline, config)
+	if err != nil {
+		return nil, err
+	}
+	return getDriftStatusDto(status, pipeline), nil
+}
+
+func (impl *DriftDetectionServiceImpl) DetectDrifts() []*bean.ReconcileRequest {
+	configs, err := impl.configRepository.FindAllEnabled()
+	if err != nil {
+		impl.logger.Errorw("error in fetching drift detection configs", "err", err)
+		return nil
+	}
+	reconcileRequests := make([]*bean.ReconcileRequest, 0)
+	for _, config := range configs {
+		now := time.Now()
+		claimed, err := impl.configRepository.ClaimCheck(config.Id, now.Add(-bean.MinCheckInterval), now)
+		if err != nil || !claimed {
+			// checked recently by another replica
+			continue
+		}
+		pipeline, err := impl.pipelineRepository.FindById(config.PipelineId)
+		if errors.Is(err, pg.ErrNoRows) {
+			// pipeline is deleted, it is not checked again
+			err = impl.configRepository.MarkInactiveByPipelineId(config.PipelineId, userBean.SystemUserId)
+			if err != nil {
+				impl.logger.Errorw("error in deleting drift detection config of deleted pipeline", "pipelineId", config.PipelineId, "err", err)
+			}
+			continue
+		} else if err != nil {
+			impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", config.PipelineId, "err", err)
+			continue
+		}
+		status, reconcileRequest, err := impl.checkPipeline(context.Background(), pipeline, config)
+		if err != nil {
+			// pipelines which are not deployed or are hibernated are not compared
+			impl.logger.Debugw("drift not checked", "pipelineId", config.PipelineId, "reason", err)
+			continue
+		}
+		if status.Status == bean.DriftStatusDrifted.String() {
+			impl.logger.Infow("drift detected", "pipelineId", config.PipelineId, "wfrId", status.CdWorkflowRunnerId, "driftedResources", status.DriftedResources)
+		}
+		if reconcileRequest != nil {
+			reconcileRequests = append(reconcileRequests, reconcileRequest)
+		}
+	}
+	return reconcileRequests
+}
+
+// checkPipeline saves the drift of the last deployment of the pipeline, a reconcile request is
+// returned if the pipeline drifted and is to be reconciled as per the config
+func (impl *DriftDetectionServiceImpl) checkPipeline(ctx context.Context, pipeline *pipelineConfig.Pipeline, config *driftRepository.DriftDetectionConfig) (*driftRepository.DriftStatus, *bean.ReconcileRequest, error) {
+	wfr, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(pipeline.Id, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching latest deployment", "pipelineId", pipeline.Id, "err", err)
+		return nil, nil, err
+	}
+	if errors.Is(err, pg.ErrNoRows) || !slices.Contains(comparableDeploymentStatuses, wfr.Status) {
+		return nil, nil, util.NewApiError(http.StatusConflict, bean.NotDeployedMessage, bean.NotDeployedMessage)
+	}
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindLatestByCdWorkflowId(wfr.CdWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override", "pipelineId", pipeline.Id, "cdWorkflowId", wfr.CdWorkflowId, "err", err)
+		return nil, nil, err
+	}
+	if pipelineOverride.DeploymentType == models.DEPLOYMENTTYPE_STOP {
+		return nil, nil, util.NewApiError(http.StatusConflict, bean.HibernatedMessage, bean.HibernatedMessage)
+	}
+	previousStatus, err := impl.statusRepository.FindByPipelineId(pipeline.Id)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching drift status", "pipelineId", pipeline.Id, "err", err)
+		return nil, nil, err
+	}
+	now := time.Now()
+	status := previousStatus
+	if status == nil || status.Id == 0 {
+		status = &driftRepository.DriftStatus{
+			PipelineId: pipeline.Id,
+			AuditLog:   sql.NewDefaultAuditLog(userBean.SystemUserId),
+		}
+	}
+	wasDrifted := status.Id > 0 && status.Status == bean.DriftStatusDrifted.String() && status.CdWorkflowRunnerId == wfr.Id
+
+	diffs, err := impl.diffLiveObjects(ctx, pipeline, pipelineOverride)
+	if err != nil {
+		status.Status = bean.DriftStatusError.String()
+		status.Message = err.Error()
+		status.DriftedResources = 0
+		status.Diff = ""
+	} else if len(diffs) > 0 {
+		diffJson, err := json.Marshal(diffs)
+		if err != nil {
+			return nil, nil, err
+		}
+		status.Status = bean.DriftStatusDrifted.String()
+		status.Message = ""
+		status.DriftedResources = len(diffs)
+		status.Diff = string(diffJson)
+		if !wasDrifted {
+			status.DriftDetectedOn = &now
+		}
+	} else {
+		status.Status = bean.DriftStatusInSync.String()
+		status.Message = ""
+		status.DriftedResources = 0
+		status.Diff = ""
+		status.DriftDetectedOn = nil
+	}
+	status.CdWorkflowRunnerId = wfr.Id
+	status.LastCheckedOn = now
+
+	var reconcileRequest *bean.ReconcileRequest
+	isDrifted := status.Status == bean.DriftStatusDrifted.String()
+	if isDrifted && config != nil && config.Id > 0 && config.Enabled && config.AutoReconcile {
+		if status.ReconcileCdWorkflowRunnerId == wfr.Id {
+			// the drift is back after the reconcile deployment, it is left to the user
+			status.Message = bean.ReconcileSkippedMessage
+		} else if !wasDrifted {
+			reconcileRequest = &bean.ReconcileRequest{
+				PipelineId:         pipeline.Id,
+				AppId:              pipeline.AppId,
+				CiArtifactId:       wfr.CdWorkflow.CiArtifactId,
+				CdWorkflowRunnerId: wfr.Id,
+			}
+		}
+	}
+	if status.Id == 0 {
+		err = impl.statusRepository.Save(status)
+	} else {
+		status.UpdateAuditLog(userBean.SystemUserId)
+		err = impl.statusRepository.Update(status)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving drift status", "pipelineId", pipeline.Id, "err", err)
+		return nil, nil, err
+	}
+	if isDrifted && !wasDrifted && config != nil && config.Id > 0 && config.Enabled && config.Notify {
+		impl.sendDriftNotification(pipeline, &wfr, status)
+	}
+	return status, reconcileRequest, nil
+}
+
+// diffLiveObjects renders the manifest of the deployment and compares every rendered object with its live object
+func (impl *DriftDetectionServiceImpl) diffLiveObjects(ctx context.Context, pipeline *pipelineConfig.Pipeline, pipelineOverride *chartConfig.PipelineOverride) ([]*bean.ResourceDiff, error) {
+	manifestRequest := &configDiffBean.ManifestRequest{
+		Values:             json.RawMessage(pipelineOverride.PipelineMergedValues),
+		ResourceType:       pipelineBean.DeploymentTemplate,
+		AppId:              pipeline.AppId,
+		EnvironmentId:      pipeline.EnvironmentId,
+		UserHasAdminAccess: true,
+	}
+	manifestResponse, err := impl.deploymentConfigurationService.GetManifest(util2.SetSuperAdminInContext(ctx, true), manifestRequest)
+	if err != nil {
+		impl.logger.Errorw("error in rendering deployed manifest", "pipelineId", pipeline.Id, "pipelineOverrideId", pipelineOverride.Id, "err", err)
+		return nil, fmt.Errorf("error in rendering deployed manifest: %w", err)
+	}
+	objects, err := kube.SplitYAML([]byte(manifestResponse.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing deployed manifest: %w", err)
+	}
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(ctx, pipeline.Environment.ClusterId)
+	if err != nil {
+		impl.logger.Errorw("error in getting rest config", "clusterId", pipeline.Environment.ClusterId, "err", err)
+		return nil, fmt.Errorf("error in connecting to cluster: %w", err)
+	}
+	autoscaledWorkloads := helper.GetAutoscaledWorkloads(objects)
+	diffs := make([]*bean.ResourceDiff, 0)
+	for _, desired := range objects {
+		if _, isHook := desired.GetAnnotations()[bean.HelmHookAnnotation]; isHook {
+			continue
+		}
+		resourceDiff, err := impl.diffLiveObject(ctx, restConfig, desired, pipeline.Environment.Namespace, autoscaledWorkloads)
+		if err != nil {
+			return nil, err
+		}
+		if resourceDiff != nil {
+			diffs = append(diffs, resourceDiff)
+		}
+	}
+	return diffs, nil
+}
+
+func (impl *DriftDetectionServiceImpl) diffLiveObject(ctx context.Context, restConfig *rest.Config, desired *unstructured.Unstructured, defaultNamespace string, autoscaledWorkloads map[string]bool) (*bean.ResourceDiff, error) {
+	gvk := desired.GroupVersionKind()
+	namespace := desired.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+	resourceDiff := &bean.ResourceDiff{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      desired.GetName(),
+		Namespace: namespace,
+	}
+	live, err := impl.k8sUtil.GetResource(ctx, namespace, desired.GetName(), gvk, restConfig)
+	if k8sErrors.IsNotFound(err) {
+		resourceDiff.Missing = true
+		return resourceDiff, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error in fetching live %s %s/%s: %w", gvk.Kind, namespace, desired.GetName(), err)
+	}
+	isAutoscaled := autoscaledWorkloads[helper.GetWorkloadKey(gvk.Kind, desired.GetName())]
+	resourceDiff.Fields = helper.DiffResource(desired, &live.Manifest, isAutoscaled)
+	if len(resourceDiff.Fields) == 0 {
+		return nil, nil
+	}
+	return resourceDiff, nil
+}
+
+func (impl *DriftDetectionServiceImpl) UpdateReconcileOutcome(pipelineId int, reconcileWfrId int, reconcileErr error) {
+	status, err := impl.statusRepository.FindByPipelineId(pipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching drift status", "pipelineId", pipelineId, "err", err)
+		return
+	}
+	if reconcileErr != nil {
+		status.Message = fmt.Sprintf("error in reconciling drift: %s", reconcileErr.Error())
+	}
+	if reconcileWfrId > 0 {
+		status.ReconcileCdWorkflowRunnerId = reconcileWfrId
+	}
+	status.UpdateAuditLog(userBean.SystemUserId)
+	err = impl.statusRepository.Update(status)
+	if err != nil {
+		impl.logger.Errorw("error in updating drift reconcile outcome", "pipelineId", pipelineId, "reconcileWfrId", reconcileWfrId, "err", err)
+	}
+}
+
+func (impl *DriftDetectionServiceImpl) sendDriftNotification(pipeline *pipelineConfig.Pipeline, wfr *pipelineConfig.CdWorkflowRunner, status *driftRepository.DriftStatus) {
+	event, err := impl.eventFactory.Build(util3.DriftDetected, &pipeline.Id, pipeline.AppId, &pipeline.EnvironmentId, util3.CD)
+	if err != nil {
+		impl.logger.Errorw("error in building drift detected event", "pipelineId", pipeline.Id, "err", err)
+		return
+	}
+	payload := &client.Payload{
+		DriftSummary: getDriftSummary(status),
+	}
+	if wfr.CdWorkflow != nil && wfr.CdWorkflow.CiArtifact != nil {
+		event.CiArtifactId = wfr.CdWorkflow.CiArtifactId
+		payload.DockerImageUrl = wfr.CdWorkflow.CiArtifact.Image
+	}
+	event.Payload = payload
+	event.UserId = userBean.SystemUserId
+	event.CdWorkflowRunnerId = wfr.Id
+	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
+	if evtErr != nil {
+		impl.logger.Errorw("drift detected event not sent", "pipelineId", pipeline.Id, "wfrId", wfr.Id, "error", evtErr)
+	}
+}
+
+// getDriftSummary lists the drifted resources of the status for the notification
+func getDriftSummary(status *driftRepository.DriftStatus) string {
+	diffs := adapter.GetDriftStatusDto(status).Diffs
+	resources := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		resource := fmt.Sprintf("%s/%s", diff.Kind, diff.Name)
+		if diff.Missing {
+			resource = fmt.Sprintf("%s (%s)", resource, bean.ResourceMissingMessage)
+		}
+		resources = append(resources, resource)
+	}
+	return fmt.Sprintf("%d resource(s) drifted from the last deployment: %s", status.DriftedResources, strings.Join(resources, ", "))
+}
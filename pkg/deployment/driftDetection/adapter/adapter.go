@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"encoding/json"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	driftRepository "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetDriftDetectionConfigDto(model *driftRepository.DriftDetectionConfig) *bean.DriftDetectionConfigDto {
+	return &bean.DriftDetectionConfigDto{
+		Id:            model.Id,
+		PipelineId:    model.PipelineId,
+		Enabled:       model.Enabled,
+		Notify:        model.Notify,
+		AutoReconcile: model.AutoReconcile,
+	}
+}
+
+func NewDriftDetectionConfig(configDto *bean.DriftDetectionConfigDto) *driftRepository.DriftDetectionConfig {
+	return &driftRepository.DriftDetectionConfig{
+		PipelineId:    configDto.PipelineId,
+		Enabled:       configDto.Enabled,
+		Notify:        configDto.Notify,
+		AutoReconcile: configDto.AutoReconcile,
+		Active:        true,
+		AuditLog:      sql.NewDefaultAuditLog(configDto.UserId),
+	}
+}
+
+// GetDriftStatusDto converts the saved status, the diff is left empty if it can not be decoded
+func GetDriftStatusDto(model *driftRepository.DriftStatus) *bean.DriftStatusDto {
+	statusDto := &bean.DriftStatusDto{
+		PipelineId:                  model.PipelineId,
+		CdWorkflowRunnerId:          model.CdWorkflowRunnerId,
+		Status:                      bean.DriftStatus(model.Status),
+		Message:                     model.Message,
+		DriftedResources:            model.DriftedResources,
+		DriftDetectedOn:             model.DriftDetectedOn,
+		LastCheckedOn:               model.LastCheckedOn,
+		ReconcileCdWorkflowRunnerId: model.ReconcileCdWorkflowRunnerId,
+	}
+	if len(model.Diff) > 0 {
+		_ = json.Unmarshal([]byte(model.Diff), &statusDto.Diffs)
+	}
+	return statusDto
+}
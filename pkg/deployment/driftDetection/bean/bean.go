@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type DriftStatus string
+
+const (
+	DriftStatusInSync  DriftStatus = "IN_SYNC"
+	DriftStatusDrifted DriftStatus = "DRIFTED"
+	// DriftStatusError is set when the desired or the live state could not be fetched
+	DriftStatusError DriftStatus = "ERROR"
+)
+
+func (s DriftStatus) String() string {
+	return string(s)
+}
+
+const (
+	NotDeployedMessage      = "pipeline has no healthy deployment to compare with"
+	HibernatedMessage       = "application is hibernated"
+	ResourceMissingMessage  = "resource is missing in the cluster"
+	ReconcileSkippedMessage = "drift reconcile deployment is drifted as well, not reconciled again"
+	MaskedValue             = "*****"
+)
+
+const (
+	// HelmHookAnnotation marks the hook resources of a chart, hooks are not part of the live state of a release
+	HelmHookAnnotation = "helm.sh/hook"
+	// MinCheckInterval is the minimum time between two periodic checks of a pipeline across replicas
+	MinCheckInterval = time.Minute
+)
+
+type DriftDetectionConfigDto struct {
+	Id         int  `json:"id"`
+	PipelineId int  `json:"pipelineId" validate:"number,required"`
+	Enabled    bool `json:"enabled"`
+	// Notify sends the drift detected notification when the pipeline drifts from its last deployment
+	Notify bool `json:"notify"`
+	// AutoReconcile redeploys the last deployment when the pipeline drifts from it
+	AutoReconcile bool  `json:"autoReconcile"`
+	UserId        int32 `json:"-"`
+}
+
+// FieldDiff is a field of a resource whose live value differs from the desired value, values are json encoded
+type FieldDiff struct {
+	Path    string `json:"path"`
+	Desired string `json:"desired"`
+	Live    string `json:"live,omitempty"`
+}
+
+type ResourceDiff struct {
+	Group     string       `json:"group"`
+	Version   string       `json:"version"`
+	Kind      string       `json:"kind"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace,omitempty"`
+	Missing   bool         `json:"missing,omitempty"`
+	Fields    []*FieldDiff `json:"fields,omitempty"`
+}
+
+type DriftStatusDto struct {
+	PipelineId         int             `json:"pipelineId"`
+	AppId              int             `json:"appId"`
+	EnvironmentId      int             `json:"environmentId"`
+	EnvironmentName    string          `json:"environmentName,omitempty"`
+	CdWorkflowRunnerId int             `json:"cdWorkflowRunnerId,omitempty"`
+	Status             DriftStatus     `json:"status"`
+	Message            string          `json:"message,omitempty"`
+	DriftedResources   int             `json:"driftedResources"`
+	Diffs              []*ResourceDiff `json:"diffs,omitempty"`
+	DriftDetectedOn    *time.Time      `json:"driftDetectedOn,omitempty"`
+	LastCheckedOn      time.Time       `json:"lastCheckedOn"`
+	// ReconcileCdWorkflowRunnerId is the deployment triggered to reconcile the drift, if any
+	ReconcileCdWorkflowRunnerId int `json:"reconcileCdWorkflowRunnerId,omitempty"`
+}
+
+// ReconcileRequest is a drifted pipeline which is redeployed with its last deployment
+type ReconcileRequest struct {
+	PipelineId   int
+	AppId        int
+	CiArtifactId int
+	// CdWorkflowRunnerId is the drifted deployment, its configuration snapshot is redeployed
+	CdWorkflowRunnerId int
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverManagedPaths are set on live objects by the api server, controllers or the cd agent, they are never compared
+var serverManagedPaths = [][]string{
+	{"status"},
+	{"metadata", "namespace"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "managedFields"},
+	{"metadata", "selfLink"},
+	{"metadata", "ownerReferences"},
+	{"metadata", "finalizers"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "annotations", "deployment.kubernetes.io/revision"},
+	// argocd overrides the instance label with its application name for resource tracking
+	{"metadata", "labels", "app.kubernetes.io/instance"},
+}
+
+// autoscaledReplicasPath is owned by the autoscaler of a workload, it is not drift if it differs from the chart
+var autoscaledReplicasPath = []string{"spec", "replicas"}
+
+// DiffResource returns the fields of the desired object which differ on the live object.
+// Only the fields set on the desired object are compared, fields set on the live object alone
+// can not be told apart from server defaults and are not reported.
+func DiffResource(desired, live *unstructured.Unstructured, isAutoscaled bool) []*bean.FieldDiff {
+	ignoredPaths := serverManagedPaths
+	if isAutoscaled {
+		ignoredPaths = append(append([][]string{}, serverManagedPaths...), autoscaledReplicasPath)
+	}
+	desiredObject := desired.Object
+	isSecret := desired.GetKind() == "Secret"
+	if isSecret {
+		desiredObject = normaliseSecret(desiredObject)
+	}
+	diffs := make([]*bean.FieldDiff, 0)
+	diffValue(nil, desiredObject, live.Object, true, ignoredPaths, &diffs)
+	if isSecret {
+		for _, diff := range diffs {
+			if strings.HasPrefix(diff.Path, "data") {
+				diff.Desired, diff.Live = bean.MaskedValue, bean.MaskedValue
+			}
+		}
+	}
+	return diffs
+}
+
+// GetAutoscaledWorkloads returns the kind/name keys of the workloads scaled by an autoscaler in the given objects
+func GetAutoscaledWorkloads(objects []*unstructured.Unstructured) map[string]bool {
+	autoscaled := make(map[string]bool)
+	for _, object := range objects {
+		if object.GetKind() != "HorizontalPodAutoscaler" && object.GetKind() != "ScaledObject" {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(object.Object, "spec", "scaleTargetRef", "name")
+		kind, _, _ := unstructured.NestedString(object.Object, "spec", "scaleTargetRef", "kind")
+		if len(kind) == 0 {
+			// keda scales deployments by default
+			kind = "Deployment"
+		}
+		if len(name) > 0 {
+			autoscaled[GetWorkloadKey(kind, name)] = true
+		}
+	}
+	return autoscaled
+}
+
+func GetWorkloadKey(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// normaliseSecret moves stringData into data, as the api server does, so that it can be compared with the live secret
+func normaliseSecret(object map[string]interface{}) map[string]interface{} {
+	stringData, found, _ := unstructured.NestedStringMap(object, "stringData")
+	if !found || len(stringData) == 0 {
+		return object
+	}
+	normalised := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		normalised[key] = value
+	}
+	delete(normalised, "stringData")
+	data := make(map[string]interface{})
+	if existingData, ok := object["data"].(map[string]interface{}); ok {
+		for key, value := range existingData {
+			data[key] = value
+		}
+	}
+	for key, value := range stringData {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	normalised["data"] = data
+	return normalised
+}
+
+func diffValue(path []string, desired, live interface{}, liveFound bool, ignoredPaths [][]string, diffs *[]*bean.FieldDiff) {
+	if isIgnoredPath(path, ignoredPaths) {
+		return
+	}
+	switch desiredValue := desired.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		if len(desiredValue) == 0 {
+			return
+		}
+		liveValue, ok := live.(map[string]interface{})
+		if !liveFound || !ok {
+			appendDiff(path, desired, live, liveFound, diffs)
+			return
+		}
+		keys := make([]string, 0, len(desiredValue))
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			liveFieldValue, found := liveValue[key]
+			diffValue(append(path, key), desiredValue[key], liveFieldValue, found, ignoredPaths, diffs)
+		}
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if len(desiredValue) == 0 && len(liveValue) == 0 {
+			return
+		}
+		if !liveFound || !ok || len(liveValue) != len(desiredValue) {
+			appendDiff(path, desired, live, liveFound, diffs)
+			return
+		}
+		for i := range desiredValue {
+			diffValue(append(path, fmt.Sprintf("[%d]", i)), desiredValue[i], liveValue[i], true, ignoredPaths, diffs)
+		}
+	default:
+		if !liveFound || !isScalarEqual(desired, live) {
+			appendDiff(path, desired, live, liveFound, diffs)
+		}
+	}
+}
+
+func appendDiff(path []string, desired, live interface{}, liveFound bool, diffs *[]*bean.FieldDiff) {
+	diff := &bean.FieldDiff{
+		Path:    formatPath(path),
+		Desired: toJson(desired),
+	}
+	if liveFound {
+		diff.Live = toJson(live)
+	}
+	*diffs = append(*diffs, diff)
+}
+
+// isScalarEqual compares numbers by value and quantities by amount, e.g. cpu 0.5 and 500m are equal
+func isScalarEqual(desired, live interface{}) bool {
+	desiredNumber, isDesiredNumber := toFloat(desired)
+	liveNumber, isLiveNumber := toFloat(live)
+	if isDesiredNumber && isLiveNumber {
+		return desiredNumber == liveNumber
+	}
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	desiredQuantity, isDesiredQuantity := toQuantity(desired)
+	liveQuantity, isLiveQuantity := toQuantity(live)
+	return isDesiredQuantity && isLiveQuantity && desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case float32:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
+
+func toQuantity(value interface{}) (resource.Quantity, bool) {
+	var quantityString string
+	if number, ok := toFloat(value); ok {
+		quantityString = fmt.Sprint(number)
+	} else if stringValue, ok := value.(string); ok {
+		quantityString = stringValue
+	} else {
+		return resource.Quantity{}, false
+	}
+	quantity, err := resource.ParseQuantity(quantityString)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+	return quantity, true
+}
+
+func isIgnoredPath(path []string, ignoredPaths [][]string) bool {
+	for _, ignoredPath := range ignoredPaths {
+		if reflect.DeepEqual(path, ignoredPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPath joins the path segments, list indices are appended to their field, e.g. spec.containers[0].image
+func formatPath(path []string) string {
+	builder := strings.Builder{}
+	for i, segment := range path {
+		if i > 0 && !strings.HasPrefix(segment, "[") {
+			builder.WriteString(".")
+		}
+		builder.WriteString(segment)
+	}
+	return builder.String()
+}
+
+func toJson(value interface{}) string {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueJson)
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func toUnstructured(t *testing.T, manifest string) *unstructured.Unstructured {
+	object := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(manifest), &object)
+	assert.NoError(t, err)
+	return &unstructured.Unstructured{Object: object}
+}
+
+const desiredDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-dev
+  labels:
+    app: app
+    app.kubernetes.io/instance: app-dev
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:v1
+          resources:
+            limits:
+              cpu: 0.5
+              memory: 256Mi
+`
+
+func TestDiffResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		live         string
+		isAutoscaled bool
+		want         []*bean.FieldDiff
+	}{
+		{
+			name: "server managed fields and defaults are ignored",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-dev
+  namespace: dev
+  uid: 8d1c
+  resourceVersion: "1200"
+  generation: 3
+  labels:
+    app: app
+    app.kubernetes.io/instance: argo-app-dev
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:v1
+          imagePullPolicy: IfNotPresent
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+status:
+  readyReplicas: 2
+`,
+			want: []*bean.FieldDiff{},
+		},
+		{
+			name: "edited fields are reported",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-dev
+  labels:
+    app: app
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:hotfix
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+`,
+			want: []*bean.FieldDiff{
+				{Path: "spec.replicas", Desired: "2", Live: "5"},
+				{Path: "spec.template.spec.containers[0].image", Desired: `"app:v1"`, Live: `"app:hotfix"`},
+			},
+		},
+		{
+			name:         "replicas of an autoscaled workload are ignored",
+			isAutoscaled: true,
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-dev
+  labels:
+    app: app
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:v1
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+`,
+			want: []*bean.FieldDiff{},
+		},
+		{
+			name: "added container is reported",
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-dev
+  labels:
+    app: app
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: app
+          image: app:v1
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+        - name: debug
+          image: busybox
+`,
+			want: []*bean.FieldDiff{
+				{
+					Path:    "spec.template.spec.containers",
+					Desired: `[{"image":"app:v1","name":"app","resources":{"limits":{"cpu":0.5,"memory":"256Mi"}}}]`,
+					Live:    `[{"image":"app:v1","name":"app","resources":{"limits":{"cpu":"500m","memory":"256Mi"}}},{"image":"busybox","name":"debug"}]`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffResource(toUnstructured(t, desiredDeployment), toUnstructured(t, tt.live), tt.isAutoscaled)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiffResource_Secret(t *testing.T) {
+	desired := toUnstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+stringData:
+  password: admin
+`)
+	inSync := toUnstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+data:
+  password: YWRtaW4=
+`)
+	assert.Empty(t, DiffResource(desired, inSync, false))
+
+	edited := toUnstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+data:
+  password: cm9vdA==
+`)
+	diffs := DiffResource(desired, edited, false)
+	assert.Equal(t, []*bean.FieldDiff{{Path: "data.password", Desired: bean.MaskedValue, Live: bean.MaskedValue}}, diffs)
+}
+
+func TestGetAutoscaledWorkloads(t *testing.T) {
+	objects := []*unstructured.Unstructured{
+		toUnstructured(t, desiredDeployment),
+		toUnstructured(t, `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: app-dev-hpa
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app-dev
+`),
+		toUnstructured(t, `
+apiVersion: keda.sh/v1alpha1
+kind: ScaledObject
+metadata:
+  name: worker-keda
+spec:
+  scaleTargetRef:
+    name: worker
+`),
+	}
+	assert.Equal(t, map[string]bool{"Deployment/app-dev": true, "Deployment/worker": true}, GetAutoscaledWorkloads(objects))
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type DriftDetectionConfig struct {
+	tableName     struct{}   `sql:"drift_detection_config" pg:",discard_unknown_columns"`
+	Id            int        `sql:"id,pk"`
+	PipelineId    int        `sql:"pipeline_id,notnull"`
+	Enabled       bool       `sql:"enabled,notnull"`
+	Notify        bool       `sql:"notify,notnull"`
+	AutoReconcile bool       `sql:"auto_reconcile,notnull"`
+	LastCheckedOn *time.Time `sql:"last_checked_on"`
+	Active        bool       `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type DriftDetectionConfigRepository interface {
+	Save(config *DriftDetectionConfig) error
+	Update(config *DriftDetectionConfig) error
+	FindByPipelineId(pipelineId int) (*DriftDetectionConfig, error)
+	// FindAllEnabled returns the enabled configs of the cd pipelines which are not deleted
+	FindAllEnabled() ([]*DriftDetectionConfig, error)
+	MarkInactiveByPipelineId(pipelineId int, userId int32) error
+	// ClaimCheck marks the config checked, false is returned if another replica checked it after checkedBefore
+	ClaimCheck(id int, checkedBefore time.Time, now time.Time) (bool, error)
+}
+
+type DriftDetectionConfigRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDriftDetectionConfigRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DriftDetectionConfigRepositoryImpl {
+	return &DriftDetectionConfigRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) Save(config *DriftDetectionConfig) error {
+	return impl.dbConnection.Insert(config)
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) Update(config *DriftDetectionConfig) error {
+	return impl.dbConnection.Update(config)
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) FindByPipelineId(pipelineId int) (*DriftDetectionConfig, error) {
+	config := &DriftDetectionConfig{}
+	err := impl.dbConnection.Model(config).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return config, err
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) FindAllEnabled() ([]*DriftDetectionConfig, error) {
+	var configs []*DriftDetectionConfig
+	err := impl.dbConnection.Model(&configs).
+		Join("INNER JOIN pipeline p ON p.id = drift_detection_config.pipeline_id").
+		Where("drift_detection_config.active = ?", true).
+		Where("drift_detection_config.enabled = ?", true).
+		Where("p.deleted = ?", false).
+		Order("drift_detection_config.id ASC").
+		Select()
+	return configs, err
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) MarkInactiveByPipelineId(pipelineId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*DriftDetectionConfig)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
+
+func (impl *DriftDetectionConfigRepositoryImpl) ClaimCheck(id int, checkedBefore time.Time, now time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*DriftDetectionConfig)(nil)).
+		Set("last_checked_on = ?", now).
+		Where("id = ?", id).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return q.WhereOr("last_checked_on IS NULL").WhereOr("last_checked_on < ?", checkedBefore), nil
+		}).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
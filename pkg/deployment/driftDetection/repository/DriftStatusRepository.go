@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type DriftStatus struct {
+	tableName                   struct{}   `sql:"drift_status" pg:",discard_unknown_columns"`
+	Id                          int        `sql:"id,pk"`
+	PipelineId                  int        `sql:"pipeline_id,notnull"`
+	CdWorkflowRunnerId          int        `sql:"cd_workflow_runner_id"`
+	Status                      string     `sql:"status,notnull"`
+	DriftedResources            int        `sql:"drifted_resources,notnull"`
+	Diff                        string     `sql:"diff"`
+	Message                     string     `sql:"message"`
+	DriftDetectedOn             *time.Time `sql:"drift_detected_on"`
+	LastCheckedOn               time.Time  `sql:"last_checked_on,notnull"`
+	ReconcileCdWorkflowRunnerId int        `sql:"reconcile_cd_workflow_runner_id"`
+	sql.AuditLog
+}
+
+type DriftStatusRepository interface {
+	Save(status *DriftStatus) error
+	Update(status *DriftStatus) error
+	FindByPipelineId(pipelineId int) (*DriftStatus, error)
+	FindByPipelineIds(pipelineIds []int) ([]*DriftStatus, error)
+}
+
+type DriftStatusRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDriftStatusRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DriftStatusRepositoryImpl {
+	return &DriftStatusRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DriftStatusRepositoryImpl) Save(status *DriftStatus) error {
+	return impl.dbConnection.Insert(status)
+}
+
+func (impl *DriftStatusRepositoryImpl) Update(status *DriftStatus) error {
+	return impl.dbConnection.Update(status)
+}
+
+func (impl *DriftStatusRepositoryImpl) FindByPipelineId(pipelineId int) (*DriftStatus, error) {
+	status := &DriftStatus{}
+	err := impl.dbConnection.Model(status).
+		Where("pipeline_id = ?", pipelineId).
+		Select()
+	return status, err
+}
+
+func (impl *DriftStatusRepositoryImpl) FindByPipelineIds(pipelineIds []int) ([]*DriftStatus, error) {
+	var statuses []*DriftStatus
+	if len(pipelineIds) == 0 {
+		return statuses, nil
+	}
+	err := impl.dbConnection.Model(&statuses).
+		Where("pipeline_id IN (?)", pg.In(pipelineIds)).
+		Select()
+	return statuses, err
+}
@@ -184,6 +184,7 @@ func (impl *GitOpsConfigReadServiceImpl) GetAllGitOpsConfig() ([]*bean2.GitOpsCo
 			AzureProjectName:      model.AzureProject,
 			BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 			BitBucketProjectKey:   model.BitBucketProjectKey,
+			GiteaOrgId:            model.GiteaOrgId,
 			AllowCustomRepository: model.AllowCustomRepository,
 			TLSConfig: &bean3.TLSConfig{
 				CaData:      model.CaCert,
@@ -0,0 +1,334 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/bean"
+
+	gitOps "github.com/devtron-labs/devtron/api/bean/gitOps"
+
+	gitbean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GitOpsConfigReadService is an autogenerated mock type for the GitOpsConfigReadService type
+type GitOpsConfigReadService struct {
+	mock.Mock
+}
+
+// GetAllGitOpsConfig provides a mock function with no fields
+func (_m *GitOpsConfigReadService) GetAllGitOpsConfig() ([]*gitOps.GitOpsConfigDto, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllGitOpsConfig")
+	}
+
+	var r0 []*gitOps.GitOpsConfigDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*gitOps.GitOpsConfigDto, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*gitOps.GitOpsConfigDto); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gitOps.GitOpsConfigDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBitbucketMetadata provides a mock function with no fields
+func (_m *GitOpsConfigReadService) GetBitbucketMetadata() (*bean.BitbucketProviderMetadata, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBitbucketMetadata")
+	}
+
+	var r0 *bean.BitbucketProviderMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*bean.BitbucketProviderMetadata, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *bean.BitbucketProviderMetadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.BitbucketProviderMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConfiguredGitOpsCount provides a mock function with no fields
+func (_m *GitOpsConfigReadService) GetConfiguredGitOpsCount() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfiguredGitOpsCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitConfig provides a mock function with no fields
+func (_m *GitOpsConfigReadService) GetGitConfig() (*gitbean.GitConfig, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitConfig")
+	}
+
+	var r0 *gitbean.GitConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*gitbean.GitConfig, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *gitbean.GitConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitbean.GitConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitOpsById provides a mock function with given fields: id
+func (_m *GitOpsConfigReadService) GetGitOpsById(id int) (*gitOps.GitOpsConfigDto, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsById")
+	}
+
+	var r0 *gitOps.GitOpsConfigDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*gitOps.GitOpsConfigDto, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *gitOps.GitOpsConfigDto); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitOps.GitOpsConfigDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitOpsConfigActive provides a mock function with no fields
+func (_m *GitOpsConfigReadService) GetGitOpsConfigActive() (*gitOps.GitOpsConfigDto, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsConfigActive")
+	}
+
+	var r0 *gitOps.GitOpsConfigDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*gitOps.GitOpsConfigDto, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *gitOps.GitOpsConfigDto); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitOps.GitOpsConfigDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitOpsProviderByRepoURL provides a mock function with given fields: gitRepoUrl
+func (_m *GitOpsConfigReadService) GetGitOpsProviderByRepoURL(gitRepoUrl string) (*gitOps.GitOpsConfigDto, error) {
+	ret := _m.Called(gitRepoUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsProviderByRepoURL")
+	}
+
+	var r0 *gitOps.GitOpsConfigDto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*gitOps.GitOpsConfigDto, error)); ok {
+		return rf(gitRepoUrl)
+	}
+	if rf, ok := ret.Get(0).(func(string) *gitOps.GitOpsConfigDto); ok {
+		r0 = rf(gitRepoUrl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitOps.GitOpsConfigDto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(gitRepoUrl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGitOpsRepoName provides a mock function with given fields: appName
+func (_m *GitOpsConfigReadService) GetGitOpsRepoName(appName string) string {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsRepoName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetGitOpsRepoNameFromUrl provides a mock function with given fields: gitRepoUrl
+func (_m *GitOpsConfigReadService) GetGitOpsRepoNameFromUrl(gitRepoUrl string) string {
+	ret := _m.Called(gitRepoUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGitOpsRepoNameFromUrl")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(gitRepoUrl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetUserEmailIdAndNameForGitOpsCommit provides a mock function with given fields: userId
+func (_m *GitOpsConfigReadService) GetUserEmailIdAndNameForGitOpsCommit(userId int32) (string, string) {
+	ret := _m.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserEmailIdAndNameForGitOpsCommit")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(int32) (string, string)); ok {
+		return rf(userId)
+	}
+	if rf, ok := ret.Get(0).(func(int32) string); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int32) string); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// IsGitOpsConfigured provides a mock function with no fields
+func (_m *GitOpsConfigReadService) IsGitOpsConfigured() (*bean.GitOpsConfigurationStatus, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsGitOpsConfigured")
+	}
+
+	var r0 *bean.GitOpsConfigurationStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*bean.GitOpsConfigurationStatus, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *bean.GitOpsConfigurationStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.GitOpsConfigurationStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGitOpsConfigReadService creates a new instance of GitOpsConfigReadService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGitOpsConfigReadService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GitOpsConfigReadService {
+	mock := &GitOpsConfigReadService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
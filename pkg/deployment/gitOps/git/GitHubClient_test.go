@@ -12,6 +12,7 @@ import (
 	"github.com/devtron-labs/devtron/api/bean"
 	apiBean "github.com/devtron-labs/devtron/api/bean/gitOps"
 	"github.com/devtron-labs/devtron/internal/util"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/commandManager"
 	validationBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/validation/bean"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +44,7 @@ func newGitHubTestConfig() (*gitHubTestConfig, error) {
 }
 
 func (github *gitHubTestConfig) getProvider() string {
-	return GITHUB_PROVIDER
+	return gitBean.GITHUB_PROVIDER
 }
 
 func (github *gitHubTestConfig) getHost() string {
@@ -78,6 +79,9 @@ func getGitHubTestHttpClient(t *testing.T) (GitHubClient, *GitOpsHelper, *gitHub
 	if err != nil {
 		t.Fatalf("failed to load GitHub test config: %v", err)
 	}
+	if len(githubCfg.GitHubToken) == 0 {
+		t.Skip("GITHUB_TOKEN is not set, skipping GitHub client tests")
+	}
 	gitService, err := NewGitOpsHelperImpl(
 		githubCfg.getBasicAuth(), logger,
 		&bean.TLSConfig{}, false)
@@ -54,6 +54,12 @@ type GitOperationService interface {
 
 	CommitValues(ctx context.Context, chartGitAttr *ChartConfig) (commitHash string, commitTime time.Time, err error)
 	PushChartToGitRepo(ctx context.Context, gitOpsRepoName, chartLocation, tempReferenceTemplateDir, repoUrl, targetRevision string, userId int32) (err error)
+	// ReplaceChartInGitRepo - replaces the contents of chartLocation with sourceChartDir and pushes the change to targetRevision.
+	ReplaceChartInGitRepo(ctx context.Context, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg string, userId int32) (commitHash string, err error)
+	// CreateBranch - creates the branch from the HEAD of fromBranch, if not already present.
+	CreateBranch(ctx context.Context, gitOpsRepoName, branch, fromBranch string) error
+	CreatePullRequest(ctx context.Context, prConfig *PullRequestConfig) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, gitOpsRepoName string, pullRequestId int) (*PullRequest, error)
 	CloneChartForHelmApp(helmAppName, gitRepoUrl, targetRevision string) (string, error)
 	PushChartToGitOpsRepoForHelmApp(ctx context.Context, pushChartToGitRequest *bean.PushChartToGitRequestDTO, valuesConfig *ChartConfig) (*commonBean.ChartGitAttribute, string, error)
 	MigrateProxyChartDependenciesIfRequired(ctx context.Context, isChartUpdated bool, pushChartToGitRequest *bean.PushChartToGitRequestDTO, expectedChartYamlContent string) error
@@ -102,7 +108,7 @@ func (impl *GitOperationServiceImpl) CreateGitRepositoryForDevtronApp(ctx contex
 	gitRepoRequest := &apiBean.GitOpsConfigDto{
 		GitRepoName:          gitOpsRepoName,
 		TargetRevision:       targetRevision,
-		Description:          fmt.Sprintf("helm chart for " + gitOpsRepoName),
+		Description:          fmt.Sprintf("helm chart for %s", gitOpsRepoName),
 		BitBucketWorkspaceId: bitbucketMetadata.BitBucketWorkspaceId,
 		BitBucketProjectKey:  bitbucketMetadata.BitBucketProjectKey,
 	}
@@ -218,6 +224,56 @@ func (impl *GitOperationServiceImpl) updateRepoAndPushAllChanges(ctx context.Con
 	return commit, nil
 }
 
+func (impl *GitOperationServiceImpl) ReplaceChartInGitRepo(ctx context.Context, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg string, userId int32) (commitHash string, err error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOperationServiceImpl.ReplaceChartInGitRepo")
+	defer span.End()
+	chartDir := fmt.Sprintf("%s-%s", gitOpsRepoName, impl.chartTemplateService.GetDir())
+	clonedDir, err := impl.GetClonedDir(newCtx, chartDir, repoUrl, targetRevision)
+	defer impl.chartTemplateService.CleanDir(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in cloning repo", "url", repoUrl, "err", err)
+		return commitHash, err
+	}
+	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(userId)
+	dir := filepath.Join(clonedDir, chartLocation)
+	callback := func(int) error {
+		err = impl.GitPull(clonedDir, repoUrl, targetRevision)
+		if err != nil {
+			return err
+		}
+		err = os.RemoveAll(dir)
+		if err != nil {
+			impl.logger.Errorw("error in removing chart dir", "dir", dir, "err", err)
+			return err
+		}
+		err = os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			impl.logger.Errorw("error in making dir", "dir", dir, "err", err)
+			return err
+		}
+		err = dirCopy.Copy(sourceChartDir, dir)
+		if err != nil {
+			impl.logger.Errorw("error copying dir", "from", sourceChartDir, "to", dir, "err", err)
+			return err
+		}
+		commitHash, err = impl.gitFactory.GitOpsHelper.CommitAndPushAllChanges(newCtx, clonedDir, targetRevision, commitMsg, userName, userEmailId)
+		if err != nil {
+			impl.logger.Errorw("error in pushing git", "url", repoUrl, "err", err)
+			return retryFunc.NewRetryableError(err)
+		}
+		return nil
+	}
+	err = retryFunc.Retry(callback,
+		impl.isRetryableGitCommitError,
+		impl.globalEnvVariables.ArgoGitCommitRetryCountOnConflict,
+		time.Duration(impl.globalEnvVariables.ArgoGitCommitRetryDelayOnConflict)*time.Second,
+		impl.logger)
+	if err != nil {
+		return commitHash, err
+	}
+	return commitHash, nil
+}
+
 func (impl *GitOperationServiceImpl) CreateFirstCommitOnHead(ctx context.Context, gitOpsRepoName string, userId int32) error {
 	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(userId)
 	gitOpsConfig, err := impl.gitOpsConfigReadService.GetGitOpsConfigActive()
@@ -289,6 +345,62 @@ func (impl *GitOperationServiceImpl) CommitValues(ctx context.Context, chartGitA
 	return commitHash, commitTime, nil
 }
 
+func (impl *GitOperationServiceImpl) CreateBranch(ctx context.Context, gitOpsRepoName, branch, fromBranch string) error {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "gitOperationService.CreateBranch")
+	defer span.End()
+	gitOpsConfig, err := impl.getBitbucketGitOpsConfig()
+	if err != nil {
+		return err
+	}
+	if len(fromBranch) == 0 {
+		fromBranch = globalUtil.GetDefaultTargetRevision()
+	}
+	err = impl.gitFactory.Client.CreateBranch(newCtx, gitOpsRepoName, branch, fromBranch, gitOpsConfig)
+	if err != nil {
+		impl.logger.Errorw("error in creating branch", "gitOpsRepoName", gitOpsRepoName, "branch", branch, "fromBranch", fromBranch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOperationServiceImpl) CreatePullRequest(ctx context.Context, prConfig *PullRequestConfig) (*PullRequest, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "gitOperationService.CreatePullRequest")
+	defer span.End()
+	gitOpsConfig, err := impl.getBitbucketGitOpsConfig()
+	if err != nil {
+		return nil, err
+	}
+	pullRequest, err := impl.gitFactory.Client.CreatePullRequest(newCtx, prConfig, gitOpsConfig)
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request", "prConfig", prConfig, "err", err)
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+func (impl *GitOperationServiceImpl) GetPullRequest(ctx context.Context, gitOpsRepoName string, pullRequestId int) (*PullRequest, error) {
+	gitOpsConfig, err := impl.getBitbucketGitOpsConfig()
+	if err != nil {
+		return nil, err
+	}
+	pullRequest, err := impl.gitFactory.Client.GetPullRequest(ctx, gitOpsRepoName, pullRequestId, gitOpsConfig)
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request", "gitOpsRepoName", gitOpsRepoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// getBitbucketGitOpsConfig - bitbucket apis are scoped by the workspace, other providers ignore it
+func (impl *GitOperationServiceImpl) getBitbucketGitOpsConfig() (*apiBean.GitOpsConfigDto, error) {
+	bitbucketMetadata, err := impl.gitOpsConfigReadService.GetBitbucketMetadata()
+	if err != nil {
+		impl.logger.Errorw("error in getting bitbucket metadata", "err", err)
+		return nil, err
+	}
+	return &apiBean.GitOpsConfigDto{BitBucketWorkspaceId: bitbucketMetadata.BitBucketWorkspaceId}, nil
+}
+
 func (impl *GitOperationServiceImpl) isRetryableGitCommitError(err error) bool {
 	return retryFunc.IsRetryableError(err)
 }
@@ -629,6 +741,16 @@ func (impl *GitOperationServiceImpl) UpdateGitHostUrlByProvider(request *apiBean
 		}
 	case bean.BITBUCKET_PROVIDER:
 		request.Host = BITBUCKET_CLONE_BASE_URL + request.BitBucketWorkspaceId
+	case bean.GITEA_PROVIDER:
+		owner := request.GiteaOrgId
+		if len(owner) == 0 {
+			owner = request.Username
+		}
+		orgUrl, err := buildGithubOrgUrl(request.Host, owner)
+		if err != nil {
+			return err
+		}
+		request.Host = orgUrl
 	}
 	return nil
 }
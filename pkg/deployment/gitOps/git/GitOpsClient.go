@@ -37,6 +37,12 @@ type GitOpsClient interface {
 	// CreateFirstCommitOnHead creates a commit on the HEAD of the repository, used for initializing the repository.
 	// It is used when the repository is empty and needs an initial commit.
 	CreateFirstCommitOnHead(ctx context.Context, config *gitOps.GitOpsConfigDto) (string, error)
+	// CreateBranch creates the branch on the repository from the HEAD of fromBranch, it is a no-op if the branch already exists.
+	CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *gitOps.GitOpsConfigDto) error
+	// CreatePullRequest opens a pull/merge request from SourceBranch to TargetBranch.
+	CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequest, error)
+	// GetPullRequest returns the current state of the pull/merge request.
+	GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequest, error)
 }
 
 func GetGitConfigAll(gitOpsConfigReadService config.GitOpsConfigReadService) ([]*bean.GitConfig, error) {
@@ -59,6 +65,7 @@ func GetGitConfigAll(gitOpsConfigReadService config.GitOpsConfigReadService) ([]
 			AzureProject:          gitOpsConfig.AzureProjectName,
 			BitbucketWorkspaceId:  gitOpsConfig.BitBucketWorkspaceId,
 			BitbucketProjectKey:   gitOpsConfig.BitBucketProjectKey,
+			GiteaOrganization:     gitOpsConfig.GiteaOrgId,
 			IsActiveConfig:        gitOpsConfig.Active,
 			CaCert:                gitOpsConfig.TLSConfig.CaData,
 			TLSCert:               gitOpsConfig.TLSConfig.TLSCertData,
@@ -93,6 +100,9 @@ func NewGitOpsClient(config *bean.GitConfig, logger *zap.SugaredLogger, gitOpsHe
 	} else if config.GitProvider == bean.BITBUCKET_PROVIDER {
 		gitBitbucketClient := NewGitBitbucketClient(config.GitUserName, config.GitToken, config.GitHost, logger, gitOpsHelper, tlsConfig)
 		return gitBitbucketClient, nil
+	} else if config.GitProvider == bean.GITEA_PROVIDER {
+		gitGiteaClient, err := NewGitGiteaClient(config.GitHost, config.GitUserName, config.GitToken, config.GiteaOrganization, logger, gitOpsHelper, tlsConfig)
+		return gitGiteaClient, err
 	} else {
 		logger.Warn("no gitops config provided, gitops will not work")
 		return &UnimplementedGitOpsClient{}, nil
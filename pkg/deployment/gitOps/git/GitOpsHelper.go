@@ -99,7 +99,7 @@ func (impl *GitOpsHelper) cloneAndFetch(url, targetDir string) (ctx git.GitConte
 	_, errMsg, err := impl.gitCommandManager.Fetch(ctx, clonedDir)
 	if errMsg != "" {
 		impl.logger.Errorw("error in git fetch command", "errMsg", errMsg, "err", err)
-		return ctx, clonedDir, fmt.Errorf(errMsg)
+		return ctx, clonedDir, fmt.Errorf("%s", errMsg)
 	} else if err != nil {
 		impl.logger.Errorw("error in git fetch command", "clonedDir", clonedDir, "url", url, "err", err)
 		return ctx, clonedDir, fmt.Errorf("error in git fetch command: %v", err)
@@ -394,3 +394,114 @@ func (impl GitAzureClient) ensureProjectAvailabilityOnSsh(projectName string, re
 	}
 	return false, nil
 }
+
+func (impl GitAzureClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreateBranch", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	_, err = clientAzure.GetBranch(ctx, git.GetBranchArgs{Project: &impl.project, Name: &branch, RepositoryId: &repoName})
+	if err == nil {
+		return nil
+	} else if e := (azuredevops.WrappedError{}); !errors.As(err, &e) || e.StatusCode == nil || *e.StatusCode != http2.StatusNotFound {
+		impl.logger.Errorw("error in fetching branch from azure devops", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	baseBranch, err := clientAzure.GetBranch(ctx, git.GetBranchArgs{Project: &impl.project, Name: &fromBranch, RepositoryId: &repoName})
+	if err != nil {
+		impl.logger.Errorw("error in fetching base branch from azure devops", "repo", repoName, "branch", fromBranch, "err", err)
+		return err
+	}
+	branchRefHead := gitUtil.GetRefBranchHead(branch)
+	oldObjId := "0000000000000000000000000000000000000000"
+	refUpdates := []git.GitRefUpdate{{
+		Name:        &branchRefHead,
+		OldObjectId: &oldObjId,
+		NewObjectId: baseBranch.Commit.CommitId,
+	}}
+	results, err := clientAzure.UpdateRefs(ctx, git.UpdateRefsArgs{
+		RefUpdates:   &refUpdates,
+		RepositoryId: &repoName,
+		Project:      &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating branch azure devops", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	if results != nil {
+		for _, result := range *results {
+			if result.Success != nil && !*result.Success {
+				err = fmt.Errorf("error in creating branch %s, status: %v", branch, result.UpdateStatus)
+				impl.logger.Errorw("error in creating branch azure devops", "repo", repoName, "branch", branch, "err", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (impl GitAzureClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreatePullRequest", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	sourceRef := gitUtil.GetRefBranchHead(config.SourceBranch)
+	targetRef := gitUtil.GetRefBranchHead(config.TargetBranch)
+	pr, err := clientAzure.CreatePullRequest(ctx, git.CreatePullRequestArgs{
+		GitPullRequestToCreate: &git.GitPullRequest{
+			Title:         &config.Title,
+			Description:   &config.Description,
+			SourceRefName: &sourceRef,
+			TargetRefName: &targetRef,
+		},
+		RepositoryId: &config.ChartRepoName,
+		Project:      &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request azure devops", "config", config, "err", err)
+		return nil, err
+	}
+	return getAzurePullRequest(pr), nil
+}
+
+func (impl GitAzureClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("GetPullRequest", "GitAzureClient", start, err)
+	}()
+	clientAzure := *impl.client
+	pr, err := clientAzure.GetPullRequest(ctx, git.GetPullRequestArgs{
+		RepositoryId:  &repoName,
+		PullRequestId: &pullRequestId,
+		Project:       &impl.project,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request azure devops", "repo", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getAzurePullRequest(pr), nil
+}
+
+func getAzurePullRequest(pr *git.GitPullRequest) *PullRequest {
+	pullRequest := &PullRequest{State: PullRequestStateOpen}
+	if pr.PullRequestId != nil {
+		pullRequest.Id = *pr.PullRequestId
+	}
+	if pr.Repository != nil && pr.Repository.WebUrl != nil {
+		pullRequest.Url = fmt.Sprintf("%s/pullrequest/%d", *pr.Repository.WebUrl, pullRequest.Id)
+	}
+	if pr.Status != nil {
+		switch *pr.Status {
+		case git.PullRequestStatusValues.Completed:
+			pullRequest.State = PullRequestStateMerged
+			if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
+				pullRequest.MergeCommitHash = *pr.LastMergeCommit.CommitId
+			}
+		case git.PullRequestStatusValues.Abandoned:
+			pullRequest.State = PullRequestStateClosed
+		}
+	}
+	return pullRequest
+}
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -405,3 +406,110 @@ func (impl GitBitbucketClient) commitValues(ctx context.Context, config *ChartCo
 	util.TriggerGitOpsMetrics("CommitValues", "GitBitbucketClient", start, nil)
 	return commitHash, commitTime, nil
 }
+
+func (impl GitBitbucketClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateBranch", "GitBitbucketClient", start, err)
+	}()
+	_, err = impl.client.Repositories.Repository.GetBranch(&bitbucket.RepositoryBranchOptions{
+		Owner:      gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug:   repoName,
+		BranchName: branch,
+	})
+	if err == nil {
+		return nil
+	} else if e := (&bitbucket.UnexpectedResponseStatusError{}); !errors.As(err, &e) || !strings.HasPrefix(e.Status, strconv.Itoa(http.StatusNotFound)) {
+		impl.logger.Errorw("error in getting branch bitbucket", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	baseBranch, err := impl.client.Repositories.Repository.GetBranch(&bitbucket.RepositoryBranchOptions{
+		Owner:      gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug:   repoName,
+		BranchName: fromBranch,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in getting base branch bitbucket", "repo", repoName, "branch", fromBranch, "err", err)
+		return err
+	}
+	baseHash, _ := baseBranch.Target["hash"].(string)
+	_, err = impl.client.Repositories.Repository.CreateBranch(&bitbucket.RepositoryBranchCreationOptions{
+		Owner:    gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug: repoName,
+		Name:     branch,
+		Target:   bitbucket.RepositoryBranchTarget{Hash: baseHash},
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating branch bitbucket", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl GitBitbucketClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitBitbucketClient", start, err)
+	}()
+	prOptions := &bitbucket.PullRequestsOptions{
+		Owner:             gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug:          config.ChartRepoName,
+		Title:             config.Title,
+		Description:       config.Description,
+		SourceBranch:      config.SourceBranch,
+		DestinationBranch: config.TargetBranch,
+		CloseSourceBranch: true,
+	}
+	prOptions.WithContext(ctx)
+	pr, err := impl.client.Repositories.PullRequests.Create(prOptions)
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request bitbucket", "config", config, "err", err)
+		return nil, err
+	}
+	return getBitbucketPullRequest(pr)
+}
+
+func (impl GitBitbucketClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitBitbucketClient", start, err)
+	}()
+	pr, err := impl.client.Repositories.PullRequests.Get(&bitbucket.PullRequestsOptions{
+		ID:       strconv.Itoa(pullRequestId),
+		Owner:    gitOpsConfig.BitBucketWorkspaceId,
+		RepoSlug: repoName,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request bitbucket", "repo", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getBitbucketPullRequest(pr)
+}
+
+// getBitbucketPullRequest extracts the pull request from the untyped api response,
+// reference - https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests
+func getBitbucketPullRequest(response interface{}) (*PullRequest, error) {
+	prMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected pull request response format from bitbucket")
+	}
+	pullRequest := &PullRequest{State: PullRequestStateOpen}
+	if id, ok := prMap["id"].(float64); ok {
+		pullRequest.Id = int(id)
+	}
+	if links, ok := prMap["links"].(map[string]interface{}); ok {
+		if html, ok := links["html"].(map[string]interface{}); ok {
+			pullRequest.Url, _ = html["href"].(string)
+		}
+	}
+	switch prMap["state"] {
+	case "MERGED":
+		pullRequest.State = PullRequestStateMerged
+		if mergeCommit, ok := prMap["merge_commit"].(map[string]interface{}); ok {
+			pullRequest.MergeCommitHash, _ = mergeCommit["hash"].(string)
+		}
+	case "DECLINED", "SUPERSEDED":
+		pullRequest.State = PullRequestStateClosed
+	}
+	return pullRequest, nil
+}
@@ -0,0 +1,550 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/common-lib/utils/retryFunc"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/util"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GitGiteaClient talks to the v1 rest api of Gitea, Forgejo serves the same api and is supported through it
+type GitGiteaClient struct {
+	client       *http.Client
+	baseUrl      string
+	token        string
+	org          string
+	owner        string
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+// GiteaApiError is returned for the non 2xx responses of the gitea api
+type GiteaApiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *GiteaApiError) Error() string {
+	return fmt.Sprintf("gitea api error, status: %d, message: %s", err.StatusCode, err.Message)
+}
+
+func isGiteaStatusError(err error, statusCodes ...int) bool {
+	var apiErr *GiteaApiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if apiErr.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func IsGiteaRepoNotFound(err error) bool {
+	return isGiteaStatusError(err, http.StatusNotFound)
+}
+
+// NewGitGiteaClient creates the repositories under org, or under the user of the token if org is empty
+func NewGitGiteaClient(host, username, token, org string, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper, tlsConfig *tls.Config) (GitGiteaClient, error) {
+	hostUrl, err := url.Parse(host)
+	if err != nil {
+		logger.Errorw("error in creating gitea client", "host", host, "err", err)
+		return GitGiteaClient{}, err
+	}
+	hostUrl.Path = path.Join(hostUrl.Path, bean.GITEA_API_V1)
+	owner := org
+	if len(owner) == 0 {
+		owner = username
+	}
+	return GitGiteaClient{
+		client:       util.GetHTTPClientWithTLSConfig(tlsConfig),
+		baseUrl:      hostUrl.String(),
+		token:        token,
+		org:          org,
+		owner:        owner,
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+type giteaRepository struct {
+	CloneUrl      string `json:"clone_url"`
+	HtmlUrl       string `json:"html_url"`
+	Empty         bool   `json:"empty"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type giteaCreateRepositoryOption struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+}
+
+type giteaContents struct {
+	Sha string `json:"sha"`
+}
+
+type giteaIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type giteaCommitDates struct {
+	Author    time.Time `json:"author"`
+	Committer time.Time `json:"committer"`
+}
+
+type giteaFileOption struct {
+	Content   string           `json:"content"`
+	Message   string           `json:"message"`
+	Branch    string           `json:"branch"`
+	Sha       string           `json:"sha,omitempty"`
+	Author    giteaIdentity    `json:"author"`
+	Committer giteaIdentity    `json:"committer"`
+	Dates     giteaCommitDates `json:"dates"`
+}
+
+type giteaFileResponse struct {
+	Commit *struct {
+		Sha    string `json:"sha"`
+		Author *struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type giteaBranch struct {
+	Name        string `json:"name"`
+	Protected   bool   `json:"protected"`
+	UserCanPush bool   `json:"user_can_push"`
+}
+
+type giteaCreateBranchOption struct {
+	NewBranchName string `json:"new_branch_name"`
+	OldBranchName string `json:"old_branch_name"`
+}
+
+type giteaPullRequest struct {
+	Number         int    `json:"number"`
+	HtmlUrl        string `json:"html_url"`
+	State          string `json:"state"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSha string `json:"merge_commit_sha"`
+}
+
+type giteaCreatePullRequestOption struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// escapeGiteaPath escapes every segment of the path, branch names and file paths can contain slashes
+func escapeGiteaPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (impl GitGiteaClient) repoPath(repoName string) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(impl.owner), url.PathEscape(repoName))
+}
+
+func (impl GitGiteaClient) doRequest(ctx context.Context, method, apiPath string, body, response interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, impl.baseUrl+apiPath, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+impl.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := impl.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		apiErr := &GiteaApiError{StatusCode: resp.StatusCode}
+		errResponse := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(respBody, &errResponse) == nil && len(errResponse.Message) > 0 {
+			apiErr.Message = errResponse.Message
+		} else {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if response != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, response)
+	}
+	return nil
+}
+
+func (impl GitGiteaClient) DeleteRepository(config *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRepository", "GitGiteaClient", start, err)
+	}()
+	err = impl.doRequest(context.Background(), http.MethodDelete, impl.repoPath(config.GitRepoName), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("repo deletion failed for gitea", "repo", config.GitRepoName, "err", err)
+	}
+	return err
+}
+
+func (impl GitGiteaClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, isRepoEmpty bool, err error) {
+	start := time.Now()
+	defer func() {
+		if IsGiteaRepoNotFound(err) {
+			return
+		}
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GitGiteaClient", start, err)
+	}()
+	repo := &giteaRepository{}
+	err = impl.doRequest(context.Background(), http.MethodGet, impl.repoPath(config.GitRepoName), nil, repo)
+	if err != nil {
+		impl.logger.Errorw("error in getting repo url by repo name", "owner", impl.owner, "gitRepoName", config.GitRepoName, "err", err)
+		return "", false, err
+	}
+	return repo.CloneUrl, repo.Empty, nil
+}
+
+func (impl GitGiteaClient) createRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (*giteaRepository, error) {
+	apiPath := "/user/repos"
+	if len(impl.org) > 0 {
+		apiPath = fmt.Sprintf("/orgs/%s/repos", url.PathEscape(impl.org))
+	}
+	repo := &giteaRepository{}
+	err := impl.doRequest(ctx, http.MethodPost, apiPath, &giteaCreateRepositoryOption{
+		Name:        config.GitRepoName,
+		Description: config.Description,
+		Private:     true,
+	}, repo)
+	return repo, err
+}
+
+func (impl GitGiteaClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, isEmpty bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+	repoExists := true
+	url, isEmpty, err = impl.GetRepoUrl(config)
+	if err != nil {
+		if IsGiteaRepoNotFound(err) {
+			repoExists = false
+		} else {
+			impl.logger.Errorw("error in creating gitea repo", "err", err)
+			detailedErrorGitOpsConfigActions.StageErrorMap[bean.GetRepoUrlStage] = err
+			util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+			return "", false, isEmpty, detailedErrorGitOpsConfigActions
+		}
+	}
+	if repoExists {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.GetRepoUrlStage)
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, nil)
+		return url, false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	repo, err := impl.createRepository(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating gitea repo", "repo", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateRepoStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return "", true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	impl.logger.Infow("gitea repo created", "cloneUrl", repo.CloneUrl)
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CreateRepoStage)
+
+	// unlike github, gitea creates the repository synchronously so it is available on http once the create call returns
+	url, isEmpty, err = impl.GetRepoUrl(config)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability gitea", "project", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CloneHttpStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return repo.CloneUrl, true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CloneHttpStage)
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "project", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateReadmeStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return url, true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	isEmpty = false //As we have created readme, repo is no longer empty
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CreateReadmeStage)
+
+	validated, err := impl.ensureProjectAvailabilityOnSsh(config.GitRepoName, url, config.TargetRevision)
+	if err != nil {
+		impl.logger.Errorw("error in ensuring project availability gitea", "project", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CloneSshStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return url, true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	if !validated {
+		err = fmt.Errorf("unable to validate project:%s in given time", config.GitRepoName)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CloneSshStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return "", true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CloneSshStage)
+	util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, nil)
+	return url, true, isEmpty, detailedErrorGitOpsConfigActions
+}
+
+func (impl GitGiteaClient) ensureProjectAvailabilityOnSsh(projectName string, repoUrl, targetRevision string) (bool, error) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("ensureProjectAvailabilityOnSsh", "GitGiteaClient", start, err)
+	}()
+
+	count := 0
+	for count < 3 {
+		count = count + 1
+		_, err := impl.gitOpsHelper.Clone(repoUrl, fmt.Sprintf("/ensure-clone/%s", projectName), targetRevision)
+		if err == nil {
+			impl.logger.Infow("gitea ensureProjectAvailability clone passed", "try count", count, "repoUrl", repoUrl)
+			return true, nil
+		} else {
+			impl.logger.Errorw("gitea ensureProjectAvailability clone failed", "try count", count, "err", err)
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return false, nil
+}
+
+func (impl GitGiteaClient) CreateFirstCommitOnHead(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	return impl.CreateReadme(ctx, config)
+}
+
+func (impl GitGiteaClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	var err error
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateReadme", "GitGiteaClient", start, err)
+	}()
+
+	cfg := &ChartConfig{
+		ChartName:      config.GitRepoName,
+		ChartLocation:  "",
+		FileName:       "README.md",
+		FileContent:    "@devtron",
+		ReleaseMessage: "readme",
+		ChartRepoName:  config.GitRepoName,
+		TargetRevision: config.TargetRevision,
+		UserName:       config.Username,
+		UserEmailId:    config.UserEmailId,
+	}
+	hash, _, err := impl.CommitValues(ctx, cfg, config, true)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "repo", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+// checkBranchProtection returns an error if the branch is protected against direct pushes of the gitops user,
+// a missing branch is not an error as it is created by the first commit.
+func (impl GitGiteaClient) checkBranchProtection(ctx context.Context, repoName, branchName string) error {
+	branch := &giteaBranch{}
+	err := impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/branches/%s", impl.repoPath(repoName), escapeGiteaPath(branchName)), nil, branch)
+	if IsGiteaRepoNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if branch.Protected && !branch.UserCanPush {
+		return fmt.Errorf("branch %s of repository %s is protected and the gitops user is not allowed to push to it, allow the push in the branch protection rules or enable pull request mode for the environment", branchName, repoName)
+	}
+	return nil
+}
+
+func (impl GitGiteaClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto, publishStatusConflictErrorMetrics bool) (commitHash string, commitTime time.Time, err error) {
+	start := time.Now()
+
+	branch := config.TargetRevision
+	if len(branch) == 0 {
+		branch = util.GetDefaultTargetRevision()
+	}
+	err = impl.checkBranchProtection(ctx, config.ChartRepoName, branch)
+	if err != nil {
+		impl.logger.Errorw("error in checking branch protection gitea", "repo", config.ChartRepoName, "branch", branch, "err", err)
+		util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+		return "", time.Time{}, err
+	}
+	filePath := filepath.Join(config.ChartLocation, config.FileName)
+	contentsPath := fmt.Sprintf("%s/contents/%s", impl.repoPath(config.ChartRepoName), escapeGiteaPath(filePath))
+	currentFile := &giteaContents{}
+	newFile := false
+	err = impl.doRequest(ctx, http.MethodGet, contentsPath+"?ref="+url.QueryEscape(branch), nil, currentFile)
+	if err != nil {
+		if !IsGiteaRepoNotFound(err) {
+			impl.logger.Errorw("error in getting file gitea", "config", config, "err", err)
+			util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+			return "", time.Time{}, err
+		}
+		newFile = true
+	}
+	timeNow := time.Now()
+	author := giteaIdentity{Name: config.UserName, Email: config.UserEmailId}
+	options := &giteaFileOption{
+		Content:   base64.StdEncoding.EncodeToString([]byte(config.FileContent)),
+		Message:   config.ReleaseMessage,
+		Branch:    branch,
+		Author:    author,
+		Committer: author,
+		Dates:     giteaCommitDates{Author: timeNow, Committer: timeNow},
+	}
+	method := http.MethodPost
+	if !newFile {
+		method = http.MethodPut
+		options.Sha = currentFile.Sha
+	}
+	fileResponse := &giteaFileResponse{}
+	err = impl.doRequest(ctx, method, contentsPath, options, fileResponse)
+	// gitea returns 422 if the sha does not match or the file was created in the meantime
+	if isGiteaStatusError(err, http.StatusConflict, http.StatusUnprocessableEntity) {
+		impl.logger.Warnw("conflict found in commit gitea", "config", config, "err", err)
+		if publishStatusConflictErrorMetrics {
+			util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+		}
+		return "", time.Time{}, retryFunc.NewRetryableError(err)
+	} else if err != nil {
+		impl.logger.Errorw("error in commit gitea", "config", config, "err", err)
+		util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+		return "", time.Time{}, err
+	}
+	if fileResponse.Commit == nil {
+		err = fmt.Errorf("commit not found in the response of gitea")
+		util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, err)
+		return "", time.Time{}, err
+	}
+	commitTime = time.Now() // default is current time, if found then will get updated accordingly
+	if fileResponse.Commit.Author != nil && !fileResponse.Commit.Author.Date.IsZero() {
+		commitTime = fileResponse.Commit.Author.Date
+	}
+	util.TriggerGitOpsMetrics("CommitValues", "GitGiteaClient", start, nil)
+	return fileResponse.Commit.Sha, commitTime, nil
+}
+
+func (impl GitGiteaClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateBranch", "GitGiteaClient", start, err)
+	}()
+	branchesPath := fmt.Sprintf("%s/branches", impl.repoPath(repoName))
+	err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", branchesPath, escapeGiteaPath(branch)), nil, nil)
+	if err == nil {
+		return nil
+	} else if !IsGiteaRepoNotFound(err) {
+		impl.logger.Errorw("error in getting branch gitea", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	err = impl.doRequest(ctx, http.MethodPost, branchesPath, &giteaCreateBranchOption{
+		NewBranchName: branch,
+		OldBranchName: fromBranch,
+	}, nil)
+	if err != nil {
+		impl.logger.Errorw("error in creating branch gitea", "repo", repoName, "branch", branch, "fromBranch", fromBranch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl GitGiteaClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitGiteaClient", start, err)
+	}()
+	pr := &giteaPullRequest{}
+	err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/pulls", impl.repoPath(config.ChartRepoName)), &giteaCreatePullRequestOption{
+		Head:  config.SourceBranch,
+		Base:  config.TargetBranch,
+		Title: config.Title,
+		Body:  config.Description,
+	}, pr)
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request gitea", "config", config, "err", err)
+		return nil, err
+	}
+	return getGiteaPullRequest(pr), nil
+}
+
+func (impl GitGiteaClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitGiteaClient", start, err)
+	}()
+	pr := &giteaPullRequest{}
+	err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/pulls/%d", impl.repoPath(repoName), pullRequestId), nil, pr)
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request gitea", "repo", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getGiteaPullRequest(pr), nil
+}
+
+func getGiteaPullRequest(pr *giteaPullRequest) *PullRequest {
+	pullRequest := &PullRequest{
+		Id:    pr.Number,
+		Url:   pr.HtmlUrl,
+		State: PullRequestStateOpen,
+	}
+	if pr.Merged {
+		pullRequest.State = PullRequestStateMerged
+		pullRequest.MergeCommitHash = pr.MergeCommitSha
+	} else if pr.State == "closed" {
+		pullRequest.State = PullRequestStateClosed
+	}
+	return pullRequest
+}
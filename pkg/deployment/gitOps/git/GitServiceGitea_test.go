@@ -0,0 +1,464 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/devtron-labs/common-lib/utils/retryFunc"
+	"github.com/devtron-labs/devtron/api/bean"
+	apiBean "github.com/devtron-labs/devtron/api/bean/gitOps"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/commandManager"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const (
+	giteaTestOrg   = "devtron"
+	giteaTestToken = "gitea-token"
+)
+
+type fakeGiteaFile struct {
+	content string
+	sha     string
+}
+
+type fakeGiteaBranch struct {
+	protected   bool
+	userCanPush bool
+}
+
+// fakeGitea serves the subset of the gitea v1 api used by GitGiteaClient for the repositories of giteaTestOrg
+type fakeGitea struct {
+	lock         sync.Mutex
+	cloneUrl     string
+	repos        map[string]bool
+	branches     map[string]*fakeGiteaBranch // <repo>/<branch>
+	files        map[string]*fakeGiteaFile   // <repo>/<branch>/<path>
+	pullRequests map[int]map[string]interface{}
+	commits      int
+	// commitStatus fails the next file commit with the status if set
+	commitStatus int
+	requests     []string
+}
+
+func newFakeGitea(cloneUrl string) *fakeGitea {
+	return &fakeGitea{
+		cloneUrl:     cloneUrl,
+		repos:        make(map[string]bool),
+		branches:     make(map[string]*fakeGiteaBranch),
+		files:        make(map[string]*fakeGiteaFile),
+		pullRequests: make(map[int]map[string]interface{}),
+	}
+}
+
+func (fake *fakeGitea) writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (fake *fakeGitea) notFound(w http.ResponseWriter) {
+	fake.writeJson(w, http.StatusNotFound, map[string]string{"message": "The target couldn't be found."})
+}
+
+func (fake *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	fake.requests = append(fake.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "token "+giteaTestToken {
+		fake.writeJson(w, http.StatusUnauthorized, map[string]string{"message": "token is required"})
+		return
+	}
+	apiPath := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	if r.Method == http.MethodPost && apiPath == fmt.Sprintf("/orgs/%s/repos", giteaTestOrg) {
+		option := &giteaCreateRepositoryOption{}
+		_ = json.NewDecoder(r.Body).Decode(option)
+		if !option.Private {
+			fake.writeJson(w, http.StatusUnprocessableEntity, map[string]string{"message": "repository must be private"})
+			return
+		}
+		fake.repos[option.Name] = true
+		fake.writeJson(w, http.StatusCreated, fake.repository(option.Name))
+		return
+	}
+	repoPrefix := fmt.Sprintf("/repos/%s/", giteaTestOrg)
+	if !strings.HasPrefix(apiPath, repoPrefix) {
+		fake.notFound(w)
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(apiPath, repoPrefix), "/", 3)
+	repoName := parts[0]
+	if !fake.repos[repoName] {
+		fake.notFound(w)
+		return
+	}
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			fake.writeJson(w, http.StatusOK, fake.repository(repoName))
+		case http.MethodDelete:
+			delete(fake.repos, repoName)
+			w.WriteHeader(http.StatusNoContent)
+		}
+		return
+	}
+	rest := ""
+	if len(parts) == 3 {
+		rest = parts[2]
+	}
+	switch parts[1] {
+	case "branches":
+		fake.serveBranches(w, r, repoName, rest)
+	case "contents":
+		fake.serveContents(w, r, repoName, rest)
+	case "pulls":
+		fake.servePulls(w, r, repoName, rest)
+	default:
+		fake.notFound(w)
+	}
+}
+
+func (fake *fakeGitea) repository(repoName string) map[string]interface{} {
+	empty := true
+	for key := range fake.branches {
+		if strings.HasPrefix(key, repoName+"/") {
+			empty = false
+		}
+	}
+	return map[string]interface{}{
+		"name":      repoName,
+		"clone_url": fake.cloneUrl,
+		"html_url":  fmt.Sprintf("https://gitea.example.com/%s/%s", giteaTestOrg, repoName),
+		"empty":     empty,
+	}
+}
+
+func (fake *fakeGitea) serveBranches(w http.ResponseWriter, r *http.Request, repoName, branchName string) {
+	if r.Method == http.MethodPost {
+		option := &giteaCreateBranchOption{}
+		_ = json.NewDecoder(r.Body).Decode(option)
+		if _, ok := fake.branches[repoName+"/"+option.OldBranchName]; !ok {
+			fake.notFound(w)
+			return
+		}
+		fake.branches[repoName+"/"+option.NewBranchName] = &fakeGiteaBranch{userCanPush: true}
+		fake.writeJson(w, http.StatusCreated, map[string]interface{}{"name": option.NewBranchName})
+		return
+	}
+	branch, ok := fake.branches[repoName+"/"+branchName]
+	if !ok {
+		fake.notFound(w)
+		return
+	}
+	fake.writeJson(w, http.StatusOK, map[string]interface{}{"name": branchName, "protected": branch.protected, "user_can_push": branch.userCanPush})
+}
+
+func (fake *fakeGitea) serveContents(w http.ResponseWriter, r *http.Request, repoName, filePath string) {
+	if r.Method == http.MethodGet {
+		file, ok := fake.files[repoName+"/"+r.URL.Query().Get("ref")+"/"+filePath]
+		if !ok {
+			fake.notFound(w)
+			return
+		}
+		fake.writeJson(w, http.StatusOK, map[string]interface{}{"path": filePath, "sha": file.sha})
+		return
+	}
+	option := &giteaFileOption{}
+	_ = json.NewDecoder(r.Body).Decode(option)
+	if fake.commitStatus != 0 {
+		status := fake.commitStatus
+		fake.commitStatus = 0
+		fake.writeJson(w, status, map[string]string{"message": "sha does not match"})
+		return
+	}
+	key := repoName + "/" + option.Branch + "/" + filePath
+	existing, exists := fake.files[key]
+	if (r.Method == http.MethodPost && exists) || (r.Method == http.MethodPut && (!exists || existing.sha != option.Sha)) {
+		fake.writeJson(w, http.StatusUnprocessableEntity, map[string]string{"message": "sha does not match"})
+		return
+	}
+	content, err := base64.StdEncoding.DecodeString(option.Content)
+	if err != nil {
+		fake.writeJson(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	fake.commits++
+	commitSha := fmt.Sprintf("commit-%d", fake.commits)
+	fake.files[key] = &fakeGiteaFile{content: string(content), sha: fmt.Sprintf("blob-%d", fake.commits)}
+	if _, ok := fake.branches[repoName+"/"+option.Branch]; !ok {
+		fake.branches[repoName+"/"+option.Branch] = &fakeGiteaBranch{userCanPush: true}
+	}
+	status := http.StatusCreated
+	if r.Method == http.MethodPut {
+		status = http.StatusOK
+	}
+	fake.writeJson(w, status, map[string]interface{}{
+		"commit": map[string]interface{}{
+			"sha":    commitSha,
+			"author": map[string]interface{}{"name": option.Author.Name, "email": option.Author.Email, "date": option.Dates.Author},
+		},
+	})
+}
+
+func (fake *fakeGitea) servePulls(w http.ResponseWriter, r *http.Request, repoName, index string) {
+	if r.Method == http.MethodPost {
+		option := &giteaCreatePullRequestOption{}
+		_ = json.NewDecoder(r.Body).Decode(option)
+		number := len(fake.pullRequests) + 1
+		fake.pullRequests[number] = map[string]interface{}{
+			"number":           number,
+			"html_url":         fmt.Sprintf("https://gitea.example.com/%s/%s/pulls/%d", giteaTestOrg, repoName, number),
+			"state":            "open",
+			"merged":           false,
+			"merge_commit_sha": nil,
+			"head":             option.Head,
+			"base":             option.Base,
+		}
+		fake.writeJson(w, http.StatusCreated, fake.pullRequests[number])
+		return
+	}
+	for number, pr := range fake.pullRequests {
+		if fmt.Sprint(number) == index {
+			fake.writeJson(w, http.StatusOK, pr)
+			return
+		}
+	}
+	fake.notFound(w)
+}
+
+// newBareTestRepo creates a bare repository with a commit on master, the fake gitea serves it as the clone url
+func newBareTestRepo(t *testing.T) string {
+	dir := t.TempDir()
+	bareDir := filepath.Join(dir, "repo.git")
+	workDir := filepath.Join(dir, "work")
+	commands := [][]string{
+		{"git", "init", "--bare", bareDir},
+		{"git", "init", workDir},
+		{"git", "-C", workDir, "checkout", "-b", "master"},
+		{"git", "-C", workDir, "-c", "user.name=devtron", "-c", "user.email=devtron@example.com", "commit", "--allow-empty", "-m", "init"},
+		{"git", "-C", workDir, "push", bareDir, "master"},
+	}
+	for _, command := range commands {
+		if out, err := exec.Command(command[0], command[1:]...).CombinedOutput(); err != nil {
+			t.Skipf("git is not usable, %v: %s", err, out)
+		}
+	}
+	return bareDir
+}
+
+func newTestGiteaClient(t *testing.T, fake *fakeGitea) GitGiteaClient {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	logger := zap.NewNop().Sugar()
+	gitOpsHelper, err := NewGitOpsHelperImpl(&commandManager.BasicAuth{Username: "devtron", Password: giteaTestToken}, logger, &bean.TLSConfig{}, false)
+	assert.NoError(t, err)
+	client, err := NewGitGiteaClient(server.URL, "devtron", giteaTestToken, giteaTestOrg, logger, gitOpsHelper, nil)
+	assert.NoError(t, err)
+	return client
+}
+
+func newGiteaTestChartConfig(repoName, content string) *ChartConfig {
+	return &ChartConfig{
+		FileName:       "values.yaml",
+		ChartLocation:  "app/env",
+		FileContent:    content,
+		ReleaseMessage: "release",
+		ChartRepoName:  repoName,
+		TargetRevision: "master",
+		UserName:       "devtron",
+		UserEmailId:    "devtron@example.com",
+	}
+}
+
+func TestNewGitOpsClient_Gitea(t *testing.T) {
+	client, err := NewGitOpsClient(&gitBean.GitConfig{
+		GitProvider:       gitBean.GITEA_PROVIDER,
+		GitHost:           "https://gitea.example.com",
+		GitUserName:       "devtron",
+		GitToken:          giteaTestToken,
+		GiteaOrganization: giteaTestOrg,
+	}, zap.NewNop().Sugar(), nil)
+	assert.NoError(t, err)
+	giteaClient, ok := client.(GitGiteaClient)
+	assert.True(t, ok)
+	assert.Equal(t, "https://gitea.example.com/api/v1", giteaClient.baseUrl)
+	assert.Equal(t, giteaTestOrg, giteaClient.owner)
+}
+
+func TestGitGiteaClient_CreateRepository(t *testing.T) {
+	t.Run("existing repository is not created again", func(t *testing.T) {
+		fake := newFakeGitea("https://gitea.example.com/devtron/existing.git")
+		fake.repos["existing"] = true
+		client := newTestGiteaClient(t, fake)
+		url, isNew, isEmpty, actions := client.CreateRepository(context.Background(), &apiBean.GitOpsConfigDto{GitRepoName: "existing"})
+		assert.Empty(t, actions.StageErrorMap)
+		assert.Equal(t, []string{gitBean.GetRepoUrlStage}, actions.SuccessfulStages)
+		assert.Equal(t, "https://gitea.example.com/devtron/existing.git", url)
+		assert.False(t, isNew)
+		assert.True(t, isEmpty)
+	})
+
+	t.Run("new repository is created with a readme", func(t *testing.T) {
+		fake := newFakeGitea(newBareTestRepo(t))
+		client := newTestGiteaClient(t, fake)
+		repoName := fmt.Sprintf("gitea-new-%d", os.Getpid())
+		url, isNew, isEmpty, actions := client.CreateRepository(context.Background(), &apiBean.GitOpsConfigDto{
+			GitRepoName:    repoName,
+			Description:    "helm chart for app",
+			TargetRevision: "master",
+			Username:       "devtron",
+			UserEmailId:    "devtron@example.com",
+		})
+		assert.Empty(t, actions.StageErrorMap)
+		assert.Equal(t, []string{gitBean.CreateRepoStage, gitBean.CloneHttpStage, gitBean.CreateReadmeStage, gitBean.CloneSshStage}, actions.SuccessfulStages)
+		assert.Equal(t, fake.cloneUrl, url)
+		assert.True(t, isNew)
+		assert.False(t, isEmpty)
+		assert.Equal(t, "@devtron", fake.files[repoName+"/master/README.md"].content)
+	})
+
+	t.Run("create failure is reported on the create stage", func(t *testing.T) {
+		fake := newFakeGitea("")
+		client := newTestGiteaClient(t, fake)
+		client.org = "unknown"
+		_, _, _, actions := client.CreateRepository(context.Background(), &apiBean.GitOpsConfigDto{GitRepoName: "app"})
+		assert.Contains(t, actions.StageErrorMap, gitBean.CreateRepoStage)
+		assert.True(t, IsGiteaRepoNotFound(actions.StageErrorMap[gitBean.CreateRepoStage]))
+	})
+}
+
+func TestGitGiteaClient_GetRepoUrl(t *testing.T) {
+	fake := newFakeGitea("https://gitea.example.com/devtron/app.git")
+	fake.repos["app"] = true
+	client := newTestGiteaClient(t, fake)
+
+	url, _, err := client.GetRepoUrl(&apiBean.GitOpsConfigDto{GitRepoName: "app"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/devtron/app.git", url)
+
+	_, _, err = client.GetRepoUrl(&apiBean.GitOpsConfigDto{GitRepoName: "missing"})
+	assert.True(t, IsGiteaRepoNotFound(err))
+
+	client.token = "invalid"
+	_, _, err = client.GetRepoUrl(&apiBean.GitOpsConfigDto{GitRepoName: "app"})
+	assert.EqualError(t, err, "gitea api error, status: 401, message: token is required")
+}
+
+func TestGitGiteaClient_CommitValues(t *testing.T) {
+	fake := newFakeGitea("")
+	fake.repos["app"] = true
+	fake.branches["app/master"] = &fakeGiteaBranch{userCanPush: true}
+	fake.branches["app/protected"] = &fakeGiteaBranch{protected: true}
+	fake.branches["app/protected-allowed"] = &fakeGiteaBranch{protected: true, userCanPush: true}
+	client := newTestGiteaClient(t, fake)
+	ctx := context.Background()
+
+	commitHash, commitTime, err := client.CommitValues(ctx, newGiteaTestChartConfig("app", "replicaCount: 1"), nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "commit-1", commitHash)
+	assert.False(t, commitTime.IsZero())
+	assert.Equal(t, "replicaCount: 1", fake.files["app/master/app/env/values.yaml"].content)
+
+	// the existing file is updated with its sha
+	commitHash, _, err = client.CommitValues(ctx, newGiteaTestChartConfig("app", "replicaCount: 2"), nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "commit-2", commitHash)
+	assert.Equal(t, "replicaCount: 2", fake.files["app/master/app/env/values.yaml"].content)
+
+	// a conflicting commit is retried by the caller
+	fake.commitStatus = http.StatusUnprocessableEntity
+	_, _, err = client.CommitValues(ctx, newGiteaTestChartConfig("app", "replicaCount: 3"), nil, true)
+	assert.True(t, retryFunc.IsRetryableError(err))
+
+	protectedConfig := newGiteaTestChartConfig("app", "replicaCount: 4")
+	protectedConfig.TargetRevision = "protected"
+	_, _, err = client.CommitValues(ctx, protectedConfig, nil, true)
+	assert.ErrorContains(t, err, "branch protected of repository app is protected")
+	assert.False(t, retryFunc.IsRetryableError(err))
+	assert.NotContains(t, fake.files, "app/protected/app/env/values.yaml")
+
+	protectedConfig.TargetRevision = "protected-allowed"
+	_, _, err = client.CommitValues(ctx, protectedConfig, nil, true)
+	assert.NoError(t, err)
+}
+
+func TestGitGiteaClient_PullRequest(t *testing.T) {
+	fake := newFakeGitea("")
+	fake.repos["app"] = true
+	fake.branches["app/master"] = &fakeGiteaBranch{userCanPush: true}
+	client := newTestGiteaClient(t, fake)
+	ctx := context.Background()
+
+	err := client.CreateBranch(ctx, "app", "devtron/release-1-env-2", "master", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, fake.branches, "app/devtron/release-1-env-2")
+	// creating the branch again is a no-op
+	requestCount := len(fake.requests)
+	err = client.CreateBranch(ctx, "app", "devtron/release-1-env-2", "master", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /api/v1/repos/devtron/app/branches/devtron/release-1-env-2"}, fake.requests[requestCount:])
+	assert.True(t, IsGiteaRepoNotFound(client.CreateBranch(ctx, "app", "feature", "missing", nil)))
+
+	pr, err := client.CreatePullRequest(ctx, &PullRequestConfig{
+		ChartRepoName: "app",
+		SourceBranch:  "devtron/release-1-env-2",
+		TargetBranch:  "master",
+		Title:         "Deploy app",
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &PullRequest{Id: 1, Url: "https://gitea.example.com/devtron/app/pulls/1", State: PullRequestStateOpen}, pr)
+	assert.Equal(t, "devtron/release-1-env-2", fake.pullRequests[1]["head"])
+
+	fake.pullRequests[1]["state"] = "closed"
+	fake.pullRequests[1]["merged"] = true
+	fake.pullRequests[1]["merge_commit_sha"] = "merge-sha"
+	pr, err = client.GetPullRequest(ctx, "app", 1, nil)
+	assert.NoError(t, err)
+	assert.True(t, pr.IsMerged())
+	assert.Equal(t, "merge-sha", pr.MergeCommitHash)
+
+	fake.pullRequests[1]["merged"] = false
+	fake.pullRequests[1]["merge_commit_sha"] = nil
+	pr, err = client.GetPullRequest(ctx, "app", 1, nil)
+	assert.NoError(t, err)
+	assert.True(t, pr.IsClosed())
+
+	_, err = client.GetPullRequest(ctx, "app", 2, nil)
+	assert.True(t, IsGiteaRepoNotFound(err))
+}
+
+func TestGitGiteaClient_DeleteRepository(t *testing.T) {
+	fake := newFakeGitea("")
+	fake.repos["app"] = true
+	client := newTestGiteaClient(t, fake)
+	assert.NoError(t, client.DeleteRepository(&apiBean.GitOpsConfigDto{GitRepoName: "app"}))
+	assert.NotContains(t, fake.repos, "app")
+	assert.True(t, IsGiteaRepoNotFound(client.DeleteRepository(&apiBean.GitOpsConfigDto{GitRepoName: "app"})))
+}
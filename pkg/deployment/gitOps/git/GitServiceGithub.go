@@ -26,6 +26,7 @@ import (
 	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
 	globalUtil "github.com/devtron-labs/devtron/util"
+	"github.com/devtron-labs/devtron/util/gitUtil"
 	"github.com/google/go-github/github"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
@@ -360,3 +361,77 @@ func (impl GitHubClient) ensureProjectAvailabilityOnSsh(projectName string, repo
 	}
 	return false, nil
 }
+
+func (impl GitHubClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreateBranch", "GitHubClient", start, err)
+	}()
+	_, _, err = impl.client.Git.GetRef(ctx, impl.org, repoName, gitUtil.GetRefBranchHead(branch))
+	if err == nil {
+		return nil
+	} else if responseErr, ok := err.(*github.ErrorResponse); !ok || responseErr.Response.StatusCode != http2.StatusNotFound {
+		impl.logger.Errorw("error in getting branch github", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	baseRef, _, err := impl.client.Git.GetRef(ctx, impl.org, repoName, gitUtil.GetRefBranchHead(fromBranch))
+	if err != nil {
+		impl.logger.Errorw("error in getting base branch github", "repo", repoName, "branch", fromBranch, "err", err)
+		return err
+	}
+	_, _, err = impl.client.Git.CreateRef(ctx, impl.org, repoName, &github.Reference{
+		Ref:    github.String(gitUtil.GetRefBranchHead(branch)),
+		Object: &github.GitObject{SHA: baseRef.Object.SHA},
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating branch github", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl GitHubClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreatePullRequest", "GitHubClient", start, err)
+	}()
+	pr, _, err := impl.client.PullRequests.Create(ctx, impl.org, config.ChartRepoName, &github.NewPullRequest{
+		Title: github.String(config.Title),
+		Head:  github.String(config.SourceBranch),
+		Base:  github.String(config.TargetBranch),
+		Body:  github.String(config.Description),
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request github", "config", config, "err", err)
+		return nil, err
+	}
+	return getGithubPullRequest(pr), nil
+}
+
+func (impl GitHubClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("GetPullRequest", "GitHubClient", start, err)
+	}()
+	pr, _, err := impl.client.PullRequests.Get(ctx, impl.org, repoName, pullRequestId)
+	if err != nil {
+		impl.logger.Errorw("error in getting pull request github", "repo", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getGithubPullRequest(pr), nil
+}
+
+func getGithubPullRequest(pr *github.PullRequest) *PullRequest {
+	pullRequest := &PullRequest{
+		Id:    pr.GetNumber(),
+		Url:   pr.GetHTMLURL(),
+		State: PullRequestStateOpen,
+	}
+	if pr.GetMerged() {
+		pullRequest.State = PullRequestStateMerged
+		pullRequest.MergeCommitHash = pr.GetMergeCommitSHA()
+	} else if pr.GetState() == "closed" {
+		pullRequest.State = PullRequestStateClosed
+	}
+	return pullRequest
+}
@@ -398,3 +398,84 @@ func (impl GitLabClient) CommitValues(ctx context.Context, config *ChartConfig,
 	util.TriggerGitOpsMetrics("CommitValues", "GitLabClient", start, nil)
 	return c.ID, commitTime, err
 }
+
+func (impl GitLabClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreateBranch", "GitLabClient", start, err)
+	}()
+	projectPath := fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, repoName)
+	_, res, err := impl.client.Branches.GetBranch(projectPath, branch, gitlab.WithContext(ctx))
+	if err == nil {
+		return nil
+	} else if res == nil || res.StatusCode != http.StatusNotFound {
+		impl.logger.Errorw("error in getting branch gitlab", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	_, _, err = impl.client.Branches.CreateBranch(projectPath, &gitlab.CreateBranchOptions{
+		Branch: gitlab.String(branch),
+		Ref:    gitlab.String(fromBranch),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating branch gitlab", "repo", repoName, "branch", branch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl GitLabClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.CreateMergeRequest(fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, config.ChartRepoName),
+		&gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.String(config.Title),
+			Description:        gitlab.String(config.Description),
+			SourceBranch:       gitlab.String(config.SourceBranch),
+			TargetBranch:       gitlab.String(config.TargetBranch),
+			RemoveSourceBranch: gitlab.Bool(true),
+		}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating merge request gitlab", "config", config, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequest(mr), nil
+}
+
+func (impl GitLabClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *bean2.GitOpsConfigDto) (pullRequest *PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.GetMergeRequest(fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, repoName),
+		pullRequestId, &gitlab.GetMergeRequestsOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in getting merge request gitlab", "repo", repoName, "pullRequestId", pullRequestId, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequest(mr), nil
+}
+
+func getGitlabPullRequest(mr *gitlab.MergeRequest) *PullRequest {
+	pullRequest := &PullRequest{
+		Id:    mr.IID,
+		Url:   mr.WebURL,
+		State: PullRequestStateOpen,
+	}
+	switch mr.State {
+	case "merged":
+		pullRequest.State = PullRequestStateMerged
+		// fast-forward merges do not create a merge commit, the head of the source branch is on target then
+		pullRequest.MergeCommitHash = mr.MergeCommitSHA
+		if len(pullRequest.MergeCommitHash) == 0 {
+			pullRequest.MergeCommitHash = mr.SquashCommitSHA
+		}
+		if len(pullRequest.MergeCommitHash) == 0 {
+			pullRequest.MergeCommitHash = mr.SHA
+		}
+	case "closed", "locked":
+		pullRequest.State = PullRequestStateClosed
+	}
+	return pullRequest
+}
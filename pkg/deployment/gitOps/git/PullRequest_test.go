@@ -0,0 +1,318 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/internal/util"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	prTestRepo         = "app-env"
+	prTestPullRequest  = 7
+	prTestSourceBranch = "devtron/release-6-env-3"
+	prTestTargetBranch = "master"
+)
+
+// fakePullRequestServer answers the create and get pull request calls of a provider with the same pull request
+type fakePullRequestServer struct {
+	createPath  string
+	getPath     string
+	pullRequest interface{}
+	// created is the body of the create call
+	created map[string]interface{}
+}
+
+func (fake *fakePullRequestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodPost && path == fake.createPath:
+		fake.created = map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&fake.created)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && path == fake.getPath:
+		w.Header().Set("Content-Type", "application/json")
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(fake.pullRequest)
+}
+
+func newPullRequestTestConfig() *PullRequestConfig {
+	return &PullRequestConfig{
+		ChartRepoName: prTestRepo,
+		SourceBranch:  prTestSourceBranch,
+		TargetBranch:  prTestTargetBranch,
+		Title:         "Deploy app to prod (release 6)",
+		Description:   "Opened by Devtron",
+	}
+}
+
+type pullRequestStateTest struct {
+	name            string
+	pullRequest     interface{}
+	wantState       PullRequestState
+	wantMergeCommit string
+}
+
+// assertPullRequestStates creates and gets the pull request through the client for each of the states served
+func assertPullRequestStates(t *testing.T, fake *fakePullRequestServer, client GitOpsClient, gitOpsConfig *apiBean.GitOpsConfigDto,
+	wantUrl string, tests []pullRequestStateTest) map[string]interface{} {
+	var created map[string]interface{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.pullRequest = tt.pullRequest
+			pr, err := client.CreatePullRequest(context.Background(), newPullRequestTestConfig(), gitOpsConfig)
+			assert.Nil(t, err)
+			assert.Equal(t, prTestPullRequest, pr.Id)
+			assert.Equal(t, wantUrl, pr.Url)
+			assert.Equal(t, tt.wantState, pr.State)
+			created = fake.created
+
+			pr, err = client.GetPullRequest(context.Background(), prTestRepo, prTestPullRequest, gitOpsConfig)
+			assert.Nil(t, err)
+			assert.Equal(t, prTestPullRequest, pr.Id)
+			assert.Equal(t, tt.wantState, pr.State)
+			assert.Equal(t, tt.wantMergeCommit, pr.MergeCommitHash)
+			assert.Equal(t, tt.wantState == PullRequestStateMerged, pr.IsMerged())
+			assert.Equal(t, tt.wantState == PullRequestStateClosed, pr.IsClosed())
+		})
+	}
+	return created
+}
+
+func TestGitHubClient_PullRequest(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	fake := &fakePullRequestServer{
+		createPath: "/api/v3/repos/devtron/app-env/pulls",
+		getPath:    "/api/v3/repos/devtron/app-env/pulls/7",
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client, err := NewGithubClient(server.URL+"/", "token", "devtron", logger, nil, nil)
+	assert.Nil(t, err)
+	prUrl := "https://github.example.com/devtron/app-env/pull/7"
+	githubPullRequest := func(state string, merged bool, mergeCommit string) map[string]interface{} {
+		return map[string]interface{}{"number": 7, "html_url": prUrl, "state": state, "merged": merged, "merge_commit_sha": mergeCommit}
+	}
+	created := assertPullRequestStates(t, fake, client, &apiBean.GitOpsConfigDto{}, prUrl, []pullRequestStateTest{
+		{
+			name:        "open",
+			pullRequest: githubPullRequest("open", false, "test-merge"),
+			wantState:   PullRequestStateOpen,
+		},
+		{
+			name:            "merged",
+			pullRequest:     githubPullRequest("closed", true, "abc123"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "abc123",
+		},
+		{
+			name:        "closed without merge",
+			pullRequest: githubPullRequest("closed", false, ""),
+			wantState:   PullRequestStateClosed,
+		},
+	})
+	assert.Equal(t, prTestSourceBranch, created["head"])
+	assert.Equal(t, prTestTargetBranch, created["base"])
+}
+
+func TestGitLabClient_PullRequest(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	fake := &fakePullRequestServer{
+		createPath: "/api/v4/projects/devtron%2Fapp-env/merge_requests",
+		getPath:    "/api/v4/projects/devtron%2Fapp-env/merge_requests/7",
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	gitlabClient, err := CreateGitlabClient(server.URL, "token", nil)
+	assert.Nil(t, err)
+	client := GitLabClient{client: gitlabClient, config: &gitBean.GitConfig{GitlabGroupPath: "devtron"}, logger: logger}
+	prUrl := "https://gitlab.example.com/devtron/app-env/-/merge_requests/7"
+	gitlabMergeRequest := func(state, mergeCommit, squashCommit, sha string) map[string]interface{} {
+		return map[string]interface{}{"iid": 7, "web_url": prUrl, "state": state,
+			"merge_commit_sha": mergeCommit, "squash_commit_sha": squashCommit, "sha": sha}
+	}
+	created := assertPullRequestStates(t, fake, client, &apiBean.GitOpsConfigDto{}, prUrl, []pullRequestStateTest{
+		{
+			name:        "opened",
+			pullRequest: gitlabMergeRequest("opened", "", "", "def456"),
+			wantState:   PullRequestStateOpen,
+		},
+		{
+			name:            "merged with a merge commit",
+			pullRequest:     gitlabMergeRequest("merged", "abc123", "", "def456"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "abc123",
+		},
+		{
+			name:            "merged with squash",
+			pullRequest:     gitlabMergeRequest("merged", "", "fed789", "def456"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "fed789",
+		},
+		{
+			name:            "merged fast-forward",
+			pullRequest:     gitlabMergeRequest("merged", "", "", "def456"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "def456",
+		},
+		{
+			name:        "closed without merge",
+			pullRequest: gitlabMergeRequest("closed", "", "", "def456"),
+			wantState:   PullRequestStateClosed,
+		},
+		{
+			name:        "locked",
+			pullRequest: gitlabMergeRequest("locked", "", "", "def456"),
+			wantState:   PullRequestStateClosed,
+		},
+	})
+	assert.Equal(t, prTestSourceBranch, created["source_branch"])
+	assert.Equal(t, prTestTargetBranch, created["target_branch"])
+}
+
+func TestGitBitbucketClient_PullRequest(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	fake := &fakePullRequestServer{
+		createPath: "/repositories/workspace/app-env/pullrequests/",
+		getPath:    "/repositories/workspace/app-env/pullrequests/7",
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := NewGitBitbucketClient("user", "token", server.URL, logger, nil, nil)
+	apiUrl, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	client.client.SetApiBaseURL(*apiUrl)
+	prUrl := "https://bitbucket.org/workspace/app-env/pull-requests/7"
+	bitbucketPullRequest := func(state, mergeCommit string) map[string]interface{} {
+		pullRequest := map[string]interface{}{"id": 7, "state": state,
+			"links": map[string]interface{}{"html": map[string]interface{}{"href": prUrl}}}
+		if len(mergeCommit) > 0 {
+			pullRequest["merge_commit"] = map[string]interface{}{"hash": mergeCommit}
+		}
+		return pullRequest
+	}
+	created := assertPullRequestStates(t, fake, client, &apiBean.GitOpsConfigDto{BitBucketWorkspaceId: "workspace"}, prUrl, []pullRequestStateTest{
+		{
+			name:        "open",
+			pullRequest: bitbucketPullRequest("OPEN", ""),
+			wantState:   PullRequestStateOpen,
+		},
+		{
+			name:            "merged",
+			pullRequest:     bitbucketPullRequest("MERGED", "abc123"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "abc123",
+		},
+		{
+			name:        "declined",
+			pullRequest: bitbucketPullRequest("DECLINED", ""),
+			wantState:   PullRequestStateClosed,
+		},
+		{
+			name:        "superseded",
+			pullRequest: bitbucketPullRequest("SUPERSEDED", ""),
+			wantState:   PullRequestStateClosed,
+		},
+	})
+	source, _ := created["source"].(map[string]interface{})
+	destination, _ := created["destination"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": prTestSourceBranch}, source["branch"])
+	assert.Equal(t, map[string]interface{}{"name": prTestTargetBranch}, destination["branch"])
+}
+
+// fakeAzureDevOps serves the resource locations the azure devops client resolves its routes from, along with the pull requests
+type fakeAzureDevOps struct {
+	*fakePullRequestServer
+}
+
+func (fake *fakeAzureDevOps) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodOptions && strings.TrimSuffix(r.URL.Path, "/") == "/_apis":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 2, "value": []map[string]interface{}{
+			{"id": "e81700f7-3be2-46de-8624-2eb35882fcaa", "area": "Location", "resourceName": "ResourceAreas",
+				"routeTemplate": "_apis/{resource}/{areaId}", "resourceVersion": 1, "minVersion": "1.0", "maxVersion": "5.1", "releasedVersion": "0.0"},
+			{"id": "9946fd70-0d40-406e-b686-b4744cbbcc37", "area": "git", "resourceName": "pullRequests",
+				"routeTemplate":   "{project}/_apis/{area}/repositories/{repositoryId}/{resource}/{pullRequestId}",
+				"resourceVersion": 1, "minVersion": "1.0", "maxVersion": "5.1", "releasedVersion": "5.1"},
+		}})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/_apis/ResourceAreas"):
+		// on prem servers have no resource areas, the routes are resolved from the base url
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 0, "value": []interface{}{}})
+	default:
+		fake.fakePullRequestServer.ServeHTTP(w, r)
+	}
+}
+
+func TestGitAzureClient_PullRequest(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	fake := &fakeAzureDevOps{&fakePullRequestServer{
+		createPath: "/project/_apis/git/repositories/app-env/pullRequests",
+		getPath:    "/project/_apis/git/repositories/app-env/pullRequests/7",
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client, err := NewGitAzureClient("token", server.URL, "project", logger, nil, nil)
+	assert.Nil(t, err)
+	repoUrl := "https://dev.azure.com/org/project/_git/app-env"
+	azurePullRequest := func(status, mergeCommit string) map[string]interface{} {
+		pullRequest := map[string]interface{}{"pullRequestId": 7, "status": status,
+			"repository": map[string]interface{}{"webUrl": repoUrl}}
+		if len(mergeCommit) > 0 {
+			pullRequest["lastMergeCommit"] = map[string]interface{}{"commitId": mergeCommit}
+		}
+		return pullRequest
+	}
+	created := assertPullRequestStates(t, fake.fakePullRequestServer, client, &apiBean.GitOpsConfigDto{}, repoUrl+"/pullrequest/7", []pullRequestStateTest{
+		{
+			name:        "active",
+			pullRequest: azurePullRequest("active", "abc123"),
+			wantState:   PullRequestStateOpen,
+		},
+		{
+			name:            "completed",
+			pullRequest:     azurePullRequest("completed", "abc123"),
+			wantState:       PullRequestStateMerged,
+			wantMergeCommit: "abc123",
+		},
+		{
+			name:        "abandoned",
+			pullRequest: azurePullRequest("abandoned", ""),
+			wantState:   PullRequestStateClosed,
+		},
+	})
+	assert.Equal(t, "refs/heads/"+prTestSourceBranch, created["sourceRefName"])
+	assert.Equal(t, "refs/heads/"+prTestTargetBranch, created["targetRefName"])
+}
@@ -52,3 +52,15 @@ func (u *UnimplementedGitOpsClient) CreateReadme(ctx context.Context, config *gi
 func (u *UnimplementedGitOpsClient) CreateFirstCommitOnHead(ctx context.Context, config *gitOps.GitOpsConfigDto) (string, error) {
 	return "", fmt.Errorf("invalid gitops config found, please configure gitops properly and try again")
 }
+
+func (u *UnimplementedGitOpsClient) CreateBranch(ctx context.Context, repoName, branch, fromBranch string, gitOpsConfig *gitOps.GitOpsConfigDto) error {
+	return fmt.Errorf("invalid gitops config found, please configure gitops properly and try again")
+}
+
+func (u *UnimplementedGitOpsClient) CreatePullRequest(ctx context.Context, config *PullRequestConfig, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequest, error) {
+	return nil, fmt.Errorf("invalid gitops config found, please configure gitops properly and try again")
+}
+
+func (u *UnimplementedGitOpsClient) GetPullRequest(ctx context.Context, repoName string, pullRequestId int, gitOpsConfig *gitOps.GitOpsConfigDto) (*PullRequest, error) {
+	return nil, fmt.Errorf("invalid gitops config found, please configure gitops properly and try again")
+}
@@ -33,6 +33,7 @@ func ConvertGitOpsConfigToGitConfig(dto *bean2.GitOpsConfigDto) *bean.GitConfig
 		AzureProject:          dto.AzureProjectName,
 		BitbucketWorkspaceId:  dto.BitBucketWorkspaceId,
 		BitbucketProjectKey:   dto.BitBucketProjectKey,
+		GiteaOrganization:     dto.GiteaOrgId,
 		EnableTLSVerification: dto.EnableTLSVerification,
 	}
 	if dto.TLSConfig != nil {
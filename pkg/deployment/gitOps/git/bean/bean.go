@@ -33,6 +33,7 @@ type GitConfig struct {
 	AzureProject         string
 	BitbucketWorkspaceId string
 	BitbucketProjectKey  string
+	GiteaOrganization    string
 
 	IsActiveConfig bool //flag to check if the gitOps config is active
 
@@ -27,7 +27,9 @@ const (
 	GITHUB_PROVIDER       = "GITHUB"
 	AZURE_DEVOPS_PROVIDER = "AZURE_DEVOPS"
 	BITBUCKET_PROVIDER    = "BITBUCKET_CLOUD"
+	GITEA_PROVIDER        = "GITEA" // also used for Forgejo, which serves the same api
 	GITHUB_API_V3         = "api/v3"
+	GITEA_API_V1          = "api/v1"
 	GITHUB_HOST           = "github.com"
 	GIT_TLS_DIR           = "/tmp/gitops/tls"
 )
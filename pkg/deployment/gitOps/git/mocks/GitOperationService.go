@@ -0,0 +1,480 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/common/bean"
+
+	git "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+
+	gitOps "github.com/devtron-labs/devtron/api/bean/gitOps"
+
+	gitbean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// GitOperationService is an autogenerated mock type for the GitOperationService type
+type GitOperationService struct {
+	mock.Mock
+}
+
+// CloneChartForHelmApp provides a mock function with given fields: helmAppName, gitRepoUrl, targetRevision
+func (_m *GitOperationService) CloneChartForHelmApp(helmAppName string, gitRepoUrl string, targetRevision string) (string, error) {
+	ret := _m.Called(helmAppName, gitRepoUrl, targetRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneChartForHelmApp")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
+		return rf(helmAppName, gitRepoUrl, targetRevision)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(helmAppName, gitRepoUrl, targetRevision)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(helmAppName, gitRepoUrl, targetRevision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommitValues provides a mock function with given fields: ctx, chartGitAttr
+func (_m *GitOperationService) CommitValues(ctx context.Context, chartGitAttr *git.ChartConfig) (string, time.Time, error) {
+	ret := _m.Called(ctx, chartGitAttr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitValues")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *git.ChartConfig) (string, time.Time, error)); ok {
+		return rf(ctx, chartGitAttr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *git.ChartConfig) string); ok {
+		r0 = rf(ctx, chartGitAttr)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *git.ChartConfig) time.Time); ok {
+		r1 = rf(ctx, chartGitAttr)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *git.ChartConfig) error); ok {
+		r2 = rf(ctx, chartGitAttr)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateBranch provides a mock function with given fields: ctx, gitOpsRepoName, branch, fromBranch
+func (_m *GitOperationService) CreateBranch(ctx context.Context, gitOpsRepoName string, branch string, fromBranch string) error {
+	ret := _m.Called(ctx, gitOpsRepoName, branch, fromBranch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBranch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, gitOpsRepoName, branch, fromBranch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateFirstCommitOnHead provides a mock function with given fields: ctx, gitOpsRepoName, userId
+func (_m *GitOperationService) CreateFirstCommitOnHead(ctx context.Context, gitOpsRepoName string, userId int32) error {
+	ret := _m.Called(ctx, gitOpsRepoName, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFirstCommitOnHead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int32) error); ok {
+		r0 = rf(ctx, gitOpsRepoName, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGitRepositoryForDevtronApp provides a mock function with given fields: ctx, gitOpsRepoName, targetRevision, userId
+func (_m *GitOperationService) CreateGitRepositoryForDevtronApp(ctx context.Context, gitOpsRepoName string, targetRevision string, userId int32) (*bean.ChartGitAttribute, error) {
+	ret := _m.Called(ctx, gitOpsRepoName, targetRevision, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGitRepositoryForDevtronApp")
+	}
+
+	var r0 *bean.ChartGitAttribute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) (*bean.ChartGitAttribute, error)); ok {
+		return rf(ctx, gitOpsRepoName, targetRevision, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) *bean.ChartGitAttribute); ok {
+		r0 = rf(ctx, gitOpsRepoName, targetRevision, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.ChartGitAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int32) error); ok {
+		r1 = rf(ctx, gitOpsRepoName, targetRevision, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePullRequest provides a mock function with given fields: ctx, prConfig
+func (_m *GitOperationService) CreatePullRequest(ctx context.Context, prConfig *git.PullRequestConfig) (*git.PullRequest, error) {
+	ret := _m.Called(ctx, prConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePullRequest")
+	}
+
+	var r0 *git.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *git.PullRequestConfig) (*git.PullRequest, error)); ok {
+		return rf(ctx, prConfig)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *git.PullRequestConfig) *git.PullRequest); ok {
+		r0 = rf(ctx, prConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*git.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *git.PullRequestConfig) error); ok {
+		r1 = rf(ctx, prConfig)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRepository provides a mock function with given fields: ctx, dto, userId
+func (_m *GitOperationService) CreateRepository(ctx context.Context, dto *gitOps.GitOpsConfigDto, userId int32) (string, bool, bool, error) {
+	ret := _m.Called(ctx, dto, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRepository")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 bool
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gitOps.GitOpsConfigDto, int32) (string, bool, bool, error)); ok {
+		return rf(ctx, dto, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gitOps.GitOpsConfigDto, int32) string); ok {
+		r0 = rf(ctx, dto, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gitOps.GitOpsConfigDto, int32) bool); ok {
+		r1 = rf(ctx, dto, userId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *gitOps.GitOpsConfigDto, int32) bool); ok {
+		r2 = rf(ctx, dto, userId)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context, *gitOps.GitOpsConfigDto, int32) error); ok {
+		r3 = rf(ctx, dto, userId)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetClonedDir provides a mock function with given fields: ctx, chartDir, repoUrl, targetRevision
+func (_m *GitOperationService) GetClonedDir(ctx context.Context, chartDir string, repoUrl string, targetRevision string) (string, error) {
+	ret := _m.Called(ctx, chartDir, repoUrl, targetRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClonedDir")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, chartDir, repoUrl, targetRevision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, chartDir, repoUrl, targetRevision)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, chartDir, repoUrl, targetRevision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPullRequest provides a mock function with given fields: ctx, gitOpsRepoName, pullRequestId
+func (_m *GitOperationService) GetPullRequest(ctx context.Context, gitOpsRepoName string, pullRequestId int) (*git.PullRequest, error) {
+	ret := _m.Called(ctx, gitOpsRepoName, pullRequestId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPullRequest")
+	}
+
+	var r0 *git.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*git.PullRequest, error)); ok {
+		return rf(ctx, gitOpsRepoName, pullRequestId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *git.PullRequest); ok {
+		r0 = rf(ctx, gitOpsRepoName, pullRequestId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*git.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, gitOpsRepoName, pullRequestId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRepoUrlWithUserName provides a mock function with given fields: url
+func (_m *GitOperationService) GetRepoUrlWithUserName(url string) (string, error) {
+	ret := _m.Called(url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepoUrlWithUserName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(url)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(url)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GitPull provides a mock function with given fields: clonedDir, repoUrl, targetRevision
+func (_m *GitOperationService) GitPull(clonedDir string, repoUrl string, targetRevision string) error {
+	ret := _m.Called(clonedDir, repoUrl, targetRevision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GitPull")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(clonedDir, repoUrl, targetRevision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MigrateProxyChartDependenciesIfRequired provides a mock function with given fields: ctx, isChartUpdated, pushChartToGitRequest, expectedChartYamlContent
+func (_m *GitOperationService) MigrateProxyChartDependenciesIfRequired(ctx context.Context, isChartUpdated bool, pushChartToGitRequest *gitbean.PushChartToGitRequestDTO, expectedChartYamlContent string) error {
+	ret := _m.Called(ctx, isChartUpdated, pushChartToGitRequest, expectedChartYamlContent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateProxyChartDependenciesIfRequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, *gitbean.PushChartToGitRequestDTO, string) error); ok {
+		r0 = rf(ctx, isChartUpdated, pushChartToGitRequest, expectedChartYamlContent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushChartToGitOpsRepoForHelmApp provides a mock function with given fields: ctx, pushChartToGitRequest, valuesConfig
+func (_m *GitOperationService) PushChartToGitOpsRepoForHelmApp(ctx context.Context, pushChartToGitRequest *gitbean.PushChartToGitRequestDTO, valuesConfig *git.ChartConfig) (*bean.ChartGitAttribute, string, error) {
+	ret := _m.Called(ctx, pushChartToGitRequest, valuesConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushChartToGitOpsRepoForHelmApp")
+	}
+
+	var r0 *bean.ChartGitAttribute
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gitbean.PushChartToGitRequestDTO, *git.ChartConfig) (*bean.ChartGitAttribute, string, error)); ok {
+		return rf(ctx, pushChartToGitRequest, valuesConfig)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gitbean.PushChartToGitRequestDTO, *git.ChartConfig) *bean.ChartGitAttribute); ok {
+		r0 = rf(ctx, pushChartToGitRequest, valuesConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bean.ChartGitAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gitbean.PushChartToGitRequestDTO, *git.ChartConfig) string); ok {
+		r1 = rf(ctx, pushChartToGitRequest, valuesConfig)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *gitbean.PushChartToGitRequestDTO, *git.ChartConfig) error); ok {
+		r2 = rf(ctx, pushChartToGitRequest, valuesConfig)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PushChartToGitRepo provides a mock function with given fields: ctx, gitOpsRepoName, chartLocation, tempReferenceTemplateDir, repoUrl, targetRevision, userId
+func (_m *GitOperationService) PushChartToGitRepo(ctx context.Context, gitOpsRepoName string, chartLocation string, tempReferenceTemplateDir string, repoUrl string, targetRevision string, userId int32) error {
+	ret := _m.Called(ctx, gitOpsRepoName, chartLocation, tempReferenceTemplateDir, repoUrl, targetRevision, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushChartToGitRepo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int32) error); ok {
+		r0 = rf(ctx, gitOpsRepoName, chartLocation, tempReferenceTemplateDir, repoUrl, targetRevision, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReloadGitOpsProvider provides a mock function with no fields
+func (_m *GitOperationService) ReloadGitOpsProvider() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadGitOpsProvider")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceChartInGitRepo provides a mock function with given fields: ctx, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId
+func (_m *GitOperationService) ReplaceChartInGitRepo(ctx context.Context, gitOpsRepoName string, chartLocation string, sourceChartDir string, repoUrl string, targetRevision string, commitMsg string, userId int32) (string, error) {
+	ret := _m.Called(ctx, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceChartInGitRepo")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string, int32) (string, error)); ok {
+		return rf(ctx, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string, int32) string); ok {
+		r0 = rf(ctx, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, string, int32) error); ok {
+		r1 = rf(ctx, gitOpsRepoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGitHostUrlByProvider provides a mock function with given fields: request
+func (_m *GitOperationService) UpdateGitHostUrlByProvider(request *gitOps.GitOpsConfigDto) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGitHostUrlByProvider")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gitOps.GitOpsConfigDto) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGitOperationService creates a new instance of GitOperationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGitOperationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GitOperationService {
+	mock := &GitOperationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
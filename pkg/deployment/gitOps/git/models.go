@@ -53,3 +53,36 @@ func (c *ChartConfig) SetBitBucketBaseDir(dir string) {
 func (c *ChartConfig) GetBitBucketBaseDir() string {
 	return c.bitBucketBaseDir
 }
+
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "OPEN"
+	PullRequestStateMerged PullRequestState = "MERGED"
+	PullRequestStateClosed PullRequestState = "CLOSED"
+)
+
+// PullRequestConfig describes a pull/merge request to be opened on a GitOps repository.
+type PullRequestConfig struct {
+	ChartRepoName string
+	SourceBranch  string
+	TargetBranch  string
+	Title         string
+	Description   string
+}
+
+// PullRequest is the provider agnostic view of a pull/merge request.
+type PullRequest struct {
+	Id              int
+	Url             string
+	State           PullRequestState
+	MergeCommitHash string
+}
+
+func (pr *PullRequest) IsMerged() bool {
+	return pr.State == PullRequestStateMerged
+}
+
+func (pr *PullRequest) IsClosed() bool {
+	return pr.State == PullRequestStateClosed
+}
@@ -0,0 +1,402 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monoRepo
+
+import (
+	"context"
+	"fmt"
+	"github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	argoBean "github.com/devtron-labs/devtron/client/argocdServer/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	chartService "github.com/devtron-labs/devtron/pkg/chart"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	globalUtil "github.com/devtron-labs/devtron/util"
+	"go.uber.org/zap"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type MonoRepoService interface {
+	// GetLayout returns the configured GitOps repository layout
+	GetLayout() bean.GitOpsRepoLayout
+	// GetMonoRepoLocation returns the shared GitOps repository and the chart base path of an app-env
+	GetMonoRepoLocation(appId, envId int) (*bean.MonoRepoLocation, error)
+	// EnsureMonoRepo creates the shared GitOps repository if absent and registers it in ArgoCd
+	EnsureMonoRepo(ctx context.Context, repoName, targetRevision string, userId int32) (repoUrl string, err error)
+	// LockRepo serializes commits on a shared GitOps repository across replicas, the returned func releases the lock
+	LockRepo(repoName string) (unlock func(), err error)
+	// MigrateApp moves the charts of an app from its own GitOps repository to the shared repository,
+	// ArgoCd applications are re-pointed to identical manifests, hence no redeployment happens
+	MigrateApp(ctx context.Context, request *bean.MigrateAppRequest) (*bean.MigrateAppResponse, error)
+}
+
+type MonoRepoServiceImpl struct {
+	logger                   *zap.SugaredLogger
+	globalEnvVariables       *globalUtil.GlobalEnvVariables
+	appRepository            app.AppRepository
+	environmentRepository    repository.EnvironmentRepository
+	pipelineRepository       pipelineConfig.PipelineRepository
+	gitOpsConfigReadService  config.GitOpsConfigReadService
+	gitOperationService      git.GitOperationService
+	argoClientWrapperService argocdServer.ArgoClientWrapperService
+	deploymentConfigService  common.DeploymentConfigService
+	chartService             chartService.ChartService
+	chartTemplateService     util.ChartTemplateService
+	*sql.TransactionUtilImpl
+
+	repoMutexMapLock sync.Mutex
+	repoMutexMap     map[string]*sync.Mutex
+}
+
+func NewMonoRepoServiceImpl(logger *zap.SugaredLogger,
+	envVariables *globalUtil.EnvironmentVariables,
+	appRepository app.AppRepository,
+	environmentRepository repository.EnvironmentRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	gitOpsConfigReadService config.GitOpsConfigReadService,
+	gitOperationService git.GitOperationService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	deploymentConfigService common.DeploymentConfigService,
+	chartService chartService.ChartService,
+	chartTemplateService util.ChartTemplateService,
+	transactionUtilImpl *sql.TransactionUtilImpl) *MonoRepoServiceImpl {
+	return &MonoRepoServiceImpl{
+		logger:                   logger,
+		globalEnvVariables:       envVariables.GlobalEnvVariables,
+		appRepository:            appRepository,
+		environmentRepository:    environmentRepository,
+		pipelineRepository:       pipelineRepository,
+		gitOpsConfigReadService:  gitOpsConfigReadService,
+		gitOperationService:      gitOperationService,
+		argoClientWrapperService: argoClientWrapperService,
+		deploymentConfigService:  deploymentConfigService,
+		chartService:             chartService,
+		chartTemplateService:     chartTemplateService,
+		TransactionUtilImpl:      transactionUtilImpl,
+		repoMutexMap:             make(map[string]*sync.Mutex),
+	}
+}
+
+func (impl *MonoRepoServiceImpl) GetLayout() bean.GitOpsRepoLayout {
+	layout := bean.GitOpsRepoLayout(strings.ToUpper(impl.globalEnvVariables.GitOpsRepoLayout))
+	if !layout.IsMonoRepo() {
+		return bean.AppRepoLayout
+	}
+	return layout
+}
+
+func (impl *MonoRepoServiceImpl) GetMonoRepoLocation(appId, envId int) (*bean.MonoRepoLocation, error) {
+	layout := impl.GetLayout()
+	if !layout.IsMonoRepo() {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.MonoRepoLayoutDisabledMessage, bean.MonoRepoLayoutDisabledMessage)
+	}
+	appModel, err := impl.appRepository.FindAppAndProjectByAppId(appId)
+	if err != nil {
+		impl.logger.Errorw("error in finding app and project", "appId", appId, "err", err)
+		return nil, err
+	}
+	env, err := impl.environmentRepository.FindById(envId)
+	if err != nil {
+		impl.logger.Errorw("error in finding environment", "envId", envId, "err", err)
+		return nil, err
+	}
+	var repoScope string
+	if layout == bean.ClusterRepoLayout {
+		if env.Cluster == nil {
+			return nil, fmt.Errorf("cluster not found for environment %q", env.Name)
+		}
+		repoScope = env.Cluster.ClusterName
+	} else {
+		repoScope = appModel.Team.Name
+	}
+	return &bean.MonoRepoLocation{
+		Layout:   layout,
+		RepoName: impl.gitOpsConfigReadService.GetGitOpsRepoName(fmt.Sprintf(bean.MonoRepoNameFormat, repoScope)),
+		BasePath: path.Join(appModel.AppName, env.Name),
+	}, nil
+}
+
+func (impl *MonoRepoServiceImpl) EnsureMonoRepo(ctx context.Context, repoName, targetRevision string, userId int32) (string, error) {
+	// concurrent first deployments of different apps would otherwise race on the repository creation
+	unlock, err := impl.LockRepo(repoName)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	chartGitAttr, err := impl.gitOperationService.CreateGitRepositoryForDevtronApp(ctx, repoName, targetRevision, userId)
+	if err != nil {
+		impl.logger.Errorw("error in creating gitOps mono repo", "repoName", repoName, "err", err)
+		return "", fmt.Errorf("No repository configured for Gitops! Error while creating git repository: '%s'", repoName)
+	}
+	err = impl.argoClientWrapperService.RegisterGitOpsRepoInArgoWithRetry(ctx, chartGitAttr.RepoUrl, chartGitAttr.TargetRevision, userId)
+	if err != nil {
+		impl.logger.Errorw("error in registering gitOps mono repo in acd", "repoName", repoName, "err", err)
+		return "", fmt.Errorf("Error in registering repository '%s' in ArgoCd", repoName)
+	}
+	return chartGitAttr.RepoUrl, nil
+}
+
+func (impl *MonoRepoServiceImpl) LockRepo(repoName string) (func(), error) {
+	// the in-memory mutex avoids holding a db connection per waiting goroutine,
+	// the advisory lock serializes across replicas and is released with the transaction
+	mutex := impl.getRepoMutex(repoName)
+	mutex.Lock()
+	tx, err := impl.StartTx()
+	if err != nil {
+		mutex.Unlock()
+		impl.logger.Errorw("error in starting transaction for gitOps repo lock", "repoName", repoName, "err", err)
+		return nil, err
+	}
+	_, err = tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf(bean.RepoLockKeyFormat, repoName))
+	if err != nil {
+		_ = impl.RollbackTx(tx)
+		mutex.Unlock()
+		impl.logger.Errorw("error in acquiring gitOps repo lock", "repoName", repoName, "err", err)
+		return nil, err
+	}
+	return func() {
+		_ = impl.RollbackTx(tx)
+		mutex.Unlock()
+	}, nil
+}
+
+func (impl *MonoRepoServiceImpl) getRepoMutex(repoName string) *sync.Mutex {
+	impl.repoMutexMapLock.Lock()
+	defer impl.repoMutexMapLock.Unlock()
+	mutex, ok := impl.repoMutexMap[repoName]
+	if !ok {
+		mutex = &sync.Mutex{}
+		impl.repoMutexMap[repoName] = mutex
+	}
+	return mutex
+}
+
+func (impl *MonoRepoServiceImpl) MigrateApp(ctx context.Context, request *bean.MigrateAppRequest) (*bean.MigrateAppResponse, error) {
+	layout := impl.GetLayout()
+	if !layout.IsMonoRepo() {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.MonoRepoLayoutDisabledMessage, bean.MonoRepoLayoutDisabledMessage)
+	}
+	pipelines, err := impl.pipelineRepository.FindActiveByAppId(request.AppId)
+	if err != nil {
+		impl.logger.Errorw("error in finding pipelines of app", "appId", request.AppId, "err", err)
+		return nil, err
+	}
+	response := &bean.MigrateAppResponse{
+		AppId:   request.AppId,
+		Layout:  layout,
+		Results: make([]*bean.EnvMigrationResult, 0, len(pipelines)),
+	}
+	allPipelinesSelected := true
+	for _, pipeline := range pipelines {
+		if len(request.EnvIds) != 0 && !slices.Contains(request.EnvIds, pipeline.EnvironmentId) {
+			allPipelinesSelected = false
+			continue
+		}
+		response.Results = append(response.Results, impl.migratePipeline(ctx, pipeline, request.UserId))
+	}
+	// with one repo per project, new environments of the app start in the mono repo as well,
+	// the app level repo is switched only after all the environments have moved to it
+	if layout == bean.ProjectRepoLayout && allPipelinesSelected {
+		if repoUrl, ok := getAppLevelRepoUrl(response.Results); ok {
+			err = impl.configureAppLevelRepoUrl(request.AppId, repoUrl, request.UserId)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return response, nil
+}
+
+// getAppLevelRepoUrl returns the mono repo the environments of the app were migrated to,
+// false if any migration failed or the environments are not in a single repo
+func getAppLevelRepoUrl(results []*bean.EnvMigrationResult) (string, bool) {
+	repoUrl := ""
+	for _, result := range results {
+		switch result.Status {
+		case bean.MigrationStatusFailed:
+			return "", false
+		case bean.MigrationStatusMigrated:
+			if len(repoUrl) != 0 && repoUrl != result.RepoUrl {
+				return "", false
+			}
+			repoUrl = result.RepoUrl
+		}
+	}
+	return repoUrl, len(repoUrl) != 0
+}
+
+func (impl *MonoRepoServiceImpl) configureAppLevelRepoUrl(appId int, repoUrl string, userId int32) error {
+	appLevelConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, appId, 0)
+	if err != nil {
+		impl.logger.Errorw("error in getting app level deployment config", "appId", appId, "err", err)
+		return err
+	}
+	_, err = impl.chartService.ConfigureGitOpsRepoUrlForApp(appId, repoUrl, appLevelConfig.GetChartLocation(), false, userId)
+	if err != nil {
+		impl.logger.Errorw("error in updating git repo url in charts", "appId", appId, "repoUrl", repoUrl, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *MonoRepoServiceImpl) migratePipeline(ctx context.Context, pipeline *pipelineConfig.Pipeline, userId int32) *bean.EnvMigrationResult {
+	result := &bean.EnvMigrationResult{EnvId: pipeline.EnvironmentId, Status: bean.MigrationStatusSkipped}
+	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in getting deployment config", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return result.Failed(err)
+	}
+	if !deploymentConfig.IsAcdRelease() || deploymentConfig.ReleaseMode != util.PIPELINE_RELEASE_MODE_CREATE {
+		result.Message = bean.NotArgoCdPipelineMessage
+		return result
+	}
+	if common.IsCustomGitOpsRepo(deploymentConfig.ConfigType) {
+		result.Message = bean.CustomRepoMessage
+		return result
+	}
+	currentRepoUrl := deploymentConfig.GetRepoURL()
+	if gitOps.IsGitOpsRepoNotConfigured(currentRepoUrl) {
+		result.Message = bean.NotDeployedMessage
+		return result
+	}
+	location, err := impl.GetMonoRepoLocation(pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		return result.Failed(err)
+	}
+	result.EnvName = path.Base(location.BasePath)
+	currentRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(currentRepoUrl)
+	if currentRepoName == location.RepoName && deploymentConfig.GetGitOpsRepoBasePath() == location.BasePath {
+		result.Message = bean.AlreadyMigratedMessage
+		return result
+	}
+	chartLocation := deploymentConfig.GetChartLocation()
+	targetRevision := deploymentConfig.GetTargetRevision()
+	repoUrl, err := impl.EnsureMonoRepo(ctx, location.RepoName, targetRevision, userId)
+	if err != nil {
+		return result.Failed(err)
+	}
+	deploymentConfig.SetGitOpsRepoBasePath(location.BasePath)
+	newChartLocation := deploymentConfig.GetChartLocation()
+
+	// deployments commit to the app repository under the same lock, holding it until the db update is committed
+	// ensures no commit reaches the app repository after it has been copied
+	unlockSource, err := impl.LockRepo(currentRepoName)
+	if err != nil {
+		return result.Failed(err)
+	}
+	defer unlockSource()
+	// copy the chart as it is in the app repository, values included, so that ArgoCd renders identical manifests
+	sourceCloneDir := fmt.Sprintf("%s-%s", currentRepoName, impl.chartTemplateService.GetDir())
+	clonedDir, err := impl.gitOperationService.GetClonedDir(ctx, sourceCloneDir, currentRepoUrl, targetRevision)
+	defer impl.chartTemplateService.CleanDir(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in cloning app gitOps repo", "repoUrl", currentRepoUrl, "err", err)
+		return result.Failed(err)
+	}
+	sourceChartDir := filepath.Join(clonedDir, chartLocation)
+	if _, err = os.Stat(sourceChartDir); err != nil {
+		impl.logger.Errorw("chart not found in app gitOps repo", "repoUrl", currentRepoUrl, "chartLocation", chartLocation, "err", err)
+		return result.Failed(fmt.Errorf("%s: %s", bean.ChartNotFoundMessage, chartLocation))
+	}
+	commitMsg := fmt.Sprintf(bean.MigrationCommitMessageFormat, path.Dir(location.BasePath), path.Base(location.BasePath))
+	err = impl.replaceChartInMonoRepo(ctx, location.RepoName, currentRepoName, newChartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+	if err != nil {
+		impl.logger.Errorw("error in pushing chart to gitOps mono repo", "repoName", location.RepoName, "chartLocation", newChartLocation, "err", err)
+		return result.Failed(err)
+	}
+
+	tx, err := impl.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return result.Failed(err)
+	}
+	defer impl.RollbackTx(tx)
+	_, err = impl.deploymentConfigService.UpdateGitOpsRepoForAppAndEnvId(tx, repoUrl, location.BasePath, pipeline.AppId, pipeline.EnvironmentId, userId)
+	if err != nil {
+		return result.Failed(err)
+	}
+	// the db update is committed only once ArgoCd points to the mono repo, else commits would reach a repo not being synced
+	if pipeline.DeploymentAppCreated {
+		err = impl.patchArgoApplication(ctx, pipeline.DeploymentAppName, repoUrl, newChartLocation, targetRevision)
+		if err != nil {
+			return result.Failed(err)
+		}
+	}
+	err = impl.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		if pipeline.DeploymentAppCreated {
+			// the pipeline keeps deploying to the app repository, ArgoCd is pointed back to it
+			revertErr := impl.patchArgoApplication(ctx, pipeline.DeploymentAppName, currentRepoUrl, chartLocation, targetRevision)
+			if revertErr != nil {
+				impl.logger.Errorw("error in reverting argo application to app gitOps repo", "argoAppName", pipeline.DeploymentAppName, "repoUrl", currentRepoUrl, "err", revertErr)
+			}
+		}
+		return result.Failed(err)
+	}
+	result.Status = bean.MigrationStatusMigrated
+	result.RepoUrl = repoUrl
+	result.ChartLocation = newChartLocation
+	return result
+}
+
+// replaceChartInMonoRepo pushes the chart to the mono repo under its lock, lockedRepoName is already locked by the caller
+func (impl *MonoRepoServiceImpl) replaceChartInMonoRepo(ctx context.Context, repoName, lockedRepoName, chartLocation, sourceChartDir,
+	repoUrl, targetRevision, commitMsg string, userId int32) error {
+	if repoName != lockedRepoName {
+		unlock, err := impl.LockRepo(repoName)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	_, err := impl.gitOperationService.ReplaceChartInGitRepo(ctx, repoName, chartLocation, sourceChartDir, repoUrl, targetRevision, commitMsg, userId)
+	return err
+}
+
+func (impl *MonoRepoServiceImpl) patchArgoApplication(ctx context.Context, argoAppName, repoUrl, chartLocation, targetRevision string) error {
+	repoUrlWithUserName, err := impl.gitOperationService.GetRepoUrlWithUserName(repoUrl)
+	if err != nil {
+		return err
+	}
+	patchRequestDto := &argoBean.ArgoCdAppPatchReqDto{
+		ArgoAppName:    argoAppName,
+		ChartLocation:  chartLocation,
+		GitRepoUrl:     repoUrlWithUserName,
+		TargetRevision: targetRevision,
+		PatchType:      argoBean.PatchTypeMerge,
+	}
+	err = impl.argoClientWrapperService.PatchArgoCdApp(ctx, patchRequestDto)
+	if err != nil {
+		impl.logger.Errorw("error in patching argo application", "req", patchRequestDto, "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package monoRepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	appMocks "github.com/devtron-labs/devtron/internal/sql/repository/app/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	pipelineConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/util"
+	chartMocks "github.com/devtron-labs/devtron/pkg/chart/mocks"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	environmentMocks "github.com/devtron-labs/devtron/pkg/cluster/environment/repository/mocks"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	commonBean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	commonMocks "github.com/devtron-labs/devtron/pkg/deployment/common/mocks"
+	gitOpsConfigMocks "github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/mocks"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo/bean"
+	teamRepository "github.com/devtron-labs/devtron/pkg/team/repository"
+	globalUtil "github.com/devtron-labs/devtron/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testAppId       = 1
+	testEnvId       = 2
+	testAppName     = "payments"
+	testEnvName     = "prod"
+	testTeamName    = "fintech"
+	testClusterName = "default_cluster"
+	testAppRepoUrl  = "https://github.com/org/payments.git"
+	testMonoRepoUrl = "https://github.com/org/fintech-gitops.git"
+)
+
+type monoRepoServiceMocks struct {
+	appRepository           *appMocks.AppRepository
+	environmentRepository   *environmentMocks.EnvironmentRepository
+	pipelineRepository      *pipelineConfigMocks.PipelineRepository
+	gitOpsConfigReadService *gitOpsConfigMocks.GitOpsConfigReadService
+	deploymentConfigService *commonMocks.DeploymentConfigService
+	chartService            *chartMocks.ChartService
+}
+
+func TestGetMonoRepoLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		layout        string
+		cluster       *clusterRepository.Cluster
+		wantLocation  *bean.MonoRepoLocation
+		wantErr       bool
+		wantApiStatus int
+	}{
+		{name: "app layout", layout: "APP", wantErr: true, wantApiStatus: http.StatusBadRequest},
+		{name: "unknown layout falls back to app layout", layout: "ENV", wantErr: true, wantApiStatus: http.StatusBadRequest},
+		{
+			name:   "project layout",
+			layout: "PROJECT",
+			wantLocation: &bean.MonoRepoLocation{
+				Layout:   bean.ProjectRepoLayout,
+				RepoName: testTeamName + "-gitops",
+				BasePath: testAppName + "/" + testEnvName,
+			},
+		},
+		{
+			name:   "project layout configured in lower case",
+			layout: "project",
+			wantLocation: &bean.MonoRepoLocation{
+				Layout:   bean.ProjectRepoLayout,
+				RepoName: testTeamName + "-gitops",
+				BasePath: testAppName + "/" + testEnvName,
+			},
+		},
+		{
+			name:    "cluster layout",
+			layout:  "CLUSTER",
+			cluster: &clusterRepository.Cluster{ClusterName: testClusterName},
+			wantLocation: &bean.MonoRepoLocation{
+				Layout:   bean.ClusterRepoLayout,
+				RepoName: testClusterName + "-gitops",
+				BasePath: testAppName + "/" + testEnvName,
+			},
+		},
+		{name: "cluster layout without cluster", layout: "CLUSTER", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestMonoRepoService(t, tt.layout)
+			if impl.GetLayout().IsMonoRepo() {
+				mockAppAndEnv(m, tt.cluster)
+			}
+			location, err := impl.GetMonoRepoLocation(testAppId, testEnvId)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantApiStatus != 0 {
+					assert.Equal(t, tt.wantApiStatus, err.(*util.ApiError).HttpStatusCode)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLocation, location)
+		})
+	}
+}
+
+func TestMigratePipelineSkipped(t *testing.T) {
+	tests := []struct {
+		name             string
+		deploymentConfig *commonBean.DeploymentConfig
+		configErr        error
+		wantStatus       bean.MigrationStatus
+		wantMessage      string
+	}{
+		{
+			name:       "deployment config not found",
+			configErr:  errors.New("config not found"),
+			wantStatus: bean.MigrationStatusFailed,
+		},
+		{
+			name:             "helm pipeline",
+			deploymentConfig: testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_HELM, util.PIPELINE_RELEASE_MODE_CREATE, "", testAppRepoUrl, ""),
+			wantStatus:       bean.MigrationStatusSkipped,
+			wantMessage:      bean.NotArgoCdPipelineMessage,
+		},
+		{
+			name:             "linked argo cd pipeline",
+			deploymentConfig: testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_ACD, util.PIPELINE_RELEASE_MODE_LINK, "", testAppRepoUrl, ""),
+			wantStatus:       bean.MigrationStatusSkipped,
+			wantMessage:      bean.NotArgoCdPipelineMessage,
+		},
+		{
+			name:             "custom GitOps repo",
+			deploymentConfig: testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_ACD, util.PIPELINE_RELEASE_MODE_CREATE, commonBean.CUSTOM.String(), testAppRepoUrl, ""),
+			wantStatus:       bean.MigrationStatusSkipped,
+			wantMessage:      bean.CustomRepoMessage,
+		},
+		{
+			name:             "not deployed yet",
+			deploymentConfig: testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_ACD, util.PIPELINE_RELEASE_MODE_CREATE, "", "", ""),
+			wantStatus:       bean.MigrationStatusSkipped,
+			wantMessage:      bean.NotDeployedMessage,
+		},
+		{
+			name: "already migrated",
+			deploymentConfig: testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_ACD, util.PIPELINE_RELEASE_MODE_CREATE, "", testMonoRepoUrl,
+				testAppName+"/"+testEnvName),
+			wantStatus:  bean.MigrationStatusSkipped,
+			wantMessage: bean.AlreadyMigratedMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestMonoRepoService(t, "PROJECT")
+			m.deploymentConfigService.On("GetConfigForDevtronApps", mock.Anything, testAppId, testEnvId).Return(tt.deploymentConfig, tt.configErr)
+			if tt.wantMessage == bean.AlreadyMigratedMessage {
+				mockAppAndEnv(m, nil)
+				m.gitOpsConfigReadService.On("GetGitOpsRepoNameFromUrl", testMonoRepoUrl).Return(testTeamName + "-gitops")
+			}
+
+			result := impl.migratePipeline(context.Background(), testCdPipeline(testEnvId), 1)
+			assert.Equal(t, testEnvId, result.EnvId)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			if tt.configErr != nil {
+				assert.Equal(t, tt.configErr.Error(), result.Message)
+			} else {
+				assert.Equal(t, tt.wantMessage, result.Message)
+			}
+			assert.Empty(t, result.RepoUrl)
+		})
+	}
+}
+
+func TestMigrateApp(t *testing.T) {
+	tests := []struct {
+		name        string
+		layout      string
+		envIds      []int
+		wantResults []bean.MigrationStatus
+		wantApiErr  bool
+	}{
+		{name: "mono repo layout disabled", layout: "APP", wantApiErr: true},
+		{name: "all pipelines", layout: "PROJECT", wantResults: []bean.MigrationStatus{bean.MigrationStatusSkipped, bean.MigrationStatusFailed}},
+		{name: "selected environments", layout: "PROJECT", envIds: []int{testEnvId}, wantResults: []bean.MigrationStatus{bean.MigrationStatusSkipped}},
+		{name: "cluster layout", layout: "CLUSTER", wantResults: []bean.MigrationStatus{bean.MigrationStatusSkipped, bean.MigrationStatusFailed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// no expectations are set on the chart service, the app level repo must not be touched without a migrated environment
+			impl, m := newTestMonoRepoService(t, tt.layout)
+			if !tt.wantApiErr {
+				m.pipelineRepository.On("FindActiveByAppId", testAppId).Return([]*pipelineConfig.Pipeline{testCdPipeline(testEnvId), testCdPipeline(3)}, nil)
+				m.deploymentConfigService.On("GetConfigForDevtronApps", mock.Anything, testAppId, testEnvId).
+					Return(testDeploymentConfig(util.PIPELINE_DEPLOYMENT_TYPE_HELM, util.PIPELINE_RELEASE_MODE_CREATE, "", testAppRepoUrl, ""), nil)
+				m.deploymentConfigService.On("GetConfigForDevtronApps", mock.Anything, testAppId, 3).
+					Return(nil, errors.New("config not found")).Maybe()
+			}
+
+			response, err := impl.MigrateApp(context.Background(), &bean.MigrateAppRequest{AppId: testAppId, EnvIds: tt.envIds, UserId: 1})
+			if tt.wantApiErr {
+				assert.Equal(t, http.StatusBadRequest, err.(*util.ApiError).HttpStatusCode)
+				return
+			}
+			assert.NoError(t, err)
+			statuses := make([]bean.MigrationStatus, 0, len(response.Results))
+			for _, result := range response.Results {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.wantResults, statuses)
+		})
+	}
+}
+
+func TestGetAppLevelRepoUrl(t *testing.T) {
+	otherMonoRepoUrl := "https://github.com/org/platform-gitops.git"
+	migrated := func(repoUrl string) *bean.EnvMigrationResult {
+		return &bean.EnvMigrationResult{Status: bean.MigrationStatusMigrated, RepoUrl: repoUrl}
+	}
+	skipped := &bean.EnvMigrationResult{Status: bean.MigrationStatusSkipped, Message: bean.AlreadyMigratedMessage}
+	failed := &bean.EnvMigrationResult{Status: bean.MigrationStatusFailed}
+	tests := []struct {
+		name        string
+		results     []*bean.EnvMigrationResult
+		wantRepoUrl string
+		wantOk      bool
+	}{
+		{name: "no environment", wantOk: false},
+		{name: "all migrated to one repo", results: []*bean.EnvMigrationResult{migrated(testMonoRepoUrl), migrated(testMonoRepoUrl)},
+			wantRepoUrl: testMonoRepoUrl, wantOk: true},
+		{name: "migrated and skipped", results: []*bean.EnvMigrationResult{skipped, migrated(testMonoRepoUrl)},
+			wantRepoUrl: testMonoRepoUrl, wantOk: true},
+		{name: "only skipped", results: []*bean.EnvMigrationResult{skipped}, wantOk: false},
+		{name: "failed after migrated", results: []*bean.EnvMigrationResult{migrated(testMonoRepoUrl), failed}, wantOk: false},
+		{name: "failed before migrated", results: []*bean.EnvMigrationResult{failed, migrated(testMonoRepoUrl)}, wantOk: false},
+		{name: "migrated to different repos", results: []*bean.EnvMigrationResult{migrated(testMonoRepoUrl), migrated(otherMonoRepoUrl)}, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoUrl, ok := getAppLevelRepoUrl(tt.results)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantRepoUrl, repoUrl)
+		})
+	}
+}
+
+func newTestMonoRepoService(t *testing.T, layout string) (*MonoRepoServiceImpl, *monoRepoServiceMocks) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	m := &monoRepoServiceMocks{
+		appRepository:           appMocks.NewAppRepository(t),
+		environmentRepository:   environmentMocks.NewEnvironmentRepository(t),
+		pipelineRepository:      pipelineConfigMocks.NewPipelineRepository(t),
+		gitOpsConfigReadService: gitOpsConfigMocks.NewGitOpsConfigReadService(t),
+		deploymentConfigService: commonMocks.NewDeploymentConfigService(t),
+		chartService:            chartMocks.NewChartService(t),
+	}
+	envVariables := &globalUtil.EnvironmentVariables{
+		GlobalEnvVariables: &globalUtil.GlobalEnvVariables{GitOpsRepoLayout: layout},
+	}
+	impl := NewMonoRepoServiceImpl(logger, envVariables, m.appRepository, m.environmentRepository, m.pipelineRepository,
+		m.gitOpsConfigReadService, nil, nil, m.deploymentConfigService, m.chartService, nil, nil)
+	return impl, m
+}
+
+func mockAppAndEnv(m *monoRepoServiceMocks, cluster *clusterRepository.Cluster) {
+	m.appRepository.On("FindAppAndProjectByAppId", testAppId).Return(&app.App{
+		Id:      testAppId,
+		AppName: testAppName,
+		Team:    teamRepository.Team{Name: testTeamName},
+	}, nil)
+	m.environmentRepository.On("FindById", testEnvId).Return(&repository.Environment{
+		Id:      testEnvId,
+		Name:    testEnvName,
+		Cluster: cluster,
+	}, nil)
+	m.gitOpsConfigReadService.On("GetGitOpsRepoName", mock.Anything).Return(func(name string) string {
+		return name
+	}).Maybe()
+}
+
+func testCdPipeline(envId int) *pipelineConfig.Pipeline {
+	return &pipelineConfig.Pipeline{
+		Id:                   envId * 10,
+		AppId:                testAppId,
+		EnvironmentId:        envId,
+		DeploymentAppName:    testAppName + "-" + testEnvName,
+		DeploymentAppCreated: true,
+	}
+}
+
+func testDeploymentConfig(deploymentAppType, releaseMode, configType, repoUrl, basePath string) *commonBean.DeploymentConfig {
+	chartLocation := "reference-chart_4-19-0"
+	if len(basePath) != 0 {
+		chartLocation = basePath + "/" + chartLocation
+	}
+	return &commonBean.DeploymentConfig{
+		AppId:             testAppId,
+		EnvironmentId:     testEnvId,
+		ConfigType:        configType,
+		DeploymentAppType: deploymentAppType,
+		ReleaseMode:       releaseMode,
+		RepoURL:           repoUrl,
+		ReleaseConfiguration: &commonBean.ReleaseConfiguration{
+			ArgoCDSpec: commonBean.ArgoCDSpec{
+				Spec: commonBean.ApplicationSpec{
+					Source: &commonBean.ApplicationSource{
+						RepoURL: repoUrl,
+						Path:    chartLocation,
+					},
+				},
+			},
+			GitOpsRepoBasePath: basePath,
+		},
+	}
+}
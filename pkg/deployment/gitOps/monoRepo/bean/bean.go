@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+type GitOpsRepoLayout string
+
+const (
+	// AppRepoLayout - a GitOps repository is created per app (default)
+	AppRepoLayout GitOpsRepoLayout = "APP"
+	// ProjectRepoLayout - a single GitOps repository per project holds <app>/<env> chart directories
+	ProjectRepoLayout GitOpsRepoLayout = "PROJECT"
+	// ClusterRepoLayout - a single GitOps repository per cluster holds <app>/<env> chart directories
+	ClusterRepoLayout GitOpsRepoLayout = "CLUSTER"
+)
+
+func (l GitOpsRepoLayout) String() string {
+	return string(l)
+}
+
+func (l GitOpsRepoLayout) IsMonoRepo() bool {
+	return l == ProjectRepoLayout || l == ClusterRepoLayout
+}
+
+const (
+	// MonoRepoNameFormat is formatted with the project or cluster name
+	MonoRepoNameFormat = "%s-gitops"
+	// RepoLockKeyFormat is formatted with the repository name, used as the advisory lock key for commit serialization
+	RepoLockKeyFormat = "gitops-repo-%s"
+	// MigrationCommitMessageFormat is formatted with the app and env name
+	MigrationCommitMessageFormat = "migrate %s/%s from app repository"
+)
+
+type MigrationStatus string
+
+const (
+	MigrationStatusMigrated MigrationStatus = "MIGRATED"
+	MigrationStatusSkipped  MigrationStatus = "SKIPPED"
+	MigrationStatusFailed   MigrationStatus = "FAILED"
+)
+
+const (
+	MonoRepoLayoutDisabledMessage = "GitOps mono repo layout is not enabled, set GITOPS_REPO_LAYOUT to PROJECT or CLUSTER"
+	NotArgoCdPipelineMessage      = "only pipelines deployed via Argo CD in create mode are supported"
+	CustomRepoMessage             = "pipeline uses a custom GitOps repository"
+	NotDeployedMessage            = "pipeline is not deployed yet, it uses the mono repo on first deployment"
+	AlreadyMigratedMessage        = "pipeline already uses the mono repo"
+	ChartNotFoundMessage          = "chart not found in the app GitOps repository"
+)
+
+// MonoRepoLocation is where the chart of an app-env lives in the shared GitOps repository
+type MonoRepoLocation struct {
+	Layout   GitOpsRepoLayout `json:"layout"`
+	RepoName string           `json:"repoName"`
+	// BasePath is the <app>/<env> directory the chart is pushed in
+	BasePath string `json:"basePath"`
+}
+
+type MigrateAppRequest struct {
+	AppId int `json:"appId" validate:"required,number,gt=0"`
+	// EnvIds limits the migration to the given environments, all CD pipelines of the app are migrated if empty
+	EnvIds []int `json:"envIds"`
+	UserId int32 `json:"-"`
+}
+
+type EnvMigrationResult struct {
+	EnvId         int             `json:"envId"`
+	EnvName       string          `json:"envName"`
+	Status        MigrationStatus `json:"status"`
+	RepoUrl       string          `json:"repoUrl,omitempty"`
+	ChartLocation string          `json:"chartLocation,omitempty"`
+	Message       string          `json:"message,omitempty"`
+}
+
+type MigrateAppResponse struct {
+	AppId   int                   `json:"appId"`
+	Layout  GitOpsRepoLayout      `json:"layout"`
+	Results []*EnvMigrationResult `json:"results"`
+}
+
+func (r *EnvMigrationResult) Failed(err error) *EnvMigrationResult {
+	r.Status = MigrationStatusFailed
+	r.Message = err.Error()
+	return r
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	prRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cdWorkflowService "github.com/devtron-labs/devtron/pkg/workflow/cd"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+type PullRequestModeService interface {
+	// GetConfig returns nil if pull request mode is not configured for the environment
+	GetConfig(envId int) (*bean.PullRequestModeConfigDto, error)
+	GetAllConfigs() ([]*bean.PullRequestModeConfigDto, error)
+	SaveConfig(config *bean.PullRequestModeConfigDto) (*bean.PullRequestModeConfigDto, error)
+	DeleteConfig(envId int, userId int32) error
+	// IsPullRequestModeEnabled returns true if the deployments to the environment are to be committed through a pull request
+	IsPullRequestModeEnabled(envId int) (bool, error)
+
+	// OpenPullRequest opens a pull request from the source branch of the deployment and marks the deployment awaiting merge
+	OpenPullRequest(ctx context.Context, request *bean.OpenPullRequestRequest) (*bean.PullRequestDto, error)
+	// GetPullRequest returns nil if no pull request was opened for the deployment
+	GetPullRequest(wfrId int) (*bean.PullRequestDto, error)
+	// ProcessOpenPullRequests continues the deployments whose pull request is merged and fails the ones whose pull request is closed
+	ProcessOpenPullRequests()
+}
+
+type PullRequestModeServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	configRepository              prRepository.PullRequestModeConfigRepository
+	pullRequestRepository         prRepository.GitOpsPullRequestRepository
+	environmentRepository         repository.EnvironmentRepository
+	gitOperationService           git.GitOperationService
+	pipelineOverrideRepository    chartConfig.PipelineOverrideRepository
+	cdWorkflowRepository          pipelineConfig.CdWorkflowRepository
+	cdWorkflowCommonService       cdWorkflowService.CdWorkflowCommonService
+	pipelineStatusTimelineService status.PipelineStatusTimelineService
+	deploymentConfigService       common.DeploymentConfigService
+	argoClientWrapperService      argocdServer.ArgoClientWrapperService
+	acdConfig                     *argocdServer.ACDConfig
+	deploymentEventHandler        app.DeploymentEventHandler
+	transactionManager            sql.TransactionWrapper
+}
+
+func NewPullRequestModeServiceImpl(logger *zap.SugaredLogger,
+	configRepository prRepository.PullRequestModeConfigRepository,
+	pullRequestRepository prRepository.GitOpsPullRequestRepository,
+	environmentRepository repository.EnvironmentRepository,
+	gitOperationService git.GitOperationService,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	cdWorkflowCommonService cdWorkflowService.CdWorkflowCommonService,
+	pipelineStatusTimelineService status.PipelineStatusTimelineService,
+	deploymentConfigService common.DeploymentConfigService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	acdConfig *argocdServer.ACDConfig,
+	deploymentEventHandler app.DeploymentEventHandler,
+	transactionManager sql.TransactionWrapper) *PullRequestModeServiceImpl {
+	return &PullRequestModeServiceImpl{
+		logger:                        logger,
+		configRepository:              configRepository,
+		pullRequestRepository:         pullRequestRepository,
+		environmentRepository:         environmentRepository,
+		gitOperationService:           gitOperationService,
+		pipelineOverrideRepository:    pipelineOverrideRepository,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		cdWorkflowCommonService:       cdWorkflowCommonService,
+		pipelineStatusTimelineService: pipelineStatusTimelineService,
+		deploymentConfigService:       deploymentConfigService,
+		argoClientWrapperService:      argoClientWrapperService,
+		acdConfig:                     acdConfig,
+		deploymentEventHandler:        deploymentEventHandler,
+		transactionManager:            transactionManager,
+	}
+}
+
+func (impl *PullRequestModeServiceImpl) GetConfig(envId int) (*bean.PullRequestModeConfigDto, error) {
+	config, err := impl.configRepository.FindByEnvironmentId(envId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching pull request mode config", "envId", envId, "err", err)
+		return nil, err
+	}
+	return adapter.GetPullRequestModeConfigDto(config), nil
+}
+
+func (impl *PullRequestModeServiceImpl) GetAllConfigs() ([]*bean.PullRequestModeConfigDto, error) {
+	configs, err := impl.configRepository.FindAllActive()
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching pull request mode configs", "err", err)
+		return nil, err
+	}
+	configDtos := make([]*bean.PullRequestModeConfigDto, 0, len(configs))
+	for _, config := range configs {
+		configDtos = append(configDtos, adapter.GetPullRequestModeConfigDto(config))
+	}
+	return configDtos, nil
+}
+
+func (impl *PullRequestModeServiceImpl) SaveConfig(configDto *bean.PullRequestModeConfigDto) (*bean.PullRequestModeConfigDto, error) {
+	env, err := impl.environmentRepository.FindById(configDto.EnvironmentId)
+	if errors.Is(err, pg.ErrNoRows) || (err == nil && !env.Active) {
+		return nil, util.NewApiError(http.StatusNotFound, "environment not found", "environment not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", configDto.EnvironmentId, "err", err)
+		return nil, err
+	}
+	config, err := impl.configRepository.FindByEnvironmentId(configDto.EnvironmentId)
+	if errors.Is(err, pg.ErrNoRows) {
+		config = adapter.NewPullRequestModeConfig(configDto)
+		err = impl.configRepository.Save(config)
+	} else if err == nil {
+		config.Enabled = configDto.Enabled
+		config.UpdateAuditLog(configDto.UserId)
+		err = impl.configRepository.Update(config)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving pull request mode config", "envId", configDto.EnvironmentId, "err", err)
+		return nil, err
+	}
+	savedConfig := adapter.GetPullRequestModeConfigDto(config)
+	savedConfig.Environment = env.Name
+	return savedConfig, nil
+}
+
+func (impl *PullRequestModeServiceImpl) DeleteConfig(envId int, userId int32) error {
+	err := impl.configRepository.MarkInactiveByEnvironmentId(envId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting pull request mode config", "envId", envId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *PullRequestModeServiceImpl) IsPullRequestModeEnabled(envId int) (bool, error) {
+	config, err := impl.GetConfig(envId)
+	if err != nil {
+		return false, err
+	}
+	return config != nil && config.Enabled, nil
+}
+
+func (impl *PullRequestModeServiceImpl) OpenPullRequest(ctx context.Context, request *bean.OpenPullRequestRequest) (*bean.PullRequestDto, error) {
+	env, err := impl.environmentRepository.FindById(request.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", request.EnvironmentId, "err", err)
+		return nil, err
+	}
+	prConfig := &git.PullRequestConfig{
+		ChartRepoName: request.RepoName,
+		SourceBranch:  request.SourceBranch,
+		TargetBranch:  request.TargetBranch,
+		Title:         fmt.Sprintf(bean.TitleFormat, request.AppName, env.Name, request.PipelineOverrideId),
+		Description:   fmt.Sprintf(bean.DescriptionFormat, request.AppName, env.Name, request.TriggeredBy),
+	}
+	pr, err := impl.gitOperationService.CreatePullRequest(ctx, prConfig)
+	if err != nil {
+		impl.logger.Errorw("error in opening pull request", "request", request, "err", err)
+		return nil, err
+	}
+	pullRequest := &prRepository.GitOpsPullRequest{
+		PipelineId:         request.PipelineId,
+		CdWorkflowRunnerId: request.CdWorkflowRunnerId,
+		PipelineOverrideId: request.PipelineOverrideId,
+		RepoName:           request.RepoName,
+		SourceBranch:       request.SourceBranch,
+		TargetBranch:       request.TargetBranch,
+		PullRequestId:      pr.Id,
+		PullRequestUrl:     pr.Url,
+		Status:             bean.PullRequestStatusOpen,
+		AuditLog:           sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.pullRequestRepository.Save(pullRequest)
+	if err != nil {
+		impl.logger.Errorw("error in saving pull request", "pullRequest", pullRequest, "err", err)
+		return nil, err
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(request.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_AWAITING_MERGE,
+		fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_GIT_AWAITING_MERGE, pr.Url), request.UserId)
+	_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving awaiting merge timeline", "wfrId", request.CdWorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	return adapter.GetPullRequestDto(pullRequest), nil
+}
+
+func (impl *PullRequestModeServiceImpl) GetPullRequest(wfrId int) (*bean.PullRequestDto, error) {
+	pullRequest, err := impl.pullRequestRepository.FindByCdWorkflowRunnerId(wfrId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching pull request", "wfrId", wfrId, "err", err)
+		return nil, err
+	}
+	return adapter.GetPullRequestDto(pullRequest), nil
+}
+
+func (impl *PullRequestModeServiceImpl) ProcessOpenPullRequests() {
+	pullRequests, err := impl.pullRequestRepository.FindAllOpen()
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching open pull requests", "err", err)
+		return
+	}
+	for _, pullRequest := range pullRequests {
+		err = impl.processOpenPullRequest(pullRequest)
+		if err != nil {
+			impl.logger.Errorw("error in processing open pull request", "wfrId", pullRequest.CdWorkflowRunnerId, "pullRequestId", pullRequest.PullRequestId, "err", err)
+		}
+	}
+}
+
+func (impl *PullRequestModeServiceImpl) processOpenPullRequest(pullRequest *prRepository.GitOpsPullRequest) error {
+	runner, err := impl.cdWorkflowRepository.FindBasicWorkflowRunnerById(pullRequest.CdWorkflowRunnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd workflow runner", "wfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	if slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		// a later deployment has superseded this one, merging the pull request will not continue it
+		_, err = impl.pullRequestRepository.UpdateStatusIfOpen(pullRequest.Id, bean.PullRequestStatusSuperseded, "", bean.SupersededMessage, userBean.SystemUserId)
+		return err
+	}
+	ctx := context.Background()
+	pr, err := impl.gitOperationService.GetPullRequest(ctx, pullRequest.RepoName, pullRequest.PullRequestId)
+	if err != nil {
+		return err
+	}
+	if pr.IsMerged() {
+		return impl.continueDeployment(ctx, pullRequest, pr)
+	} else if pr.IsClosed() {
+		claimed, err := impl.pullRequestRepository.UpdateStatusIfOpen(pullRequest.Id, bean.PullRequestStatusClosed, "", bean.ClosedMessage, userBean.SystemUserId)
+		if err != nil || !claimed {
+			return err
+		}
+		return impl.failDeployment(pullRequest, fmt.Errorf("%s: %s", bean.ClosedMessage, pullRequest.PullRequestUrl))
+	}
+	return nil
+}
+
+// continueDeployment records the merge commit of the pull request as the commit of the deployment and syncs the argo cd application,
+// it completes what the trigger does after the git commit for the environments not in pull request mode. The pull request is
+// marked merged in the same transaction as the commit details, so that it is picked up again if anything before fails.
+func (impl *PullRequestModeServiceImpl) continueDeployment(ctx context.Context, pullRequest *prRepository.GitOpsPullRequest, pr *git.PullRequest) error {
+	override, err := impl.pipelineOverrideRepository.FindById(pullRequest.PipelineOverrideId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override", "pipelineOverrideId", pullRequest.PipelineOverrideId, "err", err)
+		return err
+	}
+	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, override.Pipeline.AppId, override.Pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment config", "appId", override.Pipeline.AppId, "envId", override.Pipeline.EnvironmentId, "err", err)
+		return err
+	}
+	tx, err := impl.transactionManager.StartTx()
+	defer impl.transactionManager.RollbackTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	claimed, err := impl.pullRequestRepository.UpdateStatusIfOpenWithTx(tx, pullRequest.Id, bean.PullRequestStatusMerged, pr.MergeCommitHash, "", userBean.SystemUserId)
+	if err != nil || !claimed {
+		return err
+	}
+	err = impl.pipelineOverrideRepository.UpdateCommitDetails(ctx, tx, override.Id, pr.MergeCommitHash, time.Now(), userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in updating commit details to PipelineConfigOverride", "pipelineOverrideId", override.Id, "err", err)
+		return err
+	}
+	timelines := []*pipelineConfig.PipelineStatusTimeline{
+		impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT,
+			fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_MERGED, pullRequest.PullRequestUrl), userBean.SystemUserId),
+	}
+	argoSyncNeeded := deploymentConfig.IsArgoAppSyncAndRefreshSupported()
+	if impl.acdConfig.IsManualSyncEnabled() && argoSyncNeeded {
+		timelines = append(timelines, impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId,
+			timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_INITIATED, timelineStatus.TIMELINE_DESCRIPTION_ARGOCD_SYNC_INITIATED, userBean.SystemUserId))
+	}
+	err = impl.pipelineStatusTimelineService.SaveMultipleTimelinesIfNotAlreadyPresent(timelines, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving git commit timeline", "wfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	err = impl.transactionManager.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return err
+	}
+	if argoSyncNeeded {
+		err = impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(ctx, override.Pipeline.DeploymentAppName, deploymentConfig.GetTargetRevision())
+		if err != nil {
+			impl.logger.Errorw("error in syncing argo application", "argoAppName", override.Pipeline.DeploymentAppName, "err", err)
+			return impl.failDeployment(pullRequest, fmt.Errorf("ArgoCD sync failed. err: %s", util.GetClientErrorDetailedMessage(err)))
+		}
+		if impl.acdConfig.IsManualSyncEnabled() {
+			timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId,
+				timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED, timelineStatus.TIMELINE_DESCRIPTION_ARGOCD_SYNC_COMPLETED, userBean.SystemUserId)
+			_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+			if err != nil {
+				impl.logger.Errorw("error in saving argocd sync completed timeline", "wfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+			}
+		}
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(pullRequest.CdWorkflowRunnerId,
+		timelineStatus.TIMELINE_STATUS_DEPLOYMENT_TRIGGERED, timelineStatus.TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED, userBean.SystemUserId)
+	_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving deployment triggered timeline", "wfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+	}
+	return nil
+}
+
+func (impl *PullRequestModeServiceImpl) failDeployment(pullRequest *prRepository.GitOpsPullRequest, deploymentErr error) error {
+	err := impl.cdWorkflowCommonService.MarkDeploymentFailedForRunnerId(pullRequest.CdWorkflowRunnerId, deploymentErr, userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in marking deployment failed", "wfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	override, err := impl.pipelineOverrideRepository.FindById(pullRequest.PipelineOverrideId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override", "pipelineOverrideId", pullRequest.PipelineOverrideId, "err", err)
+		return err
+	}
+	impl.deploymentEventHandler.WriteCDNotificationEventAsync(override.Pipeline.AppId, override.Pipeline.EnvironmentId, override, eventUtil.Fail)
+	return nil
+}
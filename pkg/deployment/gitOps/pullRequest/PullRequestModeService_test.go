@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	argoMocks "github.com/devtron-labs/devtron/client/argocdServer/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	chartConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/chartConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	pipelineConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/util"
+	appMocks "github.com/devtron-labs/devtron/pkg/app/mocks"
+	statusMocks "github.com/devtron-labs/devtron/pkg/app/status/mocks"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	environmentMocks "github.com/devtron-labs/devtron/pkg/cluster/environment/repository/mocks"
+	commonBean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	commonMocks "github.com/devtron-labs/devtron/pkg/deployment/common/mocks"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	gitMocks "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/mocks"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	prRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	prMocks "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository/mocks"
+	sqlMocks "github.com/devtron-labs/devtron/pkg/sql/mocks"
+	cdWorkflowMocks "github.com/devtron-labs/devtron/pkg/workflow/cd/mocks"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testWfrId          = 5
+	testOverrideId     = 6
+	testPullRequestId  = 7
+	testPullRequestUrl = "https://github.com/devtron/app-env/pull/7"
+	testArgoAppName    = "app-env"
+	testAppId          = 2
+	testEnvId          = 3
+	testId             = 1
+	testUserId         = int32(8)
+	systemUserId       = int32(userBean.SystemUserId)
+)
+
+type pullRequestModeServiceMocks struct {
+	pullRequestRepository         *prMocks.GitOpsPullRequestRepository
+	environmentRepository         *environmentMocks.EnvironmentRepository
+	gitOperationService           *gitMocks.GitOperationService
+	pipelineOverrideRepository    *chartConfigMocks.PipelineOverrideRepository
+	cdWorkflowRepository          *pipelineConfigMocks.CdWorkflowRepository
+	cdWorkflowCommonService       *cdWorkflowMocks.CdWorkflowCommonService
+	pipelineStatusTimelineService *statusMocks.PipelineStatusTimelineService
+	deploymentConfigService       *commonMocks.DeploymentConfigService
+	argoClientWrapperService      *argoMocks.ArgoClientWrapperService
+	deploymentEventHandler        *appMocks.DeploymentEventHandler
+	transactionManager            *sqlMocks.TransactionWrapper
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	impl, m := newTestPullRequestModeService(t)
+	m.environmentRepository.On("FindById", testEnvId).Return(&repository.Environment{Id: testEnvId, Name: "prod", Active: true}, nil)
+	m.gitOperationService.On("CreatePullRequest", mock.Anything, mock.MatchedBy(func(config *git.PullRequestConfig) bool {
+		return config.Title == "Deploy app to prod (release 6)" && config.SourceBranch == "devtron/release-6-env-3" && config.TargetBranch == "master"
+	})).Return(&git.PullRequest{Id: testPullRequestId, Url: testPullRequestUrl, State: git.PullRequestStateOpen}, nil)
+	m.pullRequestRepository.On("Save", mock.MatchedBy(func(pullRequest *prRepository.GitOpsPullRequest) bool {
+		return pullRequest.CdWorkflowRunnerId == testWfrId && pullRequest.PipelineOverrideId == testOverrideId &&
+			pullRequest.PullRequestId == testPullRequestId && pullRequest.Status == bean.PullRequestStatusOpen
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*prRepository.GitOpsPullRequest).Id = testId
+	}).Return(nil)
+	mockTimeline(m, timelineStatus.TIMELINE_STATUS_GIT_AWAITING_MERGE, testUserId)
+
+	pullRequest, err := impl.OpenPullRequest(context.Background(), &bean.OpenPullRequestRequest{
+		PipelineId:         1,
+		CdWorkflowRunnerId: testWfrId,
+		PipelineOverrideId: testOverrideId,
+		AppName:            "app",
+		EnvironmentId:      testEnvId,
+		RepoName:           testArgoAppName,
+		SourceBranch:       "devtron/release-6-env-3",
+		TargetBranch:       "master",
+		TriggeredBy:        "admin",
+		UserId:             testUserId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, bean.PullRequestStatusOpen, pullRequest.Status)
+	assert.Equal(t, testPullRequestId, pullRequest.PullRequestId)
+	assert.Equal(t, testPullRequestUrl, pullRequest.PullRequestUrl)
+	m.assertExpectations(t)
+}
+
+func TestProcessOpenPullRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		runnerStatus string
+		// pullRequest is nil if the pull request is not expected to be looked up
+		pullRequest *git.PullRequest
+		syncErr     error
+		// wantStatus is the status the pull request is moved to, empty if it is left open
+		wantStatus bean.PullRequestStatus
+		// wantFailure is the error the deployment is failed with, empty if it is not failed
+		wantFailure string
+	}{
+		{
+			name:         "still open keeps the deployment awaiting merge",
+			runnerStatus: cdWorkflow.WorkflowInProgress,
+			pullRequest:  &git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateOpen},
+		},
+		{
+			name:         "merged continues the deployment from the merge commit",
+			runnerStatus: cdWorkflow.WorkflowInProgress,
+			pullRequest:  &git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateMerged, MergeCommitHash: "abc123"},
+			wantStatus:   bean.PullRequestStatusMerged,
+		},
+		{
+			name:         "merged but argo sync fails fails the deployment",
+			runnerStatus: cdWorkflow.WorkflowInProgress,
+			pullRequest:  &git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateMerged, MergeCommitHash: "abc123"},
+			syncErr:      errors.New("connection refused"),
+			wantStatus:   bean.PullRequestStatusMerged,
+			wantFailure:  "ArgoCD sync failed. err: connection refused",
+		},
+		{
+			name:         "closed without merge fails the deployment",
+			runnerStatus: cdWorkflow.WorkflowInProgress,
+			pullRequest:  &git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateClosed},
+			wantStatus:   bean.PullRequestStatusClosed,
+			wantFailure:  bean.ClosedMessage + ": " + testPullRequestUrl,
+		},
+		{
+			name:         "superseded deployment is not continued on merge",
+			runnerStatus: cdWorkflow.WorkflowFailed,
+			wantStatus:   bean.PullRequestStatusSuperseded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestPullRequestModeService(t)
+			m.pullRequestRepository.On("FindAllOpen").Return([]*prRepository.GitOpsPullRequest{testOpenPullRequest()}, nil)
+			m.cdWorkflowRepository.On("FindBasicWorkflowRunnerById", testWfrId).Return(&pipelineConfig.CdWorkflowRunner{Id: testWfrId, Status: tt.runnerStatus}, nil)
+			if tt.pullRequest != nil {
+				m.gitOperationService.On("GetPullRequest", mock.Anything, testArgoAppName, testPullRequestId).Return(tt.pullRequest, nil)
+			}
+			switch tt.wantStatus {
+			case bean.PullRequestStatusSuperseded:
+				m.pullRequestRepository.On("UpdateStatusIfOpen", testId, bean.PullRequestStatusSuperseded, "", bean.SupersededMessage, systemUserId).Return(true, nil)
+			case bean.PullRequestStatusClosed:
+				m.pullRequestRepository.On("UpdateStatusIfOpen", testId, bean.PullRequestStatusClosed, "", bean.ClosedMessage, systemUserId).Return(true, nil)
+			case bean.PullRequestStatusMerged:
+				mockMerge(m, tt.pullRequest.MergeCommitHash, tt.syncErr)
+			}
+			if tt.wantStatus == bean.PullRequestStatusMerged || len(tt.wantFailure) > 0 {
+				m.pipelineOverrideRepository.On("FindById", testOverrideId).Return(testOverride(), nil)
+			}
+			if len(tt.wantFailure) > 0 {
+				mockFailure(m, tt.wantFailure)
+			}
+
+			// the mocks fail the test on a status update, sync or failure which is not expected above
+			impl.ProcessOpenPullRequests()
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestPullRequestAwaitingMergeThenMerged(t *testing.T) {
+	impl, m := newTestPullRequestModeService(t)
+	mockAwaitingMerge := func() {
+		m.pullRequestRepository.On("FindAllOpen").Return([]*prRepository.GitOpsPullRequest{testOpenPullRequest()}, nil).Once()
+		m.cdWorkflowRepository.On("FindBasicWorkflowRunnerById", testWfrId).Return(&pipelineConfig.CdWorkflowRunner{Id: testWfrId, Status: cdWorkflow.WorkflowInProgress}, nil).Once()
+	}
+
+	mockAwaitingMerge()
+	m.gitOperationService.On("GetPullRequest", mock.Anything, testArgoAppName, testPullRequestId).
+		Return(&git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateOpen}, nil).Once()
+	impl.ProcessOpenPullRequests()
+	m.assertExpectations(t)
+
+	mockAwaitingMerge()
+	m.gitOperationService.On("GetPullRequest", mock.Anything, testArgoAppName, testPullRequestId).
+		Return(&git.PullRequest{Id: testPullRequestId, State: git.PullRequestStateMerged, MergeCommitHash: "abc123"}, nil).Once()
+	m.pipelineOverrideRepository.On("FindById", testOverrideId).Return(testOverride(), nil).Once()
+	mockMerge(m, "abc123", nil)
+	impl.ProcessOpenPullRequests()
+	m.assertExpectations(t)
+
+	// merged pull requests are no longer listed as open, nothing is synced again
+	m.pullRequestRepository.On("FindAllOpen").Return(nil, pg.ErrNoRows).Once()
+	impl.ProcessOpenPullRequests()
+	m.assertExpectations(t)
+}
+
+func newTestPullRequestModeService(t *testing.T) (*PullRequestModeServiceImpl, *pullRequestModeServiceMocks) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	m := &pullRequestModeServiceMocks{
+		pullRequestRepository:         prMocks.NewGitOpsPullRequestRepository(t),
+		environmentRepository:         environmentMocks.NewEnvironmentRepository(t),
+		gitOperationService:           gitMocks.NewGitOperationService(t),
+		pipelineOverrideRepository:    chartConfigMocks.NewPipelineOverrideRepository(t),
+		cdWorkflowRepository:          pipelineConfigMocks.NewCdWorkflowRepository(t),
+		cdWorkflowCommonService:       cdWorkflowMocks.NewCdWorkflowCommonService(t),
+		pipelineStatusTimelineService: statusMocks.NewPipelineStatusTimelineService(t),
+		deploymentConfigService:       commonMocks.NewDeploymentConfigService(t),
+		argoClientWrapperService:      argoMocks.NewArgoClientWrapperService(t),
+		deploymentEventHandler:        appMocks.NewDeploymentEventHandler(t),
+		transactionManager:            sqlMocks.NewTransactionWrapper(t),
+	}
+	// the config repository is not used while processing deployments
+	impl := NewPullRequestModeServiceImpl(logger, nil, m.pullRequestRepository, m.environmentRepository, m.gitOperationService, m.pipelineOverrideRepository,
+		m.cdWorkflowRepository, m.cdWorkflowCommonService, m.pipelineStatusTimelineService, m.deploymentConfigService, m.argoClientWrapperService,
+		&argocdServer.ACDConfig{ArgoCDAutoSyncEnabled: true}, m.deploymentEventHandler, m.transactionManager)
+	return impl, m
+}
+
+func (m *pullRequestModeServiceMocks) assertExpectations(t *testing.T) {
+	m.pullRequestRepository.AssertExpectations(t)
+	m.environmentRepository.AssertExpectations(t)
+	m.gitOperationService.AssertExpectations(t)
+	m.pipelineOverrideRepository.AssertExpectations(t)
+	m.cdWorkflowRepository.AssertExpectations(t)
+	m.cdWorkflowCommonService.AssertExpectations(t)
+	m.pipelineStatusTimelineService.AssertExpectations(t)
+	m.deploymentConfigService.AssertExpectations(t)
+	m.argoClientWrapperService.AssertExpectations(t)
+	m.deploymentEventHandler.AssertExpectations(t)
+	m.transactionManager.AssertExpectations(t)
+}
+
+// testOpenPullRequest returns the pull request of a deployment awaiting merge
+func testOpenPullRequest() *prRepository.GitOpsPullRequest {
+	return &prRepository.GitOpsPullRequest{
+		Id:                 testId,
+		CdWorkflowRunnerId: testWfrId,
+		PipelineOverrideId: testOverrideId,
+		RepoName:           testArgoAppName,
+		PullRequestId:      testPullRequestId,
+		PullRequestUrl:     testPullRequestUrl,
+		Status:             bean.PullRequestStatusOpen,
+	}
+}
+
+func testOverride() *chartConfig.PipelineOverride {
+	return &chartConfig.PipelineOverride{
+		Id:       testOverrideId,
+		Pipeline: &pipelineConfig.Pipeline{Id: 1, AppId: testAppId, EnvironmentId: testEnvId, DeploymentAppName: testArgoAppName},
+	}
+}
+
+// mockTimeline expects a timeline in the status to be saved outside of a transaction
+func mockTimeline(m *pullRequestModeServiceMocks, status timelineStatus.TimelineStatus, userId int32) {
+	timeline := &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: testWfrId, Status: status}
+	m.pipelineStatusTimelineService.On("NewDevtronAppPipelineStatusTimelineDbObject", testWfrId, status, mock.Anything, userId).Return(timeline).Once()
+	m.pipelineStatusTimelineService.On("SaveTimelineIfNotAlreadyPresent", timeline, (*pg.Tx)(nil)).Return(true, nil).Once()
+}
+
+// mockMerge expects the pull request to be marked merged along with recording the merge commit as the commit of the deployment
+// in a transaction, and the argo application to be synced after
+func mockMerge(m *pullRequestModeServiceMocks, mergeCommitHash string, syncErr error) {
+	tx := &pg.Tx{}
+	m.deploymentConfigService.On("GetConfigForDevtronApps", (*pg.Tx)(nil), testAppId, testEnvId).
+		Return(&commonBean.DeploymentConfig{AppId: testAppId, EnvironmentId: testEnvId, DeploymentAppType: util.PIPELINE_DEPLOYMENT_TYPE_ACD}, nil).Once()
+	m.transactionManager.On("StartTx").Return(tx, nil).Once()
+	m.transactionManager.On("RollbackTx", tx).Return(nil).Once()
+	m.pullRequestRepository.On("UpdateStatusIfOpenWithTx", tx, testId, bean.PullRequestStatusMerged, mergeCommitHash, "", systemUserId).Return(true, nil).Once()
+	m.pipelineOverrideRepository.On("UpdateCommitDetails", mock.Anything, tx, testOverrideId, mergeCommitHash, mock.Anything, systemUserId).Return(nil).Once()
+	gitCommitTimeline := &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: testWfrId, Status: timelineStatus.TIMELINE_STATUS_GIT_COMMIT}
+	m.pipelineStatusTimelineService.On("NewDevtronAppPipelineStatusTimelineDbObject", testWfrId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT, mock.Anything, systemUserId).
+		Return(gitCommitTimeline).Once()
+	m.pipelineStatusTimelineService.On("SaveMultipleTimelinesIfNotAlreadyPresent", []*pipelineConfig.PipelineStatusTimeline{gitCommitTimeline}, tx).Return(nil).Once()
+	m.transactionManager.On("CommitTx", tx).Return(nil).Once()
+	m.argoClientWrapperService.On("SyncArgoCDApplicationIfNeededAndRefresh", mock.Anything, testArgoAppName, mock.Anything).Return(syncErr).Once()
+	if syncErr == nil {
+		mockTimeline(m, timelineStatus.TIMELINE_STATUS_DEPLOYMENT_TRIGGERED, systemUserId)
+	}
+}
+
+// mockFailure expects the deployment to be marked failed with the error and its failure to be notified
+func mockFailure(m *pullRequestModeServiceMocks, wantFailure string) {
+	m.cdWorkflowCommonService.On("MarkDeploymentFailedForRunnerId", testWfrId, mock.MatchedBy(func(err error) bool {
+		return err != nil && err.Error() == wantFailure
+	}), systemUserId).Return(nil).Once()
+	m.deploymentEventHandler.On("WriteCDNotificationEventAsync", testAppId, testEnvId, mock.Anything, eventUtil.Fail).Return().Once()
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetPullRequestModeConfigDto(config *repository.PullRequestModeConfig) *bean.PullRequestModeConfigDto {
+	return &bean.PullRequestModeConfigDto{
+		Id:            config.Id,
+		EnvironmentId: config.EnvironmentId,
+		Enabled:       config.Enabled,
+	}
+}
+
+func NewPullRequestModeConfig(configDto *bean.PullRequestModeConfigDto) *repository.PullRequestModeConfig {
+	return &repository.PullRequestModeConfig{
+		EnvironmentId: configDto.EnvironmentId,
+		Enabled:       configDto.Enabled,
+		Active:        true,
+		AuditLog:      sql.NewDefaultAuditLog(configDto.UserId),
+	}
+}
+
+func GetPullRequestDto(pullRequest *repository.GitOpsPullRequest) *bean.PullRequestDto {
+	return &bean.PullRequestDto{
+		PipelineId:         pullRequest.PipelineId,
+		CdWorkflowRunnerId: pullRequest.CdWorkflowRunnerId,
+		RepoName:           pullRequest.RepoName,
+		SourceBranch:       pullRequest.SourceBranch,
+		TargetBranch:       pullRequest.TargetBranch,
+		PullRequestId:      pullRequest.PullRequestId,
+		PullRequestUrl:     pullRequest.PullRequestUrl,
+		Status:             pullRequest.Status,
+		MergeCommitHash:    pullRequest.MergeCommitHash,
+		Message:            pullRequest.Message,
+		CreatedOn:          pullRequest.CreatedOn,
+		UpdatedOn:          pullRequest.UpdatedOn,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusMerged PullRequestStatus = "MERGED"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+	// PullRequestStatusSuperseded is set when the deployment of the pull request is superseded by a later deployment,
+	// the pull request is not tracked any further.
+	PullRequestStatusSuperseded PullRequestStatus = "SUPERSEDED"
+)
+
+func (s PullRequestStatus) String() string {
+	return string(s)
+}
+
+const (
+	// SourceBranchFormat is the branch a deployment is committed to, formatted with the pipeline override id and the environment id
+	SourceBranchFormat = "devtron/release-%d-env-%d"
+	TitleFormat        = "Deploy %s to %s (release %d)"
+	DescriptionFormat  = "Opened by Devtron for the deployment of application %s to environment %s triggered by %s.\n\nThe deployment continues once this pull request is merged and fails if it is closed."
+	ClosedMessage      = "pull request was closed without merging"
+	SupersededMessage  = "deployment was superseded, pull request is not tracked anymore"
+)
+
+type PullRequestModeConfigDto struct {
+	Id            int    `json:"id"`
+	EnvironmentId int    `json:"environmentId" validate:"number,required"`
+	Environment   string `json:"environmentName,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	UserId        int32  `json:"-"`
+}
+
+type PullRequestDto struct {
+	PipelineId         int               `json:"pipelineId"`
+	CdWorkflowRunnerId int               `json:"cdWorkflowRunnerId"`
+	RepoName           string            `json:"repoName"`
+	SourceBranch       string            `json:"sourceBranch"`
+	TargetBranch       string            `json:"targetBranch"`
+	PullRequestId      int               `json:"pullRequestId"`
+	PullRequestUrl     string            `json:"pullRequestUrl"`
+	Status             PullRequestStatus `json:"status"`
+	MergeCommitHash    string            `json:"mergeCommitHash,omitempty"`
+	Message            string            `json:"message,omitempty"`
+	CreatedOn          time.Time         `json:"createdOn"`
+	UpdatedOn          time.Time         `json:"updatedOn"`
+}
+
+// OpenPullRequestRequest is a deployment whose chart and values are committed to SourceBranch
+type OpenPullRequestRequest struct {
+	PipelineId         int
+	CdWorkflowRunnerId int
+	PipelineOverrideId int
+	AppName            string
+	EnvironmentId      int
+	RepoName           string
+	SourceBranch       string
+	TargetBranch       string
+	// TriggeredBy is the name of the user who triggered the deployment
+	TriggeredBy string
+	UserId      int32
+}
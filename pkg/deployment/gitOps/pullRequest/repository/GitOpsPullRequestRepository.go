@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type GitOpsPullRequest struct {
+	tableName          struct{}               `sql:"gitops_pull_request" pg:",discard_unknown_columns"`
+	Id                 int                    `sql:"id,pk"`
+	PipelineId         int                    `sql:"pipeline_id,notnull"`
+	CdWorkflowRunnerId int                    `sql:"cd_workflow_runner_id,notnull"`
+	PipelineOverrideId int                    `sql:"pipeline_override_id,notnull"`
+	RepoName           string                 `sql:"repo_name,notnull"`
+	SourceBranch       string                 `sql:"source_branch,notnull"`
+	TargetBranch       string                 `sql:"target_branch,notnull"`
+	PullRequestId      int                    `sql:"pull_request_id,notnull"`
+	PullRequestUrl     string                 `sql:"pull_request_url"`
+	Status             bean.PullRequestStatus `sql:"status,notnull"`
+	MergeCommitHash    string                 `sql:"merge_commit_hash"`
+	Message            string                 `sql:"message"`
+	sql.AuditLog
+}
+
+type GitOpsPullRequestRepository interface {
+	Save(pullRequest *GitOpsPullRequest) error
+	FindByCdWorkflowRunnerId(wfrId int) (*GitOpsPullRequest, error)
+	// FindAllOpen returns the open pull requests of the cd pipelines which are not deleted
+	FindAllOpen() ([]*GitOpsPullRequest, error)
+	// UpdateStatusIfOpen moves an open pull request to the given status,
+	// false is returned if the pull request was not open i.e. it is already handled by another replica
+	UpdateStatusIfOpen(id int, status bean.PullRequestStatus, mergeCommitHash, message string, userId int32) (bool, error)
+	UpdateStatusIfOpenWithTx(tx *pg.Tx, id int, status bean.PullRequestStatus, mergeCommitHash, message string, userId int32) (bool, error)
+}
+
+type GitOpsPullRequestRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewGitOpsPullRequestRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *GitOpsPullRequestRepositoryImpl {
+	return &GitOpsPullRequestRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) Save(pullRequest *GitOpsPullRequest) error {
+	return impl.dbConnection.Insert(pullRequest)
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) FindByCdWorkflowRunnerId(wfrId int) (*GitOpsPullRequest, error) {
+	pullRequest := &GitOpsPullRequest{}
+	err := impl.dbConnection.Model(pullRequest).
+		Where("cd_workflow_runner_id = ?", wfrId).
+		Select()
+	return pullRequest, err
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) FindAllOpen() ([]*GitOpsPullRequest, error) {
+	var pullRequests []*GitOpsPullRequest
+	err := impl.dbConnection.Model(&pullRequests).
+		Join("INNER JOIN pipeline p ON p.id = gitops_pull_request.pipeline_id").
+		Where("gitops_pull_request.status = ?", bean.PullRequestStatusOpen).
+		Where("p.deleted = ?", false).
+		Order("gitops_pull_request.id ASC").
+		Select()
+	return pullRequests, err
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) UpdateStatusIfOpen(id int, status bean.PullRequestStatus, mergeCommitHash, message string, userId int32) (bool, error) {
+	return updateStatusIfOpen(impl.dbConnection, id, status, mergeCommitHash, message, userId)
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) UpdateStatusIfOpenWithTx(tx *pg.Tx, id int, status bean.PullRequestStatus, mergeCommitHash, message string, userId int32) (bool, error) {
+	return updateStatusIfOpen(tx, id, status, mergeCommitHash, message, userId)
+}
+
+func updateStatusIfOpen(db orm.DB, id int, status bean.PullRequestStatus, mergeCommitHash, message string, userId int32) (bool, error) {
+	res, err := db.Model((*GitOpsPullRequest)(nil)).
+		Set("status = ?", status).
+		Set("merge_commit_hash = ?", mergeCommitHash).
+		Set("message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status = ?", bean.PullRequestStatusOpen).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
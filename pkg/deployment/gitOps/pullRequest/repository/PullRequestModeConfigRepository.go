@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type PullRequestModeConfig struct {
+	tableName     struct{} `sql:"gitops_pull_request_config" pg:",discard_unknown_columns"`
+	Id            int      `sql:"id,pk"`
+	EnvironmentId int      `sql:"environment_id,notnull"`
+	Enabled       bool     `sql:"enabled,notnull"`
+	Active        bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type PullRequestModeConfigRepository interface {
+	Save(config *PullRequestModeConfig) error
+	Update(config *PullRequestModeConfig) error
+	FindByEnvironmentId(envId int) (*PullRequestModeConfig, error)
+	FindAllActive() ([]*PullRequestModeConfig, error)
+	MarkInactiveByEnvironmentId(envId int, userId int32) error
+}
+
+type PullRequestModeConfigRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewPullRequestModeConfigRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *PullRequestModeConfigRepositoryImpl {
+	return &PullRequestModeConfigRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *PullRequestModeConfigRepositoryImpl) Save(config *PullRequestModeConfig) error {
+	return impl.dbConnection.Insert(config)
+}
+
+func (impl *PullRequestModeConfigRepositoryImpl) Update(config *PullRequestModeConfig) error {
+	return impl.dbConnection.Update(config)
+}
+
+func (impl *PullRequestModeConfigRepositoryImpl) FindByEnvironmentId(envId int) (*PullRequestModeConfig, error) {
+	config := &PullRequestModeConfig{}
+	err := impl.dbConnection.Model(config).
+		Where("environment_id = ?", envId).
+		Where("active = ?", true).
+		Select()
+	return config, err
+}
+
+func (impl *PullRequestModeConfigRepositoryImpl) FindAllActive() ([]*PullRequestModeConfig, error) {
+	var configs []*PullRequestModeConfig
+	err := impl.dbConnection.Model(&configs).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return configs, err
+}
+
+func (impl *PullRequestModeConfigRepositoryImpl) MarkInactiveByEnvironmentId(envId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*PullRequestModeConfig)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("environment_id = ?", envId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
@@ -0,0 +1,165 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	bean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
+	mock "github.com/stretchr/testify/mock"
+
+	pg "github.com/go-pg/pg"
+
+	repository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+)
+
+// GitOpsPullRequestRepository is an autogenerated mock type for the GitOpsPullRequestRepository type
+type GitOpsPullRequestRepository struct {
+	mock.Mock
+}
+
+// FindAllOpen provides a mock function with no fields
+func (_m *GitOpsPullRequestRepository) FindAllOpen() ([]*repository.GitOpsPullRequest, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllOpen")
+	}
+
+	var r0 []*repository.GitOpsPullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*repository.GitOpsPullRequest, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*repository.GitOpsPullRequest); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.GitOpsPullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByCdWorkflowRunnerId provides a mock function with given fields: wfrId
+func (_m *GitOpsPullRequestRepository) FindByCdWorkflowRunnerId(wfrId int) (*repository.GitOpsPullRequest, error) {
+	ret := _m.Called(wfrId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCdWorkflowRunnerId")
+	}
+
+	var r0 *repository.GitOpsPullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*repository.GitOpsPullRequest, error)); ok {
+		return rf(wfrId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *repository.GitOpsPullRequest); ok {
+		r0 = rf(wfrId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.GitOpsPullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(wfrId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: pullRequest
+func (_m *GitOpsPullRequestRepository) Save(pullRequest *repository.GitOpsPullRequest) error {
+	ret := _m.Called(pullRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.GitOpsPullRequest) error); ok {
+		r0 = rf(pullRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatusIfOpen provides a mock function with given fields: id, status, mergeCommitHash, message, userId
+func (_m *GitOpsPullRequestRepository) UpdateStatusIfOpen(id int, status bean.PullRequestStatus, mergeCommitHash string, message string, userId int32) (bool, error) {
+	ret := _m.Called(id, status, mergeCommitHash, message, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusIfOpen")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, bean.PullRequestStatus, string, string, int32) (bool, error)); ok {
+		return rf(id, status, mergeCommitHash, message, userId)
+	}
+	if rf, ok := ret.Get(0).(func(int, bean.PullRequestStatus, string, string, int32) bool); ok {
+		r0 = rf(id, status, mergeCommitHash, message, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, bean.PullRequestStatus, string, string, int32) error); ok {
+		r1 = rf(id, status, mergeCommitHash, message, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusIfOpenWithTx provides a mock function with given fields: tx, id, status, mergeCommitHash, message, userId
+func (_m *GitOpsPullRequestRepository) UpdateStatusIfOpenWithTx(tx *pg.Tx, id int, status bean.PullRequestStatus, mergeCommitHash string, message string, userId int32) (bool, error) {
+	ret := _m.Called(tx, id, status, mergeCommitHash, message, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusIfOpenWithTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, bean.PullRequestStatus, string, string, int32) (bool, error)); ok {
+		return rf(tx, id, status, mergeCommitHash, message, userId)
+	}
+	if rf, ok := ret.Get(0).(func(*pg.Tx, int, bean.PullRequestStatus, string, string, int32) bool); ok {
+		r0 = rf(tx, id, status, mergeCommitHash, message, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*pg.Tx, int, bean.PullRequestStatus, string, string, int32) error); ok {
+		r1 = rf(tx, id, status, mergeCommitHash, message, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGitOpsPullRequestRepository creates a new instance of GitOpsPullRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGitOpsPullRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GitOpsPullRequestRepository {
+	mock := &GitOpsPullRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
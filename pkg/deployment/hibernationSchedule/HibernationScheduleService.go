@@ -0,0 +1,614 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationSchedule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	resourceGroupRepository "github.com/devtron-labs/devtron/internal/sql/repository/resourceGroup"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	buildScheduleUtil "github.com/devtron-labs/devtron/pkg/build/schedule/util"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment"
+	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
+	deployedAppBean "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/repository"
+	scheduleUtil "github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/util"
+	devtronResourceBean "github.com/devtron-labs/devtron/pkg/devtronResource/bean"
+	"github.com/devtron-labs/devtron/pkg/devtronResource/read"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	leaderElectionBean "github.com/devtron-labs/devtron/pkg/leaderElection/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+const runHistoryLimit = 50
+
+type HibernationScheduleService interface {
+	GetSchedules() ([]*bean.HibernationScheduleDto, error)
+	GetSchedule(id int) (*bean.HibernationScheduleDto, error)
+	// SaveSchedule creates the schedule, or updates it when the id is set
+	SaveSchedule(request *bean.HibernationScheduleDto) (*bean.HibernationScheduleDto, error)
+	DeleteSchedule(id int, userId int32) error
+	// SaveAppOverride opts an app of the schedule out or keeps it awake until the given time
+	SaveAppOverride(request *bean.AppOverrideDto) (*bean.AppOverrideDto, error)
+	// KeepAwake skips the sleeps of the schedule until the given time, optionally waking its apps up right away. The
+	// apps are woken up only if checkAuthForWake allows the user to trigger every one of them.
+	KeepAwake(request *bean.KeepAwakeRequest, checkAuthForWake func(target *bean.Target) bool) (*bean.HibernationScheduleDto, error)
+	GetRuns(scheduleId int) ([]*bean.RunDto, error)
+}
+
+type HibernationScheduleServiceImpl struct {
+	logger                              *zap.SugaredLogger
+	config                              *bean.HibernationScheduleConfig
+	scheduleRepository                  repository.HibernationScheduleRepository
+	appOverrideRepository               repository.HibernationScheduleAppOverrideRepository
+	runRepository                       repository.HibernationScheduleRunRepository
+	environmentService                  environment.EnvironmentService
+	pipelineRepository                  pipelineConfig.PipelineRepository
+	resourceGroupRepository             resourceGroupRepository.ResourceGroupRepository
+	resourceGroupMappingRepository      resourceGroupRepository.ResourceGroupMappingRepository
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService
+	deployedAppService                  deployedApp.DeployedAppService
+	leaderElectionService               leaderElection.LeaderElectionService
+	asyncRunnable                       *async.Runnable
+}
+
+func NewHibernationScheduleServiceImpl(logger *zap.SugaredLogger,
+	scheduleRepository repository.HibernationScheduleRepository,
+	appOverrideRepository repository.HibernationScheduleAppOverrideRepository,
+	runRepository repository.HibernationScheduleRunRepository,
+	environmentService environment.EnvironmentService,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	resourceGroupRepository resourceGroupRepository.ResourceGroupRepository,
+	resourceGroupMappingRepository resourceGroupRepository.ResourceGroupMappingRepository,
+	devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService,
+	deployedAppService deployedApp.DeployedAppService,
+	leaderElectionService leaderElection.LeaderElectionService,
+	asyncRunnable *async.Runnable,
+	cronLogger *cron2.CronLoggerImpl) (*HibernationScheduleServiceImpl, error) {
+	config := &bean.HibernationScheduleConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing hibernation schedule config", "err", err)
+		return nil, err
+	}
+	impl := &HibernationScheduleServiceImpl{
+		logger:                              logger,
+		config:                              config,
+		scheduleRepository:                  scheduleRepository,
+		appOverrideRepository:               appOverrideRepository,
+		runRepository:                       runRepository,
+		environmentService:                  environmentService,
+		pipelineRepository:                  pipelineRepository,
+		resourceGroupRepository:             resourceGroupRepository,
+		resourceGroupMappingRepository:      resourceGroupMappingRepository,
+		devtronResourceSearchableKeyService: devtronResourceSearchableKeyService,
+		deployedAppService:                  deployedAppService,
+		leaderElectionService:               leaderElectionService,
+		asyncRunnable:                       asyncRunnable,
+	}
+	if !config.Enabled {
+		return impl, nil
+	}
+	// every replica polls, only the leader runs the due schedules
+	schedulerCron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	schedulerCron.Start()
+	_, err = schedulerCron.AddFunc(config.SchedulerCron, impl.runDueSchedules)
+	if err != nil {
+		logger.Errorw("error while configure cron job for hibernation schedules", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) GetSchedules() ([]*bean.HibernationScheduleDto, error) {
+	schedules, err := impl.scheduleRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernation schedules", "err", err)
+		return nil, err
+	}
+	res := make([]*bean.HibernationScheduleDto, 0, len(schedules))
+	for _, schedule := range schedules {
+		res = append(res, adapter.GetHibernationScheduleDto(schedule))
+	}
+	return res, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) GetSchedule(id int) (*bean.HibernationScheduleDto, error) {
+	schedule, err := impl.findSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	res := adapter.GetHibernationScheduleDto(schedule)
+	environmentBean, err := impl.environmentService.FindById(schedule.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment of schedule", "envId", schedule.EnvironmentId, "err", err)
+		return nil, err
+	}
+	res.EnvironmentName = environmentBean.Environment
+	if schedule.ResourceGroupId > 0 {
+		resourceGroup, err := impl.resourceGroupRepository.FindById(schedule.ResourceGroupId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching resource group of schedule", "resourceGroupId", schedule.ResourceGroupId, "err", err)
+			return nil, err
+		}
+		if err == nil {
+			res.ResourceGroupName = resourceGroup.Name
+		}
+	}
+	targets, err := impl.getTargets(schedule)
+	if err != nil {
+		return nil, err
+	}
+	appNames := make(map[int]string, len(targets))
+	for _, target := range targets {
+		appNames[target.AppId] = target.AppName
+	}
+	overrides, err := impl.appOverrideRepository.FindByScheduleId(schedule.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching app overrides of schedule", "scheduleId", schedule.Id, "err", err)
+		return nil, err
+	}
+	res.AppOverrides = make([]*bean.AppOverrideDto, 0, len(overrides))
+	for _, override := range overrides {
+		overrideDto := adapter.GetAppOverrideDto(override)
+		overrideDto.AppName = appNames[override.AppId]
+		res.AppOverrides = append(res.AppOverrides, overrideDto)
+	}
+	return res, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) SaveSchedule(request *bean.HibernationScheduleDto) (*bean.HibernationScheduleDto, error) {
+	err := impl.validateSchedule(request)
+	if err != nil {
+		return nil, err
+	}
+	var schedule *repository.HibernationSchedule
+	if request.Id > 0 {
+		schedule, err = impl.findSchedule(request.Id)
+		if err != nil {
+			return nil, err
+		}
+		schedule.UpdateAuditLog(request.UserId)
+	} else {
+		schedule = &repository.HibernationSchedule{
+			Active:   true,
+			AuditLog: sql.NewDefaultAuditLog(request.UserId),
+		}
+	}
+	schedule.Name = strings.TrimSpace(request.Name)
+	schedule.EnvironmentId = request.EnvironmentId
+	schedule.ResourceGroupId = request.ResourceGroupId
+	schedule.SleepCron = strings.TrimSpace(request.SleepCron)
+	schedule.WakeCron = strings.TrimSpace(request.WakeCron)
+	schedule.Timezone = request.Timezone
+	schedule.Holidays = request.Holidays
+	schedule.KeepAwakeUntil = request.KeepAwakeUntil
+	// the runs are computed afresh, changing the crons must not run the ones missed under the previous crons
+	err = impl.setNextRuns(schedule, time.Now(), true)
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	if request.Id > 0 {
+		err = impl.scheduleRepository.Update(schedule)
+	} else {
+		err = impl.scheduleRepository.Save(schedule)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving hibernation schedule", "request", request, "err", err)
+		return nil, err
+	}
+	return impl.GetSchedule(schedule.Id)
+}
+
+// validateSchedule validates the request and resolves its environment and timezone
+func (impl *HibernationScheduleServiceImpl) validateSchedule(request *bean.HibernationScheduleDto) error {
+	if request.ResourceGroupId > 0 {
+		resourceGroup, err := impl.resourceGroupRepository.FindById(request.ResourceGroupId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching resource group", "resourceGroupId", request.ResourceGroupId, "err", err)
+			return err
+		}
+		// only the groups of the apps of an environment are supported, the schedule runs on a single environment
+		envKey := impl.devtronResourceSearchableKeyService.GetAllSearchableKeyNameIdMap()[devtronResourceBean.DEVTRON_RESOURCE_SEARCHABLE_KEY_ENV_ID]
+		if util.IsErrNoRows(err) || !resourceGroup.Active || resourceGroup.ResourceKey != envKey {
+			return util.NewApiError(http.StatusBadRequest, "resource group must be an active group of the apps of an environment", "resource group not found")
+		}
+		if request.EnvironmentId > 0 && request.EnvironmentId != resourceGroup.ResourceId {
+			return util.NewApiError(http.StatusBadRequest, "resource group does not belong to the environment", "resource group environment mismatch")
+		}
+		request.EnvironmentId = resourceGroup.ResourceId
+	}
+	if request.EnvironmentId == 0 {
+		return util.NewApiError(http.StatusBadRequest, "environment or resource group is required", "environment not set")
+	}
+	_, err := impl.environmentService.FindById(request.EnvironmentId)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusBadRequest, fmt.Sprintf("environment %d not found", request.EnvironmentId), "environment not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", request.EnvironmentId, "err", err)
+		return err
+	}
+	request.Timezone = strings.TrimSpace(request.Timezone)
+	if len(request.Timezone) == 0 {
+		request.Timezone = bean.DefaultScheduleTimezone
+	}
+	if err = scheduleUtil.ValidateHolidays(request.Holidays); err != nil {
+		return util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	return nil
+}
+
+func (impl *HibernationScheduleServiceImpl) DeleteSchedule(id int, userId int32) error {
+	schedule, err := impl.findSchedule(id)
+	if err != nil {
+		return err
+	}
+	schedule.Active = false
+	schedule.UpdateAuditLog(userId)
+	err = impl.scheduleRepository.Update(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in deleting hibernation schedule", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *HibernationScheduleServiceImpl) SaveAppOverride(request *bean.AppOverrideDto) (*bean.AppOverrideDto, error) {
+	schedule, err := impl.findSchedule(request.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := impl.getTargets(schedule)
+	if err != nil {
+		return nil, err
+	}
+	appName := ""
+	for _, target := range targets {
+		if target.AppId == request.AppId {
+			appName = target.AppName
+		}
+	}
+	if len(appName) == 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "app is not deployed to the environment of the schedule", "app not a target of the schedule")
+	}
+	override, err := impl.appOverrideRepository.FindByScheduleIdAndAppId(schedule.Id, request.AppId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching app override", "scheduleId", schedule.Id, "appId", request.AppId, "err", err)
+		return nil, err
+	}
+	isNew := util.IsErrNoRows(err)
+	if isNew {
+		override = &repository.HibernationScheduleAppOverride{
+			ScheduleId: schedule.Id,
+			AppId:      request.AppId,
+			AuditLog:   sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		override.UpdateAuditLog(request.UserId)
+	}
+	override.OptOut = request.OptOut
+	override.KeepAwakeUntil = request.KeepAwakeUntil
+	if isNew {
+		err = impl.appOverrideRepository.Save(override)
+	} else {
+		err = impl.appOverrideRepository.Update(override)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving app override", "scheduleId", schedule.Id, "appId", request.AppId, "err", err)
+		return nil, err
+	}
+	res := adapter.GetAppOverrideDto(override)
+	res.AppName = appName
+	return res, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) KeepAwake(request *bean.KeepAwakeRequest, checkAuthForWake func(target *bean.Target) bool) (*bean.HibernationScheduleDto, error) {
+	schedule, err := impl.findSchedule(request.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+	if request.WakeNow {
+		// waking up deploys the apps, so the user must be able to trigger each of them like in the bulk un-hibernate
+		targets, err := impl.getTargets(schedule)
+		if err != nil {
+			return nil, err
+		}
+		var unauthorizedApps []string
+		for _, target := range targets {
+			if !checkAuthForWake(target) {
+				unauthorizedApps = append(unauthorizedApps, target.AppName)
+			}
+		}
+		if len(unauthorizedApps) > 0 {
+			return nil, util.NewApiError(http.StatusForbidden, fmt.Sprintf("not authorized to wake up %s", strings.Join(unauthorizedApps, ", ")), "unauthorized user")
+		}
+	}
+	schedule.KeepAwakeUntil = request.Until
+	schedule.UpdateAuditLog(request.UserId)
+	err = impl.scheduleRepository.Update(schedule)
+	if err != nil {
+		impl.logger.Errorw("error in updating keep awake of schedule", "scheduleId", schedule.Id, "err", err)
+		return nil, err
+	}
+	if request.WakeNow {
+		// the apps are woken up on behalf of the user, so the deployments are recorded as manual ones
+		impl.asyncRunnable.Execute(func() {
+			impl.executeRun(schedule, bean.ActionWake, time.Now(), request.UserId)
+		})
+	}
+	return adapter.GetHibernationScheduleDto(schedule), nil
+}
+
+func (impl *HibernationScheduleServiceImpl) GetRuns(scheduleId int) ([]*bean.RunDto, error) {
+	runs, err := impl.runRepository.FindByScheduleId(scheduleId, runHistoryLimit)
+	if err != nil {
+		impl.logger.Errorw("error in fetching runs of schedule", "scheduleId", scheduleId, "err", err)
+		return nil, err
+	}
+	runIds := make([]int, 0, len(runs))
+	for _, run := range runs {
+		runIds = append(runIds, run.Id)
+	}
+	targets, err := impl.runRepository.FindTargetsByRunIds(runIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching run targets of schedule", "scheduleId", scheduleId, "err", err)
+		return nil, err
+	}
+	targetsByRunId := make(map[int][]*repository.HibernationScheduleRunTarget, len(runs))
+	for _, target := range targets {
+		targetsByRunId[target.RunId] = append(targetsByRunId[target.RunId], target)
+	}
+	res := make([]*bean.RunDto, 0, len(runs))
+	for _, run := range runs {
+		res = append(res, adapter.GetRunDto(run, targetsByRunId[run.Id]))
+	}
+	return res, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) runDueSchedules() {
+	if !impl.leaderElectionService.IsLeader(leaderElectionBean.HibernationScheduler) {
+		return
+	}
+	now := time.Now()
+	schedules, err := impl.scheduleRepository.FindActiveDueBefore(now)
+	if err != nil {
+		impl.logger.Errorw("error in fetching due hibernation schedules", "err", err)
+		return
+	}
+	for _, schedule := range schedules {
+		action, scheduledAt, due := scheduleUtil.GetDueAction(schedule.NextSleepAt, schedule.NextWakeAt, now)
+		if !due {
+			continue
+		}
+		readUpdatedOn := schedule.UpdatedOn
+		err = impl.setNextRuns(schedule, now, false)
+		if err != nil {
+			// the crons were validated on save, so this only happens if the cron library changed
+			impl.logger.Errorw("error in computing next runs of schedule", "scheduleId", schedule.Id, "err", err)
+			continue
+		}
+		schedule.UpdateAuditLog(userBean.SystemUserId)
+		claimed, err := impl.scheduleRepository.UpdateNextRuns(schedule, readUpdatedOn)
+		if err != nil {
+			impl.logger.Errorw("error in claiming run of schedule", "scheduleId", schedule.Id, "err", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		impl.executeRun(schedule, action, scheduledAt, userBean.SystemUserId)
+	}
+}
+
+// setNextRuns moves the due runs of the schedule, or all of them when reset, to their next activation after now
+func (impl *HibernationScheduleServiceImpl) setNextRuns(schedule *repository.HibernationSchedule, now time.Time, reset bool) error {
+	if reset || !schedule.NextSleepAt.After(now) {
+		nextSleepAt, err := buildScheduleUtil.GetNextRunTime(schedule.SleepCron, schedule.Timezone, now)
+		if err != nil {
+			return fmt.Errorf("sleep cron: %w", err)
+		}
+		schedule.NextSleepAt = nextSleepAt
+	}
+	if len(schedule.WakeCron) == 0 {
+		schedule.NextWakeAt = nil
+		return nil
+	}
+	if reset || schedule.NextWakeAt == nil || !schedule.NextWakeAt.After(now) {
+		nextWakeAt, err := buildScheduleUtil.GetNextRunTime(schedule.WakeCron, schedule.Timezone, now)
+		if err != nil {
+			return fmt.Errorf("wake cron: %w", err)
+		}
+		schedule.NextWakeAt = &nextWakeAt
+	}
+	return nil
+}
+
+// executeRun puts the apps of the schedule to sleep or wakes them up and records the outcome of every app
+func (impl *HibernationScheduleServiceImpl) executeRun(schedule *repository.HibernationSchedule, action bean.Action, scheduledAt time.Time, userId int32) {
+	now := time.Now()
+	run := &repository.HibernationScheduleRun{
+		ScheduleId:  schedule.Id,
+		Action:      action.String(),
+		ScheduledAt: scheduledAt,
+		Status:      bean.RunStatusRunning.String(),
+		TriggeredBy: userId,
+		StartedOn:   now,
+	}
+	err := impl.runRepository.Save(run)
+	if err != nil {
+		impl.logger.Errorw("error in saving run of schedule", "scheduleId", schedule.Id, "action", action, "err", err)
+		return
+	}
+	isScheduledRun := userId == userBean.SystemUserId
+	if action == bean.ActionSleep && scheduleUtil.IsKeptAwake(schedule.KeepAwakeUntil, now) {
+		impl.finishRun(run, bean.RunStatusSkipped, fmt.Sprintf(bean.KeptAwakeMessageTmpl, schedule.KeepAwakeUntil.Format(time.RFC3339)), nil)
+		return
+	}
+	if action == bean.ActionWake && isScheduledRun && scheduleUtil.IsHoliday(schedule.Holidays, schedule.Timezone, scheduledAt) {
+		impl.finishRun(run, bean.RunStatusSkipped, bean.HolidayMessage, nil)
+		return
+	}
+	targets, err := impl.getTargets(schedule)
+	if err != nil {
+		impl.finishRun(run, bean.RunStatusFailed, fmt.Sprintf("error in resolving the apps of the schedule: %s", err.Error()), nil)
+		return
+	}
+	if len(targets) == 0 {
+		impl.finishRun(run, bean.RunStatusSkipped, bean.NoAppsMessage, nil)
+		return
+	}
+	overrides, err := impl.appOverrideRepository.FindByScheduleId(schedule.Id)
+	if err != nil {
+		impl.finishRun(run, bean.RunStatusFailed, fmt.Sprintf("error in fetching the app overrides of the schedule: %s", err.Error()), nil)
+		return
+	}
+	overrideByAppId := make(map[int]*repository.HibernationScheduleAppOverride, len(overrides))
+	for _, override := range overrides {
+		overrideByAppId[override.AppId] = override
+	}
+	requestType := deployedAppBean.STOP
+	if action == bean.ActionWake {
+		requestType = deployedAppBean.START
+	}
+	runTargets := make([]*repository.HibernationScheduleRunTarget, 0, len(targets))
+	triggeredCount, failedCount := 0, 0
+	for _, target := range targets {
+		runTarget := &repository.HibernationScheduleRunTarget{
+			AppId:         target.AppId,
+			EnvironmentId: target.EnvironmentId,
+		}
+		runTargets = append(runTargets, runTarget)
+		if override, ok := overrideByAppId[target.AppId]; ok {
+			if override.OptOut {
+				runTarget.Status, runTarget.Message = bean.TargetStatusSkipped.String(), bean.OptedOutMessage
+				continue
+			}
+			if action == bean.ActionSleep && scheduleUtil.IsKeptAwake(override.KeepAwakeUntil, now) {
+				runTarget.Status = bean.TargetStatusSkipped.String()
+				runTarget.Message = fmt.Sprintf(bean.KeptAwakeMessageTmpl, override.KeepAwakeUntil.Format(time.RFC3339))
+				continue
+			}
+		}
+		_, err = impl.deployedAppService.StopStartApp(context.Background(), &deployedAppBean.StopAppRequest{
+			AppId:             target.AppId,
+			EnvironmentId:     target.EnvironmentId,
+			UserId:            userId,
+			RequestType:       requestType,
+			TriggeredBySystem: isScheduledRun,
+		}, nil)
+		if err != nil {
+			impl.logger.Errorw("error in running hibernation schedule for app", "scheduleId", schedule.Id, "appId", target.AppId, "envId", target.EnvironmentId, "action", action, "err", err)
+			runTarget.Status, runTarget.Message = bean.TargetStatusFailed.String(), util.GetClientErrorDetailedMessage(err)
+			failedCount++
+			continue
+		}
+		runTarget.Status = bean.TargetStatusTriggered.String()
+		triggeredCount++
+	}
+	status, message := bean.RunStatusSucceeded, fmt.Sprintf("%d of %d apps triggered", triggeredCount, len(targets))
+	switch {
+	case failedCount > 0 && triggeredCount == 0:
+		status = bean.RunStatusFailed
+	case failedCount > 0:
+		status = bean.RunStatusPartiallyFailed
+	case triggeredCount == 0:
+		status = bean.RunStatusSkipped
+	}
+	impl.finishRun(run, status, message, runTargets)
+}
+
+func (impl *HibernationScheduleServiceImpl) finishRun(run *repository.HibernationScheduleRun, status bean.RunStatus, message string, targets []*repository.HibernationScheduleRunTarget) {
+	for _, target := range targets {
+		target.RunId = run.Id
+	}
+	err := impl.runRepository.SaveTargets(targets)
+	if err != nil {
+		impl.logger.Errorw("error in saving run targets of schedule", "runId", run.Id, "err", err)
+	}
+	finishedOn := time.Now()
+	run.Status = status.String()
+	run.Message = message
+	run.FinishedOn = &finishedOn
+	err = impl.runRepository.Update(run)
+	if err != nil {
+		impl.logger.Errorw("error in updating run of schedule", "runId", run.Id, "err", err)
+	}
+}
+
+// getTargets returns the apps deployed to the environment of the schedule, limited to the apps of its resource group
+func (impl *HibernationScheduleServiceImpl) getTargets(schedule *repository.HibernationSchedule) ([]*bean.Target, error) {
+	pipelines, err := impl.pipelineRepository.FindActiveByEnvId(schedule.EnvironmentId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching pipelines of environment", "envId", schedule.EnvironmentId, "err", err)
+		return nil, err
+	}
+	var groupAppIds map[int]bool
+	if schedule.ResourceGroupId > 0 {
+		mappings, err := impl.resourceGroupMappingRepository.FindByResourceGroupId(schedule.ResourceGroupId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching apps of resource group", "resourceGroupId", schedule.ResourceGroupId, "err", err)
+			return nil, err
+		}
+		groupAppIds = make(map[int]bool, len(mappings))
+		for _, mapping := range mappings {
+			groupAppIds[mapping.ResourceId] = true
+		}
+	}
+	targets := make([]*bean.Target, 0, len(pipelines))
+	seen := make(map[int]bool, len(pipelines))
+	for _, pipeline := range pipelines {
+		if seen[pipeline.AppId] || (groupAppIds != nil && !groupAppIds[pipeline.AppId]) {
+			continue
+		}
+		seen[pipeline.AppId] = true
+		appName := ""
+		if pipeline.App.Id > 0 {
+			appName = pipeline.App.AppName
+		}
+		targets = append(targets, &bean.Target{
+			AppId:         pipeline.AppId,
+			AppName:       appName,
+			EnvironmentId: pipeline.EnvironmentId,
+		})
+	}
+	return targets, nil
+}
+
+func (impl *HibernationScheduleServiceImpl) findSchedule(id int) (*repository.HibernationSchedule, error) {
+	schedule, err := impl.scheduleRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("hibernation schedule %d not found", id), "hibernation schedule not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching hibernation schedule", "id", id, "err", err)
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/repository"
+)
+
+func GetHibernationScheduleDto(schedule *repository.HibernationSchedule) *bean.HibernationScheduleDto {
+	holidays := schedule.Holidays
+	if holidays == nil {
+		holidays = make([]string, 0)
+	}
+	nextSleepAt := schedule.NextSleepAt
+	return &bean.HibernationScheduleDto{
+		Id:              schedule.Id,
+		Name:            schedule.Name,
+		EnvironmentId:   schedule.EnvironmentId,
+		ResourceGroupId: schedule.ResourceGroupId,
+		SleepCron:       schedule.SleepCron,
+		WakeCron:        schedule.WakeCron,
+		Timezone:        schedule.Timezone,
+		Holidays:        holidays,
+		KeepAwakeUntil:  schedule.KeepAwakeUntil,
+		NextSleepAt:     &nextSleepAt,
+		NextWakeAt:      schedule.NextWakeAt,
+	}
+}
+
+func GetAppOverrideDto(override *repository.HibernationScheduleAppOverride) *bean.AppOverrideDto {
+	return &bean.AppOverrideDto{
+		ScheduleId:     override.ScheduleId,
+		AppId:          override.AppId,
+		OptOut:         override.OptOut,
+		KeepAwakeUntil: override.KeepAwakeUntil,
+	}
+}
+
+func GetRunDto(run *repository.HibernationScheduleRun, targets []*repository.HibernationScheduleRunTarget) *bean.RunDto {
+	targetDtos := make([]*bean.RunTargetDto, 0, len(targets))
+	for _, target := range targets {
+		targetDtos = append(targetDtos, &bean.RunTargetDto{
+			AppId:         target.AppId,
+			EnvironmentId: target.EnvironmentId,
+			Status:        bean.TargetStatus(target.Status),
+			Message:       target.Message,
+		})
+	}
+	return &bean.RunDto{
+		Id:          run.Id,
+		ScheduleId:  run.ScheduleId,
+		Action:      bean.Action(run.Action),
+		ScheduledAt: run.ScheduledAt,
+		Status:      bean.RunStatus(run.Status),
+		Message:     run.Message,
+		TriggeredBy: run.TriggeredBy,
+		StartedOn:   run.StartedOn,
+		FinishedOn:  run.FinishedOn,
+		Targets:     targetDtos,
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+// CATEGORY=HIBERNATION_SCHEDULE
+type HibernationScheduleConfig struct {
+	Enabled       bool   `env:"HIBERNATION_SCHEDULE_ENABLED" envDefault:"true" description:"Enables the scheduled hibernation and wake up of the environments"`
+	SchedulerCron string `env:"HIBERNATION_SCHEDULE_CRON" envDefault:"* * * * *" description:"Cron at which the leader replica polls the due hibernation schedules and runs them"`
+}
+
+type Action string
+
+const (
+	ActionSleep Action = "SLEEP"
+	ActionWake  Action = "WAKE"
+)
+
+func (a Action) String() string {
+	return string(a)
+}
+
+type RunStatus string
+
+const (
+	RunStatusRunning         RunStatus = "RUNNING"
+	RunStatusSucceeded       RunStatus = "SUCCEEDED"
+	RunStatusPartiallyFailed RunStatus = "PARTIALLY_FAILED"
+	RunStatusFailed          RunStatus = "FAILED"
+	RunStatusSkipped         RunStatus = "SKIPPED"
+)
+
+func (s RunStatus) String() string {
+	return string(s)
+}
+
+type TargetStatus string
+
+const (
+	TargetStatusTriggered TargetStatus = "TRIGGERED"
+	TargetStatusFailed    TargetStatus = "FAILED"
+	TargetStatusSkipped   TargetStatus = "SKIPPED"
+)
+
+func (s TargetStatus) String() string {
+	return string(s)
+}
+
+const (
+	DefaultScheduleTimezone = "UTC"
+	// HolidayDateLayout is the layout of the holidays, the dates are in the timezone of the schedule
+	HolidayDateLayout = "2006-01-02"
+
+	HolidayMessage       = "holiday, the apps are not woken up"
+	OptedOutMessage      = "app opted out of the schedule"
+	NoAppsMessage        = "no apps are deployed to the environment of the schedule"
+	KeptAwakeMessageTmpl = "kept awake until %s"
+)
+
+// HibernationScheduleDto puts the apps of an environment, or of a resource group of an environment, to sleep and
+// wakes them up on cron schedules
+type HibernationScheduleDto struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name" validate:"required,max=250"`
+	EnvironmentId     int    `json:"environmentId"`
+	EnvironmentName   string `json:"environmentName,omitempty"`
+	ResourceGroupId   int    `json:"resourceGroupId,omitempty"` // apps of the group are scheduled instead of all the apps of the environment
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+	SleepCron         string `json:"sleepCron" validate:"required"`
+	// WakeCron is optional, the apps stay hibernated until woken up manually when it is not set
+	WakeCron       string            `json:"wakeCron"`
+	Timezone       string            `json:"timezone"`
+	Holidays       []string          `json:"holidays"`
+	KeepAwakeUntil *time.Time        `json:"keepAwakeUntil,omitempty"`
+	NextSleepAt    *time.Time        `json:"nextSleepAt,omitempty"`
+	NextWakeAt     *time.Time        `json:"nextWakeAt,omitempty"`
+	AppOverrides   []*AppOverrideDto `json:"appOverrides,omitempty"`
+	UserId         int32             `json:"-"`
+}
+
+// AppOverrideDto opts an app out of the schedule or keeps it awake until the given time
+type AppOverrideDto struct {
+	ScheduleId     int        `json:"scheduleId"`
+	AppId          int        `json:"appId" validate:"required,min=1"`
+	AppName        string     `json:"appName,omitempty"`
+	OptOut         bool       `json:"optOut"`
+	KeepAwakeUntil *time.Time `json:"keepAwakeUntil,omitempty"`
+	UserId         int32      `json:"-"`
+}
+
+// KeepAwakeRequest skips the sleeps of the schedule until the given time, a nil time clears the override
+type KeepAwakeRequest struct {
+	ScheduleId int        `json:"-"`
+	Until      *time.Time `json:"until"`
+	// WakeNow wakes the apps of the schedule up right away
+	WakeNow bool  `json:"wakeNow"`
+	UserId  int32 `json:"-"`
+}
+
+type RunDto struct {
+	Id          int             `json:"id"`
+	ScheduleId  int             `json:"scheduleId"`
+	Action      Action          `json:"action"`
+	ScheduledAt time.Time       `json:"scheduledAt"`
+	Status      RunStatus       `json:"status"`
+	Message     string          `json:"message,omitempty"`
+	TriggeredBy int32           `json:"triggeredBy"`
+	StartedOn   time.Time       `json:"startedOn"`
+	FinishedOn  *time.Time      `json:"finishedOn,omitempty"`
+	Targets     []*RunTargetDto `json:"targets"`
+}
+
+type RunTargetDto struct {
+	AppId         int          `json:"appId"`
+	EnvironmentId int          `json:"environmentId"`
+	Status        TargetStatus `json:"status"`
+	Message       string       `json:"message,omitempty"`
+}
+
+// Target is an app of the environment of a schedule
+type Target struct {
+	AppId         int
+	AppName       string
+	EnvironmentId int
+}
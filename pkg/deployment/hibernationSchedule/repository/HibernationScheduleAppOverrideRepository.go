@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type HibernationScheduleAppOverride struct {
+	tableName      struct{}   `sql:"hibernation_schedule_app_override" pg:",discard_unknown_columns"`
+	Id             int        `sql:"id,pk"`
+	ScheduleId     int        `sql:"schedule_id,notnull"`
+	AppId          int        `sql:"app_id,notnull"`
+	OptOut         bool       `sql:"opt_out,notnull"`
+	KeepAwakeUntil *time.Time `sql:"keep_awake_until"`
+	sql.AuditLog
+}
+
+type HibernationScheduleAppOverrideRepository interface {
+	Save(override *HibernationScheduleAppOverride) error
+	Update(override *HibernationScheduleAppOverride) error
+	FindByScheduleId(scheduleId int) ([]*HibernationScheduleAppOverride, error)
+	FindByScheduleIdAndAppId(scheduleId, appId int) (*HibernationScheduleAppOverride, error)
+}
+
+type HibernationScheduleAppOverrideRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewHibernationScheduleAppOverrideRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *HibernationScheduleAppOverrideRepositoryImpl {
+	return &HibernationScheduleAppOverrideRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *HibernationScheduleAppOverrideRepositoryImpl) Save(override *HibernationScheduleAppOverride) error {
+	return impl.dbConnection.Insert(override)
+}
+
+func (impl *HibernationScheduleAppOverrideRepositoryImpl) Update(override *HibernationScheduleAppOverride) error {
+	return impl.dbConnection.Update(override)
+}
+
+func (impl *HibernationScheduleAppOverrideRepositoryImpl) FindByScheduleId(scheduleId int) ([]*HibernationScheduleAppOverride, error) {
+	var overrides []*HibernationScheduleAppOverride
+	err := impl.dbConnection.Model(&overrides).
+		Where("schedule_id = ?", scheduleId).
+		Order("id ASC").
+		Select()
+	return overrides, err
+}
+
+func (impl *HibernationScheduleAppOverrideRepositoryImpl) FindByScheduleIdAndAppId(scheduleId, appId int) (*HibernationScheduleAppOverride, error) {
+	override := &HibernationScheduleAppOverride{}
+	err := impl.dbConnection.Model(override).
+		Where("schedule_id = ?", scheduleId).
+		Where("app_id = ?", appId).
+		Select()
+	return override, err
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type HibernationSchedule struct {
+	tableName       struct{}   `sql:"hibernation_schedule" pg:",discard_unknown_columns"`
+	Id              int        `sql:"id,pk"`
+	Name            string     `sql:"name,notnull"`
+	EnvironmentId   int        `sql:"environment_id,notnull"`
+	ResourceGroupId int        `sql:"resource_group_id"`
+	SleepCron       string     `sql:"sleep_cron,notnull"`
+	WakeCron        string     `sql:"wake_cron"`
+	Timezone        string     `sql:"timezone,notnull"`
+	Holidays        []string   `sql:"holidays" pg:",array"`
+	KeepAwakeUntil  *time.Time `sql:"keep_awake_until"`
+	NextSleepAt     time.Time  `sql:"next_sleep_at,notnull"`
+	NextWakeAt      *time.Time `sql:"next_wake_at"`
+	Active          bool       `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type HibernationScheduleRepository interface {
+	Save(schedule *HibernationSchedule) error
+	Update(schedule *HibernationSchedule) error
+	// UpdateNextRuns moves the schedule to its next runs only if it was not updated since it was read, so a due run
+	// is claimed once even if the leadership changes in between. false is returned if it was updated in between.
+	UpdateNextRuns(schedule *HibernationSchedule, readUpdatedOn time.Time) (bool, error)
+	FindById(id int) (*HibernationSchedule, error)
+	FindAllActive() ([]*HibernationSchedule, error)
+	FindActiveDueBefore(time time.Time) ([]*HibernationSchedule, error)
+}
+
+type HibernationScheduleRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewHibernationScheduleRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *HibernationScheduleRepositoryImpl {
+	return &HibernationScheduleRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *HibernationScheduleRepositoryImpl) Save(schedule *HibernationSchedule) error {
+	return impl.dbConnection.Insert(schedule)
+}
+
+func (impl *HibernationScheduleRepositoryImpl) Update(schedule *HibernationSchedule) error {
+	return impl.dbConnection.Update(schedule)
+}
+
+func (impl *HibernationScheduleRepositoryImpl) UpdateNextRuns(schedule *HibernationSchedule, readUpdatedOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model(schedule).
+		Column("next_sleep_at", "next_wake_at", "updated_on", "updated_by").
+		WherePK().
+		Where("updated_on = ?", readUpdatedOn).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *HibernationScheduleRepositoryImpl) FindById(id int) (*HibernationSchedule, error) {
+	schedule := &HibernationSchedule{}
+	err := impl.dbConnection.Model(schedule).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return schedule, err
+}
+
+func (impl *HibernationScheduleRepositoryImpl) FindAllActive() ([]*HibernationSchedule, error) {
+	var schedules []*HibernationSchedule
+	err := impl.dbConnection.Model(&schedules).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return schedules, err
+}
+
+func (impl *HibernationScheduleRepositoryImpl) FindActiveDueBefore(time time.Time) ([]*HibernationSchedule, error) {
+	var schedules []*HibernationSchedule
+	err := impl.dbConnection.Model(&schedules).
+		Where("active = ?", true).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("next_sleep_at <= ?", time).
+				WhereOr("next_wake_at <= ?", time)
+			return q, nil
+		}).
+		Order("id ASC").
+		Select()
+	return schedules, err
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type HibernationScheduleRun struct {
+	tableName   struct{}   `sql:"hibernation_schedule_run" pg:",discard_unknown_columns"`
+	Id          int        `sql:"id,pk"`
+	ScheduleId  int        `sql:"schedule_id,notnull"`
+	Action      string     `sql:"action,notnull"`
+	ScheduledAt time.Time  `sql:"scheduled_at,notnull"`
+	Status      string     `sql:"status,notnull"`
+	Message     string     `sql:"message"`
+	TriggeredBy int32      `sql:"triggered_by,notnull"`
+	StartedOn   time.Time  `sql:"started_on,notnull"`
+	FinishedOn  *time.Time `sql:"finished_on"`
+}
+
+type HibernationScheduleRunTarget struct {
+	tableName     struct{} `sql:"hibernation_schedule_run_target" pg:",discard_unknown_columns"`
+	Id            int      `sql:"id,pk"`
+	RunId         int      `sql:"run_id,notnull"`
+	AppId         int      `sql:"app_id,notnull"`
+	EnvironmentId int      `sql:"environment_id,notnull"`
+	Status        string   `sql:"status,notnull"`
+	Message       string   `sql:"message"`
+}
+
+type HibernationScheduleRunRepository interface {
+	Save(run *HibernationScheduleRun) error
+	Update(run *HibernationScheduleRun) error
+	SaveTargets(targets []*HibernationScheduleRunTarget) error
+	FindByScheduleId(scheduleId int, limit int) ([]*HibernationScheduleRun, error)
+	FindTargetsByRunIds(runIds []int) ([]*HibernationScheduleRunTarget, error)
+}
+
+type HibernationScheduleRunRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewHibernationScheduleRunRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *HibernationScheduleRunRepositoryImpl {
+	return &HibernationScheduleRunRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *HibernationScheduleRunRepositoryImpl) Save(run *HibernationScheduleRun) error {
+	return impl.dbConnection.Insert(run)
+}
+
+func (impl *HibernationScheduleRunRepositoryImpl) Update(run *HibernationScheduleRun) error {
+	return impl.dbConnection.Update(run)
+}
+
+func (impl *HibernationScheduleRunRepositoryImpl) SaveTargets(targets []*HibernationScheduleRunTarget) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	_, err := impl.dbConnection.Model(&targets).Insert()
+	return err
+}
+
+func (impl *HibernationScheduleRunRepositoryImpl) FindByScheduleId(scheduleId int, limit int) ([]*HibernationScheduleRun, error) {
+	var runs []*HibernationScheduleRun
+	err := impl.dbConnection.Model(&runs).
+		Where("schedule_id = ?", scheduleId).
+		Order("id DESC").
+		Limit(limit).
+		Select()
+	return runs, err
+}
+
+func (impl *HibernationScheduleRunRepositoryImpl) FindTargetsByRunIds(runIds []int) ([]*HibernationScheduleRunTarget, error) {
+	var targets []*HibernationScheduleRunTarget
+	if len(runIds) == 0 {
+		return targets, nil
+	}
+	err := impl.dbConnection.Model(&targets).
+		Where("run_id IN (?)", pg.In(runIds)).
+		Order("id ASC").
+		Select()
+	return targets, err
+}
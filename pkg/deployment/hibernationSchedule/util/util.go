@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/bean"
+)
+
+// GetDueAction returns the action of the schedule due at the given time. When both the sleep and the wake up are
+// due, e.g. the scheduler was down in between, the later one wins as it is the state the apps are expected to be in.
+func GetDueAction(nextSleepAt time.Time, nextWakeAt *time.Time, now time.Time) (bean.Action, time.Time, bool) {
+	sleepDue := !nextSleepAt.After(now)
+	wakeDue := nextWakeAt != nil && !nextWakeAt.After(now)
+	switch {
+	case sleepDue && wakeDue:
+		if nextWakeAt.After(nextSleepAt) {
+			return bean.ActionWake, *nextWakeAt, true
+		}
+		return bean.ActionSleep, nextSleepAt, true
+	case sleepDue:
+		return bean.ActionSleep, nextSleepAt, true
+	case wakeDue:
+		return bean.ActionWake, *nextWakeAt, true
+	}
+	return "", time.Time{}, false
+}
+
+// IsHoliday checks if the given time falls on one of the holidays in the timezone of the schedule
+func IsHoliday(holidays []string, timezone string, at time.Time) bool {
+	if len(holidays) == 0 {
+		return false
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	date := at.In(location).Format(bean.HolidayDateLayout)
+	for _, holiday := range holidays {
+		if holiday == date {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateHolidays(holidays []string) error {
+	for _, holiday := range holidays {
+		if _, err := time.Parse(bean.HolidayDateLayout, holiday); err != nil {
+			return fmt.Errorf("invalid holiday %q, expected a date like 2025-12-25", holiday)
+		}
+	}
+	return nil
+}
+
+// IsKeptAwake checks if a keep awake override is still in effect at the given time
+func IsKeptAwake(keepAwakeUntil *time.Time, now time.Time) bool {
+	return keepAwakeUntil != nil && keepAwakeUntil.After(now)
+}
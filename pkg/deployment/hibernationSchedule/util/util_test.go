@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/bean"
+)
+
+func TestGetDueAction(t *testing.T) {
+	now := time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC)
+	before := func(d time.Duration) time.Time { return now.Add(-d) }
+	after := func(d time.Duration) time.Time { return now.Add(d) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	tests := []struct {
+		name        string
+		nextSleepAt time.Time
+		nextWakeAt  *time.Time
+		wantAction  bean.Action
+		wantAt      time.Time
+		wantDue     bool
+	}{
+		{
+			name:        "nothing due",
+			nextSleepAt: after(time.Hour),
+			nextWakeAt:  ptr(after(12 * time.Hour)),
+		},
+		{
+			name:        "sleep due",
+			nextSleepAt: before(time.Minute),
+			nextWakeAt:  ptr(after(12 * time.Hour)),
+			wantAction:  bean.ActionSleep,
+			wantAt:      before(time.Minute),
+			wantDue:     true,
+		},
+		{
+			name:        "sleep due without wake up",
+			nextSleepAt: now,
+			wantAction:  bean.ActionSleep,
+			wantAt:      now,
+			wantDue:     true,
+		},
+		{
+			name:        "wake up due",
+			nextSleepAt: after(time.Hour),
+			nextWakeAt:  ptr(before(time.Minute)),
+			wantAction:  bean.ActionWake,
+			wantAt:      before(time.Minute),
+			wantDue:     true,
+		},
+		{
+			name:        "both due, wake up is later",
+			nextSleepAt: before(10 * time.Hour),
+			nextWakeAt:  ptr(before(time.Hour)),
+			wantAction:  bean.ActionWake,
+			wantAt:      before(time.Hour),
+			wantDue:     true,
+		},
+		{
+			name:        "both due, sleep is later",
+			nextSleepAt: before(time.Hour),
+			nextWakeAt:  ptr(before(10 * time.Hour)),
+			wantAction:  bean.ActionSleep,
+			wantAt:      before(time.Hour),
+			wantDue:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, at, due := GetDueAction(tt.nextSleepAt, tt.nextWakeAt, now)
+			if due != tt.wantDue || action != tt.wantAction || !at.Equal(tt.wantAt) {
+				t.Errorf("GetDueAction() = %v, %v, %v, want %v, %v, %v", action, at, due, tt.wantAction, tt.wantAt, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestIsHoliday(t *testing.T) {
+	holidays := []string{"2025-12-25"}
+	// 2025-12-24 20:00 UTC is already the 25th in Kolkata
+	at := time.Date(2025, 12, 24, 20, 0, 0, 0, time.UTC)
+	if IsHoliday(holidays, "UTC", at) {
+		t.Errorf("IsHoliday() in utc = true, want false")
+	}
+	if !IsHoliday(holidays, "Asia/Kolkata", at) {
+		t.Errorf("IsHoliday() in kolkata = false, want true")
+	}
+	if IsHoliday(nil, "UTC", at) {
+		t.Errorf("IsHoliday() without holidays = true, want false")
+	}
+}
+
+func TestValidateHolidays(t *testing.T) {
+	if err := ValidateHolidays([]string{"2025-12-25", "2026-01-01"}); err != nil {
+		t.Errorf("ValidateHolidays() error = %v", err)
+	}
+	if err := ValidateHolidays([]string{"25-12-2025"}); err == nil {
+		t.Errorf("ValidateHolidays() expected error for invalid date")
+	}
+}
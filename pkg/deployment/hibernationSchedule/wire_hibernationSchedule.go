@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hibernationSchedule
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/repository"
+	"github.com/google/wire"
+)
+
+var HibernationScheduleWireSet = wire.NewSet(
+	repository.NewHibernationScheduleRepositoryImpl,
+	wire.Bind(new(repository.HibernationScheduleRepository), new(*repository.HibernationScheduleRepositoryImpl)),
+	repository.NewHibernationScheduleAppOverrideRepositoryImpl,
+	wire.Bind(new(repository.HibernationScheduleAppOverrideRepository), new(*repository.HibernationScheduleAppOverrideRepositoryImpl)),
+	repository.NewHibernationScheduleRunRepositoryImpl,
+	wire.Bind(new(repository.HibernationScheduleRunRepository), new(*repository.HibernationScheduleRunRepositoryImpl)),
+	NewHibernationScheduleServiceImpl,
+	wire.Bind(new(HibernationScheduleService), new(*HibernationScheduleServiceImpl)),
+)
@@ -71,6 +71,12 @@ type ManifestCreationService interface {
 
 	//TODO: remove below method
 	GetValuesOverrideForTrigger(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest, envDeploymentConfig *deploymentBean.DeploymentConfig, triggeredAt time.Time) (*app.ValuesOverrideResponse, error)
+	// GetValuesOverrideForPreview returns the merged values the override request would be deployed with,
+	// unlike GetValuesOverrideForTrigger nothing is saved and no image pull secret is created on the cluster
+	GetValuesOverrideForPreview(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest, envDeploymentConfig *deploymentBean.DeploymentConfig) (*app.ValuesOverrideResponse, error)
+	// GetValuesOverrideForClusterSetMember returns the merged values the override request is released with on the member cluster
+	// ClusterId of the cluster set of the pipeline, nothing is saved but the image pull secret is created on the member cluster
+	GetValuesOverrideForClusterSetMember(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest, envDeploymentConfig *deploymentBean.DeploymentConfig) (*app.ValuesOverrideResponse, error)
 }
 
 type ManifestCreationServiceImpl struct {
@@ -202,8 +208,6 @@ func (impl *ManifestCreationServiceImpl) GetValuesOverrideForTrigger(ctx context
 
 	var (
 		pipelineOverride *chartConfig.PipelineOverride
-		appLabelJsonByte []byte
-		configMapJson    *bean3.MergedCmAndCsJsonV2Response
 		envOverride      *bean2.EnvConfigOverride
 	)
 	if isPipelineOverrideCreated {
@@ -223,7 +227,7 @@ func (impl *ManifestCreationServiceImpl) GetValuesOverrideForTrigger(ctx context
 			return nil, err
 		}
 	} else {
-		envOverride, err = impl.getEnvOverrideByTriggerType(overrideRequest, triggeredAt, newCtx)
+		envOverride, err = impl.getEnvOverrideByTriggerType(overrideRequest, triggeredAt, false, newCtx)
 		if err != nil {
 			impl.logger.Errorw("error in getting env override by trigger type", "err", err)
 			return valuesOverrideResponse, err
@@ -258,37 +262,9 @@ func (impl *ManifestCreationServiceImpl) GetValuesOverrideForTrigger(ctx context
 
 	// Conditional Block based on PipelineOverrideCreated --> start
 	if !isPipelineOverrideCreated {
-		chartVersion := envOverride.Chart.ChartVersion
-		scope := helper.GetScopeForVariables(overrideRequest, envOverride)
-		request := helper.NewMergedCmAndCsJsonV2Request(overrideRequest, envOverride, chartVersion, scope)
-
-		configMapJson, err = impl.getConfigMapAndSecretJsonV2(newCtx, request, envOverride)
-		if err != nil {
-			impl.logger.Errorw("error in fetching config map n secret ", "err", err)
-			configMapJson.MergedJson = nil
-		}
-		appLabelJsonByte, err = impl.appCrudOperationService.GetAppLabelsForDeployment(newCtx, overrideRequest.AppId, overrideRequest.AppName, overrideRequest.EnvName)
+		mergedValues, err := impl.getMergedValues(newCtx, overrideRequest, envOverride, pipeline, releaseOverrideJson, strategy, envDeploymentConfig)
 		if err != nil {
-			impl.logger.Errorw("error in fetching app labels for gitOps commit", "err", err)
-			appLabelJsonByte = nil
-		}
-		mergedValues, err := impl.mergeOverrideValues(envOverride, releaseOverrideJson, configMapJson.MergedJson, appLabelJsonByte, strategy)
-		appName := pipeline.DeploymentAppName
-		var k8sErr error
-		if !envOverride.Environment.IsVirtualEnvironment {
-			mergedValues, k8sErr = impl.updatedExternalCmCsHashForTrigger(newCtx, overrideRequest.ClusterId,
-				envOverride.Namespace, mergedValues, configMapJson.ExternalCmList, configMapJson.ExternalCsList)
-			if k8sErr != nil {
-				impl.logger.Errorw("error in updating external cm cs hash for trigger",
-					"clusterId", overrideRequest.ClusterId, "namespace", envOverride.Namespace, "err", k8sErr)
-				// error is not returned as it's not blocking for deployment process
-				// blocking deployments based on this use case can vary for user to user
-			}
-			mergedValues, err = impl.autoscalingCheckBeforeTrigger(newCtx, appName, envOverride.Namespace, mergedValues, overrideRequest, envDeploymentConfig)
-			if err != nil {
-				impl.logger.Errorw("error in autoscaling check before trigger", "pipelineId", overrideRequest.PipelineId, "err", err)
-				return valuesOverrideResponse, err
-			}
+			return valuesOverrideResponse, err
 		}
 		// handle image pull secret if access given
 		mergedValues, err = impl.dockerRegistryIpsConfigService.HandleImagePullSecretOnApplicationDeployment(newCtx, envOverride.Environment, artifact, pipeline.CiPipelineId, mergedValues)
@@ -310,6 +286,120 @@ func (impl *ManifestCreationServiceImpl) GetValuesOverrideForTrigger(ctx context
 	return valuesOverrideResponse, err
 }
 
+func (impl *ManifestCreationServiceImpl) GetValuesOverrideForPreview(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest,
+	envDeploymentConfig *deploymentBean.DeploymentConfig) (*app.ValuesOverrideResponse, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "ManifestCreationServiceImpl.GetValuesOverrideForPreview")
+	defer span.End()
+	helper.ResolveDeploymentTypeAndUpdate(overrideRequest)
+	valuesOverrideResponse := &app.ValuesOverrideResponse{}
+	pipeline, err := impl.pipelineRepository.FindById(overrideRequest.PipelineId)
+	valuesOverrideResponse.Pipeline = pipeline
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline by pipeline id", "err", err, "pipeline-id-", overrideRequest.PipelineId)
+		return valuesOverrideResponse, err
+	}
+	artifact, err := impl.ciArtifactRepository.Get(overrideRequest.CiArtifactId)
+	valuesOverrideResponse.Artifact = artifact
+	if err != nil {
+		return valuesOverrideResponse, err
+	}
+	overrideRequest.Image = artifact.Image
+	strategy, err := impl.getDeploymentStrategyByTriggerType(overrideRequest, newCtx)
+	valuesOverrideResponse.PipelineStrategy = strategy
+	if err != nil {
+		impl.logger.Errorw("error in getting strategy by trigger type", "err", err)
+		return valuesOverrideResponse, err
+	}
+	// nothing is saved for a preview, the env override a deployment would create is built in memory only
+	envOverride, err := impl.getEnvOverrideByTriggerType(overrideRequest, time.Now(), true, newCtx)
+	if err != nil {
+		impl.logger.Errorw("error in getting env override by trigger type", "err", err)
+		return valuesOverrideResponse, err
+	}
+	valuesOverrideResponse.EnvOverride = envOverride
+	appMetrics, err := impl.getAppMetricsByTriggerType(overrideRequest, newCtx)
+	if err != nil {
+		impl.logger.Errorw("error in getting app metrics by trigger type", "err", err)
+		return valuesOverrideResponse, err
+	}
+	// the release counter the deployment would get, as saved by savePipelineOverride
+	currentReleaseNo, err := impl.pipelineOverrideRepository.GetCurrentPipelineReleaseCounter(overrideRequest.PipelineId)
+	if err != nil {
+		return valuesOverrideResponse, err
+	}
+	releaseOverrideJson, err := impl.getReleaseOverride(envOverride, overrideRequest, artifact, currentReleaseNo+1, strategy, &appMetrics)
+	valuesOverrideResponse.ReleaseOverrideJSON = releaseOverrideJson
+	if err != nil {
+		return valuesOverrideResponse, err
+	}
+	mergedValues, err := impl.getMergedValues(newCtx, overrideRequest, envOverride, pipeline, releaseOverrideJson, strategy, envDeploymentConfig)
+	if err != nil {
+		return valuesOverrideResponse, err
+	}
+	valuesOverrideResponse.MergedValues = string(mergedValues)
+	return valuesOverrideResponse, nil
+}
+
+func (impl *ManifestCreationServiceImpl) GetValuesOverrideForClusterSetMember(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest,
+	envDeploymentConfig *deploymentBean.DeploymentConfig) (*app.ValuesOverrideResponse, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "ManifestCreationServiceImpl.GetValuesOverrideForClusterSetMember")
+	defer span.End()
+	valuesOverrideResponse, err := impl.GetValuesOverrideForPreview(newCtx, overrideRequest, envDeploymentConfig)
+	if err != nil {
+		return valuesOverrideResponse, err
+	}
+	// the member is released in the namespace of the pipeline environment on the member cluster
+	memberEnvironment := *valuesOverrideResponse.EnvOverride.Environment
+	memberEnvironment.ClusterId = overrideRequest.ClusterId
+	// handle image pull secret if access given
+	mergedValues, err := impl.dockerRegistryIpsConfigService.HandleImagePullSecretOnApplicationDeployment(newCtx, &memberEnvironment,
+		valuesOverrideResponse.Artifact, valuesOverrideResponse.Pipeline.CiPipelineId, []byte(valuesOverrideResponse.MergedValues))
+	if err != nil {
+		impl.logger.Errorw("error in handling image pull secret for cluster set member", "pipelineId", overrideRequest.PipelineId, "clusterId", overrideRequest.ClusterId, "err", err)
+		return valuesOverrideResponse, err
+	}
+	valuesOverrideResponse.MergedValues = string(mergedValues)
+	return valuesOverrideResponse, nil
+}
+
+// getMergedValues merges the release override with the deployment template, config maps, secrets and app labels of the env override
+func (impl *ManifestCreationServiceImpl) getMergedValues(ctx context.Context, overrideRequest *bean.ValuesOverrideRequest, envOverride *bean2.EnvConfigOverride,
+	pipeline *pipelineConfig.Pipeline, releaseOverrideJson string, strategy *chartConfig.PipelineStrategy, envDeploymentConfig *deploymentBean.DeploymentConfig) ([]byte, error) {
+	chartVersion := envOverride.Chart.ChartVersion
+	scope := helper.GetScopeForVariables(overrideRequest, envOverride)
+	request := helper.NewMergedCmAndCsJsonV2Request(overrideRequest, envOverride, chartVersion, scope)
+
+	configMapJson, err := impl.getConfigMapAndSecretJsonV2(ctx, request, envOverride)
+	if err != nil {
+		impl.logger.Errorw("error in fetching config map n secret ", "err", err)
+		configMapJson.MergedJson = nil
+	}
+	appLabelJsonByte, err := impl.appCrudOperationService.GetAppLabelsForDeployment(ctx, overrideRequest.AppId, overrideRequest.AppName, overrideRequest.EnvName)
+	if err != nil {
+		impl.logger.Errorw("error in fetching app labels for gitOps commit", "err", err)
+		appLabelJsonByte = nil
+	}
+	mergedValues, err := impl.mergeOverrideValues(envOverride, releaseOverrideJson, configMapJson.MergedJson, appLabelJsonByte, strategy)
+	appName := pipeline.DeploymentAppName
+	var k8sErr error
+	if !envOverride.Environment.IsVirtualEnvironment {
+		mergedValues, k8sErr = impl.updatedExternalCmCsHashForTrigger(ctx, overrideRequest.ClusterId,
+			envOverride.Namespace, mergedValues, configMapJson.ExternalCmList, configMapJson.ExternalCsList)
+		if k8sErr != nil {
+			impl.logger.Errorw("error in updating external cm cs hash for trigger",
+				"clusterId", overrideRequest.ClusterId, "namespace", envOverride.Namespace, "err", k8sErr)
+			// error is not returned as it's not blocking for deployment process
+			// blocking deployments based on this use case can vary for user to user
+		}
+		mergedValues, err = impl.autoscalingCheckBeforeTrigger(ctx, appName, envOverride.Namespace, mergedValues, overrideRequest, envDeploymentConfig)
+		if err != nil {
+			impl.logger.Errorw("error in autoscaling check before trigger", "pipelineId", overrideRequest.PipelineId, "err", err)
+			return nil, err
+		}
+	}
+	return mergedValues, nil
+}
+
 func (impl *ManifestCreationServiceImpl) getDeploymentStrategyByTriggerType(overrideRequest *bean.ValuesOverrideRequest, ctx context.Context) (*chartConfig.PipelineStrategy, error) {
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "ManifestCreationServiceImpl.getDeploymentStrategyByTriggerType")
 	defer span.End()
@@ -346,7 +436,9 @@ func (impl *ManifestCreationServiceImpl) getDeploymentStrategyByTriggerType(over
 	return strategy, nil
 }
 
-func (impl *ManifestCreationServiceImpl) getEnvOverrideByTriggerType(overrideRequest *bean.ValuesOverrideRequest, triggeredAt time.Time, ctx context.Context) (*bean2.EnvConfigOverride, error) {
+// getEnvOverrideByTriggerType returns the env override the request is deployed with, a missing env override is created
+// for the last saved config unless it is a dry run
+func (impl *ManifestCreationServiceImpl) getEnvOverrideByTriggerType(overrideRequest *bean.ValuesOverrideRequest, triggeredAt time.Time, dryRun bool, ctx context.Context) (*bean2.EnvConfigOverride, error) {
 	envOverride := &bean2.EnvConfigOverride{}
 	var err error
 	if overrideRequest.DeploymentWithConfig == bean.DEPLOYMENT_CONFIG_TYPE_SPECIFIC_TRIGGER {
@@ -356,7 +448,7 @@ func (impl *ManifestCreationServiceImpl) getEnvOverrideByTriggerType(overrideReq
 			return nil, err
 		}
 	} else if overrideRequest.DeploymentWithConfig == bean.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED {
-		envOverride, err = impl.getEnvOverrideForLastSavedConfigTrigger(overrideRequest, triggeredAt, ctx)
+		envOverride, err = impl.getEnvOverrideForLastSavedConfigTrigger(overrideRequest, triggeredAt, dryRun, ctx)
 		if err != nil {
 			impl.logger.Errorw("error, getEnvOverrideForLastSavedConfigTrigger", "err", err, "overrideRequest", overrideRequest)
 			return nil, err
@@ -419,7 +511,7 @@ func (impl *ManifestCreationServiceImpl) GetEnvOverrideForSpecificConfigTrigger(
 }
 
 func (impl *ManifestCreationServiceImpl) getEnvOverrideForLastSavedConfigTrigger(overrideRequest *bean.ValuesOverrideRequest,
-	triggeredAt time.Time, ctx context.Context) (*bean2.EnvConfigOverride, error) {
+	triggeredAt time.Time, dryRun bool, ctx context.Context) (*bean2.EnvConfigOverride, error) {
 	envOverride := &bean2.EnvConfigOverride{}
 	var err error
 	_, span := otel.Tracer("orchestrator").Start(ctx, "environmentConfigRepository.ActiveEnvConfigOverride")
@@ -468,12 +560,14 @@ func (impl *ManifestCreationServiceImpl) getEnvOverrideForLastSavedConfigTrigger
 				IsBasicViewLocked: chart.IsBasicViewLocked,
 				CurrentViewEditor: chart.CurrentViewEditor,
 			}
-			_, span = otel.Tracer("orchestrator").Start(ctx, "environmentConfigRepository.Save")
-			err = impl.environmentConfigRepository.Save(envOverrideDBObj)
-			span.End()
-			if err != nil {
-				impl.logger.Errorw("error in creating envConfig", "data", envOverride, "error", err)
-				return nil, err
+			if !dryRun {
+				_, span = otel.Tracer("orchestrator").Start(ctx, "environmentConfigRepository.Save")
+				err = impl.environmentConfigRepository.Save(envOverrideDBObj)
+				span.End()
+				if err != nil {
+					impl.logger.Errorw("error in creating envConfig", "data", envOverride, "error", err)
+					return nil, err
+				}
 			}
 			envOverride = adapter.EnvOverrideDBToDTO(envOverrideDBObj)
 		}
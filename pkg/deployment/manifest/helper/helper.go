@@ -210,7 +210,7 @@ func GetScopeForVariables(overrideRequest *bean.ValuesOverrideRequest, envOverri
 	scope := resourceQualifiers.Scope{
 		AppId:     overrideRequest.AppId,
 		EnvId:     envOverride.TargetEnvironment,
-		ClusterId: envOverride.Environment.Id,
+		ClusterId: envOverride.Environment.ClusterId,
 		SystemMetadata: &resourceQualifiers.SystemMetadata{
 			EnvironmentName: envOverride.Environment.Name,
 			ClusterName:     envOverride.Environment.Cluster.ClusterName,
@@ -220,5 +220,9 @@ func GetScopeForVariables(overrideRequest *bean.ValuesOverrideRequest, envOverri
 			Image:           overrideRequest.Image,
 		},
 	}
+	if len(overrideRequest.ClusterSetClusterName) > 0 {
+		scope.ClusterId = overrideRequest.ClusterId
+		scope.SystemMetadata.ClusterName = overrideRequest.ClusterSetClusterName
+	}
 	return scope
 }
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/api/bean"
+	envRepository "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	bean4 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScopeForVariables(t *testing.T) {
+	envOverride := &bean4.EnvConfigOverride{
+		TargetEnvironment: 7,
+		Environment: &envRepository.Environment{
+			Id:        7,
+			Name:      "prod",
+			ClusterId: 3,
+			Cluster:   &clusterRepository.Cluster{Id: 3, ClusterName: "prod-cluster"},
+			Namespace: "prod-ns",
+		},
+	}
+
+	// variables scoped to a cluster are resolved with the cluster of the environment, not the environment itself
+	scope := GetScopeForVariables(&bean.ValuesOverrideRequest{AppId: 1, Image: "registry.local/app:v1"}, envOverride)
+	assert.Equal(t, 1, scope.AppId)
+	assert.Equal(t, 7, scope.EnvId)
+	assert.Equal(t, 3, scope.ClusterId)
+	assert.Equal(t, "prod-cluster", scope.SystemMetadata.ClusterName)
+	assert.Equal(t, "v1", scope.SystemMetadata.ImageTag)
+
+	// a cluster set deployment is scoped to the member cluster it is deployed to
+	scope = GetScopeForVariables(&bean.ValuesOverrideRequest{AppId: 1, ClusterId: 9, ClusterSetClusterName: "edge-1"}, envOverride)
+	assert.Equal(t, 7, scope.EnvId)
+	assert.Equal(t, 9, scope.ClusterId)
+	assert.Equal(t, "edge-1", scope.SystemMetadata.ClusterName)
+}
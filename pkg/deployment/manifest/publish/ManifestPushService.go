@@ -32,11 +32,16 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	gitOpsBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo"
+	monoRepoBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	prBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	globalUtil "github.com/devtron-labs/devtron/util"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
+	"path"
 	"time"
 )
 
@@ -60,6 +65,8 @@ type GitOpsManifestPushServiceImpl struct {
 	argoClientWrapperService      argocdServer.ArgoClientWrapperService
 	deploymentConfigService       common.DeploymentConfigService
 	chartTemplateService          util.ChartTemplateService
+	pullRequestModeService        pullRequest.PullRequestModeService
+	monoRepoService               monoRepo.MonoRepoService
 	*sql.TransactionUtilImpl
 }
 
@@ -74,7 +81,9 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 	argoClientWrapperService argocdServer.ArgoClientWrapperService,
 	transactionUtilImpl *sql.TransactionUtilImpl,
 	deploymentConfigService common.DeploymentConfigService,
-	chartTemplateService util.ChartTemplateService) *GitOpsManifestPushServiceImpl {
+	chartTemplateService util.ChartTemplateService,
+	pullRequestModeService pullRequest.PullRequestModeService,
+	monoRepoService monoRepo.MonoRepoService) *GitOpsManifestPushServiceImpl {
 	return &GitOpsManifestPushServiceImpl{
 		logger:                        logger,
 		pipelineStatusTimelineService: pipelineStatusTimelineService,
@@ -88,6 +97,8 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 		TransactionUtilImpl:           transactionUtilImpl,
 		deploymentConfigService:       deploymentConfigService,
 		chartTemplateService:          chartTemplateService,
+		pullRequestModeService:        pullRequestModeService,
+		monoRepoService:               monoRepoService,
 	}
 }
 
@@ -115,6 +126,71 @@ func (impl *GitOpsManifestPushServiceImpl) createRepoForGitOperation(manifestPus
 	return chartGitAttr.RepoUrl, nil
 }
 
+// configureMonoRepoIfRequired moves the chart of a new deployment under the <app>/<env> directory of the shared GitOps repo,
+// apps having their own GitOps repo keep using it until migrated
+func (impl *GitOpsManifestPushServiceImpl) configureMonoRepoIfRequired(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate, manifestPushResponse *bean.ManifestPushResponse) (bool, error) {
+	if !impl.monoRepoService.GetLayout().IsMonoRepo() ||
+		manifestPushTemplate.IsCustomGitRepository ||
+		manifestPushTemplate.ReleaseMode != util.PIPELINE_RELEASE_MODE_CREATE ||
+		!util.IsAcdApp(manifestPushTemplate.DeploymentAppType) ||
+		len(manifestPushTemplate.GitOpsRepoBasePath) != 0 {
+		return false, nil
+	}
+	location, err := impl.monoRepoService.GetMonoRepoLocation(manifestPushTemplate.AppId, manifestPushTemplate.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in getting gitOps mono repo location", "appId", manifestPushTemplate.AppId, "envId", manifestPushTemplate.EnvironmentId, "err", err)
+		return false, err
+	}
+	if gitOps.IsGitOpsRepoConfigured(manifestPushTemplate.RepoUrl) &&
+		impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(manifestPushTemplate.RepoUrl) != location.RepoName {
+		return false, nil
+	}
+	targetRevision := globalUtil.GetDefaultTargetRevision()
+	if len(manifestPushTemplate.TargetRevision) != 0 {
+		targetRevision = manifestPushTemplate.TargetRevision
+	}
+	repoUrl, err := impl.monoRepoService.EnsureMonoRepo(ctx, location.RepoName, targetRevision, manifestPushTemplate.UserId)
+	if err != nil {
+		return false, err
+	}
+	if location.Layout == monoRepoBean.ProjectRepoLayout {
+		// all the environments of the app share the repo, new environments pick it from the app level config
+		_, err = impl.chartService.ConfigureGitOpsRepoUrlForApp(manifestPushTemplate.AppId, repoUrl, manifestPushTemplate.ChartLocation, false, manifestPushTemplate.UserId)
+		if err != nil {
+			impl.logger.Errorw("error in updating git repo url in charts", "appId", manifestPushTemplate.AppId, "err", err)
+			return false, fmt.Errorf("No repository configured for Gitops! Error while migrating gitops repository: '%s'", repoUrl)
+		}
+	}
+	_, err = impl.deploymentConfigService.UpdateGitOpsRepoForAppAndEnvId(nil, repoUrl, location.BasePath, manifestPushTemplate.AppId, manifestPushTemplate.EnvironmentId, manifestPushTemplate.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in updating gitOps repo in env config", "appId", manifestPushTemplate.AppId, "envId", manifestPushTemplate.EnvironmentId, "err", err)
+		return false, err
+	}
+	manifestPushTemplate.RepoUrl = repoUrl
+	manifestPushTemplate.GitOpsRepoBasePath = location.BasePath
+	manifestPushTemplate.ChartLocation = path.Join(location.BasePath, manifestPushTemplate.ChartLocation)
+	manifestPushResponse.NewGitRepoUrl = repoUrl
+	manifestPushResponse.NewGitOpsRepoBasePath = location.BasePath
+	return true, nil
+}
+
+// validateRepoNotMigrated fails a push to an app repository whose pipeline was moved to the mono repo
+// while the push waited for the repository lock, ArgoCd no longer syncs the app repository
+func (impl *GitOpsManifestPushServiceImpl) validateRepoNotMigrated(manifestPushTemplate *bean.ManifestPushTemplate) error {
+	if len(manifestPushTemplate.GitOpsRepoBasePath) != 0 {
+		return nil
+	}
+	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, manifestPushTemplate.AppId, manifestPushTemplate.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in getting deployment config", "appId", manifestPushTemplate.AppId, "envId", manifestPushTemplate.EnvironmentId, "err", err)
+		return err
+	}
+	if deploymentConfig.GetRepoURL() != manifestPushTemplate.RepoUrl {
+		return fmt.Errorf("GitOps repository of the pipeline was migrated to '%s', please redeploy", deploymentConfig.GetRepoURL())
+	}
+	return nil
+}
+
 func (impl *GitOpsManifestPushServiceImpl) validateManifestPushRequest(globalGitOpsConfigStatus *gitOpsBean.GitOpsConfigurationStatus, manifestPushTemplate *bean.ManifestPushTemplate) error {
 	if manifestPushTemplate.ReleaseMode == util.PIPELINE_RELEASE_MODE_LINK {
 		if gitOps.IsGitOpsRepoNotConfigured(manifestPushTemplate.RepoUrl) {
@@ -153,7 +229,13 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 		return manifestPushResponse
 	}
 	// 3. Create Git Repo if required
-	if gitOps.IsGitOpsRepoNotConfigured(manifestPushTemplate.RepoUrl) {
+	isMonoRepoConfigured, err := impl.configureMonoRepoIfRequired(newCtx, manifestPushTemplate, &manifestPushResponse)
+	if err != nil {
+		manifestPushResponse.Error = err
+		impl.SaveTimelineForError(manifestPushTemplate, err)
+		return manifestPushResponse
+	}
+	if !isMonoRepoConfigured && gitOps.IsGitOpsRepoNotConfigured(manifestPushTemplate.RepoUrl) {
 		newGitRepoUrl, errMsg := impl.createRepoForGitOperation(*manifestPushTemplate, newCtx)
 		if errMsg != nil {
 			manifestPushResponse.Error = errMsg
@@ -179,6 +261,42 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 		}
 
 	}
+	if len(manifestPushTemplate.GitOpsRepoBasePath) != 0 || impl.monoRepoService.GetLayout().IsMonoRepo() {
+		// apps sharing the GitOps repo commit one at a time to avoid push races,
+		// app repositories are locked as well while the mono repo layout is enabled as a migration may be copying them
+		unlock, err := impl.monoRepoService.LockRepo(impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(manifestPushTemplate.RepoUrl))
+		if err != nil {
+			manifestPushResponse.Error = err
+			impl.SaveTimelineForError(manifestPushTemplate, err)
+			return manifestPushResponse
+		}
+		defer unlock()
+		err = impl.validateRepoNotMigrated(manifestPushTemplate)
+		if err != nil {
+			manifestPushResponse.Error = err
+			impl.SaveTimelineForError(manifestPushTemplate, err)
+			return manifestPushResponse
+		}
+	}
+	isPullRequestMode, err := impl.pullRequestModeService.IsPullRequestModeEnabled(manifestPushTemplate.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in checking pull request mode", "envId", manifestPushTemplate.EnvironmentId, "err", err)
+		manifestPushResponse.Error = err
+		impl.SaveTimelineForError(manifestPushTemplate, err)
+		return manifestPushResponse
+	}
+	if isPullRequestMode {
+		// commit is done on a branch and reaches the target revision once its pull request is merged
+		err = impl.pushChartToPullRequest(newCtx, manifestPushTemplate)
+		if err != nil {
+			impl.logger.Errorw("error in pushing chart to pull request", "err", err)
+			manifestPushResponse.Error = err
+			impl.SaveTimelineForError(manifestPushTemplate, err)
+			return manifestPushResponse
+		}
+		manifestPushResponse.AwaitingMerge = true
+		return manifestPushResponse
+	}
 	// 4. Push Chart to Git Repository
 	err = impl.pushChartToGitRepo(newCtx, manifestPushTemplate)
 	if err != nil {
@@ -234,6 +352,46 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 	return manifestPushResponse
 }
 
+func (impl *GitOpsManifestPushServiceImpl) pushChartToPullRequest(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) error {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.pushChartToPullRequest")
+	defer span.End()
+	gitOpsRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(manifestPushTemplate.RepoUrl)
+	targetBranch := manifestPushTemplate.TargetRevision
+	if len(targetBranch) == 0 {
+		targetBranch = globalUtil.GetDefaultTargetRevision()
+	}
+	sourceBranch := fmt.Sprintf(prBean.SourceBranchFormat, manifestPushTemplate.PipelineOverrideId, manifestPushTemplate.EnvironmentId)
+	err := impl.gitOperationService.CreateBranch(newCtx, gitOpsRepoName, sourceBranch, targetBranch)
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request branch", "gitOpsRepoName", gitOpsRepoName, "branch", sourceBranch, "err", err)
+		return err
+	}
+	branchPushTemplate := *manifestPushTemplate
+	branchPushTemplate.TargetRevision = sourceBranch
+	err = impl.pushChartToGitRepo(newCtx, &branchPushTemplate)
+	if err != nil {
+		return err
+	}
+	_, _, err = impl.commitValuesToGit(newCtx, &branchPushTemplate)
+	if err != nil {
+		return err
+	}
+	_, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(manifestPushTemplate.UserId)
+	_, err = impl.pullRequestModeService.OpenPullRequest(newCtx, &prBean.OpenPullRequestRequest{
+		PipelineId:         manifestPushTemplate.PipelineId,
+		CdWorkflowRunnerId: manifestPushTemplate.WorkflowRunnerId,
+		PipelineOverrideId: manifestPushTemplate.PipelineOverrideId,
+		AppName:            manifestPushTemplate.AppName,
+		EnvironmentId:      manifestPushTemplate.EnvironmentId,
+		RepoName:           gitOpsRepoName,
+		SourceBranch:       sourceBranch,
+		TargetBranch:       targetBranch,
+		TriggeredBy:        userName,
+		UserId:             manifestPushTemplate.UserId,
+	})
+	return err
+}
+
 func (impl *GitOpsManifestPushServiceImpl) pushChartToGitRepo(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) error {
 	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.pushChartToGitRepo")
 	defer span.End()
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metricVerification
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+)
+
+type MetricEvaluator interface {
+	// EvaluateQueries runs the queries against prometheus at the given time, one result is returned per query.
+	// A query which could not be run fails its result, an error is returned only if prometheus is not reachable by config.
+	EvaluateQueries(ctx context.Context, promConfig *bean.PrometheusConfig, queries []*bean.MetricQueryDto, ts time.Time) ([]*bean.MetricQueryResultDto, error)
+}
+
+type MetricEvaluatorImpl struct {
+	logger *zap.SugaredLogger
+}
+
+func NewMetricEvaluatorImpl(logger *zap.SugaredLogger) *MetricEvaluatorImpl {
+	return &MetricEvaluatorImpl{
+		logger: logger,
+	}
+}
+
+func (impl *MetricEvaluatorImpl) EvaluateQueries(ctx context.Context, promConfig *bean.PrometheusConfig, queries []*bean.MetricQueryDto, ts time.Time) ([]*bean.MetricQueryResultDto, error) {
+	promApi, err := newPrometheusApi(promConfig)
+	if err != nil {
+		impl.logger.Errorw("error in creating prometheus client", "endpoint", promConfig.Endpoint, "err", err)
+		return nil, err
+	}
+	results := make([]*bean.MetricQueryResultDto, 0, len(queries))
+	for _, query := range queries {
+		results = append(results, impl.evaluateQuery(ctx, promApi, query, ts))
+	}
+	return results, nil
+}
+
+func (impl *MetricEvaluatorImpl) evaluateQuery(ctx context.Context, promApi promv1.API, query *bean.MetricQueryDto, ts time.Time) *bean.MetricQueryResultDto {
+	result := &bean.MetricQueryResultDto{
+		QueryName:   query.Name,
+		Query:       query.Query,
+		EvaluatedOn: ts,
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, bean.PrometheusQueryTimeout)
+	defer cancel()
+	value, warnings, err := promApi.Query(queryCtx, query.Query, ts)
+	if err != nil {
+		impl.logger.Errorw("error in running prometheus query", "name", query.Name, "query", query.Query, "err", err)
+		result.Message = fmt.Sprintf("error in running query: %s", err.Error())
+		return result
+	}
+	if len(warnings) > 0 {
+		impl.logger.Warnw("prometheus query returned warnings", "name", query.Name, "warnings", warnings)
+	}
+	samples := getSampleValues(value)
+	if len(samples) == 0 {
+		result.Passed = !query.FailOnNoData
+		result.Message = bean.NoDataMessage
+		return result
+	}
+	worst := getWorstValue(samples, query.Operator)
+	result.Value = &worst
+	result.Passed = query.Operator.IsSatisfiedBy(worst, query.Threshold)
+	if !result.Passed {
+		result.Message = fmt.Sprintf("value %v breached threshold %s %v", worst, query.Operator, query.Threshold)
+	}
+	return result
+}
+
+// getSampleValues returns the values of all series of the query result, NaN values (e.g. rates over no traffic) are dropped
+func getSampleValues(value model.Value) []float64 {
+	samples := make([]float64, 0)
+	appendSample := func(sampleValue model.SampleValue) {
+		if !math.IsNaN(float64(sampleValue)) {
+			samples = append(samples, float64(sampleValue))
+		}
+	}
+	switch typedValue := value.(type) {
+	case *model.Scalar:
+		appendSample(typedValue.Value)
+	case model.Vector:
+		for _, sample := range typedValue {
+			appendSample(sample.Value)
+		}
+	case model.Matrix:
+		// range queries are evaluated on the latest value of every series
+		for _, stream := range typedValue {
+			if len(stream.Values) > 0 {
+				appendSample(stream.Values[len(stream.Values)-1].Value)
+			}
+		}
+	}
+	return samples
+}
+
+// getWorstValue returns the sample most likely to breach the threshold, all series must satisfy the threshold
+func getWorstValue(samples []float64, operator bean.ThresholdOperator) float64 {
+	worst := samples[0]
+	for _, sample := range samples[1:] {
+		switch operator {
+		case bean.ThresholdOperatorLessThan, bean.ThresholdOperatorLessThanOrEqual:
+			worst = math.Max(worst, sample)
+		default:
+			worst = math.Min(worst, sample)
+		}
+	}
+	return worst
+}
+
+func newPrometheusApi(promConfig *bean.PrometheusConfig) (promv1.API, error) {
+	if len(promConfig.Endpoint) == 0 {
+		return nil, errors.New(bean.PrometheusNotConfiguredError)
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: promConfig.InsecureSkipTlsVerify}
+	if len(promConfig.TlsClientCert) > 0 && len(promConfig.TlsClientKey) > 0 {
+		certificate, err := tls.X509KeyPair([]byte(promConfig.TlsClientCert), []byte(promConfig.TlsClientKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport := api.DefaultRoundTripper.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	var roundTripper http.RoundTripper = transport
+	if len(promConfig.UserName) > 0 {
+		roundTripper = &basicAuthRoundTripper{
+			userName: promConfig.UserName,
+			password: promConfig.Password,
+			next:     transport,
+		}
+	}
+	client, err := api.NewClient(api.Config{
+		Address:      promConfig.Endpoint,
+		RoundTripper: roundTripper,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promv1.NewAPI(client), nil
+}
+
+type basicAuthRoundTripper struct {
+	userName string
+	password string
+	next     http.RoundTripper
+}
+
+func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.userName, rt.password)
+	return rt.next.RoundTrip(req)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metricVerification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// newFakePrometheus serves /api/v1/query with the vector samples configured per query, unknown queries fail with bad_data
+func newFakePrometheus(samplesByQuery map[string][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		if userName, password, ok := r.BasicAuth(); ok && (userName != "admin" || password != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		samples, ok := samplesByQuery[r.FormValue("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		result := ""
+		for i, sample := range samples {
+			if i > 0 {
+				result += ","
+			}
+			result += fmt.Sprintf(`{"metric":{"pod":"pod-%d"},"value":[1700000000,"%s"]}`, i, sample)
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[%s]}}`, result)
+	}))
+}
+
+func TestMetricEvaluator_EvaluateQueries(t *testing.T) {
+	server := newFakePrometheus(map[string][]string{
+		"error_rate":     {"0.01", "0.02"},
+		"high_error":     {"0.01", "0.2"},
+		"latency_p99":    {"0.3"},
+		"slow_latency":   {"1.5"},
+		"no_traffic":     {"NaN"},
+		"empty":          {},
+		"available_pods": {"3", "1"},
+	})
+	defer server.Close()
+	evaluator := NewMetricEvaluatorImpl(zap.NewNop().Sugar())
+	promConfig := &bean.PrometheusConfig{Endpoint: server.URL}
+
+	tests := []struct {
+		name         string
+		query        *bean.MetricQueryDto
+		wantPassed   bool
+		wantValue    *float64
+		wantNoResult bool
+	}{
+		{
+			name:       "all series below threshold",
+			query:      &bean.MetricQueryDto{Name: "errors", Query: "error_rate", Operator: bean.ThresholdOperatorLessThan, Threshold: 0.05},
+			wantPassed: true,
+			wantValue:  float(0.02),
+		},
+		{
+			name:      "one series above threshold",
+			query:     &bean.MetricQueryDto{Name: "errors", Query: "high_error", Operator: bean.ThresholdOperatorLessThan, Threshold: 0.05},
+			wantValue: float(0.2),
+		},
+		{
+			name:       "latency within threshold",
+			query:      &bean.MetricQueryDto{Name: "latency", Query: "latency_p99", Operator: bean.ThresholdOperatorLessThanOrEqual, Threshold: 0.5},
+			wantPassed: true,
+			wantValue:  float(0.3),
+		},
+		{
+			name:      "latency breaches threshold",
+			query:     &bean.MetricQueryDto{Name: "latency", Query: "slow_latency", Operator: bean.ThresholdOperatorLessThanOrEqual, Threshold: 0.5},
+			wantValue: float(1.5),
+		},
+		{
+			name:      "lowest series is compared for greater than",
+			query:     &bean.MetricQueryDto{Name: "pods", Query: "available_pods", Operator: bean.ThresholdOperatorGreaterThanOrEqual, Threshold: 2},
+			wantValue: float(1),
+		},
+		{
+			name:         "NaN is no data",
+			query:        &bean.MetricQueryDto{Name: "errors", Query: "no_traffic", Operator: bean.ThresholdOperatorLessThan, Threshold: 0.05},
+			wantPassed:   true,
+			wantNoResult: true,
+		},
+		{
+			name:         "no data fails if configured",
+			query:        &bean.MetricQueryDto{Name: "errors", Query: "empty", Operator: bean.ThresholdOperatorLessThan, Threshold: 0.05, FailOnNoData: true},
+			wantNoResult: true,
+		},
+		{
+			name:         "query error fails",
+			query:        &bean.MetricQueryDto{Name: "invalid", Query: "sum(", Operator: bean.ThresholdOperatorLessThan, Threshold: 1},
+			wantNoResult: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := evaluator.EvaluateQueries(context.Background(), promConfig, []*bean.MetricQueryDto{tt.query}, time.Now())
+			assert.NoError(t, err)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.query.Name, results[0].QueryName)
+			assert.Equal(t, tt.wantPassed, results[0].Passed)
+			if tt.wantNoResult {
+				assert.Nil(t, results[0].Value)
+			} else {
+				assert.Equal(t, tt.wantValue, results[0].Value)
+			}
+			if !tt.wantPassed {
+				assert.NotEmpty(t, results[0].Message)
+			}
+		})
+	}
+}
+
+func TestMetricEvaluator_BasicAuth(t *testing.T) {
+	server := newFakePrometheus(map[string][]string{"up": {"1"}})
+	defer server.Close()
+	evaluator := NewMetricEvaluatorImpl(zap.NewNop().Sugar())
+	query := &bean.MetricQueryDto{Name: "up", Query: "up", Operator: bean.ThresholdOperatorGreaterThanOrEqual, Threshold: 1}
+
+	results, err := evaluator.EvaluateQueries(context.Background(), &bean.PrometheusConfig{Endpoint: server.URL, UserName: "admin", Password: "secret"}, []*bean.MetricQueryDto{query}, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, results[0].Passed)
+
+	results, err = evaluator.EvaluateQueries(context.Background(), &bean.PrometheusConfig{Endpoint: server.URL, UserName: "admin", Password: "wrong"}, []*bean.MetricQueryDto{query}, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, results[0].Passed)
+}
+
+func TestMetricEvaluator_PrometheusNotConfigured(t *testing.T) {
+	evaluator := NewMetricEvaluatorImpl(zap.NewNop().Sugar())
+	query := &bean.MetricQueryDto{Name: "up", Query: "up", Operator: bean.ThresholdOperatorGreaterThanOrEqual, Threshold: 1}
+	_, err := evaluator.EvaluateQueries(context.Background(), &bean.PrometheusConfig{}, []*bean.MetricQueryDto{query}, time.Now())
+	assert.EqualError(t, err, bean.PrometheusNotConfiguredError)
+}
+
+func float(value float64) *float64 {
+	return &value
+}
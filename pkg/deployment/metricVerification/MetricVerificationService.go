@@ -0,0 +1,466 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metricVerification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	verificationRepository "github.com/devtron-labs/devtron/pkg/deployment/metricVerification/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/workflow/cd"
+	util2 "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type MetricVerificationService interface {
+	// GetPolicy returns nil if metric verification is not configured for the pipeline
+	GetPolicy(pipelineId int) (*bean.MetricVerificationPolicyDto, error)
+	SavePolicy(policy *bean.MetricVerificationPolicyDto) (*bean.MetricVerificationPolicyDto, error)
+	DeletePolicy(pipelineId int, userId int32) error
+	// GetRun returns nil if the deployment was not verified
+	GetRun(cdWorkflowRunnerId int) (*bean.MetricVerificationRunDto, error)
+
+	// StartVerification starts the verification of a healthy deployment if the pipeline has metric verification enabled.
+	// isHeldForVerification is set if the deployment is (or already was) held for verification, post-cd is then deferred
+	// until the verification passes.
+	StartVerification(pipelineOverride *chartConfig.PipelineOverride, cdWorkflowRunnerId int) (isHeldForVerification bool, err error)
+	// EvaluateDueRuns evaluates the running verifications whose next evaluation is due and
+	// returns the outcome of the verifications which finished in this evaluation
+	EvaluateDueRuns(now time.Time) []*bean.RunOutcome
+}
+
+type MetricVerificationServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	policyRepository              verificationRepository.MetricVerificationPolicyRepository
+	runRepository                 verificationRepository.MetricVerificationRunRepository
+	metricEvaluator               MetricEvaluator
+	pipelineRepository            pipelineConfig.PipelineRepository
+	environmentRepository         repository.EnvironmentRepository
+	cdWorkflowRepository          pipelineConfig.CdWorkflowRepository
+	cdWorkflowRunnerService       cd.CdWorkflowRunnerService
+	pipelineOverrideRepository    chartConfig.PipelineOverrideRepository
+	pipelineStatusTimelineService status.PipelineStatusTimelineService
+	deploymentEventHandler        app.DeploymentEventHandler
+}
+
+func NewMetricVerificationServiceImpl(logger *zap.SugaredLogger,
+	policyRepository verificationRepository.MetricVerificationPolicyRepository,
+	runRepository verificationRepository.MetricVerificationRunRepository,
+	metricEvaluator MetricEvaluator,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	environmentRepository repository.EnvironmentRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	cdWorkflowRunnerService cd.CdWorkflowRunnerService,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	pipelineStatusTimelineService status.PipelineStatusTimelineService,
+	deploymentEventHandler app.DeploymentEventHandler) *MetricVerificationServiceImpl {
+	return &MetricVerificationServiceImpl{
+		logger:                        logger,
+		policyRepository:              policyRepository,
+		runRepository:                 runRepository,
+		metricEvaluator:               metricEvaluator,
+		pipelineRepository:            pipelineRepository,
+		environmentRepository:         environmentRepository,
+		cdWorkflowRepository:          cdWorkflowRepository,
+		cdWorkflowRunnerService:       cdWorkflowRunnerService,
+		pipelineOverrideRepository:    pipelineOverrideRepository,
+		pipelineStatusTimelineService: pipelineStatusTimelineService,
+		deploymentEventHandler:        deploymentEventHandler,
+	}
+}
+
+func (impl *MetricVerificationServiceImpl) GetPolicy(pipelineId int) (*bean.MetricVerificationPolicyDto, error) {
+	policy, err := impl.policyRepository.FindByPipelineId(pipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching metric verification policy", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	queries, err := impl.policyRepository.FindQueriesByPolicyId(policy.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching metric verification queries", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	return adapter.GetMetricVerificationPolicyDto(policy, queries), nil
+}
+
+func (impl *MetricVerificationServiceImpl) SavePolicy(policyDto *bean.MetricVerificationPolicyDto) (*bean.MetricVerificationPolicyDto, error) {
+	err := validatePolicy(policyDto)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(policyDto.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.Deleted {
+		return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline is deleted")
+	}
+	policy, err := impl.policyRepository.FindByPipelineId(policyDto.PipelineId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching metric verification policy", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	if errors.Is(err, pg.ErrNoRows) {
+		policy = &verificationRepository.MetricVerificationPolicy{
+			PipelineId: policyDto.PipelineId,
+			Active:     true,
+			AuditLog:   sql.NewDefaultAuditLog(policyDto.UserId),
+		}
+	}
+	policy.Enabled = policyDto.Enabled
+	policy.DurationMinutes = policyDto.DurationMinutes
+	policy.IntervalSeconds = policyDto.IntervalSeconds
+	policy.FailureLimit = policyDto.FailureLimit
+
+	tx, err := impl.policyRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.policyRepository.RollbackTx(tx)
+	if policy.Id == 0 {
+		err = impl.policyRepository.SavePolicy(tx, policy)
+	} else {
+		policy.UpdateAuditLog(policyDto.UserId)
+		err = impl.policyRepository.UpdatePolicy(tx, policy)
+		if err == nil {
+			err = impl.policyRepository.MarkQueriesInactiveByPolicyId(tx, policy.Id, policyDto.UserId)
+		}
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving metric verification policy", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	queries := make([]*verificationRepository.MetricVerificationQuery, 0, len(policyDto.Queries))
+	for _, queryDto := range policyDto.Queries {
+		queries = append(queries, adapter.NewMetricVerificationQuery(policy.Id, queryDto, policyDto.UserId))
+	}
+	err = impl.policyRepository.SaveQueries(tx, queries)
+	if err != nil {
+		impl.logger.Errorw("error in saving metric verification queries", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	err = impl.policyRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "pipelineId", policyDto.PipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetMetricVerificationPolicyDto(policy, queries), nil
+}
+
+func validatePolicy(policyDto *bean.MetricVerificationPolicyDto) error {
+	if policyDto.DurationMinutes < bean.MinDurationMinutes || policyDto.DurationMinutes > bean.MaxDurationMinutes {
+		return util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("duration should be between %d and %d minutes", bean.MinDurationMinutes, bean.MaxDurationMinutes), "invalid duration")
+	}
+	if policyDto.IntervalSeconds < bean.MinIntervalSeconds || policyDto.IntervalSeconds > bean.MaxIntervalSeconds {
+		return util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("interval should be between %d and %d seconds", bean.MinIntervalSeconds, bean.MaxIntervalSeconds), "invalid interval")
+	}
+	if policyDto.IntervalSeconds > policyDto.DurationMinutes*60 {
+		return util.NewApiError(http.StatusBadRequest, "interval should not be longer than the duration", "invalid interval")
+	}
+	if len(policyDto.Queries) == 0 {
+		return util.NewApiError(http.StatusBadRequest, "at least one query is required", "no queries")
+	}
+	names := make(map[string]bool, len(policyDto.Queries))
+	for _, query := range policyDto.Queries {
+		if names[query.Name] {
+			return util.NewApiError(http.StatusBadRequest, fmt.Sprintf("query name %q is repeated", query.Name), "duplicate query name")
+		}
+		names[query.Name] = true
+		switch query.Operator {
+		case bean.ThresholdOperatorLessThan, bean.ThresholdOperatorLessThanOrEqual,
+			bean.ThresholdOperatorGreaterThan, bean.ThresholdOperatorGreaterThanOrEqual:
+		default:
+			return util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid operator %q for query %q", query.Operator, query.Name), "invalid operator")
+		}
+	}
+	return nil
+}
+
+func (impl *MetricVerificationServiceImpl) DeletePolicy(pipelineId int, userId int32) error {
+	err := impl.policyRepository.MarkInactiveByPipelineId(pipelineId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting metric verification policy", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *MetricVerificationServiceImpl) GetRun(cdWorkflowRunnerId int) (*bean.MetricVerificationRunDto, error) {
+	run, err := impl.runRepository.FindByCdWorkflowRunnerId(cdWorkflowRunnerId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching metric verification run", "cdWorkflowRunnerId", cdWorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	results, err := impl.runRepository.FindResultsByRunId(run.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching metric verification results", "runId", run.Id, "err", err)
+		return nil, err
+	}
+	return adapter.GetMetricVerificationRunDto(run, results), nil
+}
+
+func (impl *MetricVerificationServiceImpl) StartVerification(pipelineOverride *chartConfig.PipelineOverride, cdWorkflowRunnerId int) (isHeldForVerification bool, err error) {
+	if pipelineOverride.DeploymentType == models.DEPLOYMENTTYPE_STOP || pipelineOverride.DeploymentType == models.DEPLOYMENTTYPE_START {
+		// hibernation and un-hibernation are not verified
+		return false, nil
+	}
+	existingRun, err := impl.runRepository.FindByCdWorkflowRunnerId(cdWorkflowRunnerId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching metric verification run", "cdWorkflowRunnerId", cdWorkflowRunnerId, "err", err)
+		return false, err
+	}
+	if existingRun != nil && existingRun.Id > 0 {
+		// success event is received again for a verified deployment, the verification owns the post-cd trigger
+		return true, nil
+	}
+	policy, err := impl.policyRepository.FindByPipelineId(pipelineOverride.PipelineId)
+	if errors.Is(err, pg.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching metric verification policy", "pipelineId", pipelineOverride.PipelineId, "err", err)
+		return false, err
+	}
+	if !policy.Enabled {
+		return false, nil
+	}
+	run := adapter.NewMetricVerificationRun(policy, cdWorkflowRunnerId, pipelineOverride.Id, time.Now(), userBean.SystemUserId)
+	started, err := impl.runRepository.SaveIfAbsent(run)
+	if err != nil {
+		impl.logger.Errorw("error in saving metric verification run", "cdWorkflowRunnerId", cdWorkflowRunnerId, "err", err)
+		return false, err
+	}
+	if started {
+		impl.saveTimeline(cdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_METRIC_VERIFICATION_STARTED,
+			fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_METRIC_VERIFICATION_STARTED, policy.DurationMinutes))
+	}
+	return true, nil
+}
+
+func (impl *MetricVerificationServiceImpl) EvaluateDueRuns(now time.Time) []*bean.RunOutcome {
+	runs, err := impl.runRepository.FindDueRuns(now)
+	if err != nil {
+		impl.logger.Errorw("error in fetching due metric verification runs", "err", err)
+		return nil
+	}
+	outcomes := make([]*bean.RunOutcome, 0)
+	for _, run := range runs {
+		runStatus, err := impl.evaluateRun(run, now)
+		if err != nil {
+			// other runs are still evaluated, the run is retried on its next evaluation
+			impl.logger.Errorw("error in evaluating metric verification run", "runId", run.Id, "err", err)
+			continue
+		}
+		if runStatus != bean.VerificationStatusRunning {
+			outcomes = append(outcomes, &bean.RunOutcome{
+				RunId:              run.Id,
+				PipelineOverrideId: run.PipelineOverrideId,
+				Status:             runStatus,
+			})
+		}
+	}
+	return outcomes
+}
+
+func (impl *MetricVerificationServiceImpl) evaluateRun(run *verificationRepository.MetricVerificationRun, now time.Time) (bean.VerificationStatus, error) {
+	policy, err := impl.policyRepository.FindByPipelineId(run.PipelineId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		return bean.VerificationStatusRunning, err
+	}
+	policyRemoved := errors.Is(err, pg.ErrNoRows) || !policy.Enabled
+	nextEvaluationOn := now.Add(time.Minute)
+	if !policyRemoved {
+		nextEvaluationOn = now.Add(time.Duration(policy.IntervalSeconds) * time.Second)
+	}
+	claimed, err := impl.runRepository.ClaimEvaluation(run.Id, run.NextEvaluationOn, nextEvaluationOn)
+	if err != nil || !claimed {
+		// run is being evaluated by another replica
+		return bean.VerificationStatusRunning, err
+	}
+	run.NextEvaluationOn = nextEvaluationOn
+	if policyRemoved {
+		// deployment is not held back by a policy which no longer applies
+		return impl.finishRun(run, bean.VerificationStatusPassed, bean.PolicyRemovedMessage, now)
+	}
+	latestRunner, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(run.PipelineId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		return bean.VerificationStatusRunning, err
+	}
+	if latestRunner.Id != run.CdWorkflowRunnerId {
+		return impl.finishRun(run, bean.VerificationStatusAborted, bean.SupersededMessage, now)
+	}
+
+	results, err := impl.evaluateQueries(run, policy, now)
+	if err != nil {
+		return bean.VerificationStatusRunning, err
+	}
+	resultModels := make([]*verificationRepository.MetricVerificationResult, 0, len(results))
+	failedQueries := make([]string, 0)
+	for _, result := range results {
+		resultModels = append(resultModels, adapter.NewMetricVerificationResult(run.Id, result))
+		if !result.Passed {
+			failedQueries = append(failedQueries, fmt.Sprintf("%s: %s", result.QueryName, result.Message))
+		}
+	}
+	err = impl.runRepository.SaveResults(resultModels)
+	if err != nil {
+		return bean.VerificationStatusRunning, err
+	}
+	run.EvaluationCount++
+	if len(failedQueries) > 0 {
+		run.FailedEvaluationCount++
+		run.Message = strings.Join(failedQueries, "; ")
+	}
+	if run.FailedEvaluationCount > policy.FailureLimit {
+		return impl.finishRun(run, bean.VerificationStatusFailed, run.Message, now)
+	}
+	if !now.Before(run.EndsOn) {
+		return impl.finishRun(run, bean.VerificationStatusPassed, "", now)
+	}
+	run.UpdateAuditLog(userBean.SystemUserId)
+	err = impl.runRepository.Update(run)
+	if err != nil {
+		return bean.VerificationStatusRunning, err
+	}
+	return bean.VerificationStatusRunning, nil
+}
+
+// evaluateQueries runs the policy queries against the prometheus of the cluster the deployment runs in,
+// a prometheus which can not be reached fails every query of the evaluation
+func (impl *MetricVerificationServiceImpl) evaluateQueries(run *verificationRepository.MetricVerificationRun, policy *verificationRepository.MetricVerificationPolicy, now time.Time) ([]*bean.MetricQueryResultDto, error) {
+	queries, err := impl.policyRepository.FindQueriesByPolicyId(policy.Id)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := impl.pipelineRepository.FindById(run.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+	environment, err := impl.environmentRepository.FindById(pipeline.EnvironmentId)
+	if err != nil {
+		return nil, err
+	}
+	replacer := strings.NewReplacer(
+		bean.QueryPlaceholderAppName, pipeline.App.AppName,
+		bean.QueryPlaceholderEnvName, environment.Name,
+		bean.QueryPlaceholderNamespace, environment.Namespace,
+		bean.QueryPlaceholderDeploymentAppName, pipeline.DeploymentAppName,
+	)
+	queryDtos := make([]*bean.MetricQueryDto, 0, len(queries))
+	for _, query := range queries {
+		queryDto := adapter.GetMetricQueryDto(query)
+		queryDto.Query = replacer.Replace(queryDto.Query)
+		queryDtos = append(queryDtos, queryDto)
+	}
+	var results []*bean.MetricQueryResultDto
+	if environment.Cluster != nil {
+		results, err = impl.metricEvaluator.EvaluateQueries(context.Background(), adapter.GetPrometheusConfig(environment.Cluster), queryDtos, now)
+	} else {
+		err = errors.New(bean.PrometheusNotConfiguredError)
+	}
+	if err != nil {
+		results = make([]*bean.MetricQueryResultDto, 0, len(queryDtos))
+		for _, queryDto := range queryDtos {
+			results = append(results, &bean.MetricQueryResultDto{
+				QueryName:   queryDto.Name,
+				Query:       queryDto.Query,
+				Message:     err.Error(),
+				EvaluatedOn: now,
+			})
+		}
+	}
+	return results, nil
+}
+
+func (impl *MetricVerificationServiceImpl) finishRun(run *verificationRepository.MetricVerificationRun, runStatus bean.VerificationStatus, message string, now time.Time) (bean.VerificationStatus, error) {
+	run.Status = runStatus.String()
+	run.Message = message
+	run.FinishedOn = &now
+	run.UpdateAuditLog(userBean.SystemUserId)
+	err := impl.runRepository.Update(run)
+	if err != nil {
+		return bean.VerificationStatusRunning, err
+	}
+	switch runStatus {
+	case bean.VerificationStatusPassed:
+		impl.saveTimeline(run.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_METRIC_VERIFICATION_PASSED, timelineStatus.TIMELINE_DESCRIPTION_METRIC_VERIFICATION_PASSED)
+	case bean.VerificationStatusFailed, bean.VerificationStatusAborted:
+		impl.saveTimeline(run.CdWorkflowRunnerId, timelineStatus.TIMELINE_STATUS_METRIC_VERIFICATION_FAILED, timelineStatus.TIMELINE_DESCRIPTION_METRIC_VERIFICATION_FAILED+message)
+	}
+	if runStatus == bean.VerificationStatusFailed {
+		impl.failDeployment(run, message, now)
+	}
+	return runStatus, nil
+}
+
+// failDeployment marks the verified deployment failed and sends the deployment failure notification
+func (impl *MetricVerificationServiceImpl) failDeployment(run *verificationRepository.MetricVerificationRun, message string, now time.Time) {
+	wfr, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(run.CdWorkflowRunnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd workflow runner", "wfrId", run.CdWorkflowRunnerId, "err", err)
+		return
+	}
+	wfr.Status = cdWorkflow.WorkflowFailed
+	wfr.Message = timelineStatus.TIMELINE_DESCRIPTION_METRIC_VERIFICATION_FAILED + message
+	wfr.FinishedOn = now
+	wfr.UpdateAuditLog(userBean.SystemUserId)
+	err = impl.cdWorkflowRunnerService.UpdateCdWorkflowRunnerWithStage(wfr)
+	if err != nil {
+		impl.logger.Errorw("error in updating cd workflow runner", "wfrId", run.CdWorkflowRunnerId, "err", err)
+		return
+	}
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindById(run.PipelineOverrideId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override", "pipelineOverrideId", run.PipelineOverrideId, "err", err)
+		return
+	}
+	impl.deploymentEventHandler.WriteCDNotificationEventAsync(pipelineOverride.Pipeline.AppId, pipelineOverride.Pipeline.EnvironmentId, pipelineOverride, util2.Fail)
+}
+
+func (impl *MetricVerificationServiceImpl) saveTimeline(wfrId int, status timelineStatus.TimelineStatus, description string) {
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(wfrId, status, description, userBean.SystemUserId)
+	_, err := impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving metric verification timeline", "wfrId", wfrId, "status", status, "err", err)
+	}
+}
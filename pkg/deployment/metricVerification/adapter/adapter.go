@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	verificationRepository "github.com/devtron-labs/devtron/pkg/deployment/metricVerification/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetMetricVerificationPolicyDto(model *verificationRepository.MetricVerificationPolicy, queries []*verificationRepository.MetricVerificationQuery) *bean.MetricVerificationPolicyDto {
+	policyDto := &bean.MetricVerificationPolicyDto{
+		Id:              model.Id,
+		PipelineId:      model.PipelineId,
+		Enabled:         model.Enabled,
+		DurationMinutes: model.DurationMinutes,
+		IntervalSeconds: model.IntervalSeconds,
+		FailureLimit:    model.FailureLimit,
+		Queries:         make([]*bean.MetricQueryDto, 0, len(queries)),
+	}
+	for _, query := range queries {
+		policyDto.Queries = append(policyDto.Queries, GetMetricQueryDto(query))
+	}
+	return policyDto
+}
+
+func GetMetricQueryDto(model *verificationRepository.MetricVerificationQuery) *bean.MetricQueryDto {
+	return &bean.MetricQueryDto{
+		Name:         model.Name,
+		Query:        model.Query,
+		Operator:     bean.ThresholdOperator(model.Operator),
+		Threshold:    model.Threshold,
+		FailOnNoData: model.FailOnNoData,
+	}
+}
+
+func NewMetricVerificationQuery(policyId int, queryDto *bean.MetricQueryDto, userId int32) *verificationRepository.MetricVerificationQuery {
+	return &verificationRepository.MetricVerificationQuery{
+		PolicyId:     policyId,
+		Name:         queryDto.Name,
+		Query:        queryDto.Query,
+		Operator:     string(queryDto.Operator),
+		Threshold:    queryDto.Threshold,
+		FailOnNoData: queryDto.FailOnNoData,
+		Active:       true,
+		AuditLog:     sql.NewDefaultAuditLog(userId),
+	}
+}
+
+func NewMetricVerificationRun(policy *verificationRepository.MetricVerificationPolicy, cdWorkflowRunnerId, pipelineOverrideId int, startedOn time.Time, userId int32) *verificationRepository.MetricVerificationRun {
+	return &verificationRepository.MetricVerificationRun{
+		PipelineId:         policy.PipelineId,
+		CdWorkflowRunnerId: cdWorkflowRunnerId,
+		PipelineOverrideId: pipelineOverrideId,
+		Status:             bean.VerificationStatusRunning.String(),
+		StartedOn:          startedOn,
+		EndsOn:             startedOn.Add(time.Duration(policy.DurationMinutes) * time.Minute),
+		// first evaluation happens after an interval, so that the new pods have served traffic
+		NextEvaluationOn: startedOn.Add(time.Duration(policy.IntervalSeconds) * time.Second),
+		AuditLog:         sql.NewDefaultAuditLog(userId),
+	}
+}
+
+func GetMetricVerificationRunDto(model *verificationRepository.MetricVerificationRun, results []*verificationRepository.MetricVerificationResult) *bean.MetricVerificationRunDto {
+	runDto := &bean.MetricVerificationRunDto{
+		Id:                    model.Id,
+		PipelineId:            model.PipelineId,
+		CdWorkflowRunnerId:    model.CdWorkflowRunnerId,
+		Status:                bean.VerificationStatus(model.Status),
+		Message:               model.Message,
+		StartedOn:             model.StartedOn,
+		EndsOn:                model.EndsOn,
+		FinishedOn:            model.FinishedOn,
+		EvaluationCount:       model.EvaluationCount,
+		FailedEvaluationCount: model.FailedEvaluationCount,
+		Results:               make([]*bean.MetricQueryResultDto, 0, len(results)),
+	}
+	for _, result := range results {
+		runDto.Results = append(runDto.Results, &bean.MetricQueryResultDto{
+			QueryName:   result.QueryName,
+			Query:       result.Query,
+			Value:       result.Value,
+			Passed:      result.Passed,
+			Message:     result.Message,
+			EvaluatedOn: result.EvaluatedOn,
+		})
+	}
+	return runDto
+}
+
+func NewMetricVerificationResult(runId int, resultDto *bean.MetricQueryResultDto) *verificationRepository.MetricVerificationResult {
+	return &verificationRepository.MetricVerificationResult{
+		RunId:       runId,
+		QueryName:   resultDto.QueryName,
+		Query:       resultDto.Query,
+		Value:       resultDto.Value,
+		Passed:      resultDto.Passed,
+		Message:     resultDto.Message,
+		EvaluatedOn: resultDto.EvaluatedOn,
+	}
+}
+
+func GetPrometheusConfig(cluster *repository.Cluster) *bean.PrometheusConfig {
+	return &bean.PrometheusConfig{
+		Endpoint:              cluster.PrometheusEndpoint,
+		UserName:              cluster.PUserName,
+		Password:              cluster.PPassword,
+		TlsClientCert:         cluster.PTlsClientCert,
+		TlsClientKey:          cluster.PTlsClientKey,
+		InsecureSkipTlsVerify: cluster.InsecureSkipTlsVerify,
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type VerificationStatus string
+
+const (
+	VerificationStatusRunning VerificationStatus = "RUNNING"
+	VerificationStatusPassed  VerificationStatus = "PASSED"
+	VerificationStatusFailed  VerificationStatus = "FAILED"
+	// VerificationStatusAborted is set when a newer deployment of the pipeline is triggered during verification
+	VerificationStatusAborted VerificationStatus = "ABORTED"
+)
+
+func (s VerificationStatus) String() string {
+	return string(s)
+}
+
+// ThresholdOperator is the condition a healthy value of a query satisfies against its threshold
+type ThresholdOperator string
+
+const (
+	ThresholdOperatorLessThan           ThresholdOperator = "LT"
+	ThresholdOperatorLessThanOrEqual    ThresholdOperator = "LTE"
+	ThresholdOperatorGreaterThan        ThresholdOperator = "GT"
+	ThresholdOperatorGreaterThanOrEqual ThresholdOperator = "GTE"
+)
+
+// IsSatisfiedBy returns true if the value is healthy for the threshold
+func (o ThresholdOperator) IsSatisfiedBy(value, threshold float64) bool {
+	switch o {
+	case ThresholdOperatorLessThan:
+		return value < threshold
+	case ThresholdOperatorLessThanOrEqual:
+		return value <= threshold
+	case ThresholdOperatorGreaterThan:
+		return value > threshold
+	case ThresholdOperatorGreaterThanOrEqual:
+		return value >= threshold
+	}
+	return false
+}
+
+const (
+	MinDurationMinutes = 1
+	MaxDurationMinutes = 1440
+	MinIntervalSeconds = 15
+	MaxIntervalSeconds = 3600
+	// PrometheusQueryTimeout bounds a single query so that one slow prometheus does not stall the other verifications
+	PrometheusQueryTimeout = 30 * time.Second
+)
+
+// query placeholders, replaced with the values of the verified deployment before the query is run
+const (
+	QueryPlaceholderAppName           = "{{appName}}"
+	QueryPlaceholderEnvName           = "{{envName}}"
+	QueryPlaceholderNamespace         = "{{namespace}}"
+	QueryPlaceholderDeploymentAppName = "{{deploymentAppName}}"
+)
+
+const (
+	NoDataMessage                = "query returned no data"
+	PolicyRemovedMessage         = "metric verification policy was removed during verification"
+	SupersededMessage            = "deployment was superseded by a newer deployment during verification"
+	PrometheusNotConfiguredError = "prometheus endpoint is not configured for the cluster of the environment"
+)
+
+type MetricQueryDto struct {
+	Name      string            `json:"name" validate:"required,max=250"`
+	Query     string            `json:"query" validate:"required"`
+	Operator  ThresholdOperator `json:"operator" validate:"oneof=LT LTE GT GTE"`
+	Threshold float64           `json:"threshold"`
+	// FailOnNoData fails the evaluation if the query returns no data, by default no data is healthy
+	FailOnNoData bool `json:"failOnNoData"`
+}
+
+type MetricVerificationPolicyDto struct {
+	Id         int  `json:"id"`
+	PipelineId int  `json:"pipelineId" validate:"number,required"`
+	Enabled    bool `json:"enabled"`
+	// DurationMinutes is the time the queries are evaluated for, counted from the deployment becoming healthy
+	DurationMinutes int `json:"durationMinutes" validate:"min=1,max=1440"`
+	IntervalSeconds int `json:"intervalSeconds" validate:"min=15,max=3600"`
+	// FailureLimit is the number of failed evaluations tolerated before the deployment is marked failed
+	FailureLimit int               `json:"failureLimit" validate:"min=0"`
+	Queries      []*MetricQueryDto `json:"queries" validate:"required,min=1,dive"`
+	UserId       int32             `json:"-"`
+}
+
+type MetricQueryResultDto struct {
+	QueryName string `json:"queryName"`
+	Query     string `json:"query"`
+	// Value is nil if the query returned no data or could not be run
+	Value       *float64  `json:"value,omitempty"`
+	Passed      bool      `json:"passed"`
+	Message     string    `json:"message,omitempty"`
+	EvaluatedOn time.Time `json:"evaluatedOn"`
+}
+
+type MetricVerificationRunDto struct {
+	Id                    int                     `json:"id"`
+	PipelineId            int                     `json:"pipelineId"`
+	CdWorkflowRunnerId    int                     `json:"cdWorkflowRunnerId"`
+	Status                VerificationStatus      `json:"status"`
+	Message               string                  `json:"message,omitempty"`
+	StartedOn             time.Time               `json:"startedOn"`
+	EndsOn                time.Time               `json:"endsOn"`
+	FinishedOn            *time.Time              `json:"finishedOn,omitempty"`
+	EvaluationCount       int                     `json:"evaluationCount"`
+	FailedEvaluationCount int                     `json:"failedEvaluationCount"`
+	Results               []*MetricQueryResultDto `json:"results"`
+}
+
+// PrometheusConfig is the prometheus of the cluster a verified deployment runs in
+type PrometheusConfig struct {
+	Endpoint              string
+	UserName              string
+	Password              string
+	TlsClientCert         string
+	TlsClientKey          string
+	InsecureSkipTlsVerify bool
+}
+
+// RunOutcome is the result of evaluating a running verification
+type RunOutcome struct {
+	RunId              int
+	PipelineOverrideId int
+	Status             VerificationStatus
+}
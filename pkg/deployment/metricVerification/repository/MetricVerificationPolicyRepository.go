@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type MetricVerificationPolicy struct {
+	tableName       struct{} `sql:"metric_verification_policy" pg:",discard_unknown_columns"`
+	Id              int      `sql:"id,pk"`
+	PipelineId      int      `sql:"pipeline_id,notnull"`
+	Enabled         bool     `sql:"enabled,notnull"`
+	DurationMinutes int      `sql:"duration_minutes,notnull"`
+	IntervalSeconds int      `sql:"interval_seconds,notnull"`
+	FailureLimit    int      `sql:"failure_limit,notnull"`
+	Active          bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type MetricVerificationQuery struct {
+	tableName    struct{} `sql:"metric_verification_query" pg:",discard_unknown_columns"`
+	Id           int      `sql:"id,pk"`
+	PolicyId     int      `sql:"policy_id,notnull"`
+	Name         string   `sql:"name,notnull"`
+	Query        string   `sql:"query,notnull"`
+	Operator     string   `sql:"operator,notnull"`
+	Threshold    float64  `sql:"threshold,notnull"`
+	FailOnNoData bool     `sql:"fail_on_no_data,notnull"`
+	Active       bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type MetricVerificationPolicyRepository interface {
+	sql.TransactionWrapper
+	SavePolicy(tx *pg.Tx, policy *MetricVerificationPolicy) error
+	UpdatePolicy(tx *pg.Tx, policy *MetricVerificationPolicy) error
+	FindByPipelineId(pipelineId int) (*MetricVerificationPolicy, error)
+	MarkInactiveByPipelineId(pipelineId int, userId int32) error
+
+	SaveQueries(tx *pg.Tx, queries []*MetricVerificationQuery) error
+	// MarkQueriesInactiveByPolicyId is used to replace the queries of a policy on update
+	MarkQueriesInactiveByPolicyId(tx *pg.Tx, policyId int, userId int32) error
+	FindQueriesByPolicyId(policyId int) ([]*MetricVerificationQuery, error)
+}
+
+type MetricVerificationPolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewMetricVerificationPolicyRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	transactionUtilImpl *sql.TransactionUtilImpl) *MetricVerificationPolicyRepositoryImpl {
+	return &MetricVerificationPolicyRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) SavePolicy(tx *pg.Tx, policy *MetricVerificationPolicy) error {
+	return tx.Insert(policy)
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) UpdatePolicy(tx *pg.Tx, policy *MetricVerificationPolicy) error {
+	return tx.Update(policy)
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) FindByPipelineId(pipelineId int) (*MetricVerificationPolicy, error) {
+	policy := &MetricVerificationPolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) MarkInactiveByPipelineId(pipelineId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*MetricVerificationPolicy)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("pipeline_id = ?", pipelineId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) SaveQueries(tx *pg.Tx, queries []*MetricVerificationQuery) error {
+	if len(queries) == 0 {
+		return nil
+	}
+	_, err := tx.Model(&queries).Insert()
+	return err
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) MarkQueriesInactiveByPolicyId(tx *pg.Tx, policyId int, userId int32) error {
+	_, err := tx.Model((*MetricVerificationQuery)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("policy_id = ?", policyId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
+
+func (impl *MetricVerificationPolicyRepositoryImpl) FindQueriesByPolicyId(policyId int) ([]*MetricVerificationQuery, error) {
+	var queries []*MetricVerificationQuery
+	err := impl.dbConnection.Model(&queries).
+		Where("policy_id = ?", policyId).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return queries, err
+}
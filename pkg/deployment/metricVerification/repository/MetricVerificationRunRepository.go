@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type MetricVerificationRun struct {
+	tableName             struct{}   `sql:"metric_verification_run" pg:",discard_unknown_columns"`
+	Id                    int        `sql:"id,pk"`
+	PipelineId            int        `sql:"pipeline_id,notnull"`
+	CdWorkflowRunnerId    int        `sql:"cd_workflow_runner_id,notnull"`
+	PipelineOverrideId    int        `sql:"pipeline_override_id,notnull"`
+	Status                string     `sql:"status,notnull"`
+	Message               string     `sql:"message"`
+	StartedOn             time.Time  `sql:"started_on,notnull"`
+	EndsOn                time.Time  `sql:"ends_on,notnull"`
+	NextEvaluationOn      time.Time  `sql:"next_evaluation_on,notnull"`
+	FinishedOn            *time.Time `sql:"finished_on"`
+	EvaluationCount       int        `sql:"evaluation_count,notnull"`
+	FailedEvaluationCount int        `sql:"failed_evaluation_count,notnull"`
+	sql.AuditLog
+}
+
+type MetricVerificationResult struct {
+	tableName   struct{}  `sql:"metric_verification_result" pg:",discard_unknown_columns"`
+	Id          int       `sql:"id,pk"`
+	RunId       int       `sql:"run_id,notnull"`
+	QueryName   string    `sql:"query_name,notnull"`
+	Query       string    `sql:"query,notnull"`
+	Value       *float64  `sql:"value"`
+	Passed      bool      `sql:"passed,notnull"`
+	Message     string    `sql:"message"`
+	EvaluatedOn time.Time `sql:"evaluated_on,notnull"`
+}
+
+type MetricVerificationRunRepository interface {
+	// SaveIfAbsent inserts the run only if the deployment has none yet,
+	// so that a deployment is verified by exactly one orchestrator replica
+	SaveIfAbsent(run *MetricVerificationRun) (bool, error)
+	Update(run *MetricVerificationRun) error
+	FindByCdWorkflowRunnerId(cdWorkflowRunnerId int) (*MetricVerificationRun, error)
+	// FindDueRuns returns the running verifications whose next evaluation is due
+	FindDueRuns(now time.Time) ([]*MetricVerificationRun, error)
+	// ClaimEvaluation moves the next evaluation of a due run ahead, false is returned if another replica already did
+	ClaimEvaluation(runId int, dueOn time.Time, nextEvaluationOn time.Time) (bool, error)
+
+	SaveResults(results []*MetricVerificationResult) error
+	FindResultsByRunId(runId int) ([]*MetricVerificationResult, error)
+}
+
+type MetricVerificationRunRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewMetricVerificationRunRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *MetricVerificationRunRepositoryImpl {
+	return &MetricVerificationRunRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) SaveIfAbsent(run *MetricVerificationRun) (bool, error) {
+	res, err := impl.dbConnection.Model(run).
+		OnConflict("(cd_workflow_runner_id) DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) Update(run *MetricVerificationRun) error {
+	return impl.dbConnection.Update(run)
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) FindByCdWorkflowRunnerId(cdWorkflowRunnerId int) (*MetricVerificationRun, error) {
+	run := &MetricVerificationRun{}
+	err := impl.dbConnection.Model(run).
+		Where("cd_workflow_runner_id = ?", cdWorkflowRunnerId).
+		Select()
+	return run, err
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) FindDueRuns(now time.Time) ([]*MetricVerificationRun, error) {
+	var runs []*MetricVerificationRun
+	err := impl.dbConnection.Model(&runs).
+		Where("status = ?", bean.VerificationStatusRunning.String()).
+		Where("next_evaluation_on <= ?", now).
+		Order("next_evaluation_on ASC").
+		Select()
+	return runs, err
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) ClaimEvaluation(runId int, dueOn time.Time, nextEvaluationOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*MetricVerificationRun)(nil)).
+		Set("next_evaluation_on = ?", nextEvaluationOn).
+		Where("id = ?", runId).
+		Where("status = ?", bean.VerificationStatusRunning.String()).
+		Where("next_evaluation_on = ?", dueOn).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) SaveResults(results []*MetricVerificationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	_, err := impl.dbConnection.Model(&results).Insert()
+	return err
+}
+
+func (impl *MetricVerificationRunRepositoryImpl) FindResultsByRunId(runId int) ([]*MetricVerificationResult, error) {
+	var results []*MetricVerificationResult
+	err := impl.dbConnection.Model(&results).
+		Where("run_id = ?", runId).
+		Order("id ASC").
+		Select()
+	return results, err
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metricVerification
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification/repository"
+	"github.com/google/wire"
+)
+
+var MetricVerificationWireSet = wire.NewSet(
+	repository.NewMetricVerificationPolicyRepositoryImpl,
+	wire.Bind(new(repository.MetricVerificationPolicyRepository), new(*repository.MetricVerificationPolicyRepositoryImpl)),
+	repository.NewMetricVerificationRunRepositoryImpl,
+	wire.Bind(new(repository.MetricVerificationRunRepository), new(*repository.MetricVerificationRunRepositoryImpl)),
+	NewMetricEvaluatorImpl,
+	wire.Bind(new(MetricEvaluator), new(*MetricEvaluatorImpl)),
+	NewMetricVerificationServiceImpl,
+	wire.Bind(new(MetricVerificationService), new(*MetricVerificationServiceImpl)),
+)
@@ -0,0 +1,738 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package previewEnvironment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	bean3 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/appWorkflow"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	appWorkflowBean "github.com/devtron-labs/devtron/pkg/appWorkflow/bean"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	pkgBean "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment"
+	envBean "github.com/devtron-labs/devtron/pkg/cluster/environment/bean"
+	clusterRead "github.com/devtron-labs/devtron/pkg/cluster/read"
+	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
+	deployedAppBean "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/helper"
+	previewRepository "github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type PreviewEnvironmentService interface {
+	GetTemplate(appId int) (*bean.PreviewTemplateDto, error)
+	// SaveTemplate creates or updates the preview environment template of the app
+	SaveTemplate(request *bean.PreviewTemplateDto) (*bean.PreviewTemplateDto, error)
+	// DeleteTemplate disables the previews of the app, the live previews are hibernated and deleted
+	DeleteTemplate(appId int, userId int32) error
+	GetPreviews(appId int) ([]*bean.PreviewEnvironmentDto, error)
+	// DeletePreview hibernates the preview, it is deleted after the grace period
+	DeletePreview(appId, previewId int, userId int32) error
+
+	// DeployPreviewAsync deploys the artifact to the preview environment of the pull request it is built from, the
+	// environment is created on the first build of the pull request
+	DeployPreviewAsync(ciArtifactId, ciWorkflowId int)
+	// HandlePullRequestEventAsync hibernates the previews of the pull request closed by the git host webhook event
+	HandlePullRequestEventAsync(eventType string, payload []byte)
+	HibernateExpiredPreviews()
+	DeleteHibernatedPreviews()
+}
+
+type PreviewEnvironmentServiceImpl struct {
+	logger                  *zap.SugaredLogger
+	config                  *bean.PreviewEnvironmentConfig
+	templateRepository      previewRepository.PreviewEnvironmentTemplateRepository
+	previewRepository       previewRepository.PreviewEnvironmentRepository
+	pipelineRepository      pipelineConfig.PipelineRepository
+	ciWorkflowRepository    pipelineConfig.CiWorkflowRepository
+	ciArtifactRepository    repository.CiArtifactRepository
+	appWorkflowRepository   appWorkflow.AppWorkflowRepository
+	cdPipelineConfigService pipeline.CdPipelineConfigService
+	environmentService      environment.EnvironmentService
+	clusterReadService      clusterRead.ClusterReadService
+	cdHandlerService        devtronApps.HandlerService
+	deployedAppService      deployedApp.DeployedAppService
+	commitStatusService     commitStatus.CommitStatusService
+	K8sUtil                 *k8s.K8sServiceImpl
+	asyncRunnable           *async.Runnable
+}
+
+func NewPreviewEnvironmentServiceImpl(logger *zap.SugaredLogger,
+	templateRepository previewRepository.PreviewEnvironmentTemplateRepository,
+	previewRepository previewRepository.PreviewEnvironmentRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	appWorkflowRepository appWorkflow.AppWorkflowRepository,
+	cdPipelineConfigService pipeline.CdPipelineConfigService,
+	environmentService environment.EnvironmentService,
+	clusterReadService clusterRead.ClusterReadService,
+	cdHandlerService devtronApps.HandlerService,
+	deployedAppService deployedApp.DeployedAppService,
+	commitStatusService commitStatus.CommitStatusService,
+	K8sUtil *k8s.K8sServiceImpl,
+	asyncRunnable *async.Runnable,
+	cronLogger *cron2.CronLoggerImpl) (*PreviewEnvironmentServiceImpl, error) {
+	config := &bean.PreviewEnvironmentConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing preview environment config", "err", err)
+		return nil, err
+	}
+	impl := &PreviewEnvironmentServiceImpl{
+		logger:                  logger,
+		config:                  config,
+		templateRepository:      templateRepository,
+		previewRepository:       previewRepository,
+		pipelineRepository:      pipelineRepository,
+		ciWorkflowRepository:    ciWorkflowRepository,
+		ciArtifactRepository:    ciArtifactRepository,
+		appWorkflowRepository:   appWorkflowRepository,
+		cdPipelineConfigService: cdPipelineConfigService,
+		environmentService:      environmentService,
+		clusterReadService:      clusterReadService,
+		cdHandlerService:        cdHandlerService,
+		deployedAppService:      deployedAppService,
+		commitStatusService:     commitStatusService,
+		K8sUtil:                 K8sUtil,
+		asyncRunnable:           asyncRunnable,
+	}
+	// the clean up is not exposed to other services, so the cron is owned by the service itself
+	cleanUpCron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cleanUpCron.Start()
+	_, err = cleanUpCron.AddFunc(config.CleanUpCron, impl.cleanUpPreviews)
+	if err != nil {
+		logger.Errorw("error while configure cron job for preview environments", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) GetTemplate(appId int) (*bean.PreviewTemplateDto, error) {
+	template, err := impl.templateRepository.FindActiveByAppId(appId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "preview environments are not configured for the app", "preview environment template not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching preview environment template", "appId", appId, "err", err)
+		return nil, err
+	}
+	return adapter.GetPreviewTemplateDto(template), nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) SaveTemplate(request *bean.PreviewTemplateDto) (*bean.PreviewTemplateDto, error) {
+	templatePipeline, err := impl.pipelineRepository.FindById(request.TemplatePipelineId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching template pipeline", "pipelineId", request.TemplatePipelineId, "err", err)
+		return nil, err
+	}
+	if util.IsErrNoRows(err) || templatePipeline.AppId != request.AppId {
+		return nil, util.NewApiError(http.StatusBadRequest, "template pipeline is not a cd pipeline of the app", "template pipeline not found in app")
+	}
+	// previews are deployed with the artifacts of the pull request builds, so the template pipeline must be deployed
+	// from the ci pipeline building them
+	workflowMapping, err := impl.appWorkflowRepository.FindWFCDMappingByCDPipelineId(templatePipeline.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching workflow of template pipeline", "pipelineId", templatePipeline.Id, "err", err)
+		return nil, err
+	}
+	if workflowMapping.ParentType != appWorkflowBean.CI_PIPELINE_TYPE || templatePipeline.CiPipelineId == 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "template pipeline must be deployed from a ci pipeline", "template pipeline parent is not a ci pipeline")
+	}
+	_, err = impl.clusterReadService.FindById(request.ClusterId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("cluster %d not found", request.ClusterId), "cluster not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching cluster", "clusterId", request.ClusterId, "err", err)
+		return nil, err
+	}
+	namespaceTemplate := strings.TrimSpace(request.NamespaceTemplate)
+	if len(namespaceTemplate) == 0 {
+		namespaceTemplate = bean.DefaultNamespaceTemplate
+	}
+	if !strings.Contains(namespaceTemplate, bean.PlaceholderPrNumber) {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.NamespaceTemplateMessage, "namespace template has no pr number placeholder")
+	}
+	if err = helper.ValidateValues(request.ValuesOverride); err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, "values override must be a yaml or json object", err.Error())
+	}
+	template, err := impl.templateRepository.FindActiveByAppId(request.AppId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching preview environment template", "appId", request.AppId, "err", err)
+		return nil, err
+	}
+	isNew := util.IsErrNoRows(err)
+	if isNew {
+		template = &previewRepository.PreviewEnvironmentTemplate{
+			AppId:    request.AppId,
+			Active:   true,
+			AuditLog: sql.NewDefaultAuditLog(request.UserId),
+		}
+	} else {
+		template.UpdateAuditLog(request.UserId)
+	}
+	template.TemplatePipelineId = templatePipeline.Id
+	template.CiPipelineId = templatePipeline.CiPipelineId
+	template.ClusterId = request.ClusterId
+	template.NamespaceTemplate = namespaceTemplate
+	template.ValuesOverride = request.ValuesOverride
+	template.UrlTemplate = strings.TrimSpace(request.UrlTemplate)
+	template.TtlHours = request.TtlHours
+	template.MaxActivePreviews = request.MaxActivePreviews
+	if isNew {
+		err = impl.templateRepository.Save(template)
+	} else {
+		err = impl.templateRepository.Update(template)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving preview environment template", "appId", request.AppId, "err", err)
+		return nil, err
+	}
+	return adapter.GetPreviewTemplateDto(template), nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) DeleteTemplate(appId int, userId int32) error {
+	template, err := impl.templateRepository.FindActiveByAppId(appId)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, "preview environments are not configured for the app", "preview environment template not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching preview environment template", "appId", appId, "err", err)
+		return err
+	}
+	template.Active = false
+	template.UpdateAuditLog(userId)
+	err = impl.templateRepository.Update(template)
+	if err != nil {
+		impl.logger.Errorw("error in deleting preview environment template", "appId", appId, "err", err)
+		return err
+	}
+	previews, err := impl.previewRepository.FindLiveByTemplateId(template.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching live previews of template", "templateId", template.Id, "err", err)
+		return err
+	}
+	for _, preview := range previews {
+		if preview.Status == bean.PreviewStatusActive.String() {
+			impl.hibernatePreview(preview, bean.DeletedByUserMessage, userId)
+		}
+	}
+	return nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) GetPreviews(appId int) ([]*bean.PreviewEnvironmentDto, error) {
+	previews, err := impl.previewRepository.FindByAppId(appId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching previews", "appId", appId, "err", err)
+		return nil, err
+	}
+	res := make([]*bean.PreviewEnvironmentDto, 0, len(previews))
+	for _, preview := range previews {
+		res = append(res, adapter.GetPreviewEnvironmentDto(preview))
+	}
+	return res, nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) DeletePreview(appId, previewId int, userId int32) error {
+	preview, err := impl.previewRepository.FindById(previewId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching preview", "previewId", previewId, "err", err)
+		return err
+	}
+	if util.IsErrNoRows(err) || preview.AppId != appId {
+		return util.NewApiError(http.StatusNotFound, fmt.Sprintf("preview %d not found", previewId), "preview not found")
+	}
+	if preview.Status != bean.PreviewStatusActive.String() {
+		return util.NewApiError(http.StatusConflict, fmt.Sprintf("preview is already %s", strings.ToLower(preview.Status)), "preview is not active")
+	}
+	if !impl.hibernatePreview(preview, bean.DeletedByUserMessage, userId) {
+		return util.NewApiError(http.StatusConflict, "preview was changed in between, please retry", "preview status changed")
+	}
+	return nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) DeployPreviewAsync(ciArtifactId, ciWorkflowId int) {
+	impl.asyncRunnable.Execute(func() {
+		impl.deployPreview(ciArtifactId, ciWorkflowId)
+	})
+}
+
+func (impl *PreviewEnvironmentServiceImpl) deployPreview(ciArtifactId, ciWorkflowId int) {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return
+	}
+	template, err := impl.templateRepository.FindActiveByCiPipelineId(artifact.PipelineId)
+	if util.IsErrNoRows(err) {
+		return
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching preview environment template", "ciPipelineId", artifact.PipelineId, "err", err)
+		return
+	}
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(ciWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci workflow of artifact", "ciWorkflowId", ciWorkflowId, "err", err)
+		return
+	}
+	pr, ok := helper.PullRequestFromGitTriggers(ciWorkflow.GitTriggers)
+	if !ok {
+		impl.logger.Debugw("artifact is not built from a pull request, no preview deployed", "ciArtifactId", artifact.Id)
+		return
+	}
+	templatePipeline, err := impl.pipelineRepository.FindById(template.TemplatePipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching template pipeline", "pipelineId", template.TemplatePipelineId, "err", err)
+		return
+	}
+	appName := templatePipeline.App.AppName
+	preview, err := impl.previewRepository.FindLiveByTemplateIdAndPrNumber(template.Id, pr.Number)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching preview of pull request", "templateId", template.Id, "prNumber", pr.Number, "err", err)
+		return
+	}
+	if util.IsErrNoRows(err) {
+		preview, err = impl.createPreview(template, templatePipeline, pr)
+		if err != nil {
+			impl.logger.Errorw("error in creating preview environment", "appId", template.AppId, "prNumber", pr.Number, "err", err)
+			impl.reportPreview(template.AppId, appName, pr, commitStatusBean.StateError, fmt.Sprintf("Preview not created: %s", util.GetClientErrorDetailedMessage(err)), "")
+			return
+		}
+	} else if preview.Status == bean.PreviewStatusHibernated.String() {
+		// the pull request was reopened or rebuilt before the hibernated preview got deleted
+		preview.Status = bean.PreviewStatusActive.String()
+		preview.DeleteAfter = nil
+		preview.Message = ""
+		preview.UpdateAuditLog(userBean.SystemUserId)
+		reactivated, activeCount, err := impl.previewRepository.ReactivateIfUnderQuota(preview, template.MaxActivePreviews)
+		if err == nil && !reactivated && activeCount >= template.MaxActivePreviews {
+			err = quotaReachedError(activeCount, template.MaxActivePreviews)
+			impl.reportPreview(template.AppId, appName, pr, commitStatusBean.StateError, fmt.Sprintf("Preview not reactivated: %s", util.GetClientErrorDetailedMessage(err)), "")
+		}
+		if err != nil || !reactivated {
+			impl.logger.Warnw("hibernated preview could not be reactivated", "previewId", preview.Id, "err", err)
+			return
+		}
+	}
+	data := &helper.TemplateData{AppName: appName, PullRequest: pr, Namespace: preview.Namespace, Url: preview.Url}
+	additionalOverride, err := data.RenderValues(template.ValuesOverride)
+	if err != nil {
+		impl.logger.Errorw("error in rendering values override of preview", "templateId", template.Id, "err", err)
+		return
+	}
+	overrideRequest := &bean3.ValuesOverrideRequest{
+		PipelineId:           preview.CdPipelineId,
+		AppId:                preview.AppId,
+		CiArtifactId:         artifact.Id,
+		AdditionalOverride:   additionalOverride,
+		CdWorkflowType:       bean3.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig: bean3.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED,
+		DeploymentType:       models.DEPLOYMENTTYPE_DEPLOY,
+		UserId:               userBean.SystemUserId,
+	}
+	_, _, _, err = impl.cdHandlerService.ManualCdTrigger(triggerBean.TriggerContext{Context: context.Background()}, overrideRequest, nil)
+	preview.PrTitle = pr.Title
+	preview.CommitHash = pr.Commit
+	preview.ExpiresOn = time.Now().Add(impl.ttl(template))
+	if err != nil {
+		impl.logger.Errorw("error in deploying preview", "previewId", preview.Id, "ciArtifactId", artifact.Id, "err", err)
+		preview.Message = fmt.Sprintf("deployment failed: %s", util.GetClientErrorDetailedMessage(err))
+		impl.reportPreview(preview.AppId, appName, pr, commitStatusBean.StateFailure, fmt.Sprintf("Preview deployment to %s failed", preview.Namespace), preview.Url)
+	} else {
+		preview.Message = ""
+		preview.LastDeployedArtifactId = artifact.Id
+		impl.reportPreview(preview.AppId, appName, pr, commitStatusBean.StateSuccess, fmt.Sprintf("Preview deployed to %s", preview.Namespace), preview.Url)
+	}
+	preview.UpdateAuditLog(userBean.SystemUserId)
+	if _, err = impl.previewRepository.UpdateIfStatus(preview, bean.PreviewStatusActive); err != nil {
+		impl.logger.Errorw("error in updating preview", "previewId", preview.Id, "err", err)
+	}
+}
+
+// createPreview creates the environment, the namespace and the cd pipeline of the preview of the pull request
+func (impl *PreviewEnvironmentServiceImpl) createPreview(template *previewRepository.PreviewEnvironmentTemplate,
+	templatePipeline *pipelineConfig.Pipeline, pr *bean.PullRequest) (*previewRepository.PreviewEnvironment, error) {
+	data := &helper.TemplateData{AppName: templatePipeline.App.AppName, PullRequest: pr}
+	data.Namespace = data.RenderNamespace(template.NamespaceTemplate)
+	if len(data.Namespace) == 0 {
+		return nil, errors.New("namespace template renders to an empty namespace")
+	}
+	data.Url = data.Render(template.UrlTemplate)
+	// the preview is saved first, the unique index on the live previews of a pull request keeps concurrent builds
+	// of the same pull request from creating two environments, and the quota lock concurrent builds of different ones
+	// from exceeding the max active previews
+	preview := &previewRepository.PreviewEnvironment{
+		TemplateId:   template.Id,
+		AppId:        template.AppId,
+		PrNumber:     pr.Number,
+		PrUrl:        helper.NormalizePrUrl(pr.Url),
+		PrTitle:      pr.Title,
+		SourceBranch: pr.SourceBranch,
+		RepoUrl:      pr.RepoUrl,
+		CommitHash:   pr.Commit,
+		Namespace:    data.Namespace,
+		Url:          data.Url,
+		Status:       bean.PreviewStatusActive.String(),
+		ExpiresOn:    time.Now().Add(impl.ttl(template)),
+		AuditLog:     sql.NewDefaultAuditLog(userBean.SystemUserId),
+	}
+	saved, activeCount, err := impl.previewRepository.SaveIfUnderQuota(preview, template.MaxActivePreviews)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, quotaReachedError(activeCount, template.MaxActivePreviews)
+	}
+	err = impl.createPreviewResources(template, templatePipeline, preview)
+	if err != nil {
+		// whatever got created is cleaned up, the preview is recreated on the next build of the pull request
+		impl.deletePreviewResources(preview, template.ClusterId)
+		preview.Status = bean.PreviewStatusFailed.String()
+		preview.Message = util.GetClientErrorDetailedMessage(err)
+		preview.UpdateAuditLog(userBean.SystemUserId)
+		if updateErr := impl.previewRepository.Update(preview); updateErr != nil {
+			impl.logger.Errorw("error in marking preview failed", "previewId", preview.Id, "err", updateErr)
+		}
+		return nil, err
+	}
+	return preview, nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) createPreviewResources(template *previewRepository.PreviewEnvironmentTemplate,
+	templatePipeline *pipelineConfig.Pipeline, preview *previewRepository.PreviewEnvironment) error {
+	err := impl.createPreviewNamespace(template.ClusterId, preview)
+	if err != nil {
+		return err
+	}
+	environmentBean, err := impl.environmentService.Create(&envBean.EnvironmentBean{
+		Environment: preview.Namespace,
+		ClusterId:   template.ClusterId,
+		Namespace:   preview.Namespace,
+		Active:      true,
+		Description: fmt.Sprintf("preview of pull request #%d", preview.PrNumber),
+	}, userBean.SystemUserId)
+	if err != nil {
+		impl.logger.Errorw("error in creating preview environment", "namespace", preview.Namespace, "err", err)
+		return err
+	}
+	preview.EnvironmentId = environmentBean.Id
+	refPipeline, err := impl.cdPipelineConfigService.GetCdPipelineById(templatePipeline.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching template pipeline", "pipelineId", templatePipeline.Id, "err", err)
+		return err
+	}
+	workflowMapping, err := impl.appWorkflowRepository.FindWFCDMappingByCDPipelineId(templatePipeline.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching workflow of template pipeline", "pipelineId", templatePipeline.Id, "err", err)
+		return err
+	}
+	// the preview pipelines are triggered for the artifacts of their own pull request only, so they are never
+	// triggered automatically on the success of the shared ci pipeline
+	cdPipeline := &pkgBean.CDPipelineConfigObject{
+		EnvironmentId:                 environmentBean.Id,
+		CiPipelineId:                  refPipeline.CiPipelineId,
+		TriggerType:                   pipelineConfig.TRIGGER_TYPE_MANUAL,
+		Name:                          helper.Slug(fmt.Sprintf("%s-pr-%d", refPipeline.Name, preview.PrNumber), 50),
+		Strategies:                    refPipeline.Strategies,
+		Namespace:                     preview.Namespace,
+		AppWorkflowId:                 workflowMapping.AppWorkflowId,
+		DeploymentTemplate:            refPipeline.DeploymentTemplate,
+		PreStage:                      refPipeline.PreStage,
+		PostStage:                     refPipeline.PostStage,
+		PreStageConfigMapSecretNames:  refPipeline.PreStageConfigMapSecretNames,
+		PostStageConfigMapSecretNames: refPipeline.PostStageConfigMapSecretNames,
+		RunPreStageInEnv:              refPipeline.RunPreStageInEnv,
+		RunPostStageInEnv:             refPipeline.RunPostStageInEnv,
+		DeploymentAppType:             refPipeline.DeploymentAppType,
+		PreDeployStage:                refPipeline.PreDeployStage,
+		PostDeployStage:               refPipeline.PostDeployStage,
+		ParentPipelineType:            refPipeline.ParentPipelineType,
+		IsDigestEnforcedForPipeline:   refPipeline.IsDigestEnforcedForPipeline,
+	}
+	res, err := impl.cdPipelineConfigService.CreateCdPipelines(&pkgBean.CdPipelines{
+		Pipelines: []*pkgBean.CDPipelineConfigObject{cdPipeline},
+		AppId:     template.AppId,
+		UserId:    userBean.SystemUserId,
+	}, context.Background())
+	if err != nil {
+		impl.logger.Errorw("error in creating preview pipeline", "appId", template.AppId, "envId", environmentBean.Id, "err", err)
+		return err
+	}
+	if res == nil || len(res.Pipelines) == 0 {
+		return errors.New("preview pipeline not created")
+	}
+	preview.CdPipelineId = res.Pipelines[0].Id
+	preview.UpdateAuditLog(userBean.SystemUserId)
+	return impl.previewRepository.Update(preview)
+}
+
+func (impl *PreviewEnvironmentServiceImpl) HandlePullRequestEventAsync(eventType string, payload []byte) {
+	prUrl, closed := helper.ClosedPullRequestUrl(eventType, payload)
+	if !closed {
+		return
+	}
+	impl.asyncRunnable.Execute(func() {
+		previews, err := impl.previewRepository.FindActiveByPrUrl(helper.NormalizePrUrl(prUrl))
+		if err != nil {
+			impl.logger.Errorw("error in fetching previews of pull request", "prUrl", prUrl, "err", err)
+			return
+		}
+		for _, preview := range previews {
+			impl.logger.Infow("hibernating preview of closed pull request", "previewId", preview.Id, "prUrl", prUrl)
+			impl.hibernatePreview(preview, bean.ClosedByPullRequestMessage, userBean.SystemUserId)
+		}
+	})
+}
+
+func (impl *PreviewEnvironmentServiceImpl) cleanUpPreviews() {
+	impl.HibernateExpiredPreviews()
+	impl.DeleteHibernatedPreviews()
+}
+
+func (impl *PreviewEnvironmentServiceImpl) HibernateExpiredPreviews() {
+	previews, err := impl.previewRepository.FindActiveExpiredBefore(time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching expired previews", "err", err)
+		return
+	}
+	for _, preview := range previews {
+		impl.logger.Infow("hibernating expired preview", "previewId", preview.Id, "expiresOn", preview.ExpiresOn)
+		impl.hibernatePreview(preview, bean.ExpiredMessage, userBean.SystemUserId)
+	}
+}
+
+func (impl *PreviewEnvironmentServiceImpl) DeleteHibernatedPreviews() {
+	previews, err := impl.previewRepository.FindHibernatedToDeleteBefore(time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching hibernated previews", "err", err)
+		return
+	}
+	for _, preview := range previews {
+		impl.deletePreview(preview)
+	}
+}
+
+// hibernatePreview scales the preview down and schedules its deletion, false is returned if the preview was not
+// active anymore
+func (impl *PreviewEnvironmentServiceImpl) hibernatePreview(preview *previewRepository.PreviewEnvironment, message string, userId int32) bool {
+	deleteAfter := time.Now().Add(time.Duration(impl.config.DeleteGraceMins) * time.Minute)
+	preview.Status = bean.PreviewStatusHibernated.String()
+	preview.Message = message
+	preview.DeleteAfter = &deleteAfter
+	preview.UpdateAuditLog(userId)
+	hibernated, err := impl.previewRepository.UpdateIfStatus(preview, bean.PreviewStatusActive)
+	if err != nil {
+		impl.logger.Errorw("error in hibernating preview", "previewId", preview.Id, "err", err)
+		return false
+	}
+	if !hibernated {
+		return false
+	}
+	if preview.LastDeployedArtifactId == 0 {
+		// nothing was deployed, nothing to scale down
+		return true
+	}
+	_, err = impl.deployedAppService.StopStartApp(context.Background(), &deployedAppBean.StopAppRequest{
+		AppId:         preview.AppId,
+		EnvironmentId: preview.EnvironmentId,
+		UserId:        userId,
+		RequestType:   deployedAppBean.STOP,
+	}, nil)
+	if err != nil {
+		// the preview is deleted after the grace period anyway
+		impl.logger.Errorw("error in hibernating preview deployment", "previewId", preview.Id, "envId", preview.EnvironmentId, "err", err)
+	}
+	return true
+}
+
+// deletePreview deletes the pipeline, the environment and the namespace of the hibernated preview
+func (impl *PreviewEnvironmentServiceImpl) deletePreview(preview *previewRepository.PreviewEnvironment) {
+	// the preview is claimed by moving it to deleted first, so it is deleted by a single replica
+	preview.Status = bean.PreviewStatusDeleted.String()
+	preview.UpdateAuditLog(userBean.SystemUserId)
+	claimed, err := impl.previewRepository.UpdateIfStatus(preview, bean.PreviewStatusHibernated)
+	if err != nil || !claimed {
+		if err != nil {
+			impl.logger.Errorw("error in claiming preview for deletion", "previewId", preview.Id, "err", err)
+		}
+		return
+	}
+	err = impl.deletePreviewResources(preview, 0)
+	if err != nil {
+		// retried after the grace period
+		deleteAfter := time.Now().Add(time.Duration(impl.config.DeleteGraceMins) * time.Minute)
+		preview.Status = bean.PreviewStatusHibernated.String()
+		preview.Message = fmt.Sprintf("deletion failed: %s", util.GetClientErrorDetailedMessage(err))
+		preview.DeleteAfter = &deleteAfter
+		preview.UpdateAuditLog(userBean.SystemUserId)
+		if _, updateErr := impl.previewRepository.UpdateIfStatus(preview, bean.PreviewStatusDeleted); updateErr != nil {
+			impl.logger.Errorw("error in rescheduling preview deletion", "previewId", preview.Id, "err", updateErr)
+		}
+		return
+	}
+	impl.logger.Infow("deleted preview environment", "previewId", preview.Id, "namespace", preview.Namespace)
+}
+
+// createPreviewNamespace creates the namespace of the preview labelled with the preview id, an existing namespace is
+// never taken over since it would be deleted with the preview
+func (impl *PreviewEnvironmentServiceImpl) createPreviewNamespace(clusterId int, preview *previewRepository.PreviewEnvironment) error {
+	client, err := impl.getCoreV1Client(clusterId)
+	if err != nil {
+		return err
+	}
+	_, exists, err := impl.K8sUtil.GetNsIfExists(preview.Namespace, client)
+	if err != nil {
+		impl.logger.Errorw("error in fetching preview namespace", "namespace", preview.Namespace, "err", err)
+		return err
+	}
+	if exists {
+		return util.NewApiError(http.StatusConflict, fmt.Sprintf("namespace %s already exists", preview.Namespace), "preview namespace already exists")
+	}
+	_, err = impl.K8sUtil.CreateNsWithLabels(preview.Namespace, map[string]string{bean.PreviewLabelKey: strconv.Itoa(preview.Id)}, client)
+	if err != nil {
+		impl.logger.Errorw("error in creating preview namespace", "namespace", preview.Namespace, "err", err)
+		return err
+	}
+	return nil
+}
+
+// deletePreviewResources deletes the pipeline, the environment and the namespace of the preview, clusterId is the
+// cluster of the namespace when the environment of the preview was not created
+func (impl *PreviewEnvironmentServiceImpl) deletePreviewResources(preview *previewRepository.PreviewEnvironment, clusterId int) error {
+	if preview.CdPipelineId > 0 {
+		cdPipeline, err := impl.pipelineRepository.FindById(preview.CdPipelineId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching preview pipeline", "pipelineId", preview.CdPipelineId, "err", err)
+			return err
+		}
+		if err == nil {
+			_, err = impl.cdPipelineConfigService.DeleteCdPipeline(cdPipeline, context.Background(), pkgBean.CASCADE_DELETE, true, userBean.SystemUserId)
+			if err != nil {
+				impl.logger.Errorw("error in deleting preview pipeline", "pipelineId", preview.CdPipelineId, "err", err)
+				return err
+			}
+		}
+	}
+	if preview.EnvironmentId > 0 {
+		environmentBean, err := impl.environmentService.FindById(preview.EnvironmentId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching preview environment", "envId", preview.EnvironmentId, "err", err)
+			return err
+		}
+		if err == nil {
+			err = impl.environmentService.Delete(environmentBean, userBean.SystemUserId)
+			if err != nil {
+				impl.logger.Errorw("error in deleting preview environment", "envId", preview.EnvironmentId, "err", err)
+				return err
+			}
+			clusterId = environmentBean.ClusterId
+		}
+	}
+	if clusterId == 0 {
+		return nil
+	}
+	return impl.deletePreviewNamespace(clusterId, preview)
+}
+
+// deletePreviewNamespace deletes the namespace only if it was created for this preview
+func (impl *PreviewEnvironmentServiceImpl) deletePreviewNamespace(clusterId int, preview *previewRepository.PreviewEnvironment) error {
+	client, err := impl.getCoreV1Client(clusterId)
+	if err != nil {
+		return err
+	}
+	namespace, exists, err := impl.K8sUtil.GetNsIfExists(preview.Namespace, client)
+	if err != nil {
+		impl.logger.Errorw("error in fetching preview namespace", "namespace", preview.Namespace, "err", err)
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	if namespace.Labels[bean.PreviewLabelKey] != strconv.Itoa(preview.Id) {
+		impl.logger.Warnw("namespace is not created for the preview, not deleting it", "previewId", preview.Id, "namespace", preview.Namespace, "labels", namespace.Labels)
+		return nil
+	}
+	err = impl.K8sUtil.DeleteNs(preview.Namespace, client)
+	if err != nil && !k8s.IsNotFoundError(err) {
+		impl.logger.Errorw("error in deleting preview namespace", "namespace", preview.Namespace, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) getCoreV1Client(clusterId int) (*v1.CoreV1Client, error) {
+	clusterBean, err := impl.clusterReadService.FindById(clusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster of preview", "clusterId", clusterId, "err", err)
+		return nil, err
+	}
+	client, err := impl.K8sUtil.GetCoreV1Client(clusterBean.GetClusterConfig())
+	if err != nil {
+		impl.logger.Errorw("error in getting k8s client of preview cluster", "clusterId", clusterId, "err", err)
+		return nil, err
+	}
+	return client, nil
+}
+
+func (impl *PreviewEnvironmentServiceImpl) ttl(template *previewRepository.PreviewEnvironmentTemplate) time.Duration {
+	ttlHours := template.TtlHours
+	if ttlHours <= 0 {
+		ttlHours = impl.config.DefaultTtlHours
+	}
+	return time.Duration(ttlHours) * time.Hour
+}
+
+func quotaReachedError(activeCount, maxActivePreviews int) error {
+	return util.NewApiError(http.StatusTooManyRequests, bean.QuotaReachedMessage,
+		fmt.Sprintf("%d of %d previews active", activeCount, maxActivePreviews))
+}
+
+func (impl *PreviewEnvironmentServiceImpl) reportPreview(appId int, appName string, pr *bean.PullRequest, state commitStatusBean.State, description, url string) {
+	impl.commitStatusService.ReportPreviewEnvironmentAsync(&commitStatusBean.PreviewStatusRequest{
+		AppId:       appId,
+		AppName:     appName,
+		RepoUrl:     pr.RepoUrl,
+		Sha:         pr.Commit,
+		State:       state,
+		Description: description,
+		TargetUrl:   url,
+	})
+}
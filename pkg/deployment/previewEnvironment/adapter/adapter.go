@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/repository"
+)
+
+func GetPreviewTemplateDto(template *repository.PreviewEnvironmentTemplate) *bean.PreviewTemplateDto {
+	return &bean.PreviewTemplateDto{
+		Id:                 template.Id,
+		AppId:              template.AppId,
+		TemplatePipelineId: template.TemplatePipelineId,
+		CiPipelineId:       template.CiPipelineId,
+		ClusterId:          template.ClusterId,
+		NamespaceTemplate:  template.NamespaceTemplate,
+		ValuesOverride:     template.ValuesOverride,
+		UrlTemplate:        template.UrlTemplate,
+		TtlHours:           template.TtlHours,
+		MaxActivePreviews:  template.MaxActivePreviews,
+	}
+}
+
+func GetPreviewEnvironmentDto(preview *repository.PreviewEnvironment) *bean.PreviewEnvironmentDto {
+	return &bean.PreviewEnvironmentDto{
+		Id:                     preview.Id,
+		TemplateId:             preview.TemplateId,
+		AppId:                  preview.AppId,
+		PrNumber:               preview.PrNumber,
+		PrUrl:                  preview.PrUrl,
+		PrTitle:                preview.PrTitle,
+		SourceBranch:           preview.SourceBranch,
+		CommitHash:             preview.CommitHash,
+		EnvironmentId:          preview.EnvironmentId,
+		CdPipelineId:           preview.CdPipelineId,
+		Namespace:              preview.Namespace,
+		Url:                    preview.Url,
+		Status:                 bean.PreviewStatus(preview.Status),
+		Message:                preview.Message,
+		LastDeployedArtifactId: preview.LastDeployedArtifactId,
+		ExpiresOn:              preview.ExpiresOn,
+		DeleteAfter:            preview.DeleteAfter,
+		CreatedOn:              preview.CreatedOn,
+	}
+}
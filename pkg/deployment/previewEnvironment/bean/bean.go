@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+// CATEGORY=PREVIEW_ENVIRONMENT
+type PreviewEnvironmentConfig struct {
+	DefaultTtlHours int    `env:"PREVIEW_ENV_DEFAULT_TTL_HOURS" envDefault:"72" description:"Hours a preview environment is kept after its last deployment, when the template does not set a ttl"`
+	DeleteGraceMins int    `env:"PREVIEW_ENV_DELETE_GRACE_MINS" envDefault:"15" description:"Minutes a preview environment stays hibernated before its pipeline, environment and namespace are deleted"`
+	CleanUpCron     string `env:"PREVIEW_ENVIRONMENT_CRON" envDefault:"@every 5m" description:"Cron at which the expired preview environments are hibernated and the hibernated ones past their grace period are deleted"`
+}
+
+type PreviewStatus string
+
+const (
+	PreviewStatusActive PreviewStatus = "ACTIVE"
+	// PreviewStatusHibernated is set once the pull request is closed or the preview expired, the preview is deleted
+	// after the grace period
+	PreviewStatusHibernated PreviewStatus = "HIBERNATED"
+	PreviewStatusDeleted    PreviewStatus = "DELETED"
+	// PreviewStatusFailed is set when the environment or the pipeline of the preview could not be created
+	PreviewStatusFailed PreviewStatus = "FAILED"
+)
+
+func (s PreviewStatus) String() string {
+	return string(s)
+}
+
+// LiveStatuses are the statuses of the previews owning an environment
+var LiveStatuses = []string{PreviewStatusActive.String(), PreviewStatusHibernated.String()}
+
+const (
+	DefaultNamespaceTemplate = "{{appName}}-pr-{{prNumber}}"
+	// MaxNamespaceLength is the max length of the namespace of an environment
+	MaxNamespaceLength = 50
+	// PreviewLabelKey labels the namespaces created for a preview with the id of the preview, a namespace is
+	// deleted with the preview only if it carries the label
+	PreviewLabelKey = "devtron.ai/preview-environment"
+	// PreviewQuotaLockKeyFormat is the advisory lock serializing the quota check of the previews of a template
+	PreviewQuotaLockKeyFormat = "preview-environment-quota-%d"
+)
+
+// placeholders of the namespace, url and values templates
+const (
+	PlaceholderAppName    = "{{appName}}"
+	PlaceholderPrNumber   = "{{prNumber}}"
+	PlaceholderBranch     = "{{branch}}"
+	PlaceholderBranchSlug = "{{branchSlug}}"
+	PlaceholderCommit     = "{{commit}}"
+	PlaceholderShortSha   = "{{shortSha}}"
+	PlaceholderNamespace  = "{{namespace}}"
+	PlaceholderUrl        = "{{url}}"
+)
+
+const (
+	ClosedByPullRequestMessage = "pull request closed"
+	ExpiredMessage             = "preview expired"
+	DeletedByUserMessage       = "preview deleted by user"
+	QuotaReachedMessage        = "max active previews of the app reached"
+	NamespaceTemplateMessage   = "namespace template must contain " + PlaceholderPrNumber + " so every pull request gets its own namespace"
+)
+
+type PreviewTemplateDto struct {
+	Id    int `json:"id"`
+	AppId int `json:"appId" validate:"number,required"`
+	// TemplatePipelineId is the cd pipeline cloned for every preview, it must be attached to the ci pipeline building
+	// the pull requests
+	TemplatePipelineId int    `json:"templatePipelineId" validate:"number,required"`
+	CiPipelineId       int    `json:"ciPipelineId"`
+	ClusterId          int    `json:"clusterId" validate:"number,required"`
+	NamespaceTemplate  string `json:"namespaceTemplate,omitempty" validate:"max=250"`
+	// ValuesOverride is a yaml or json patch of the deployment template values of the previews, its string values are
+	// templated with the pull request metadata
+	ValuesOverride string `json:"valuesOverride,omitempty"`
+	// UrlTemplate is the url the preview is reachable at, it is posted back on the pull request
+	UrlTemplate       string `json:"urlTemplate,omitempty"`
+	TtlHours          int    `json:"ttlHours" validate:"min=0"`
+	MaxActivePreviews int    `json:"maxActivePreviews" validate:"min=1"`
+	UserId            int32  `json:"-"`
+}
+
+type PreviewEnvironmentDto struct {
+	Id                     int           `json:"id"`
+	TemplateId             int           `json:"templateId"`
+	AppId                  int           `json:"appId"`
+	PrNumber               int           `json:"prNumber"`
+	PrUrl                  string        `json:"prUrl"`
+	PrTitle                string        `json:"prTitle,omitempty"`
+	SourceBranch           string        `json:"sourceBranch,omitempty"`
+	CommitHash             string        `json:"commitHash,omitempty"`
+	EnvironmentId          int           `json:"environmentId,omitempty"`
+	CdPipelineId           int           `json:"cdPipelineId,omitempty"`
+	Namespace              string        `json:"namespace"`
+	Url                    string        `json:"url,omitempty"`
+	Status                 PreviewStatus `json:"status"`
+	Message                string        `json:"message,omitempty"`
+	LastDeployedArtifactId int           `json:"lastDeployedArtifactId,omitempty"`
+	ExpiresOn              time.Time     `json:"expiresOn"`
+	DeleteAfter            *time.Time    `json:"deleteAfter,omitempty"`
+	CreatedOn              time.Time     `json:"createdOn"`
+}
+
+// PullRequest is the metadata of the pull request an artifact is built from
+type PullRequest struct {
+	Number       int
+	Url          string
+	Title        string
+	SourceBranch string
+	RepoUrl      string
+	Commit       string
+}
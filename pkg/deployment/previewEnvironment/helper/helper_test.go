@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrNumberFromUrl(t *testing.T) {
+	tests := []struct {
+		url    string
+		number int
+		ok     bool
+	}{
+		{url: "https://github.com/org/repo/pull/42", number: 42, ok: true},
+		{url: "https://gitlab.com/org/repo/-/merge_requests/7/", number: 7, ok: true},
+		{url: "https://bitbucket.org/org/repo/pull-requests/3", number: 3, ok: true},
+		{url: "https://github.com/org/repo", ok: false},
+		{url: "", ok: false},
+	}
+	for _, tt := range tests {
+		number, ok := PrNumberFromUrl(tt.url)
+		assert.Equal(t, tt.ok, ok, tt.url)
+		assert.Equal(t, tt.number, number, tt.url)
+	}
+}
+
+func TestPullRequestFromGitTriggers(t *testing.T) {
+	gitTriggers := map[int]pipelineConfig.GitCommit{
+		1: {Commit: "abc", CiConfigureSourceType: constants.SOURCE_TYPE_BRANCH_FIXED},
+		2: {
+			GitRepoUrl:            "https://github.com/org/repo.git",
+			CiConfigureSourceType: constants.SOURCE_TYPE_WEBHOOK,
+			WebhookData: pipelineConfig.WebhookData{Data: map[string]string{
+				"git url":            "https://github.com/org/repo/pull/42",
+				"header":             "Add login page",
+				"source branch name": "feature/login",
+				"source checkout":    "0123456789abcdef",
+			}},
+		},
+	}
+	pr, ok := PullRequestFromGitTriggers(gitTriggers)
+	assert.True(t, ok)
+	assert.Equal(t, &bean.PullRequest{
+		Number:       42,
+		Url:          "https://github.com/org/repo/pull/42",
+		Title:        "Add login page",
+		SourceBranch: "feature/login",
+		RepoUrl:      "https://github.com/org/repo.git",
+		Commit:       "0123456789abcdef",
+	}, pr)
+
+	_, ok = PullRequestFromGitTriggers(map[int]pipelineConfig.GitCommit{1: gitTriggers[1]})
+	assert.False(t, ok)
+}
+
+func TestClosedPullRequestUrl(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		payload   string
+		url       string
+		closed    bool
+	}{
+		{
+			name:      "github closed",
+			eventType: "pull_request",
+			payload:   `{"action":"closed","pull_request":{"html_url":"https://github.com/org/repo/pull/42"}}`,
+			url:       "https://github.com/org/repo/pull/42",
+			closed:    true,
+		},
+		{
+			name:      "github synchronize",
+			eventType: "pull_request",
+			payload:   `{"action":"synchronize","pull_request":{"html_url":"https://github.com/org/repo/pull/42"}}`,
+		},
+		{
+			name:      "gitlab merged",
+			eventType: "Merge Request Hook",
+			payload:   `{"object_attributes":{"action":"merge","url":"https://gitlab.com/org/repo/-/merge_requests/7"}}`,
+			url:       "https://gitlab.com/org/repo/-/merge_requests/7",
+			closed:    true,
+		},
+		{
+			name:      "bitbucket cloud declined",
+			eventType: "pullrequest:rejected",
+			payload:   `{"pullrequest":{"links":{"html":{"href":"https://bitbucket.org/org/repo/pull-requests/3"}}}}`,
+			url:       "https://bitbucket.org/org/repo/pull-requests/3",
+			closed:    true,
+		},
+		{
+			name:      "bitbucket server merged",
+			eventType: "pr:merged",
+			payload:   `{"pullRequest":{"links":{"self":[{"href":"https://bitbucket.example.com/projects/P/repos/repo/pull-requests/5"}]}}}`,
+			url:       "https://bitbucket.example.com/projects/P/repos/repo/pull-requests/5",
+			closed:    true,
+		},
+		{
+			name:    "azure devops abandoned",
+			payload: `{"eventType":"git.pullrequest.updated","resource":{"status":"abandoned","pullRequestId":9,"repository":{"webUrl":"https://dev.azure.com/org/project/_git/repo"}}}`,
+			url:     "https://dev.azure.com/org/project/_git/repo/pullrequest/9",
+			closed:  true,
+		},
+		{
+			name:      "push",
+			eventType: "push",
+			payload:   `{"ref":"refs/heads/main"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, closed := ClosedPullRequestUrl(tt.eventType, []byte(tt.payload))
+			assert.Equal(t, tt.closed, closed)
+			assert.Equal(t, tt.url, url)
+		})
+	}
+}
+
+func TestTemplateData(t *testing.T) {
+	data := &TemplateData{
+		AppName: "Payments",
+		PullRequest: &bean.PullRequest{
+			Number:       42,
+			SourceBranch: "feature/Login_Page",
+			Commit:       "0123456789abcdef",
+		},
+	}
+	data.Namespace = data.RenderNamespace("")
+	assert.Equal(t, "payments-pr-42", data.Namespace)
+	data.Url = data.Render("https://pr-{{prNumber}}.preview.example.com")
+	assert.Equal(t, "https://pr-42.preview.example.com", data.Url)
+
+	values, err := data.RenderValues(`
+ingress:
+  hosts:
+    - host: "{{branchSlug}}.preview.example.com"
+env:
+  BRANCH: "{{branch}}"
+  SHA: "{{shortSha}}"
+replicaCount: 1
+`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ingress":{"hosts":[{"host":"feature-login-page.preview.example.com"}]},"env":{"BRANCH":"feature/Login_Page","SHA":"0123456"},"replicaCount":1}`, string(values))
+
+	assert.Error(t, ValidateValues("- a\n- b"))
+	assert.NoError(t, ValidateValues("a: b"))
+}
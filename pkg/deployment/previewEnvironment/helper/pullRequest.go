@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	pkgBean "github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+)
+
+var prNumberRegex = regexp.MustCompile(`/(\d+)/?$`)
+
+// PrNumberFromUrl extracts the number of the pull request from its web url, e.g. https://github.com/org/repo/pull/42,
+// https://gitlab.com/org/repo/-/merge_requests/42 or https://bitbucket.org/org/repo/pull-requests/42
+func PrNumberFromUrl(prUrl string) (int, bool) {
+	matches := prNumberRegex.FindStringSubmatch(strings.TrimSpace(prUrl))
+	if len(matches) != 2 {
+		return 0, false
+	}
+	number, err := strconv.Atoi(matches[1])
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+	return number, true
+}
+
+// NormalizePrUrl makes the urls of a pull request received from the git sensor and from the git host comparable
+func NormalizePrUrl(prUrl string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(prUrl), "/"))
+}
+
+// PullRequestFromGitTriggers returns the pull request a ci workflow was triggered for, false is returned for the
+// workflows not triggered by a pull request webhook
+func PullRequestFromGitTriggers(gitTriggers map[int]pipelineConfig.GitCommit) (*bean.PullRequest, bool) {
+	materialIds := make([]int, 0, len(gitTriggers))
+	for materialId := range gitTriggers {
+		materialIds = append(materialIds, materialId)
+	}
+	sort.Ints(materialIds)
+	for _, materialId := range materialIds {
+		gitCommit := gitTriggers[materialId]
+		if gitCommit.CiConfigureSourceType != constants.SOURCE_TYPE_WEBHOOK {
+			continue
+		}
+		data := gitCommit.WebhookData.Data
+		prUrl := data[pkgBean.WEBHOOK_SELECTOR_GIT_URL_NAME]
+		number, ok := PrNumberFromUrl(prUrl)
+		if !ok {
+			continue
+		}
+		commit := data[pkgBean.WEBHOOK_SELECTOR_SOURCE_CHECKOUT_NAME]
+		if len(commit) == 0 {
+			commit = gitCommit.Commit
+		}
+		return &bean.PullRequest{
+			Number:       number,
+			Url:          prUrl,
+			Title:        data[pkgBean.WEBHOOK_SELECTOR_HEADER_NAME],
+			SourceBranch: data[pkgBean.WEBHOOK_SELECTOR_SOURCE_BRANCH_NAME_NAME],
+			RepoUrl:      gitCommit.GitRepoUrl,
+			Commit:       commit,
+		}, true
+	}
+	return nil, false
+}
+
+// the subset of the pull request events of the git hosts needed to know if a pull request was closed
+type pullRequestEvent struct {
+	// GitHub
+	Action      string `json:"action"`
+	PullRequest struct {
+		HtmlUrl string `json:"html_url"`
+	} `json:"pull_request"`
+	// GitLab
+	ObjectAttributes struct {
+		Action string `json:"action"`
+		Url    string `json:"url"`
+	} `json:"object_attributes"`
+	// Bitbucket cloud
+	BitbucketPullRequest struct {
+		Links struct {
+			Html struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	} `json:"pullrequest"`
+	// Bitbucket server
+	BitbucketServerPullRequest struct {
+		Links struct {
+			Self []struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	} `json:"pullRequest"`
+	// Azure DevOps
+	EventType string `json:"eventType"`
+	Resource  struct {
+		Status        string `json:"status"`
+		PullRequestId int    `json:"pullRequestId"`
+		Repository    struct {
+			WebUrl string `json:"webUrl"`
+		} `json:"repository"`
+	} `json:"resource"`
+}
+
+// ClosedPullRequestUrl returns the web url of the pull request closed (merged or declined) by a git host webhook
+// event, false is returned for all other events
+func ClosedPullRequestUrl(eventType string, payload []byte) (string, bool) {
+	event := &pullRequestEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return "", false
+	}
+	switch {
+	case eventType == "pull_request":
+		if event.Action == "closed" && len(event.PullRequest.HtmlUrl) > 0 {
+			return event.PullRequest.HtmlUrl, true
+		}
+	case eventType == "Merge Request Hook":
+		action := event.ObjectAttributes.Action
+		if (action == "close" || action == "merge") && len(event.ObjectAttributes.Url) > 0 {
+			return event.ObjectAttributes.Url, true
+		}
+	case eventType == "pullrequest:fulfilled" || eventType == "pullrequest:rejected":
+		if href := event.BitbucketPullRequest.Links.Html.Href; len(href) > 0 {
+			return href, true
+		}
+	case eventType == "pr:merged" || eventType == "pr:declined" || eventType == "pr:deleted":
+		if self := event.BitbucketServerPullRequest.Links.Self; len(self) > 0 && len(self[0].Href) > 0 {
+			return self[0].Href, true
+		}
+	case event.EventType == "git.pullrequest.updated" || event.EventType == "git.pullrequest.merged":
+		// azure devops does not send an event type header, the type is a part of the payload
+		status := event.Resource.Status
+		if (status == "completed" || status == "abandoned") && event.Resource.PullRequestId > 0 && len(event.Resource.Repository.WebUrl) > 0 {
+			return strings.TrimSuffix(event.Resource.Repository.WebUrl, "/") + "/pullrequest/" + strconv.Itoa(event.Resource.PullRequestId), true
+		}
+	}
+	return "", false
+}
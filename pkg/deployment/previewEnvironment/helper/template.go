@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"sigs.k8s.io/yaml"
+)
+
+var nonDnsCharRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Slug converts the value to a dns-1123 label of at most maxLength characters
+func Slug(value string, maxLength int) string {
+	slug := nonDnsCharRegex.ReplaceAllString(strings.ToLower(value), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > maxLength {
+		slug = strings.TrimRight(slug[:maxLength], "-")
+	}
+	return slug
+}
+
+// TemplateData is the pull request metadata substituted in the templates of a preview
+type TemplateData struct {
+	AppName     string
+	PullRequest *bean.PullRequest
+	Namespace   string
+	Url         string
+}
+
+func (data *TemplateData) replacer() *strings.Replacer {
+	pr := data.PullRequest
+	shortSha := pr.Commit
+	if len(shortSha) > 7 {
+		shortSha = shortSha[:7]
+	}
+	return strings.NewReplacer(
+		bean.PlaceholderAppName, data.AppName,
+		bean.PlaceholderPrNumber, strconv.Itoa(pr.Number),
+		bean.PlaceholderBranch, pr.SourceBranch,
+		bean.PlaceholderBranchSlug, Slug(pr.SourceBranch, 63),
+		bean.PlaceholderCommit, pr.Commit,
+		bean.PlaceholderShortSha, shortSha,
+		bean.PlaceholderNamespace, data.Namespace,
+		bean.PlaceholderUrl, data.Url,
+	)
+}
+
+// Render substitutes the placeholders of the template
+func (data *TemplateData) Render(template string) string {
+	return data.replacer().Replace(template)
+}
+
+// RenderNamespace renders the namespace template of a preview into a valid namespace
+func (data *TemplateData) RenderNamespace(template string) string {
+	if len(strings.TrimSpace(template)) == 0 {
+		template = bean.DefaultNamespaceTemplate
+	}
+	return Slug(data.Render(template), bean.MaxNamespaceLength)
+}
+
+// RenderValues renders the yaml or json values override of a preview into the json patch of the deployment. Only the
+// string values are templated, so the pull request metadata can not change the structure of the values.
+func (data *TemplateData) RenderValues(values string) (json.RawMessage, error) {
+	if len(strings.TrimSpace(values)) == 0 {
+		return nil, nil
+	}
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(values), &parsed); err != nil {
+		return nil, err
+	}
+	rendered, err := json.Marshal(renderStrings(parsed, data.replacer()))
+	if err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func renderStrings(value interface{}, replacer *strings.Replacer) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return replacer.Replace(typed)
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = renderStrings(item, replacer)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = renderStrings(item, replacer)
+		}
+		return typed
+	}
+	return value
+}
+
+// ValidateValues checks that the values override of a template is a yaml or json object
+func ValidateValues(values string) error {
+	if len(strings.TrimSpace(values)) == 0 {
+		return nil
+	}
+	var parsed map[string]interface{}
+	return yaml.Unmarshal([]byte(values), &parsed)
+}
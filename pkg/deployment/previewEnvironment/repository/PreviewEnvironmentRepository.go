@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type PreviewEnvironment struct {
+	tableName              struct{}   `sql:"preview_environment" pg:",discard_unknown_columns"`
+	Id                     int        `sql:"id,pk"`
+	TemplateId             int        `sql:"template_id,notnull"`
+	AppId                  int        `sql:"app_id,notnull"`
+	PrNumber               int        `sql:"pr_number,notnull"`
+	PrUrl                  string     `sql:"pr_url,notnull"`
+	PrTitle                string     `sql:"pr_title"`
+	SourceBranch           string     `sql:"source_branch"`
+	RepoUrl                string     `sql:"repo_url"`
+	CommitHash             string     `sql:"commit_hash"`
+	EnvironmentId          int        `sql:"environment_id"`
+	CdPipelineId           int        `sql:"cd_pipeline_id"`
+	Namespace              string     `sql:"namespace,notnull"`
+	Url                    string     `sql:"url"`
+	Status                 string     `sql:"status,notnull"`
+	Message                string     `sql:"message"`
+	LastDeployedArtifactId int        `sql:"last_deployed_artifact_id"`
+	ExpiresOn              time.Time  `sql:"expires_on,notnull"`
+	DeleteAfter            *time.Time `sql:"delete_after"`
+	sql.AuditLog
+}
+
+type PreviewEnvironmentRepository interface {
+	// SaveIfUnderQuota saves the active preview only if less than maxActive previews of its template are active. The
+	// count and the insert are serialized per template, so concurrent builds of pull requests can not exceed the quota.
+	SaveIfUnderQuota(preview *PreviewEnvironment, maxActive int) (saved bool, activeCount int, err error)
+	// ReactivateIfUnderQuota moves the hibernated preview back to active under the same quota and lock as SaveIfUnderQuota
+	ReactivateIfUnderQuota(preview *PreviewEnvironment, maxActive int) (reactivated bool, activeCount int, err error)
+	Update(preview *PreviewEnvironment) error
+	// UpdateIfStatus updates the preview only if its status is still fromStatus, so a preview is moved to the next
+	// status by a single replica. false is returned if the status was changed in between.
+	UpdateIfStatus(preview *PreviewEnvironment, fromStatus bean.PreviewStatus) (bool, error)
+	FindById(id int) (*PreviewEnvironment, error)
+	FindByAppId(appId int) ([]*PreviewEnvironment, error)
+	// FindLiveByTemplateIdAndPrNumber returns the active or hibernated preview of the pull request
+	FindLiveByTemplateIdAndPrNumber(templateId, prNumber int) (*PreviewEnvironment, error)
+	FindActiveByPrUrl(prUrl string) ([]*PreviewEnvironment, error)
+	FindLiveByTemplateId(templateId int) ([]*PreviewEnvironment, error)
+	FindActiveExpiredBefore(time time.Time) ([]*PreviewEnvironment, error)
+	FindHibernatedToDeleteBefore(time time.Time) ([]*PreviewEnvironment, error)
+}
+
+type PreviewEnvironmentRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewPreviewEnvironmentRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *PreviewEnvironmentRepositoryImpl {
+	return &PreviewEnvironmentRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) SaveIfUnderQuota(preview *PreviewEnvironment, maxActive int) (saved bool, activeCount int, err error) {
+	err = impl.runInQuotaLock(preview.TemplateId, func(tx *pg.Tx) error {
+		activeCount, err = countActiveByTemplateId(tx, preview.TemplateId)
+		if err != nil || activeCount >= maxActive {
+			return err
+		}
+		if err = tx.Insert(preview); err != nil {
+			return err
+		}
+		saved = true
+		return nil
+	})
+	return saved, activeCount, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) ReactivateIfUnderQuota(preview *PreviewEnvironment, maxActive int) (reactivated bool, activeCount int, err error) {
+	err = impl.runInQuotaLock(preview.TemplateId, func(tx *pg.Tx) error {
+		activeCount, err = countActiveByTemplateId(tx, preview.TemplateId)
+		if err != nil || activeCount >= maxActive {
+			return err
+		}
+		res, err := tx.Model(preview).
+			WherePK().
+			Where("status = ?", bean.PreviewStatusHibernated.String()).
+			Update()
+		if err != nil {
+			return err
+		}
+		reactivated = res.RowsAffected() == 1
+		return nil
+	})
+	return reactivated, activeCount, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) runInQuotaLock(templateId int, fn func(tx *pg.Tx) error) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		_, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf(bean.PreviewQuotaLockKeyFormat, templateId))
+		if err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+func countActiveByTemplateId(tx *pg.Tx, templateId int) (int, error) {
+	return tx.Model((*PreviewEnvironment)(nil)).
+		Where("template_id = ?", templateId).
+		Where("status = ?", bean.PreviewStatusActive.String()).
+		Count()
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) Update(preview *PreviewEnvironment) error {
+	return impl.dbConnection.Update(preview)
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) UpdateIfStatus(preview *PreviewEnvironment, fromStatus bean.PreviewStatus) (bool, error) {
+	res, err := impl.dbConnection.Model(preview).
+		WherePK().
+		Where("status = ?", fromStatus.String()).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindById(id int) (*PreviewEnvironment, error) {
+	preview := &PreviewEnvironment{}
+	err := impl.dbConnection.Model(preview).
+		Where("id = ?", id).
+		Select()
+	return preview, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindByAppId(appId int) ([]*PreviewEnvironment, error) {
+	var previews []*PreviewEnvironment
+	err := impl.dbConnection.Model(&previews).
+		Where("app_id = ?", appId).
+		Order("id DESC").
+		Select()
+	return previews, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindLiveByTemplateIdAndPrNumber(templateId, prNumber int) (*PreviewEnvironment, error) {
+	preview := &PreviewEnvironment{}
+	err := impl.dbConnection.Model(preview).
+		Where("template_id = ?", templateId).
+		Where("pr_number = ?", prNumber).
+		Where("status IN (?)", pg.In(bean.LiveStatuses)).
+		Select()
+	return preview, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindActiveByPrUrl(prUrl string) ([]*PreviewEnvironment, error) {
+	var previews []*PreviewEnvironment
+	err := impl.dbConnection.Model(&previews).
+		Where("pr_url = ?", prUrl).
+		Where("status = ?", bean.PreviewStatusActive.String()).
+		Select()
+	return previews, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindLiveByTemplateId(templateId int) ([]*PreviewEnvironment, error) {
+	var previews []*PreviewEnvironment
+	err := impl.dbConnection.Model(&previews).
+		Where("template_id = ?", templateId).
+		Where("status IN (?)", pg.In(bean.LiveStatuses)).
+		Select()
+	return previews, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindActiveExpiredBefore(time time.Time) ([]*PreviewEnvironment, error) {
+	var previews []*PreviewEnvironment
+	err := impl.dbConnection.Model(&previews).
+		Where("status = ?", bean.PreviewStatusActive.String()).
+		Where("expires_on < ?", time).
+		Order("id ASC").
+		Select()
+	return previews, err
+}
+
+func (impl *PreviewEnvironmentRepositoryImpl) FindHibernatedToDeleteBefore(time time.Time) ([]*PreviewEnvironment, error) {
+	var previews []*PreviewEnvironment
+	err := impl.dbConnection.Model(&previews).
+		Where("status = ?", bean.PreviewStatusHibernated.String()).
+		Where("delete_after < ?", time).
+		Order("id ASC").
+		Select()
+	return previews, err
+}
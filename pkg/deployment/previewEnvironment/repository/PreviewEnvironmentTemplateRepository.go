@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type PreviewEnvironmentTemplate struct {
+	tableName          struct{} `sql:"preview_environment_template" pg:",discard_unknown_columns"`
+	Id                 int      `sql:"id,pk"`
+	AppId              int      `sql:"app_id,notnull"`
+	TemplatePipelineId int      `sql:"template_pipeline_id,notnull"`
+	CiPipelineId       int      `sql:"ci_pipeline_id,notnull"`
+	ClusterId          int      `sql:"cluster_id,notnull"`
+	NamespaceTemplate  string   `sql:"namespace_template,notnull"`
+	ValuesOverride     string   `sql:"values_override"`
+	UrlTemplate        string   `sql:"url_template"`
+	TtlHours           int      `sql:"ttl_hours,notnull"`
+	MaxActivePreviews  int      `sql:"max_active_previews,notnull"`
+	Active             bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type PreviewEnvironmentTemplateRepository interface {
+	Save(template *PreviewEnvironmentTemplate) error
+	Update(template *PreviewEnvironmentTemplate) error
+	FindById(id int) (*PreviewEnvironmentTemplate, error)
+	FindActiveByAppId(appId int) (*PreviewEnvironmentTemplate, error)
+	FindActiveByCiPipelineId(ciPipelineId int) (*PreviewEnvironmentTemplate, error)
+}
+
+type PreviewEnvironmentTemplateRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewPreviewEnvironmentTemplateRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *PreviewEnvironmentTemplateRepositoryImpl {
+	return &PreviewEnvironmentTemplateRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *PreviewEnvironmentTemplateRepositoryImpl) Save(template *PreviewEnvironmentTemplate) error {
+	return impl.dbConnection.Insert(template)
+}
+
+func (impl *PreviewEnvironmentTemplateRepositoryImpl) Update(template *PreviewEnvironmentTemplate) error {
+	return impl.dbConnection.Update(template)
+}
+
+func (impl *PreviewEnvironmentTemplateRepositoryImpl) FindById(id int) (*PreviewEnvironmentTemplate, error) {
+	template := &PreviewEnvironmentTemplate{}
+	err := impl.dbConnection.Model(template).
+		Where("id = ?", id).
+		Select()
+	return template, err
+}
+
+func (impl *PreviewEnvironmentTemplateRepositoryImpl) FindActiveByAppId(appId int) (*PreviewEnvironmentTemplate, error) {
+	template := &PreviewEnvironmentTemplate{}
+	err := impl.dbConnection.Model(template).
+		Where("app_id = ?", appId).
+		Where("active = ?", true).
+		Select()
+	return template, err
+}
+
+func (impl *PreviewEnvironmentTemplateRepositoryImpl) FindActiveByCiPipelineId(ciPipelineId int) (*PreviewEnvironmentTemplate, error) {
+	template := &PreviewEnvironmentTemplate{}
+	err := impl.dbConnection.Model(template).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Where("active = ?", true).
+		Limit(1).
+		Select()
+	return template, err
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package previewEnvironment
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/repository"
+	"github.com/google/wire"
+)
+
+var PreviewEnvironmentWireSet = wire.NewSet(
+	repository.NewPreviewEnvironmentTemplateRepositoryImpl,
+	wire.Bind(new(repository.PreviewEnvironmentTemplateRepository), new(*repository.PreviewEnvironmentTemplateRepositoryImpl)),
+	repository.NewPreviewEnvironmentRepositoryImpl,
+	wire.Bind(new(repository.PreviewEnvironmentRepository), new(*repository.PreviewEnvironmentRepositoryImpl)),
+	NewPreviewEnvironmentServiceImpl,
+	wire.Bind(new(PreviewEnvironmentService), new(*PreviewEnvironmentServiceImpl)),
+)
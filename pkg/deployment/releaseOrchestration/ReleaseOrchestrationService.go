@@ -0,0 +1,719 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package releaseOrchestration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/repository"
+	releaseUtil "github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	leaderElectionBean "github.com/devtron-labs/devtron/pkg/leaderElection/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// healthyDeploymentStatuses complete an item of a release, the release moves on once all the items of a stage have one
+var healthyDeploymentStatuses = []string{string(health.HealthStatusHealthy), cdWorkflow.WorkflowSucceeded}
+
+// failedDeploymentStatuses fail an item right away, a degraded deployment can still recover within the health timeout
+var failedDeploymentStatuses = []string{cdWorkflow.WorkflowFailed, cdWorkflow.WorkflowAborted, cdWorkflow.WorkflowTimedOut, cdWorkflow.WorkflowCancel}
+
+type ReleaseOrchestrationService interface {
+	GetReleases() ([]*bean.ReleaseDto, error)
+	GetRelease(id int) (*bean.ReleaseDto, error)
+	// CreateRelease validates the items and their dependencies and saves the release, it is deployed once started
+	CreateRelease(request *bean.ReleaseDto) (*bean.ReleaseDto, error)
+	// StartRelease deploys the first stage of the release as the user, the next stages follow as the previous ones become healthy
+	StartRelease(id int, userId int32) (*bean.ReleaseDto, error)
+	// ResumeRelease redeploys the failed items of the stage the release is paused at and continues the release
+	ResumeRelease(id int, userId int32) (*bean.ReleaseDto, error)
+	// RollbackRelease redeploys the last healthy deployment before the release of every item deployed by it, the
+	// later stages first
+	RollbackRelease(id int, userId int32) (*bean.ReleaseDto, error)
+	GetTimeline(id int) (*bean.ReleaseTimelineDto, error)
+}
+
+type ReleaseOrchestrationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	config                         *bean.ReleaseOrchestrationConfig
+	releaseOrchestrationRepository repository.ReleaseOrchestrationRepository
+	releaseEventRepository         repository.ReleaseOrchestrationEventRepository
+	pipelineRepository             pipelineConfig.PipelineRepository
+	ciArtifactRepository           repository2.CiArtifactRepository
+	cdWorkflowRepository           pipelineConfig.CdWorkflowRepository
+	cdHandlerService               devtronApps.HandlerService
+	leaderElectionService          leaderElection.LeaderElectionService
+	asyncRunnable                  *async.Runnable
+}
+
+func NewReleaseOrchestrationServiceImpl(logger *zap.SugaredLogger,
+	releaseOrchestrationRepository repository.ReleaseOrchestrationRepository,
+	releaseEventRepository repository.ReleaseOrchestrationEventRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	cdHandlerService devtronApps.HandlerService,
+	leaderElectionService leaderElection.LeaderElectionService,
+	asyncRunnable *async.Runnable,
+	cronLogger *cron2.CronLoggerImpl) (*ReleaseOrchestrationServiceImpl, error) {
+	config := &bean.ReleaseOrchestrationConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing release orchestration config", "err", err)
+		return nil, err
+	}
+	impl := &ReleaseOrchestrationServiceImpl{
+		logger:                         logger,
+		config:                         config,
+		releaseOrchestrationRepository: releaseOrchestrationRepository,
+		releaseEventRepository:         releaseEventRepository,
+		pipelineRepository:             pipelineRepository,
+		ciArtifactRepository:           ciArtifactRepository,
+		cdWorkflowRepository:           cdWorkflowRepository,
+		cdHandlerService:               cdHandlerService,
+		leaderElectionService:          leaderElectionService,
+		asyncRunnable:                  asyncRunnable,
+	}
+	if !config.Enabled {
+		return impl, nil
+	}
+	// the health of the deployments is only known by polling, every replica polls and only the leader progresses
+	progressCron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	progressCron.Start()
+	_, err = progressCron.AddFunc(config.ProgressCron, impl.progressActiveReleases)
+	if err != nil {
+		logger.Errorw("error while configure cron job for release orchestration", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) GetReleases() ([]*bean.ReleaseDto, error) {
+	releases, err := impl.releaseOrchestrationRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching releases", "err", err)
+		return nil, err
+	}
+	releaseIds := make([]int, 0, len(releases))
+	for _, release := range releases {
+		releaseIds = append(releaseIds, release.Id)
+	}
+	items, err := impl.releaseOrchestrationRepository.FindItemsByReleaseIds(releaseIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching release items", "releaseIds", releaseIds, "err", err)
+		return nil, err
+	}
+	itemsByReleaseId := make(map[int][]*repository.ReleaseOrchestrationItem, len(releases))
+	for _, item := range items {
+		itemsByReleaseId[item.ReleaseId] = append(itemsByReleaseId[item.ReleaseId], item)
+	}
+	res := make([]*bean.ReleaseDto, 0, len(releases))
+	for _, release := range releases {
+		res = append(res, adapter.GetReleaseDto(release, itemsByReleaseId[release.Id]))
+	}
+	err = impl.fillItemDetails(res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) GetRelease(id int) (*bean.ReleaseDto, error) {
+	release, err := impl.findRelease(id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := impl.releaseOrchestrationRepository.FindItemsByReleaseId(release.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching release items", "releaseId", release.Id, "err", err)
+		return nil, err
+	}
+	res := adapter.GetReleaseDto(release, items)
+	err = impl.fillItemDetails([]*bean.ReleaseDto{res})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) CreateRelease(request *bean.ReleaseDto) (*bean.ReleaseDto, error) {
+	if request.HealthTimeoutMins == 0 {
+		request.HealthTimeoutMins = impl.config.DefaultHealthTimeoutMins
+	}
+	if request.HealthTimeoutMins > bean.MaxHealthTimeoutMins {
+		return nil, util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("health timeout should not be more than %d minutes", bean.MaxHealthTimeoutMins), "invalid health timeout")
+	}
+	dependencies := make(map[string][]string, len(request.Items))
+	pipelineIds := make([]int, 0, len(request.Items))
+	artifactIds := make([]int, 0, len(request.Items))
+	for _, item := range request.Items {
+		item.Key = strings.TrimSpace(item.Key)
+		if _, ok := dependencies[item.Key]; ok {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("item key %q is repeated", item.Key), "duplicate item key")
+		}
+		dependencies[item.Key] = item.DependsOn
+		pipelineIds = append(pipelineIds, item.PipelineId)
+		artifactIds = append(artifactIds, item.CiArtifactId)
+	}
+	stages, err := releaseUtil.GetStages(dependencies)
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	pipelines, err := impl.pipelineRepository.FindByIdsIn(pipelineIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching cd pipelines", "pipelineIds", pipelineIds, "err", err)
+		return nil, err
+	}
+	pipelineById := make(map[int]*pipelineConfig.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelineById[pipeline.Id] = pipeline
+	}
+	artifacts, err := impl.ciArtifactRepository.GetByIds(artifactIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching artifacts", "artifactIds", artifactIds, "err", err)
+		return nil, err
+	}
+	artifactIdSet := make(map[int]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		artifactIdSet[artifact.Id] = true
+	}
+	items := make([]*repository.ReleaseOrchestrationItem, 0, len(request.Items))
+	deployedPipelines := make(map[int]string, len(request.Items))
+	for _, item := range request.Items {
+		pipeline, ok := pipelineById[item.PipelineId]
+		if !ok {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("pipeline %d of item %q not found", item.PipelineId, item.Key), "pipeline not found")
+		}
+		if otherKey, ok := deployedPipelines[item.PipelineId]; ok {
+			return nil, util.NewApiError(http.StatusBadRequest,
+				fmt.Sprintf("items %q and %q deploy the same pipeline %d", otherKey, item.Key, item.PipelineId), "pipeline repeated in release")
+		}
+		deployedPipelines[item.PipelineId] = item.Key
+		if !artifactIdSet[item.CiArtifactId] {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("artifact %d of item %q not found", item.CiArtifactId, item.Key), "artifact not found")
+		}
+		items = append(items, &repository.ReleaseOrchestrationItem{
+			ItemKey:       item.Key,
+			PipelineId:    pipeline.Id,
+			AppId:         pipeline.AppId,
+			EnvironmentId: pipeline.EnvironmentId,
+			CiArtifactId:  item.CiArtifactId,
+			DependsOn:     item.DependsOn,
+			Stage:         stages[item.Key],
+			Status:        bean.ItemStatusPending.String(),
+		})
+	}
+	release := &repository.ReleaseOrchestration{
+		Name:              strings.TrimSpace(request.Name),
+		Description:       request.Description,
+		Status:            bean.ReleaseStatusCreated.String(),
+		HealthTimeoutMins: request.HealthTimeoutMins,
+		Active:            true,
+		AuditLog:          sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.releaseOrchestrationRepository.SaveWithItems(release, items)
+	if err != nil {
+		impl.logger.Errorw("error in saving release", "request", request, "err", err)
+		return nil, err
+	}
+	impl.saveEvent(release.Id, 0, bean.EventTypeCreated, "", request.UserId)
+	return impl.GetRelease(release.Id)
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) StartRelease(id int, userId int32) (*bean.ReleaseDto, error) {
+	release, err := impl.findRelease(id)
+	if err != nil {
+		return nil, err
+	}
+	if release.Status != bean.ReleaseStatusCreated.String() {
+		return nil, util.NewApiError(http.StatusConflict, fmt.Sprintf("release is %s, only a created release can be started", release.Status), "release already started")
+	}
+	now := time.Now()
+	release.Status = bean.ReleaseStatusRunning.String()
+	release.TriggeredBy = userId
+	release.StartedOn = &now
+	release.UpdateAuditLog(userId)
+	err = impl.transitionRelease(release, bean.ReleaseStatusCreated, release.CurrentStage)
+	if err != nil {
+		return nil, err
+	}
+	impl.saveEvent(release.Id, 0, bean.EventTypeStarted, "", userId)
+	impl.asyncRunnable.Execute(func() {
+		impl.progressRelease(release)
+	})
+	return impl.GetRelease(release.Id)
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) ResumeRelease(id int, userId int32) (*bean.ReleaseDto, error) {
+	release, err := impl.findRelease(id)
+	if err != nil {
+		return nil, err
+	}
+	if release.Status != bean.ReleaseStatusPaused.String() {
+		return nil, util.NewApiError(http.StatusConflict, fmt.Sprintf("release is %s, only a paused release can be resumed", release.Status), "release not paused")
+	}
+	items, err := impl.releaseOrchestrationRepository.FindItemsByReleaseId(release.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching release items", "releaseId", release.Id, "err", err)
+		return nil, err
+	}
+	// the failed items are reset along with the transition, a running release with failed items is paused again
+	failedItems := make([]*repository.ReleaseOrchestrationItem, 0)
+	for _, item := range items {
+		if item.Stage != release.CurrentStage || item.Status != bean.ItemStatusFailed.String() {
+			continue
+		}
+		item.Status = bean.ItemStatusPending.String()
+		item.Message = ""
+		item.FinishedOn = nil
+		failedItems = append(failedItems, item)
+	}
+	release.Status = bean.ReleaseStatusRunning.String()
+	release.TriggeredBy = userId
+	release.UpdateAuditLog(userId)
+	resumed, err := impl.releaseOrchestrationRepository.UpdateWithItemsIfInState(release, bean.ReleaseStatusPaused.String(), release.CurrentStage,
+		failedItems, bean.ItemStatusFailed.String())
+	if err != nil {
+		impl.logger.Errorw("error in resuming release", "releaseId", release.Id, "err", err)
+		return nil, err
+	}
+	if !resumed {
+		return nil, util.NewApiError(http.StatusConflict, "release was updated in between, please retry", "release state changed")
+	}
+	impl.saveEvent(release.Id, 0, bean.EventTypeResumed, "", userId)
+	impl.asyncRunnable.Execute(func() {
+		impl.progressRelease(release)
+	})
+	return impl.GetRelease(release.Id)
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) RollbackRelease(id int, userId int32) (*bean.ReleaseDto, error) {
+	release, err := impl.findRelease(id)
+	if err != nil {
+		return nil, err
+	}
+	fromStatus := bean.ReleaseStatus(release.Status)
+	if fromStatus != bean.ReleaseStatusPaused && fromStatus != bean.ReleaseStatusSucceeded {
+		return nil, util.NewApiError(http.StatusConflict, fmt.Sprintf("release is %s, only a paused or succeeded release can be rolled back", release.Status), "release can not be rolled back")
+	}
+	release.Status = bean.ReleaseStatusRollingBack.String()
+	release.UpdateAuditLog(userId)
+	err = impl.transitionRelease(release, fromStatus, release.CurrentStage)
+	if err != nil {
+		return nil, err
+	}
+	impl.saveEvent(release.Id, 0, bean.EventTypeRollbackStarted, "", userId)
+	impl.asyncRunnable.Execute(func() {
+		impl.rollbackRelease(release)
+	})
+	return impl.GetRelease(release.Id)
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) GetTimeline(id int) (*bean.ReleaseTimelineDto, error) {
+	release, err := impl.GetRelease(id)
+	if err != nil {
+		return nil, err
+	}
+	events, err := impl.releaseEventRepository.FindByReleaseId(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching release events", "releaseId", id, "err", err)
+		return nil, err
+	}
+	itemKeys := make(map[int]string, len(release.Items))
+	for _, item := range release.Items {
+		itemKeys[item.Id] = item.Key
+	}
+	eventDtos := make([]*bean.ReleaseEventDto, 0, len(events))
+	for _, event := range events {
+		eventDtos = append(eventDtos, adapter.GetReleaseEventDto(event, itemKeys[event.ItemId]))
+	}
+	return &bean.ReleaseTimelineDto{Release: release, Events: eventDtos}, nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) progressActiveReleases() {
+	if !impl.leaderElectionService.IsLeader(leaderElectionBean.ReleaseOrchestrator) {
+		return
+	}
+	releases, err := impl.releaseOrchestrationRepository.FindActiveByStatuses([]string{bean.ReleaseStatusRunning.String(), bean.ReleaseStatusRollingBack.String()})
+	if err != nil {
+		impl.logger.Errorw("error in fetching active releases", "err", err)
+		return
+	}
+	for _, release := range releases {
+		if release.Status == bean.ReleaseStatusRollingBack.String() {
+			// picks up the rollbacks interrupted by a restart
+			impl.rollbackRelease(release)
+			continue
+		}
+		impl.progressRelease(release)
+	}
+}
+
+// progressRelease deploys the pending items of the current stage of a running release and checks the health of the
+// deploying ones. The release is paused on the first failed item, and moves to the next stage once all are healthy.
+func (impl *ReleaseOrchestrationServiceImpl) progressRelease(release *repository.ReleaseOrchestration) {
+	for {
+		items, err := impl.releaseOrchestrationRepository.FindItemsByReleaseId(release.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching release items", "releaseId", release.Id, "err", err)
+			return
+		}
+		stageCount := 0
+		for _, item := range items {
+			if item.Stage+1 > stageCount {
+				stageCount = item.Stage + 1
+			}
+		}
+		stage := release.CurrentStage
+		healthyCount, failedCount, stageItemCount := 0, 0, 0
+		for _, item := range items {
+			if item.Stage != stage {
+				continue
+			}
+			stageItemCount++
+			switch bean.ItemStatus(item.Status) {
+			case bean.ItemStatusPending:
+				impl.triggerItem(release, item)
+			case bean.ItemStatusDeploying:
+				impl.checkItemHealth(release, item)
+			}
+			switch bean.ItemStatus(item.Status) {
+			case bean.ItemStatusHealthy:
+				healthyCount++
+			case bean.ItemStatusFailed:
+				failedCount++
+			}
+		}
+		now := time.Now()
+		switch {
+		case failedCount > 0:
+			release.Status = bean.ReleaseStatusPaused.String()
+			release.UpdatedOn = now
+			if impl.transitionRelease(release, bean.ReleaseStatusRunning, stage) == nil {
+				impl.saveEvent(release.Id, 0, bean.EventTypePaused, fmt.Sprintf(bean.PausedMessageTmpl, failedCount, stage+1), release.TriggeredBy)
+			}
+			return
+		case healthyCount < stageItemCount:
+			// waiting for the deployments of the stage
+			return
+		case stage+1 >= stageCount:
+			release.Status = bean.ReleaseStatusSucceeded.String()
+			release.FinishedOn = &now
+			release.UpdatedOn = now
+			if impl.transitionRelease(release, bean.ReleaseStatusRunning, stage) == nil {
+				impl.saveEvent(release.Id, 0, bean.EventTypeSucceeded, "", release.TriggeredBy)
+			}
+			return
+		}
+		release.CurrentStage = stage + 1
+		release.UpdatedOn = now
+		if impl.transitionRelease(release, bean.ReleaseStatusRunning, stage) != nil {
+			return
+		}
+		impl.saveEvent(release.Id, 0, bean.EventTypeStageStarted, fmt.Sprintf(bean.StageStartedMessageTmpl, release.CurrentStage+1, stageCount), release.TriggeredBy)
+	}
+}
+
+// triggerItem deploys the artifact of the item with the last saved configuration on behalf of the user running the release
+func (impl *ReleaseOrchestrationServiceImpl) triggerItem(release *repository.ReleaseOrchestration, item *repository.ReleaseOrchestrationItem) {
+	isRetry := item.TriggeredOn != nil
+	now := time.Now()
+	item.Status = bean.ItemStatusDeploying.String()
+	item.TriggeredOn = &now
+	claimed, err := impl.releaseOrchestrationRepository.UpdateItemIfInStatus(item, bean.ItemStatusPending.String())
+	if err != nil || !claimed {
+		// triggered by some other replica
+		item.Status = bean.ItemStatusPending.String()
+		return
+	}
+	if !isRetry {
+		impl.setRollbackTarget(item)
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:           item.PipelineId,
+		AppId:                item.AppId,
+		CiArtifactId:         item.CiArtifactId,
+		CdWorkflowType:       apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig: apiBean.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED,
+		DeploymentType:       models.DEPLOYMENTTYPE_DEPLOY,
+		UserId:               release.TriggeredBy,
+	}
+	_, _, _, err = impl.cdHandlerService.ManualCdTrigger(triggerBean.TriggerContext{Context: context.Background()}, overrideRequest, nil)
+	// the runner of a previous attempt must not be health checked for this one
+	item.CdWorkflowRunnerId = overrideRequest.WfrId
+	eventType, message := bean.EventTypeItemTriggered, fmt.Sprintf("artifact %d deployed", item.CiArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in deploying release item", "releaseId", release.Id, "itemId", item.Id, "pipelineId", item.PipelineId, "err", err)
+		finishedOn := time.Now()
+		item.Status = bean.ItemStatusFailed.String()
+		item.Message = util.GetClientErrorDetailedMessage(err)
+		item.FinishedOn = &finishedOn
+		eventType, message = bean.EventTypeItemFailed, item.Message
+	}
+	impl.updateItem(item, bean.ItemStatusDeploying, eventType, message, release.TriggeredBy)
+}
+
+// setRollbackTarget records the deployment of the item's pipeline live before the release, if it was healthy
+func (impl *ReleaseOrchestrationServiceImpl) setRollbackTarget(item *repository.ReleaseOrchestrationItem) {
+	latestRunner, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(item.PipelineId, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		if !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching latest deployment of release item", "itemId", item.Id, "pipelineId", item.PipelineId, "err", err)
+		}
+		return
+	}
+	if slices.Contains(healthyDeploymentStatuses, latestRunner.Status) {
+		item.PreviousWfrId = latestRunner.Id
+	}
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) checkItemHealth(release *repository.ReleaseOrchestration, item *repository.ReleaseOrchestrationItem) {
+	// an item whose trigger recorded no deployment still times out, instead of deploying forever
+	runnerStatus := bean.DeploymentNotFoundStatus
+	if item.CdWorkflowRunnerId > 0 {
+		runner, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(item.CdWorkflowRunnerId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching deployment of release item", "releaseId", release.Id, "itemId", item.Id, "wfrId", item.CdWorkflowRunnerId, "err", err)
+			return
+		}
+		if err == nil {
+			runnerStatus = runner.Status
+		}
+	}
+	now := time.Now()
+	var eventType bean.EventType
+	switch {
+	case slices.Contains(healthyDeploymentStatuses, runnerStatus):
+		item.Status = bean.ItemStatusHealthy.String()
+		item.Message = ""
+		eventType = bean.EventTypeItemHealthy
+	case slices.Contains(failedDeploymentStatuses, runnerStatus):
+		item.Status = bean.ItemStatusFailed.String()
+		item.Message = fmt.Sprintf(bean.DeploymentFailedMessageTmpl, strings.ToLower(runnerStatus))
+		eventType = bean.EventTypeItemFailed
+	case item.TriggeredOn != nil && item.TriggeredOn.Add(time.Duration(release.HealthTimeoutMins)*time.Minute).Before(now):
+		item.Status = bean.ItemStatusFailed.String()
+		item.Message = fmt.Sprintf(bean.HealthTimeoutMessageTmpl, release.HealthTimeoutMins, runnerStatus)
+		eventType = bean.EventTypeItemFailed
+	default:
+		return
+	}
+	item.FinishedOn = &now
+	impl.updateItem(item, bean.ItemStatusDeploying, eventType, item.Message, release.TriggeredBy)
+}
+
+// rollbackRelease rolls back the items deployed by the release in the reverse order of their stages
+func (impl *ReleaseOrchestrationServiceImpl) rollbackRelease(release *repository.ReleaseOrchestration) {
+	items, err := impl.releaseOrchestrationRepository.FindItemsByReleaseId(release.Id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching release items", "releaseId", release.Id, "err", err)
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Stage > items[j].Stage
+	})
+	userId := release.UpdatedBy
+	for _, item := range items {
+		fromStatus := bean.ItemStatus(item.Status)
+		if !fromStatus.IsDeployed() {
+			continue
+		}
+		if item.PreviousWfrId == 0 {
+			item.Status = bean.ItemStatusRollbackSkipped.String()
+			item.Message = bean.NoRollbackTargetMessage
+			impl.updateItem(item, fromStatus, bean.EventTypeItemRollbackSkipped, item.Message, userId)
+			continue
+		}
+		item.Status = bean.ItemStatusRollingBack.String()
+		claimed, err := impl.releaseOrchestrationRepository.UpdateItemIfInStatus(item, fromStatus.String())
+		if err != nil || !claimed {
+			continue
+		}
+		rollbackWfrId, err := impl.triggerItemRollback(item, userId)
+		item.RollbackWfrId = rollbackWfrId
+		eventType, message := bean.EventTypeItemRolledBack, fmt.Sprintf("deployment %d redeployed", item.PreviousWfrId)
+		item.Status = bean.ItemStatusRolledBack.String()
+		if err != nil {
+			impl.logger.Errorw("error in rolling back release item", "releaseId", release.Id, "itemId", item.Id, "pipelineId", item.PipelineId, "err", err)
+			item.Status = bean.ItemStatusRollbackFailed.String()
+			eventType, message = bean.EventTypeItemRollbackFailed, util.GetClientErrorDetailedMessage(err)
+		}
+		item.Message = message
+		impl.updateItem(item, bean.ItemStatusRollingBack, eventType, message, userId)
+	}
+	rolledBackCount, deployedCount := 0, 0
+	for _, item := range items {
+		switch bean.ItemStatus(item.Status) {
+		case bean.ItemStatusRollingBack:
+			// still being rolled back by some other replica
+			return
+		case bean.ItemStatusRolledBack:
+			rolledBackCount++
+			deployedCount++
+		case bean.ItemStatusRollbackFailed, bean.ItemStatusRollbackSkipped:
+			deployedCount++
+		}
+	}
+	now := time.Now()
+	release.Status = bean.ReleaseStatusRolledBack.String()
+	release.FinishedOn = &now
+	release.UpdatedOn = now
+	if impl.transitionRelease(release, bean.ReleaseStatusRollingBack, release.CurrentStage) == nil {
+		impl.saveEvent(release.Id, 0, bean.EventTypeRolledBack, fmt.Sprintf(bean.RolledBackMessageTmpl, rolledBackCount, deployedCount), userId)
+	}
+}
+
+// triggerItemRollback redeploys the artifact and the configuration snapshot of the rollback target of the item,
+// the runner created for the rollback is returned even if the release fails
+func (impl *ReleaseOrchestrationServiceImpl) triggerItemRollback(item *repository.ReleaseOrchestrationItem, userId int32) (int, error) {
+	targetRunner, err := impl.cdWorkflowRepository.FindWorkflowRunnerById(item.PreviousWfrId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching rollback target of release item", "itemId", item.Id, "wfrId", item.PreviousWfrId, "err", err)
+		return 0, err
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:                            item.PipelineId,
+		AppId:                                 item.AppId,
+		CiArtifactId:                          targetRunner.CdWorkflow.CiArtifactId,
+		CdWorkflowType:                        apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig:                  apiBean.DEPLOYMENT_CONFIG_TYPE_SPECIFIC_TRIGGER,
+		WfrIdForDeploymentWithSpecificTrigger: targetRunner.Id,
+		DeploymentType:                        models.DEPLOYMENTTYPE_ROLLBACK,
+		IsRollbackDeployment:                  true,
+		UserId:                                userId,
+	}
+	_, _, _, err = impl.cdHandlerService.ManualCdTrigger(triggerBean.TriggerContext{Context: context.Background()}, overrideRequest, nil)
+	return overrideRequest.WfrId, err
+}
+
+// updateItem saves the transition of the item from the given status and records it on the timeline
+func (impl *ReleaseOrchestrationServiceImpl) updateItem(item *repository.ReleaseOrchestrationItem, fromStatus bean.ItemStatus,
+	eventType bean.EventType, message string, userId int32) {
+	updated, err := impl.releaseOrchestrationRepository.UpdateItemIfInStatus(item, fromStatus.String())
+	if err != nil {
+		impl.logger.Errorw("error in updating release item", "itemId", item.Id, "status", item.Status, "err", err)
+		return
+	}
+	if updated {
+		impl.saveEvent(item.ReleaseId, item.Id, eventType, message, userId)
+	}
+}
+
+// transitionRelease saves the release if it is still in the given status and stage, a conflict error is returned otherwise
+func (impl *ReleaseOrchestrationServiceImpl) transitionRelease(release *repository.ReleaseOrchestration, fromStatus bean.ReleaseStatus, fromStage int) error {
+	updated, err := impl.releaseOrchestrationRepository.UpdateIfInState(release, fromStatus.String(), fromStage)
+	if err != nil {
+		impl.logger.Errorw("error in updating release", "releaseId", release.Id, "status", release.Status, "err", err)
+		return err
+	}
+	if !updated {
+		return util.NewApiError(http.StatusConflict, "release was updated in between, please retry", "release state changed")
+	}
+	return nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) saveEvent(releaseId, itemId int, eventType bean.EventType, message string, userId int32) {
+	event := &repository.ReleaseOrchestrationEvent{
+		ReleaseId: releaseId,
+		ItemId:    itemId,
+		EventType: eventType.String(),
+		Message:   message,
+		CreatedOn: time.Now(),
+		CreatedBy: userId,
+	}
+	err := impl.releaseEventRepository.Save(event)
+	if err != nil {
+		impl.logger.Errorw("error in saving release event", "releaseId", releaseId, "itemId", itemId, "eventType", eventType, "err", err)
+	}
+}
+
+// fillItemDetails sets the app, environment and image of the items of the releases
+func (impl *ReleaseOrchestrationServiceImpl) fillItemDetails(releases []*bean.ReleaseDto) error {
+	var pipelineIds, artifactIds []int
+	for _, release := range releases {
+		for _, item := range release.Items {
+			pipelineIds = append(pipelineIds, item.PipelineId)
+			artifactIds = append(artifactIds, item.CiArtifactId)
+		}
+	}
+	if len(pipelineIds) == 0 {
+		return nil
+	}
+	pipelines, err := impl.pipelineRepository.FindByIdsIn(pipelineIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching cd pipelines", "pipelineIds", pipelineIds, "err", err)
+		return err
+	}
+	pipelineById := make(map[int]*pipelineConfig.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelineById[pipeline.Id] = pipeline
+	}
+	artifacts, err := impl.ciArtifactRepository.GetByIds(artifactIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching artifacts", "artifactIds", artifactIds, "err", err)
+		return err
+	}
+	imageByArtifactId := make(map[int]string, len(artifacts))
+	for _, artifact := range artifacts {
+		imageByArtifactId[artifact.Id] = artifact.Image
+	}
+	for _, release := range releases {
+		for _, item := range release.Items {
+			if pipeline, ok := pipelineById[item.PipelineId]; ok {
+				item.AppName = pipeline.App.AppName
+				item.EnvironmentName = pipeline.Environment.Name
+			}
+			item.Image = imageByArtifactId[item.CiArtifactId]
+		}
+	}
+	return nil
+}
+
+func (impl *ReleaseOrchestrationServiceImpl) findRelease(id int) (*repository.ReleaseOrchestration, error) {
+	release, err := impl.releaseOrchestrationRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("release %d not found", id), "release not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching release", "id", id, "err", err)
+		return nil, err
+	}
+	return release, nil
+}
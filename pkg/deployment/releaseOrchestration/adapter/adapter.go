@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/repository"
+)
+
+func GetReleaseDto(release *repository.ReleaseOrchestration, items []*repository.ReleaseOrchestrationItem) *bean.ReleaseDto {
+	itemDtos := make([]*bean.ReleaseItemDto, 0, len(items))
+	stageCount := 0
+	for _, item := range items {
+		itemDtos = append(itemDtos, GetReleaseItemDto(item))
+		if item.Stage+1 > stageCount {
+			stageCount = item.Stage + 1
+		}
+	}
+	return &bean.ReleaseDto{
+		Id:                release.Id,
+		Name:              release.Name,
+		Description:       release.Description,
+		HealthTimeoutMins: release.HealthTimeoutMins,
+		Status:            bean.ReleaseStatus(release.Status),
+		CurrentStage:      release.CurrentStage,
+		StageCount:        stageCount,
+		Items:             itemDtos,
+		TriggeredBy:       release.TriggeredBy,
+		StartedOn:         release.StartedOn,
+		FinishedOn:        release.FinishedOn,
+		CreatedBy:         release.CreatedBy,
+		CreatedOn:         release.CreatedOn,
+	}
+}
+
+func GetReleaseItemDto(item *repository.ReleaseOrchestrationItem) *bean.ReleaseItemDto {
+	dependsOn := item.DependsOn
+	if dependsOn == nil {
+		dependsOn = make([]string, 0)
+	}
+	return &bean.ReleaseItemDto{
+		Id:                 item.Id,
+		Key:                item.ItemKey,
+		PipelineId:         item.PipelineId,
+		AppId:              item.AppId,
+		EnvironmentId:      item.EnvironmentId,
+		CiArtifactId:       item.CiArtifactId,
+		DependsOn:          dependsOn,
+		Stage:              item.Stage,
+		Status:             bean.ItemStatus(item.Status),
+		CdWorkflowRunnerId: item.CdWorkflowRunnerId,
+		PreviousWfrId:      item.PreviousWfrId,
+		RollbackWfrId:      item.RollbackWfrId,
+		Message:            item.Message,
+		TriggeredOn:        item.TriggeredOn,
+		FinishedOn:         item.FinishedOn,
+	}
+}
+
+func GetReleaseEventDto(event *repository.ReleaseOrchestrationEvent, itemKey string) *bean.ReleaseEventDto {
+	return &bean.ReleaseEventDto{
+		Id:        event.Id,
+		ItemId:    event.ItemId,
+		ItemKey:   itemKey,
+		EventType: bean.EventType(event.EventType),
+		Message:   event.Message,
+		CreatedBy: event.CreatedBy,
+		CreatedOn: event.CreatedOn,
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+// CATEGORY=RELEASE_ORCHESTRATION
+type ReleaseOrchestrationConfig struct {
+	Enabled                  bool   `env:"RELEASE_ORCHESTRATION_ENABLED" envDefault:"true" description:"Enables the stage by stage deployment of the multi app releases"`
+	ProgressCron             string `env:"RELEASE_ORCHESTRATION_CRON" envDefault:"* * * * *" description:"Cron at which the leader replica checks the health of the running releases and moves them to their next stage"`
+	DefaultHealthTimeoutMins int    `env:"RELEASE_ORCHESTRATION_DEFAULT_HEALTH_TIMEOUT_MINS" envDefault:"30" description:"Minutes an app of a release is given to become healthy when the release does not set it, the release is paused after it"`
+}
+
+type ReleaseStatus string
+
+const (
+	ReleaseStatusCreated     ReleaseStatus = "CREATED"
+	ReleaseStatusRunning     ReleaseStatus = "RUNNING"
+	ReleaseStatusPaused      ReleaseStatus = "PAUSED"
+	ReleaseStatusSucceeded   ReleaseStatus = "SUCCEEDED"
+	ReleaseStatusRollingBack ReleaseStatus = "ROLLING_BACK"
+	ReleaseStatusRolledBack  ReleaseStatus = "ROLLED_BACK"
+)
+
+func (s ReleaseStatus) String() string {
+	return string(s)
+}
+
+type ItemStatus string
+
+const (
+	ItemStatusPending         ItemStatus = "PENDING"
+	ItemStatusDeploying       ItemStatus = "DEPLOYING"
+	ItemStatusHealthy         ItemStatus = "HEALTHY"
+	ItemStatusFailed          ItemStatus = "FAILED"
+	ItemStatusRollingBack     ItemStatus = "ROLLING_BACK"
+	ItemStatusRolledBack      ItemStatus = "ROLLED_BACK"
+	ItemStatusRollbackFailed  ItemStatus = "ROLLBACK_FAILED"
+	ItemStatusRollbackSkipped ItemStatus = "ROLLBACK_SKIPPED"
+)
+
+func (s ItemStatus) String() string {
+	return string(s)
+}
+
+// IsDeployed is true for the items whose deployment was triggered by the release, only these are rolled back
+func (s ItemStatus) IsDeployed() bool {
+	return s == ItemStatusDeploying || s == ItemStatusHealthy || s == ItemStatusFailed
+}
+
+type EventType string
+
+const (
+	EventTypeCreated             EventType = "CREATED"
+	EventTypeStarted             EventType = "STARTED"
+	EventTypeStageStarted        EventType = "STAGE_STARTED"
+	EventTypeItemTriggered       EventType = "ITEM_TRIGGERED"
+	EventTypeItemHealthy         EventType = "ITEM_HEALTHY"
+	EventTypeItemFailed          EventType = "ITEM_FAILED"
+	EventTypePaused              EventType = "PAUSED"
+	EventTypeResumed             EventType = "RESUMED"
+	EventTypeSucceeded           EventType = "SUCCEEDED"
+	EventTypeRollbackStarted     EventType = "ROLLBACK_STARTED"
+	EventTypeItemRolledBack      EventType = "ITEM_ROLLED_BACK"
+	EventTypeItemRollbackFailed  EventType = "ITEM_ROLLBACK_FAILED"
+	EventTypeItemRollbackSkipped EventType = "ITEM_ROLLBACK_SKIPPED"
+	EventTypeRolledBack          EventType = "ROLLED_BACK"
+)
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+const (
+	MaxHealthTimeoutMins = 24 * 60
+
+	StageStartedMessageTmpl  = "stage %d of %d started"
+	PausedMessageTmpl        = "paused as %d app(s) of stage %d failed"
+	HealthTimeoutMessageTmpl = "deployment not healthy within %d minutes, last status %s"
+	// DeploymentNotFoundStatus is the last status of an item whose deployment was not recorded
+	DeploymentNotFoundStatus    = "not found"
+	DeploymentFailedMessageTmpl = "deployment %s"
+	NoRollbackTargetMessage     = "no healthy deployment of the pipeline before the release, not rolled back"
+	RolledBackMessageTmpl       = "%d of %d deployed app(s) rolled back"
+)
+
+// ReleaseDto is a set of app+environment+artifact tuples deployed stage by stage, an item is deployed only after all
+// the items it depends on are healthy
+type ReleaseDto struct {
+	Id                int               `json:"id"`
+	Name              string            `json:"name" validate:"required,max=250"`
+	Description       string            `json:"description"`
+	HealthTimeoutMins int               `json:"healthTimeoutMins" validate:"min=0"`
+	Status            ReleaseStatus     `json:"status"`
+	CurrentStage      int               `json:"currentStage"`
+	StageCount        int               `json:"stageCount"`
+	Items             []*ReleaseItemDto `json:"items" validate:"required,min=1,dive"`
+	TriggeredBy       int32             `json:"triggeredBy,omitempty"`
+	StartedOn         *time.Time        `json:"startedOn,omitempty"`
+	FinishedOn        *time.Time        `json:"finishedOn,omitempty"`
+	CreatedBy         int32             `json:"createdBy"`
+	CreatedOn         time.Time         `json:"createdOn"`
+	UserId            int32             `json:"-"`
+}
+
+type ReleaseItemDto struct {
+	Id int `json:"id"`
+	// Key names the item within the release, the dependencies of the items refer to it
+	Key                string     `json:"key" validate:"required,max=100"`
+	PipelineId         int        `json:"pipelineId" validate:"required,min=1"`
+	AppId              int        `json:"appId"`
+	AppName            string     `json:"appName,omitempty"`
+	EnvironmentId      int        `json:"environmentId"`
+	EnvironmentName    string     `json:"environmentName,omitempty"`
+	CiArtifactId       int        `json:"ciArtifactId" validate:"required,min=1"`
+	Image              string     `json:"image,omitempty"`
+	DependsOn          []string   `json:"dependsOn"`
+	Stage              int        `json:"stage"`
+	Status             ItemStatus `json:"status"`
+	CdWorkflowRunnerId int        `json:"cdWorkflowRunnerId,omitempty"`
+	PreviousWfrId      int        `json:"previousWfrId,omitempty"`
+	RollbackWfrId      int        `json:"rollbackWfrId,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	TriggeredOn        *time.Time `json:"triggeredOn,omitempty"`
+	FinishedOn         *time.Time `json:"finishedOn,omitempty"`
+}
+
+// ReleaseEventDto is an entry of the timeline of a release
+type ReleaseEventDto struct {
+	Id        int       `json:"id"`
+	ItemId    int       `json:"itemId,omitempty"`
+	ItemKey   string    `json:"itemKey,omitempty"`
+	EventType EventType `json:"eventType"`
+	Message   string    `json:"message,omitempty"`
+	CreatedBy int32     `json:"createdBy"`
+	CreatedOn time.Time `json:"createdOn"`
+}
+
+// ReleaseTimelineDto is the release with its events in the order they happened
+type ReleaseTimelineDto struct {
+	Release *ReleaseDto        `json:"release"`
+	Events  []*ReleaseEventDto `json:"events"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ReleaseOrchestrationEvent struct {
+	tableName struct{}  `sql:"release_orchestration_event" pg:",discard_unknown_columns"`
+	Id        int       `sql:"id,pk"`
+	ReleaseId int       `sql:"release_id,notnull"`
+	ItemId    int       `sql:"item_id"`
+	EventType string    `sql:"event_type,notnull"`
+	Message   string    `sql:"message"`
+	CreatedOn time.Time `sql:"created_on,notnull"`
+	CreatedBy int32     `sql:"created_by,notnull"`
+}
+
+type ReleaseOrchestrationEventRepository interface {
+	Save(event *ReleaseOrchestrationEvent) error
+	FindByReleaseId(releaseId int) ([]*ReleaseOrchestrationEvent, error)
+}
+
+type ReleaseOrchestrationEventRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewReleaseOrchestrationEventRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ReleaseOrchestrationEventRepositoryImpl {
+	return &ReleaseOrchestrationEventRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ReleaseOrchestrationEventRepositoryImpl) Save(event *ReleaseOrchestrationEvent) error {
+	return impl.dbConnection.Insert(event)
+}
+
+func (impl *ReleaseOrchestrationEventRepositoryImpl) FindByReleaseId(releaseId int) ([]*ReleaseOrchestrationEvent, error) {
+	var events []*ReleaseOrchestrationEvent
+	err := impl.dbConnection.Model(&events).
+		Where("release_id = ?", releaseId).
+		Order("id ASC").
+		Select()
+	return events, err
+}
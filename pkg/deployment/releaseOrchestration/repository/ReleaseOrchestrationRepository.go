@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type ReleaseOrchestration struct {
+	tableName         struct{}   `sql:"release_orchestration" pg:",discard_unknown_columns"`
+	Id                int        `sql:"id,pk"`
+	Name              string     `sql:"name,notnull"`
+	Description       string     `sql:"description"`
+	Status            string     `sql:"status,notnull"`
+	CurrentStage      int        `sql:"current_stage,notnull"`
+	HealthTimeoutMins int        `sql:"health_timeout_mins,notnull"`
+	TriggeredBy       int32      `sql:"triggered_by"`
+	StartedOn         *time.Time `sql:"started_on"`
+	FinishedOn        *time.Time `sql:"finished_on"`
+	Active            bool       `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type ReleaseOrchestrationItem struct {
+	tableName          struct{}   `sql:"release_orchestration_item" pg:",discard_unknown_columns"`
+	Id                 int        `sql:"id,pk"`
+	ReleaseId          int        `sql:"release_id,notnull"`
+	ItemKey            string     `sql:"item_key,notnull"`
+	PipelineId         int        `sql:"pipeline_id,notnull"`
+	AppId              int        `sql:"app_id,notnull"`
+	EnvironmentId      int        `sql:"environment_id,notnull"`
+	CiArtifactId       int        `sql:"ci_artifact_id,notnull"`
+	DependsOn          []string   `sql:"depends_on" pg:",array"`
+	Stage              int        `sql:"stage,notnull"`
+	Status             string     `sql:"status,notnull"`
+	CdWorkflowRunnerId int        `sql:"cd_workflow_runner_id"`
+	PreviousWfrId      int        `sql:"previous_wfr_id"`
+	RollbackWfrId      int        `sql:"rollback_wfr_id"`
+	Message            string     `sql:"message"`
+	TriggeredOn        *time.Time `sql:"triggered_on"`
+	FinishedOn         *time.Time `sql:"finished_on"`
+}
+
+type ReleaseOrchestrationRepository interface {
+	// SaveWithItems saves the release and its items in a single transaction
+	SaveWithItems(release *ReleaseOrchestration, items []*ReleaseOrchestrationItem) error
+	// UpdateIfInState updates the release only if it is still in the given status and stage, so a transition
+	// is made once even if several replicas progress the release. false is returned if the release moved in between.
+	UpdateIfInState(release *ReleaseOrchestration, status string, stage int) (bool, error)
+	// UpdateWithItemsIfInState updates the items still in itemStatus and then the release if it is still in the given
+	// status and stage, in a single transaction. Nothing is updated if the release moved in between.
+	UpdateWithItemsIfInState(release *ReleaseOrchestration, status string, stage int, items []*ReleaseOrchestrationItem, itemStatus string) (bool, error)
+	FindById(id int) (*ReleaseOrchestration, error)
+	FindAllActive() ([]*ReleaseOrchestration, error)
+	FindActiveByStatuses(statuses []string) ([]*ReleaseOrchestration, error)
+
+	FindItemsByReleaseId(releaseId int) ([]*ReleaseOrchestrationItem, error)
+	FindItemsByReleaseIds(releaseIds []int) ([]*ReleaseOrchestrationItem, error)
+	// UpdateItemIfInStatus updates the item only if it is still in the given status, false is returned otherwise
+	UpdateItemIfInStatus(item *ReleaseOrchestrationItem, status string) (bool, error)
+}
+
+type ReleaseOrchestrationRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewReleaseOrchestrationRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ReleaseOrchestrationRepositoryImpl {
+	return &ReleaseOrchestrationRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) SaveWithItems(release *ReleaseOrchestration, items []*ReleaseOrchestrationItem) error {
+	return impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		err := tx.Insert(release)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			item.ReleaseId = release.Id
+		}
+		_, err = tx.Model(&items).Insert()
+		return err
+	})
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) UpdateIfInState(release *ReleaseOrchestration, status string, stage int) (bool, error) {
+	return updateIfInState(impl.dbConnection, release, status, stage)
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) UpdateWithItemsIfInState(release *ReleaseOrchestration, status string, stage int,
+	items []*ReleaseOrchestrationItem, itemStatus string) (bool, error) {
+	err := impl.dbConnection.RunInTransaction(func(tx *pg.Tx) error {
+		for _, item := range items {
+			_, err := tx.Model(item).
+				WherePK().
+				Where("status = ?", itemStatus).
+				Update()
+			if err != nil {
+				return err
+			}
+		}
+		updated, err := updateIfInState(tx, release, status, stage)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return errReleaseStateChanged
+		}
+		return nil
+	})
+	if errors.Is(err, errReleaseStateChanged) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// errReleaseStateChanged rolls back a transaction when the release moved in between
+var errReleaseStateChanged = errors.New("release state changed")
+
+func updateIfInState(db orm.DB, release *ReleaseOrchestration, status string, stage int) (bool, error) {
+	res, err := db.Model(release).
+		WherePK().
+		Where("status = ?", status).
+		Where("current_stage = ?", stage).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) FindById(id int) (*ReleaseOrchestration, error) {
+	release := &ReleaseOrchestration{}
+	err := impl.dbConnection.Model(release).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return release, err
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) FindAllActive() ([]*ReleaseOrchestration, error) {
+	var releases []*ReleaseOrchestration
+	err := impl.dbConnection.Model(&releases).
+		Where("active = ?", true).
+		Order("id DESC").
+		Select()
+	return releases, err
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) FindActiveByStatuses(statuses []string) ([]*ReleaseOrchestration, error) {
+	var releases []*ReleaseOrchestration
+	err := impl.dbConnection.Model(&releases).
+		Where("active = ?", true).
+		Where("status IN (?)", pg.In(statuses)).
+		Order("id ASC").
+		Select()
+	return releases, err
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) FindItemsByReleaseId(releaseId int) ([]*ReleaseOrchestrationItem, error) {
+	return impl.FindItemsByReleaseIds([]int{releaseId})
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) FindItemsByReleaseIds(releaseIds []int) ([]*ReleaseOrchestrationItem, error) {
+	var items []*ReleaseOrchestrationItem
+	if len(releaseIds) == 0 {
+		return items, nil
+	}
+	err := impl.dbConnection.Model(&items).
+		Where("release_id IN (?)", pg.In(releaseIds)).
+		Order("stage ASC").
+		Order("id ASC").
+		Select()
+	return items, err
+}
+
+func (impl *ReleaseOrchestrationRepositoryImpl) UpdateItemIfInStatus(item *ReleaseOrchestrationItem, status string) (bool, error) {
+	res, err := impl.dbConnection.Model(item).
+		WherePK().
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
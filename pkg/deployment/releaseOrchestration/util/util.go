@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GetStages layers the items of a release by their dependencies, keyed by the item keys. An item is put in the stage
+// after the last stage of the items it depends on, so the items of a stage can be deployed together.
+// An error is returned for dependencies on unknown items and for cycles.
+func GetStages(dependencies map[string][]string) (map[string]int, error) {
+	keys := make([]string, 0, len(dependencies))
+	for key := range dependencies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pendingDependencyCount := make(map[string]int, len(dependencies))
+	dependents := make(map[string][]string, len(dependencies))
+	for _, key := range keys {
+		seen := make(map[string]bool, len(dependencies[key]))
+		for _, dependency := range dependencies[key] {
+			if dependency == key {
+				return nil, fmt.Errorf("item %q depends on itself", key)
+			}
+			if _, ok := dependencies[dependency]; !ok {
+				return nil, fmt.Errorf("item %q depends on unknown item %q", key, dependency)
+			}
+			if seen[dependency] {
+				continue
+			}
+			seen[dependency] = true
+			pendingDependencyCount[key]++
+			dependents[dependency] = append(dependents[dependency], key)
+		}
+	}
+	stages := make(map[string]int, len(dependencies))
+	var current []string
+	for _, key := range keys {
+		if pendingDependencyCount[key] == 0 {
+			current = append(current, key)
+		}
+	}
+	for stage := 0; len(current) > 0; stage++ {
+		var next []string
+		for _, key := range current {
+			stages[key] = stage
+			for _, dependent := range dependents[key] {
+				pendingDependencyCount[dependent]--
+				if pendingDependencyCount[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Strings(next)
+		current = next
+	}
+	if len(stages) != len(dependencies) {
+		var cyclic []string
+		for _, key := range keys {
+			if _, ok := stages[key]; !ok {
+				cyclic = append(cyclic, key)
+			}
+		}
+		return nil, fmt.Errorf("items %s are in or depend on a dependency cycle", strings.Join(cyclic, ", "))
+	}
+	return stages, nil
+}
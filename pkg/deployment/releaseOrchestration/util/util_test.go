@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStages(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string][]string
+		want         map[string]int
+		wantErr      bool
+	}{
+		{
+			name: "chain",
+			dependencies: map[string][]string{
+				"migrator": nil,
+				"backend":  {"migrator"},
+				"frontend": {"backend"},
+			},
+			want: map[string]int{"migrator": 0, "backend": 1, "frontend": 2},
+		},
+		{
+			name: "diamond waits for the longest path",
+			dependencies: map[string][]string{
+				"migrator": nil,
+				"backend":  {"migrator"},
+				"worker":   {"migrator"},
+				"frontend": {"backend", "worker", "migrator"},
+				"docs":     {},
+			},
+			want: map[string]int{"migrator": 0, "docs": 0, "backend": 1, "worker": 1, "frontend": 2},
+		},
+		{
+			name: "duplicate dependency",
+			dependencies: map[string][]string{
+				"migrator": nil,
+				"backend":  {"migrator", "migrator"},
+			},
+			want: map[string]int{"migrator": 0, "backend": 1},
+		},
+		{
+			name:         "unknown dependency",
+			dependencies: map[string][]string{"backend": {"migrator"}},
+			wantErr:      true,
+		},
+		{
+			name:         "self dependency",
+			dependencies: map[string][]string{"backend": {"backend"}},
+			wantErr:      true,
+		},
+		{
+			name: "cycle",
+			dependencies: map[string][]string{
+				"migrator": nil,
+				"backend":  {"frontend", "migrator"},
+				"frontend": {"backend"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStages(tt.dependencies)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetStages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
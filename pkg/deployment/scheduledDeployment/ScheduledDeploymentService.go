@@ -0,0 +1,538 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduledDeployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/appWorkflow"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	leaderElectionBean "github.com/devtron-labs/devtron/pkg/leaderElection/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	util3 "github.com/devtron-labs/devtron/util/event"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// dueDeploymentsBatchSize is the max number of scheduled deployments run in a single run of the cron
+const dueDeploymentsBatchSize = 50
+
+type ScheduledDeploymentService interface {
+	// GetScheduledDeployments lists the scheduled deployments of the app or the pipeline, the pending ones by default
+	GetScheduledDeployments(filter *bean.ListFilter) ([]*bean.ScheduledDeploymentDto, error)
+	GetScheduledDeployment(id int) (*bean.ScheduledDeploymentDto, error)
+	// CreateScheduledDeployment validates the override request and queues it to be triggered at the scheduled time on
+	// behalf of the user
+	CreateScheduledDeployment(request *bean.ScheduledDeploymentDto) (*bean.ScheduledDeploymentDto, error)
+	// CancelScheduledDeployment cancels a scheduled deployment which has not run yet
+	CancelScheduledDeployment(id int, userId int32) (*bean.ScheduledDeploymentDto, error)
+}
+
+type ScheduledDeploymentServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	config                        *bean.ScheduledDeploymentConfig
+	scheduledDeploymentRepository repository.ScheduledDeploymentRepository
+	pipelineRepository            pipelineConfig.PipelineRepository
+	appWorkflowRepository         appWorkflow.AppWorkflowRepository
+	ciArtifactRepository          repository2.CiArtifactRepository
+	cdHandlerService              devtronApps.HandlerService
+	deploymentWindowService       deploymentWindow.DeploymentWindowService
+	userService                   user.UserService
+	enforcer                      casbin.Enforcer
+	enforcerUtil                  rbac.EnforcerUtil
+	eventFactory                  client.EventFactory
+	eventClient                   client.EventClient
+	leaderElectionService         leaderElection.LeaderElectionService
+}
+
+func NewScheduledDeploymentServiceImpl(logger *zap.SugaredLogger,
+	scheduledDeploymentRepository repository.ScheduledDeploymentRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	appWorkflowRepository appWorkflow.AppWorkflowRepository,
+	ciArtifactRepository repository2.CiArtifactRepository,
+	cdHandlerService devtronApps.HandlerService,
+	deploymentWindowService deploymentWindow.DeploymentWindowService,
+	userService user.UserService,
+	enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil,
+	eventFactory client.EventFactory,
+	eventClient client.EventClient,
+	leaderElectionService leaderElection.LeaderElectionService,
+	cronLogger *cron2.CronLoggerImpl) (*ScheduledDeploymentServiceImpl, error) {
+	config := &bean.ScheduledDeploymentConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing scheduled deployment config", "err", err)
+		return nil, err
+	}
+	impl := &ScheduledDeploymentServiceImpl{
+		logger:                        logger,
+		config:                        config,
+		scheduledDeploymentRepository: scheduledDeploymentRepository,
+		pipelineRepository:            pipelineRepository,
+		appWorkflowRepository:         appWorkflowRepository,
+		ciArtifactRepository:          ciArtifactRepository,
+		cdHandlerService:              cdHandlerService,
+		deploymentWindowService:       deploymentWindowService,
+		userService:                   userService,
+		enforcer:                      enforcer,
+		enforcerUtil:                  enforcerUtil,
+		eventFactory:                  eventFactory,
+		eventClient:                   eventClient,
+		leaderElectionService:         leaderElectionService,
+	}
+	if !config.Enabled {
+		return impl, nil
+	}
+	executorCron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	executorCron.Start()
+	_, err = executorCron.AddFunc(config.ExecutorCron, impl.runDueDeployments)
+	if err != nil {
+		logger.Errorw("error while configure cron job for scheduled deployments", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) GetScheduledDeployments(filter *bean.ListFilter) ([]*bean.ScheduledDeploymentDto, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []string{bean.StatusPending.String()}
+	}
+	scheduledDeployments, err := impl.scheduledDeploymentRepository.FindAll(filter.AppId, filter.PipelineId, statuses)
+	if err != nil {
+		impl.logger.Errorw("error in fetching scheduled deployments", "filter", filter, "err", err)
+		return nil, err
+	}
+	res := make([]*bean.ScheduledDeploymentDto, 0, len(scheduledDeployments))
+	for _, scheduledDeployment := range scheduledDeployments {
+		dto, err := adapter.GetScheduledDeploymentDto(scheduledDeployment)
+		if err != nil {
+			impl.logger.Errorw("error in parsing scheduled deployment", "id", scheduledDeployment.Id, "err", err)
+			return nil, err
+		}
+		res = append(res, dto)
+	}
+	err = impl.fillDetails(res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) GetScheduledDeployment(id int) (*bean.ScheduledDeploymentDto, error) {
+	scheduledDeployment, err := impl.findScheduledDeployment(id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.GetScheduledDeploymentDto(scheduledDeployment)
+	if err != nil {
+		impl.logger.Errorw("error in parsing scheduled deployment", "id", id, "err", err)
+		return nil, err
+	}
+	err = impl.fillDetails([]*bean.ScheduledDeploymentDto{res})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) CreateScheduledDeployment(request *bean.ScheduledDeploymentDto) (*bean.ScheduledDeploymentDto, error) {
+	now := time.Now()
+	if !request.ScheduledAt.After(now) {
+		return nil, util.NewApiError(http.StatusBadRequest, "scheduled time should be in the future", "scheduled time in the past")
+	}
+	if request.ScheduledAt.After(now.AddDate(0, 0, impl.config.MaxDaysAhead)) {
+		return nil, util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("deployment can not be scheduled more than %d days ahead", impl.config.MaxDaysAhead), "scheduled time too far ahead")
+	}
+	overrideRequest := request.OverrideRequest
+	if len(overrideRequest.CdWorkflowType) == 0 {
+		overrideRequest.CdWorkflowType = apiBean.CD_WORKFLOW_TYPE_DEPLOY
+	}
+	pipeline, err := impl.pipelineRepository.FindById(overrideRequest.PipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "pipeline not found", "pipeline not found")
+		}
+		impl.logger.Errorw("error in fetching pipeline", "pipelineId", overrideRequest.PipelineId, "err", err)
+		return nil, err
+	}
+	if pipeline.AppId != overrideRequest.AppId {
+		return nil, util.NewApiError(http.StatusBadRequest, "pipeline does not belong to the app", "invalid app id")
+	}
+	artifact, message, err := impl.validateArtifact(pipeline, overrideRequest.CiArtifactId)
+	if err != nil {
+		return nil, err
+	} else if len(message) > 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, message, message)
+	}
+	if overrideRequest.CdWorkflowType == apiBean.CD_WORKFLOW_TYPE_DEPLOY {
+		// approval and deployment windows are checked at the scheduled time only, as they can be met by then
+		err = impl.cdHandlerService.ValidateArtifactGates(context.Background(), pipeline, artifact)
+		if err != nil {
+			return nil, err
+		}
+	}
+	overrideRequest.UserId = request.UserId
+	overrideRequestJson, err := json.Marshal(overrideRequest)
+	if err != nil {
+		impl.logger.Errorw("error in marshalling override request", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	scheduledDeployment := &repository.ScheduledDeployment{
+		PipelineId:      pipeline.Id,
+		AppId:           pipeline.AppId,
+		EnvironmentId:   pipeline.EnvironmentId,
+		CiArtifactId:    overrideRequest.CiArtifactId,
+		CdWorkflowType:  overrideRequest.CdWorkflowType.String(),
+		OverrideRequest: string(overrideRequestJson),
+		ScheduledAt:     request.ScheduledAt,
+		Status:          bean.StatusPending.String(),
+		AuditLog:        sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.scheduledDeploymentRepository.Save(scheduledDeployment)
+	if err != nil {
+		impl.logger.Errorw("error in saving scheduled deployment", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	return impl.GetScheduledDeployment(scheduledDeployment.Id)
+}
+
+func (impl *ScheduledDeploymentServiceImpl) CancelScheduledDeployment(id int, userId int32) (*bean.ScheduledDeploymentDto, error) {
+	scheduledDeployment, err := impl.findScheduledDeployment(id)
+	if err != nil {
+		return nil, err
+	}
+	if scheduledDeployment.Status != bean.StatusPending.String() {
+		return nil, util.NewApiError(http.StatusConflict,
+			fmt.Sprintf("scheduled deployment is %s, only a pending one can be cancelled", scheduledDeployment.Status), "scheduled deployment not pending")
+	}
+	scheduledDeployment.Status = bean.StatusCancelled.String()
+	scheduledDeployment.UpdateAuditLog(userId)
+	updated, err := impl.scheduledDeploymentRepository.UpdateIfInStatus(scheduledDeployment, bean.StatusPending.String())
+	if err != nil {
+		impl.logger.Errorw("error in cancelling scheduled deployment", "id", id, "err", err)
+		return nil, err
+	}
+	if !updated {
+		return nil, util.NewApiError(http.StatusConflict, "scheduled deployment has already started", "scheduled deployment not pending")
+	}
+	return impl.GetScheduledDeployment(id)
+}
+
+func (impl *ScheduledDeploymentServiceImpl) findScheduledDeployment(id int) (*repository.ScheduledDeployment, error) {
+	scheduledDeployment, err := impl.scheduledDeploymentRepository.FindById(id)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "scheduled deployment not found", "scheduled deployment not found")
+		}
+		impl.logger.Errorw("error in fetching scheduled deployment", "id", id, "err", err)
+		return nil, err
+	}
+	return scheduledDeployment, nil
+}
+
+// fillDetails sets the app, environment and image of the scheduled deployments, deleted pipelines are left out
+func (impl *ScheduledDeploymentServiceImpl) fillDetails(scheduledDeployments []*bean.ScheduledDeploymentDto) error {
+	if len(scheduledDeployments) == 0 {
+		return nil
+	}
+	var pipelineIds, artifactIds []int
+	for _, scheduledDeployment := range scheduledDeployments {
+		pipelineIds = append(pipelineIds, scheduledDeployment.OverrideRequest.PipelineId)
+		artifactIds = append(artifactIds, scheduledDeployment.OverrideRequest.CiArtifactId)
+	}
+	pipelines, err := impl.pipelineRepository.FindByIdsIn(pipelineIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching pipelines", "pipelineIds", pipelineIds, "err", err)
+		return err
+	}
+	pipelineById := make(map[int]*pipelineConfig.Pipeline, len(pipelines))
+	for _, pipeline := range pipelines {
+		pipelineById[pipeline.Id] = pipeline
+	}
+	artifacts, err := impl.ciArtifactRepository.GetByIds(artifactIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching artifacts", "artifactIds", artifactIds, "err", err)
+		return err
+	}
+	imageByArtifactId := make(map[int]string, len(artifacts))
+	for _, artifact := range artifacts {
+		imageByArtifactId[artifact.Id] = artifact.Image
+	}
+	for _, scheduledDeployment := range scheduledDeployments {
+		if pipeline, ok := pipelineById[scheduledDeployment.OverrideRequest.PipelineId]; ok {
+			scheduledDeployment.AppName = pipeline.App.AppName
+			scheduledDeployment.EnvironmentName = pipeline.Environment.Name
+		}
+		scheduledDeployment.Image = imageByArtifactId[scheduledDeployment.OverrideRequest.CiArtifactId]
+	}
+	return nil
+}
+
+// validateArtifact returns the reason for which the artifact can not be deployed on the pipeline, empty if it can be.
+// The artifact should be built by the ci pipeline of the workflow or deployed on the parent stage of the pipeline.
+func (impl *ScheduledDeploymentServiceImpl) validateArtifact(pipeline *pipelineConfig.Pipeline, artifactId int) (*repository2.CiArtifact, string, error) {
+	artifact, err := impl.ciArtifactRepository.Get(artifactId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return nil, bean.ArtifactNotFoundMessage, nil
+		}
+		impl.logger.Errorw("error in fetching artifact", "artifactId", artifactId, "err", err)
+		return nil, "", err
+	}
+	sourceArtifact := artifact
+	if artifact.DataSource == repository2.PRE_CD && artifact.ComponentId == pipeline.Id && artifact.ParentCiArtifact > 0 {
+		// built by the pre stage of the pipeline itself, the artifact it was built from should come from the parent
+		sourceArtifact, err = impl.ciArtifactRepository.Get(artifact.ParentCiArtifact)
+		if err != nil {
+			if util.IsErrNoRows(err) {
+				return nil, bean.ArtifactNotFoundMessage, nil
+			}
+			impl.logger.Errorw("error in fetching parent artifact", "artifactId", artifact.ParentCiArtifact, "err", err)
+			return nil, "", err
+		}
+	}
+	isFromParent, err := impl.isArtifactFromParent(pipeline, sourceArtifact)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isFromParent {
+		return nil, bean.ArtifactMismatchMessage, nil
+	}
+	return artifact, "", nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) isArtifactFromParent(pipeline *pipelineConfig.Pipeline, artifact *repository2.CiArtifact) (bool, error) {
+	parent, err := impl.appWorkflowRepository.GetParentDetailsByPipelineId(pipeline.Id)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return false, nil
+		}
+		impl.logger.Errorw("error in fetching parent of pipeline", "pipelineId", pipeline.Id, "err", err)
+		return false, err
+	}
+	switch parent.ParentType {
+	case appWorkflow.CIPIPELINE:
+		return artifact.PipelineId == parent.ParentId, nil
+	case appWorkflow.WEBHOOK:
+		return artifact.ExternalCiPipelineId == parent.ParentId, nil
+	case appWorkflow.CDPIPELINE:
+		if artifact.DataSource == repository2.POST_CD && artifact.ComponentId == parent.ParentId {
+			return true, nil
+		}
+		successStatuses := []string{cdWorkflow.WorkflowSucceeded, string(health.HealthStatusHealthy)}
+		for _, runnerType := range []apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_DEPLOY, apiBean.CD_WORKFLOW_TYPE_POST} {
+			deployed, err := impl.scheduledDeploymentRepository.IsArtifactDeployedOnPipeline(artifact.Id, parent.ParentId, runnerType.String(), successStatuses)
+			if err != nil {
+				impl.logger.Errorw("error in checking artifact on parent pipeline", "artifactId", artifact.Id, "parentPipelineId", parent.ParentId, "err", err)
+				return false, err
+			}
+			if deployed {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) runDueDeployments() {
+	if !impl.leaderElectionService.IsLeader(leaderElectionBean.ScheduledDeployer) {
+		return
+	}
+	impl.failStaleDeployments()
+	scheduledDeployments, err := impl.scheduledDeploymentRepository.FindDueBefore(time.Now(), bean.StatusPending.String(), dueDeploymentsBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error in fetching due scheduled deployments", "err", err)
+		return
+	}
+	for _, scheduledDeployment := range scheduledDeployments {
+		impl.runScheduledDeployment(scheduledDeployment)
+	}
+}
+
+// failStaleDeployments fails the deployments left running by a replica which went down while triggering them. They are
+// not re-run as the deployment could have been triggered already.
+func (impl *ScheduledDeploymentServiceImpl) failStaleDeployments() {
+	claimedBefore := time.Now().Add(-time.Duration(impl.config.StaleRunningMinutes) * time.Minute)
+	scheduledDeployments, err := impl.scheduledDeploymentRepository.FindClaimedBefore(claimedBefore, bean.StatusRunning.String(), dueDeploymentsBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error in fetching stale scheduled deployments", "err", err)
+		return
+	}
+	for _, scheduledDeployment := range scheduledDeployments {
+		now := time.Now()
+		scheduledDeployment.Status = bean.StatusFailed.String()
+		scheduledDeployment.Message = bean.InterruptedMessage
+		scheduledDeployment.ExecutedOn = &now
+		scheduledDeployment.UpdateAuditLog(userBean.SystemUserId)
+		updated, err := impl.scheduledDeploymentRepository.UpdateIfInStatus(scheduledDeployment, bean.StatusRunning.String())
+		if err != nil || !updated {
+			impl.logger.Errorw("error in failing stale scheduled deployment", "id", scheduledDeployment.Id, "err", err)
+			continue
+		}
+		email, _ := impl.userService.GetActiveEmailById(scheduledDeployment.CreatedBy)
+		impl.sendNotification(scheduledDeployment, email, scheduledDeployment.CreatedBy)
+	}
+}
+
+// runScheduledDeployment re-checks the rbac of the user, the artifact and the deployment gates, as any of them could
+// have changed since the deployment was scheduled, and then triggers the deployment
+func (impl *ScheduledDeploymentServiceImpl) runScheduledDeployment(scheduledDeployment *repository.ScheduledDeployment) {
+	claimedOn := time.Now()
+	scheduledDeployment.Status = bean.StatusRunning.String()
+	scheduledDeployment.ClaimedOn = &claimedOn
+	scheduledDeployment.UpdateAuditLog(userBean.SystemUserId)
+	claimed, err := impl.scheduledDeploymentRepository.UpdateIfInStatus(scheduledDeployment, bean.StatusPending.String())
+	if err != nil || !claimed {
+		// cancelled in between
+		return
+	}
+	triggeredBy := scheduledDeployment.CreatedBy
+	email, wfrId, runErr := impl.triggerScheduledDeployment(scheduledDeployment)
+	now := time.Now()
+	scheduledDeployment.Status = bean.StatusTriggered.String()
+	scheduledDeployment.Message = bean.TriggeredMessage
+	if runErr != nil {
+		impl.logger.Errorw("scheduled deployment failed", "id", scheduledDeployment.Id, "pipelineId", scheduledDeployment.PipelineId, "err", runErr)
+		scheduledDeployment.Status = bean.StatusFailed.String()
+		scheduledDeployment.Message = util.GetClientErrorDetailedMessage(runErr)
+	}
+	scheduledDeployment.CdWorkflowRunnerId = wfrId
+	scheduledDeployment.ExecutedOn = &now
+	scheduledDeployment.UpdateAuditLog(userBean.SystemUserId)
+	_, err = impl.scheduledDeploymentRepository.UpdateIfInStatus(scheduledDeployment, bean.StatusRunning.String())
+	if err != nil {
+		impl.logger.Errorw("error in updating scheduled deployment outcome", "id", scheduledDeployment.Id, "status", scheduledDeployment.Status, "err", err)
+	}
+	impl.sendNotification(scheduledDeployment, email, triggeredBy)
+}
+
+// triggerScheduledDeployment returns the email of the user the deployment is triggered as and the runner of the deployment
+func (impl *ScheduledDeploymentServiceImpl) triggerScheduledDeployment(scheduledDeployment *repository.ScheduledDeployment) (string, int, error) {
+	if delay := time.Since(scheduledDeployment.ScheduledAt); delay > time.Duration(impl.config.MaxDelayMinutes)*time.Minute {
+		message := fmt.Sprintf(bean.MissedMessageTmpl, impl.config.MaxDelayMinutes)
+		return "", 0, util.NewApiError(http.StatusConflict, message, message)
+	}
+	pipeline, err := impl.pipelineRepository.FindById(scheduledDeployment.PipelineId)
+	if err != nil {
+		if util.IsErrNoRows(err) {
+			return "", 0, util.NewApiError(http.StatusNotFound, bean.PipelineDeletedMessage, bean.PipelineDeletedMessage)
+		}
+		return "", 0, err
+	}
+	userId := scheduledDeployment.CreatedBy
+	email, err := impl.userService.GetActiveEmailById(userId)
+	if err != nil || len(email) == 0 {
+		return "", 0, util.NewApiError(http.StatusForbidden, bean.UserInactiveMessage, bean.UserInactiveMessage)
+	}
+	isSuperAdmin, err := impl.userService.IsSuperAdmin(int(userId), "")
+	if err != nil {
+		return email, 0, err
+	}
+	if !isSuperAdmin && !impl.isTriggerAllowed(email, pipeline) {
+		message := fmt.Sprintf(bean.UserNotAllowedMessageTmpl, email)
+		return email, 0, util.NewApiError(http.StatusForbidden, message, message)
+	}
+	artifact, message, err := impl.validateArtifact(pipeline, scheduledDeployment.CiArtifactId)
+	if err != nil {
+		return email, 0, err
+	} else if len(message) > 0 {
+		return email, 0, util.NewApiError(http.StatusConflict, message, message)
+	}
+	if scheduledDeployment.CdWorkflowType == apiBean.CD_WORKFLOW_TYPE_DEPLOY.String() {
+		err = impl.cdHandlerService.ValidateDeploymentGates(context.Background(), pipeline, artifact, userId)
+	} else {
+		_, err = impl.deploymentWindowService.CheckDeploymentAllowed(pipeline.EnvironmentId, pipeline.Environment.Name, isSuperAdmin)
+	}
+	if err != nil {
+		return email, 0, err
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{}
+	err = json.Unmarshal([]byte(scheduledDeployment.OverrideRequest), overrideRequest)
+	if err != nil {
+		return email, 0, err
+	}
+	overrideRequest.UserId = userId
+	userMetadata := &userBean.UserMetadata{
+		UserEmailId:      email,
+		IsUserSuperAdmin: isSuperAdmin,
+		UserId:           userId,
+	}
+	_, _, _, err = impl.cdHandlerService.ManualCdTrigger(triggerBean.TriggerContext{Context: context.Background()}, overrideRequest, userMetadata)
+	return email, overrideRequest.WfrId, err
+}
+
+// isTriggerAllowed checks the trigger access of the user on both the app and the environment of the pipeline
+func (impl *ScheduledDeploymentServiceImpl) isTriggerAllowed(email string, pipeline *pipelineConfig.Pipeline) bool {
+	appObject := impl.enforcerUtil.GetAppRBACNameByAppId(pipeline.AppId)
+	if !impl.enforcer.EnforceByEmail(email, casbin.ResourceApplications, casbin.ActionTrigger, appObject) {
+		return false
+	}
+	envObject := impl.enforcerUtil.GetAppRBACByAppIdAndPipelineId(pipeline.AppId, pipeline.Id)
+	return impl.enforcer.EnforceByEmail(email, casbin.ResourceEnvironment, casbin.ActionTrigger, envObject)
+}
+
+func (impl *ScheduledDeploymentServiceImpl) sendNotification(scheduledDeployment *repository.ScheduledDeployment, email string, userId int32) {
+	event, err := impl.eventFactory.Build(util3.ScheduledDeployment, &scheduledDeployment.PipelineId, scheduledDeployment.AppId, &scheduledDeployment.EnvironmentId, util3.CD)
+	if err != nil {
+		impl.logger.Errorw("error in building scheduled deployment event", "id", scheduledDeployment.Id, "err", err)
+		return
+	}
+	payload := &client.Payload{
+		ScheduledDeploymentStatus:  scheduledDeployment.Status,
+		ScheduledDeploymentMessage: scheduledDeployment.Message,
+		TriggeredBy:                email,
+	}
+	artifact, err := impl.ciArtifactRepository.Get(scheduledDeployment.CiArtifactId)
+	if err == nil {
+		payload.DockerImageUrl = artifact.Image
+	}
+	event.Payload = payload
+	event.UserId = int(userId)
+	event.CiArtifactId = scheduledDeployment.CiArtifactId
+	event.CdWorkflowRunnerId = scheduledDeployment.CdWorkflowRunnerId
+	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
+	if evtErr != nil {
+		impl.logger.Errorw("scheduled deployment event not sent", "id", scheduledDeployment.Id, "error", evtErr)
+	}
+}
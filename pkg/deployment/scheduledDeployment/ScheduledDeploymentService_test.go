@@ -0,0 +1,542 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduledDeployment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	client "github.com/devtron-labs/devtron/client/events"
+	eventMocks "github.com/devtron-labs/devtron/client/events/mocks"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/appWorkflow"
+	appWorkflowMocks "github.com/devtron-labs/devtron/internal/sql/repository/appWorkflow/mocks"
+	repositoryMocks "github.com/devtron-labs/devtron/internal/sql/repository/mocks"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	pipelineConfigMocks "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/mocks"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	casbinMocks "github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin/mocks"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	mock_user "github.com/devtron-labs/devtron/pkg/auth/user/mocks"
+	deploymentWindowMocks "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/mocks"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository"
+	scheduledDeploymentMocks "github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository/mocks"
+	triggerMocks "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/mocks"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	util3 "github.com/devtron-labs/devtron/util/event"
+	rbacMocks "github.com/devtron-labs/devtron/util/rbac/mocks"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testAppId        = 1
+	testPipelineId   = 10
+	testCiPipelineId = 20
+	testParentCdId   = 30
+	testArtifactId   = 1
+	testUserId       = int32(2)
+	testEmail        = "user@example.com"
+	testWfrId        = 100
+)
+
+type scheduledDeploymentServiceMocks struct {
+	scheduledDeploymentRepository *scheduledDeploymentMocks.ScheduledDeploymentRepository
+	pipelineRepository            *pipelineConfigMocks.PipelineRepository
+	appWorkflowRepository         *appWorkflowMocks.AppWorkflowRepository
+	ciArtifactRepository          *repositoryMocks.CiArtifactRepository
+	handlerService                *triggerMocks.HandlerService
+	deploymentWindowService       *deploymentWindowMocks.DeploymentWindowService
+	userService                   *mock_user.UserService
+	enforcer                      *casbinMocks.Enforcer
+	enforcerUtil                  *rbacMocks.EnforcerUtil
+	eventFactory                  *eventMocks.EventFactory
+	eventClient                   *eventMocks.EventClient
+}
+
+func TestIsTriggerAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		appAllowed bool
+		// envAllowed is only checked if the user is allowed on the app
+		envAllowed bool
+		want       bool
+	}{
+		{name: "allowed on both app and environment", appAllowed: true, envAllowed: true, want: true},
+		{name: "allowed on the app only", appAllowed: true},
+		{name: "not allowed on the app", envAllowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestScheduledDeploymentService(t)
+			mockTriggerAccess(m, tt.appAllowed, tt.envAllowed)
+
+			assert.Equal(t, tt.want, impl.isTriggerAllowed(testEmail, testPipeline()))
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestValidateArtifact(t *testing.T) {
+	ciParent := &appWorkflow.AppWorkflowMapping{ParentId: testCiPipelineId, ParentType: appWorkflow.CIPIPELINE}
+	webhookParent := &appWorkflow.AppWorkflowMapping{ParentId: 40, ParentType: appWorkflow.WEBHOOK}
+	cdParent := &appWorkflow.AppWorkflowMapping{ParentId: testParentCdId, ParentType: appWorkflow.CDPIPELINE}
+	tests := []struct {
+		name string
+		// artifacts are looked up in the given order, the last one is not found if artifactNotFound is set
+		artifacts        []*repository2.CiArtifact
+		artifactNotFound bool
+		// parent is nil if the pipeline is not in a workflow
+		parent *appWorkflow.AppWorkflowMapping
+		// deployedOnParentBy are the runner types the artifact is looked up on the parent cd pipeline by,
+		// it is deployed by the last one if deployedOnParent is set
+		deployedOnParentBy []apiBean.WorkflowType
+		deployedOnParent   bool
+		artifactId         int
+		wantMessage        string
+	}{
+		{
+			name:       "built by the ci pipeline of the workflow",
+			artifacts:  []*repository2.CiArtifact{{Id: 1, PipelineId: testCiPipelineId}},
+			parent:     ciParent,
+			artifactId: 1,
+		},
+		{
+			name:        "built by another ci pipeline",
+			artifacts:   []*repository2.CiArtifact{{Id: 1, PipelineId: 99}},
+			parent:      ciParent,
+			artifactId:  1,
+			wantMessage: bean.ArtifactMismatchMessage,
+		},
+		{
+			name:             "deleted artifact",
+			artifactNotFound: true,
+			artifactId:       1,
+			wantMessage:      bean.ArtifactNotFoundMessage,
+		},
+		{
+			name:       "received on the webhook of the workflow",
+			artifacts:  []*repository2.CiArtifact{{Id: 1, ExternalCiPipelineId: 40}},
+			parent:     webhookParent,
+			artifactId: 1,
+		},
+		{
+			name:        "received on another webhook",
+			artifacts:   []*repository2.CiArtifact{{Id: 1, ExternalCiPipelineId: 41}},
+			parent:      webhookParent,
+			artifactId:  1,
+			wantMessage: bean.ArtifactMismatchMessage,
+		},
+		{
+			name:               "deployed on the parent cd pipeline",
+			artifacts:          []*repository2.CiArtifact{{Id: 1, PipelineId: testCiPipelineId}},
+			parent:             cdParent,
+			deployedOnParentBy: []apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_DEPLOY},
+			deployedOnParent:   true,
+			artifactId:         1,
+		},
+		{
+			name:               "deployed by the post stage of the parent cd pipeline",
+			artifacts:          []*repository2.CiArtifact{{Id: 1, PipelineId: testCiPipelineId}},
+			parent:             cdParent,
+			deployedOnParentBy: []apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_DEPLOY, apiBean.CD_WORKFLOW_TYPE_POST},
+			deployedOnParent:   true,
+			artifactId:         1,
+		},
+		{
+			name:               "not deployed on the parent cd pipeline",
+			artifacts:          []*repository2.CiArtifact{{Id: 1, PipelineId: testCiPipelineId}},
+			parent:             cdParent,
+			deployedOnParentBy: []apiBean.WorkflowType{apiBean.CD_WORKFLOW_TYPE_DEPLOY, apiBean.CD_WORKFLOW_TYPE_POST},
+			artifactId:         1,
+			wantMessage:        bean.ArtifactMismatchMessage,
+		},
+		{
+			name:       "built by the post stage of the parent cd pipeline",
+			artifacts:  []*repository2.CiArtifact{{Id: 1, DataSource: repository2.POST_CD, ComponentId: testParentCdId}},
+			parent:     cdParent,
+			artifactId: 1,
+		},
+		{
+			name: "built by the pre stage of the pipeline from an artifact of the parent",
+			artifacts: []*repository2.CiArtifact{
+				{Id: 2, DataSource: repository2.PRE_CD, ComponentId: testPipelineId, ParentCiArtifact: 1},
+				{Id: 1, PipelineId: testCiPipelineId},
+			},
+			parent:     ciParent,
+			artifactId: 2,
+		},
+		{
+			name: "built by the pre stage of the pipeline from an artifact of another pipeline",
+			artifacts: []*repository2.CiArtifact{
+				{Id: 2, DataSource: repository2.PRE_CD, ComponentId: testPipelineId, ParentCiArtifact: 1},
+				{Id: 1, PipelineId: 99},
+			},
+			parent:      ciParent,
+			artifactId:  2,
+			wantMessage: bean.ArtifactMismatchMessage,
+		},
+		{
+			name:        "pipeline not in a workflow",
+			artifacts:   []*repository2.CiArtifact{{Id: 1, PipelineId: testCiPipelineId}},
+			artifactId:  1,
+			wantMessage: bean.ArtifactMismatchMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestScheduledDeploymentService(t)
+			for _, artifact := range tt.artifacts {
+				m.ciArtifactRepository.On("Get", artifact.Id).Return(artifact, nil)
+			}
+			if tt.artifactNotFound {
+				m.ciArtifactRepository.On("Get", tt.artifactId).Return(nil, pg.ErrNoRows)
+			} else {
+				mockParent(m, tt.parent)
+			}
+			for i, runnerType := range tt.deployedOnParentBy {
+				deployed := tt.deployedOnParent && i == len(tt.deployedOnParentBy)-1
+				m.scheduledDeploymentRepository.On("IsArtifactDeployedOnPipeline", 1, testParentCdId, runnerType.String(), mock.Anything).Return(deployed, nil)
+			}
+
+			artifact, message, err := impl.validateArtifact(testPipeline(), tt.artifactId)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMessage, message)
+			if len(tt.wantMessage) == 0 {
+				assert.Equal(t, tt.artifactId, artifact.Id)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestCreateScheduledDeploymentChecksArtifactGates(t *testing.T) {
+	impl, m := newTestScheduledDeploymentService(t)
+	pipeline := testPipeline()
+	artifact := &repository2.CiArtifact{Id: testArtifactId, PipelineId: testCiPipelineId}
+	gatesErr := util.NewApiError(http.StatusPreconditionFailed, "found vulnerability", "found vulnerability")
+	m.pipelineRepository.On("FindById", testPipelineId).Return(pipeline, nil)
+	m.ciArtifactRepository.On("Get", testArtifactId).Return(artifact, nil)
+	mockParent(m, &appWorkflow.AppWorkflowMapping{ParentId: testCiPipelineId, ParentType: appWorkflow.CIPIPELINE})
+	m.handlerService.On("ValidateArtifactGates", mock.Anything, pipeline, artifact).Return(gatesErr)
+
+	// the deployment is not saved, the repository mock fails the test otherwise
+	_, err := impl.CreateScheduledDeployment(&bean.ScheduledDeploymentDto{
+		ScheduledAt: time.Now().Add(time.Hour),
+		OverrideRequest: &apiBean.ValuesOverrideRequest{
+			PipelineId:   testPipelineId,
+			AppId:        testAppId,
+			CiArtifactId: testArtifactId,
+		},
+		UserId: testUserId,
+	})
+	assert.Equal(t, gatesErr, err)
+	m.assertExpectations(t)
+}
+
+func TestRunScheduledDeployment(t *testing.T) {
+	otherParent := &appWorkflow.AppWorkflowMapping{ParentId: 99, ParentType: appWorkflow.CIPIPELINE}
+	tests := []struct {
+		name string
+		// missed is set if the deployment is run past the max delay, e.g. after devtron was down
+		missed bool
+		// claimed is false if the deployment is cancelled in between
+		claimed bool
+		// email is empty if the user who scheduled the deployment is inactive
+		email          string
+		triggerAllowed bool
+		// parent is the ci pipeline of the workflow if not set
+		parent      *appWorkflow.AppWorkflowMapping
+		gatesErr    error
+		triggerErr  error
+		wantStatus  bean.Status
+		wantMessage string
+		wantWfrId   int
+	}{
+		{
+			name:           "pending is triggered",
+			claimed:        true,
+			email:          testEmail,
+			triggerAllowed: true,
+			wantStatus:     bean.StatusTriggered,
+			wantMessage:    bean.TriggeredMessage,
+			wantWfrId:      testWfrId,
+		},
+		{
+			name: "cancelled in between is not claimed",
+		},
+		{
+			name:        "missed by more than the max delay",
+			missed:      true,
+			claimed:     true,
+			wantStatus:  bean.StatusFailed,
+			wantMessage: "missed by more than 60 minutes, not deployed late",
+		},
+		{
+			name:        "inactive user",
+			claimed:     true,
+			wantStatus:  bean.StatusFailed,
+			wantMessage: bean.UserInactiveMessage,
+		},
+		{
+			name:        "user lost trigger access",
+			claimed:     true,
+			email:       testEmail,
+			wantStatus:  bean.StatusFailed,
+			wantMessage: "user@example.com is no longer allowed to deploy the pipeline",
+		},
+		{
+			name:           "artifact no longer from the parent",
+			claimed:        true,
+			email:          testEmail,
+			triggerAllowed: true,
+			parent:         otherParent,
+			wantStatus:     bean.StatusFailed,
+			wantMessage:    bean.ArtifactMismatchMessage,
+		},
+		{
+			name:           "blocked by deployment gates at the scheduled time",
+			claimed:        true,
+			email:          testEmail,
+			triggerAllowed: true,
+			gatesErr:       util.NewApiError(http.StatusPreconditionFailed, "artifact is not approved", "artifact is not approved"),
+			wantStatus:     bean.StatusFailed,
+			wantMessage:    "artifact is not approved",
+		},
+		{
+			name:           "trigger failed",
+			claimed:        true,
+			email:          testEmail,
+			triggerAllowed: true,
+			triggerErr:     errors.New("helm install failed"),
+			wantStatus:     bean.StatusFailed,
+			wantMessage:    "helm install failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestScheduledDeploymentService(t)
+			scheduledAt := time.Now().Add(-time.Minute)
+			if tt.missed {
+				scheduledAt = time.Now().Add(-2 * time.Hour)
+			}
+			scheduledDeployment := testScheduledDeployment(t, bean.StatusPending, scheduledAt)
+			pipeline := testPipeline()
+			artifact := &repository2.CiArtifact{Id: testArtifactId, PipelineId: testCiPipelineId}
+			m.scheduledDeploymentRepository.On("UpdateIfInStatus", scheduledDeployment, bean.StatusPending.String()).Return(tt.claimed, nil).Once()
+			if tt.claimed {
+				if !tt.missed {
+					mockTriggerChecks(m, pipeline, artifact, tt.email, tt.triggerAllowed, tt.parent)
+				}
+				if tt.triggerAllowed && tt.parent == nil {
+					m.handlerService.On("ValidateDeploymentGates", mock.Anything, pipeline, artifact, testUserId).Return(tt.gatesErr)
+				}
+				if tt.triggerAllowed && tt.parent == nil && tt.gatesErr == nil {
+					m.handlerService.On("ManualCdTrigger", mock.Anything, mock.MatchedBy(func(overrideRequest *apiBean.ValuesOverrideRequest) bool {
+						return overrideRequest.CiArtifactId == testArtifactId && overrideRequest.UserId == testUserId
+					}), mock.MatchedBy(func(userMetadata *userBean.UserMetadata) bool {
+						return userMetadata.UserEmailId == testEmail && userMetadata.UserId == testUserId
+					})).Run(func(args mock.Arguments) {
+						if tt.triggerErr == nil {
+							args.Get(1).(*apiBean.ValuesOverrideRequest).WfrId = testWfrId
+						}
+					}).Return(0, "", nil, tt.triggerErr)
+				}
+				m.scheduledDeploymentRepository.On("UpdateIfInStatus", scheduledDeployment, bean.StatusRunning.String()).Return(true, nil).Once()
+				mockNotification(m, tt.wantStatus)
+			}
+
+			// the mocks fail the test if a deployment which is not claimed is triggered
+			impl.runScheduledDeployment(scheduledDeployment)
+			if tt.claimed {
+				assert.Equal(t, tt.wantStatus.String(), scheduledDeployment.Status)
+				assert.Equal(t, tt.wantMessage, scheduledDeployment.Message)
+				assert.Equal(t, tt.wantWfrId, scheduledDeployment.CdWorkflowRunnerId)
+				assert.NotNil(t, scheduledDeployment.ClaimedOn)
+				assert.NotNil(t, scheduledDeployment.ExecutedOn)
+			} else {
+				assert.Nil(t, scheduledDeployment.ExecutedOn)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestFailStaleDeployments(t *testing.T) {
+	tests := []struct {
+		name string
+		// stale is false if no deployment is running past the stale time
+		stale bool
+		// updated is false if the deployment is updated by some other replica in between
+		updated    bool
+		wantStatus bean.Status
+	}{
+		{name: "running past the stale time is failed", stale: true, updated: true, wantStatus: bean.StatusFailed},
+		{name: "updated in between is not notified", stale: true, wantStatus: bean.StatusFailed},
+		{name: "none running past the stale time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl, m := newTestScheduledDeploymentService(t)
+			scheduledDeployment := testScheduledDeployment(t, bean.StatusRunning, time.Now().Add(-2*time.Hour))
+			var staleDeployments []*repository.ScheduledDeployment
+			if tt.stale {
+				staleDeployments = append(staleDeployments, scheduledDeployment)
+				m.scheduledDeploymentRepository.On("UpdateIfInStatus", scheduledDeployment, bean.StatusRunning.String()).Return(tt.updated, nil)
+			}
+			m.scheduledDeploymentRepository.On("FindClaimedBefore", mock.MatchedBy(func(claimedBefore time.Time) bool {
+				// claimed more than the configured 30 minutes ago
+				staleFor := time.Since(claimedBefore)
+				return staleFor >= 30*time.Minute && staleFor < 31*time.Minute
+			}), bean.StatusRunning.String(), dueDeploymentsBatchSize).Return(staleDeployments, nil)
+			if tt.updated {
+				m.userService.On("GetActiveEmailById", testUserId).Return(testEmail, nil)
+				mockNotification(m, bean.StatusFailed)
+			}
+
+			// the handler service mock fails the test if a stale deployment is triggered again
+			impl.failStaleDeployments()
+			if tt.stale {
+				assert.Equal(t, tt.wantStatus.String(), scheduledDeployment.Status)
+				assert.Equal(t, bean.InterruptedMessage, scheduledDeployment.Message)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func newTestScheduledDeploymentService(t *testing.T) (*ScheduledDeploymentServiceImpl, *scheduledDeploymentServiceMocks) {
+	// the executor cron is not started, deployments are run by the tests themselves
+	t.Setenv("SCHEDULED_DEPLOYMENT_ENABLED", "false")
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	m := &scheduledDeploymentServiceMocks{
+		scheduledDeploymentRepository: scheduledDeploymentMocks.NewScheduledDeploymentRepository(t),
+		pipelineRepository:            pipelineConfigMocks.NewPipelineRepository(t),
+		appWorkflowRepository:         appWorkflowMocks.NewAppWorkflowRepository(t),
+		ciArtifactRepository:          repositoryMocks.NewCiArtifactRepository(t),
+		handlerService:                triggerMocks.NewHandlerService(t),
+		deploymentWindowService:       deploymentWindowMocks.NewDeploymentWindowService(t),
+		userService:                   mock_user.NewUserService(t),
+		enforcer:                      casbinMocks.NewEnforcer(t),
+		enforcerUtil:                  rbacMocks.NewEnforcerUtil(t),
+		eventFactory:                  eventMocks.NewEventFactory(t),
+		eventClient:                   eventMocks.NewEventClient(t),
+	}
+	impl, err := NewScheduledDeploymentServiceImpl(logger, m.scheduledDeploymentRepository, m.pipelineRepository, m.appWorkflowRepository,
+		m.ciArtifactRepository, m.handlerService, m.deploymentWindowService, m.userService, m.enforcer, m.enforcerUtil,
+		m.eventFactory, m.eventClient, nil, nil)
+	assert.NoError(t, err)
+	return impl, m
+}
+
+func (m *scheduledDeploymentServiceMocks) assertExpectations(t *testing.T) {
+	m.scheduledDeploymentRepository.AssertExpectations(t)
+	m.pipelineRepository.AssertExpectations(t)
+	m.appWorkflowRepository.AssertExpectations(t)
+	m.ciArtifactRepository.AssertExpectations(t)
+	m.handlerService.AssertExpectations(t)
+	m.deploymentWindowService.AssertExpectations(t)
+	m.userService.AssertExpectations(t)
+	m.enforcer.AssertExpectations(t)
+	m.enforcerUtil.AssertExpectations(t)
+	m.eventFactory.AssertExpectations(t)
+	m.eventClient.AssertExpectations(t)
+}
+
+func testPipeline() *pipelineConfig.Pipeline {
+	return &pipelineConfig.Pipeline{Id: testPipelineId, AppId: testAppId, EnvironmentId: 5}
+}
+
+// testScheduledDeployment returns a deployment of the test artifact scheduled by the test user in the given status
+func testScheduledDeployment(t *testing.T, status bean.Status, scheduledAt time.Time) *repository.ScheduledDeployment {
+	overrideRequest, err := json.Marshal(&apiBean.ValuesOverrideRequest{
+		PipelineId:     testPipelineId,
+		AppId:          testAppId,
+		CiArtifactId:   testArtifactId,
+		CdWorkflowType: apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+	})
+	assert.NoError(t, err)
+	return &repository.ScheduledDeployment{
+		Id:              1,
+		PipelineId:      testPipelineId,
+		AppId:           testAppId,
+		EnvironmentId:   5,
+		CiArtifactId:    testArtifactId,
+		CdWorkflowType:  apiBean.CD_WORKFLOW_TYPE_DEPLOY.String(),
+		OverrideRequest: string(overrideRequest),
+		ScheduledAt:     scheduledAt,
+		Status:          status.String(),
+		AuditLog:        sql.NewDefaultAuditLog(testUserId),
+	}
+}
+
+func mockTriggerAccess(m *scheduledDeploymentServiceMocks, appAllowed, envAllowed bool) {
+	m.enforcerUtil.On("GetAppRBACNameByAppId", testAppId).Return("team/app")
+	m.enforcer.On("EnforceByEmail", testEmail, casbin.ResourceApplications, casbin.ActionTrigger, "team/app").Return(appAllowed)
+	if appAllowed {
+		m.enforcerUtil.On("GetAppRBACByAppIdAndPipelineId", testAppId, testPipelineId).Return("env/app")
+		m.enforcer.On("EnforceByEmail", testEmail, casbin.ResourceEnvironment, casbin.ActionTrigger, "env/app").Return(envAllowed)
+	}
+}
+
+// mockParent expects the parent of the pipeline to be looked up, nil if the pipeline is not in a workflow
+func mockParent(m *scheduledDeploymentServiceMocks, parent *appWorkflow.AppWorkflowMapping) {
+	if parent == nil {
+		m.appWorkflowRepository.On("GetParentDetailsByPipelineId", testPipelineId).Return(nil, pg.ErrNoRows)
+		return
+	}
+	m.appWorkflowRepository.On("GetParentDetailsByPipelineId", testPipelineId).Return(parent, nil)
+}
+
+// mockTriggerChecks expects the checks run before triggering a claimed deployment, up to the first one failing.
+// parent is the ci pipeline of the workflow if nil.
+func mockTriggerChecks(m *scheduledDeploymentServiceMocks, pipeline *pipelineConfig.Pipeline, artifact *repository2.CiArtifact,
+	email string, triggerAllowed bool, parent *appWorkflow.AppWorkflowMapping) {
+	m.pipelineRepository.On("FindById", testPipelineId).Return(pipeline, nil)
+	if len(email) == 0 {
+		m.userService.On("GetActiveEmailById", testUserId).Return("", pg.ErrNoRows)
+		return
+	}
+	m.userService.On("GetActiveEmailById", testUserId).Return(email, nil)
+	m.userService.On("IsSuperAdmin", int(testUserId), "").Return(false, nil)
+	mockTriggerAccess(m, true, triggerAllowed)
+	if !triggerAllowed {
+		return
+	}
+	m.ciArtifactRepository.On("Get", testArtifactId).Return(artifact, nil).Once()
+	if parent == nil {
+		parent = &appWorkflow.AppWorkflowMapping{ParentId: testCiPipelineId, ParentType: appWorkflow.CIPIPELINE}
+	}
+	mockParent(m, parent)
+}
+
+// mockNotification expects a notification of the deployment outcome with the image of the artifact
+func mockNotification(m *scheduledDeploymentServiceMocks, wantStatus bean.Status) {
+	m.eventFactory.On("Build", util3.ScheduledDeployment, mock.Anything, testAppId, mock.Anything, util3.CD).Return(client.Event{}, nil)
+	m.ciArtifactRepository.On("Get", testArtifactId).Return(&repository2.CiArtifact{Id: testArtifactId, Image: "app:v1"}, nil).Once()
+	m.eventClient.On("WriteNotificationEvent", mock.MatchedBy(func(event client.Event) bool {
+		return event.Payload.ScheduledDeploymentStatus == wantStatus.String() && event.Payload.DockerImageUrl == "app:v1"
+	})).Return(true, nil)
+}
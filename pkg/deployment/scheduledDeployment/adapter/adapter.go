@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"encoding/json"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository"
+)
+
+func GetScheduledDeploymentDto(scheduledDeployment *repository.ScheduledDeployment) (*bean.ScheduledDeploymentDto, error) {
+	overrideRequest := &apiBean.ValuesOverrideRequest{}
+	err := json.Unmarshal([]byte(scheduledDeployment.OverrideRequest), overrideRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &bean.ScheduledDeploymentDto{
+		Id:                 scheduledDeployment.Id,
+		ScheduledAt:        scheduledDeployment.ScheduledAt,
+		OverrideRequest:    overrideRequest,
+		EnvironmentId:      scheduledDeployment.EnvironmentId,
+		Status:             bean.Status(scheduledDeployment.Status),
+		Message:            scheduledDeployment.Message,
+		CdWorkflowRunnerId: scheduledDeployment.CdWorkflowRunnerId,
+		ExecutedOn:         scheduledDeployment.ExecutedOn,
+		CreatedBy:          scheduledDeployment.CreatedBy,
+		CreatedOn:          scheduledDeployment.CreatedOn,
+	}, nil
+}
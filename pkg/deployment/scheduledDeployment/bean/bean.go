@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"time"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+)
+
+// CATEGORY=SCHEDULED_DEPLOYMENT
+type ScheduledDeploymentConfig struct {
+	Enabled             bool   `env:"SCHEDULED_DEPLOYMENT_ENABLED" envDefault:"true" description:"Enables the one-off deployments scheduled for a later time"`
+	ExecutorCron        string `env:"SCHEDULED_DEPLOYMENT_CRON" envDefault:"* * * * *" description:"Cron at which the leader replica runs the due scheduled deployments"`
+	MaxDaysAhead        int    `env:"SCHEDULED_DEPLOYMENT_MAX_DAYS_AHEAD" envDefault:"90" description:"Max number of days ahead a deployment can be scheduled"`
+	MaxDelayMinutes     int    `env:"SCHEDULED_DEPLOYMENT_MAX_DELAY_MINS" envDefault:"60" description:"Scheduled deployments missed by more than these minutes, e.g. while devtron was down, are failed instead of deployed late"`
+	StaleRunningMinutes int    `env:"SCHEDULED_DEPLOYMENT_STALE_RUNNING_MINS" envDefault:"30" description:"Scheduled deployments claimed by a replica which went down before recording the outcome are failed after these minutes"`
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"
+	StatusRunning   Status = "RUNNING"
+	StatusTriggered Status = "TRIGGERED"
+	StatusFailed    Status = "FAILED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+const (
+	PipelineDeletedMessage    = "pipeline is deleted"
+	UserInactiveMessage       = "user who scheduled the deployment is no longer active"
+	UserNotAllowedMessageTmpl = "%s is no longer allowed to deploy the pipeline"
+	ArtifactNotFoundMessage   = "artifact is deleted"
+	ArtifactMismatchMessage   = "artifact is not built by the ci pipeline or deployed on the parent stage of the pipeline"
+	InterruptedMessage        = "interrupted while triggering, check the deployment history of the pipeline before scheduling again"
+	MissedMessageTmpl         = "missed by more than %d minutes, not deployed late"
+	TriggeredMessage          = "deployment triggered"
+)
+
+// ScheduledDeploymentDto is a deployment of a cd pipeline queued for a later time, the override request is the same
+// one accepted by the manual trigger
+type ScheduledDeploymentDto struct {
+	Id                 int                            `json:"id"`
+	ScheduledAt        time.Time                      `json:"scheduledAt" validate:"required"`
+	OverrideRequest    *apiBean.ValuesOverrideRequest `json:"overrideRequest" validate:"required"`
+	AppName            string                         `json:"appName,omitempty"`
+	EnvironmentId      int                            `json:"environmentId"`
+	EnvironmentName    string                         `json:"environmentName,omitempty"`
+	Image              string                         `json:"image,omitempty"`
+	Status             Status                         `json:"status"`
+	Message            string                         `json:"message,omitempty"`
+	CdWorkflowRunnerId int                            `json:"cdWorkflowRunnerId,omitempty"`
+	ExecutedOn         *time.Time                     `json:"executedOn,omitempty"`
+	CreatedBy          int32                          `json:"createdBy"`
+	CreatedOn          time.Time                      `json:"createdOn"`
+	UserId             int32                          `json:"-"`
+}
+
+type ListFilter struct {
+	AppId      int
+	PipelineId int
+	// Statuses defaults to the pending ones
+	Statuses []string
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ScheduledDeployment struct {
+	tableName          struct{}   `sql:"scheduled_deployment" pg:",discard_unknown_columns"`
+	Id                 int        `sql:"id,pk"`
+	PipelineId         int        `sql:"pipeline_id,notnull"`
+	AppId              int        `sql:"app_id,notnull"`
+	EnvironmentId      int        `sql:"environment_id,notnull"`
+	CiArtifactId       int        `sql:"ci_artifact_id,notnull"`
+	CdWorkflowType     string     `sql:"cd_workflow_type,notnull"`
+	OverrideRequest    string     `sql:"override_request,notnull"`
+	ScheduledAt        time.Time  `sql:"scheduled_at,notnull"`
+	Status             string     `sql:"status,notnull"`
+	Message            string     `sql:"message"`
+	CdWorkflowRunnerId int        `sql:"cd_workflow_runner_id"`
+	ExecutedOn         *time.Time `sql:"executed_on"`
+	// ClaimedOn is set when a replica moves the deployment to running, to find the ones left running by a replica
+	// which went down
+	ClaimedOn *time.Time `sql:"claimed_on"`
+	sql.AuditLog
+}
+
+type ScheduledDeploymentRepository interface {
+	Save(scheduledDeployment *ScheduledDeployment) error
+	// UpdateIfInStatus updates the scheduled deployment only if it is still in the given status, so it is run or
+	// cancelled once. false is returned if its status changed in between.
+	UpdateIfInStatus(scheduledDeployment *ScheduledDeployment, status string) (bool, error)
+	FindById(id int) (*ScheduledDeployment, error)
+	FindAll(appId, pipelineId int, statuses []string) ([]*ScheduledDeployment, error)
+	FindDueBefore(time time.Time, status string, limit int) ([]*ScheduledDeployment, error)
+	FindClaimedBefore(time time.Time, status string, limit int) ([]*ScheduledDeployment, error)
+	// IsArtifactDeployedOnPipeline checks if the artifact has a runner of the given type in any of the statuses on the pipeline
+	IsArtifactDeployedOnPipeline(artifactId, pipelineId int, runnerType string, statuses []string) (bool, error)
+}
+
+type ScheduledDeploymentRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewScheduledDeploymentRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ScheduledDeploymentRepositoryImpl {
+	return &ScheduledDeploymentRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) Save(scheduledDeployment *ScheduledDeployment) error {
+	return impl.dbConnection.Insert(scheduledDeployment)
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) UpdateIfInStatus(scheduledDeployment *ScheduledDeployment, status string) (bool, error) {
+	res, err := impl.dbConnection.Model(scheduledDeployment).
+		WherePK().
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindById(id int) (*ScheduledDeployment, error) {
+	scheduledDeployment := &ScheduledDeployment{}
+	err := impl.dbConnection.Model(scheduledDeployment).
+		Where("id = ?", id).
+		Select()
+	return scheduledDeployment, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindAll(appId, pipelineId int, statuses []string) ([]*ScheduledDeployment, error) {
+	var scheduledDeployments []*ScheduledDeployment
+	query := impl.dbConnection.Model(&scheduledDeployments).
+		Where("status IN (?)", pg.In(statuses))
+	if appId > 0 {
+		query = query.Where("app_id = ?", appId)
+	}
+	if pipelineId > 0 {
+		query = query.Where("pipeline_id = ?", pipelineId)
+	}
+	err := query.Order("scheduled_at ASC").Select()
+	return scheduledDeployments, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindDueBefore(time time.Time, status string, limit int) ([]*ScheduledDeployment, error) {
+	var scheduledDeployments []*ScheduledDeployment
+	err := impl.dbConnection.Model(&scheduledDeployments).
+		Where("status = ?", status).
+		Where("scheduled_at <= ?", time).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Select()
+	return scheduledDeployments, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindClaimedBefore(time time.Time, status string, limit int) ([]*ScheduledDeployment, error) {
+	var scheduledDeployments []*ScheduledDeployment
+	err := impl.dbConnection.Model(&scheduledDeployments).
+		Where("status = ?", status).
+		Where("claimed_on <= ?", time).
+		Order("claimed_on ASC").
+		Limit(limit).
+		Select()
+	return scheduledDeployments, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) IsArtifactDeployedOnPipeline(artifactId, pipelineId int, runnerType string, statuses []string) (bool, error) {
+	var count int
+	query := "SELECT COUNT(wfr.id) FROM cd_workflow_runner wfr" +
+		" INNER JOIN cd_workflow wf ON wf.id = wfr.cd_workflow_id" +
+		" WHERE wf.ci_artifact_id = ? AND wf.pipeline_id = ? AND wfr.workflow_type = ? AND wfr.status IN (?);"
+	_, err := impl.dbConnection.Query(&count, query, artifactId, pipelineId, runnerType, pg.In(statuses))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
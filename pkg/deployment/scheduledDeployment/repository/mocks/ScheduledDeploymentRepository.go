@@ -0,0 +1,223 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	repository "github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduledDeploymentRepository is an autogenerated mock type for the ScheduledDeploymentRepository type
+type ScheduledDeploymentRepository struct {
+	mock.Mock
+}
+
+// FindAll provides a mock function with given fields: appId, pipelineId, statuses
+func (_m *ScheduledDeploymentRepository) FindAll(appId int, pipelineId int, statuses []string) ([]*repository.ScheduledDeployment, error) {
+	ret := _m.Called(appId, pipelineId, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []*repository.ScheduledDeployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, []string) ([]*repository.ScheduledDeployment, error)); ok {
+		return rf(appId, pipelineId, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, []string) []*repository.ScheduledDeployment); ok {
+		r0 = rf(appId, pipelineId, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.ScheduledDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, []string) error); ok {
+		r1 = rf(appId, pipelineId, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindById provides a mock function with given fields: id
+func (_m *ScheduledDeploymentRepository) FindById(id int) (*repository.ScheduledDeployment, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindById")
+	}
+
+	var r0 *repository.ScheduledDeployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*repository.ScheduledDeployment, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *repository.ScheduledDeployment); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ScheduledDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindClaimedBefore provides a mock function with given fields: _a0, status, limit
+func (_m *ScheduledDeploymentRepository) FindClaimedBefore(_a0 time.Time, status string, limit int) ([]*repository.ScheduledDeployment, error) {
+	ret := _m.Called(_a0, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindClaimedBefore")
+	}
+
+	var r0 []*repository.ScheduledDeployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, string, int) ([]*repository.ScheduledDeployment, error)); ok {
+		return rf(_a0, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, string, int) []*repository.ScheduledDeployment); ok {
+		r0 = rf(_a0, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.ScheduledDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, string, int) error); ok {
+		r1 = rf(_a0, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDueBefore provides a mock function with given fields: _a0, status, limit
+func (_m *ScheduledDeploymentRepository) FindDueBefore(_a0 time.Time, status string, limit int) ([]*repository.ScheduledDeployment, error) {
+	ret := _m.Called(_a0, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDueBefore")
+	}
+
+	var r0 []*repository.ScheduledDeployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, string, int) ([]*repository.ScheduledDeployment, error)); ok {
+		return rf(_a0, status, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, string, int) []*repository.ScheduledDeployment); ok {
+		r0 = rf(_a0, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repository.ScheduledDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, string, int) error); ok {
+		r1 = rf(_a0, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsArtifactDeployedOnPipeline provides a mock function with given fields: artifactId, pipelineId, runnerType, statuses
+func (_m *ScheduledDeploymentRepository) IsArtifactDeployedOnPipeline(artifactId int, pipelineId int, runnerType string, statuses []string) (bool, error) {
+	ret := _m.Called(artifactId, pipelineId, runnerType, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsArtifactDeployedOnPipeline")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) (bool, error)); ok {
+		return rf(artifactId, pipelineId, runnerType, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, string, []string) bool); ok {
+		r0 = rf(artifactId, pipelineId, runnerType, statuses)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, string, []string) error); ok {
+		r1 = rf(artifactId, pipelineId, runnerType, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: scheduledDeployment
+func (_m *ScheduledDeploymentRepository) Save(scheduledDeployment *repository.ScheduledDeployment) error {
+	ret := _m.Called(scheduledDeployment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*repository.ScheduledDeployment) error); ok {
+		r0 = rf(scheduledDeployment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateIfInStatus provides a mock function with given fields: scheduledDeployment, status
+func (_m *ScheduledDeploymentRepository) UpdateIfInStatus(scheduledDeployment *repository.ScheduledDeployment, status string) (bool, error) {
+	ret := _m.Called(scheduledDeployment, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIfInStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*repository.ScheduledDeployment, string) (bool, error)); ok {
+		return rf(scheduledDeployment, status)
+	}
+	if rf, ok := ret.Get(0).(func(*repository.ScheduledDeployment, string) bool); ok {
+		r0 = rf(scheduledDeployment, status)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*repository.ScheduledDeployment, string) error); ok {
+		r1 = rf(scheduledDeployment, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewScheduledDeploymentRepository creates a new instance of ScheduledDeploymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduledDeploymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduledDeploymentRepository {
+	mock := &ScheduledDeploymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
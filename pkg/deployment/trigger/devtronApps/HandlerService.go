@@ -21,6 +21,7 @@ import (
 	"context"
 	"github.com/devtron-labs/common-lib/async"
 	"github.com/devtron-labs/devtron/client/fluxcd"
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
 	service2 "github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/service"
 	"github.com/devtron-labs/devtron/pkg/workflow/workflowStatusLatest"
 	"os"
@@ -46,14 +47,21 @@ import (
 	"github.com/devtron-labs/devtron/pkg/attributes"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
 	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitMaterial/read"
 	pipeline2 "github.com/devtron-labs/devtron/pkg/build/pipeline"
+	"github.com/devtron-labs/devtron/pkg/build/testReport"
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	"github.com/devtron-labs/devtron/pkg/cluster"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	repository5 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback"
+	clusterSetRepository "github.com/devtron-labs/devtron/pkg/deployment/clusterSet/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
 	bean9 "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
@@ -70,6 +78,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/plugin"
 	security2 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning"
 	read2 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/read"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/devtron-labs/devtron/pkg/variables"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
@@ -90,6 +99,12 @@ preStageHandlerCode_ent.go - ent code related to pre stage trigger
 postStageHandlerCode.go - code related to post stage trigger
 postStageHandlerCode_ent.go - ent code related to post stage trigger
 prePostWfAndLogsCode.go - code containing pre/post wf handling(abort) and logs related code
+deploymentWindowHandlerCode.go - code related to deployment window enforcement and queued triggers
+deploymentApprovalHandlerCode.go - code related to deployment approval enforcement
+autoRollbackHandlerCode.go - code related to auto rollback of unhealthy deployments
+testGateHandlerCode.go - code related to test gate enforcement on test reports of the ci workflow
+signatureVerificationHandlerCode.go - code related to image signature verification policies of the environment
+clusterSetHandlerCode.go - code related to the releases of the pipeline on the member clusters of its cluster set
 */
 
 type HandlerService interface {
@@ -108,6 +123,32 @@ type HandlerService interface {
 	CancelStage(workflowRunnerId int, forceAbort bool, userId int32) (int, error)
 	DownloadCdWorkflowArtifacts(buildId int) (*os.File, error)
 	GetRunningWorkflowLogs(environmentId int, pipelineId int, workflowId int, followLogs bool) (*bufio.Reader, func() error, error)
+
+	// ReleaseDeploymentWindowQueuedTriggers triggers the automatic deployments which were queued
+	// because of a deployment window and whose environment is now open for deployment
+	ReleaseDeploymentWindowQueuedTriggers()
+	// TriggerAutoRollbacks redeploys the last healthy deployment of the pipelines with auto rollback enabled
+	// whose latest deployment is not healthy within the health timeout
+	TriggerAutoRollbacks()
+	// ReconcileDrift redeploys the drifted deployment of the pipeline with its configuration snapshot,
+	// the runner created for the redeployment is returned even if the release fails
+	ReconcileDrift(request *driftBean.ReconcileRequest) (int, error)
+	// ValidateDeploymentGates runs the checks of a manual deployment which do not need a workflow runner: deployment window,
+	// approval, test gate, vulnerability and image signature, for deployments which are not released through a runner.
+	// super admins bypass the deployment windows as in a manual trigger
+	ValidateDeploymentGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository3.CiArtifact, triggeredBy int32) error
+	// ValidateArtifactGates runs the gates of ValidateDeploymentGates which depend only on the artifact: test gate,
+	// vulnerability and image signature
+	ValidateArtifactGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository3.CiArtifact) error
+	// TriggerClusterSetMemberRelease releases the artifact on a member cluster of the cluster set of the pipeline, with the
+	// last saved config and the scoped variables resolved for the cluster, in a runner of its own. The runner is returned
+	// even if the release fails, it is marked failed then.
+	TriggerClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository3.CiArtifact, cluster *repository5.Cluster, triggeredBy int32) (int, error)
+	// SyncClusterSetMemberRunnerStatus updates the runner of a member cluster release from the status of the release on
+	// the cluster, it is marked failed if not healthy within the timeout
+	SyncClusterSetMemberRunnerStatus(ctx context.Context, pipeline *pipelineConfig.Pipeline, runnerId, clusterId int, timeout time.Duration) (*pipelineConfig.CdWorkflowRunner, error)
+	// DeleteClusterSetMemberRelease deletes the release of the pipeline on a member cluster, a missing release is ignored
+	DeleteClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline, deploymentAppType string, clusterId int) error
 }
 
 type HandlerServiceImpl struct {
@@ -176,6 +217,13 @@ type HandlerServiceImpl struct {
 	workflowTriggerAuditService         service2.WorkflowTriggerAuditService
 	fluxCdDeploymentService             fluxcd.DeploymentService
 	workflowStatusLatestService         workflowStatusLatest.WorkflowStatusLatestService
+	deploymentWindowService             deploymentWindow.DeploymentWindowService
+	deploymentApprovalService           approval.DeploymentApprovalService
+	autoRollbackService                 autoRollback.AutoRollbackService
+	testGateService                     testReport.TestGateService
+	imageSignatureService               imageSigning.ImageSignatureService
+	commitStatusService                 commitStatus.CommitStatusService
+	clusterSetRepository                clusterSetRepository.ClusterSetRepository
 }
 
 func NewHandlerServiceImpl(logger *zap.SugaredLogger,
@@ -241,7 +289,14 @@ func NewHandlerServiceImpl(logger *zap.SugaredLogger,
 	asyncRunnable *async.Runnable,
 	workflowTriggerAuditService service2.WorkflowTriggerAuditService,
 	fluxCdDeploymentService fluxcd.DeploymentService,
-	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService) (*HandlerServiceImpl, error) {
+	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
+	deploymentWindowService deploymentWindow.DeploymentWindowService,
+	deploymentApprovalService approval.DeploymentApprovalService,
+	autoRollbackService autoRollback.AutoRollbackService,
+	testGateService testReport.TestGateService,
+	imageSignatureService imageSigning.ImageSignatureService,
+	commitStatusService commitStatus.CommitStatusService,
+	clusterSetRepository clusterSetRepository.ClusterSetRepository) (*HandlerServiceImpl, error) {
 	impl := &HandlerServiceImpl{
 		logger:                              logger,
 		cdWorkflowCommonService:             cdWorkflowCommonService,
@@ -312,6 +367,13 @@ func NewHandlerServiceImpl(logger *zap.SugaredLogger,
 		workflowTriggerAuditService: workflowTriggerAuditService,
 		fluxCdDeploymentService:     fluxCdDeploymentService,
 		workflowStatusLatestService: workflowStatusLatestService,
+		deploymentWindowService:     deploymentWindowService,
+		deploymentApprovalService:   deploymentApprovalService,
+		autoRollbackService:         autoRollbackService,
+		testGateService:             testGateService,
+		imageSignatureService:       imageSignatureService,
+		commitStatusService:         commitStatusService,
+		clusterSetRepository:        clusterSetRepository,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -328,6 +390,14 @@ func (impl *HandlerServiceImpl) writeCDTriggerEvent(overrideRequest *bean3.Value
 		impl.logger.Errorw("error in building cd trigger event", "cdPipelineId", overrideRequest.PipelineId, "err", err)
 	}
 	impl.logger.Debugw("event WriteCDTriggerEvent", "event", event)
+	impl.commitStatusService.ReportDeploymentStatusAsync(&commitStatusBean.DeploymentStatusRequest{
+		AppId:              overrideRequest.AppId,
+		EnvId:              overrideRequest.EnvId,
+		PipelineId:         overrideRequest.PipelineId,
+		CiArtifactId:       artifact.Id,
+		CdWorkflowRunnerId: wfrId,
+		State:              commitStatusBean.StatePending,
+	})
 	wfr := impl.getEnrichedWorkflowRunner(overrideRequest, artifact, wfrId)
 	event = impl.eventFactory.BuildExtraCDData(event, wfr, pipelineOverrideId, bean3.CD_WORKFLOW_TYPE_DEPLOY)
 	_, evtErr := impl.eventClient.WriteNotificationEvent(event)
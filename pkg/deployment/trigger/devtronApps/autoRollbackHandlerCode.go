@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package devtronApps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bean3 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	bean2 "github.com/devtron-labs/devtron/pkg/deployment/autoRollback/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+)
+
+func (impl *HandlerServiceImpl) TriggerAutoRollbacks() {
+	candidates, err := impl.autoRollbackService.GetRollbackCandidates(time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching auto rollback candidates", "err", err)
+		return
+	}
+	for _, candidate := range candidates {
+		historyId, claimed, err := impl.autoRollbackService.ClaimRollback(candidate)
+		if err != nil || !claimed {
+			// claimed by some other replica
+			continue
+		}
+		if candidate.IsRollbackDeployment || candidate.TargetWfrId == 0 {
+			message := bean2.NoHealthyDeploymentMessage
+			if candidate.IsRollbackDeployment {
+				message = bean2.SkippedForRollbackDeploymentMessage
+			}
+			impl.logger.Infow("unhealthy deployment not rolled back", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId, "reason", message)
+			impl.saveAutoRollbackTimeline(candidate.FailedWfrId, timelineStatus.TIMELINE_STATUS_AUTO_ROLLBACK_FAILED,
+				timelineStatus.TIMELINE_DESCRIPTION_AUTO_ROLLBACK_FAILED+message)
+			impl.autoRollbackService.SendRollbackNotification(candidate, bean2.RollbackStatusSkipped, message)
+			continue
+		}
+		impl.logger.Infow("rolling back unhealthy deployment", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId,
+			"failedStatus", candidate.FailedStatus, "targetWfrId", candidate.TargetWfrId, "artifactId", candidate.TargetArtifactId)
+		rollbackWfrId, rollbackErr := impl.triggerAutoRollback(candidate)
+		if rollbackErr != nil {
+			impl.logger.Errorw("error in triggering auto rollback", "pipelineId", candidate.PipelineId, "failedWfrId", candidate.FailedWfrId, "err", rollbackErr)
+			if dbErr := impl.autoRollbackService.UpdateRollbackOutcome(historyId, bean2.RollbackStatusFailed, rollbackWfrId, rollbackErr.Error()); dbErr != nil {
+				impl.logger.Errorw("error in marking auto rollback failed", "historyId", historyId, "err", dbErr)
+			}
+			impl.saveAutoRollbackTimeline(candidate.FailedWfrId, timelineStatus.TIMELINE_STATUS_AUTO_ROLLBACK_FAILED,
+				timelineStatus.TIMELINE_DESCRIPTION_AUTO_ROLLBACK_FAILED+rollbackErr.Error())
+			impl.autoRollbackService.SendRollbackNotification(candidate, bean2.RollbackStatusFailed, rollbackErr.Error())
+			continue
+		}
+		message := fmt.Sprintf("deployment was %s after the health timeout, rolled back to the last healthy deployment", candidate.FailedStatus)
+		if dbErr := impl.autoRollbackService.UpdateRollbackOutcome(historyId, bean2.RollbackStatusTriggered, rollbackWfrId, message); dbErr != nil {
+			impl.logger.Errorw("error in marking auto rollback triggered", "historyId", historyId, "err", dbErr)
+		}
+		impl.saveAutoRollbackTimeline(candidate.FailedWfrId, timelineStatus.TIMELINE_STATUS_AUTO_ROLLBACK_TRIGGERED,
+			timelineStatus.TIMELINE_DESCRIPTION_AUTO_ROLLBACK_TRIGGERED)
+		impl.autoRollbackService.SendRollbackNotification(candidate, bean2.RollbackStatusTriggered, message)
+	}
+}
+
+// triggerAutoRollback redeploys the artifact and the configuration snapshot of the last healthy deployment as a system
+// trigger, the runner created for the rollback is returned even if the release fails. Rollbacks are not held back by
+// the deployment windows or change freezes of the environment, as they restore a deployment which already went through
+// them, the bypass is audited as it is for super admins.
+func (impl *HandlerServiceImpl) triggerAutoRollback(candidate *bean2.RollbackCandidate) (int, error) {
+	overrideRequest := &bean3.ValuesOverrideRequest{
+		PipelineId:                            candidate.PipelineId,
+		AppId:                                 candidate.AppId,
+		CiArtifactId:                          candidate.TargetArtifactId,
+		CdWorkflowType:                        bean3.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig:                  bean3.DEPLOYMENT_CONFIG_TYPE_SPECIFIC_TRIGGER,
+		WfrIdForDeploymentWithSpecificTrigger: candidate.TargetWfrId,
+		DeploymentType:                        models.DEPLOYMENTTYPE_ROLLBACK,
+		IsRollbackDeployment:                  true,
+		IsAutoRollback:                        true,
+		UserId:                                userBean.SystemUserId,
+	}
+	triggerContext := bean.TriggerContext{
+		Context:     context.Background(),
+		TriggerType: bean.System,
+	}
+	_, _, _, err := impl.ManualCdTrigger(triggerContext, overrideRequest, nil)
+	return overrideRequest.WfrId, err
+}
+
+func (impl *HandlerServiceImpl) saveAutoRollbackTimeline(wfrId int, status timelineStatus.TimelineStatus, description string) {
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(wfrId, status, description, userBean.SystemUserId)
+	_, err := impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving auto rollback timeline", "wfrId", wfrId, "status", status, "err", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	bean3 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
 	"time"
 )
 
@@ -53,6 +54,9 @@ type CdTriggerRequest struct {
 	TriggerContext
 	// below fields used for retrigger flow
 	IsRetrigger bool
+	// DeploymentWindowCheckResult is set when deployment windows are already evaluated for this trigger,
+	// it is used to audit a super admin bypass against the created runner
+	DeploymentWindowCheckResult *bean3.DeploymentWindowCheckResult
 }
 
 type TriggerContext struct {
@@ -72,8 +76,23 @@ type TriggerType int
 const (
 	Automatic TriggerType = 1
 	Manual    TriggerType = 2
+	// System is set for the deployments triggered by devtron itself on a schedule, e.g. the scheduled hibernation
+	System TriggerType = 3
 )
 
+// String returns the trigger type recorded in the deployment history, refer pipelineConfig.CdWorkflowRunner
+func (t TriggerType) String() string {
+	switch t {
+	case Automatic:
+		return "AUTOMATIC"
+	case Manual:
+		return "MANUAL"
+	case System:
+		return "SYSTEM"
+	}
+	return ""
+}
+
 type DeploymentType = string
 
 const (
@@ -110,6 +129,7 @@ const (
 type ValidateDeploymentTriggerObj struct {
 	Runner               *pipelineConfig.CdWorkflowRunner
 	CdPipeline           *pipelineConfig.Pipeline
+	Artifact             *repository.CiArtifact
 	ImageDigest          string
 	DeploymentConfig     *bean2.DeploymentConfig
 	TriggeredBy          int32
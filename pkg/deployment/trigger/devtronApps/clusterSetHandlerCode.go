@@ -0,0 +1,407 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	bean3 "github.com/devtron-labs/devtron/api/bean"
+	bean6 "github.com/devtron-labs/devtron/api/helm-app/bean"
+	"github.com/devtron-labs/devtron/api/helm-app/gRPC"
+	helmBean "github.com/devtron-labs/devtron/api/helm-app/service/bean"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	bean7 "github.com/devtron-labs/devtron/client/argocdServer/bean"
+	repository3 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app"
+	repository5 "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	clusterSetBean "github.com/devtron-labs/devtron/pkg/deployment/clusterSet/bean"
+	clusterSetRepository "github.com/devtron-labs/devtron/pkg/deployment/clusterSet/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	bean5 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/helper"
+	clientErrors "github.com/devtron-labs/devtron/pkg/errors"
+	serverBean "github.com/devtron-labs/devtron/pkg/server/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	status2 "google.golang.org/grpc/status"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// queueClusterSetDeployment queues the deployment of the artifact to the cluster set of the pipeline, if it has one. The
+// cluster set deployer starts it once the previous deployment to the cluster set finished. Errors are only logged as the
+// pipeline itself is already deployed.
+func (impl *HandlerServiceImpl) queueClusterSetDeployment(pipeline *pipelineConfig.Pipeline, artifactId int,
+	deploymentWithConfig bean3.DeploymentConfigurationType, triggeredBy int32) {
+	target, err := impl.clusterSetRepository.FindActiveTargetByPipelineId(pipeline.Id)
+	if err != nil {
+		if !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching cluster set of pipeline", "pipelineId", pipeline.Id, "err", err)
+		}
+		return
+	}
+	if len(deploymentWithConfig) > 0 && deploymentWithConfig != bean3.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED {
+		impl.logger.Infow("deployment not fanned out to cluster set", "pipelineId", pipeline.Id, "reason", clusterSetBean.UnsupportedConfigTypeMessage)
+		return
+	}
+	deployment := &clusterSetRepository.ClusterSetDeployment{
+		TargetId:          target.Id,
+		PipelineId:        pipeline.Id,
+		CiArtifactId:      artifactId,
+		Status:            clusterSetBean.DeploymentStatusQueued.String(),
+		Parallelism:       target.Parallelism,
+		MaxUnavailable:    target.MaxUnavailable,
+		HealthTimeoutMins: target.HealthTimeoutMins,
+		TriggeredBy:       triggeredBy,
+		StartedOn:         time.Now(),
+		AuditLog:          sql.NewDefaultAuditLog(triggeredBy),
+	}
+	err = impl.clusterSetRepository.SaveDeploymentWithMembers(deployment, nil)
+	if err != nil {
+		impl.logger.Errorw("error in queuing cluster set deployment", "pipelineId", pipeline.Id, "artifactId", artifactId, "err", err)
+		return
+	}
+	impl.logger.Infow("queued cluster set deployment", "pipelineId", pipeline.Id, "artifactId", artifactId, "deploymentId", deployment.Id)
+}
+
+func (impl *HandlerServiceImpl) TriggerClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	artifact *repository3.CiArtifact, cluster *repository5.Cluster, triggeredBy int32) (int, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "HandlerServiceImpl.TriggerClusterSetMemberRelease")
+	defer span.End()
+	triggeredAt := time.Now()
+	cdWf := &pipelineConfig.CdWorkflow{
+		CiArtifactId: artifact.Id,
+		PipelineId:   pipeline.Id,
+		AuditLog:     sql.AuditLog{CreatedOn: triggeredAt, CreatedBy: triggeredBy, UpdatedOn: triggeredAt, UpdatedBy: triggeredBy},
+	}
+	err := impl.cdWorkflowRepository.SaveWorkFlow(newCtx, cdWf)
+	if err != nil {
+		impl.logger.Errorw("error in creating cdWorkflow, TriggerClusterSetMemberRelease", "pipelineId", pipeline.Id, "err", err)
+		return 0, err
+	}
+	// the runner is not recorded as the latest status of the pipeline, that is the deployment to its own environment
+	runner := &pipelineConfig.CdWorkflowRunner{
+		Name:         pipeline.Name,
+		WorkflowType: bean3.CD_WORKFLOW_TYPE_CLUSTER_SET_DEPLOY,
+		ExecutorType: cdWorkflow.WORKFLOW_EXECUTOR_TYPE_SYSTEM,
+		Status:       cdWorkflow.WorkflowInitiated,
+		TriggeredBy:  triggeredBy,
+		StartedOn:    triggeredAt,
+		Namespace:    impl.config.GetDefaultNamespace(),
+		CdWorkflowId: cdWf.Id,
+		AuditLog:     sql.AuditLog{CreatedOn: triggeredAt, CreatedBy: triggeredBy, UpdatedOn: triggeredAt, UpdatedBy: triggeredBy},
+		TriggerType:  bean.System.String(),
+	}
+	err = impl.cdWorkflowRunnerService.SaveWfr(nil, runner)
+	if err != nil {
+		impl.logger.Errorw("error in creating cdWorkflowRunner, TriggerClusterSetMemberRelease", "cdWorkflowId", cdWf.Id, "err", err)
+		return 0, err
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(runner.Id, timelineStatus.TIMELINE_STATUS_DEPLOYMENT_INITIATED, timelineStatus.TIMELINE_DESCRIPTION_DEPLOYMENT_INITIATED, triggeredBy)
+	_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in creating timeline status for deployment initiation, TriggerClusterSetMemberRelease", "runnerId", runner.Id, "err", err)
+	}
+	releaseErr := impl.releaseClusterSetMember(newCtx, pipeline, artifact, cluster, runner)
+	if releaseErr != nil {
+		err = impl.cdWorkflowCommonService.MarkCurrentDeploymentFailed(runner, releaseErr, triggeredBy)
+		if err != nil {
+			impl.logger.Errorw("error while updating current runner status to failed", "cdWfr", runner.Id, "err", err)
+		}
+		timelineErr := impl.pipelineStatusTimelineService.MarkPipelineStatusTimelineFailed(runner.Id, util.GetClientErrorDetailedMessage(releaseErr))
+		if timelineErr != nil {
+			impl.logger.Errorw("error in marking timeline failed, TriggerClusterSetMemberRelease", "runnerId", runner.Id, "err", timelineErr)
+		}
+		return runner.Id, releaseErr
+	}
+	err = impl.cdWorkflowCommonService.UpdateNonTerminalStatusInRunner(newCtx, runner.Id, triggeredBy, cdWorkflow.WorkflowInProgress)
+	if err != nil {
+		impl.logger.Errorw("error in updating the workflow runner status, TriggerClusterSetMemberRelease", "runnerId", runner.Id, "err", err)
+	}
+	return runner.Id, nil
+}
+
+// releaseClusterSetMember builds the values of the pipeline with the scoped variables resolved and the image pull secret created
+// for the member cluster and releases them there. Nothing is saved as a pipeline override, as that is the latest release of the pipeline.
+func (impl *HandlerServiceImpl) releaseClusterSetMember(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	artifact *repository3.CiArtifact, cluster *repository5.Cluster, runner *pipelineConfig.CdWorkflowRunner) error {
+	envDeploymentConfig, err := impl.deploymentConfigService.GetAndMigrateConfigIfAbsentForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment deployment config by appId and envId", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return err
+	}
+	overrideRequest := &bean3.ValuesOverrideRequest{
+		CiArtifactId:         artifact.Id,
+		CdWorkflowType:       bean3.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig: bean3.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED,
+		WfrId:                runner.Id,
+		CdWorkflowId:         runner.CdWorkflowId,
+		UserId:               runner.TriggeredBy,
+	}
+	adapter.SetPipelineFieldsInOverrideRequest(overrideRequest, pipeline, envDeploymentConfig)
+	overrideRequest.ClusterId = cluster.Id
+	overrideRequest.ClusterSetClusterName = cluster.ClusterName
+	valuesOverrideResponse, err := impl.manifestCreationService.GetValuesOverrideForClusterSetMember(ctx, overrideRequest, envDeploymentConfig)
+	if err != nil {
+		impl.logger.Errorw("error in building values for cluster set member", "pipelineId", pipeline.Id, "clusterId", cluster.Id, "err", err)
+		return err
+	}
+	valuesOverrideResponse.DeploymentConfig = envDeploymentConfig
+	switch {
+	case util.IsHelmApp(envDeploymentConfig.DeploymentAppType):
+		return impl.releaseClusterSetMemberHelmApp(ctx, overrideRequest, valuesOverrideResponse, cluster)
+	case util.IsAcdApp(envDeploymentConfig.DeploymentAppType):
+		return impl.releaseClusterSetMemberArgoApp(ctx, overrideRequest, valuesOverrideResponse, cluster)
+	}
+	return fmt.Errorf("deployment app type %s is not supported for cluster sets", envDeploymentConfig.DeploymentAppType)
+}
+
+// releaseClusterSetMemberHelmApp upgrades the release of the pipeline on the member cluster, it is installed if not found
+func (impl *HandlerServiceImpl) releaseClusterSetMemberHelmApp(ctx context.Context, overrideRequest *bean3.ValuesOverrideRequest,
+	valuesOverrideResponse *app.ValuesOverrideResponse, cluster *repository5.Cluster) error {
+	envOverride := valuesOverrideResponse.EnvOverride
+	referenceTemplate := envOverride.Chart.ReferenceTemplate
+	sanitizedK8sVersion, err := impl.getSanitizedK8sVersion(referenceTemplate)
+	if err != nil {
+		return err
+	}
+	chartMetaData := &chart.Metadata{
+		Name:    valuesOverrideResponse.Pipeline.App.AppName,
+		Version: envOverride.Chart.ChartVersion,
+	}
+	referenceChartByte, err := impl.getReferenceChartByteForHelmTypeApp(envOverride, chartMetaData, path.Join(bean5.RefChartDirPath, referenceTemplate), overrideRequest, valuesOverrideResponse)
+	if err != nil {
+		impl.logger.Errorw("error, getReferenceChartByteForHelmTypeApp", "pipelineId", overrideRequest.PipelineId, "err", err)
+		return err
+	}
+	req := &gRPC.UpgradeReleaseRequest{
+		ReleaseIdentifier: &gRPC.ReleaseIdentifier{
+			ReleaseName:      overrideRequest.ReleaseName,
+			ReleaseNamespace: envOverride.Namespace,
+			ClusterConfig:    impl.getClusterGRPCConfig(*cluster),
+		},
+		ValuesYaml:   valuesOverrideResponse.MergedValues,
+		HistoryMax:   impl.helmAppService.GetRevisionHistoryMaxValue(bean6.SOURCE_DEVTRON_APP),
+		ChartContent: &gRPC.ChartContent{Content: referenceChartByte},
+	}
+	if len(sanitizedK8sVersion) > 0 {
+		req.K8SVersion = sanitizedK8sVersion
+	}
+	// For cases where helm release was not found, kubelink will install the same configuration
+	_, err = impl.helmAppClient.UpdateApplication(ctx, req)
+	if err != nil {
+		impl.logger.Errorw("error in updating helm application on cluster set member", "pipelineId", overrideRequest.PipelineId, "clusterId", cluster.Id, "err", err)
+		if apiError := clientErrors.ConvertToApiError(err); apiError != nil {
+			return apiError
+		}
+		return err
+	}
+	return nil
+}
+
+// releaseClusterSetMemberArgoApp commits the values of the member cluster next to the values of the pipeline, creates
+// the argo application of the member cluster if not present and syncs it. The values are committed directly, as the
+// pipeline deployment they follow has already been through the pull request mode of the environment, if enabled.
+func (impl *HandlerServiceImpl) releaseClusterSetMemberArgoApp(ctx context.Context, overrideRequest *bean3.ValuesOverrideRequest,
+	valuesOverrideResponse *app.ValuesOverrideResponse, cluster *repository5.Cluster) error {
+	deploymentConfig := valuesOverrideResponse.DeploymentConfig
+	envOverride := valuesOverrideResponse.EnvOverride
+	valuesFile := helper.GetValuesFileForClusterSetMember(overrideRequest.EnvId, cluster.Id)
+	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(overrideRequest.UserId)
+	chartGitAttr := &git.ChartConfig{
+		FileName:       valuesFile,
+		FileContent:    valuesOverrideResponse.MergedValues,
+		ChartName:      envOverride.Chart.ChartName,
+		ChartLocation:  deploymentConfig.GetChartLocation(),
+		ChartRepoName:  impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(deploymentConfig.GetRepoURL()),
+		TargetRevision: deploymentConfig.GetTargetRevision(),
+		ReleaseMessage: fmt.Sprintf("release-wfr-%d-env-%d-cluster-%d ", overrideRequest.WfrId, overrideRequest.EnvId, cluster.Id),
+		UserName:       userName,
+		UserEmailId:    userEmailId,
+	}
+	chartGitAttr.SetBitBucketBaseDir(fmt.Sprintf("wfr-%d-%s", overrideRequest.WfrId, impl.chartTemplateService.GetDir()))
+	_, _, err := impl.gitOperationService.CommitValues(ctx, chartGitAttr)
+	if err != nil {
+		impl.logger.Errorw("error in committing values of cluster set member", "pipelineId", overrideRequest.PipelineId, "clusterId", cluster.Id, "err", err)
+		return err
+	}
+	argoAppName := helper.GetArgoAppNameForClusterSetMember(overrideRequest.ReleaseName, cluster.Id)
+	_, err = impl.argoClientWrapperService.GetArgoAppByName(ctx, argoAppName)
+	if appStatus, _ := status2.FromError(err); appStatus.Code() == codes.NotFound {
+		appNamespace := envOverride.Namespace
+		if appNamespace == "" {
+			appNamespace = "default"
+		}
+		appRequest := &argocdServer.AppTemplate{
+			ApplicationName: argoAppName,
+			Namespace:       argocdServer.DevtronInstalationNs,
+			TargetNamespace: appNamespace,
+			TargetServer:    cluster.ServerUrl,
+			Project:         "default",
+			ValuesFile:      valuesFile,
+			RepoPath:        deploymentConfig.GetChartLocation(),
+			AutoSyncEnabled: impl.ACDConfig.ArgoCDAutoSyncEnabled,
+		}
+		appRequest.RepoUrl, err = impl.gitOperationService.GetRepoUrlWithUserName(deploymentConfig.GetRepoURL())
+		if err != nil {
+			return err
+		}
+		_, err = impl.argoK8sClient.CreateAcdApp(ctx, appRequest, argocdServer.ARGOCD_APPLICATION_TEMPLATE)
+		if err != nil {
+			impl.logger.Errorw("error in creating argo application of cluster set member", "argoAppName", argoAppName, "err", err)
+			return err
+		}
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching argo application of cluster set member", "argoAppName", argoAppName, "err", err)
+		return err
+	}
+	return impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(ctx, argoAppName, deploymentConfig.GetTargetRevision())
+}
+
+func (impl *HandlerServiceImpl) SyncClusterSetMemberRunnerStatus(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	runnerId, clusterId int, timeout time.Duration) (*pipelineConfig.CdWorkflowRunner, error) {
+	runner, err := impl.cdWorkflowRepository.FindBasicWorkflowRunnerById(runnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching runner of cluster set member", "runnerId", runnerId, "err", err)
+		return nil, err
+	}
+	if slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		return runner, nil
+	}
+	envDeploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment deployment config by appId and envId", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return nil, err
+	}
+	var runnerStatus, statusDetail string
+	if util.IsHelmApp(envDeploymentConfig.DeploymentAppType) {
+		runnerStatus, statusDetail = impl.getClusterSetMemberHelmAppStatus(ctx, pipeline, clusterId, runner.StartedOn)
+	} else {
+		runnerStatus, statusDetail = impl.getClusterSetMemberArgoAppStatus(ctx, pipeline, clusterId, runner.StartedOn)
+	}
+	if len(runnerStatus) == 0 {
+		if time.Since(runner.StartedOn) <= timeout {
+			return runner, nil
+		}
+		runnerStatus = cdWorkflow.WorkflowFailed
+		statusDetail = fmt.Sprintf(clusterSetBean.HealthTimeoutMessageTmpl, int(timeout.Minutes()), statusDetail)
+	}
+	runner.Status = runnerStatus
+	runner.Message = statusDetail
+	runner.FinishedOn = time.Now()
+	runner.UpdateAuditLog(1)
+	err = impl.cdWorkflowRunnerService.UpdateCdWorkflowRunnerWithStage(runner)
+	if err != nil {
+		impl.logger.Errorw("error in updating runner of cluster set member", "runnerId", runner.Id, "err", err)
+		return nil, err
+	}
+	if runnerStatus == cdWorkflow.WorkflowSucceeded {
+		timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(runner.Id, timelineStatus.TIMELINE_STATUS_APP_HEALTHY, "App status is Healthy.", 1)
+		_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+	} else {
+		err = impl.pipelineStatusTimelineService.MarkPipelineStatusTimelineFailed(runner.Id, statusDetail)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving terminal timeline of cluster set member", "runnerId", runner.Id, "err", err)
+	}
+	return runner, nil
+}
+
+// getClusterSetMemberHelmAppStatus returns the terminal status of the release on the member cluster, or an empty status
+// with the current state while it is progressing, refer WorkflowDagExecutor.UpdateWorkflowRunnerStatusForDeployment
+func (impl *HandlerServiceImpl) getClusterSetMemberHelmAppStatus(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	clusterId int, startedOn time.Time) (string, string) {
+	appIdentifier := &helmBean.AppIdentifier{
+		ClusterId:   clusterId,
+		Namespace:   pipeline.Environment.Namespace,
+		ReleaseName: pipeline.DeploymentAppName,
+	}
+	appStatus, err := impl.helmAppService.GetApplicationAndReleaseStatus(ctx, appIdentifier)
+	if err != nil {
+		if util.GetClientErrorDetailedMessage(err) == bean6.ErrReleaseNotFound {
+			return cdWorkflow.WorkflowFailed, fmt.Sprintf("helm client error: %s", util.GetClientErrorDetailedMessage(err))
+		}
+		return "", util.GetClientErrorDetailedMessage(err)
+	}
+	switch appStatus.GetReleaseStatus() {
+	case serverBean.HelmReleaseStatusSuperseded:
+		return cdWorkflow.WorkflowFailed, cdWorkflow.ErrorDeploymentSuperseded.Error()
+	case serverBean.HelmReleaseStatusFailed:
+		return cdWorkflow.WorkflowFailed, appStatus.GetDescription()
+	case serverBean.HelmReleaseStatusDeployed:
+		if !appStatus.GetLastDeployed().AsTime().Before(startedOn) && appStatus.GetApplicationStatus() == bean7.Healthy {
+			return cdWorkflow.WorkflowSucceeded, ""
+		}
+	}
+	return "", fmt.Sprintf("release %s, application %s", appStatus.GetReleaseStatus(), appStatus.GetApplicationStatus())
+}
+
+// getClusterSetMemberArgoAppStatus returns the terminal status of the sync of the argo application of the member
+// cluster started after the release, or an empty status with the current state while it is progressing
+func (impl *HandlerServiceImpl) getClusterSetMemberArgoAppStatus(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	clusterId int, startedOn time.Time) (string, string) {
+	argoApp, err := impl.argoClientWrapperService.GetArgoAppByName(ctx, helper.GetArgoAppNameForClusterSetMember(pipeline.DeploymentAppName, clusterId))
+	if err != nil {
+		return "", err.Error()
+	}
+	operationState := argoApp.Status.OperationState
+	if operationState != nil && !operationState.StartedAt.Time.Before(startedOn) {
+		if operationState.Phase.Completed() && !operationState.Phase.Successful() {
+			return cdWorkflow.WorkflowFailed, operationState.Message
+		}
+		if operationState.Phase.Successful() && argoApp.Status.Health.Status == bean7.Healthy {
+			return cdWorkflow.WorkflowSucceeded, ""
+		}
+	}
+	return "", fmt.Sprintf("sync %s, health %s", argoApp.Status.Sync.Status, argoApp.Status.Health.Status)
+}
+
+func (impl *HandlerServiceImpl) DeleteClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline,
+	deploymentAppType string, clusterId int) error {
+	if util.IsHelmApp(deploymentAppType) {
+		appIdentifier := &helmBean.AppIdentifier{
+			ClusterId:   clusterId,
+			Namespace:   pipeline.Environment.Namespace,
+			ReleaseName: pipeline.DeploymentAppName,
+		}
+		_, err := impl.helmAppService.DeleteApplication(ctx, appIdentifier)
+		if err != nil && util.GetClientErrorDetailedMessage(err) != bean6.ErrReleaseNotFound {
+			impl.logger.Errorw("error in deleting helm release of cluster set member", "appIdentifier", appIdentifier, "err", err)
+			return err
+		}
+		return nil
+	} else if util.IsAcdApp(deploymentAppType) {
+		argoAppName := helper.GetArgoAppNameForClusterSetMember(pipeline.DeploymentAppName, clusterId)
+		_, err := impl.argoClientWrapperService.DeleteArgoApp(ctx, argoAppName, true)
+		if appStatus, _ := status2.FromError(err); err != nil && appStatus.Code() != codes.NotFound {
+			impl.logger.Errorw("error in deleting argo application of cluster set member", "argoAppName", argoAppName, "err", err)
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("deployment app type %s is not supported for cluster sets", deploymentAppType)
+}
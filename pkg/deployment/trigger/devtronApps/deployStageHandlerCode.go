@@ -68,6 +68,19 @@ import (
 )
 
 func (impl *HandlerServiceImpl) TriggerStageForBulk(triggerRequest bean.CdTriggerRequest) error {
+	// bulk deploy is requested by a user, so it is failed instead of queued if the environment is closed for deployment
+	deploymentWindowCheckResult, err := impl.checkDeploymentWindow(triggerRequest.Pipeline, impl.isUserSuperAdmin(triggerRequest.TriggeredBy))
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed due to deployment window, TriggerStageForBulk", "cdPipelineId", triggerRequest.Pipeline.Id, "err", err)
+		return err
+	}
+	triggerRequest.DeploymentWindowCheckResult = deploymentWindowCheckResult
+	// for the same reason an artifact which is not approved is rejected as in a manual trigger, instead of raising an approval request
+	_, err = impl.checkDeploymentApproval(triggerRequest.Pipeline, triggerRequest.Artifact.Id)
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed as artifact is not approved, TriggerStageForBulk", "cdPipelineId", triggerRequest.Pipeline.Id, "artifactId", triggerRequest.Artifact.Id, "err", err)
+		return err
+	}
 
 	preStage, err := impl.pipelineStageService.GetCdStageByCdPipelineIdAndStageType(triggerRequest.Pipeline.Id, repository.PIPELINE_STAGE_TYPE_PRE_CD, false)
 	if err != nil && err != pg.ErrNoRows {
@@ -151,13 +164,59 @@ func (impl *HandlerServiceImpl) validateDeploymentTriggerRequest(ctx context.Con
 		}
 		return fmt.Errorf("found vulnerability for image digest %s", validateDeploymentTriggerObj.ImageDigest)
 	}
+	return impl.checkImageSignature(newCtx, validateDeploymentTriggerObj)
+}
+
+func (impl *HandlerServiceImpl) ValidateDeploymentGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository3.CiArtifact, triggeredBy int32) error {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "HandlerServiceImpl.ValidateDeploymentGates")
+	defer span.End()
+	_, err := impl.checkDeploymentWindow(pipeline, impl.isUserSuperAdmin(triggeredBy))
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed due to deployment window, ValidateDeploymentGates", "pipelineId", pipeline.Id, "err", err)
+		return err
+	}
+	_, err = impl.checkDeploymentApproval(pipeline, artifact.Id)
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed as artifact is not approved, ValidateDeploymentGates", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return err
+	}
+	return impl.ValidateArtifactGates(newCtx, pipeline, artifact)
+}
+
+func (impl *HandlerServiceImpl) ValidateArtifactGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository3.CiArtifact) error {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "HandlerServiceImpl.ValidateArtifactGates")
+	defer span.End()
+	err := impl.checkTestGate(pipeline, artifact)
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed by test gate, ValidateArtifactGates", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return err
+	}
+	vulnerabilityCheckRequest := adapter.GetVulnerabilityCheckRequest(pipeline, artifact.ImageDigest)
+	isVulnerable, err := impl.imageScanService.GetArtifactVulnerabilityStatus(newCtx, vulnerabilityCheckRequest)
+	if err != nil {
+		impl.logger.Errorw("error in getting Artifact vulnerability status, ValidateArtifactGates", "err", err)
+		return err
+	}
+	if isVulnerable {
+		return util.NewApiError(http.StatusPreconditionFailed, cdWorkflow.FOUND_VULNERABILITY, cdWorkflow.FOUND_VULNERABILITY)
+	}
+	result, err := impl.imageSignatureService.VerifyArtifactForDeployment(newCtx, pipeline, artifact)
+	if err != nil {
+		impl.logger.Errorw("error in verifying image signature", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return err
+	}
+	if result != nil && result.Blocked {
+		return util.NewApiError(http.StatusPreconditionFailed, result.Message, result.Message)
+	}
 	return nil
 }
 
 // TODO: write a wrapper to handle auto and manual trigger
 func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerContext, overrideRequest *bean3.ValuesOverrideRequest, userMetadata *userBean.UserMetadata) (int, string, *bean4.ManifestPushTemplate, error) {
 
-	triggerContext.TriggerType = bean.Manual
+	if triggerContext.TriggerType != bean.System {
+		triggerContext.TriggerType = bean.Manual
+	}
 	// setting triggeredAt variable to have consistent data for various audit log places in db for deployment time
 	triggeredAt := time.Now()
 
@@ -173,6 +232,21 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 		}
 		return 0, "", nil, err
 	}
+	isUserSuperAdmin := userMetadata != nil && userMetadata.IsUserSuperAdmin
+	// auto rollbacks restore the last healthy deployment, so they bypass the deployment windows like a super admin
+	// and the bypass is audited against the rollback runner
+	canBypassDeploymentWindow := isUserSuperAdmin || overrideRequest.IsAutoRollback
+	deploymentWindowCheckResult, err := impl.checkDeploymentWindow(cdPipeline, canBypassDeploymentWindow)
+	if err != nil {
+		impl.logger.Errorw("deployment not allowed due to deployment window, ManualCdTrigger", "pipelineId", cdPipeline.Id, "err", err)
+		if overrideRequest.WfrId != 0 {
+			err2 := impl.cdWorkflowCommonService.MarkDeploymentFailedForRunnerId(overrideRequest.WfrId, err, overrideRequest.UserId)
+			if err2 != nil {
+				impl.logger.Errorw("error while updating current runner status to failed, ManualCdTrigger", "cdWfr", overrideRequest.WfrId, "err2", err2)
+			}
+		}
+		return 0, "", nil, err
+	}
 	envDeploymentConfig, err := impl.deploymentConfigService.GetAndMigrateConfigIfAbsentForDevtronApps(nil, cdPipeline.AppId, cdPipeline.EnvironmentId)
 	if err != nil {
 		impl.logger.Errorw("error in fetching environment deployment config by appId and envId", "appId", cdPipeline.AppId, "envId", cdPipeline.EnvironmentId, "err", err)
@@ -237,6 +311,8 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 			TriggerContext:        triggerContext,
 			RefCdWorkflowRunnerId: 0,
 			CdWorkflowRunnerId:    overrideRequest.WfrId,
+
+			DeploymentWindowCheckResult: deploymentWindowCheckResult,
 		}
 		manifestPushTemplate, err = impl.TriggerPreStage(triggerRequest)
 		span.End()
@@ -248,6 +324,20 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 		if overrideRequest.DeploymentType == models.DEPLOYMENTTYPE_UNKNOWN {
 			overrideRequest.DeploymentType = models.DEPLOYMENTTYPE_DEPLOY
 		}
+		approvalRequestId := 0
+		// auto rollback and drift reconcile redeploy an artifact which was already deployed and healthy on this pipeline
+		if isNotHibernateRequest(overrideRequest.DeploymentType) && !overrideRequest.IsAutoRollback && !overrideRequest.IsDriftReconcile {
+			approvalRequestId, err = impl.checkDeploymentApproval(cdPipeline, artifact.Id)
+			if err != nil {
+				impl.logger.Errorw("deployment not allowed as artifact is not approved, ManualCdTrigger", "pipelineId", cdPipeline.Id, "artifactId", artifact.Id, "err", err)
+				return 0, "", nil, err
+			}
+			err = impl.checkTestGate(cdPipeline, artifact)
+			if err != nil {
+				impl.logger.Errorw("deployment not allowed by test gate, ManualCdTrigger", "pipelineId", cdPipeline.Id, "artifactId", artifact.Id, "err", err)
+				return 0, "", nil, err
+			}
+		}
 
 		cdWf, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(ctx, overrideRequest.CdWorkflowId, bean3.CD_WORKFLOW_TYPE_PRE)
 		if err != nil && !util.IsErrNoRows(err) {
@@ -294,6 +384,9 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 			CdWorkflowId: cdWorkflowId,
 			AuditLog:     sql.AuditLog{CreatedOn: triggeredAt, CreatedBy: overrideRequest.UserId, UpdatedOn: triggeredAt, UpdatedBy: overrideRequest.UserId},
 			ReferenceId:  triggerContext.ReferenceId,
+			TriggerType:  triggerContext.TriggerType.String(),
+
+			DeploymentApprovalRequestId: approvalRequestId,
 		}
 
 		err = impl.cdWorkflowRunnerService.SaveWfr(tx, runner)
@@ -329,8 +422,19 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 		if err != nil {
 			impl.logger.Errorw("error in creating timeline status for deployment initiation, ManualCdTrigger", "err", err, "timeline", timeline)
 		}
+		bypassAuditRequest := bean.CdTriggerRequest{
+			Pipeline:                    cdPipeline,
+			TriggeredBy:                 overrideRequest.UserId,
+			DeploymentWindowCheckResult: deploymentWindowCheckResult,
+		}
+		dbErr := impl.createAuditDataForDeploymentWindowBypass(bypassAuditRequest, runner.Id)
+		if dbErr != nil {
+			impl.logger.Errorw("error in creating audit data for deployment window bypass", "runnerId", runner.Id, "err", dbErr)
+			// skip error for audit data creation
+		}
+		impl.markApprovedArtifactDeploymentTriggered(approvalRequestId, overrideRequest.UserId)
 		if isNotHibernateRequest(overrideRequest.DeploymentType) {
-			validateReqObj := adapter.NewValidateDeploymentTriggerObj(runner, cdPipeline, artifact.ImageDigest, envDeploymentConfig, overrideRequest.UserId, overrideRequest.IsRollbackDeployment)
+			validateReqObj := adapter.NewValidateDeploymentTriggerObj(runner, cdPipeline, artifact, envDeploymentConfig, overrideRequest.UserId, overrideRequest.IsRollbackDeployment)
 			validationErr := impl.validateDeploymentTriggerRequest(ctx, validateReqObj)
 			if validationErr != nil {
 				impl.logger.Errorw("validation error deployment request", "cdWfr", runner.Id, "err", validationErr)
@@ -354,6 +458,14 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 			impl.deploymentEventHandler.WriteCDNotificationEventAsync(pipelineOverride.Pipeline.AppId, pipelineOverride.Pipeline.EnvironmentId, pipelineOverride, util2.Fail)
 			return 0, "", nil, releaseErr
 		}
+		// automatic deployments waiting for the deployment window are older than this one, so they are dropped
+		supersedeErr := impl.deploymentWindowService.SupersedeQueuedTriggers(cdPipeline.Id, overrideRequest.UserId)
+		if supersedeErr != nil {
+			impl.logger.Errorw("error in superseding deployment window queued triggers, ManualCdTrigger", "pipelineId", cdPipeline.Id, "err", supersedeErr)
+		}
+		if isNotHibernateRequest(overrideRequest.DeploymentType) {
+			impl.queueClusterSetDeployment(cdPipeline, artifact.Id, overrideRequest.DeploymentWithConfig, overrideRequest.UserId)
+		}
 
 	case bean3.CD_WORKFLOW_TYPE_POST:
 		cdWfRunner, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(ctx, overrideRequest.CdWorkflowId, bean3.CD_WORKFLOW_TYPE_DEPLOY)
@@ -392,6 +504,8 @@ func (impl *HandlerServiceImpl) ManualCdTrigger(triggerContext bean.TriggerConte
 			RefCdWorkflowRunnerId: 0,
 			TriggerContext:        triggerContext,
 			CdWorkflowRunnerId:    overrideRequest.WfrId,
+
+			DeploymentWindowCheckResult: deploymentWindowCheckResult,
 		}
 		manifestPushTemplate, err = impl.TriggerPostStage(triggerRequest)
 		span.End()
@@ -422,6 +536,31 @@ func (impl *HandlerServiceImpl) TriggerAutomaticDeployment(request bean.CdTrigge
 	cdWf := request.CdWf
 	ctx := context.Background()
 
+	approvalRequestId, isBlockedForApproval, err := impl.requestApprovalIfNotApproved(&request)
+	if err != nil {
+		return err
+	}
+	if isBlockedForApproval {
+		return nil
+	}
+
+	err = impl.checkTestGate(pipeline, artifact)
+	if err != nil {
+		impl.logger.Errorw("automatic deployment not allowed by test gate", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return err
+	}
+
+	// windows are already evaluated if the request is coming from bulk deploy
+	if request.DeploymentWindowCheckResult == nil {
+		isQueued, err := impl.queueIfBlockedByDeploymentWindow(&request)
+		if err != nil {
+			return err
+		}
+		if isQueued {
+			return nil
+		}
+	}
+
 	if cdWf == nil || (cdWf != nil && cdWf.CiArtifactId != artifact.Id) {
 		// cdWf != nil && cdWf.CiArtifactId != artifact.Id for auto trigger case when deployment is triggered with image generated by plugin
 		cdWf = &pipelineConfig.CdWorkflow{
@@ -462,6 +601,9 @@ func (impl *HandlerServiceImpl) TriggerAutomaticDeployment(request bean.CdTrigge
 		CdWorkflowId: cdWf.Id,
 		AuditLog:     sql.AuditLog{CreatedOn: triggeredAt, CreatedBy: triggeredBy, UpdatedOn: triggeredAt, UpdatedBy: triggeredBy},
 		ReferenceId:  request.TriggerContext.ReferenceId,
+		TriggerType:  bean.Automatic.String(),
+
+		DeploymentApprovalRequestId: approvalRequestId,
 	}
 	err = impl.cdWorkflowRunnerService.SaveWfr(tx, runner)
 	if err != nil {
@@ -495,12 +637,18 @@ func (impl *HandlerServiceImpl) TriggerAutomaticDeployment(request bean.CdTrigge
 	if err != nil {
 		impl.logger.Errorw("error in creating timeline status for deployment initiation", "err", err, "timeline", timeline)
 	}
+	dbErr := impl.createAuditDataForDeploymentWindowBypass(request, runner.Id)
+	if dbErr != nil {
+		impl.logger.Errorw("error in creating audit data for deployment window bypass", "runnerId", runner.Id, "err", dbErr)
+		// skip error for audit data creation
+	}
+	impl.markApprovedArtifactDeploymentTriggered(approvalRequestId, triggeredBy)
 	envDeploymentConfig, err := impl.deploymentConfigService.GetAndMigrateConfigIfAbsentForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
 	if err != nil {
 		impl.logger.Errorw("error in fetching environment deployment config by appId and envId", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
 		return err
 	}
-	validationErr := impl.validateDeploymentTriggerRequest(ctx, adapter.NewValidateDeploymentTriggerObj(runner, pipeline, artifact.ImageDigest, envDeploymentConfig, triggeredBy, false))
+	validationErr := impl.validateDeploymentTriggerRequest(ctx, adapter.NewValidateDeploymentTriggerObj(runner, pipeline, artifact, envDeploymentConfig, triggeredBy, false))
 	if validationErr != nil {
 		impl.logger.Errorw("validation error deployment request", "cdWfr", runner.Id, "err", validationErr)
 		return validationErr
@@ -514,6 +662,7 @@ func (impl *HandlerServiceImpl) TriggerAutomaticDeployment(request bean.CdTrigge
 		}
 		return releaseErr
 	}
+	impl.queueClusterSetDeployment(pipeline, artifact.Id, bean3.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED, triggeredBy)
 	return nil
 }
 
@@ -711,10 +860,13 @@ func (impl *HandlerServiceImpl) TriggerRelease(ctx context.Context, overrideRequ
 		return releaseNo, manifestPushTemplate, err
 	}
 	// creating cd pipeline status timeline for deployment triggered - for successfully triggered requests
-	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(overrideRequest.WfrId, timelineStatus.TIMELINE_STATUS_DEPLOYMENT_TRIGGERED, timelineStatus.TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED, overrideRequest.UserId)
-	_, dbErr := impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
-	if dbErr != nil {
-		impl.logger.Errorw("error in creating timeline status for deployment completed", "err", dbErr, "timeline", timeline)
+	// for deployments awaiting merge of their gitops pull request, it is created once the pull request is merged
+	if !valuesOverrideResponse.AwaitingGitOpsMerge {
+		timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(overrideRequest.WfrId, timelineStatus.TIMELINE_STATUS_DEPLOYMENT_TRIGGERED, timelineStatus.TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED, overrideRequest.UserId)
+		_, dbErr := impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, nil)
+		if dbErr != nil {
+			impl.logger.Errorw("error in creating timeline status for deployment completed", "err", dbErr, "timeline", timeline)
+		}
 	}
 	impl.logger.Debugw("triggered pipeline for release successfully", "wfrId", overrideRequest.WfrId, "builtChartPath", builtChartPath)
 	return releaseNo, valuesOverrideResponse.ManifestPushTemplate, nil
@@ -742,10 +894,15 @@ func (impl *HandlerServiceImpl) performGitOps(ctx context.Context,
 		impl.logger.Errorw("error in pushing manifest to git/helm", "err", manifestPushResponse.Error, "git_repo_url", manifestPushTemplate.RepoUrl)
 		return manifestPushResponse.Error
 	}
+	valuesOverrideResponse.AwaitingGitOpsMerge = manifestPushResponse.AwaitingMerge
 	if manifestPushResponse.IsNewGitRepoConfigured() {
 		// Update GitOps repo url after repo new repo created
 		valuesOverrideResponse.DeploymentConfig.SetRepoURL(manifestPushResponse.NewGitRepoUrl)
 	}
+	if len(manifestPushResponse.NewGitOpsRepoBasePath) != 0 {
+		// chart location is moved under the base path of the shared GitOps repo
+		valuesOverrideResponse.DeploymentConfig.SetGitOpsRepoBasePath(manifestPushResponse.NewGitOpsRepoBasePath)
+	}
 	valuesOverrideResponse.ManifestPushTemplate = manifestPushTemplate
 	return nil
 }
@@ -773,6 +930,12 @@ func (impl *HandlerServiceImpl) buildTriggerEventForOverrideRequest(overrideRequ
 		skipRequest = true
 		return triggerEvent, skipRequest, nil
 	}
+	if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_GIT_AWAITING_MERGE) {
+		// gitops pull request has been opened, deployment is continued once it is merged
+		impl.logger.Info("deployment is awaiting merge of gitops pull request. skipping", "cdWfrId", overrideRequest.WfrId, "timelineStatuses", timelineStatuses)
+		skipRequest = true
+		return triggerEvent, skipRequest, nil
+	}
 	if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_GIT_COMMIT) ||
 		slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_INITIATED) {
 		// git commit has already been performed
@@ -828,6 +991,7 @@ func (impl *HandlerServiceImpl) buildManifestPushTemplate(overrideRequest *bean3
 
 	manifestPushTemplate := &bean4.ManifestPushTemplate{
 		WorkflowRunnerId:    overrideRequest.WfrId,
+		PipelineId:          overrideRequest.PipelineId,
 		AppId:               overrideRequest.AppId,
 		ChartRefId:          valuesOverrideResponse.EnvOverride.Chart.ChartRefId,
 		EnvironmentId:       valuesOverrideResponse.EnvOverride.Environment.Id,
@@ -863,9 +1027,11 @@ func (impl *HandlerServiceImpl) buildManifestPushTemplate(overrideRequest *bean3
 		manifestPushTemplate.ChartVersion = valuesOverrideResponse.EnvOverride.Chart.ChartVersion
 		manifestPushTemplate.ChartLocation = valuesOverrideResponse.DeploymentConfig.GetChartLocation()
 		manifestPushTemplate.RepoUrl = valuesOverrideResponse.DeploymentConfig.GetRepoURL()
+		manifestPushTemplate.GitOpsRepoBasePath = valuesOverrideResponse.DeploymentConfig.GetGitOpsRepoBasePath()
 		manifestPushTemplate.TargetRevision = valuesOverrideResponse.DeploymentConfig.GetTargetRevision()
 		manifestPushTemplate.ValuesFilePath = valuesOverrideResponse.DeploymentConfig.GetValuesFilePathForCommit()
 		manifestPushTemplate.ReleaseMode = valuesOverrideResponse.DeploymentConfig.ReleaseMode
+		manifestPushTemplate.DeploymentAppType = valuesOverrideResponse.DeploymentConfig.DeploymentAppType
 		manifestPushTemplate.IsCustomGitRepository = common.IsCustomGitOpsRepo(valuesOverrideResponse.DeploymentConfig.ConfigType)
 		manifestPushTemplate.ArgoSyncNeeded = valuesOverrideResponse.DeploymentConfig.IsArgoAppSyncAndRefreshSupported()
 	}
@@ -1071,7 +1237,8 @@ func (impl *HandlerServiceImpl) deployArgoCdApp(ctx context.Context, overrideReq
 		impl.logger.Errorw("error in updating argocd app ", "err", err)
 		return err
 	}
-	if valuesOverrideResponse.DeploymentConfig.IsArgoAppSyncAndRefreshSupported() {
+	// argo cd application is synced once the gitops pull request is merged
+	if valuesOverrideResponse.DeploymentConfig.IsArgoAppSyncAndRefreshSupported() && !valuesOverrideResponse.AwaitingGitOpsMerge {
 		syncTime := time.Now()
 		targetRevision := valuesOverrideResponse.DeploymentConfig.GetTargetRevision()
 		err = impl.argoClientWrapperService.SyncArgoCDApplicationIfNeededAndRefresh(newCtx, valuesOverrideResponse.Pipeline.DeploymentAppName, targetRevision)
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"errors"
+
+	"github.com/devtron-labs/devtron/internal/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+)
+
+// checkDeploymentApproval returns an error if the pipeline requires approval for deployment and the artifact is not approved,
+// the approval request id is returned for linking it to the deployment, 0 if the pipeline does not require approval
+func (impl *HandlerServiceImpl) checkDeploymentApproval(pipeline *pipelineConfig.Pipeline, artifactId int) (int, error) {
+	approvalRequest, err := impl.deploymentApprovalService.CheckArtifactApproved(pipeline.Id, artifactId)
+	if err != nil {
+		return 0, err
+	}
+	if approvalRequest == nil {
+		return 0, nil
+	}
+	return approvalRequest.Id, nil
+}
+
+// requestApprovalIfNotApproved raises an approval request for an automatic deployment of an artifact which is not approved yet,
+// the deployment is triggered through the queued triggers once the request is approved
+func (impl *HandlerServiceImpl) requestApprovalIfNotApproved(request *bean.CdTriggerRequest) (approvalRequestId int, isBlocked bool, err error) {
+	approvalRequestId, err = impl.checkDeploymentApproval(request.Pipeline, request.Artifact.Id)
+	if err == nil {
+		return approvalRequestId, false, nil
+	}
+	if apiErr := (&util.ApiError{}); !errors.As(err, &apiErr) || apiErr.Code != constants.ApprovalNodeFail {
+		impl.logger.Errorw("error in checking deployment approval", "pipelineId", request.Pipeline.Id, "artifactId", request.Artifact.Id, "err", err)
+		return 0, false, err
+	}
+	err = impl.deploymentApprovalService.RequestApprovalForAutoTrigger(request.Pipeline, request.Artifact.Id, getGatedCdWorkflowId(request), request.TriggeredBy)
+	if err != nil {
+		impl.logger.Errorw("error in requesting approval for automatic deployment", "pipelineId", request.Pipeline.Id, "artifactId", request.Artifact.Id, "err", err)
+		return 0, false, err
+	}
+	impl.logger.Infow("automatic deployment is waiting for approval", "pipelineId", request.Pipeline.Id, "artifactId", request.Artifact.Id)
+	return 0, true, nil
+}
+
+func (impl *HandlerServiceImpl) markApprovedArtifactDeploymentTriggered(approvalRequestId int, userId int32) {
+	if approvalRequestId == 0 {
+		return
+	}
+	err := impl.deploymentApprovalService.MarkArtifactDeploymentTriggered(approvalRequestId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in marking approved artifact deployed", "approvalRequestId", approvalRequestId, "err", err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"context"
+	"time"
+
+	bean3 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	bean2 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+)
+
+// queuedTriggerReleaseBatchSize is the max number of queued triggers released in a single run
+const queuedTriggerReleaseBatchSize = 100
+
+// checkDeploymentWindow returns an error with the next allowed window if the pipeline environment is closed for deployment,
+// super admins and auto rollbacks bypass the windows and the returned result is used for auditing the bypass
+func (impl *HandlerServiceImpl) checkDeploymentWindow(pipeline *pipelineConfig.Pipeline, canBypass bool) (*bean2.DeploymentWindowCheckResult, error) {
+	envName := ""
+	if pipeline.Environment.Id > 0 {
+		envName = pipeline.Environment.Name
+	}
+	return impl.deploymentWindowService.CheckDeploymentAllowed(pipeline.EnvironmentId, envName, canBypass)
+}
+
+// queueIfBlockedByDeploymentWindow queues an automatic deployment if the pipeline environment is closed for deployment,
+// the queued trigger is released by ReleaseDeploymentWindowQueuedTriggers once the environment opens
+func (impl *HandlerServiceImpl) queueIfBlockedByDeploymentWindow(request *bean.CdTriggerRequest) (isQueued bool, err error) {
+	state, err := impl.deploymentWindowService.GetWindowState(request.Pipeline.EnvironmentId, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in getting deployment window state", "pipelineId", request.Pipeline.Id, "err", err)
+		return false, err
+	}
+	if state.IsAllowed {
+		request.DeploymentWindowCheckResult = &bean2.DeploymentWindowCheckResult{WindowState: state}
+		return false, nil
+	}
+	queuedTrigger := &bean2.QueuedTriggerDto{
+		PipelineId:    request.Pipeline.Id,
+		EnvironmentId: request.Pipeline.EnvironmentId,
+		CiArtifactId:  request.Artifact.Id,
+		WorkflowType:  bean3.CD_WORKFLOW_TYPE_DEPLOY.String(),
+		CdWorkflowId:  getGatedCdWorkflowId(request),
+		TriggeredBy:   request.TriggeredBy,
+	}
+	err = impl.deploymentWindowService.QueueTrigger(queuedTrigger, state)
+	if err != nil {
+		impl.logger.Errorw("error in queuing automatic deployment blocked by deployment window", "pipelineId", request.Pipeline.Id, "artifactId", request.Artifact.Id, "err", err)
+		return false, err
+	}
+	impl.logger.Infow("automatic deployment blocked by deployment window, queued", "pipelineId", request.Pipeline.Id,
+		"artifactId", request.Artifact.Id, "queuedTriggerId", queuedTrigger.Id, "nextAllowedAt", state.NextAllowedAt)
+	return true, nil
+}
+
+// getGatedCdWorkflowId returns the workflow an automatic deployment held back by a gate continues in once released,
+// 0 if a new workflow is to be created for it
+func getGatedCdWorkflowId(request *bean.CdTriggerRequest) int {
+	if request.CdWf != nil && request.CdWf.CiArtifactId == request.Artifact.Id {
+		// pre stage is already done in this workflow, deployment will continue in the same workflow
+		return request.CdWf.Id
+	}
+	return 0
+}
+
+func (impl *HandlerServiceImpl) isUserSuperAdmin(userId int32) bool {
+	// token is not available for async triggers, roles are resolved from the user id
+	isSuperAdmin, err := impl.userService.IsSuperAdmin(int(userId), "")
+	if err != nil {
+		impl.logger.Errorw("error in checking if user is super admin", "userId", userId, "err", err)
+		return false
+	}
+	return isSuperAdmin
+}
+
+func (impl *HandlerServiceImpl) ReleaseDeploymentWindowQueuedTriggers() {
+	queuedTriggers, err := impl.deploymentWindowService.GetReleasableQueuedTriggers(queuedTriggerReleaseBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error in fetching releasable queued triggers", "err", err)
+		return
+	}
+	for _, queuedTrigger := range queuedTriggers {
+		claimed, err := impl.deploymentWindowService.ClaimQueuedTrigger(queuedTrigger.Id)
+		if err != nil || !claimed {
+			// claimed by some other replica
+			continue
+		}
+		err = impl.releaseQueuedTrigger(queuedTrigger)
+		if err != nil {
+			impl.logger.Errorw("error in releasing queued trigger", "queuedTriggerId", queuedTrigger.Id, "pipelineId", queuedTrigger.PipelineId, "err", err)
+			if dbErr := impl.deploymentWindowService.MarkQueuedTriggerFailed(queuedTrigger.Id, err.Error()); dbErr != nil {
+				impl.logger.Errorw("error in marking queued trigger failed", "queuedTriggerId", queuedTrigger.Id, "err", dbErr)
+			}
+		}
+	}
+}
+
+func (impl *HandlerServiceImpl) releaseQueuedTrigger(queuedTrigger *bean2.QueuedTriggerDto) error {
+	pipeline, err := impl.pipelineRepository.FindById(queuedTrigger.PipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd pipeline", "pipelineId", queuedTrigger.PipelineId, "err", err)
+		return err
+	}
+	artifact, err := impl.ciArtifactRepository.Get(queuedTrigger.CiArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "artifactId", queuedTrigger.CiArtifactId, "err", err)
+		return err
+	}
+	var cdWf *pipelineConfig.CdWorkflow
+	if queuedTrigger.CdWorkflowId > 0 {
+		cdWf, err = impl.cdWorkflowRepository.FindById(queuedTrigger.CdWorkflowId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching cd workflow", "cdWorkflowId", queuedTrigger.CdWorkflowId, "err", err)
+			return err
+		}
+	}
+	impl.logger.Infow("releasing automatic deployment queued by deployment window", "queuedTriggerId", queuedTrigger.Id,
+		"pipelineId", pipeline.Id, "artifactId", artifact.Id)
+	return impl.TriggerAutomaticDeployment(bean.CdTriggerRequest{
+		CdWf:        cdWf,
+		Pipeline:    pipeline,
+		Artifact:    artifact,
+		TriggeredBy: queuedTrigger.TriggeredBy,
+		TriggerContext: bean.TriggerContext{
+			Context:     context.Background(),
+			TriggerType: bean.Automatic,
+		},
+	})
+}
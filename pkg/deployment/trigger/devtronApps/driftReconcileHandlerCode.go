@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"context"
+
+	bean3 "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/models"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+)
+
+func (impl *HandlerServiceImpl) ReconcileDrift(request *driftBean.ReconcileRequest) (int, error) {
+	overrideRequest := &bean3.ValuesOverrideRequest{
+		PipelineId:                            request.PipelineId,
+		AppId:                                 request.AppId,
+		CiArtifactId:                          request.CiArtifactId,
+		CdWorkflowType:                        bean3.CD_WORKFLOW_TYPE_DEPLOY,
+		DeploymentWithConfig:                  bean3.DEPLOYMENT_CONFIG_TYPE_SPECIFIC_TRIGGER,
+		WfrIdForDeploymentWithSpecificTrigger: request.CdWorkflowRunnerId,
+		DeploymentType:                        models.DEPLOYMENTTYPE_DEPLOY,
+		IsDriftReconcile:                      true,
+		UserId:                                userBean.SystemUserId,
+	}
+	triggerContext := bean.TriggerContext{
+		Context: context.Background(),
+	}
+	_, _, _, err := impl.ManualCdTrigger(triggerContext, overrideRequest, nil)
+	return overrideRequest.WfrId, err
+}
@@ -27,6 +27,16 @@ func GetValuesFileForEnv(environmentId int) string {
 	return fmt.Sprintf("_%d-values.yaml", environmentId) //_{envId}-values.yaml
 }
 
+// GetValuesFileForClusterSetMember is the values file of the release of the pipeline on a member cluster of its cluster set
+func GetValuesFileForClusterSetMember(environmentId, clusterId int) string {
+	return fmt.Sprintf("_%d-cluster-%d-values.yaml", environmentId, clusterId) //_{envId}-cluster-{clusterId}-values.yaml
+}
+
+// GetArgoAppNameForClusterSetMember is the argo application of the pipeline on a member cluster of its cluster set
+func GetArgoAppNameForClusterSetMember(deploymentAppName string, clusterId int) string {
+	return fmt.Sprintf("%s-cluster-%d", deploymentAppName, clusterId)
+}
+
 func NewTriggerEvent(deploymentAppType string, triggeredAt time.Time, deployedBy int32) bean.TriggerEvent {
 	// trigger event will decide whether to perform GitOps or deployment for a particular deployment app type
 	triggerEvent := bean.TriggerEvent{
@@ -0,0 +1,493 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	apibean "github.com/devtron-labs/devtron/api/bean"
+	appbean "github.com/devtron-labs/devtron/pkg/app/bean"
+
+	bean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+
+	bufio "bufio"
+
+	clusterrepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+
+	commonbean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+
+	context "context"
+
+	driftDetectionbean "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/bean"
+
+	mock "github.com/stretchr/testify/mock"
+
+	os "os"
+
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	repository "github.com/devtron-labs/devtron/internal/sql/repository"
+
+	time "time"
+
+	userbean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+)
+
+// HandlerService is an autogenerated mock type for the HandlerService type
+type HandlerService struct {
+	mock.Mock
+}
+
+// CancelStage provides a mock function with given fields: workflowRunnerId, forceAbort, userId
+func (_m *HandlerService) CancelStage(workflowRunnerId int, forceAbort bool, userId int32) (int, error) {
+	ret := _m.Called(workflowRunnerId, forceAbort, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelStage")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, bool, int32) (int, error)); ok {
+		return rf(workflowRunnerId, forceAbort, userId)
+	}
+	if rf, ok := ret.Get(0).(func(int, bool, int32) int); ok {
+		r0 = rf(workflowRunnerId, forceAbort, userId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, bool, int32) error); ok {
+		r1 = rf(workflowRunnerId, forceAbort, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClusterSetMemberRelease provides a mock function with given fields: ctx, pipeline, deploymentAppType, clusterId
+func (_m *HandlerService) DeleteClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline, deploymentAppType string, clusterId int) error {
+	ret := _m.Called(ctx, pipeline, deploymentAppType, clusterId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClusterSetMemberRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, string, int) error); ok {
+		r0 = rf(ctx, pipeline, deploymentAppType, clusterId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadCdWorkflowArtifacts provides a mock function with given fields: buildId
+func (_m *HandlerService) DownloadCdWorkflowArtifacts(buildId int) (*os.File, error) {
+	ret := _m.Called(buildId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadCdWorkflowArtifacts")
+	}
+
+	var r0 *os.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*os.File, error)); ok {
+		return rf(buildId)
+	}
+	if rf, ok := ret.Get(0).(func(int) *os.File); ok {
+		r0 = rf(buildId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*os.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(buildId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRunningWorkflowLogs provides a mock function with given fields: environmentId, pipelineId, workflowId, followLogs
+func (_m *HandlerService) GetRunningWorkflowLogs(environmentId int, pipelineId int, workflowId int, followLogs bool) (*bufio.Reader, func() error, error) {
+	ret := _m.Called(environmentId, pipelineId, workflowId, followLogs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunningWorkflowLogs")
+	}
+
+	var r0 *bufio.Reader
+	var r1 func() error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) (*bufio.Reader, func() error, error)); ok {
+		return rf(environmentId, pipelineId, workflowId, followLogs)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int, bool) *bufio.Reader); ok {
+		r0 = rf(environmentId, pipelineId, workflowId, followLogs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bufio.Reader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int, bool) func() error); ok {
+		r1 = rf(environmentId, pipelineId, workflowId, followLogs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(int, int, int, bool) error); ok {
+		r2 = rf(environmentId, pipelineId, workflowId, followLogs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ManualCdTrigger provides a mock function with given fields: triggerContext, overrideRequest, userMetadata
+func (_m *HandlerService) ManualCdTrigger(triggerContext bean.TriggerContext, overrideRequest *apibean.ValuesOverrideRequest, userMetadata *userbean.UserMetadata) (int, string, *appbean.ManifestPushTemplate, error) {
+	ret := _m.Called(triggerContext, overrideRequest, userMetadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ManualCdTrigger")
+	}
+
+	var r0 int
+	var r1 string
+	var r2 *appbean.ManifestPushTemplate
+	var r3 error
+	if rf, ok := ret.Get(0).(func(bean.TriggerContext, *apibean.ValuesOverrideRequest, *userbean.UserMetadata) (int, string, *appbean.ManifestPushTemplate, error)); ok {
+		return rf(triggerContext, overrideRequest, userMetadata)
+	}
+	if rf, ok := ret.Get(0).(func(bean.TriggerContext, *apibean.ValuesOverrideRequest, *userbean.UserMetadata) int); ok {
+		r0 = rf(triggerContext, overrideRequest, userMetadata)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(bean.TriggerContext, *apibean.ValuesOverrideRequest, *userbean.UserMetadata) string); ok {
+		r1 = rf(triggerContext, overrideRequest, userMetadata)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(bean.TriggerContext, *apibean.ValuesOverrideRequest, *userbean.UserMetadata) *appbean.ManifestPushTemplate); ok {
+		r2 = rf(triggerContext, overrideRequest, userMetadata)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*appbean.ManifestPushTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(3).(func(bean.TriggerContext, *apibean.ValuesOverrideRequest, *userbean.UserMetadata) error); ok {
+		r3 = rf(triggerContext, overrideRequest, userMetadata)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// ReconcileDrift provides a mock function with given fields: request
+func (_m *HandlerService) ReconcileDrift(request *driftDetectionbean.ReconcileRequest) (int, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileDrift")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*driftDetectionbean.ReconcileRequest) (int, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*driftDetectionbean.ReconcileRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*driftDetectionbean.ReconcileRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseDeploymentWindowQueuedTriggers provides a mock function with no fields
+func (_m *HandlerService) ReleaseDeploymentWindowQueuedTriggers() {
+	_m.Called()
+}
+
+// SyncClusterSetMemberRunnerStatus provides a mock function with given fields: ctx, pipeline, runnerId, clusterId, timeout
+func (_m *HandlerService) SyncClusterSetMemberRunnerStatus(ctx context.Context, pipeline *pipelineConfig.Pipeline, runnerId int, clusterId int, timeout time.Duration) (*pipelineConfig.CdWorkflowRunner, error) {
+	ret := _m.Called(ctx, pipeline, runnerId, clusterId, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncClusterSetMemberRunnerStatus")
+	}
+
+	var r0 *pipelineConfig.CdWorkflowRunner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, int, int, time.Duration) (*pipelineConfig.CdWorkflowRunner, error)); ok {
+		return rf(ctx, pipeline, runnerId, clusterId, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, int, int, time.Duration) *pipelineConfig.CdWorkflowRunner); ok {
+		r0 = rf(ctx, pipeline, runnerId, clusterId, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipelineConfig.CdWorkflowRunner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pipelineConfig.Pipeline, int, int, time.Duration) error); ok {
+		r1 = rf(ctx, pipeline, runnerId, clusterId, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerAutoCDOnPreStageSuccess provides a mock function with given fields: triggerContext, cdPipelineId, ciArtifactId, workflowId
+func (_m *HandlerService) TriggerAutoCDOnPreStageSuccess(triggerContext bean.TriggerContext, cdPipelineId int, ciArtifactId int, workflowId int) error {
+	ret := _m.Called(triggerContext, cdPipelineId, ciArtifactId, workflowId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerAutoCDOnPreStageSuccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bean.TriggerContext, int, int, int) error); ok {
+		r0 = rf(triggerContext, cdPipelineId, ciArtifactId, workflowId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TriggerAutoRollbacks provides a mock function with no fields
+func (_m *HandlerService) TriggerAutoRollbacks() {
+	_m.Called()
+}
+
+// TriggerAutomaticDeployment provides a mock function with given fields: request
+func (_m *HandlerService) TriggerAutomaticDeployment(request bean.CdTriggerRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerAutomaticDeployment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TriggerClusterSetMemberRelease provides a mock function with given fields: ctx, pipeline, artifact, cluster, triggeredBy
+func (_m *HandlerService) TriggerClusterSetMemberRelease(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact, cluster *clusterrepository.Cluster, triggeredBy int32) (int, error) {
+	ret := _m.Called(ctx, pipeline, artifact, cluster, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerClusterSetMemberRelease")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, *repository.CiArtifact, *clusterrepository.Cluster, int32) (int, error)); ok {
+		return rf(ctx, pipeline, artifact, cluster, triggeredBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, *repository.CiArtifact, *clusterrepository.Cluster, int32) int); ok {
+		r0 = rf(ctx, pipeline, artifact, cluster, triggeredBy)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pipelineConfig.Pipeline, *repository.CiArtifact, *clusterrepository.Cluster, int32) error); ok {
+		r1 = rf(ctx, pipeline, artifact, cluster, triggeredBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerPostStage provides a mock function with given fields: request
+func (_m *HandlerService) TriggerPostStage(request bean.CdTriggerRequest) (*appbean.ManifestPushTemplate, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerPostStage")
+	}
+
+	var r0 *appbean.ManifestPushTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) (*appbean.ManifestPushTemplate, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) *appbean.ManifestPushTemplate); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appbean.ManifestPushTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bean.CdTriggerRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerPreStage provides a mock function with given fields: request
+func (_m *HandlerService) TriggerPreStage(request bean.CdTriggerRequest) (*appbean.ManifestPushTemplate, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerPreStage")
+	}
+
+	var r0 *appbean.ManifestPushTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) (*appbean.ManifestPushTemplate, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) *appbean.ManifestPushTemplate); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*appbean.ManifestPushTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bean.CdTriggerRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerRelease provides a mock function with given fields: ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy
+func (_m *HandlerService) TriggerRelease(ctx context.Context, overrideRequest *apibean.ValuesOverrideRequest, envDeploymentConfig *commonbean.DeploymentConfig, triggeredAt time.Time, triggeredBy int32) (int, *appbean.ManifestPushTemplate, error) {
+	ret := _m.Called(ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerRelease")
+	}
+
+	var r0 int
+	var r1 *appbean.ManifestPushTemplate
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *apibean.ValuesOverrideRequest, *commonbean.DeploymentConfig, time.Time, int32) (int, *appbean.ManifestPushTemplate, error)); ok {
+		return rf(ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *apibean.ValuesOverrideRequest, *commonbean.DeploymentConfig, time.Time, int32) int); ok {
+		r0 = rf(ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *apibean.ValuesOverrideRequest, *commonbean.DeploymentConfig, time.Time, int32) *appbean.ManifestPushTemplate); ok {
+		r1 = rf(ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*appbean.ManifestPushTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *apibean.ValuesOverrideRequest, *commonbean.DeploymentConfig, time.Time, int32) error); ok {
+		r2 = rf(ctx, overrideRequest, envDeploymentConfig, triggeredAt, triggeredBy)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TriggerStageForBulk provides a mock function with given fields: triggerRequest
+func (_m *HandlerService) TriggerStageForBulk(triggerRequest bean.CdTriggerRequest) error {
+	ret := _m.Called(triggerRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerStageForBulk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bean.CdTriggerRequest) error); ok {
+		r0 = rf(triggerRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateArtifactGates provides a mock function with given fields: ctx, pipeline, artifact
+func (_m *HandlerService) ValidateArtifactGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) error {
+	ret := _m.Called(ctx, pipeline, artifact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateArtifactGates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, *repository.CiArtifact) error); ok {
+		r0 = rf(ctx, pipeline, artifact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateDeploymentGates provides a mock function with given fields: ctx, pipeline, artifact, triggeredBy
+func (_m *HandlerService) ValidateDeploymentGates(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact, triggeredBy int32) error {
+	ret := _m.Called(ctx, pipeline, artifact, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateDeploymentGates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pipelineConfig.Pipeline, *repository.CiArtifact, int32) error); ok {
+		r0 = rf(ctx, pipeline, artifact, triggeredBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHandlerService creates a new instance of HandlerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHandlerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HandlerService {
+	mock := &HandlerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -110,6 +110,12 @@ func (impl *HandlerServiceImpl) TriggerPreStage(request bean.CdTriggerRequest) (
 		return nil, nil
 	}
 
+	err = impl.checkTestGate(pipeline, artifact)
+	if err != nil {
+		impl.logger.Errorw("pre stage not allowed by test gate", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return nil, err
+	}
+
 	cdWf, runner, err := impl.createStartingWfAndRunner(request, triggeredAt)
 	if err != nil {
 		impl.logger.Errorw("error in creating wf starting and runner entry", "err", err, "request", request)
@@ -21,7 +21,7 @@ func (impl *HandlerServiceImpl) checkFeasibilityForPreStage(pipeline *pipelineCo
 }
 
 func (impl *HandlerServiceImpl) createAuditDataForDeploymentWindowBypass(request bean2.CdTriggerRequest, wfrId int) error {
-	return nil
+	return impl.deploymentWindowService.SaveBypassAudit(request.DeploymentWindowCheckResult, request.Pipeline.Id, wfrId, request.TriggeredBy)
 }
 
 func (impl *HandlerServiceImpl) getManifestPushTemplateForPreStage(ctx context.Context, envDeploymentConfig *bean3.DeploymentConfig,
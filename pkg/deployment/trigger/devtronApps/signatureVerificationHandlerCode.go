@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+)
+
+// checkImageSignature verifies the signatures of the artifact against the signature policies of the environment
+// and records the result on the runner, the runner is marked failed when an enforced policy blocks the artifact.
+// rollbacks are verified as well so that images signed by a revoked identity are not deployed again
+func (impl *HandlerServiceImpl) checkImageSignature(ctx context.Context, validateDeploymentTriggerObj *bean.ValidateDeploymentTriggerObj) error {
+	runner := validateDeploymentTriggerObj.Runner
+	result, err := impl.imageSignatureService.VerifyArtifactForDeployment(ctx, validateDeploymentTriggerObj.CdPipeline, validateDeploymentTriggerObj.Artifact)
+	if err != nil {
+		impl.logger.Errorw("error in verifying image signature", "pipelineId", validateDeploymentTriggerObj.CdPipeline.Id, "artifactId", validateDeploymentTriggerObj.Artifact.Id, "err", err)
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	runner.SignatureVerificationStatus = result.Status.String()
+	runner.SignatureVerificationMessage = result.Message
+	// failing to record the result does not decide the deployment
+	_ = impl.imageSignatureService.RecordRunnerVerificationResult(runner.Id, result)
+	if !result.Blocked {
+		return nil
+	}
+	if err = impl.cdWorkflowCommonService.MarkCurrentDeploymentFailed(runner, errors.New(result.Message), validateDeploymentTriggerObj.TriggeredBy); err != nil {
+		impl.logger.Errorw("error while updating current runner status to failed, checkImageSignature", "wfrId", runner.Id, "err", err)
+	}
+	return util.NewApiError(http.StatusPreconditionFailed, result.Message, result.Message)
+}
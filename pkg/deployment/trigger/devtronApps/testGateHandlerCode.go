@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devtronApps
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+)
+
+// checkTestGate returns an error if the test results of the ci workflow which built the artifact
+// do not meet the test gate configured on the pipeline, pipelines without a test gate are always allowed
+func (impl *HandlerServiceImpl) checkTestGate(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) error {
+	evaluation, err := impl.testGateService.EvaluateTestGate(pipeline.Id, artifact)
+	if err != nil {
+		impl.logger.Errorw("error in evaluating test gate", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return err
+	}
+	if !evaluation.Allowed {
+		errMsg := fmt.Sprintf("artifact blocked by test gate: %s", strings.Join(evaluation.Reasons, ", "))
+		return util.NewApiError(http.StatusPreconditionFailed, errMsg, errMsg)
+	}
+	return nil
+}
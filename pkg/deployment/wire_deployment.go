@@ -17,10 +17,23 @@
 package deployment
 
 import (
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentPreview"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment"
 	"github.com/devtron-labs/devtron/pkg/deployment/providerConfig"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger"
 	"github.com/google/wire"
 )
@@ -33,4 +46,17 @@ var DeploymentWireSet = wire.NewSet(
 	trigger.DeploymentTriggerWireSet,
 	deployedApp.DeployedAppWireSet,
 	providerConfig.DeploymentProviderConfigWireSet,
+	deploymentWindow.DeploymentWindowWireSet,
+	approval.DeploymentApprovalWireSet,
+	autoRollback.AutoRollbackWireSet,
+	metricVerification.MetricVerificationWireSet,
+	driftDetection.DriftDetectionWireSet,
+	pullRequest.PullRequestModeWireSet,
+	monoRepo.MonoRepoWireSet,
+	previewEnvironment.PreviewEnvironmentWireSet,
+	hibernationSchedule.HibernationScheduleWireSet,
+	releaseOrchestration.ReleaseOrchestrationWireSet,
+	scheduledDeployment.ScheduledDeploymentWireSet,
+	deploymentPreview.DeploymentPreviewWireSet,
+	clusterSet.ClusterSetWireSet,
 )
@@ -24,24 +24,26 @@ import (
 	"github.com/devtron-labs/devtron/api/bean"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	bean3 "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	workflowStatusBean "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/bean"
 	"github.com/devtron-labs/devtron/util"
 	"time"
 )
 
 type CdStageCompleteEvent struct {
-	CiProjectDetails              []bean3.CiProjectDetails     `json:"ciProjectDetails"`
-	WorkflowId                    int                          `json:"workflowId"`
-	WorkflowRunnerId              int                          `json:"workflowRunnerId"`
-	CdPipelineId                  int                          `json:"cdPipelineId"`
-	TriggeredBy                   int32                        `json:"triggeredBy"`
-	StageYaml                     string                       `json:"stageYaml"`
-	ArtifactLocation              string                       `json:"artifactLocation"`
-	PipelineName                  string                       `json:"pipelineName"`
-	CiArtifactDTO                 pipelineConfig.CiArtifactDTO `json:"ciArtifactDTO"`
-	PluginRegistryArtifactDetails map[string][]string          `json:"PluginRegistryArtifactDetails"`
-	PluginArtifacts               *PluginArtifacts             `json:"pluginArtifacts"`
-	IsArtifactUploaded            bool                         `json:"isArtifactUploaded"`
-	IsFailed                      bool                         `json:"isFailed"`
+	CiProjectDetails              []bean3.CiProjectDetails               `json:"ciProjectDetails"`
+	WorkflowId                    int                                    `json:"workflowId"`
+	WorkflowRunnerId              int                                    `json:"workflowRunnerId"`
+	CdPipelineId                  int                                    `json:"cdPipelineId"`
+	TriggeredBy                   int32                                  `json:"triggeredBy"`
+	StageYaml                     string                                 `json:"stageYaml"`
+	ArtifactLocation              string                                 `json:"artifactLocation"`
+	PipelineName                  string                                 `json:"pipelineName"`
+	CiArtifactDTO                 pipelineConfig.CiArtifactDTO           `json:"ciArtifactDTO"`
+	PluginRegistryArtifactDetails map[string][]string                    `json:"PluginRegistryArtifactDetails"`
+	PluginArtifacts               *PluginArtifacts                       `json:"pluginArtifacts"`
+	IsArtifactUploaded            bool                                   `json:"isArtifactUploaded"`
+	IsFailed                      bool                                   `json:"isFailed"`
+	StepExecutionDetails          []*workflowStatusBean.StepExecutionDto `json:"stepExecutionDetails,omitempty"` // per step status and retry attempts
 }
 
 type UserDeploymentRequest struct {
@@ -87,7 +89,8 @@ type CiCompleteEvent struct {
 	IsScanEnabled                 bool                     `json:"isScanEnabled"`
 	TargetPlatforms               []string                 `json:"targetPlatforms"`
 	pluginImageDetails            *registry.ImageDetailsFromCR
-	PluginArtifacts               *PluginArtifacts `json:"pluginArtifacts"`
+	PluginArtifacts               *PluginArtifacts                       `json:"pluginArtifacts"`
+	StepExecutionDetails          []*workflowStatusBean.StepExecutionDto `json:"stepExecutionDetails,omitempty"` // per step status and retry attempts
 }
 
 func (c *CiCompleteEvent) GetPluginImageDetails() *registry.ImageDetailsFromCR {
@@ -46,6 +46,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
 	deploymentBean "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
 	triggerAdapter "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/adapter"
 	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
@@ -54,6 +55,8 @@ import (
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/out/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
+	"github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus"
+	workflowStatusBean "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/bean"
 	"github.com/devtron-labs/devtron/pkg/ucid"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd/adapter"
@@ -106,6 +109,9 @@ type WorkflowEventProcessorImpl struct {
 	ciArtifactRepository    repository.CiArtifactRepository
 	cdWorkflowRepository    pipelineConfig.CdWorkflowRepository
 	deploymentConfigService common.DeploymentConfigService
+
+	workflowStageStatusService workflowStatus.WorkFlowStageStatusService
+	previewEnvironmentService  previewEnvironment.PreviewEnvironmentService
 }
 
 func NewWorkflowEventProcessorImpl(logger *zap.SugaredLogger,
@@ -131,7 +137,9 @@ func NewWorkflowEventProcessorImpl(logger *zap.SugaredLogger,
 	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
 	deploymentConfigService common.DeploymentConfigService,
 	ciHandlerService trigger.HandlerService,
-	asyncRunnable *async.Runnable) (*WorkflowEventProcessorImpl, error) {
+	asyncRunnable *async.Runnable,
+	workflowStageStatusService workflowStatus.WorkFlowStageStatusService,
+	previewEnvironmentService previewEnvironment.PreviewEnvironmentService) (*WorkflowEventProcessorImpl, error) {
 	impl := &WorkflowEventProcessorImpl{
 		logger:                          logger,
 		pubSubClient:                    pubSubClient,
@@ -161,6 +169,8 @@ func NewWorkflowEventProcessorImpl(logger *zap.SugaredLogger,
 		deploymentConfigService:         deploymentConfigService,
 		ciHandlerService:                ciHandlerService,
 		asyncRunnable:                   asyncRunnable,
+		workflowStageStatusService:      workflowStageStatusService,
+		previewEnvironmentService:       previewEnvironmentService,
 	}
 	appServiceConfig, err := app.GetAppServiceConfig()
 	if err != nil {
@@ -208,6 +218,7 @@ func (impl *WorkflowEventProcessorImpl) SubscribeCDStageCompleteEvent() error {
 				return
 			}
 		}
+		impl.saveStepExecutionDetails(wfr.Id, wfr.WorkflowType.String(), cdStageCompleteEvent.StepExecutionDetails)
 		triggerContext := triggerBean.TriggerContext{
 			ReferenceId: pointer.String(msg.MsgId),
 		}
@@ -234,6 +245,18 @@ func (impl *WorkflowEventProcessorImpl) SubscribeCDStageCompleteEvent() error {
 	return nil
 }
 
+// saveStepExecutionDetails records the step statuses and retry attempts reported by the runner,
+// failure here is only logged as the completion event is still to be processed
+func (impl *WorkflowEventProcessorImpl) saveStepExecutionDetails(wfId int, wfType string, stepExecutionDetails []*workflowStatusBean.StepExecutionDto) {
+	if len(stepExecutionDetails) == 0 {
+		return
+	}
+	err := impl.workflowStageStatusService.SaveStepExecutionStages(wfId, wfType, stepExecutionDetails)
+	if err != nil {
+		impl.logger.Errorw("error in saving step execution details", "wfId", wfId, "wfType", wfType, "err", err)
+	}
+}
+
 func (impl *WorkflowEventProcessorImpl) handleCDStageCompleteEvent(triggerContext triggerBean.TriggerContext, cdStageCompleteEvent bean.CdStageCompleteEvent, wfr *cdWorkflowBean.CdWorkflowRunnerDto) {
 	if cdStageCompleteEvent.IsFailed {
 		impl.logger.Debugw("event received from ci runner, updating workflow runner status as failed, not taking any action", "savedWorkflowRunnerId", wfr.Id, "oldStatus", wfr.Status, "podStatus", wfr.PodStatus)
@@ -588,6 +611,9 @@ func (impl *WorkflowEventProcessorImpl) SubscribeCICompleteEvent() error {
 			return
 		}
 		impl.logger.Debugw("ci complete event for ci", "ciPipelineId", ciCompleteEvent.PipelineId)
+		if ciCompleteEvent.WorkflowId != nil {
+			impl.saveStepExecutionDetails(*ciCompleteEvent.WorkflowId, apiBean.CI_WORKFLOW_TYPE.String(), ciCompleteEvent.StepExecutionDetails)
+		}
 		req, err := impl.BuildCiArtifactRequest(ciCompleteEvent)
 		if err != nil {
 			return
@@ -680,6 +706,9 @@ func (impl *WorkflowEventProcessorImpl) validateAndHandleCiSuccessEvent(triggerC
 			ciPipelineId, "request", request, "error", err)
 		return 0, err
 	}
+	if request.WorkflowId != nil {
+		impl.previewEnvironmentService.DeployPreviewAsync(buildArtifactId, *request.WorkflowId)
+	}
 	return buildArtifactId, nil
 }
 
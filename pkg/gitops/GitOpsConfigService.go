@@ -416,6 +416,7 @@ func (impl *GitOpsConfigServiceImpl) createGitOpsConfig(ctx context.Context, req
 		AllowCustomRepository: request.AllowCustomRepository,
 		BitBucketWorkspaceId:  request.BitBucketWorkspaceId,
 		BitBucketProjectKey:   request.BitBucketProjectKey,
+		GiteaOrgId:            request.GiteaOrgId,
 		EnableTLSVerification: request.EnableTLSVerification,
 		AuditLog:              sql.AuditLog{CreatedBy: request.UserId, CreatedOn: time.Now(), UpdatedOn: time.Now(), UpdatedBy: request.UserId},
 	}
@@ -687,6 +688,7 @@ func (impl *GitOpsConfigServiceImpl) updateGitOpsConfig(request *apiBean.GitOpsC
 	model.AzureProject = request.AzureProjectName
 	model.BitBucketWorkspaceId = request.BitBucketWorkspaceId
 	model.BitBucketProjectKey = request.BitBucketProjectKey
+	model.GiteaOrgId = request.GiteaOrgId
 	model.AllowCustomRepository = request.AllowCustomRepository
 	model.EnableTLSVerification = request.EnableTLSVerification
 	model.UpdatedBy = request.UserId
@@ -790,6 +792,7 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigById(id int) (*apiBean.GitOp
 		AzureProjectName:      model.AzureProject,
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
+		GiteaOrgId:            model.GiteaOrgId,
 		AllowCustomRepository: model.AllowCustomRepository,
 		EnableTLSVerification: model.EnableTLSVerification,
 		TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
@@ -825,6 +828,7 @@ func (impl *GitOpsConfigServiceImpl) GetAllGitOpsConfig() ([]*apiBean.GitOpsConf
 			AzureProjectName:      model.AzureProject,
 			BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 			BitBucketProjectKey:   model.BitBucketProjectKey,
+			GiteaOrgId:            model.GiteaOrgId,
 			AllowCustomRepository: model.AllowCustomRepository,
 			EnableTLSVerification: model.EnableTLSVerification,
 			TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
@@ -860,6 +864,7 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigByProvider(provider string)
 		AzureProjectName:      model.AzureProject,
 		BitBucketWorkspaceId:  model.BitBucketWorkspaceId,
 		BitBucketProjectKey:   model.BitBucketProjectKey,
+		GiteaOrgId:            model.GiteaOrgId,
 		AllowCustomRepository: model.AllowCustomRepository,
 		EnableTLSVerification: model.EnableTLSVerification,
 		TLSConfig: &bean.TLSConfig{ // sending empty values as they are hidden in FE
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package leaderElection
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/leaderElection/bean"
+	"github.com/devtron-labs/devtron/pkg/leaderElection/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LeaderElectionService elects a single replica to run a scheduler, the leader keeps its lease by renewing it on
+// every run of the scheduler and another replica takes over once the lease expires
+type LeaderElectionService interface {
+	// IsLeader acquires or renews the lease of the scheduler, it returns true if this replica holds it
+	IsLeader(scheduler string) bool
+}
+
+type LeaderElectionServiceImpl struct {
+	logger                *zap.SugaredLogger
+	config                *bean.LeaderElectionConfig
+	leaderLeaseRepository repository.LeaderLeaseRepository
+	holderId              string
+}
+
+func NewLeaderElectionServiceImpl(logger *zap.SugaredLogger,
+	leaderLeaseRepository repository.LeaderLeaseRepository) (*LeaderElectionServiceImpl, error) {
+	config := &bean.LeaderElectionConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing leader election config", "err", err)
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warnw("error in getting hostname, leases are held by a random id", "err", err)
+	}
+	// the random suffix keeps a restarted replica with the same hostname from inheriting the lease of its
+	// previous process while that one might still be running
+	holderId := fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8])
+	return &LeaderElectionServiceImpl{
+		logger:                logger,
+		config:                config,
+		leaderLeaseRepository: leaderLeaseRepository,
+		holderId:              holderId,
+	}, nil
+}
+
+func (impl *LeaderElectionServiceImpl) IsLeader(scheduler string) bool {
+	leaseDuration := time.Duration(impl.config.LeaseDurationSecs) * time.Second
+	isLeader, err := impl.leaderLeaseRepository.AcquireOrRenew(scheduler, impl.holderId, leaseDuration)
+	if err != nil {
+		// without the lease the run is skipped, running it on every replica is worse than missing it
+		impl.logger.Errorw("error in acquiring leader lease", "scheduler", scheduler, "holderId", impl.holderId, "err", err)
+		return false
+	}
+	return isLeader
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+// CATEGORY=LEADER_ELECTION
+type LeaderElectionConfig struct {
+	LeaseDurationSecs int `env:"LEADER_ELECTION_LEASE_DURATION_SECS" envDefault:"90" description:"Seconds a replica stays the leader of a scheduler without renewing its lease, must be longer than the interval of the scheduler"`
+}
+
+const (
+	// HibernationScheduler runs the hibernation schedules of the environments
+	HibernationScheduler = "hibernation-scheduler"
+	// ReleaseOrchestrator moves the running releases through their stages
+	ReleaseOrchestrator = "release-orchestrator"
+	// ScheduledDeployer runs the due scheduled deployments
+	ScheduledDeployer = "scheduled-deployer"
+	// ClusterSetDeployer deploys the running cluster set deployments to their member clusters
+	ClusterSetDeployer = "cluster-set-deployer"
+)
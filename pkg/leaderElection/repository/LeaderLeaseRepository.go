@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type LeaderLease struct {
+	tableName  struct{}  `sql:"scheduler_leader_lease" pg:",discard_unknown_columns"`
+	Name       string    `sql:"name,pk"`
+	HolderId   string    `sql:"holder_id,notnull"`
+	LeaseUntil time.Time `sql:"lease_until,notnull"`
+	AcquiredOn time.Time `sql:"acquired_on,notnull"`
+	UpdatedOn  time.Time `sql:"updated_on,notnull"`
+}
+
+type LeaderLeaseRepository interface {
+	// AcquireOrRenew takes the lease if it is free or expired, or extends it if it is already held by the holder.
+	// It returns true if the holder holds the lease afterwards.
+	AcquireOrRenew(name, holderId string, leaseDuration time.Duration) (bool, error)
+	FindByName(name string) (*LeaderLease, error)
+}
+
+type LeaderLeaseRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewLeaderLeaseRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *LeaderLeaseRepositoryImpl {
+	return &LeaderLeaseRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *LeaderLeaseRepositoryImpl) AcquireOrRenew(name, holderId string, leaseDuration time.Duration) (bool, error) {
+	// the database clock is used for the expiry, so the clock skew of the replicas does not matter
+	query := `INSERT INTO scheduler_leader_lease (name, holder_id, lease_until, acquired_on, updated_on)
+		VALUES (?, ?, now() + ? * interval '1 millisecond', now(), now())
+		ON CONFLICT (name) DO UPDATE SET holder_id = EXCLUDED.holder_id, lease_until = EXCLUDED.lease_until, updated_on = EXCLUDED.updated_on,
+			acquired_on = CASE WHEN scheduler_leader_lease.holder_id = EXCLUDED.holder_id THEN scheduler_leader_lease.acquired_on ELSE EXCLUDED.acquired_on END
+		WHERE scheduler_leader_lease.holder_id = EXCLUDED.holder_id OR scheduler_leader_lease.lease_until < now()`
+	res, err := impl.dbConnection.Exec(query, name, holderId, leaseDuration.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *LeaderLeaseRepositoryImpl) FindByName(name string) (*LeaderLease, error) {
+	lease := &LeaderLease{}
+	err := impl.dbConnection.Model(lease).
+		Where("name = ?", name).
+		Select()
+	return lease, err
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package adaptor
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/overview/bean"
+	"github.com/devtron-labs/devtron/pkg/overview/repository"
+)
+
+// commitTimeLayouts are the formats in which commit time is stored in artifact material info
+var commitTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// GetDoraDeployments converts finished deployment runners to DORA deployments,
+// runners which are still in progress or were aborted are dropped
+func GetDoraDeployments(runners []*repository.DeploymentRunnerData) []*bean.DoraDeployment {
+	deployments := make([]*bean.DoraDeployment, 0, len(runners))
+	for _, runner := range runners {
+		outcome, ok := getDeploymentOutcome(runner.Status)
+		if !ok {
+			continue
+		}
+		deployment := &bean.DoraDeployment{
+			AppId:        runner.AppId,
+			EnvId:        runner.EnvironmentId,
+			PipelineId:   runner.PipelineId,
+			CiArtifactId: runner.CiArtifactId,
+			Status:       outcome,
+			StartedOn:    runner.StartedOn,
+			FinishedOn:   runner.FinishedOn,
+		}
+		if deployment.FinishedOn.IsZero() || deployment.FinishedOn.Before(deployment.StartedOn) {
+			deployment.FinishedOn = deployment.StartedOn
+		}
+		deployment.CommitHash, deployment.CommitTime = getLatestCommit(runner.MaterialInfo)
+		deployments = append(deployments, deployment)
+	}
+	return deployments
+}
+
+func getDeploymentOutcome(status string) (bean.DeploymentOutcome, bool) {
+	switch status {
+	case cdWorkflow.WorkflowSucceeded, string(health.HealthStatusHealthy):
+		return bean.DeploymentOutcomeSuccess, true
+	case cdWorkflow.WorkflowFailed, cdWorkflow.WorkflowTimedOut, string(health.HealthStatusDegraded):
+		return bean.DeploymentOutcomeFailure, true
+	default:
+		return 0, false
+	}
+}
+
+// getLatestCommit returns the most recent commit across all materials of the artifact
+func getLatestCommit(materialInfo string) (string, time.Time) {
+	var commitHash string
+	var commitTime time.Time
+	if len(materialInfo) == 0 {
+		return commitHash, commitTime
+	}
+	var ciMaterials []*repository2.CiMaterialInfo
+	if err := json.Unmarshal([]byte(materialInfo), &ciMaterials); err != nil {
+		return commitHash, commitTime
+	}
+	for _, ciMaterial := range ciMaterials {
+		if ciMaterial == nil || len(ciMaterial.Modifications) == 0 {
+			continue
+		}
+		modification := ciMaterial.Modifications[0]
+		modifiedTime, ok := parseCommitTime(modification.ModifiedTime)
+		if !ok {
+			if len(commitHash) == 0 {
+				commitHash = modification.Revision
+			}
+			continue
+		}
+		if modifiedTime.After(commitTime) {
+			commitTime = modifiedTime
+			commitHash = modification.Revision
+		}
+	}
+	return commitHash, commitTime
+}
+
+func parseCommitTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if idx := strings.Index(value, " m="); idx > 0 {
+		// monotonic clock reading present when time was stored using time.String()
+		value = value[:idx]
+	}
+	if len(value) == 0 {
+		return time.Time{}, false
+	}
+	for _, layout := range commitTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -42,18 +42,37 @@ func (r *AllDoraMetrics) WithMeanTimeToRecovery(meanTimeToRecovery *DoraMetric)
 	return r
 }
 
-// LensMetrics represents the response structure from Lens API
-type LensMetrics struct {
-	AverageCycleTime       float64 `json:"average_cycle_time"`
-	AverageLeadTime        float64 `json:"average_lead_time"`
-	ChangeFailureRate      float64 `json:"change_failure_rate"`
-	AverageRecoveryTime    float64 `json:"average_recovery_time"`
-	AverageDeploymentSize  float32 `json:"average_deployment_size"`
-	AverageLineAdded       float32 `json:"average_line_added"`
-	AverageLineDeleted     float32 `json:"average_line_deleted"`
-	LastFailedTime         string  `json:"last_failed_time"`
-	RecoveryTimeLastFailed float64 `json:"recovery_time_last_failed"`
-}
+// AppEnvDoraMetrics holds the DORA metrics computed for a single app-environment pair
+type AppEnvDoraMetrics struct {
+	AppId               int
+	EnvId               int
+	DeploymentFrequency float64 // successful deployments per day
+	MeanLeadTime        float64 // minutes from commit to successful deployment
+	ChangeFailureRate   float64 // percentage of failed deployments
+	MeanTimeToRecovery  float64 // minutes from a failed deployment to the next successful one
+	LeadTimeSamples     int     // deployments MeanLeadTime is averaged over
+	RecoveryTimeSamples int     // recoveries MeanTimeToRecovery is averaged over
+}
+
+// DoraDeployment is a finished deployment used as input for DORA metric calculation
+type DoraDeployment struct {
+	AppId        int
+	EnvId        int
+	PipelineId   int
+	CiArtifactId int
+	Status       DeploymentOutcome
+	StartedOn    time.Time
+	FinishedOn   time.Time
+	CommitHash   string
+	CommitTime   time.Time // zero if commit time is not known
+}
+
+type DeploymentOutcome int
+
+const (
+	DeploymentOutcomeSuccess DeploymentOutcome = iota
+	DeploymentOutcomeFailure
+)
 
 type DoraMetric struct {
 	OverallAverage        *MetricValue           `json:"overallAverage"`
@@ -100,6 +119,9 @@ type DoraMetricsRequest struct {
 	TimeRangeRequest *utils.TimeRangeRequest `json:"timeRangeRequest"`
 	PrevFrom         *time.Time              `json:"prevFrom,omitempty"` // Previous period start time
 	PrevTo           *time.Time              `json:"prevTo,omitempty"`   // Previous period end time
+	AppIds           []int                   `json:"appIds,omitempty"`
+	EnvIds           []int                   `json:"envIds,omitempty"`
+	TeamIds          []int                   `json:"teamIds,omitempty"`
 }
 
 type DoraMetricsResponse struct {
@@ -170,3 +192,36 @@ func IsValidPerformanceCategory(category string) bool {
 		return false
 	}
 }
+
+// DeploymentMetricsRequest is the request for deployment metrics of a single app-environment pair,
+// from and to are RFC3339 timestamps
+type DeploymentMetricsRequest struct {
+	AppId int    `json:"app_id"`
+	EnvId int    `json:"env_id"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// AppEnvDeploymentMetrics is the deployment metrics response of a single app-environment pair
+type AppEnvDeploymentMetrics struct {
+	Series                 []*DeploymentMetricPoint `json:"series"`
+	AverageCycleTime       float64                  `json:"average_cycle_time"` // days between deployments
+	AverageLeadTime        float64                  `json:"average_lead_time"`  // minutes
+	ChangeFailureRate      float64                  `json:"change_failure_rate"`
+	AverageRecoveryTime    float64                  `json:"average_recovery_time"` // minutes
+	AverageDeploymentSize  float32                  `json:"average_deployment_size"`
+	AverageLineAdded       float32                  `json:"average_line_added"`
+	AverageLineDeleted     float32                  `json:"average_line_deleted"`
+	LastFailedTime         string                   `json:"last_failed_time"`
+	RecoveryTimeLastFailed float64                  `json:"recovery_time_last_failed"` // minutes
+}
+
+type DeploymentMetricPoint struct {
+	ReleaseStatus DeploymentOutcome `json:"release_status"`
+	ReleaseTime   time.Time         `json:"release_time"`
+	CommitHash    string            `json:"commit_hash"`
+	CommitTime    *time.Time        `json:"commit_time,omitempty"`
+	LeadTime      float64           `json:"lead_time"`     // minutes
+	CycleTime     float64           `json:"cycle_time"`    // days since the previous deployment
+	RecoveryTime  float64           `json:"recovery_time"` // minutes, set on the deployment that recovered a failure
+}
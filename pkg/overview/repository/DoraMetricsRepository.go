@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+// DeploymentRunnerData is a single deployment (DEPLOY workflow runner) along with the artifact it deployed
+type DeploymentRunnerData struct {
+	PipelineId    int       `sql:"pipeline_id"`
+	AppId         int       `sql:"app_id"`
+	EnvironmentId int       `sql:"environment_id"`
+	WfrId         int       `sql:"wfr_id"`
+	Status        string    `sql:"status"`
+	StartedOn     time.Time `sql:"started_on"`
+	FinishedOn    time.Time `sql:"finished_on"`
+	CiArtifactId  int       `sql:"ci_artifact_id"`
+	MaterialInfo  string    `sql:"material_info"`
+	DataSource    string    `sql:"data_source"`
+}
+
+type DeploymentRunnerFilter struct {
+	From *time.Time
+	To   *time.Time
+	// ProdOnly restricts deployments to production (default) environments
+	ProdOnly bool
+	AppIds   []int
+	EnvIds   []int
+	TeamIds  []int
+}
+
+type DoraMetricsRepository interface {
+	// FindDeploymentRunnersInTimeRange returns deployments started within the time range, ordered by start time
+	FindDeploymentRunnersInTimeRange(filter *DeploymentRunnerFilter) ([]*DeploymentRunnerData, error)
+}
+
+type DoraMetricsRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewDoraMetricsRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *DoraMetricsRepositoryImpl {
+	return &DoraMetricsRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *DoraMetricsRepositoryImpl) FindDeploymentRunnersInTimeRange(filter *DeploymentRunnerFilter) ([]*DeploymentRunnerData, error) {
+	var results []*DeploymentRunnerData
+	query := `
+		SELECT
+			p.id AS pipeline_id,
+			p.app_id,
+			p.environment_id,
+			cwr.id AS wfr_id,
+			cwr.status,
+			cwr.started_on,
+			cwr.finished_on,
+			ca.id AS ci_artifact_id,
+			ca.material_info,
+			ca.data_source
+		FROM cd_workflow_runner cwr
+		INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id
+		INNER JOIN pipeline p ON p.id = cw.pipeline_id
+		INNER JOIN environment e ON e.id = p.environment_id
+		INNER JOIN app a ON a.id = p.app_id
+		INNER JOIN ci_artifact ca ON ca.id = cw.ci_artifact_id
+		WHERE p.deleted = false
+		AND e.active = true
+		AND a.active = true
+		AND cwr.workflow_type = 'DEPLOY'
+		AND cwr.started_on >= ?
+		AND cwr.started_on <= ?`
+	queryParams := []interface{}{filter.From, filter.To}
+	if filter.ProdOnly {
+		query += ` AND e.default = true`
+	}
+	if len(filter.AppIds) > 0 {
+		query += ` AND p.app_id IN (?)`
+		queryParams = append(queryParams, pg.In(filter.AppIds))
+	}
+	if len(filter.EnvIds) > 0 {
+		query += ` AND p.environment_id IN (?)`
+		queryParams = append(queryParams, pg.In(filter.EnvIds))
+	}
+	if len(filter.TeamIds) > 0 {
+		query += ` AND a.team_id IN (?)`
+		queryParams = append(queryParams, pg.In(filter.TeamIds))
+	}
+	query += ` ORDER BY cwr.started_on ASC, cwr.id ASC`
+
+	_, err := impl.dbConnection.Query(&results, query, queryParams...)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment runners in time range", "filter", filter, "err", err)
+		return nil, err
+	}
+	return results, nil
+}
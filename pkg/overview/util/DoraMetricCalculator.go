@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/overview/bean"
+)
+
+// GetAppEnvKey returns the key against which metrics of an app-environment pair are kept
+func GetAppEnvKey(appId, envId int) string {
+	return fmt.Sprintf("%d-%d", appId, envId)
+}
+
+// CalculateAppEnvDoraMetrics computes DORA metrics for every app-environment pair present in deployments.
+// from and to bound the period used for deployment frequency.
+func CalculateAppEnvDoraMetrics(deployments []*bean.DoraDeployment, from, to time.Time) map[string]*bean.AppEnvDoraMetrics {
+	metricsData := make(map[string]*bean.AppEnvDoraMetrics)
+	periodInDays := getPeriodInDays(from, to)
+	for key, appEnvDeployments := range groupDeploymentsByAppEnv(deployments) {
+		summary := summariseDeployments(appEnvDeployments)
+		metricsData[key] = &bean.AppEnvDoraMetrics{
+			AppId:               appEnvDeployments[0].AppId,
+			EnvId:               appEnvDeployments[0].EnvId,
+			DeploymentFrequency: float64(summary.successCount) / periodInDays,
+			MeanLeadTime:        CalculateAverageFromValues(summary.leadTimes),
+			ChangeFailureRate:   summary.changeFailureRate(),
+			MeanTimeToRecovery:  CalculateAverageFromValues(summary.recoveryTimes),
+			LeadTimeSamples:     len(summary.leadTimes),
+			RecoveryTimeSamples: len(summary.recoveryTimes),
+		}
+	}
+	return metricsData
+}
+
+// CalculateDeploymentMetrics computes the deployment metrics along with per deployment series
+// for deployments of a single app-environment pair
+func CalculateDeploymentMetrics(deployments []*bean.DoraDeployment) *bean.AppEnvDeploymentMetrics {
+	sorted := sortDeployments(deployments)
+	summary := summariseDeployments(sorted)
+	metrics := &bean.AppEnvDeploymentMetrics{
+		Series:              summary.series,
+		AverageCycleTime:    CalculateAverageFromValues(summary.cycleTimes),
+		AverageLeadTime:     CalculateAverageFromValues(summary.leadTimes),
+		ChangeFailureRate:   summary.changeFailureRate(),
+		AverageRecoveryTime: CalculateAverageFromValues(summary.recoveryTimes),
+	}
+	if summary.lastFailure != nil {
+		metrics.LastFailedTime = summary.lastFailure.FinishedOn.Format(time.RFC3339)
+		metrics.RecoveryTimeLastFailed = summary.recoveryTimeLastFailed
+	}
+	return metrics
+}
+
+type deploymentSummary struct {
+	successCount           int
+	failureCount           int
+	leadTimes              []float64 // minutes
+	recoveryTimes          []float64 // minutes
+	cycleTimes             []float64 // days
+	series                 []*bean.DeploymentMetricPoint
+	lastFailure            *bean.DoraDeployment
+	recoveryTimeLastFailed float64
+}
+
+func (s *deploymentSummary) changeFailureRate() float64 {
+	total := s.successCount + s.failureCount
+	if total == 0 {
+		return 0
+	}
+	return float64(s.failureCount) / float64(total) * 100
+}
+
+// summariseDeployments walks deployments of one app-environment pair in the order they were started.
+// Lead time is counted once per artifact, on its first successful deployment to a pipeline.
+// Recovery time is measured from the first failure in a run of failures to the next successful deployment.
+func summariseDeployments(deployments []*bean.DoraDeployment) *deploymentSummary {
+	summary := &deploymentSummary{}
+	deployedArtifacts := make(map[string]bool)
+	var openFailure *bean.DoraDeployment
+	var previous *bean.DoraDeployment
+	for _, deployment := range deployments {
+		point := &bean.DeploymentMetricPoint{
+			ReleaseStatus: deployment.Status,
+			ReleaseTime:   deployment.FinishedOn,
+			CommitHash:    deployment.CommitHash,
+		}
+		if !deployment.CommitTime.IsZero() {
+			commitTime := deployment.CommitTime
+			point.CommitTime = &commitTime
+		}
+		if previous != nil {
+			point.CycleTime = deployment.StartedOn.Sub(previous.StartedOn).Hours() / 24
+			summary.cycleTimes = append(summary.cycleTimes, point.CycleTime)
+		}
+		previous = deployment
+
+		switch deployment.Status {
+		case bean.DeploymentOutcomeFailure:
+			summary.failureCount++
+			summary.lastFailure = deployment
+			summary.recoveryTimeLastFailed = 0
+			if openFailure == nil {
+				openFailure = deployment
+			}
+		case bean.DeploymentOutcomeSuccess:
+			summary.successCount++
+			artifactKey := fmt.Sprintf("%d-%d", deployment.PipelineId, deployment.CiArtifactId)
+			if !deployment.CommitTime.IsZero() && !deployedArtifacts[artifactKey] && deployment.FinishedOn.After(deployment.CommitTime) {
+				point.LeadTime = deployment.FinishedOn.Sub(deployment.CommitTime).Minutes()
+				summary.leadTimes = append(summary.leadTimes, point.LeadTime)
+			}
+			deployedArtifacts[artifactKey] = true
+			if openFailure != nil {
+				point.RecoveryTime = deployment.FinishedOn.Sub(openFailure.FinishedOn).Minutes()
+				summary.recoveryTimes = append(summary.recoveryTimes, point.RecoveryTime)
+				if summary.lastFailure != nil && summary.recoveryTimeLastFailed == 0 {
+					summary.recoveryTimeLastFailed = deployment.FinishedOn.Sub(summary.lastFailure.FinishedOn).Minutes()
+				}
+				openFailure = nil
+			}
+		}
+		summary.series = append(summary.series, point)
+	}
+	return summary
+}
+
+func groupDeploymentsByAppEnv(deployments []*bean.DoraDeployment) map[string][]*bean.DoraDeployment {
+	grouped := make(map[string][]*bean.DoraDeployment)
+	for _, deployment := range sortDeployments(deployments) {
+		key := GetAppEnvKey(deployment.AppId, deployment.EnvId)
+		grouped[key] = append(grouped[key], deployment)
+	}
+	return grouped
+}
+
+func sortDeployments(deployments []*bean.DoraDeployment) []*bean.DoraDeployment {
+	sorted := make([]*bean.DoraDeployment, len(deployments))
+	copy(sorted, deployments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartedOn.Before(sorted[j].StartedOn)
+	})
+	return sorted
+}
+
+// getPeriodInDays returns the length of the period in days, periods shorter than a day are counted as a day
+func getPeriodInDays(from, to time.Time) float64 {
+	days := to.Sub(from).Hours() / 24
+	if days < 1 {
+		return 1
+	}
+	return days
+}
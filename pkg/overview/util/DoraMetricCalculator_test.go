@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/overview/bean"
+)
+
+func TestCalculateAppEnvDoraMetrics(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	deployment := func(artifactId int, status bean.DeploymentOutcome, startedAfter time.Duration, commitBefore time.Duration) *bean.DoraDeployment {
+		startedOn := base.Add(startedAfter)
+		return &bean.DoraDeployment{
+			AppId:        1,
+			EnvId:        2,
+			PipelineId:   3,
+			CiArtifactId: artifactId,
+			Status:       status,
+			StartedOn:    startedOn,
+			FinishedOn:   startedOn.Add(10 * time.Minute),
+			CommitTime:   startedOn.Add(-commitBefore),
+		}
+	}
+	deployments := []*bean.DoraDeployment{
+		// given out of order to verify sorting
+		deployment(2, bean.DeploymentOutcomeFailure, 24*time.Hour, time.Hour),
+		deployment(1, bean.DeploymentOutcomeSuccess, 0, 50*time.Minute),
+		deployment(2, bean.DeploymentOutcomeFailure, 25*time.Hour, 2*time.Hour),
+		deployment(3, bean.DeploymentOutcomeSuccess, 26*time.Hour, 20*time.Minute),
+		// redeploy of an already deployed artifact is not counted in lead time
+		deployment(3, bean.DeploymentOutcomeSuccess, 27*time.Hour, 24*time.Hour),
+	}
+
+	metricsData := CalculateAppEnvDoraMetrics(deployments, base, base.Add(4*24*time.Hour))
+	metrics, ok := metricsData[GetAppEnvKey(1, 2)]
+	if !ok || len(metricsData) != 1 {
+		t.Fatalf("expected metrics for a single app env pair, got %v", metricsData)
+	}
+	assertFloat(t, "deployment frequency", metrics.DeploymentFrequency, 3.0/4)
+	assertFloat(t, "lead time", metrics.MeanLeadTime, (60.0+30.0)/2)
+	assertFloat(t, "change failure rate", metrics.ChangeFailureRate, 40)
+	// first failure finished at 24h10m, recovered at 26h10m
+	assertFloat(t, "mean time to recovery", metrics.MeanTimeToRecovery, 120)
+	if metrics.LeadTimeSamples != 2 || metrics.RecoveryTimeSamples != 1 {
+		t.Errorf("unexpected samples, lead time %d, recovery time %d", metrics.LeadTimeSamples, metrics.RecoveryTimeSamples)
+	}
+}
+
+func TestCalculateDeploymentMetrics(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	deployments := []*bean.DoraDeployment{
+		{AppId: 1, EnvId: 2, CiArtifactId: 1, Status: bean.DeploymentOutcomeSuccess, StartedOn: base, FinishedOn: base},
+		{AppId: 1, EnvId: 2, CiArtifactId: 2, Status: bean.DeploymentOutcomeFailure, StartedOn: base.Add(48 * time.Hour), FinishedOn: base.Add(48 * time.Hour)},
+		{AppId: 1, EnvId: 2, CiArtifactId: 3, Status: bean.DeploymentOutcomeSuccess, StartedOn: base.Add(72 * time.Hour), FinishedOn: base.Add(72 * time.Hour)},
+	}
+	metrics := CalculateDeploymentMetrics(deployments)
+	if len(metrics.Series) != 3 {
+		t.Fatalf("expected 3 points in series, got %d", len(metrics.Series))
+	}
+	assertFloat(t, "average cycle time", metrics.AverageCycleTime, 1.5)
+	assertFloat(t, "recovery time of last failure", metrics.RecoveryTimeLastFailed, 24*60)
+	if metrics.LastFailedTime != base.Add(48*time.Hour).Format(time.RFC3339) {
+		t.Errorf("unexpected last failed time %s", metrics.LastFailedTime)
+	}
+}
+
+func TestGetMetricValuesSkipsAppEnvsWithoutSamples(t *testing.T) {
+	metricsData := map[string]*bean.AppEnvDoraMetrics{
+		GetAppEnvKey(1, 1): {DeploymentFrequency: 2, MeanLeadTime: 60, LeadTimeSamples: 2},
+		// deployed only artifacts without commit info and never failed
+		GetAppEnvKey(2, 1): {DeploymentFrequency: 1},
+	}
+	if values := GetMetricValues(metricsData, bean.MetricCategoryDeploymentFrequency); len(values) != 2 {
+		t.Errorf("expected deployment frequency of both app envs, got %v", values)
+	}
+	leadTimes := GetMetricValues(metricsData, bean.MetricCategoryMeanLeadTime)
+	if len(leadTimes) != 1 {
+		t.Fatalf("expected lead time of a single app env, got %v", leadTimes)
+	}
+	assertFloat(t, "lead time", CalculateAverageFromValues(leadTimes), 60)
+	if values := GetMetricValues(metricsData, bean.MetricCategoryMeanTimeToRecovery); len(values) != 0 {
+		t.Errorf("expected no recovery time samples, got %v", values)
+	}
+	levels := CalculatePerformanceLevelsForMetric(metricsData, bean.MetricCategoryMeanTimeToRecovery)
+	if levels.Elite+levels.High+levels.Medium+levels.Low != 0 {
+		t.Errorf("expected app envs without recovery to be left out of performance levels, got %+v", levels)
+	}
+}
+
+func assertFloat(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
@@ -47,7 +47,7 @@ func CalculateComparison(current, previous float64, metricCategory bean.MetricCa
 }
 
 // CalculatePerformanceLevelsForMetric calculates the count of pipelines in each performance category for a specific metric
-func CalculatePerformanceLevelsForMetric(metricsData map[string]*bean.LensMetrics, metricCategory bean.MetricCategory) *bean.PerformanceLevelCount {
+func CalculatePerformanceLevelsForMetric(metricsData map[string]*bean.AppEnvDoraMetrics, metricCategory bean.MetricCategory) *bean.PerformanceLevelCount {
 	performanceLevels := &bean.PerformanceLevelCount{
 		Elite:  0,
 		High:   0,
@@ -60,19 +60,22 @@ func CalculatePerformanceLevelsForMetric(metricsData map[string]*bean.LensMetric
 	}
 
 	// Categorize each app-env pair based on the specific metric
-	for _, lensMetrics := range metricsData {
+	for _, appEnvMetrics := range metricsData {
+		if !HasMetricSamples(appEnvMetrics, metricCategory) {
+			continue
+		}
 		var metricValue float64
 
 		// Get the appropriate metric value based on category
 		switch metricCategory {
 		case bean.MetricCategoryDeploymentFrequency:
-			metricValue = lensMetrics.AverageCycleTime
+			metricValue = appEnvMetrics.DeploymentFrequency
 		case bean.MetricCategoryMeanLeadTime:
-			metricValue = lensMetrics.AverageLeadTime
+			metricValue = appEnvMetrics.MeanLeadTime
 		case bean.MetricCategoryChangeFailureRate:
-			metricValue = lensMetrics.ChangeFailureRate
+			metricValue = appEnvMetrics.ChangeFailureRate
 		case bean.MetricCategoryMeanTimeToRecovery:
-			metricValue = lensMetrics.AverageRecoveryTime
+			metricValue = appEnvMetrics.MeanTimeToRecovery
 		default:
 			// Default to low performance for unknown metric categories
 			performanceLevels.Low++
@@ -94,6 +97,40 @@ func CalculatePerformanceLevelsForMetric(metricsData map[string]*bean.LensMetric
 	return performanceLevels
 }
 
+// HasMetricSamples tells whether the app-env pair has any samples for the metric, lead time and recovery time
+// are only known once an artifact with commit info is deployed or a failed deployment is recovered
+func HasMetricSamples(appEnvMetrics *bean.AppEnvDoraMetrics, metricCategory bean.MetricCategory) bool {
+	switch metricCategory {
+	case bean.MetricCategoryMeanLeadTime:
+		return appEnvMetrics.LeadTimeSamples > 0
+	case bean.MetricCategoryMeanTimeToRecovery:
+		return appEnvMetrics.RecoveryTimeSamples > 0
+	default:
+		return true
+	}
+}
+
+// GetMetricValues returns the metric values of the app-env pairs having samples for the metric
+func GetMetricValues(metricsData map[string]*bean.AppEnvDoraMetrics, metricCategory bean.MetricCategory) []float64 {
+	var values []float64
+	for _, appEnvMetrics := range metricsData {
+		if !HasMetricSamples(appEnvMetrics, metricCategory) {
+			continue
+		}
+		switch metricCategory {
+		case bean.MetricCategoryDeploymentFrequency:
+			values = append(values, appEnvMetrics.DeploymentFrequency)
+		case bean.MetricCategoryMeanLeadTime:
+			values = append(values, appEnvMetrics.MeanLeadTime)
+		case bean.MetricCategoryChangeFailureRate:
+			values = append(values, appEnvMetrics.ChangeFailureRate)
+		case bean.MetricCategoryMeanTimeToRecovery:
+			values = append(values, appEnvMetrics.MeanTimeToRecovery)
+		}
+	}
+	return values
+}
+
 // IsInMetricCategory routes to the appropriate category checking function based on metric type
 func IsInMetricCategory(value float64, metricCategory bean.MetricCategory, performanceCategory bean.PerformanceCategory) bool {
 	switch metricCategory {
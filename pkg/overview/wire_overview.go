@@ -7,6 +7,7 @@ package overview
 import (
 	"github.com/devtron-labs/devtron/pkg/overview/cache"
 	"github.com/devtron-labs/devtron/pkg/overview/config"
+	"github.com/devtron-labs/devtron/pkg/overview/repository"
 	"github.com/google/wire"
 )
 
@@ -14,6 +15,10 @@ import (
 var OverviewWireSet = wire.NewSet(
 	config.GetClusterOverviewConfig,
 
+	// Repository layer
+	repository.NewDoraMetricsRepositoryImpl,
+	wire.Bind(new(repository.DoraMetricsRepository), new(*repository.DoraMetricsRepositoryImpl)),
+
 	// Service layer
 	NewAppManagementServiceImpl,
 	wire.Bind(new(AppManagementService), new(*AppManagementServiceImpl)),
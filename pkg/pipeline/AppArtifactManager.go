@@ -33,6 +33,7 @@ import (
 	bean2 "github.com/devtron-labs/devtron/pkg/bean"
 	repository2 "github.com/devtron-labs/devtron/pkg/pipeline/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
 )
@@ -60,6 +61,7 @@ type AppArtifactManagerImpl struct {
 	dockerArtifactRegistry  dockerArtifactStoreRegistry.DockerArtifactStoreRepository
 	CiPipelineRepository    pipelineConfig.CiPipelineRepository
 	ciTemplateService       pipeline.CiTemplateReadService
+	imageSignatureService   imageSigning.ImageSignatureService
 }
 
 func NewAppArtifactManagerImpl(
@@ -73,7 +75,8 @@ func NewAppArtifactManagerImpl(
 	cdPipelineConfigService CdPipelineConfigService,
 	dockerArtifactRegistry dockerArtifactStoreRegistry.DockerArtifactStoreRepository,
 	CiPipelineRepository pipelineConfig.CiPipelineRepository,
-	ciTemplateService pipeline.CiTemplateReadService) *AppArtifactManagerImpl {
+	ciTemplateService pipeline.CiTemplateReadService,
+	imageSignatureService imageSigning.ImageSignatureService) *AppArtifactManagerImpl {
 	cdConfig, err := types.GetCdConfig()
 	if err != nil {
 		return nil
@@ -91,6 +94,7 @@ func NewAppArtifactManagerImpl(
 		dockerArtifactRegistry:  dockerArtifactRegistry,
 		CiPipelineRepository:    CiPipelineRepository,
 		ciTemplateService:       ciTemplateService,
+		imageSignatureService:   imageSignatureService,
 	}
 }
 
@@ -266,6 +270,11 @@ func (impl *AppArtifactManagerImpl) FetchArtifactForRollbackV2(cdPipelineId, app
 		}
 	}
 
+	err = impl.setSignatureVerifications(deployedCiArtifacts, cdPipelineId)
+	if err != nil {
+		return deployedCiArtifactsResponse, err
+	}
+
 	deployedCiArtifactsResponse.CdPipelineId = cdPipelineId
 	if deployedCiArtifacts == nil {
 		deployedCiArtifacts = []bean2.CiArtifactBean{}
@@ -488,10 +497,30 @@ func (impl *AppArtifactManagerImpl) setAdditionalDataInArtifacts(ciArtifacts []b
 			ciArtifacts[i].RegistryName = dockerRegistryId
 		}
 	}
+	if err = impl.setSignatureVerifications(ciArtifacts, pipeline.Id); err != nil {
+		return ciArtifacts, err
+	}
 	return impl.setGitTriggerData(ciArtifacts)
 
 }
 
+// setSignatureVerifications sets the image signature verification result of the latest deployment of the artifacts on the pipeline
+func (impl *AppArtifactManagerImpl) setSignatureVerifications(ciArtifacts []bean2.CiArtifactBean, pipelineId int) error {
+	artifactIds := make([]int, 0, len(ciArtifacts))
+	for _, artifact := range ciArtifacts {
+		artifactIds = append(artifactIds, artifact.Id)
+	}
+	verifications, err := impl.imageSignatureService.GetArtifactVerifications(pipelineId, artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in getting signature verifications of artifacts", "pipelineId", pipelineId, "err", err)
+		return err
+	}
+	for i := range ciArtifacts {
+		ciArtifacts[i].SignatureVerification = verifications[ciArtifacts[i].Id]
+	}
+	return nil
+}
+
 func (impl *AppArtifactManagerImpl) setGitTriggerData(ciArtifacts []bean2.CiArtifactBean) ([]bean2.CiArtifactBean, error) {
 	directArtifactIndexes, directWorkflowIds, artifactsWithParentIndexes, parentArtifactIds := make([]int, 0), make([]int, 0), make([]int, 0), make([]int, 0)
 	for i, artifact := range ciArtifacts {
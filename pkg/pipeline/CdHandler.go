@@ -46,6 +46,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
 	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	resourceGroup2 "github.com/devtron-labs/devtron/pkg/resourceGroup"
@@ -95,6 +96,7 @@ type CdHandlerImpl struct {
 	WorkflowStatusLatestService  workflowStatusLatest.WorkflowStatusLatestService
 	pipelineStageRepository      repository2.PipelineStageRepository
 	cdWorkflowRunnerReadService  read.CdWorkflowRunnerReadService
+	deploymentApprovalService    approval.DeploymentApprovalService
 }
 
 func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
@@ -112,6 +114,7 @@ func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
 	WorkflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
 	pipelineStageRepository repository2.PipelineStageRepository,
 	cdWorkflowRunnerReadService read.CdWorkflowRunnerReadService,
+	deploymentApprovalService approval.DeploymentApprovalService,
 ) *CdHandlerImpl {
 	cdh := &CdHandlerImpl{
 		Logger:                       Logger,
@@ -133,6 +136,7 @@ func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
 		WorkflowStatusLatestService:  WorkflowStatusLatestService,
 		pipelineStageRepository:      pipelineStageRepository,
 		cdWorkflowRunnerReadService:  cdWorkflowRunnerReadService,
+		deploymentApprovalService:    deploymentApprovalService,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -374,6 +378,12 @@ func (impl *CdHandlerImpl) GetCdBuildHistory(appId int, environmentId int, pipel
 		cdWorkflowArtifact[i] = item
 	}
 
+	err = impl.setDeploymentApprovalRequests(cdWorkflowArtifact)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching deployment approval requests", "err", err, "appId", appId)
+		return cdWorkflowArtifact, err
+	}
+
 	//process pre/post cd stage data
 	//prepare a map of wfId and wf type to pass to next function
 	wfIdToWfTypeMap := make(map[int]pipelineBean.CdWorkflowWithArtifact)
@@ -531,10 +541,34 @@ func (impl *CdHandlerImpl) converterWFR(wfr pipelineConfig.CdWorkflowRunner) pip
 		workflow.IsArtifactUploaded = isArtifactUploaded
 		workflow.BlobStorageEnabled = wfr.BlobStorageEnabled
 		workflow.RefCdWorkflowRunnerId = wfr.RefCdWorkflowRunnerId
+		workflow.TriggerType = wfr.TriggerType
+		workflow.DeploymentApprovalRequestId = wfr.DeploymentApprovalRequestId
 	}
 	return workflow
 }
 
+func (impl *CdHandlerImpl) setDeploymentApprovalRequests(cdWorkflowArtifact []pipelineBean.CdWorkflowWithArtifact) error {
+	var approvalRequestIds []int
+	for _, item := range cdWorkflowArtifact {
+		if item.DeploymentApprovalRequestId > 0 {
+			approvalRequestIds = append(approvalRequestIds, item.DeploymentApprovalRequestId)
+		}
+	}
+	if len(approvalRequestIds) == 0 {
+		return nil
+	}
+	approvalRequests, err := impl.deploymentApprovalService.GetApprovalRequestsByIds(approvalRequestIds)
+	if err != nil {
+		return err
+	}
+	for i, item := range cdWorkflowArtifact {
+		if approvalRequest, ok := approvalRequests[item.DeploymentApprovalRequestId]; ok {
+			cdWorkflowArtifact[i].DeploymentApprovalRequest = approvalRequest
+		}
+	}
+	return nil
+}
+
 func (impl *CdHandlerImpl) converterWFRList(wfrList []pipelineConfig.CdWorkflowRunner) []pipelineBean.CdWorkflowWithArtifact {
 	var workflowList []pipelineBean.CdWorkflowWithArtifact
 	var results []pipelineBean.CdWorkflowWithArtifact
@@ -24,6 +24,8 @@ import (
 	"github.com/devtron-labs/common-lib/utils/workFlow"
 	cdWorkflowBean "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts/imageTagging"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
@@ -101,6 +103,7 @@ type CiHandlerImpl struct {
 	k8sCommonService             k8sPkg.K8sCommonService
 	workFlowStageStatusService   workflowStatus.WorkFlowStageStatusService
 	workflowStatusLatestService  workflowStatusLatest.WorkflowStatusLatestService
+	commitStatusService          commitStatus.CommitStatusService
 }
 
 func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository, gitSensorClient gitSensor.Client, ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
@@ -109,6 +112,7 @@ func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipeline
 	imageTaggingService imageTagging.ImageTaggingService, k8sCommonService k8sPkg.K8sCommonService, appWorkflowRepository appWorkflow.AppWorkflowRepository, customTagService CustomTagService,
 	workFlowStageStatusService workflowStatus.WorkFlowStageStatusService,
 	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
+	commitStatusService commitStatus.CommitStatusService,
 ) *CiHandlerImpl {
 	cih := &CiHandlerImpl{
 		Logger:                       Logger,
@@ -132,6 +136,7 @@ func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipeline
 		k8sCommonService:             k8sCommonService,
 		workFlowStageStatusService:   workFlowStageStatusService,
 		workflowStatusLatestService:  workflowStatusLatestService,
+		commitStatusService:          commitStatusService,
 	}
 	config, err := types.GetCiConfig()
 	if err != nil {
@@ -386,6 +391,7 @@ func (impl *CiHandlerImpl) GetBuildHistory(pipelineId int, appId int, offset int
 			EnvironmentName:        w.EnvironmentName,
 			ReferenceWorkflowId:    w.RefCiWorkflowId,
 			PodName:                w.PodName,
+			TriggerType:            w.TriggerType,
 			TargetPlatforms:        utils.ConvertTargetPlatformStringToObject(w.TargetPlatforms),
 			WorkflowExecutionStage: impl.workFlowStageStatusService.ConvertDBWorkflowStageToMap(allWfStagesDetail, w.Id, w.Status, w.PodStatus, w.Message, bean2.CI_WORKFLOW_TYPE.String(), w.StartedOn, w.FinishedOn),
 		}
@@ -505,6 +511,7 @@ func (impl *CiHandlerImpl) FetchWorkflowDetails(appId int, pipelineId int, build
 		EnvironmentName:        environmentName,
 		PipelineType:           workflow.CiPipeline.PipelineType,
 		PodName:                workflow.PodName,
+		TriggerType:            workflow.TriggerType,
 		TargetPlatforms:        utils.ConvertTargetPlatformStringToObject(ciArtifact.TargetPlatforms),
 		WorkflowExecutionStage: impl.workFlowStageStatusService.ConvertDBWorkflowStageToMap(wfStagesDetail, workflow.Id, workflow.Status, workflow.PodStatus, workflow.Message, bean2.CI_WORKFLOW_TYPE.String(), workflow.StartedOn, workflow.FinishedOn),
 	}
@@ -611,12 +618,17 @@ func (impl *CiHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 }
 
 func (impl *CiHandlerImpl) sendCIFailEvent(savedWorkflow *pipelineConfig.CiWorkflow, status, message string) {
+	if savedWorkflow.Status == cdWorkflowBean.WorkflowCancel {
+		impl.commitStatusService.ReportCiWorkflowStatusAsync(savedWorkflow.Id, commitStatusBean.StateError)
+		return
+	}
 	if string(v1alpha1.NodeError) == savedWorkflow.Status || string(v1alpha1.NodeFailed) == savedWorkflow.Status {
 		if executors.CheckIfReTriggerRequired(status, message, savedWorkflow.Status) {
 			impl.Logger.Infow("not sending failure notification for re-trigger workflow", "workflowId", savedWorkflow.Id)
 			return
 		}
 		impl.Logger.Warnw("ci failed for workflow: ", "wfId", savedWorkflow.Id)
+		impl.commitStatusService.ReportCiWorkflowStatusAsync(savedWorkflow.Id, commitStatusBean.StateFailure)
 
 		if extractErrorCode(savedWorkflow.Message) != workFlow.CiStageFailErrorCode {
 			impl.ciService.WriteCIFailEvent(savedWorkflow)
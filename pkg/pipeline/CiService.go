@@ -22,6 +22,8 @@ import (
 	client "github.com/devtron-labs/devtron/client/events"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	"github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus"
@@ -37,6 +39,12 @@ type CiService interface {
 	WriteCIFailEvent(ciWorkflow *pipelineConfig.CiWorkflow)
 	SaveCiWorkflowWithStage(wf *pipelineConfig.CiWorkflow) error
 	UpdateCiWorkflowWithStage(wf *pipelineConfig.CiWorkflow) error
+	// SaveQueuedCiWorkflow saves a ci workflow waiting in the build queue, stages are saved once it is started
+	SaveQueuedCiWorkflow(wf *pipelineConfig.CiWorkflow) error
+	// UpdateQueuedCiWorkflow updates a ci workflow which has not left the build queue yet
+	UpdateQueuedCiWorkflow(wf *pipelineConfig.CiWorkflow) error
+	// StartQueuedCiWorkflowWithStage moves a queued ci workflow to the starting state and saves its stages
+	StartQueuedCiWorkflowWithStage(wf *pipelineConfig.CiWorkflow) error
 }
 
 type CiServiceImpl struct {
@@ -49,6 +57,7 @@ type CiServiceImpl struct {
 	ciPipelineRepository        pipelineConfig.CiPipelineRepository
 	transactionManager          sql.TransactionWrapper
 	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService
+	commitStatusService         commitStatus.CommitStatusService
 }
 
 func NewCiServiceImpl(Logger *zap.SugaredLogger,
@@ -58,6 +67,7 @@ func NewCiServiceImpl(Logger *zap.SugaredLogger,
 	ciPipelineRepository pipelineConfig.CiPipelineRepository,
 	transactionManager sql.TransactionWrapper,
 	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
+	commitStatusService commitStatus.CommitStatusService,
 ) *CiServiceImpl {
 	cis := &CiServiceImpl{
 		Logger:                      Logger,
@@ -68,6 +78,7 @@ func NewCiServiceImpl(Logger *zap.SugaredLogger,
 		ciPipelineRepository:        ciPipelineRepository,
 		transactionManager:          transactionManager,
 		workflowStatusLatestService: workflowStatusLatestService,
+		commitStatusService:         commitStatusService,
 	}
 	config, err := types.GetCiConfig()
 	if err != nil {
@@ -79,6 +90,7 @@ func NewCiServiceImpl(Logger *zap.SugaredLogger,
 
 func (impl *CiServiceImpl) WriteCITriggerEvent(trigger *types.CiTriggerRequest, workflowRequest *types.WorkflowRequest) {
 	impl.Logger.Debugw("triggering notification for ci trigger event", "pipelineId", workflowRequest.PipelineId, "ciWorkflowId", workflowRequest.WorkflowId)
+	impl.commitStatusService.ReportCiWorkflowStatusAsync(workflowRequest.WorkflowId, commitStatusBean.StatePending)
 	event, _ := impl.eventFactory.Build(util2.Trigger, &workflowRequest.PipelineId, workflowRequest.AppId, nil, util2.CI)
 	material := &buildBean.MaterialTriggerInfo{}
 
@@ -198,3 +210,94 @@ func (impl *CiServiceImpl) UpdateCiWorkflowWithStage(wf *pipelineConfig.CiWorkfl
 	return nil
 
 }
+
+func (impl *CiServiceImpl) SaveQueuedCiWorkflow(wf *pipelineConfig.CiWorkflow) error {
+	tx, err := impl.transactionManager.StartTx()
+	if err != nil {
+		impl.Logger.Errorw("error in starting transaction to save queued workflow", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	defer func() {
+		dbErr := impl.transactionManager.RollbackTx(tx)
+		if dbErr != nil && dbErr.Error() != util3.SqlAlreadyCommitedErrMsg {
+			impl.Logger.Errorw("error in rolling back transaction", "workflowName", wf.Name, "error", dbErr)
+		}
+	}()
+	wf.Status = cdWorkflow.WorkflowInQueue
+	err = impl.ciWorkflowRepository.SaveWorkFlowWithTx(wf, tx)
+	if err != nil {
+		impl.Logger.Errorw("error in saving queued workflow", "payload", wf, "error", err)
+		return err
+	}
+	ciPipeline, err := impl.ciPipelineRepository.FindById(wf.CiPipelineId)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching ci pipeline for appId", "err", err, "ciPipelineId", wf.CiPipelineId)
+		return err
+	}
+	err = impl.workflowStatusLatestService.SaveCiWorkflowStatusLatest(tx, wf.CiPipelineId, ciPipeline.AppId, wf.Id, wf.TriggeredBy)
+	if err != nil {
+		impl.Logger.Errorw("error in saving ci workflow status latest", "err", err, "pipelineId", wf.CiPipelineId, "workflowId", wf.Id)
+		return err
+	}
+	err = impl.transactionManager.CommitTx(tx)
+	if err != nil {
+		impl.Logger.Errorw("error in committing transaction", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CiServiceImpl) UpdateQueuedCiWorkflow(wf *pipelineConfig.CiWorkflow) error {
+	tx, err := impl.transactionManager.StartTx()
+	if err != nil {
+		impl.Logger.Errorw("error in starting transaction to update queued workflow", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	defer func() {
+		dbErr := impl.transactionManager.RollbackTx(tx)
+		if dbErr != nil && dbErr.Error() != util3.SqlAlreadyCommitedErrMsg {
+			impl.Logger.Errorw("error in rolling back transaction", "workflowName", wf.Name, "error", dbErr)
+		}
+	}()
+	err = impl.ciWorkflowRepository.UpdateWorkFlowWithTx(wf, tx)
+	if err != nil {
+		impl.Logger.Errorw("error in updating queued workflow", "payload", wf, "error", err)
+		return err
+	}
+	return impl.transactionManager.CommitTx(tx)
+}
+
+func (impl *CiServiceImpl) StartQueuedCiWorkflowWithStage(wf *pipelineConfig.CiWorkflow) error {
+	tx, err := impl.transactionManager.StartTx()
+	if err != nil {
+		impl.Logger.Errorw("error in starting transaction to start queued workflow", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	defer func() {
+		dbErr := impl.transactionManager.RollbackTx(tx)
+		if dbErr != nil && dbErr.Error() != util3.SqlAlreadyCommitedErrMsg {
+			impl.Logger.Errorw("error in rolling back transaction", "workflowName", wf.Name, "error", dbErr)
+		}
+	}()
+	wf.Status = cdWorkflow.WorkflowStarting
+	if impl.config.EnableWorkflowExecutionStage {
+		wf.Status = cdWorkflow.WorkflowWaitingToStart
+		wf.PodStatus = string(v1alpha1.NodePending)
+	}
+	err = impl.ciWorkflowRepository.UpdateWorkFlowWithTx(wf, tx)
+	if err != nil {
+		impl.Logger.Errorw("error in updating queued workflow", "payload", wf, "error", err)
+		return err
+	}
+	err = impl.workflowStageStatusService.SaveWorkflowStages(wf.Id, bean5.CI_WORKFLOW_TYPE.String(), wf.Name, tx)
+	if err != nil {
+		impl.Logger.Errorw("error in saving workflow stages", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	err = impl.transactionManager.CommitTx(tx)
+	if err != nil {
+		impl.Logger.Errorw("error in committing transaction", "workflowName", wf.Name, "error", err)
+		return err
+	}
+	return nil
+}
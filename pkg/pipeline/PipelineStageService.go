@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	commonBean "github.com/devtron-labs/common-lib/workflow"
+	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/internal/util"
 	"github.com/devtron-labs/devtron/pkg/pipeline/adapter"
@@ -37,6 +38,7 @@ import (
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -63,7 +65,7 @@ func NewPipelineStageService(logger *zap.SugaredLogger,
 	pipelineRepository pipelineConfig.PipelineRepository,
 	scopedVariableManager variables.ScopedVariableManager,
 	globalPluginService plugin.GlobalPluginService,
-
+	celService cel.EvaluatorService,
 ) *PipelineStageServiceImpl {
 	return &PipelineStageServiceImpl{
 		logger:                  logger,
@@ -72,6 +74,7 @@ func NewPipelineStageService(logger *zap.SugaredLogger,
 		pipelineRepository:      pipelineRepository,
 		scopedVariableManager:   scopedVariableManager,
 		globalPluginService:     globalPluginService,
+		celService:              celService,
 	}
 }
 
@@ -87,6 +90,7 @@ type PipelineStageServiceImpl struct {
 	pipelineRepository      pipelineConfig.PipelineRepository
 	scopedVariableManager   variables.ScopedVariableManager
 	globalPluginService     plugin.GlobalPluginService
+	celService              cel.EvaluatorService
 }
 
 func (impl *PipelineStageServiceImpl) GetCiPipelineStageDataDeepCopy(ciPipelineId int) (*bean.PipelineStageDto, *bean.PipelineStageDto, error) {
@@ -243,6 +247,8 @@ func (impl *PipelineStageServiceImpl) BuildPipelineStageDataDeepCopy(pipelineSta
 			Index:                    step.Index,
 			Description:              step.Description,
 			OutputDirectoryPath:      step.OutputDirectoryPath,
+			TestReportPaths:          step.TestReportPaths,
+			RetryPolicy:              step.RetryPolicy,
 			StepType:                 step.StepType,
 			TriggerIfParentStageFail: step.TriggerIfParentStageFail,
 		}
@@ -380,6 +386,7 @@ func (impl *PipelineStageServiceImpl) BuildVariableAndConditionDataByStepIdDeepC
 			Id:                  condition.Id,
 			ConditionalOperator: condition.ConditionalOperator,
 			ConditionalValue:    condition.ConditionalValue,
+			ConditionExpression: condition.ConditionExpression,
 			ConditionType:       condition.ConditionType,
 		}
 		varName, ok := variableNameIdMap[condition.ConditionVariableId]
@@ -449,6 +456,8 @@ func (impl *PipelineStageServiceImpl) BuildCiStageData(ciStage *repository.Pipel
 			Index:                    step.Index,
 			Description:              step.Description,
 			OutputDirectoryPath:      step.OutputDirectoryPath,
+			TestReportPaths:          step.TestReportPaths,
+			RetryPolicy:              step.RetryPolicy,
 			StepType:                 step.StepType,
 			TriggerIfParentStageFail: step.TriggerIfParentStageFail,
 		}
@@ -586,6 +595,7 @@ func (impl *PipelineStageServiceImpl) BuildVariableAndConditionDataByStepId(step
 			Id:                  condition.Id,
 			ConditionalOperator: condition.ConditionalOperator,
 			ConditionalValue:    condition.ConditionalValue,
+			ConditionExpression: condition.ConditionExpression,
 			ConditionType:       condition.ConditionType,
 		}
 		varName, ok := variableNameIdMap[condition.ConditionVariableId]
@@ -601,6 +611,14 @@ func (impl *PipelineStageServiceImpl) BuildVariableAndConditionDataByStepId(step
 
 // CreatePipelineStage and related methods starts
 func (impl *PipelineStageServiceImpl) CreatePipelineStage(stageReq *bean.PipelineStageDto, stageType repository.PipelineStageType, pipelineId int, userId int32) error {
+	err := validateStepRetryPolicies(stageReq)
+	if err != nil {
+		return err
+	}
+	err = impl.validateStepConditionExpressions(stageReq)
+	if err != nil {
+		return err
+	}
 	dbConnection := impl.pipelineRepository.GetConnection()
 	tx, err := dbConnection.Begin()
 	if err != nil {
@@ -689,6 +707,8 @@ func (impl *PipelineStageServiceImpl) CreateStageSteps(steps []*bean.PipelineSta
 				StepType:            step.StepType,
 				ScriptId:            scriptEntryId,
 				OutputDirectoryPath: helper.FilterReservedPathFromOutputDirPath(step.OutputDirectoryPath), // TODO: silently filtering reserved paths, not throwing error as of now since this flow is not in tx
+				TestReportPaths:     helper.FilterReservedPathFromOutputDirPath(step.TestReportPaths),
+				RetryPolicy:         step.RetryPolicy,
 				DependentOnStep:     dependentOnStep,
 				Deleted:             false,
 				AuditLog: sql.AuditLog{
@@ -718,6 +738,8 @@ func (impl *PipelineStageServiceImpl) CreateStageSteps(steps []*bean.PipelineSta
 				StepType:            step.StepType,
 				RefPluginId:         refPluginStepDetail.PluginId,
 				OutputDirectoryPath: step.OutputDirectoryPath,
+				TestReportPaths:     helper.FilterReservedPathFromOutputDirPath(step.TestReportPaths),
+				RetryPolicy:         step.RetryPolicy,
 				DependentOnStep:     dependentOnStep,
 				Deleted:             false,
 				AuditLog: sql.AuditLog{
@@ -946,6 +968,7 @@ func (impl *PipelineStageServiceImpl) CreateConditionsEntryInDb(stepId int, cond
 			ConditionType:       condition.ConditionType,
 			ConditionalOperator: condition.ConditionalOperator,
 			ConditionalValue:    condition.ConditionalValue,
+			ConditionExpression: condition.ConditionExpression,
 			Deleted:             false,
 			AuditLog: sql.AuditLog{
 				CreatedOn: time.Now(),
@@ -971,8 +994,15 @@ func (impl *PipelineStageServiceImpl) CreateConditionsEntryInDb(stepId int, cond
 
 // UpdatePipelineStage and related methods starts
 func (impl *PipelineStageServiceImpl) UpdatePipelineStage(stageReq *bean.PipelineStageDto, stageType repository.PipelineStageType, pipelineId int, userId int32) error {
+	err := validateStepRetryPolicies(stageReq)
+	if err != nil {
+		return err
+	}
+	err = impl.validateStepConditionExpressions(stageReq)
+	if err != nil {
+		return err
+	}
 	var stageOld *repository.PipelineStage
-	var err error
 	if stageType == repository.PIPELINE_STAGE_TYPE_PRE_CI || stageType == repository.PIPELINE_STAGE_TYPE_POST_CI {
 		//getting stage by stageType and ciPipelineId
 		stageOld, err = impl.pipelineStageRepository.GetCiStageByCiPipelineIdAndStageType(pipelineId, stageType)
@@ -1208,6 +1238,8 @@ func (impl *PipelineStageServiceImpl) UpdateStageStepsWithTx(steps []*bean.Pipel
 			Index:               step.Index,
 			StepType:            step.StepType,
 			OutputDirectoryPath: helper.FilterReservedPathFromOutputDirPath(step.OutputDirectoryPath),
+			TestReportPaths:     helper.FilterReservedPathFromOutputDirPath(step.TestReportPaths),
+			RetryPolicy:         step.RetryPolicy,
 			DependentOnStep:     dependentOnStep,
 			Deleted:             false,
 			AuditLog: sql.AuditLog{
@@ -1622,6 +1654,7 @@ func (impl *PipelineStageServiceImpl) UpdatePipelineStageStepConditions(stepId i
 			ConditionType:       condition.ConditionType,
 			ConditionalOperator: condition.ConditionalOperator,
 			ConditionalValue:    condition.ConditionalValue,
+			ConditionExpression: condition.ConditionExpression,
 			Deleted:             false,
 			AuditLog: sql.AuditLog{
 				UpdatedOn: time.Now(),
@@ -1917,7 +1950,9 @@ func (impl *PipelineStageServiceImpl) buildPipelineStepDataForWfRequest(step *re
 		Name:                     step.Name,
 		Index:                    step.Index,
 		StepType:                 string(step.StepType),
-		ArtifactPaths:            step.OutputDirectoryPath,
+		ArtifactPaths:            helper.GetArtifactPathsForStep(step.OutputDirectoryPath, step.TestReportPaths),
+		TestReportPaths:          step.TestReportPaths,
+		RetryPolicy:              step.RetryPolicy,
 		TriggerIfParentStageFail: step.TriggerIfParentStageFail,
 	}
 	if step.StepType == repository.PIPELINE_STEP_TYPE_INLINE {
@@ -1972,6 +2007,17 @@ func (impl *PipelineStageServiceImpl) buildPipelineStepDataForWfRequest(step *re
 	} else if step.StepType == repository.PIPELINE_STEP_TYPE_REF_PLUGIN {
 		stepData.ExecutorType = "PLUGIN" //added only to avoid un-marshaling issues at ci-runner side, will not be used
 		stepData.RefPluginId = step.RefPluginId
+		if stepData.RetryPolicy == nil {
+			// falling back to the default retry policy declared by the plugin
+			pluginMetadata, err := impl.globalPluginRepository.GetMetaDataByPluginId(step.RefPluginId)
+			if err != nil && !util.IsErrNoRows(err) {
+				impl.logger.Errorw("error in getting plugin metadata", "err", err, "pluginId", step.RefPluginId)
+				return nil, err
+			}
+			if pluginMetadata != nil {
+				stepData.RetryPolicy = pluginMetadata.DefaultRetryPolicy
+			}
+		}
 	}
 	variableAndConditionData, err := impl.buildVariableAndConditionDataForWfRequest(step.Id)
 	if err != nil {
@@ -2043,6 +2089,7 @@ func (impl *PipelineStageServiceImpl) buildVariableAndConditionDataForWfRequest(
 		conditionData := &bean.ConditionObject{
 			ConditionalOperator: condition.ConditionalOperator,
 			ConditionalValue:    condition.ConditionalValue,
+			ConditionExpression: condition.ConditionExpression,
 			ConditionType:       string(condition.ConditionType),
 		}
 		varName, ok := variableNameIdMap[condition.ConditionVariableId]
@@ -2324,6 +2371,84 @@ func validateStepVariable(variable *bean.StepVariableDto, isTriggerStage bool) e
 	return nil
 }
 
+func validateStepRetryPolicies(stageReq *bean.PipelineStageDto) error {
+	if stageReq == nil {
+		return nil
+	}
+	for _, step := range stageReq.Steps {
+		if step == nil {
+			continue
+		}
+		if err := step.RetryPolicy.Validate(); err != nil {
+			errMsg := fmt.Sprintf("invalid retry policy for step '%s': %s", step.Name, err.Error())
+			return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+		}
+	}
+	return nil
+}
+
+// validateStepConditionExpressions type checks the CEL expressions of the step conditions against
+// the global variables and the input/output variables of the step
+func (impl *PipelineStageServiceImpl) validateStepConditionExpressions(stageReq *bean.PipelineStageDto) error {
+	if stageReq == nil {
+		return nil
+	}
+	for _, step := range stageReq.Steps {
+		if step == nil {
+			continue
+		}
+		var inputVariables, outputVariables []*bean.StepVariableDto
+		var conditions []*bean.ConditionDetailDto
+		if step.StepType == repository.PIPELINE_STEP_TYPE_INLINE && step.InlineStepDetail != nil {
+			inputVariables, outputVariables = step.InlineStepDetail.InputVariables, step.InlineStepDetail.OutputVariables
+			conditions = step.InlineStepDetail.ConditionDetails
+		} else if step.StepType == repository.PIPELINE_STEP_TYPE_REF_PLUGIN && step.RefPluginStepDetail != nil {
+			inputVariables, outputVariables = step.RefPluginStepDetail.InputVariables, step.RefPluginStepDetail.OutputVariables
+			conditions = step.RefPluginStepDetail.ConditionDetails
+		}
+		if !helper.HasConditionExpression(conditions) {
+			continue
+		}
+		if step.StepType == repository.PIPELINE_STEP_TYPE_REF_PLUGIN && len(outputVariables) == 0 {
+			// output variables of a plugin are defined by the plugin itself and are not sent in the request
+			pluginOutputVariables, err := impl.getPluginOutputVariables(step.RefPluginStepDetail.PluginId)
+			if err != nil {
+				return err
+			}
+			outputVariables = pluginOutputVariables
+		}
+		params := helper.GetStepConditionParamDeclarations(inputVariables, outputVariables)
+		for _, condition := range conditions {
+			if condition == nil || len(strings.TrimSpace(condition.ConditionExpression)) == 0 {
+				continue
+			}
+			err := helper.ValidateConditionExpression(impl.celService, condition.ConditionExpression, params)
+			if err != nil {
+				impl.logger.Errorw("invalid step condition expression", "stepName", step.Name, "expression", condition.ConditionExpression, "err", err)
+				errMsg := fmt.Sprintf("invalid condition expression for step '%s': %s", step.Name, err.Error())
+				return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+			}
+		}
+	}
+	return nil
+}
+
+func (impl *PipelineStageServiceImpl) getPluginOutputVariables(pluginId int) ([]*bean.StepVariableDto, error) {
+	pluginVariables, err := impl.globalPluginRepository.GetExposedVariablesByPluginIdAndVariableType(pluginId, repository2.PLUGIN_VARIABLE_TYPE_OUTPUT)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in getting plugin output variables", "pluginId", pluginId, "err", err)
+		return nil, err
+	}
+	outputVariables := make([]*bean.StepVariableDto, 0, len(pluginVariables))
+	for _, variable := range pluginVariables {
+		outputVariables = append(outputVariables, &bean.StepVariableDto{
+			Name:   variable.Name,
+			Format: repository.PipelineStageStepVariableFormatType(variable.Format),
+		})
+	}
+	return outputVariables, nil
+}
+
 func validateStepVariables(variable []*bean.StepVariableDto, isTriggerStage bool) error {
 	for _, v := range variable {
 		err := validateStepVariable(v, isTriggerStage)
@@ -5,6 +5,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
+	approvalBean "github.com/devtron-labs/devtron/pkg/deployment/approval/bean"
 	bean2 "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/bean"
 	"time"
 )
@@ -38,4 +39,9 @@ type CdWorkflowWithArtifact struct {
 	ImageComment           *repository.ImageComment               `json:"imageComment"`
 	RefCdWorkflowRunnerId  int                                    `json:"referenceCdWorkflowRunnerId"`
 	WorkflowExecutionStage map[string][]*bean2.WorkflowStageDto   `json:"workflowExecutionStages"`
+	// TriggerType is SYSTEM for the deployments triggered by devtron itself on a schedule
+	TriggerType string `json:"triggerType,omitempty"`
+	// approval request the artifact was deployed with, set only for pipelines requiring approval
+	DeploymentApprovalRequestId int                              `json:"-"`
+	DeploymentApprovalRequest   *approvalBean.ApprovalRequestDto `json:"deploymentApprovalRequest,omitempty"`
 }
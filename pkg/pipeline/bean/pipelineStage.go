@@ -40,9 +40,11 @@ type PipelineStageStepDto struct {
 	Index                    int                         `json:"index"`
 	StepType                 repository.PipelineStepType `json:"stepType" validate:"omitempty,oneof=INLINE REF_PLUGIN"`
 	OutputDirectoryPath      []string                    `json:"outputDirectoryPath"`
+	TestReportPaths          []string                    `json:"testReportPaths"` // paths (files, directories or globs) of JUnit/test2json/TAP reports produced by the step
 	InlineStepDetail         *InlineStepDetailDto        `json:"inlineStepDetail" validate:"omitempty,dive"`
 	RefPluginStepDetail      *RefPluginStepDetailDto     `json:"pluginRefStepDetail" validate:"omitempty,dive"`
 	TriggerIfParentStageFail bool                        `json:"triggerIfParentStageFail"`
+	RetryPolicy              *pluginRepo.StepRetryPolicy `json:"retryPolicy,omitempty"` // default retry policy of the plugin is used for plugin steps if not set
 }
 
 type InlineStepDetailDto struct {
@@ -130,6 +132,7 @@ type ConditionDetailDto struct {
 	ConditionType       repository.PipelineStageStepConditionType `json:"conditionType" validate:"oneof=SKIP TRIGGER FAIL PASS"`
 	ConditionalOperator string                                    `json:"conditionOperator"`
 	ConditionalValue    string                                    `json:"conditionalValue"`
+	ConditionExpression string                                    `json:"conditionExpression,omitempty"` //CEL expression over step variables, global variables and previous step status
 }
 
 type MountPathMap struct {
@@ -20,6 +20,7 @@ import (
 	commonBean "github.com/devtron-labs/common-lib/workflow"
 	"github.com/devtron-labs/devtron/internal/sql/constants"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	pluginRepo "github.com/devtron-labs/devtron/pkg/plugin/repository"
 )
 
 const IMAGE_SCANNER_ENDPOINT = "IMAGE_SCANNER_ENDPOINT"
@@ -64,7 +65,9 @@ type StepObject struct {
 	SourceCodeMount          *MountPath                   `json:"sourceCodeMount"`   // destination path - mountCodeToContainerPath
 	ExtraVolumeMounts        []*MountPath                 `json:"extraVolumeMounts"` // filePathMapping
 	ArtifactPaths            []string                     `json:"artifactPaths"`
+	TestReportPaths          []string                     `json:"testReportPaths"`
 	TriggerIfParentStageFail bool                         `json:"triggerIfParentStageFail"`
+	RetryPolicy              *pluginRepo.StepRetryPolicy  `json:"retryPolicy,omitempty"`
 }
 
 type ConditionObject struct {
@@ -72,6 +75,7 @@ type ConditionObject struct {
 	ConditionOnVariable string `json:"conditionOnVariable"` //name of variable
 	ConditionalOperator string `json:"conditionalOperator"`
 	ConditionalValue    string `json:"conditionalValue"`
+	ConditionExpression string `json:"conditionExpression,omitempty"` //CEL expression, used instead of variable, operator and value when present
 }
 
 type MountPath struct {
@@ -14,3 +14,21 @@ func FilterReservedPathFromOutputDirPath(outputDirectoryPath []string) []string
 	}
 	return newOutputDirPath
 }
+
+// GetArtifactPathsForStep returns the paths the ci runner uploads as artifacts for a step,
+// test report paths are included so that they can be ingested once the workflow completes
+func GetArtifactPathsForStep(outputDirectoryPath, testReportPaths []string) []string {
+	if len(testReportPaths) == 0 {
+		return outputDirectoryPath
+	}
+	artifactPaths := make([]string, 0, len(outputDirectoryPath)+len(testReportPaths))
+	seen := make(map[string]bool)
+	for _, path := range append(append([]string{}, outputDirectoryPath...), testReportPaths...) {
+		if len(path) == 0 || seen[path] {
+			continue
+		}
+		seen[path] = true
+		artifactPaths = append(artifactPaths, path)
+	}
+	return artifactPaths
+}
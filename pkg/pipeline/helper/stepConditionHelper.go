@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
+	celGo "github.com/google/cel-go/cel"
+	"regexp"
+	"strings"
+)
+
+var celIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// HasConditionExpression returns true if any of the conditions is written as a CEL expression
+func HasConditionExpression(conditions []*bean.ConditionDetailDto) bool {
+	for _, condition := range conditions {
+		if condition != nil && len(strings.TrimSpace(condition.ConditionExpression)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStepConditionGlobalParamDeclarations returns the declarations of the global variables
+// which can be used in a step condition expression, the values are resolved at run time
+func GetStepConditionGlobalParamDeclarations() []cel.ExpressionParam {
+	return []cel.ExpressionParam{
+		{ParamName: cel.Branch, Type: cel.ParamTypeString},
+		{ParamName: cel.CommitHash, Type: cel.ParamTypeString},
+		{ParamName: cel.AppName, Type: cel.ParamTypeString},
+		{ParamName: cel.EnvName, Type: cel.ParamTypeString},
+		{ParamName: cel.IsProdEnv, Type: cel.ParamTypeBool},
+		{ParamName: cel.PreviousStepStatus, Type: cel.ParamTypeString},
+		{ParamName: cel.StepStatuses, Type: cel.ParamTypeMapStringToAny},
+	}
+}
+
+// GetStepConditionParamDeclarations returns the declarations of all the variables available to a step condition expression,
+// i.e. the global variables along with the input and output variables of the step.
+// Variables whose names are not valid CEL identifiers or clash with a global variable are not declared.
+func GetStepConditionParamDeclarations(inputVariables, outputVariables []*bean.StepVariableDto) []cel.ExpressionParam {
+	params := GetStepConditionGlobalParamDeclarations()
+	declared := make(map[cel.ParamName]bool, len(params))
+	for _, param := range params {
+		declared[param.ParamName] = true
+	}
+	for _, variable := range append(append([]*bean.StepVariableDto{}, inputVariables...), outputVariables...) {
+		if variable == nil || !celIdentifierRegex.MatchString(variable.Name) || declared[cel.ParamName(variable.Name)] {
+			continue
+		}
+		declared[cel.ParamName(variable.Name)] = true
+		params = append(params, cel.ExpressionParam{
+			ParamName: cel.ParamName(variable.Name),
+			Type:      getParamTypeForVariableFormat(variable.Format),
+		})
+	}
+	return params
+}
+
+func getParamTypeForVariableFormat(format repository.PipelineStageStepVariableFormatType) cel.ParamValuesType {
+	switch format {
+	case repository.PIPELINE_STAGE_STEP_VARIABLE_FORMAT_TYPE_BOOL:
+		return cel.ParamTypeBool
+	case repository.PIPELINE_STAGE_STEP_VARIABLE_FORMAT_TYPE_NUMBER:
+		// numbers can either be integers or decimals, leaving the type to be resolved at run time
+		return cel.ParamTypeObject
+	default:
+		return cel.ParamTypeString
+	}
+}
+
+// BuildStepConditionGlobalParams returns the values of the global variables for step condition expressions,
+// branch and commit are picked from the first git material of the pipeline
+func BuildStepConditionGlobalParams(ciProjectDetails []bean.CiProjectDetails, appName, envName string, isProdEnv bool) []cel.ExpressionParam {
+	var branch, commitHash string
+	if len(ciProjectDetails) > 0 {
+		commitHash = ciProjectDetails[0].CommitHash
+		if ciProjectDetails[0].SourceType == constants.SOURCE_TYPE_BRANCH_FIXED || ciProjectDetails[0].SourceType == constants.SOURCE_TYPE_BRANCH_REGEX {
+			branch = ciProjectDetails[0].SourceValue
+		}
+	}
+	return []cel.ExpressionParam{
+		{ParamName: cel.Branch, Type: cel.ParamTypeString, Value: branch},
+		{ParamName: cel.CommitHash, Type: cel.ParamTypeString, Value: commitHash},
+		{ParamName: cel.AppName, Type: cel.ParamTypeString, Value: appName},
+		{ParamName: cel.EnvName, Type: cel.ParamTypeString, Value: envName},
+		{ParamName: cel.IsProdEnv, Type: cel.ParamTypeBool, Value: isProdEnv},
+	}
+}
+
+// ValidateConditionExpression type checks the expression against the step condition variables,
+// the expression must evaluate to a boolean
+func ValidateConditionExpression(celService cel.EvaluatorService, expression string, params []cel.ExpressionParam) error {
+	ast, _, err := celService.Validate(cel.Request{
+		Expression:         expression,
+		ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+	})
+	if err != nil {
+		return err
+	}
+	if outputType := ast.OutputType(); !outputType.IsExactType(celGo.BoolType) && !outputType.IsExactType(celGo.DynType) {
+		return fmt.Errorf("expression must evaluate to a boolean, found %s", outputType.String())
+	}
+	return nil
+}
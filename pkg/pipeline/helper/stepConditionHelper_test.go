@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestValidateConditionExpression(t *testing.T) {
+	celService := cel.NewCELServiceImpl(zap.NewNop().Sugar())
+	params := GetStepConditionParamDeclarations(
+		[]*bean.StepVariableDto{{Name: "REPLICAS", Format: repository.PIPELINE_STAGE_STEP_VARIABLE_FORMAT_TYPE_NUMBER}},
+		[]*bean.StepVariableDto{{Name: "SCAN_PASSED", Format: repository.PIPELINE_STAGE_STEP_VARIABLE_FORMAT_TYPE_BOOL}, {Name: "invalid-name"}},
+	)
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{expression: `branch.startsWith("release/") && isProdEnv`},
+		{expression: `previousStepStatus == "Succeeded" && SCAN_PASSED`},
+		{expression: `REPLICAS > 2 || stepStatuses["build"] == "Failed"`},
+		{expression: `branch`, wantErr: true},
+		{expression: `unknownVar == "x"`, wantErr: true},
+		{expression: `branch.startsWith(`, wantErr: true},
+	}
+	for _, tt := range tests {
+		err := ValidateConditionExpression(celService, tt.expression, params)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateConditionExpression(%q) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+		}
+	}
+}
+
+func TestBuildStepConditionGlobalParams(t *testing.T) {
+	params := BuildStepConditionGlobalParams([]bean.CiProjectDetails{{
+		CommitHash:  "abc123",
+		SourceType:  constants.SOURCE_TYPE_BRANCH_FIXED,
+		SourceValue: "release/1.0",
+	}}, "app", "prod", true)
+	celService := cel.NewCELServiceImpl(zap.NewNop().Sugar())
+	result, err := celService.EvaluateCELRequest(cel.Request{
+		Expression:         `branch.startsWith("release/") && isProdEnv && commit == "abc123" && envName == "prod"`,
+		ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+	})
+	if err != nil || !result {
+		t.Errorf("expected expression to evaluate to true, got %v, err %v", result, err)
+	}
+}
@@ -130,19 +130,21 @@ func (ps *PipelineStage) IsPipelineStageExists() bool {
 }
 
 type PipelineStageStep struct {
-	tableName                struct{}         `sql:"pipeline_stage_step" pg:",discard_unknown_columns"`
-	Id                       int              `sql:"id,pk"`
-	PipelineStageId          int              `sql:"pipeline_stage_id"`
-	Name                     string           `sql:"name"`
-	Description              string           `sql:"description"`
-	Index                    int              `sql:"index"`
-	StepType                 PipelineStepType `sql:"step_type"`
-	ScriptId                 int              `sql:"script_id"`
-	RefPluginId              int              `sql:"ref_plugin_id"` //id of plugin used as reference
-	OutputDirectoryPath      []string         `sql:"output_directory_path" pg:",array"`
-	DependentOnStep          string           `sql:"dependent_on_step"`
-	Deleted                  bool             `sql:"deleted,notnull"`
-	TriggerIfParentStageFail bool             `sql:"trigger_if_parent_stage_fail"`
+	tableName                struct{}                    `sql:"pipeline_stage_step" pg:",discard_unknown_columns"`
+	Id                       int                         `sql:"id,pk"`
+	PipelineStageId          int                         `sql:"pipeline_stage_id"`
+	Name                     string                      `sql:"name"`
+	Description              string                      `sql:"description"`
+	Index                    int                         `sql:"index"`
+	StepType                 PipelineStepType            `sql:"step_type"`
+	ScriptId                 int                         `sql:"script_id"`
+	RefPluginId              int                         `sql:"ref_plugin_id"` //id of plugin used as reference
+	OutputDirectoryPath      []string                    `sql:"output_directory_path" pg:",array"`
+	TestReportPaths          []string                    `sql:"test_report_paths" pg:",array"`
+	DependentOnStep          string                      `sql:"dependent_on_step"`
+	Deleted                  bool                        `sql:"deleted,notnull"`
+	TriggerIfParentStageFail bool                        `sql:"trigger_if_parent_stage_fail"`
+	RetryPolicy              *repository.StepRetryPolicy `sql:"retry_policy"`
 	sql.AuditLog
 }
 
@@ -217,6 +219,7 @@ type PipelineStageStepCondition struct {
 	ConditionType       PipelineStageStepConditionType `sql:"condition_type"`
 	ConditionalOperator string                         `sql:"conditional_operator"`
 	ConditionalValue    string                         `sql:"conditional_value"`
+	ConditionExpression string                         `sql:"condition_expression"`
 	Deleted             bool                           `sql:"deleted,notnull"`
 	sql.AuditLog
 }
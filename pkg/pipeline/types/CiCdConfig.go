@@ -25,6 +25,7 @@ import (
 	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
 	bean2 "github.com/devtron-labs/common-lib/utils/bean"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/bean/common"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
@@ -576,10 +577,14 @@ type CiTriggerRequest struct {
 	PipelineType          string
 	CiArtifactLastFetch   time.Time
 	ReferenceCiWorkflowId int
+	TriggerType           workflow.CiTriggerType
 	// below fields used at the time of retrigger
 	IsRetrigger              bool
 	RetriggerWorkflowRequest *WorkflowRequest
 	RetriggerCiWorkflow      *pipelineConfig.CiWorkflow
+	// QueuedCiWorkflow is set when a trigger waiting in the build queue is dispatched,
+	// the queued ci workflow is started instead of creating a new one
+	QueuedCiWorkflow *pipelineConfig.CiWorkflow `json:"-"`
 }
 
 func (obj *CiTriggerRequest) BuildTriggerObject(refCiWorkflow *pipelineConfig.CiWorkflow,
@@ -597,6 +602,7 @@ func (obj *CiTriggerRequest) BuildTriggerObject(refCiWorkflow *pipelineConfig.Ci
 	obj.InvalidateCache = invalidateCache
 	obj.RuntimeParameters = runtimeParameters
 	obj.PipelineType = pipelineType
+	obj.TriggerType = refCiWorkflow.TriggerType
 
 }
 
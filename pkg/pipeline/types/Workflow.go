@@ -28,9 +28,11 @@ import (
 	commonBean "github.com/devtron-labs/common-lib/utils/bean"
 	"github.com/devtron-labs/common-lib/utils/workFlow"
 	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/cel"
 	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
 	repository3 "github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	bean2 "github.com/devtron-labs/devtron/pkg/bean"
 	bean5 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
@@ -38,6 +40,7 @@ import (
 	repository4 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	infraBean "github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/helper"
 	bean6 "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/bean"
 	bean4 "github.com/devtron-labs/devtron/pkg/plugin/bean"
 	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
@@ -125,6 +128,7 @@ type WorkflowRequest struct {
 	ImageRetryInterval          int                               `json:"imageRetryInterval"`
 	IsReTrigger                 bool                              `json:"isReTrigger"`
 	ReferenceCiWorkflowId       int                               `json:"referenceCiWorkflowId"` // data filled when retriggering a ci workflow
+	TriggerType                 workflow.CiTriggerType            `json:"triggerType,omitempty"`
 	// Data from CD Workflow service
 	WorkflowRunnerId            int                                  `json:"workflowRunnerId"`
 	CdPipelineId                int                                  `json:"cdPipelineId"`
@@ -161,6 +165,7 @@ type WorkflowRequest struct {
 	BuildxBuilderPodWaitDurationSecs  int    `json:"buildxBuilderPodWaitDurationSecs"`
 	UseDockerApiToGetDigest           bool   `json:"useDockerApiToGetDigest"`
 	HostUrl                     string `json:"hostUrl"`
+	StepConditionParams         []cel.ExpressionParam `json:"stepConditionParams,omitempty"` // values of global variables used in step condition expressions
 	WorkflowRequestEnt
 }
 
@@ -259,6 +264,40 @@ func (workflowRequest *WorkflowRequest) updateUseExternalClusterBlob(config *CiC
 	workflowRequest.UseExternalClusterBlob = !workflowRequest.CheckBlobStorageConfig(config) && workflowRequest.IsExtRun
 }
 
+// updateStepConditionParams resolves the global variables for the steps having a condition expression,
+// step variables and previous step statuses are resolved by the runner while evaluating the expression
+func (workflowRequest *WorkflowRequest) updateStepConditionParams() {
+	if !workflowRequest.hasStepConditionExpression() {
+		return
+	}
+	appName := workflowRequest.AppName
+	if len(appName) == 0 && workflowRequest.Pipeline != nil {
+		appName = workflowRequest.Pipeline.App.AppName
+	}
+	var envName string
+	var isProdEnv bool
+	if env := workflowRequest.Env; env != nil {
+		envName, isProdEnv = env.Name, env.Default
+	}
+	workflowRequest.StepConditionParams = helper.BuildStepConditionGlobalParams(workflowRequest.CiProjectDetails, appName, envName, isProdEnv)
+}
+
+func (workflowRequest *WorkflowRequest) hasStepConditionExpression() bool {
+	steps := make([]*bean.StepObject, 0, len(workflowRequest.PreCiSteps)+len(workflowRequest.PostCiSteps)+len(workflowRequest.PrePostDeploySteps))
+	steps = append(append(append(steps, workflowRequest.PreCiSteps...), workflowRequest.PostCiSteps...), workflowRequest.PrePostDeploySteps...)
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		for _, condition := range append(append([]*bean.ConditionObject{}, step.TriggerSkipConditions...), step.SuccessFailureConditions...) {
+			if condition != nil && len(condition.ConditionExpression) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (workflowRequest *WorkflowRequest) GetWorkflowTemplate(workflowJson []byte, config *CiCdConfig) bean.WorkflowTemplate {
 
 	ttl := int32(config.BuildLogTTLValue)
@@ -294,6 +333,7 @@ func (workflowRequest *WorkflowRequest) GetWorkflowJson(config *CiCdConfig) ([]b
 	workflowRequest.updateBlobStorageLogsKey(config)
 	workflowRequest.updateExternalRunMetadata()
 	workflowRequest.updateUseExternalClusterBlob(config)
+	workflowRequest.updateStepConditionParams()
 	workflowJson, err := workflowRequest.getWorkflowJson()
 	if err != nil {
 		return nil, err
@@ -763,6 +803,7 @@ type WorkflowResponse struct {
 	ReferenceWorkflowId    int                                    `json:"referenceWorkflowId"`
 	TargetPlatforms        []*commonBean.TargetPlatform           `json:"targetPlatforms"`
 	WorkflowExecutionStage map[string][]*bean6.WorkflowStageDto   `json:"workflowExecutionStages"`
+	TriggerType            workflow.CiTriggerType                 `json:"triggerType,omitempty"`
 }
 
 type ConfigMapSecretDto struct {
@@ -31,6 +31,8 @@ type WorkFlowStageStatusService interface {
 	SaveWorkflowStages(wfId int, wfType, wfName string, tx *pg.Tx) error
 	UpdateWorkflowStages(wfId int, wfType, wfName, wfStatus, podStatus, message, podName string, tx *pg.Tx) (string, string, error)
 	ConvertDBWorkflowStageToMap(workflowStages []*repository.WorkflowExecutionStage, wfId int, status, podStatus, message, wfType string, startTime, endTime time.Time) map[string][]*bean2.WorkflowStageDto
+	// SaveStepExecutionStages saves the per step execution reported by the runner, including timings of each retry attempt
+	SaveStepExecutionStages(wfId int, wfType string, steps []*bean2.StepExecutionDto) error
 }
 
 type WorkFlowStageStatusServiceImpl struct {
@@ -73,6 +75,7 @@ func (impl *WorkFlowStageStatusServiceImpl) getUpdatedPipelineStagesForWorkflow(
 		impl.logger.Errorw("error in getting workflow stages", "workflowId", wfId, "error", err)
 		return nil, wfStatus, podStatus
 	}
+	currentWorkflowStages = adapter.FilterOutStepStages(currentWorkflowStages)
 	if len(currentWorkflowStages) == 0 {
 		return []*repository.WorkflowExecutionStage{}, wfStatus, podStatus
 	}
@@ -420,3 +423,36 @@ func (impl *WorkFlowStageStatusServiceImpl) UpdateWorkflowStages(wfId int, wfTyp
 	}
 	return wfStatus, podStatus, nil
 }
+
+func (impl *WorkFlowStageStatusServiceImpl) SaveStepExecutionStages(wfId int, wfType string, steps []*bean2.StepExecutionDto) error {
+	if !impl.config.EnableWorkflowExecutionStage || len(steps) == 0 {
+		return nil
+	}
+	stepStages := make([]*repository.WorkflowExecutionStage, 0, len(steps))
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		stepStages = append(stepStages, adapter.GetStepExecutionStage(wfId, wfType, step))
+	}
+	tx, err := impl.transactionManager.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "wfId", wfId, "err", err)
+		return err
+	}
+	defer impl.transactionManager.RollbackTx(tx)
+	// steps are reported once the stage completes, replacing earlier entries keeps redelivered events idempotent
+	err = impl.workflowStatusRepository.DeleteWorkflowStagesByStatusFor(wfId, wfType, bean2.WORKFLOW_STAGE_STATUS_TYPE_STEP, tx)
+	if err != nil {
+		impl.logger.Errorw("error in deleting step execution stages", "wfId", wfId, "wfType", wfType, "err", err)
+		return err
+	}
+	if len(stepStages) > 0 {
+		_, err = impl.workflowStatusRepository.SaveWorkflowStages(stepStages, tx)
+		if err != nil {
+			impl.logger.Errorw("error in saving step execution stages", "wfId", wfId, "wfType", wfType, "err", err)
+			return err
+		}
+	}
+	return impl.transactionManager.CommitTx(tx)
+}
@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	bean3 "github.com/devtron-labs/devtron/pkg/bean"
@@ -124,3 +125,53 @@ func GetDefaultPodExecutionStage(workflowId int, workflowType string) *repositor
 		AuditLog: sql.NewDefaultAuditLog(1),
 	}
 }
+
+func GetStepExecutionStage(workflowId int, workflowType string, step *bean.StepExecutionDto) *repository.WorkflowExecutionStage {
+	metadata, _ := json.Marshal(&bean.StepExecutionMetadata{
+		StageType: step.StageType,
+		StepIndex: step.StepIndex,
+		Attempts:  step.Attempts,
+	})
+	stage := &repository.WorkflowExecutionStage{
+		StageName:    bean.WorkflowStageName(step.StepName),
+		Status:       step.Status,
+		StatusFor:    bean.WORKFLOW_STAGE_STATUS_TYPE_STEP,
+		WorkflowId:   workflowId,
+		WorkflowType: workflowType,
+		Message:      getStepExecutionMessage(step),
+		Metadata:     string(metadata),
+		AuditLog:     sql.NewDefaultAuditLog(1),
+	}
+	if len(step.Attempts) > 0 {
+		stage.StartTime = step.Attempts[0].StartTime
+		stage.EndTime = step.Attempts[len(step.Attempts)-1].EndTime
+	}
+	return stage
+}
+
+// getStepExecutionMessage returns the message shown for a step, e.g. "passed on attempt 3"
+func getStepExecutionMessage(step *bean.StepExecutionDto) string {
+	attempts := len(step.Attempts)
+	if attempts <= 1 {
+		return ""
+	}
+	switch step.Status {
+	case bean.WORKFLOW_STAGE_STATUS_SUCCEEDED:
+		return fmt.Sprintf("passed on attempt %d", attempts)
+	case bean.WORKFLOW_STAGE_STATUS_FAILED:
+		return fmt.Sprintf("failed after %d attempts", attempts)
+	default:
+		return fmt.Sprintf("%d attempts", attempts)
+	}
+}
+
+// FilterOutStepStages removes the step execution stages, only workflow and pod stages are derived from the workflow status
+func FilterOutStepStages(stages []*repository.WorkflowExecutionStage) []*repository.WorkflowExecutionStage {
+	filteredStages := make([]*repository.WorkflowExecutionStage, 0, len(stages))
+	for _, stage := range stages {
+		if stage.StatusFor != bean.WORKFLOW_STAGE_STATUS_TYPE_STEP {
+			filteredStages = append(filteredStages, stage)
+		}
+	}
+	return filteredStages
+}
@@ -17,6 +17,7 @@ type WorkflowStageStatusFor string
 const (
 	WORKFLOW_STAGE_STATUS_TYPE_WORKFLOW WorkflowStageStatusFor = "workflow"
 	WORKFLOW_STAGE_STATUS_TYPE_POD      WorkflowStageStatusFor = "pod"
+	WORKFLOW_STAGE_STATUS_TYPE_STEP     WorkflowStageStatusFor = "step"
 )
 
 func (n WorkflowStageStatusFor) ToString() string {
@@ -60,3 +61,29 @@ type WorkflowStageDto struct {
 	StartTime    string                 `json:"startTime"`
 	EndTime      string                 `json:"endTime"`
 }
+
+// StepExecutionDto is reported by the runner for every executed step of pre/post ci and cd stages
+type StepExecutionDto struct {
+	StepName  string              `json:"stepName"`
+	StepIndex int                 `json:"stepIndex"`
+	StageType string              `json:"stageType"` // PRE_CI, POST_CI, PRE_CD or POST_CD
+	Status    WorkflowStageStatus `json:"status"`
+	Attempts  []*StepAttemptDto   `json:"attempts"`
+}
+
+type StepAttemptDto struct {
+	Attempt   int                 `json:"attempt"`
+	Status    WorkflowStageStatus `json:"status"`
+	ExitCode  int                 `json:"exitCode"`
+	StartTime string              `json:"startTime"`
+	EndTime   string              `json:"endTime"`
+	LogsKey   string              `json:"logsKey,omitempty"` // blob storage key of the logs of this attempt
+	Message   string              `json:"message,omitempty"`
+}
+
+// StepExecutionMetadata is saved as metadata of a step execution stage
+type StepExecutionMetadata struct {
+	StageType string            `json:"stageType"`
+	StepIndex int               `json:"stepIndex"`
+	Attempts  []*StepAttemptDto `json:"attempts"`
+}
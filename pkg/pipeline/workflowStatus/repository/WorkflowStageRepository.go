@@ -16,6 +16,7 @@ type WorkflowStageRepository interface {
 	GetWorkflowStagesByWorkflowIdAndWtype(wfId int, wfType string) ([]*WorkflowExecutionStage, error)
 	GetWorkflowStagesByWorkflowIdsAndWtype(wfIds []int, wfType string) ([]*WorkflowExecutionStage, error)
 	GetSuccessfulCIExecutionStages(from, to *time.Time) ([]*WorkflowExecutionStage, error)
+	DeleteWorkflowStagesByStatusFor(workflowId int, workflowType string, statusFor bean.WorkflowStageStatusFor, tx *pg.Tx) error
 }
 
 type WorkflowStageRepositoryImpl struct {
@@ -114,3 +115,12 @@ func (impl *WorkflowStageRepositoryImpl) GetSuccessfulCIExecutionStages(from, to
 	}
 	return workflowStages, nil
 }
+
+func (impl *WorkflowStageRepositoryImpl) DeleteWorkflowStagesByStatusFor(workflowId int, workflowType string, statusFor bean.WorkflowStageStatusFor, tx *pg.Tx) error {
+	_, err := tx.Model((*WorkflowExecutionStage)(nil)).
+		Where("workflow_id = ?", workflowId).
+		Where("workflow_type = ?", workflowType).
+		Where("status_for = ?", statusFor).
+		Delete()
+	return err
+}
@@ -289,11 +289,12 @@ func (impl *GlobalPluginServiceImpl) GetPluginDetailById(pluginId int) (*bean2.P
 		return nil, err
 	}
 	metadataDto := &bean2.PluginMetadataDto{
-		Id:          pluginMetadata.Id,
-		Name:        pluginMetadata.Name,
-		Type:        string(pluginMetadata.Type),
-		Description: pluginMetadata.Description,
-		Icon:        pluginMetadata.Icon,
+		Id:                 pluginMetadata.Id,
+		Name:               pluginMetadata.Name,
+		Type:               string(pluginMetadata.Type),
+		Description:        pluginMetadata.Description,
+		Icon:               pluginMetadata.Icon,
+		DefaultRetryPolicy: pluginMetadata.DefaultRetryPolicy,
 	}
 	pluginDetail := &bean2.PluginDetailDto{
 		Metadata: metadataDto,
@@ -424,7 +425,12 @@ func (impl *GlobalPluginServiceImpl) GetParentPluginIdByIdentifiers(identifiers
 }
 
 func (impl *GlobalPluginServiceImpl) PatchPlugin(pluginDto *bean2.PluginMetadataDto, userId int32) (*bean2.PluginMetadataDto, error) {
-
+	if pluginDto.Action == bean2.CREATEPLUGIN || pluginDto.Action == bean2.UPDATEPLUGIN {
+		err := validateDefaultRetryPolicy(pluginDto.DefaultRetryPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
 	switch pluginDto.Action {
 	case bean2.CREATEPLUGIN:
 		pluginData, err := impl.createPlugin(pluginDto, userId)
@@ -455,6 +461,14 @@ func (impl *GlobalPluginServiceImpl) PatchPlugin(pluginDto *bean2.PluginMetadata
 	return nil, nil
 }
 
+func validateDefaultRetryPolicy(retryPolicy *repository.StepRetryPolicy) error {
+	if err := retryPolicy.Validate(); err != nil {
+		errMsg := fmt.Sprintf("invalid default retry policy: %s", err.Error())
+		return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return nil
+}
+
 func (impl *GlobalPluginServiceImpl) validatePluginRequest(pluginReq *bean2.PluginMetadataDto) error {
 	if len(pluginReq.Type) == 0 {
 		return errors.New("invalid plugin type, should be of the type PRESET or SHARED")
@@ -780,6 +794,7 @@ func (impl *GlobalPluginServiceImpl) updatePlugin(pluginUpdateReq *bean2.PluginM
 	pluginMetaData.Description = pluginUpdateReq.Description
 	pluginMetaData.Type = repository.PluginType(pluginUpdateReq.Type)
 	pluginMetaData.Icon = pluginUpdateReq.Icon
+	pluginMetaData.DefaultRetryPolicy = pluginUpdateReq.DefaultRetryPolicy
 	pluginMetaData.UpdatedOn = time.Now()
 	pluginMetaData.UpdatedBy = userId
 
@@ -1448,13 +1463,14 @@ func (impl *GlobalPluginServiceImpl) GetDetailedPluginInfoByPluginId(pluginId in
 		return nil, err
 	}
 	pluginMetadataResponse := &bean2.PluginMetadataDto{
-		Id:          pluginMetaData.Id,
-		Name:        pluginMetaData.Name,
-		Description: pluginMetaData.Description,
-		Type:        string(pluginMetaData.Type),
-		Icon:        pluginMetaData.Icon,
-		Tags:        pluginIdTagsMap[pluginMetaData.Id],
-		PluginStage: pluginStage,
+		Id:                 pluginMetaData.Id,
+		Name:               pluginMetaData.Name,
+		Description:        pluginMetaData.Description,
+		Type:               string(pluginMetaData.Type),
+		Icon:               pluginMetaData.Icon,
+		Tags:               pluginIdTagsMap[pluginMetaData.Id],
+		PluginStage:        pluginStage,
+		DefaultRetryPolicy: pluginMetaData.DefaultRetryPolicy,
 	}
 
 	pluginStepsResp := make([]*bean2.PluginStepsDto, 0)
@@ -2106,6 +2122,9 @@ func (impl *GlobalPluginServiceImpl) validateV2PluginRequest(pluginReq *bean2.Pl
 	if err := utils.ValidatePluginVersion(version); err != nil {
 		return err
 	}
+	if err := validateDefaultRetryPolicy(pluginReq.Versions.DetailedPluginVersionData[0].DefaultRetryPolicy); err != nil {
+		return err
+	}
 	//validate icon url and size
 	if len(pluginReq.Icon) > 0 {
 		err := utils.FetchIconAndCheckSize(pluginReq.Icon, bean2.PluginIconMaxSizeInBytes)
@@ -13,7 +13,8 @@ func GetPluginParentMetadataDbObject(pluginDto *pluginBean.PluginParentMetadataD
 
 func GetPluginVersionMetadataDbObject(pluginDto *pluginBean.PluginParentMetadataDto, userId int32) *repository.PluginMetadata {
 	versionDto := pluginDto.Versions.DetailedPluginVersionData[0]
-	return repository.NewPluginVersionMetadata().CreateAuditLog(userId).WithBasicMetadata(pluginDto.Name, versionDto.Description, versionDto.Version, versionDto.DocLink, versionDto.IsExposed)
+	return repository.NewPluginVersionMetadata().CreateAuditLog(userId).WithBasicMetadata(pluginDto.Name, versionDto.Description, versionDto.Version, versionDto.DocLink, versionDto.IsExposed).
+		WithDefaultRetryPolicy(versionDto.DefaultRetryPolicy)
 }
 
 func GetPluginStepDbObject(pluginStepDto *pluginBean.PluginStepsDto, pluginVersionMetadataId int, userId int32) *repository.PluginStep {
@@ -55,6 +55,8 @@ type PluginMetadataDto struct {
 	PluginSteps       []*PluginStepsDto `json:"pluginSteps,omitempty"`
 	AreNewTagsPresent bool              `json:"areNewTagsPresent,omitempty"`
 	IsExposed         *bool             `json:"-"`
+	// DefaultRetryPolicy is applied to the steps using this plugin which don't define their own retry policy
+	DefaultRetryPolicy *repository.StepRetryPolicy `json:"defaultRetryPolicy,omitempty"`
 }
 
 type PluginMinDto struct {
@@ -215,6 +217,7 @@ func (r *PluginsVersionDetail) SetMinimalPluginsVersionDetail(pluginVersionMetad
 	r.Version = pluginVersionMetadata.PluginVersion
 	r.IsLatest = pluginVersionMetadata.IsLatest
 	r.DocLink = pluginVersionMetadata.DocLink
+	r.DefaultRetryPolicy = pluginVersionMetadata.DefaultRetryPolicy
 	return r
 }
 
@@ -290,11 +293,12 @@ func (r *PluginTagsDto) WithTagNames(tags []string) *PluginTagsDto {
 
 func (r *PluginMetadataDto) GetPluginMetadataSqlObj(userId int32) *repository.PluginMetadata {
 	return &repository.PluginMetadata{
-		Name:        r.Name,
-		Description: r.Description,
-		Type:        repository.PluginType(r.Type),
-		Icon:        r.Icon,
-		AuditLog:    sql.NewDefaultAuditLog(userId),
+		Name:               r.Name,
+		Description:        r.Description,
+		Type:               repository.PluginType(r.Type),
+		Icon:               r.Icon,
+		DefaultRetryPolicy: r.DefaultRetryPolicy,
+		AuditLog:           sql.NewDefaultAuditLog(userId),
 	}
 }
 
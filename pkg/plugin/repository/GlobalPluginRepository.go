@@ -171,19 +171,20 @@ func (r *PluginParentMetadata) CreateAndSetPluginIdentifier(pluginName string, p
 }
 
 type PluginMetadata struct {
-	tableName              struct{}   `sql:"plugin_metadata" pg:",discard_unknown_columns"`
-	Id                     int        `sql:"id,pk"`
-	Name                   string     `sql:"name"`
-	Description            string     `sql:"description"`
-	Type                   PluginType `sql:"type"` //deprecated
-	Icon                   string     `sql:"icon"` //deprecated
-	Deleted                bool       `sql:"deleted, notnull"`
-	PluginParentMetadataId int        `sql:"plugin_parent_metadata_id"`
-	PluginVersion          string     `sql:"plugin_version, notnull"`
-	IsDeprecated           bool       `sql:"is_deprecated, notnull"`
-	DocLink                string     `sql:"doc_link"`
-	IsLatest               bool       `sql:"is_latest, notnull"`
-	IsExposed              bool       `sql:"is_exposed, notnull"` // it's not user driven, used internally to make decision weather to show plugin or not in plugin list
+	tableName              struct{}         `sql:"plugin_metadata" pg:",discard_unknown_columns"`
+	Id                     int              `sql:"id,pk"`
+	Name                   string           `sql:"name"`
+	Description            string           `sql:"description"`
+	Type                   PluginType       `sql:"type"` //deprecated
+	Icon                   string           `sql:"icon"` //deprecated
+	Deleted                bool             `sql:"deleted, notnull"`
+	PluginParentMetadataId int              `sql:"plugin_parent_metadata_id"`
+	PluginVersion          string           `sql:"plugin_version, notnull"`
+	IsDeprecated           bool             `sql:"is_deprecated, notnull"`
+	DocLink                string           `sql:"doc_link"`
+	IsLatest               bool             `sql:"is_latest, notnull"`
+	IsExposed              bool             `sql:"is_exposed, notnull"`  // it's not user driven, used internally to make decision weather to show plugin or not in plugin list
+	DefaultRetryPolicy     *StepRetryPolicy `sql:"default_retry_policy"` // used for steps referring this plugin version when they don't define a retry policy
 	sql.AuditLog
 }
 
@@ -225,6 +226,11 @@ func (r *PluginMetadata) WithIsLatestFlag(isLatest bool) *PluginMetadata {
 	return r
 }
 
+func (r *PluginMetadata) WithDefaultRetryPolicy(retryPolicy *StepRetryPolicy) *PluginMetadata {
+	r.DefaultRetryPolicy = retryPolicy
+	return r
+}
+
 type PluginTag struct {
 	tableName struct{} `sql:"plugin_tag" pg:",discard_unknown_columns"`
 	Id        int      `sql:"id,pk"`
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type RetryBackoffType string
+
+const (
+	RETRY_BACKOFF_TYPE_FIXED       RetryBackoffType = "FIXED"
+	RETRY_BACKOFF_TYPE_EXPONENTIAL RetryBackoffType = "EXPONENTIAL"
+)
+
+const (
+	StepRetryMaxAttemptsLimit    = 10
+	StepRetryMaxBackoffSecsLimit = 3600
+)
+
+// StepRetryPolicy defines how a failed step is retried by the runner, it is stored as json for
+// pipeline stage steps and as the default policy of a plugin version
+type StepRetryPolicy struct {
+	MaxAttempts       int              `json:"maxAttempts"`                 // total attempts including the first one
+	BackoffType       RetryBackoffType `json:"backoffType,omitempty"`       // FIXED or EXPONENTIAL, FIXED if not set
+	BackoffSeconds    int              `json:"backoffSeconds,omitempty"`    // wait before the first retry
+	MaxBackoffSeconds int              `json:"maxBackoffSeconds,omitempty"` // upper bound of wait for EXPONENTIAL backoff
+	RetryOnExitCodes  []int            `json:"retryOnExitCodes,omitempty"`  // retried on any non-zero exit code if empty
+}
+
+func (r *StepRetryPolicy) IsEnabled() bool {
+	return r != nil && r.MaxAttempts > 1
+}
+
+// GetBackoff returns the wait before the given retry, retry starts from 1 for the second attempt
+func (r *StepRetryPolicy) GetBackoff(retry int) time.Duration {
+	if !r.IsEnabled() || retry < 1 || r.BackoffSeconds <= 0 {
+		return 0
+	}
+	backoffSeconds := float64(r.BackoffSeconds)
+	if r.BackoffType == RETRY_BACKOFF_TYPE_EXPONENTIAL {
+		backoffSeconds = backoffSeconds * math.Pow(2, float64(retry-1))
+		if r.MaxBackoffSeconds > 0 {
+			backoffSeconds = math.Min(backoffSeconds, float64(r.MaxBackoffSeconds))
+		}
+	}
+	return time.Duration(math.Min(backoffSeconds, StepRetryMaxBackoffSecsLimit)) * time.Second
+}
+
+// ShouldRetryOnExitCode returns true if a step exiting with the given code is to be retried
+func (r *StepRetryPolicy) ShouldRetryOnExitCode(exitCode int) bool {
+	if !r.IsEnabled() || exitCode == 0 {
+		return false
+	}
+	if len(r.RetryOnExitCodes) == 0 {
+		return true
+	}
+	for _, code := range r.RetryOnExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *StepRetryPolicy) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MaxAttempts < 1 || r.MaxAttempts > StepRetryMaxAttemptsLimit {
+		return fmt.Errorf("max attempts should be between 1 and %d", StepRetryMaxAttemptsLimit)
+	}
+	if len(r.BackoffType) > 0 && r.BackoffType != RETRY_BACKOFF_TYPE_FIXED && r.BackoffType != RETRY_BACKOFF_TYPE_EXPONENTIAL {
+		return fmt.Errorf("invalid backoff type '%s', should be one of %s, %s", r.BackoffType, RETRY_BACKOFF_TYPE_FIXED, RETRY_BACKOFF_TYPE_EXPONENTIAL)
+	}
+	if r.BackoffSeconds < 0 || r.BackoffSeconds > StepRetryMaxBackoffSecsLimit {
+		return fmt.Errorf("backoff seconds should be between 0 and %d", StepRetryMaxBackoffSecsLimit)
+	}
+	if r.MaxBackoffSeconds < 0 || r.MaxBackoffSeconds > StepRetryMaxBackoffSecsLimit {
+		return fmt.Errorf("max backoff seconds should be between 0 and %d", StepRetryMaxBackoffSecsLimit)
+	}
+	for _, code := range r.RetryOnExitCodes {
+		if code <= 0 || code > 255 {
+			return fmt.Errorf("invalid exit code %d, retry exit codes should be between 1 and 255", code)
+		}
+	}
+	return nil
+}
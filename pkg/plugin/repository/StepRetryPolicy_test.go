@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStepRetryPolicy_GetBackoff(t *testing.T) {
+	exponential := &StepRetryPolicy{MaxAttempts: 5, BackoffType: RETRY_BACKOFF_TYPE_EXPONENTIAL, BackoffSeconds: 10, MaxBackoffSeconds: 30}
+	fixed := &StepRetryPolicy{MaxAttempts: 3, BackoffSeconds: 5}
+	tests := []struct {
+		policy *StepRetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{policy: exponential, retry: 1, want: 10 * time.Second},
+		{policy: exponential, retry: 2, want: 20 * time.Second},
+		{policy: exponential, retry: 3, want: 30 * time.Second},
+		{policy: fixed, retry: 2, want: 5 * time.Second},
+		{policy: nil, retry: 1, want: 0},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.GetBackoff(tt.retry); got != tt.want {
+			t.Errorf("GetBackoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestStepRetryPolicy_ShouldRetryOnExitCode(t *testing.T) {
+	anyCode := &StepRetryPolicy{MaxAttempts: 2}
+	specificCodes := &StepRetryPolicy{MaxAttempts: 2, RetryOnExitCodes: []int{7, 28}}
+	if !anyCode.ShouldRetryOnExitCode(1) || anyCode.ShouldRetryOnExitCode(0) {
+		t.Errorf("policy without exit codes should retry on every non-zero exit code")
+	}
+	if !specificCodes.ShouldRetryOnExitCode(28) || specificCodes.ShouldRetryOnExitCode(1) {
+		t.Errorf("policy with exit codes should retry only on the declared exit codes")
+	}
+	if (&StepRetryPolicy{MaxAttempts: 1}).ShouldRetryOnExitCode(1) {
+		t.Errorf("policy with a single attempt should not retry")
+	}
+}
+
+func TestStepRetryPolicy_Validate(t *testing.T) {
+	invalidPolicies := []*StepRetryPolicy{
+		{MaxAttempts: 0},
+		{MaxAttempts: StepRetryMaxAttemptsLimit + 1},
+		{MaxAttempts: 2, BackoffType: "LINEAR"},
+		{MaxAttempts: 2, BackoffSeconds: -1},
+		{MaxAttempts: 2, RetryOnExitCodes: []int{0}},
+	}
+	for _, policy := range invalidPolicies {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("expected validation error for policy %+v", policy)
+		}
+	}
+	var nilPolicy *StepRetryPolicy
+	if err := nilPolicy.Validate(); err != nil {
+		t.Errorf("nil policy should be valid, got %v", err)
+	}
+	if err := (&StepRetryPolicy{MaxAttempts: 3, BackoffType: RETRY_BACKOFF_TYPE_FIXED, BackoffSeconds: 5, RetryOnExitCodes: []int{1}}).Validate(); err != nil {
+		t.Errorf("expected valid policy, got %v", err)
+	}
+}
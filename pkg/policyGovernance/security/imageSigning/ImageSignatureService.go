@@ -0,0 +1,469 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSigning
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	dockerRegistry "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/read"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/adapter"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	signingRepository "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/repository"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/verifier"
+	"github.com/devtron-labs/devtron/util/sliceUtil"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ImageSignatureService interface {
+	CreateTrustedIdentity(request *bean.TrustedIdentityDto) (*bean.TrustedIdentityDto, error)
+	UpdateTrustedIdentity(request *bean.TrustedIdentityDto) (*bean.TrustedIdentityDto, error)
+	GetAllTrustedIdentities() ([]*bean.TrustedIdentityDto, error)
+	// DeleteTrustedIdentity deletes the identity if no policy trusts it
+	DeleteTrustedIdentity(id int, userId int32) error
+
+	CreatePolicy(request *bean.SignaturePolicyDto) (*bean.SignaturePolicyDto, error)
+	UpdatePolicy(request *bean.SignaturePolicyDto) (*bean.SignaturePolicyDto, error)
+	GetAllPolicies() ([]*bean.SignaturePolicyDto, error)
+	DeletePolicy(id int, userId int32) error
+
+	// VerifyArtifactForDeployment verifies the signatures and attestations of the artifact against every policy scoped
+	// to the environment of the pipeline or to its cluster, nil is returned when no policy applies
+	VerifyArtifactForDeployment(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) (*bean.VerificationResult, error)
+	RecordRunnerVerificationResult(wfrId int, result *bean.VerificationResult) error
+	// GetArtifactVerifications returns the latest verification result of the artifacts deployed on the pipeline
+	GetArtifactVerifications(pipelineId int, artifactIds []int) (map[int]*bean.ArtifactSignatureVerification, error)
+}
+
+type ImageSignatureServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	config                        *bean.ImageSigningConfig
+	trustedIdentityRepository     signingRepository.TrustedIdentityRepository
+	signaturePolicyRepository     signingRepository.SignaturePolicyRepository
+	verificationResultRepository  signingRepository.VerificationResultRepository
+	dockerArtifactStoreRepository dockerRegistry.DockerArtifactStoreRepository
+	ciPipelineConfigReadService   read.CiPipelineConfigReadService
+}
+
+func NewImageSignatureServiceImpl(logger *zap.SugaredLogger,
+	trustedIdentityRepository signingRepository.TrustedIdentityRepository,
+	signaturePolicyRepository signingRepository.SignaturePolicyRepository,
+	verificationResultRepository signingRepository.VerificationResultRepository,
+	dockerArtifactStoreRepository dockerRegistry.DockerArtifactStoreRepository,
+	ciPipelineConfigReadService read.CiPipelineConfigReadService) (*ImageSignatureServiceImpl, error) {
+	config := &bean.ImageSigningConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing image signing config", "err", err)
+		return nil, err
+	}
+	return &ImageSignatureServiceImpl{
+		logger:                        logger,
+		config:                        config,
+		trustedIdentityRepository:     trustedIdentityRepository,
+		signaturePolicyRepository:     signaturePolicyRepository,
+		verificationResultRepository:  verificationResultRepository,
+		dockerArtifactStoreRepository: dockerArtifactStoreRepository,
+		ciPipelineConfigReadService:   ciPipelineConfigReadService,
+	}, nil
+}
+
+func (impl *ImageSignatureServiceImpl) CreateTrustedIdentity(request *bean.TrustedIdentityDto) (*bean.TrustedIdentityDto, error) {
+	if err := impl.validateTrustedIdentity(request); err != nil {
+		return nil, err
+	}
+	identity := adapter.GetTrustedIdentityDbObj(request)
+	identity.Id = 0
+	err := impl.trustedIdentityRepository.Save(identity)
+	if err != nil {
+		impl.logger.Errorw("error in saving trusted identity", "name", request.Name, "err", err)
+		return nil, err
+	}
+	return adapter.GetTrustedIdentityDto(identity), nil
+}
+
+func (impl *ImageSignatureServiceImpl) UpdateTrustedIdentity(request *bean.TrustedIdentityDto) (*bean.TrustedIdentityDto, error) {
+	existing, err := impl.trustedIdentityRepository.FindById(request.Id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "trusted identity not found", "trusted identity not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching trusted identity", "id", request.Id, "err", err)
+		return nil, err
+	}
+	if err = impl.validateTrustedIdentity(request); err != nil {
+		return nil, err
+	}
+	identity := adapter.GetTrustedIdentityDbObj(request)
+	identity.CreatedOn, identity.CreatedBy = existing.CreatedOn, existing.CreatedBy
+	err = impl.trustedIdentityRepository.Update(identity)
+	if err != nil {
+		impl.logger.Errorw("error in updating trusted identity", "id", request.Id, "err", err)
+		return nil, err
+	}
+	return adapter.GetTrustedIdentityDto(identity), nil
+}
+
+func (impl *ImageSignatureServiceImpl) validateTrustedIdentity(request *bean.TrustedIdentityDto) error {
+	if _, err := verifier.NewTrustedIdentity(request); err != nil {
+		return util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	exists, err := impl.trustedIdentityRepository.ExistsByName(request.Name, request.Id)
+	if err != nil {
+		impl.logger.Errorw("error in checking trusted identity name", "name", request.Name, "err", err)
+		return err
+	}
+	if exists {
+		errMsg := fmt.Sprintf("trusted identity %s already exists", request.Name)
+		return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return nil
+}
+
+func (impl *ImageSignatureServiceImpl) GetAllTrustedIdentities() ([]*bean.TrustedIdentityDto, error) {
+	identities, err := impl.trustedIdentityRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching trusted identities", "err", err)
+		return nil, err
+	}
+	dtos := make([]*bean.TrustedIdentityDto, 0, len(identities))
+	for _, identity := range identities {
+		dtos = append(dtos, adapter.GetTrustedIdentityDto(identity))
+	}
+	return dtos, nil
+}
+
+func (impl *ImageSignatureServiceImpl) DeleteTrustedIdentity(id int, userId int32) error {
+	identity, err := impl.trustedIdentityRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, "trusted identity not found", "trusted identity not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching trusted identity", "id", id, "err", err)
+		return err
+	}
+	policies, err := impl.signaturePolicyRepository.FindActiveByTrustedIdentityId(id)
+	if err != nil {
+		impl.logger.Errorw("error in fetching policies of trusted identity", "id", id, "err", err)
+		return err
+	}
+	if len(policies) != 0 {
+		policyNames := make([]string, 0, len(policies))
+		for _, policy := range policies {
+			policyNames = append(policyNames, policy.Name)
+		}
+		errMsg := fmt.Sprintf("trusted identity is used by the signature policies %s", strings.Join(policyNames, ", "))
+		return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	identity.Active = false
+	identity.UpdateAuditLog(userId)
+	return impl.trustedIdentityRepository.Update(identity)
+}
+
+func (impl *ImageSignatureServiceImpl) CreatePolicy(request *bean.SignaturePolicyDto) (*bean.SignaturePolicyDto, error) {
+	request.Id = 0
+	return impl.savePolicy(request, nil)
+}
+
+func (impl *ImageSignatureServiceImpl) UpdatePolicy(request *bean.SignaturePolicyDto) (*bean.SignaturePolicyDto, error) {
+	existing, err := impl.signaturePolicyRepository.FindById(request.Id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "signature policy not found", "signature policy not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching signature policy", "id", request.Id, "err", err)
+		return nil, err
+	}
+	return impl.savePolicy(request, existing)
+}
+
+func (impl *ImageSignatureServiceImpl) savePolicy(request *bean.SignaturePolicyDto, existing *signingRepository.SignaturePolicy) (*bean.SignaturePolicyDto, error) {
+	if err := impl.validatePolicy(request); err != nil {
+		return nil, err
+	}
+	policy := adapter.GetSignaturePolicyDbObj(request)
+	tx, err := impl.signaturePolicyRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.signaturePolicyRepository.RollbackTx(tx)
+	if existing == nil {
+		err = impl.signaturePolicyRepository.SaveWithTx(tx, policy)
+	} else {
+		policy.CreatedOn, policy.CreatedBy = existing.CreatedOn, existing.CreatedBy
+		err = impl.signaturePolicyRepository.UpdateWithTx(tx, policy)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving signature policy", "name", request.Name, "err", err)
+		return nil, err
+	}
+	scopes := adapter.GetSignaturePolicyScopeDbObjs(policy.Id, request)
+	err = impl.signaturePolicyRepository.ReplaceScopesWithTx(tx, policy.Id, scopes)
+	if err != nil {
+		impl.logger.Errorw("error in saving signature policy scopes", "policyId", policy.Id, "err", err)
+		return nil, err
+	}
+	err = impl.signaturePolicyRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return adapter.GetSignaturePolicyDto(policy, scopes), nil
+}
+
+func (impl *ImageSignatureServiceImpl) validatePolicy(request *bean.SignaturePolicyDto) error {
+	exists, err := impl.signaturePolicyRepository.ExistsByName(request.Name, request.Id)
+	if err != nil {
+		impl.logger.Errorw("error in checking signature policy name", "name", request.Name, "err", err)
+		return err
+	}
+	if exists {
+		errMsg := fmt.Sprintf("signature policy %s already exists", request.Name)
+		return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	identities, err := impl.trustedIdentityRepository.FindByIds(request.TrustedIdentityIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching trusted identities", "ids", request.TrustedIdentityIds, "err", err)
+		return err
+	}
+	if len(identities) != len(sliceUtil.GetUniqueElements(request.TrustedIdentityIds)) {
+		return util.NewApiError(http.StatusBadRequest, "trusted identity not found", "trusted identity not found")
+	}
+	return nil
+}
+
+func (impl *ImageSignatureServiceImpl) GetAllPolicies() ([]*bean.SignaturePolicyDto, error) {
+	policies, err := impl.signaturePolicyRepository.FindAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in fetching signature policies", "err", err)
+		return nil, err
+	}
+	policyIds := make([]int, 0, len(policies))
+	for _, policy := range policies {
+		policyIds = append(policyIds, policy.Id)
+	}
+	scopes, err := impl.signaturePolicyRepository.FindScopesByPolicyIds(policyIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching signature policy scopes", "err", err)
+		return nil, err
+	}
+	scopesByPolicyId := make(map[int][]*signingRepository.SignaturePolicyScope, len(policies))
+	for _, scope := range scopes {
+		scopesByPolicyId[scope.PolicyId] = append(scopesByPolicyId[scope.PolicyId], scope)
+	}
+	dtos := make([]*bean.SignaturePolicyDto, 0, len(policies))
+	for _, policy := range policies {
+		dtos = append(dtos, adapter.GetSignaturePolicyDto(policy, scopesByPolicyId[policy.Id]))
+	}
+	return dtos, nil
+}
+
+func (impl *ImageSignatureServiceImpl) DeletePolicy(id int, userId int32) error {
+	policy, err := impl.signaturePolicyRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, "signature policy not found", "signature policy not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching signature policy", "id", id, "err", err)
+		return err
+	}
+	policy.Active = false
+	policy.UpdateAuditLog(userId)
+	tx, err := impl.signaturePolicyRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.signaturePolicyRepository.RollbackTx(tx)
+	if err = impl.signaturePolicyRepository.UpdateWithTx(tx, policy); err != nil {
+		impl.logger.Errorw("error in deleting signature policy", "id", id, "err", err)
+		return err
+	}
+	if err = impl.signaturePolicyRepository.ReplaceScopesWithTx(tx, id, nil); err != nil {
+		impl.logger.Errorw("error in deleting signature policy scopes", "id", id, "err", err)
+		return err
+	}
+	return impl.signaturePolicyRepository.CommitTx(tx)
+}
+
+func (impl *ImageSignatureServiceImpl) VerifyArtifactForDeployment(ctx context.Context, pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) (*bean.VerificationResult, error) {
+	policies, err := impl.getPolicies(pipeline.EnvironmentId)
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	digest := artifact.ImageDigest
+	if len(digest) != 0 && !strings.Contains(digest, ":") {
+		digest = "sha256:" + digest
+	}
+	var artifacts *verifier.CosignArtifacts
+	var fetchErr error
+	if len(digest) == 0 {
+		fetchErr = fmt.Errorf("digest of image %s is unknown", artifact.Image)
+	} else {
+		artifacts, fetchErr = impl.fetchCosignArtifacts(ctx, artifact, digest)
+		if fetchErr != nil {
+			impl.logger.Errorw("error in fetching image signatures", "image", artifact.Image, "digest", digest, "err", fetchErr)
+		}
+	}
+	var enforcedFailures, auditFailures, verified []string
+	for _, policy := range policies {
+		policyErr := fetchErr
+		if policyErr == nil {
+			policyErr = policy.Verify(artifacts, digest)
+		}
+		switch {
+		case policyErr == nil:
+			verified = append(verified, policy.Name)
+		case policy.Enforced:
+			enforcedFailures = append(enforcedFailures, fmt.Sprintf("%s: %s", policy.Name, policyErr.Error()))
+		default:
+			auditFailures = append(auditFailures, fmt.Sprintf("%s: %s", policy.Name, policyErr.Error()))
+		}
+	}
+	if len(enforcedFailures) != 0 {
+		return &bean.VerificationResult{
+			Status:  bean.VerificationStatusFailed,
+			Message: fmt.Sprintf("image signature verification failed for policy %s", strings.Join(append(enforcedFailures, auditFailures...), "; ")),
+			Blocked: true,
+		}, nil
+	}
+	if len(auditFailures) != 0 {
+		return &bean.VerificationResult{
+			Status:  bean.VerificationStatusAuditFailed,
+			Message: fmt.Sprintf("image signature verification failed for audited policy %s", strings.Join(auditFailures, "; ")),
+		}, nil
+	}
+	return &bean.VerificationResult{
+		Status:  bean.VerificationStatusVerified,
+		Message: fmt.Sprintf("image signature verified by policy %s", strings.Join(verified, ", ")),
+	}, nil
+}
+
+// getPolicies returns the policies of the environment prepared for verification, identities deleted since
+// are left out so that a policy without any trusted identity fails
+func (impl *ImageSignatureServiceImpl) getPolicies(environmentId int) ([]*verifier.Policy, error) {
+	policies, err := impl.signaturePolicyRepository.FindActiveByEnvironmentId(environmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching signature policies of environment", "environmentId", environmentId, "err", err)
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	var identityIds []int
+	for _, policy := range policies {
+		identityIds = append(identityIds, policy.TrustedIdentityIds...)
+	}
+	identities, err := impl.trustedIdentityRepository.FindByIds(sliceUtil.GetUniqueElements(identityIds))
+	if err != nil {
+		impl.logger.Errorw("error in fetching trusted identities", "ids", identityIds, "err", err)
+		return nil, err
+	}
+	trustedIdentities := make(map[int]*verifier.TrustedIdentity, len(identities))
+	for _, identity := range identities {
+		trustedIdentity, err := verifier.NewTrustedIdentity(adapter.GetTrustedIdentityDto(identity))
+		if err != nil {
+			impl.logger.Errorw("skipping invalid trusted identity", "id", identity.Id, "err", err)
+			continue
+		}
+		trustedIdentities[identity.Id] = trustedIdentity
+	}
+	verifierPolicies := make([]*verifier.Policy, 0, len(policies))
+	for _, policy := range policies {
+		verifierPolicy := &verifier.Policy{
+			Name:                 policy.Name,
+			Enforced:             policy.Mode == string(bean.PolicyModeEnforce),
+			RequiredAttestations: policy.RequiredAttestations,
+		}
+		for _, identityId := range policy.TrustedIdentityIds {
+			if trustedIdentity, ok := trustedIdentities[identityId]; ok {
+				verifierPolicy.Identities = append(verifierPolicy.Identities, trustedIdentity)
+			}
+		}
+		verifierPolicies = append(verifierPolicies, verifierPolicy)
+	}
+	return verifierPolicies, nil
+}
+
+func (impl *ImageSignatureServiceImpl) fetchCosignArtifacts(ctx context.Context, artifact *repository.CiArtifact, digest string) (*verifier.CosignArtifacts, error) {
+	store, err := impl.getArtifactStore(artifact)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(impl.config.RegistryRequestTimeoutSecs)*time.Second)
+	defer cancel()
+	remoteRepository, err := newRemoteRepository(ctx, artifact.Image, store)
+	if err != nil {
+		return nil, err
+	}
+	return verifier.FetchCosignArtifacts(ctx, remoteRepository, digest, impl.config.MaxSignatureLayers)
+}
+
+// getArtifactStore returns the registry the artifact is pushed to, nil is returned for images of external ci
+// whose registry is not known and which are then read anonymously
+func (impl *ImageSignatureServiceImpl) getArtifactStore(artifact *repository.CiArtifact) (*dockerRegistry.DockerArtifactStore, error) {
+	registryId := ""
+	if artifact.IsRegistryCredentialMapped() {
+		registryId = artifact.CredentialSourceValue
+	} else if artifact.PipelineId > 0 {
+		dockerRegistryId, err := impl.ciPipelineConfigReadService.GetDockerRegistryIdForCiPipeline(artifact.PipelineId, artifact)
+		if err != nil && err != pg.ErrNoRows {
+			return nil, err
+		}
+		if dockerRegistryId != nil {
+			registryId = *dockerRegistryId
+		}
+	}
+	if len(registryId) == 0 {
+		return nil, nil
+	}
+	store, err := impl.dockerArtifactStoreRepository.FindOne(registryId)
+	if err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching docker registry", "registryId", registryId, "err", err)
+		return nil, err
+	}
+	return store, nil
+}
+
+func (impl *ImageSignatureServiceImpl) RecordRunnerVerificationResult(wfrId int, result *bean.VerificationResult) error {
+	err := impl.verificationResultRepository.UpdateRunnerResult(wfrId, result.Status.String(), result.Message)
+	if err != nil {
+		impl.logger.Errorw("error in recording signature verification result", "wfrId", wfrId, "err", err)
+	}
+	return err
+}
+
+func (impl *ImageSignatureServiceImpl) GetArtifactVerifications(pipelineId int, artifactIds []int) (map[int]*bean.ArtifactSignatureVerification, error) {
+	verifications, err := impl.verificationResultRepository.FindLatestByPipelineIdAndArtifactIds(pipelineId, artifactIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching signature verifications of artifacts", "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	verificationByArtifactId := make(map[int]*bean.ArtifactSignatureVerification, len(verifications))
+	for _, verification := range verifications {
+		verificationByArtifactId[verification.CiArtifactId] = adapter.GetArtifactSignatureVerification(verification)
+	}
+	return verificationByArtifactId, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetTrustedIdentityDbObj(dto *bean.TrustedIdentityDto) *repository.TrustedIdentity {
+	return &repository.TrustedIdentity{
+		Id:               dto.Id,
+		Name:             dto.Name,
+		IdentityType:     string(dto.Type),
+		PublicKey:        dto.PublicKey,
+		Subject:          dto.Subject,
+		SubjectRegex:     dto.SubjectRegex,
+		Issuer:           dto.Issuer,
+		RootCertificates: dto.RootCertificates,
+		RekorPublicKey:   dto.RekorPublicKey,
+		Active:           true,
+		AuditLog:         sql.NewDefaultAuditLog(dto.UserId),
+	}
+}
+
+func GetTrustedIdentityDto(identity *repository.TrustedIdentity) *bean.TrustedIdentityDto {
+	return &bean.TrustedIdentityDto{
+		Id:               identity.Id,
+		Name:             identity.Name,
+		Type:             bean.TrustedIdentityType(identity.IdentityType),
+		PublicKey:        identity.PublicKey,
+		Subject:          identity.Subject,
+		SubjectRegex:     identity.SubjectRegex,
+		Issuer:           identity.Issuer,
+		RootCertificates: identity.RootCertificates,
+		RekorPublicKey:   identity.RekorPublicKey,
+	}
+}
+
+func GetSignaturePolicyDbObj(dto *bean.SignaturePolicyDto) *repository.SignaturePolicy {
+	return &repository.SignaturePolicy{
+		Id:                   dto.Id,
+		Name:                 dto.Name,
+		Mode:                 string(dto.Mode),
+		TrustedIdentityIds:   dto.TrustedIdentityIds,
+		RequiredAttestations: dto.RequiredAttestations,
+		Active:               true,
+		AuditLog:             sql.NewDefaultAuditLog(dto.UserId),
+	}
+}
+
+func GetSignaturePolicyScopeDbObjs(policyId int, dto *bean.SignaturePolicyDto) []*repository.SignaturePolicyScope {
+	scopes := make([]*repository.SignaturePolicyScope, 0, len(dto.Scopes))
+	for _, scope := range dto.Scopes {
+		scopes = append(scopes, &repository.SignaturePolicyScope{
+			PolicyId:  policyId,
+			ScopeType: string(scope.Type),
+			ScopeId:   scope.Id,
+			AuditLog:  sql.NewDefaultAuditLog(dto.UserId),
+		})
+	}
+	return scopes
+}
+
+func GetSignaturePolicyDto(policy *repository.SignaturePolicy, scopes []*repository.SignaturePolicyScope) *bean.SignaturePolicyDto {
+	dto := &bean.SignaturePolicyDto{
+		Id:                   policy.Id,
+		Name:                 policy.Name,
+		Mode:                 bean.PolicyMode(policy.Mode),
+		TrustedIdentityIds:   policy.TrustedIdentityIds,
+		RequiredAttestations: policy.RequiredAttestations,
+		Scopes:               make([]*bean.PolicyScopeDto, 0, len(scopes)),
+	}
+	for _, scope := range scopes {
+		dto.Scopes = append(dto.Scopes, &bean.PolicyScopeDto{Type: bean.ScopeType(scope.ScopeType), Id: scope.ScopeId})
+	}
+	return dto
+}
+
+func GetArtifactSignatureVerification(verification *repository.ArtifactVerification) *bean.ArtifactSignatureVerification {
+	return &bean.ArtifactSignatureVerification{
+		Status:     bean.VerificationStatus(verification.SignatureVerificationStatus),
+		Message:    verification.SignatureVerificationMessage,
+		WfrId:      verification.WfrId,
+		VerifiedOn: verification.StartedOn,
+	}
+}
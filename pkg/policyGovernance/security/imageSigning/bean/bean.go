@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+// CATEGORY=IMAGE_SIGNING
+type ImageSigningConfig struct {
+	RegistryRequestTimeoutSecs int `env:"IMAGE_SIGNATURE_REGISTRY_TIMEOUT_SECS" envDefault:"30" description:"Timeout in seconds for fetching the cosign signatures and attestations of an image from its registry"`
+	MaxSignatureLayers         int `env:"IMAGE_SIGNATURE_MAX_LAYERS" envDefault:"20" description:"Max signatures or attestations of an image read from the registry during verification"`
+}
+
+type TrustedIdentityType string
+
+const (
+	// TrustedIdentityTypePublicKey trusts signatures made with the private key of a pem encoded public key
+	TrustedIdentityTypePublicKey TrustedIdentityType = "PUBLIC_KEY"
+	// TrustedIdentityTypeKeyless trusts signatures made with short-lived certificates issued to an oidc identity
+	TrustedIdentityTypeKeyless TrustedIdentityType = "KEYLESS"
+)
+
+type PolicyMode string
+
+const (
+	// PolicyModeEnforce blocks the deployment of artifacts which fail the verification
+	PolicyModeEnforce PolicyMode = "ENFORCE"
+	// PolicyModeAudit records the verification result without blocking the deployment
+	PolicyModeAudit PolicyMode = "AUDIT"
+)
+
+type ScopeType string
+
+const (
+	ScopeTypeEnvironment ScopeType = "ENVIRONMENT"
+	ScopeTypeCluster     ScopeType = "CLUSTER"
+)
+
+type VerificationStatus string
+
+const (
+	VerificationStatusVerified VerificationStatus = "VERIFIED"
+	// VerificationStatusFailed is recorded when an enforced policy blocked the deployment
+	VerificationStatusFailed VerificationStatus = "FAILED"
+	// VerificationStatusAuditFailed is recorded when only audit policies failed and the deployment went ahead
+	VerificationStatusAuditFailed VerificationStatus = "AUDIT_FAILED"
+)
+
+func (s VerificationStatus) String() string {
+	return string(s)
+}
+
+const (
+	// SignatureTagSuffix is the suffix of the tag cosign attaches the signatures of a digest to
+	SignatureTagSuffix = ".sig"
+	// AttestationTagSuffix is the suffix of the tag cosign attaches the attestations of a digest to
+	AttestationTagSuffix = ".att"
+
+	SignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	CertificateAnnotation = "dev.sigstore.cosign/certificate"
+	ChainAnnotation       = "dev.sigstore.cosign/chain"
+	BundleAnnotation      = "dev.sigstore.cosign/bundle"
+
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	DsseEnvelopeMediaType  = "application/vnd.dsse.envelope.v1+json"
+	InTotoPayloadType      = "application/vnd.in-toto+json"
+)
+
+type TrustedIdentityDto struct {
+	Id               int                 `json:"id"`
+	Name             string              `json:"name" validate:"required,max=250"`
+	Type             TrustedIdentityType `json:"type" validate:"required,oneof=PUBLIC_KEY KEYLESS"`
+	PublicKey        string              `json:"publicKey,omitempty"`
+	Subject          string              `json:"subject,omitempty"`
+	SubjectRegex     string              `json:"subjectRegex,omitempty"`
+	Issuer           string              `json:"issuer,omitempty"`
+	RootCertificates string              `json:"rootCertificates,omitempty"`
+	RekorPublicKey   string              `json:"rekorPublicKey,omitempty"`
+	UserId           int32               `json:"-"`
+}
+
+type PolicyScopeDto struct {
+	Type ScopeType `json:"type" validate:"required,oneof=ENVIRONMENT CLUSTER"`
+	Id   int       `json:"id" validate:"required,min=1"`
+}
+
+type SignaturePolicyDto struct {
+	Id                   int               `json:"id"`
+	Name                 string            `json:"name" validate:"required,max=250"`
+	Mode                 PolicyMode        `json:"mode" validate:"required,oneof=ENFORCE AUDIT"`
+	TrustedIdentityIds   []int             `json:"trustedIdentityIds" validate:"required,min=1"`
+	RequiredAttestations []string          `json:"requiredAttestations"`
+	Scopes               []*PolicyScopeDto `json:"scopes" validate:"required,min=1,dive"`
+	UserId               int32             `json:"-"`
+}
+
+// VerificationResult is the outcome of the verification of an artifact against the policies of an environment
+type VerificationResult struct {
+	Status  VerificationStatus `json:"status"`
+	Message string             `json:"message"`
+	// Blocked is set when an enforced policy failed and the deployment must not go ahead
+	Blocked bool `json:"-"`
+}
+
+// ArtifactSignatureVerification is the latest verification result of an artifact deployed on a pipeline
+type ArtifactSignatureVerification struct {
+	Status     VerificationStatus `json:"status"`
+	Message    string             `json:"message"`
+	WfrId      int                `json:"wfrId"`
+	VerifiedOn time.Time          `json:"verifiedOn"`
+}
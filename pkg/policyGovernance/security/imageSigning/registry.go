@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imageSigning
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	dockerRegistry "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+	"github.com/devtron-labs/devtron/util"
+	"github.com/devtron-labs/devtron/util/registryUtil"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+const (
+	dockerHubRegistry = "docker.io"
+	dockerHubApiHost  = "registry-1.docker.io"
+)
+
+// newRemoteRepository returns the repository of the image, authenticated with the credentials of the store
+// when the image is pushed to it, images of other registries are read anonymously
+func newRemoteRepository(ctx context.Context, image string, store *dockerRegistry.DockerArtifactStore) (*remote.Repository, error) {
+	imageMetadata, err := util.ExtractImageRepoAndTag(image)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := remote.NewRepository(imageMetadata.Repo)
+	if err != nil {
+		return nil, err
+	}
+	client := &auth.Client{
+		Client: auth.DefaultClient.Client,
+		Cache:  auth.NewCache(),
+	}
+	repository.Client = client
+	isStoreRegistry := store != nil && registryUtil.GetRegistryHost(store.RegistryURL) == repository.Reference.Registry
+	if repository.Reference.Registry == dockerHubRegistry {
+		// docker hub serves the registry api on another host than the one in image references
+		repository.Reference.Registry = dockerHubApiHost
+	}
+	if !isStoreRegistry {
+		return repository, nil
+	}
+	repository.PlainHTTP = strings.HasPrefix(store.RegistryURL, "http://")
+	credential := auth.Credential{
+		Username: store.Username,
+		Password: store.Password.String(),
+	}
+	if store.RegistryType == dockerRegistry.REGISTRYTYPE_ECR {
+		credential, err = getEcrCredential(ctx, store)
+		if err != nil {
+			return nil, err
+		}
+	}
+	client.Credential = auth.StaticCredential(repository.Reference.Registry, credential)
+	return repository, nil
+}
+
+func getEcrCredential(ctx context.Context, store *dockerRegistry.DockerArtifactStore) (auth.Credential, error) {
+	sess, err := registryUtil.NewEcrSession(store)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	output, err := ecr.New(sess).GetAuthorizationTokenWithContext(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if len(output.AuthorizationData) == 0 {
+		return auth.EmptyCredential, errors.New("no ecr authorization token returned")
+	}
+	token, err := base64.StdEncoding.DecodeString(aws.StringValue(output.AuthorizationData[0].AuthorizationToken))
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	username, password, found := strings.Cut(string(token), ":")
+	if !found {
+		return auth.EmptyCredential, errors.New("invalid ecr authorization token")
+	}
+	return auth.Credential{Username: username, Password: password}, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type SignaturePolicy struct {
+	tableName            struct{} `sql:"image_signature_policy" pg:",discard_unknown_columns"`
+	Id                   int      `sql:"id,pk"`
+	Name                 string   `sql:"name,notnull"`
+	Mode                 string   `sql:"mode,notnull"`
+	TrustedIdentityIds   []int    `sql:"trusted_identity_ids" pg:",array,notnull"`
+	RequiredAttestations []string `sql:"required_attestations" pg:",array"`
+	Active               bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type SignaturePolicyScope struct {
+	tableName struct{} `sql:"image_signature_policy_scope" pg:",discard_unknown_columns"`
+	Id        int      `sql:"id,pk"`
+	PolicyId  int      `sql:"policy_id,notnull"`
+	ScopeType string   `sql:"scope_type,notnull"`
+	ScopeId   int      `sql:"scope_id,notnull"`
+	sql.AuditLog
+}
+
+type SignaturePolicyRepository interface {
+	sql.TransactionWrapper
+	SaveWithTx(tx *pg.Tx, policy *SignaturePolicy) error
+	UpdateWithTx(tx *pg.Tx, policy *SignaturePolicy) error
+	FindById(id int) (*SignaturePolicy, error)
+	FindAllActive() ([]*SignaturePolicy, error)
+	ExistsByName(name string, excludeId int) (bool, error)
+	// FindActiveByEnvironmentId returns the policies scoped to the environment or to its cluster
+	FindActiveByEnvironmentId(environmentId int) ([]*SignaturePolicy, error)
+	FindActiveByTrustedIdentityId(trustedIdentityId int) ([]*SignaturePolicy, error)
+	ReplaceScopesWithTx(tx *pg.Tx, policyId int, scopes []*SignaturePolicyScope) error
+	FindScopesByPolicyIds(policyIds []int) ([]*SignaturePolicyScope, error)
+}
+
+type SignaturePolicyRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewSignaturePolicyRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	transactionUtilImpl *sql.TransactionUtilImpl) *SignaturePolicyRepositoryImpl {
+	return &SignaturePolicyRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *SignaturePolicyRepositoryImpl) SaveWithTx(tx *pg.Tx, policy *SignaturePolicy) error {
+	return tx.Insert(policy)
+}
+
+func (impl *SignaturePolicyRepositoryImpl) UpdateWithTx(tx *pg.Tx, policy *SignaturePolicy) error {
+	return tx.Update(policy)
+}
+
+func (impl *SignaturePolicyRepositoryImpl) FindById(id int) (*SignaturePolicy, error) {
+	policy := &SignaturePolicy{}
+	err := impl.dbConnection.Model(policy).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return policy, err
+}
+
+func (impl *SignaturePolicyRepositoryImpl) FindAllActive() ([]*SignaturePolicy, error) {
+	var policies []*SignaturePolicy
+	err := impl.dbConnection.Model(&policies).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return policies, err
+}
+
+func (impl *SignaturePolicyRepositoryImpl) ExistsByName(name string, excludeId int) (bool, error) {
+	return impl.dbConnection.Model((*SignaturePolicy)(nil)).
+		Where("name = ?", name).
+		Where("id != ?", excludeId).
+		Where("active = ?", true).
+		Exists()
+}
+
+func (impl *SignaturePolicyRepositoryImpl) FindActiveByEnvironmentId(environmentId int) ([]*SignaturePolicy, error) {
+	var policies []*SignaturePolicy
+	query := `SELECT p.* FROM image_signature_policy p
+		WHERE p.active = true AND EXISTS (
+			SELECT 1 FROM image_signature_policy_scope s
+			WHERE s.policy_id = p.id AND (
+				(s.scope_type = ? AND s.scope_id = ?) OR
+				(s.scope_type = ? AND s.scope_id = (SELECT e.cluster_id FROM environment e WHERE e.id = ?))
+			)
+		)
+		ORDER BY p.id ASC;`
+	_, err := impl.dbConnection.Query(&policies, query, bean.ScopeTypeEnvironment, environmentId, bean.ScopeTypeCluster, environmentId)
+	return policies, err
+}
+
+func (impl *SignaturePolicyRepositoryImpl) FindActiveByTrustedIdentityId(trustedIdentityId int) ([]*SignaturePolicy, error) {
+	var policies []*SignaturePolicy
+	err := impl.dbConnection.Model(&policies).
+		Where("? = ANY(trusted_identity_ids)", trustedIdentityId).
+		Where("active = ?", true).
+		Select()
+	return policies, err
+}
+
+func (impl *SignaturePolicyRepositoryImpl) ReplaceScopesWithTx(tx *pg.Tx, policyId int, scopes []*SignaturePolicyScope) error {
+	_, err := tx.Model((*SignaturePolicyScope)(nil)).
+		Where("policy_id = ?", policyId).
+		Delete()
+	if err != nil || len(scopes) == 0 {
+		return err
+	}
+	return tx.Insert(&scopes)
+}
+
+func (impl *SignaturePolicyRepositoryImpl) FindScopesByPolicyIds(policyIds []int) ([]*SignaturePolicyScope, error) {
+	var scopes []*SignaturePolicyScope
+	if len(policyIds) == 0 {
+		return scopes, nil
+	}
+	err := impl.dbConnection.Model(&scopes).
+		Where("policy_id IN (?)", pg.In(policyIds)).
+		Order("id ASC").
+		Select()
+	return scopes, err
+}
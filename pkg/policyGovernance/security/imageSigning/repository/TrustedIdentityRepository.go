@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type TrustedIdentity struct {
+	tableName        struct{} `sql:"image_signing_trusted_identity" pg:",discard_unknown_columns"`
+	Id               int      `sql:"id,pk"`
+	Name             string   `sql:"name,notnull"`
+	IdentityType     string   `sql:"identity_type,notnull"`
+	PublicKey        string   `sql:"public_key"`
+	Subject          string   `sql:"subject"`
+	SubjectRegex     string   `sql:"subject_regex"`
+	Issuer           string   `sql:"issuer"`
+	RootCertificates string   `sql:"root_certificates"`
+	RekorPublicKey   string   `sql:"rekor_public_key"`
+	Active           bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type TrustedIdentityRepository interface {
+	Save(identity *TrustedIdentity) error
+	Update(identity *TrustedIdentity) error
+	FindById(id int) (*TrustedIdentity, error)
+	FindByIds(ids []int) ([]*TrustedIdentity, error)
+	FindAllActive() ([]*TrustedIdentity, error)
+	ExistsByName(name string, excludeId int) (bool, error)
+}
+
+type TrustedIdentityRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewTrustedIdentityRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *TrustedIdentityRepositoryImpl {
+	return &TrustedIdentityRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *TrustedIdentityRepositoryImpl) Save(identity *TrustedIdentity) error {
+	return impl.dbConnection.Insert(identity)
+}
+
+func (impl *TrustedIdentityRepositoryImpl) Update(identity *TrustedIdentity) error {
+	return impl.dbConnection.Update(identity)
+}
+
+func (impl *TrustedIdentityRepositoryImpl) FindById(id int) (*TrustedIdentity, error) {
+	identity := &TrustedIdentity{}
+	err := impl.dbConnection.Model(identity).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return identity, err
+}
+
+func (impl *TrustedIdentityRepositoryImpl) FindByIds(ids []int) ([]*TrustedIdentity, error) {
+	var identities []*TrustedIdentity
+	if len(ids) == 0 {
+		return identities, nil
+	}
+	err := impl.dbConnection.Model(&identities).
+		Where("id IN (?)", pg.In(ids)).
+		Where("active = ?", true).
+		Select()
+	return identities, err
+}
+
+func (impl *TrustedIdentityRepositoryImpl) FindAllActive() ([]*TrustedIdentity, error) {
+	var identities []*TrustedIdentity
+	err := impl.dbConnection.Model(&identities).
+		Where("active = ?", true).
+		Order("id ASC").
+		Select()
+	return identities, err
+}
+
+func (impl *TrustedIdentityRepositoryImpl) ExistsByName(name string, excludeId int) (bool, error) {
+	return impl.dbConnection.Model((*TrustedIdentity)(nil)).
+		Where("name = ?", name).
+		Where("id != ?", excludeId).
+		Where("active = ?", true).
+		Exists()
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+// ArtifactVerification is the verification result recorded on the latest deployment runner of an artifact
+type ArtifactVerification struct {
+	CiArtifactId                 int       `sql:"ci_artifact_id"`
+	WfrId                        int       `sql:"wfr_id"`
+	SignatureVerificationStatus  string    `sql:"signature_verification_status"`
+	SignatureVerificationMessage string    `sql:"signature_verification_message"`
+	StartedOn                    time.Time `sql:"started_on"`
+}
+
+// VerificationResultRepository records the signature verification results on the cd workflow runners
+type VerificationResultRepository interface {
+	UpdateRunnerResult(wfrId int, status, message string) error
+	FindLatestByPipelineIdAndArtifactIds(pipelineId int, artifactIds []int) ([]*ArtifactVerification, error)
+}
+
+type VerificationResultRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewVerificationResultRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *VerificationResultRepositoryImpl {
+	return &VerificationResultRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *VerificationResultRepositoryImpl) UpdateRunnerResult(wfrId int, status, message string) error {
+	query := `UPDATE cd_workflow_runner SET signature_verification_status = ?, signature_verification_message = ? WHERE id = ?;`
+	_, err := impl.dbConnection.Exec(query, status, message, wfrId)
+	return err
+}
+
+func (impl *VerificationResultRepositoryImpl) FindLatestByPipelineIdAndArtifactIds(pipelineId int, artifactIds []int) ([]*ArtifactVerification, error) {
+	var verifications []*ArtifactVerification
+	if len(artifactIds) == 0 {
+		return verifications, nil
+	}
+	query := `SELECT DISTINCT ON (cw.ci_artifact_id) cw.ci_artifact_id, cwr.id AS wfr_id,
+			cwr.signature_verification_status, cwr.signature_verification_message, cwr.started_on
+		FROM cd_workflow_runner cwr
+		INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id
+		WHERE cw.pipeline_id = ? AND cw.ci_artifact_id IN (?)
+			AND cwr.signature_verification_status IS NOT NULL
+		ORDER BY cw.ci_artifact_id, cwr.id DESC;`
+	_, err := impl.dbConnection.Query(&verifications, query, pipelineId, pg.In(artifactIds))
+	return verifications, err
+}
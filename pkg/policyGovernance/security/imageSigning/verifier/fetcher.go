@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// maxLayerSizeBytes caps the size of a signature or an attestation, attestations with large predicates
+// like an sbom are still read
+const maxLayerSizeBytes = 32 << 20
+
+// FetchCosignArtifacts fetches the signatures and attestations cosign attached to the digest in the repository,
+// the tag based layout of cosign is read where the manifest is tagged by the digest and a suffix
+func FetchCosignArtifacts(ctx context.Context, repository *remote.Repository, digest string, maxLayers int) (*CosignArtifacts, error) {
+	signatures, err := fetchLayers(ctx, repository, digest, bean.SignatureTagSuffix, maxLayers)
+	if err != nil {
+		return nil, fmt.Errorf("error in fetching signatures: %w", err)
+	}
+	attestations, err := fetchLayers(ctx, repository, digest, bean.AttestationTagSuffix, maxLayers)
+	if err != nil {
+		return nil, fmt.Errorf("error in fetching attestations: %w", err)
+	}
+	return &CosignArtifacts{Signatures: signatures, Attestations: attestations}, nil
+}
+
+func fetchLayers(ctx context.Context, repository *remote.Repository, digest, tagSuffix string, maxLayers int) ([]*Layer, error) {
+	tag := strings.Replace(digest, ":", "-", 1) + tagSuffix
+	descriptor, reader, err := repository.FetchReference(ctx, tag)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	manifestBytes, err := content.ReadAll(reader, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ocispec.Manifest{}
+	if err = json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", tag, err)
+	}
+	layers := make([]*Layer, 0, len(manifest.Layers))
+	for _, layerDescriptor := range manifest.Layers {
+		if len(layers) >= maxLayers {
+			break
+		}
+		if layerDescriptor.Size > maxLayerSizeBytes {
+			continue
+		}
+		// the digest and the size of the fetched content are verified against the descriptor
+		layerContent, err := content.FetchAll(ctx, repository, layerDescriptor)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, &Layer{
+			MediaType:   layerDescriptor.MediaType,
+			Annotations: layerDescriptor.Annotations,
+			Content:     layerContent,
+		})
+	}
+	return layers, nil
+}
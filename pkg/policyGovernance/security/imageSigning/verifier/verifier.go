@@ -0,0 +1,561 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verifier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+)
+
+var (
+	// oidcIssuerOid is the deprecated fulcio extension holding the issuer as raw bytes
+	oidcIssuerOid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidcIssuerV2Oid is the fulcio extension holding the issuer as a der encoded utf8 string
+	oidcIssuerV2Oid = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Layer is a signature or an attestation attached to an image by cosign
+type Layer struct {
+	MediaType   string
+	Annotations map[string]string
+	Content     []byte
+}
+
+// CosignArtifacts are the signatures and attestations attached to a digest
+type CosignArtifacts struct {
+	Signatures   []*Layer
+	Attestations []*Layer
+}
+
+// TrustedIdentity verifies that a signature was made by a trusted key or keyless identity
+type TrustedIdentity struct {
+	Name           string
+	publicKey      crypto.PublicKey
+	roots          *x509.CertPool
+	subject        string
+	subjectRegex   *regexp.Regexp
+	issuer         string
+	rekorPublicKey crypto.PublicKey
+}
+
+// NewTrustedIdentity validates the trusted identity config and prepares it for verification
+func NewTrustedIdentity(identity *bean.TrustedIdentityDto) (*TrustedIdentity, error) {
+	trustedIdentity := &TrustedIdentity{Name: identity.Name}
+	switch identity.Type {
+	case bean.TrustedIdentityTypePublicKey:
+		publicKey, err := ParsePublicKey(identity.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		trustedIdentity.publicKey = publicKey
+	case bean.TrustedIdentityTypeKeyless:
+		if len(identity.Subject) == 0 && len(identity.SubjectRegex) == 0 {
+			return nil, errors.New("subject or subject regex is required for keyless identities")
+		}
+		if len(identity.Issuer) == 0 {
+			return nil, errors.New("issuer is required for keyless identities")
+		}
+		roots, err := ParseCertificates(identity.RootCertificates)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root certificates: %w", err)
+		}
+		trustedIdentity.roots = x509.NewCertPool()
+		for _, root := range roots {
+			trustedIdentity.roots.AddCert(root)
+		}
+		// short-lived certificates can only be checked at the time the transparency log saw the signature,
+		// which can not be trusted without the log key
+		rekorPublicKey, err := ParsePublicKey(identity.RekorPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rekor public key: %w", err)
+		}
+		trustedIdentity.rekorPublicKey = rekorPublicKey
+		if len(identity.SubjectRegex) != 0 {
+			subjectRegex, err := regexp.Compile("^(?:" + identity.SubjectRegex + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid subject regex: %w", err)
+			}
+			trustedIdentity.subjectRegex = subjectRegex
+		}
+		trustedIdentity.subject = identity.Subject
+		trustedIdentity.issuer = identity.Issuer
+	default:
+		return nil, fmt.Errorf("unknown trusted identity type %s", identity.Type)
+	}
+	return trustedIdentity, nil
+}
+
+// signerKey returns the key the layer was signed with if the signer is this identity
+func (t *TrustedIdentity) signerKey(layer *Layer) (crypto.PublicKey, error) {
+	if t.publicKey != nil {
+		return t.publicKey, nil
+	}
+	certificatePem := layer.Annotations[bean.CertificateAnnotation]
+	if len(certificatePem) == 0 {
+		return nil, errors.New("signature has no certificate")
+	}
+	certificates, err := ParseCertificates(certificatePem)
+	if err != nil {
+		return nil, err
+	}
+	leaf := certificates[0]
+	integratedTime, err := t.verifyBundle(layer, leaf)
+	if err != nil {
+		return nil, err
+	}
+	intermediates := x509.NewCertPool()
+	if chainPem := layer.Annotations[bean.ChainAnnotation]; len(chainPem) != 0 {
+		chain, err := ParseCertificates(chainPem)
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range chain {
+			intermediates.AddCert(certificate)
+		}
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         t.roots,
+		Intermediates: intermediates,
+		CurrentTime:   integratedTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("untrusted certificate: %w", err)
+	}
+	if !t.matchesSubject(leaf) {
+		return nil, fmt.Errorf("certificate subject %s is not trusted", strings.Join(getSubjects(leaf), ", "))
+	}
+	if issuer := getIssuer(leaf); issuer != t.issuer {
+		return nil, fmt.Errorf("certificate issuer %q is not trusted", issuer)
+	}
+	return leaf.PublicKey, nil
+}
+
+func (t *TrustedIdentity) matchesSubject(certificate *x509.Certificate) bool {
+	for _, subject := range getSubjects(certificate) {
+		if len(t.subject) != 0 && subject == t.subject {
+			return true
+		}
+		if t.subjectRegex != nil && t.subjectRegex.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func getSubjects(certificate *x509.Certificate) []string {
+	subjects := make([]string, 0, len(certificate.EmailAddresses)+len(certificate.URIs))
+	subjects = append(subjects, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	return subjects
+}
+
+func getIssuer(certificate *x509.Certificate) string {
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(oidcIssuerV2Oid) {
+			var issuer string
+			if _, err := asn1.Unmarshal(extension.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(oidcIssuerOid) {
+			return string(extension.Value)
+		}
+	}
+	return ""
+}
+
+// rekorBundle is the transparency log entry cosign attaches to keyless signatures
+type rekorBundle struct {
+	SignedEntryTimestamp []byte             `json:"SignedEntryTimestamp"`
+	Payload              rekorBundlePayload `json:"Payload"`
+}
+
+// rekorBundlePayload fields are in the order of their keys, its json is the canonical form the log signs
+type rekorBundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogId          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+const (
+	rekorKindHashedRekord = "hashedrekord"
+	rekorKindInToto       = "intoto"
+	rekorKindDsse         = "dsse"
+)
+
+var errEntryNotOfSignature = errors.New("transparency log entry is not of the signature")
+
+// rekorEntryBody is the entry the log integrated, its spec depends on the kind of the entry
+type rekorEntryBody struct {
+	Kind string          `json:"kind"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+type rekorHash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+type hashedRekordSpec struct {
+	Data struct {
+		Hash rekorHash `json:"hash"`
+	} `json:"data"`
+	Signature struct {
+		Content   []byte `json:"content"`
+		PublicKey struct {
+			Content []byte `json:"content"`
+		} `json:"publicKey"`
+	} `json:"signature"`
+}
+
+// inTotoSpec covers both versions of the kind, v0.0.1 logs a single public key and the hash of the whole
+// envelope while v0.0.2 logs the signatures of the envelope with their public keys
+type inTotoSpec struct {
+	Content struct {
+		Hash        rekorHash `json:"hash"`
+		PayloadHash rekorHash `json:"payloadHash"`
+		Envelope    struct {
+			Signatures []struct {
+				Sig       []byte `json:"sig"`
+				PublicKey []byte `json:"publicKey"`
+			} `json:"signatures"`
+		} `json:"envelope"`
+	} `json:"content"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+type dsseSpec struct {
+	PayloadHash rekorHash `json:"payloadHash"`
+	Signatures  []struct {
+		Signature string `json:"signature"`
+		Verifier  []byte `json:"verifier"`
+	} `json:"signatures"`
+}
+
+// verifyBundle verifies the signed entry timestamp of the transparency log and returns the time
+// the log integrated the signature at, the certificate must have been valid at that time
+func (t *TrustedIdentity) verifyBundle(layer *Layer, leaf *x509.Certificate) (time.Time, error) {
+	bundleJson := layer.Annotations[bean.BundleAnnotation]
+	if len(bundleJson) == 0 {
+		return time.Time{}, errors.New("keyless signature has no transparency log bundle")
+	}
+	bundle := &rekorBundle{}
+	if err := json.Unmarshal([]byte(bundleJson), bundle); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log bundle: %w", err)
+	}
+	canonicalPayload, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err = verifyRawSignature(t.rekorPublicKey, canonicalPayload, bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry timestamp: %w", err)
+	}
+	bodyJson, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry body: %w", err)
+	}
+	if err = verifyEntryBody(bodyJson, layer, leaf); err != nil {
+		return time.Time{}, err
+	}
+	integratedTime := time.Unix(bundle.Payload.IntegratedTime, 0)
+	if integratedTime.Before(leaf.NotBefore) || integratedTime.After(leaf.NotAfter) {
+		return time.Time{}, errors.New("signature was not logged while the certificate was valid")
+	}
+	return integratedTime, nil
+}
+
+// verifyEntryBody checks that the log entry is of the signature or attestation of the layer and was made
+// with the key of the certificate, an entry of any other kind can not be bound to the layer
+func verifyEntryBody(bodyJson []byte, layer *Layer, leaf *x509.Certificate) error {
+	body := &rekorEntryBody{}
+	if err := json.Unmarshal(bodyJson, body); err != nil {
+		return fmt.Errorf("invalid transparency log entry body: %w", err)
+	}
+	switch body.Kind {
+	case rekorKindHashedRekord:
+		spec := &hashedRekordSpec{}
+		if err := json.Unmarshal(body.Spec, spec); err != nil {
+			return fmt.Errorf("invalid transparency log entry body: %w", err)
+		}
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[bean.SignatureAnnotation])
+		if err != nil || len(signature) == 0 || !bytes.Equal(signature, spec.Signature.Content) || !matchesHash(spec.Data.Hash, layer.Content) {
+			return errEntryNotOfSignature
+		}
+		return matchesLeaf(spec.Signature.PublicKey.Content, leaf)
+	case rekorKindInToto:
+		spec := &inTotoSpec{}
+		if err := json.Unmarshal(body.Spec, spec); err != nil {
+			return fmt.Errorf("invalid transparency log entry body: %w", err)
+		}
+		envelope := &dsseEnvelope{}
+		if err := json.Unmarshal(layer.Content, envelope); err != nil || !matchesHash(spec.Content.PayloadHash, envelope.Payload) {
+			return errEntryNotOfSignature
+		}
+		if len(spec.Content.Envelope.Signatures) == 0 {
+			if !matchesHash(spec.Content.Hash, layer.Content) {
+				return errEntryNotOfSignature
+			}
+			return matchesLeaf(spec.PublicKey, leaf)
+		}
+		for _, entrySignature := range spec.Content.Envelope.Signatures {
+			// cosign logs the base64 encoded signature as the content of the field
+			sig := entrySignature.Sig
+			if decoded, err := base64.StdEncoding.DecodeString(string(sig)); err == nil {
+				sig = decoded
+			}
+			if hasSignature(envelope, sig) {
+				return matchesLeaf(entrySignature.PublicKey, leaf)
+			}
+		}
+		return errEntryNotOfSignature
+	case rekorKindDsse:
+		spec := &dsseSpec{}
+		if err := json.Unmarshal(body.Spec, spec); err != nil {
+			return fmt.Errorf("invalid transparency log entry body: %w", err)
+		}
+		envelope := &dsseEnvelope{}
+		if err := json.Unmarshal(layer.Content, envelope); err != nil || !matchesHash(spec.PayloadHash, envelope.Payload) {
+			return errEntryNotOfSignature
+		}
+		for _, entrySignature := range spec.Signatures {
+			sig, err := base64.StdEncoding.DecodeString(entrySignature.Signature)
+			if err == nil && hasSignature(envelope, sig) {
+				return matchesLeaf(entrySignature.Verifier, leaf)
+			}
+		}
+		return errEntryNotOfSignature
+	default:
+		return fmt.Errorf("unsupported transparency log entry kind %q", body.Kind)
+	}
+}
+
+func matchesHash(hash rekorHash, content []byte) bool {
+	return hash.Algorithm == "sha256" && strings.EqualFold(hash.Value, fmt.Sprintf("%x", sha256.Sum256(content)))
+}
+
+func hasSignature(envelope *dsseEnvelope, sig []byte) bool {
+	for _, signature := range envelope.Signatures {
+		if len(sig) != 0 && bytes.Equal(signature.Sig, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLeaf checks that the pem encoded certificate or public key logged with the entry is of the leaf certificate
+func matchesLeaf(keyPem []byte, leaf *x509.Certificate) error {
+	if certificates, err := ParseCertificates(string(keyPem)); err == nil {
+		if !certificates[0].Equal(leaf) {
+			return errors.New("transparency log entry is of another certificate")
+		}
+		return nil
+	}
+	publicKey, err := ParsePublicKey(string(keyPem))
+	if err != nil {
+		return fmt.Errorf("invalid transparency log entry key: %w", err)
+	}
+	if key, ok := publicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !key.Equal(leaf.PublicKey) {
+		return errors.New("transparency log entry is of another key")
+	}
+	return nil
+}
+
+// Policy is a signature policy prepared for verification
+type Policy struct {
+	Name                 string
+	Enforced             bool
+	Identities           []*TrustedIdentity
+	RequiredAttestations []string
+}
+
+// Verify checks that the digest is signed by a trusted identity of the policy and that every required
+// attestation is attested by a trusted identity
+func (p *Policy) Verify(artifacts *CosignArtifacts, digest string) error {
+	err := VerifySignatures(artifacts.Signatures, digest, p.Identities)
+	if err != nil {
+		return err
+	}
+	if len(p.RequiredAttestations) == 0 {
+		return nil
+	}
+	return VerifyAttestations(artifacts.Attestations, digest, p.Identities, p.RequiredAttestations)
+}
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifySignatures returns nil if at least one cosign signature of the digest is made by a trusted identity
+func VerifySignatures(signatures []*Layer, digest string, identities []*TrustedIdentity) error {
+	if len(signatures) == 0 {
+		return fmt.Errorf("image %s is not signed", digest)
+	}
+	var reasons []string
+	for _, layer := range signatures {
+		if layer.MediaType != bean.SimpleSigningMediaType {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[bean.SignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			reasons = append(reasons, "signature is not base64 encoded")
+			continue
+		}
+		payload := &simpleSigningPayload{}
+		if err = json.Unmarshal(layer.Content, payload); err != nil {
+			reasons = append(reasons, "invalid signature payload")
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest != digest {
+			reasons = append(reasons, fmt.Sprintf("signature is of digest %s", payload.Critical.Image.DockerManifestDigest))
+			continue
+		}
+		for _, identity := range identities {
+			publicKey, err := identity.signerKey(layer)
+			if err == nil {
+				err = verifyRawSignature(publicKey, layer.Content, signature)
+			}
+			if err == nil {
+				return nil
+			}
+			reasons = append(reasons, fmt.Sprintf("%s: %s", identity.Name, err.Error()))
+		}
+	}
+	return fmt.Errorf("no signature of image %s is made by a trusted identity (%s)", digest, summarize(reasons))
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     []byte `json:"payload"`
+	Signatures  []struct {
+		KeyId string `json:"keyid"`
+		Sig   []byte `json:"sig"`
+	} `json:"signatures"`
+}
+
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// VerifyAttestations returns nil if every predicate type is attested for the digest by a trusted identity
+func VerifyAttestations(attestations []*Layer, digest string, identities []*TrustedIdentity, predicateTypes []string) error {
+	attested := make(map[string]bool)
+	var reasons []string
+	for _, layer := range attestations {
+		envelope := &dsseEnvelope{}
+		if err := json.Unmarshal(layer.Content, envelope); err != nil || envelope.PayloadType != bean.InTotoPayloadType {
+			continue
+		}
+		statement := &inTotoStatement{}
+		if err := json.Unmarshal(envelope.Payload, statement); err != nil || !isStatementSubject(statement, digest) {
+			continue
+		}
+		if err := verifyEnvelope(layer, envelope, identities); err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", statement.PredicateType, err.Error()))
+			continue
+		}
+		attested[statement.PredicateType] = true
+	}
+	var missing []string
+	for _, predicateType := range predicateTypes {
+		if !attested[predicateType] {
+			missing = append(missing, predicateType)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("image %s has no trusted attestation of %s (%s)", digest, strings.Join(missing, ", "), summarize(reasons))
+	}
+	return nil
+}
+
+func verifyEnvelope(layer *Layer, envelope *dsseEnvelope, identities []*TrustedIdentity) error {
+	message := preAuthEncoding(envelope.PayloadType, envelope.Payload)
+	var lastErr error = errors.New("attestation is not signed")
+	for _, identity := range identities {
+		publicKey, err := identity.signerKey(layer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		for _, signature := range envelope.Signatures {
+			if lastErr = verifyRawSignature(publicKey, message, signature.Sig); lastErr == nil {
+				return nil
+			}
+		}
+	}
+	return lastErr
+}
+
+// preAuthEncoding is the dsse message signed for an envelope
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return append([]byte(fmt.Sprintf("DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))), payload...)
+}
+
+func isStatementSubject(statement *inTotoStatement, digest string) bool {
+	algorithm, hexDigest, found := strings.Cut(digest, ":")
+	if !found {
+		return false
+	}
+	for _, subject := range statement.Subject {
+		if subject.Digest[algorithm] == hexDigest {
+			return true
+		}
+	}
+	return false
+}
+
+func summarize(reasons []string) string {
+	if len(reasons) == 0 {
+		return "no valid signature found"
+	}
+	unique := make(map[string]bool, len(reasons))
+	var summary []string
+	for _, reason := range reasons {
+		if !unique[reason] {
+			unique[reason] = true
+			summary = append(summary, reason)
+		}
+	}
+	sort.Strings(summary)
+	return strings.Join(summary, "; ")
+}
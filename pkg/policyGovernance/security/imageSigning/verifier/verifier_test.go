@@ -0,0 +1,487 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/bean"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+const testIssuer = "https://token.actions.githubusercontent.com"
+
+// testRegistry is a minimal read only oci distribution registry serving manifests by tag and blobs by digest
+type testRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	reference := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	var content []byte
+	var found bool
+	contentType := "application/octet-stream"
+	switch {
+	case strings.Contains(req.URL.Path, "/manifests/"):
+		content, found = r.manifests[reference]
+		contentType = ocispec.MediaTypeImageManifest
+	case strings.Contains(req.URL.Path, "/blobs/"):
+		content, found = r.blobs[reference]
+	}
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Docker-Content-Digest", digestOf(content))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	if req.Method != http.MethodHead {
+		_, _ = w.Write(content)
+	}
+}
+
+// attach pushes a cosign signature or attestation manifest for the image digest
+func (r *testRegistry) attach(t *testing.T, imageDigest, tagSuffix string, layers ...*Layer) {
+	manifest := ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest}
+	manifest.SchemaVersion = 2
+	config := []byte("{}")
+	r.blobs[digestOf(config)] = config
+	manifest.Config = ocispec.Descriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: digest.Digest(digestOf(config)), Size: int64(len(config))}
+	for _, layer := range layers {
+		r.blobs[digestOf(layer.Content)] = layer.Content
+		manifest.Layers = append(manifest.Layers, ocispec.Descriptor{
+			MediaType:   layer.MediaType,
+			Digest:      digest.Digest(digestOf(layer.Content)),
+			Size:        int64(len(layer.Content)),
+			Annotations: layer.Annotations,
+		})
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.manifests[strings.Replace(imageDigest, ":", "-", 1)+tagSuffix] = manifestBytes
+}
+
+func (r *testRegistry) fetch(t *testing.T, imageDigest string) *CosignArtifacts {
+	server := httptest.NewServer(r)
+	defer server.Close()
+	repository, err := remote.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/devtron/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository.PlainHTTP = true
+	artifacts, err := FetchCosignArtifacts(context.Background(), repository, imageDigest, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return artifacts
+}
+
+func digestOf(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+func sign(t *testing.T, signer crypto.Signer, message []byte) []byte {
+	var signature []byte
+	var err error
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		signature, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(message)
+		signature, err = signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+func publicKeyPem(t *testing.T, publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signatureLayer(t *testing.T, signer crypto.Signer, imageDigest string, annotations map[string]string) *Layer {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"registry.local/devtron/app"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, imageDigest))
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[bean.SignatureAnnotation] = base64.StdEncoding.EncodeToString(sign(t, signer, payload))
+	return &Layer{MediaType: bean.SimpleSigningMediaType, Annotations: annotations, Content: payload}
+}
+
+func attestationLayer(t *testing.T, signer crypto.Signer, imageDigest, predicateType string) *Layer {
+	statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":%q,"subject":[{"name":"registry.local/devtron/app","digest":{"sha256":%q}}],"predicate":{}}`,
+		predicateType, strings.TrimPrefix(imageDigest, "sha256:")))
+	envelope := map[string]interface{}{
+		"payloadType": bean.InTotoPayloadType,
+		"payload":     statement,
+		"signatures":  []map[string]interface{}{{"keyid": "", "sig": sign(t, signer, preAuthEncoding(bean.InTotoPayloadType, statement))}},
+	}
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Layer{MediaType: bean.DsseEnvelopeMediaType, Annotations: map[string]string{}, Content: envelopeBytes}
+}
+
+func newPublicKeyIdentity(t *testing.T, publicKey crypto.PublicKey) *TrustedIdentity {
+	identity, err := NewTrustedIdentity(&bean.TrustedIdentityDto{Name: "ci-key", Type: bean.TrustedIdentityTypePublicKey, PublicKey: publicKeyPem(t, publicKey)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func TestPublicKeySignature(t *testing.T) {
+	imageDigest := digestOf([]byte("image manifest"))
+	ciKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	registry := newTestRegistry()
+	registry.attach(t, imageDigest, bean.SignatureTagSuffix, signatureLayer(t, ciKey, imageDigest, nil))
+	artifacts := registry.fetch(t, imageDigest)
+
+	policy := &Policy{Name: "prod", Identities: []*TrustedIdentity{newPublicKeyIdentity(t, ciKey.Public())}}
+	if err := policy.Verify(artifacts, imageDigest); err != nil {
+		t.Fatalf("expected signature to be trusted: %v", err)
+	}
+	untrusted := &Policy{Name: "prod", Identities: []*TrustedIdentity{newPublicKeyIdentity(t, otherKey.Public())}}
+	if err := untrusted.Verify(artifacts, imageDigest); err == nil {
+		t.Fatal("expected signature of an untrusted key to fail")
+	}
+
+	// a signature copied to another digest must not verify it
+	otherDigest := digestOf([]byte("other manifest"))
+	registry.attach(t, otherDigest, bean.SignatureTagSuffix, signatureLayer(t, ciKey, imageDigest, nil))
+	if err := policy.Verify(registry.fetch(t, otherDigest), otherDigest); err == nil {
+		t.Fatal("expected signature of another digest to fail")
+	}
+
+	unsignedDigest := digestOf([]byte("unsigned manifest"))
+	err := policy.Verify(registry.fetch(t, unsignedDigest), unsignedDigest)
+	if err == nil || !strings.Contains(err.Error(), "is not signed") {
+		t.Fatalf("expected unsigned image to fail, got %v", err)
+	}
+}
+
+func TestRequiredAttestations(t *testing.T) {
+	imageDigest := digestOf([]byte("image manifest"))
+	_, ciKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	const slsa = "https://slsa.dev/provenance/v0.2"
+	const sbom = "https://cyclonedx.org/bom"
+
+	registry := newTestRegistry()
+	registry.attach(t, imageDigest, bean.SignatureTagSuffix, signatureLayer(t, ciKey, imageDigest, nil))
+	registry.attach(t, imageDigest, bean.AttestationTagSuffix,
+		attestationLayer(t, ciKey, imageDigest, slsa),
+		attestationLayer(t, otherKey, imageDigest, sbom))
+	artifacts := registry.fetch(t, imageDigest)
+
+	identities := []*TrustedIdentity{newPublicKeyIdentity(t, ciKey.Public())}
+	policy := &Policy{Name: "prod", Identities: identities, RequiredAttestations: []string{slsa}}
+	if err := policy.Verify(artifacts, imageDigest); err != nil {
+		t.Fatalf("expected attestation to be trusted: %v", err)
+	}
+	policy.RequiredAttestations = []string{slsa, sbom}
+	err := policy.Verify(artifacts, imageDigest)
+	if err == nil || !strings.Contains(err.Error(), sbom) {
+		t.Fatalf("expected attestation of an untrusted key to fail, got %v", err)
+	}
+}
+
+// keylessFixture is a local certificate authority and transparency log issuing keyless signatures
+type keylessFixture struct {
+	rootKey     *ecdsa.PrivateKey
+	root        *x509.Certificate
+	rootPem     string
+	rekorKey    *ecdsa.PrivateKey
+	rekorKeyPem string
+}
+
+func newKeylessFixture(t *testing.T) *keylessFixture {
+	rootKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, rootKey.Public(), rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ := x509.ParseCertificate(der)
+	rekorKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	return &keylessFixture{
+		rootKey:     rootKey,
+		root:        root,
+		rootPem:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		rekorKey:    rekorKey,
+		rekorKeyPem: publicKeyPem(t, rekorKey.Public()),
+	}
+}
+
+// certificate issues a short-lived certificate to the email
+func (f *keylessFixture) certificate(t *testing.T, email string) (*ecdsa.PrivateKey, string) {
+	signingKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	issuer, _ := asn1.Marshal(testIssuer)
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-5 * time.Minute),
+		NotAfter:        time.Now().Add(5 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{email},
+		ExtraExtensions: []pkix.Extension{{Id: oidcIssuerV2Oid, Value: issuer}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, f.root, signingKey.Public(), f.rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingKey, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// logEntry attaches the bundle of the entry body as logged by the transparency log to the layer
+func (f *keylessFixture) logEntry(t *testing.T, layer *Layer, body map[string]interface{}) {
+	bodyBytes, _ := json.Marshal(body)
+	payload := rekorBundlePayload{
+		Body:           base64.StdEncoding.EncodeToString(bodyBytes),
+		IntegratedTime: time.Now().Unix(),
+		LogId:          "test-log",
+		LogIndex:       1,
+	}
+	payloadBytes, _ := json.Marshal(payload)
+	bundle, _ := json.Marshal(rekorBundle{SignedEntryTimestamp: sign(t, f.rekorKey, payloadBytes), Payload: payload})
+	layer.Annotations[bean.BundleAnnotation] = string(bundle)
+}
+
+func hashOf(content []byte) map[string]interface{} {
+	return map[string]interface{}{"algorithm": "sha256", "value": fmt.Sprintf("%x", sha256.Sum256(content))}
+}
+
+// signKeyless signs the image with a short-lived certificate issued to the email and logs the signature
+func (f *keylessFixture) signKeyless(t *testing.T, imageDigest, email string) *Layer {
+	signingKey, certificatePem := f.certificate(t, email)
+	layer := signatureLayer(t, signingKey, imageDigest, map[string]string{bean.CertificateAnnotation: certificatePem})
+	signature, _ := base64.StdEncoding.DecodeString(layer.Annotations[bean.SignatureAnnotation])
+	f.logEntry(t, layer, hashedRekordBody(signature, layer.Content, []byte(certificatePem)))
+	return layer
+}
+
+func hashedRekordBody(signature, content, keyPem []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"kind": rekorKindHashedRekord,
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{"hash": hashOf(content)},
+			"signature": map[string]interface{}{
+				"content":   signature,
+				"publicKey": map[string]interface{}{"content": keyPem},
+			},
+		},
+	}
+}
+
+// attestKeyless attests the image with a short-lived certificate issued to the email and logs the envelope
+// as an entry of the kind
+func (f *keylessFixture) attestKeyless(t *testing.T, imageDigest, email, predicateType, kind string) *Layer {
+	signingKey, certificatePem := f.certificate(t, email)
+	layer := attestationLayer(t, signingKey, imageDigest, predicateType)
+	layer.Annotations[bean.CertificateAnnotation] = certificatePem
+	envelope := &dsseEnvelope{}
+	if err := json.Unmarshal(layer.Content, envelope); err != nil {
+		t.Fatal(err)
+	}
+	sig := envelope.Signatures[0].Sig
+	var spec map[string]interface{}
+	switch kind {
+	case rekorKindInToto:
+		spec = map[string]interface{}{"content": map[string]interface{}{
+			"hash":        hashOf(layer.Content),
+			"payloadHash": hashOf(envelope.Payload),
+			"envelope": map[string]interface{}{
+				"payloadType": envelope.PayloadType,
+				"signatures":  []map[string]interface{}{{"sig": []byte(base64.StdEncoding.EncodeToString(sig)), "publicKey": []byte(certificatePem)}},
+			},
+		}}
+	case rekorKindDsse:
+		spec = map[string]interface{}{
+			"envelopeHash": hashOf(layer.Content),
+			"payloadHash":  hashOf(envelope.Payload),
+			"signatures":   []map[string]interface{}{{"signature": base64.StdEncoding.EncodeToString(sig), "verifier": []byte(certificatePem)}},
+		}
+	}
+	f.logEntry(t, layer, map[string]interface{}{"kind": kind, "spec": spec})
+	return layer
+}
+
+func (f *keylessFixture) identity(t *testing.T, subject, subjectRegex string) *TrustedIdentity {
+	identity, err := NewTrustedIdentity(&bean.TrustedIdentityDto{
+		Name:             "github-actions",
+		Type:             bean.TrustedIdentityTypeKeyless,
+		Subject:          subject,
+		SubjectRegex:     subjectRegex,
+		Issuer:           testIssuer,
+		RootCertificates: f.rootPem,
+		RekorPublicKey:   f.rekorKeyPem,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func TestKeylessSignature(t *testing.T) {
+	imageDigest := digestOf([]byte("image manifest"))
+	fixture := newKeylessFixture(t)
+	registry := newTestRegistry()
+	registry.attach(t, imageDigest, bean.SignatureTagSuffix, fixture.signKeyless(t, imageDigest, "release@devtron.ai"))
+	artifacts := registry.fetch(t, imageDigest)
+
+	for name, identity := range map[string]*TrustedIdentity{
+		"subject":       fixture.identity(t, "release@devtron.ai", ""),
+		"subject regex": fixture.identity(t, "", ".*@devtron\\.ai"),
+	} {
+		if err := (&Policy{Identities: []*TrustedIdentity{identity}}).Verify(artifacts, imageDigest); err != nil {
+			t.Fatalf("expected keyless signature to be trusted by %s: %v", name, err)
+		}
+	}
+	err := (&Policy{Identities: []*TrustedIdentity{fixture.identity(t, "someone@devtron.ai", "")}}).Verify(artifacts, imageDigest)
+	if err == nil || !strings.Contains(err.Error(), "is not trusted") {
+		t.Fatalf("expected keyless signature of another subject to fail, got %v", err)
+	}
+	// certificates of another authority and entries signed by another log are not trusted
+	otherFixture := newKeylessFixture(t)
+	if err = (&Policy{Identities: []*TrustedIdentity{otherFixture.identity(t, "release@devtron.ai", "")}}).Verify(artifacts, imageDigest); err == nil {
+		t.Fatal("expected keyless signature of an untrusted authority to fail")
+	}
+}
+
+func TestKeylessTransparencyLogEntry(t *testing.T) {
+	imageDigest := digestOf([]byte("image manifest"))
+	fixture := newKeylessFixture(t)
+	identity := fixture.identity(t, "release@devtron.ai", "")
+	verify := func(layer *Layer) error {
+		return VerifySignatures([]*Layer{layer}, imageDigest, []*TrustedIdentity{identity})
+	}
+	if err := verify(fixture.signKeyless(t, imageDigest, "release@devtron.ai")); err != nil {
+		t.Fatalf("expected logged signature to be trusted: %v", err)
+	}
+
+	// entries which can not be bound to the signature and the certificate are not trusted
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, otherCertificatePem := fixture.certificate(t, "release@devtron.ai")
+	invalid := map[string]func(layer *Layer, signature []byte) map[string]interface{}{
+		"unknown kind": func(layer *Layer, signature []byte) map[string]interface{} {
+			return map[string]interface{}{"kind": "rekord", "spec": map[string]interface{}{}}
+		},
+		"unparseable spec": func(layer *Layer, signature []byte) map[string]interface{} {
+			return map[string]interface{}{"kind": rekorKindHashedRekord, "spec": "not a spec"}
+		},
+		"other signature": func(layer *Layer, signature []byte) map[string]interface{} {
+			return hashedRekordBody([]byte("other signature"), layer.Content, []byte(layer.Annotations[bean.CertificateAnnotation]))
+		},
+		"other content": func(layer *Layer, signature []byte) map[string]interface{} {
+			return hashedRekordBody(signature, []byte("other content"), []byte(layer.Annotations[bean.CertificateAnnotation]))
+		},
+		"other certificate": func(layer *Layer, signature []byte) map[string]interface{} {
+			return hashedRekordBody(signature, layer.Content, []byte(otherCertificatePem))
+		},
+		"other key": func(layer *Layer, signature []byte) map[string]interface{} {
+			return hashedRekordBody(signature, layer.Content, []byte(publicKeyPem(t, otherKey.Public())))
+		},
+	}
+	for name, body := range invalid {
+		layer := fixture.signKeyless(t, imageDigest, "release@devtron.ai")
+		signature, _ := base64.StdEncoding.DecodeString(layer.Annotations[bean.SignatureAnnotation])
+		fixture.logEntry(t, layer, body(layer, signature))
+		if err := verify(layer); err == nil {
+			t.Errorf("%s: expected signature to fail", name)
+		}
+	}
+}
+
+func TestKeylessAttestations(t *testing.T) {
+	imageDigest := digestOf([]byte("image manifest"))
+	const slsa = "https://slsa.dev/provenance/v0.2"
+	fixture := newKeylessFixture(t)
+	identities := []*TrustedIdentity{fixture.identity(t, "release@devtron.ai", "")}
+	for _, kind := range []string{rekorKindInToto, rekorKindDsse} {
+		layer := fixture.attestKeyless(t, imageDigest, "release@devtron.ai", slsa, kind)
+		if err := VerifyAttestations([]*Layer{layer}, imageDigest, identities, []string{slsa}); err != nil {
+			t.Fatalf("%s: expected logged attestation to be trusted: %v", kind, err)
+		}
+		// an entry of another attestation does not cover this one
+		other := fixture.attestKeyless(t, imageDigest, "release@devtron.ai", slsa, kind)
+		layer.Annotations[bean.BundleAnnotation] = other.Annotations[bean.BundleAnnotation]
+		if err := VerifyAttestations([]*Layer{layer}, imageDigest, identities, []string{slsa}); err == nil {
+			t.Fatalf("%s: expected attestation logged by another entry to fail", kind)
+		}
+	}
+}
+
+func TestNewTrustedIdentityValidation(t *testing.T) {
+	fixture := newKeylessFixture(t)
+	invalid := map[string]*bean.TrustedIdentityDto{
+		"invalid public key":  {Type: bean.TrustedIdentityTypePublicKey, PublicKey: "not a key"},
+		"keyless no subject":  {Type: bean.TrustedIdentityTypeKeyless, Issuer: testIssuer, RootCertificates: fixture.rootPem, RekorPublicKey: fixture.rekorKeyPem},
+		"keyless no issuer":   {Type: bean.TrustedIdentityTypeKeyless, Subject: "a@b.c", RootCertificates: fixture.rootPem, RekorPublicKey: fixture.rekorKeyPem},
+		"keyless no roots":    {Type: bean.TrustedIdentityTypeKeyless, Subject: "a@b.c", Issuer: testIssuer, RekorPublicKey: fixture.rekorKeyPem},
+		"keyless no rekor":    {Type: bean.TrustedIdentityTypeKeyless, Subject: "a@b.c", Issuer: testIssuer, RootCertificates: fixture.rootPem},
+		"keyless bad pattern": {Type: bean.TrustedIdentityTypeKeyless, SubjectRegex: "(", Issuer: testIssuer, RootCertificates: fixture.rootPem, RekorPublicKey: fixture.rekorKeyPem},
+	}
+	for name, identity := range invalid {
+		if _, err := NewTrustedIdentity(identity); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package imageSigning
+
+import (
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/repository"
+	"github.com/google/wire"
+)
+
+var ImageSigningWireSet = wire.NewSet(
+	repository.NewTrustedIdentityRepositoryImpl,
+	wire.Bind(new(repository.TrustedIdentityRepository), new(*repository.TrustedIdentityRepositoryImpl)),
+	repository.NewSignaturePolicyRepositoryImpl,
+	wire.Bind(new(repository.SignaturePolicyRepository), new(*repository.SignaturePolicyRepositoryImpl)),
+	repository.NewVerificationResultRepositoryImpl,
+	wire.Bind(new(repository.VerificationResultRepository), new(*repository.VerificationResultRepositoryImpl)),
+	NewImageSignatureServiceImpl,
+	wire.Bind(new(ImageSignatureService), new(*ImageSignatureServiceImpl)),
+)
@@ -0,0 +1,390 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/build/trigger"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/adapter"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/parser"
+	sbomRepository "github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/repository"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type SbomService interface {
+	// IngestCiStepSbom stores the sbom written by a ci step of the workflow which built the artifact,
+	// the uploaded ci artifacts are looked up for CycloneDX and SPDX json documents
+	IngestCiStepSbom(artifact *repository.CiArtifact) error
+	// SyncScannerSboms stores the sboms produced by the image scanner for the recently scanned artifacts without one
+	SyncScannerSboms()
+	// GetArtifactSbom returns the sbom of the artifact, packages are included only if asked for
+	GetArtifactSbom(ciArtifactId int, includePackages bool) (*bean.ArtifactSbomDto, error)
+	GetSbomDocument(ciArtifactId int) (*bean.SbomDocument, error)
+	// SearchDeployedPackages returns the artifacts currently deployed across environments which contain the package,
+	// out of the deployments kept by the filter if set. The filter is applied before the results are capped.
+	SearchDeployedPackages(request *bean.PackageSearchRequest, filter bean.DeploymentFilter) (*bean.PackageSearchResponse, error)
+	// GetAppIdByArtifactId returns the app of the ci pipeline which produced the artifact, used for rbac
+	GetAppIdByArtifactId(ciArtifactId int) (int, error)
+}
+
+type SbomServiceImpl struct {
+	logger                 *zap.SugaredLogger
+	config                 *bean.SbomConfig
+	artifactSbomRepository sbomRepository.ArtifactSbomRepository
+	ciArtifactRepository   repository.CiArtifactRepository
+	ciWorkflowRepository   pipelineConfig.CiWorkflowRepository
+	ciPipelineRepository   pipelineConfig.CiPipelineRepository
+	ciHandlerService       trigger.HandlerService
+}
+
+func NewSbomServiceImpl(logger *zap.SugaredLogger,
+	artifactSbomRepository sbomRepository.ArtifactSbomRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository,
+	ciHandlerService trigger.HandlerService) (*SbomServiceImpl, error) {
+	config := &bean.SbomConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing sbom config", "err", err)
+		return nil, err
+	}
+	return &SbomServiceImpl{
+		logger:                 logger,
+		config:                 config,
+		artifactSbomRepository: artifactSbomRepository,
+		ciArtifactRepository:   ciArtifactRepository,
+		ciWorkflowRepository:   ciWorkflowRepository,
+		ciPipelineRepository:   ciPipelineRepository,
+		ciHandlerService:       ciHandlerService,
+	}, nil
+}
+
+func (impl *SbomServiceImpl) IngestCiStepSbom(artifact *repository.CiArtifact) error {
+	// derived artifacts and artifacts of linked ci pipelines use the sbom of their parent
+	if artifact.WorkflowId == nil || artifact.ParentCiArtifact > 0 {
+		return nil
+	}
+	ciWorkflow, err := impl.ciWorkflowRepository.FindById(*artifact.WorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci workflow", "ciWorkflowId", *artifact.WorkflowId, "err", err)
+		return err
+	}
+	if isArtifactUploaded, _ := ciWorkflow.GetIsArtifactUploaded(); !ciWorkflow.BlobStorageEnabled || !isArtifactUploaded {
+		return nil
+	}
+	artifactFile, err := impl.ciHandlerService.DownloadCiWorkflowArtifacts(ciWorkflow.CiPipelineId, ciWorkflow.Id)
+	if err != nil {
+		impl.logger.Errorw("error in downloading ci workflow artifacts", "ciWorkflowId", ciWorkflow.Id, "err", err)
+		return err
+	}
+	defer func() {
+		artifactFile.Close()
+		os.Remove(artifactFile.Name())
+	}()
+	fileName, content, parsed, err := impl.findSbomInArtifactZip(artifactFile)
+	if err != nil {
+		impl.logger.Errorw("error in reading ci workflow artifacts", "ciWorkflowId", ciWorkflow.Id, "err", err)
+		return err
+	} else if parsed == nil {
+		return nil
+	}
+	sbom := adapter.GetArtifactSbomDbObj(artifact.Id, bean.SbomSourceCiStep, fileName, content, parsed, userBean.SystemUserId)
+	sbom.CiWorkflowId = ciWorkflow.Id
+	return impl.saveSbom(sbom, parsed)
+}
+
+// findSbomInArtifactZip returns the sbom listing the most packages among the json documents of the zip,
+// a workflow may produce an sbom per step or per layer and the image sbom is the most complete one
+func (impl *SbomServiceImpl) findSbomInArtifactZip(artifactFile *os.File) (string, []byte, *bean.ParsedSbom, error) {
+	fileInfo, err := artifactFile.Stat()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	zipReader, err := zip.NewReader(artifactFile, fileInfo.Size())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	entries := make([]*zip.File, 0)
+	for _, entry := range zipReader.File {
+		if entry.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(entry.Name), ".json") {
+			continue
+		}
+		if int64(entry.UncompressedSize64) > impl.config.MaxDocumentSizeBytes {
+			impl.logger.Warnw("skipping json file of ci artifacts, size exceeds sbom limit", "file", entry.Name, "size", entry.UncompressedSize64)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	var selectedName string
+	var selectedContent []byte
+	var selected *bean.ParsedSbom
+	sbomFiles := 0
+	for _, entry := range entries {
+		if sbomFiles >= bean.MaxSbomFilesPerWorkflow {
+			break
+		}
+		content, err := readZipEntry(entry, impl.config.MaxDocumentSizeBytes)
+		if err != nil {
+			impl.logger.Warnw("error in reading json file of ci artifacts", "file", entry.Name, "err", err)
+			continue
+		}
+		if _, ok := parser.DetectFormat(content); !ok {
+			continue
+		}
+		sbomFiles++
+		parsed, err := parser.Parse(content)
+		if err != nil {
+			impl.logger.Warnw("error in parsing sbom of ci artifacts", "file", entry.Name, "err", err)
+			continue
+		}
+		if selected == nil || len(parsed.Packages) > len(selected.Packages) {
+			selectedName, selectedContent, selected = entry.Name, content, parsed
+		}
+	}
+	return selectedName, selectedContent, selected, nil
+}
+
+func readZipEntry(entry *zip.File, maxSize int64) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxSize))
+}
+
+func (impl *SbomServiceImpl) saveSbom(sbom *sbomRepository.ArtifactSbom, parsed *bean.ParsedSbom) error {
+	tx, err := impl.artifactSbomRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction for saving sbom", "ciArtifactId", sbom.CiArtifactId, "err", err)
+		return err
+	}
+	defer impl.artifactSbomRepository.RollbackTx(tx)
+	err = impl.artifactSbomRepository.ReplaceWithTx(tx, sbom, adapter.GetSbomPackageDbObjs(parsed.Packages))
+	if err != nil {
+		impl.logger.Errorw("error in saving sbom", "ciArtifactId", sbom.CiArtifactId, "err", err)
+		return err
+	}
+	err = impl.artifactSbomRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing sbom", "ciArtifactId", sbom.CiArtifactId, "err", err)
+		return err
+	}
+	impl.logger.Infow("stored sbom of artifact", "ciArtifactId", sbom.CiArtifactId, "source", sbom.Source, "format", sbom.Format, "packages", sbom.PackageCount)
+	return nil
+}
+
+func (impl *SbomServiceImpl) SyncScannerSboms() {
+	createdAfter := time.Now().Add(-time.Duration(impl.config.ScannerSyncLookbackHours) * time.Hour)
+	artifacts, err := impl.artifactSbomRepository.FindScannedArtifactsWithoutSbom(createdAfter, impl.config.ScannerSyncBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error in fetching scanned artifacts without sbom", "err", err)
+		return
+	}
+	for _, artifact := range artifacts {
+		_, err = impl.ingestScannerSbom(artifact.CiArtifactId, artifact.Image, artifact.ImageDigest)
+		if err != nil {
+			impl.logger.Errorw("error in storing sbom produced by the image scanner", "ciArtifactId", artifact.CiArtifactId, "err", err)
+		}
+	}
+}
+
+// ingestScannerSbom stores the sbom of the latest scan of the image, false is returned when the scanner produced none
+func (impl *SbomServiceImpl) ingestScannerSbom(ciArtifactId int, image, digest string) (bool, error) {
+	scannerSbom, err := impl.artifactSbomRepository.FindLatestScannerSbom(image, digest)
+	if err == pg.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching sbom produced by the image scanner", "image", image, "err", err)
+		return false, err
+	}
+	content := []byte(scannerSbom.ScanDataJson)
+	if int64(len(content)) > impl.config.MaxDocumentSizeBytes {
+		return false, fmt.Errorf("sbom of image %s exceeds %d bytes", image, impl.config.MaxDocumentSizeBytes)
+	}
+	parsed, err := parser.Parse(content)
+	if err != nil {
+		return false, err
+	}
+	fileName := fmt.Sprintf("sbom-%d.%s.json", ciArtifactId, strings.ToLower(parsed.Format.String()))
+	sbom := adapter.GetArtifactSbomDbObj(ciArtifactId, bean.SbomSourceImageScanner, fileName, content, parsed, userBean.SystemUserId)
+	sbom.ImageScanExecutionHistoryId = scannerSbom.ImageScanExecutionHistoryId
+	err = impl.saveSbom(sbom, parsed)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (impl *SbomServiceImpl) GetArtifactSbom(ciArtifactId int, includePackages bool) (*bean.ArtifactSbomDto, error) {
+	sbomArtifactId, err := impl.getSbomArtifactId(ciArtifactId)
+	if err != nil {
+		return nil, err
+	}
+	sbom, err := impl.artifactSbomRepository.FindSummaryByCiArtifactId(sbomArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching sbom of artifact", "ciArtifactId", sbomArtifactId, "err", err)
+		return nil, err
+	}
+	var packages []*sbomRepository.SbomPackage
+	if includePackages {
+		packages, err = impl.artifactSbomRepository.FindPackagesBySbomId(sbom.Id)
+		if err != nil {
+			impl.logger.Errorw("error in fetching sbom packages", "sbomId", sbom.Id, "err", err)
+			return nil, err
+		}
+		if packages == nil {
+			packages = make([]*sbomRepository.SbomPackage, 0)
+		}
+	}
+	return adapter.BuildArtifactSbomDto(sbom, packages), nil
+}
+
+func (impl *SbomServiceImpl) GetSbomDocument(ciArtifactId int) (*bean.SbomDocument, error) {
+	sbomArtifactId, err := impl.getSbomArtifactId(ciArtifactId)
+	if err != nil {
+		return nil, err
+	}
+	sbom, err := impl.artifactSbomRepository.FindByCiArtifactId(sbomArtifactId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching sbom of artifact", "ciArtifactId", sbomArtifactId, "err", err)
+		return nil, err
+	}
+	fileName := sbom.FileName
+	if idx := strings.LastIndex(fileName, "/"); idx >= 0 {
+		fileName = fileName[idx+1:]
+	}
+	if len(fileName) == 0 {
+		fileName = fmt.Sprintf("sbom-%d.json", sbomArtifactId)
+	}
+	return &bean.SbomDocument{
+		FileName: fileName,
+		Format:   bean.SbomFormat(sbom.Format),
+		Content:  []byte(sbom.Document),
+	}, nil
+}
+
+// getSbomArtifactId returns the artifact holding the sbom of the given artifact, the sbom produced by the
+// image scanner is stored on demand for artifacts scanned before the last sync
+func (impl *SbomServiceImpl) getSbomArtifactId(ciArtifactId int) (int, error) {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err == pg.ErrNoRows {
+		return 0, util.NewApiError(http.StatusNotFound, bean.ArtifactNotFoundMessage, bean.ArtifactNotFoundMessage)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return 0, err
+	}
+	if artifact.ParentCiArtifact > 0 {
+		artifact, err = impl.ciArtifactRepository.Get(artifact.ParentCiArtifact)
+		if err == pg.ErrNoRows {
+			return 0, util.NewApiError(http.StatusNotFound, bean.SbomNotFoundMessage, bean.SbomNotFoundMessage)
+		} else if err != nil {
+			impl.logger.Errorw("error in fetching parent artifact", "ciArtifactId", ciArtifactId, "err", err)
+			return 0, err
+		}
+	}
+	_, err = impl.artifactSbomRepository.FindSummaryByCiArtifactId(artifact.Id)
+	if err == nil {
+		return artifact.Id, nil
+	} else if err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching sbom of artifact", "ciArtifactId", artifact.Id, "err", err)
+		return 0, err
+	}
+	stored, err := impl.ingestScannerSbom(artifact.Id, artifact.Image, artifact.ImageDigest)
+	if err != nil {
+		impl.logger.Errorw("error in storing sbom produced by the image scanner", "ciArtifactId", artifact.Id, "err", err)
+		return 0, err
+	} else if !stored {
+		return 0, util.NewApiError(http.StatusNotFound, bean.SbomNotFoundMessage, bean.SbomNotFoundMessage)
+	}
+	return artifact.Id, nil
+}
+
+func (impl *SbomServiceImpl) SearchDeployedPackages(request *bean.PackageSearchRequest, filter bean.DeploymentFilter) (*bean.PackageSearchResponse, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	request.Purl = strings.TrimSpace(request.Purl)
+	request.Version = strings.TrimSpace(request.Version)
+	if len(request.Name) == 0 && len(request.Purl) == 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, bean.InvalidSearchMessage, bean.InvalidSearchMessage)
+	}
+	// one more than the max is fetched to know if the result is truncated
+	pageSize := bean.MaxSearchResults + 1
+	deployments := make([]*bean.DeployedPackageDto, 0)
+	for offset := 0; len(deployments) < pageSize; offset += pageSize {
+		deployedPackages, err := impl.artifactSbomRepository.FindDeployedPackages(request, offset, pageSize)
+		if err != nil {
+			impl.logger.Errorw("error in searching deployed packages", "request", request, "offset", offset, "err", err)
+			return nil, err
+		}
+		page := make([]*bean.DeployedPackageDto, 0, len(deployedPackages))
+		for _, deployedPackage := range deployedPackages {
+			page = append(page, adapter.BuildDeployedPackageDto(deployedPackage))
+		}
+		if filter != nil {
+			page = filter(page)
+		}
+		deployments = append(deployments, page...)
+		if len(deployedPackages) < pageSize {
+			break
+		}
+	}
+	response := &bean.PackageSearchResponse{
+		Deployments: deployments,
+	}
+	if len(deployments) > bean.MaxSearchResults {
+		response.Deployments = deployments[:bean.MaxSearchResults]
+		response.Truncated = true
+	}
+	return response, nil
+}
+
+func (impl *SbomServiceImpl) GetAppIdByArtifactId(ciArtifactId int) (int, error) {
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err == pg.ErrNoRows {
+		return 0, util.NewApiError(http.StatusNotFound, bean.ArtifactNotFoundMessage, bean.ArtifactNotFoundMessage)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return 0, err
+	}
+	ciPipeline, err := impl.ciPipelineRepository.FindByIdIncludingInActive(artifact.PipelineId)
+	if err == pg.ErrNoRows {
+		return 0, util.NewApiError(http.StatusNotFound, bean.ArtifactNotFoundMessage, bean.ArtifactNotFoundMessage)
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline of artifact", "ciArtifactId", ciArtifactId, "err", err)
+		return 0, err
+	}
+	return ciPipeline.AppId, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+)
+
+func GetArtifactSbomDbObj(ciArtifactId int, source bean.SbomSource, fileName string, content []byte, parsed *bean.ParsedSbom, userId int32) *repository.ArtifactSbom {
+	return &repository.ArtifactSbom{
+		CiArtifactId:   ciArtifactId,
+		Source:         source.String(),
+		Format:         parsed.Format.String(),
+		SpecVersion:    parsed.SpecVersion,
+		FileName:       fileName,
+		Document:       string(content),
+		DocumentDigest: fmt.Sprintf("sha256:%x", sha256.Sum256(content)),
+		PackageCount:   len(parsed.Packages),
+		AuditLog:       sql.NewDefaultAuditLog(userId),
+	}
+}
+
+func GetSbomPackageDbObjs(packages []*bean.Package) []*repository.SbomPackage {
+	models := make([]*repository.SbomPackage, 0, len(packages))
+	for _, pkg := range packages {
+		models = append(models, &repository.SbomPackage{
+			Name:        pkg.Name,
+			Namespace:   pkg.Namespace,
+			Version:     pkg.Version,
+			Purl:        pkg.Purl,
+			PackageType: pkg.Type,
+			Licenses:    pkg.Licenses,
+		})
+	}
+	return models
+}
+
+func BuildArtifactSbomDto(sbom *repository.ArtifactSbom, packages []*repository.SbomPackage) *bean.ArtifactSbomDto {
+	dto := &bean.ArtifactSbomDto{
+		Id:             sbom.Id,
+		CiArtifactId:   sbom.CiArtifactId,
+		Source:         bean.SbomSource(sbom.Source),
+		Format:         bean.SbomFormat(sbom.Format),
+		SpecVersion:    sbom.SpecVersion,
+		DocumentDigest: sbom.DocumentDigest,
+		FileName:       sbom.FileName,
+		PackageCount:   sbom.PackageCount,
+		IngestedOn:     sbom.UpdatedOn,
+	}
+	if packages != nil {
+		dto.Packages = make([]*bean.SbomPackageDto, 0, len(packages))
+		for _, pkg := range packages {
+			dto.Packages = append(dto.Packages, buildSbomPackageDto(pkg.Name, pkg.Namespace, pkg.Version, pkg.Purl, pkg.PackageType, pkg.Licenses))
+		}
+	}
+	return dto
+}
+
+func BuildDeployedPackageDto(deployedPackage *repository.DeployedPackage) *bean.DeployedPackageDto {
+	return &bean.DeployedPackageDto{
+		AppId:                 deployedPackage.AppId,
+		AppName:               deployedPackage.AppName,
+		EnvironmentId:         deployedPackage.EnvironmentId,
+		EnvironmentName:       deployedPackage.EnvironmentName,
+		CdPipelineId:          deployedPackage.CdPipelineId,
+		CiArtifactId:          deployedPackage.CiArtifactId,
+		Image:                 deployedPackage.Image,
+		DeployedOn:            deployedPackage.DeployedOn,
+		TeamName:              deployedPackage.TeamName,
+		EnvironmentIdentifier: deployedPackage.EnvironmentIdentifier,
+		SbomPackageDto: buildSbomPackageDto(deployedPackage.Name, deployedPackage.Namespace, deployedPackage.Version,
+			deployedPackage.Purl, deployedPackage.PackageType, deployedPackage.Licenses),
+	}
+}
+
+func buildSbomPackageDto(name, namespace, version, purl, packageType string, licenses []string) *bean.SbomPackageDto {
+	if licenses == nil {
+		licenses = make([]string, 0)
+	}
+	return &bean.SbomPackageDto{
+		Name:      name,
+		Namespace: namespace,
+		Version:   version,
+		Purl:      purl,
+		Type:      packageType,
+		Licenses:  licenses,
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+// CATEGORY=SBOM
+type SbomConfig struct {
+	MaxDocumentSizeBytes     int64 `env:"SBOM_MAX_DOCUMENT_SIZE_BYTES" envDefault:"20971520" description:"Max size of an sbom document read from the ci artifacts or the scanner output, larger documents are skipped"`
+	ScannerSyncBatchSize     int   `env:"SBOM_SCANNER_SYNC_BATCH_SIZE" envDefault:"100" description:"Max scanned artifacts for which the sbom produced by the image scanner is stored in a single sync"`
+	ScannerSyncLookbackHours int   `env:"SBOM_SCANNER_SYNC_LOOKBACK_HOURS" envDefault:"72" description:"Artifacts created within these hours are looked up for an sbom produced by the image scanner"`
+}
+
+type SbomFormat string
+
+const (
+	SbomFormatCycloneDx SbomFormat = "CYCLONEDX"
+	SbomFormatSpdx      SbomFormat = "SPDX"
+)
+
+func (f SbomFormat) String() string {
+	return string(f)
+}
+
+type SbomSource string
+
+const (
+	// SbomSourceCiStep is an sbom written by a ci step in its output directory and uploaded with the ci artifacts
+	SbomSourceCiStep SbomSource = "CI_STEP"
+	// SbomSourceImageScanner is an sbom produced by the image scanner while scanning the artifact
+	SbomSourceImageScanner SbomSource = "IMAGE_SCANNER"
+)
+
+func (s SbomSource) String() string {
+	return string(s)
+}
+
+const (
+	// MaxSearchResults caps the deployments returned for a package search
+	MaxSearchResults = 1000
+	// MaxSbomFilesPerWorkflow caps the sbom files read from the artifacts of a ci workflow
+	MaxSbomFilesPerWorkflow = 10
+	// PackageInsertBatchSize is the number of packages inserted in a single query
+	PackageInsertBatchSize = 1000
+)
+
+const (
+	SbomNotFoundMessage     = "sbom not found for the artifact"
+	ArtifactNotFoundMessage = "artifact not found"
+	InvalidSearchMessage    = "package name or purl is required"
+)
+
+// Package is a single package listed in an sbom
+type Package struct {
+	Name      string
+	Namespace string
+	Version   string
+	Purl      string
+	Type      string
+	Licenses  []string
+}
+
+// ParsedSbom is the normalised content of an sbom document
+type ParsedSbom struct {
+	Format      SbomFormat
+	SpecVersion string
+	Packages    []*Package
+}
+
+// ArtifactSbomDto is the sbom stored for an artifact along with its packages
+type ArtifactSbomDto struct {
+	Id             int               `json:"id"`
+	CiArtifactId   int               `json:"ciArtifactId"`
+	Source         SbomSource        `json:"source"`
+	Format         SbomFormat        `json:"format"`
+	SpecVersion    string            `json:"specVersion"`
+	DocumentDigest string            `json:"documentDigest"`
+	FileName       string            `json:"fileName,omitempty"`
+	PackageCount   int               `json:"packageCount"`
+	Packages       []*SbomPackageDto `json:"packages,omitempty"`
+	IngestedOn     time.Time         `json:"ingestedOn"`
+}
+
+type SbomPackageDto struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Version   string   `json:"version"`
+	Purl      string   `json:"purl,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Licenses  []string `json:"licenses"`
+}
+
+// SbomDocument is the stored sbom document of an artifact, served as is for download
+type SbomDocument struct {
+	FileName string
+	Format   SbomFormat
+	Content  []byte
+}
+
+// PackageSearchRequest looks up the artifacts currently deployed on cd pipelines which contain a package,
+// the package is matched on its name (case-insensitive) or on the purl prefix
+type PackageSearchRequest struct {
+	Name    string `json:"name"`
+	Purl    string `json:"purl"`
+	Version string `json:"version"`
+	EnvIds  []int  `json:"envIds"`
+}
+
+// DeploymentFilter returns the deployments the user has access to, out of a page of search results
+type DeploymentFilter func(deployments []*DeployedPackageDto) []*DeployedPackageDto
+
+// DeployedPackageDto is a package found in the artifact currently deployed on a cd pipeline
+type DeployedPackageDto struct {
+	AppId           int       `json:"appId"`
+	AppName         string    `json:"appName"`
+	EnvironmentId   int       `json:"environmentId"`
+	EnvironmentName string    `json:"environmentName"`
+	CdPipelineId    int       `json:"cdPipelineId"`
+	CiArtifactId    int       `json:"ciArtifactId"`
+	Image           string    `json:"image"`
+	DeployedOn      time.Time `json:"deployedOn"`
+	*SbomPackageDto
+	// TeamName and EnvironmentIdentifier are used to build rbac objects of the result
+	TeamName              string `json:"-"`
+	EnvironmentIdentifier string `json:"-"`
+}
+
+type PackageSearchResponse struct {
+	Deployments []*DeployedPackageDto `json:"deployments"`
+	// Truncated is set when more deployments matched than returned
+	Truncated bool `json:"truncated"`
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+)
+
+type cycloneDxDocument struct {
+	SpecVersion string                `json:"specVersion"`
+	Components  []*cycloneDxComponent `json:"components"`
+}
+
+type cycloneDxComponent struct {
+	Type       string                `json:"type"`
+	Group      string                `json:"group"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	Purl       string                `json:"purl"`
+	Licenses   []*cycloneDxLicense   `json:"licenses"`
+	Components []*cycloneDxComponent `json:"components"`
+}
+
+// cycloneDxLicense is either a license (by SPDX id or name) or an SPDX license expression
+type cycloneDxLicense struct {
+	License *struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+func parseCycloneDx(content []byte) (*bean.ParsedSbom, error) {
+	document := &cycloneDxDocument{}
+	err := json.Unmarshal(content, document)
+	if err != nil {
+		return nil, err
+	}
+	sbom := &bean.ParsedSbom{
+		Format:      bean.SbomFormatCycloneDx,
+		SpecVersion: document.SpecVersion,
+	}
+	sbom.Packages = appendCycloneDxComponents(sbom.Packages, document.Components)
+	return sbom, nil
+}
+
+// appendCycloneDxComponents flattens the component tree, e.g. jars shaded inside another jar are nested components
+func appendCycloneDxComponents(packages []*bean.Package, components []*cycloneDxComponent) []*bean.Package {
+	for _, component := range components {
+		if component == nil {
+			continue
+		}
+		// files are listed by some generators along with the packages, they do not identify software
+		if component.Type != "file" {
+			pkg := &bean.Package{
+				Name:      component.Name,
+				Namespace: component.Group,
+				Version:   component.Version,
+				Purl:      component.Purl,
+				Licenses:  getCycloneDxLicenses(component.Licenses),
+			}
+			completePackage(pkg)
+			packages = append(packages, pkg)
+		}
+		packages = appendCycloneDxComponents(packages, component.Components)
+	}
+	return packages
+}
+
+func getCycloneDxLicenses(licenses []*cycloneDxLicense) []string {
+	names := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		if license == nil {
+			continue
+		}
+		switch {
+		case len(license.Expression) > 0:
+			names = append(names, license.Expression)
+		case license.License != nil && len(license.License.Id) > 0:
+			names = append(names, license.License.Id)
+		case license.License != nil && len(license.License.Name) > 0:
+			names = append(names, license.License.Name)
+		}
+	}
+	return names
+}
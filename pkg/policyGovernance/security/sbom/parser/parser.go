@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+)
+
+// sbomHeader holds the fields identifying the format of a json sbom document
+type sbomHeader struct {
+	BomFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	SpdxVersion string `json:"spdxVersion"`
+}
+
+// DetectFormat returns the format of a json sbom document, ok is false for any other content
+func DetectFormat(content []byte) (bean.SbomFormat, bool) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return "", false
+	}
+	header := &sbomHeader{}
+	if err := json.Unmarshal(trimmed, header); err != nil {
+		return "", false
+	}
+	switch {
+	case strings.EqualFold(header.BomFormat, "CycloneDX"):
+		return bean.SbomFormatCycloneDx, true
+	case strings.HasPrefix(header.SpdxVersion, "SPDX-"):
+		return bean.SbomFormatSpdx, true
+	}
+	return "", false
+}
+
+// Parse normalises a CycloneDX or SPDX json document into the packages it lists
+func Parse(content []byte) (*bean.ParsedSbom, error) {
+	format, ok := DetectFormat(content)
+	if !ok {
+		return nil, fmt.Errorf("content is not a CycloneDX or SPDX json document")
+	}
+	var sbom *bean.ParsedSbom
+	var err error
+	switch format {
+	case bean.SbomFormatCycloneDx:
+		sbom, err = parseCycloneDx(content)
+	case bean.SbomFormatSpdx:
+		sbom, err = parseSpdx(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing %s sbom: %w", format, err)
+	}
+	sbom.Packages = dedupePackages(sbom.Packages)
+	return sbom, nil
+}
+
+// ParsePurl returns the type, namespace and name of a package url, e.g. pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1
+func ParsePurl(purl string) (pkgType, namespace, name string, ok bool) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return "", "", "", false
+	}
+	remainder := strings.TrimPrefix(purl, "pkg:")
+	// qualifiers, subpath and version are not part of the package identity
+	if idx := strings.IndexAny(remainder, "?#"); idx >= 0 {
+		remainder = remainder[:idx]
+	}
+	if idx := strings.LastIndex(remainder, "@"); idx >= 0 {
+		remainder = remainder[:idx]
+	}
+	parts := strings.Split(strings.Trim(remainder, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", "", false
+	}
+	pkgType = strings.ToLower(parts[0])
+	name = unescape(parts[len(parts)-1])
+	namespaceParts := make([]string, 0, len(parts)-2)
+	for _, part := range parts[1 : len(parts)-1] {
+		namespaceParts = append(namespaceParts, unescape(part))
+	}
+	return pkgType, strings.Join(namespaceParts, "/"), name, len(name) > 0
+}
+
+func unescape(value string) string {
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		return value
+	}
+	return unescaped
+}
+
+// completePackage fills the type and namespace from the purl when the document does not carry them
+func completePackage(pkg *bean.Package) {
+	pkgType, namespace, name, ok := ParsePurl(pkg.Purl)
+	if !ok {
+		return
+	}
+	if len(pkg.Type) == 0 {
+		pkg.Type = pkgType
+	}
+	if len(pkg.Namespace) == 0 {
+		pkg.Namespace = namespace
+	}
+	if len(pkg.Name) == 0 {
+		pkg.Name = name
+	}
+}
+
+// dedupePackages drops packages listed more than once, e.g. the same jar found at multiple paths of the image
+func dedupePackages(packages []*bean.Package) []*bean.Package {
+	seen := make(map[string]*bean.Package, len(packages))
+	deduped := make([]*bean.Package, 0, len(packages))
+	for _, pkg := range packages {
+		if len(pkg.Name) == 0 {
+			continue
+		}
+		key := strings.Join([]string{pkg.Type, pkg.Namespace, pkg.Name, pkg.Version, pkg.Purl}, "|")
+		if existing, ok := seen[key]; ok {
+			existing.Licenses = mergeLicenses(existing.Licenses, pkg.Licenses)
+			continue
+		}
+		pkg.Licenses = mergeLicenses(nil, pkg.Licenses)
+		seen[key] = pkg
+		deduped = append(deduped, pkg)
+	}
+	return deduped
+}
+
+func mergeLicenses(licenses, others []string) []string {
+	merged := make([]string, 0, len(licenses)+len(others))
+	seen := make(map[string]bool)
+	for _, license := range append(append([]string{}, licenses...), others...) {
+		license = strings.TrimSpace(license)
+		if len(license) == 0 || seen[license] {
+			continue
+		}
+		seen[license] = true
+		merged = append(merged, license)
+	}
+	sort.Strings(merged)
+	return merged
+}
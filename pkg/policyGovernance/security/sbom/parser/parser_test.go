@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+)
+
+const cycloneDxSbom = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"type": "container", "name": "registry.example.com/payments:v1"}},
+  "components": [
+    {
+      "type": "library",
+      "group": "org.apache.logging.log4j",
+      "name": "log4j-core",
+      "version": "2.14.1",
+      "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+      "licenses": [{"license": {"id": "Apache-2.0"}}],
+      "components": [
+        {"type": "library", "name": "shaded-dep", "version": "1.0.0", "purl": "pkg:maven/com.example/shaded-dep@1.0.0"}
+      ]
+    },
+    {
+      "type": "library",
+      "group": "org.apache.logging.log4j",
+      "name": "log4j-core",
+      "version": "2.14.1",
+      "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+      "licenses": [{"expression": "Apache-2.0 OR MIT"}]
+    },
+    {"type": "file", "name": "/app/run.sh"},
+    {"type": "library", "name": "openssl", "version": "3.0.2", "purl": "pkg:deb/debian/openssl@3.0.2?arch=amd64", "licenses": [{"license": {"name": "OpenSSL"}}]}
+  ]
+}`
+
+const spdxSbom = `{
+  "spdxVersion": "SPDX-2.3",
+  "name": "registry.example.com/payments:v1",
+  "packages": [
+    {"name": "registry.example.com/payments", "versionInfo": "v1", "primaryPackagePurpose": "CONTAINER"},
+    {
+      "name": "@babel/core",
+      "versionInfo": "7.22.0",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "MIT",
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/%40babel/core@7.22.0"}]
+    },
+    {"name": "zlib", "versionInfo": "1.2.13", "licenseConcluded": "NONE"}
+  ]
+}`
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  bean.SbomFormat
+		ok      bool
+	}{
+		{name: "cyclonedx", content: cycloneDxSbom, format: bean.SbomFormatCycloneDx, ok: true},
+		{name: "spdx", content: spdxSbom, format: bean.SbomFormatSpdx, ok: true},
+		{name: "other json", content: `{"SchemaVersion": 2, "Results": []}`},
+		{name: "not json", content: "<bom/>"},
+		{name: "empty", content: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, ok := DetectFormat([]byte(tt.content))
+			if ok != tt.ok || format != tt.format {
+				t.Errorf("DetectFormat() = %v, %v, want %v, %v", format, ok, tt.format, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseCycloneDx(t *testing.T) {
+	sbom, err := Parse([]byte(cycloneDxSbom))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if sbom.Format != bean.SbomFormatCycloneDx || sbom.SpecVersion != "1.5" {
+		t.Errorf("unexpected format %s %s", sbom.Format, sbom.SpecVersion)
+	}
+	want := []*bean.Package{
+		{Name: "log4j-core", Namespace: "org.apache.logging.log4j", Version: "2.14.1", Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+			Type: "maven", Licenses: []string{"Apache-2.0", "Apache-2.0 OR MIT"}},
+		{Name: "shaded-dep", Namespace: "com.example", Version: "1.0.0", Purl: "pkg:maven/com.example/shaded-dep@1.0.0", Type: "maven", Licenses: []string{}},
+		{Name: "openssl", Namespace: "debian", Version: "3.0.2", Purl: "pkg:deb/debian/openssl@3.0.2?arch=amd64", Type: "deb", Licenses: []string{"OpenSSL"}},
+	}
+	if !reflect.DeepEqual(sbom.Packages, want) {
+		for _, pkg := range sbom.Packages {
+			t.Logf("%+v", *pkg)
+		}
+		t.Errorf("unexpected packages")
+	}
+}
+
+func TestParseSpdx(t *testing.T) {
+	sbom, err := Parse([]byte(spdxSbom))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if sbom.Format != bean.SbomFormatSpdx || sbom.SpecVersion != "2.3" {
+		t.Errorf("unexpected format %s %s", sbom.Format, sbom.SpecVersion)
+	}
+	want := []*bean.Package{
+		{Name: "@babel/core", Namespace: "@babel", Version: "7.22.0", Purl: "pkg:npm/%40babel/core@7.22.0", Type: "npm", Licenses: []string{"MIT"}},
+		{Name: "zlib", Version: "1.2.13", Licenses: []string{}},
+	}
+	if !reflect.DeepEqual(sbom.Packages, want) {
+		for _, pkg := range sbom.Packages {
+			t.Logf("%+v", *pkg)
+		}
+		t.Errorf("unexpected packages")
+	}
+}
+
+func TestParsePurl(t *testing.T) {
+	pkgType, namespace, name, ok := ParsePurl("pkg:golang/github.com/gorilla/mux@v1.8.0#subpath")
+	if !ok || pkgType != "golang" || namespace != "github.com/gorilla" || name != "mux" {
+		t.Errorf("ParsePurl() = %s, %s, %s, %v", pkgType, namespace, name, ok)
+	}
+	if _, _, _, ok = ParsePurl("log4j-core"); ok {
+		t.Errorf("ParsePurl() accepted a value which is not a purl")
+	}
+}
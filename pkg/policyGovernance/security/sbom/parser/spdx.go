@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+)
+
+const (
+	spdxNoAssertion = "NOASSERTION"
+	spdxNone        = "NONE"
+	// spdxPurposeContainer marks the package describing the image itself
+	spdxPurposeContainer  = "CONTAINER"
+	spdxReferenceTypePurl = "purl"
+)
+
+type spdxDocument struct {
+	SpdxVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name                  string             `json:"name"`
+	VersionInfo           string             `json:"versionInfo"`
+	LicenseConcluded      string             `json:"licenseConcluded"`
+	LicenseDeclared       string             `json:"licenseDeclared"`
+	PrimaryPackagePurpose string             `json:"primaryPackagePurpose"`
+	ExternalRefs          []*spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceType    string `json:"referenceType"`
+	ReferenceLocator string `json:"referenceLocator"`
+}
+
+func parseSpdx(content []byte) (*bean.ParsedSbom, error) {
+	document := &spdxDocument{}
+	err := json.Unmarshal(content, document)
+	if err != nil {
+		return nil, err
+	}
+	sbom := &bean.ParsedSbom{
+		Format:      bean.SbomFormatSpdx,
+		SpecVersion: strings.TrimPrefix(document.SpdxVersion, "SPDX-"),
+	}
+	for _, spdxPkg := range document.Packages {
+		if spdxPkg == nil || spdxPkg.PrimaryPackagePurpose == spdxPurposeContainer {
+			continue
+		}
+		pkg := &bean.Package{
+			Name:     spdxPkg.Name,
+			Version:  spdxPkg.VersionInfo,
+			Purl:     getSpdxPurl(spdxPkg.ExternalRefs),
+			Licenses: getSpdxLicenses(spdxPkg),
+		}
+		completePackage(pkg)
+		sbom.Packages = append(sbom.Packages, pkg)
+	}
+	return sbom, nil
+}
+
+func getSpdxPurl(refs []*spdxExternalRef) string {
+	for _, ref := range refs {
+		if ref != nil && ref.ReferenceType == spdxReferenceTypePurl {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+// getSpdxLicenses prefers the concluded license over the declared one, NOASSERTION and NONE carry no license
+func getSpdxLicenses(pkg *spdxPackage) []string {
+	for _, license := range []string{pkg.LicenseConcluded, pkg.LicenseDeclared} {
+		if len(license) > 0 && license != spdxNoAssertion && license != spdxNone {
+			return []string{license}
+		}
+	}
+	return nil
+}
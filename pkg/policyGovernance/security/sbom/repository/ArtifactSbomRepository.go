@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/k8s/health"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	imageScanningRepository "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository"
+
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type ArtifactSbom struct {
+	tableName                   struct{} `sql:"artifact_sbom" pg:",discard_unknown_columns"`
+	Id                          int      `sql:"id,pk"`
+	CiArtifactId                int      `sql:"ci_artifact_id,notnull"`
+	Source                      string   `sql:"source,notnull"`
+	Format                      string   `sql:"format,notnull"`
+	SpecVersion                 string   `sql:"spec_version"`
+	FileName                    string   `sql:"file_name"`
+	Document                    string   `sql:"document,notnull"`
+	DocumentDigest              string   `sql:"document_digest,notnull"`
+	PackageCount                int      `sql:"package_count,notnull"`
+	CiWorkflowId                int      `sql:"ci_workflow_id"`
+	ImageScanExecutionHistoryId int      `sql:"image_scan_execution_history_id"`
+	sql.AuditLog
+}
+
+type SbomPackage struct {
+	tableName      struct{} `sql:"sbom_package" pg:",discard_unknown_columns"`
+	Id             int      `sql:"id,pk"`
+	ArtifactSbomId int      `sql:"artifact_sbom_id,notnull"`
+	Name           string   `sql:"name,notnull"`
+	Namespace      string   `sql:"namespace"`
+	Version        string   `sql:"version"`
+	Purl           string   `sql:"purl"`
+	PackageType    string   `sql:"package_type"`
+	Licenses       []string `sql:"licenses" pg:",array"`
+}
+
+// ScannedArtifact is a scanned artifact for which the image scanner produced an sbom
+type ScannedArtifact struct {
+	CiArtifactId int    `sql:"ci_artifact_id"`
+	Image        string `sql:"image"`
+	ImageDigest  string `sql:"image_digest"`
+}
+
+// ScannerSbom is the sbom stored by the image scanner for a scan execution
+type ScannerSbom struct {
+	ImageScanExecutionHistoryId int    `sql:"image_scan_execution_history_id"`
+	ScanDataJson                string `sql:"scan_data_json"`
+}
+
+// DeployedPackage is a package of the artifact currently deployed on a cd pipeline
+type DeployedPackage struct {
+	AppId                 int       `sql:"app_id"`
+	AppName               string    `sql:"app_name"`
+	TeamName              string    `sql:"team_name"`
+	EnvironmentId         int       `sql:"environment_id"`
+	EnvironmentName       string    `sql:"environment_name"`
+	EnvironmentIdentifier string    `sql:"environment_identifier"`
+	CdPipelineId          int       `sql:"cd_pipeline_id"`
+	CiArtifactId          int       `sql:"ci_artifact_id"`
+	Image                 string    `sql:"image"`
+	DeployedOn            time.Time `sql:"deployed_on"`
+	Name                  string    `sql:"name"`
+	Namespace             string    `sql:"namespace"`
+	Version               string    `sql:"version"`
+	Purl                  string    `sql:"purl"`
+	PackageType           string    `sql:"package_type"`
+	Licenses              []string  `sql:"licenses" pg:",array"`
+}
+
+// likePatternEscaper escapes the wildcards of a LIKE pattern, purls are matched as a literal prefix
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type ArtifactSbomRepository interface {
+	sql.TransactionWrapper
+	FindByCiArtifactId(ciArtifactId int) (*ArtifactSbom, error)
+	// FindSummaryByCiArtifactId is FindByCiArtifactId without the document
+	FindSummaryByCiArtifactId(ciArtifactId int) (*ArtifactSbom, error)
+	// ReplaceWithTx saves the sbom of the artifact along with its packages, an existing sbom of the artifact is replaced
+	ReplaceWithTx(tx *pg.Tx, sbom *ArtifactSbom, packages []*SbomPackage) error
+	FindPackagesBySbomId(sbomId int) ([]*SbomPackage, error)
+	// FindScannedArtifactsWithoutSbom returns built artifacts created after the given time which have no sbom stored
+	// while the image scanner produced one for their image
+	FindScannedArtifactsWithoutSbom(createdAfter time.Time, limit int) ([]*ScannedArtifact, error)
+	// FindLatestScannerSbom returns the sbom of the latest scan of the image, digest is matched when not empty
+	FindLatestScannerSbom(image, digest string) (*ScannerSbom, error)
+	// FindDeployedPackages returns the matching packages of the artifacts of the latest successful deployment
+	// of every active cd pipeline, derived artifacts are matched on the sbom of their parent
+	FindDeployedPackages(request *bean.PackageSearchRequest, offset, limit int) ([]*DeployedPackage, error)
+}
+
+type ArtifactSbomRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewArtifactSbomRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	transactionUtilImpl *sql.TransactionUtilImpl) *ArtifactSbomRepositoryImpl {
+	return &ArtifactSbomRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindByCiArtifactId(ciArtifactId int) (*ArtifactSbom, error) {
+	sbom := &ArtifactSbom{}
+	err := impl.dbConnection.Model(sbom).
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Select()
+	return sbom, err
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindSummaryByCiArtifactId(ciArtifactId int) (*ArtifactSbom, error) {
+	sbom := &ArtifactSbom{}
+	err := impl.dbConnection.Model(sbom).
+		Column("id", "ci_artifact_id", "source", "format", "spec_version", "file_name", "document_digest",
+			"package_count", "ci_workflow_id", "image_scan_execution_history_id", "created_on", "created_by", "updated_on", "updated_by").
+		Where("ci_artifact_id = ?", ciArtifactId).
+		Select()
+	return sbom, err
+}
+
+func (impl *ArtifactSbomRepositoryImpl) ReplaceWithTx(tx *pg.Tx, sbom *ArtifactSbom, packages []*SbomPackage) error {
+	// packages of the replaced sbom are deleted by the cascade
+	_, err := tx.Model((*ArtifactSbom)(nil)).
+		Where("ci_artifact_id = ?", sbom.CiArtifactId).
+		Delete()
+	if err != nil {
+		return err
+	}
+	err = tx.Insert(sbom)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(packages); start += bean.PackageInsertBatchSize {
+		end := start + bean.PackageInsertBatchSize
+		if end > len(packages) {
+			end = len(packages)
+		}
+		batch := packages[start:end]
+		for _, pkg := range batch {
+			pkg.ArtifactSbomId = sbom.Id
+		}
+		err = tx.Insert(&batch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindPackagesBySbomId(sbomId int) ([]*SbomPackage, error) {
+	var packages []*SbomPackage
+	err := impl.dbConnection.Model(&packages).
+		Where("artifact_sbom_id = ?", sbomId).
+		Order("name", "version").
+		Select()
+	return packages, err
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindScannedArtifactsWithoutSbom(createdAfter time.Time, limit int) ([]*ScannedArtifact, error) {
+	var artifacts []*ScannedArtifact
+	query := `SELECT ca.id AS ci_artifact_id, ca.image, ca.image_digest
+		FROM ci_artifact ca
+		WHERE ca.scanned = true AND ca.created_on > ?
+			AND (ca.parent_ci_artifact IS NULL OR ca.parent_ci_artifact = 0)
+			AND NOT EXISTS (SELECT 1 FROM artifact_sbom s WHERE s.ci_artifact_id = ca.id)
+			AND EXISTS (SELECT 1 FROM image_scan_execution_history h
+				INNER JOIN resource_scan_execution_result r ON r.image_scan_execution_history_id = h.id
+				WHERE h.image = ca.image AND r.format = ?)
+		ORDER BY ca.id DESC
+		LIMIT ?;`
+	_, err := impl.dbConnection.Query(&artifacts, query, createdAfter, imageScanningRepository.CycloneDxSbom, limit)
+	return artifacts, err
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindLatestScannerSbom(image, digest string) (*ScannerSbom, error) {
+	sbom := &ScannerSbom{}
+	query := `SELECT h.id AS image_scan_execution_history_id, r.scan_data_json
+		FROM image_scan_execution_history h
+		INNER JOIN resource_scan_execution_result r ON r.image_scan_execution_history_id = h.id
+		WHERE h.image = ? AND (? = '' OR h.image_hash = ?) AND r.format = ?
+		ORDER BY h.execution_time DESC, h.id DESC
+		LIMIT 1;`
+	_, err := impl.dbConnection.QueryOne(sbom, query, image, digest, digest, imageScanningRepository.CycloneDxSbom)
+	return sbom, err
+}
+
+func (impl *ArtifactSbomRepositoryImpl) FindDeployedPackages(request *bean.PackageSearchRequest, offset, limit int) ([]*DeployedPackage, error) {
+	var packages []*DeployedPackage
+	queryParams := make([]interface{}, 0)
+	packageFilter := ""
+	if len(request.Name) > 0 {
+		packageFilter += " AND LOWER(sp.name) = LOWER(?)"
+		queryParams = append(queryParams, request.Name)
+	}
+	if len(request.Purl) > 0 {
+		packageFilter += " AND sp.purl LIKE ?"
+		queryParams = append(queryParams, likePatternEscaper.Replace(request.Purl)+"%")
+	}
+	if len(request.Version) > 0 {
+		packageFilter += " AND sp.version = ?"
+		queryParams = append(queryParams, request.Version)
+	}
+	queryParams = append(queryParams, pg.In([]string{cdWorkflow.WorkflowSucceeded, string(health.HealthStatusHealthy)}))
+	pipelineFilter := ""
+	if len(request.EnvIds) > 0 {
+		pipelineFilter = " AND p.environment_id IN (?)"
+		queryParams = append(queryParams, pg.In(request.EnvIds))
+	}
+	queryParams = append(queryParams, limit, offset)
+	// packages are matched first so that only the sboms containing them are joined with the deployments
+	query := `WITH matched AS (
+			SELECT s.ci_artifact_id, sp.name, sp.namespace, sp.version, sp.purl, sp.package_type, sp.licenses
+			FROM sbom_package sp
+			INNER JOIN artifact_sbom s ON s.id = sp.artifact_sbom_id
+			WHERE true` + packageFilter + `
+		)
+		SELECT p.app_id, a.app_name, t.name AS team_name, p.environment_id, e.environment_name, e.environment_identifier,
+			p.id AS cd_pipeline_id, ca.id AS ci_artifact_id, ca.image, d.started_on AS deployed_on,
+			m.name, m.namespace, m.version, m.purl, m.package_type, m.licenses
+		FROM pipeline p
+		INNER JOIN app a ON a.id = p.app_id AND a.active = true
+		INNER JOIN team t ON t.id = a.team_id
+		INNER JOIN environment e ON e.id = p.environment_id
+		INNER JOIN LATERAL (
+			SELECT cw.ci_artifact_id, cwr.started_on
+			FROM cd_workflow cw
+			INNER JOIN cd_workflow_runner cwr ON cwr.cd_workflow_id = cw.id
+			WHERE cw.pipeline_id = p.id AND cwr.workflow_type = 'DEPLOY' AND cwr.status IN (?)
+			ORDER BY cwr.id DESC
+			LIMIT 1
+		) d ON true
+		INNER JOIN ci_artifact ca ON ca.id = d.ci_artifact_id
+		INNER JOIN matched m ON m.ci_artifact_id = COALESCE(NULLIF(ca.parent_ci_artifact, 0), ca.id)
+		WHERE p.deleted = false` + pipelineFilter + `
+		ORDER BY a.app_name, e.environment_name, m.name, m.version, p.id, m.purl
+		LIMIT ? OFFSET ?;`
+	_, err := impl.dbConnection.Query(&packages, query, queryParams...)
+	return packages, err
+}
@@ -0,0 +1,13 @@
+package sbom
+
+import (
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/repository"
+	"github.com/google/wire"
+)
+
+var SbomWireSet = wire.NewSet(
+	repository.NewArtifactSbomRepositoryImpl,
+	wire.Bind(new(repository.ArtifactSbomRepository), new(*repository.ArtifactSbomRepositoryImpl)),
+	NewSbomServiceImpl,
+	wire.Bind(new(SbomService), new(*SbomServiceImpl)),
+)
@@ -2,6 +2,8 @@ package policyGovernance
 
 import (
 	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom"
 	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/scanTool"
 	"github.com/google/wire"
 )
@@ -9,4 +11,6 @@ import (
 var PolicyGovernanceWireSet = wire.NewSet(
 	imageScanning.ImageScanningWireSet,
 	scanTool.ScanToolWireSet,
+	sbom.SbomWireSet,
+	imageSigning.ImageSigningWireSet,
 )
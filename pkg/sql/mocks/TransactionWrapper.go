@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	pg "github.com/go-pg/pg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionWrapper is an autogenerated mock type for the TransactionWrapper type
+type TransactionWrapper struct {
+	mock.Mock
+}
+
+// CommitTx provides a mock function with given fields: tx
+func (_m *TransactionWrapper) CommitTx(tx *pg.Tx) error {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx) error); ok {
+		r0 = rf(tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RollbackTx provides a mock function with given fields: tx
+func (_m *TransactionWrapper) RollbackTx(tx *pg.Tx) error {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pg.Tx) error); ok {
+		r0 = rf(tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartTx provides a mock function with no fields
+func (_m *TransactionWrapper) StartTx() (*pg.Tx, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartTx")
+	}
+
+	var r0 *pg.Tx
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pg.Tx, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pg.Tx); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pg.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTransactionWrapper creates a new instance of TransactionWrapper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactionWrapper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransactionWrapper {
+	mock := &TransactionWrapper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
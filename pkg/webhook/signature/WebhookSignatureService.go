@@ -0,0 +1,446 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signature
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/git/gitHost/read"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/adapter"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/repository"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/verifier"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+const (
+	generatedSecretBytes          = 32
+	uniqueKeyViolationPgErrorCode = 23505
+	defaultDeliveryPageSize       = 20
+)
+
+type WebhookSignatureService interface {
+	GetConfig(webhookType bean.WebhookType, webhookId int) (*bean.WebhookSignatureConfigDto, error)
+	// CreateConfig enables the signature verification of the webhook and returns its first secret
+	CreateConfig(request *bean.WebhookSignatureConfigDto) (*bean.WebhookSecretResponse, error)
+	UpdateConfig(request *bean.WebhookSignatureConfigDto) (*bean.WebhookSignatureConfigDto, error)
+	// DeleteConfig disables the signature verification, deliveries fall back to the existing authentication of the webhook
+	DeleteConfig(webhookType bean.WebhookType, webhookId int, userId int32) error
+	// RotateSecret adds a new secret to the webhook, the current secrets are accepted until the overlap period ends
+	RotateSecret(request *bean.RotateSecretRequest) (*bean.WebhookSecretResponse, error)
+
+	// VerifyDelivery verifies and records a delivery of a webhook. verified is false when the webhook has no signature
+	// verification configured, an ApiError is returned for rejected deliveries.
+	VerifyDelivery(request *bean.DeliveryRequest) (verified bool, err error)
+	GetDeliveries(filter *bean.DeliveryFilter) (*bean.WebhookDeliveryList, error)
+	// DeleteExpiredDeliveries deletes the deliveries older than the retention period
+	DeleteExpiredDeliveries()
+}
+
+type WebhookSignatureServiceImpl struct {
+	logger                           *zap.SugaredLogger
+	config                           *bean.WebhookSignatureConfig
+	webhookSignatureConfigRepository repository.WebhookSignatureConfigRepository
+	webhookDeliveryRepository        repository.WebhookDeliveryRepository
+	gitHostReadService               read.GitHostReadService
+	ciPipelineRepository             pipelineConfig.CiPipelineRepository
+}
+
+func NewWebhookSignatureServiceImpl(logger *zap.SugaredLogger,
+	webhookSignatureConfigRepository repository.WebhookSignatureConfigRepository,
+	webhookDeliveryRepository repository.WebhookDeliveryRepository,
+	gitHostReadService read.GitHostReadService,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository) (*WebhookSignatureServiceImpl, error) {
+	config := &bean.WebhookSignatureConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing webhook signature config", "err", err)
+		return nil, err
+	}
+	return &WebhookSignatureServiceImpl{
+		logger:                           logger,
+		config:                           config,
+		webhookSignatureConfigRepository: webhookSignatureConfigRepository,
+		webhookDeliveryRepository:        webhookDeliveryRepository,
+		gitHostReadService:               gitHostReadService,
+		ciPipelineRepository:             ciPipelineRepository,
+	}, nil
+}
+
+func (impl *WebhookSignatureServiceImpl) GetConfig(webhookType bean.WebhookType, webhookId int) (*bean.WebhookSignatureConfigDto, error) {
+	config, err := impl.getActiveConfig(webhookType, webhookId)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := impl.webhookSignatureConfigRepository.FindValidSecrets(config.Id, time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in fetching webhook secrets", "configId", config.Id, "err", err)
+		return nil, err
+	}
+	return adapter.GetWebhookSignatureConfigDto(config, secrets), nil
+}
+
+func (impl *WebhookSignatureServiceImpl) CreateConfig(request *bean.WebhookSignatureConfigDto) (*bean.WebhookSecretResponse, error) {
+	err := impl.validateWebhook(request.WebhookType, request.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	_, err = impl.webhookSignatureConfigRepository.FindActiveByWebhook(request.WebhookType.String(), request.WebhookId)
+	if err == nil {
+		return nil, util.NewApiError(http.StatusConflict, "signature verification is already configured for the webhook, update it or rotate its secret instead", "webhook signature config exists")
+	} else if !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching webhook signature config", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		return nil, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		impl.logger.Errorw("error in generating webhook secret", "err", err)
+		return nil, err
+	}
+	config := &repository.WebhookSignatureConfig{
+		WebhookType: request.WebhookType.String(),
+		WebhookId:   request.WebhookId,
+		Active:      true,
+		AuditLog:    sql.NewDefaultAuditLog(request.UserId),
+	}
+	setProvider(config, request)
+
+	tx, err := impl.webhookSignatureConfigRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.webhookSignatureConfigRepository.RollbackTx(tx)
+	err = impl.webhookSignatureConfigRepository.SaveWithTx(tx, config)
+	if err != nil {
+		impl.logger.Errorw("error in saving webhook signature config", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		return nil, err
+	}
+	webhookSecret := &repository.WebhookSecret{
+		ConfigId: config.Id,
+		Secret:   securestore.ToEncryptedString(secret),
+		Active:   true,
+		AuditLog: sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.webhookSignatureConfigRepository.SaveSecretWithTx(tx, webhookSecret)
+	if err != nil {
+		impl.logger.Errorw("error in saving webhook secret", "configId", config.Id, "err", err)
+		return nil, err
+	}
+	err = impl.webhookSignatureConfigRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return &bean.WebhookSecretResponse{
+		WebhookType: request.WebhookType,
+		WebhookId:   request.WebhookId,
+		SecretId:    webhookSecret.Id,
+		Secret:      secret,
+	}, nil
+}
+
+func (impl *WebhookSignatureServiceImpl) UpdateConfig(request *bean.WebhookSignatureConfigDto) (*bean.WebhookSignatureConfigDto, error) {
+	config, err := impl.getActiveConfig(request.WebhookType, request.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	setProvider(config, request)
+	config.UpdateAuditLog(request.UserId)
+	tx, err := impl.webhookSignatureConfigRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.webhookSignatureConfigRepository.RollbackTx(tx)
+	err = impl.webhookSignatureConfigRepository.UpdateWithTx(tx, config)
+	if err != nil {
+		impl.logger.Errorw("error in updating webhook signature config", "configId", config.Id, "err", err)
+		return nil, err
+	}
+	err = impl.webhookSignatureConfigRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	return impl.GetConfig(request.WebhookType, request.WebhookId)
+}
+
+func (impl *WebhookSignatureServiceImpl) DeleteConfig(webhookType bean.WebhookType, webhookId int, userId int32) error {
+	config, err := impl.getActiveConfig(webhookType, webhookId)
+	if err != nil {
+		return err
+	}
+	config.Active = false
+	config.UpdateAuditLog(userId)
+	tx, err := impl.webhookSignatureConfigRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.webhookSignatureConfigRepository.RollbackTx(tx)
+	err = impl.webhookSignatureConfigRepository.UpdateWithTx(tx, config)
+	if err != nil {
+		impl.logger.Errorw("error in deleting webhook signature config", "configId", config.Id, "err", err)
+		return err
+	}
+	err = impl.webhookSignatureConfigRepository.DeactivateSecretsWithTx(tx, config.Id, userId)
+	if err != nil {
+		impl.logger.Errorw("error in deleting webhook secrets", "configId", config.Id, "err", err)
+		return err
+	}
+	return impl.webhookSignatureConfigRepository.CommitTx(tx)
+}
+
+func (impl *WebhookSignatureServiceImpl) RotateSecret(request *bean.RotateSecretRequest) (*bean.WebhookSecretResponse, error) {
+	config, err := impl.getActiveConfig(request.WebhookType, request.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	secret := strings.TrimSpace(request.Secret)
+	isGenerated := len(secret) == 0
+	if isGenerated {
+		secret, err = generateSecret()
+		if err != nil {
+			impl.logger.Errorw("error in generating webhook secret", "err", err)
+			return nil, err
+		}
+	}
+	overlapMins := impl.config.RotationOverlapMins
+	if request.OverlapMins != nil {
+		overlapMins = *request.OverlapMins
+	}
+	expiresOn := time.Now().Add(time.Duration(overlapMins) * time.Minute)
+
+	tx, err := impl.webhookSignatureConfigRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.webhookSignatureConfigRepository.RollbackTx(tx)
+	err = impl.webhookSignatureConfigRepository.ExpireSecretsWithTx(tx, config.Id, expiresOn, request.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in expiring webhook secrets", "configId", config.Id, "err", err)
+		return nil, err
+	}
+	webhookSecret := &repository.WebhookSecret{
+		ConfigId: config.Id,
+		Secret:   securestore.ToEncryptedString(secret),
+		Active:   true,
+		AuditLog: sql.NewDefaultAuditLog(request.UserId),
+	}
+	err = impl.webhookSignatureConfigRepository.SaveSecretWithTx(tx, webhookSecret)
+	if err != nil {
+		impl.logger.Errorw("error in saving webhook secret", "configId", config.Id, "err", err)
+		return nil, err
+	}
+	err = impl.webhookSignatureConfigRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	impl.logger.Infow("rotated webhook secret", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "previousSecretsExpireOn", expiresOn)
+	response := &bean.WebhookSecretResponse{
+		WebhookType: request.WebhookType,
+		WebhookId:   request.WebhookId,
+		SecretId:    webhookSecret.Id,
+		Secret:      secret,
+	}
+	if !isGenerated {
+		// the caller already has the secret it provided, it is not echoed back
+		response.Secret = adapter.GetSecretHint(secret)
+	}
+	return response, nil
+}
+
+func (impl *WebhookSignatureServiceImpl) VerifyDelivery(request *bean.DeliveryRequest) (bool, error) {
+	config, err := impl.webhookSignatureConfigRepository.FindActiveByWebhook(request.WebhookType.String(), request.WebhookId)
+	if util.IsErrNoRows(err) {
+		return false, nil
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching webhook signature config", "webhookType", request.WebhookType, "webhookId", request.WebhookId, "err", err)
+		return true, err
+	}
+	now := time.Now()
+	secrets, err := impl.webhookSignatureConfigRepository.FindValidSecrets(config.Id, now)
+	if err != nil {
+		impl.logger.Errorw("error in fetching webhook secrets", "configId", config.Id, "err", err)
+		return true, err
+	}
+	secretValues := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		secretValues = append(secretValues, secret.Secret.String())
+	}
+	scheme := verifier.NewScheme(bean.Provider(config.Provider), config.SignatureHeader, config.TimestampHeader, config.DeliveryIdHeader)
+	deliveryId := scheme.DeliveryId(request.Header)
+	tolerance := time.Duration(impl.config.TimestampToleranceSecs) * time.Second
+
+	rejection := scheme.Verify(request.Header, request.Body, secretValues, now, tolerance)
+	if rejection == nil && len(deliveryId) > 0 {
+		exists, err := impl.webhookDeliveryRepository.ExistsAccepted(config.WebhookType, config.WebhookId, deliveryId)
+		if err != nil {
+			impl.logger.Errorw("error in checking webhook delivery", "deliveryId", deliveryId, "err", err)
+			return true, err
+		}
+		if exists {
+			rejection = duplicateDeliveryRejection(deliveryId)
+		}
+	}
+
+	delivery := &repository.WebhookDelivery{
+		WebhookType: config.WebhookType,
+		WebhookId:   config.WebhookId,
+		DeliveryId:  deliveryId,
+		Provider:    config.Provider,
+		Status:      string(bean.DeliveryStatusAccepted),
+		EventType:   request.EventType,
+		SourceIp:    request.SourceIp,
+		CreatedOn:   now,
+	}
+	if rejection == nil {
+		err = impl.webhookDeliveryRepository.Save(delivery)
+		if err == nil {
+			return true, nil
+		} else if !isUniqueKeyViolation(err) {
+			impl.logger.Errorw("error in saving webhook delivery", "deliveryId", deliveryId, "err", err)
+			return true, err
+		}
+		// the same delivery was accepted concurrently
+		delivery.Id = 0
+		rejection = duplicateDeliveryRejection(deliveryId)
+	}
+	delivery.Status = string(bean.DeliveryStatusRejected)
+	delivery.Reason = string(rejection.Reason)
+	delivery.Message = rejection.Message
+	err = impl.webhookDeliveryRepository.Save(delivery)
+	if err != nil {
+		// the delivery is rejected regardless of it not being logged
+		impl.logger.Errorw("error in saving rejected webhook delivery", "deliveryId", deliveryId, "reason", rejection.Reason, "err", err)
+	}
+	impl.logger.Warnw("rejected webhook delivery", "webhookType", config.WebhookType, "webhookId", config.WebhookId,
+		"deliveryId", deliveryId, "reason", rejection.Reason, "message", rejection.Message)
+	status := http.StatusUnauthorized
+	if rejection.Reason == bean.RejectionReasonDuplicateDelivery {
+		status = http.StatusConflict
+	}
+	return true, util.NewApiError(status, fmt.Sprintf("webhook delivery rejected: %s", rejection.Message), string(rejection.Reason))
+}
+
+func (impl *WebhookSignatureServiceImpl) GetDeliveries(filter *bean.DeliveryFilter) (*bean.WebhookDeliveryList, error) {
+	size := filter.Size
+	if size <= 0 {
+		size = defaultDeliveryPageSize
+	}
+	deliveries, count, err := impl.webhookDeliveryRepository.FindByFilter(filter.WebhookType.String(), filter.WebhookId, string(filter.Status), filter.Offset, size)
+	if err != nil {
+		impl.logger.Errorw("error in fetching webhook deliveries", "filter", filter, "err", err)
+		return nil, err
+	}
+	resp := &bean.WebhookDeliveryList{
+		TotalCount: count,
+		Deliveries: make([]*bean.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, adapter.GetWebhookDeliveryDto(delivery))
+	}
+	return resp, nil
+}
+
+func (impl *WebhookSignatureServiceImpl) DeleteExpiredDeliveries() {
+	before := time.Now().AddDate(0, 0, -impl.config.DeliveryRetentionDays)
+	deleted, err := impl.webhookDeliveryRepository.DeleteReceivedBefore(before)
+	if err != nil {
+		impl.logger.Errorw("error in deleting expired webhook deliveries", "before", before, "err", err)
+		return
+	}
+	impl.logger.Debugw("deleted expired webhook deliveries", "count", deleted, "before", before)
+}
+
+func (impl *WebhookSignatureServiceImpl) getActiveConfig(webhookType bean.WebhookType, webhookId int) (*repository.WebhookSignatureConfig, error) {
+	config, err := impl.webhookSignatureConfigRepository.FindActiveByWebhook(webhookType.String(), webhookId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "signature verification is not configured for the webhook", "webhook signature config not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching webhook signature config", "webhookType", webhookType, "webhookId", webhookId, "err", err)
+		return nil, err
+	}
+	return config, nil
+}
+
+func (impl *WebhookSignatureServiceImpl) validateWebhook(webhookType bean.WebhookType, webhookId int) error {
+	var err error
+	switch webhookType {
+	case bean.WebhookTypeGitHost:
+		_, err = impl.gitHostReadService.GetById(webhookId)
+	case bean.WebhookTypeExternalCi:
+		_, err = impl.ciPipelineRepository.FindExternalCiById(webhookId)
+	default:
+		return util.NewApiError(http.StatusBadRequest, fmt.Sprintf("unsupported webhook type %s", webhookType), "unsupported webhook type")
+	}
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, fmt.Sprintf("%s webhook %d not found", webhookType, webhookId), "webhook not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in fetching webhook", "webhookType", webhookType, "webhookId", webhookId, "err", err)
+		return err
+	}
+	return nil
+}
+
+// setProvider sets the provider of the request on the config, headers are only kept for generic webhooks as they are
+// fixed for the others
+func setProvider(config *repository.WebhookSignatureConfig, request *bean.WebhookSignatureConfigDto) {
+	config.Provider = string(request.Provider)
+	config.SignatureHeader, config.TimestampHeader, config.DeliveryIdHeader = "", "", ""
+	if request.Provider == bean.ProviderGeneric {
+		config.SignatureHeader = strings.TrimSpace(request.SignatureHeader)
+		config.TimestampHeader = strings.TrimSpace(request.TimestampHeader)
+		config.DeliveryIdHeader = strings.TrimSpace(request.DeliveryIdHeader)
+	}
+}
+
+func duplicateDeliveryRejection(deliveryId string) *bean.Rejection {
+	return &bean.Rejection{Reason: bean.RejectionReasonDuplicateDelivery, Message: fmt.Sprintf("delivery %s was already accepted", deliveryId)}
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func isUniqueKeyViolation(err error) bool {
+	pgErr, ok := err.(pg.Error)
+	if !ok {
+		return false
+	}
+	errCode, conversionErr := strconv.Atoi(pgErr.Field('C'))
+	return conversionErr == nil && errCode == uniqueKeyViolationPgErrorCode
+}
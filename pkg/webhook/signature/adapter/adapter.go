@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/repository"
+)
+
+// secretHintLength is the number of trailing characters of a secret shown to identify it
+const secretHintLength = 4
+
+// minHintedSecretLength is the length below which no character of a secret is shown
+const minHintedSecretLength = 16
+
+func GetWebhookSignatureConfigDto(config *repository.WebhookSignatureConfig, secrets []*repository.WebhookSecret) *bean.WebhookSignatureConfigDto {
+	dto := &bean.WebhookSignatureConfigDto{
+		Id:               config.Id,
+		WebhookType:      bean.WebhookType(config.WebhookType),
+		WebhookId:        config.WebhookId,
+		Provider:         bean.Provider(config.Provider),
+		SignatureHeader:  config.SignatureHeader,
+		TimestampHeader:  config.TimestampHeader,
+		DeliveryIdHeader: config.DeliveryIdHeader,
+		Secrets:          make([]*bean.WebhookSecret, 0, len(secrets)),
+	}
+	for _, secret := range secrets {
+		dto.Secrets = append(dto.Secrets, &bean.WebhookSecret{
+			Id:         secret.Id,
+			SecretHint: GetSecretHint(secret.Secret.String()),
+			CreatedOn:  secret.CreatedOn,
+			ExpiresOn:  secret.ExpiresOn,
+		})
+	}
+	return dto
+}
+
+// GetSecretHint masks all but the last characters of the secret, short secrets are masked completely
+func GetSecretHint(secret string) string {
+	if len(secret) < minHintedSecretLength {
+		return "****"
+	}
+	return "****" + secret[len(secret)-secretHintLength:]
+}
+
+func GetWebhookDeliveryDto(delivery *repository.WebhookDelivery) *bean.WebhookDelivery {
+	return &bean.WebhookDelivery{
+		Id:          delivery.Id,
+		WebhookType: bean.WebhookType(delivery.WebhookType),
+		WebhookId:   delivery.WebhookId,
+		DeliveryId:  delivery.DeliveryId,
+		Provider:    bean.Provider(delivery.Provider),
+		Status:      bean.DeliveryStatus(delivery.Status),
+		Reason:      bean.RejectionReason(delivery.Reason),
+		Message:     delivery.Message,
+		EventType:   delivery.EventType,
+		SourceIp:    delivery.SourceIp,
+		ReceivedOn:  delivery.CreatedOn,
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"net/http"
+	"time"
+)
+
+// CATEGORY=WEBHOOK_SIGNATURE
+type WebhookSignatureConfig struct {
+	TimestampToleranceSecs int `env:"WEBHOOK_SIGNATURE_TIMESTAMP_TOLERANCE_SECS" envDefault:"300" description:"Max difference in seconds between the signed timestamp of a generic hmac webhook delivery and the server time, deliveries outside of it are rejected as replays"`
+	RotationOverlapMins    int `env:"WEBHOOK_SECRET_ROTATION_OVERLAP_MINS" envDefault:"1440" description:"Default minutes for which a rotated webhook secret is still accepted alongside the new secret"`
+	DeliveryRetentionDays  int `env:"WEBHOOK_DELIVERY_RETENTION_DAYS" envDefault:"30" description:"Days for which webhook deliveries are kept for replay protection and debugging of rejected deliveries"`
+}
+
+type WebhookType string
+
+const (
+	// WebhookTypeGitHost is the webhook of a git host, webhook id is the git host id
+	WebhookTypeGitHost WebhookType = "GIT_HOST"
+	// WebhookTypeExternalCi is the webhook of an external ci pipeline, webhook id is the external ci pipeline id
+	WebhookTypeExternalCi WebhookType = "EXTERNAL_CI"
+)
+
+func (t WebhookType) String() string {
+	return string(t)
+}
+
+type Provider string
+
+const (
+	// ProviderGithub verifies the hmac sha256 of the payload sent in X-Hub-Signature-256
+	ProviderGithub Provider = "GITHUB"
+	// ProviderGitlab verifies the secret token sent in X-Gitlab-Token
+	ProviderGitlab Provider = "GITLAB"
+	// ProviderBitbucket verifies the hmac sha256 of the payload sent in X-Hub-Signature
+	ProviderBitbucket Provider = "BITBUCKET"
+	// ProviderGeneric verifies the hmac sha256 of the signed timestamp and the payload sent in configurable headers
+	ProviderGeneric Provider = "GENERIC"
+)
+
+const (
+	GithubSignatureHeader    = "X-Hub-Signature-256"
+	GithubDeliveryHeader     = "X-GitHub-Delivery"
+	GitlabTokenHeader        = "X-Gitlab-Token"
+	GitlabDeliveryHeader     = "X-Gitlab-Event-UUID"
+	BitbucketSignatureHeader = "X-Hub-Signature"
+	// BitbucketDeliveryHeader is sent by bitbucket cloud, BitbucketServerDeliveryHeader by bitbucket server
+	BitbucketDeliveryHeader       = "X-Request-UUID"
+	BitbucketServerDeliveryHeader = "X-Request-Id"
+
+	DefaultGenericSignatureHeader = "X-Devtron-Signature"
+	DefaultGenericTimestampHeader = "X-Devtron-Timestamp"
+	DefaultGenericDeliveryHeader  = "X-Devtron-Delivery"
+
+	// Sha256SignaturePrefix prefixes the hex encoded hmac in the signature headers
+	Sha256SignaturePrefix = "sha256="
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusAccepted DeliveryStatus = "ACCEPTED"
+	DeliveryStatusRejected DeliveryStatus = "REJECTED"
+)
+
+type RejectionReason string
+
+const (
+	RejectionReasonMissingSignature  RejectionReason = "MISSING_SIGNATURE"
+	RejectionReasonInvalidSignature  RejectionReason = "INVALID_SIGNATURE"
+	RejectionReasonMissingTimestamp  RejectionReason = "MISSING_TIMESTAMP"
+	RejectionReasonMissingDeliveryId RejectionReason = "MISSING_DELIVERY_ID"
+	RejectionReasonStaleTimestamp    RejectionReason = "STALE_TIMESTAMP"
+	RejectionReasonDuplicateDelivery RejectionReason = "DUPLICATE_DELIVERY"
+	RejectionReasonNoActiveSecret    RejectionReason = "NO_ACTIVE_SECRET"
+)
+
+// Rejection is the reason a delivery failed the verification, it is logged against the delivery
+type Rejection struct {
+	Reason  RejectionReason
+	Message string
+}
+
+func (r *Rejection) Error() string {
+	return r.Message
+}
+
+// WebhookSignatureConfigDto configures the signature verification of a webhook, deliveries of webhooks without it
+// fall back to the existing authentication of the webhook
+type WebhookSignatureConfigDto struct {
+	Id               int              `json:"id"`
+	WebhookType      WebhookType      `json:"webhookType" validate:"oneof=GIT_HOST EXTERNAL_CI"`
+	WebhookId        int              `json:"webhookId" validate:"number,gt=0"`
+	Provider         Provider         `json:"provider" validate:"oneof=GITHUB GITLAB BITBUCKET GENERIC"`
+	SignatureHeader  string           `json:"signatureHeader,omitempty"`
+	TimestampHeader  string           `json:"timestampHeader,omitempty"`
+	DeliveryIdHeader string           `json:"deliveryIdHeader,omitempty"`
+	Secrets          []*WebhookSecret `json:"secrets,omitempty"`
+	UserId           int32            `json:"-"`
+}
+
+// WebhookSecret is the metadata of a secret, the secret itself is only returned once on creation
+type WebhookSecret struct {
+	Id         int        `json:"id"`
+	SecretHint string     `json:"secretHint"`
+	CreatedOn  time.Time  `json:"createdOn"`
+	ExpiresOn  *time.Time `json:"expiresOn,omitempty"`
+}
+
+type RotateSecretRequest struct {
+	WebhookType WebhookType `json:"webhookType" validate:"oneof=GIT_HOST EXTERNAL_CI"`
+	WebhookId   int         `json:"webhookId" validate:"number,gt=0"`
+	// Secret is generated when empty
+	Secret string `json:"secret,omitempty"`
+	// OverlapMins is the time for which the current secrets are still accepted, the configured default is used when nil
+	OverlapMins *int  `json:"overlapMins,omitempty" validate:"omitempty,min=0"`
+	UserId      int32 `json:"-"`
+}
+
+// WebhookSecretResponse carries the plain secret to be configured on the webhook sender,
+// a secret provided by the user is returned masked
+type WebhookSecretResponse struct {
+	WebhookType WebhookType `json:"webhookType"`
+	WebhookId   int         `json:"webhookId"`
+	SecretId    int         `json:"secretId"`
+	Secret      string      `json:"secret"`
+}
+
+type DeliveryFilter struct {
+	WebhookType WebhookType
+	WebhookId   int
+	Status      DeliveryStatus
+	Offset      int
+	Size        int
+}
+
+type WebhookDelivery struct {
+	Id          int             `json:"id"`
+	WebhookType WebhookType     `json:"webhookType"`
+	WebhookId   int             `json:"webhookId"`
+	DeliveryId  string          `json:"deliveryId,omitempty"`
+	Provider    Provider        `json:"provider"`
+	Status      DeliveryStatus  `json:"status"`
+	Reason      RejectionReason `json:"reason,omitempty"`
+	Message     string          `json:"message,omitempty"`
+	EventType   string          `json:"eventType,omitempty"`
+	SourceIp    string          `json:"sourceIp,omitempty"`
+	ReceivedOn  time.Time       `json:"receivedOn"`
+}
+
+type WebhookDeliveryList struct {
+	TotalCount int                `json:"totalCount"`
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// DeliveryRequest is a webhook delivery received for verification
+type DeliveryRequest struct {
+	WebhookType WebhookType
+	WebhookId   int
+	Header      http.Header
+	Body        []byte
+	EventType   string
+	SourceIp    string
+}
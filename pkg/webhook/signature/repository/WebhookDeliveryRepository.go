@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type WebhookDelivery struct {
+	tableName   struct{}  `sql:"webhook_delivery" pg:",discard_unknown_columns"`
+	Id          int       `sql:"id,pk"`
+	WebhookType string    `sql:"webhook_type,notnull"`
+	WebhookId   int       `sql:"webhook_id,notnull"`
+	DeliveryId  string    `sql:"delivery_id"`
+	Provider    string    `sql:"provider,notnull"`
+	Status      string    `sql:"status,notnull"`
+	Reason      string    `sql:"reason"`
+	Message     string    `sql:"message"`
+	EventType   string    `sql:"event_type"`
+	SourceIp    string    `sql:"source_ip"`
+	CreatedOn   time.Time `sql:"created_on,notnull"`
+}
+
+type WebhookDeliveryRepository interface {
+	// Save fails with a unique key violation when the delivery id of an accepted delivery was already accepted
+	Save(delivery *WebhookDelivery) error
+	ExistsAccepted(webhookType string, webhookId int, deliveryId string) (bool, error)
+	// FindByFilter returns the deliveries matching the non-empty filters, latest first, along with their total count
+	FindByFilter(webhookType string, webhookId int, status string, offset, size int) ([]*WebhookDelivery, int, error)
+	DeleteReceivedBefore(before time.Time) (int, error)
+}
+
+type WebhookDeliveryRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewWebhookDeliveryRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *WebhookDeliveryRepositoryImpl {
+	return &WebhookDeliveryRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *WebhookDeliveryRepositoryImpl) Save(delivery *WebhookDelivery) error {
+	return impl.dbConnection.Insert(delivery)
+}
+
+func (impl *WebhookDeliveryRepositoryImpl) ExistsAccepted(webhookType string, webhookId int, deliveryId string) (bool, error) {
+	return impl.dbConnection.Model((*WebhookDelivery)(nil)).
+		Where("webhook_type = ?", webhookType).
+		Where("webhook_id = ?", webhookId).
+		Where("delivery_id = ?", deliveryId).
+		Where("status = ?", bean.DeliveryStatusAccepted).
+		Exists()
+}
+
+func (impl *WebhookDeliveryRepositoryImpl) FindByFilter(webhookType string, webhookId int, status string, offset, size int) ([]*WebhookDelivery, int, error) {
+	var deliveries []*WebhookDelivery
+	query := impl.dbConnection.Model(&deliveries)
+	if len(webhookType) > 0 {
+		query = query.Where("webhook_type = ?", webhookType)
+	}
+	if webhookId > 0 {
+		query = query.Where("webhook_id = ?", webhookId)
+	}
+	if len(status) > 0 {
+		query = query.Where("status = ?", status)
+	}
+	count, err := query.Order("id DESC").
+		Offset(offset).
+		Limit(size).
+		SelectAndCount()
+	return deliveries, count, err
+}
+
+func (impl *WebhookDeliveryRepositoryImpl) DeleteReceivedBefore(before time.Time) (int, error) {
+	result, err := impl.dbConnection.Model((*WebhookDelivery)(nil)).
+		Where("created_on < ?", before).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
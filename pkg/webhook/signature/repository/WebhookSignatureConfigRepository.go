@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	globalUtil "github.com/devtron-labs/devtron/util"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type WebhookSignatureConfig struct {
+	tableName        struct{} `sql:"webhook_signature_config" pg:",discard_unknown_columns"`
+	Id               int      `sql:"id,pk"`
+	WebhookType      string   `sql:"webhook_type,notnull"`
+	WebhookId        int      `sql:"webhook_id,notnull"`
+	Provider         string   `sql:"provider,notnull"`
+	SignatureHeader  string   `sql:"signature_header"`
+	TimestampHeader  string   `sql:"timestamp_header"`
+	DeliveryIdHeader string   `sql:"delivery_id_header"`
+	Active           bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type WebhookSecret struct {
+	tableName struct{}                    `sql:"webhook_secret" pg:",discard_unknown_columns"`
+	Id        int                         `sql:"id,pk"`
+	ConfigId  int                         `sql:"config_id,notnull"`
+	Secret    securestore.EncryptedString `sql:"secret,notnull"`
+	ExpiresOn *time.Time                  `sql:"expires_on"`
+	Active    bool                        `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+type WebhookSignatureConfigRepository interface {
+	SaveWithTx(tx *pg.Tx, config *WebhookSignatureConfig) error
+	UpdateWithTx(tx *pg.Tx, config *WebhookSignatureConfig) error
+	FindActiveByWebhook(webhookType string, webhookId int) (*WebhookSignatureConfig, error)
+
+	SaveSecretWithTx(tx *pg.Tx, secret *WebhookSecret) error
+	// FindValidSecrets returns the active secrets of the config which have not expired, latest first
+	FindValidSecrets(configId int, now time.Time) ([]*WebhookSecret, error)
+	// ExpireSecretsWithTx sets the expiry of the valid secrets of the config which expire after it
+	ExpireSecretsWithTx(tx *pg.Tx, configId int, expiresOn time.Time, userId int32) error
+	DeactivateSecretsWithTx(tx *pg.Tx, configId int, userId int32) error
+	sql.TransactionWrapper
+}
+
+type WebhookSignatureConfigRepositoryImpl struct {
+	dbConnection       *pg.DB
+	logger             *zap.SugaredLogger
+	GlobalEnvVariables *globalUtil.GlobalEnvVariables
+	*sql.TransactionUtilImpl
+}
+
+func NewWebhookSignatureConfigRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	variables *globalUtil.EnvironmentVariables, transactionUtilImpl *sql.TransactionUtilImpl) *WebhookSignatureConfigRepositoryImpl {
+	return &WebhookSignatureConfigRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		GlobalEnvVariables:  variables.GlobalEnvVariables,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) SaveWithTx(tx *pg.Tx, config *WebhookSignatureConfig) error {
+	return tx.Insert(config)
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) UpdateWithTx(tx *pg.Tx, config *WebhookSignatureConfig) error {
+	return tx.Update(config)
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) FindActiveByWebhook(webhookType string, webhookId int) (*WebhookSignatureConfig, error) {
+	config := &WebhookSignatureConfig{}
+	err := impl.dbConnection.Model(config).
+		Where("webhook_type = ?", webhookType).
+		Where("webhook_id = ?", webhookId).
+		Where("active = ?", true).
+		Select()
+	return config, err
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) SaveSecretWithTx(tx *pg.Tx, secret *WebhookSecret) error {
+	var err error
+	if impl.GlobalEnvVariables.EnablePasswordEncryption {
+		// secrets saved in plain text earlier are still read back as they are, decryption falls back to the stored value
+		secret.Secret, err = securestore.EncryptString(secret.Secret.String())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Insert(secret)
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) FindValidSecrets(configId int, now time.Time) ([]*WebhookSecret, error) {
+	var secrets []*WebhookSecret
+	err := impl.dbConnection.Model(&secrets).
+		Where("config_id = ?", configId).
+		Where("active = ?", true).
+		Where("expires_on IS NULL OR expires_on > ?", now).
+		Order("id DESC").
+		Select()
+	return secrets, err
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) ExpireSecretsWithTx(tx *pg.Tx, configId int, expiresOn time.Time, userId int32) error {
+	_, err := tx.Model((*WebhookSecret)(nil)).
+		Set("expires_on = ?", expiresOn).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("config_id = ?", configId).
+		Where("active = ?", true).
+		Where("expires_on IS NULL OR expires_on > ?", expiresOn).
+		Update()
+	return err
+}
+
+func (impl *WebhookSignatureConfigRepositoryImpl) DeactivateSecretsWithTx(tx *pg.Tx, configId int, userId int32) error {
+	_, err := tx.Model((*WebhookSecret)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("config_id = ?", configId).
+		Where("active = ?", true).
+		Update()
+	return err
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+)
+
+// Scheme is how the sender of a webhook signs its deliveries
+type Scheme struct {
+	Provider         bean.Provider
+	SignatureHeader  string
+	TimestampHeader  string
+	DeliveryIdHeader string
+}
+
+// NewScheme returns the scheme of the provider, headers are only configurable for generic webhooks
+func NewScheme(provider bean.Provider, signatureHeader, timestampHeader, deliveryIdHeader string) Scheme {
+	scheme := Scheme{Provider: provider}
+	switch provider {
+	case bean.ProviderGithub:
+		scheme.SignatureHeader = bean.GithubSignatureHeader
+		scheme.DeliveryIdHeader = bean.GithubDeliveryHeader
+	case bean.ProviderGitlab:
+		scheme.SignatureHeader = bean.GitlabTokenHeader
+		scheme.DeliveryIdHeader = bean.GitlabDeliveryHeader
+	case bean.ProviderBitbucket:
+		scheme.SignatureHeader = bean.BitbucketSignatureHeader
+		scheme.DeliveryIdHeader = bean.BitbucketDeliveryHeader
+	default:
+		scheme.SignatureHeader = valueOrDefault(signatureHeader, bean.DefaultGenericSignatureHeader)
+		scheme.TimestampHeader = valueOrDefault(timestampHeader, bean.DefaultGenericTimestampHeader)
+		scheme.DeliveryIdHeader = valueOrDefault(deliveryIdHeader, bean.DefaultGenericDeliveryHeader)
+	}
+	return scheme
+}
+
+// DeliveryId returns the unique id the sender assigned to the delivery, empty if not sent
+func (s Scheme) DeliveryId(header http.Header) string {
+	deliveryId := header.Get(s.DeliveryIdHeader)
+	if len(deliveryId) == 0 && s.Provider == bean.ProviderBitbucket {
+		deliveryId = header.Get(bean.BitbucketServerDeliveryHeader)
+	}
+	return deliveryId
+}
+
+// Verify checks the delivery against each of the secrets, so that the secrets being rotated out are accepted until
+// they expire. Timestamps are only signed by generic webhooks, the replay of github and bitbucket deliveries is
+// detected by their delivery id, hence deliveries without it are rejected.
+func (s Scheme) Verify(header http.Header, body []byte, secrets []string, now time.Time, tolerance time.Duration) *bean.Rejection {
+	if len(secrets) == 0 {
+		return &bean.Rejection{Reason: bean.RejectionReasonNoActiveSecret, Message: "no active secret is configured for the webhook"}
+	}
+	signature := strings.TrimSpace(header.Get(s.SignatureHeader))
+	if len(signature) == 0 {
+		return &bean.Rejection{Reason: bean.RejectionReasonMissingSignature, Message: fmt.Sprintf("%s header is missing", s.SignatureHeader)}
+	}
+	if s.requiresDeliveryId() && len(strings.TrimSpace(s.DeliveryId(header))) == 0 {
+		return &bean.Rejection{Reason: bean.RejectionReasonMissingDeliveryId, Message: fmt.Sprintf("%s header is missing", s.DeliveryIdHeader)}
+	}
+	signedContent := body
+	if s.Provider == bean.ProviderGeneric {
+		timestamp := strings.TrimSpace(header.Get(s.TimestampHeader))
+		if len(timestamp) == 0 {
+			return &bean.Rejection{Reason: bean.RejectionReasonMissingTimestamp, Message: fmt.Sprintf("%s header is missing", s.TimestampHeader)}
+		}
+		unixSecs, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return &bean.Rejection{Reason: bean.RejectionReasonMissingTimestamp, Message: fmt.Sprintf("%s header is not a unix timestamp", s.TimestampHeader)}
+		}
+		signedAt := time.Unix(unixSecs, 0)
+		if signedAt.Before(now.Add(-tolerance)) || signedAt.After(now.Add(tolerance)) {
+			return &bean.Rejection{Reason: bean.RejectionReasonStaleTimestamp,
+				Message: fmt.Sprintf("delivery was signed at %s, outside the accepted tolerance of %s", signedAt.UTC().Format(time.RFC3339), tolerance)}
+		}
+		signedContent = GenericSignedContent(timestamp, body)
+	}
+	for _, secret := range secrets {
+		if s.matches(signature, signedContent, secret) {
+			return nil
+		}
+	}
+	return &bean.Rejection{Reason: bean.RejectionReasonInvalidSignature, Message: fmt.Sprintf("%s does not match any active secret", s.SignatureHeader)}
+}
+
+// requiresDeliveryId is true for the providers whose signature does not cover a timestamp
+func (s Scheme) requiresDeliveryId() bool {
+	return s.Provider == bean.ProviderGithub || s.Provider == bean.ProviderBitbucket
+}
+
+func (s Scheme) matches(signature string, signedContent []byte, secret string) bool {
+	if s.Provider == bean.ProviderGitlab {
+		// gitlab sends the secret itself instead of a signature
+		return subtle.ConstantTimeCompare([]byte(signature), []byte(secret)) == 1
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, bean.Sha256SignaturePrefix))
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, ComputeHmac(signedContent, secret))
+}
+
+// ComputeHmac returns the hmac sha256 of the content
+func ComputeHmac(content []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(content)
+	return mac.Sum(nil)
+}
+
+// GenericSignedContent is the content signed by generic webhooks, the timestamp is signed along with the payload so
+// that it can not be replaced to replay a delivery
+func GenericSignedContent(timestamp string, body []byte) []byte {
+	content := make([]byte, 0, len(timestamp)+1+len(body))
+	content = append(content, timestamp...)
+	content = append(content, '.')
+	return append(content, body...)
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
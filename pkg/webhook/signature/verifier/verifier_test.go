@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verifier
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/bean"
+)
+
+var payload = []byte(`{"ref":"refs/heads/main"}`)
+
+func sha256Signature(content []byte, secret string) string {
+	return bean.Sha256SignaturePrefix + hex.EncodeToString(ComputeHmac(content, secret))
+}
+
+func TestVerifyProviders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	tests := []struct {
+		name       string
+		scheme     Scheme
+		header     http.Header
+		secrets    []string
+		wantReason bean.RejectionReason
+	}{
+		{
+			name:    "github signature",
+			scheme:  NewScheme(bean.ProviderGithub, "", "", ""),
+			header:  http.Header{bean.GithubSignatureHeader: {sha256Signature(payload, "current")}, bean.GithubDeliveryHeader: {"delivery-1"}},
+			secrets: []string{"current"},
+		},
+		{
+			name:    "github signature of secret being rotated out",
+			scheme:  NewScheme(bean.ProviderGithub, "", "", ""),
+			header:  http.Header{bean.GithubSignatureHeader: {sha256Signature(payload, "previous")}, bean.GithubDeliveryHeader: {"delivery-1"}},
+			secrets: []string{"current", "previous"},
+		},
+		{
+			name:       "github signature of unknown secret",
+			scheme:     NewScheme(bean.ProviderGithub, "", "", ""),
+			header:     http.Header{bean.GithubSignatureHeader: {sha256Signature(payload, "other")}, bean.GithubDeliveryHeader: {"delivery-1"}},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonInvalidSignature,
+		},
+		{
+			name:       "github signature missing",
+			scheme:     NewScheme(bean.ProviderGithub, "", "", ""),
+			header:     http.Header{},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonMissingSignature,
+		},
+		{
+			name:       "github delivery id missing",
+			scheme:     NewScheme(bean.ProviderGithub, "", "", ""),
+			header:     http.Header{bean.GithubSignatureHeader: {sha256Signature(payload, "current")}},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonMissingDeliveryId,
+		},
+		{
+			name:    "gitlab token",
+			scheme:  NewScheme(bean.ProviderGitlab, "", "", ""),
+			header:  http.Header{bean.GitlabTokenHeader: {"current"}},
+			secrets: []string{"current"},
+		},
+		{
+			name:       "gitlab wrong token",
+			scheme:     NewScheme(bean.ProviderGitlab, "", "", ""),
+			header:     http.Header{bean.GitlabTokenHeader: {"curren"}},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonInvalidSignature,
+		},
+		{
+			name:    "bitbucket signature",
+			scheme:  NewScheme(bean.ProviderBitbucket, "", "", ""),
+			header:  http.Header{bean.BitbucketSignatureHeader: {sha256Signature(payload, "current")}, bean.BitbucketDeliveryHeader: {"delivery-1"}},
+			secrets: []string{"current"},
+		},
+		{
+			name:    "bitbucket server signature",
+			scheme:  NewScheme(bean.ProviderBitbucket, "", "", ""),
+			header:  http.Header{bean.BitbucketSignatureHeader: {sha256Signature(payload, "current")}, bean.BitbucketServerDeliveryHeader: {"delivery-1"}},
+			secrets: []string{"current"},
+		},
+		{
+			name:       "bitbucket delivery id missing",
+			scheme:     NewScheme(bean.ProviderBitbucket, "", "", ""),
+			header:     http.Header{bean.BitbucketSignatureHeader: {sha256Signature(payload, "current")}},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonMissingDeliveryId,
+		},
+		{
+			name:   "generic signature over timestamp and payload",
+			scheme: NewScheme(bean.ProviderGeneric, "X-Signature", "", ""),
+			header: http.Header{
+				"X-Signature":                      {sha256Signature(GenericSignedContent(timestamp, payload), "current")},
+				bean.DefaultGenericTimestampHeader: {timestamp},
+			},
+			secrets: []string{"current"},
+		},
+		{
+			name:   "generic signature of payload only",
+			scheme: NewScheme(bean.ProviderGeneric, "", "", ""),
+			header: http.Header{
+				bean.DefaultGenericSignatureHeader: {sha256Signature(payload, "current")},
+				bean.DefaultGenericTimestampHeader: {timestamp},
+			},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonInvalidSignature,
+		},
+		{
+			name:   "generic stale timestamp",
+			scheme: NewScheme(bean.ProviderGeneric, "", "", ""),
+			header: http.Header{
+				bean.DefaultGenericSignatureHeader: {sha256Signature(GenericSignedContent("1699999000", payload), "current")},
+				bean.DefaultGenericTimestampHeader: {"1699999000"},
+			},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonStaleTimestamp,
+		},
+		{
+			name:       "generic timestamp missing",
+			scheme:     NewScheme(bean.ProviderGeneric, "", "", ""),
+			header:     http.Header{bean.DefaultGenericSignatureHeader: {sha256Signature(payload, "current")}},
+			secrets:    []string{"current"},
+			wantReason: bean.RejectionReasonMissingTimestamp,
+		},
+		{
+			name:       "no active secret",
+			scheme:     NewScheme(bean.ProviderGithub, "", "", ""),
+			header:     http.Header{bean.GithubSignatureHeader: {sha256Signature(payload, "current")}, bean.GithubDeliveryHeader: {"delivery-1"}},
+			wantReason: bean.RejectionReasonNoActiveSecret,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// headers of a received request are canonicalized, the literals above are not
+			header := http.Header{}
+			for key, values := range tt.header {
+				for _, value := range values {
+					header.Add(key, value)
+				}
+			}
+			rejection := tt.scheme.Verify(header, payload, tt.secrets, now, 5*time.Minute)
+			if len(tt.wantReason) == 0 {
+				if rejection != nil {
+					t.Fatalf("expected delivery to be accepted, got %s: %s", rejection.Reason, rejection.Message)
+				}
+				return
+			}
+			if rejection == nil || rejection.Reason != tt.wantReason {
+				t.Fatalf("expected rejection %s, got %v", tt.wantReason, rejection)
+			}
+		})
+	}
+}
+
+func TestDeliveryId(t *testing.T) {
+	scheme := NewScheme(bean.ProviderBitbucket, "", "", "")
+	if id := scheme.DeliveryId(http.Header{bean.BitbucketServerDeliveryHeader: {"server-id"}}); id != "server-id" {
+		t.Fatalf("expected bitbucket server request id, got %q", id)
+	}
+	scheme = NewScheme(bean.ProviderGeneric, "", "", "X-Event-Id")
+	if id := scheme.DeliveryId(http.Header{"X-Event-Id": {"event-1"}}); id != "event-1" {
+		t.Fatalf("expected configured delivery header, got %q", id)
+	}
+}
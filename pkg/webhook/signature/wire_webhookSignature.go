@@ -0,0 +1,15 @@
+package signature
+
+import (
+	"github.com/devtron-labs/devtron/pkg/webhook/signature/repository"
+	"github.com/google/wire"
+)
+
+var WebhookSignatureWireSet = wire.NewSet(
+	repository.NewWebhookSignatureConfigRepositoryImpl,
+	wire.Bind(new(repository.WebhookSignatureConfigRepository), new(*repository.WebhookSignatureConfigRepositoryImpl)),
+	repository.NewWebhookDeliveryRepositoryImpl,
+	wire.Bind(new(repository.WebhookDeliveryRepository), new(*repository.WebhookDeliveryRepositoryImpl)),
+	NewWebhookSignatureServiceImpl,
+	wire.Bind(new(WebhookSignatureService), new(*WebhookSignatureServiceImpl)),
+)
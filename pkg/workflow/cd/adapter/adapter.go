@@ -47,6 +47,7 @@ func ConvertCdWorkflowRunnerDbObjToDto(dbObj *pipelineConfig.CdWorkflowRunner) *
 		RefCdWorkflowRunnerId:   dbObj.RefCdWorkflowRunnerId,
 		ImagePathReservationIds: dbObj.ImagePathReservationIds,
 		ReferenceId:             &newReferenceId,
+		TriggerType:             dbObj.TriggerType,
 	}
 }
 
@@ -83,6 +84,7 @@ func ConvertCdWorkflowRunnerDtoToDbObj(dto *bean.CdWorkflowRunnerDto) *pipelineC
 		RefCdWorkflowRunnerId:   dto.RefCdWorkflowRunnerId,
 		ImagePathReservationIds: dto.ImagePathReservationIds,
 		ReferenceId:             dto.ReferenceId,
+		TriggerType:             dto.TriggerType,
 		AuditLog: sql.AuditLog{
 			CreatedOn: dto.StartedOn,
 			CreatedBy: dto.TriggeredBy,
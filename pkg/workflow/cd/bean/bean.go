@@ -51,4 +51,5 @@ type CdWorkflowRunnerDto struct {
 	ImagePathReservationIds []int                           `json:"imagePathReservationIds"`
 	ReferenceId             *string                         `json:"referenceId"`
 	IsArtifactUploaded      bool                            `json:"isArtifactUploaded"`
+	TriggerType             string                          `json:"triggerType"`
 }
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pubsub_lib "github.com/devtron-labs/common-lib/pubsub-lib"
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CdWorkflowCommonService is an autogenerated mock type for the CdWorkflowCommonService type
+type CdWorkflowCommonService struct {
+	mock.Mock
+}
+
+// GetTriggerValidateFuncs provides a mock function with no fields
+func (_m *CdWorkflowCommonService) GetTriggerValidateFuncs() []pubsub_lib.ValidateMsg {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTriggerValidateFuncs")
+	}
+
+	var r0 []pubsub_lib.ValidateMsg
+	if rf, ok := ret.Get(0).(func() []pubsub_lib.ValidateMsg); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pubsub_lib.ValidateMsg)
+		}
+	}
+
+	return r0
+}
+
+// MarkCurrentDeploymentFailed provides a mock function with given fields: runner, releaseErr, triggeredBy
+func (_m *CdWorkflowCommonService) MarkCurrentDeploymentFailed(runner *pipelineConfig.CdWorkflowRunner, releaseErr error, triggeredBy int32) error {
+	ret := _m.Called(runner, releaseErr, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCurrentDeploymentFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pipelineConfig.CdWorkflowRunner, error, int32) error); ok {
+		r0 = rf(runner, releaseErr, triggeredBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkDeploymentFailedForRunnerId provides a mock function with given fields: cdWfrId, releaseErr, triggeredBy
+func (_m *CdWorkflowCommonService) MarkDeploymentFailedForRunnerId(cdWfrId int, releaseErr error, triggeredBy int32) error {
+	ret := _m.Called(cdWfrId, releaseErr, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDeploymentFailedForRunnerId")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, error, int32) error); ok {
+		r0 = rf(cdWfrId, releaseErr, triggeredBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SupersedePreviousDeployments provides a mock function with given fields: ctx, cdWfrId, pipelineId, triggeredAt, triggeredBy
+func (_m *CdWorkflowCommonService) SupersedePreviousDeployments(ctx context.Context, cdWfrId int, pipelineId int, triggeredAt time.Time, triggeredBy int32) error {
+	ret := _m.Called(ctx, cdWfrId, pipelineId, triggeredAt, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SupersedePreviousDeployments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Time, int32) error); ok {
+		r0 = rf(ctx, cdWfrId, pipelineId, triggeredAt, triggeredBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateNonTerminalStatusInRunner provides a mock function with given fields: ctx, wfrId, userId, status
+func (_m *CdWorkflowCommonService) UpdateNonTerminalStatusInRunner(ctx context.Context, wfrId int, userId int32, status string) error {
+	ret := _m.Called(ctx, wfrId, userId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNonTerminalStatusInRunner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int32, string) error); ok {
+		r0 = rf(ctx, wfrId, userId, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePreviousQueuedRunnerStatus provides a mock function with given fields: cdWfrId, pipelineId, triggeredBy
+func (_m *CdWorkflowCommonService) UpdatePreviousQueuedRunnerStatus(cdWfrId int, pipelineId int, triggeredBy int32) error {
+	ret := _m.Called(cdWfrId, pipelineId, triggeredBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreviousQueuedRunnerStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, int32) error); ok {
+		r0 = rf(cdWfrId, pipelineId, triggeredBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCdWorkflowCommonService creates a new instance of CdWorkflowCommonService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCdWorkflowCommonService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CdWorkflowCommonService {
+	mock := &CdWorkflowCommonService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -44,13 +44,17 @@ import (
 	"github.com/devtron-labs/devtron/pkg/app/status"
 	bean7 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	commitStatusBean "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/bean"
 	bean5 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	buildCommonBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
+	"github.com/devtron-labs/devtron/pkg/build/testReport"
 	"github.com/devtron-labs/devtron/pkg/build/trigger"
 	"github.com/devtron-labs/devtron/pkg/cluster/adapter"
 	repository5 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	common2 "github.com/devtron-labs/devtron/pkg/deployment/common"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
 	triggerAdapter "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/adapter"
 	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
@@ -65,6 +69,7 @@ import (
 	repository2 "github.com/devtron-labs/devtron/pkg/plugin/repository"
 	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning"
 	repository3 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
 	bean4 "github.com/devtron-labs/devtron/pkg/workflow/cd/bean"
@@ -103,6 +108,9 @@ type WorkflowDagExecutor interface {
 	HandleCiSuccessEvent(triggerContext triggerBean.TriggerContext, ciPipelineId int, request *bean2.CiArtifactWebhookRequest, imagePushedAt time.Time) (id int, err error)
 	HandlePreStageSuccessEvent(triggerContext triggerBean.TriggerContext, cdStageCompleteEvent eventProcessorBean.CdStageCompleteEvent) error
 	HandleDeploymentSuccessEvent(triggerContext triggerBean.TriggerContext, pipelineOverride *chartConfig.PipelineOverride) error
+	// HandleMetricVerificationSuccessEvent continues the deployment success flow (post-cd or next pipelines)
+	// of a deployment held back by metric verification, once the verification has passed
+	HandleMetricVerificationSuccessEvent(triggerContext triggerBean.TriggerContext, pipelineOverrideId int) error
 	HandlePostStageSuccessEvent(triggerContext triggerBean.TriggerContext, wfr *bean4.CdWorkflowRunnerDto, cdWorkflowId int, cdPipelineId int, triggeredBy int32, pluginRegistryImageDetails map[string][]string) error
 	HandleCdStageReTrigger(runner *pipelineConfig.CdWorkflowRunner) error
 	HandleCiStepFailedEvent(ciPipelineId int, request *bean2.CiArtifactWebhookRequest) (err error)
@@ -160,6 +168,10 @@ type WorkflowDagExecutorImpl struct {
 	ciHandlerService            trigger.HandlerService
 	workflowTriggerAuditService auditService.WorkflowTriggerAuditService
 	fluxApplicationService      fluxApplication.FluxApplicationService
+	metricVerificationService   metricVerification.MetricVerificationService
+	testReportService           testReport.TestReportService
+	sbomService                 sbom.SbomService
+	commitStatusService         commitStatus.CommitStatusService
 }
 
 func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pipelineConfig.PipelineRepository,
@@ -196,6 +208,10 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 	ciHandlerService trigger.HandlerService,
 	workflowTriggerAuditService auditService.WorkflowTriggerAuditService,
 	fluxApplicationService fluxApplication.FluxApplicationService,
+	metricVerificationService metricVerification.MetricVerificationService,
+	testReportService testReport.TestReportService,
+	sbomService sbom.SbomService,
+	commitStatusService commitStatus.CommitStatusService,
 ) *WorkflowDagExecutorImpl {
 	wde := &WorkflowDagExecutorImpl{logger: Logger,
 		pipelineRepository:            pipelineRepository,
@@ -231,7 +247,11 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 		workflowService:               workflowService,
 		ciHandlerService:              ciHandlerService,
 		workflowTriggerAuditService:   workflowTriggerAuditService,
-		fluxApplicationService:        fluxApplicationService}
+		fluxApplicationService:        fluxApplicationService,
+		metricVerificationService:     metricVerificationService,
+		testReportService:             testReportService,
+		sbomService:                   sbomService,
+		commitStatusService:           commitStatusService}
 	config, err := types.GetCdConfig()
 	if err != nil {
 		return nil
@@ -807,6 +827,34 @@ func (impl *WorkflowDagExecutorImpl) HandleDeploymentSuccessEvent(triggerContext
 	if pipelineOverride == nil {
 		return fmt.Errorf("invalid request, pipeline override not found")
 	}
+	deployRunner, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(context.Background(), pipelineOverride.CdWorkflowId, bean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment runner by cd workflow id", "pipelineOverride", pipelineOverride, "err", err)
+		return err
+	}
+	isHeldForVerification, err := impl.metricVerificationService.StartVerification(pipelineOverride, deployRunner.Id)
+	if err != nil {
+		impl.logger.Errorw("error in starting metric verification of deployment", "wfrId", deployRunner.Id, "err", err)
+		return err
+	}
+	if isHeldForVerification {
+		// post-cd and next pipelines are triggered by HandleMetricVerificationSuccessEvent
+		return nil
+	}
+	return impl.handlePostDeploymentSuccess(triggerContext, pipelineOverride)
+}
+
+func (impl *WorkflowDagExecutorImpl) HandleMetricVerificationSuccessEvent(triggerContext triggerBean.TriggerContext, pipelineOverrideId int) error {
+	pipelineOverride, err := impl.pipelineOverrideRepository.FindById(pipelineOverrideId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pipeline override by id", "pipelineOverrideId", pipelineOverrideId, "err", err)
+		return err
+	}
+	return impl.handlePostDeploymentSuccess(triggerContext, pipelineOverride)
+}
+
+// handlePostDeploymentSuccess triggers the post-cd of a successful deployment, or the next pipelines if there is no post-cd
+func (impl *WorkflowDagExecutorImpl) handlePostDeploymentSuccess(triggerContext triggerBean.TriggerContext, pipelineOverride *chartConfig.PipelineOverride) error {
 	cdWorkflow, err := impl.cdWorkflowRepository.FindById(pipelineOverride.CdWorkflowId)
 	if err != nil {
 		impl.logger.Errorw("error in fetching cd workflow by id", "pipelineOverride", pipelineOverride)
@@ -939,9 +987,18 @@ func (impl *WorkflowDagExecutorImpl) UpdateCiWorkflowForCiSuccess(request *bean2
 		impl.logger.Errorw("update wf failed for id ", "err", err)
 		return err
 	}
+	// test reports are ingested before the artifact is saved, so that test gates of auto triggered cd pipelines find the summary
+	impl.ingestCiTestReports(savedWorkflow.Id)
 	return nil
 }
 
+func (impl *WorkflowDagExecutorImpl) ingestCiTestReports(ciWorkflowId int) {
+	_, err := impl.testReportService.IngestTestReports(ciWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in ingesting test reports of ci workflow", "ciWorkflowId", ciWorkflowId, "err", err)
+	}
+}
+
 func (impl *WorkflowDagExecutorImpl) isArtifactScannedByPluginForPipeline(ciArtifact *repository.CiArtifact, pipelineId int,
 	pipelineStage repository4.PipelineStageType, pluginName string) (bool, bool, error) {
 	var isScanningDone bool
@@ -1004,6 +1061,12 @@ func (impl *WorkflowDagExecutorImpl) HandleCiSuccessEvent(triggerContext trigger
 		impl.logger.Errorw("error in saving material", "err", err)
 		return 0, err
 	}
+	// the sbom is not needed for triggering the cd pipelines, so the uploaded ci artifacts are read asynchronously
+	impl.asyncRunnable.Execute(func() {
+		if err := impl.sbomService.IngestCiStepSbom(buildArtifact); err != nil {
+			impl.logger.Errorw("error in ingesting sbom of artifact", "ciArtifactId", buildArtifact.Id, "err", err)
+		}
+	})
 
 	var pluginArtifacts []*repository.CiArtifact
 	for registry, artifacts := range request.PluginRegistryArtifactDetails {
@@ -1125,6 +1188,7 @@ func (impl *WorkflowDagExecutorImpl) WriteCiSuccessEvent(request *bean2.CiArtifa
 	event.CiArtifactId = artifact.Id
 	if artifact.WorkflowId != nil {
 		event.CiWorkflowRunnerId = *artifact.WorkflowId
+		impl.commitStatusService.ReportCiWorkflowStatusAsync(*artifact.WorkflowId, commitStatusBean.StateSuccess)
 	}
 	event.UserId = int(request.UserId)
 	event = impl.eventFactory.BuildExtraCIData(event, nil)
@@ -1163,6 +1227,7 @@ func (impl *WorkflowDagExecutorImpl) HandleCiStepFailedEvent(ciPipelineId int, r
 		}
 	}
 	impl.asyncRunnable.Execute(customTagServiceRunnableFunc)
+	impl.asyncRunnable.Execute(func() { impl.ingestCiTestReports(savedWorkflow.Id) })
 	if request.FailureReason != workFlow.CiFailed.String() {
 		notificationServiceRunnableFunc := func() {
 			impl.WriteCiStepFailedEvent(pipelineModel, request, savedWorkflow)
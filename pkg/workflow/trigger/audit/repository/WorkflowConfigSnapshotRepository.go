@@ -31,6 +31,7 @@ type WorkflowConfigSnapshot struct {
 	PipelineId                   int                `sql:"pipeline_id,notnull"`
 	WorkflowRequestJson          string             `sql:"workflow_request_json,notnull"`
 	WorkflowRequestSchemaVersion string             `sql:"workflow_request_schema_version"`
+	TriggerType                  string             `sql:"trigger_type"` // only captured for ci workflows
 	sql.AuditLog
 }
 
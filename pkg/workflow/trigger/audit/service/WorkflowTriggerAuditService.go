@@ -118,12 +118,15 @@ func (impl *WorkflowTriggerAuditServiceImpl) createWorkflowConfigSnapshot(workfl
 		return nil, err
 	}
 	workflowType, pipelineId, workflowId := types.PRE_CD_WORKFLOW_TYPE, workflowRequest.CdPipelineId, workflowRequest.WorkflowRunnerId
+	triggerType := ""
 	if workflowRequest.IsCdStageTypePost() {
 		workflowType = types.POST_CD_WORKFLOW_TYPE
 	} else if workflowRequest.IsCiTypeWorkflowRequest() {
 		workflowType, pipelineId, workflowId = types.CI_WORKFLOW_TYPE, workflowRequest.PipelineId, workflowRequest.WorkflowId
+		triggerType = workflowRequest.TriggerType.String()
 	}
 	configSnapshot := adapter.GetWorkflowConfigSnapshot(workflowId, workflowType, pipelineId, compressedWorkflowJson, types.TriggerAuditSchemaVersionV1, workflowRequest.TriggeredBy)
+	configSnapshot.TriggerType = triggerType
 	return configSnapshot, nil
 }
 
@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE "public"."workflow_config_snapshot"
+    DROP COLUMN IF EXISTS "trigger_type";
+
+ALTER TABLE "public"."ci_workflow"
+    DROP COLUMN IF EXISTS "trigger_type";
+
+DROP INDEX IF EXISTS "public"."idx_ci_pipeline_schedule_next_run_at";
+
+DROP INDEX IF EXISTS "public"."idx_unique_ci_pipeline_schedule_ci_pipeline_id";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."ci_pipeline_schedule";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_ci_pipeline_schedule;
+
+COMMIT;
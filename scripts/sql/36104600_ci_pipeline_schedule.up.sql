@@ -0,0 +1,43 @@
+BEGIN;
+
+-- Create Sequence for ci_pipeline_schedule
+CREATE SEQUENCE IF NOT EXISTS id_seq_ci_pipeline_schedule;
+
+-- cron based schedule for a ci pipeline or a job pipeline, one active schedule per pipeline
+CREATE TABLE IF NOT EXISTS "public"."ci_pipeline_schedule" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_ci_pipeline_schedule'::regclass),
+    "ci_pipeline_id"          int4            NOT NULL,
+    "cron_expression"         varchar(100)    NOT NULL,
+    "timezone"                varchar(100)    NOT NULL DEFAULT 'UTC',
+    "environment_id"          int4,           -- only used for job pipelines
+    "material_selection"      jsonb,          -- list of ci pipeline material ids with optional branch override
+    "skip_if_no_new_commit"   bool            NOT NULL DEFAULT FALSE,
+    "enabled"                 bool            NOT NULL DEFAULT TRUE,
+    "next_run_at"             timestamptz,
+    "last_run_at"             timestamptz,
+    "last_run_status"         varchar(50),
+    "last_run_message"        text,
+    "last_ci_workflow_id"     int4,
+    "active"                  bool            NOT NULL DEFAULT TRUE,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "ci_pipeline_schedule_ci_pipeline_id_fkey" FOREIGN KEY ("ci_pipeline_id") REFERENCES "public"."ci_pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_ci_pipeline_schedule_ci_pipeline_id"
+    ON "public"."ci_pipeline_schedule" ("ci_pipeline_id") WHERE "active" = TRUE;
+
+CREATE INDEX IF NOT EXISTS "idx_ci_pipeline_schedule_next_run_at"
+    ON "public"."ci_pipeline_schedule" ("next_run_at") WHERE "active" = TRUE AND "enabled" = TRUE;
+
+-- how a ci workflow was triggered, MANUAL, WEBHOOK or SCHEDULED
+ALTER TABLE "public"."ci_workflow"
+    ADD COLUMN IF NOT EXISTS "trigger_type" varchar(50);
+
+ALTER TABLE "public"."workflow_config_snapshot"
+    ADD COLUMN IF NOT EXISTS "trigger_type" varchar(50);
+
+COMMIT;
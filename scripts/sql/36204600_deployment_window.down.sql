@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."deployment_window_queued_trigger";
+DROP SEQUENCE IF EXISTS id_seq_deployment_window_queued_trigger;
+
+DROP TABLE IF EXISTS "public"."deployment_window_bypass_audit";
+DROP SEQUENCE IF EXISTS id_seq_deployment_window_bypass_audit;
+
+DROP TABLE IF EXISTS "public"."deployment_window";
+DROP SEQUENCE IF EXISTS id_seq_deployment_window;
+
+COMMIT;
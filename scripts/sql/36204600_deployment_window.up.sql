@@ -0,0 +1,81 @@
+BEGIN;
+
+-- Create Sequence for deployment_window
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_window;
+
+-- environment scoped maintenance windows (deployments allowed only inside) and blackout periods (no deployments inside)
+CREATE TABLE IF NOT EXISTS "public"."deployment_window" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_deployment_window'::regclass),
+    "name"                varchar(250)    NOT NULL,
+    "description"         text,
+    "environment_id"      int4            NOT NULL,
+    "window_type"         varchar(50)     NOT NULL, -- MAINTENANCE or BLACKOUT
+    "schedule_type"       varchar(50)     NOT NULL, -- ONE_OFF or RECURRING
+    "cron_expression"     varchar(100),             -- start of every occurrence, only for RECURRING
+    "duration_minutes"    int4,                     -- length of every occurrence, only for RECURRING
+    "start_time"          timestamptz,              -- only for ONE_OFF
+    "end_time"            timestamptz,              -- only for ONE_OFF
+    "timezone"            varchar(100)    NOT NULL DEFAULT 'UTC',
+    "enabled"             bool            NOT NULL DEFAULT TRUE,
+    "active"              bool            NOT NULL DEFAULT TRUE,
+    "created_on"          timestamptz     NOT NULL,
+    "created_by"          int4            NOT NULL,
+    "updated_on"          timestamptz     NOT NULL,
+    "updated_by"          int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "deployment_window_environment_id_fkey" FOREIGN KEY ("environment_id") REFERENCES "public"."environment" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_deployment_window_environment_id"
+    ON "public"."deployment_window" ("environment_id") WHERE "active" = TRUE;
+
+-- Create Sequence for deployment_window_bypass_audit
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_window_bypass_audit;
+
+-- super admin triggers done while the environment was closed for deployments
+CREATE TABLE IF NOT EXISTS "public"."deployment_window_bypass_audit" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_deployment_window_bypass_audit'::regclass),
+    "cd_workflow_runner_id"   int4            NOT NULL,
+    "pipeline_id"             int4            NOT NULL,
+    "environment_id"          int4            NOT NULL,
+    "deployment_window_ids"   jsonb,
+    "message"                 text,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "deployment_window_bypass_audit_cd_workflow_runner_id_fkey" FOREIGN KEY ("cd_workflow_runner_id") REFERENCES "public"."cd_workflow_runner" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_deployment_window_bypass_audit_environment_id"
+    ON "public"."deployment_window_bypass_audit" ("environment_id");
+
+-- Create Sequence for deployment_window_queued_trigger
+CREATE SEQUENCE IF NOT EXISTS id_seq_deployment_window_queued_trigger;
+
+-- automatic triggers blocked by a deployment window, released once the environment opens
+CREATE TABLE IF NOT EXISTS "public"."deployment_window_queued_trigger" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_deployment_window_queued_trigger'::regclass),
+    "pipeline_id"         int4            NOT NULL,
+    "environment_id"      int4            NOT NULL,
+    "ci_artifact_id"      int4            NOT NULL,
+    "cd_workflow_id"      int4,
+    "workflow_type"       varchar(50)     NOT NULL,
+    "triggered_by"        int4            NOT NULL,
+    "status"              varchar(50)     NOT NULL, -- QUEUED, RELEASED, SUPERSEDED or FAILED
+    "status_message"      text,
+    "release_after"       timestamptz,
+    "released_on"         timestamptz,
+    "created_on"          timestamptz     NOT NULL,
+    "created_by"          int4            NOT NULL,
+    "updated_on"          timestamptz     NOT NULL,
+    "updated_by"          int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "deployment_window_queued_trigger_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_deployment_window_queued_trigger_status"
+    ON "public"."deployment_window_queued_trigger" ("status", "release_after");
+
+COMMIT;
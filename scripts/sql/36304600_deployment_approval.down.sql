@@ -0,0 +1,16 @@
+BEGIN;
+
+DELETE FROM "public"."notification_templates" WHERE event_type_id = 10;
+DELETE FROM "public"."notifier_event_log" WHERE event_type_id = 10;
+DELETE FROM "public"."event" WHERE id = 10;
+
+DROP INDEX IF EXISTS "public"."idx_deployment_approval_request_pipeline_artifact";
+
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "cd_workflow_id";
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "auto_trigger_pending";
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "expires_on";
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "comment";
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "required_approvals";
+ALTER TABLE "public"."deployment_approval_request" DROP COLUMN IF EXISTS "status";
+
+COMMIT;
@@ -0,0 +1,58 @@
+BEGIN;
+
+-- approval policy of a cd pipeline is kept in pipeline.user_approval_config,
+-- approval requests and user actions are kept in deployment_approval_request and request_approval_user_data
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "status" varchar(50);
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "required_approvals" integer;
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "comment" varchar(1000);
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "expires_on" timestamptz;
+-- set when an automatic deployment is waiting for this request, the deployment is triggered once approved
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "auto_trigger_pending" bool NOT NULL DEFAULT false;
+ALTER TABLE "public"."deployment_approval_request" ADD COLUMN IF NOT EXISTS "cd_workflow_id" integer;
+
+CREATE INDEX IF NOT EXISTS "idx_deployment_approval_request_pipeline_artifact"
+    ON "public"."deployment_approval_request" ("pipeline_id", "ci_artifact_id")
+    WHERE "active" = true;
+
+INSERT INTO "public"."event" (id, event_type, description) VALUES (10, 'APPROVAL ACTION', '');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('ses', 'CD', 10, 'CD approval action ses template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "🛎️ Image approval {{approvalRequestStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Image approval {{approvalRequestStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">by {{approvalActionBy}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Comment</div><div style=\"color:#000a14;font-size:14px\">{{approvalComment}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('smtp', 'CD', 10, 'CD approval action smtp template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "🛎️ Image approval {{approvalRequestStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Image approval {{approvalRequestStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">by {{approvalActionBy}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Comment</div><div style=\"color:#000a14;font-size:14px\">{{approvalComment}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CD', 10, 'CD approval action slack template', '{
+    "text": ":bell: Image approval {{approvalRequestStatus}} | Application > {{appName}} | Environment > {{envName}}",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":bell: *Image approval {{approvalRequestStatus}}*\n<!date^{{eventTime}}^{date_long} {time} | \"-\"> \n by {{approvalActionBy}}"
+            }
+        },
+        {
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*Application*\n{{appName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Environment*\n{{envName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Image*\n{{dockerImageUrl}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Comment*\n{{approvalComment}}"
+                }
+            ]
+        }
+    ]
+}');
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM "public"."notification_templates" WHERE event_type_id = 11;
+DELETE FROM "public"."notifier_event_log" WHERE event_type_id = 11;
+DELETE FROM "public"."event" WHERE id = 11;
+
+DROP TABLE IF EXISTS "public"."auto_rollback_history";
+DROP SEQUENCE IF EXISTS "public"."id_seq_auto_rollback_history";
+
+DROP TABLE IF EXISTS "public"."auto_rollback_policy";
+DROP SEQUENCE IF EXISTS "public"."id_seq_auto_rollback_policy";
+
+COMMIT;
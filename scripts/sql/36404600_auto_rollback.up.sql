@@ -0,0 +1,96 @@
+BEGIN;
+
+-- Create Sequence for auto_rollback_policy
+CREATE SEQUENCE IF NOT EXISTS id_seq_auto_rollback_policy;
+
+-- opt-in auto rollback of a cd pipeline, deployments not healthy within the health timeout are rolled back
+CREATE TABLE IF NOT EXISTS "public"."auto_rollback_policy" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_auto_rollback_policy'::regclass),
+    "pipeline_id"             int4            NOT NULL,
+    "enabled"                 bool            NOT NULL DEFAULT TRUE,
+    "health_timeout_minutes"  int4            NOT NULL,
+    "active"                  bool            NOT NULL DEFAULT TRUE,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "auto_rollback_policy_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_auto_rollback_policy_pipeline_id"
+    ON "public"."auto_rollback_policy" ("pipeline_id") WHERE "active" = TRUE;
+
+-- Create Sequence for auto_rollback_history
+CREATE SEQUENCE IF NOT EXISTS id_seq_auto_rollback_history;
+
+-- one entry per unhealthy deployment considered for auto rollback
+CREATE TABLE IF NOT EXISTS "public"."auto_rollback_history" (
+    "id"                              int4            NOT NULL DEFAULT nextval('id_seq_auto_rollback_history'::regclass),
+    "pipeline_id"                     int4            NOT NULL,
+    "failed_cd_workflow_runner_id"    int4            NOT NULL,
+    "failed_status"                   varchar(50)     NOT NULL,
+    "target_cd_workflow_runner_id"    int4,                     -- last healthy deployment which is redeployed
+    "ci_artifact_id"                  int4,
+    "rollback_cd_workflow_runner_id"  int4,                     -- deployment created by the rollback
+    "status"                          varchar(50)     NOT NULL, -- INITIATED, TRIGGERED, FAILED or SKIPPED
+    "message"                         text,
+    "created_on"                      timestamptz     NOT NULL,
+    "created_by"                      int4            NOT NULL,
+    "updated_on"                      timestamptz     NOT NULL,
+    "updated_by"                      int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- a deployment is rolled back at most once, the index also stops replicas from rolling back the same deployment
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_auto_rollback_history_failed_wfr_id"
+    ON "public"."auto_rollback_history" ("failed_cd_workflow_runner_id");
+
+CREATE INDEX IF NOT EXISTS "idx_auto_rollback_history_rollback_wfr_id"
+    ON "public"."auto_rollback_history" ("rollback_cd_workflow_runner_id");
+
+CREATE INDEX IF NOT EXISTS "idx_auto_rollback_history_pipeline_id"
+    ON "public"."auto_rollback_history" ("pipeline_id");
+
+INSERT INTO "public"."event" (id, event_type, description) VALUES (11, 'AUTO ROLLBACK', '');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('ses', 'CD', 11, 'CD auto rollback ses template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "🔁 Auto rollback {{autoRollbackStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Auto rollback {{autoRollbackStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{autoRollbackMessage}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Rolled back to</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Failed deployment</div><div style=\"color:#000a14;font-size:14px\">{{failedDockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('smtp', 'CD', 11, 'CD auto rollback smtp template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "🔁 Auto rollback {{autoRollbackStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Auto rollback {{autoRollbackStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{autoRollbackMessage}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Rolled back to</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Failed deployment</div><div style=\"color:#000a14;font-size:14px\">{{failedDockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CD', 11, 'CD auto rollback slack template', '{
+    "text": ":rewind: Auto rollback {{autoRollbackStatus}} | Application > {{appName}} | Environment > {{envName}}",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":rewind: *Auto rollback {{autoRollbackStatus}}*\n<!date^{{eventTime}}^{date_long} {time} | \"-\"> \n {{autoRollbackMessage}}"
+            }
+        },
+        {
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*Application*\n{{appName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Environment*\n{{envName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Rolled back to*\n{{dockerImageUrl}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Failed deployment*\n{{failedDockerImageUrl}}"
+                }
+            ]
+        }
+    ]
+}');
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."metric_verification_result";
+DROP SEQUENCE IF EXISTS "public"."id_seq_metric_verification_result";
+
+DROP TABLE IF EXISTS "public"."metric_verification_run";
+DROP SEQUENCE IF EXISTS "public"."id_seq_metric_verification_run";
+
+DROP TABLE IF EXISTS "public"."metric_verification_query";
+DROP SEQUENCE IF EXISTS "public"."id_seq_metric_verification_query";
+
+DROP TABLE IF EXISTS "public"."metric_verification_policy";
+DROP SEQUENCE IF EXISTS "public"."id_seq_metric_verification_policy";
+
+COMMIT;
@@ -0,0 +1,101 @@
+BEGIN;
+
+-- Create Sequence for metric_verification_policy
+CREATE SEQUENCE IF NOT EXISTS id_seq_metric_verification_policy;
+
+-- opt-in metric verification of a cd pipeline, healthy deployments are verified against prometheus queries before post-cd
+CREATE TABLE IF NOT EXISTS "public"."metric_verification_policy" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_metric_verification_policy'::regclass),
+    "pipeline_id"         int4            NOT NULL,
+    "enabled"             bool            NOT NULL DEFAULT TRUE,
+    "duration_minutes"    int4            NOT NULL,
+    "interval_seconds"    int4            NOT NULL,
+    "failure_limit"       int4            NOT NULL DEFAULT 0, -- failed evaluations tolerated before the deployment is failed
+    "active"              bool            NOT NULL DEFAULT TRUE,
+    "created_on"          timestamptz     NOT NULL,
+    "created_by"          int4            NOT NULL,
+    "updated_on"          timestamptz     NOT NULL,
+    "updated_by"          int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "metric_verification_policy_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_metric_verification_policy_pipeline_id"
+    ON "public"."metric_verification_policy" ("pipeline_id") WHERE "active" = TRUE;
+
+-- Create Sequence for metric_verification_query
+CREATE SEQUENCE IF NOT EXISTS id_seq_metric_verification_query;
+
+CREATE TABLE IF NOT EXISTS "public"."metric_verification_query" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_metric_verification_query'::regclass),
+    "policy_id"           int4            NOT NULL,
+    "name"                varchar(250)    NOT NULL,
+    "query"               text            NOT NULL,
+    "operator"            varchar(10)     NOT NULL, -- LT, LTE, GT or GTE, the condition a healthy value satisfies
+    "threshold"           float8          NOT NULL,
+    "fail_on_no_data"     bool            NOT NULL DEFAULT FALSE,
+    "active"              bool            NOT NULL DEFAULT TRUE,
+    "created_on"          timestamptz     NOT NULL,
+    "created_by"          int4            NOT NULL,
+    "updated_on"          timestamptz     NOT NULL,
+    "updated_by"          int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "metric_verification_query_policy_id_fkey" FOREIGN KEY ("policy_id") REFERENCES "public"."metric_verification_policy" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_metric_verification_query_policy_id"
+    ON "public"."metric_verification_query" ("policy_id");
+
+-- Create Sequence for metric_verification_run
+CREATE SEQUENCE IF NOT EXISTS id_seq_metric_verification_run;
+
+-- one entry per verified deployment
+CREATE TABLE IF NOT EXISTS "public"."metric_verification_run" (
+    "id"                       int4            NOT NULL DEFAULT nextval('id_seq_metric_verification_run'::regclass),
+    "pipeline_id"              int4            NOT NULL,
+    "cd_workflow_runner_id"    int4            NOT NULL,
+    "pipeline_override_id"     int4            NOT NULL,
+    "status"                   varchar(50)     NOT NULL, -- RUNNING, PASSED or FAILED
+    "message"                  text,
+    "started_on"               timestamptz     NOT NULL,
+    "ends_on"                  timestamptz     NOT NULL,
+    "next_evaluation_on"       timestamptz     NOT NULL,
+    "finished_on"              timestamptz,
+    "evaluation_count"         int4            NOT NULL DEFAULT 0,
+    "failed_evaluation_count"  int4            NOT NULL DEFAULT 0,
+    "created_on"               timestamptz     NOT NULL,
+    "created_by"               int4            NOT NULL,
+    "updated_on"               timestamptz     NOT NULL,
+    "updated_by"               int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "metric_verification_run_cd_workflow_runner_id_fkey" FOREIGN KEY ("cd_workflow_runner_id") REFERENCES "public"."cd_workflow_runner" ("id")
+);
+
+-- a deployment is verified at most once, the index also stops replicas from starting the same verification
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_metric_verification_run_wfr_id"
+    ON "public"."metric_verification_run" ("cd_workflow_runner_id");
+
+CREATE INDEX IF NOT EXISTS "idx_metric_verification_run_status"
+    ON "public"."metric_verification_run" ("status", "next_evaluation_on");
+
+-- Create Sequence for metric_verification_result
+CREATE SEQUENCE IF NOT EXISTS id_seq_metric_verification_result;
+
+-- one entry per query per evaluation of a run
+CREATE TABLE IF NOT EXISTS "public"."metric_verification_result" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_metric_verification_result'::regclass),
+    "run_id"              int4            NOT NULL,
+    "query_name"          varchar(250)    NOT NULL,
+    "query"               text            NOT NULL,
+    "value"               float8,                   -- null if the query returned no data or errored
+    "passed"              bool            NOT NULL,
+    "message"             text,
+    "evaluated_on"        timestamptz     NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "metric_verification_result_run_id_fkey" FOREIGN KEY ("run_id") REFERENCES "public"."metric_verification_run" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_metric_verification_result_run_id"
+    ON "public"."metric_verification_result" ("run_id");
+
+COMMIT;
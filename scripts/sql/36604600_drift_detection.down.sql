@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM "public"."notification_templates" WHERE event_type_id = 12;
+DELETE FROM "public"."notifier_event_log" WHERE event_type_id = 12;
+DELETE FROM "public"."event" WHERE id = 12;
+
+DROP TABLE IF EXISTS "public"."drift_status";
+DROP SEQUENCE IF EXISTS "public"."id_seq_drift_status";
+
+DROP TABLE IF EXISTS "public"."drift_detection_config";
+DROP SEQUENCE IF EXISTS "public"."id_seq_drift_detection_config";
+
+COMMIT;
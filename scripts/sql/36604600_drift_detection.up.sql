@@ -0,0 +1,89 @@
+BEGIN;
+
+-- Create Sequence for drift_detection_config
+CREATE SEQUENCE IF NOT EXISTS id_seq_drift_detection_config;
+
+-- opt-in drift detection of a cd pipeline, its last deployment is compared with the live objects periodically
+CREATE TABLE IF NOT EXISTS "public"."drift_detection_config" (
+    "id"                 int4            NOT NULL DEFAULT nextval('id_seq_drift_detection_config'::regclass),
+    "pipeline_id"        int4            NOT NULL,
+    "enabled"            bool            NOT NULL DEFAULT TRUE,
+    "notify"             bool            NOT NULL DEFAULT FALSE,
+    "auto_reconcile"     bool            NOT NULL DEFAULT FALSE,
+    "last_checked_on"    timestamptz,              -- claimed by a replica before a periodic check
+    "active"             bool            NOT NULL DEFAULT TRUE,
+    "created_on"         timestamptz     NOT NULL,
+    "created_by"         int4            NOT NULL,
+    "updated_on"         timestamptz     NOT NULL,
+    "updated_by"         int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "drift_detection_config_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_drift_detection_config_pipeline_id"
+    ON "public"."drift_detection_config" ("pipeline_id") WHERE "active" = TRUE;
+
+-- Create Sequence for drift_status
+CREATE SEQUENCE IF NOT EXISTS id_seq_drift_status;
+
+-- latest drift of a cd pipeline from its last deployment
+CREATE TABLE IF NOT EXISTS "public"."drift_status" (
+    "id"                                int4            NOT NULL DEFAULT nextval('id_seq_drift_status'::regclass),
+    "pipeline_id"                       int4            NOT NULL,
+    "cd_workflow_runner_id"             int4,                     -- deployment the live objects were compared with
+    "status"                            varchar(50)     NOT NULL, -- IN_SYNC, DRIFTED or ERROR
+    "drifted_resources"                 int4            NOT NULL DEFAULT 0,
+    "diff"                              text,                     -- json field level diff of the drifted resources
+    "message"                           text,
+    "drift_detected_on"                 timestamptz,
+    "last_checked_on"                   timestamptz     NOT NULL,
+    "reconcile_cd_workflow_runner_id"   int4,                     -- deployment triggered to reconcile the drift
+    "created_on"                        timestamptz     NOT NULL,
+    "created_by"                        int4            NOT NULL,
+    "updated_on"                        timestamptz     NOT NULL,
+    "updated_by"                        int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "drift_status_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_drift_status_pipeline_id"
+    ON "public"."drift_status" ("pipeline_id");
+
+INSERT INTO "public"."event" (id, event_type, description) VALUES (12, 'DRIFT DETECTED', '');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('ses', 'CD', 12, 'CD drift detected ses template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "⚠️ Drift detected | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Drift detected</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{driftSummary}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Deployed image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('smtp', 'CD', 12, 'CD drift detected smtp template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "⚠️ Drift detected | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Drift detected</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{driftSummary}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td colspan=\"2\"><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Deployed image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CD', 12, 'CD drift detected slack template', '{
+    "text": ":warning: Drift detected | Application > {{appName}} | Environment > {{envName}}",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":warning: *Drift detected*\n<!date^{{eventTime}}^{date_long} {time} | \"-\"> \n {{driftSummary}}"
+            }
+        },
+        {
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*Application*\n{{appName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Environment*\n{{envName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Deployed image*\n{{dockerImageUrl}}"
+                }
+            ]
+        }
+    ]
+}');
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."gitops_pull_request";
+DROP SEQUENCE IF EXISTS "public"."id_seq_gitops_pull_request";
+
+DROP TABLE IF EXISTS "public"."gitops_pull_request_config";
+DROP SEQUENCE IF EXISTS "public"."id_seq_gitops_pull_request_config";
+
+COMMIT;
@@ -0,0 +1,55 @@
+BEGIN;
+
+-- Create Sequence for gitops_pull_request_config
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_pull_request_config;
+
+-- environments in gitops pull request mode, deployments to them are committed through a reviewed pull request
+CREATE TABLE IF NOT EXISTS "public"."gitops_pull_request_config" (
+    "id"                 int4            NOT NULL DEFAULT nextval('id_seq_gitops_pull_request_config'::regclass),
+    "environment_id"     int4            NOT NULL,
+    "enabled"            bool            NOT NULL DEFAULT TRUE,
+    "active"             bool            NOT NULL DEFAULT TRUE,
+    "created_on"         timestamptz     NOT NULL,
+    "created_by"         int4            NOT NULL,
+    "updated_on"         timestamptz     NOT NULL,
+    "updated_by"         int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gitops_pull_request_config_environment_id_fkey" FOREIGN KEY ("environment_id") REFERENCES "public"."environment" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_gitops_pull_request_config_environment_id"
+    ON "public"."gitops_pull_request_config" ("environment_id") WHERE "active" = TRUE;
+
+-- Create Sequence for gitops_pull_request
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_pull_request;
+
+-- pull request opened for a deployment, the deployment is continued once it is merged
+CREATE TABLE IF NOT EXISTS "public"."gitops_pull_request" (
+    "id"                        int4            NOT NULL DEFAULT nextval('id_seq_gitops_pull_request'::regclass),
+    "pipeline_id"               int4            NOT NULL,
+    "cd_workflow_runner_id"     int4            NOT NULL,
+    "pipeline_override_id"      int4            NOT NULL,
+    "repo_name"                 varchar(250)    NOT NULL,
+    "source_branch"             varchar(250)    NOT NULL,
+    "target_branch"             varchar(250)    NOT NULL,
+    "pull_request_id"           int4            NOT NULL, -- id/number of the pull request on the git provider
+    "pull_request_url"          text,
+    "status"                    varchar(50)     NOT NULL, -- OPEN, MERGED, CLOSED or SUPERSEDED
+    "merge_commit_hash"         varchar(100),
+    "message"                   text,
+    "created_on"                timestamptz     NOT NULL,
+    "created_by"                int4            NOT NULL,
+    "updated_on"                timestamptz     NOT NULL,
+    "updated_by"                int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gitops_pull_request_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id"),
+    CONSTRAINT "gitops_pull_request_cd_workflow_runner_id_fkey" FOREIGN KEY ("cd_workflow_runner_id") REFERENCES "public"."cd_workflow_runner" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_gitops_pull_request_cd_workflow_runner_id"
+    ON "public"."gitops_pull_request" ("cd_workflow_runner_id");
+
+CREATE INDEX IF NOT EXISTS "idx_gitops_pull_request_status"
+    ON "public"."gitops_pull_request" ("status");
+
+COMMIT;
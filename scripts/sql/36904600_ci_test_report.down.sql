@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "public"."idx_unique_cd_pipeline_test_gate_pipeline_id";
+DROP TABLE IF EXISTS "public"."cd_pipeline_test_gate";
+DROP SEQUENCE IF EXISTS "public"."id_seq_cd_pipeline_test_gate";
+
+DROP INDEX IF EXISTS "public"."idx_unique_ci_workflow_test_summary_ci_workflow_id";
+DROP TABLE IF EXISTS "public"."ci_workflow_test_summary";
+DROP SEQUENCE IF EXISTS "public"."id_seq_ci_workflow_test_summary";
+
+ALTER TABLE "public"."pipeline_stage_step" DROP COLUMN IF EXISTS "test_report_paths";
+
+COMMIT;
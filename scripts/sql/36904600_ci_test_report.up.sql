@@ -0,0 +1,58 @@
+BEGIN;
+
+-- report paths declared on a ci step, uploaded by the ci runner along with the step artifacts
+ALTER TABLE "public"."pipeline_stage_step"
+    ADD COLUMN IF NOT EXISTS "test_report_paths" text[];
+
+-- Create Sequence for ci_workflow_test_summary
+CREATE SEQUENCE IF NOT EXISTS id_seq_ci_workflow_test_summary;
+
+-- test summary parsed from the JUnit/test2json/TAP reports of a ci workflow
+CREATE TABLE IF NOT EXISTS "public"."ci_workflow_test_summary" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_ci_workflow_test_summary'::regclass),
+    "ci_workflow_id"          int4            NOT NULL,
+    "status"                  varchar(50)     NOT NULL,
+    "total"                   int4            NOT NULL DEFAULT 0,
+    "passed"                  int4            NOT NULL DEFAULT 0,
+    "failed"                  int4            NOT NULL DEFAULT 0,
+    "skipped"                 int4            NOT NULL DEFAULT 0,
+    "duration_ms"             int8            NOT NULL DEFAULT 0,
+    "formats"                 text[],
+    "report_files"            text[],
+    "failed_tests"            jsonb,          -- capped list of failed test cases with their failure messages
+    "slowest_tests"           jsonb,          -- slowest test cases of the workflow
+    "message"                 text,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "ci_workflow_test_summary_ci_workflow_id_fkey" FOREIGN KEY ("ci_workflow_id") REFERENCES "public"."ci_workflow" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_ci_workflow_test_summary_ci_workflow_id"
+    ON "public"."ci_workflow_test_summary" ("ci_workflow_id");
+
+-- Create Sequence for cd_pipeline_test_gate
+CREATE SEQUENCE IF NOT EXISTS id_seq_cd_pipeline_test_gate;
+
+-- test result requirements an artifact must meet before being deployed by a cd pipeline
+CREATE TABLE IF NOT EXISTS "public"."cd_pipeline_test_gate" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_cd_pipeline_test_gate'::regclass),
+    "pipeline_id"             int4            NOT NULL,
+    "block_on_failed_tests"   bool            NOT NULL DEFAULT TRUE,
+    "min_pass_rate"           float8          NOT NULL DEFAULT 0,
+    "fail_if_report_missing"  bool            NOT NULL DEFAULT FALSE,
+    "active"                  bool            NOT NULL DEFAULT TRUE,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "cd_pipeline_test_gate_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_cd_pipeline_test_gate_pipeline_id"
+    ON "public"."cd_pipeline_test_gate" ("pipeline_id") WHERE "active" = TRUE;
+
+COMMIT;
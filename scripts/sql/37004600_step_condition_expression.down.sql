@@ -0,0 +1,2 @@
+ALTER TABLE "public"."pipeline_stage_step_condition"
+    DROP COLUMN IF EXISTS "condition_expression";
@@ -0,0 +1,2 @@
+ALTER TABLE "public"."pipeline_stage_step_condition"
+    ADD COLUMN IF NOT EXISTS "condition_expression" text;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "public"."plugin_metadata" DROP COLUMN IF EXISTS "default_retry_policy";
+ALTER TABLE "public"."pipeline_stage_step" DROP COLUMN IF EXISTS "retry_policy";
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- retry policy of a pre/post ci or cd step, e.g. {"maxAttempts":3,"backoffType":"EXPONENTIAL","backoffSeconds":10,"retryOnExitCodes":[1]}
+ALTER TABLE "public"."pipeline_stage_step" ADD COLUMN IF NOT EXISTS "retry_policy" jsonb;
+
+-- default retry policy used by steps referring the plugin version when they don't define one
+ALTER TABLE "public"."plugin_metadata" ADD COLUMN IF NOT EXISTS "default_retry_policy" jsonb;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "public"."idx_unique_ci_build_queue_limit_scope";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."ci_build_queue_limit";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_ci_build_queue_limit;
+
+DROP INDEX IF EXISTS "public"."idx_ci_build_queue_ci_pipeline_id_branch";
+
+DROP INDEX IF EXISTS "public"."idx_ci_build_queue_status_priority";
+
+DROP INDEX IF EXISTS "public"."idx_unique_ci_build_queue_ci_workflow_id";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."ci_build_queue";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_ci_build_queue;
+
+COMMIT;
@@ -0,0 +1,62 @@
+BEGIN;
+
+-- Create Sequence for ci_build_queue
+CREATE SEQUENCE IF NOT EXISTS id_seq_ci_build_queue;
+
+-- ci triggers waiting for a free build slot, the queued ci_workflow is created at enqueue time
+-- and is submitted once the concurrency limits of its pipeline, app, project and the global limit allow
+CREATE TABLE IF NOT EXISTS "public"."ci_build_queue" (
+    "id"                 int4            NOT NULL DEFAULT nextval('id_seq_ci_build_queue'::regclass),
+    "ci_workflow_id"     int4            NOT NULL,
+    "ci_pipeline_id"     int4            NOT NULL,
+    "app_id"             int4            NOT NULL,
+    "team_id"            int4            NOT NULL,
+    "branch"             varchar(250),
+    "trigger_type"       varchar(50)     NOT NULL,
+    "priority"           int4            NOT NULL DEFAULT 0,
+    "status"             varchar(50)     NOT NULL,
+    "status_message"     text,
+    "trigger_request"    jsonb           NOT NULL,
+    "triggered_by"       int4            NOT NULL,
+    "dispatched_on"      timestamptz,
+    "dispatched_by"      varchar(250),   -- orchestrator replica which claimed the queued build
+    "created_on"         timestamptz     NOT NULL,
+    "created_by"         int4            NOT NULL,
+    "updated_on"         timestamptz     NOT NULL,
+    "updated_by"         int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "ci_build_queue_ci_workflow_id_fkey" FOREIGN KEY ("ci_workflow_id") REFERENCES "public"."ci_workflow" ("id"),
+    CONSTRAINT "ci_build_queue_ci_pipeline_id_fkey" FOREIGN KEY ("ci_pipeline_id") REFERENCES "public"."ci_pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_ci_build_queue_ci_workflow_id"
+    ON "public"."ci_build_queue" ("ci_workflow_id");
+
+CREATE INDEX IF NOT EXISTS "idx_ci_build_queue_status_priority"
+    ON "public"."ci_build_queue" ("status", "priority" DESC, "id");
+
+CREATE INDEX IF NOT EXISTS "idx_ci_build_queue_ci_pipeline_id_branch"
+    ON "public"."ci_build_queue" ("ci_pipeline_id", "branch") WHERE "status" = 'QUEUED';
+
+-- Create Sequence for ci_build_queue_limit
+CREATE SEQUENCE IF NOT EXISTS id_seq_ci_build_queue_limit;
+
+-- concurrency limit overrides, scope_id is the ci pipeline, app or team id and is 0 for the global scope
+CREATE TABLE IF NOT EXISTS "public"."ci_build_queue_limit" (
+    "id"                      int4            NOT NULL DEFAULT nextval('id_seq_ci_build_queue_limit'::regclass),
+    "scope_type"              varchar(50)     NOT NULL,
+    "scope_id"                int4            NOT NULL DEFAULT 0,
+    "max_concurrent_builds"   int4            NOT NULL DEFAULT 0, -- 0 means unlimited
+    "cancel_superseded"       bool,                               -- null inherits from the wider scope
+    "active"                  bool            NOT NULL DEFAULT TRUE,
+    "created_on"              timestamptz     NOT NULL,
+    "created_by"              int4            NOT NULL,
+    "updated_on"              timestamptz     NOT NULL,
+    "updated_by"              int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_ci_build_queue_limit_scope"
+    ON "public"."ci_build_queue_limit" ("scope_type", "scope_id") WHERE "active" = TRUE;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "public"."idx_ci_artifact_image_digest";
+
+DROP INDEX IF EXISTS "public"."idx_ci_artifact_image";
+
+DROP INDEX IF EXISTS "public"."idx_artifact_gc_run_item_ci_artifact_id";
+
+DROP INDEX IF EXISTS "public"."idx_artifact_gc_run_item_gc_run_id";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."artifact_gc_run_item";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_artifact_gc_run_item;
+
+DROP INDEX IF EXISTS "public"."idx_artifact_gc_run_policy_id_status";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."artifact_gc_run";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_artifact_gc_run;
+
+DROP INDEX IF EXISTS "public"."idx_unique_artifact_retention_policy_scope";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."artifact_retention_policy";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_artifact_retention_policy;
+
+COMMIT;
@@ -0,0 +1,99 @@
+BEGIN;
+
+-- Create Sequence for artifact_retention_policy
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_retention_policy;
+
+-- retention policy of the build artifacts of an app or of all the apps of a project (team), the app policy wins
+CREATE TABLE IF NOT EXISTS "public"."artifact_retention_policy" (
+    "id"                          int4            NOT NULL DEFAULT nextval('id_seq_artifact_retention_policy'::regclass),
+    "scope_type"                  varchar(50)     NOT NULL,
+    "scope_id"                    int4            NOT NULL,
+    "keep_last_n"                 int4            NOT NULL,           -- latest artifacts kept per ci pipeline
+    "keep_deployed_within_days"   int4            NOT NULL DEFAULT 0, -- artifacts deployed within these days are kept
+    "delete_registry_tags"        bool            NOT NULL DEFAULT FALSE,
+    "delete_build_logs"           bool            NOT NULL DEFAULT TRUE,
+    "delete_build_cache"          bool            NOT NULL DEFAULT TRUE,
+    "active"                      bool            NOT NULL DEFAULT TRUE,
+    "created_on"                  timestamptz     NOT NULL,
+    "created_by"                  int4            NOT NULL,
+    "updated_on"                  timestamptz     NOT NULL,
+    "updated_by"                  int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_artifact_retention_policy_scope"
+    ON "public"."artifact_retention_policy" ("scope_type", "scope_id") WHERE "active" = TRUE;
+
+-- Create Sequence for artifact_gc_run
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_gc_run;
+
+-- a garbage collection run of a policy, every run starts as a dry run report and deletes only once executed
+CREATE TABLE IF NOT EXISTS "public"."artifact_gc_run" (
+    "id"                 int4            NOT NULL DEFAULT nextval('id_seq_artifact_gc_run'::regclass),
+    "policy_id"          int4            NOT NULL,
+    "status"             varchar(50)     NOT NULL,
+    "status_message"     text,
+    "trigger_type"       varchar(50)     NOT NULL,
+    "kept_count"         int4            NOT NULL DEFAULT 0,
+    "planned_count"      int4            NOT NULL DEFAULT 0,
+    "deleted_count"      int4            NOT NULL DEFAULT 0,
+    "skipped_count"      int4            NOT NULL DEFAULT 0,
+    "failed_count"       int4            NOT NULL DEFAULT 0,
+    "executed_on"        timestamptz,
+    "executed_by"        int4,
+    "finished_on"        timestamptz,
+    "created_on"         timestamptz     NOT NULL,
+    "created_by"         int4            NOT NULL,
+    "updated_on"         timestamptz     NOT NULL,
+    "updated_by"         int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "artifact_gc_run_policy_id_fkey" FOREIGN KEY ("policy_id") REFERENCES "public"."artifact_retention_policy" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_artifact_gc_run_policy_id_status"
+    ON "public"."artifact_gc_run" ("policy_id", "status");
+
+-- Create Sequence for artifact_gc_run_item
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_gc_run_item;
+
+-- an artifact or a ci pipeline build cache planned for deletion in a run, no foreign key on ci_artifact
+-- as the report outlives the deleted artifact
+CREATE TABLE IF NOT EXISTS "public"."artifact_gc_run_item" (
+    "id"                     int4            NOT NULL DEFAULT nextval('id_seq_artifact_gc_run_item'::regclass),
+    "gc_run_id"              int4            NOT NULL,
+    "action"                 varchar(50)     NOT NULL,
+    "ci_artifact_id"         int4,
+    "ci_workflow_id"         int4,
+    "ci_pipeline_id"         int4            NOT NULL,
+    "app_id"                 int4            NOT NULL,
+    "image"                  text,
+    "artifact_created_on"    timestamptz,
+    "last_deployed_on"       timestamptz,
+    "reason"                 text,
+    "status"                 varchar(50)     NOT NULL,
+    "status_message"         text,
+    "registry_tag_deleted"   bool            NOT NULL DEFAULT FALSE,
+    "blob_objects_deleted"   bool            NOT NULL DEFAULT FALSE,
+    "row_deleted"            bool            NOT NULL DEFAULT FALSE,
+    "created_on"             timestamptz     NOT NULL,
+    "created_by"             int4            NOT NULL,
+    "updated_on"             timestamptz     NOT NULL,
+    "updated_by"             int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "artifact_gc_run_item_gc_run_id_fkey" FOREIGN KEY ("gc_run_id") REFERENCES "public"."artifact_gc_run" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_artifact_gc_run_item_gc_run_id"
+    ON "public"."artifact_gc_run_item" ("gc_run_id");
+
+CREATE INDEX IF NOT EXISTS "idx_artifact_gc_run_item_ci_artifact_id"
+    ON "public"."artifact_gc_run_item" ("ci_artifact_id");
+
+-- garbage collection keeps the artifacts whose image or digest is shared by an artifact it does not delete
+CREATE INDEX IF NOT EXISTS "idx_ci_artifact_image"
+    ON "public"."ci_artifact" ("image");
+
+CREATE INDEX IF NOT EXISTS "idx_ci_artifact_image_digest"
+    ON "public"."ci_artifact" ("image_digest");
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "public"."idx_sbom_package_purl";
+
+DROP INDEX IF EXISTS "public"."idx_sbom_package_lower_name_version";
+
+DROP INDEX IF EXISTS "public"."idx_sbom_package_artifact_sbom_id";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."sbom_package";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_sbom_package;
+
+DROP INDEX IF EXISTS "public"."idx_unique_artifact_sbom_ci_artifact_id";
+
+-- Drop table
+DROP TABLE IF EXISTS "public"."artifact_sbom";
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS id_seq_artifact_sbom;
+
+COMMIT;
@@ -0,0 +1,56 @@
+BEGIN;
+
+-- Create Sequence for artifact_sbom
+CREATE SEQUENCE IF NOT EXISTS id_seq_artifact_sbom;
+
+-- sbom of a built artifact, artifacts of linked ci pipelines and derived artifacts use the sbom of their parent
+CREATE TABLE IF NOT EXISTS "public"."artifact_sbom" (
+    "id"                              int4            NOT NULL DEFAULT nextval('id_seq_artifact_sbom'::regclass),
+    "ci_artifact_id"                  int4            NOT NULL,
+    "source"                          varchar(50)     NOT NULL,
+    "format"                          varchar(50)     NOT NULL,
+    "spec_version"                    varchar(50),
+    "file_name"                       text,
+    "document"                        text            NOT NULL, -- document as received, served for download
+    "document_digest"                 varchar(100)    NOT NULL,
+    "package_count"                   int4            NOT NULL DEFAULT 0,
+    "ci_workflow_id"                  int4,
+    "image_scan_execution_history_id" int4,
+    "created_on"                      timestamptz     NOT NULL,
+    "created_by"                      int4            NOT NULL,
+    "updated_on"                      timestamptz     NOT NULL,
+    "updated_by"                      int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "artifact_sbom_ci_artifact_id_fkey" FOREIGN KEY ("ci_artifact_id") REFERENCES "public"."ci_artifact" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_artifact_sbom_ci_artifact_id"
+    ON "public"."artifact_sbom" ("ci_artifact_id");
+
+-- Create Sequence for sbom_package
+CREATE SEQUENCE IF NOT EXISTS id_seq_sbom_package;
+
+-- packages of an sbom normalised across formats, searched by name or purl
+CREATE TABLE IF NOT EXISTS "public"."sbom_package" (
+    "id"                int4            NOT NULL DEFAULT nextval('id_seq_sbom_package'::regclass),
+    "artifact_sbom_id"  int4            NOT NULL,
+    "name"              text            NOT NULL,
+    "namespace"         text,
+    "version"           text,
+    "purl"              text,
+    "package_type"      varchar(100),
+    "licenses"          text[],
+    PRIMARY KEY ("id"),
+    CONSTRAINT "sbom_package_artifact_sbom_id_fkey" FOREIGN KEY ("artifact_sbom_id") REFERENCES "public"."artifact_sbom" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_sbom_package_artifact_sbom_id"
+    ON "public"."sbom_package" ("artifact_sbom_id");
+
+CREATE INDEX IF NOT EXISTS "idx_sbom_package_lower_name_version"
+    ON "public"."sbom_package" (LOWER("name"), "version");
+
+CREATE INDEX IF NOT EXISTS "idx_sbom_package_purl"
+    ON "public"."sbom_package" ("purl" text_pattern_ops);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE "public"."cd_workflow_runner"
+    DROP COLUMN IF EXISTS "signature_verification_status",
+    DROP COLUMN IF EXISTS "signature_verification_message";
+
+DROP TABLE IF EXISTS "public"."image_signature_policy_scope";
+DROP SEQUENCE IF EXISTS id_seq_image_signature_policy_scope;
+
+DROP TABLE IF EXISTS "public"."image_signature_policy";
+DROP SEQUENCE IF EXISTS id_seq_image_signature_policy;
+
+DROP TABLE IF EXISTS "public"."image_signing_trusted_identity";
+DROP SEQUENCE IF EXISTS id_seq_image_signing_trusted_identity;
+
+COMMIT;
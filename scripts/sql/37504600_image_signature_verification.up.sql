@@ -0,0 +1,74 @@
+BEGIN;
+
+-- Create Sequence for image_signing_trusted_identity
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_signing_trusted_identity;
+
+-- global config of the keys and keyless (fulcio certificate) identities trusted to sign images with cosign
+CREATE TABLE IF NOT EXISTS "public"."image_signing_trusted_identity" (
+    "id"                   int4            NOT NULL DEFAULT nextval('id_seq_image_signing_trusted_identity'::regclass),
+    "name"                 varchar(250)    NOT NULL,
+    "identity_type"        varchar(50)     NOT NULL, -- PUBLIC_KEY or KEYLESS
+    "public_key"           text,                     -- pem encoded public key of PUBLIC_KEY identities
+    "subject"              text,                     -- exact certificate subject (email or uri san) of KEYLESS identities
+    "subject_regex"        text,                     -- certificate subject pattern of KEYLESS identities
+    "issuer"               text,                     -- oidc issuer of KEYLESS identities
+    "root_certificates"    text,                     -- pem encoded certificate authority roots of KEYLESS identities
+    "rekor_public_key"     text,                     -- pem encoded transparency log key of KEYLESS identities
+    "active"               bool            NOT NULL DEFAULT TRUE,
+    "created_on"           timestamptz     NOT NULL,
+    "created_by"           int4            NOT NULL,
+    "updated_on"           timestamptz     NOT NULL,
+    "updated_by"           int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_image_signing_trusted_identity_name"
+    ON "public"."image_signing_trusted_identity" ("name") WHERE "active" = TRUE;
+
+-- Create Sequence for image_signature_policy
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_signature_policy;
+
+-- signature and attestation verification policy, every active policy scoped to the environment of a deployment must pass
+CREATE TABLE IF NOT EXISTS "public"."image_signature_policy" (
+    "id"                       int4            NOT NULL DEFAULT nextval('id_seq_image_signature_policy'::regclass),
+    "name"                     varchar(250)    NOT NULL,
+    "mode"                     varchar(50)     NOT NULL, -- ENFORCE blocks the deployment, AUDIT only records the result
+    "trusted_identity_ids"     int4[]          NOT NULL,
+    "required_attestations"    text[],                   -- in-toto predicate types which must be attested by a trusted identity
+    "active"                   bool            NOT NULL DEFAULT TRUE,
+    "created_on"               timestamptz     NOT NULL,
+    "created_by"               int4            NOT NULL,
+    "updated_on"               timestamptz     NOT NULL,
+    "updated_by"               int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_image_signature_policy_name"
+    ON "public"."image_signature_policy" ("name") WHERE "active" = TRUE;
+
+-- Create Sequence for image_signature_policy_scope
+CREATE SEQUENCE IF NOT EXISTS id_seq_image_signature_policy_scope;
+
+-- environments and clusters a policy applies to
+CREATE TABLE IF NOT EXISTS "public"."image_signature_policy_scope" (
+    "id"            int4            NOT NULL DEFAULT nextval('id_seq_image_signature_policy_scope'::regclass),
+    "policy_id"     int4            NOT NULL,
+    "scope_type"    varchar(50)     NOT NULL, -- ENVIRONMENT or CLUSTER
+    "scope_id"      int4            NOT NULL,
+    "created_on"    timestamptz     NOT NULL,
+    "created_by"    int4            NOT NULL,
+    "updated_on"    timestamptz     NOT NULL,
+    "updated_by"    int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "image_signature_policy_scope_policy_id_fkey" FOREIGN KEY ("policy_id") REFERENCES "public"."image_signature_policy" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_image_signature_policy_scope_scope"
+    ON "public"."image_signature_policy_scope" ("scope_type", "scope_id");
+
+-- result of the signature verification of the deployed artifact
+ALTER TABLE "public"."cd_workflow_runner"
+    ADD COLUMN IF NOT EXISTS "signature_verification_status" varchar(50),
+    ADD COLUMN IF NOT EXISTS "signature_verification_message" text;
+
+COMMIT;
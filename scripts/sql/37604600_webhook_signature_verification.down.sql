@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."webhook_delivery";
+DROP SEQUENCE IF EXISTS "public"."id_seq_webhook_delivery";
+
+DROP TABLE IF EXISTS "public"."webhook_secret";
+DROP SEQUENCE IF EXISTS "public"."id_seq_webhook_secret";
+
+DROP TABLE IF EXISTS "public"."webhook_signature_config";
+DROP SEQUENCE IF EXISTS "public"."id_seq_webhook_signature_config";
+
+COMMIT;
@@ -0,0 +1,75 @@
+BEGIN;
+
+-- Create Sequence for webhook_signature_config
+CREATE SEQUENCE IF NOT EXISTS id_seq_webhook_signature_config;
+
+-- signature verification of the deliveries of a git host or external ci webhook
+CREATE TABLE IF NOT EXISTS "public"."webhook_signature_config" (
+    "id"                    int4            NOT NULL DEFAULT nextval('id_seq_webhook_signature_config'::regclass),
+    "webhook_type"          varchar(50)     NOT NULL, -- GIT_HOST or EXTERNAL_CI
+    "webhook_id"            int4            NOT NULL, -- git host id or external ci pipeline id
+    "provider"              varchar(50)     NOT NULL, -- GITHUB, GITLAB, BITBUCKET or GENERIC
+    "signature_header"      varchar(250),             -- headers of GENERIC webhooks
+    "timestamp_header"      varchar(250),
+    "delivery_id_header"    varchar(250),
+    "active"                bool            NOT NULL DEFAULT TRUE,
+    "created_on"            timestamptz     NOT NULL,
+    "created_by"            int4            NOT NULL,
+    "updated_on"            timestamptz     NOT NULL,
+    "updated_by"            int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_webhook_signature_config_webhook"
+    ON "public"."webhook_signature_config" ("webhook_type", "webhook_id") WHERE "active" = TRUE;
+
+-- Create Sequence for webhook_secret
+CREATE SEQUENCE IF NOT EXISTS id_seq_webhook_secret;
+
+-- secrets accepted for a webhook, secrets being rotated out are accepted until they expire
+CREATE TABLE IF NOT EXISTS "public"."webhook_secret" (
+    "id"            int4            NOT NULL DEFAULT nextval('id_seq_webhook_secret'::regclass),
+    "config_id"     int4            NOT NULL,
+    "secret"        text            NOT NULL,
+    "expires_on"    timestamptz,
+    "active"        bool            NOT NULL DEFAULT TRUE,
+    "created_on"    timestamptz     NOT NULL,
+    "created_by"    int4            NOT NULL,
+    "updated_on"    timestamptz     NOT NULL,
+    "updated_by"    int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "webhook_secret_config_id_fkey" FOREIGN KEY ("config_id") REFERENCES "public"."webhook_signature_config" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_secret_config_id"
+    ON "public"."webhook_secret" ("config_id") WHERE "active" = TRUE;
+
+-- Create Sequence for webhook_delivery
+CREATE SEQUENCE IF NOT EXISTS id_seq_webhook_delivery;
+
+-- deliveries of webhooks with signature verification, accepted delivery ids guard against replays
+CREATE TABLE IF NOT EXISTS "public"."webhook_delivery" (
+    "id"             int4            NOT NULL DEFAULT nextval('id_seq_webhook_delivery'::regclass),
+    "webhook_type"   varchar(50)     NOT NULL,
+    "webhook_id"     int4            NOT NULL,
+    "delivery_id"    varchar(250),
+    "provider"       varchar(50)     NOT NULL,
+    "status"         varchar(50)     NOT NULL, -- ACCEPTED or REJECTED
+    "reason"         varchar(50),
+    "message"        text,
+    "event_type"     varchar(250),
+    "source_ip"      varchar(100),
+    "created_on"     timestamptz     NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_webhook_delivery_accepted_delivery_id"
+    ON "public"."webhook_delivery" ("webhook_type", "webhook_id", "delivery_id") WHERE "status" = 'ACCEPTED';
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_webhook"
+    ON "public"."webhook_delivery" ("webhook_type", "webhook_id", "created_on");
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_created_on"
+    ON "public"."webhook_delivery" ("created_on");
+
+COMMIT;
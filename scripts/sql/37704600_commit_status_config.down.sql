@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."commit_status_config";
+DROP SEQUENCE IF EXISTS "public"."id_seq_commit_status_config";
+
+COMMIT;
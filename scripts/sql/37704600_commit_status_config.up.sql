@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Create Sequence for commit_status_config
+CREATE SEQUENCE IF NOT EXISTS id_seq_commit_status_config;
+
+-- build and deployment statuses reported on the commits of the repositories of a git provider
+CREATE TABLE IF NOT EXISTS "public"."commit_status_config" (
+    "id"                   int4            NOT NULL DEFAULT nextval('id_seq_commit_status_config'::regclass),
+    "git_provider_id"      int4            NOT NULL,
+    "provider_type"        varchar(50)     NOT NULL, -- GITHUB, GITLAB, BITBUCKET_CLOUD, BITBUCKET_SERVER or AZURE_DEVOPS
+    "api_url"              text,                     -- overrides the api url derived from the repository url
+    "report_ci"            bool            NOT NULL DEFAULT TRUE,
+    "report_deployment"    bool            NOT NULL DEFAULT FALSE,
+    "active"               bool            NOT NULL DEFAULT TRUE,
+    "created_on"           timestamptz     NOT NULL,
+    "created_by"           int4            NOT NULL,
+    "updated_on"           timestamptz     NOT NULL,
+    "updated_by"           int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "commit_status_config_git_provider_id_fkey" FOREIGN KEY ("git_provider_id") REFERENCES "public"."git_provider" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_commit_status_config_git_provider_id"
+    ON "public"."commit_status_config" ("git_provider_id") WHERE "active" = TRUE;
+
+COMMIT;
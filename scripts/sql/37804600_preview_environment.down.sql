@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."preview_environment";
+DROP SEQUENCE IF EXISTS "public"."id_seq_preview_environment";
+DROP TABLE IF EXISTS "public"."preview_environment_template";
+DROP SEQUENCE IF EXISTS "public"."id_seq_preview_environment_template";
+
+COMMIT;
@@ -0,0 +1,70 @@
+BEGIN;
+
+-- Create Sequence for preview_environment_template
+CREATE SEQUENCE IF NOT EXISTS id_seq_preview_environment_template;
+
+-- app level template of the environments created for the pull requests built by a ci pipeline
+CREATE TABLE IF NOT EXISTS "public"."preview_environment_template" (
+    "id"                     int4            NOT NULL DEFAULT nextval('id_seq_preview_environment_template'::regclass),
+    "app_id"                 int4            NOT NULL,
+    "template_pipeline_id"   int4            NOT NULL, -- cd pipeline cloned for every preview
+    "ci_pipeline_id"         int4            NOT NULL, -- ci pipeline building the pull requests, parent of the template pipeline
+    "cluster_id"             int4            NOT NULL,
+    "namespace_template"     varchar(250)    NOT NULL,
+    "values_override"        text,
+    "url_template"           text,
+    "ttl_hours"              int4            NOT NULL,
+    "max_active_previews"    int4            NOT NULL,
+    "active"                 bool            NOT NULL DEFAULT TRUE,
+    "created_on"             timestamptz     NOT NULL,
+    "created_by"             int4            NOT NULL,
+    "updated_on"             timestamptz     NOT NULL,
+    "updated_by"             int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "preview_environment_template_app_id_fkey" FOREIGN KEY ("app_id") REFERENCES "public"."app" ("id"),
+    CONSTRAINT "preview_environment_template_template_pipeline_id_fkey" FOREIGN KEY ("template_pipeline_id") REFERENCES "public"."pipeline" ("id"),
+    CONSTRAINT "preview_environment_template_cluster_id_fkey" FOREIGN KEY ("cluster_id") REFERENCES "public"."cluster" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_preview_environment_template_app_id"
+    ON "public"."preview_environment_template" ("app_id") WHERE "active" = TRUE;
+
+-- Create Sequence for preview_environment
+CREATE SEQUENCE IF NOT EXISTS id_seq_preview_environment;
+
+-- environment of a pull request, created on its first build and deleted once the pull request is closed or expires
+CREATE TABLE IF NOT EXISTS "public"."preview_environment" (
+    "id"                          int4            NOT NULL DEFAULT nextval('id_seq_preview_environment'::regclass),
+    "template_id"                 int4            NOT NULL,
+    "app_id"                      int4            NOT NULL,
+    "pr_number"                   int4            NOT NULL,
+    "pr_url"                      text            NOT NULL,
+    "pr_title"                    text,
+    "source_branch"               varchar(250),
+    "repo_url"                    text,
+    "commit_hash"                 varchar(250),
+    "environment_id"              int4,
+    "cd_pipeline_id"              int4,
+    "namespace"                   varchar(250)    NOT NULL,
+    "url"                         text,
+    "status"                      varchar(50)     NOT NULL, -- ACTIVE, HIBERNATED, DELETED or FAILED
+    "message"                     text,
+    "last_deployed_artifact_id"   int4,
+    "expires_on"                  timestamptz     NOT NULL,
+    "delete_after"                timestamptz,
+    "created_on"                  timestamptz     NOT NULL,
+    "created_by"                  int4            NOT NULL,
+    "updated_on"                  timestamptz     NOT NULL,
+    "updated_by"                  int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "preview_environment_template_id_fkey" FOREIGN KEY ("template_id") REFERENCES "public"."preview_environment_template" ("id")
+);
+
+-- a pull request has a single live preview per template
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_preview_environment_template_pr"
+    ON "public"."preview_environment" ("template_id", "pr_number") WHERE "status" IN ('ACTIVE', 'HIBERNATED');
+
+CREATE INDEX IF NOT EXISTS "idx_preview_environment_status"
+    ON "public"."preview_environment" ("status");
+
+COMMIT;
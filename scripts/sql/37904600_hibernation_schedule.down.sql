@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE "public"."cd_workflow_runner" DROP COLUMN IF EXISTS "trigger_type";
+DROP TABLE IF EXISTS "public"."hibernation_schedule_run_target";
+DROP SEQUENCE IF EXISTS "public"."id_seq_hibernation_schedule_run_target";
+DROP TABLE IF EXISTS "public"."hibernation_schedule_run";
+DROP SEQUENCE IF EXISTS "public"."id_seq_hibernation_schedule_run";
+DROP TABLE IF EXISTS "public"."hibernation_schedule_app_override";
+DROP SEQUENCE IF EXISTS "public"."id_seq_hibernation_schedule_app_override";
+DROP TABLE IF EXISTS "public"."hibernation_schedule";
+DROP SEQUENCE IF EXISTS "public"."id_seq_hibernation_schedule";
+DROP TABLE IF EXISTS "public"."scheduler_leader_lease";
+
+COMMIT;
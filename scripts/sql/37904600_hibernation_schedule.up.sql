@@ -0,0 +1,104 @@
+BEGIN;
+
+-- lease of the schedulers which must run on a single replica, held by the replica which renewed it last
+CREATE TABLE IF NOT EXISTS "public"."scheduler_leader_lease" (
+    "name"           varchar(100)    NOT NULL,
+    "holder_id"      varchar(250)    NOT NULL,
+    "lease_until"    timestamptz     NOT NULL,
+    "acquired_on"    timestamptz     NOT NULL,
+    "updated_on"     timestamptz     NOT NULL,
+    PRIMARY KEY ("name")
+);
+
+-- Create Sequence for hibernation_schedule
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_schedule;
+
+-- on/off times of the apps of an environment, or of the apps of a resource group of an environment
+CREATE TABLE IF NOT EXISTS "public"."hibernation_schedule" (
+    "id"                   int4            NOT NULL DEFAULT nextval('id_seq_hibernation_schedule'::regclass),
+    "name"                 varchar(250)    NOT NULL,
+    "environment_id"       int4            NOT NULL,
+    "resource_group_id"    int4,
+    "sleep_cron"           varchar(100)    NOT NULL,
+    "wake_cron"            varchar(100),
+    "timezone"             varchar(100)    NOT NULL,
+    "holidays"             text[],         -- dates in the timezone of the schedule on which the apps are not woken up
+    "keep_awake_until"     timestamptz,
+    "next_sleep_at"        timestamptz     NOT NULL,
+    "next_wake_at"         timestamptz,
+    "active"               bool            NOT NULL DEFAULT TRUE,
+    "created_on"           timestamptz     NOT NULL,
+    "created_by"           int4            NOT NULL,
+    "updated_on"           timestamptz     NOT NULL,
+    "updated_by"           int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "hibernation_schedule_environment_id_fkey" FOREIGN KEY ("environment_id") REFERENCES "public"."environment" ("id"),
+    CONSTRAINT "hibernation_schedule_resource_group_id_fkey" FOREIGN KEY ("resource_group_id") REFERENCES "public"."resource_group" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_hibernation_schedule_environment_id"
+    ON "public"."hibernation_schedule" ("environment_id") WHERE "active" = TRUE;
+
+-- Create Sequence for hibernation_schedule_app_override
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_schedule_app_override;
+
+CREATE TABLE IF NOT EXISTS "public"."hibernation_schedule_app_override" (
+    "id"                  int4            NOT NULL DEFAULT nextval('id_seq_hibernation_schedule_app_override'::regclass),
+    "schedule_id"         int4            NOT NULL,
+    "app_id"              int4            NOT NULL,
+    "opt_out"             bool            NOT NULL DEFAULT FALSE,
+    "keep_awake_until"    timestamptz,
+    "created_on"          timestamptz     NOT NULL,
+    "created_by"          int4            NOT NULL,
+    "updated_on"          timestamptz     NOT NULL,
+    "updated_by"          int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "hibernation_schedule_app_override_schedule_id_fkey" FOREIGN KEY ("schedule_id") REFERENCES "public"."hibernation_schedule" ("id"),
+    CONSTRAINT "hibernation_schedule_app_override_app_id_fkey" FOREIGN KEY ("app_id") REFERENCES "public"."app" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_hibernation_schedule_app_override"
+    ON "public"."hibernation_schedule_app_override" ("schedule_id", "app_id");
+
+-- Create Sequence for hibernation_schedule_run
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_schedule_run;
+
+CREATE TABLE IF NOT EXISTS "public"."hibernation_schedule_run" (
+    "id"              int4            NOT NULL DEFAULT nextval('id_seq_hibernation_schedule_run'::regclass),
+    "schedule_id"     int4            NOT NULL,
+    "action"          varchar(20)     NOT NULL, -- SLEEP or WAKE
+    "scheduled_at"    timestamptz     NOT NULL,
+    "status"          varchar(50)     NOT NULL, -- RUNNING, SUCCEEDED, PARTIALLY_FAILED, FAILED or SKIPPED
+    "message"         text,
+    "triggered_by"    int4            NOT NULL,
+    "started_on"      timestamptz     NOT NULL,
+    "finished_on"     timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "hibernation_schedule_run_schedule_id_fkey" FOREIGN KEY ("schedule_id") REFERENCES "public"."hibernation_schedule" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_hibernation_schedule_run_schedule_id"
+    ON "public"."hibernation_schedule_run" ("schedule_id");
+
+-- Create Sequence for hibernation_schedule_run_target
+CREATE SEQUENCE IF NOT EXISTS id_seq_hibernation_schedule_run_target;
+
+-- outcome of a run for every app of the schedule
+CREATE TABLE IF NOT EXISTS "public"."hibernation_schedule_run_target" (
+    "id"                int4            NOT NULL DEFAULT nextval('id_seq_hibernation_schedule_run_target'::regclass),
+    "run_id"            int4            NOT NULL,
+    "app_id"            int4            NOT NULL,
+    "environment_id"    int4            NOT NULL,
+    "status"            varchar(50)     NOT NULL, -- TRIGGERED, FAILED or SKIPPED
+    "message"           text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "hibernation_schedule_run_target_run_id_fkey" FOREIGN KEY ("run_id") REFERENCES "public"."hibernation_schedule_run" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_hibernation_schedule_run_target_run_id"
+    ON "public"."hibernation_schedule_run_target" ("run_id");
+
+-- MANUAL, AUTOMATIC or SYSTEM, the deployments triggered by devtron itself on a schedule are SYSTEM
+ALTER TABLE "public"."cd_workflow_runner" ADD COLUMN IF NOT EXISTS "trigger_type" varchar(20);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."release_orchestration_event";
+DROP SEQUENCE IF EXISTS "public"."id_seq_release_orchestration_event";
+DROP TABLE IF EXISTS "public"."release_orchestration_item";
+DROP SEQUENCE IF EXISTS "public"."id_seq_release_orchestration_item";
+DROP TABLE IF EXISTS "public"."release_orchestration";
+DROP SEQUENCE IF EXISTS "public"."id_seq_release_orchestration";
+
+COMMIT;
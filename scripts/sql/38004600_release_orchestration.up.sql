@@ -0,0 +1,76 @@
+BEGIN;
+
+-- Create Sequence for release_orchestration
+CREATE SEQUENCE IF NOT EXISTS id_seq_release_orchestration;
+
+-- release of a set of app+environment+artifact tuples deployed stage by stage in the order of their dependencies
+CREATE TABLE IF NOT EXISTS "public"."release_orchestration" (
+    "id"                     int4            NOT NULL DEFAULT nextval('id_seq_release_orchestration'::regclass),
+    "name"                   varchar(250)    NOT NULL,
+    "description"            text,
+    "status"                 varchar(50)     NOT NULL, -- CREATED, RUNNING, PAUSED, SUCCEEDED, ROLLING_BACK or ROLLED_BACK
+    "current_stage"          int4            NOT NULL DEFAULT 0,
+    "health_timeout_mins"    int4            NOT NULL,
+    "triggered_by"           int4,
+    "started_on"             timestamptz,
+    "finished_on"            timestamptz,
+    "active"                 bool            NOT NULL DEFAULT TRUE,
+    "created_on"             timestamptz     NOT NULL,
+    "created_by"             int4            NOT NULL,
+    "updated_on"             timestamptz     NOT NULL,
+    "updated_by"             int4            NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_release_orchestration_status"
+    ON "public"."release_orchestration" ("status") WHERE "active" = TRUE;
+
+-- Create Sequence for release_orchestration_item
+CREATE SEQUENCE IF NOT EXISTS id_seq_release_orchestration_item;
+
+CREATE TABLE IF NOT EXISTS "public"."release_orchestration_item" (
+    "id"                       int4            NOT NULL DEFAULT nextval('id_seq_release_orchestration_item'::regclass),
+    "release_id"               int4            NOT NULL,
+    "item_key"                 varchar(100)    NOT NULL,
+    "pipeline_id"              int4            NOT NULL,
+    "app_id"                   int4            NOT NULL,
+    "environment_id"           int4            NOT NULL,
+    "ci_artifact_id"           int4            NOT NULL,
+    "depends_on"               text[],         -- keys of the items of the release deployed before this one
+    "stage"                    int4            NOT NULL,
+    "status"                   varchar(50)     NOT NULL,
+    "cd_workflow_runner_id"    int4,
+    "previous_wfr_id"          int4,           -- last healthy deployment of the pipeline before the release, the rollback target
+    "rollback_wfr_id"          int4,
+    "message"                  text,
+    "triggered_on"             timestamptz,
+    "finished_on"              timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "release_orchestration_item_release_id_fkey" FOREIGN KEY ("release_id") REFERENCES "public"."release_orchestration" ("id"),
+    CONSTRAINT "release_orchestration_item_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id"),
+    CONSTRAINT "release_orchestration_item_ci_artifact_id_fkey" FOREIGN KEY ("ci_artifact_id") REFERENCES "public"."ci_artifact" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_release_orchestration_item_key"
+    ON "public"."release_orchestration_item" ("release_id", "item_key");
+
+-- Create Sequence for release_orchestration_event
+CREATE SEQUENCE IF NOT EXISTS id_seq_release_orchestration_event;
+
+-- timeline of a release, item_id is set for the events of a single item
+CREATE TABLE IF NOT EXISTS "public"."release_orchestration_event" (
+    "id"            int4            NOT NULL DEFAULT nextval('id_seq_release_orchestration_event'::regclass),
+    "release_id"    int4            NOT NULL,
+    "item_id"       int4,
+    "event_type"    varchar(50)     NOT NULL,
+    "message"       text,
+    "created_on"    timestamptz     NOT NULL,
+    "created_by"    int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "release_orchestration_event_release_id_fkey" FOREIGN KEY ("release_id") REFERENCES "public"."release_orchestration" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_release_orchestration_event_release_id"
+    ON "public"."release_orchestration_event" ("release_id");
+
+COMMIT;
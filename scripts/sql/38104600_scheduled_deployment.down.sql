@@ -0,0 +1,10 @@
+BEGIN;
+
+DELETE FROM "public"."notification_templates" WHERE event_type_id = 13;
+DELETE FROM "public"."notifier_event_log" WHERE event_type_id = 13;
+DELETE FROM "public"."event" WHERE id = 13;
+
+DROP TABLE IF EXISTS "public"."scheduled_deployment";
+DROP SEQUENCE IF EXISTS "public"."id_seq_scheduled_deployment";
+
+COMMIT;
@@ -0,0 +1,80 @@
+BEGIN;
+
+-- Create Sequence for scheduled_deployment
+CREATE SEQUENCE IF NOT EXISTS id_seq_scheduled_deployment;
+
+-- one-off deployment of a cd pipeline queued by a user for a later time
+CREATE TABLE IF NOT EXISTS "public"."scheduled_deployment" (
+    "id"                       int4            NOT NULL DEFAULT nextval('id_seq_scheduled_deployment'::regclass),
+    "pipeline_id"              int4            NOT NULL,
+    "app_id"                   int4            NOT NULL,
+    "environment_id"           int4            NOT NULL,
+    "ci_artifact_id"           int4            NOT NULL,
+    "cd_workflow_type"         varchar(20)     NOT NULL, -- PRE, DEPLOY or POST
+    "override_request"         text            NOT NULL, -- values override request passed to the manual trigger at the scheduled time
+    "scheduled_at"             timestamptz     NOT NULL,
+    "status"                   varchar(50)     NOT NULL, -- PENDING, RUNNING, TRIGGERED, FAILED or CANCELLED
+    "message"                  text,
+    "cd_workflow_runner_id"    int4,
+    "executed_on"              timestamptz,
+    "claimed_on"               timestamptz,     -- set when moved to RUNNING, rows running for long are failed by the leader
+    "created_on"               timestamptz     NOT NULL,
+    "created_by"               int4            NOT NULL,
+    "updated_on"               timestamptz     NOT NULL,
+    "updated_by"               int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "scheduled_deployment_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id"),
+    CONSTRAINT "scheduled_deployment_ci_artifact_id_fkey" FOREIGN KEY ("ci_artifact_id") REFERENCES "public"."ci_artifact" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_scheduled_deployment_pending_scheduled_at"
+    ON "public"."scheduled_deployment" ("scheduled_at") WHERE "status" = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS "idx_scheduled_deployment_running_claimed_on"
+    ON "public"."scheduled_deployment" ("claimed_on") WHERE "status" = 'RUNNING';
+
+CREATE INDEX IF NOT EXISTS "idx_scheduled_deployment_pipeline_id"
+    ON "public"."scheduled_deployment" ("pipeline_id");
+
+INSERT INTO "public"."event" (id, event_type, description) VALUES (13, 'SCHEDULED DEPLOYMENT', '');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('ses', 'CD', 13, 'CD scheduled deployment ses template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "⏰ Scheduled deployment {{scheduledDeploymentStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Scheduled deployment {{scheduledDeploymentStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{scheduledDeploymentMessage}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Scheduled by</div><div style=\"color:#000a14;font-size:14px\">{{triggeredBy}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('smtp', 'CD', 13, 'CD scheduled deployment smtp template', '{"from": "{{fromEmail}}", "to": "{{toEmail}}","subject": "⏰ Scheduled deployment {{scheduledDeploymentStatus}} | Application > {{appName}} | Environment > {{envName}}","html": "<table cellpadding=\"0\" style=\"font-family:Arial,Verdana,Helvetica;width:600px;border-collapse:inherit;border-spacing:0;border:1px solid #d0d4d9;border-radius:8px;padding:16px 20px;margin:20px auto\"><tr><td colspan=\"2\"><div style=\"background-color:#e5f2ff;border-radius:8px;padding:20px\"><div style=\"font-size:16px;line-height:24px;font-weight:600;margin-bottom:6px;color:#000a14\">Scheduled deployment {{scheduledDeploymentStatus}}</div><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{eventTime}}</span><br><span style=\"font-size:14px;line-height:20px;color:#000a14\">{{scheduledDeploymentMessage}}</span></div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Application</div><div style=\"color:#000a14;font-size:14px\">{{appName}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Environment</div><div style=\"color:#000a14;font-size:14px\">{{envName}}</div></td></tr><tr><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Scheduled by</div><div style=\"color:#000a14;font-size:14px\">{{triggeredBy}}</div></td><td><div style=\"color:#3b444c;font-size:13px;padding-top:16px\">Image</div><div style=\"color:#000a14;font-size:14px\">{{dockerImageUrl}}</div></td></tr></table>"}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CD', 13, 'CD scheduled deployment slack template', '{
+    "text": ":alarm_clock: Scheduled deployment {{scheduledDeploymentStatus}} | Application > {{appName}} | Environment > {{envName}}",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":alarm_clock: *Scheduled deployment {{scheduledDeploymentStatus}}*\n<!date^{{eventTime}}^{date_long} {time} | \"-\"> \n {{scheduledDeploymentMessage}}"
+            }
+        },
+        {
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*Application*\n{{appName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Environment*\n{{envName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Scheduled by*\n{{triggeredBy}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Image*\n{{dockerImageUrl}}"
+                }
+            ]
+        }
+    ]
+}');
+
+COMMIT;
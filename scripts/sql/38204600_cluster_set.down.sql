@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."cluster_set_deployment_member";
+DROP SEQUENCE IF EXISTS "public"."id_seq_cluster_set_deployment_member";
+DROP TABLE IF EXISTS "public"."cluster_set_deployment";
+DROP SEQUENCE IF EXISTS "public"."id_seq_cluster_set_deployment";
+DROP TABLE IF EXISTS "public"."cluster_set_target";
+DROP SEQUENCE IF EXISTS "public"."id_seq_cluster_set_target";
+DROP TABLE IF EXISTS "public"."cluster_label";
+DROP SEQUENCE IF EXISTS "public"."id_seq_cluster_label";
+
+COMMIT;
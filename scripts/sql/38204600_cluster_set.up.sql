@@ -0,0 +1,100 @@
+BEGIN;
+
+-- Create Sequence for cluster_label
+CREATE SEQUENCE IF NOT EXISTS id_seq_cluster_label;
+
+-- labels of a cluster, cluster sets select their member clusters by them
+CREATE TABLE IF NOT EXISTS "public"."cluster_label" (
+    "id"            int4            NOT NULL DEFAULT nextval('id_seq_cluster_label'::regclass),
+    "cluster_id"    int4            NOT NULL,
+    "key"           varchar(317)    NOT NULL,
+    "value"         varchar(63)     NOT NULL,
+    "created_on"    timestamptz     NOT NULL,
+    "created_by"    int4            NOT NULL,
+    "updated_on"    timestamptz     NOT NULL,
+    "updated_by"    int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "cluster_label_cluster_id_fkey" FOREIGN KEY ("cluster_id") REFERENCES "public"."cluster" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_cluster_label_key"
+    ON "public"."cluster_label" ("cluster_id", "key");
+
+-- Create Sequence for cluster_set_target
+CREATE SEQUENCE IF NOT EXISTS id_seq_cluster_set_target;
+
+-- set of clusters, selected by their labels, a cd pipeline is deployed to besides its own environment
+CREATE TABLE IF NOT EXISTS "public"."cluster_set_target" (
+    "id"                     int4            NOT NULL DEFAULT nextval('id_seq_cluster_set_target'::regclass),
+    "pipeline_id"            int4            NOT NULL,
+    "selector"               text            NOT NULL,
+    "parallelism"            int4            NOT NULL,
+    "max_unavailable"        int4            NOT NULL,
+    "health_timeout_mins"    int4            NOT NULL,
+    "active"                 bool            NOT NULL DEFAULT TRUE,
+    "created_on"             timestamptz     NOT NULL,
+    "created_by"             int4            NOT NULL,
+    "updated_on"             timestamptz     NOT NULL,
+    "updated_by"             int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "cluster_set_target_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_cluster_set_target_pipeline_id"
+    ON "public"."cluster_set_target" ("pipeline_id") WHERE "active" = TRUE;
+
+-- Create Sequence for cluster_set_deployment
+CREATE SEQUENCE IF NOT EXISTS id_seq_cluster_set_deployment;
+
+-- deployment of an artifact of the pipeline to the member clusters of its cluster set
+CREATE TABLE IF NOT EXISTS "public"."cluster_set_deployment" (
+    "id"                     int4            NOT NULL DEFAULT nextval('id_seq_cluster_set_deployment'::regclass),
+    "target_id"              int4            NOT NULL,
+    "pipeline_id"            int4            NOT NULL,
+    "ci_artifact_id"         int4            NOT NULL,
+    "status"                 varchar(50)     NOT NULL, -- QUEUED, RUNNING, SUCCEEDED, DEGRADED or HALTED
+    "parallelism"            int4            NOT NULL,
+    "max_unavailable"        int4            NOT NULL,
+    "health_timeout_mins"    int4            NOT NULL,
+    "message"                text,
+    "triggered_by"           int4            NOT NULL,
+    "started_on"             timestamptz     NOT NULL,
+    "finished_on"            timestamptz,
+    "created_on"             timestamptz     NOT NULL,
+    "created_by"             int4            NOT NULL,
+    "updated_on"             timestamptz     NOT NULL,
+    "updated_by"             int4            NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "cluster_set_deployment_target_id_fkey" FOREIGN KEY ("target_id") REFERENCES "public"."cluster_set_target" ("id"),
+    CONSTRAINT "cluster_set_deployment_ci_artifact_id_fkey" FOREIGN KEY ("ci_artifact_id") REFERENCES "public"."ci_artifact" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_cluster_set_deployment_pipeline_id"
+    ON "public"."cluster_set_deployment" ("pipeline_id");
+
+CREATE INDEX IF NOT EXISTS "idx_cluster_set_deployment_status"
+    ON "public"."cluster_set_deployment" ("status") WHERE "status" IN ('QUEUED', 'RUNNING');
+
+-- Create Sequence for cluster_set_deployment_member
+CREATE SEQUENCE IF NOT EXISTS id_seq_cluster_set_deployment_member;
+
+-- deployment to a single member cluster of a cluster set deployment
+CREATE TABLE IF NOT EXISTS "public"."cluster_set_deployment_member" (
+    "id"                       int4            NOT NULL DEFAULT nextval('id_seq_cluster_set_deployment_member'::regclass),
+    "deployment_id"            int4            NOT NULL,
+    "cluster_id"               int4            NOT NULL,
+    "status"                   varchar(50)     NOT NULL, -- PENDING, DEPLOYING, SUCCEEDED, FAILED or SKIPPED
+    "cd_workflow_runner_id"    int4,           -- runner of the release on the cluster, its status decides the status
+    "message"                  text,
+    "started_on"               timestamptz,
+    "finished_on"              timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "cluster_set_deployment_member_deployment_id_fkey" FOREIGN KEY ("deployment_id") REFERENCES "public"."cluster_set_deployment" ("id"),
+    CONSTRAINT "cluster_set_deployment_member_cluster_id_fkey" FOREIGN KEY ("cluster_id") REFERENCES "public"."cluster" ("id"),
+    CONSTRAINT "cluster_set_deployment_member_cd_workflow_runner_id_fkey" FOREIGN KEY ("cd_workflow_runner_id") REFERENCES "public"."cd_workflow_runner" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_cluster_set_deployment_member"
+    ON "public"."cluster_set_deployment_member" ("deployment_id", "cluster_id");
+
+COMMIT;
@@ -57,6 +57,7 @@ func (d *DeploymentServiceTypeConfig) IsFeatureMigrateFluxApplicationEnable() bo
 
 type GlobalEnvVariables struct {
 	GitOpsRepoPrefix                     string `env:"GITOPS_REPO_PREFIX" envDefault:"" description:"Prefix for Gitops repo being creation for argocd application"`
+	GitOpsRepoLayout                     string `env:"GITOPS_REPO_LAYOUT" envDefault:"APP" description:"Layout of GitOps repositories for new deployments; APP creates a repo per app, PROJECT or CLUSTER keep <app>/<env> chart directories in a single repo per project or cluster"`
 	EnableAsyncHelmInstallDevtronChart   bool   `env:"ENABLE_ASYNC_INSTALL_DEVTRON_CHART" envDefault:"false" description:"To enable async installation of no-gitops application"`
 	EnableAsyncArgoCdInstallDevtronChart bool   `env:"ENABLE_ASYNC_ARGO_CD_INSTALL_DEVTRON_CHART" envDefault:"false" description:"To enable async installation of gitops application"`
 	ArgoGitCommitRetryCountOnConflict    int    `env:"ARGO_GIT_COMMIT_RETRY_COUNT_ON_CONFLICT" envDefault:"3" description:"retry argocd app manual sync if the timeline is stuck in ARGOCD_SYNC_INITIATED state for more than this defined time (in mins)"
@@ -18,6 +18,7 @@ package util
 
 import (
 	"math"
+	"time"
 )
 
 // XORBool returns the XOR of two boolean values
@@ -42,3 +43,11 @@ func GetDeReferencedBean[T any](ptrObject *T) T {
 	}
 	return deReferencedObj
 }
+
+// GetTimePtr returns nil for the zero time, so that times which are not set yet are omitted from the responses
+func GetTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -21,6 +21,11 @@ type EventType int
 const Trigger EventType = 1
 const Success EventType = 2
 const Fail EventType = 3
+const Approval EventType = 4
+const ApprovalAction EventType = 10
+const AutoRollback EventType = 11
+const DriftDetected EventType = 12
+const ScheduledDeployment EventType = 13
 
 type PipelineType string
 
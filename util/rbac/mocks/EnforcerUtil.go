@@ -0,0 +1,1058 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	app "github.com/devtron-labs/devtron/internal/sql/repository/app"
+	bean "github.com/devtron-labs/devtron/pkg/bean"
+
+	helper "github.com/devtron-labs/devtron/internal/sql/repository/helper"
+
+	k8s "github.com/devtron-labs/common-lib/utils/k8s"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pipelineConfig "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+
+	repository "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+)
+
+// EnforcerUtil is an autogenerated mock type for the EnforcerUtil type
+type EnforcerUtil struct {
+	mock.Mock
+}
+
+// CheckAppRbacForAppOrJob provides a mock function with given fields: token, resourceName, action
+func (_m *EnforcerUtil) CheckAppRbacForAppOrJob(token string, resourceName string, action string) bool {
+	ret := _m.Called(token, resourceName, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAppRbacForAppOrJob")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(token, resourceName, action)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CheckAppRbacForAppOrJobInBulk provides a mock function with given fields: token, action, rbacObjects, appType
+func (_m *EnforcerUtil) CheckAppRbacForAppOrJobInBulk(token string, action string, rbacObjects []string, appType helper.AppType) map[string]bool {
+	ret := _m.Called(token, action, rbacObjects, appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAppRbacForAppOrJobInBulk")
+	}
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func(string, string, []string, helper.AppType) map[string]bool); ok {
+		r0 = rf(token, action, rbacObjects, appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	return r0
+}
+
+// GetAllActiveTeamNames provides a mock function with no fields
+func (_m *EnforcerUtil) GetAllActiveTeamNames() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllActiveTeamNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllWorkflowRBACObjectsByAppId provides a mock function with given fields: appId, workflowNames, workflowIds
+func (_m *EnforcerUtil) GetAllWorkflowRBACObjectsByAppId(appId int, workflowNames []string, workflowIds []int) map[int]string {
+	ret := _m.Called(appId, workflowNames, workflowIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllWorkflowRBACObjectsByAppId")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func(int, []string, []int) map[int]string); ok {
+		r0 = rf(appId, workflowNames, workflowIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetAppAndEnvObjectByDbPipeline provides a mock function with given fields: cdPipelines
+func (_m *EnforcerUtil) GetAppAndEnvObjectByDbPipeline(cdPipelines []*pipelineConfig.Pipeline) map[int][]string {
+	ret := _m.Called(cdPipelines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppAndEnvObjectByDbPipeline")
+	}
+
+	var r0 map[int][]string
+	if rf, ok := ret.Get(0).(func([]*pipelineConfig.Pipeline) map[int][]string); ok {
+		r0 = rf(cdPipelines)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]string)
+		}
+	}
+
+	return r0
+}
+
+// GetAppAndEnvObjectByPipeline provides a mock function with given fields: cdPipelines
+func (_m *EnforcerUtil) GetAppAndEnvObjectByPipeline(cdPipelines []*bean.CDPipelineConfigObject) map[int][]string {
+	ret := _m.Called(cdPipelines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppAndEnvObjectByPipeline")
+	}
+
+	var r0 map[int][]string
+	if rf, ok := ret.Get(0).(func([]*bean.CDPipelineConfigObject) map[int][]string); ok {
+		r0 = rf(cdPipelines)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]string)
+		}
+	}
+
+	return r0
+}
+
+// GetAppAndEnvObjectByPipelineIds provides a mock function with given fields: cdPipelineIds
+func (_m *EnforcerUtil) GetAppAndEnvObjectByPipelineIds(cdPipelineIds []int) map[int][]string {
+	ret := _m.Called(cdPipelineIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppAndEnvObjectByPipelineIds")
+	}
+
+	var r0 map[int][]string
+	if rf, ok := ret.Get(0).(func([]int) map[int][]string); ok {
+		r0 = rf(cdPipelineIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]string)
+		}
+	}
+
+	return r0
+}
+
+// GetAppAndEnvRBACNamesByAppAndEnvIds provides a mock function with given fields: IdToAppEnvPairs
+func (_m *EnforcerUtil) GetAppAndEnvRBACNamesByAppAndEnvIds(IdToAppEnvPairs map[int][2]int) (map[int]string, map[int]string, map[int]*app.App, map[int]*repository.Environment, error) {
+	ret := _m.Called(IdToAppEnvPairs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppAndEnvRBACNamesByAppAndEnvIds")
+	}
+
+	var r0 map[int]string
+	var r1 map[int]string
+	var r2 map[int]*app.App
+	var r3 map[int]*repository.Environment
+	var r4 error
+	if rf, ok := ret.Get(0).(func(map[int][2]int) (map[int]string, map[int]string, map[int]*app.App, map[int]*repository.Environment, error)); ok {
+		return rf(IdToAppEnvPairs)
+	}
+	if rf, ok := ret.Get(0).(func(map[int][2]int) map[int]string); ok {
+		r0 = rf(IdToAppEnvPairs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[int][2]int) map[int]string); ok {
+		r1 = rf(IdToAppEnvPairs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(map[int][2]int) map[int]*app.App); ok {
+		r2 = rf(IdToAppEnvPairs)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(map[int]*app.App)
+		}
+	}
+
+	if rf, ok := ret.Get(3).(func(map[int][2]int) map[int]*repository.Environment); ok {
+		r3 = rf(IdToAppEnvPairs)
+	} else {
+		if ret.Get(3) != nil {
+			r3 = ret.Get(3).(map[int]*repository.Environment)
+		}
+	}
+
+	if rf, ok := ret.Get(4).(func(map[int][2]int) error); ok {
+		r4 = rf(IdToAppEnvPairs)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
+// GetAppObjectByCiPipelineIds provides a mock function with given fields: ciPipelineIds
+func (_m *EnforcerUtil) GetAppObjectByCiPipelineIds(ciPipelineIds []int) map[int]string {
+	ret := _m.Called(ciPipelineIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppObjectByCiPipelineIds")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func([]int) map[int]string); ok {
+		r0 = rf(ciPipelineIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetAppRBACByAppIdAndPipelineId provides a mock function with given fields: appId, pipelineId
+func (_m *EnforcerUtil) GetAppRBACByAppIdAndPipelineId(appId int, pipelineId int) string {
+	ret := _m.Called(appId, pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACByAppIdAndPipelineId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(appId, pipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACByAppNameAndEnvId provides a mock function with given fields: appName, envId
+func (_m *EnforcerUtil) GetAppRBACByAppNameAndEnvId(appName string, envId int) string {
+	ret := _m.Called(appName, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACByAppNameAndEnvId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(appName, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACName provides a mock function with given fields: appName
+func (_m *EnforcerUtil) GetAppRBACName(appName string) string {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACNameByAppAndProjectName provides a mock function with given fields: projectName, appName
+func (_m *EnforcerUtil) GetAppRBACNameByAppAndProjectName(projectName string, appName string) string {
+	ret := _m.Called(projectName, appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACNameByAppAndProjectName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(projectName, appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACNameByAppId provides a mock function with given fields: appId
+func (_m *EnforcerUtil) GetAppRBACNameByAppId(appId int) string {
+	ret := _m.Called(appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACNameByAppId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(appId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACNameByAppName provides a mock function with given fields: appName
+func (_m *EnforcerUtil) GetAppRBACNameByAppName(appName string) string {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACNameByAppName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAppRBACNameByTeamIdAndAppId provides a mock function with given fields: teamId, appId
+func (_m *EnforcerUtil) GetAppRBACNameByTeamIdAndAppId(teamId int, appId int) string {
+	ret := _m.Called(teamId, appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppRBACNameByTeamIdAndAppId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(teamId, appId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetEnvRBACArrayByAppId provides a mock function with given fields: appId
+func (_m *EnforcerUtil) GetEnvRBACArrayByAppId(appId int) []string {
+	ret := _m.Called(appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACArrayByAppId")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(appId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetEnvRBACArrayByAppIdForJobs provides a mock function with given fields: appId
+func (_m *EnforcerUtil) GetEnvRBACArrayByAppIdForJobs(appId int) []string {
+	ret := _m.Called(appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACArrayByAppIdForJobs")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(appId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetEnvRBACNameByAppAndEnvName provides a mock function with given fields: appName, envName
+func (_m *EnforcerUtil) GetEnvRBACNameByAppAndEnvName(appName string, envName string) string {
+	ret := _m.Called(appName, envName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACNameByAppAndEnvName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(appName, envName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetEnvRBACNameByAppId provides a mock function with given fields: appId, envId
+func (_m *EnforcerUtil) GetEnvRBACNameByAppId(appId int, envId int) string {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACNameByAppId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(appId, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetEnvRBACNameByCdPipelineIdAndEnvId provides a mock function with given fields: cdPipelineId
+func (_m *EnforcerUtil) GetEnvRBACNameByCdPipelineIdAndEnvId(cdPipelineId int) string {
+	ret := _m.Called(cdPipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACNameByCdPipelineIdAndEnvId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(cdPipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetEnvRBACNameByCiPipelineIdAndEnvId provides a mock function with given fields: ciPipelineId, envId
+func (_m *EnforcerUtil) GetEnvRBACNameByCiPipelineIdAndEnvId(ciPipelineId int, envId int) string {
+	ret := _m.Called(ciPipelineId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvRBACNameByCiPipelineIdAndEnvId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(ciPipelineId, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetHelmObject provides a mock function with given fields: appId, envId
+func (_m *EnforcerUtil) GetHelmObject(appId int, envId int) (string, string) {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHelmObject")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(int, int) (string, string)); ok {
+		return rf(appId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(appId, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) string); ok {
+		r1 = rf(appId, envId)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetHelmObjectByAppNameAndEnvId provides a mock function with given fields: appName, envId
+func (_m *EnforcerUtil) GetHelmObjectByAppNameAndEnvId(appName string, envId int) (string, string) {
+	ret := _m.Called(appName, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHelmObjectByAppNameAndEnvId")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(string, int) (string, string)); ok {
+		return rf(appName, envId)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(appName, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) string); ok {
+		r1 = rf(appName, envId)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetHelmObjectByProjectIdAndEnvId provides a mock function with given fields: teamId, envId
+func (_m *EnforcerUtil) GetHelmObjectByProjectIdAndEnvId(teamId int, envId int) (string, string) {
+	ret := _m.Called(teamId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHelmObjectByProjectIdAndEnvId")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(int, int) (string, string)); ok {
+		return rf(teamId, envId)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(teamId, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) string); ok {
+		r1 = rf(teamId, envId)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetProjectAdminRBACNameBYAppName provides a mock function with given fields: appName
+func (_m *EnforcerUtil) GetProjectAdminRBACNameBYAppName(appName string) string {
+	ret := _m.Called(appName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProjectAdminRBACNameBYAppName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(appName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetRBACNameForClusterEntity provides a mock function with given fields: clusterName, resourceIdentifier
+func (_m *EnforcerUtil) GetRBACNameForClusterEntity(clusterName string, resourceIdentifier k8s.ResourceIdentifier) (string, string) {
+	ret := _m.Called(clusterName, resourceIdentifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRBACNameForClusterEntity")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(string, k8s.ResourceIdentifier) (string, string)); ok {
+		return rf(clusterName, resourceIdentifier)
+	}
+	if rf, ok := ret.Get(0).(func(string, k8s.ResourceIdentifier) string); ok {
+		r0 = rf(clusterName, resourceIdentifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, k8s.ResourceIdentifier) string); ok {
+		r1 = rf(clusterName, resourceIdentifier)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetRbacObjectNameByAppAndWorkflow provides a mock function with given fields: appName, workflowName
+func (_m *EnforcerUtil) GetRbacObjectNameByAppAndWorkflow(appName string, workflowName string) string {
+	ret := _m.Called(appName, workflowName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectNameByAppAndWorkflow")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(appName, workflowName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetRbacObjectNameByAppIdAndWorkflow provides a mock function with given fields: appId, workflowName
+func (_m *EnforcerUtil) GetRbacObjectNameByAppIdAndWorkflow(appId int, workflowName string) string {
+	ret := _m.Called(appId, workflowName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectNameByAppIdAndWorkflow")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, string) string); ok {
+		r0 = rf(appId, workflowName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetRbacObjectsByAppIds provides a mock function with given fields: appIds
+func (_m *EnforcerUtil) GetRbacObjectsByAppIds(appIds []int) map[int]string {
+	ret := _m.Called(appIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsByAppIds")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func([]int) map[int]string); ok {
+		r0 = rf(appIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetRbacObjectsByEnvIdsAndAppId provides a mock function with given fields: envIds, appId
+func (_m *EnforcerUtil) GetRbacObjectsByEnvIdsAndAppId(envIds []int, appId int) (map[int]string, map[string]string) {
+	ret := _m.Called(envIds, appId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsByEnvIdsAndAppId")
+	}
+
+	var r0 map[int]string
+	var r1 map[string]string
+	if rf, ok := ret.Get(0).(func([]int, int) (map[int]string, map[string]string)); ok {
+		return rf(envIds, appId)
+	}
+	if rf, ok := ret.Get(0).(func([]int, int) map[int]string); ok {
+		r0 = rf(envIds, appId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int, int) map[string]string); ok {
+		r1 = rf(envIds, appId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[string]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRbacObjectsByEnvIdsAndAppIdBatch provides a mock function with given fields: appIdToEnvIds
+func (_m *EnforcerUtil) GetRbacObjectsByEnvIdsAndAppIdBatch(appIdToEnvIds map[int][]int) map[int]map[int]string {
+	ret := _m.Called(appIdToEnvIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsByEnvIdsAndAppIdBatch")
+	}
+
+	var r0 map[int]map[int]string
+	if rf, ok := ret.Get(0).(func(map[int][]int) map[int]map[int]string); ok {
+		r0 = rf(appIdToEnvIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetRbacObjectsForAllApps provides a mock function with given fields: appType
+func (_m *EnforcerUtil) GetRbacObjectsForAllApps(appType helper.AppType) map[int]string {
+	ret := _m.Called(appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsForAllApps")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func(helper.AppType) map[int]string); ok {
+		r0 = rf(appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetRbacObjectsForAllAppsAndEnvironments provides a mock function with no fields
+func (_m *EnforcerUtil) GetRbacObjectsForAllAppsAndEnvironments() (map[int]string, map[string]string) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsForAllAppsAndEnvironments")
+	}
+
+	var r0 map[int]string
+	var r1 map[string]string
+	if rf, ok := ret.Get(0).(func() (map[int]string, map[string]string)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[int]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() map[string]string); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[string]string)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetRbacObjectsForAllAppsWithMatchingAppName provides a mock function with given fields: appNameMatch, appType
+func (_m *EnforcerUtil) GetRbacObjectsForAllAppsWithMatchingAppName(appNameMatch string, appType helper.AppType) map[int]string {
+	ret := _m.Called(appNameMatch, appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsForAllAppsWithMatchingAppName")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func(string, helper.AppType) map[int]string); ok {
+		r0 = rf(appNameMatch, appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetRbacObjectsForAllAppsWithTeamID provides a mock function with given fields: teamID, appType
+func (_m *EnforcerUtil) GetRbacObjectsForAllAppsWithTeamID(teamID int, appType helper.AppType) map[int]string {
+	ret := _m.Called(teamID, appType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacObjectsForAllAppsWithTeamID")
+	}
+
+	var r0 map[int]string
+	if rf, ok := ret.Get(0).(func(int, helper.AppType) map[int]string); ok {
+		r0 = rf(teamID, appType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	return r0
+}
+
+// GetRbacResourceAndObjectForNode provides a mock function with given fields: clusterName, nodeName
+func (_m *EnforcerUtil) GetRbacResourceAndObjectForNode(clusterName string, nodeName string) (string, string) {
+	ret := _m.Called(clusterName, nodeName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacResourceAndObjectForNode")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(string, string) (string, string)); ok {
+		return rf(clusterName, nodeName)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(clusterName, nodeName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) string); ok {
+		r1 = rf(clusterName, nodeName)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetRbacResourceAndObjectForNodeByClusterId provides a mock function with given fields: clusterId, nodeName
+func (_m *EnforcerUtil) GetRbacResourceAndObjectForNodeByClusterId(clusterId int, nodeName string) (string, string) {
+	ret := _m.Called(clusterId, nodeName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRbacResourceAndObjectForNodeByClusterId")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(int, string) (string, string)); ok {
+		return rf(clusterId, nodeName)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) string); ok {
+		r0 = rf(clusterId, nodeName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) string); ok {
+		r1 = rf(clusterId, nodeName)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetTeamAndEnvironmentRbacObjectByCDPipelineId provides a mock function with given fields: pipelineId
+func (_m *EnforcerUtil) GetTeamAndEnvironmentRbacObjectByCDPipelineId(pipelineId int) (string, string) {
+	ret := _m.Called(pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamAndEnvironmentRbacObjectByCDPipelineId")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(int) (string, string)); ok {
+		return rf(pipelineId)
+	}
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(pipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) string); ok {
+		r1 = rf(pipelineId)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// GetTeamEnvAppRbacObjectByAppIdEnvIdOrName provides a mock function with given fields: appId, envId, envName
+func (_m *EnforcerUtil) GetTeamEnvAppRbacObjectByAppIdEnvIdOrName(appId int, envId int, envName string) string {
+	ret := _m.Called(appId, envId, envName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamEnvAppRbacObjectByAppIdEnvIdOrName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int, string) string); ok {
+		r0 = rf(appId, envId, envName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTeamEnvRBACNameByAppId provides a mock function with given fields: appId, envId
+func (_m *EnforcerUtil) GetTeamEnvRBACNameByAppId(appId int, envId int) string {
+	ret := _m.Called(appId, envId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamEnvRBACNameByAppId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int) string); ok {
+		r0 = rf(appId, envId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTeamEnvRBACNameByCiPipelineIdAndEnvIdOrName provides a mock function with given fields: ciPipelineId, envId, envName
+func (_m *EnforcerUtil) GetTeamEnvRBACNameByCiPipelineIdAndEnvIdOrName(ciPipelineId int, envId int, envName string) string {
+	ret := _m.Called(ciPipelineId, envId, envName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamEnvRBACNameByCiPipelineIdAndEnvIdOrName")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, int, string) string); ok {
+		r0 = rf(ciPipelineId, envId, envName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTeamRBACByCiPipelineId provides a mock function with given fields: pipelineId
+func (_m *EnforcerUtil) GetTeamRBACByCiPipelineId(pipelineId int) string {
+	ret := _m.Called(pipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamRBACByCiPipelineId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(pipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetTeamRbacObjectByCiPipelineId provides a mock function with given fields: ciPipelineId
+func (_m *EnforcerUtil) GetTeamRbacObjectByCiPipelineId(ciPipelineId int) string {
+	ret := _m.Called(ciPipelineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamRbacObjectByCiPipelineId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int) string); ok {
+		r0 = rf(ciPipelineId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetWorkflowRBACByCiPipelineId provides a mock function with given fields: pipelineId, workflowName
+func (_m *EnforcerUtil) GetWorkflowRBACByCiPipelineId(pipelineId int, workflowName string) string {
+	ret := _m.Called(pipelineId, workflowName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowRBACByCiPipelineId")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(int, string) string); ok {
+		r0 = rf(pipelineId, workflowName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// IsAuthorizedForAppInAppResults provides a mock function with given fields: appId, rbacResults, appIdtoApp
+func (_m *EnforcerUtil) IsAuthorizedForAppInAppResults(appId int, rbacResults map[string]bool, appIdtoApp map[int]*app.App) bool {
+	ret := _m.Called(appId, rbacResults, appIdtoApp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthorizedForAppInAppResults")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, map[string]bool, map[int]*app.App) bool); ok {
+		r0 = rf(appId, rbacResults, appIdtoApp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsAuthorizedForEnvInEnvResults provides a mock function with given fields: appId, envId, appResults, appIdtoApp, envIdToEnv
+func (_m *EnforcerUtil) IsAuthorizedForEnvInEnvResults(appId int, envId int, appResults map[string]bool, appIdtoApp map[int]*app.App, envIdToEnv map[int]*repository.Environment) bool {
+	ret := _m.Called(appId, envId, appResults, appIdtoApp, envIdToEnv)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthorizedForEnvInEnvResults")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, int, map[string]bool, map[int]*app.App, map[int]*repository.Environment) bool); ok {
+		r0 = rf(appId, envId, appResults, appIdtoApp, envIdToEnv)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewEnforcerUtil creates a new instance of EnforcerUtil. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEnforcerUtil(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EnforcerUtil {
+	mock := &EnforcerUtil{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registryUtil
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	dockerRegistry "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
+)
+
+// GetRegistryHost returns the host of the registry url, as it appears in the references of the images pushed to it
+func GetRegistryHost(registryUrl string) string {
+	if !strings.Contains(registryUrl, "://") {
+		registryUrl = "https://" + registryUrl
+	}
+	parsedUrl, err := url.Parse(registryUrl)
+	if err != nil {
+		return ""
+	}
+	return parsedUrl.Host
+}
+
+// NewEcrSession returns the aws session for the ecr registry of the store
+func NewEcrSession(store *dockerRegistry.DockerArtifactStore) (*session.Session, error) {
+	awsCfg := &aws.Config{
+		Region: aws.String(store.AWSRegion),
+	}
+	// without access keys the default credential chain is used, like the IAM role of the orchestrator
+	if len(store.AWSAccessKeyId) != 0 && len(store.AWSSecretAccessKey.String()) != 0 {
+		awsCfg.Credentials = credentials.NewStaticCredentials(store.AWSAccessKeyId, store.AWSSecretAccessKey.String(), "")
+	}
+	return session.NewSession(awsCfg)
+}
@@ -48,9 +48,13 @@ import (
 	"github.com/devtron-labs/devtron/api/restHandler/app/appList"
 	configDiff2 "github.com/devtron-labs/devtron/api/restHandler/app/configDiff"
 	pipeline3 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline"
+	buildQueue2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/buildQueue"
 	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/configure"
 	history2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/history"
+	retention2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/retention"
+	schedule2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/schedule"
 	status3 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/status"
+	testReport2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/testReport"
 	trigger2 "github.com/devtron-labs/devtron/api/restHandler/app/pipeline/trigger"
 	"github.com/devtron-labs/devtron/api/restHandler/app/pipeline/webhook"
 	"github.com/devtron-labs/devtron/api/restHandler/app/workflow"
@@ -61,9 +65,13 @@ import (
 	appList2 "github.com/devtron-labs/devtron/api/router/app/appList"
 	configDiff3 "github.com/devtron-labs/devtron/api/router/app/configDiff"
 	pipeline4 "github.com/devtron-labs/devtron/api/router/app/pipeline"
+	buildQueue3 "github.com/devtron-labs/devtron/api/router/app/pipeline/buildQueue"
 	configure2 "github.com/devtron-labs/devtron/api/router/app/pipeline/configure"
 	history3 "github.com/devtron-labs/devtron/api/router/app/pipeline/history"
+	retention3 "github.com/devtron-labs/devtron/api/router/app/pipeline/retention"
+	schedule3 "github.com/devtron-labs/devtron/api/router/app/pipeline/schedule"
 	status4 "github.com/devtron-labs/devtron/api/router/app/pipeline/status"
+	testReport3 "github.com/devtron-labs/devtron/api/router/app/pipeline/testReport"
 	trigger3 "github.com/devtron-labs/devtron/api/router/app/pipeline/trigger"
 	workflow2 "github.com/devtron-labs/devtron/api/router/app/workflow"
 	server2 "github.com/devtron-labs/devtron/api/server"
@@ -91,7 +99,6 @@ import (
 	"github.com/devtron-labs/devtron/client/fluxcd"
 	"github.com/devtron-labs/devtron/client/gitSensor"
 	"github.com/devtron-labs/devtron/client/grafana"
-	"github.com/devtron-labs/devtron/client/lens"
 	"github.com/devtron-labs/devtron/client/proxy"
 	telemetry2 "github.com/devtron-labs/devtron/client/telemetry"
 	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
@@ -102,7 +109,7 @@ import (
 	"github.com/devtron-labs/devtron/internal/sql/repository/deploymentConfig"
 	repository10 "github.com/devtron-labs/devtron/internal/sql/repository/dockerRegistry"
 	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
-	repository25 "github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
+	repository27 "github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	"github.com/devtron-labs/devtron/internal/sql/repository/resourceGroup"
 	"github.com/devtron-labs/devtron/internal/util"
@@ -115,7 +122,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/appClone/batch"
 	appStatus2 "github.com/devtron-labs/devtron/pkg/appStatus"
 	"github.com/devtron-labs/devtron/pkg/appStore/chartGroup"
-	repository30 "github.com/devtron-labs/devtron/pkg/appStore/chartGroup/repository"
+	repository41 "github.com/devtron-labs/devtron/pkg/appStore/chartGroup/repository"
 	"github.com/devtron-labs/devtron/pkg/appStore/chartProvider"
 	"github.com/devtron-labs/devtron/pkg/appStore/discover/repository"
 	service7 "github.com/devtron-labs/devtron/pkg/appStore/discover/service"
@@ -147,11 +154,17 @@ import (
 	"github.com/devtron-labs/devtron/pkg/build/artifacts"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts/imageTagging"
 	read17 "github.com/devtron-labs/devtron/pkg/build/artifacts/imageTagging/read"
+	"github.com/devtron-labs/devtron/pkg/build/artifacts/retention"
+	repository46 "github.com/devtron-labs/devtron/pkg/build/artifacts/retention/repository"
+	"github.com/devtron-labs/devtron/pkg/build/buildQueue"
+	repository26 "github.com/devtron-labs/devtron/pkg/build/buildQueue/repository"
+	"github.com/devtron-labs/devtron/pkg/build/git/commitStatus"
+	repository20 "github.com/devtron-labs/devtron/pkg/build/git/commitStatus/repository"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitHost"
 	read21 "github.com/devtron-labs/devtron/pkg/build/git/gitHost/read"
-	repository28 "github.com/devtron-labs/devtron/pkg/build/git/gitHost/repository"
+	repository39 "github.com/devtron-labs/devtron/pkg/build/git/gitHost/repository"
 	read15 "github.com/devtron-labs/devtron/pkg/build/git/gitMaterial/read"
-	repository23 "github.com/devtron-labs/devtron/pkg/build/git/gitMaterial/repository"
+	repository21 "github.com/devtron-labs/devtron/pkg/build/git/gitMaterial/repository"
 	"github.com/devtron-labs/devtron/pkg/build/git/gitProvider"
 	read9 "github.com/devtron-labs/devtron/pkg/build/git/gitProvider/read"
 	repository13 "github.com/devtron-labs/devtron/pkg/build/git/gitProvider/repository"
@@ -159,8 +172,12 @@ import (
 	repository12 "github.com/devtron-labs/devtron/pkg/build/git/gitWebhook/repository"
 	pipeline2 "github.com/devtron-labs/devtron/pkg/build/pipeline"
 	read14 "github.com/devtron-labs/devtron/pkg/build/pipeline/read"
+	"github.com/devtron-labs/devtron/pkg/build/schedule"
+	repository45 "github.com/devtron-labs/devtron/pkg/build/schedule/repository"
+	"github.com/devtron-labs/devtron/pkg/build/testReport"
+	repository35 "github.com/devtron-labs/devtron/pkg/build/testReport/repository"
 	"github.com/devtron-labs/devtron/pkg/build/trigger"
-	repository31 "github.com/devtron-labs/devtron/pkg/bulkAction/repository"
+	repository43 "github.com/devtron-labs/devtron/pkg/bulkAction/repository"
 	service8 "github.com/devtron-labs/devtron/pkg/bulkAction/service"
 	"github.com/devtron-labs/devtron/pkg/chart"
 	"github.com/devtron-labs/devtron/pkg/chart/gitOpsConfig"
@@ -179,13 +196,29 @@ import (
 	"github.com/devtron-labs/devtron/pkg/config/configDiff"
 	read11 "github.com/devtron-labs/devtron/pkg/config/read"
 	delete2 "github.com/devtron-labs/devtron/pkg/delete"
+	"github.com/devtron-labs/devtron/pkg/deployment/approval"
+	repository29 "github.com/devtron-labs/devtron/pkg/deployment/approval/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/autoRollback"
+	repository34 "github.com/devtron-labs/devtron/pkg/deployment/autoRollback/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/clusterSet"
+	repository52 "github.com/devtron-labs/devtron/pkg/deployment/clusterSet/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/common"
 	read8 "github.com/devtron-labs/devtron/pkg/deployment/common/read"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp"
 	"github.com/devtron-labs/devtron/pkg/deployment/deployedApp/status/resourceTree"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentPreview"
+	"github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow"
+	repository30 "github.com/devtron-labs/devtron/pkg/deployment/deploymentWindow/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/driftDetection"
+	repository47 "github.com/devtron-labs/devtron/pkg/deployment/driftDetection/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/monoRepo"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	repository32 "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/validation"
+	"github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule"
+	repository48 "github.com/devtron-labs/devtron/pkg/deployment/hibernationSchedule/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/configMapAndSecret"
 	read20 "github.com/devtron-labs/devtron/pkg/deployment/manifest/configMapAndSecret/read"
@@ -197,9 +230,17 @@ import (
 	read7 "github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/read"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/validator"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/publish"
+	"github.com/devtron-labs/devtron/pkg/deployment/metricVerification"
+	repository36 "github.com/devtron-labs/devtron/pkg/deployment/metricVerification/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment"
+	repository44 "github.com/devtron-labs/devtron/pkg/deployment/previewEnvironment/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/providerConfig"
+	"github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration"
+	repository50 "github.com/devtron-labs/devtron/pkg/deployment/releaseOrchestration/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment"
+	repository51 "github.com/devtron-labs/devtron/pkg/deployment/scheduledDeployment/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
-	repository27 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/repository"
+	repository33 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/repository"
 	service4 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/service"
 	"github.com/devtron-labs/devtron/pkg/deploymentGroup"
 	"github.com/devtron-labs/devtron/pkg/devtronResource"
@@ -229,7 +270,9 @@ import (
 	"github.com/devtron-labs/devtron/pkg/k8s/capacity"
 	"github.com/devtron-labs/devtron/pkg/k8s/informer"
 	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
-	repository29 "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/repository"
+	repository40 "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/repository"
+	"github.com/devtron-labs/devtron/pkg/leaderElection"
+	repository49 "github.com/devtron-labs/devtron/pkg/leaderElection/repository"
 	"github.com/devtron-labs/devtron/pkg/module"
 	bean2 "github.com/devtron-labs/devtron/pkg/module/bean"
 	"github.com/devtron-labs/devtron/pkg/module/read"
@@ -239,23 +282,28 @@ import (
 	"github.com/devtron-labs/devtron/pkg/overview"
 	"github.com/devtron-labs/devtron/pkg/overview/cache"
 	config5 "github.com/devtron-labs/devtron/pkg/overview/config"
+	repository42 "github.com/devtron-labs/devtron/pkg/overview/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline"
 	"github.com/devtron-labs/devtron/pkg/pipeline/draftAwareConfigService"
 	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
 	"github.com/devtron-labs/devtron/pkg/pipeline/history"
-	repository24 "github.com/devtron-labs/devtron/pkg/pipeline/history/repository"
+	repository25 "github.com/devtron-labs/devtron/pkg/pipeline/history/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline/infraProviders"
 	"github.com/devtron-labs/devtron/pkg/pipeline/infraProviders/infraGetters/ci"
 	"github.com/devtron-labs/devtron/pkg/pipeline/infraProviders/infraGetters/job"
-	repository21 "github.com/devtron-labs/devtron/pkg/pipeline/repository"
+	repository23 "github.com/devtron-labs/devtron/pkg/pipeline/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
 	"github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus"
 	repository19 "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/repository"
 	"github.com/devtron-labs/devtron/pkg/plugin"
-	repository22 "github.com/devtron-labs/devtron/pkg/plugin/repository"
+	repository24 "github.com/devtron-labs/devtron/pkg/plugin/repository"
 	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning"
 	read19 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/read"
-	repository26 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository"
+	repository31 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning"
+	repository28 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageSigning/repository"
+	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom"
+	repository37 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/sbom/repository"
 	"github.com/devtron-labs/devtron/pkg/policyGovernance/security/scanTool"
 	repository17 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/scanTool/repository"
 	resourceGroup2 "github.com/devtron-labs/devtron/pkg/resourceGroup"
@@ -275,12 +323,14 @@ import (
 	"github.com/devtron-labs/devtron/pkg/variables/parsers"
 	repository14 "github.com/devtron-labs/devtron/pkg/variables/repository"
 	"github.com/devtron-labs/devtron/pkg/webhook/helm"
+	"github.com/devtron-labs/devtron/pkg/webhook/signature"
+	repository38 "github.com/devtron-labs/devtron/pkg/webhook/signature/repository"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
 	read18 "github.com/devtron-labs/devtron/pkg/workflow/cd/read"
 	"github.com/devtron-labs/devtron/pkg/workflow/dag"
 	status2 "github.com/devtron-labs/devtron/pkg/workflow/status"
 	"github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/hook"
-	repository20 "github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/repository"
+	repository22 "github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/repository"
 	service3 "github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/service"
 	"github.com/devtron-labs/devtron/pkg/workflow/workflowStatusLatest"
 	util2 "github.com/devtron-labs/devtron/util"
@@ -636,7 +686,13 @@ func InitializeApp() (*App, error) {
 	workflowStatusLatestRepositoryImpl := pipelineConfig.NewWorkflowStatusLatestRepositoryImpl(db, sugaredLogger)
 	workflowStatusLatestServiceImpl := workflowStatusLatest.NewWorkflowStatusLatestServiceImpl(sugaredLogger, workflowStatusLatestRepositoryImpl, ciWorkflowRepositoryImpl, cdWorkflowRepositoryImpl, ciPipelineRepositoryImpl)
 	cdWorkflowRunnerServiceImpl := cd.NewCdWorkflowRunnerServiceImpl(sugaredLogger, cdWorkflowRepositoryImpl, workFlowStageStatusServiceImpl, transactionUtilImpl, workflowStatusLatestServiceImpl)
-	deploymentEventHandlerImpl := app2.NewDeploymentEventHandlerImpl(sugaredLogger, eventRESTClientImpl, eventSimpleFactoryImpl, runnable)
+	commitStatusConfigRepositoryImpl := repository20.NewCommitStatusConfigRepositoryImpl(db, sugaredLogger)
+	materialRepositoryImpl := repository21.NewMaterialRepositoryImpl(db)
+	commitStatusServiceImpl, err := commitStatus.NewCommitStatusServiceImpl(sugaredLogger, commitStatusConfigRepositoryImpl, gitProviderReadServiceImpl, materialRepositoryImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, pipelineRepositoryImpl, cdWorkflowRepositoryImpl, attributesServiceImpl, runnable)
+	if err != nil {
+		return nil, err
+	}
+	deploymentEventHandlerImpl := app2.NewDeploymentEventHandlerImpl(sugaredLogger, eventRESTClientImpl, eventSimpleFactoryImpl, runnable, commitStatusServiceImpl)
 	appServiceImpl := app2.NewAppService(pipelineOverrideRepositoryImpl, utilMergeUtil, sugaredLogger, pipelineRepositoryImpl, eventRESTClientImpl, eventSimpleFactoryImpl, appRepositoryImpl, configMapRepositoryImpl, chartRepositoryImpl, cdWorkflowRepositoryImpl, commonServiceImpl, chartTemplateServiceImpl, pipelineStatusTimelineRepositoryImpl, pipelineStatusTimelineResourcesServiceImpl, pipelineStatusSyncDetailServiceImpl, pipelineStatusTimelineServiceImpl, appServiceConfig, appStatusServiceImpl, installedAppReadServiceImpl, installedAppVersionHistoryRepositoryImpl, scopedVariableCMCSManagerImpl, acdConfig, gitOpsConfigReadServiceImpl, gitOperationServiceImpl, deploymentTemplateServiceImpl, appListingServiceImpl, deploymentConfigServiceImpl, envConfigOverrideReadServiceImpl, cdWorkflowRunnerServiceImpl, deploymentEventHandlerImpl)
 	scopedVariableManagerImpl, err := variables.NewScopedVariableManagerImpl(sugaredLogger, scopedVariableServiceImpl, variableEntityMappingServiceImpl, variableSnapshotHistoryServiceImpl, variableTemplateParserImpl)
 	if err != nil {
@@ -650,17 +706,18 @@ func InitializeApp() (*App, error) {
 	ciInfraGetter := ci.NewCiInfraGetter(sugaredLogger, infraConfigServiceImpl, infraConfigAuditServiceImpl)
 	infraProviderImpl := infraProviders.NewInfraProviderImpl(sugaredLogger, infraGetter, ciInfraGetter)
 	serviceImpl := ucid.NewServiceImpl(sugaredLogger, k8sServiceImpl, acdAuthConfig)
-	workflowConfigSnapshotRepositoryImpl := repository20.NewWorkflowConfigSnapshotRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	workflowConfigSnapshotRepositoryImpl := repository22.NewWorkflowConfigSnapshotRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
 	workflowTriggerAuditServiceImpl := service3.NewWorkflowTriggerAuditServiceImpl(sugaredLogger, workflowConfigSnapshotRepositoryImpl, ciCdConfig, dockerRegistryConfigImpl, transactionUtilImpl)
 	triggerAuditHookImpl := hook.NewTriggerAuditHookImpl(sugaredLogger, workflowTriggerAuditServiceImpl)
 	workflowServiceImpl, err := executor.NewWorkflowServiceImpl(sugaredLogger, environmentRepositoryImpl, ciCdConfig, configReadServiceImpl, globalCMCSServiceImpl, argoWorkflowExecutorImpl, systemWorkflowExecutorImpl, k8sCommonServiceImpl, infraProviderImpl, serviceImpl, k8sServiceImpl, triggerAuditHookImpl, infraConfigAuditServiceImpl)
 	if err != nil {
 		return nil, err
 	}
-	pipelineStageRepositoryImpl := repository21.NewPipelineStageRepository(sugaredLogger, db)
-	globalPluginRepositoryImpl := repository22.NewGlobalPluginRepository(sugaredLogger, db)
+	pipelineStageRepositoryImpl := repository23.NewPipelineStageRepository(sugaredLogger, db)
+	globalPluginRepositoryImpl := repository24.NewGlobalPluginRepository(sugaredLogger, db)
 	globalPluginServiceImpl := plugin.NewGlobalPluginService(sugaredLogger, globalPluginRepositoryImpl, pipelineStageRepositoryImpl, userServiceImpl)
-	pipelineStageServiceImpl := pipeline.NewPipelineStageService(sugaredLogger, pipelineStageRepositoryImpl, globalPluginRepositoryImpl, pipelineRepositoryImpl, scopedVariableManagerImpl, globalPluginServiceImpl)
+	evaluatorServiceImpl := cel.NewCELServiceImpl(sugaredLogger)
+	pipelineStageServiceImpl := pipeline.NewPipelineStageService(sugaredLogger, pipelineStageRepositoryImpl, globalPluginRepositoryImpl, pipelineRepositoryImpl, scopedVariableManagerImpl, globalPluginServiceImpl, evaluatorServiceImpl)
 	ciTemplateRepositoryImpl := pipelineConfig.NewCiTemplateRepositoryImpl(db, sugaredLogger)
 	ciTemplateReadServiceImpl := pipeline2.NewCiTemplateReadServiceImpl(sugaredLogger, ciTemplateRepositoryImpl, ciTemplateOverrideRepositoryImpl)
 	appLabelRepositoryImpl := pipelineConfig.NewAppLabelRepositoryImpl(db)
@@ -668,7 +725,6 @@ func InitializeApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	materialRepositoryImpl := repository23.NewMaterialRepositoryImpl(db)
 	gitMaterialReadServiceImpl := read15.NewGitMaterialReadServiceImpl(sugaredLogger, materialRepositoryImpl)
 	appCrudOperationServiceImpl := app2.NewAppCrudOperationServiceImpl(appLabelRepositoryImpl, sugaredLogger, appRepositoryImpl, userRepositoryImpl, installedAppRepositoryImpl, genericNoteServiceImpl, installedAppDBServiceImpl, crudOperationServiceConfig, dbMigrationServiceImpl, gitMaterialReadServiceImpl)
 	imageTagRepositoryImpl := repository2.NewImageTagRepository(db, sugaredLogger)
@@ -681,39 +737,42 @@ func InitializeApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	prePostCdScriptHistoryRepositoryImpl := repository24.NewPrePostCdScriptHistoryRepositoryImpl(sugaredLogger, db)
-	configMapHistoryRepositoryImpl := repository24.NewConfigMapHistoryRepositoryImpl(sugaredLogger, db, transactionUtilImpl)
+	prePostCdScriptHistoryRepositoryImpl := repository25.NewPrePostCdScriptHistoryRepositoryImpl(sugaredLogger, db)
+	configMapHistoryRepositoryImpl := repository25.NewConfigMapHistoryRepositoryImpl(sugaredLogger, db, transactionUtilImpl)
 	configMapHistoryServiceImpl := configMapAndSecret.NewConfigMapHistoryServiceImpl(sugaredLogger, configMapHistoryRepositoryImpl, pipelineRepositoryImpl, configMapRepositoryImpl, userServiceImpl, scopedVariableCMCSManagerImpl)
 	prePostCdScriptHistoryServiceImpl := history.NewPrePostCdScriptHistoryServiceImpl(sugaredLogger, prePostCdScriptHistoryRepositoryImpl, configMapRepositoryImpl, configMapHistoryServiceImpl)
-	gitMaterialHistoryRepositoryImpl := repository24.NewGitMaterialHistoryRepositoyImpl(db)
+	gitMaterialHistoryRepositoryImpl := repository25.NewGitMaterialHistoryRepositoyImpl(db)
 	gitMaterialHistoryServiceImpl := history.NewGitMaterialHistoryServiceImpl(gitMaterialHistoryRepositoryImpl, sugaredLogger)
-	ciPipelineHistoryRepositoryImpl := repository24.NewCiPipelineHistoryRepositoryImpl(db, sugaredLogger)
+	ciPipelineHistoryRepositoryImpl := repository25.NewCiPipelineHistoryRepositoryImpl(db, sugaredLogger)
 	ciPipelineHistoryServiceImpl := history.NewCiPipelineHistoryServiceImpl(ciPipelineHistoryRepositoryImpl, sugaredLogger, ciPipelineRepositoryImpl)
 	ciBuildConfigRepositoryImpl := pipelineConfig.NewCiBuildConfigRepositoryImpl(db, sugaredLogger)
 	ciBuildConfigServiceImpl := pipeline.NewCiBuildConfigServiceImpl(sugaredLogger, ciBuildConfigRepositoryImpl)
 	ciTemplateServiceImpl := pipeline.NewCiTemplateServiceImpl(sugaredLogger, ciBuildConfigServiceImpl, ciTemplateRepositoryImpl, ciTemplateOverrideRepositoryImpl)
 	pipelineConfigRepositoryImpl := chartConfig.NewPipelineConfigRepository(db)
 	configMapServiceImpl := pipeline.NewConfigMapServiceImpl(chartRepositoryImpl, sugaredLogger, chartRepoRepositoryImpl, mergeUtil, pipelineConfigRepositoryImpl, configMapRepositoryImpl, commonServiceImpl, appRepositoryImpl, configMapHistoryServiceImpl, environmentRepositoryImpl, scopedVariableCMCSManagerImpl)
-	deploymentTemplateHistoryRepositoryImpl := repository24.NewDeploymentTemplateHistoryRepositoryImpl(sugaredLogger, db)
+	deploymentTemplateHistoryRepositoryImpl := repository25.NewDeploymentTemplateHistoryRepositoryImpl(sugaredLogger, db)
 	deploymentTemplateHistoryServiceImpl := deploymentTemplate.NewDeploymentTemplateHistoryServiceImpl(sugaredLogger, deploymentTemplateHistoryRepositoryImpl, pipelineRepositoryImpl, chartRepositoryImpl, userServiceImpl, cdWorkflowRepositoryImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, chartRefServiceImpl)
 	chartReadServiceImpl := read16.NewChartReadServiceImpl(sugaredLogger, chartRepositoryImpl, deploymentConfigServiceImpl, deployedAppMetricsServiceImpl, gitOpsConfigReadServiceImpl, chartRefReadServiceImpl)
 	chartServiceImpl := chart.NewChartServiceImpl(chartRepositoryImpl, sugaredLogger, chartTemplateServiceImpl, chartRepoRepositoryImpl, appRepositoryImpl, mergeUtil, envConfigOverrideRepositoryImpl, pipelineConfigRepositoryImpl, environmentRepositoryImpl, deploymentTemplateHistoryServiceImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, chartRefServiceImpl, gitOpsConfigReadServiceImpl, deploymentConfigServiceImpl, envConfigOverrideReadServiceImpl, chartReadServiceImpl)
 	ciCdPipelineOrchestratorImpl := pipeline.NewCiCdPipelineOrchestrator(appRepositoryImpl, sugaredLogger, materialRepositoryImpl, pipelineRepositoryImpl, ciPipelineRepositoryImpl, ciPipelineMaterialRepositoryImpl, cdWorkflowRepositoryImpl, clientImpl, ciCdConfig, appWorkflowRepositoryImpl, environmentRepositoryImpl, attributesServiceImpl, appCrudOperationServiceImpl, userAuthServiceImpl, prePostCdScriptHistoryServiceImpl, pipelineStageServiceImpl, gitMaterialHistoryServiceImpl, ciPipelineHistoryServiceImpl, ciTemplateReadServiceImpl, ciTemplateServiceImpl, dockerArtifactStoreRepositoryImpl, ciArtifactRepositoryImpl, configMapServiceImpl, customTagServiceImpl, genericNoteServiceImpl, chartServiceImpl, transactionUtilImpl, gitOpsConfigReadServiceImpl, deploymentConfigServiceImpl, deploymentConfigReadServiceImpl, chartReadServiceImpl, environmentVariables)
 	pluginInputVariableParserImpl := pipeline.NewPluginInputVariableParserImpl(sugaredLogger, dockerRegistryConfigImpl, customTagServiceImpl)
-	ciServiceImpl := pipeline.NewCiServiceImpl(sugaredLogger, workFlowStageStatusServiceImpl, eventRESTClientImpl, eventSimpleFactoryImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, transactionUtilImpl, workflowStatusLatestServiceImpl)
+	ciServiceImpl := pipeline.NewCiServiceImpl(sugaredLogger, workFlowStageStatusServiceImpl, eventRESTClientImpl, eventSimpleFactoryImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, transactionUtilImpl, workflowStatusLatestServiceImpl, commitStatusServiceImpl)
 	ciLogServiceImpl, err := pipeline.NewCiLogServiceImpl(sugaredLogger, k8sServiceImpl)
 	if err != nil {
 		return nil, err
 	}
 	blobStorageConfigServiceImpl := pipeline.NewBlobStorageConfigServiceImpl(sugaredLogger, k8sServiceImpl, ciCdConfig)
-	handlerServiceImpl := trigger.NewHandlerServiceImpl(sugaredLogger, workflowServiceImpl, ciPipelineMaterialRepositoryImpl, ciPipelineRepositoryImpl, ciArtifactRepositoryImpl, pipelineStageServiceImpl, userServiceImpl, ciTemplateReadServiceImpl, appCrudOperationServiceImpl, environmentRepositoryImpl, appRepositoryImpl, scopedVariableManagerImpl, customTagServiceImpl, ciCdPipelineOrchestratorImpl, attributesServiceImpl, pluginInputVariableParserImpl, globalPluginServiceImpl, ciServiceImpl, ciWorkflowRepositoryImpl, clientImpl, ciLogServiceImpl, blobStorageConfigServiceImpl, clusterServiceImplExtended, environmentServiceImpl, k8sServiceImpl, runnable, workflowTriggerAuditServiceImpl)
+	ciBuildQueueRepositoryImpl := repository26.NewCiBuildQueueRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	ciBuildQueueLimitRepositoryImpl := repository26.NewCiBuildQueueLimitRepositoryImpl(db, sugaredLogger)
+	ciBuildQueueServiceImpl := buildQueue.NewCiBuildQueueServiceImpl(sugaredLogger, ciBuildQueueRepositoryImpl, ciBuildQueueLimitRepositoryImpl)
+	handlerServiceImpl := trigger.NewHandlerServiceImpl(sugaredLogger, workflowServiceImpl, ciPipelineMaterialRepositoryImpl, ciPipelineRepositoryImpl, ciArtifactRepositoryImpl, pipelineStageServiceImpl, userServiceImpl, ciTemplateReadServiceImpl, appCrudOperationServiceImpl, environmentRepositoryImpl, appRepositoryImpl, scopedVariableManagerImpl, customTagServiceImpl, ciCdPipelineOrchestratorImpl, attributesServiceImpl, pluginInputVariableParserImpl, globalPluginServiceImpl, ciServiceImpl, ciWorkflowRepositoryImpl, clientImpl, ciLogServiceImpl, blobStorageConfigServiceImpl, clusterServiceImplExtended, environmentServiceImpl, k8sServiceImpl, runnable, workflowTriggerAuditServiceImpl, ciBuildQueueServiceImpl)
 	gitWebhookServiceImpl := gitWebhook.NewGitWebhookServiceImpl(sugaredLogger, gitWebhookRepositoryImpl, handlerServiceImpl)
 	gitWebhookRestHandlerImpl := restHandler.NewGitWebhookRestHandlerImpl(sugaredLogger, gitWebhookServiceImpl)
 	ecrConfig, err := pipeline.GetEcrConfig()
 	if err != nil {
 		return nil, err
 	}
-	ciTemplateHistoryRepositoryImpl := repository24.NewCiTemplateHistoryRepositoryImpl(db, sugaredLogger)
+	ciTemplateHistoryRepositoryImpl := repository25.NewCiTemplateHistoryRepositoryImpl(db, sugaredLogger)
 	ciTemplateHistoryServiceImpl := history.NewCiTemplateHistoryServiceImpl(ciTemplateHistoryRepositoryImpl, sugaredLogger)
 	resourceGroupRepositoryImpl := resourceGroup.NewResourceGroupRepositoryImpl(db)
 	resourceGroupMappingRepositoryImpl := resourceGroup.NewResourceGroupMappingRepositoryImpl(db)
@@ -721,14 +780,14 @@ func InitializeApp() (*App, error) {
 	buildPipelineSwitchServiceImpl := pipeline.NewBuildPipelineSwitchServiceImpl(sugaredLogger, ciPipelineConfigReadServiceImpl, ciPipelineRepositoryImpl, ciCdPipelineOrchestratorImpl, pipelineRepositoryImpl, ciWorkflowRepositoryImpl, appWorkflowRepositoryImpl, ciPipelineHistoryServiceImpl, ciTemplateOverrideRepositoryImpl, ciPipelineMaterialRepositoryImpl)
 	ciPipelineConfigServiceImpl := pipeline.NewCiPipelineConfigServiceImpl(sugaredLogger, ciCdPipelineOrchestratorImpl, dockerArtifactStoreRepositoryImpl, gitMaterialReadServiceImpl, appRepositoryImpl, pipelineRepositoryImpl, ciPipelineConfigReadServiceImpl, ciPipelineRepositoryImpl, ecrConfig, appWorkflowRepositoryImpl, ciCdConfig, attributesServiceImpl, pipelineStageServiceImpl, ciPipelineMaterialRepositoryImpl, ciTemplateServiceImpl, ciTemplateReadServiceImpl, ciTemplateOverrideRepositoryImpl, ciTemplateHistoryServiceImpl, enforcerUtilImpl, ciWorkflowRepositoryImpl, resourceGroupServiceImpl, customTagServiceImpl, cdWorkflowRepositoryImpl, buildPipelineSwitchServiceImpl, pipelineStageRepositoryImpl, globalPluginRepositoryImpl, appListingServiceImpl)
 	ciMaterialConfigServiceImpl := pipeline.NewCiMaterialConfigServiceImpl(sugaredLogger, materialRepositoryImpl, ciTemplateReadServiceImpl, ciCdPipelineOrchestratorImpl, ciPipelineRepositoryImpl, gitMaterialHistoryServiceImpl, pipelineRepositoryImpl, ciPipelineMaterialRepositoryImpl, transactionUtilImpl, gitMaterialReadServiceImpl)
-	imageTaggingRepositoryImpl := repository25.NewImageTaggingRepositoryImpl(db, transactionUtilImpl)
+	imageTaggingRepositoryImpl := repository27.NewImageTaggingRepositoryImpl(db, transactionUtilImpl)
 	imageTaggingReadServiceImpl, err := read17.NewImageTaggingReadServiceImpl(imageTaggingRepositoryImpl, sugaredLogger)
 	if err != nil {
 		return nil, err
 	}
 	imageTaggingServiceImpl := imageTagging.NewImageTaggingServiceImpl(imageTaggingRepositoryImpl, imageTaggingReadServiceImpl, ciPipelineRepositoryImpl, pipelineRepositoryImpl, environmentRepositoryImpl, sugaredLogger)
 	deploymentGroupRepositoryImpl := repository2.NewDeploymentGroupRepositoryImpl(sugaredLogger, db)
-	pipelineStrategyHistoryRepositoryImpl := repository24.NewPipelineStrategyHistoryRepositoryImpl(sugaredLogger, db)
+	pipelineStrategyHistoryRepositoryImpl := repository25.NewPipelineStrategyHistoryRepositoryImpl(sugaredLogger, db)
 	pipelineStrategyHistoryServiceImpl := history.NewPipelineStrategyHistoryServiceImpl(sugaredLogger, pipelineStrategyHistoryRepositoryImpl, userServiceImpl)
 	propertiesConfigServiceImpl := pipeline.NewPropertiesConfigServiceImpl(sugaredLogger, envConfigOverrideRepositoryImpl, chartRepositoryImpl, environmentRepositoryImpl, deploymentTemplateHistoryServiceImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, envConfigOverrideReadServiceImpl, deploymentConfigServiceImpl, chartServiceImpl)
 	installedAppDBExtendedServiceImpl := FullMode.NewInstalledAppDBExtendedServiceImpl(installedAppDBServiceImpl, appStatusServiceImpl, gitOpsConfigReadServiceImpl)
@@ -738,14 +797,20 @@ func InitializeApp() (*App, error) {
 	deploymentTypeOverrideServiceImpl := providerConfig.NewDeploymentTypeOverrideServiceImpl(sugaredLogger, environmentVariables, attributesServiceImpl)
 	deploymentServiceImpl := fluxcd.NewDeploymentService(sugaredLogger, k8sServiceImpl, gitOpsConfigReadServiceImpl)
 	cdPipelineConfigServiceImpl := pipeline.NewCdPipelineConfigServiceImpl(sugaredLogger, pipelineRepositoryImpl, environmentRepositoryImpl, pipelineConfigRepositoryImpl, appWorkflowRepositoryImpl, pipelineStageServiceImpl, appRepositoryImpl, appServiceImpl, deploymentGroupRepositoryImpl, ciCdPipelineOrchestratorImpl, appStatusRepositoryImpl, ciPipelineRepositoryImpl, prePostCdScriptHistoryServiceImpl, clusterRepositoryImpl, helmAppServiceImpl, enforcerUtilImpl, pipelineStrategyHistoryServiceImpl, chartRepositoryImpl, resourceGroupServiceImpl, propertiesConfigServiceImpl, deploymentTemplateHistoryServiceImpl, scopedVariableManagerImpl, environmentVariables, customTagServiceImpl, ciPipelineConfigServiceImpl, buildPipelineSwitchServiceImpl, argoClientWrapperServiceImpl, deployedAppMetricsServiceImpl, gitOpsConfigReadServiceImpl, gitOpsValidationServiceImpl, gitOperationServiceImpl, chartServiceImpl, imageDigestPolicyServiceImpl, pipelineConfigEventPublishServiceImpl, deploymentTypeOverrideServiceImpl, deploymentConfigServiceImpl, envConfigOverrideReadServiceImpl, chartRefReadServiceImpl, chartTemplateServiceImpl, gitFactory, clusterReadServiceImpl, installedAppReadServiceImpl, chartReadServiceImpl, helmAppReadServiceImpl, k8sServiceImpl, deploymentServiceImpl)
-	appArtifactManagerImpl := pipeline.NewAppArtifactManagerImpl(sugaredLogger, cdWorkflowRepositoryImpl, userServiceImpl, imageTaggingServiceImpl, ciArtifactRepositoryImpl, ciWorkflowRepositoryImpl, pipelineStageServiceImpl, cdPipelineConfigServiceImpl, dockerArtifactStoreRepositoryImpl, ciPipelineRepositoryImpl, ciTemplateReadServiceImpl)
+	trustedIdentityRepositoryImpl := repository28.NewTrustedIdentityRepositoryImpl(db, sugaredLogger)
+	signaturePolicyRepositoryImpl := repository28.NewSignaturePolicyRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	verificationResultRepositoryImpl := repository28.NewVerificationResultRepositoryImpl(db, sugaredLogger)
+	imageSignatureServiceImpl, err := imageSigning.NewImageSignatureServiceImpl(sugaredLogger, trustedIdentityRepositoryImpl, signaturePolicyRepositoryImpl, verificationResultRepositoryImpl, dockerArtifactStoreRepositoryImpl, ciPipelineConfigReadServiceImpl)
+	if err != nil {
+		return nil, err
+	}
+	appArtifactManagerImpl := pipeline.NewAppArtifactManagerImpl(sugaredLogger, cdWorkflowRepositoryImpl, userServiceImpl, imageTaggingServiceImpl, ciArtifactRepositoryImpl, ciWorkflowRepositoryImpl, pipelineStageServiceImpl, cdPipelineConfigServiceImpl, dockerArtifactStoreRepositoryImpl, ciPipelineRepositoryImpl, ciTemplateReadServiceImpl, imageSignatureServiceImpl)
 	devtronAppCMCSServiceImpl := pipeline.NewDevtronAppCMCSServiceImpl(sugaredLogger, appServiceImpl, attributesRepositoryImpl)
 	devtronAppStrategyServiceImpl := pipeline.NewDevtronAppStrategyServiceImpl(sugaredLogger, chartRepositoryImpl, globalStrategyMetadataChartRefMappingRepositoryImpl, ciCdPipelineOrchestratorImpl, cdPipelineConfigServiceImpl, chartRefServiceImpl)
 	cdWorkflowCommonServiceImpl, err := cd.NewCdWorkflowCommonServiceImpl(sugaredLogger, cdWorkflowRepositoryImpl, pipelineStatusTimelineServiceImpl, pipelineRepositoryImpl, pipelineStatusTimelineRepositoryImpl, deploymentConfigServiceImpl, cdWorkflowRunnerServiceImpl)
 	if err != nil {
 		return nil, err
 	}
-	evaluatorServiceImpl := cel.NewCELServiceImpl(sugaredLogger)
 	triggerEventEvaluatorImpl, err := celEvaluator.NewTriggerEventEvaluatorImpl(sugaredLogger, imageTaggingRepositoryImpl, attributesServiceImpl, evaluatorServiceImpl, teamReadServiceImpl)
 	if err != nil {
 		return nil, err
@@ -760,9 +825,15 @@ func InitializeApp() (*App, error) {
 	deploymentTemplateValidationServiceEntImpl := validator.NewDeploymentTemplateValidationServiceEntImpl()
 	deploymentTemplateValidationServiceImpl := validator.NewDeploymentTemplateValidationServiceImpl(sugaredLogger, chartRefServiceImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, deploymentTemplateValidationServiceEntImpl)
 	devtronAppGitOpConfigServiceImpl := gitOpsConfig.NewDevtronAppGitOpConfigServiceImpl(sugaredLogger, chartRepositoryImpl, chartServiceImpl, gitOpsConfigReadServiceImpl, gitOpsValidationServiceImpl, argoClientWrapperServiceImpl, deploymentConfigServiceImpl, chartReadServiceImpl)
-	ciHandlerImpl := pipeline.NewCiHandlerImpl(sugaredLogger, ciServiceImpl, ciPipelineMaterialRepositoryImpl, clientImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, userServiceImpl, eventRESTClientImpl, eventSimpleFactoryImpl, ciPipelineRepositoryImpl, appListingRepositoryImpl, pipelineRepositoryImpl, enforcerUtilImpl, resourceGroupServiceImpl, environmentRepositoryImpl, imageTaggingServiceImpl, k8sCommonServiceImpl, appWorkflowRepositoryImpl, customTagServiceImpl, workFlowStageStatusServiceImpl, workflowStatusLatestServiceImpl)
+	ciHandlerImpl := pipeline.NewCiHandlerImpl(sugaredLogger, ciServiceImpl, ciPipelineMaterialRepositoryImpl, clientImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, userServiceImpl, eventRESTClientImpl, eventSimpleFactoryImpl, ciPipelineRepositoryImpl, appListingRepositoryImpl, pipelineRepositoryImpl, enforcerUtilImpl, resourceGroupServiceImpl, environmentRepositoryImpl, imageTaggingServiceImpl, k8sCommonServiceImpl, appWorkflowRepositoryImpl, customTagServiceImpl, workFlowStageStatusServiceImpl, workflowStatusLatestServiceImpl, commitStatusServiceImpl)
 	cdWorkflowRunnerReadServiceImpl := read18.NewCdWorkflowRunnerReadServiceImpl(sugaredLogger, cdWorkflowRepositoryImpl, workflowStatusLatestServiceImpl, pipelineStageRepositoryImpl)
-	cdHandlerImpl := pipeline.NewCdHandlerImpl(sugaredLogger, userServiceImpl, cdWorkflowRepositoryImpl, ciArtifactRepositoryImpl, ciPipelineMaterialRepositoryImpl, pipelineRepositoryImpl, environmentRepositoryImpl, ciWorkflowRepositoryImpl, enforcerUtilImpl, resourceGroupServiceImpl, imageTaggingServiceImpl, k8sServiceImpl, customTagServiceImpl, deploymentConfigServiceImpl, workFlowStageStatusServiceImpl, cdWorkflowRunnerServiceImpl, workflowStatusLatestServiceImpl, pipelineStageRepositoryImpl, cdWorkflowRunnerReadServiceImpl)
+	deploymentApprovalRepositoryImpl := repository29.NewDeploymentApprovalRepositoryImpl(db, sugaredLogger)
+	deploymentWindowRepositoryImpl := repository30.NewDeploymentWindowRepositoryImpl(db, sugaredLogger)
+	deploymentWindowBypassAuditRepositoryImpl := repository30.NewDeploymentWindowBypassAuditRepositoryImpl(db, sugaredLogger)
+	deploymentWindowQueuedTriggerRepositoryImpl := repository30.NewDeploymentWindowQueuedTriggerRepositoryImpl(db, sugaredLogger)
+	deploymentWindowServiceImpl := deploymentWindow.NewDeploymentWindowServiceImpl(sugaredLogger, deploymentWindowRepositoryImpl, deploymentWindowBypassAuditRepositoryImpl, deploymentWindowQueuedTriggerRepositoryImpl, environmentRepositoryImpl)
+	deploymentApprovalServiceImpl := approval.NewDeploymentApprovalServiceImpl(sugaredLogger, deploymentApprovalRepositoryImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, userServiceImpl, roleGroupServiceImpl, eventSimpleFactoryImpl, eventRESTClientImpl, deploymentWindowServiceImpl)
+	cdHandlerImpl := pipeline.NewCdHandlerImpl(sugaredLogger, userServiceImpl, cdWorkflowRepositoryImpl, ciArtifactRepositoryImpl, ciPipelineMaterialRepositoryImpl, pipelineRepositoryImpl, environmentRepositoryImpl, ciWorkflowRepositoryImpl, enforcerUtilImpl, resourceGroupServiceImpl, imageTaggingServiceImpl, k8sServiceImpl, customTagServiceImpl, deploymentConfigServiceImpl, workFlowStageStatusServiceImpl, cdWorkflowRunnerServiceImpl, workflowStatusLatestServiceImpl, pipelineStageRepositoryImpl, cdWorkflowRunnerReadServiceImpl, deploymentApprovalServiceImpl)
 	appWorkflowServiceImpl := appWorkflow2.NewAppWorkflowServiceImpl(sugaredLogger, appWorkflowRepositoryImpl, ciCdPipelineOrchestratorImpl, ciPipelineRepositoryImpl, pipelineRepositoryImpl, enforcerUtilImpl, resourceGroupServiceImpl, appRepositoryImpl, userAuthServiceImpl, chartServiceImpl, deploymentConfigServiceImpl, pipelineBuilderImpl)
 	appCloneServiceImpl := appClone.NewAppCloneServiceImpl(sugaredLogger, pipelineBuilderImpl, attributesServiceImpl, chartServiceImpl, configMapServiceImpl, appWorkflowServiceImpl, appListingServiceImpl, propertiesConfigServiceImpl, pipelineStageServiceImpl, ciTemplateReadServiceImpl, appRepositoryImpl, ciPipelineRepositoryImpl, pipelineRepositoryImpl, ciPipelineConfigServiceImpl, gitOpsConfigReadServiceImpl, chartReadServiceImpl)
 	deploymentTemplateRepositoryImpl := repository2.NewDeploymentTemplateRepositoryImpl(db, sugaredLogger)
@@ -771,37 +842,66 @@ func InitializeApp() (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	cvePolicyRepositoryImpl := repository26.NewPolicyRepositoryImpl(db, sugaredLogger)
-	imageScanResultRepositoryImpl := repository26.NewImageScanResultRepositoryImpl(db, sugaredLogger)
-	imageScanDeployInfoRepositoryImpl := repository26.NewImageScanDeployInfoRepositoryImpl(db, sugaredLogger)
-	imageScanObjectMetaRepositoryImpl := repository26.NewImageScanObjectMetaRepositoryImpl(db, sugaredLogger)
-	imageScanHistoryRepositoryImpl := repository26.NewImageScanHistoryRepositoryImpl(db, sugaredLogger)
+	cvePolicyRepositoryImpl := repository31.NewPolicyRepositoryImpl(db, sugaredLogger)
+	imageScanResultRepositoryImpl := repository31.NewImageScanResultRepositoryImpl(db, sugaredLogger)
+	imageScanDeployInfoRepositoryImpl := repository31.NewImageScanDeployInfoRepositoryImpl(db, sugaredLogger)
+	imageScanObjectMetaRepositoryImpl := repository31.NewImageScanObjectMetaRepositoryImpl(db, sugaredLogger)
+	imageScanHistoryRepositoryImpl := repository31.NewImageScanHistoryRepositoryImpl(db, sugaredLogger)
 	imageScanHistoryReadServiceImpl := read19.NewImageScanHistoryReadService(sugaredLogger, imageScanHistoryRepositoryImpl)
-	cveStoreRepositoryImpl := repository26.NewCveStoreRepositoryImpl(db, sugaredLogger)
+	cveStoreRepositoryImpl := repository31.NewCveStoreRepositoryImpl(db, sugaredLogger)
 	policyServiceImpl := imageScanning.NewPolicyServiceImpl(environmentServiceImpl, sugaredLogger, appRepositoryImpl, pipelineOverrideRepositoryImpl, cvePolicyRepositoryImpl, clusterServiceImplExtended, pipelineRepositoryImpl, imageScanResultRepositoryImpl, imageScanDeployInfoRepositoryImpl, imageScanObjectMetaRepositoryImpl, httpClient, ciArtifactRepositoryImpl, ciCdConfig, imageScanHistoryReadServiceImpl, cveStoreRepositoryImpl, ciTemplateRepositoryImpl, clusterReadServiceImpl, transactionUtilImpl)
 	imageScanResultReadServiceImpl := read19.NewImageScanResultReadServiceImpl(sugaredLogger, imageScanResultRepositoryImpl)
 	draftAwareConfigServiceImpl := draftAwareConfigService.NewDraftAwareResourceServiceImpl(sugaredLogger, configMapServiceImpl, chartServiceImpl, propertiesConfigServiceImpl)
-	gitOpsManifestPushServiceImpl := publish.NewGitOpsManifestPushServiceImpl(sugaredLogger, pipelineStatusTimelineServiceImpl, pipelineOverrideRepositoryImpl, acdConfig, chartRefServiceImpl, gitOpsConfigReadServiceImpl, chartServiceImpl, gitOperationServiceImpl, argoClientWrapperServiceImpl, transactionUtilImpl, deploymentConfigServiceImpl, chartTemplateServiceImpl)
+	pullRequestModeConfigRepositoryImpl := repository32.NewPullRequestModeConfigRepositoryImpl(db, sugaredLogger)
+	gitOpsPullRequestRepositoryImpl := repository32.NewGitOpsPullRequestRepositoryImpl(db, sugaredLogger)
+	pullRequestModeServiceImpl := pullRequest.NewPullRequestModeServiceImpl(sugaredLogger, pullRequestModeConfigRepositoryImpl, gitOpsPullRequestRepositoryImpl, environmentRepositoryImpl, gitOperationServiceImpl, pipelineOverrideRepositoryImpl, cdWorkflowRepositoryImpl, cdWorkflowCommonServiceImpl, pipelineStatusTimelineServiceImpl, deploymentConfigServiceImpl, argoClientWrapperServiceImpl, acdConfig, deploymentEventHandlerImpl, transactionUtilImpl)
+	monoRepoServiceImpl := monoRepo.NewMonoRepoServiceImpl(sugaredLogger, environmentVariables, appRepositoryImpl, environmentRepositoryImpl, pipelineRepositoryImpl, gitOpsConfigReadServiceImpl, gitOperationServiceImpl, argoClientWrapperServiceImpl, deploymentConfigServiceImpl, chartServiceImpl, chartTemplateServiceImpl, transactionUtilImpl)
+	gitOpsManifestPushServiceImpl := publish.NewGitOpsManifestPushServiceImpl(sugaredLogger, pipelineStatusTimelineServiceImpl, pipelineOverrideRepositoryImpl, acdConfig, chartRefServiceImpl, gitOpsConfigReadServiceImpl, chartServiceImpl, gitOperationServiceImpl, argoClientWrapperServiceImpl, transactionUtilImpl, deploymentConfigServiceImpl, chartTemplateServiceImpl, pullRequestModeServiceImpl, monoRepoServiceImpl)
 	manifestCreationServiceImpl := manifest.NewManifestCreationServiceImpl(sugaredLogger, dockerRegistryIpsConfigServiceImpl, chartRefServiceImpl, scopedVariableCMCSManagerImpl, k8sCommonServiceImpl, deployedAppMetricsServiceImpl, imageDigestPolicyServiceImpl, utilMergeUtil, appCrudOperationServiceImpl, deploymentTemplateServiceImpl, argoClientWrapperServiceImpl, configMapHistoryRepositoryImpl, configMapRepositoryImpl, chartRepositoryImpl, envConfigOverrideRepositoryImpl, environmentRepositoryImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, pipelineOverrideRepositoryImpl, pipelineStrategyHistoryRepositoryImpl, pipelineConfigRepositoryImpl, deploymentTemplateHistoryRepositoryImpl, deploymentConfigServiceImpl, envConfigOverrideReadServiceImpl)
 	configMapHistoryReadServiceImpl := read20.NewConfigMapHistoryReadService(sugaredLogger, configMapHistoryRepositoryImpl, scopedVariableCMCSManagerImpl)
 	deployedConfigurationHistoryServiceImpl := history.NewDeployedConfigurationHistoryServiceImpl(sugaredLogger, userServiceImpl, deploymentTemplateHistoryServiceImpl, pipelineStrategyHistoryServiceImpl, configMapHistoryServiceImpl, cdWorkflowRepositoryImpl, scopedVariableCMCSManagerImpl, deploymentTemplateHistoryReadServiceImpl, configMapHistoryReadServiceImpl)
-	userDeploymentRequestRepositoryImpl := repository27.NewUserDeploymentRequestRepositoryImpl(db, transactionUtilImpl)
+	userDeploymentRequestRepositoryImpl := repository33.NewUserDeploymentRequestRepositoryImpl(db, transactionUtilImpl)
 	userDeploymentRequestServiceImpl := service4.NewUserDeploymentRequestServiceImpl(sugaredLogger, userDeploymentRequestRepositoryImpl)
 	imageScanDeployInfoReadServiceImpl := read19.NewImageScanDeployInfoReadService(sugaredLogger, imageScanDeployInfoRepositoryImpl)
 	imageScanDeployInfoServiceImpl := imageScanning.NewImageScanDeployInfoService(sugaredLogger, imageScanDeployInfoRepositoryImpl)
-	manifestPushConfigRepositoryImpl := repository21.NewManifestPushConfigRepository(sugaredLogger, db)
-	scanToolExecutionHistoryMappingRepositoryImpl := repository26.NewScanToolExecutionHistoryMappingRepositoryImpl(db, sugaredLogger)
+	manifestPushConfigRepositoryImpl := repository23.NewManifestPushConfigRepository(sugaredLogger, db)
+	scanToolExecutionHistoryMappingRepositoryImpl := repository31.NewScanToolExecutionHistoryMappingRepositoryImpl(db, sugaredLogger)
 	cdWorkflowReadServiceImpl := read18.NewCdWorkflowReadServiceImpl(sugaredLogger, cdWorkflowRepositoryImpl)
 	imageScanServiceImpl := imageScanning.NewImageScanServiceImpl(sugaredLogger, imageScanHistoryRepositoryImpl, imageScanResultRepositoryImpl, imageScanObjectMetaRepositoryImpl, cveStoreRepositoryImpl, imageScanDeployInfoRepositoryImpl, userServiceImpl, appRepositoryImpl, environmentServiceImpl, ciArtifactRepositoryImpl, policyServiceImpl, pipelineRepositoryImpl, ciPipelineRepositoryImpl, scanToolMetadataRepositoryImpl, scanToolExecutionHistoryMappingRepositoryImpl, cvePolicyRepositoryImpl, cdWorkflowReadServiceImpl)
-	devtronAppsHandlerServiceImpl, err := devtronApps.NewHandlerServiceImpl(sugaredLogger, cdWorkflowCommonServiceImpl, gitOpsManifestPushServiceImpl, gitOpsConfigReadServiceImpl, argoK8sClientImpl, acdConfig, argoClientWrapperServiceImpl, pipelineStatusTimelineServiceImpl, chartTemplateServiceImpl, workflowEventPublishServiceImpl, manifestCreationServiceImpl, deployedConfigurationHistoryServiceImpl, pipelineStageServiceImpl, globalPluginServiceImpl, customTagServiceImpl, pluginInputVariableParserImpl, prePostCdScriptHistoryServiceImpl, scopedVariableCMCSManagerImpl, imageDigestPolicyServiceImpl, userServiceImpl, helmAppServiceImpl, enforcerUtilImpl, userDeploymentRequestServiceImpl, helmAppClientImpl, eventSimpleFactoryImpl, eventRESTClientImpl, environmentVariables, appRepositoryImpl, ciPipelineMaterialRepositoryImpl, imageScanHistoryReadServiceImpl, imageScanDeployInfoReadServiceImpl, imageScanDeployInfoServiceImpl, pipelineRepositoryImpl, pipelineOverrideRepositoryImpl, manifestPushConfigRepositoryImpl, chartRepositoryImpl, environmentRepositoryImpl, cdWorkflowRepositoryImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, ciTemplateReadServiceImpl, gitMaterialReadServiceImpl, appLabelRepositoryImpl, ciPipelineRepositoryImpl, appWorkflowRepositoryImpl, dockerArtifactStoreRepositoryImpl, imageScanServiceImpl, k8sServiceImpl, transactionUtilImpl, deploymentConfigServiceImpl, ciCdPipelineOrchestratorImpl, gitOperationServiceImpl, attributesServiceImpl, clusterRepositoryImpl, cdWorkflowRunnerServiceImpl, clusterServiceImplExtended, ciLogServiceImpl, workflowServiceImpl, blobStorageConfigServiceImpl, deploymentEventHandlerImpl, runnable, workflowTriggerAuditServiceImpl, deploymentServiceImpl, workflowStatusLatestServiceImpl)
+	autoRollbackPolicyRepositoryImpl := repository34.NewAutoRollbackPolicyRepositoryImpl(db, sugaredLogger)
+	autoRollbackHistoryRepositoryImpl := repository34.NewAutoRollbackHistoryRepositoryImpl(db, sugaredLogger)
+	autoRollbackServiceImpl := autoRollback.NewAutoRollbackServiceImpl(sugaredLogger, autoRollbackPolicyRepositoryImpl, autoRollbackHistoryRepositoryImpl, pipelineRepositoryImpl, cdWorkflowRepositoryImpl, pipelineOverrideRepositoryImpl, ciArtifactRepositoryImpl, eventSimpleFactoryImpl, eventRESTClientImpl)
+	cdPipelineTestGateRepositoryImpl := repository35.NewCdPipelineTestGateRepositoryImpl(db, sugaredLogger)
+	ciWorkflowTestSummaryRepositoryImpl := repository35.NewCiWorkflowTestSummaryRepositoryImpl(db, sugaredLogger)
+	testReportServiceImpl := testReport.NewTestReportServiceImpl(sugaredLogger, ciWorkflowTestSummaryRepositoryImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, pipelineStageRepositoryImpl, handlerServiceImpl)
+	testGateServiceImpl := testReport.NewTestGateServiceImpl(sugaredLogger, cdPipelineTestGateRepositoryImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, testReportServiceImpl)
+	clusterSetRepositoryImpl := repository52.NewClusterSetRepositoryImpl(db, sugaredLogger)
+	devtronAppsHandlerServiceImpl, err := devtronApps.NewHandlerServiceImpl(sugaredLogger, cdWorkflowCommonServiceImpl, gitOpsManifestPushServiceImpl, gitOpsConfigReadServiceImpl, argoK8sClientImpl, acdConfig, argoClientWrapperServiceImpl, pipelineStatusTimelineServiceImpl, chartTemplateServiceImpl, workflowEventPublishServiceImpl, manifestCreationServiceImpl, deployedConfigurationHistoryServiceImpl, pipelineStageServiceImpl, globalPluginServiceImpl, customTagServiceImpl, pluginInputVariableParserImpl, prePostCdScriptHistoryServiceImpl, scopedVariableCMCSManagerImpl, imageDigestPolicyServiceImpl, userServiceImpl, helmAppServiceImpl, enforcerUtilImpl, userDeploymentRequestServiceImpl, helmAppClientImpl, eventSimpleFactoryImpl, eventRESTClientImpl, environmentVariables, appRepositoryImpl, ciPipelineMaterialRepositoryImpl, imageScanHistoryReadServiceImpl, imageScanDeployInfoReadServiceImpl, imageScanDeployInfoServiceImpl, pipelineRepositoryImpl, pipelineOverrideRepositoryImpl, manifestPushConfigRepositoryImpl, chartRepositoryImpl, environmentRepositoryImpl, cdWorkflowRepositoryImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, ciTemplateReadServiceImpl, gitMaterialReadServiceImpl, appLabelRepositoryImpl, ciPipelineRepositoryImpl, appWorkflowRepositoryImpl, dockerArtifactStoreRepositoryImpl, imageScanServiceImpl, k8sServiceImpl, transactionUtilImpl, deploymentConfigServiceImpl, ciCdPipelineOrchestratorImpl, gitOperationServiceImpl, attributesServiceImpl, clusterRepositoryImpl, cdWorkflowRunnerServiceImpl, clusterServiceImplExtended, ciLogServiceImpl, workflowServiceImpl, blobStorageConfigServiceImpl, deploymentEventHandlerImpl, runnable, workflowTriggerAuditServiceImpl, deploymentServiceImpl, workflowStatusLatestServiceImpl, deploymentWindowServiceImpl, deploymentApprovalServiceImpl, autoRollbackServiceImpl, testGateServiceImpl, imageSignatureServiceImpl, commitStatusServiceImpl, clusterSetRepositoryImpl)
 	if err != nil {
 		return nil, err
 	}
 	pipelineConfigRestHandlerImpl := configure.NewPipelineRestHandlerImpl(pipelineBuilderImpl, sugaredLogger, deploymentTemplateValidationServiceImpl, chartServiceImpl, devtronAppGitOpConfigServiceImpl, propertiesConfigServiceImpl, userServiceImpl, teamServiceImpl, enforcerImpl, ciHandlerImpl, validate, clientImpl, ciPipelineRepositoryImpl, pipelineRepositoryImpl, enforcerUtilImpl, dockerRegistryConfigImpl, cdHandlerImpl, appCloneServiceImpl, generateManifestDeploymentTemplateServiceImpl, appWorkflowServiceImpl, gitMaterialReadServiceImpl, policyServiceImpl, imageScanResultReadServiceImpl, ciPipelineMaterialRepositoryImpl, imageTaggingReadServiceImpl, imageTaggingServiceImpl, ciArtifactRepositoryImpl, deployedAppMetricsServiceImpl, chartRefServiceImpl, ciCdPipelineOrchestratorImpl, gitProviderReadServiceImpl, teamReadServiceImpl, environmentRepositoryImpl, chartReadServiceImpl, draftAwareConfigServiceImpl, handlerServiceImpl, devtronAppsHandlerServiceImpl)
 	commonArtifactServiceImpl := artifacts.NewCommonArtifactServiceImpl(sugaredLogger, ciArtifactRepositoryImpl)
 	fluxApplicationServiceImpl := fluxApplication.NewFluxApplicationServiceImpl(sugaredLogger, helmAppReadServiceImpl, clusterServiceImplExtended, helmAppClientImpl, pumpImpl, pipelineRepositoryImpl, installedAppRepositoryImpl)
-	workflowDagExecutorImpl := dag.NewWorkflowDagExecutorImpl(sugaredLogger, pipelineRepositoryImpl, pipelineOverrideRepositoryImpl, cdWorkflowRepositoryImpl, ciArtifactRepositoryImpl, enforcerUtilImpl, appWorkflowRepositoryImpl, pipelineStageServiceImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, pipelineStageRepositoryImpl, globalPluginRepositoryImpl, eventRESTClientImpl, eventSimpleFactoryImpl, customTagServiceImpl, pipelineStatusTimelineServiceImpl, cdWorkflowRunnerServiceImpl, ciServiceImpl, helmAppServiceImpl, cdWorkflowCommonServiceImpl, devtronAppsHandlerServiceImpl, userDeploymentRequestServiceImpl, manifestCreationServiceImpl, commonArtifactServiceImpl, deploymentConfigServiceImpl, runnable, imageScanHistoryRepositoryImpl, imageScanServiceImpl, k8sServiceImpl, environmentRepositoryImpl, k8sCommonServiceImpl, workflowServiceImpl, handlerServiceImpl, workflowTriggerAuditServiceImpl, fluxApplicationServiceImpl)
-	externalCiRestHandlerImpl := restHandler.NewExternalCiRestHandlerImpl(sugaredLogger, validate, userServiceImpl, enforcerImpl, workflowDagExecutorImpl)
+	metricVerificationPolicyRepositoryImpl := repository36.NewMetricVerificationPolicyRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	metricVerificationRunRepositoryImpl := repository36.NewMetricVerificationRunRepositoryImpl(db, sugaredLogger)
+	metricEvaluatorImpl := metricVerification.NewMetricEvaluatorImpl(sugaredLogger)
+	metricVerificationServiceImpl := metricVerification.NewMetricVerificationServiceImpl(sugaredLogger, metricVerificationPolicyRepositoryImpl, metricVerificationRunRepositoryImpl, metricEvaluatorImpl, pipelineRepositoryImpl, environmentRepositoryImpl, cdWorkflowRepositoryImpl, cdWorkflowRunnerServiceImpl, pipelineOverrideRepositoryImpl, pipelineStatusTimelineServiceImpl, deploymentEventHandlerImpl)
+	artifactSbomRepositoryImpl := repository37.NewArtifactSbomRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	sbomServiceImpl, err := sbom.NewSbomServiceImpl(sugaredLogger, artifactSbomRepositoryImpl, ciArtifactRepositoryImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, handlerServiceImpl)
+	if err != nil {
+		return nil, err
+	}
+	workflowDagExecutorImpl := dag.NewWorkflowDagExecutorImpl(sugaredLogger, pipelineRepositoryImpl, pipelineOverrideRepositoryImpl, cdWorkflowRepositoryImpl, ciArtifactRepositoryImpl, enforcerUtilImpl, appWorkflowRepositoryImpl, pipelineStageServiceImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, pipelineStageRepositoryImpl, globalPluginRepositoryImpl, eventRESTClientImpl, eventSimpleFactoryImpl, customTagServiceImpl, pipelineStatusTimelineServiceImpl, cdWorkflowRunnerServiceImpl, ciServiceImpl, helmAppServiceImpl, cdWorkflowCommonServiceImpl, devtronAppsHandlerServiceImpl, userDeploymentRequestServiceImpl, manifestCreationServiceImpl, commonArtifactServiceImpl, deploymentConfigServiceImpl, runnable, imageScanHistoryRepositoryImpl, imageScanServiceImpl, k8sServiceImpl, environmentRepositoryImpl, k8sCommonServiceImpl, workflowServiceImpl, handlerServiceImpl, workflowTriggerAuditServiceImpl, fluxApplicationServiceImpl, metricVerificationServiceImpl, testReportServiceImpl, sbomServiceImpl, commitStatusServiceImpl)
+	webhookSignatureConfigRepositoryImpl := repository38.NewWebhookSignatureConfigRepositoryImpl(db, sugaredLogger, environmentVariables, transactionUtilImpl)
+	webhookDeliveryRepositoryImpl := repository38.NewWebhookDeliveryRepositoryImpl(db, sugaredLogger)
+	gitHostRepositoryImpl := repository39.NewGitHostRepositoryImpl(db)
+	gitHostReadServiceImpl := read21.NewGitHostReadServiceImpl(sugaredLogger, gitHostRepositoryImpl, attributesServiceImpl)
+	webhookSignatureServiceImpl, err := signature.NewWebhookSignatureServiceImpl(sugaredLogger, webhookSignatureConfigRepositoryImpl, webhookDeliveryRepositoryImpl, gitHostReadServiceImpl, ciPipelineRepositoryImpl)
+	if err != nil {
+		return nil, err
+	}
+	externalCiRestHandlerImpl := restHandler.NewExternalCiRestHandlerImpl(sugaredLogger, validate, userServiceImpl, enforcerImpl, workflowDagExecutorImpl, webhookSignatureServiceImpl)
 	pubSubClientRestHandlerImpl := restHandler.NewPubSubClientRestHandlerImpl(pubSubClientServiceImpl, sugaredLogger, ciCdConfig)
 	webhookRouterImpl := router.NewWebhookRouterImpl(gitWebhookRestHandlerImpl, pipelineConfigRestHandlerImpl, externalCiRestHandlerImpl, pubSubClientRestHandlerImpl)
 	userAuthHandlerImpl := user2.NewUserAuthHandlerImpl(userAuthServiceImpl, validate, sugaredLogger, enforcerImpl)
@@ -816,9 +916,7 @@ func InitializeApp() (*App, error) {
 	deleteServiceFullModeImpl := delete2.NewDeleteServiceFullModeImpl(sugaredLogger, gitMaterialReadServiceImpl, gitRegistryConfigImpl, ciTemplateRepositoryImpl, dockerRegistryConfigImpl, dockerArtifactStoreRepositoryImpl)
 	gitProviderRestHandlerImpl := restHandler.NewGitProviderRestHandlerImpl(dockerRegistryConfigImpl, sugaredLogger, gitRegistryConfigImpl, userServiceImpl, validate, enforcerImpl, teamServiceImpl, deleteServiceFullModeImpl, gitProviderReadServiceImpl)
 	gitProviderRouterImpl := router.NewGitProviderRouterImpl(gitProviderRestHandlerImpl)
-	gitHostRepositoryImpl := repository28.NewGitHostRepositoryImpl(db)
 	gitHostConfigImpl := gitHost.NewGitHostConfigImpl(gitHostRepositoryImpl, sugaredLogger)
-	gitHostReadServiceImpl := read21.NewGitHostReadServiceImpl(sugaredLogger, gitHostRepositoryImpl, attributesServiceImpl)
 	gitHostRestHandlerImpl := restHandler.NewGitHostRestHandlerImpl(sugaredLogger, gitHostConfigImpl, userServiceImpl, validate, enforcerImpl, clientImpl, gitProviderReadServiceImpl, gitHostReadServiceImpl)
 	gitHostRouterImpl := router.NewGitHostRouterImpl(gitHostRestHandlerImpl)
 	chartProviderServiceImpl := chartProvider.NewChartProviderServiceImpl(sugaredLogger, chartRepoRepositoryImpl, chartRepositoryServiceImpl, dockerArtifactStoreRepositoryImpl, ociRegistryConfigRepositoryImpl)
@@ -844,7 +942,7 @@ func InitializeApp() (*App, error) {
 	chartRefRouterImpl := router.NewChartRefRouterImpl(chartRefRestHandlerImpl)
 	configMapRestHandlerImpl := restHandler.NewConfigMapRestHandlerImpl(pipelineBuilderImpl, sugaredLogger, chartServiceImpl, userServiceImpl, teamServiceImpl, enforcerImpl, pipelineRepositoryImpl, enforcerUtilImpl, configMapServiceImpl, draftAwareConfigServiceImpl)
 	configMapRouterImpl := router.NewConfigMapRouterImpl(configMapRestHandlerImpl)
-	k8sResourceHistoryRepositoryImpl := repository29.NewK8sResourceHistoryRepositoryImpl(db, sugaredLogger)
+	k8sResourceHistoryRepositoryImpl := repository40.NewK8sResourceHistoryRepositoryImpl(db, sugaredLogger)
 	k8sResourceHistoryServiceImpl := kubernetesResourceAuditLogs.Newk8sResourceHistoryServiceImpl(k8sResourceHistoryRepositoryImpl, sugaredLogger, appRepositoryImpl, environmentRepositoryImpl)
 	ephemeralContainersRepositoryImpl := repository6.NewEphemeralContainersRepositoryImpl(db, transactionUtilImpl)
 	ephemeralContainerServiceImpl := cluster.NewEphemeralContainerServiceImpl(ephemeralContainersRepositoryImpl, sugaredLogger)
@@ -857,9 +955,9 @@ func InitializeApp() (*App, error) {
 	argoApplicationReadServiceImpl := read22.NewArgoApplicationReadServiceImpl(sugaredLogger, clusterRepositoryImpl, k8sServiceImpl, helmAppClientImpl, helmAppServiceImpl)
 	argoApplicationServiceExtendedImpl := argoApplication.NewArgoApplicationServiceExtendedServiceImpl(acdAuthConfig, argoApplicationServiceImpl, argoClientWrapperServiceImpl, argoApplicationReadServiceImpl, clusterServiceImplExtended, runnable)
 	installedAppResourceServiceImpl := resource.NewInstalledAppResourceServiceImpl(sugaredLogger, installedAppRepositoryImpl, appStoreApplicationVersionRepositoryImpl, argoClientWrapperServiceImpl, acdAuthConfig, installedAppVersionHistoryRepositoryImpl, helmAppServiceImpl, helmAppReadServiceImpl, appStatusServiceImpl, k8sCommonServiceImpl, k8sApplicationServiceImpl, k8sServiceImpl, deploymentConfigServiceImpl, ociRegistryConfigRepositoryImpl, argoApplicationServiceExtendedImpl, fluxApplicationServiceImpl)
-	chartGroupEntriesRepositoryImpl := repository30.NewChartGroupEntriesRepositoryImpl(db, sugaredLogger)
-	chartGroupReposotoryImpl := repository30.NewChartGroupReposotoryImpl(db, sugaredLogger)
-	chartGroupDeploymentRepositoryImpl := repository30.NewChartGroupDeploymentRepositoryImpl(db, sugaredLogger)
+	chartGroupEntriesRepositoryImpl := repository41.NewChartGroupEntriesRepositoryImpl(db, sugaredLogger)
+	chartGroupReposotoryImpl := repository41.NewChartGroupReposotoryImpl(db, sugaredLogger)
+	chartGroupDeploymentRepositoryImpl := repository41.NewChartGroupDeploymentRepositoryImpl(db, sugaredLogger)
 	appStoreVersionValuesRepositoryImpl := appStoreValuesRepository.NewAppStoreVersionValuesRepositoryImpl(sugaredLogger, db)
 	appStoreRepositoryImpl := appStoreDiscoverRepository.NewAppStoreRepositoryImpl(sugaredLogger, db)
 	clusterInstalledAppsRepositoryImpl := repository3.NewClusterInstalledAppsRepositoryImpl(db, sugaredLogger)
@@ -898,15 +996,8 @@ func InitializeApp() (*App, error) {
 	appStoreRouterImpl := appStore.NewAppStoreRouterImpl(installedAppRestHandlerImpl, appStoreValuesRouterImpl, appStoreDiscoverRouterImpl, chartProviderRouterImpl, appStoreDeploymentRouterImpl, appStoreStatusTimelineRestHandlerImpl)
 	chartRepositoryRestHandlerImpl := chartRepo2.NewChartRepositoryRestHandlerImpl(sugaredLogger, userServiceImpl, chartRepositoryServiceImpl, enforcerImpl, validate, deleteServiceExtendedImpl, attributesServiceImpl)
 	chartRepositoryRouterImpl := chartRepo2.NewChartRepositoryRouterImpl(chartRepositoryRestHandlerImpl)
-	lensConfig, err := lens.GetLensConfig()
-	if err != nil {
-		return nil, err
-	}
-	lensClientImpl, err := lens.NewLensClientImpl(lensConfig, sugaredLogger)
-	if err != nil {
-		return nil, err
-	}
-	releaseDataServiceImpl := app2.NewReleaseDataServiceImpl(pipelineOverrideRepositoryImpl, sugaredLogger, ciPipelineMaterialRepositoryImpl, eventRESTClientImpl, lensClientImpl)
+	doraMetricsRepositoryImpl := repository42.NewDoraMetricsRepositoryImpl(db, sugaredLogger)
+	releaseDataServiceImpl := app2.NewReleaseDataServiceImpl(pipelineOverrideRepositoryImpl, sugaredLogger, ciPipelineMaterialRepositoryImpl, eventRESTClientImpl, doraMetricsRepositoryImpl)
 	releaseMetricsRestHandlerImpl := restHandler.NewReleaseMetricsRestHandlerImpl(sugaredLogger, enforcerImpl, releaseDataServiceImpl, userServiceImpl, teamServiceImpl, pipelineRepositoryImpl, enforcerUtilImpl)
 	releaseMetricsRouterImpl := router.NewReleaseMetricsRouterImpl(sugaredLogger, releaseMetricsRestHandlerImpl)
 	deploymentGroupAppRepositoryImpl := repository2.NewDeploymentGroupAppRepositoryImpl(sugaredLogger, db)
@@ -924,6 +1015,14 @@ func InitializeApp() (*App, error) {
 	chartGroupRouterImpl := chartGroup2.NewChartGroupRouterImpl(chartGroupRestHandlerImpl)
 	imageScanRestHandlerImpl := restHandler.NewImageScanRestHandlerImpl(sugaredLogger, imageScanServiceImpl, userServiceImpl, enforcerImpl, enforcerUtilImpl, environmentServiceImpl)
 	imageScanRouterImpl := router.NewImageScanRouterImpl(imageScanRestHandlerImpl)
+	sbomRestHandlerImpl := restHandler.NewSbomRestHandlerImpl(sugaredLogger, sbomServiceImpl, userServiceImpl, enforcerImpl, enforcerUtilImpl)
+	sbomRouterImpl := router.NewSbomRouterImpl(sbomRestHandlerImpl)
+	imageSignatureRestHandlerImpl := restHandler.NewImageSignatureRestHandlerImpl(sugaredLogger, imageSignatureServiceImpl, userServiceImpl, enforcerImpl, validate)
+	imageSignatureRouterImpl := router.NewImageSignatureRouterImpl(imageSignatureRestHandlerImpl)
+	webhookSignatureRestHandlerImpl := restHandler.NewWebhookSignatureRestHandlerImpl(sugaredLogger, webhookSignatureServiceImpl, userServiceImpl, enforcerImpl, validate)
+	webhookSignatureRouterImpl := router.NewWebhookSignatureRouterImpl(webhookSignatureRestHandlerImpl)
+	commitStatusRestHandlerImpl := restHandler.NewCommitStatusRestHandlerImpl(sugaredLogger, commitStatusServiceImpl, gitProviderReadServiceImpl, userServiceImpl, enforcerImpl, validate)
+	commitStatusRouterImpl := router.NewCommitStatusRouterImpl(commitStatusRestHandlerImpl)
 	policyRestHandlerImpl := restHandler.NewPolicyRestHandlerImpl(sugaredLogger, policyServiceImpl, userServiceImpl, userAuthServiceImpl, enforcerImpl, enforcerUtilImpl, environmentServiceImpl)
 	policyRouterImpl := router.NewPolicyRouterImpl(policyRestHandlerImpl)
 	gitOpsConfigServiceImpl := gitops.NewGitOpsConfigServiceImpl(sugaredLogger, gitOpsConfigRepositoryImpl, k8sServiceImpl, acdAuthConfig, clusterServiceImplExtended, gitOperationServiceImpl, gitOpsConfigReadServiceImpl, gitOpsValidationServiceImpl, certificateServiceClientImpl, repositoryServiceClientImpl, environmentVariables, argoCDConnectionManagerImpl, argoCDConfigGetterImpl, argoClientWrapperServiceImpl, clusterReadServiceImpl, moduleReadServiceImpl)
@@ -968,17 +1067,23 @@ func InitializeApp() (*App, error) {
 	}
 	telemetryRestHandlerImpl := restHandler.NewTelemetryRestHandlerImpl(sugaredLogger, telemetryEventClientImplExtended, enforcerImpl, userServiceImpl)
 	telemetryRouterImpl := router.NewTelemetryRouterImpl(sugaredLogger, telemetryRestHandlerImpl)
-	bulkEditRepositoryImpl := repository31.NewBulkEditRepository(db, sugaredLogger)
+	bulkEditRepositoryImpl := repository43.NewBulkEditRepository(db, sugaredLogger)
 	deployedAppServiceImpl := deployedApp.NewDeployedAppServiceImpl(sugaredLogger, k8sCommonServiceImpl, devtronAppsHandlerServiceImpl, environmentRepositoryImpl, pipelineRepositoryImpl, cdWorkflowRepositoryImpl)
 	bulkUpdateServiceEntImpl := service8.NewBulkUpdateServiceEntImpl()
-	bulkUpdateServiceImpl := service8.NewBulkUpdateServiceImpl(bulkEditRepositoryImpl, sugaredLogger, environmentRepositoryImpl, pipelineRepositoryImpl, appRepositoryImpl, deploymentTemplateHistoryServiceImpl, configMapHistoryServiceImpl, pipelineBuilderImpl, enforcerUtilImpl, ciHandlerImpl, ciPipelineRepositoryImpl, appWorkflowRepositoryImpl, appWorkflowServiceImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, chartRefServiceImpl, deployedAppServiceImpl, cdPipelineEventPublishServiceImpl, handlerServiceImpl, bulkUpdateServiceEntImpl)
+	bulkUpdateServiceImpl := service8.NewBulkUpdateServiceImpl(bulkEditRepositoryImpl, sugaredLogger, environmentRepositoryImpl, pipelineRepositoryImpl, appRepositoryImpl, deploymentTemplateHistoryServiceImpl, configMapHistoryServiceImpl, pipelineBuilderImpl, enforcerUtilImpl, ciHandlerImpl, ciPipelineRepositoryImpl, appWorkflowRepositoryImpl, appWorkflowServiceImpl, scopedVariableManagerImpl, deployedAppMetricsServiceImpl, chartRefServiceImpl, deployedAppServiceImpl, cdPipelineEventPublishServiceImpl, handlerServiceImpl, deploymentApprovalServiceImpl, bulkUpdateServiceEntImpl)
 	bulkUpdateRestHandlerImpl := restHandler.NewBulkUpdateRestHandlerImpl(pipelineBuilderImpl, sugaredLogger, bulkUpdateServiceImpl, chartServiceImpl, propertiesConfigServiceImpl, userServiceImpl, enforcerImpl, ciHandlerImpl, validate, clientImpl, ciPipelineRepositoryImpl, pipelineRepositoryImpl, enforcerUtilImpl, environmentServiceImpl, gitRegistryConfigImpl, dockerRegistryConfigImpl, cdHandlerImpl, appCloneServiceImpl, appWorkflowServiceImpl, materialRepositoryImpl)
 	bulkUpdateRouterImpl := router.NewBulkUpdateRouterImpl(bulkUpdateRestHandlerImpl)
 	webhookSecretValidatorImpl := gitWebhook.NewWebhookSecretValidatorImpl(sugaredLogger)
 	webhookEventDataRepositoryImpl := repository2.NewWebhookEventDataRepositoryImpl(db)
 	webhookEventDataConfigImpl := pipeline.NewWebhookEventDataConfigImpl(sugaredLogger, webhookEventDataRepositoryImpl)
 	ciPipelineEventPublishServiceImpl := out.NewCIPipelineEventPublishServiceImpl(sugaredLogger, pubSubClientServiceImpl)
-	webhookEventHandlerImpl := restHandler.NewWebhookEventHandlerImpl(sugaredLogger, eventRESTClientImpl, webhookSecretValidatorImpl, webhookEventDataConfigImpl, ciPipelineEventPublishServiceImpl, gitHostReadServiceImpl)
+	previewEnvironmentTemplateRepositoryImpl := repository44.NewPreviewEnvironmentTemplateRepositoryImpl(db, sugaredLogger)
+	previewEnvironmentRepositoryImpl := repository44.NewPreviewEnvironmentRepositoryImpl(db, sugaredLogger)
+	previewEnvironmentServiceImpl, err := previewEnvironment.NewPreviewEnvironmentServiceImpl(sugaredLogger, previewEnvironmentTemplateRepositoryImpl, previewEnvironmentRepositoryImpl, pipelineRepositoryImpl, ciWorkflowRepositoryImpl, ciArtifactRepositoryImpl, appWorkflowRepositoryImpl, cdPipelineConfigServiceImpl, environmentServiceImpl, clusterReadServiceImpl, devtronAppsHandlerServiceImpl, deployedAppServiceImpl, commitStatusServiceImpl, k8sServiceImpl, runnable, cronLoggerImpl)
+	if err != nil {
+		return nil, err
+	}
+	webhookEventHandlerImpl := restHandler.NewWebhookEventHandlerImpl(sugaredLogger, eventRESTClientImpl, webhookSecretValidatorImpl, webhookEventDataConfigImpl, ciPipelineEventPublishServiceImpl, gitHostReadServiceImpl, webhookSignatureServiceImpl, previewEnvironmentServiceImpl)
 	webhookListenerRouterImpl := router.NewWebhookListenerRouterImpl(webhookEventHandlerImpl)
 	appFilteringRestHandlerImpl := appList.NewAppFilteringRestHandlerImpl(sugaredLogger, teamServiceImpl, enforcerImpl, userServiceImpl, clusterServiceImplExtended, environmentServiceImpl, teamReadServiceImpl)
 	appFilteringRouterImpl := appList2.NewAppFilteringRouterImpl(appFilteringRestHandlerImpl)
@@ -993,17 +1098,36 @@ func InitializeApp() (*App, error) {
 	pipelineTriggerRouterImpl := trigger3.NewPipelineTriggerRouter(pipelineTriggerRestHandlerImpl, sseSSE)
 	webhookDataRestHandlerImpl := webhook.NewWebhookDataRestHandlerImpl(sugaredLogger, userServiceImpl, ciPipelineMaterialRepositoryImpl, enforcerUtilImpl, enforcerImpl, clientImpl, webhookEventDataConfigImpl)
 	pipelineConfigRouterImpl := configure2.NewPipelineRouterImpl(pipelineConfigRestHandlerImpl, webhookDataRestHandlerImpl)
-	prePostCiScriptHistoryRepositoryImpl := repository24.NewPrePostCiScriptHistoryRepositoryImpl(sugaredLogger, db)
+	prePostCiScriptHistoryRepositoryImpl := repository25.NewPrePostCiScriptHistoryRepositoryImpl(sugaredLogger, db)
 	prePostCiScriptHistoryServiceImpl := history.NewPrePostCiScriptHistoryServiceImpl(sugaredLogger, prePostCiScriptHistoryRepositoryImpl)
 	pipelineHistoryRestHandlerImpl := history2.NewPipelineHistoryRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, pipelineStrategyHistoryServiceImpl, deploymentTemplateHistoryServiceImpl, configMapHistoryServiceImpl, prePostCiScriptHistoryServiceImpl, prePostCdScriptHistoryServiceImpl, enforcerUtilImpl, deployedConfigurationHistoryServiceImpl)
 	pipelineHistoryRouterImpl := history3.NewPipelineHistoryRouterImpl(pipelineHistoryRestHandlerImpl)
 	pipelineStatusTimelineRestHandlerImpl := status3.NewPipelineStatusTimelineRestHandlerImpl(sugaredLogger, userServiceImpl, pipelineStatusTimelineServiceImpl, enforcerUtilImpl, enforcerImpl, cdApplicationStatusUpdateHandlerImpl, pipelineBuilderImpl)
 	pipelineStatusRouterImpl := status4.NewPipelineStatusRouterImpl(pipelineStatusTimelineRestHandlerImpl)
-	appWorkflowRestHandlerImpl := workflow.NewAppWorkflowRestHandlerImpl(sugaredLogger, userServiceImpl, appWorkflowServiceImpl, teamServiceImpl, enforcerImpl, pipelineBuilderImpl, appRepositoryImpl, enforcerUtilImpl, chartServiceImpl)
+	ciPipelineScheduleRepositoryImpl := repository45.NewCiPipelineScheduleRepositoryImpl(db, sugaredLogger)
+	ciPipelineScheduleServiceImpl := schedule.NewCiPipelineScheduleServiceImpl(sugaredLogger, ciPipelineScheduleRepositoryImpl, ciPipelineRepositoryImpl, ciPipelineMaterialRepositoryImpl, ciWorkflowRepositoryImpl, clientImpl, handlerServiceImpl)
+	appWorkflowRestHandlerImpl := workflow.NewAppWorkflowRestHandlerImpl(sugaredLogger, userServiceImpl, appWorkflowServiceImpl, teamServiceImpl, enforcerImpl, pipelineBuilderImpl, appRepositoryImpl, enforcerUtilImpl, chartServiceImpl, ciPipelineScheduleServiceImpl)
 	appWorkflowRouterImpl := workflow2.NewAppWorkflowRouterImpl(appWorkflowRestHandlerImpl)
 	devtronAppAutoCompleteRestHandlerImpl := pipeline3.NewDevtronAppAutoCompleteRestHandlerImpl(sugaredLogger, userServiceImpl, teamServiceImpl, enforcerImpl, enforcerUtilImpl, devtronAppConfigServiceImpl, environmentServiceImpl, dockerRegistryConfigImpl, gitProviderReadServiceImpl)
 	devtronAppAutoCompleteRouterImpl := pipeline4.NewDevtronAppAutoCompleteRouterImpl(devtronAppAutoCompleteRestHandlerImpl)
-	appRouterImpl := app3.NewAppRouterImpl(appFilteringRouterImpl, appListingRouterImpl, appInfoRouterImpl, pipelineTriggerRouterImpl, pipelineConfigRouterImpl, pipelineHistoryRouterImpl, pipelineStatusRouterImpl, appWorkflowRouterImpl, devtronAppAutoCompleteRouterImpl, appWorkflowRestHandlerImpl, appListingRestHandlerImpl, appFilteringRestHandlerImpl)
+	ciPipelineScheduleRestHandlerImpl := schedule2.NewCiPipelineScheduleRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerUtilImpl, validate, ciPipelineScheduleServiceImpl)
+	ciPipelineScheduleRouterImpl := schedule3.NewCiPipelineScheduleRouterImpl(ciPipelineScheduleRestHandlerImpl)
+	ciBuildQueueRestHandlerImpl := buildQueue2.NewCiBuildQueueRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, ciBuildQueueServiceImpl)
+	ciBuildQueueRouterImpl := buildQueue3.NewCiBuildQueueRouterImpl(ciBuildQueueRestHandlerImpl)
+	artifactRetentionPolicyRepositoryImpl := repository46.NewArtifactRetentionPolicyRepositoryImpl(db, sugaredLogger)
+	artifactGcRunRepositoryImpl := repository46.NewArtifactGcRunRepositoryImpl(db, sugaredLogger, transactionUtilImpl)
+	artifactGcRepositoryImpl := repository46.NewArtifactGcRepositoryImpl(db, sugaredLogger)
+	buildBlobCleanerImpl, err := retention.NewBuildBlobCleanerImpl(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	registryTagCleanerImpl := retention.NewRegistryTagCleanerImpl(sugaredLogger)
+	artifactRetentionServiceImpl := retention.NewArtifactRetentionServiceImpl(sugaredLogger, artifactRetentionPolicyRepositoryImpl, artifactGcRunRepositoryImpl, artifactGcRepositoryImpl, appRepositoryImpl, teamRepositoryImpl, ciArtifactRepositoryImpl, ciWorkflowRepositoryImpl, ciPipelineRepositoryImpl, ciPipelineConfigReadServiceImpl, dockerArtifactStoreRepositoryImpl, customTagServiceImpl, buildBlobCleanerImpl, registryTagCleanerImpl, runnable)
+	artifactRetentionRestHandlerImpl := retention2.NewArtifactRetentionRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, validate, artifactRetentionServiceImpl)
+	artifactRetentionRouterImpl := retention3.NewArtifactRetentionRouterImpl(artifactRetentionRestHandlerImpl)
+	testReportRestHandlerImpl := testReport2.NewTestReportRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, testReportServiceImpl, testGateServiceImpl)
+	testReportRouterImpl := testReport3.NewTestReportRouterImpl(testReportRestHandlerImpl)
+	appRouterImpl := app3.NewAppRouterImpl(appFilteringRouterImpl, appListingRouterImpl, appInfoRouterImpl, pipelineTriggerRouterImpl, pipelineConfigRouterImpl, pipelineHistoryRouterImpl, pipelineStatusRouterImpl, appWorkflowRouterImpl, devtronAppAutoCompleteRouterImpl, ciPipelineScheduleRouterImpl, ciBuildQueueRouterImpl, artifactRetentionRouterImpl, testReportRouterImpl, appWorkflowRestHandlerImpl, appListingRestHandlerImpl, appFilteringRestHandlerImpl)
 	coreAppRestHandlerImpl := restHandler.NewCoreAppRestHandlerImpl(sugaredLogger, userServiceImpl, validate, enforcerUtilImpl, enforcerImpl, appCrudOperationServiceImpl, pipelineBuilderImpl, gitRegistryConfigImpl, chartServiceImpl, configMapServiceImpl, appListingServiceImpl, propertiesConfigServiceImpl, appWorkflowServiceImpl, appWorkflowRepositoryImpl, environmentRepositoryImpl, configMapRepositoryImpl, chartRepositoryImpl, teamServiceImpl, pipelineStageServiceImpl, ciPipelineRepositoryImpl, gitProviderReadServiceImpl, gitMaterialReadServiceImpl, teamReadServiceImpl, chartReadServiceImpl)
 	coreAppRouterImpl := router.NewCoreAppRouterImpl(coreAppRestHandlerImpl)
 	helmAppRestHandlerImpl := client3.NewHelmAppRestHandlerImpl(sugaredLogger, helmAppServiceImpl, enforcerImpl, clusterServiceImplExtended, enforcerUtilHelmImpl, appStoreDeploymentServiceImpl, installedAppDBServiceImpl, userServiceImpl, attributesServiceImpl, serverEnvConfigServerEnvConfig, fluxApplicationServiceImpl, argoApplicationServiceExtendedImpl)
@@ -1081,18 +1205,126 @@ func InitializeApp() (*App, error) {
 		return nil, err
 	}
 	ciTriggerCronImpl := cron2.NewCiTriggerCronImpl(sugaredLogger, ciTriggerCronConfig, pipelineStageRepositoryImpl, ciArtifactRepositoryImpl, globalPluginRepositoryImpl, cronLoggerImpl, handlerServiceImpl)
-	proxyConfig, err := proxy.GetProxyConfig()
+	ciScheduleTriggerCronConfig, err := cron2.GetCiScheduleTriggerCronConfig()
 	if err != nil {
 		return nil, err
 	}
-	proxyRouterImpl, err := proxy.NewProxyRouterImpl(sugaredLogger, proxyConfig, enforcerImpl)
+	ciScheduleTriggerCronImpl := cron2.NewCiScheduleTriggerCronImpl(sugaredLogger, ciScheduleTriggerCronConfig, cronLoggerImpl, ciPipelineScheduleServiceImpl)
+	ciBuildQueueCronConfig, err := cron2.GetCiBuildQueueCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	ciBuildQueueCronImpl := cron2.NewCiBuildQueueCronImpl(sugaredLogger, ciBuildQueueCronConfig, cronLoggerImpl, handlerServiceImpl)
+	artifactGcCronConfig, err := cron2.GetArtifactGcCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	artifactGcCronImpl := cron2.NewArtifactGcCronImpl(sugaredLogger, artifactGcCronConfig, cronLoggerImpl, artifactRetentionServiceImpl)
+	sbomSyncCronConfig, err := cron2.GetSbomSyncCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	sbomSyncCronImpl := cron2.NewSbomSyncCronImpl(sugaredLogger, sbomSyncCronConfig, cronLoggerImpl, sbomServiceImpl)
+	webhookDeliveryCleanupCronConfig, err := cron2.GetWebhookDeliveryCleanupCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	webhookDeliveryCleanupCronImpl := cron2.NewWebhookDeliveryCleanupCronImpl(sugaredLogger, webhookDeliveryCleanupCronConfig, cronLoggerImpl, webhookSignatureServiceImpl)
+	deploymentWindowQueueCronConfig, err := cron2.GetDeploymentWindowQueueCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	deploymentWindowQueueCronImpl := cron2.NewDeploymentWindowQueueCronImpl(sugaredLogger, deploymentWindowQueueCronConfig, cronLoggerImpl, devtronAppsHandlerServiceImpl)
+	deploymentWindowRestHandlerImpl := deployment3.NewDeploymentWindowRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, validate, environmentServiceImpl, deploymentWindowServiceImpl)
+	deploymentWindowRouterImpl := deployment3.NewDeploymentWindowRouterImpl(deploymentWindowRestHandlerImpl)
+	deploymentApprovalRestHandlerImpl := deployment3.NewDeploymentApprovalRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, deploymentApprovalServiceImpl)
+	deploymentApprovalRouterImpl := deployment3.NewDeploymentApprovalRouterImpl(deploymentApprovalRestHandlerImpl)
+	autoRollbackCronConfig, err := cron2.GetAutoRollbackCronConfig()
 	if err != nil {
 		return nil, err
 	}
+	autoRollbackCronImpl := cron2.NewAutoRollbackCronImpl(sugaredLogger, autoRollbackCronConfig, cronLoggerImpl, devtronAppsHandlerServiceImpl)
+	autoRollbackRestHandlerImpl := deployment3.NewAutoRollbackRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, autoRollbackServiceImpl)
+	autoRollbackRouterImpl := deployment3.NewAutoRollbackRouterImpl(autoRollbackRestHandlerImpl)
+	metricVerificationCronConfig, err := cron2.GetMetricVerificationCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	metricVerificationCronImpl := cron2.NewMetricVerificationCronImpl(sugaredLogger, metricVerificationCronConfig, cronLoggerImpl, metricVerificationServiceImpl, workflowDagExecutorImpl)
+	metricVerificationRestHandlerImpl := deployment3.NewMetricVerificationRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, metricVerificationServiceImpl)
+	metricVerificationRouterImpl := deployment3.NewMetricVerificationRouterImpl(metricVerificationRestHandlerImpl)
+	driftDetectionCronConfig, err := cron2.GetDriftDetectionCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	driftDetectionConfigRepositoryImpl := repository47.NewDriftDetectionConfigRepositoryImpl(db, sugaredLogger)
+	driftStatusRepositoryImpl := repository47.NewDriftStatusRepositoryImpl(db, sugaredLogger)
 	deploymentConfigurationServiceImpl, err := configDiff.NewDeploymentConfigurationServiceImpl(sugaredLogger, configMapServiceImpl, appRepositoryImpl, environmentRepositoryImpl, chartServiceImpl, generateManifestDeploymentTemplateServiceImpl, deploymentTemplateHistoryRepositoryImpl, pipelineStrategyHistoryRepositoryImpl, configMapHistoryRepositoryImpl, scopedVariableCMCSManagerImpl, configMapRepositoryImpl, pipelineDeploymentConfigServiceImpl, chartRefServiceImpl, pipelineRepositoryImpl, configMapHistoryServiceImpl, deploymentTemplateHistoryReadServiceImpl, configMapHistoryReadServiceImpl, cdWorkflowRepositoryImpl, envConfigOverrideReadServiceImpl, chartTemplateServiceImpl, helmAppClientImpl, helmAppServiceImpl, k8sServiceImpl, mergeUtil, helmAppReadServiceImpl, chartReadServiceImpl)
 	if err != nil {
 		return nil, err
 	}
+	driftDetectionServiceImpl := driftDetection.NewDriftDetectionServiceImpl(sugaredLogger, driftDetectionConfigRepositoryImpl, driftStatusRepositoryImpl, pipelineRepositoryImpl, cdWorkflowRepositoryImpl, pipelineOverrideRepositoryImpl, deploymentConfigurationServiceImpl, k8sCommonServiceImpl, k8sServiceImpl, eventSimpleFactoryImpl, eventRESTClientImpl)
+	driftDetectionCronImpl := cron2.NewDriftDetectionCronImpl(sugaredLogger, driftDetectionCronConfig, cronLoggerImpl, driftDetectionServiceImpl, devtronAppsHandlerServiceImpl)
+	driftDetectionRestHandlerImpl := deployment3.NewDriftDetectionRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, driftDetectionServiceImpl)
+	driftDetectionRouterImpl := deployment3.NewDriftDetectionRouterImpl(driftDetectionRestHandlerImpl)
+	gitOpsPullRequestCronConfig, err := cron2.GetGitOpsPullRequestCronConfig()
+	if err != nil {
+		return nil, err
+	}
+	gitOpsPullRequestCronImpl := cron2.NewGitOpsPullRequestCronImpl(sugaredLogger, gitOpsPullRequestCronConfig, cronLoggerImpl, pullRequestModeServiceImpl)
+	gitOpsPullRequestRestHandlerImpl := deployment3.NewGitOpsPullRequestRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, pullRequestModeServiceImpl)
+	gitOpsPullRequestRouterImpl := deployment3.NewGitOpsPullRequestRouterImpl(gitOpsPullRequestRestHandlerImpl)
+	gitOpsMonoRepoRestHandlerImpl := deployment3.NewGitOpsMonoRepoRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, monoRepoServiceImpl)
+	gitOpsMonoRepoRouterImpl := deployment3.NewGitOpsMonoRepoRouterImpl(gitOpsMonoRepoRestHandlerImpl)
+	previewEnvironmentRestHandlerImpl := deployment3.NewPreviewEnvironmentRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, previewEnvironmentServiceImpl)
+	previewEnvironmentRouterImpl := deployment3.NewPreviewEnvironmentRouterImpl(previewEnvironmentRestHandlerImpl)
+	hibernationScheduleRepositoryImpl := repository48.NewHibernationScheduleRepositoryImpl(db, sugaredLogger)
+	hibernationScheduleAppOverrideRepositoryImpl := repository48.NewHibernationScheduleAppOverrideRepositoryImpl(db, sugaredLogger)
+	hibernationScheduleRunRepositoryImpl := repository48.NewHibernationScheduleRunRepositoryImpl(db, sugaredLogger)
+	leaderLeaseRepositoryImpl := repository49.NewLeaderLeaseRepositoryImpl(db, sugaredLogger)
+	leaderElectionServiceImpl, err := leaderElection.NewLeaderElectionServiceImpl(sugaredLogger, leaderLeaseRepositoryImpl)
+	if err != nil {
+		return nil, err
+	}
+	hibernationScheduleServiceImpl, err := hibernationSchedule.NewHibernationScheduleServiceImpl(sugaredLogger, hibernationScheduleRepositoryImpl, hibernationScheduleAppOverrideRepositoryImpl, hibernationScheduleRunRepositoryImpl, environmentServiceImpl, pipelineRepositoryImpl, resourceGroupRepositoryImpl, resourceGroupMappingRepositoryImpl, devtronResourceSearchableKeyServiceImpl, deployedAppServiceImpl, leaderElectionServiceImpl, runnable, cronLoggerImpl)
+	if err != nil {
+		return nil, err
+	}
+	hibernationScheduleRestHandlerImpl := deployment3.NewHibernationScheduleRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, environmentServiceImpl, hibernationScheduleServiceImpl)
+	hibernationScheduleRouterImpl := deployment3.NewHibernationScheduleRouterImpl(hibernationScheduleRestHandlerImpl)
+	releaseOrchestrationRepositoryImpl := repository50.NewReleaseOrchestrationRepositoryImpl(db, sugaredLogger)
+	releaseOrchestrationEventRepositoryImpl := repository50.NewReleaseOrchestrationEventRepositoryImpl(db, sugaredLogger)
+	releaseOrchestrationServiceImpl, err := releaseOrchestration.NewReleaseOrchestrationServiceImpl(sugaredLogger, releaseOrchestrationRepositoryImpl, releaseOrchestrationEventRepositoryImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, cdWorkflowRepositoryImpl, devtronAppsHandlerServiceImpl, leaderElectionServiceImpl, runnable, cronLoggerImpl)
+	if err != nil {
+		return nil, err
+	}
+	releaseOrchestrationRestHandlerImpl := deployment3.NewReleaseOrchestrationRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, releaseOrchestrationServiceImpl)
+	releaseOrchestrationRouterImpl := deployment3.NewReleaseOrchestrationRouterImpl(releaseOrchestrationRestHandlerImpl)
+	scheduledDeploymentRepositoryImpl := repository51.NewScheduledDeploymentRepositoryImpl(db, sugaredLogger)
+	scheduledDeploymentServiceImpl, err := scheduledDeployment.NewScheduledDeploymentServiceImpl(sugaredLogger, scheduledDeploymentRepositoryImpl, pipelineRepositoryImpl, appWorkflowRepositoryImpl, ciArtifactRepositoryImpl, devtronAppsHandlerServiceImpl, deploymentWindowServiceImpl, userServiceImpl, enforcerImpl, enforcerUtilImpl, eventSimpleFactoryImpl, eventRESTClientImpl, leaderElectionServiceImpl, cronLoggerImpl)
+	if err != nil {
+		return nil, err
+	}
+	scheduledDeploymentRestHandlerImpl := deployment3.NewScheduledDeploymentRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, scheduledDeploymentServiceImpl)
+	scheduledDeploymentRouterImpl := deployment3.NewScheduledDeploymentRouterImpl(scheduledDeploymentRestHandlerImpl)
+	deploymentPreviewServiceImpl := deploymentPreview.NewDeploymentPreviewServiceImpl(sugaredLogger, pipelineRepositoryImpl, cdWorkflowRepositoryImpl, pipelineOverrideRepositoryImpl, deploymentConfigServiceImpl, manifestCreationServiceImpl, deploymentConfigurationServiceImpl, k8sCommonServiceImpl, k8sServiceImpl)
+	deploymentPreviewRestHandlerImpl := deployment3.NewDeploymentPreviewRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, deploymentPreviewServiceImpl)
+	deploymentPreviewRouterImpl := deployment3.NewDeploymentPreviewRouterImpl(deploymentPreviewRestHandlerImpl)
+	clusterLabelRepositoryImpl := repository52.NewClusterLabelRepositoryImpl(db, sugaredLogger)
+	clusterSetServiceImpl, err := clusterSet.NewClusterSetServiceImpl(sugaredLogger, clusterSetRepositoryImpl, clusterLabelRepositoryImpl, clusterRepositoryImpl, pipelineRepositoryImpl, cdWorkflowRepositoryImpl, ciArtifactRepositoryImpl, deploymentConfigServiceImpl, leaderElectionServiceImpl, devtronAppsHandlerServiceImpl, cronLoggerImpl)
+	if err != nil {
+		return nil, err
+	}
+	clusterSetRestHandlerImpl := deployment3.NewClusterSetRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate, clusterSetServiceImpl)
+	clusterSetRouterImpl := deployment3.NewClusterSetRouterImpl(clusterSetRestHandlerImpl)
+	proxyConfig, err := proxy.GetProxyConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxyRouterImpl, err := proxy.NewProxyRouterImpl(sugaredLogger, proxyConfig, enforcerImpl)
+	if err != nil {
+		return nil, err
+	}
 	deploymentConfigurationRestHandlerImpl := configDiff2.NewDeploymentConfigurationRestHandlerImpl(sugaredLogger, userServiceImpl, enforcerUtilImpl, deploymentConfigurationServiceImpl, enforcerImpl)
 	deploymentConfigurationRouterImpl := configDiff3.NewDeploymentConfigurationRouter(deploymentConfigurationRestHandlerImpl)
 	infraConfigRestHandlerImpl := infraConfig.NewInfraConfigRestHandlerImpl(sugaredLogger, infraConfigServiceImpl, userServiceImpl, enforcerImpl, enforcerUtilImpl, validate)
@@ -1112,7 +1344,7 @@ func InitializeApp() (*App, error) {
 	restHandlerImpl := userResource2.NewUserResourceRestHandler(sugaredLogger, userServiceImpl, userResourceExtendedServiceImpl)
 	routerImpl := userResource2.NewUserResourceRouterImpl(restHandlerImpl)
 	appManagementServiceImpl := overview.NewAppManagementServiceImpl(sugaredLogger, appRepositoryImpl, pipelineRepositoryImpl, ciPipelineRepositoryImpl, ciWorkflowRepositoryImpl, cdWorkflowRepositoryImpl, environmentRepositoryImpl, teamRepositoryImpl, workflowStageRepositoryImpl, repositoryImpl)
-	doraMetricsServiceImpl := overview.NewDoraMetricsServiceImpl(sugaredLogger, lensClientImpl, appRepositoryImpl, pipelineRepositoryImpl, environmentRepositoryImpl, cdWorkflowRepositoryImpl)
+	doraMetricsServiceImpl := overview.NewDoraMetricsServiceImpl(sugaredLogger, doraMetricsRepositoryImpl)
 	insightsServiceImpl := overview.NewInsightsServiceImpl(sugaredLogger, appRepositoryImpl, pipelineRepositoryImpl, ciPipelineRepositoryImpl, ciWorkflowRepositoryImpl, cdWorkflowRepositoryImpl, environmentRepositoryImpl)
 	clusterOverviewConfig, err := config5.GetClusterOverviewConfig()
 	if err != nil {
@@ -1127,11 +1359,11 @@ func InitializeApp() (*App, error) {
 	overviewRouterImpl := router.NewOverviewRouterImpl(overviewRestHandlerImpl, infraOverviewRouterImpl)
 	authorisationConfigRestHandlerImpl := globalConfig2.NewGlobalAuthorisationConfigRestHandlerImpl(validate, sugaredLogger, enforcerImpl, userServiceImpl, globalAuthorisationConfigServiceImpl, userCommonServiceImpl, commonEnforcementUtilImpl)
 	authorisationConfigRouterImpl := globalConfig2.NewGlobalConfigAuthorisationRouterImpl(authorisationConfigRestHandlerImpl)
-	muxRouter := router.NewMuxRouter(sugaredLogger, environmentRouterImpl, clusterRouterImpl, webhookRouterImpl, userAuthRouterImpl, gitProviderRouterImpl, gitHostRouterImpl, dockerRegRouterImpl, notificationRouterImpl, teamRouterImpl, userRouterImpl, chartRefRouterImpl, configMapRouterImpl, appStoreRouterImpl, chartRepositoryRouterImpl, releaseMetricsRouterImpl, deploymentGroupRouterImpl, batchOperationRouterImpl, chartGroupRouterImpl, imageScanRouterImpl, policyRouterImpl, gitOpsConfigRouterImpl, dashboardRouterImpl, attributesRouterImpl, userAttributesRouterImpl, commonRouterImpl, grafanaRouterImpl, ssoLoginRouterImpl, telemetryRouterImpl, telemetryEventClientImplExtended, bulkUpdateRouterImpl, webhookListenerRouterImpl, appRouterImpl, coreAppRouterImpl, helmAppRouterImpl, k8sApplicationRouterImpl, pProfRouterImpl, deploymentConfigRouterImpl, dashboardTelemetryRouterImpl, commonDeploymentRouterImpl, externalLinkRouterImpl, globalPluginRouterImpl, moduleRouterImpl, serverRouterImpl, apiTokenRouterImpl, cdApplicationStatusUpdateHandlerImpl, k8sCapacityRouterImpl, webhookHelmRouterImpl, globalCMCSRouterImpl, userTerminalAccessRouterImpl, jobRouterImpl, ciStatusUpdateCronImpl, resourceGroupingRouterImpl, rbacRoleRouterImpl, scopedVariableRouterImpl, ciTriggerCronImpl, proxyRouterImpl, deploymentConfigurationRouterImpl, infraConfigRouterImpl, argoApplicationRouterImpl, devtronResourceRouterImpl, fluxApplicationRouterImpl, scanningResultRouterImpl, routerImpl, overviewRouterImpl, authorisationConfigRouterImpl)
+	muxRouter := router.NewMuxRouter(sugaredLogger, environmentRouterImpl, clusterRouterImpl, webhookRouterImpl, userAuthRouterImpl, gitProviderRouterImpl, gitHostRouterImpl, dockerRegRouterImpl, notificationRouterImpl, teamRouterImpl, userRouterImpl, chartRefRouterImpl, configMapRouterImpl, appStoreRouterImpl, chartRepositoryRouterImpl, releaseMetricsRouterImpl, deploymentGroupRouterImpl, batchOperationRouterImpl, chartGroupRouterImpl, imageScanRouterImpl, sbomRouterImpl, imageSignatureRouterImpl, webhookSignatureRouterImpl, commitStatusRouterImpl, policyRouterImpl, gitOpsConfigRouterImpl, dashboardRouterImpl, attributesRouterImpl, userAttributesRouterImpl, commonRouterImpl, grafanaRouterImpl, ssoLoginRouterImpl, telemetryRouterImpl, telemetryEventClientImplExtended, bulkUpdateRouterImpl, webhookListenerRouterImpl, appRouterImpl, coreAppRouterImpl, helmAppRouterImpl, k8sApplicationRouterImpl, pProfRouterImpl, deploymentConfigRouterImpl, dashboardTelemetryRouterImpl, commonDeploymentRouterImpl, externalLinkRouterImpl, globalPluginRouterImpl, moduleRouterImpl, serverRouterImpl, apiTokenRouterImpl, cdApplicationStatusUpdateHandlerImpl, k8sCapacityRouterImpl, webhookHelmRouterImpl, globalCMCSRouterImpl, userTerminalAccessRouterImpl, jobRouterImpl, ciStatusUpdateCronImpl, resourceGroupingRouterImpl, rbacRoleRouterImpl, scopedVariableRouterImpl, ciTriggerCronImpl, ciScheduleTriggerCronImpl, ciBuildQueueCronImpl, artifactGcCronImpl, sbomSyncCronImpl, webhookDeliveryCleanupCronImpl, deploymentWindowQueueCronImpl, deploymentWindowRouterImpl, deploymentApprovalRouterImpl, autoRollbackCronImpl, autoRollbackRouterImpl, metricVerificationCronImpl, metricVerificationRouterImpl, driftDetectionCronImpl, driftDetectionRouterImpl, gitOpsPullRequestCronImpl, gitOpsPullRequestRouterImpl, gitOpsMonoRepoRouterImpl, previewEnvironmentRouterImpl, hibernationScheduleRouterImpl, releaseOrchestrationRouterImpl, scheduledDeploymentRouterImpl, deploymentPreviewRouterImpl, clusterSetRouterImpl, proxyRouterImpl, deploymentConfigurationRouterImpl, infraConfigRouterImpl, argoApplicationRouterImpl, devtronResourceRouterImpl, fluxApplicationRouterImpl, scanningResultRouterImpl, routerImpl, overviewRouterImpl, authorisationConfigRouterImpl)
 	loggingMiddlewareImpl := util4.NewLoggingMiddlewareImpl(userServiceImpl)
 	cdWorkflowServiceImpl := cd.NewCdWorkflowServiceImpl(sugaredLogger, cdWorkflowRepositoryImpl)
 	webhookServiceImpl := pipeline.NewWebhookServiceImpl(ciArtifactRepositoryImpl, sugaredLogger, ciPipelineRepositoryImpl, ciWorkflowRepositoryImpl, cdWorkflowCommonServiceImpl, workFlowStageStatusServiceImpl, ciServiceImpl)
-	workflowEventProcessorImpl, err := in.NewWorkflowEventProcessorImpl(sugaredLogger, pubSubClientServiceImpl, cdWorkflowServiceImpl, cdWorkflowReadServiceImpl, cdWorkflowRunnerServiceImpl, cdWorkflowRunnerReadServiceImpl, workflowDagExecutorImpl, ciHandlerImpl, cdHandlerImpl, eventSimpleFactoryImpl, eventRESTClientImpl, devtronAppsHandlerServiceImpl, deployedAppServiceImpl, webhookServiceImpl, validate, environmentVariables, cdWorkflowCommonServiceImpl, cdPipelineConfigServiceImpl, userDeploymentRequestServiceImpl, serviceImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, cdWorkflowRepositoryImpl, deploymentConfigServiceImpl, handlerServiceImpl, runnable)
+	workflowEventProcessorImpl, err := in.NewWorkflowEventProcessorImpl(sugaredLogger, pubSubClientServiceImpl, cdWorkflowServiceImpl, cdWorkflowReadServiceImpl, cdWorkflowRunnerServiceImpl, cdWorkflowRunnerReadServiceImpl, workflowDagExecutorImpl, ciHandlerImpl, cdHandlerImpl, eventSimpleFactoryImpl, eventRESTClientImpl, devtronAppsHandlerServiceImpl, deployedAppServiceImpl, webhookServiceImpl, validate, environmentVariables, cdWorkflowCommonServiceImpl, cdPipelineConfigServiceImpl, userDeploymentRequestServiceImpl, serviceImpl, pipelineRepositoryImpl, ciArtifactRepositoryImpl, cdWorkflowRepositoryImpl, deploymentConfigServiceImpl, handlerServiceImpl, runnable, workFlowStageStatusServiceImpl, previewEnvironmentServiceImpl)
 	if err != nil {
 		return nil, err
 	}